@@ -35,6 +35,7 @@ type event struct {
 	Subject            string              `yaml:"subject"`
 	MessageFields      []eventMessageField `yaml:"messageFields"`
 	CustomValidation   string              `yaml:"customValidation,omitempty"`
+	CustomSubject      string              `yaml:"customSubject,omitempty"`
 	ResponseFields     []eventMessageField `yaml:"responseFields,omitempty"`
 	ResponseValidation string              `yaml:"responseValidation,omitempty"`
 	WebSocketEvent     bool                `yaml:"websocketEvent,omitempty"`
@@ -145,7 +146,11 @@ package service
 		// Add Certain implementations
 		eventDef += fmt.Sprintf("// Subject returns the event subject for %s events\n", evt.Name)
 		eventDef += fmt.Sprintf("func (msg *%s%sMessage) Subject() EventSubject {\n", evt.Name, eventSuffix)
-		eventDef += fmt.Sprintf("\treturn %s%sSubject\n", evt.Name, eventSuffix)
+		if evt.CustomSubject != "" {
+			eventDef += fmt.Sprintf("\t%s\n", evt.CustomSubject)
+		} else {
+			eventDef += fmt.Sprintf("\treturn %s%sSubject\n", evt.Name, eventSuffix)
+		}
 		eventDef += "}\n\n"
 
 		// Add Validation function
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pinazu/internal/agents"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/urfave/cli/v3"
+)
+
+// createAgentsCommand defines the agents command used to maintain the stored agents.
+func createAgentsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "agents",
+		Usage: "Manage the stored agents",
+		Commands: []*cli.Command{
+			{
+				Name:  "migrate-specs",
+				Usage: "Upgrade the stored agent specs to the current specs version",
+				Flags: append(createDbFlags(), &cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Report the migrations without updating the specs",
+				}),
+				Action: createAgentsMigrateSpecsAction(),
+			},
+		},
+	}
+}
+
+// createAgentsMigrateSpecsAction upgrades the specs of every agent in place and reports the specs needing manual attention.
+// A specs update only applies when the specs did not change since they were read, otherwise the agent is reported as failed.
+func createAgentsMigrateSpecsAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		// Load the YAML configuration file if provided from `config` flag
+		config, err := service.LoadExternalConfigFile(cmd.String("config"), cmd)
+		if err != nil {
+			return err
+		}
+
+		pool, err := pgxpool.New(ctx, config.GetDatabaseConnectionString())
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer pool.Close()
+
+		queries := db.New(pool)
+		agentList, err := queries.GetAgents(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list agents: %w", err)
+		}
+
+		dryRun := cmd.Bool("dry-run")
+		fmt.Printf("Current agent specs version: %d\n", agents.CurrentSpecVersion)
+		for _, migration := range agents.SpecMigrations {
+			fmt.Printf("  v%d: %s\n", migration.Version, migration.Description)
+		}
+
+		var migrated, upToDate, failed, attention int
+		for _, agent := range agentList {
+			if !agent.Specs.Valid || agent.Specs.String == "" {
+				upToDate++
+				continue
+			}
+			result, err := agents.MigrateSpecs(agent.Specs.String)
+			if err != nil {
+				fmt.Printf("%s (%s): failed: %v\n", agent.Name, agent.ID, err)
+				failed++
+				continue
+			}
+			if len(result.Notes) > 0 {
+				attention++
+			}
+			if !result.Changed() {
+				fmt.Printf("%s (%s): up to date (v%d)\n", agent.Name, agent.ID, result.FromVersion)
+				upToDate++
+				printSpecNotes(result.Notes)
+				continue
+			}
+
+			if dryRun {
+				fmt.Printf("%s (%s): would migrate v%d -> v%d\n", agent.Name, agent.ID, result.FromVersion, result.ToVersion)
+				migrated++
+				printSpecNotes(result.Notes)
+				continue
+			}
+			updated, err := queries.UpdateAgentSpecs(ctx, db.UpdateAgentSpecsParams{
+				Specs:         pgtype.Text{String: result.Specs, Valid: true},
+				ID:            agent.ID,
+				PreviousSpecs: agent.Specs,
+			})
+			if err != nil {
+				fmt.Printf("%s (%s): failed: %v\n", agent.Name, agent.ID, err)
+				failed++
+				continue
+			}
+			if updated == 0 {
+				fmt.Printf("%s (%s): failed: the specs changed during the migration, run the command again\n", agent.Name, agent.ID)
+				failed++
+				continue
+			}
+			fmt.Printf("%s (%s): migrated v%d -> v%d\n", agent.Name, agent.ID, result.FromVersion, result.ToVersion)
+			migrated++
+			printSpecNotes(result.Notes)
+		}
+
+		verb := "migrated"
+		if dryRun {
+			verb = "to migrate"
+		}
+		fmt.Printf("%d agents %s, %d up to date, %d failed, %d need manual attention.\n", migrated, verb, upToDate, failed, attention)
+		if failed > 0 {
+			return fmt.Errorf("%d agent specs could not be migrated", failed)
+		}
+		return nil
+	}
+}
+
+// printSpecNotes prints the findings of a specs migration needing manual attention
+func printSpecNotes(notes []string) {
+	for _, note := range notes {
+		fmt.Printf("  needs attention: %s\n", note)
+	}
+}
@@ -8,12 +8,16 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pinazu/internal/agents"
 	"github.com/pinazu/internal/api"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/embeddings"
 	"github.com/pinazu/internal/flows"
 	"github.com/pinazu/internal/service"
 	"github.com/pinazu/internal/tasks"
 	"github.com/pinazu/internal/tools"
+	"github.com/pinazu/internal/webhooks"
 	"github.com/pinazu/internal/worker"
 	"github.com/urfave/cli/v3"
 )
@@ -46,6 +50,12 @@ func CreateCLICommand() *cli.Command {
 						Flags:  createServeFlags(),
 						Action: createServeAPIGatewayAction(),
 					},
+					{
+						Name:   "embeddings",
+						Usage:  "Run the embeddings service",
+						Flags:  createServeFlags(),
+						Action: createServeEmbeddingsAction(),
+					},
 					{
 						Name:   "flows",
 						Usage:  "Run the workflow service",
@@ -64,6 +74,12 @@ func CreateCLICommand() *cli.Command {
 						Flags:  createServeFlags(),
 						Action: createServeToolsAction(),
 					},
+					{
+						Name:   "webhooks",
+						Usage:  "Run the webhooks service",
+						Flags:  createServeFlags(),
+						Action: createServeWebhooksAction(),
+					},
 					{
 						Name:   "worker",
 						Usage:  "Run the worker service",
@@ -72,6 +88,21 @@ func CreateCLICommand() *cli.Command {
 					},
 				},
 			},
+			{
+				Name:  "db",
+				Usage: "Manage the core database",
+				Commands: []*cli.Command{
+					{
+						Name:   "verify",
+						Usage:  "Verify the database schema matches the compiled queries",
+						Flags:  createDbFlags(),
+						Action: createDbVerifyAction(),
+					},
+				},
+			},
+			createAgentsCommand(),
+			createToolsCommand(),
+			createReplicationCommand(),
 			{
 				Name:    "version",
 				Aliases: []string{"v"},
@@ -125,6 +156,60 @@ func createServeFlags() []cli.Flag {
 	}
 }
 
+// createDbFlags defines the flags used for the db command and its subcommands.
+func createDbFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "config",
+			Aliases: []string{"c"},
+			Usage:   "Path to configuration file",
+		},
+		&cli.StringFlag{
+			Name:  "db-host",
+			Usage: "Database host (PostgreSQL)",
+		},
+		&cli.StringFlag{
+			Name:  "db-port",
+			Usage: "Database port (PostgreSQL)",
+		},
+		&cli.StringFlag{
+			Name:  "db-user",
+			Usage: "Database user (PostgreSQL)",
+		},
+		&cli.StringFlag{
+			Name:  "db-password",
+			Usage: "Database password (PostgreSQL)",
+		},
+		&cli.StringFlag{
+			Name:  "db-name",
+			Usage: "Database name (PostgreSQL)",
+		},
+	}
+}
+
+// createDbVerifyAction checks the database schema against the schema contract of the compiled queries.
+func createDbVerifyAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		// Load the YAML configuration file if provided from `config` flag
+		config, err := service.LoadExternalConfigFile(cmd.String("config"), cmd)
+		if err != nil {
+			return err
+		}
+
+		pool, err := pgxpool.New(ctx, config.GetDatabaseConnectionString())
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer pool.Close()
+
+		if err := db.VerifySchema(ctx, pool); err != nil {
+			return err
+		}
+		fmt.Println("Database schema matches the compiled queries.")
+		return nil
+	}
+}
+
 func createServeAction() cli.ActionFunc {
 	return func(ctx context.Context, cmd *cli.Command) error {
 		// Load the YAML configuration file if provided from `config` flag
@@ -143,7 +228,7 @@ func createServeAction() cli.ActionFunc {
 
 		// Create the application instance
 		wg := &sync.WaitGroup{}
-		wg.Add(6)
+		wg.Add(8)
 
 		// Create services - they handle their own lifecycle via service.Service
 		_, err = agents.NewService(signalCtx, config, log, wg)
@@ -154,6 +239,10 @@ func createServeAction() cli.ActionFunc {
 		if err != nil {
 			return fmt.Errorf("failed to create API gateway service: %w", err)
 		}
+		_, err = embeddings.NewService(signalCtx, config, log, wg)
+		if err != nil {
+			return fmt.Errorf("failed to create embeddings service: %w", err)
+		}
 		_, err = flows.NewService(signalCtx, config, log, wg)
 		if err != nil {
 			return fmt.Errorf("failed to create flows orchestration service: %w", err)
@@ -166,6 +255,10 @@ func createServeAction() cli.ActionFunc {
 		if err != nil {
 			return fmt.Errorf("failed to create tools handler service: %w", err)
 		}
+		_, err = webhooks.NewService(signalCtx, config, log, wg)
+		if err != nil {
+			return fmt.Errorf("failed to create webhooks service: %w", err)
+		}
 		_, err = worker.NewService(signalCtx, config, log, wg)
 		if err != nil {
 			return fmt.Errorf("failed to create worker service: %w", err)
@@ -327,6 +420,78 @@ func createServeTasksAction() cli.ActionFunc {
 	}
 }
 
+func createServeEmbeddingsAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		// Load the YAML configuration file if provided from `config` flag
+		config, err := service.LoadExternalConfigFile(cmd.String("config"), cmd)
+		if err != nil {
+			return err
+		}
+
+		// Create logger with appropriate level based on debug configuration
+		log := config.CreateLogger()
+		log.Info("Starting embeddings service...")
+
+		// Create a context that listens for OS signals for graceful shutdown
+		signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Create the application instance
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		_, err = embeddings.NewService(signalCtx, config, log, wg)
+		if err != nil {
+			return fmt.Errorf("failed to create embeddings service: %w", err)
+		}
+
+		log.Info("Embeddings service started successfully, waiting for shutdown signal...")
+
+		// Wait for shutdown signal
+		<-signalCtx.Done()
+		log.Warn("Shutdown signal received, waiting for service to complete...")
+
+		wg.Wait()
+		log.Info("Embeddings service shut down complete.")
+		return nil
+	}
+}
+
+func createServeWebhooksAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		// Load the YAML configuration file if provided from `config` flag
+		config, err := service.LoadExternalConfigFile(cmd.String("config"), cmd)
+		if err != nil {
+			return err
+		}
+
+		// Create logger with appropriate level based on debug configuration
+		log := config.CreateLogger()
+		log.Info("Starting webhooks service...")
+
+		// Create a context that listens for OS signals for graceful shutdown
+		signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Create the application instance
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		_, err = webhooks.NewService(signalCtx, config, log, wg)
+		if err != nil {
+			return fmt.Errorf("failed to create webhooks service: %w", err)
+		}
+
+		log.Info("Webhooks service started successfully, waiting for shutdown signal...")
+
+		// Wait for shutdown signal
+		<-signalCtx.Done()
+		log.Warn("Shutdown signal received, waiting for service to complete...")
+
+		wg.Wait()
+		log.Info("Webhooks service shut down complete.")
+		return nil
+	}
+}
+
 func createServeToolsAction() cli.ActionFunc {
 	return func(ctx context.Context, cmd *cli.Command) error {
 		// Load the YAML configuration file if provided from `config` flag
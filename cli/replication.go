@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/urfave/cli/v3"
+)
+
+// createReplicationCommand defines the replication command used to operate a multi-region deployment.
+func createReplicationCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "replication",
+		Usage: "Manage multi-region replication and failover",
+		Commands: []*cli.Command{
+			{
+				Name:   "status",
+				Usage:  "Show the replication role of the region and the mirror lag of the streams",
+				Flags:  createReplicationFlags(),
+				Action: createReplicationStatusAction(),
+			},
+			{
+				Name:   "setup-db",
+				Usage:  "Create the logical replication publication (primary) or subscription (standby)",
+				Flags:  createReplicationFlags(),
+				Action: createReplicationSetupDbAction(),
+			},
+			{
+				Name:   "promote",
+				Usage:  "Promote the standby region to primary after the primary region failed",
+				Flags:  createReplicationFlags(),
+				Action: createReplicationPromoteAction(),
+			},
+		},
+	}
+}
+
+// createReplicationFlags defines the flags used for the replication command and its subcommands.
+func createReplicationFlags() []cli.Flag {
+	return append(createDbFlags(), &cli.StringFlag{
+		Name:  "nats-url",
+		Usage: "NATS server URL",
+	})
+}
+
+// loadReplicationConfig loads the configuration and returns it with the replication settings of the region.
+func loadReplicationConfig(cmd *cli.Command) (*service.ExternalDependenciesConfig, *service.ReplicationConfig, error) {
+	config, err := service.LoadExternalConfigFile(cmd.String("config"), cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc := config.GetReplicationConfig()
+	if rc == nil {
+		return nil, nil, fmt.Errorf("replication is not configured, set replication.region in the configuration file")
+	}
+	return config, rc, nil
+}
+
+// connectJetStream connects to the region-local NATS server and returns the JetStream service.
+func connectJetStream(ctx context.Context, config *service.ExternalDependenciesConfig) (*nats.Conn, *service.JetStreamService, error) {
+	nc, err := nats.Connect(config.GetNatsURL())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to NATS server: %w", err)
+	}
+	js, err := service.NewJetStreamService(ctx, nc, config.CreateLogger())
+	if err != nil {
+		nc.Close()
+		return nil, nil, err
+	}
+	return nc, js, nil
+}
+
+// createReplicationStatusAction prints the role of the region and the state of the replicated streams.
+func createReplicationStatusAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		config, rc, err := loadReplicationConfig(cmd)
+		if err != nil {
+			return err
+		}
+		nc, js, err := connectJetStream(ctx, config)
+		if err != nil {
+			return err
+		}
+		defer nc.Close()
+
+		activeRegion, err := js.ActiveRegion(rc)
+		if err != nil {
+			return err
+		}
+		role, err := js.ReplicationRole(rc)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Region:        %s\n", rc.Region)
+		fmt.Printf("Role:          %s\n", role)
+		fmt.Printf("Active region: %s\n", activeRegion)
+		fmt.Println("Streams:")
+		for _, name := range rc.Streams {
+			info, err := js.GetStreamInfo(name)
+			if err != nil {
+				fmt.Printf("  %-14s error: %v\n", name, err)
+				continue
+			}
+			if info.Mirror == nil {
+				fmt.Printf("  %-14s messages=%d (not a mirror)\n", name, info.State.Msgs)
+				continue
+			}
+			fmt.Printf("  %-14s messages=%d mirror_lag=%d last_active=%s\n", name, info.State.Msgs, info.Mirror.Lag, info.Mirror.Active)
+		}
+		return nil
+	}
+}
+
+// createReplicationSetupDbAction configures PostgreSQL logical replication for the key tables.
+// The primary region publishes the tables and the standby region subscribes to that publication.
+func createReplicationSetupDbAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		config, rc, err := loadReplicationConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		pool, err := pgxpool.New(ctx, config.GetDatabaseConnectionString())
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer pool.Close()
+
+		if rc.Region == rc.PrimaryRegion {
+			if err := db.EnsurePublication(ctx, pool, rc.Publication, rc.Tables); err != nil {
+				return err
+			}
+			fmt.Printf("Publication %s publishes %d tables. The database must run with wal_level = logical.\n", rc.Publication, len(rc.Tables))
+			return nil
+		}
+
+		// Without the primary connection string the subscription is left to the operator
+		if rc.PrimaryDatabaseURL == "" {
+			fmt.Println("Run the following statement on the standby database to subscribe to the primary region:")
+			fmt.Printf("%s;\n", db.SubscriptionSQL(rc.Subscription, rc.Publication, "<primary connection string>"))
+			return nil
+		}
+		created, err := db.EnsureSubscription(ctx, pool, rc.Subscription, rc.Publication, rc.PrimaryDatabaseURL)
+		if err != nil {
+			return err
+		}
+		if created {
+			fmt.Printf("Subscription %s created to publication %s of region %s.\n", rc.Subscription, rc.Publication, rc.PrimaryRegion)
+		} else {
+			fmt.Printf("Subscription %s already exists.\n", rc.Subscription)
+		}
+		return nil
+	}
+}
+
+// createReplicationPromoteAction promotes the standby region: mirrors become regular streams,
+// the database subscription is disabled and the region is recorded as active so the
+// services waiting for promotion start consuming.
+func createReplicationPromoteAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		config, rc, err := loadReplicationConfig(cmd)
+		if err != nil {
+			return err
+		}
+		nc, js, err := connectJetStream(ctx, config)
+		if err != nil {
+			return err
+		}
+		defer nc.Close()
+
+		role, err := js.ReplicationRole(rc)
+		if err != nil {
+			return err
+		}
+		if role != service.ReplicationRoleStandby {
+			return fmt.Errorf("region %s is already the active region", rc.Region)
+		}
+
+		for _, name := range rc.Streams {
+			if err := js.PromoteStream(name); err != nil {
+				return err
+			}
+		}
+
+		pool, err := pgxpool.New(ctx, config.GetDatabaseConnectionString())
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer pool.Close()
+		disabled, err := db.DisableSubscription(ctx, pool, rc.Subscription)
+		if err != nil {
+			return err
+		}
+		if !disabled {
+			fmt.Printf("Subscription %s not found, skipping.\n", rc.Subscription)
+		}
+
+		if err := js.SetActiveRegion(rc.Region); err != nil {
+			return err
+		}
+		fmt.Printf("Region %s promoted to primary.\n", rc.Region)
+		return nil
+	}
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/urfave/cli/v3"
+)
+
+// createToolsCommand defines the tools command used to move tools between environments as bundles.
+func createToolsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "tools",
+		Usage: "Manage the stored tools",
+		Commands: []*cli.Command{
+			{
+				Name:  "export",
+				Usage: "Export tools as a bundle, without their secrets",
+				Flags: append(createDbFlags(),
+					&cli.StringSliceFlag{
+						Name:  "id",
+						Usage: "ID of a tool to export, every tool is exported when no tool is selected",
+					},
+					&cli.StringSliceFlag{
+						Name:  "name",
+						Usage: "Name of a tool to export, every tool is exported when no tool is selected",
+					},
+					&cli.StringFlag{
+						Name:     "user",
+						Usage:    "ID of the user the tools are exported for, the tools hidden from the user are not exported",
+						Required: true,
+					},
+					workspaceFlag(),
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Format of the bundle, json or yaml",
+						Value: string(db.ToolBundleFormatYAML),
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "File the bundle is written to, defaults to the standard output",
+					},
+				),
+				Action: createToolsExportAction(),
+			},
+			{
+				Name:  "import",
+				Usage: "Import the tools of a bundle",
+				Flags: append(createDbFlags(),
+					&cli.StringFlag{
+						Name:     "file",
+						Aliases:  []string{"f"},
+						Usage:    "JSON or YAML bundle to import, - reads the standard input",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "on-conflict",
+						Usage: "How a tool whose name is taken is imported: create a copy, update the existing tool, or skip it",
+						Value: string(db.ToolBundleConflictSkip),
+					},
+					&cli.StringFlag{
+						Name:     "created-by",
+						Usage:    "ID of the user the imported tools are created and updated by",
+						Required: true,
+					},
+					workspaceFlag(),
+				),
+				Action: createToolsImportAction(),
+			},
+		},
+	}
+}
+
+// createToolsExportAction writes the selected tools as a bundle
+func createToolsExportAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		format := db.ToolBundleFormat(cmd.String("format"))
+		if format != db.ToolBundleFormatJSON && format != db.ToolBundleFormatYAML {
+			return fmt.Errorf("invalid format %q, must be json or yaml", format)
+		}
+		workspaceID, err := uuid.Parse(cmd.String("workspace"))
+		if err != nil {
+			return fmt.Errorf("invalid workspace ID %q: %w", cmd.String("workspace"), err)
+		}
+		var ids []uuid.UUID
+		for _, id := range cmd.StringSlice("id") {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				return fmt.Errorf("invalid tool ID %q: %w", id, err)
+			}
+			ids = append(ids, parsed)
+		}
+		userID, err := uuid.Parse(cmd.String("user"))
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q: %w", cmd.String("user"), err)
+		}
+
+		queries, closeDB, err := openToolsDB(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		tools, missing, err := queries.ToolsForBundle(ctx, workspaceID, userID, ids, cmd.StringSlice("name"))
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("tools not found: %v", missing)
+		}
+		bundle := db.NewToolBundle(tools)
+		data, err := db.EncodeToolBundle(bundle, format)
+		if err != nil {
+			return err
+		}
+
+		output := cmd.String("output")
+		if output == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(output, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write tool bundle: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "%d tools exported to %s\n", len(bundle.Tools), output)
+		return nil
+	}
+}
+
+// createToolsImportAction imports the tools of a bundle and prints the outcome of each tool
+func createToolsImportAction() cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		onConflict := db.ToolBundleConflict(cmd.String("on-conflict"))
+		if !onConflict.Valid() {
+			return fmt.Errorf("invalid on-conflict %q, must be create, update or skip", onConflict)
+		}
+		workspaceID, err := uuid.Parse(cmd.String("workspace"))
+		if err != nil {
+			return fmt.Errorf("invalid workspace ID %q: %w", cmd.String("workspace"), err)
+		}
+		createdBy, err := uuid.Parse(cmd.String("created-by"))
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q: %w", cmd.String("created-by"), err)
+		}
+
+		var data []byte
+		if file := cmd.String("file"); file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tool bundle: %w", err)
+		}
+		bundle, err := db.DecodeToolBundle(data)
+		if err != nil {
+			return err
+		}
+
+		queries, closeDB, err := openToolsDB(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		if _, err := queries.GetUserByID(ctx, createdBy); err != nil {
+			if err == pgx.ErrNoRows {
+				return fmt.Errorf("user %s not found", createdBy)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		results, err := queries.ImportToolBundle(ctx, workspaceID, bundle, onConflict, createdBy)
+		if err != nil {
+			return err
+		}
+
+		counts := make(map[db.ToolBundleImportAction]int)
+		for _, result := range results {
+			line := fmt.Sprintf("%s: %s", result.Name, result.Action)
+			if result.ToolID != nil {
+				line += fmt.Sprintf(" as %s (%s)", result.ToolName, result.ToolID)
+			}
+			if result.Message != "" {
+				line += ": " + result.Message
+			}
+			fmt.Println(line)
+			counts[result.Action]++
+		}
+		fmt.Printf("%d tools created, %d updated, %d unchanged, %d skipped, %d failed.\n",
+			counts[db.ToolBundleImportActionCreated], counts[db.ToolBundleImportActionUpdated],
+			counts[db.ToolBundleImportActionUnchanged], counts[db.ToolBundleImportActionSkipped],
+			counts[db.ToolBundleImportActionFailed])
+		if failed := counts[db.ToolBundleImportActionFailed]; failed > 0 {
+			return fmt.Errorf("%d tools could not be imported", failed)
+		}
+		return nil
+	}
+}
+
+// workspaceFlag selects the workspace the tools are exported from or imported to
+func workspaceFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "workspace",
+		Usage: "ID of the workspace of the tools",
+		Value: db.DefaultWorkspaceID.String(),
+	}
+}
+
+// openToolsDB connects to the database, with the secret keys of the configuration so the tool secrets
+// are read and written like the services do
+func openToolsDB(ctx context.Context, cmd *cli.Command) (*db.Queries, func(), error) {
+	// Load the YAML configuration file if provided from `config` flag
+	config, err := service.LoadExternalConfigFile(cmd.String("config"), cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if sc := config.Secrets; sc != nil && len(sc.Keys) > 0 {
+		wrapper, err := sc.NewKeyWrapper()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load secret keys: %w", err)
+		}
+		db.SetSecretKeyWrapper(wrapper)
+	}
+
+	pool, err := pgxpool.New(ctx, config.GetDatabaseConnectionString())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db.New(pool), pool.Close, nil
+}
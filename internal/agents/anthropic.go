@@ -1,9 +1,11 @@
 package agents
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,7 +18,7 @@ import (
 )
 
 // handleAnthropicRequest handles requests for Anthropic models
-func (as *AgentService) handleAnthropicRequest(m []anthropic.MessageParam, spec *AgentSpecs, header *service.EventHeaders, meta *service.EventMetadata) (*anthropic.MessageParam, string, error) {
+func (as *AgentService) handleAnthropicRequest(ctx context.Context, agentID uuid.UUID, m []anthropic.MessageParam, spec *AgentSpecs, header *service.EventHeaders, meta *service.EventMetadata) (*anthropic.MessageParam, string, error) {
 	// Initialize variables to accumulate content
 	var (
 		signature, toolUseID, toolName                                          string
@@ -24,8 +26,172 @@ func (as *AgentService) handleAnthropicRequest(m []anthropic.MessageParam, spec
 		response                                                                anthropic.MessageParam
 		accumulatedThinkContent, accumulatedTextContent, accumulatedToolContent strings.Builder
 		content                                                                 []anthropic.ContentBlockParamUnion
-		tools                                                                   []anthropic.ToolUnionParam
-		subAgentList                                                            []db.Agent
+	)
+
+	params, err := as.buildAnthropicParams(header.Workspace(), m, spec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	paramBytes, _ := json.Marshal(params)
+	as.log.Debug("Show invoke params", "params", string(paramBytes))
+
+	// Keep refreshing the prompt cache of the agent while it is in use
+	as.touchPromptCache(header.Workspace(), agentID)
+
+	var usage anthropic.Usage
+
+	if spec.Model.Stream {
+		stream := as.ac.Messages.NewStreaming(ctx, params)
+
+		as.log.Debug("Streaming response from Anthropic API")
+		for stream.Next() {
+			event := stream.Current()
+
+			// Publish the streaming event to websocket client
+			as.publishAnthropicStreamEvent(event, header, meta)
+
+			// Continue processing the stream
+			switch event.Type {
+			case "message_start":
+				usage = event.Message.Usage
+				as.recordPromptCacheUsage(agentID, event.Message.Usage)
+			case "content_block_start":
+				switch event.ContentBlock.Type {
+				case "thinking":
+				case "redacted_thinking":
+				case "text":
+				case "tool_use":
+					toolUseID = event.ContentBlock.ID
+					as.log.Debug("Recieve tool use block with", "id", toolUseID)
+					toolName = event.ContentBlock.Name
+				case "signature":
+				case "server_tool_use":
+				case "web_search_tool_result":
+				default:
+					as.log.Warn("Unknown content block start type", "type", event.ContentBlock.Type)
+				}
+			case "content_block_delta":
+				switch event.Delta.Type {
+				case "thinking_delta":
+					accumulatedThinkContent.WriteString(event.Delta.Thinking)
+				case "signature_delta":
+					signature = event.Delta.Signature
+				case "text_delta":
+					accumulatedTextContent.WriteString(event.Delta.Text)
+				case "input_json_delta":
+					accumulatedToolContent.WriteString(event.Delta.PartialJSON)
+					as.log.Debug("Received content block delta json", "delta", event.Delta.PartialJSON)
+				case "server_tool_use":
+				default:
+					as.log.Warn("Unknown content block delta type", "type", event.Delta.Type)
+				}
+			case "content_block_stop":
+				// Add completed content blocks to the response
+				if accumulatedThinkContent.Len() > 0 {
+					thinkBlock := anthropic.NewThinkingBlock(signature, accumulatedThinkContent.String())
+					// Set the Type field for streaming responses
+					if thinkBlock.OfThinking != nil {
+						thinkBlock.OfThinking.Type = "thinking"
+					}
+					content = append(content, thinkBlock)
+					accumulatedThinkContent.Reset()
+				}
+				if accumulatedTextContent.Len() > 0 {
+					textBlock := anthropic.NewTextBlock(accumulatedTextContent.String())
+					// Set the Type field for streaming responses
+					if textBlock.OfText != nil {
+						textBlock.OfText.Type = "text"
+					}
+					content = append(content, textBlock)
+					accumulatedTextContent.Reset()
+				}
+				if accumulatedToolContent.Len() > 0 {
+					var jsonData map[string]any
+					// Validate the JSON
+					err := json.Unmarshal([]byte(accumulatedToolContent.String()), &jsonData)
+					if err != nil {
+						return nil, "", fmt.Errorf("invalid JSON in tool use: %w", err)
+					}
+					content = append(content, anthropic.NewToolUseBlock(toolUseID, jsonData, toolName))
+					// Print last content for debugging
+					as.log.Debug("Completed tool use content", "content", content[len(content)-1])
+					accumulatedToolContent.Reset()
+				}
+			case "message_delta":
+				stop = event.Delta.StopReason
+				// The output tokens of the delta are cumulative
+				usage.OutputTokens = event.Usage.OutputTokens
+			case "message_stop":
+				// Extract Amazon Bedrock invocation metrics from raw JSON
+				var rawEvent map[string]any
+				if err := json.Unmarshal([]byte(event.RawJSON()), &rawEvent); err != nil {
+					return nil, "", fmt.Errorf("failed to parse raw response JSON: %w", err)
+				} else if bedrockMetrics, ok := rawEvent["amazon-bedrock-invocationMetrics"]; ok {
+					if metrics, ok := bedrockMetrics.(map[string]any); ok {
+						as.log.Info("Amazon Bedrock invocation metrics",
+							"input_token_count", metrics["inputTokenCount"],
+							"output_token_count", metrics["outputTokenCount"],
+							"invocation_latency", metrics["invocationLatency"],
+							"first_byte_latency", metrics["firstByteLatency"],
+						)
+					}
+				}
+			default:
+				as.log.Warn("Unknown event type received", "event_type", event.Type)
+			}
+		}
+
+		if err := stream.Err(); err != nil && err != io.EOF {
+			return nil, "", fmt.Errorf("streaming error: %w", err)
+		}
+
+	} else {
+		resp, err := as.ac.Messages.New(ctx, params)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create message: %w", err)
+		}
+		usage = resp.Usage
+		as.recordPromptCacheUsage(agentID, resp.Usage)
+
+		// Extract Amazon Bedrock invocation metrics from raw JSON for non-streaming
+		var rawResp map[string]any
+		if err := json.Unmarshal([]byte(resp.RawJSON()), &rawResp); err != nil {
+			return nil, "", fmt.Errorf("failed to parse raw response JSON: %w", err)
+		} else if bedrockMetrics, ok := rawResp["amazon-bedrock-invocationMetrics"]; ok {
+			if metrics, ok := bedrockMetrics.(map[string]any); ok {
+				as.log.Info("Amazon Bedrock invocation metrics",
+					"input_token_count", metrics["inputTokenCount"],
+					"output_token_count", metrics["outputTokenCount"],
+					"invocation_latency", metrics["invocationLatency"],
+					"first_byte_latency", metrics["firstByteLatency"],
+				)
+			}
+		}
+
+		content = resp.ToParam().Content
+		stop = resp.StopReason
+	}
+
+	// Create response message with accumulated content
+	response = anthropic.MessageParam{
+		Role:    "assistant",
+		Content: content,
+	}
+
+	// Anthropic bills the thinking as output tokens without reporting them separately
+	as.recordThinkingUsage(agentID, spec, &response, 0)
+	as.recordTaskTokenUsage(header, anthropicInputTokens(usage), usage.OutputTokens)
+
+	return &response, string(stop), nil
+}
+
+// buildAnthropicParams builds the Anthropic request parameters for the agent specs, the sub agents and the tools
+// being looked up in the workspace of the agent. The system prompt and tools form the cached prefix of the request.
+func (as *AgentService) buildAnthropicParams(workspaceID uuid.UUID, m []anthropic.MessageParam, spec *AgentSpecs) (anthropic.MessageNewParams, error) {
+	var (
+		tools        []anthropic.ToolUnionParam
+		subAgentList []db.Agent
 	)
 
 	// Fetch sub agent for this agent
@@ -40,7 +206,7 @@ func (as *AgentService) handleAnthropicRequest(m []anthropic.MessageParam, spec
 			}
 
 			// Check if agent ID is valid
-			agent, err := queries.GetAgentByID(as.ctx, subAgentUUID)
+			agent, err := queries.GetAgentByID(as.ctx, db.GetAgentByIDParams{WorkspaceID: workspaceID, ID: subAgentUUID})
 			if err != nil {
 				if err == pgx.ErrNoRows {
 					as.log.Error("Sub-agent ID not found in database, skipping this agent", "sub_agent_id", subAgentID)
@@ -58,10 +224,10 @@ func (as *AgentService) handleAnthropicRequest(m []anthropic.MessageParam, spec
 
 		// Get the invoke_agent tool
 		invokeAgentToolID, _ := uuid.Parse("550e8400-c00b-8888-3333-446655447896")
-		invokeAgentTool, err := queries.GetToolById(as.ctx, invokeAgentToolID)
+		invokeAgentTool, err := queries.GetToolById(as.ctx, db.GetToolByIdParams{ID: invokeAgentToolID, WorkspaceID: workspaceID})
 		if err != nil {
 			as.log.Error("Failed to get invoke_agent tool", "error", err)
-			return nil, "", fmt.Errorf("failed to get invoke_agent tool: %w", err)
+			return anthropic.MessageNewParams{}, fmt.Errorf("failed to get invoke_agent tool: %w", err)
 		}
 
 		// Extract description
@@ -80,16 +246,16 @@ func (as *AgentService) handleAnthropicRequest(m []anthropic.MessageParam, spec
 				schemaBytes, err := json.Marshal(internalConfig.Params)
 				if err != nil {
 					as.log.Error("Failed to marshal invoke_agent tool schema", "tool_name", invokeAgentTool.Name, "error", err)
-					return nil, "", fmt.Errorf("failed to marshal invoke_agent tool schema: %w", err)
+					return anthropic.MessageNewParams{}, fmt.Errorf("failed to marshal invoke_agent tool schema: %w", err)
 				}
 				if err := json.Unmarshal(schemaBytes, &inputSchema); err != nil {
 					as.log.Error("Failed to unmarshal invoke_agent tool schema", "tool_name", invokeAgentTool.Name, "error", err)
-					return nil, "", fmt.Errorf("failed to unmarshal invoke_agent tool schema: %w", err)
+					return anthropic.MessageNewParams{}, fmt.Errorf("failed to unmarshal invoke_agent tool schema: %w", err)
 				}
 			}
 		default:
 			as.log.Error("invoke_agent tool is not of internal type", "actual_type", invokeAgentTool.Config.Type)
-			return nil, "", fmt.Errorf("invoke_agent tool is not of internal type")
+			return anthropic.MessageNewParams{}, fmt.Errorf("invoke_agent tool is not of internal type")
 		}
 
 		// Create enum values for valid sub-agent IDs
@@ -139,12 +305,17 @@ func (as *AgentService) handleAnthropicRequest(m []anthropic.MessageParam, spec
 	}
 
 	// Fetch and convert tools for this agent
-	if len(spec.ToolRefs) > 0 {
-		var err error
-		tools, err = as.fetchAnthropicTools(spec.ToolRefs, spec.Model.ModelID)
+	if len(spec.ToolRefs) > 0 || len(spec.ToolTags) > 0 {
+		fetched, err := as.fetchAnthropicTools(workspaceID, spec)
 		if err != nil {
 			as.log.Error("Failed to convert tools to Anthropic format", "error", err)
-			return nil, "", fmt.Errorf("failed to convert tools to Anthropic format: %w", err)
+			return anthropic.MessageNewParams{}, fmt.Errorf("failed to convert tools to Anthropic format: %w", err)
+		}
+		// The tools already offered, such as the invoke_agent tool of the sub agents, are not repeated
+		for _, t := range fetched {
+			if !slices.ContainsFunc(tools, func(u anthropic.ToolUnionParam) bool { return u.OfTool.Name == t.OfTool.Name }) {
+				tools = append(tools, t)
+			}
 		}
 
 		as.log.Debug("Loaded tools for agent", "tool_count", len(tools), "tool_names", func() []string {
@@ -233,141 +404,7 @@ func (as *AgentService) handleAnthropicRequest(m []anthropic.MessageParam, spec
 		}
 	}
 
-	paramBytes, _ := json.Marshal(params)
-	as.log.Debug("Show invoke params", "params", string(paramBytes))
-
-	if spec.Model.Stream {
-		stream := as.ac.Messages.NewStreaming(as.ctx, params)
-
-		as.log.Debug("Streaming response from Anthropic API")
-		for stream.Next() {
-			event := stream.Current()
-
-			// Publish the streaming event to websocket client
-			as.publishAnthropicStreamEvent(event, header, meta)
-
-			// Continue processing the stream
-			switch event.Type {
-			case "message_start":
-			case "content_block_start":
-				switch event.ContentBlock.Type {
-				case "thinking":
-				case "redacted_thinking":
-				case "text":
-				case "tool_use":
-					toolUseID = event.ContentBlock.ID
-					as.log.Debug("Recieve tool use block with", "id", toolUseID)
-					toolName = event.ContentBlock.Name
-				case "signature":
-				case "server_tool_use":
-				case "web_search_tool_result":
-				default:
-					as.log.Warn("Unknown content block start type", "type", event.ContentBlock.Type)
-				}
-			case "content_block_delta":
-				switch event.Delta.Type {
-				case "thinking_delta":
-					accumulatedThinkContent.WriteString(event.Delta.Thinking)
-				case "signature_delta":
-					signature = event.Delta.Signature
-				case "text_delta":
-					accumulatedTextContent.WriteString(event.Delta.Text)
-				case "input_json_delta":
-					accumulatedToolContent.WriteString(event.Delta.PartialJSON)
-					as.log.Debug("Received content block delta json", "delta", event.Delta.PartialJSON)
-				case "server_tool_use":
-				default:
-					as.log.Warn("Unknown content block delta type", "type", event.Delta.Type)
-				}
-			case "content_block_stop":
-				// Add completed content blocks to the response
-				if accumulatedThinkContent.Len() > 0 {
-					thinkBlock := anthropic.NewThinkingBlock(signature, accumulatedThinkContent.String())
-					// Set the Type field for streaming responses
-					if thinkBlock.OfThinking != nil {
-						thinkBlock.OfThinking.Type = "thinking"
-					}
-					content = append(content, thinkBlock)
-					accumulatedThinkContent.Reset()
-				}
-				if accumulatedTextContent.Len() > 0 {
-					textBlock := anthropic.NewTextBlock(accumulatedTextContent.String())
-					// Set the Type field for streaming responses
-					if textBlock.OfText != nil {
-						textBlock.OfText.Type = "text"
-					}
-					content = append(content, textBlock)
-					accumulatedTextContent.Reset()
-				}
-				if accumulatedToolContent.Len() > 0 {
-					var jsonData map[string]any
-					// Validate the JSON
-					err := json.Unmarshal([]byte(accumulatedToolContent.String()), &jsonData)
-					if err != nil {
-						return nil, "", fmt.Errorf("invalid JSON in tool use: %w", err)
-					}
-					content = append(content, anthropic.NewToolUseBlock(toolUseID, jsonData, toolName))
-					// Print last content for debugging
-					as.log.Debug("Completed tool use content", "content", content[len(content)-1])
-					accumulatedToolContent.Reset()
-				}
-			case "message_delta":
-				stop = event.Delta.StopReason
-			case "message_stop":
-				// Extract Amazon Bedrock invocation metrics from raw JSON
-				var rawEvent map[string]any
-				if err := json.Unmarshal([]byte(event.RawJSON()), &rawEvent); err != nil {
-					return nil, "", fmt.Errorf("failed to parse raw response JSON: %w", err)
-				} else if bedrockMetrics, ok := rawEvent["amazon-bedrock-invocationMetrics"]; ok {
-					if metrics, ok := bedrockMetrics.(map[string]any); ok {
-						as.log.Info("Amazon Bedrock invocation metrics",
-							"input_token_count", metrics["inputTokenCount"],
-							"output_token_count", metrics["outputTokenCount"],
-							"invocation_latency", metrics["invocationLatency"],
-							"first_byte_latency", metrics["firstByteLatency"],
-						)
-					}
-				}
-			default:
-				as.log.Warn("Unknown event type received", "event_type", event.Type)
-			}
-		}
-
-		if err := stream.Err(); err != nil && err != io.EOF {
-			return nil, "", fmt.Errorf("streaming error: %w", err)
-		}
-
-	} else {
-		resp, err := as.ac.Messages.New(as.ctx, params)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create message: %w", err)
-		}
-		// Extract Amazon Bedrock invocation metrics from raw JSON for non-streaming
-		var rawResp map[string]any
-		if err := json.Unmarshal([]byte(resp.RawJSON()), &rawResp); err != nil {
-			return nil, "", fmt.Errorf("failed to parse raw response JSON: %w", err)
-		} else if bedrockMetrics, ok := rawResp["amazon-bedrock-invocationMetrics"]; ok {
-			if metrics, ok := bedrockMetrics.(map[string]any); ok {
-				as.log.Info("Amazon Bedrock invocation metrics",
-					"input_token_count", metrics["inputTokenCount"],
-					"output_token_count", metrics["outputTokenCount"],
-					"invocation_latency", metrics["invocationLatency"],
-					"first_byte_latency", metrics["firstByteLatency"],
-				)
-			}
-		}
-
-		content = resp.ToParam().Content
-		stop = resp.StopReason
-	}
-
-	// Create response message with accumulated content
-	response = anthropic.MessageParam{
-		Role:    "assistant",
-		Content: content,
-	}
-
-	return &response, string(stop), nil
+	return params, nil
 }
 
 // getSystemPrompt returns the system prompt for the agent based on the provided specs
@@ -481,33 +518,22 @@ func (as *AgentService) publishAnthropicStreamEvent(event anthropic.MessageStrea
 	}
 }
 
-// fetchAgentTools retrieves tools from database based on agent's tool_refs
-func (as *AgentService) fetchAnthropicTools(toolRefs []uuid.UUID, modelID string) ([]anthropic.ToolUnionParam, error) {
+// fetchAgentTools retrieves tools from database based on agent's tool_refs and tool_tags
+func (as *AgentService) fetchAnthropicTools(workspaceID uuid.UUID, spec *AgentSpecs) ([]anthropic.ToolUnionParam, error) {
 	var anthropicTools = []anthropic.ToolUnionParam{}
 
-	if len(toolRefs) == 0 {
+	if len(spec.ToolRefs) == 0 && len(spec.ToolTags) == 0 {
 		return nil, nil
 	}
 
-	// Fetch tools from database
-	queries := db.New(as.s.GetDB())
-	tools, err := queries.GetToolsByIDs(as.ctx, toolRefs)
+	// Fetch tools from database, resolving the pinned revisions
+	tools, err := as.resolveToolRefs(workspaceID, spec.ToolRefs, spec.ToolTags)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tools from database: %w", err)
+		return nil, err
 	}
-
-	// Check if any tools were not found and log warnings
-	if len(tools) < len(toolRefs) {
-		foundToolIDs := make(map[uuid.UUID]bool)
-		for _, tool := range tools {
-			foundToolIDs[tool.ID] = true
-		}
-
-		for _, toolRef := range toolRefs {
-			if !foundToolIDs[toolRef] {
-				as.log.Warn("Tool not found in database, will not use this tool", "tool_id", toolRef)
-			}
-		}
+	names, err := as.modelToolNames(tools, spec.StrictToolNames)
+	if err != nil {
+		return nil, err
 	}
 
 	// Extract tool params
@@ -564,10 +590,22 @@ func (as *AgentService) fetchAnthropicTools(toolRefs []uuid.UUID, modelID string
 				}
 			}
 		case db.ToolTypeMCP:
-			// MCP tools don't have predefined schemas in the database
-			// They are dynamically discovered, so we'll skip them for now
-			as.log.Debug("Skipping MCP tool - dynamic schema discovery required", "tool_name", tool.Name)
-			continue
+			// The schemas of the MCP tools are discovered from their server by the tools service
+			mcpConfig := tool.Config.GetMCP()
+			if mcpConfig == nil || mcpConfig.Params == nil {
+				as.log.Debug("Skipping MCP tool - schema not discovered yet", "tool_name", tool.Name)
+				continue
+			}
+			// Convert OpenAPI schema to map for Anthropic
+			schemaBytes, err := json.Marshal(mcpConfig.Params)
+			if err != nil {
+				as.log.Warn("Failed to marshal MCP tool schema", "tool_name", tool.Name, "error", err)
+				continue
+			}
+			if err := json.Unmarshal(schemaBytes, &inputSchema); err != nil {
+				as.log.Warn("Failed to unmarshal MCP tool schema", "tool_name", tool.Name, "error", err)
+				continue
+			}
 		default:
 			as.log.Warn("Unknown tool type", "tool_name", tool.Name, "type", tool.Config.Type)
 			continue
@@ -594,7 +632,7 @@ func (as *AgentService) fetchAnthropicTools(toolRefs []uuid.UUID, modelID string
 			}
 
 			toolParam := &anthropic.ToolParam{
-				Name:        tool.Name,
+				Name:        names[tool.ID],
 				Description: param.NewOpt(description),
 				InputSchema: anthropic.ToolInputSchemaParam{
 					Type:       "object",
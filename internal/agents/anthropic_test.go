@@ -231,7 +231,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 	}()
 	tests := []struct {
 		name        string
-		toolRefs    []uuid.UUID
+		toolRefs    []ToolRef
 		modelID     string
 		expectError bool
 		errorMsg    string
@@ -239,7 +239,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 	}{
 		{
 			name:        "empty_tool_refs",
-			toolRefs:    []uuid.UUID{},
+			toolRefs:    []ToolRef{},
 			modelID:     testModelID,
 			expectError: false,
 			validate: func(t *testing.T, tools []anthropic.ToolUnionParam, err error) {
@@ -259,7 +259,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 		},
 		{
 			name:        "valid_uuid_not_in_db",
-			toolRefs:    []uuid.UUID{uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")},
+			toolRefs:    []ToolRef{{ID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")}},
 			modelID:     testModelID,
 			expectError: false,
 			validate: func(t *testing.T, tools []anthropic.ToolUnionParam, err error) {
@@ -278,7 +278,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 		},
 		{
 			name:        "real_tool_with_cache",
-			toolRefs:    []uuid.UUID{tool.ID},
+			toolRefs:    []ToolRef{{ID: tool.ID}},
 			modelID:     testModelID,
 			expectError: false,
 			validate: func(t *testing.T, tools []anthropic.ToolUnionParam, err error) {
@@ -315,7 +315,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 		},
 		{
 			name:        "multiple_valid_tools_some_not_in_db",
-			toolRefs:    []uuid.UUID{tool.ID, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")},
+			toolRefs:    []ToolRef{{ID: tool.ID}, {ID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")}},
 			modelID:     testModelID,
 			expectError: false,
 			validate: func(t *testing.T, tools []anthropic.ToolUnionParam, err error) {
@@ -330,7 +330,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 		},
 		{
 			name:        "all_invalid_tool_ids",
-			toolRefs:    []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000001"), uuid.MustParse("00000000-0000-0000-0000-000000000002")},
+			toolRefs:    []ToolRef{{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001")}, {ID: uuid.MustParse("00000000-0000-0000-0000-000000000002")}},
 			modelID:     testModelID,
 			expectError: false,
 			validate: func(t *testing.T, tools []anthropic.ToolUnionParam, err error) {
@@ -342,7 +342,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 		},
 		{
 			name:        "mixed_valid_and_invalid_tool_ids",
-			toolRefs:    []uuid.UUID{tool.ID, uuid.MustParse("00000000-0000-0000-0000-000000000001"), uuid.MustParse("00000000-0000-0000-0000-000000000002")},
+			toolRefs:    []ToolRef{{ID: tool.ID}, {ID: uuid.MustParse("00000000-0000-0000-0000-000000000001")}, {ID: uuid.MustParse("00000000-0000-0000-0000-000000000002")}},
 			modelID:     testModelID,
 			expectError: false,
 			validate: func(t *testing.T, tools []anthropic.ToolUnionParam, err error) {
@@ -370,7 +370,7 @@ func TestFetchAnthropicTools(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tools, err := mockService.fetchAnthropicTools(tt.toolRefs, tt.modelID)
+			tools, err := mockService.fetchAnthropicTools(db.DefaultWorkspaceID, &AgentSpecs{ToolRefs: tt.toolRefs, Model: ModelSpecs{ModelID: tt.modelID}})
 			tt.validate(t, tools, err)
 		})
 	}
@@ -562,7 +562,7 @@ func TestInvokeAnthropicModel(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			msg, stop, err := mockService.handleAnthropicRequest(tc.messages, tc.spec, &service.EventHeaders{}, &service.EventMetadata{})
+			msg, stop, err := mockService.handleAnthropicRequest(context.Background(), uuid.Nil, tc.messages, tc.spec, &service.EventHeaders{}, &service.EventMetadata{})
 
 			// Assert no error occurred
 			assert.Nil(t, err)
@@ -1,6 +1,7 @@
 package agents
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -17,12 +18,12 @@ import (
 )
 
 // handleBedrockRequest handles requests for Bedrock models
-func (as *AgentService) handleBedrockRequest(m []anthropic.MessageParam, spec *AgentSpecs, header *service.EventHeaders, meta *service.EventMetadata) (*anthropic.MessageParam, string, error) {
+func (as *AgentService) handleBedrockRequest(ctx context.Context, agentID uuid.UUID, m []anthropic.MessageParam, spec *AgentSpecs, header *service.EventHeaders, meta *service.EventMetadata) (*anthropic.MessageParam, string, error) {
 	// Fetch and convert tools for this agent
 	var tools []types.Tool
-	if len(spec.ToolRefs) > 0 {
+	if len(spec.ToolRefs) > 0 || len(spec.ToolTags) > 0 {
 		var err error
-		tools, err = as.fetchBedrockTools(spec.ToolRefs)
+		tools, err = as.fetchBedrockTools(header.Workspace(), spec)
 		if err != nil {
 			as.log.Error("Failed to convert tools to Bedrock format", "error", err)
 			return nil, "", fmt.Errorf("failed to convert tools to Bedrock format: %w", err)
@@ -64,6 +65,7 @@ func (as *AgentService) handleBedrockRequest(m []anthropic.MessageParam, spec *A
 		accumulatedTextContent      strings.Builder
 		accumulatedReasoningContent strings.Builder
 		content                     []types.ContentBlock
+		usage                       *types.TokenUsage
 	)
 
 	if spec.Model.Stream {
@@ -126,7 +128,7 @@ func (as *AgentService) handleBedrockRequest(m []anthropic.MessageParam, spec *A
 			}
 		}
 
-		response, err := as.bc.ConverseStream(as.ctx, params)
+		response, err := as.bc.ConverseStream(ctx, params)
 		if err != nil {
 			as.log.Error("Error calling Bedrock Converse Stream API", "error", err)
 			return nil, "", err
@@ -189,6 +191,7 @@ func (as *AgentService) handleBedrockRequest(m []anthropic.MessageParam, spec *A
 				as.log.Debug("Message stopped", "stop_reason", stop)
 			case *types.ConverseStreamOutputMemberMetadata:
 				if v.Value.Usage != nil {
+					usage = v.Value.Usage
 					as.log.Info("Bedrock usage metrics",
 						"input_tokens", *v.Value.Usage.InputTokens,
 						"output_tokens", *v.Value.Usage.OutputTokens,
@@ -267,7 +270,7 @@ func (as *AgentService) handleBedrockRequest(m []anthropic.MessageParam, spec *A
 			}
 		}
 
-		resp, err := as.bc.Converse(as.ctx, params)
+		resp, err := as.bc.Converse(ctx, params)
 		if err != nil {
 			as.log.Error("Error calling Bedrock Converse API", "error", err)
 			return nil, "", err
@@ -281,6 +284,7 @@ func (as *AgentService) handleBedrockRequest(m []anthropic.MessageParam, spec *A
 
 		// Log usage metrics if available
 		if resp.Usage != nil {
+			usage = resp.Usage
 			as.log.Info("Bedrock usage metrics",
 				"input_tokens", *resp.Usage.InputTokens,
 				"output_tokens", *resp.Usage.OutputTokens,
@@ -302,6 +306,12 @@ func (as *AgentService) handleBedrockRequest(m []anthropic.MessageParam, spec *A
 		return nil, "", fmt.Errorf("failed to convert bedrock response: %w", err)
 	}
 
+	// The Converse API does not report the reasoning tokens separately
+	as.recordThinkingUsage(agentID, spec, &anthropicResponse, 0)
+	if usage != nil {
+		as.recordTaskTokenUsage(header, int64(aws.ToInt32(usage.InputTokens)), int64(aws.ToInt32(usage.OutputTokens)))
+	}
+
 	return &anthropicResponse, string(stop), nil
 }
 
@@ -646,33 +656,22 @@ func convertBedrockStreamEventToAnthropic(event types.ConverseStreamOutput, stat
 	return events
 }
 
-// fetchBedrockTools retrieves tools from database based on agent's tool_refs
-func (as *AgentService) fetchBedrockTools(toolRefs []uuid.UUID) ([]types.Tool, error) {
+// fetchBedrockTools retrieves tools from database based on agent's tool_refs and tool_tags
+func (as *AgentService) fetchBedrockTools(workspaceID uuid.UUID, spec *AgentSpecs) ([]types.Tool, error) {
 	var bedrockTools []types.Tool
 
-	if len(toolRefs) == 0 {
+	if len(spec.ToolRefs) == 0 && len(spec.ToolTags) == 0 {
 		return nil, nil
 	}
 
-	// Fetch tools from database
-	queries := db.New(as.s.GetDB())
-	tools, err := queries.GetToolsByIDs(as.ctx, toolRefs)
+	// Fetch tools from database, resolving the pinned revisions
+	tools, err := as.resolveToolRefs(workspaceID, spec.ToolRefs, spec.ToolTags)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tools from database: %w", err)
+		return nil, err
 	}
-
-	// Check if any tools were not found and log warnings
-	if len(tools) < len(toolRefs) {
-		foundToolIDs := make(map[uuid.UUID]bool)
-		for _, tool := range tools {
-			foundToolIDs[tool.ID] = true
-		}
-
-		for _, toolRef := range toolRefs {
-			if !foundToolIDs[toolRef] {
-				as.log.Warn("Tool not found in database, will not use this tool", "tool_id", toolRef)
-			}
-		}
+	names, err := as.modelToolNames(tools, spec.StrictToolNames)
+	if err != nil {
+		return nil, err
 	}
 
 	// Extract tool params
@@ -699,10 +698,14 @@ func (as *AgentService) fetchBedrockTools(toolRefs []uuid.UUID) ([]types.Tool, e
 				inputSchema = document.NewLazyDocument(workflowConfig.Params)
 			}
 		case db.ToolTypeMCP:
-			// MCP tools don't have predefined schemas in the database
-			// They are dynamically discovered, so we'll skip them for now
-			as.log.Debug("Skipping MCP tool - dynamic schema discovery required", "tool_name", tool.Name)
-			continue
+			// The schemas of the MCP tools are discovered from their server by the tools service
+			mcpConfig := tool.Config.GetMCP()
+			if mcpConfig == nil || mcpConfig.Params == nil {
+				as.log.Debug("Skipping MCP tool - schema not discovered yet", "tool_name", tool.Name)
+				continue
+			}
+			// Convert OpenAPI schema to document for Bedrock
+			inputSchema = document.NewLazyDocument(mcpConfig.Params)
 		default:
 			as.log.Warn("Unknown tool type", "tool_name", tool.Name, "type", tool.Config.Type)
 			continue
@@ -712,7 +715,7 @@ func (as *AgentService) fetchBedrockTools(toolRefs []uuid.UUID) ([]types.Tool, e
 		if inputSchema != nil {
 			bedrockTool := &types.ToolMemberToolSpec{
 				Value: types.ToolSpecification{
-					Name:        aws.String(tool.Name),
+					Name:        aws.String(names[tool.ID]),
 					Description: aws.String(description),
 					InputSchema: &types.ToolInputSchemaMemberJson{
 						Value: inputSchema,
@@ -1,11 +1,13 @@
 package agents
 
 import (
+	"context"
 	"sync"
 	"testing"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/google/uuid"
 	"github.com/pinazu/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -190,7 +192,7 @@ func TestInvokeBedrockModel(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			msg, stop, err := mockService.handleBedrockRequest(tc.messages, tc.spec, &service.EventHeaders{}, &service.EventMetadata{})
+			msg, stop, err := mockService.handleBedrockRequest(context.Background(), uuid.Nil, tc.messages, tc.spec, &service.EventHeaders{}, &service.EventMetadata{})
 
 			assert.Nil(t, err, "Error should be nil for successful requests")
 
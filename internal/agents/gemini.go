@@ -1,19 +1,21 @@
 package agents
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
 	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/service"
 	"google.golang.org/genai"
 )
 
 // handleGeminiRequest handles requests for Gemini models
-func (as *AgentService) handleGeminiRequest(m []anthropic.MessageParam, spec *AgentSpecs, header *service.EventHeaders, meta *service.EventMetadata) (*anthropic.MessageParam, string, error) {
+func (as *AgentService) handleGeminiRequest(ctx context.Context, agentID uuid.UUID, m []anthropic.MessageParam, spec *AgentSpecs, header *service.EventHeaders, meta *service.EventMetadata) (*anthropic.MessageParam, string, error) {
 	// Check if Gemini client is available
 	if as.gc == nil {
 		return nil, "", fmt.Errorf("gemini client is not initialized - API key may be missing")
@@ -37,6 +39,8 @@ func (as *AgentService) handleGeminiRequest(m []anthropic.MessageParam, spec *Ag
 		accumulatedTextContent     strings.Builder
 		accumulatedThinkingContent strings.Builder
 		parts                      []*genai.Part
+		thoughtsTokens             int32
+		usage                      *genai.GenerateContentResponseUsageMetadata
 	)
 
 	if spec.Model.Stream {
@@ -76,7 +80,7 @@ func (as *AgentService) handleGeminiRequest(m []anthropic.MessageParam, spec *Ag
 	}
 
 	if spec.Model.Stream {
-		stream := as.gc.Models.GenerateContentStream(as.ctx, spec.Model.ModelID, contentPointers, config)
+		stream := as.gc.Models.GenerateContentStream(ctx, spec.Model.ModelID, contentPointers, config)
 
 		for chunk, err := range stream {
 			if err != nil {
@@ -89,6 +93,12 @@ func (as *AgentService) handleGeminiRequest(m []anthropic.MessageParam, spec *Ag
 			// Publish the streaming event to websocket client
 			as.publishGeminiStreamEvent(chunk, header, meta)
 
+			// The usage of the last chunk covers the whole response
+			if chunk.UsageMetadata != nil {
+				thoughtsTokens = chunk.UsageMetadata.ThoughtsTokenCount
+				usage = chunk.UsageMetadata
+			}
+
 			// Accumulate content from streaming response
 			if len(chunk.Candidates) > 0 {
 				candidate := chunk.Candidates[0]
@@ -118,7 +128,7 @@ func (as *AgentService) handleGeminiRequest(m []anthropic.MessageParam, spec *Ag
 		// Clean up state tracking to prevent memory leaks
 		as.contentBlockStartSent = nil
 	} else {
-		resp, err := as.gc.Models.GenerateContent(as.ctx, spec.Model.ModelID, contentPointers, config)
+		resp, err := as.gc.Models.GenerateContent(ctx, spec.Model.ModelID, contentPointers, config)
 		if err != nil {
 			as.log.Error("Error in non-streaming response from Gemini",
 				"error", err,
@@ -153,7 +163,10 @@ func (as *AgentService) handleGeminiRequest(m []anthropic.MessageParam, spec *Ag
 				"input_tokens", resp.UsageMetadata.PromptTokenCount,
 				"output_tokens", resp.UsageMetadata.CandidatesTokenCount,
 				"total_tokens", resp.UsageMetadata.TotalTokenCount,
+				"thoughts_tokens", resp.UsageMetadata.ThoughtsTokenCount,
 			)
+			thoughtsTokens = resp.UsageMetadata.ThoughtsTokenCount
+			usage = resp.UsageMetadata
 		}
 	}
 
@@ -182,6 +195,11 @@ func (as *AgentService) handleGeminiRequest(m []anthropic.MessageParam, spec *Ag
 		as.log.Error("Failed to convert Gemini response to Anthropic format", "error", err)
 		return nil, "", fmt.Errorf("failed to convert gemini response: %w", err)
 	}
+	as.recordThinkingUsage(agentID, spec, &anthropicResponse, int64(thoughtsTokens))
+	if usage != nil {
+		// Gemini bills the thoughts as output tokens
+		as.recordTaskTokenUsage(header, int64(usage.PromptTokenCount), int64(usage.CandidatesTokenCount+usage.ThoughtsTokenCount))
+	}
 
 	// Map finish reasons to stop reasons
 	var stop string
@@ -1,12 +1,14 @@
 package agents
 
 import (
+	"context"
 	"os"
 	"sync"
 	"testing"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
 	"github.com/pinazu/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -508,7 +510,7 @@ func TestInvokeGeminiModel(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			msg, stop, err := mockService.handleGeminiRequest(tc.messages, tc.spec, &service.EventHeaders{}, &service.EventMetadata{})
+			msg, stop, err := mockService.handleGeminiRequest(context.Background(), uuid.Nil, tc.messages, tc.spec, &service.EventHeaders{}, &service.EventMetadata{})
 
 			// Handle potential credential errors in test environment
 			if err != nil {
@@ -0,0 +1,53 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/service"
+)
+
+// invokeRequestCallback answers a request to invoke an agent once with the message of its model. Unlike
+// invokeEventCallback no task follows: the tools the model asks for are not dispatched. The streaming events of the
+// model are sent to the user with the connection of the request headers.
+func (as *AgentService) invokeRequestCallback(msg *nats.Msg) {
+	_, span := as.s.GetTracer().Start(as.ctx, "invokeRequestCallback")
+	defer span.End()
+
+	req, err := service.ParseEvent[*service.AgentInvokeRequestEventMessage](msg.Data)
+	if err != nil {
+		as.log.Error("Failed to parse agent invoke request", "error", err)
+		var (
+			header *service.EventHeaders
+			meta   *service.EventMetadata
+		)
+		if req != nil {
+			header, meta = req.H, req.M
+		}
+		service.NewErrorEvent[*service.AgentInvokeResponseEventMessage](header, meta, err).Respond(msg)
+		return
+	}
+	as.log.Info("Received agent invoke request", "agent_id", req.Msg.AgentId, "user_id", req.H.UserID)
+
+	response, stop, _, err := as.invokeModel(as.ctx, req.Msg.AgentId, req.Msg.Messages, req.H, req.M)
+	if err != nil {
+		service.NewErrorEvent[*service.AgentInvokeResponseEventMessage](req.H, req.M, err).Respond(msg)
+		return
+	}
+	message, err := json.Marshal(response)
+	if err != nil {
+		as.log.Error("Failed to marshal response", "error", err)
+		service.NewErrorEvent[*service.AgentInvokeResponseEventMessage](req.H, req.M, fmt.Errorf("failed to marshal response: %w", err)).Respond(msg)
+		return
+	}
+
+	event := service.Event[*service.AgentInvokeResponseEventMessage]{
+		H:   req.H,
+		Msg: &service.AgentInvokeResponseEventMessage{Message: message, StopReason: stop},
+		M:   req.M,
+	}
+	if err := event.Respond(msg); err != nil {
+		as.log.Error("Failed to respond to agent invoke request", "error", err)
+	}
+}
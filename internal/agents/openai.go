@@ -1,13 +1,14 @@
 package agents
 
 import (
+	"context"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/packages/param"
 	"github.com/pinazu/internal/service"
 )
 
 // handleOpenAIRequest handles requests for OpenAI models
-func (as *AgentService) handleOpenAIRequest(m []openai.ChatCompletionMessageParamUnion, spec *AgentSpecs, header *service.EventHeaders) (*openai.ChatCompletionMessageParamUnion, error) {
+func (as *AgentService) handleOpenAIRequest(ctx context.Context, m []openai.ChatCompletionMessageParamUnion, spec *AgentSpecs, header *service.EventHeaders) (*openai.ChatCompletionMessageParamUnion, error) {
 	params := openai.ChatCompletionNewParams{
 		Model:               spec.Model.ModelID,
 		Messages:            m,
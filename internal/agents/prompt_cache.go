@@ -0,0 +1,191 @@
+package agents
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// promptCacheState tracks when the cached prefix of an agent was last used and written
+type promptCacheState struct {
+	workspaceID uuid.UUID // Workspace of the agent, its sub agents and tools are looked up in
+	lastUsed    time.Time
+	lastWritten time.Time
+}
+
+// promptCacheProviders are the model providers whose requests carry prompt cache breakpoints
+var promptCacheProviders = map[string]bool{
+	"bedrock/anthropic": true,
+}
+
+// touchPromptCache records that a request of the agent wrote or refreshed its cached prefix
+func (as *AgentService) touchPromptCache(workspaceID, agentID uuid.UUID) {
+	if agentID == uuid.Nil {
+		return
+	}
+	now := time.Now()
+	as.promptCacheMu.Lock()
+	defer as.promptCacheMu.Unlock()
+	as.promptCacheAgents[agentID] = &promptCacheState{workspaceID: workspaceID, lastUsed: now, lastWritten: now}
+}
+
+// markPromptCacheWritten records a warmup of the cached prefix of the agent.
+// An agent seen for the first time counts as used, so it is kept warm until it stays idle.
+func (as *AgentService) markPromptCacheWritten(workspaceID, agentID uuid.UUID) {
+	now := time.Now()
+	as.promptCacheMu.Lock()
+	defer as.promptCacheMu.Unlock()
+	if state, ok := as.promptCacheAgents[agentID]; ok {
+		state.lastWritten = now
+		return
+	}
+	as.promptCacheAgents[agentID] = &promptCacheState{workspaceID: workspaceID, lastUsed: now, lastWritten: now}
+}
+
+// forgetPromptCache stops refreshing the cached prefix of the agent
+func (as *AgentService) forgetPromptCache(agentID uuid.UUID) {
+	as.promptCacheMu.Lock()
+	defer as.promptCacheMu.Unlock()
+	delete(as.promptCacheAgents, agentID)
+}
+
+// recordPromptCacheUsage stores the prompt cache usage of an interactive request for the agent analytics
+func (as *AgentService) recordPromptCacheUsage(agentID uuid.UUID, usage anthropic.Usage) {
+	if agentID == uuid.Nil {
+		return
+	}
+	as.log.Debug("Prompt cache usage",
+		"agent_id", agentID,
+		"input_tokens", usage.InputTokens,
+		"cache_read_input_tokens", usage.CacheReadInputTokens,
+		"cache_creation_input_tokens", usage.CacheCreationInputTokens,
+	)
+	err := db.New(as.s.GetDB()).RecordAgentPromptCacheUsage(as.ctx, db.RecordAgentPromptCacheUsageParams{
+		AgentID:                  agentID,
+		CacheReadInputTokens:     usage.CacheReadInputTokens,
+		InputTokens:              usage.InputTokens,
+		CacheCreationInputTokens: usage.CacheCreationInputTokens,
+	})
+	if err != nil {
+		as.log.Warn("Failed to record prompt cache usage", "agent_id", agentID, "error", err)
+	}
+}
+
+// warmupEventCallback handles the agent prompt cache warmup event callback
+func (as *AgentService) warmupEventCallback(msg *nats.Msg) {
+	req, err := service.ParseEvent[*service.AgentCacheWarmupEventMessage](msg.Data)
+	if err != nil {
+		as.log.Error("Failed to unmarshal message to request", "error", err)
+		return
+	}
+	if !as.promptCache.Warmup {
+		as.log.Debug("Prompt cache warmup disabled, ignoring warmup event", "agent_id", req.Msg.AgentId)
+		return
+	}
+
+	if err := as.warmupPromptCache(req.H.Workspace(), req.Msg.AgentId); err != nil {
+		as.log.Error("Failed to warm up prompt cache", "agent_id", req.Msg.AgentId, "error", err)
+	}
+}
+
+// warmupPromptCache uploads the system prompt and tools of the agent of a workspace to the provider prompt cache.
+// The request asks for a single output token, so its cost is mostly the cache write.
+// Thinking is left disabled: changing the thinking parameters keeps the cached system prompt and tools valid.
+func (as *AgentService) warmupPromptCache(workspaceID, agentID uuid.UUID) error {
+	yamlSpecs, err := db.New(as.s.GetDB()).GetAgentSpecsByID(as.ctx, db.GetAgentSpecsByIDParams{WorkspaceID: workspaceID, ID: agentID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		as.forgetPromptCache(agentID)
+		return fmt.Errorf("agent not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load agent specs: %w", err)
+	}
+	specs, err := ParseAgentSpecs(yamlSpecs.String)
+	if err != nil {
+		return err
+	}
+	if !promptCacheProviders[specs.Model.Provider] {
+		as.log.Debug("Prompt cache warmup not supported for provider", "agent_id", agentID, "provider", specs.Model.Provider)
+		as.forgetPromptCache(agentID)
+		return nil
+	}
+
+	warmupMessages := []anthropic.MessageParam{anthropic.NewUserMessage(anthropic.NewTextBlock("."))}
+	params, err := as.buildAnthropicParams(workspaceID, warmupMessages, specs)
+	if err != nil {
+		return err
+	}
+	params.Messages = warmupMessages
+	params.MaxTokens = 1
+	params.Thinking = anthropic.ThinkingConfigParamUnion{}
+
+	resp, err := as.ac.Messages.New(as.ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to send warmup request: %w", err)
+	}
+
+	as.markPromptCacheWritten(workspaceID, agentID)
+
+	err = db.New(as.s.GetDB()).RecordAgentPromptCacheWarmup(as.ctx, db.RecordAgentPromptCacheWarmupParams{
+		AgentID:           agentID,
+		WarmupInputTokens: resp.Usage.CacheCreationInputTokens + resp.Usage.InputTokens,
+	})
+	if err != nil {
+		as.log.Warn("Failed to record prompt cache warmup", "agent_id", agentID, "error", err)
+	}
+
+	as.log.Info("Prompt cache warmed up",
+		"agent_id", agentID,
+		"cache_creation_input_tokens", resp.Usage.CacheCreationInputTokens,
+		"cache_read_input_tokens", resp.Usage.CacheReadInputTokens,
+	)
+	return nil
+}
+
+// dueForPromptCacheRefresh returns the agents whose cached prefix expires soon with their workspace, and forgets the
+// idle ones
+func (as *AgentService) dueForPromptCacheRefresh(now time.Time) map[uuid.UUID]uuid.UUID {
+	refreshInterval := time.Duration(as.promptCache.RefreshIntervalSeconds) * time.Second
+	idleTimeout := time.Duration(as.promptCache.IdleTimeoutSeconds) * time.Second
+
+	as.promptCacheMu.Lock()
+	defer as.promptCacheMu.Unlock()
+
+	due := make(map[uuid.UUID]uuid.UUID)
+	for agentID, state := range as.promptCacheAgents {
+		if now.Sub(state.lastUsed) > idleTimeout {
+			delete(as.promptCacheAgents, agentID)
+			continue
+		}
+		if now.Sub(state.lastWritten) >= refreshInterval {
+			due[agentID] = state.workspaceID
+		}
+	}
+	return due
+}
+
+// refreshPromptCache periodically refreshes the cached prefix of the agents in use before it expires
+func (as *AgentService) refreshPromptCache() {
+	ticker := time.NewTicker(time.Duration(as.promptCache.RefreshIntervalSeconds) * time.Second / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-as.ctx.Done():
+			return
+		case now := <-ticker.C:
+			for agentID, workspaceID := range as.dueForPromptCacheRefresh(now) {
+				if err := as.warmupPromptCache(workspaceID, agentID); err != nil {
+					as.log.Warn("Failed to refresh prompt cache", "agent_id", agentID, "error", err)
+				}
+			}
+		}
+	}
+}
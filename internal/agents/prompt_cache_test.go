@@ -0,0 +1,52 @@
+package agents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueForPromptCacheRefresh(t *testing.T) {
+	now := time.Now()
+	fresh, expiring, idle := uuid.New(), uuid.New(), uuid.New()
+	workspaceID := uuid.New()
+
+	as := &AgentService{
+		promptCache: &service.PromptCacheConfig{Warmup: true, RefreshIntervalSeconds: 240, IdleTimeoutSeconds: 3600},
+		promptCacheAgents: map[uuid.UUID]*promptCacheState{
+			fresh:    {lastUsed: now.Add(-time.Minute), lastWritten: now.Add(-time.Minute)},
+			expiring: {workspaceID: workspaceID, lastUsed: now.Add(-30 * time.Minute), lastWritten: now.Add(-4 * time.Minute)},
+			idle:     {lastUsed: now.Add(-2 * time.Hour), lastWritten: now.Add(-4 * time.Minute)},
+		},
+	}
+
+	due := as.dueForPromptCacheRefresh(now)
+	assert.Equal(t, map[uuid.UUID]uuid.UUID{expiring: workspaceID}, due)
+
+	// Idle agents are forgotten, the others stay tracked
+	assert.NotContains(t, as.promptCacheAgents, idle)
+	assert.Contains(t, as.promptCacheAgents, fresh)
+	assert.Contains(t, as.promptCacheAgents, expiring)
+}
+
+func TestMarkPromptCacheWritten(t *testing.T) {
+	agentID := uuid.New()
+	as := &AgentService{promptCacheAgents: map[uuid.UUID]*promptCacheState{}}
+
+	// A first warmup counts as a use of the agent
+	as.markPromptCacheWritten(db.DefaultWorkspaceID, agentID)
+	state := as.promptCacheAgents[agentID]
+	assert.False(t, state.lastUsed.IsZero())
+	assert.Equal(t, db.DefaultWorkspaceID, state.workspaceID)
+
+	// A refresh keeps the last use time
+	lastUsed := state.lastUsed.Add(-time.Hour)
+	state.lastUsed = lastUsed
+	as.markPromptCacheWritten(db.DefaultWorkspaceID, agentID)
+	assert.Equal(t, lastUsed, as.promptCacheAgents[agentID].lastUsed)
+	assert.True(t, as.promptCacheAgents[agentID].lastWritten.After(lastUsed))
+}
@@ -14,17 +14,26 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/nats-io/nats.go"
 	"github.com/openai/openai-go"
 	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/service"
 	"google.golang.org/genai"
-	"gopkg.in/yaml.v3"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/bedrock"
 )
 
+// How the agents receive the tool results offloaded to object storage
+const (
+	ToolResultOffloadedTruncate = "truncate" // The start of the result, followed by its location
+	ToolResultOffloadedLink     = "link"     // Only the location and size of the result
+)
+
+// staleCacheEntries bounds the agent specs and tools kept to be served while the database is unavailable
+const staleCacheEntries = 1000
+
 type (
 	AgentService struct {
 		ac  *anthropic.Client
@@ -37,14 +46,50 @@ type (
 		ctx context.Context
 		// State tracking for Bedrock streaming event normalization
 		contentBlockStartSent map[int64]bool
+		// Prompt cache warmup configuration and state of the agents in use
+		promptCache       *service.PromptCacheConfig
+		promptCacheMu     sync.Mutex
+		promptCacheAgents map[uuid.UUID]*promptCacheState
+		// Agent specs and tools last loaded, served while the database is unavailable
+		specsCache  *db.StaleCache[uuid.UUID, pgtype.Text]
+		toolsCache  *db.StaleCache[string, []db.Tool]
+		accessCache *db.StaleCache[agentUser, db.GrantAccess]
+		// Usage quotas checked before the model requests
+		quotas *db.Quotas
+		// Model requests in flight of each task, stopped when their task is cancelled
+		streams *taskStreams
+	}
+
+	// agentUser is an agent invoked by a user
+	agentUser struct {
+		AgentID uuid.UUID
+		UserID  uuid.UUID
 	}
 
 	AgentSpecs struct {
-		Model      ModelSpecs  `yaml:"model"`
-		System     string      `yaml:"system"`
-		ToolRefs   []uuid.UUID `yaml:"tool_refs,omitempty"`
-		ToolChoice ToolChoice  `yaml:"tool_choice,omitempty"`
-		SubAgents  *SubAgents  `yaml:"sub_agents,omitempty"`
+		Version         int              `yaml:"version,omitempty"` // Version of the specs schema, see CurrentSpecVersion
+		Model           ModelSpecs       `yaml:"model"`
+		System          string           `yaml:"system"`
+		ToolRefs        []ToolRef        `yaml:"tool_refs,omitempty"`
+		ToolTags        []string         `yaml:"tool_tags,omitempty"` // Tools labelled with any of these tags are included along with the tool_refs
+		ToolDeny        []ToolDenyRule   `yaml:"tool_deny,omitempty"` // Tools the agent cannot call, their calls get an error result
+		ToolChoice      ToolChoice       `yaml:"tool_choice,omitempty"`
+		SubAgents       *SubAgents       `yaml:"sub_agents,omitempty"`
+		ToolResults     *ToolResultSpecs `yaml:"tool_results,omitempty"`
+		StrictToolNames bool             `yaml:"strict_tool_names,omitempty"` // Colliding tool names fail the request instead of being namespaced
+	}
+
+	// ToolResultSpecs sets how the agent receives a tool result too large for the database, offloaded to object storage
+	ToolResultSpecs struct {
+		Offloaded string `yaml:"offloaded,omitempty"` // ToolResultOffloadedTruncate (default) or ToolResultOffloadedLink
+		MaxChars  int    `yaml:"max_chars,omitempty"` // Characters kept when truncating, defaults to the tools service setting
+	}
+
+	// ToolRef references a tool, written as "<tool_id>" or "<tool_id>@<revision>",
+	// or as a mapping with id and revision keys
+	ToolRef struct {
+		ID       uuid.UUID `yaml:"id"`
+		Revision int32     `yaml:"revision,omitempty"` // Pinned revision, zero follows the promoted revision
 	}
 
 	SubAgents struct {
@@ -152,11 +197,35 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("failed to create agent service: %v", err)
 	}
 
-	as := &AgentService{ac: &ac, gc: gc, oc: &oc, bc: bc, s: s, log: log, wg: wg, ctx: ctx}
+	as := &AgentService{
+		ac:                &ac,
+		gc:                gc,
+		oc:                &oc,
+		bc:                bc,
+		s:                 s,
+		log:               log,
+		wg:                wg,
+		ctx:               ctx,
+		promptCache:       externalDependenciesConfig.GetPromptCacheConfig(),
+		promptCacheAgents: make(map[uuid.UUID]*promptCacheState),
+		specsCache:        db.NewStaleCache[uuid.UUID, pgtype.Text](staleCacheEntries),
+		toolsCache:        db.NewStaleCache[string, []db.Tool](staleCacheEntries),
+		accessCache:       db.NewStaleCache[agentUser, db.GrantAccess](staleCacheEntries),
+		quotas:            externalDependenciesConfig.GetQuotas(),
+		streams:           newTaskStreams(),
+	}
 
 	s.RegisterHandler(service.AgentInvokeEventSubject.String(), as.invokeEventCallback)
-	s.RegisterHandler("v1.svc.agent._info", nil)
-	s.RegisterHandler("v1.svc.agent._stats", nil)
+	s.RegisterHandler(service.AgentInvokeRequestEventSubject.String(), as.invokeRequestCallback)
+	s.RegisterHandler(service.AgentCacheWarmupEventSubject.String(), as.warmupEventCallback)
+	s.RegisterHandler(service.AgentStreamCancelEventSubject.String(), as.streamCancelEventCallback)
+	s.RegisterHandler("v1.svc.agent._info", service.InfoHandler(s))
+	s.RegisterHandler("v1.svc.agent._stats", service.StatsHandler(s))
+
+	// Keep the prompt cache of the agents in use warm
+	if as.promptCache.Warmup {
+		go as.refreshPromptCache()
+	}
 
 	// Start a goroutine to wait for context cancellation and then shutdown
 	go func() {
@@ -171,6 +240,35 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 	return as, nil
 }
 
+// loadAgentSpecs loads the specs of an agent of a workspace, the specs last loaded are served while the database is
+// unavailable
+func (as *AgentService) loadAgentSpecs(workspaceID, agentID uuid.UUID) (pgtype.Text, error) {
+	specs, loadedAt, err := as.specsCache.Load(agentID, func() (pgtype.Text, error) {
+		return db.New(as.s.GetDB()).GetAgentSpecsByID(as.ctx, db.GetAgentSpecsByIDParams{WorkspaceID: workspaceID, ID: agentID})
+	})
+	if err == nil && !loadedAt.IsZero() {
+		as.log.Warn("Database unavailable, using the agent specs last loaded", "agent_id", agentID, "loaded_at", loadedAt)
+	}
+	return specs, err
+}
+
+// loadAgentAccess loads the access of a user on an agent of a workspace, the access last loaded is served while the
+// database is unavailable
+func (as *AgentService) loadAgentAccess(workspaceID, agentID, userID uuid.UUID) (db.GrantAccess, error) {
+	access, loadedAt, err := as.accessCache.Load(agentUser{AgentID: agentID, UserID: userID}, func() (db.GrantAccess, error) {
+		queries := db.New(as.s.GetDB())
+		ownerID, err := queries.GetAgentOwner(as.ctx, db.GetAgentOwnerParams{WorkspaceID: workspaceID, ID: agentID})
+		if err != nil {
+			return db.GrantAccessNil, err
+		}
+		return queries.ResourceAccess(as.ctx, db.ResourceTypeAgent, agentID, ownerID, userID)
+	})
+	if err == nil && !loadedAt.IsZero() {
+		as.log.Warn("Database unavailable, using the agent access last loaded", "agent_id", agentID, "user_id", userID, "loaded_at", loadedAt)
+	}
+	return access, err
+}
+
 // invokeEventCallback handles the agent invoke request event callback
 func (as *AgentService) invokeEventCallback(msg *nats.Msg) {
 	// Check if context was cancelled
@@ -200,30 +298,107 @@ func (as *AgentService) invokeEventCallback(msg *nats.Msg) {
 		"user_id", req.H.UserID,
 	)
 
+	// The model request stops when its task is cancelled, the task service ends the task
+	ctx := as.ctx
+	if req.H.TaskID != nil {
+		var done func()
+		ctx, done = as.streams.start(as.ctx, *req.H.TaskID)
+		defer done()
+	}
+	response, stop, specs, err := as.invokeModel(ctx, req.Msg.AgentId, req.Msg.Messages, req.H, req.M)
+	if err != nil && ctx.Err() != nil && as.ctx.Err() == nil {
+		as.log.Info("Model request stopped, the task was cancelled", "agent_id", req.Msg.AgentId, "task_id", *req.H.TaskID)
+		return
+	}
+	if err != nil {
+		// Create and publish new Error Event back to websocket
+		service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
+		service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
+		return
+	}
+
+	// Convert response to db.JsonRaw
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		as.log.Error("Failed to marshal response", "error", err)
+		return
+	}
+
+	switch stop {
+	case "end_turn":
+		event := service.NewEvent(&service.TaskFinishEventMessage{
+			AgentId:     req.Msg.AgentId,
+			RecipientId: req.Msg.RecipientId,
+			Response:    responseBytes,
+		}, req.H, &service.EventMetadata{
+			TraceID:   req.M.TraceID,
+			Timestamp: time.Now().UTC(),
+		})
+		err = event.Publish(as.s.GetNATS())
+		if err != nil {
+			as.log.Error("Failed to publish event", "error", err)
+			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
+			return
+		}
+	case "tool_use":
+		event := service.NewEvent(&service.ToolDispatchEventMessage{
+			AgentId:     req.Msg.AgentId,
+			Provider:    db.ProviderModel(specs.Model.Provider),
+			RecipientId: req.Msg.RecipientId,
+			Message:     responseBytes,
+		}, req.H, &service.EventMetadata{
+			TraceID:   req.M.TraceID,
+			Timestamp: time.Now().UTC(),
+		})
+		err = event.Publish(as.s.GetNATS())
+		if err != nil {
+			as.log.Error("Failed to publish event", "error", err)
+			service.NewErrorEvent[*service.ToolDispatchEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
+			return
+		}
+	default:
+		// Handle unexpected stop reasons
+		as.log.Warn("Unexpected stop reason", "stop_reason", stop)
+		service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, fmt.Errorf("unexpected stop reason: %s", stop)).Publish(as.s.GetNATS())
+	}
+}
+
+// invokeModel sends the messages to the model of an agent once the user of the headers is allowed to invoke it within
+// its quotas, and returns the message of the model with the reason it stopped. The model request stops with ctx.
+func (as *AgentService) invokeModel(ctx context.Context, agentID uuid.UUID, messages []db.JsonRaw, header *service.EventHeaders, meta *service.EventMetadata) (any, string, *AgentSpecs, error) {
 	// Load the agent specs
-	queries := db.New(as.s.GetDB())
-	yamlSpecs, err := queries.GetAgentSpecsByID(as.ctx, req.Msg.AgentId)
+	yamlSpecs, err := as.loadAgentSpecs(header.Workspace(), agentID)
 	if err != nil {
 		if err.Error() == "no rows in result set" {
-			as.log.Error("Agent not found", "agent_id", req.Msg.AgentId)
-			err := fmt.Errorf("invalid agent_id")
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-		} else {
-			as.log.Error("Failed to load agent specs", "error", err)
-			err := fmt.Errorf("failed to load agent specs: %w", err)
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
+			as.log.Error("Agent not found", "agent_id", agentID)
+			return nil, "", nil, fmt.Errorf("invalid agent_id")
 		}
-		return
+		as.log.Error("Failed to load agent specs", "error", err)
+		return nil, "", nil, fmt.Errorf("failed to load agent specs: %w", err)
+	}
+
+	// The agents shared with grants are only invoked by the users allowed to
+	access, err := as.loadAgentAccess(header.Workspace(), agentID, header.UserID)
+	if err != nil {
+		as.log.Error("Failed to load agent access", "error", err)
+		return nil, "", nil, fmt.Errorf("failed to load agent access: %w", err)
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		as.log.Error("User not allowed to invoke the agent", "agent_id", agentID, "user_id", header.UserID, "access", access)
+		return nil, "", nil, fmt.Errorf("the %s access on the agent is required", db.GrantAccessInvoke)
+	}
+
+	// The model requests stop once the user or its workspace used up its tokens of the day
+	if err := db.New(as.s.GetDB()).CheckQuotas(as.ctx, as.quotas, header.Workspace(), header.UserID, db.QuotaTokensPerDay); err != nil {
+		as.log.Error("Token quota check failed", "agent_id", agentID, "user_id", header.UserID, "error", err)
+		return nil, "", nil, err
 	}
 
-	// Convert specs to AgentSpecs struct
-	specs := &AgentSpecs{}
-	err = yaml.Unmarshal([]byte(yamlSpecs.String), specs)
+	// Convert specs to AgentSpecs struct, the specs written for an older schema are migrated in memory
+	specs, err := ParseAgentSpecs(yamlSpecs.String)
 	if err != nil {
 		as.log.Error("Failed to unmarshal agent specs", "error", err)
-		return
+		return nil, "", nil, fmt.Errorf("failed to unmarshal agent specs: %w", err)
 	}
 
 	// Detect the model provider from the model string
@@ -234,160 +409,66 @@ func (as *AgentService) invokeEventCallback(msg *nats.Msg) {
 	var stop string
 	switch specs.Model.Provider {
 	case "bedrock/anthropic":
-		// Parse Anthropic messages
-		msgs, err := ParseMessages[anthropic.MessageParam](req.Msg.Messages)
+		msgs, err := ParseMessages[anthropic.MessageParam](messages)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to parse Anthropic messages", "error", err)
-			err = fmt.Errorf("failed to parse Anthropic messages: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to parse Anthropic messages: %w", err)
 		}
 
 		// Invoke the Anthropic model
-		response, stop, err = as.handleAnthropicRequest(msgs, specs, req.H, req.M)
+		response, stop, err = as.handleAnthropicRequest(ctx, agentID, msgs, specs, header, meta)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to handle Anthropic request", "error", err)
-			err = fmt.Errorf("failed to handle Anthropic request: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to handle Anthropic request: %w", err)
 		}
 
 	case "bedrock":
 		// Parse Anthropic messages (consistent format)
-		msgs, err := ParseMessages[anthropic.MessageParam](req.Msg.Messages)
+		msgs, err := ParseMessages[anthropic.MessageParam](messages)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to parse Anthropic messages", "error", err)
-			err = fmt.Errorf("failed to parse Anthropic messages: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to parse Anthropic messages: %w", err)
 		}
 
 		// Invoke the Bedrock Foundation model
-		response, stop, err = as.handleBedrockRequest(msgs, specs, req.H, req.M)
+		response, stop, err = as.handleBedrockRequest(ctx, agentID, msgs, specs, header, meta)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to handle Bedrock request", "error", err)
-			err = fmt.Errorf("failed to handle Bedrock request: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to handle Bedrock request: %w", err)
 		}
 
 	case "openai":
-		// Parse OpenAI messages
-		msgs, err := ParseMessages[openai.ChatCompletionMessageParamUnion](req.Msg.Messages)
+		msgs, err := ParseMessages[openai.ChatCompletionMessageParamUnion](messages)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to parse OpenAI messages", "error", err)
-			err = fmt.Errorf("failed to parse OpenAI messages: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to parse OpenAI messages: %w", err)
 		}
 
 		// Invoke the OpenAI model
-		response, err = as.handleOpenAIRequest(msgs, specs, req.H)
+		response, err = as.handleOpenAIRequest(ctx, msgs, specs, header)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to handle OpenAI request", "error", err)
-			err = fmt.Errorf("failed to handle OpenAI request: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to handle OpenAI request: %w", err)
 		}
 
 	case "google":
 		// Parse Anthropic messages (consistent format)
-		msgs, err := ParseMessages[anthropic.MessageParam](req.Msg.Messages)
+		msgs, err := ParseMessages[anthropic.MessageParam](messages)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to parse Anthropic messages", "error", err)
-			err = fmt.Errorf("failed to parse Anthropic messages: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to parse Anthropic messages: %w", err)
 		}
 
 		// Invoke the Gemini model
-		response, stop, err = as.handleGeminiRequest(msgs, specs, req.H, req.M)
+		response, stop, err = as.handleGeminiRequest(ctx, agentID, msgs, specs, header, meta)
 		if err != nil {
-			// Log error and create error message
 			as.log.Error("Failed to handle Gemini request", "error", err)
-			err = fmt.Errorf("failed to handle Gemini request: %w", err)
-
-			// Create and publish new Error Event back to websocket
-			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(as.s.GetNATS(), req.H.UserID)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
+			return nil, "", nil, fmt.Errorf("failed to handle Gemini request: %w", err)
 		}
 
 	default:
 		as.log.Error("Unsupported model provider", "provider", specs.Model.Provider)
-		return
-	}
-
-	// Convert response to db.JsonRaw
-	responseBytes, err := json.Marshal(response)
-	if err != nil {
-		as.log.Error("Failed to marshal response", "error", err)
-		return
-	}
-
-	switch stop {
-	case "end_turn":
-		event := service.NewEvent(&service.TaskFinishEventMessage{
-			AgentId:     req.Msg.AgentId,
-			RecipientId: req.Msg.RecipientId,
-			Response:    responseBytes,
-		}, req.H, &service.EventMetadata{
-			TraceID:   req.M.TraceID,
-			Timestamp: time.Now().UTC(),
-		})
-		err = event.Publish(as.s.GetNATS())
-		if err != nil {
-			as.log.Error("Failed to publish event", "error", err)
-			service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
-		}
-	case "tool_use":
-		event := service.NewEvent(&service.ToolDispatchEventMessage{
-			AgentId:     req.Msg.AgentId,
-			Provider:    db.ProviderModel(specs.Model.Provider),
-			RecipientId: req.Msg.RecipientId,
-			Message:     responseBytes,
-		}, req.H, &service.EventMetadata{
-			TraceID:   req.M.TraceID,
-			Timestamp: time.Now().UTC(),
-		})
-		err = event.Publish(as.s.GetNATS())
-		if err != nil {
-			as.log.Error("Failed to publish event", "error", err)
-			service.NewErrorEvent[*service.ToolDispatchEventMessage](req.H, req.M, err).Publish(as.s.GetNATS())
-			return
-		}
-	default:
-		// Handle unexpected stop reasons
-		as.log.Warn("Unexpected stop reason", "stop_reason", stop)
-		service.NewErrorEvent[*service.TaskFinishEventMessage](req.H, req.M, fmt.Errorf("unexpected stop reason: %s", stop)).Publish(as.s.GetNATS())
+		return nil, "", nil, fmt.Errorf("unsupported model provider %q", specs.Model.Provider)
 	}
+	return response, stop, specs, nil
 }
@@ -0,0 +1,222 @@
+package agents
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pinazu/internal/db"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSpecVersion is the version of the agent specs schema understood by this release.
+// Specs without a version key predate the versioning and are version 1.
+const CurrentSpecVersion = 2
+
+type (
+	// SpecMigration upgrades agent specs to the next version of the specs schema
+	SpecMigration struct {
+		Version     int    // Version of the specs once migrated
+		Description string // What the migration changes, printed by the migrate-specs command
+		// Migrate edits the top-level mapping of the specs in place, and returns the findings that need
+		// manual attention because they cannot be migrated automatically
+		Migrate func(specs *yaml.Node) ([]string, error)
+	}
+
+	// SpecMigrationResult describes the migration of agent specs
+	SpecMigrationResult struct {
+		FromVersion int
+		ToVersion   int
+		Specs       string   // Migrated specs, the original specs when already up to date
+		Notes       []string // Findings that need manual attention
+	}
+)
+
+// SpecMigrations lists the migrations of the agent specs schema, in version order.
+// A change of the specs that existing specs must follow adds a migration and bumps CurrentSpecVersion.
+var SpecMigrations = []SpecMigration{
+	{
+		Version:     2,
+		Description: "Normalize the model provider and write the tool refs in their compact form",
+		Migrate:     migrateSpecsV2,
+	},
+}
+
+// Changed reports whether the specs were migrated to a newer version
+func (r SpecMigrationResult) Changed() bool {
+	return r.ToVersion != r.FromVersion
+}
+
+// ParseAgentSpecs parses the YAML specs of an agent, migrating the specs written for an older version in memory
+func ParseAgentSpecs(specs string) (*AgentSpecs, error) {
+	result, err := MigrateSpecs(specs)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &AgentSpecs{}
+	if err := yaml.Unmarshal([]byte(result.Specs), parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent specs: %w", err)
+	}
+	return parsed, nil
+}
+
+// MigrateSpecs upgrades YAML agent specs to CurrentSpecVersion, keeping their comments and key order.
+// Specs newer than CurrentSpecVersion are rejected, they may use fields this release does not understand.
+func MigrateSpecs(specs string) (SpecMigrationResult, error) {
+	result := SpecMigrationResult{FromVersion: 1, ToVersion: 1, Specs: specs}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(specs), &doc); err != nil {
+		return result, fmt.Errorf("failed to parse agent specs: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		// Empty specs, nothing to migrate
+		return result, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return result, fmt.Errorf("agent specs must be a mapping")
+	}
+
+	version, err := specVersion(root)
+	if err != nil {
+		return result, err
+	}
+	if version > CurrentSpecVersion {
+		return result, fmt.Errorf("agent specs version %d is newer than the supported version %d", version, CurrentSpecVersion)
+	}
+	result.FromVersion, result.ToVersion = version, version
+
+	for _, migration := range SpecMigrations {
+		if migration.Version <= version {
+			continue
+		}
+		notes, err := migration.Migrate(root)
+		if err != nil {
+			return result, fmt.Errorf("failed to migrate agent specs to version %d: %w", migration.Version, err)
+		}
+		setSpecVersion(root, migration.Version)
+		result.ToVersion = migration.Version
+		result.Notes = append(result.Notes, notes...)
+	}
+	if !result.Changed() {
+		return result, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return result, fmt.Errorf("failed to encode migrated agent specs: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return result, fmt.Errorf("failed to encode migrated agent specs: %w", err)
+	}
+	result.Specs = buf.String()
+	return result, nil
+}
+
+// specVersion returns the version of the specs, 1 when they have no version key
+func specVersion(root *yaml.Node) (int, error) {
+	node := mappingValue(root, "version")
+	if node == nil {
+		return 1, nil
+	}
+	version, err := strconv.Atoi(node.Value)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid agent specs version %q, must be a positive integer", node.Value)
+	}
+	return version, nil
+}
+
+// setSpecVersion writes the version of the specs, as their first key
+func setSpecVersion(root *yaml.Node, version int) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)}
+	if node := mappingValue(root, "version"); node != nil {
+		*node = *value
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	root.Content = append([]*yaml.Node{key, value}, root.Content...)
+}
+
+// mappingValue returns the value of a key of a mapping node, nil when the key is absent
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// migrateSpecsV2 lowercases the model provider, rewrites the tool refs given as mappings as "<tool_id>@<revision>",
+// and reports the keys unknown to the agent service and the thinking budgets the model would reject
+func migrateSpecsV2(root *yaml.Node) ([]string, error) {
+	notes := []string{}
+
+	known := make(map[string]bool)
+	for _, key := range specKeys() {
+		known[key] = true
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if key := root.Content[i].Value; !known[key] {
+			notes = append(notes, fmt.Sprintf("unknown key %q is ignored by the agent service, remove or rename it", key))
+		}
+	}
+
+	model := mappingValue(root, "model")
+	if provider := mappingValue(model, "provider"); provider != nil {
+		provider.Value = strings.ToLower(strings.TrimSpace(provider.Value))
+		switch db.ProviderModel(provider.Value) {
+		case db.ProviderModelAnthropic, db.ProviderModelBedrockAnthropic, db.ProviderModelBedrock, db.ProviderModelGoogle, db.ProviderModelOpenAI:
+		default:
+			notes = append(notes, fmt.Sprintf("unknown model provider %q", provider.Value))
+		}
+	}
+
+	thinking := mappingValue(model, "thinking")
+	if enabled := mappingValue(thinking, "enabled"); enabled != nil && enabled.Value == "true" {
+		budget, _ := strconv.ParseInt(nodeValue(mappingValue(thinking, "budget_token")), 10, 64)
+		maxTokens, _ := strconv.ParseInt(nodeValue(mappingValue(model, "max_tokens")), 10, 64)
+		if maxTokens > 0 && budget >= maxTokens {
+			notes = append(notes, fmt.Sprintf("thinking budget_token %d must be lower than max_tokens %d", budget, maxTokens))
+		}
+	}
+
+	if refs := mappingValue(root, "tool_refs"); refs != nil && refs.Kind == yaml.SequenceNode {
+		for i, item := range refs.Content {
+			var ref ToolRef
+			if err := item.Decode(&ref); err != nil {
+				notes = append(notes, fmt.Sprintf("tool_refs[%d] is not a valid tool reference: %v", i, err))
+				continue
+			}
+			refs.Content[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ref.String(), LineComment: item.LineComment}
+		}
+	}
+	return notes, nil
+}
+
+// specKeys returns the top-level keys of the agent specs
+func specKeys() []string {
+	t := reflect.TypeOf(AgentSpecs{})
+	keys := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// nodeValue returns the value of a scalar node, empty for a nil node
+func nodeValue(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+	return node.Value
+}
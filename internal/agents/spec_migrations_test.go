@@ -0,0 +1,71 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateSpecs(t *testing.T) {
+	toolID := uuid.MustParse("550e8400-c00b-8888-1111-446655447896")
+	specs := `model:
+  provider: " Anthropic "
+  model_id: "claude-sonnet-4"
+  max_tokens: 1024
+  thinking:
+    enabled: true
+    budget_token: 2048
+
+# The assistant persona
+system: |
+  You are a helpful assistant.
+
+tool_refs:
+  - id: 550e8400-c00b-8888-1111-446655447896
+    revision: 3 # Pinned until the new schema is tested
+guardrails: strict
+`
+
+	result, err := MigrateSpecs(specs)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.FromVersion)
+	assert.Equal(t, CurrentSpecVersion, result.ToVersion)
+	assert.True(t, result.Changed())
+	assert.Contains(t, result.Specs, "version: 2\n")
+	assert.Contains(t, result.Specs, `provider: "anthropic"`)
+	assert.Contains(t, result.Specs, "# The assistant persona")
+	assert.Contains(t, result.Specs, "- "+toolID.String()+"@3")
+	assert.ElementsMatch(t, []string{
+		`unknown key "guardrails" is ignored by the agent service, remove or rename it`,
+		"thinking budget_token 2048 must be lower than max_tokens 1024",
+	}, result.Notes)
+
+	parsed, err := ParseAgentSpecs(specs)
+	require.NoError(t, err)
+	assert.Equal(t, CurrentSpecVersion, parsed.Version)
+	assert.Equal(t, "anthropic", parsed.Model.Provider)
+	assert.Equal(t, []ToolRef{{ID: toolID, Revision: 3}}, parsed.ToolRefs)
+
+	// Migrated specs are up to date
+	again, err := MigrateSpecs(result.Specs)
+	require.NoError(t, err)
+	assert.False(t, again.Changed())
+	assert.Equal(t, result.Specs, again.Specs)
+}
+
+func TestMigrateSpecs_Versions(t *testing.T) {
+	empty, err := MigrateSpecs("")
+	require.NoError(t, err)
+	assert.False(t, empty.Changed())
+
+	_, err = MigrateSpecs("version: 99\nsystem: hello\n")
+	assert.ErrorContains(t, err, "newer than the supported version")
+
+	_, err = MigrateSpecs("version: latest\n")
+	assert.ErrorContains(t, err, "invalid agent specs version")
+
+	_, err = MigrateSpecs("- not\n- a mapping\n")
+	assert.ErrorContains(t, err, "must be a mapping")
+}
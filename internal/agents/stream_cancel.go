@@ -0,0 +1,67 @@
+package agents
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/service"
+)
+
+// taskStreams holds the cancellation of the model requests in flight of each task, to stop them when their task is
+// cancelled
+type taskStreams struct {
+	mu     sync.Mutex
+	byTask map[string]map[*context.CancelFunc]struct{}
+}
+
+func newTaskStreams() *taskStreams {
+	return &taskStreams{byTask: map[string]map[*context.CancelFunc]struct{}{}}
+}
+
+// start returns the context of a model request of a task, cancelled when the task is cancelled. The request ends it
+// with the returned function.
+func (s *taskStreams) start(parent context.Context, taskID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byTask[taskID] == nil {
+		s.byTask[taskID] = map[*context.CancelFunc]struct{}{}
+	}
+	s.byTask[taskID][&cancel] = struct{}{}
+	return ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.byTask[taskID], &cancel)
+		if len(s.byTask[taskID]) == 0 {
+			delete(s.byTask, taskID)
+		}
+		cancel()
+	}
+}
+
+// cancel cancels the model requests in flight of a task, it returns their number
+func (s *taskStreams) cancel(taskID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for cancel := range s.byTask[taskID] {
+		(*cancel)()
+	}
+	return len(s.byTask[taskID])
+}
+
+// streamCancelEventCallback stops the model requests of a cancelled task, every agent handler receives the event and
+// stops the requests it runs
+func (as *AgentService) streamCancelEventCallback(msg *nats.Msg) {
+	req, err := service.ParseEvent[*service.AgentStreamCancelEventMessage](msg.Data)
+	if err != nil {
+		as.log.Error("Failed to unmarshal message to request", "error", err)
+		return
+	}
+	if req.H.TaskID == nil {
+		return
+	}
+	if stopped := as.streams.cancel(*req.H.TaskID); stopped > 0 {
+		as.log.Info("Stopped the model requests of the cancelled task", "task_id", *req.H.TaskID, "requests", stopped)
+	}
+}
@@ -0,0 +1,28 @@
+package agents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskStreams(t *testing.T) {
+	streams := newTaskStreams()
+	first, endFirst := streams.start(context.Background(), "task-a")
+	second, endSecond := streams.start(context.Background(), "task-a")
+	other, endOther := streams.start(context.Background(), "task-b")
+	defer endOther()
+
+	// The requests of the cancelled task are stopped, the requests of the other tasks keep running
+	assert.Equal(t, 2, streams.cancel("task-a"))
+	assert.Error(t, first.Err())
+	assert.Error(t, second.Err())
+	assert.NoError(t, other.Err())
+
+	// The ended requests are forgotten
+	endFirst()
+	endSecond()
+	assert.Equal(t, 0, streams.cancel("task-a"))
+	assert.Equal(t, 1, streams.cancel("task-b"))
+}
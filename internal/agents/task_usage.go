@@ -0,0 +1,46 @@
+package agents
+
+import (
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// anthropicInputTokens returns the input tokens of a request, Anthropic reports the uncached, cache read and cache
+// write input tokens separately
+func anthropicInputTokens(usage anthropic.Usage) int64 {
+	return usage.InputTokens + usage.CacheReadInputTokens + usage.CacheCreationInputTokens
+}
+
+// recordTaskTokenUsage adds the tokens of a model request to the daily usage of the user checked against the quotas,
+// and attributes them to the flow run that created the task, for the chargeback reports of the flows. The requests of
+// the tasks not created by a flow run are only added to the daily usage.
+func (as *AgentService) recordTaskTokenUsage(header *service.EventHeaders, inputTokens, outputTokens int64) {
+	if header == nil || inputTokens+outputTokens <= 0 {
+		return
+	}
+	queries := db.New(as.s.GetDB())
+	if err := queries.AddDailyUsage(as.ctx, db.AddDailyUsageParams{
+		WorkspaceID:  header.Workspace(),
+		UserID:       header.UserID,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+	}); err != nil {
+		as.log.Warn("Failed to record daily token usage", "user_id", header.UserID, "error", err)
+	}
+	if header.TaskID == nil || *header.TaskID == "" {
+		return
+	}
+	recorded, err := queries.RecordTaskTokenUsage(as.ctx, db.RecordTaskTokenUsageParams{
+		TaskID:       *header.TaskID,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+	})
+	if err != nil {
+		as.log.Warn("Failed to record task token usage", "task_id", *header.TaskID, "error", err)
+		return
+	}
+	if recorded > 0 {
+		as.log.Debug("Recorded flow run token usage", "task_id", *header.TaskID, "input_tokens", inputTokens, "output_tokens", outputTokens)
+	}
+}
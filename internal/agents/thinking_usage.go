@@ -0,0 +1,52 @@
+package agents
+
+import (
+	"unicode/utf8"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+// charsPerThinkingToken approximates the tokenization of the thinking content when the provider does not report it
+const charsPerThinkingToken = 4
+
+// estimateThinkingTokens estimates the tokens of the thinking blocks of a response.
+// Redacted thinking is encrypted and cannot be counted.
+func estimateThinkingTokens(content []anthropic.ContentBlockParamUnion) int64 {
+	var chars int
+	for _, block := range content {
+		if block.OfThinking != nil {
+			chars += utf8.RuneCountInString(block.OfThinking.Thinking)
+		}
+	}
+	return int64((chars + charsPerThinkingToken - 1) / charsPerThinkingToken)
+}
+
+// recordThinkingUsage stores how much of the thinking budget a request consumed for the agent analytics.
+// The thinking tokens reported by the provider are used when available, otherwise they are estimated from the response.
+func (as *AgentService) recordThinkingUsage(agentID uuid.UUID, spec *AgentSpecs, response *anthropic.MessageParam, reportedTokens int64) {
+	budget := spec.Model.Thinking.BudgetToken
+	if agentID == uuid.Nil || !spec.Model.Thinking.Enabled || budget <= 0 || response == nil {
+		return
+	}
+	tokens, estimated := reportedTokens, false
+	if tokens <= 0 {
+		tokens, estimated = estimateThinkingTokens(response.Content), true
+	}
+	as.log.Debug("Thinking usage",
+		"agent_id", agentID,
+		"budget_tokens", budget,
+		"thinking_tokens", tokens,
+		"estimated", estimated,
+	)
+	err := db.New(as.s.GetDB()).RecordAgentThinkingUsage(as.ctx, db.RecordAgentThinkingUsageParams{
+		AgentID:        agentID,
+		BudgetTokens:   budget,
+		ThinkingTokens: tokens,
+		Estimated:      estimated,
+	})
+	if err != nil {
+		as.log.Warn("Failed to record thinking usage", "agent_id", agentID, "error", err)
+	}
+}
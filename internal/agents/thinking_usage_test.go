@@ -0,0 +1,20 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateThinkingTokens(t *testing.T) {
+	content := []anthropic.ContentBlockParamUnion{
+		anthropic.NewThinkingBlock("sig", "abcdefgh"),
+		anthropic.NewTextBlock("the answer is not thinking"),
+		anthropic.NewThinkingBlock("sig", "ijklm"),
+		anthropic.NewRedactedThinkingBlock("encrypted"),
+	}
+	// 13 characters of thinking, rounded up
+	assert.Equal(t, int64(4), estimateThinkingTokens(content))
+	assert.Equal(t, int64(0), estimateThinkingTokens(nil))
+}
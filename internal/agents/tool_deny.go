@@ -0,0 +1,100 @@
+package agents
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pinazu/internal/db"
+)
+
+// ToolDenyRule denies the agent the tools it matches, whether they are offered through the tool_refs,
+// the tool_tags or called in a batch. A rule matches a tool when every criterion it sets matches,
+// a rule without criteria matches no tool.
+type ToolDenyRule struct {
+	Name     string   `yaml:"name,omitempty"`     // Name of the tool, a trailing * matches a name prefix
+	Tags     []string `yaml:"tags,omitempty"`     // Tools labelled with any of these tags
+	Type     string   `yaml:"type,omitempty"`     // Tool type: standalone, workflow, mcp or internal
+	Category string   `yaml:"category,omitempty"` // Catalog category of the tool
+	Network  bool     `yaml:"network,omitempty"`  // Tools reaching the network, see ToolReachesNetwork
+	Reason   string   `yaml:"reason,omitempty"`   // Given to the agent with the error, the rule is described otherwise
+}
+
+// DeniedTool returns the first deny rule of the agent matching the tool, nil when the tool is allowed
+func (s *AgentSpecs) DeniedTool(tool db.Tool) *ToolDenyRule {
+	for i := range s.ToolDeny {
+		if s.ToolDeny[i].Matches(tool) {
+			return &s.ToolDeny[i]
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the rule denies the tool
+func (r ToolDenyRule) Matches(tool db.Tool) bool {
+	if r.Name == "" && len(r.Tags) == 0 && r.Type == "" && r.Category == "" && !r.Network {
+		return false
+	}
+	if r.Name != "" {
+		prefix, wildcard := strings.CutSuffix(r.Name, "*")
+		if tool.Name != r.Name && !(wildcard && strings.HasPrefix(tool.Name, prefix)) {
+			return false
+		}
+	}
+	if len(r.Tags) > 0 && !slices.ContainsFunc(tool.Tags, func(tag string) bool { return slices.Contains(r.Tags, tag) }) {
+		return false
+	}
+	if r.Type != "" && string(tool.Config.Type) != r.Type {
+		return false
+	}
+	if r.Category != "" && tool.Category.String != r.Category {
+		return false
+	}
+	if r.Network && !ToolReachesNetwork(tool) {
+		return false
+	}
+	return true
+}
+
+// String describes the rule, for the error given to the agent when the rule has no reason
+func (r ToolDenyRule) String() string {
+	var criteria []string
+	if r.Name != "" {
+		criteria = append(criteria, "named "+r.Name)
+	}
+	if len(r.Tags) > 0 {
+		criteria = append(criteria, "tagged "+strings.Join(r.Tags, " or "))
+	}
+	if r.Type != "" {
+		criteria = append(criteria, "of type "+r.Type)
+	}
+	if r.Category != "" {
+		criteria = append(criteria, "in category "+r.Category)
+	}
+	if r.Network {
+		criteria = append(criteria, "with network access")
+	}
+	return fmt.Sprintf("no tools %s", strings.Join(criteria, ", "))
+}
+
+// ToolReachesNetwork reports whether calling the tool reaches the network: the HTTP tools, the MCP servers
+// not run as a local process, the flows which can call anything, and the built-in web tools.
+// The code interpreter runs in a sandbox, the other internal tools run in the tools service.
+func ToolReachesNetwork(tool db.Tool) bool {
+	switch tool.BuiltinName() {
+	case "web_search", "fetch_url":
+		return true
+	case "":
+	default:
+		return false
+	}
+	switch tool.Config.Type {
+	case db.ToolTypeStandalone, db.ToolTypeWorkflow:
+		return true
+	case db.ToolTypeMCP:
+		mcp := tool.Config.GetMCP()
+		return mcp == nil || mcp.Protocol != db.MCPProtocolStdio
+	default:
+		return false
+	}
+}
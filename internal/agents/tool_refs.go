@@ -0,0 +1,207 @@
+package agents
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pinazu/internal/db"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseToolRef parses a tool reference written as "<tool_id>" or "<tool_id>@<revision>"
+func ParseToolRef(s string) (ToolRef, error) {
+	id, revision, pinned := strings.Cut(strings.TrimSpace(s), "@")
+	ref := ToolRef{}
+	var err error
+	if ref.ID, err = uuid.Parse(id); err != nil {
+		return ToolRef{}, fmt.Errorf("invalid tool id %q: %w", id, err)
+	}
+	if pinned {
+		r, err := strconv.ParseInt(revision, 10, 32)
+		if err != nil || r < 1 {
+			return ToolRef{}, fmt.Errorf("invalid revision %q for tool %s, must be a positive integer", revision, id)
+		}
+		ref.Revision = int32(r)
+	}
+	return ref, nil
+}
+
+func (r ToolRef) String() string {
+	if r.Revision == 0 {
+		return r.ID.String()
+	}
+	return fmt.Sprintf("%s@%d", r.ID, r.Revision)
+}
+
+func (r *ToolRef) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		ref, err := ParseToolRef(value.Value)
+		if err != nil {
+			return err
+		}
+		*r = ref
+		return nil
+	}
+	// Decode the mapping form without recursing into this method
+	type toolRef ToolRef
+	return value.Decode((*toolRef)(r))
+}
+
+func (r ToolRef) MarshalYAML() (any, error) {
+	return r.String(), nil
+}
+
+// PinnedToolRevisions returns the pinned revision of each tool pinned by the agent
+func (s *AgentSpecs) PinnedToolRevisions() map[uuid.UUID]int32 {
+	pins := make(map[uuid.UUID]int32)
+	for _, ref := range s.ToolRefs {
+		if ref.Revision > 0 {
+			pins[ref.ID] = ref.Revision
+		}
+	}
+	return pins
+}
+
+// OffersTool reports whether the agent offers the tool to the model, through its tool_refs or tool_tags
+func (s *AgentSpecs) OffersTool(tool db.Tool) bool {
+	for _, ref := range s.ToolRefs {
+		if ref.ID == tool.ID {
+			return true
+		}
+	}
+	for _, tag := range tool.Tags {
+		if slices.Contains(s.ToolTags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// modelToolNames returns the name given to the model for each tool, by tool ID.
+// The colliding names are namespaced, or fail the request when the agent sets strict_tool_names.
+func (as *AgentService) modelToolNames(tools []db.Tool, strict bool) (map[uuid.UUID]string, error) {
+	names, collisions := db.ModelToolNames(tools)
+	if len(collisions) == 0 {
+		return names, nil
+	}
+	descriptions := make([]string, len(collisions))
+	for i, collision := range collisions {
+		descriptions[i] = collision.String()
+	}
+	if strict {
+		return nil, fmt.Errorf("tool names collide: %s", strings.Join(descriptions, "; "))
+	}
+	as.log.Warn("Tool names collide, the colliding tools are namespaced", "collisions", descriptions)
+	return names, nil
+}
+
+// resolveToolRefs fetches the tools referenced by the agent, and the tools labelled with its tool tags, among the
+// tools of its workspace and the shared tools.
+// Pinned tools are served with the description and configuration of their pinned revision.
+// The tools last fetched are served while the database is unavailable.
+func (as *AgentService) resolveToolRefs(workspaceID uuid.UUID, toolRefs []ToolRef, toolTags []string) ([]db.Tool, error) {
+	refs := make([]string, 0, len(toolRefs)+len(toolTags))
+	for _, ref := range toolRefs {
+		refs = append(refs, ref.String())
+	}
+	for _, tag := range toolTags {
+		refs = append(refs, "tag:"+tag)
+	}
+	tools, loadedAt, err := as.toolsCache.Load(workspaceID.String()+":"+strings.Join(refs, ","), func() ([]db.Tool, error) {
+		return as.fetchToolRefs(workspaceID, toolRefs, toolTags)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !loadedAt.IsZero() {
+		as.log.Warn("Database unavailable, using the tools last fetched for the agent", "tool_refs", refs, "fetched_at", loadedAt)
+	}
+
+	// Tools failing their health check are not offered to the model
+	available := make([]db.Tool, 0, len(tools))
+	for _, tool := range tools {
+		switch tool.Status {
+		case db.ToolStatusUnreachable:
+			as.log.Warn("Tool is unreachable, will not use this tool", "tool_id", tool.ID, "tool_name", tool.Name, "error", tool.StatusMessage.String)
+			continue
+		case db.ToolStatusDegraded:
+			as.log.Warn("Tool is degraded, its calls may fail", "tool_id", tool.ID, "tool_name", tool.Name, "error", tool.StatusMessage.String)
+		}
+		available = append(available, tool)
+	}
+	return available, nil
+}
+
+// fetchToolRefs fetches the tools referenced by the agent from the database.
+// The tools labelled with a tool tag follow their promoted revision, unless they are also pinned in the tool refs.
+func (as *AgentService) fetchToolRefs(workspaceID uuid.UUID, toolRefs []ToolRef, toolTags []string) ([]db.Tool, error) {
+	queries := db.New(as.s.GetDB())
+	ids := make([]uuid.UUID, 0, len(toolRefs))
+	pins := make(map[uuid.UUID]int32)
+	for _, ref := range toolRefs {
+		ids = append(ids, ref.ID)
+		if ref.Revision > 0 {
+			pins[ref.ID] = ref.Revision
+		}
+	}
+
+	tools, err := queries.GetToolsByIDs(as.ctx, db.GetToolsByIDsParams{Ids: ids, WorkspaceID: workspaceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tools from database: %w", err)
+	}
+
+	// Check if any tools were not found and log warnings
+	if len(tools) < len(toolRefs) {
+		foundToolIDs := make(map[uuid.UUID]bool)
+		for _, tool := range tools {
+			foundToolIDs[tool.ID] = true
+		}
+
+		for _, toolRef := range toolRefs {
+			if !foundToolIDs[toolRef.ID] {
+				as.log.Warn("Tool not found in database, will not use this tool", "tool_id", toolRef.ID)
+			}
+		}
+	}
+
+	resolved := make([]db.Tool, 0, len(tools))
+	for _, tool := range tools {
+		revision, ok := pins[tool.ID]
+		if !ok || revision == tool.Revision {
+			resolved = append(resolved, tool)
+			continue
+		}
+		r, err := queries.GetToolRevision(as.ctx, db.GetToolRevisionParams{ToolID: tool.ID, Revision: revision})
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				// Falling back to another revision could change the schema the agent was built against
+				as.log.Warn("Pinned tool revision not found, will not use this tool", "tool_id", tool.ID, "revision", revision)
+				continue
+			}
+			return nil, fmt.Errorf("failed to fetch revision %d of tool %s: %w", revision, tool.ID, err)
+		}
+		resolved = append(resolved, tool.ApplyRevision(r))
+	}
+
+	if len(toolTags) == 0 {
+		return resolved, nil
+	}
+	tagged, err := queries.ListToolsByTags(as.ctx, db.ListToolsByTagsParams{Tags: toolTags, WorkspaceID: workspaceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tools by tags from database: %w", err)
+	}
+	referenced := make(map[uuid.UUID]bool, len(toolRefs))
+	for _, ref := range toolRefs {
+		referenced[ref.ID] = true
+	}
+	for _, tool := range tagged {
+		if !referenced[tool.ID] {
+			resolved = append(resolved, tool)
+		}
+	}
+	return resolved, nil
+}
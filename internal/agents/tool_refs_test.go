@@ -0,0 +1,68 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestToolRefYAML(t *testing.T) {
+	first := uuid.MustParse("550e8400-c00b-8888-4444-446655447896")
+	second := uuid.MustParse("550e8400-c00b-8888-4444-446655447897")
+	third := uuid.MustParse("550e8400-c00b-8888-4444-446655447898")
+
+	specs := &AgentSpecs{}
+	err := yaml.Unmarshal([]byte(`
+tool_refs:
+  - 550e8400-c00b-8888-4444-446655447896
+  - 550e8400-c00b-8888-4444-446655447897@3
+  - id: 550e8400-c00b-8888-4444-446655447898
+    revision: 2
+`), specs)
+	require.NoError(t, err)
+	assert.Equal(t, []ToolRef{{ID: first}, {ID: second, Revision: 3}, {ID: third, Revision: 2}}, specs.ToolRefs)
+	assert.Equal(t, map[uuid.UUID]int32{second: 3, third: 2}, specs.PinnedToolRevisions())
+
+	out, err := yaml.Marshal(specs.ToolRefs)
+	require.NoError(t, err)
+	assert.Equal(t, "- 550e8400-c00b-8888-4444-446655447896\n- 550e8400-c00b-8888-4444-446655447897@3\n- 550e8400-c00b-8888-4444-446655447898@2\n", string(out))
+
+	for _, invalid := range []string{"not-a-uuid", "550e8400-c00b-8888-4444-446655447896@0", "550e8400-c00b-8888-4444-446655447896@latest"} {
+		_, err := ParseToolRef(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestToolDeny(t *testing.T) {
+	specs := &AgentSpecs{}
+	err := yaml.Unmarshal([]byte(`
+tool_deny:
+  - network: true
+    reason: this agent works offline
+  - tags: [finance]
+  - name: crm_*
+    type: mcp
+  - {}
+`), specs)
+	require.NoError(t, err)
+	require.Len(t, specs.ToolDeny, 4)
+
+	standalone := db.Tool{Name: "weather", Config: db.ToolConfig{Type: db.ToolTypeStandalone, C: &db.ToolConfigStandalone{}}}
+	stdio := db.Tool{Name: "crm_local", Config: db.ToolConfig{Type: db.ToolTypeMCP, C: &db.ToolConfigMCP{Protocol: db.MCPProtocolStdio}}}
+	ledger := db.Tool{Name: "ledger", Tags: []string{"finance"}, Config: db.ToolConfig{Type: db.ToolTypeInternal, C: &db.ToolConfigInternal{}}}
+	fetchURL := db.Tool{ID: db.BuiltinToolIDs["fetch_url"], Name: "fetch_url", Config: db.ToolConfig{Type: db.ToolTypeInternal, C: &db.ToolConfigInternal{}}}
+	allowed := db.Tool{Name: "calculator", Config: db.ToolConfig{Type: db.ToolTypeInternal, C: &db.ToolConfigInternal{}}}
+
+	assert.Equal(t, &specs.ToolDeny[0], specs.DeniedTool(standalone))
+	assert.Equal(t, &specs.ToolDeny[0], specs.DeniedTool(fetchURL))
+	assert.Equal(t, &specs.ToolDeny[1], specs.DeniedTool(ledger))
+	assert.Equal(t, &specs.ToolDeny[2], specs.DeniedTool(stdio))
+	assert.Nil(t, specs.DeniedTool(allowed), "a rule without criteria matches no tool")
+
+	assert.Equal(t, "no tools with network access", specs.ToolDeny[0].String())
+	assert.Equal(t, "no tools named crm_*, of type mcp", specs.ToolDeny[2].String())
+}
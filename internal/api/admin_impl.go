@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pinazu/internal/service"
+)
+
+// List parked messages
+// (GET /v1/admin/dead-letters)
+func (s *Server) ListDeadLetters(ctx context.Context, request ListDeadLettersRequestObject) (ListDeadLettersResponseObject, error) {
+	var perPage int32 = 10
+	var page int32 = 1
+	if request.Params.PerPage != nil {
+		perPage = *request.Params.PerPage
+	}
+	if request.Params.Page != nil {
+		page = *request.Params.Page
+	}
+
+	js, err := service.NewJetStreamService(ctx, s.nc, s.log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream service: %w", err)
+	}
+	parked, total, err := js.ListParkedMessages(int((page-1)*perPage), int(perPage))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parked messages: %w", err)
+	}
+
+	messages := make([]ParkedMessage, 0, len(parked))
+	for _, p := range parked {
+		messages = append(messages, toParkedMessage(p))
+	}
+	return ListDeadLetters200JSONResponse(DeadLetterList{
+		Messages:   messages,
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: (total + int(perPage) - 1) / int(perPage),
+	}), nil
+}
+
+// toParkedMessage converts a parked message of the dead letter stream to its API form
+func toParkedMessage(p *service.ParkedMessage) ParkedMessage {
+	var payload any = string(p.Data)
+	if json.Valid(p.Data) {
+		payload = json.RawMessage(p.Data)
+	}
+	return ParkedMessage{
+		Sequence:       int64(p.Sequence),
+		Stream:         p.Stream,
+		StreamSequence: int64(p.StreamSequence),
+		Subject:        p.Subject,
+		Consumer:       p.Consumer,
+		Redeliveries:   p.Redeliveries,
+		Consumers:      p.Consumers,
+		Reason:         p.Reason,
+		ParkedAt:       p.ParkedAt,
+		Payload:        payload,
+	}
+}
+
+// List the running services
+// (GET /v1/admin/services)
+func (s *Server) ListServices(ctx context.Context, request ListServicesRequestObject) (ListServicesResponseObject, error) {
+	timeoutMs := 500
+	if request.Params.TimeoutMs != nil {
+		timeoutMs = *request.Params.TimeoutMs
+	}
+	if timeoutMs < 50 || timeoutMs > 10000 {
+		return ListServices400JSONResponse{Message: "timeout_ms must be between 50 and 10000"}, nil
+	}
+
+	statuses, err := service.CollectServices(ctx, s.nc, time.Duration(timeoutMs)*time.Millisecond)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect the services: %w", err)
+	}
+
+	overview := ServiceOverview{
+		CollectedAt: time.Now().UTC(),
+		Services:    []ServiceSummary{},
+		Instances:   make([]ServiceInstance, 0, len(statuses)),
+	}
+	for _, summary := range service.SummarizeServices(statuses) {
+		overview.Services = append(overview.Services, ServiceSummary{
+			Name:        summary.Name,
+			Instances:   summary.Instances,
+			NumMessages: int64(summary.NumMessages),
+			NumErrors:   int64(summary.NumErrors),
+		})
+	}
+	for _, status := range statuses {
+		overview.Instances = append(overview.Instances, toServiceInstance(status))
+	}
+	return ListServices200JSONResponse(overview), nil
+}
+
+// toServiceInstance converts the status of a service instance to its API form
+func toServiceInstance(status service.ServiceStatus) ServiceInstance {
+	instance := ServiceInstance{
+		Name:          status.Name,
+		Id:            status.ID,
+		Version:       status.Version,
+		Description:   status.Description,
+		UptimeSeconds: int64(status.Uptime / time.Second),
+		NumMessages:   int64(status.NumMessages),
+		NumErrors:     int64(status.NumErrors),
+		Subscriptions: make([]ServiceSubscription, 0, len(status.Subscriptions)),
+	}
+	if !status.Started.IsZero() {
+		instance.Started = &status.Started
+	}
+	for _, sub := range status.Subscriptions {
+		subscription := ServiceSubscription{
+			Subject:     sub.Subject,
+			NumMessages: int64(sub.NumMessages),
+			NumErrors:   int64(sub.NumErrors),
+		}
+		if sub.LastError != "" {
+			subscription.LastError = &sub.LastError
+		}
+		instance.Subscriptions = append(instance.Subscriptions, subscription)
+	}
+	return instance
+}
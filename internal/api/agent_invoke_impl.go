@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/nats-io/nats.go"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+// agentInvokeTimeout bounds the wait for the message of an agent invoked without a task
+const agentInvokeTimeout = 5 * time.Minute
+
+// Invoke an agent
+// (POST /v1/agents/{agent_id}/invoke)
+func (s *Server) InvokeAgent(ctx context.Context, request InvokeAgentRequestObject) (InvokeAgentResponseObject, error) {
+	if request.Body == nil || len(request.Body.Messages) == 0 {
+		return InvokeAgent400JSONResponse{Message: "messages are required"}, nil
+	}
+	for i, message := range request.Body.Messages {
+		if !json.Valid(message) {
+			return InvokeAgent400JSONResponse{Message: fmt.Sprintf("message %d is not valid JSON", i)}, nil
+		}
+	}
+
+	_, access, err := s.agentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return InvokeAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return InvokeAgent403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+	exceeded, err := s.checkQuotas(ctx, db.QuotaTokensPerDay)
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return InvokeAgent429JSONResponse(*exceeded), nil
+	}
+
+	// The streaming events of the model are sent to the user with a connection of their own, no websocket receives them
+	userID := custom_middleware.RequestUserID(ctx)
+	connectionID := uuid.New()
+	event := &service.Event[*service.AgentInvokeRequestEventMessage]{
+		H: &service.EventHeaders{
+			UserID:       userID,
+			WorkspaceID:  custom_middleware.RequestWorkspaceID(ctx),
+			ConnectionID: &connectionID,
+		},
+		Msg: &service.AgentInvokeRequestEventMessage{
+			AgentId:  request.AgentId,
+			Messages: request.Body.Messages,
+		},
+		M: &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	headers := InvokeAgent200ResponseHeaders{CacheControl: "no-cache", Connection: "keep-alive"}
+
+	if request.Params.Stream == nil || !*request.Params.Stream {
+		resp, err := service.Request[*service.AgentInvokeResponseEventMessage](s.nc, event, agentInvokeTimeout)
+		if err != nil {
+			s.log.Error("Failed to invoke agent", "agent_id", request.AgentId, "error", err)
+			return nil, fmt.Errorf("failed to invoke agent: %w", err)
+		}
+		return InvokeAgent200JSONResponse{Body: invokeAgentResponse(request.AgentId, resp.Msg), Headers: headers}, nil
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	// Unblock the writes of the stream once the client is gone
+	context.AfterFunc(ctx, func() { pipeReader.CloseWithError(ctx.Err()) })
+	if err := s.streamAgentInvoke(ctx, event, pipeWriter); err != nil {
+		return nil, err
+	}
+	return InvokeAgent200TexteventStreamResponse{Body: pipeReader, Headers: headers}, nil
+}
+
+// invokeAgentResponse returns the body of the response of an agent invoke
+func invokeAgentResponse(agentID uuid.UUID, msg *service.AgentInvokeResponseEventMessage) InvokeAgentResponse {
+	response := InvokeAgentResponse{AgentId: agentID, Message: msg.Message}
+	if msg.StopReason != "" {
+		response.StopReason = &msg.StopReason
+	}
+	return response
+}
+
+// streamAgentInvoke sends an agent invoke request and writes the streaming events of the model to w as Server-Sent
+// Events until the agent answers, then writes the answer as an agent_invoke_result event, or an error event, and
+// closes w. Only the events of the connection of the request headers are written.
+func (s *Server) streamAgentInvoke(ctx context.Context, event *service.Event[*service.AgentInvokeRequestEventMessage], w io.WriteCloser) error {
+	userID, connectionID := event.H.UserID, *event.H.ConnectionID
+	events := make(chan *nats.Msg, 100)
+	sub, err := s.nc.ChanSubscribe((&service.WebsocketResponseEventMessage{}).SubjectWithUser(userID).String(), events)
+	if err != nil {
+		s.log.Error("Failed to subscribe to response channel", "user_id", userID, "error", err)
+		w.Close()
+		return fmt.Errorf("failed to get response streaming from model: %w", err)
+	}
+
+	type result struct {
+		resp *service.Event[*service.AgentInvokeResponseEventMessage]
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		resp, err := service.Request[*service.AgentInvokeResponseEventMessage](s.nc, event, agentInvokeTimeout)
+		results <- result{resp: resp, err: err}
+	}()
+
+	go func() {
+		defer func() {
+			if err := sub.Unsubscribe(); err != nil {
+				s.log.Error("Failed to unsubscribe", "user_id", userID, "error", err)
+			}
+			w.Close()
+		}()
+
+		write := func(data any) bool {
+			eventData, err := json.Marshal(data)
+			if err != nil {
+				s.log.Error("Failed to marshal response event", "error", err)
+				return true
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", eventData); err != nil {
+				s.log.Error("Failed to write SSE event", "error", err)
+				return false
+			}
+			return true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.log.Debug("Context cancelled, stopping agent invoke stream", "user_id", userID)
+				return
+			case msg := <-events:
+				response, err := service.ParseEvent[*service.WebsocketResponseEventMessage](msg.Data)
+				if response == nil || response.H == nil || response.H.ConnectionID == nil || *response.H.ConnectionID != connectionID {
+					continue
+				}
+				if err != nil {
+					continue // The agent answers the request with the error
+				}
+				if !write(response.Msg) {
+					return
+				}
+			case result := <-results:
+				if result.err != nil {
+					s.log.Error("Failed to invoke agent", "agent_id", event.Msg.AgentId, "error", result.err)
+					write(map[string]string{"type": "error", "error": result.err.Error()})
+					return
+				}
+				write(struct {
+					Type string `json:"type"`
+					InvokeAgentResponse
+				}{Type: "agent_invoke_result", InvokeAgentResponse: invokeAgentResponse(event.Msg.AgentId, result.resp.Msg)})
+				return
+			}
+		}
+	}()
+	return nil
+}
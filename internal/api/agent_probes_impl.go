@@ -0,0 +1,276 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/jackc/pgx/v5"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/db"
+)
+
+const AGENT_PROBE_RESOURCE = "AgentProbe"
+
+const (
+	defaultProbeIntervalSeconds = 300
+	defaultProbeTimeoutSeconds  = 120
+	minProbeIntervalSeconds     = 10
+)
+
+// List agent probes
+// (GET /v1/agents/{agent_id}/probes)
+func (s *Server) ListAgentProbes(ctx context.Context, request ListAgentProbesRequestObject) (ListAgentProbesResponseObject, error) {
+	if _, _, err := s.agentAccess(ctx, request.AgentId); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListAgentProbes404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	probes, err := s.queries.ListAgentProbes(ctx, request.AgentId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent probes: %w", err)
+	}
+	return ListAgentProbes200JSONResponse{Probes: probes}, nil
+}
+
+// Create agent probe
+// (POST /v1/agents/{agent_id}/probes)
+func (s *Server) CreateAgentProbe(ctx context.Context, request CreateAgentProbeRequestObject) (CreateAgentProbeResponseObject, error) {
+	createdBy := custom_middleware.RequestUserID(ctx)
+
+	params := db.CreateAgentProbeParams{
+		AgentID:         request.AgentId,
+		Name:            request.Body.Name,
+		Prompt:          request.Body.Prompt,
+		ExpectedPattern: request.Body.ExpectedPattern,
+		IntervalSeconds: defaultProbeIntervalSeconds,
+		TimeoutSeconds:  defaultProbeTimeoutSeconds,
+		Enabled:         true,
+		CreatedBy:       createdBy,
+	}
+	if request.Body.IntervalSeconds != nil {
+		params.IntervalSeconds = *request.Body.IntervalSeconds
+	}
+	if request.Body.TimeoutSeconds != nil {
+		params.TimeoutSeconds = *request.Body.TimeoutSeconds
+	}
+	if request.Body.Enabled != nil {
+		params.Enabled = *request.Body.Enabled
+	}
+	if request.Body.DryRun != nil {
+		params.DryRun = *request.Body.DryRun
+	}
+	if msg := validateAgentProbe(params.Name, params.Prompt, params.ExpectedPattern, params.IntervalSeconds, params.TimeoutSeconds); msg != "" {
+		return CreateAgentProbe400JSONResponse{Message: msg}, nil
+	}
+
+	_, access, err := s.agentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return CreateAgentProbe404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	// A probe runs the agent on behalf of its creator
+	if !access.Allows(db.GrantAccessInvoke) {
+		return CreateAgentProbe403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+
+	probe, err := s.queries.CreateAgentProbe(ctx, params)
+	if err != nil {
+		if db.IsConflictError(err) {
+			return CreateAgentProbe409JSONResponse{Message: fmt.Sprintf("Probe %s already exists for this agent", params.Name), Resource: AGENT_PROBE_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, fmt.Errorf("failed to create agent probe: %w", err)
+	}
+	return CreateAgentProbe201JSONResponse(probe), nil
+}
+
+// Get agent probe
+// (GET /v1/agents/{agent_id}/probes/{probe_id})
+func (s *Server) GetAgentProbe(ctx context.Context, request GetAgentProbeRequestObject) (GetAgentProbeResponseObject, error) {
+	if _, _, err := s.agentAccess(ctx, request.AgentId); err != nil {
+		if err == pgx.ErrNoRows {
+			return GetAgentProbe404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	probe, err := s.queries.GetAgentProbe(ctx, db.GetAgentProbeParams{ID: request.ProbeId, AgentID: request.AgentId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return GetAgentProbe404JSONResponse{Message: "Probe not found", Resource: AGENT_PROBE_RESOURCE, Id: request.ProbeId}, nil
+		}
+		return nil, err
+	}
+	return GetAgentProbe200JSONResponse(probe), nil
+}
+
+// Update agent probe
+// (PUT /v1/agents/{agent_id}/probes/{probe_id})
+func (s *Server) UpdateAgentProbe(ctx context.Context, request UpdateAgentProbeRequestObject) (UpdateAgentProbeResponseObject, error) {
+	_, access, err := s.agentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return UpdateAgentProbe404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	probe, err := s.queries.GetAgentProbe(ctx, db.GetAgentProbeParams{ID: request.ProbeId, AgentID: request.AgentId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return UpdateAgentProbe404JSONResponse{Message: "Probe not found", Resource: AGENT_PROBE_RESOURCE, Id: request.ProbeId}, nil
+		}
+		return nil, err
+	}
+	if required := probeChangeAccess(ctx, probe); !access.Allows(required) {
+		return UpdateAgentProbe403JSONResponse{Message: accessMessage(AGENT_RESOURCE, required)}, nil
+	}
+
+	params := db.UpdateAgentProbeParams{
+		ID:              probe.ID,
+		AgentID:         probe.AgentID,
+		Name:            probe.Name,
+		Prompt:          probe.Prompt,
+		ExpectedPattern: probe.ExpectedPattern,
+		IntervalSeconds: probe.IntervalSeconds,
+		TimeoutSeconds:  probe.TimeoutSeconds,
+		Enabled:         probe.Enabled,
+		DryRun:          probe.DryRun,
+	}
+	if request.Body.Name != nil {
+		params.Name = *request.Body.Name
+	}
+	if request.Body.Prompt != nil {
+		params.Prompt = *request.Body.Prompt
+	}
+	if request.Body.ExpectedPattern != nil {
+		params.ExpectedPattern = *request.Body.ExpectedPattern
+	}
+	if request.Body.IntervalSeconds != nil {
+		params.IntervalSeconds = *request.Body.IntervalSeconds
+	}
+	if request.Body.TimeoutSeconds != nil {
+		params.TimeoutSeconds = *request.Body.TimeoutSeconds
+	}
+	if request.Body.Enabled != nil {
+		params.Enabled = *request.Body.Enabled
+	}
+	if request.Body.DryRun != nil {
+		params.DryRun = *request.Body.DryRun
+	}
+	if msg := validateAgentProbe(params.Name, params.Prompt, params.ExpectedPattern, params.IntervalSeconds, params.TimeoutSeconds); msg != "" {
+		return UpdateAgentProbe400JSONResponse{Message: msg}, nil
+	}
+
+	probe, err = s.queries.UpdateAgentProbe(ctx, params)
+	if err != nil {
+		if db.IsConflictError(err) {
+			return UpdateAgentProbe409JSONResponse{Message: fmt.Sprintf("Probe %s already exists for this agent", params.Name), Resource: AGENT_PROBE_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, fmt.Errorf("failed to update agent probe: %w", err)
+	}
+	return UpdateAgentProbe200JSONResponse(probe), nil
+}
+
+// Delete agent probe
+// (DELETE /v1/agents/{agent_id}/probes/{probe_id})
+func (s *Server) DeleteAgentProbe(ctx context.Context, request DeleteAgentProbeRequestObject) (DeleteAgentProbeResponseObject, error) {
+	_, access, err := s.agentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return DeleteAgentProbe404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	params := db.GetAgentProbeParams{ID: request.ProbeId, AgentID: request.AgentId}
+	probe, err := s.queries.GetAgentProbe(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return DeleteAgentProbe404JSONResponse{Message: "Probe not found", Resource: AGENT_PROBE_RESOURCE, Id: request.ProbeId}, nil
+		}
+		return nil, err
+	}
+	if required := probeChangeAccess(ctx, probe); !access.Allows(required) {
+		return DeleteAgentProbe403JSONResponse{Message: accessMessage(AGENT_RESOURCE, required)}, nil
+	}
+	if err := s.queries.DeleteAgentProbe(ctx, db.DeleteAgentProbeParams(params)); err != nil {
+		return nil, fmt.Errorf("failed to delete agent probe: %w", err)
+	}
+	return DeleteAgentProbe204Response{}, nil
+}
+
+// List agent probe runs
+// (GET /v1/agents/{agent_id}/probes/{probe_id}/runs)
+func (s *Server) ListAgentProbeRuns(ctx context.Context, request ListAgentProbeRunsRequestObject) (ListAgentProbeRunsResponseObject, error) {
+	if _, _, err := s.agentAccess(ctx, request.AgentId); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListAgentProbeRuns404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	if _, err := s.queries.GetAgentProbe(ctx, db.GetAgentProbeParams{ID: request.ProbeId, AgentID: request.AgentId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListAgentProbeRuns404JSONResponse{Message: "Probe not found", Resource: AGENT_PROBE_RESOURCE, Id: request.ProbeId}, nil
+		}
+		return nil, err
+	}
+
+	params := db.ListAgentProbeRunsParams{ProbeID: request.ProbeId, Limit: 10}
+	var page int32 = 1
+	if request.Params.PerPage != nil {
+		params.Limit = *request.Params.PerPage
+	}
+	if request.Params.Page != nil {
+		page = *request.Params.Page
+	}
+	params.Offset = (page - 1) * params.Limit
+
+	total, err := s.queries.CountAgentProbeRuns(ctx, request.ProbeId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count agent probe runs: %w", err)
+	}
+	runs, err := s.queries.ListAgentProbeRuns(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent probe runs: %w", err)
+	}
+
+	return ListAgentProbeRuns200JSONResponse{
+		Runs:       runs,
+		Page:       page,
+		PerPage:    params.Limit,
+		Total:      int(total),
+		TotalPages: (int(total) + int(params.Limit) - 1) / int(params.Limit),
+	}, nil
+}
+
+// probeChangeAccess returns the access on the agent required to change a probe, a probe runs the agent on behalf of
+// its creator so its creator needs to invoke the agent and the other users need to manage it
+func probeChangeAccess(ctx context.Context, probe db.AgentProbe) db.GrantAccess {
+	if probe.CreatedBy == custom_middleware.RequestUserID(ctx) {
+		return db.GrantAccessInvoke
+	}
+	return db.GrantAccessManage
+}
+
+// validateAgentProbe returns why the probe settings are invalid, empty when they are valid
+func validateAgentProbe(name, prompt, expectedPattern string, intervalSeconds, timeoutSeconds int32) string {
+	switch {
+	case name == "":
+		return "name is required"
+	case len(name) > 255:
+		return "name must be less than 255 characters"
+	case prompt == "":
+		return "prompt is required"
+	case expectedPattern == "":
+		return "expected_pattern is required"
+	case intervalSeconds < minProbeIntervalSeconds:
+		return fmt.Sprintf("interval_seconds must be at least %d", minProbeIntervalSeconds)
+	case timeoutSeconds < 1:
+		return "timeout_seconds must be at least 1"
+	}
+	if _, err := regexp.Compile(expectedPattern); err != nil {
+		return fmt.Sprintf("invalid expected_pattern: %s", err)
+	}
+	return ""
+}
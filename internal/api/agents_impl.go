@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	db "github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
 )
 
 const AGENT_RESOURCE = "Agent"
@@ -16,11 +19,44 @@ const AGENT_RESOURCE = "Agent"
 // List all agents
 // (GET /v1/agents)
 func (s *Server) ListAgents(ctx context.Context, request ListAgentsRequestObject) (ListAgentsResponseObject, error) {
-	agents, err := s.queries.GetAgents(ctx)
+	limit, cursor, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return ListAgents400JSONResponse{Message: err.Error()}, nil
+	}
+	deleted := listTrash(request.Params.Deleted)
+	params := db.ListAgentsParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		Deleted:     deleted,
+		UserID:      custom_middleware.RequestUserID(ctx),
+		CursorName:  cursor.textKey(),
+		RowLimit:    limit + 1,
+	}
+	if params.CursorID, err = cursor.uuidID(); err != nil {
+		return ListAgents400JSONResponse{Message: err.Error()}, nil
+	}
+	if cursor != nil && cursor.Text == nil {
+		return ListAgents400JSONResponse{Message: errInvalidCursor.Error()}, nil
+	}
+
+	agents, err := s.queries.ListAgents(ctx, params)
 	if err != nil {
 		return nil, err
 	}
-	return ListAgents200JSONResponse(AgentList{Agents: agents}), nil
+	total, err := s.queries.CountAgents(ctx, db.CountAgentsParams{WorkspaceID: params.WorkspaceID, Deleted: deleted, UserID: params.UserID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count agents: %w", err)
+	}
+
+	agents, hasMore, next := pageRows(agents, limit, func(agent db.Agent) pageCursor {
+		return pageCursor{Text: &agent.Name, ID: agent.ID.String()}
+	})
+	return ListAgents200JSONResponse(AgentList{
+		Agents:     agents,
+		Limit:      limit,
+		HasMore:    hasMore,
+		NextCursor: next,
+		Total:      int(total),
+	}), nil
 }
 
 // Create a new agent
@@ -28,12 +64,7 @@ func (s *Server) ListAgents(ctx context.Context, request ListAgentsRequestObject
 func (s *Server) CreateAgent(ctx context.Context, request CreateAgentRequestObject) (CreateAgentResponseObject, error) {
 	now := time.Now()
 
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	// Parse string to UUID
-	createdBy, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
-	}
+	createdBy := custom_middleware.RequestUserID(ctx)
 	// Required Agent Name
 	if request.Body.Name == "" {
 		return CreateAgent400JSONResponse{Message: "agent_name is required"}, nil
@@ -51,6 +82,7 @@ func (s *Server) CreateAgent(ctx context.Context, request CreateAgentRequestObje
 		CreatedBy:   createdBy,
 		CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
 		UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
 	}
 
 	// Set optional fields if provided
@@ -65,6 +97,12 @@ func (s *Server) CreateAgent(ctx context.Context, request CreateAgentRequestObje
 	if err != nil {
 		return nil, err
 	}
+	s.recordResourceChange(ctx, db.ResourceTypeAgent, agent.ID, db.ResourceChangeActionCreate, nil, agent)
+
+	// Upload the system prompt and tools of the agent to the provider prompt cache ahead of the first request
+	if agent.Specs.Valid {
+		s.publishAgentCacheWarmup(agent)
+	}
 
 	return CreateAgent201JSONResponse(agent), nil
 }
@@ -74,25 +112,87 @@ func (s *Server) CreateAgent(ctx context.Context, request CreateAgentRequestObje
 func (s *Server) DeleteAgent(ctx context.Context, request DeleteAgentRequestObject) (DeleteAgentResponseObject, error) {
 
 	// Check if agent exists
-	_, err := s.queries.GetAgentByID(ctx, request.AgentId)
+	agent, access, err := s.agentAccess(ctx, request.AgentId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return DeleteAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
 		}
 		return nil, err
 	}
-	err = s.queries.DeleteAgent(ctx, request.AgentId)
+	if !access.Allows(db.GrantAccessManage) {
+		return DeleteAgent403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	deleted, err := s.queries.SoftDeleteAgent(ctx, db.SoftDeleteAgentParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.AgentId})
 	if err != nil {
 		return nil, err
 	}
+	if deleted == 0 {
+		return DeleteAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeAgent, agent.ID, db.ResourceChangeActionDelete, agent, nil)
 
 	return DeleteAgent204Response{}, nil
 }
 
+// Restore agent
+// (POST /v1/agents/{agent_id}/restore)
+func (s *Server) RestoreAgent(ctx context.Context, request RestoreAgentRequestObject) (RestoreAgentResponseObject, error) {
+	access, err := s.trashedAgentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RestoreAgent404JSONResponse{Message: "Agent not found in the trash", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return RestoreAgent403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	agent, err := s.queries.RestoreAgent(ctx, db.RestoreAgentParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.AgentId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RestoreAgent404JSONResponse{Message: "Agent not found in the trash", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		if db.IsConflictError(err) {
+			return RestoreAgent409JSONResponse{Message: "Another agent took the name of the agent", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeAgent, agent.ID, db.ResourceChangeActionRestore, nil, agent)
+
+	return RestoreAgent200JSONResponse(agent), nil
+}
+
+// Purge agent
+// (POST /v1/agents/{agent_id}/purge)
+func (s *Server) PurgeAgent(ctx context.Context, request PurgeAgentRequestObject) (PurgeAgentResponseObject, error) {
+	access, err := s.trashedAgentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return PurgeAgent404JSONResponse{Message: "Agent not found in the trash", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return PurgeAgent403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	purged, err := s.queries.PurgeAgent(ctx, db.PurgeAgentParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.AgentId})
+	if err != nil {
+		return nil, err
+	}
+	if purged == 0 {
+		return PurgeAgent404JSONResponse{Message: "Agent not found in the trash", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+	}
+	if err := s.queries.DeleteResourceGrants(ctx, db.DeleteResourceGrantsParams{ResourceType: db.ResourceTypeAgent, ResourceID: request.AgentId}); err != nil {
+		return nil, fmt.Errorf("failed to delete agent grants: %w", err)
+	}
+
+	return PurgeAgent204Response{}, nil
+}
+
 // Get agent by ID
 // (GET /v1/agents/{agent_id})
 func (s *Server) GetAgent(ctx context.Context, request GetAgentRequestObject) (GetAgentResponseObject, error) {
-	agent, err := s.queries.GetAgentByID(ctx, request.AgentId)
+	agent, _, err := s.agentAccess(ctx, request.AgentId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return GetAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
@@ -100,20 +200,27 @@ func (s *Server) GetAgent(ctx context.Context, request GetAgentRequestObject) (G
 		return nil, err
 	}
 
-	return GetAgent200JSONResponse(agent), nil
+	return GetAgent200JSONResponse{Body: agent, Headers: GetAgent200ResponseHeaders{ETag: db.ETag(agent.UpdatedAt)}}, nil
 }
 
 // Update agent
 // (PUT /v1/agents/{agent_id})
 func (s *Server) UpdateAgent(ctx context.Context, request UpdateAgentRequestObject) (UpdateAgentResponseObject, error) {
 	// Get current agent to preserve existing values for optional fields
-	currentAgent, err := s.queries.GetAgentByID(ctx, request.AgentId)
+	currentAgent, access, err := s.agentAccess(ctx, request.AgentId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return UpdateAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
 		}
 		return nil, err
 	}
+	if !access.Allows(db.GrantAccessManage) {
+		return UpdateAgent403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	ifUpdatedAt, ok := db.MatchETag(aws.ToString(request.Params.IfMatch), currentAgent.UpdatedAt)
+	if !ok {
+		return UpdateAgent412JSONResponse(preconditionFailed(AGENT_RESOURCE, request.AgentId, currentAgent.UpdatedAt)), nil
+	}
 
 	// Start with current values
 	params := db.UpdateAgentParams{
@@ -121,6 +228,8 @@ func (s *Server) UpdateAgent(ctx context.Context, request UpdateAgentRequestObje
 		Name:        currentAgent.Name,
 		Description: currentAgent.Description,
 		Specs:       currentAgent.Specs,
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		IfUpdatedAt: ifUpdatedAt,
 	}
 
 	// Update only provided fields
@@ -136,17 +245,43 @@ func (s *Server) UpdateAgent(ctx context.Context, request UpdateAgentRequestObje
 
 	agent, err := s.queries.UpdateAgent(ctx, params)
 	if err != nil {
+		// Another request updated the agent since it was read
+		if err == pgx.ErrNoRows && ifUpdatedAt.Valid {
+			return UpdateAgent412JSONResponse(preconditionFailed(AGENT_RESOURCE, request.AgentId, pgtype.Timestamptz{})), nil
+		}
 		return nil, err
 	}
+	s.recordResourceChange(ctx, db.ResourceTypeAgent, agent.ID, db.ResourceChangeActionUpdate, currentAgent, agent)
+
+	// The cached prefix changes with the specs, upload the new one ahead of the next request
+	if request.Body.Specs != nil && agent.Specs.Valid {
+		s.publishAgentCacheWarmup(agent)
+	}
+
+	return UpdateAgent200JSONResponse{Body: agent, Headers: UpdateAgent200ResponseHeaders{ETag: db.ETag(agent.UpdatedAt)}}, nil
+}
 
-	return UpdateAgent200JSONResponse(agent), nil
+// publishAgentCacheWarmup asks the agent service to warm up the prompt cache of the agent.
+// A failed publish only costs the first request a cache miss, so it is logged and not returned.
+func (s *Server) publishAgentCacheWarmup(agent db.Agent) {
+	event := service.NewEvent(&service.AgentCacheWarmupEventMessage{
+		AgentId: agent.ID,
+	}, &service.EventHeaders{
+		UserID:      agent.CreatedBy,
+		WorkspaceID: agent.WorkspaceID,
+	}, &service.EventMetadata{
+		Timestamp: time.Now().UTC(),
+	})
+	if err := event.Publish(s.nc); err != nil {
+		s.log.Warn("Failed to publish agent cache warmup event", "agent_id", agent.ID, "error", err)
+	}
 }
 
 // List permissions for agent mapping
 // (GET /v1/agents/{agent_id}/permissions)
 func (s Server) ListPermissionsForAgent(ctx context.Context, request ListPermissionsForAgentRequestObject) (ListPermissionsForAgentResponseObject, error) {
 	// Check if agent exists
-	_, err := s.queries.GetAgentByID(ctx, request.AgentId)
+	_, _, err := s.agentAccess(ctx, request.AgentId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return ListPermissionsForAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
@@ -166,17 +301,12 @@ func (s Server) ListPermissionsForAgent(ctx context.Context, request ListPermiss
 // Add permission to agent
 // (POST /v1/agents/{agent_id}/permissions)
 func (s Server) AddPermissionToAgent(ctx context.Context, request AddPermissionToAgentRequestObject) (AddPermissionToAgentResponseObject, error) {
-	// TODO: should be replaced with the actual user ID from the context or authentication system
 	var assignedBy uuid.UUID
 	if request.Body.AssignedBy != nil {
 		assignedBy = *request.Body.AssignedBy
 	} else {
-		// Default assigned_by if not provided
-		var err error
-		assignedBy, err = uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-		if err != nil {
-			return nil, fmt.Errorf("invalid default UUID format: %v", err)
-		}
+		// Default assigned_by to the user of the request
+		assignedBy = custom_middleware.RequestUserID(ctx)
 	}
 
 	params := db.AddAgentPermissionParams{
@@ -190,13 +320,16 @@ func (s Server) AddPermissionToAgent(ctx context.Context, request AddPermissionT
 	}
 
 	// Check if agent exists
-	_, err := s.queries.GetAgentByID(ctx, request.AgentId)
+	_, access, err := s.agentAccess(ctx, request.AgentId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return AddPermissionToAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
 		}
 		return nil, err
 	}
+	if !access.Allows(db.GrantAccessManage) {
+		return AddPermissionToAgent403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
 	// Check if permission exists
 	_, err = s.queries.GetPermissionByID(ctx, request.Body.PermissionId)
 	if err != nil {
@@ -226,13 +359,16 @@ func (s Server) RemovePermissionFromAgent(ctx context.Context, request RemovePer
 		PermissionID: request.PermissionId,
 	}
 	// Check if agent exists
-	_, err := s.queries.GetAgentByID(ctx, request.AgentId)
+	_, access, err := s.agentAccess(ctx, request.AgentId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return RemovePermissionFromAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
 		}
 		return nil, err
 	}
+	if !access.Allows(db.GrantAccessManage) {
+		return RemovePermissionFromAgent403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
 	// Check if permission exists
 	_, err = s.queries.GetPermissionByID(ctx, request.PermissionId)
 	if err != nil {
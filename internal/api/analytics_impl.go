@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/pinazu/internal/db"
+)
+
+// Get prompt cache analytics
+// (GET /v1/analytics/prompt-cache)
+func (s *Server) GetPromptCacheAnalytics(ctx context.Context, request GetPromptCacheAnalyticsRequestObject) (GetPromptCacheAnalyticsResponseObject, error) {
+	rows, err := s.queries.ListAgentPromptCacheUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]AgentPromptCacheStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, toAgentPromptCacheStats(row))
+	}
+	return GetPromptCacheAnalytics200JSONResponse(PromptCacheAnalytics{Agents: stats}), nil
+}
+
+// toAgentPromptCacheStats converts the prompt cache counters of an agent to its hit rates
+func toAgentPromptCacheStats(row db.ListAgentPromptCacheUsageRow) AgentPromptCacheStats {
+	stats := AgentPromptCacheStats{
+		AgentId:                  row.AgentID,
+		AgentName:                row.AgentName,
+		RequestCount:             row.RequestCount,
+		CacheHitCount:            row.CacheHitCount,
+		InputTokens:              row.InputTokens,
+		CacheReadInputTokens:     row.CacheReadInputTokens,
+		CacheCreationInputTokens: row.CacheCreationInputTokens,
+		WarmupCount:              row.WarmupCount,
+		WarmupInputTokens:        row.WarmupInputTokens,
+		UpdatedAt:                row.UpdatedAt,
+	}
+	if row.LastWarmupAt.Valid {
+		stats.LastWarmupAt = &row.LastWarmupAt
+	}
+	if row.RequestCount > 0 {
+		stats.CacheHitRate = float64(row.CacheHitCount) / float64(row.RequestCount)
+	}
+	// Anthropic reports the uncached, cache read and cache write input tokens separately
+	if promptTokens := row.InputTokens + row.CacheReadInputTokens + row.CacheCreationInputTokens; promptTokens > 0 {
+		stats.TokenHitRate = float64(row.CacheReadInputTokens) / float64(promptTokens)
+	}
+	return stats
+}
+
+// Get thinking budget analytics
+// (GET /v1/analytics/thinking-budget)
+func (s *Server) GetThinkingBudgetAnalytics(ctx context.Context, request GetThinkingBudgetAnalyticsRequestObject) (GetThinkingBudgetAnalyticsResponseObject, error) {
+	rows, err := s.queries.ListAgentThinkingUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]AgentThinkingBudgetStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, toAgentThinkingBudgetStats(row))
+	}
+	return GetThinkingBudgetAnalytics200JSONResponse(ThinkingBudgetAnalytics{Agents: stats}), nil
+}
+
+// toAgentThinkingBudgetStats converts the thinking counters of an agent to its budget utilization
+func toAgentThinkingBudgetStats(row db.ListAgentThinkingUsageRow) AgentThinkingBudgetStats {
+	stats := AgentThinkingBudgetStats{
+		AgentId:           row.AgentID,
+		AgentName:         row.AgentName,
+		RequestCount:      row.RequestCount,
+		BudgetTokens:      row.BudgetTokens,
+		ThinkingTokens:    row.ThinkingTokens,
+		MaxThinkingTokens: row.MaxThinkingTokens,
+		ExhaustedCount:    row.ExhaustedCount,
+		EstimatedCount:    row.EstimatedCount,
+		UpdatedAt:         row.UpdatedAt,
+	}
+	if row.RequestCount > 0 {
+		stats.AverageThinkingTokens = float64(row.ThinkingTokens) / float64(row.RequestCount)
+		stats.ExhaustedRate = float64(row.ExhaustedCount) / float64(row.RequestCount)
+	}
+	// The budget may change between requests, the utilization is relative to the budget of each request
+	if row.TotalBudgetTokens > 0 {
+		stats.Utilization = float64(row.ThinkingTokens) / float64(row.TotalBudgetTokens)
+	}
+	return stats
+}
+
+// Get flow cost analytics
+// (GET /v1/analytics/flow-costs)
+func (s *Server) GetFlowCostAnalytics(ctx context.Context, request GetFlowCostAnalyticsRequestObject) (GetFlowCostAnalyticsResponseObject, error) {
+	since, until, msg := flowCostRange(request.Params.Since, request.Params.Until)
+	if msg != "" {
+		return GetFlowCostAnalytics400JSONResponse{Message: msg}, nil
+	}
+	rows, err := s.queries.ListFlowCosts(ctx, db.ListFlowCostsParams{Since: since, Until: until})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow costs: %w", err)
+	}
+	return GetFlowCostAnalytics200JSONResponse(FlowCostAnalytics{Flows: rows}), nil
+}
+
+// Get user flow cost analytics
+// (GET /v1/analytics/user-flow-costs)
+func (s *Server) GetUserFlowCostAnalytics(ctx context.Context, request GetUserFlowCostAnalyticsRequestObject) (GetUserFlowCostAnalyticsResponseObject, error) {
+	since, until, msg := flowCostRange(request.Params.Since, request.Params.Until)
+	if msg != "" {
+		return GetUserFlowCostAnalytics400JSONResponse{Message: msg}, nil
+	}
+	rows, err := s.queries.ListUserFlowCosts(ctx, db.ListUserFlowCostsParams{Since: since, Until: until})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user flow costs: %w", err)
+	}
+	return GetUserFlowCostAnalytics200JSONResponse(UserFlowCostAnalytics{Users: rows}), nil
+}
+
+// flowCostRange converts the optional time range of a cost report, and returns why it is invalid, empty when it is valid
+func flowCostRange(since, until *time.Time) (pgtype.Timestamptz, pgtype.Timestamptz, string) {
+	var start, end pgtype.Timestamptz
+	if since != nil {
+		start = pgtype.Timestamptz{Time: *since, Valid: true}
+	}
+	if until != nil {
+		end = pgtype.Timestamptz{Time: *until, Valid: true}
+	}
+	if since != nil && until != nil && !until.After(*since) {
+		return start, end, "until must be after since"
+	}
+	return start, end, ""
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlowCostRange(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+
+	t.Run("unbounded", func(t *testing.T) {
+		start, end, msg := flowCostRange(nil, nil)
+		assert.Empty(t, msg)
+		assert.False(t, start.Valid)
+		assert.False(t, end.Valid)
+	})
+
+	t.Run("since only", func(t *testing.T) {
+		start, end, msg := flowCostRange(&since, nil)
+		assert.Empty(t, msg)
+		assert.True(t, start.Valid)
+		assert.Equal(t, since, start.Time)
+		assert.False(t, end.Valid)
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		start, end, msg := flowCostRange(&since, &until)
+		assert.Empty(t, msg)
+		assert.Equal(t, since, start.Time)
+		assert.Equal(t, until, end.Time)
+		assert.True(t, end.Valid)
+	})
+
+	t.Run("until not after since", func(t *testing.T) {
+		_, _, msg := flowCostRange(&until, &since)
+		assert.Equal(t, "until must be after since", msg)
+		_, _, msg = flowCostRange(&since, &since)
+		assert.Equal(t, "until must be after since", msg)
+	})
+}
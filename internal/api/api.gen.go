@@ -8,7 +8,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -21,6 +24,207 @@ import (
 	db "github.com/pinazu/internal/db"
 )
 
+// Defines values for ApiKeyScopes.
+const (
+	ApiKeyScopesAdmin ApiKeyScopes = "admin"
+	ApiKeyScopesRead  ApiKeyScopes = "read"
+	ApiKeyScopesWrite ApiKeyScopes = "write"
+)
+
+// Defines values for BatchItemResultStatus.
+const (
+	Created    BatchItemResultStatus = "created"
+	Deleted    BatchItemResultStatus = "deleted"
+	Failed     BatchItemResultStatus = "failed"
+	RolledBack BatchItemResultStatus = "rolled_back"
+	Updated    BatchItemResultStatus = "updated"
+)
+
+// Defines values for CreateApiKeyRequestScopes.
+const (
+	CreateApiKeyRequestScopesAdmin CreateApiKeyRequestScopes = "admin"
+	CreateApiKeyRequestScopesRead  CreateApiKeyRequestScopes = "read"
+	CreateApiKeyRequestScopesWrite CreateApiKeyRequestScopes = "write"
+)
+
+// Defines values for CreateFlowRequestConcurrencyPolicy.
+const (
+	CreateFlowRequestConcurrencyPolicyQUEUE  CreateFlowRequestConcurrencyPolicy = "QUEUE"
+	CreateFlowRequestConcurrencyPolicyREJECT CreateFlowRequestConcurrencyPolicy = "REJECT"
+)
+
+// Defines values for CreateFlowScheduleRequestCatchUpPolicy.
+const (
+	CreateFlowScheduleRequestCatchUpPolicyAll  CreateFlowScheduleRequestCatchUpPolicy = "all"
+	CreateFlowScheduleRequestCatchUpPolicyOnce CreateFlowScheduleRequestCatchUpPolicy = "once"
+	CreateFlowScheduleRequestCatchUpPolicySkip CreateFlowScheduleRequestCatchUpPolicy = "skip"
+)
+
+// Defines values for CreateTaskScheduleRequestOverlapPolicy.
+const (
+	CreateTaskScheduleRequestOverlapPolicyAllow CreateTaskScheduleRequestOverlapPolicy = "allow"
+	CreateTaskScheduleRequestOverlapPolicyQueue CreateTaskScheduleRequestOverlapPolicy = "queue"
+	CreateTaskScheduleRequestOverlapPolicySkip  CreateTaskScheduleRequestOverlapPolicy = "skip"
+)
+
+// Defines values for CreateToolFromDefinitionRequestFormat.
+const (
+	CreateToolFromDefinitionRequestFormatMcp    CreateToolFromDefinitionRequestFormat = "mcp"
+	CreateToolFromDefinitionRequestFormatOpenai CreateToolFromDefinitionRequestFormat = "openai"
+)
+
+// Defines values for FileStatus.
+const (
+	FileStatusPENDING  FileStatus = "PENDING"
+	FileStatusUPLOADED FileStatus = "UPLOADED"
+)
+
+// Defines values for ImportMessageSenderType.
+const (
+	ImportMessageSenderTypeAssistant ImportMessageSenderType = "assistant"
+	ImportMessageSenderTypeSystem    ImportMessageSenderType = "system"
+	ImportMessageSenderTypeUser      ImportMessageSenderType = "user"
+)
+
+// Defines values for SortOrder.
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// Defines values for UpdateFlowRequestConcurrencyPolicy.
+const (
+	UpdateFlowRequestConcurrencyPolicyQUEUE  UpdateFlowRequestConcurrencyPolicy = "QUEUE"
+	UpdateFlowRequestConcurrencyPolicyREJECT UpdateFlowRequestConcurrencyPolicy = "REJECT"
+)
+
+// Defines values for UpdateFlowScheduleRequestCatchUpPolicy.
+const (
+	UpdateFlowScheduleRequestCatchUpPolicyAll  UpdateFlowScheduleRequestCatchUpPolicy = "all"
+	UpdateFlowScheduleRequestCatchUpPolicyOnce UpdateFlowScheduleRequestCatchUpPolicy = "once"
+	UpdateFlowScheduleRequestCatchUpPolicySkip UpdateFlowScheduleRequestCatchUpPolicy = "skip"
+)
+
+// Defines values for UpdateTaskScheduleRequestOverlapPolicy.
+const (
+	UpdateTaskScheduleRequestOverlapPolicyAllow UpdateTaskScheduleRequestOverlapPolicy = "allow"
+	UpdateTaskScheduleRequestOverlapPolicyQueue UpdateTaskScheduleRequestOverlapPolicy = "queue"
+	UpdateTaskScheduleRequestOverlapPolicySkip  UpdateTaskScheduleRequestOverlapPolicy = "skip"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusFAILED    WebhookDeliveryStatus = "FAILED"
+	WebhookDeliveryStatusPENDING   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSUCCEEDED WebhookDeliveryStatus = "SUCCEEDED"
+)
+
+// Defines values for WebhookEvent.
+const (
+	FlowRunFailed    WebhookEvent = "flow_run.failed"
+	FlowRunSucceeded WebhookEvent = "flow_run.succeeded"
+	TaskFinished     WebhookEvent = "task.finished"
+)
+
+// Defines values for ListToolRunAuditParamsStatus.
+const (
+	ListToolRunAuditParamsStatusCANCELLED ListToolRunAuditParamsStatus = "CANCELLED"
+	ListToolRunAuditParamsStatusFAILED    ListToolRunAuditParamsStatus = "FAILED"
+	ListToolRunAuditParamsStatusPENDING   ListToolRunAuditParamsStatus = "PENDING"
+	ListToolRunAuditParamsStatusRUNNING   ListToolRunAuditParamsStatus = "RUNNING"
+	ListToolRunAuditParamsStatusSUCCESS   ListToolRunAuditParamsStatus = "SUCCESS"
+)
+
+// Defines values for ListFlowRunsParamsStatus.
+const (
+	ListFlowRunsParamsStatusCANCELLED ListFlowRunsParamsStatus = "CANCELLED"
+	ListFlowRunsParamsStatusFAILED    ListFlowRunsParamsStatus = "FAILED"
+	ListFlowRunsParamsStatusPAUSED    ListFlowRunsParamsStatus = "PAUSED"
+	ListFlowRunsParamsStatusPENDING   ListFlowRunsParamsStatus = "PENDING"
+	ListFlowRunsParamsStatusRUNNING   ListFlowRunsParamsStatus = "RUNNING"
+	ListFlowRunsParamsStatusSCHEDULED ListFlowRunsParamsStatus = "SCHEDULED"
+	ListFlowRunsParamsStatusSUCCESS   ListFlowRunsParamsStatus = "SUCCESS"
+)
+
+// Defines values for ListFlowRunsParamsSort.
+const (
+	ListFlowRunsParamsSortCreatedAt ListFlowRunsParamsSort = "created_at"
+	ListFlowRunsParamsSortUpdatedAt ListFlowRunsParamsSort = "updated_at"
+)
+
+// Defines values for ListTasksParamsStatus.
+const (
+	ListTasksParamsStatusCANCELLED ListTasksParamsStatus = "CANCELLED"
+	ListTasksParamsStatusFAILED    ListTasksParamsStatus = "FAILED"
+	ListTasksParamsStatusFINISHED  ListTasksParamsStatus = "FINISHED"
+	ListTasksParamsStatusPAUSE     ListTasksParamsStatus = "PAUSE"
+	ListTasksParamsStatusRUNNING   ListTasksParamsStatus = "RUNNING"
+	ListTasksParamsStatusSCHEDULED ListTasksParamsStatus = "SCHEDULED"
+)
+
+// Defines values for ListTasksParamsSort.
+const (
+	ListTasksParamsSortCreatedAt ListTasksParamsSort = "created_at"
+	ListTasksParamsSortUpdatedAt ListTasksParamsSort = "updated_at"
+)
+
+// Defines values for ListThreadsParamsSort.
+const (
+	ListThreadsParamsSortCreatedAt ListThreadsParamsSort = "created_at"
+	ListThreadsParamsSortTitle     ListThreadsParamsSort = "title"
+	ListThreadsParamsSortUpdatedAt ListThreadsParamsSort = "updated_at"
+)
+
+// Defines values for ExportThreadParamsFormat.
+const (
+	ExportThreadParamsFormatJson     ExportThreadParamsFormat = "json"
+	ExportThreadParamsFormatMarkdown ExportThreadParamsFormat = "markdown"
+)
+
+// Defines values for ListToolsParamsType.
+const (
+	ListToolsParamsTypeInternal   ListToolsParamsType = "internal"
+	ListToolsParamsTypeMcp        ListToolsParamsType = "mcp"
+	ListToolsParamsTypeStandalone ListToolsParamsType = "standalone"
+	ListToolsParamsTypeWorkflow   ListToolsParamsType = "workflow"
+)
+
+// Defines values for ListToolsParamsStatus.
+const (
+	Degraded    ListToolsParamsStatus = "degraded"
+	Healthy     ListToolsParamsStatus = "healthy"
+	Unknown     ListToolsParamsStatus = "unknown"
+	Unreachable ListToolsParamsStatus = "unreachable"
+)
+
+// Defines values for ListToolsParamsSort.
+const (
+	ListToolsParamsSortCategory  ListToolsParamsSort = "category"
+	ListToolsParamsSortCreatedAt ListToolsParamsSort = "created_at"
+	ListToolsParamsSortName      ListToolsParamsSort = "name"
+	ListToolsParamsSortUpdatedAt ListToolsParamsSort = "updated_at"
+)
+
+// Defines values for ExportToolsParamsFormat.
+const (
+	ExportToolsParamsFormatJson ExportToolsParamsFormat = "json"
+	ExportToolsParamsFormatYaml ExportToolsParamsFormat = "yaml"
+)
+
+// Defines values for ImportToolsParamsOnConflict.
+const (
+	Create ImportToolsParamsOnConflict = "create"
+	Skip   ImportToolsParamsOnConflict = "skip"
+	Update ImportToolsParamsOnConflict = "update"
+)
+
+// Defines values for ListWebhookDeliveriesParamsStatus.
+const (
+	ListWebhookDeliveriesParamsStatusFAILED    ListWebhookDeliveriesParamsStatus = "FAILED"
+	ListWebhookDeliveriesParamsStatusPENDING   ListWebhookDeliveriesParamsStatus = "PENDING"
+	ListWebhookDeliveriesParamsStatusSUCCEEDED ListWebhookDeliveriesParamsStatus = "SUCCEEDED"
+)
+
 // AddPermissionToAgentRequest defines model for AddPermissionToAgentRequest.
 type AddPermissionToAgentRequest struct {
 	AssignedBy   *uuid.UUID `json:"assigned_by,omitempty"`
@@ -44,11 +248,19 @@ type Agent = db.Agent
 
 // AgentList defines model for AgentList.
 type AgentList struct {
-	Agents     []Agent `json:"agents"`
-	Page       int32   `json:"page"`
-	PerPage    int32   `json:"per_page"`
-	Total      int     `json:"total"`
-	TotalPages int     `json:"total_pages"`
+	Agents []Agent `json:"agents"`
+
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
 }
 
 // AgentPermissionMapping defines model for AgentPermissionMapping.
@@ -63,12 +275,218 @@ type AgentPermissionMappingList struct {
 	TotalPages         int                      `json:"total_pages"`
 }
 
+// AgentProbe defines model for AgentProbe.
+type AgentProbe = db.AgentProbe
+
+// AgentProbeList defines model for AgentProbeList.
+type AgentProbeList struct {
+	Probes []AgentProbe `json:"probes"`
+}
+
+// AgentProbeRun defines model for AgentProbeRun.
+type AgentProbeRun = db.AgentProbeRun
+
+// AgentProbeRunList defines model for AgentProbeRunList.
+type AgentProbeRunList struct {
+	Page       int32           `json:"page"`
+	PerPage    int32           `json:"per_page"`
+	Runs       []AgentProbeRun `json:"runs"`
+	Total      int             `json:"total"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// AgentPromptCacheStats defines model for AgentPromptCacheStats.
+type AgentPromptCacheStats struct {
+	AgentId   uuid.UUID `json:"agent_id"`
+	AgentName string    `json:"agent_name"`
+
+	// CacheCreationInputTokens Input tokens of interactive requests written to the prompt cache
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+
+	// CacheHitCount Interactive requests that read from the prompt cache
+	CacheHitCount int64 `json:"cache_hit_count"`
+
+	// CacheHitRate Ratio of interactive requests that read from the prompt cache
+	CacheHitRate         float64 `json:"cache_hit_rate"`
+	CacheReadInputTokens int64   `json:"cache_read_input_tokens"`
+
+	// InputTokens Uncached input tokens of interactive requests
+	InputTokens  int64               `json:"input_tokens"`
+	LastWarmupAt *pgtype.Timestamptz `json:"last_warmup_at"`
+
+	// RequestCount Interactive requests, warmups excluded
+	RequestCount int64 `json:"request_count"`
+
+	// TokenHitRate Ratio of the input tokens of interactive requests read from the prompt cache
+	TokenHitRate float64            `json:"token_hit_rate"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	WarmupCount  int64              `json:"warmup_count"`
+
+	// WarmupInputTokens Input tokens sent by warmups, mostly written to the prompt cache
+	WarmupInputTokens int64 `json:"warmup_input_tokens"`
+}
+
+// AgentThinkingBudgetStats defines model for AgentThinkingBudgetStats.
+type AgentThinkingBudgetStats struct {
+	AgentId   uuid.UUID `json:"agent_id"`
+	AgentName string    `json:"agent_name"`
+
+	// AverageThinkingTokens Thinking tokens consumed per request
+	AverageThinkingTokens float64 `json:"average_thinking_tokens"`
+
+	// BudgetTokens Thinking budget of the last request
+	BudgetTokens int64 `json:"budget_tokens"`
+
+	// EstimatedCount Requests whose thinking tokens were estimated from the thinking content, the provider not reporting them
+	EstimatedCount int64 `json:"estimated_count"`
+
+	// ExhaustedCount Requests that consumed their whole thinking budget
+	ExhaustedCount int64 `json:"exhausted_count"`
+
+	// ExhaustedRate Ratio of the requests that consumed their whole thinking budget
+	ExhaustedRate float64 `json:"exhausted_rate"`
+
+	// MaxThinkingTokens Largest thinking token count of a single request
+	MaxThinkingTokens int64 `json:"max_thinking_tokens"`
+
+	// RequestCount Requests with thinking enabled
+	RequestCount int64 `json:"request_count"`
+
+	// ThinkingTokens Thinking tokens consumed by the requests
+	ThinkingTokens int64              `json:"thinking_tokens"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+
+	// Utilization Ratio of the thinking budgets of the requests actually consumed
+	Utilization float64 `json:"utilization"`
+}
+
+// ApiKey API key authenticating a programmatic client as its user, the key itself is only returned when it is created
+type ApiKey struct {
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy uuid.UUID `json:"created_by"`
+
+	// ExpiresAt Time the key expires at, null when it never expires
+	ExpiresAt *time.Time `json:"expires_at"`
+	Id        uuid.UUID  `json:"id"`
+
+	// KeyPrefix Start of the key, to recognize it
+	KeyPrefix string `json:"key_prefix"`
+
+	// LastUsedAt Time the key was last used at, updated at most once a minute
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Name       string     `json:"name"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+
+	// Scopes Scopes of the key, read grants the reads, write the other /v1 requests on top, admin the users, roles, permissions and API keys on top
+	Scopes []ApiKeyScopes `json:"scopes"`
+	UserId uuid.UUID      `json:"user_id"`
+}
+
+// ApiKeyScopes defines model for ApiKey.Scopes.
+type ApiKeyScopes string
+
+// ApiKeyList defines model for ApiKeyList.
+type ApiKeyList struct {
+	ApiKeys []ApiKey `json:"api_keys"`
+}
+
+// AttachmentList defines model for AttachmentList.
+type AttachmentList struct {
+	// Attachments Files attached to the message, in their order in the message
+	Attachments []File `json:"attachments"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest struct {
 	// Message Error message indicating the bad request
 	Message string `json:"message"`
 }
 
+// BatchCreateToolsRequest defines model for BatchCreateToolsRequest.
+type BatchCreateToolsRequest struct {
+	// Tools Tools to create, at most 100
+	Tools []CreateToolRequest `json:"tools"`
+}
+
+// BatchDeleteRequest defines model for BatchDeleteRequest.
+type BatchDeleteRequest struct {
+	// Ids IDs of the resources to delete, at most 100
+	Ids []uuid.UUID `json:"ids"`
+}
+
+// BatchItemResult Outcome of an operation of a batch request
+type BatchItemResult struct {
+	// Id ID of the resource of the operation, unset when it was not created
+	Id *uuid.UUID `json:"id,omitempty"`
+
+	// Index Position of the operation in the batch
+	Index int `json:"index"`
+
+	// Message Why the operation failed
+	Message *string `json:"message,omitempty"`
+
+	// Status Outcome of the operation, rolled_back when it succeeded but another operation of the batch failed
+	Status BatchItemResultStatus `json:"status"`
+}
+
+// BatchItemResultStatus Outcome of the operation, rolled_back when it succeeded but another operation of the batch failed
+type BatchItemResultStatus string
+
+// BatchResult Outcome of a batch request. The operations of a batch run in a single transaction, nothing is applied when one of them fails.
+type BatchResult struct {
+	// Committed Whether the operations were applied
+	Committed bool              `json:"committed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// BatchToolUpdate defines model for BatchToolUpdate.
+type BatchToolUpdate struct {
+	Changes UpdateToolRequest `json:"changes"`
+
+	// Id ID of the tool to update
+	Id uuid.UUID `json:"id"`
+}
+
+// BatchUpdateToolsRequest defines model for BatchUpdateToolsRequest.
+type BatchUpdateToolsRequest struct {
+	// Tools Updates of the tools, at most 100
+	Tools []BatchToolUpdate `json:"tools"`
+}
+
+// CancelFlowRunRequest defines model for CancelFlowRunRequest.
+type CancelFlowRunRequest struct {
+	// Reason Why the flow run is cancelled, stored as its error message
+	Reason *string `json:"reason,omitempty"`
+}
+
+// Conflict defines model for Conflict.
+type Conflict struct {
+	// Message Error message indicating why the request conflicts with the state of the resource
+	Message string `json:"message"`
+}
+
+// CreateAgentProbeRequest defines model for CreateAgentProbeRequest.
+type CreateAgentProbeRequest struct {
+	// DryRun Tools return their mock responses instead of being called, default false
+	DryRun *bool `json:"dry_run,omitempty"`
+
+	// Enabled Default true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ExpectedPattern Regular expression the final response of the agent must match
+	ExpectedPattern string `json:"expected_pattern"`
+
+	// IntervalSeconds Time between two runs, default 300
+	IntervalSeconds *int32 `json:"interval_seconds,omitempty"`
+	Name            string `json:"name"`
+
+	// Prompt User message sent to the agent
+	Prompt string `json:"prompt"`
+
+	// TimeoutSeconds Time the agent has to respond before the run times out, default 120
+	TimeoutSeconds *int32 `json:"timeout_seconds,omitempty"`
+}
+
 // CreateAgentRequest defines model for CreateAgentRequest.
 type CreateAgentRequest struct {
 	Description *string `json:"description,omitempty"`
@@ -78,41 +496,121 @@ type CreateAgentRequest struct {
 	Specs *string `json:"specs,omitempty"`
 }
 
+// CreateApiKeyRequest defines model for CreateApiKeyRequest.
+type CreateApiKeyRequest struct {
+	// ExpiresAt Time the key expires at, the key never expires when not set
+	ExpiresAt *time.Time                  `json:"expires_at,omitempty"`
+	Name      string                      `json:"name"`
+	Scopes    []CreateApiKeyRequestScopes `json:"scopes"`
+}
+
+// CreateApiKeyRequestScopes defines model for CreateApiKeyRequest.Scopes.
+type CreateApiKeyRequestScopes string
+
+// CreateFileUploadRequest File uploaded to a presigned URL, with a PUT request carrying the same Content-Type header
+type CreateFileUploadRequest struct {
+	ContentType string `json:"content_type"`
+	Name        string `json:"name"`
+
+	// SizeBytes Size of the file, checked against the maximum file size
+	SizeBytes int64 `json:"size_bytes"`
+}
+
 // CreateFlowRequest defines model for CreateFlowRequest.
 type CreateFlowRequest struct {
 	// AdditionalInfo Additional information related to the flow
 	AdditionalInfo *map[string]interface{} `json:"additional_info,omitempty"`
 
-	// CodeLocation Location of the code for the flow
+	// CodeLocation Location of the code for the flow, a local path, an s3://bucket/key URL, a git+https://host/repo.git@ref#subdirectory=path repository or an oci://registry/repository:tag image
 	CodeLocation string `json:"code_location"`
 
+	// ConcurrencyPolicy What happens to the runs beyond max_concurrent_runs, QUEUE (default) runs them in order once runs of the flow end, REJECT fails their request
+	ConcurrencyPolicy *CreateFlowRequestConcurrencyPolicy `json:"concurrency_policy,omitempty"`
+
 	// Description Description of the flow
 	Description *string `json:"description,omitempty"`
 
-	// Engine The engine used for the flow
+	// Engine The engine used for the flow, process for a Python process, node for a Node.js process (entrypoint node or tsx) or docker
 	Engine string `json:"engine"`
 
-	// Entrypoint Entrypoint for the flow
+	// Entrypoint Entrypoint for the flow, empty to run the entrypoint of the image of an oci:// code location
 	Entrypoint string `json:"entrypoint"`
 
+	// MaxConcurrentRuns Runs of the flow scheduled or running at once, to protect the systems the flow calls. 0 removes the limit
+	MaxConcurrentRuns *int32 `json:"max_concurrent_runs,omitempty"`
+
 	// Name Name of the flow
 	Name string `json:"name"`
 
+	// Notifications Webhooks and Slack channels notified when a run of the flow succeeds or fails
+	Notifications *[]FlowNotification `json:"notifications,omitempty"`
+
 	// ParametersSchema Schema for the parameters of the flow
 	ParametersSchema map[string]interface{} `json:"parameters_schema"`
 
+	// RequiredLabels Labels a worker must advertise to execute the runs of the flow, e.g. gpu or python3.12. The runs are routed to the workers advertising every label
+	RequiredLabels *[]string `json:"required_labels,omitempty"`
+
 	// Tags Tags associated with the flow
 	Tags *[]string `json:"tags,omitempty"`
 }
 
+// CreateFlowRequestConcurrencyPolicy What happens to the runs beyond max_concurrent_runs, QUEUE (default) runs them in order once runs of the flow end, REJECT fails their request
+type CreateFlowRequestConcurrencyPolicy string
+
+// CreateFlowScheduleBackfillRequest defines model for CreateFlowScheduleBackfillRequest.
+type CreateFlowScheduleBackfillRequest struct {
+	// EndTime Last time of the range, inclusive, not in the future
+	EndTime time.Time `json:"end_time"`
+
+	// MaxConcurrentRuns Runs of the backfill scheduled or running at once, default 5
+	MaxConcurrentRuns *int32 `json:"max_concurrent_runs,omitempty"`
+
+	// StartTime First time of the range, inclusive
+	StartTime time.Time `json:"start_time"`
+}
+
+// CreateFlowScheduleRequest defines model for CreateFlowScheduleRequest.
+type CreateFlowScheduleRequest struct {
+	// CatchUpPolicy Runs missed while the scheduler was down, dropped (skip), triggered once (once) or each triggered (all), default skip
+	CatchUpPolicy *CreateFlowScheduleRequestCatchUpPolicy `json:"catch_up_policy,omitempty"`
+
+	// CronExpression Standard 5 fields cron expression (minute, hour, day of month, month, day of week) or a macro such as @daily
+	CronExpression string `json:"cron_expression"`
+
+	// Enabled Default true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Engine Engine of the triggered flow runs, the engine of the flow when not set
+	Engine *string `json:"engine,omitempty"`
+	Name   string  `json:"name"`
+
+	// Parameters Parameters of the triggered flow runs, default empty
+	Parameters *map[string]interface{} `json:"parameters,omitempty"`
+
+	// Timezone IANA time zone the cron expression is evaluated in, default UTC
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// CreateFlowScheduleRequestCatchUpPolicy Runs missed while the scheduler was down, dropped (skip), triggered once (once) or each triggered (all), default skip
+type CreateFlowScheduleRequestCatchUpPolicy string
+
 // CreateMessageRequest defines model for CreateMessageRequest.
 type CreateMessageRequest struct {
+	// Attachments Uploaded files attached to the message, given to the agents as image or document content blocks before the content of the message
+	Attachments *[]uuid.UUID `json:"attachments,omitempty"`
+
 	// Message JSON message content
 	Message     db.JsonRaw `json:"message"`
 	RecipientId uuid.UUID  `json:"recipient_id"`
 	SenderId    uuid.UUID  `json:"sender_id"`
 }
 
+// CreateOrganizationRequest defines model for CreateOrganizationRequest.
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
 // CreatePermissionRequest defines model for CreatePermissionRequest.
 type CreatePermissionRequest struct {
 	// Content Permission content in JSON format
@@ -130,11 +628,23 @@ type CreateRoleRequest struct {
 	Name         string       `json:"name"`
 }
 
+// CreateServiceAccountRequest defines model for CreateServiceAccountRequest.
+type CreateServiceAccountRequest struct {
+	// Description What the service account is used for
+	Description *string `json:"description,omitempty"`
+
+	// Name Name of the service account, unique among the users
+	Name string `json:"name"`
+}
+
 // CreateTaskRequest defines model for CreateTaskRequest.
 type CreateTaskRequest struct {
 	// AdditionalInfo Additional information related to the task
 	AdditionalInfo *db.JsonRaw `json:"additional_info,omitempty"`
 
+	// FlowRunId Flow run creating the task, the LLM tokens of the task and of its sub-agent tasks count in the cost of the run
+	FlowRunId *uuid.UUID `json:"flow_run_id,omitempty"`
+
 	// MaxRequestLoop Maximum number of request loops for the task
 	MaxRequestLoop *int `json:"max_request_loop,omitempty"`
 
@@ -142,17 +652,81 @@ type CreateTaskRequest struct {
 	ThreadId uuid.UUID `json:"thread_id"`
 }
 
+// CreateTaskScheduleRequest defines model for CreateTaskScheduleRequest.
+type CreateTaskScheduleRequest struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+
+	// CronExpression Standard 5 fields cron expression (minute, hour, day of month, month, day of week) or a macro such as @daily
+	CronExpression string `json:"cron_expression"`
+
+	// Enabled Default true
+	Enabled *bool  `json:"enabled,omitempty"`
+	Name    string `json:"name"`
+
+	// OverlapPolicy Runs due while the previous run is still running, dropped (skip), started once the previous run ends (queue) or started in their own thread (allow, schedules without thread only), default skip
+	OverlapPolicy *CreateTaskScheduleRequestOverlapPolicy `json:"overlap_policy,omitempty"`
+
+	// Prompt Go template of the user message sent to the agent, rendered with the Name of the schedule, the ScheduledAt time and the Date of the run
+	Prompt string `json:"prompt"`
+
+	// ThreadId Thread the agent is invoked in, a new thread for each run when not set
+	ThreadId *openapi_types.UUID `json:"thread_id,omitempty"`
+
+	// Timezone IANA time zone the cron expression is evaluated in, default UTC
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// CreateTaskScheduleRequestOverlapPolicy Runs due while the previous run is still running, dropped (skip), started once the previous run ends (queue) or started in their own thread (allow, schedules without thread only), default skip
+type CreateTaskScheduleRequestOverlapPolicy string
+
 // CreateThreadRequest defines model for CreateThreadRequest.
 type CreateThreadRequest struct {
 	Title  string    `json:"title"`
 	UserId uuid.UUID `json:"user_id"`
 }
 
+// CreateToolFromDefinitionRequest defines model for CreateToolFromDefinitionRequest.
+type CreateToolFromDefinitionRequest struct {
+	// Category Catalog category used to group the tool
+	Category *pgtype.Text `json:"category"`
+
+	// Config How the tool is called, its params are replaced by the parameters of the definition
+	Config db.ToolConfig `json:"config"`
+
+	// Definition OpenAI function definition, wrapped as a tool ({"type": "function", "function": {...}}) or not, or MCP tool descriptor as listed by tools/list
+	Definition db.JsonRaw `json:"definition"`
+
+	// Description Description of the tool, the description of the definition when not set
+	Description *string `json:"description,omitempty"`
+
+	// Format Format of the definition, detected from its fields when not set
+	Format *CreateToolFromDefinitionRequestFormat `json:"format,omitempty"`
+
+	// Name Name of the tool, the name of the definition when not set
+	Name *string   `json:"name,omitempty"`
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// CreateToolFromDefinitionRequestFormat Format of the definition, detected from its fields when not set
+type CreateToolFromDefinitionRequestFormat string
+
 // CreateToolRequest defines model for CreateToolRequest.
 type CreateToolRequest struct {
+	// Category Catalog category used to group the tool
+	Category    *pgtype.Text  `json:"category"`
 	Config      db.ToolConfig `json:"config"`
 	Description *pgtype.Text  `json:"description"`
-	Name        string        `json:"name"`
+
+	// Documentation Markdown documentation for the tool
+	Documentation *pgtype.Text `json:"documentation"`
+
+	// Examples Example invocations of the tool
+	Examples *[]ToolExample `json:"examples,omitempty"`
+
+	// Icon Icon name or URL displayed in the catalog
+	Icon *pgtype.Text `json:"icon"`
+	Name string       `json:"name"`
+	Tags *[]string    `json:"tags,omitempty"`
 }
 
 // CreateUserRequest defines model for CreateUserRequest.
@@ -169,10 +743,94 @@ type CreateUserRequest struct {
 	ProviderName *db.ProviderName `json:"provider_name,omitempty"`
 }
 
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	Description *string        `json:"description,omitempty"`
+	Enabled     *bool          `json:"enabled,omitempty"`
+	Events      []WebhookEvent `json:"events"`
+
+	// Url Absolute http or https URL the events are posted to
+	Url string `json:"url"`
+}
+
+// CreateWorkspaceRequest defines model for CreateWorkspaceRequest.
+type CreateWorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+// CreatedApiKey defines model for CreatedApiKey.
+type CreatedApiKey struct {
+	// ApiKey API key authenticating a programmatic client as its user, the key itself is only returned when it is created
+	ApiKey ApiKey `json:"api_key"`
+
+	// Key The key to send as Authorization Bearer, it is not stored and cannot be retrieved again
+	Key string `json:"key"`
+}
+
+// CreatedFile defines model for CreatedFile.
+type CreatedFile struct {
+	// File File uploaded by a user to the S3 storage. A file uploaded to a presigned URL stays PENDING until its upload is completed, only the UPLOADED images, PDF and text documents can be attached to messages.
+	File File `json:"file"`
+
+	// UploadExpiresAt Expiry of the presigned upload URL
+	UploadExpiresAt *time.Time `json:"upload_expires_at,omitempty"`
+
+	// UploadUrl Presigned URL the content of the file is uploaded to, for the files created without content
+	UploadUrl *string `json:"upload_url,omitempty"`
+}
+
+// CreatedWebhook defines model for CreatedWebhook.
+type CreatedWebhook struct {
+	// Secret Secret signing the deliveries, it cannot be retrieved again. The X-Pinazu-Signature header of a delivery is sha256= followed by the hex HMAC-SHA256 of "<X-Pinazu-Timestamp>.<body>" keyed by the secret.
+	Secret string `json:"secret"`
+
+	// Webhook Webhook subscription of a user, the platform events matching its events are posted to its URL signed with its secret. The secret is only returned when it is created or rotated.
+	Webhook Webhook `json:"webhook"`
+}
+
+// CursorPaginationMeta defines model for CursorPaginationMeta.
+type CursorPaginationMeta struct {
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
+}
+
+// DeadLetterList defines model for DeadLetterList.
+type DeadLetterList struct {
+	Messages   []ParkedMessage `json:"messages"`
+	Page       int32           `json:"page"`
+	PerPage    int32           `json:"per_page"`
+	Total      int             `json:"total"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// EditMessageRequest defines model for EditMessageRequest.
+type EditMessageRequest struct {
+	// AgentId Agent answering the edited message, the thread is not regenerated when omitted
+	AgentId *uuid.UUID `json:"agent_id,omitempty"`
+
+	// Message JSON content of the new version of the message
+	Message db.JsonRaw `json:"message"`
+}
+
 // ExecuteFlowRequest defines model for ExecuteFlowRequest.
 type ExecuteFlowRequest struct {
+	// MaxRetries Automatic retries of the flow run after a failure, with an exponential backoff. The flows service default applies when omitted
+	MaxRetries *int32 `json:"max_retries,omitempty"`
+
 	// Parameters Parameters for the flow execution
 	Parameters map[string]interface{} `json:"parameters"`
+
+	// ParentRunId Running flow run triggering this run as a sub-flow, recorded as its parent
+	ParentRunId *openapi_types.UUID `json:"parent_run_id,omitempty"`
 }
 
 // ExecuteTaskRequest defines model for ExecuteTaskRequest.
@@ -182,11 +840,86 @@ type ExecuteTaskRequest struct {
 
 	// CurrentLoops Starting number of loops for the task execution
 	CurrentLoops *int `json:"current_loops,omitempty"`
+
+	// DryRun Tools called during the task return their mock response instead of being invoked
+	DryRun *bool `json:"dry_run,omitempty"`
+
+	// Queue Queue the run when the task has a started run, the run starts once the runs before it end and is followed through the status of the run. The request is rejected when the task has a started run and queue is not set.
+	Queue *bool `json:"queue,omitempty"`
+}
+
+// Feedback defines model for Feedback.
+type Feedback = db.Feedback
+
+// FeedbackList defines model for FeedbackList.
+type FeedbackList struct {
+	Feedback   []Feedback `json:"feedback"`
+	Page       int32      `json:"page"`
+	PerPage    int32      `json:"per_page"`
+	Total      int        `json:"total"`
+	TotalPages int        `json:"total_pages"`
+}
+
+// FeedbackRating Thumbs up or down
+type FeedbackRating = db.FeedbackRating
+
+// FeedbackRequest defines model for FeedbackRequest.
+type FeedbackRequest struct {
+	// Categories Labels of the feedback, e.g. incorrect, unhelpful or too_long
+	Categories *[]string `json:"categories,omitempty"`
+
+	// Comment Free-text comment
+	Comment *string `json:"comment,omitempty"`
+
+	// Rating Thumbs up or down
+	Rating FeedbackRating `json:"rating"`
+}
+
+// File File uploaded by a user to the S3 storage. A file uploaded to a presigned URL stays PENDING until its upload is completed, only the UPLOADED images, PDF and text documents can be attached to messages.
+type File struct {
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	Id          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+
+	// SizeBytes Size of the stored file, the declared size of a pending upload
+	SizeBytes  int64      `json:"size_bytes"`
+	Status     FileStatus `json:"status"`
+	UploadedAt *time.Time `json:"uploaded_at"`
+	UserId     uuid.UUID  `json:"user_id"`
+}
+
+// FileStatus defines model for File.Status.
+type FileStatus string
+
+// FileList defines model for FileList.
+type FileList struct {
+	Files []File `json:"files"`
+
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
 }
 
 // Flow defines model for Flow.
 type Flow = db.Flow
 
+// FlowCostAnalytics defines model for FlowCostAnalytics.
+type FlowCostAnalytics struct {
+	Flows []FlowCostStats `json:"flows"`
+}
+
+// FlowCostStats defines model for FlowCostStats.
+type FlowCostStats = db.ListFlowCostsRow
+
 // FlowList defines model for FlowList.
 type FlowList struct {
 	Flows      []Flow `json:"flows"`
@@ -196,30 +929,197 @@ type FlowList struct {
 	TotalPages int    `json:"total_pages"`
 }
 
+// FlowNotification Endpoint notified by the flows service when a run of the flow succeeds, or fails with no retry left. The notifications failing with a network error, a 5xx, 408 or 429 are sent again with a backoff.
+type FlowNotification = db.FlowNotification
+
 // FlowRun defines model for FlowRun.
 type FlowRun = db.FlowRun
 
-// MCPTool defines model for MCPTool.
-type MCPTool struct {
-	// ApiKey Optional API key for the MCP tool
-	ApiKey *string `json:"api_key"`
+// FlowRunArtifact Named output published by a task of a flow run
+type FlowRunArtifact = db.FlowRunArtifact
 
-	// Entrypoint MCP entry point for the tool
-	Entrypoint string `json:"entrypoint"`
+// FlowRunArtifactList defines model for FlowRunArtifactList.
+type FlowRunArtifactList struct {
+	Artifacts []FlowRunArtifact `json:"artifacts"`
+}
 
-	// EnvVars Environment variables for the MCP tool
-	EnvVars *map[string]string `json:"env_vars"`
+// FlowRunChildren Sub-flow runs triggered by a flow run, with their aggregated statuses
+type FlowRunChildren struct {
+	// Children Child flow runs, oldest first
+	Children []FlowRun `json:"children"`
 
-	// Protocol Protocol used by the MCP tool
-	Protocol db.MCPProtocol `json:"protocol"`
-	Type     db.ToolType    `json:"type"`
+	// Completed Whether every child flow run reached its final status, succeeded, cancelled or failed with no retry left
+	Completed bool `json:"completed"`
+
+	// Failed Number of completed child flow runs which did not succeed
+	Failed int `json:"failed"`
+
+	// FlowRunId ID of the parent flow run
+	FlowRunId openapi_types.UUID `json:"flow_run_id"`
+
+	// Statuses Number of child flow runs by status
+	Statuses map[string]int `json:"statuses"`
+
+	// Total Number of child flow runs
+	Total int `json:"total"`
 }
 
-// Message defines model for Message.
-type Message = db.ThreadMessage
+// FlowRunCost defines model for FlowRunCost.
+type FlowRunCost = db.GetFlowRunCostRow
 
-// MessageList defines model for MessageList.
-type MessageList struct {
+// FlowRunGraph Task DAG of a flow run, as reported by the flow library, with the live status of each task
+type FlowRunGraph = db.FlowRunGraph
+
+// FlowRunList defines model for FlowRunList.
+type FlowRunList struct {
+	FlowRuns []FlowRun `json:"flow_runs"`
+
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
+}
+
+// FlowRunLog Chunk of lines of the output of a flow process
+type FlowRunLog = db.FlowRunLog
+
+// FlowRunLogList defines model for FlowRunLogList.
+type FlowRunLogList struct {
+	Logs []FlowRunLog `json:"logs"`
+
+	// NextCursor Cursor to pass as after to read the next chunks
+	NextCursor int64 `json:"next_cursor"`
+}
+
+// FlowRunTimeline State transitions of a flow run and its tasks, oldest first, to find where the time of the run was spent
+type FlowRunTimeline = db.FlowRunTimeline
+
+// FlowSchedule defines model for FlowSchedule.
+type FlowSchedule = db.FlowSchedule
+
+// FlowScheduleBackfill Runs of a schedule triggered over a past time range, with the cron expression, time zone, parameters and engine of the schedule at its creation
+type FlowScheduleBackfill = db.FlowScheduleBackfill
+
+// FlowScheduleBackfillList defines model for FlowScheduleBackfillList.
+type FlowScheduleBackfillList struct {
+	Backfills []FlowScheduleBackfill `json:"backfills"`
+}
+
+// FlowScheduleList defines model for FlowScheduleList.
+type FlowScheduleList struct {
+	Schedules []FlowSchedule `json:"schedules"`
+}
+
+// Forbidden defines model for Forbidden.
+type Forbidden struct {
+	// Message Error message indicating why the request is not allowed
+	Message string `json:"message"`
+}
+
+// GrantAccess Access granted on a resource. READ shows the resource, INVOKE also runs the agent or the tool or posts to the thread, MANAGE also changes the resource and its grants.
+type GrantAccess = db.GrantAccess
+
+// GranteeType Grantee of a grant, a user or the users of a role
+type GranteeType = db.GranteeType
+
+// ImportMessage Message of an imported conversation
+type ImportMessage struct {
+	// CreatedAt When the message was sent, defaults to the import time. The messages of a thread are ordered by it.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Message JSON message content
+	Message     db.JsonRaw `json:"message"`
+	RecipientId uuid.UUID  `json:"recipient_id"`
+	SenderId    uuid.UUID  `json:"sender_id"`
+
+	// SenderType Who sent the message
+	SenderType ImportMessageSenderType `json:"sender_type"`
+}
+
+// ImportMessageSenderType Who sent the message
+type ImportMessageSenderType string
+
+// ImportMessagesRequest defines model for ImportMessagesRequest.
+type ImportMessagesRequest struct {
+	// Messages Messages to add to the thread in their order, at most 1000
+	Messages []ImportMessage `json:"messages"`
+}
+
+// InvalidFlowParameters The run was rejected, its parameters do not match the parameters schema of the flow
+type InvalidFlowParameters struct {
+	// Message Error message indicating the bad request
+	Message string `json:"message"`
+
+	// Violations Violations of the parameters schema, empty when the request is invalid for another reason
+	Violations *[]ToolInputViolation `json:"violations,omitempty"`
+}
+
+// InvokeAgentRequest defines model for InvokeAgentRequest.
+type InvokeAgentRequest struct {
+	// Messages Messages of the conversation in the format of the model provider of the agent, the last one answered
+	Messages []db.JsonRaw `json:"messages"`
+}
+
+// InvokeAgentResponse defines model for InvokeAgentResponse.
+type InvokeAgentResponse struct {
+	AgentId uuid.UUID `json:"agent_id"`
+
+	// Message JSON content of the message of the agent
+	Message db.JsonRaw `json:"message"`
+
+	// StopReason Why the model stopped, e.g. end_turn, or tool_use when the message asks for tools
+	StopReason *string `json:"stop_reason,omitempty"`
+}
+
+// MCPTool defines model for MCPTool.
+type MCPTool struct {
+	// ApiKey Optional API key for the MCP tool, encrypted at rest and returned as [REDACTED]. Sending [REDACTED] back keeps the stored key. A secret reference such as vault://secret/tools/weather#api_key, aws-sm://name#key or env://NAME is stored as is and resolved when the tool is executed. Sent as a bearer token in the authorization metadata of the grpc calls.
+	ApiKey *string `json:"api_key"`
+
+	// Cache Result cache of an idempotent tool, identical calls within the TTL return the cached result without invoking the tool
+	Cache *ToolCache `json:"cache,omitempty"`
+
+	// Entrypoint MCP entry point for the tool
+	Entrypoint string `json:"entrypoint"`
+
+	// EnvVars Environment variables for the MCP tool
+	EnvVars *map[string]string `json:"env_vars"`
+
+	// HealthCheck Health check probed in the background by the tools service, either a URL answering with a 2xx status or a command exiting with 0
+	HealthCheck *ToolHealthCheck `json:"health_check,omitempty"`
+
+	// Limits Invocation limits of the tool, enforced by each tool service instance. Excess calls queue up to max_wait_seconds, then fail with a throttled error result.
+	Limits *ToolLimits `json:"limits,omitempty"`
+
+	// Mock Response returned instead of invoking the tool in dry runs
+	Mock *ToolMock `json:"mock,omitempty"`
+
+	// Params Parameter schema of the tool, discovered from the MCP server by the tools service for the grpc protocol. Agents are only offered the MCP tools whose schema is known.
+	Params *map[string]interface{} `json:"params,omitempty"`
+
+	// Protocol Protocol used by the MCP tool
+	Protocol db.MCPProtocol `json:"protocol"`
+
+	// Tls TLS settings of the connection to an MCP server speaking gRPC. The connection uses TLS unless the entrypoint has the grpc:// scheme, grpcs://host:port and host:port connect with TLS.
+	Tls *ToolMCPTLS `json:"tls,omitempty"`
+
+	// ToolName Name of the tool on the MCP server, defaults to the name of the tool
+	ToolName *string     `json:"tool_name,omitempty"`
+	Type     db.ToolType `json:"type"`
+}
+
+// Message defines model for Message.
+type Message = db.ThreadMessage
+
+// MessageList defines model for MessageList.
+type MessageList struct {
 	Messages   []Message `json:"messages"`
 	Page       int32     `json:"page"`
 	PerPage    int32     `json:"per_page"`
@@ -227,6 +1127,31 @@ type MessageList struct {
 	TotalPages int       `json:"total_pages"`
 }
 
+// MessageRevision Outcome of the edit or the regeneration of a message
+type MessageRevision struct {
+	Message *Message `json:"message,omitempty"`
+
+	// StreamUrl URL of the Server-Sent Events stream of the new task execution
+	StreamUrl *string `json:"stream_url,omitempty"`
+
+	// Superseded Number of messages superseded, they are kept as the history of the thread
+	Superseded int64    `json:"superseded"`
+	Task       *Task    `json:"task,omitempty"`
+	TaskRun    *TaskRun `json:"task_run,omitempty"`
+}
+
+// MessageSearchResult Message matching a search query
+type MessageSearchResult = db.SearchMessagesRow
+
+// MessageSearchResults defines model for MessageSearchResults.
+type MessageSearchResults struct {
+	Page       int32                 `json:"page"`
+	PerPage    int32                 `json:"per_page"`
+	Results    []MessageSearchResult `json:"results"`
+	Total      int                   `json:"total"`
+	TotalPages int                   `json:"total_pages"`
+}
+
 // MockToolRequest defines model for MockToolRequest.
 type MockToolRequest struct {
 	Input string `json:"input"`
@@ -261,6 +1186,20 @@ type NotFound struct {
 	Resource string `json:"resource"`
 }
 
+// Organization Organization grouping the workspaces of a team
+type Organization = db.Organization
+
+// OrganizationMember defines model for OrganizationMember.
+type OrganizationMember = db.OrganizationMember
+
+// OrganizationMemberList defines model for OrganizationMemberList.
+type OrganizationMemberList struct {
+	Members []OrganizationMember `json:"members"`
+}
+
+// OrganizationRole Role of a member of an organization. The owners and admins manage the members and the workspaces of the organization and act in all its workspaces, only the owners manage the other owners.
+type OrganizationRole = db.OrganizationRole
+
 // PaginationMeta defines model for PaginationMeta.
 type PaginationMeta struct {
 	Page       int32 `json:"page"`
@@ -269,6 +1208,33 @@ type PaginationMeta struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+// ParkedMessage defines model for ParkedMessage.
+type ParkedMessage struct {
+	// Consumer Consumer that detected the redelivery storm
+	Consumer string `json:"consumer"`
+
+	// Consumers Redeliveries of the message per consumer
+	Consumers map[string]int `json:"consumers"`
+	ParkedAt  time.Time      `json:"parked_at"`
+
+	// Payload Event payload of the message, a string when it is not valid JSON
+	Payload interface{} `json:"payload"`
+	Reason  string      `json:"reason"`
+
+	// Redeliveries Redeliveries of the message across every consumer of its stream
+	Redeliveries int `json:"redeliveries"`
+
+	// Sequence Sequence of the message in the dead letter stream
+	Sequence int64 `json:"sequence"`
+
+	// Stream Stream the message was consumed from
+	Stream string `json:"stream"`
+
+	// StreamSequence Sequence of the message in its original stream
+	StreamSequence int64  `json:"stream_sequence"`
+	Subject        string `json:"subject"`
+}
+
 // Permission defines model for Permission.
 type Permission = db.Permission
 
@@ -281,6 +1247,81 @@ type PermissionList struct {
 	TotalPages  int          `json:"total_pages"`
 }
 
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed struct {
+	// Etag Entity tag of the current version of the resource
+	Etag *string `json:"etag,omitempty"`
+
+	// Id The ID of the resource that changed
+	Id openapi_types.UUID `json:"id"`
+
+	// Message Error message indicating the resource changed since the version of the If-Match header
+	Message string `json:"message"`
+
+	// Resource The resource that changed
+	Resource string `json:"resource"`
+}
+
+// PromptCacheAnalytics defines model for PromptCacheAnalytics.
+type PromptCacheAnalytics struct {
+	Agents []AgentPromptCacheStats `json:"agents"`
+}
+
+// QuickstartRequest defines model for QuickstartRequest.
+type QuickstartRequest struct {
+	// Message Text of the user message sent to the default agent
+	Message string `json:"message"`
+
+	// Title Title of the created thread, the beginning of the message when omitted
+	Title *string `json:"title,omitempty"`
+}
+
+// QuickstartResponse defines model for QuickstartResponse.
+type QuickstartResponse struct {
+	// AgentId Default agent answering the message
+	AgentId uuid.UUID `json:"agent_id"`
+	Message Message   `json:"message"`
+
+	// StreamUrl URL of the Server-Sent Events stream of the task execution, replaying the events sent before the client connects
+	StreamUrl string  `json:"stream_url"`
+	Task      Task    `json:"task"`
+	TaskRun   TaskRun `json:"task_run"`
+	Thread    Thread  `json:"thread"`
+}
+
+// Quota Limit on the usage of a day, reset at midnight UTC, or on the task runs scheduled or running at once
+type Quota = db.Quota
+
+// QuotaExceeded defines model for QuotaExceeded.
+type QuotaExceeded struct {
+	Limit *int64 `json:"limit,omitempty"`
+
+	// Message Error message naming the exhausted quota and when it resets
+	Message string `json:"message"`
+
+	// Quota Limit on the usage of a day, reset at midnight UTC, or on the task runs scheduled or running at once
+	Quota *Quota `json:"quota,omitempty"`
+
+	// ResetsAt Time the request can be retried, unset for the concurrent tasks which free up as task runs end
+	ResetsAt *time.Time `json:"resets_at,omitempty"`
+
+	// Scope Usage limited by the quota, of the user over all its workspaces or of the workspace over all its users
+	Scope *QuotaScope `json:"scope,omitempty"`
+	Used  *float64    `json:"used,omitempty"`
+}
+
+// QuotaScope Usage limited by the quota, of the user over all its workspaces or of the workspace over all its users
+type QuotaScope = db.QuotaScope
+
+// QuotaStatus Usage of a quota
+type QuotaStatus = db.QuotaStatus
+
+// RegenerateMessageRequest defines model for RegenerateMessageRequest.
+type RegenerateMessageRequest struct {
+	// AgentId Agent answering again
+	AgentId uuid.UUID `json:"agent_id"`
+}
+
 // ResourceAlreadyExists defines model for ResourceAlreadyExists.
 type ResourceAlreadyExists struct {
 	// Id The ID of the resource that already exists
@@ -293,6 +1334,26 @@ type ResourceAlreadyExists struct {
 	Resource string `json:"resource"`
 }
 
+// ResourceChange defines model for ResourceChange.
+type ResourceChange = db.ResourceChange
+
+// ResourceGrant Access on an agent, a tool or a thread shared with a user or a role. The agents and the tools without grants are open to their workspace, the first grant restricts them to their creator and the grantees. The threads are always restricted to their owner and the grantees.
+type ResourceGrant = db.ResourceGrant
+
+// ResourceGrantList defines model for ResourceGrantList.
+type ResourceGrantList struct {
+	Grants []ResourceGrant `json:"grants"`
+}
+
+// ResourceHistory defines model for ResourceHistory.
+type ResourceHistory struct {
+	Changes    []ResourceChange `json:"changes"`
+	Page       int32            `json:"page"`
+	PerPage    int32            `json:"per_page"`
+	Total      int              `json:"total"`
+	TotalPages int              `json:"total_pages"`
+}
+
 // Role defines model for Role.
 type Role = db.Role
 
@@ -311,11 +1372,114 @@ type RolePermissionMapping = db.RolePermissionMapping
 // RolePermissionMappingList defines model for RolePermissionMappingList.
 type RolePermissionMappingList = []RolePermissionMapping
 
+// SemanticSearchResult Message near a semantic search query
+type SemanticSearchResult = db.SemanticSearchMessagesRow
+
+// SemanticSearchResults defines model for SemanticSearchResults.
+type SemanticSearchResults struct {
+	Results []SemanticSearchResult `json:"results"`
+}
+
+// ServiceInstance defines model for ServiceInstance.
+type ServiceInstance struct {
+	Description string `json:"description"`
+
+	// Id ID of the instance, generated when it starts
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	NumErrors   int64  `json:"num_errors"`
+	NumMessages int64  `json:"num_messages"`
+
+	// Started Start time of the instance, absent when its stats were not received in time
+	Started       *time.Time            `json:"started,omitempty"`
+	Subscriptions []ServiceSubscription `json:"subscriptions"`
+	UptimeSeconds int64                 `json:"uptime_seconds"`
+	Version       string                `json:"version"`
+}
+
+// ServiceOverview defines model for ServiceOverview.
+type ServiceOverview struct {
+	CollectedAt time.Time         `json:"collected_at"`
+	Instances   []ServiceInstance `json:"instances"`
+
+	// Services Totals of the instances of each service
+	Services []ServiceSummary `json:"services"`
+}
+
+// ServiceSubscription defines model for ServiceSubscription.
+type ServiceSubscription struct {
+	LastError *string `json:"last_error,omitempty"`
+
+	// NumErrors Messages whose handling failed
+	NumErrors int64 `json:"num_errors"`
+
+	// NumMessages Messages received on the subject since the instance started
+	NumMessages int64  `json:"num_messages"`
+	Subject     string `json:"subject"`
+}
+
+// ServiceSummary defines model for ServiceSummary.
+type ServiceSummary struct {
+	Instances   int    `json:"instances"`
+	Name        string `json:"name"`
+	NumErrors   int64  `json:"num_errors"`
+	NumMessages int64  `json:"num_messages"`
+}
+
+// SetOrganizationMemberRequest defines model for SetOrganizationMemberRequest.
+type SetOrganizationMemberRequest struct {
+	// Role Role of a member of an organization. The owners and admins manage the members and the workspaces of the organization and act in all its workspaces, only the owners manage the other owners.
+	Role OrganizationRole `json:"role"`
+}
+
+// SetResourceGrantRequest defines model for SetResourceGrantRequest.
+type SetResourceGrantRequest struct {
+	// Access Access granted on a resource. READ shows the resource, INVOKE also runs the agent or the tool or posts to the thread, MANAGE also changes the resource and its grants.
+	Access    GrantAccess `json:"access"`
+	GranteeId uuid.UUID   `json:"grantee_id"`
+
+	// GranteeType Grantee of a grant, a user or the users of a role
+	GranteeType GranteeType `json:"grantee_type"`
+}
+
+// SetUserDefaultAgentRequest defines model for SetUserDefaultAgentRequest.
+type SetUserDefaultAgentRequest struct {
+	// AgentId Agent answering the quickstart requests of the user, null to use the workspace default agent
+	AgentId *uuid.UUID `json:"agent_id"`
+}
+
+// SetWorkspaceMemberRequest defines model for SetWorkspaceMemberRequest.
+type SetWorkspaceMemberRequest struct {
+	// Role Role of a member of a workspace, the admins manage the members of the workspace
+	Role WorkspaceRole `json:"role"`
+}
+
+// SortOrder Order of a sorted list
+type SortOrder string
+
 // StandaloneTool defines model for StandaloneTool.
 type StandaloneTool struct {
-	// ApiKey Optional API KEY for the tool server
+	// ApiKey Optional API KEY for the tool server, encrypted at rest and returned as [REDACTED]. Sending [REDACTED] back keeps the stored key. A secret reference such as vault://secret/tools/weather#api_key, aws-sm://name#key or env://NAME is stored as is and resolved when the tool is executed.
 	ApiKey *string `json:"api_key,omitempty"`
 
+	// Cache Result cache of an idempotent tool, identical calls within the TTL return the cached result without invoking the tool
+	Cache *ToolCache `json:"cache,omitempty"`
+
+	// Edge Called by a worker of the edge group from its own network, e.g. a tool server inside a private network, instead of the tools service
+	Edge *bool `json:"edge,omitempty"`
+
+	// EdgeGroup Edge group of the workers calling the tool, defaults to "default". Only applicable to edge tools.
+	EdgeGroup *string `json:"edge_group,omitempty"`
+
+	// HealthCheck Health check probed in the background by the tools service, either a URL answering with a 2xx status or a command exiting with 0
+	HealthCheck *ToolHealthCheck `json:"health_check,omitempty"`
+
+	// Limits Invocation limits of the tool, enforced by each tool service instance. Excess calls queue up to max_wait_seconds, then fail with a throttled error result.
+	Limits *ToolLimits `json:"limits,omitempty"`
+
+	// Mock Response returned instead of invoking the tool in dry runs
+	Mock *ToolMock `json:"mock,omitempty"`
+
 	// Params JSON request structure for the tool
 	Params openapi3.Schema `json:"params"`
 	Type   db.ToolType     `json:"type"`
@@ -329,38 +1493,227 @@ type Task = db.Task
 
 // TaskList defines model for TaskList.
 type TaskList struct {
-	Page       int32  `json:"page"`
-	PerPage    int32  `json:"per_page"`
-	Tasks      []Task `json:"tasks"`
-	Total      int    `json:"total"`
-	TotalPages int    `json:"total_pages"`
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Tasks      []Task  `json:"tasks"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
 }
 
 // TaskRun defines model for TaskRun.
 type TaskRun = db.TasksRun
 
+// TaskSchedule defines model for TaskSchedule.
+type TaskSchedule = db.TaskSchedule
+
+// TaskScheduleList defines model for TaskScheduleList.
+type TaskScheduleList struct {
+	Schedules []TaskSchedule `json:"schedules"`
+}
+
+// TestToolRequest defines model for TestToolRequest.
+type TestToolRequest struct {
+	// Input Tool input, validated against the parameter schema of the tool
+	Input map[string]interface{} `json:"input"`
+
+	// Mock Response returned instead of invoking the tool in dry runs
+	Mock *ToolMock `json:"mock,omitempty"`
+
+	// Revision Revision to test, defaults to the promoted revision
+	Revision *int32 `json:"revision,omitempty"`
+}
+
+// TestToolResponse defines model for TestToolResponse.
+type TestToolResponse struct {
+	// IsError Whether the result would reach the agent as an error tool result
+	IsError bool `json:"is_error"`
+
+	// Result Mock response of the tool, the request mock when given, else the mock configured on the tool
+	Result interface{} `json:"result,omitempty"`
+
+	// Revision Tested revision
+	Revision int32     `json:"revision"`
+	ToolId   uuid.UUID `json:"tool_id"`
+
+	// Valid Whether the input matches the parameter schema, the mock response is only returned for a valid input
+	Valid      bool                 `json:"valid"`
+	Violations []ToolInputViolation `json:"violations"`
+}
+
+// ThinkingBudgetAnalytics defines model for ThinkingBudgetAnalytics.
+type ThinkingBudgetAnalytics struct {
+	Agents []AgentThinkingBudgetStats `json:"agents"`
+}
+
 // Thread defines model for Thread.
 type Thread = db.Thread
 
+// ThreadExport Portable export of a thread with its messages and tool runs, imported as a new thread.
+type ThreadExport = db.ThreadExport
+
+// ThreadExportMessage defines model for ThreadExportMessage.
+type ThreadExportMessage = db.ThreadExportMessage
+
+// ThreadExportToolRun defines model for ThreadExportToolRun.
+type ThreadExportToolRun = db.ThreadExportToolRun
+
 // ThreadList defines model for ThreadList.
 type ThreadList struct {
-	Page       int32    `json:"page"`
-	PerPage    int32    `json:"per_page"`
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string  `json:"next_cursor,omitempty"`
 	Threads    []Thread `json:"threads"`
-	Total      int      `json:"total"`
-	TotalPages int      `json:"total_pages"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
 }
 
 // Tool defines model for Tool.
 type Tool = db.Tool
 
+// ToolBundle Declarative export of tools, imported into another environment. The API keys are redacted, the secret references are kept as is.
+type ToolBundle = db.ToolBundle
+
+// ToolBundleEntry defines model for ToolBundleEntry.
+type ToolBundleEntry = db.ToolBundleEntry
+
+// ToolBundleImportResponse defines model for ToolBundleImportResponse.
+type ToolBundleImportResponse struct {
+	Results []ToolBundleImportResult `json:"results"`
+}
+
+// ToolBundleImportResult defines model for ToolBundleImportResult.
+type ToolBundleImportResult = db.ToolBundleImportResult
+
+// ToolCache Result cache of an idempotent tool, identical calls within the TTL return the cached result without invoking the tool
+type ToolCache = db.ToolCache
+
+// ToolCatalog defines model for ToolCatalog.
+type ToolCatalog struct {
+	Categories []ToolCatalogCategory `json:"categories"`
+
+	// Total Total number of tools in the catalog
+	Total int `json:"total"`
+}
+
+// ToolCatalogCategory defines model for ToolCatalogCategory.
+type ToolCatalogCategory struct {
+	// Count Number of tools in the category
+	Count int `json:"count"`
+
+	// Name Category name, tools without a category are grouped under "uncategorized"
+	Name  string `json:"name"`
+	Tools []Tool `json:"tools"`
+}
+
+// ToolExample defines model for ToolExample.
+type ToolExample struct {
+	// Description What the example invocation demonstrates
+	Description *string `json:"description,omitempty"`
+
+	// Input Example input arguments passed to the tool
+	Input map[string]interface{} `json:"input"`
+
+	// Title Short title of the example
+	Title string `json:"title"`
+}
+
+// ToolHealthCheck Health check probed in the background by the tools service, either a URL answering with a 2xx status or a command exiting with 0
+type ToolHealthCheck = db.ToolHealthCheck
+
+// ToolInputViolation defines model for ToolInputViolation.
+type ToolInputViolation = db.ToolInputViolation
+
+// ToolLimits Invocation limits of the tool, enforced by each tool service instance. Excess calls queue up to max_wait_seconds, then fail with a throttled error result.
+type ToolLimits = db.ToolLimits
+
 // ToolList defines model for ToolList.
 type ToolList struct {
-	Page       int32  `json:"page"`
-	PerPage    int32  `json:"per_page"`
-	Tools      []Tool `json:"tools"`
-	Total      int    `json:"total"`
-	TotalPages int    `json:"total_pages"`
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Tools      []Tool  `json:"tools"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
+}
+
+// ToolMCPTLS TLS settings of the connection to an MCP server speaking gRPC. The connection uses TLS unless the entrypoint has the grpc:// scheme, grpcs://host:port and host:port connect with TLS.
+type ToolMCPTLS struct {
+	// CaCert PEM certificates of the authorities trusted for the server, the system roots when empty
+	CaCert *string `json:"ca_cert,omitempty"`
+
+	// InsecureSkipVerify Skip the verification of the server certificate, for development servers only
+	InsecureSkipVerify *bool `json:"insecure_skip_verify,omitempty"`
+
+	// ServerName Name checked against the server certificate, the host of the entrypoint when empty
+	ServerName *string `json:"server_name,omitempty"`
+}
+
+// ToolMock Response returned instead of invoking the tool in dry runs
+type ToolMock = db.ToolMock
+
+// ToolRevision defines model for ToolRevision.
+type ToolRevision = db.ToolRevision
+
+// ToolRevisionList defines model for ToolRevisionList.
+type ToolRevisionList struct {
+	// PromotedRevision Revision currently promoted on the tool
+	PromotedRevision int32          `json:"promoted_revision"`
+	Revisions        []ToolRevision `json:"revisions"`
+}
+
+// ToolRunAudit defines model for ToolRunAudit.
+type ToolRunAudit = db.ToolRunAudit
+
+// ToolRunAuditList defines model for ToolRunAuditList.
+type ToolRunAuditList struct {
+	Entries    []ToolRunAudit `json:"entries"`
+	Page       int32          `json:"page"`
+	PerPage    int32          `json:"per_page"`
+	Total      int            `json:"total"`
+	TotalPages int            `json:"total_pages"`
+}
+
+// ToolSchemaChange defines model for ToolSchemaChange.
+type ToolSchemaChange = db.ToolSchemaChange
+
+// ToolSchemaDiff defines model for ToolSchemaDiff.
+type ToolSchemaDiff struct {
+	// Breaking Whether any of the changes is breaking
+	Breaking     bool               `json:"breaking"`
+	Changes      []ToolSchemaChange `json:"changes"`
+	FromRevision int32              `json:"from_revision"`
+	ToRevision   int32              `json:"to_revision"`
+	ToolId       uuid.UUID          `json:"tool_id"`
+}
+
+// UpdateAgentProbeRequest defines model for UpdateAgentProbeRequest.
+type UpdateAgentProbeRequest struct {
+	DryRun          *bool   `json:"dry_run,omitempty"`
+	Enabled         *bool   `json:"enabled,omitempty"`
+	ExpectedPattern *string `json:"expected_pattern,omitempty"`
+	IntervalSeconds *int32  `json:"interval_seconds,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	Prompt          *string `json:"prompt,omitempty"`
+	TimeoutSeconds  *int32  `json:"timeout_seconds,omitempty"`
 }
 
 // UpdateAgentRequest defines model for UpdateAgentRequest.
@@ -377,25 +1730,54 @@ type UpdateFlowRequest struct {
 	// AdditionalInfo Additional information related to the flow
 	AdditionalInfo *map[string]interface{} `json:"additional_info,omitempty"`
 
-	// CodeLocation Location of the code for the flow
+	// CodeLocation Location of the code for the flow, a local path, an s3://bucket/key URL, a git+https://host/repo.git@ref#subdirectory=path repository or an oci://registry/repository:tag image
 	CodeLocation *string `json:"code_location,omitempty"`
 
+	// ConcurrencyPolicy What happens to the runs beyond max_concurrent_runs, QUEUE (default) runs them in order once runs of the flow end, REJECT fails their request
+	ConcurrencyPolicy *UpdateFlowRequestConcurrencyPolicy `json:"concurrency_policy,omitempty"`
+
 	// Description Description of the flow
 	Description *string `json:"description,omitempty"`
 
-	// Engine The engine used for the flow
+	// Engine The engine used for the flow, process for a Python process, node for a Node.js process (entrypoint node or tsx) or docker
 	Engine *string `json:"engine,omitempty"`
 
-	// Entrypoint Entrypoint for the flow
+	// Entrypoint Entrypoint for the flow, empty to run the entrypoint of the image of an oci:// code location
 	Entrypoint *string `json:"entrypoint,omitempty"`
 
+	// MaxConcurrentRuns Runs of the flow scheduled or running at once, to protect the systems the flow calls. 0 removes the limit
+	MaxConcurrentRuns *int32 `json:"max_concurrent_runs,omitempty"`
+
 	// Name Name of the flow
 	Name *string `json:"name,omitempty"`
 
+	// Notifications Webhooks and Slack channels notified when a run of the flow succeeds or fails
+	Notifications *[]FlowNotification `json:"notifications,omitempty"`
+
+	// RequiredLabels Labels a worker must advertise to execute the runs of the flow, e.g. gpu or python3.12. The runs are routed to the workers advertising every label
+	RequiredLabels *[]string `json:"required_labels,omitempty"`
+
 	// Tags Tags associated with the flow
 	Tags *[]string `json:"tags,omitempty"`
 }
 
+// UpdateFlowRequestConcurrencyPolicy What happens to the runs beyond max_concurrent_runs, QUEUE (default) runs them in order once runs of the flow end, REJECT fails their request
+type UpdateFlowRequestConcurrencyPolicy string
+
+// UpdateFlowScheduleRequest defines model for UpdateFlowScheduleRequest.
+type UpdateFlowScheduleRequest struct {
+	CatchUpPolicy  *UpdateFlowScheduleRequestCatchUpPolicy `json:"catch_up_policy,omitempty"`
+	CronExpression *string                                 `json:"cron_expression,omitempty"`
+	Enabled        *bool                                   `json:"enabled,omitempty"`
+	Engine         *string                                 `json:"engine,omitempty"`
+	Name           *string                                 `json:"name,omitempty"`
+	Parameters     *map[string]interface{}                 `json:"parameters,omitempty"`
+	Timezone       *string                                 `json:"timezone,omitempty"`
+}
+
+// UpdateFlowScheduleRequestCatchUpPolicy defines model for UpdateFlowScheduleRequest.CatchUpPolicy.
+type UpdateFlowScheduleRequestCatchUpPolicy string
+
 // UpdateMessageRequest defines model for UpdateMessageRequest.
 type UpdateMessageRequest struct {
 	// Message JSON message content
@@ -428,6 +1810,23 @@ type UpdateTaskRequest struct {
 	MaxRequestLoop *int `json:"max_request_loop,omitempty"`
 }
 
+// UpdateTaskScheduleRequest defines model for UpdateTaskScheduleRequest.
+type UpdateTaskScheduleRequest struct {
+	AgentId        *openapi_types.UUID                     `json:"agent_id,omitempty"`
+	CronExpression *string                                 `json:"cron_expression,omitempty"`
+	Enabled        *bool                                   `json:"enabled,omitempty"`
+	Name           *string                                 `json:"name,omitempty"`
+	OverlapPolicy  *UpdateTaskScheduleRequestOverlapPolicy `json:"overlap_policy,omitempty"`
+	Prompt         *string                                 `json:"prompt,omitempty"`
+
+	// ThreadId Moves the runs of a schedule to another thread, a schedule without thread keeps creating a new thread when not set
+	ThreadId *openapi_types.UUID `json:"thread_id,omitempty"`
+	Timezone *string             `json:"timezone,omitempty"`
+}
+
+// UpdateTaskScheduleRequestOverlapPolicy defines model for UpdateTaskScheduleRequest.OverlapPolicy.
+type UpdateTaskScheduleRequestOverlapPolicy string
+
 // UpdateThreadRequest defines model for UpdateThreadRequest.
 type UpdateThreadRequest struct {
 	Title string `json:"title"`
@@ -435,8 +1834,20 @@ type UpdateThreadRequest struct {
 
 // UpdateToolRequest defines model for UpdateToolRequest.
 type UpdateToolRequest struct {
+	// Category Catalog category used to group the tool
+	Category    *pgtype.Text   `json:"category"`
 	Config      *db.ToolConfig `json:"config,omitempty"`
 	Description *pgtype.Text   `json:"description"`
+
+	// Documentation Markdown documentation for the tool
+	Documentation *pgtype.Text `json:"documentation"`
+
+	// Examples Example invocations of the tool
+	Examples *[]ToolExample `json:"examples,omitempty"`
+
+	// Icon Icon name or URL displayed in the catalog
+	Icon *pgtype.Text `json:"icon"`
+	Tags *[]string    `json:"tags,omitempty"`
 }
 
 // UpdateUserRequest defines model for UpdateUserRequest.
@@ -450,26 +1861,162 @@ type UpdateUserRequest struct {
 	Username     *string          `json:"username,omitempty"`
 }
 
-// User defines model for User.
-type User = db.GetUsersRow
+// UpdateWebhookRequest Fields of the webhook to update, the fields not set are kept
+type UpdateWebhookRequest struct {
+	Description *string         `json:"description,omitempty"`
+	Enabled     *bool           `json:"enabled,omitempty"`
+	Events      *[]WebhookEvent `json:"events,omitempty"`
 
-// UserList defines model for UserList.
-type UserList struct {
-	Page       int32  `json:"page"`
-	PerPage    int32  `json:"per_page"`
-	Total      int    `json:"total"`
-	TotalPages int    `json:"total_pages"`
+	// Url Absolute http or https URL the events are posted to
+	Url *string `json:"url,omitempty"`
+}
+
+// UploadFileRequest defines model for UploadFileRequest.
+type UploadFileRequest struct {
+	// File Content of the file, its content type is detected when the part has none
+	File openapi_types.File `json:"file"`
+
+	// Name Name of the file, the file name of the part by default
+	Name *string `json:"name,omitempty"`
+}
+
+// UsageReport defines model for UsageReport.
+type UsageReport struct {
+	Quotas []QuotaStatus      `json:"quotas"`
+	UserId openapi_types.UUID `json:"user_id"`
+
+	// WorkspaceId Workspace the request acts in
+	WorkspaceId openapi_types.UUID `json:"workspace_id"`
+}
+
+// User defines model for User.
+type User = db.GetUsersRow
+
+// UserDefaultAgent defines model for UserDefaultAgent.
+type UserDefaultAgent struct {
+	AgentId *uuid.UUID `json:"agent_id"`
+	UserId  uuid.UUID  `json:"user_id"`
+}
+
+// UserFlowCostAnalytics defines model for UserFlowCostAnalytics.
+type UserFlowCostAnalytics struct {
+	Users []UserFlowCostStats `json:"users"`
+}
+
+// UserFlowCostStats defines model for UserFlowCostStats.
+type UserFlowCostStats = db.ListUserFlowCostsRow
+
+// UserList defines model for UserList.
+type UserList struct {
+	Page       int32  `json:"page"`
+	PerPage    int32  `json:"per_page"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
 	Users      []User `json:"users"`
 }
 
+// UserOrganization Organization of the user, with the role of the user
+type UserOrganization = db.ListUserOrganizationsRow
+
+// UserOrganizationList defines model for UserOrganizationList.
+type UserOrganizationList struct {
+	Organizations []UserOrganization `json:"organizations"`
+}
+
 // UserRoleMapping defines model for UserRoleMapping.
 type UserRoleMapping = db.UserRoleMapping
 
 // UserRoleMappingList defines model for UserRoleMappingList.
 type UserRoleMappingList = []UserRoleMapping
 
+// Webhook Webhook subscription of a user, the platform events matching its events are posted to its URL signed with its secret. The secret is only returned when it is created or rotated.
+type Webhook struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Description *string   `json:"description"`
+
+	// Enabled Whether the events are delivered, the pending deliveries of a disabled webhook wait until it is enabled again
+	Enabled   bool           `json:"enabled"`
+	Events    []WebhookEvent `json:"events"`
+	Id        uuid.UUID      `json:"id"`
+	UpdatedAt time.Time      `json:"updated_at"`
+
+	// Url URL the events are posted to
+	Url    string    `json:"url"`
+	UserId uuid.UUID `json:"user_id"`
+}
+
+// WebhookDelivery Delivery of an event to a webhook, attempted until the endpoint answers with a 2xx or the attempts run out
+type WebhookDelivery struct {
+	Attempts    int32      `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+
+	// ErrorMessage Error of the last failed attempt
+	ErrorMessage *string `json:"error_message"`
+
+	// EventId ID of the event, sent as X-Pinazu-Event-Id and the same for every attempt
+	EventId uuid.UUID `json:"event_id"`
+
+	// EventType Platform event a webhook subscribes to. task.finished is sent for the tasks of the owner of the webhook, the flow run events for the runs of every flow.
+	EventType WebhookEvent `json:"event_type"`
+	Id        uuid.UUID    `json:"id"`
+
+	// NextAttemptAt Time of the next attempt of a pending delivery
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+
+	// Payload Body posted to the webhook
+	Payload db.JsonRaw `json:"payload"`
+
+	// ResponseStatus Error status answered to the last attempt, null when it succeeded or got no response
+	ResponseStatus *int32 `json:"response_status"`
+
+	// Status PENDING until the delivery succeeds, FAILED after the last attempt or on a client error of the endpoint
+	Status    WebhookDeliveryStatus `json:"status"`
+	WebhookId uuid.UUID             `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus PENDING until the delivery succeeds, FAILED after the last attempt or on a client error of the endpoint
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryList defines model for WebhookDeliveryList.
+type WebhookDeliveryList struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+
+	// HasMore Whether pages follow this one
+	HasMore bool `json:"has_more"`
+
+	// Limit Maximum number of items of the page
+	Limit int32 `json:"limit"`
+
+	// NextCursor Cursor of the next page, only set when has_more is true
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// Total Number of items of the list across all the pages
+	Total int `json:"total"`
+}
+
+// WebhookEvent Platform event a webhook subscribes to. task.finished is sent for the tasks of the owner of the webhook, the flow run events for the runs of every flow.
+type WebhookEvent string
+
+// WebhookList defines model for WebhookList.
+type WebhookList struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
 // WorkflowTool defines model for WorkflowTool.
 type WorkflowTool struct {
+	// Cache Result cache of an idempotent tool, identical calls within the TTL return the cached result without invoking the tool
+	Cache *ToolCache `json:"cache,omitempty"`
+
+	// HealthCheck Health check probed in the background by the tools service, either a URL answering with a 2xx status or a command exiting with 0
+	HealthCheck *ToolHealthCheck `json:"health_check,omitempty"`
+
+	// Limits Invocation limits of the tool, enforced by each tool service instance. Excess calls queue up to max_wait_seconds, then fail with a throttled error result.
+	Limits *ToolLimits `json:"limits,omitempty"`
+
+	// Mock Response returned instead of invoking the tool in dry runs
+	Mock *ToolMock `json:"mock,omitempty"`
+
 	// Params JSON request structure for the tool
 	Params openapi3.Schema `json:"params"`
 
@@ -478,12 +2025,223 @@ type WorkflowTool struct {
 	Type  db.ToolType `json:"type"`
 }
 
+// Workspace Workspace isolating the agents, tools, threads and flows of its members. The requests act in the workspace selected by the X-Pinazu-Workspace header, the default workspace open to every user when the header is missing.
+type Workspace = db.Workspace
+
+// WorkspaceList defines model for WorkspaceList.
+type WorkspaceList struct {
+	Workspaces []Workspace `json:"workspaces"`
+}
+
+// WorkspaceMember defines model for WorkspaceMember.
+type WorkspaceMember = db.WorkspaceMember
+
+// WorkspaceMemberList defines model for WorkspaceMemberList.
+type WorkspaceMemberList struct {
+	Members []WorkspaceMember `json:"members"`
+}
+
+// WorkspaceRole Role of a member of a workspace, the admins manage the members of the workspace
+type WorkspaceRole = db.WorkspaceRole
+
+// CreatedAfterParam defines model for createdAfterParam.
+type CreatedAfterParam = time.Time
+
+// CreatedBeforeParam defines model for createdBeforeParam.
+type CreatedBeforeParam = time.Time
+
+// CreatedByParam defines model for createdByParam.
+type CreatedByParam = openapi_types.UUID
+
+// CursorParam defines model for cursorParam.
+type CursorParam = string
+
+// DeletedParam defines model for deletedParam.
+type DeletedParam = bool
+
+// IdempotencyKeyParam defines model for idempotencyKeyParam.
+type IdempotencyKeyParam = string
+
+// IfMatchParam defines model for ifMatchParam.
+type IfMatchParam = string
+
+// LimitParam defines model for limitParam.
+type LimitParam = int32
+
+// OrderParam Order of a sorted list
+type OrderParam = SortOrder
+
 // PageParam defines model for pageParam.
 type PageParam = int32
 
 // PerPageParam defines model for perPageParam.
 type PerPageParam = int32
 
+// ListDeadLettersParams defines parameters for ListDeadLetters.
+type ListDeadLettersParams struct {
+	// PerPage Limits the number of returned results
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Page Page number for paginated results
+	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// ListServicesParams defines parameters for ListServices.
+type ListServicesParams struct {
+	// TimeoutMs Time to wait for the responses of the instances, in milliseconds
+	TimeoutMs *int `form:"timeout_ms,omitempty" json:"timeout_ms,omitempty"`
+}
+
+// ListAgentsParams defines parameters for ListAgents.
+type ListAgentsParams struct {
+	// Deleted List the items in the trash instead of the live ones
+	Deleted *DeletedParam `form:"deleted,omitempty" json:"deleted,omitempty"`
+
+	// Limit Maximum number of items of the page, from 1 to 100
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor of the page to return, the next_cursor of the previous page. The first page is returned without it.
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// UpdateAgentParams defines parameters for UpdateAgent.
+type UpdateAgentParams struct {
+	// IfMatch Entity tags of the versions of the resource the update applies to, as returned in the ETag header of its GET response. The update is rejected with 412 when the resource changed since, "*" matches any version. The update applies to the current version when omitted.
+	IfMatch *IfMatchParam `json:"If-Match,omitempty"`
+}
+
+// GetAgentHistoryParams defines parameters for GetAgentHistory.
+type GetAgentHistoryParams struct {
+	// PerPage Limits the number of returned results
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Page Page number for paginated results
+	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// InvokeAgentParams defines parameters for InvokeAgent.
+type InvokeAgentParams struct {
+	// Stream Stream the events of the model as Server-Sent Events
+	Stream *bool `form:"stream,omitempty" json:"stream,omitempty"`
+}
+
+// ListAgentProbeRunsParams defines parameters for ListAgentProbeRuns.
+type ListAgentProbeRunsParams struct {
+	// PerPage Limits the number of returned results
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Page Page number for paginated results
+	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// GetFlowCostAnalyticsParams defines parameters for GetFlowCostAnalytics.
+type GetFlowCostAnalyticsParams struct {
+	// Since Only the runs created at or after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only the runs created before this time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// GetUserFlowCostAnalyticsParams defines parameters for GetUserFlowCostAnalytics.
+type GetUserFlowCostAnalyticsParams struct {
+	// Since Only the runs created at or after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only the runs created before this time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// ListToolRunAuditParams defines parameters for ListToolRunAudit.
+type ListToolRunAuditParams struct {
+	// ToolId Only the runs of this tool
+	ToolId *openapi_types.UUID `form:"tool_id,omitempty" json:"tool_id,omitempty"`
+
+	// AgentId Only the runs called by this agent
+	AgentId *openapi_types.UUID `form:"agent_id,omitempty" json:"agent_id,omitempty"`
+
+	// UserId Only the runs triggered for this user
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// TaskId Only the runs of this task
+	TaskId *string `form:"task_id,omitempty" json:"task_id,omitempty"`
+
+	// Status Only the runs with this status
+	Status *ListToolRunAuditParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Since Only the runs started at or after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only the runs started before this time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// PerPage Limits the number of returned results
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Page Page number for paginated results
+	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// ListToolRunAuditParamsStatus defines parameters for ListToolRunAudit.
+type ListToolRunAuditParamsStatus string
+
+// ListFeedbackParams defines parameters for ListFeedback.
+type ListFeedbackParams struct {
+	// Rating Only the feedback with this rating
+	Rating *FeedbackRating `form:"rating,omitempty" json:"rating,omitempty"`
+
+	// ThreadId Only the feedback on this thread
+	ThreadId *openapi_types.UUID `form:"thread_id,omitempty" json:"thread_id,omitempty"`
+
+	// UserId Only the feedback of this user, the workspace admins only can list the feedback of other users
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Category Only the feedback with this category
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Since Only the feedback given at or after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only the feedback given before this time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// PerPage Limits the number of returned results
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Page Page number for paginated results
+	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// ExportFeedbackParams defines parameters for ExportFeedback.
+type ExportFeedbackParams struct {
+	// Rating Only the feedback with this rating
+	Rating *FeedbackRating `form:"rating,omitempty" json:"rating,omitempty"`
+
+	// ThreadId Only the feedback on this thread
+	ThreadId *openapi_types.UUID `form:"thread_id,omitempty" json:"thread_id,omitempty"`
+
+	// UserId Only the feedback of this user, the workspace admins only can list the feedback of other users
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Category Only the feedback with this category
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Since Only the feedback given at or after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only the feedback given before this time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// ListFilesParams defines parameters for ListFiles.
+type ListFilesParams struct {
+	// Limit Maximum number of items of the page, from 1 to 100
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor of the page to return, the next_cursor of the previous page. The first page is returned without it.
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
 // ListFlowsParams defines parameters for ListFlows.
 type ListFlowsParams struct {
 	// PerPage Limits the number of returned results
@@ -491,10 +2249,19 @@ type ListFlowsParams struct {
 
 	// Page Page number for paginated results
 	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+
+	// Deleted List the items in the trash instead of the live ones
+	Deleted *DeletedParam `form:"deleted,omitempty" json:"deleted,omitempty"`
 }
 
-// ListTasksParams defines parameters for ListTasks.
-type ListTasksParams struct {
+// ExecuteFlowParams defines parameters for ExecuteFlow.
+type ExecuteFlowParams struct {
+	// IdempotencyKey Unique key of the request, up to 255 characters, to retry it safely. The response of the first request of a key is replayed for 24 hours to its retries with the Idempotent-Replayed header. A retry while the first request is in progress is rejected with 409, and a key reused for another request with 422. A key whose request failed with a 5xx response can be retried.
+	IdempotencyKey *IdempotencyKeyParam `json:"Idempotency-Key,omitempty"`
+}
+
+// GetFlowHistoryParams defines parameters for GetFlowHistory.
+type GetFlowHistoryParams struct {
 	// PerPage Limits the number of returned results
 	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
 
@@ -502,134 +2269,755 @@ type ListTasksParams struct {
 	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
 }
 
-// CreateAgentJSONRequestBody defines body for CreateAgent for application/json ContentType.
-type CreateAgentJSONRequestBody = CreateAgentRequest
+// ListFlowRunsParams defines parameters for ListFlowRuns.
+type ListFlowRunsParams struct {
+	// Status Only return the runs with this status
+	Status *ListFlowRunsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
 
-// UpdateAgentJSONRequestBody defines body for UpdateAgent for application/json ContentType.
-type UpdateAgentJSONRequestBody = UpdateAgentRequest
+	// CreatedAfter Only return the items created at or after this date
+	CreatedAfter *CreatedAfterParam `form:"created_after,omitempty" json:"created_after,omitempty"`
 
-// AddPermissionToAgentJSONRequestBody defines body for AddPermissionToAgent for application/json ContentType.
-type AddPermissionToAgentJSONRequestBody = AddPermissionToAgentRequest
+	// CreatedBefore Only return the items created before this date
+	CreatedBefore *CreatedBeforeParam `form:"created_before,omitempty" json:"created_before,omitempty"`
 
-// CreateFlowJSONRequestBody defines body for CreateFlow for application/json ContentType.
-type CreateFlowJSONRequestBody = CreateFlowRequest
+	// Sort Field the runs are sorted by, defaults to created_at
+	Sort *ListFlowRunsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
 
-// UpdateFlowJSONRequestBody defines body for UpdateFlow for application/json ContentType.
-type UpdateFlowJSONRequestBody = UpdateFlowRequest
+	// Order Sort order, defaults to descending for the dates and ascending otherwise
+	Order *OrderParam `form:"order,omitempty" json:"order,omitempty"`
 
-// ExecuteFlowJSONRequestBody defines body for ExecuteFlow for application/json ContentType.
-type ExecuteFlowJSONRequestBody = ExecuteFlowRequest
+	// Limit Maximum number of items of the page, from 1 to 100
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
 
-// MockStandaloneToolJSONRequestBody defines body for MockStandaloneTool for application/json ContentType.
-type MockStandaloneToolJSONRequestBody = MockToolRequest
+	// Cursor Opaque cursor of the page to return, the next_cursor of the previous page. The first page is returned without it.
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
 
-// MockStandaloneToolWithDelayJSONRequestBody defines body for MockStandaloneToolWithDelay for application/json ContentType.
-type MockStandaloneToolWithDelayJSONRequestBody = MockToolWithDelayRequest
+// ListFlowRunsParamsStatus defines parameters for ListFlowRuns.
+type ListFlowRunsParamsStatus string
 
-// CreatePermissionJSONRequestBody defines body for CreatePermission for application/json ContentType.
-type CreatePermissionJSONRequestBody = CreatePermissionRequest
+// ListFlowRunsParamsSort defines parameters for ListFlowRuns.
+type ListFlowRunsParamsSort string
 
-// UpdatePermissionJSONRequestBody defines body for UpdatePermission for application/json ContentType.
-type UpdatePermissionJSONRequestBody = UpdatePermissionRequest
+// GetFlowRunLogsParams defines parameters for GetFlowRunLogs.
+type GetFlowRunLogsParams struct {
+	// After Only the chunks written after this cursor, the id of the last chunk read
+	After *int64 `form:"after,omitempty" json:"after,omitempty"`
 
-// CreateRoleJSONRequestBody defines body for CreateRole for application/json ContentType.
-type CreateRoleJSONRequestBody = CreateRoleRequest
+	// Limit Maximum number of chunks returned, default 500, at most 5000. Ignored with follow
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 
-// UpdateRoleJSONRequestBody defines body for UpdateRole for application/json ContentType.
-type UpdateRoleJSONRequestBody = UpdateRoleRequest
+	// Follow Streams the chunks as Server-Sent Events until the run ends
+	Follow *bool `form:"follow,omitempty" json:"follow,omitempty"`
+}
 
-// AddPermissionToRoleJSONRequestBody defines body for AddPermissionToRole for application/json ContentType.
-type AddPermissionToRoleJSONRequestBody = AddPermissionToRoleRequest
+// QuickstartParams defines parameters for Quickstart.
+type QuickstartParams struct {
+	// Events Comma-separated stream event classes sent to the client, among text, thinking, tool, message and lifecycle. A class prefixed with "-" is excluded, e.g. "-thinking". Every event is sent when omitted, errors are always sent.
+	Events *string `form:"events,omitempty" json:"events,omitempty"`
+}
 
-// CreateTaskJSONRequestBody defines body for CreateTask for application/json ContentType.
-type CreateTaskJSONRequestBody = CreateTaskRequest
+// SearchMessagesParams defines parameters for SearchMessages.
+type SearchMessagesParams struct {
+	// Q Search query
+	Q string `form:"q" json:"q"`
 
-// UpdateTaskJSONRequestBody defines body for UpdateTask for application/json ContentType.
-type UpdateTaskJSONRequestBody = UpdateTaskRequest
+	// ThreadId Only search the messages of this thread
+	ThreadId *openapi_types.UUID `form:"thread_id,omitempty" json:"thread_id,omitempty"`
 
-// ExecuteTaskJSONRequestBody defines body for ExecuteTask for application/json ContentType.
-type ExecuteTaskJSONRequestBody = ExecuteTaskRequest
+	// PerPage Limits the number of returned results
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
 
-// CreateThreadJSONRequestBody defines body for CreateThread for application/json ContentType.
-type CreateThreadJSONRequestBody = CreateThreadRequest
+	// Page Page number for paginated results
+	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+}
 
-// UpdateThreadTitleJSONRequestBody defines body for UpdateThreadTitle for application/json ContentType.
-type UpdateThreadTitleJSONRequestBody = UpdateThreadRequest
+// SemanticSearchMessagesParams defines parameters for SemanticSearchMessages.
+type SemanticSearchMessagesParams struct {
+	// Q Search query
+	Q string `form:"q" json:"q"`
 
-// CreateMessageJSONRequestBody defines body for CreateMessage for application/json ContentType.
-type CreateMessageJSONRequestBody = CreateMessageRequest
+	// ThreadId Only search the messages of this thread
+	ThreadId *openapi_types.UUID `form:"thread_id,omitempty" json:"thread_id,omitempty"`
 
-// UpdateMessageJSONRequestBody defines body for UpdateMessage for application/json ContentType.
-type UpdateMessageJSONRequestBody = UpdateMessageRequest
+	// Limit Maximum number of messages returned, default 10
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
 
-// CreateToolJSONRequestBody defines body for CreateTool for application/json ContentType.
-type CreateToolJSONRequestBody = CreateToolRequest
+// ListTasksParams defines parameters for ListTasks.
+type ListTasksParams struct {
+	// Status Only return the tasks whose latest run has this status
+	Status *ListTasksParamsStatus `form:"status,omitempty" json:"status,omitempty"`
 
-// UpdateToolJSONRequestBody defines body for UpdateTool for application/json ContentType.
-type UpdateToolJSONRequestBody = UpdateToolRequest
+	// CreatedBy Only return the items created by this user
+	CreatedBy *CreatedByParam `form:"created_by,omitempty" json:"created_by,omitempty"`
 
-// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
-type CreateUserJSONRequestBody = CreateUserRequest
+	// CreatedAfter Only return the items created at or after this date
+	CreatedAfter *CreatedAfterParam `form:"created_after,omitempty" json:"created_after,omitempty"`
 
-// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
-type UpdateUserJSONRequestBody = UpdateUserRequest
+	// CreatedBefore Only return the items created before this date
+	CreatedBefore *CreatedBeforeParam `form:"created_before,omitempty" json:"created_before,omitempty"`
 
-// AddRoleToUserJSONRequestBody defines body for AddRoleToUser for application/json ContentType.
-type AddRoleToUserJSONRequestBody = AddRoleToUserRequest
+	// Sort Field the tasks are sorted by, defaults to created_at
+	Sort *ListTasksParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// List all agents
-	// (GET /v1/agents)
-	ListAgents(w http.ResponseWriter, r *http.Request)
-	// Create a new agent
-	// (POST /v1/agents)
-	CreateAgent(w http.ResponseWriter, r *http.Request)
-	// Delete agent
-	// (DELETE /v1/agents/{agent_id})
-	DeleteAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
-	// Get agent by ID
-	// (GET /v1/agents/{agent_id})
-	GetAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
-	// Update agent
-	// (PUT /v1/agents/{agent_id})
-	UpdateAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
-	// List permissions for agent mapping
-	// (GET /v1/agents/{agent_id}/permissions)
-	ListPermissionsForAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
-	// Add permission to agent
-	// (POST /v1/agents/{agent_id}/permissions)
-	AddPermissionToAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
-	// Remove permission from agent
-	// (DELETE /v1/agents/{agent_id}/permissions/{permission_id})
-	RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, permissionId openapi_types.UUID)
-	// List all flows
-	// (GET /v1/flows)
-	ListFlows(w http.ResponseWriter, r *http.Request, params ListFlowsParams)
-	// Create a new flow
-	// (POST /v1/flows)
-	CreateFlow(w http.ResponseWriter, r *http.Request)
-	// Delete a flow
-	// (DELETE /v1/flows/{flow_id})
-	DeleteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
-	// Get flow by ID
-	// (GET /v1/flows/{flow_id})
-	GetFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
-	// Update an existing flow
-	// (PUT /v1/flows/{flow_id})
-	UpdateFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
-	// Execute a flow
-	// (POST /v1/flows/{flow_id}/execute)
-	ExecuteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
-	// Get flow run by ID
-	// (GET /v1/flows/{flow_run_id}/status)
-	GetFlowRun(w http.ResponseWriter, r *http.Request, flowRunId openapi_types.UUID)
+	// Order Sort order, defaults to descending for the dates and ascending otherwise
+	Order *OrderParam `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Maximum number of items of the page, from 1 to 100
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor of the page to return, the next_cursor of the previous page. The first page is returned without it.
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListTasksParamsStatus defines parameters for ListTasks.
+type ListTasksParamsStatus string
+
+// ListTasksParamsSort defines parameters for ListTasks.
+type ListTasksParamsSort string
+
+// CreateTaskParams defines parameters for CreateTask.
+type CreateTaskParams struct {
+	// IdempotencyKey Unique key of the request, up to 255 characters, to retry it safely. The response of the first request of a key is replayed for 24 hours to its retries with the Idempotent-Replayed header. A retry while the first request is in progress is rejected with 409, and a key reused for another request with 422. A key whose request failed with a 5xx response can be retried.
+	IdempotencyKey *IdempotencyKeyParam `json:"Idempotency-Key,omitempty"`
+}
+
+// ExecuteTaskParams defines parameters for ExecuteTask.
+type ExecuteTaskParams struct {
+	// Events Comma-separated stream event classes sent to the client, among text, thinking, tool, message and lifecycle. A class prefixed with "-" is excluded, e.g. "-thinking". Every event is sent when omitted, errors are always sent.
+	Events *string `form:"events,omitempty" json:"events,omitempty"`
+
+	// IdempotencyKey Unique key of the request, up to 255 characters, to retry it safely. The response of the first request of a key is replayed for 24 hours to its retries with the Idempotent-Replayed header. A retry while the first request is in progress is rejected with 409, and a key reused for another request with 422. A key whose request failed with a 5xx response can be retried.
+	IdempotencyKey *IdempotencyKeyParam `json:"Idempotency-Key,omitempty"`
+}
+
+// StreamTaskParams defines parameters for StreamTask.
+type StreamTaskParams struct {
+	// LastEventID ID of the last event received, the stream resumes after it
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// ListThreadsParams defines parameters for ListThreads.
+type ListThreadsParams struct {
+	// Title Case-insensitive match against the thread title
+	Title *string `form:"title,omitempty" json:"title,omitempty"`
+
+	// CreatedAfter Only return the items created at or after this date
+	CreatedAfter *CreatedAfterParam `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Only return the items created before this date
+	CreatedBefore *CreatedBeforeParam `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// Deleted List the items in the trash instead of the live ones
+	Deleted *DeletedParam `form:"deleted,omitempty" json:"deleted,omitempty"`
+
+	// Sort Field the threads are sorted by, defaults to updated_at
+	Sort *ListThreadsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Sort order, defaults to descending for the dates and ascending otherwise
+	Order *OrderParam `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Maximum number of items of the page, from 1 to 100
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor of the page to return, the next_cursor of the previous page. The first page is returned without it.
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListThreadsParamsSort defines parameters for ListThreads.
+type ListThreadsParamsSort string
+
+// ImportThreadParams defines parameters for ImportThread.
+type ImportThreadParams struct {
+	// Title Title of the imported thread, defaults to the title of the export
+	Title *string `form:"title,omitempty" json:"title,omitempty"`
+}
+
+// UpdateThreadTitleParams defines parameters for UpdateThreadTitle.
+type UpdateThreadTitleParams struct {
+	// IfMatch Entity tags of the versions of the resource the update applies to, as returned in the ETag header of its GET response. The update is rejected with 412 when the resource changed since, "*" matches any version. The update applies to the current version when omitted.
+	IfMatch *IfMatchParam `json:"If-Match,omitempty"`
+}
+
+// StreamThreadEventsParams defines parameters for StreamThreadEvents.
+type StreamThreadEventsParams struct {
+	// Events Comma-separated stream event classes sent to the client, among text, thinking, tool, message and lifecycle. A class prefixed with "-" is excluded, e.g. "-thinking". Every event is sent when omitted, errors are always sent.
+	Events *string `form:"events,omitempty" json:"events,omitempty"`
+}
+
+// ExportThreadParams defines parameters for ExportThread.
+type ExportThreadParams struct {
+	// Format Format of the export, defaults to json
+	Format *ExportThreadParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportThreadParamsFormat defines parameters for ExportThread.
+type ExportThreadParamsFormat string
+
+// ForkThreadParams defines parameters for ForkThread.
+type ForkThreadParams struct {
+	// FromMessage Last message copied to the fork, defaults to the last live message of the thread
+	FromMessage *openapi_types.UUID `form:"from_message,omitempty" json:"from_message,omitempty"`
+
+	// Title Title of the fork, defaults to the title of the thread
+	Title *string `form:"title,omitempty" json:"title,omitempty"`
+}
+
+// ListMessagesParams defines parameters for ListMessages.
+type ListMessagesParams struct {
+	// IncludeHistory Also return the messages superseded by an edit, a deletion or a regeneration
+	IncludeHistory *bool `form:"include_history,omitempty" json:"include_history,omitempty"`
+}
+
+// DeleteMessageParams defines parameters for DeleteMessage.
+type DeleteMessageParams struct {
+	// KeepHistory Supersede the message and the messages after it rather than deleting the message
+	KeepHistory *bool `form:"keep_history,omitempty" json:"keep_history,omitempty"`
+}
+
+// ListToolsParams defines parameters for ListTools.
+type ListToolsParams struct {
+	// Category Only return tools in this catalog category
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Only return tools labelled with this tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Search Case-insensitive match against the tool name and description
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// Type Only return tools of this type
+	Type *ListToolsParamsType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Status Only return tools with this health status
+	Status *ListToolsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedBy Only return the items created by this user
+	CreatedBy *CreatedByParam `form:"created_by,omitempty" json:"created_by,omitempty"`
+
+	// CreatedAfter Only return the items created at or after this date
+	CreatedAfter *CreatedAfterParam `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Only return the items created before this date
+	CreatedBefore *CreatedBeforeParam `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// Deleted List the items in the trash instead of the live ones
+	Deleted *DeletedParam `form:"deleted,omitempty" json:"deleted,omitempty"`
+
+	// Sort Field the tools are sorted by, defaults to created_at
+	Sort *ListToolsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Sort order, defaults to descending for the dates and ascending otherwise
+	Order *OrderParam `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Maximum number of items of the page, from 1 to 100
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor of the page to return, the next_cursor of the previous page. The first page is returned without it.
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListToolsParamsType defines parameters for ListTools.
+type ListToolsParamsType string
+
+// ListToolsParamsStatus defines parameters for ListTools.
+type ListToolsParamsStatus string
+
+// ListToolsParamsSort defines parameters for ListTools.
+type ListToolsParamsSort string
+
+// CreateToolParams defines parameters for CreateTool.
+type CreateToolParams struct {
+	// IdempotencyKey Unique key of the request, up to 255 characters, to retry it safely. The response of the first request of a key is replayed for 24 hours to its retries with the Idempotent-Replayed header. A retry while the first request is in progress is rejected with 409, and a key reused for another request with 422. A key whose request failed with a 5xx response can be retried.
+	IdempotencyKey *IdempotencyKeyParam `json:"Idempotency-Key,omitempty"`
+}
+
+// GetToolCatalogParams defines parameters for GetToolCatalog.
+type GetToolCatalogParams struct {
+	// Tag Only return tools labelled with this tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Search Case-insensitive match against the tool name and description
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+}
+
+// ExportToolsParams defines parameters for ExportTools.
+type ExportToolsParams struct {
+	// ToolId ID of a tool to export, repeated to export several tools
+	ToolId *[]openapi_types.UUID `form:"tool_id,omitempty" json:"tool_id,omitempty"`
+
+	// Name Name of a tool to export, repeated to export several tools
+	Name *[]string `form:"name,omitempty" json:"name,omitempty"`
+
+	// Format Format of the bundle, defaults to json
+	Format *ExportToolsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportToolsParamsFormat defines parameters for ExportTools.
+type ExportToolsParamsFormat string
+
+// ImportToolsParams defines parameters for ImportTools.
+type ImportToolsParams struct {
+	// OnConflict How a tool whose name is taken is imported, defaults to skip
+	OnConflict *ImportToolsParamsOnConflict `form:"on_conflict,omitempty" json:"on_conflict,omitempty"`
+}
+
+// ImportToolsParamsOnConflict defines parameters for ImportTools.
+type ImportToolsParamsOnConflict string
+
+// UpdateToolParams defines parameters for UpdateTool.
+type UpdateToolParams struct {
+	// IfMatch Entity tags of the versions of the resource the update applies to, as returned in the ETag header of its GET response. The update is rejected with 412 when the resource changed since, "*" matches any version. The update applies to the current version when omitted.
+	IfMatch *IfMatchParam `json:"If-Match,omitempty"`
+}
+
+// GetToolHistoryParams defines parameters for GetToolHistory.
+type GetToolHistoryParams struct {
+	// PerPage Limits the number of returned results
+	PerPage *PerPageParam `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Page Page number for paginated results
+	Page *PageParam `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// DiffToolRevisionsParams defines parameters for DiffToolRevisions.
+type DiffToolRevisionsParams struct {
+	// From Revision to compare from
+	From int32 `form:"from" json:"from"`
+
+	// To Revision to compare to, defaults to the promoted revision
+	To *int32 `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Status Only return the deliveries with this status
+	Status *ListWebhookDeliveriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Maximum number of items of the page, from 1 to 100
+	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor of the page to return, the next_cursor of the previous page. The first page is returned without it.
+	Cursor *CursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListWebhookDeliveriesParamsStatus defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParamsStatus string
+
+// CreateAgentJSONRequestBody defines body for CreateAgent for application/json ContentType.
+type CreateAgentJSONRequestBody = CreateAgentRequest
+
+// UpdateAgentJSONRequestBody defines body for UpdateAgent for application/json ContentType.
+type UpdateAgentJSONRequestBody = UpdateAgentRequest
+
+// SetAgentGrantJSONRequestBody defines body for SetAgentGrant for application/json ContentType.
+type SetAgentGrantJSONRequestBody = SetResourceGrantRequest
+
+// InvokeAgentJSONRequestBody defines body for InvokeAgent for application/json ContentType.
+type InvokeAgentJSONRequestBody = InvokeAgentRequest
+
+// AddPermissionToAgentJSONRequestBody defines body for AddPermissionToAgent for application/json ContentType.
+type AddPermissionToAgentJSONRequestBody = AddPermissionToAgentRequest
+
+// CreateAgentProbeJSONRequestBody defines body for CreateAgentProbe for application/json ContentType.
+type CreateAgentProbeJSONRequestBody = CreateAgentProbeRequest
+
+// UpdateAgentProbeJSONRequestBody defines body for UpdateAgentProbe for application/json ContentType.
+type UpdateAgentProbeJSONRequestBody = UpdateAgentProbeRequest
+
+// CreateFileJSONRequestBody defines body for CreateFile for application/json ContentType.
+type CreateFileJSONRequestBody = CreateFileUploadRequest
+
+// CreateFileMultipartRequestBody defines body for CreateFile for multipart/form-data ContentType.
+type CreateFileMultipartRequestBody = UploadFileRequest
+
+// CreateFlowJSONRequestBody defines body for CreateFlow for application/json ContentType.
+type CreateFlowJSONRequestBody = CreateFlowRequest
+
+// UpdateFlowJSONRequestBody defines body for UpdateFlow for application/json ContentType.
+type UpdateFlowJSONRequestBody = UpdateFlowRequest
+
+// ExecuteFlowJSONRequestBody defines body for ExecuteFlow for application/json ContentType.
+type ExecuteFlowJSONRequestBody = ExecuteFlowRequest
+
+// CancelFlowRunJSONRequestBody defines body for CancelFlowRun for application/json ContentType.
+type CancelFlowRunJSONRequestBody = CancelFlowRunRequest
+
+// CreateFlowScheduleJSONRequestBody defines body for CreateFlowSchedule for application/json ContentType.
+type CreateFlowScheduleJSONRequestBody = CreateFlowScheduleRequest
+
+// UpdateFlowScheduleJSONRequestBody defines body for UpdateFlowSchedule for application/json ContentType.
+type UpdateFlowScheduleJSONRequestBody = UpdateFlowScheduleRequest
+
+// CreateFlowScheduleBackfillJSONRequestBody defines body for CreateFlowScheduleBackfill for application/json ContentType.
+type CreateFlowScheduleBackfillJSONRequestBody = CreateFlowScheduleBackfillRequest
+
+// MockStandaloneToolJSONRequestBody defines body for MockStandaloneTool for application/json ContentType.
+type MockStandaloneToolJSONRequestBody = MockToolRequest
+
+// MockStandaloneToolWithDelayJSONRequestBody defines body for MockStandaloneToolWithDelay for application/json ContentType.
+type MockStandaloneToolWithDelayJSONRequestBody = MockToolWithDelayRequest
+
+// CreateOrganizationJSONRequestBody defines body for CreateOrganization for application/json ContentType.
+type CreateOrganizationJSONRequestBody = CreateOrganizationRequest
+
+// SetOrganizationMemberJSONRequestBody defines body for SetOrganizationMember for application/json ContentType.
+type SetOrganizationMemberJSONRequestBody = SetOrganizationMemberRequest
+
+// CreateWorkspaceJSONRequestBody defines body for CreateWorkspace for application/json ContentType.
+type CreateWorkspaceJSONRequestBody = CreateWorkspaceRequest
+
+// CreatePermissionJSONRequestBody defines body for CreatePermission for application/json ContentType.
+type CreatePermissionJSONRequestBody = CreatePermissionRequest
+
+// UpdatePermissionJSONRequestBody defines body for UpdatePermission for application/json ContentType.
+type UpdatePermissionJSONRequestBody = UpdatePermissionRequest
+
+// QuickstartJSONRequestBody defines body for Quickstart for application/json ContentType.
+type QuickstartJSONRequestBody = QuickstartRequest
+
+// CreateRoleJSONRequestBody defines body for CreateRole for application/json ContentType.
+type CreateRoleJSONRequestBody = CreateRoleRequest
+
+// UpdateRoleJSONRequestBody defines body for UpdateRole for application/json ContentType.
+type UpdateRoleJSONRequestBody = UpdateRoleRequest
+
+// AddPermissionToRoleJSONRequestBody defines body for AddPermissionToRole for application/json ContentType.
+type AddPermissionToRoleJSONRequestBody = AddPermissionToRoleRequest
+
+// CreateServiceAccountJSONRequestBody defines body for CreateServiceAccount for application/json ContentType.
+type CreateServiceAccountJSONRequestBody = CreateServiceAccountRequest
+
+// CreateTaskJSONRequestBody defines body for CreateTask for application/json ContentType.
+type CreateTaskJSONRequestBody = CreateTaskRequest
+
+// CreateTaskScheduleJSONRequestBody defines body for CreateTaskSchedule for application/json ContentType.
+type CreateTaskScheduleJSONRequestBody = CreateTaskScheduleRequest
+
+// UpdateTaskScheduleJSONRequestBody defines body for UpdateTaskSchedule for application/json ContentType.
+type UpdateTaskScheduleJSONRequestBody = UpdateTaskScheduleRequest
+
+// UpdateTaskJSONRequestBody defines body for UpdateTask for application/json ContentType.
+type UpdateTaskJSONRequestBody = UpdateTaskRequest
+
+// ExecuteTaskJSONRequestBody defines body for ExecuteTask for application/json ContentType.
+type ExecuteTaskJSONRequestBody = ExecuteTaskRequest
+
+// CreateThreadJSONRequestBody defines body for CreateThread for application/json ContentType.
+type CreateThreadJSONRequestBody = CreateThreadRequest
+
+// ImportThreadJSONRequestBody defines body for ImportThread for application/json ContentType.
+type ImportThreadJSONRequestBody = ThreadExport
+
+// UpdateThreadTitleJSONRequestBody defines body for UpdateThreadTitle for application/json ContentType.
+type UpdateThreadTitleJSONRequestBody = UpdateThreadRequest
+
+// SetThreadGrantJSONRequestBody defines body for SetThreadGrant for application/json ContentType.
+type SetThreadGrantJSONRequestBody = SetResourceGrantRequest
+
+// CreateMessageJSONRequestBody defines body for CreateMessage for application/json ContentType.
+type CreateMessageJSONRequestBody = CreateMessageRequest
+
+// ImportMessagesJSONRequestBody defines body for ImportMessages for application/json ContentType.
+type ImportMessagesJSONRequestBody = ImportMessagesRequest
+
+// UpdateMessageJSONRequestBody defines body for UpdateMessage for application/json ContentType.
+type UpdateMessageJSONRequestBody = UpdateMessageRequest
+
+// EditMessageJSONRequestBody defines body for EditMessage for application/json ContentType.
+type EditMessageJSONRequestBody = EditMessageRequest
+
+// SetMessageFeedbackJSONRequestBody defines body for SetMessageFeedback for application/json ContentType.
+type SetMessageFeedbackJSONRequestBody = FeedbackRequest
+
+// RegenerateMessageJSONRequestBody defines body for RegenerateMessage for application/json ContentType.
+type RegenerateMessageJSONRequestBody = RegenerateMessageRequest
+
+// SetToolRunFeedbackJSONRequestBody defines body for SetToolRunFeedback for application/json ContentType.
+type SetToolRunFeedbackJSONRequestBody = FeedbackRequest
+
+// CreateToolJSONRequestBody defines body for CreateTool for application/json ContentType.
+type CreateToolJSONRequestBody = CreateToolRequest
+
+// BatchCreateToolsJSONRequestBody defines body for BatchCreateTools for application/json ContentType.
+type BatchCreateToolsJSONRequestBody = BatchCreateToolsRequest
+
+// BatchDeleteToolsJSONRequestBody defines body for BatchDeleteTools for application/json ContentType.
+type BatchDeleteToolsJSONRequestBody = BatchDeleteRequest
+
+// BatchUpdateToolsJSONRequestBody defines body for BatchUpdateTools for application/json ContentType.
+type BatchUpdateToolsJSONRequestBody = BatchUpdateToolsRequest
+
+// CreateToolFromDefinitionJSONRequestBody defines body for CreateToolFromDefinition for application/json ContentType.
+type CreateToolFromDefinitionJSONRequestBody = CreateToolFromDefinitionRequest
+
+// ImportToolsJSONRequestBody defines body for ImportTools for application/json ContentType.
+type ImportToolsJSONRequestBody = ToolBundle
+
+// UpdateToolJSONRequestBody defines body for UpdateTool for application/json ContentType.
+type UpdateToolJSONRequestBody = UpdateToolRequest
+
+// SetToolGrantJSONRequestBody defines body for SetToolGrant for application/json ContentType.
+type SetToolGrantJSONRequestBody = SetResourceGrantRequest
+
+// TestToolJSONRequestBody defines body for TestTool for application/json ContentType.
+type TestToolJSONRequestBody = TestToolRequest
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = CreateUserRequest
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UpdateUserRequest
+
+// CreateUserApiKeyJSONRequestBody defines body for CreateUserApiKey for application/json ContentType.
+type CreateUserApiKeyJSONRequestBody = CreateApiKeyRequest
+
+// SetUserDefaultAgentJSONRequestBody defines body for SetUserDefaultAgent for application/json ContentType.
+type SetUserDefaultAgentJSONRequestBody = SetUserDefaultAgentRequest
+
+// AddRoleToUserJSONRequestBody defines body for AddRoleToUser for application/json ContentType.
+type AddRoleToUserJSONRequestBody = AddRoleToUserRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookRequest
+
+// UpdateWebhookJSONRequestBody defines body for UpdateWebhook for application/json ContentType.
+type UpdateWebhookJSONRequestBody = UpdateWebhookRequest
+
+// SetWorkspaceMemberJSONRequestBody defines body for SetWorkspaceMember for application/json ContentType.
+type SetWorkspaceMemberJSONRequestBody = SetWorkspaceMemberRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List parked messages
+	// (GET /v1/admin/dead-letters)
+	ListDeadLetters(w http.ResponseWriter, r *http.Request, params ListDeadLettersParams)
+	// List the running services
+	// (GET /v1/admin/services)
+	ListServices(w http.ResponseWriter, r *http.Request, params ListServicesParams)
+	// List all agents
+	// (GET /v1/agents)
+	ListAgents(w http.ResponseWriter, r *http.Request, params ListAgentsParams)
+	// Create a new agent
+	// (POST /v1/agents)
+	CreateAgent(w http.ResponseWriter, r *http.Request)
+	// Delete agent
+	// (DELETE /v1/agents/{agent_id})
+	DeleteAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Get agent by ID
+	// (GET /v1/agents/{agent_id})
+	GetAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Update agent
+	// (PUT /v1/agents/{agent_id})
+	UpdateAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params UpdateAgentParams)
+	// List the grants of an agent
+	// (GET /v1/agents/{agent_id}/grants)
+	ListAgentGrants(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Share an agent
+	// (PUT /v1/agents/{agent_id}/grants)
+	SetAgentGrant(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Revoke a grant of an agent
+	// (DELETE /v1/agents/{agent_id}/grants/{grantee_type}/{grantee_id})
+	RemoveAgentGrant(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID)
+	// Get agent change history
+	// (GET /v1/agents/{agent_id}/history)
+	GetAgentHistory(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params GetAgentHistoryParams)
+	// Invoke an agent
+	// (POST /v1/agents/{agent_id}/invoke)
+	InvokeAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params InvokeAgentParams)
+	// List permissions for agent mapping
+	// (GET /v1/agents/{agent_id}/permissions)
+	ListPermissionsForAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Add permission to agent
+	// (POST /v1/agents/{agent_id}/permissions)
+	AddPermissionToAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Remove permission from agent
+	// (DELETE /v1/agents/{agent_id}/permissions/{permission_id})
+	RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, permissionId openapi_types.UUID)
+	// List agent probes
+	// (GET /v1/agents/{agent_id}/probes)
+	ListAgentProbes(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Create agent probe
+	// (POST /v1/agents/{agent_id}/probes)
+	CreateAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Delete agent probe
+	// (DELETE /v1/agents/{agent_id}/probes/{probe_id})
+	DeleteAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID)
+	// Get agent probe
+	// (GET /v1/agents/{agent_id}/probes/{probe_id})
+	GetAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID)
+	// Update agent probe
+	// (PUT /v1/agents/{agent_id}/probes/{probe_id})
+	UpdateAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID)
+	// List agent probe runs
+	// (GET /v1/agents/{agent_id}/probes/{probe_id}/runs)
+	ListAgentProbeRuns(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID, params ListAgentProbeRunsParams)
+	// Purge an agent
+	// (POST /v1/agents/{agent_id}/purge)
+	PurgeAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Restore an agent
+	// (POST /v1/agents/{agent_id}/restore)
+	RestoreAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID)
+	// Get flow cost analytics
+	// (GET /v1/analytics/flow-costs)
+	GetFlowCostAnalytics(w http.ResponseWriter, r *http.Request, params GetFlowCostAnalyticsParams)
+	// Get prompt cache analytics
+	// (GET /v1/analytics/prompt-cache)
+	GetPromptCacheAnalytics(w http.ResponseWriter, r *http.Request)
+	// Get thinking budget analytics
+	// (GET /v1/analytics/thinking-budget)
+	GetThinkingBudgetAnalytics(w http.ResponseWriter, r *http.Request)
+	// Get user flow cost analytics
+	// (GET /v1/analytics/user-flow-costs)
+	GetUserFlowCostAnalytics(w http.ResponseWriter, r *http.Request, params GetUserFlowCostAnalyticsParams)
+	// List tool run audit entries
+	// (GET /v1/audit/tool-runs)
+	ListToolRunAudit(w http.ResponseWriter, r *http.Request, params ListToolRunAuditParams)
+	// Get a tool run audit entry
+	// (GET /v1/audit/tool-runs/{tool_run_id})
+	GetToolRunAudit(w http.ResponseWriter, r *http.Request, toolRunId string)
+	// List feedback
+	// (GET /v1/feedback)
+	ListFeedback(w http.ResponseWriter, r *http.Request, params ListFeedbackParams)
+	// Export feedback as a dataset
+	// (GET /v1/feedback/export)
+	ExportFeedback(w http.ResponseWriter, r *http.Request, params ExportFeedbackParams)
+	// List files
+	// (GET /v1/files)
+	ListFiles(w http.ResponseWriter, r *http.Request, params ListFilesParams)
+	// Upload a file
+	// (POST /v1/files)
+	CreateFile(w http.ResponseWriter, r *http.Request)
+	// Delete file
+	// (DELETE /v1/files/{file_id})
+	DeleteFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID)
+	// Get file by ID
+	// (GET /v1/files/{file_id})
+	GetFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID)
+	// Complete a presigned upload
+	// (POST /v1/files/{file_id}/complete)
+	CompleteFileUpload(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID)
+	// Download file
+	// (GET /v1/files/{file_id}/content)
+	DownloadFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID)
+	// Retry a flow run
+	// (POST /v1/flow-runs/{flow_run_id}/retry)
+	RetryFlowRun(w http.ResponseWriter, r *http.Request, flowRunId openapi_types.UUID)
+	// List all flows
+	// (GET /v1/flows)
+	ListFlows(w http.ResponseWriter, r *http.Request, params ListFlowsParams)
+	// Create a new flow
+	// (POST /v1/flows)
+	CreateFlow(w http.ResponseWriter, r *http.Request)
+	// Delete a flow
+	// (DELETE /v1/flows/{flow_id})
+	DeleteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
+	// Get flow by ID
+	// (GET /v1/flows/{flow_id})
+	GetFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
+	// Update an existing flow
+	// (PUT /v1/flows/{flow_id})
+	UpdateFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
+	// Execute a flow
+	// (POST /v1/flows/{flow_id}/execute)
+	ExecuteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params ExecuteFlowParams)
+	// Get flow change history
+	// (GET /v1/flows/{flow_id}/history)
+	GetFlowHistory(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params GetFlowHistoryParams)
+	// Purge a flow
+	// (POST /v1/flows/{flow_id}/purge)
+	PurgeFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
+	// Restore a flow
+	// (POST /v1/flows/{flow_id}/restore)
+	RestoreFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
+	// List flow runs
+	// (GET /v1/flows/{flow_id}/runs)
+	ListFlowRuns(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params ListFlowRunsParams)
+	// List flow run artifacts
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts)
+	ListFlowRunArtifacts(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// Download flow run artifact
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts/{name})
+	DownloadFlowRunArtifact(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID, name string)
+	// Cancel a flow run
+	// (POST /v1/flows/{flow_id}/runs/{run_id}/cancel)
+	CancelFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// List child flow runs
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/children)
+	ListChildFlowRuns(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// Get flow run cost
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/cost)
+	GetFlowRunCost(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// Get flow run graph
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/graph)
+	GetFlowRunGraph(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// Get flow run logs
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/logs)
+	GetFlowRunLogs(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID, params GetFlowRunLogsParams)
+	// Pause a flow run
+	// (POST /v1/flows/{flow_id}/runs/{run_id}/pause)
+	PauseFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// Resume a flow run
+	// (POST /v1/flows/{flow_id}/runs/{run_id}/resume)
+	ResumeFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// Get flow run timeline
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/timeline)
+	GetFlowRunTimeline(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID)
+	// List flow schedules
+	// (GET /v1/flows/{flow_id}/schedules)
+	ListFlowSchedules(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
+	// Create flow schedule
+	// (POST /v1/flows/{flow_id}/schedules)
+	CreateFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID)
+	// Delete flow schedule
+	// (DELETE /v1/flows/{flow_id}/schedules/{schedule_id})
+	DeleteFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID)
+	// Get flow schedule
+	// (GET /v1/flows/{flow_id}/schedules/{schedule_id})
+	GetFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID)
+	// Update flow schedule
+	// (PUT /v1/flows/{flow_id}/schedules/{schedule_id})
+	UpdateFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID)
+	// List flow schedule backfills
+	// (GET /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+	ListFlowScheduleBackfills(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID)
+	// Create flow schedule backfill
+	// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+	CreateFlowScheduleBackfill(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID)
+	// Cancel flow schedule backfill
+	// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills/{backfill_id}/cancel)
+	CancelFlowScheduleBackfill(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID, backfillId openapi_types.UUID)
+	// Get flow run by ID
+	// (GET /v1/flows/{flow_run_id}/status)
+	GetFlowRun(w http.ResponseWriter, r *http.Request, flowRunId openapi_types.UUID)
 	// Mock standalone server
 	// (POST /v1/mock/tool)
 	MockStandaloneTool(w http.ResponseWriter, r *http.Request)
 	// Mock standalone server with delay
 	// (POST /v1/mock/tool_with_delay)
 	MockStandaloneToolWithDelay(w http.ResponseWriter, r *http.Request)
+	// List the organizations of the user
+	// (GET /v1/organizations)
+	ListOrganizations(w http.ResponseWriter, r *http.Request)
+	// Create an organization
+	// (POST /v1/organizations)
+	CreateOrganization(w http.ResponseWriter, r *http.Request)
+	// List the members of an organization
+	// (GET /v1/organizations/{organization_id}/members)
+	ListOrganizationMembers(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID)
+	// Remove a member from an organization
+	// (DELETE /v1/organizations/{organization_id}/members/{user_id})
+	RemoveOrganizationMember(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID, userId openapi_types.UUID)
+	// Add a member to an organization
+	// (PUT /v1/organizations/{organization_id}/members/{user_id})
+	SetOrganizationMember(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID, userId openapi_types.UUID)
+	// List the workspaces of an organization
+	// (GET /v1/organizations/{organization_id}/workspaces)
+	ListOrganizationWorkspaces(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID)
+	// Create a workspace
+	// (POST /v1/organizations/{organization_id}/workspaces)
+	CreateWorkspace(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID)
 	// List all permissions
 	// (GET /v1/permissions)
 	ListPermissions(w http.ResponseWriter, r *http.Request)
@@ -645,6 +3033,9 @@ type ServerInterface interface {
 	// Update permission
 	// (PUT /v1/permissions/{permission_id})
 	UpdatePermission(w http.ResponseWriter, r *http.Request, permissionId openapi_types.UUID)
+	// Send a message to the default agent
+	// (POST /v1/quickstart)
+	Quickstart(w http.ResponseWriter, r *http.Request, params QuickstartParams)
 	// List all roles
 	// (GET /v1/roles)
 	ListRoles(w http.ResponseWriter, r *http.Request)
@@ -669,12 +3060,36 @@ type ServerInterface interface {
 	// Remove permission from role
 	// (DELETE /v1/roles/{role_id}/permissions/{permission_id})
 	RemovePermissionFromRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID, permissionId openapi_types.UUID)
+	// Search the messages
+	// (GET /v1/search/messages)
+	SearchMessages(w http.ResponseWriter, r *http.Request, params SearchMessagesParams)
+	// Search the messages by meaning
+	// (GET /v1/search/messages/semantic)
+	SemanticSearchMessages(w http.ResponseWriter, r *http.Request, params SemanticSearchMessagesParams)
+	// Create a service account
+	// (POST /v1/service-accounts)
+	CreateServiceAccount(w http.ResponseWriter, r *http.Request)
 	// List all tasks
 	// (GET /v1/tasks)
 	ListTasks(w http.ResponseWriter, r *http.Request, params ListTasksParams)
 	// Create a new task
 	// (POST /v1/tasks)
-	CreateTask(w http.ResponseWriter, r *http.Request)
+	CreateTask(w http.ResponseWriter, r *http.Request, params CreateTaskParams)
+	// List task schedules
+	// (GET /v1/tasks/schedules)
+	ListTaskSchedules(w http.ResponseWriter, r *http.Request)
+	// Create task schedule
+	// (POST /v1/tasks/schedules)
+	CreateTaskSchedule(w http.ResponseWriter, r *http.Request)
+	// Delete task schedule
+	// (DELETE /v1/tasks/schedules/{schedule_id})
+	DeleteTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID)
+	// Get task schedule
+	// (GET /v1/tasks/schedules/{schedule_id})
+	GetTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID)
+	// Update task schedule
+	// (PUT /v1/tasks/schedules/{schedule_id})
+	UpdateTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID)
 	// Delete a task
 	// (DELETE /v1/tasks/{task_id})
 	DeleteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID)
@@ -686,19 +3101,28 @@ type ServerInterface interface {
 	UpdateTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID)
 	// Execute a task
 	// (POST /v1/tasks/{task_id}/execute)
-	ExecuteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID)
+	ExecuteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, params ExecuteTaskParams)
 	// Get all task runs for a task
 	// (GET /v1/tasks/{task_id}/runs)
 	ListTaskRuns(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID)
+	// Cancel a task run
+	// (POST /v1/tasks/{task_id}/runs/{run_id}/cancel)
+	CancelTaskRun(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, runId openapi_types.UUID)
+	// Stream a task execution
+	// (GET /v1/tasks/{task_id}/stream)
+	StreamTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, params StreamTaskParams)
 	// Get task run by ID
 	// (GET /v1/tasks/{task_run_id}/status)
 	GetTaskRun(w http.ResponseWriter, r *http.Request, taskRunId openapi_types.UUID)
 	// List all threads
 	// (GET /v1/threads)
-	ListThreads(w http.ResponseWriter, r *http.Request)
+	ListThreads(w http.ResponseWriter, r *http.Request, params ListThreadsParams)
 	// Create a new thread
 	// (POST /v1/threads)
 	CreateThread(w http.ResponseWriter, r *http.Request)
+	// Import a thread
+	// (POST /v1/threads/import)
+	ImportThread(w http.ResponseWriter, r *http.Request, params ImportThreadParams)
 	// Delete thread
 	// (DELETE /v1/threads/{thread_id})
 	DeleteThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
@@ -707,28 +3131,97 @@ type ServerInterface interface {
 	GetThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
 	// Update thread title
 	// (PUT /v1/threads/{thread_id})
-	UpdateThreadTitle(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
+	UpdateThreadTitle(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params UpdateThreadTitleParams)
+	// Stream the events of a thread
+	// (GET /v1/threads/{thread_id}/events)
+	StreamThreadEvents(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params StreamThreadEventsParams)
+	// Export a thread
+	// (GET /v1/threads/{thread_id}/export)
+	ExportThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ExportThreadParams)
+	// Fork a thread
+	// (POST /v1/threads/{thread_id}/fork)
+	ForkThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ForkThreadParams)
+	// List the grants of a thread
+	// (GET /v1/threads/{thread_id}/grants)
+	ListThreadGrants(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
+	// Share a thread
+	// (PUT /v1/threads/{thread_id}/grants)
+	SetThreadGrant(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
+	// Revoke a grant of a thread
+	// (DELETE /v1/threads/{thread_id}/grants/{grantee_type}/{grantee_id})
+	RemoveThreadGrant(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID)
 	// List all messages in a thread
 	// (GET /v1/threads/{thread_id}/messages)
-	ListMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
+	ListMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ListMessagesParams)
 	// Create a new message in a thread
 	// (POST /v1/threads/{thread_id}/messages)
 	CreateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
+	// Import messages in a thread
+	// (POST /v1/threads/{thread_id}/messages/batch)
+	ImportMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
 	// Delete message
 	// (DELETE /v1/threads/{thread_id}/messages/{message_id})
-	DeleteMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
+	DeleteMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID, params DeleteMessageParams)
 	// Get message by ID
 	// (GET /v1/threads/{thread_id}/messages/{message_id})
 	GetMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
 	// Update message
 	// (PUT /v1/threads/{thread_id}/messages/{message_id})
 	UpdateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
+	// List message attachments
+	// (GET /v1/threads/{thread_id}/messages/{message_id}/attachments)
+	ListMessageAttachments(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
+	// Edit a user message
+	// (POST /v1/threads/{thread_id}/messages/{message_id}/edit)
+	EditMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
+	// Delete the feedback on a message
+	// (DELETE /v1/threads/{thread_id}/messages/{message_id}/feedback)
+	DeleteMessageFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
+	// Rate a message
+	// (PUT /v1/threads/{thread_id}/messages/{message_id}/feedback)
+	SetMessageFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
+	// Regenerate a thread from a message
+	// (POST /v1/threads/{thread_id}/messages/{message_id}/regenerate)
+	RegenerateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID)
+	// Purge a thread
+	// (POST /v1/threads/{thread_id}/purge)
+	PurgeThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
+	// Restore a thread
+	// (POST /v1/threads/{thread_id}/restore)
+	RestoreThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID)
+	// Delete the feedback on a tool run
+	// (DELETE /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+	DeleteToolRunFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, toolRunId string)
+	// Rate a tool run
+	// (PUT /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+	SetToolRunFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, toolRunId string)
 	// List all tools
 	// (GET /v1/tools)
-	ListTools(w http.ResponseWriter, r *http.Request)
+	ListTools(w http.ResponseWriter, r *http.Request, params ListToolsParams)
 	// Create a new tool
 	// (POST /v1/tools)
-	CreateTool(w http.ResponseWriter, r *http.Request)
+	CreateTool(w http.ResponseWriter, r *http.Request, params CreateToolParams)
+	// Create tools in a batch
+	// (POST /v1/tools/batch-create)
+	BatchCreateTools(w http.ResponseWriter, r *http.Request)
+	// Delete tools in a batch
+	// (POST /v1/tools/batch-delete)
+	BatchDeleteTools(w http.ResponseWriter, r *http.Request)
+	// Update tools in a batch
+	// (POST /v1/tools/batch-update)
+	BatchUpdateTools(w http.ResponseWriter, r *http.Request)
+	// Get the tool catalog
+	// (GET /v1/tools/catalog)
+	GetToolCatalog(w http.ResponseWriter, r *http.Request, params GetToolCatalogParams)
+	// Create a tool from a function definition
+	// (POST /v1/tools/definitions)
+	CreateToolFromDefinition(w http.ResponseWriter, r *http.Request)
+	// Export tools as a bundle
+	// (GET /v1/tools/export)
+	ExportTools(w http.ResponseWriter, r *http.Request, params ExportToolsParams)
+	// Import a tool bundle
+	// (POST /v1/tools/import)
+	ImportTools(w http.ResponseWriter, r *http.Request, params ImportToolsParams)
 	// Delete a tool
 	// (DELETE /v1/tools/{tool_id})
 	DeleteTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
@@ -737,7 +3230,40 @@ type ServerInterface interface {
 	GetToolById(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
 	// Update a tool
 	// (PUT /v1/tools/{tool_id})
-	UpdateTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
+	UpdateTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params UpdateToolParams)
+	// List the grants of a tool
+	// (GET /v1/tools/{tool_id}/grants)
+	ListToolGrants(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
+	// Share a tool
+	// (PUT /v1/tools/{tool_id}/grants)
+	SetToolGrant(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
+	// Revoke a grant of a tool
+	// (DELETE /v1/tools/{tool_id}/grants/{grantee_type}/{grantee_id})
+	RemoveToolGrant(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID)
+	// Get tool change history
+	// (GET /v1/tools/{tool_id}/history)
+	GetToolHistory(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params GetToolHistoryParams)
+	// Purge a tool
+	// (POST /v1/tools/{tool_id}/purge)
+	PurgeTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
+	// Restore a tool
+	// (POST /v1/tools/{tool_id}/restore)
+	RestoreTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
+	// List tool revisions
+	// (GET /v1/tools/{tool_id}/revisions)
+	ListToolRevisions(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
+	// Diff tool revision schemas
+	// (GET /v1/tools/{tool_id}/revisions/diff)
+	DiffToolRevisions(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params DiffToolRevisionsParams)
+	// Promote a tool revision
+	// (POST /v1/tools/{tool_id}/revisions/{revision}/promote)
+	PromoteToolRevision(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, revision int32)
+	// Test a tool
+	// (POST /v1/tools/{tool_id}/test)
+	TestTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID)
+	// Get the usage of the quotas
+	// (GET /v1/usage)
+	GetUsage(w http.ResponseWriter, r *http.Request)
 	// List all users
 	// (GET /v1/users)
 	ListUsers(w http.ResponseWriter, r *http.Request)
@@ -753,6 +3279,18 @@ type ServerInterface interface {
 	// Update user
 	// (PUT /v1/users/{user_id})
 	UpdateUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// List the API keys of a user
+	// (GET /v1/users/{user_id}/api-keys)
+	ListUserApiKeys(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Create an API key for a user
+	// (POST /v1/users/{user_id}/api-keys)
+	CreateUserApiKey(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Revoke an API key
+	// (DELETE /v1/users/{user_id}/api-keys/{key_id})
+	RevokeUserApiKey(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, keyId openapi_types.UUID)
+	// Set the default agent of a user
+	// (PUT /v1/users/{user_id}/default-agent)
+	SetUserDefaultAgent(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
 	// List role for user mapping
 	// (GET /v1/users/{user_id}/roles)
 	ListRoleForUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
@@ -762,15 +3300,60 @@ type ServerInterface interface {
 	// Remove role from user
 	// (DELETE /v1/users/{user_id}/roles/{role_id})
 	RemoveRoleFromUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, roleId openapi_types.UUID)
+	// List webhooks
+	// (GET /v1/webhooks)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	// Create a webhook
+	// (POST /v1/webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// Delete webhook
+	// (DELETE /v1/webhooks/{webhook_id})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID)
+	// Get webhook by ID
+	// (GET /v1/webhooks/{webhook_id})
+	GetWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID)
+	// Update webhook
+	// (PUT /v1/webhooks/{webhook_id})
+	UpdateWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID)
+	// List webhook deliveries
+	// (GET /v1/webhooks/{webhook_id}/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID, params ListWebhookDeliveriesParams)
+	// Redeliver a webhook delivery
+	// (POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+	RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID, deliveryId openapi_types.UUID)
+	// Rotate webhook secret
+	// (POST /v1/webhooks/{webhook_id}/rotate-secret)
+	RotateWebhookSecret(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID)
+	// List the members of a workspace
+	// (GET /v1/workspaces/{workspace_id}/members)
+	ListWorkspaceMembers(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID)
+	// Remove a member from a workspace
+	// (DELETE /v1/workspaces/{workspace_id}/members/{user_id})
+	RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID, userId openapi_types.UUID)
+	// Add a member to a workspace
+	// (PUT /v1/workspaces/{workspace_id}/members/{user_id})
+	SetWorkspaceMember(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID, userId openapi_types.UUID)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// List parked messages
+// (GET /v1/admin/dead-letters)
+func (_ Unimplemented) ListDeadLetters(w http.ResponseWriter, r *http.Request, params ListDeadLettersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the running services
+// (GET /v1/admin/services)
+func (_ Unimplemented) ListServices(w http.ResponseWriter, r *http.Request, params ListServicesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List all agents
 // (GET /v1/agents)
-func (_ Unimplemented) ListAgents(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) ListAgents(w http.ResponseWriter, r *http.Request, params ListAgentsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -794,25 +3377,193 @@ func (_ Unimplemented) GetAgent(w http.ResponseWriter, r *http.Request, agentId
 
 // Update agent
 // (PUT /v1/agents/{agent_id})
-func (_ Unimplemented) UpdateAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+func (_ Unimplemented) UpdateAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params UpdateAgentParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List permissions for agent mapping
-// (GET /v1/agents/{agent_id}/permissions)
-func (_ Unimplemented) ListPermissionsForAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+// List the grants of an agent
+// (GET /v1/agents/{agent_id}/grants)
+func (_ Unimplemented) ListAgentGrants(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Add permission to agent
-// (POST /v1/agents/{agent_id}/permissions)
-func (_ Unimplemented) AddPermissionToAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+// Share an agent
+// (PUT /v1/agents/{agent_id}/grants)
+func (_ Unimplemented) SetAgentGrant(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Remove permission from agent
-// (DELETE /v1/agents/{agent_id}/permissions/{permission_id})
-func (_ Unimplemented) RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, permissionId openapi_types.UUID) {
+// Revoke a grant of an agent
+// (DELETE /v1/agents/{agent_id}/grants/{grantee_type}/{grantee_id})
+func (_ Unimplemented) RemoveAgentGrant(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get agent change history
+// (GET /v1/agents/{agent_id}/history)
+func (_ Unimplemented) GetAgentHistory(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params GetAgentHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Invoke an agent
+// (POST /v1/agents/{agent_id}/invoke)
+func (_ Unimplemented) InvokeAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params InvokeAgentParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List permissions for agent mapping
+// (GET /v1/agents/{agent_id}/permissions)
+func (_ Unimplemented) ListPermissionsForAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add permission to agent
+// (POST /v1/agents/{agent_id}/permissions)
+func (_ Unimplemented) AddPermissionToAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove permission from agent
+// (DELETE /v1/agents/{agent_id}/permissions/{permission_id})
+func (_ Unimplemented) RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, permissionId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List agent probes
+// (GET /v1/agents/{agent_id}/probes)
+func (_ Unimplemented) ListAgentProbes(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create agent probe
+// (POST /v1/agents/{agent_id}/probes)
+func (_ Unimplemented) CreateAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete agent probe
+// (DELETE /v1/agents/{agent_id}/probes/{probe_id})
+func (_ Unimplemented) DeleteAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get agent probe
+// (GET /v1/agents/{agent_id}/probes/{probe_id})
+func (_ Unimplemented) GetAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update agent probe
+// (PUT /v1/agents/{agent_id}/probes/{probe_id})
+func (_ Unimplemented) UpdateAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List agent probe runs
+// (GET /v1/agents/{agent_id}/probes/{probe_id}/runs)
+func (_ Unimplemented) ListAgentProbeRuns(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID, params ListAgentProbeRunsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Purge an agent
+// (POST /v1/agents/{agent_id}/purge)
+func (_ Unimplemented) PurgeAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore an agent
+// (POST /v1/agents/{agent_id}/restore)
+func (_ Unimplemented) RestoreAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get flow cost analytics
+// (GET /v1/analytics/flow-costs)
+func (_ Unimplemented) GetFlowCostAnalytics(w http.ResponseWriter, r *http.Request, params GetFlowCostAnalyticsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get prompt cache analytics
+// (GET /v1/analytics/prompt-cache)
+func (_ Unimplemented) GetPromptCacheAnalytics(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get thinking budget analytics
+// (GET /v1/analytics/thinking-budget)
+func (_ Unimplemented) GetThinkingBudgetAnalytics(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user flow cost analytics
+// (GET /v1/analytics/user-flow-costs)
+func (_ Unimplemented) GetUserFlowCostAnalytics(w http.ResponseWriter, r *http.Request, params GetUserFlowCostAnalyticsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tool run audit entries
+// (GET /v1/audit/tool-runs)
+func (_ Unimplemented) ListToolRunAudit(w http.ResponseWriter, r *http.Request, params ListToolRunAuditParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a tool run audit entry
+// (GET /v1/audit/tool-runs/{tool_run_id})
+func (_ Unimplemented) GetToolRunAudit(w http.ResponseWriter, r *http.Request, toolRunId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List feedback
+// (GET /v1/feedback)
+func (_ Unimplemented) ListFeedback(w http.ResponseWriter, r *http.Request, params ListFeedbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export feedback as a dataset
+// (GET /v1/feedback/export)
+func (_ Unimplemented) ExportFeedback(w http.ResponseWriter, r *http.Request, params ExportFeedbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List files
+// (GET /v1/files)
+func (_ Unimplemented) ListFiles(w http.ResponseWriter, r *http.Request, params ListFilesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload a file
+// (POST /v1/files)
+func (_ Unimplemented) CreateFile(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete file
+// (DELETE /v1/files/{file_id})
+func (_ Unimplemented) DeleteFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get file by ID
+// (GET /v1/files/{file_id})
+func (_ Unimplemented) GetFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete a presigned upload
+// (POST /v1/files/{file_id}/complete)
+func (_ Unimplemented) CompleteFileUpload(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download file
+// (GET /v1/files/{file_id}/content)
+func (_ Unimplemented) DownloadFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retry a flow run
+// (POST /v1/flow-runs/{flow_run_id}/retry)
+func (_ Unimplemented) RetryFlowRun(w http.ResponseWriter, r *http.Request, flowRunId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -848,7 +3599,139 @@ func (_ Unimplemented) UpdateFlow(w http.ResponseWriter, r *http.Request, flowId
 
 // Execute a flow
 // (POST /v1/flows/{flow_id}/execute)
-func (_ Unimplemented) ExecuteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+func (_ Unimplemented) ExecuteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params ExecuteFlowParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get flow change history
+// (GET /v1/flows/{flow_id}/history)
+func (_ Unimplemented) GetFlowHistory(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params GetFlowHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Purge a flow
+// (POST /v1/flows/{flow_id}/purge)
+func (_ Unimplemented) PurgeFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a flow
+// (POST /v1/flows/{flow_id}/restore)
+func (_ Unimplemented) RestoreFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List flow runs
+// (GET /v1/flows/{flow_id}/runs)
+func (_ Unimplemented) ListFlowRuns(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params ListFlowRunsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List flow run artifacts
+// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts)
+func (_ Unimplemented) ListFlowRunArtifacts(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download flow run artifact
+// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts/{name})
+func (_ Unimplemented) DownloadFlowRunArtifact(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel a flow run
+// (POST /v1/flows/{flow_id}/runs/{run_id}/cancel)
+func (_ Unimplemented) CancelFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List child flow runs
+// (GET /v1/flows/{flow_id}/runs/{run_id}/children)
+func (_ Unimplemented) ListChildFlowRuns(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get flow run cost
+// (GET /v1/flows/{flow_id}/runs/{run_id}/cost)
+func (_ Unimplemented) GetFlowRunCost(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get flow run graph
+// (GET /v1/flows/{flow_id}/runs/{run_id}/graph)
+func (_ Unimplemented) GetFlowRunGraph(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get flow run logs
+// (GET /v1/flows/{flow_id}/runs/{run_id}/logs)
+func (_ Unimplemented) GetFlowRunLogs(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID, params GetFlowRunLogsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Pause a flow run
+// (POST /v1/flows/{flow_id}/runs/{run_id}/pause)
+func (_ Unimplemented) PauseFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Resume a flow run
+// (POST /v1/flows/{flow_id}/runs/{run_id}/resume)
+func (_ Unimplemented) ResumeFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get flow run timeline
+// (GET /v1/flows/{flow_id}/runs/{run_id}/timeline)
+func (_ Unimplemented) GetFlowRunTimeline(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List flow schedules
+// (GET /v1/flows/{flow_id}/schedules)
+func (_ Unimplemented) ListFlowSchedules(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create flow schedule
+// (POST /v1/flows/{flow_id}/schedules)
+func (_ Unimplemented) CreateFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete flow schedule
+// (DELETE /v1/flows/{flow_id}/schedules/{schedule_id})
+func (_ Unimplemented) DeleteFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get flow schedule
+// (GET /v1/flows/{flow_id}/schedules/{schedule_id})
+func (_ Unimplemented) GetFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update flow schedule
+// (PUT /v1/flows/{flow_id}/schedules/{schedule_id})
+func (_ Unimplemented) UpdateFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List flow schedule backfills
+// (GET /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+func (_ Unimplemented) ListFlowScheduleBackfills(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create flow schedule backfill
+// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+func (_ Unimplemented) CreateFlowScheduleBackfill(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel flow schedule backfill
+// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills/{backfill_id}/cancel)
+func (_ Unimplemented) CancelFlowScheduleBackfill(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID, backfillId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -870,6 +3753,48 @@ func (_ Unimplemented) MockStandaloneToolWithDelay(w http.ResponseWriter, r *htt
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the organizations of the user
+// (GET /v1/organizations)
+func (_ Unimplemented) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an organization
+// (POST /v1/organizations)
+func (_ Unimplemented) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the members of an organization
+// (GET /v1/organizations/{organization_id}/members)
+func (_ Unimplemented) ListOrganizationMembers(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a member from an organization
+// (DELETE /v1/organizations/{organization_id}/members/{user_id})
+func (_ Unimplemented) RemoveOrganizationMember(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a member to an organization
+// (PUT /v1/organizations/{organization_id}/members/{user_id})
+func (_ Unimplemented) SetOrganizationMember(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the workspaces of an organization
+// (GET /v1/organizations/{organization_id}/workspaces)
+func (_ Unimplemented) ListOrganizationWorkspaces(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a workspace
+// (POST /v1/organizations/{organization_id}/workspaces)
+func (_ Unimplemented) CreateWorkspace(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List all permissions
 // (GET /v1/permissions)
 func (_ Unimplemented) ListPermissions(w http.ResponseWriter, r *http.Request) {
@@ -900,6 +3825,12 @@ func (_ Unimplemented) UpdatePermission(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Send a message to the default agent
+// (POST /v1/quickstart)
+func (_ Unimplemented) Quickstart(w http.ResponseWriter, r *http.Request, params QuickstartParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List all roles
 // (GET /v1/roles)
 func (_ Unimplemented) ListRoles(w http.ResponseWriter, r *http.Request) {
@@ -948,6 +3879,24 @@ func (_ Unimplemented) RemovePermissionFromRole(w http.ResponseWriter, r *http.R
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Search the messages
+// (GET /v1/search/messages)
+func (_ Unimplemented) SearchMessages(w http.ResponseWriter, r *http.Request, params SearchMessagesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search the messages by meaning
+// (GET /v1/search/messages/semantic)
+func (_ Unimplemented) SemanticSearchMessages(w http.ResponseWriter, r *http.Request, params SemanticSearchMessagesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a service account
+// (POST /v1/service-accounts)
+func (_ Unimplemented) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List all tasks
 // (GET /v1/tasks)
 func (_ Unimplemented) ListTasks(w http.ResponseWriter, r *http.Request, params ListTasksParams) {
@@ -956,7 +3905,37 @@ func (_ Unimplemented) ListTasks(w http.ResponseWriter, r *http.Request, params
 
 // Create a new task
 // (POST /v1/tasks)
-func (_ Unimplemented) CreateTask(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) CreateTask(w http.ResponseWriter, r *http.Request, params CreateTaskParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List task schedules
+// (GET /v1/tasks/schedules)
+func (_ Unimplemented) ListTaskSchedules(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create task schedule
+// (POST /v1/tasks/schedules)
+func (_ Unimplemented) CreateTaskSchedule(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete task schedule
+// (DELETE /v1/tasks/schedules/{schedule_id})
+func (_ Unimplemented) DeleteTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get task schedule
+// (GET /v1/tasks/schedules/{schedule_id})
+func (_ Unimplemented) GetTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update task schedule
+// (PUT /v1/tasks/schedules/{schedule_id})
+func (_ Unimplemented) UpdateTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -980,7 +3959,7 @@ func (_ Unimplemented) UpdateTask(w http.ResponseWriter, r *http.Request, taskId
 
 // Execute a task
 // (POST /v1/tasks/{task_id}/execute)
-func (_ Unimplemented) ExecuteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
+func (_ Unimplemented) ExecuteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, params ExecuteTaskParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -990,6 +3969,18 @@ func (_ Unimplemented) ListTaskRuns(w http.ResponseWriter, r *http.Request, task
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Cancel a task run
+// (POST /v1/tasks/{task_id}/runs/{run_id}/cancel)
+func (_ Unimplemented) CancelTaskRun(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, runId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream a task execution
+// (GET /v1/tasks/{task_id}/stream)
+func (_ Unimplemented) StreamTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, params StreamTaskParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get task run by ID
 // (GET /v1/tasks/{task_run_id}/status)
 func (_ Unimplemented) GetTaskRun(w http.ResponseWriter, r *http.Request, taskRunId openapi_types.UUID) {
@@ -998,7 +3989,7 @@ func (_ Unimplemented) GetTaskRun(w http.ResponseWriter, r *http.Request, taskRu
 
 // List all threads
 // (GET /v1/threads)
-func (_ Unimplemented) ListThreads(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) ListThreads(w http.ResponseWriter, r *http.Request, params ListThreadsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1008,6 +3999,12 @@ func (_ Unimplemented) CreateThread(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Import a thread
+// (POST /v1/threads/import)
+func (_ Unimplemented) ImportThread(w http.ResponseWriter, r *http.Request, params ImportThreadParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete thread
 // (DELETE /v1/threads/{thread_id})
 func (_ Unimplemented) DeleteThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
@@ -1022,13 +4019,49 @@ func (_ Unimplemented) GetThread(w http.ResponseWriter, r *http.Request, threadI
 
 // Update thread title
 // (PUT /v1/threads/{thread_id})
-func (_ Unimplemented) UpdateThreadTitle(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+func (_ Unimplemented) UpdateThreadTitle(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params UpdateThreadTitleParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream the events of a thread
+// (GET /v1/threads/{thread_id}/events)
+func (_ Unimplemented) StreamThreadEvents(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params StreamThreadEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export a thread
+// (GET /v1/threads/{thread_id}/export)
+func (_ Unimplemented) ExportThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ExportThreadParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Fork a thread
+// (POST /v1/threads/{thread_id}/fork)
+func (_ Unimplemented) ForkThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ForkThreadParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the grants of a thread
+// (GET /v1/threads/{thread_id}/grants)
+func (_ Unimplemented) ListThreadGrants(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Share a thread
+// (PUT /v1/threads/{thread_id}/grants)
+func (_ Unimplemented) SetThreadGrant(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a grant of a thread
+// (DELETE /v1/threads/{thread_id}/grants/{grantee_type}/{grantee_id})
+func (_ Unimplemented) RemoveThreadGrant(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // List all messages in a thread
 // (GET /v1/threads/{thread_id}/messages)
-func (_ Unimplemented) ListMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+func (_ Unimplemented) ListMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ListMessagesParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1038,9 +4071,15 @@ func (_ Unimplemented) CreateMessage(w http.ResponseWriter, r *http.Request, thr
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Import messages in a thread
+// (POST /v1/threads/{thread_id}/messages/batch)
+func (_ Unimplemented) ImportMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete message
 // (DELETE /v1/threads/{thread_id}/messages/{message_id})
-func (_ Unimplemented) DeleteMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+func (_ Unimplemented) DeleteMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID, params DeleteMessageParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1056,49 +4095,211 @@ func (_ Unimplemented) UpdateMessage(w http.ResponseWriter, r *http.Request, thr
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List all tools
-// (GET /v1/tools)
-func (_ Unimplemented) ListTools(w http.ResponseWriter, r *http.Request) {
+// List message attachments
+// (GET /v1/threads/{thread_id}/messages/{message_id}/attachments)
+func (_ Unimplemented) ListMessageAttachments(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Create a new tool
-// (POST /v1/tools)
-func (_ Unimplemented) CreateTool(w http.ResponseWriter, r *http.Request) {
+// Edit a user message
+// (POST /v1/threads/{thread_id}/messages/{message_id}/edit)
+func (_ Unimplemented) EditMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Delete a tool
-// (DELETE /v1/tools/{tool_id})
-func (_ Unimplemented) DeleteTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+// Delete the feedback on a message
+// (DELETE /v1/threads/{thread_id}/messages/{message_id}/feedback)
+func (_ Unimplemented) DeleteMessageFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get a tool by ID
-// (GET /v1/tools/{tool_id})
-func (_ Unimplemented) GetToolById(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+// Rate a message
+// (PUT /v1/threads/{thread_id}/messages/{message_id}/feedback)
+func (_ Unimplemented) SetMessageFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Update a tool
-// (PUT /v1/tools/{tool_id})
-func (_ Unimplemented) UpdateTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+// Regenerate a thread from a message
+// (POST /v1/threads/{thread_id}/messages/{message_id}/regenerate)
+func (_ Unimplemented) RegenerateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List all users
-// (GET /v1/users)
-func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request) {
+// Purge a thread
+// (POST /v1/threads/{thread_id}/purge)
+func (_ Unimplemented) PurgeThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Create a new user
-// (POST /v1/users)
-func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
+// Restore a thread
+// (POST /v1/threads/{thread_id}/restore)
+func (_ Unimplemented) RestoreThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Delete user
+// Delete the feedback on a tool run
+// (DELETE /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+func (_ Unimplemented) DeleteToolRunFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, toolRunId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rate a tool run
+// (PUT /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+func (_ Unimplemented) SetToolRunFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, toolRunId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all tools
+// (GET /v1/tools)
+func (_ Unimplemented) ListTools(w http.ResponseWriter, r *http.Request, params ListToolsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new tool
+// (POST /v1/tools)
+func (_ Unimplemented) CreateTool(w http.ResponseWriter, r *http.Request, params CreateToolParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create tools in a batch
+// (POST /v1/tools/batch-create)
+func (_ Unimplemented) BatchCreateTools(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete tools in a batch
+// (POST /v1/tools/batch-delete)
+func (_ Unimplemented) BatchDeleteTools(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update tools in a batch
+// (POST /v1/tools/batch-update)
+func (_ Unimplemented) BatchUpdateTools(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the tool catalog
+// (GET /v1/tools/catalog)
+func (_ Unimplemented) GetToolCatalog(w http.ResponseWriter, r *http.Request, params GetToolCatalogParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a tool from a function definition
+// (POST /v1/tools/definitions)
+func (_ Unimplemented) CreateToolFromDefinition(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export tools as a bundle
+// (GET /v1/tools/export)
+func (_ Unimplemented) ExportTools(w http.ResponseWriter, r *http.Request, params ExportToolsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import a tool bundle
+// (POST /v1/tools/import)
+func (_ Unimplemented) ImportTools(w http.ResponseWriter, r *http.Request, params ImportToolsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a tool
+// (DELETE /v1/tools/{tool_id})
+func (_ Unimplemented) DeleteTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a tool by ID
+// (GET /v1/tools/{tool_id})
+func (_ Unimplemented) GetToolById(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a tool
+// (PUT /v1/tools/{tool_id})
+func (_ Unimplemented) UpdateTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params UpdateToolParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the grants of a tool
+// (GET /v1/tools/{tool_id}/grants)
+func (_ Unimplemented) ListToolGrants(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Share a tool
+// (PUT /v1/tools/{tool_id}/grants)
+func (_ Unimplemented) SetToolGrant(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a grant of a tool
+// (DELETE /v1/tools/{tool_id}/grants/{grantee_type}/{grantee_id})
+func (_ Unimplemented) RemoveToolGrant(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get tool change history
+// (GET /v1/tools/{tool_id}/history)
+func (_ Unimplemented) GetToolHistory(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params GetToolHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Purge a tool
+// (POST /v1/tools/{tool_id}/purge)
+func (_ Unimplemented) PurgeTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a tool
+// (POST /v1/tools/{tool_id}/restore)
+func (_ Unimplemented) RestoreTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tool revisions
+// (GET /v1/tools/{tool_id}/revisions)
+func (_ Unimplemented) ListToolRevisions(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Diff tool revision schemas
+// (GET /v1/tools/{tool_id}/revisions/diff)
+func (_ Unimplemented) DiffToolRevisions(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params DiffToolRevisionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Promote a tool revision
+// (POST /v1/tools/{tool_id}/revisions/{revision}/promote)
+func (_ Unimplemented) PromoteToolRevision(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, revision int32) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Test a tool
+// (POST /v1/tools/{tool_id}/test)
+func (_ Unimplemented) TestTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the usage of the quotas
+// (GET /v1/usage)
+func (_ Unimplemented) GetUsage(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all users
+// (GET /v1/users)
+func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new user
+// (POST /v1/users)
+func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete user
 // (DELETE /v1/users/{user_id})
 func (_ Unimplemented) DeleteUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -1116,6 +4317,30 @@ func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, userId
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the API keys of a user
+// (GET /v1/users/{user_id}/api-keys)
+func (_ Unimplemented) ListUserApiKeys(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API key for a user
+// (POST /v1/users/{user_id}/api-keys)
+func (_ Unimplemented) CreateUserApiKey(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key
+// (DELETE /v1/users/{user_id}/api-keys/{key_id})
+func (_ Unimplemented) RevokeUserApiKey(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, keyId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the default agent of a user
+// (PUT /v1/users/{user_id}/default-agent)
+func (_ Unimplemented) SetUserDefaultAgent(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List role for user mapping
 // (GET /v1/users/{user_id}/roles)
 func (_ Unimplemented) ListRoleForUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
@@ -1134,6 +4359,72 @@ func (_ Unimplemented) RemoveRoleFromUser(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List webhooks
+// (GET /v1/webhooks)
+func (_ Unimplemented) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a webhook
+// (POST /v1/webhooks)
+func (_ Unimplemented) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete webhook
+// (DELETE /v1/webhooks/{webhook_id})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get webhook by ID
+// (GET /v1/webhooks/{webhook_id})
+func (_ Unimplemented) GetWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update webhook
+// (PUT /v1/webhooks/{webhook_id})
+func (_ Unimplemented) UpdateWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhook deliveries
+// (GET /v1/webhooks/{webhook_id}/deliveries)
+func (_ Unimplemented) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID, params ListWebhookDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Redeliver a webhook delivery
+// (POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+func (_ Unimplemented) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID, deliveryId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate webhook secret
+// (POST /v1/webhooks/{webhook_id}/rotate-secret)
+func (_ Unimplemented) RotateWebhookSecret(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the members of a workspace
+// (GET /v1/workspaces/{workspace_id}/members)
+func (_ Unimplemented) ListWorkspaceMembers(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a member from a workspace
+// (DELETE /v1/workspaces/{workspace_id}/members/{user_id})
+func (_ Unimplemented) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a member to a workspace
+// (PUT /v1/workspaces/{workspace_id}/members/{user_id})
+func (_ Unimplemented) SetWorkspaceMember(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -1143,11 +4434,102 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ListDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeadLettersParams
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeadLetters(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListServices operation middleware
+func (siw *ServerInterfaceWrapper) ListServices(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListServicesParams
+
+	// ------------- Optional query parameter "timeout_ms" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timeout_ms", r.URL.Query(), &params.TimeoutMs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "timeout_ms", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListServices(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListAgents operation middleware
 func (siw *ServerInterfaceWrapper) ListAgents(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAgentsParams
+
+	// ------------- Optional query parameter "deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "deleted", r.URL.Query(), &params.Deleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListAgents(w, r)
+		siw.Handler.ListAgents(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1235,8 +4617,32 @@ func (siw *ServerInterfaceWrapper) UpdateAgent(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateAgentParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateAgent(w, r, agentId)
+		siw.Handler.UpdateAgent(w, r, agentId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1246,8 +4652,8 @@ func (siw *ServerInterfaceWrapper) UpdateAgent(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// ListPermissionsForAgent operation middleware
-func (siw *ServerInterfaceWrapper) ListPermissionsForAgent(w http.ResponseWriter, r *http.Request) {
+// ListAgentGrants operation middleware
+func (siw *ServerInterfaceWrapper) ListAgentGrants(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1261,7 +4667,7 @@ func (siw *ServerInterfaceWrapper) ListPermissionsForAgent(w http.ResponseWriter
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListPermissionsForAgent(w, r, agentId)
+		siw.Handler.ListAgentGrants(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1271,8 +4677,8 @@ func (siw *ServerInterfaceWrapper) ListPermissionsForAgent(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// AddPermissionToAgent operation middleware
-func (siw *ServerInterfaceWrapper) AddPermissionToAgent(w http.ResponseWriter, r *http.Request) {
+// SetAgentGrant operation middleware
+func (siw *ServerInterfaceWrapper) SetAgentGrant(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1286,7 +4692,7 @@ func (siw *ServerInterfaceWrapper) AddPermissionToAgent(w http.ResponseWriter, r
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AddPermissionToAgent(w, r, agentId)
+		siw.Handler.SetAgentGrant(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1296,8 +4702,8 @@ func (siw *ServerInterfaceWrapper) AddPermissionToAgent(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// RemovePermissionFromAgent operation middleware
-func (siw *ServerInterfaceWrapper) RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request) {
+// RemoveAgentGrant operation middleware
+func (siw *ServerInterfaceWrapper) RemoveAgentGrant(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1310,17 +4716,26 @@ func (siw *ServerInterfaceWrapper) RemovePermissionFromAgent(w http.ResponseWrit
 		return
 	}
 
-	// ------------- Path parameter "permission_id" -------------
-	var permissionId openapi_types.UUID
+	// ------------- Path parameter "grantee_type" -------------
+	var granteeType GranteeType
 
-	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "grantee_type", chi.URLParam(r, "grantee_type"), &granteeType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "grantee_type", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "grantee_id" -------------
+	var granteeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "grantee_id", chi.URLParam(r, "grantee_id"), &granteeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "grantee_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemovePermissionFromAgent(w, r, agentId, permissionId)
+		siw.Handler.RemoveAgentGrant(w, r, agentId, granteeType, granteeId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1330,13 +4745,22 @@ func (siw *ServerInterfaceWrapper) RemovePermissionFromAgent(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
-// ListFlows operation middleware
-func (siw *ServerInterfaceWrapper) ListFlows(w http.ResponseWriter, r *http.Request) {
+// GetAgentHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetAgentHistory(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListFlowsParams
+	var params GetAgentHistoryParams
 
 	// ------------- Optional query parameter "per_page" -------------
 
@@ -1355,7 +4779,7 @@ func (siw *ServerInterfaceWrapper) ListFlows(w http.ResponseWriter, r *http.Requ
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListFlows(w, r, params)
+		siw.Handler.GetAgentHistory(w, r, agentId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1365,11 +4789,33 @@ func (siw *ServerInterfaceWrapper) ListFlows(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateFlow operation middleware
-func (siw *ServerInterfaceWrapper) CreateFlow(w http.ResponseWriter, r *http.Request) {
+// InvokeAgent operation middleware
+func (siw *ServerInterfaceWrapper) InvokeAgent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params InvokeAgentParams
+
+	// ------------- Optional query parameter "stream" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "stream", r.URL.Query(), &params.Stream)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "stream", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateFlow(w, r)
+		siw.Handler.InvokeAgent(w, r, agentId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1379,22 +4825,22 @@ func (siw *ServerInterfaceWrapper) CreateFlow(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteFlow operation middleware
-func (siw *ServerInterfaceWrapper) DeleteFlow(w http.ResponseWriter, r *http.Request) {
+// ListPermissionsForAgent operation middleware
+func (siw *ServerInterfaceWrapper) ListPermissionsForAgent(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "flow_id" -------------
-	var flowId openapi_types.UUID
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteFlow(w, r, flowId)
+		siw.Handler.ListPermissionsForAgent(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1404,22 +4850,22 @@ func (siw *ServerInterfaceWrapper) DeleteFlow(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetFlow operation middleware
-func (siw *ServerInterfaceWrapper) GetFlow(w http.ResponseWriter, r *http.Request) {
+// AddPermissionToAgent operation middleware
+func (siw *ServerInterfaceWrapper) AddPermissionToAgent(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "flow_id" -------------
-	var flowId openapi_types.UUID
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetFlow(w, r, flowId)
+		siw.Handler.AddPermissionToAgent(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1429,22 +4875,31 @@ func (siw *ServerInterfaceWrapper) GetFlow(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateFlow operation middleware
-func (siw *ServerInterfaceWrapper) UpdateFlow(w http.ResponseWriter, r *http.Request) {
+// RemovePermissionFromAgent operation middleware
+func (siw *ServerInterfaceWrapper) RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "flow_id" -------------
-	var flowId openapi_types.UUID
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "permission_id" -------------
+	var permissionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateFlow(w, r, flowId)
+		siw.Handler.RemovePermissionFromAgent(w, r, agentId, permissionId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1454,22 +4909,22 @@ func (siw *ServerInterfaceWrapper) UpdateFlow(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ExecuteFlow operation middleware
-func (siw *ServerInterfaceWrapper) ExecuteFlow(w http.ResponseWriter, r *http.Request) {
+// ListAgentProbes operation middleware
+func (siw *ServerInterfaceWrapper) ListAgentProbes(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "flow_id" -------------
-	var flowId openapi_types.UUID
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ExecuteFlow(w, r, flowId)
+		siw.Handler.ListAgentProbes(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1479,22 +4934,22 @@ func (siw *ServerInterfaceWrapper) ExecuteFlow(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetFlowRun operation middleware
-func (siw *ServerInterfaceWrapper) GetFlowRun(w http.ResponseWriter, r *http.Request) {
+// CreateAgentProbe operation middleware
+func (siw *ServerInterfaceWrapper) CreateAgentProbe(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "flow_run_id" -------------
-	var flowRunId openapi_types.UUID
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "flow_run_id", chi.URLParam(r, "flow_run_id"), &flowRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_run_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetFlowRun(w, r, flowRunId)
+		siw.Handler.CreateAgentProbe(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1504,11 +4959,31 @@ func (siw *ServerInterfaceWrapper) GetFlowRun(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// MockStandaloneTool operation middleware
-func (siw *ServerInterfaceWrapper) MockStandaloneTool(w http.ResponseWriter, r *http.Request) {
+// DeleteAgentProbe operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAgentProbe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "probe_id" -------------
+	var probeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "probe_id", chi.URLParam(r, "probe_id"), &probeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "probe_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MockStandaloneTool(w, r)
+		siw.Handler.DeleteAgentProbe(w, r, agentId, probeId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1518,11 +4993,31 @@ func (siw *ServerInterfaceWrapper) MockStandaloneTool(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// MockStandaloneToolWithDelay operation middleware
-func (siw *ServerInterfaceWrapper) MockStandaloneToolWithDelay(w http.ResponseWriter, r *http.Request) {
+// GetAgentProbe operation middleware
+func (siw *ServerInterfaceWrapper) GetAgentProbe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "probe_id" -------------
+	var probeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "probe_id", chi.URLParam(r, "probe_id"), &probeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "probe_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MockStandaloneToolWithDelay(w, r)
+		siw.Handler.GetAgentProbe(w, r, agentId, probeId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1532,11 +5027,31 @@ func (siw *ServerInterfaceWrapper) MockStandaloneToolWithDelay(w http.ResponseWr
 	handler.ServeHTTP(w, r)
 }
 
-// ListPermissions operation middleware
-func (siw *ServerInterfaceWrapper) ListPermissions(w http.ResponseWriter, r *http.Request) {
+// UpdateAgentProbe operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAgentProbe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "probe_id" -------------
+	var probeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "probe_id", chi.URLParam(r, "probe_id"), &probeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "probe_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListPermissions(w, r)
+		siw.Handler.UpdateAgentProbe(w, r, agentId, probeId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1546,11 +5061,50 @@ func (siw *ServerInterfaceWrapper) ListPermissions(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// CreatePermission operation middleware
-func (siw *ServerInterfaceWrapper) CreatePermission(w http.ResponseWriter, r *http.Request) {
+// ListAgentProbeRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListAgentProbeRuns(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "probe_id" -------------
+	var probeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "probe_id", chi.URLParam(r, "probe_id"), &probeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "probe_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAgentProbeRunsParams
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreatePermission(w, r)
+		siw.Handler.ListAgentProbeRuns(w, r, agentId, probeId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1560,22 +5114,22 @@ func (siw *ServerInterfaceWrapper) CreatePermission(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// DeletePermission operation middleware
-func (siw *ServerInterfaceWrapper) DeletePermission(w http.ResponseWriter, r *http.Request) {
+// PurgeAgent operation middleware
+func (siw *ServerInterfaceWrapper) PurgeAgent(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "permission_id" -------------
-	var permissionId openapi_types.UUID
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeletePermission(w, r, permissionId)
+		siw.Handler.PurgeAgent(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1585,22 +5139,22 @@ func (siw *ServerInterfaceWrapper) DeletePermission(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetPermission operation middleware
-func (siw *ServerInterfaceWrapper) GetPermission(w http.ResponseWriter, r *http.Request) {
+// RestoreAgent operation middleware
+func (siw *ServerInterfaceWrapper) RestoreAgent(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "permission_id" -------------
-	var permissionId openapi_types.UUID
+	// ------------- Path parameter "agent_id" -------------
+	var agentId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "agent_id", chi.URLParam(r, "agent_id"), &agentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPermission(w, r, permissionId)
+		siw.Handler.RestoreAgent(w, r, agentId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1610,22 +5164,32 @@ func (siw *ServerInterfaceWrapper) GetPermission(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// UpdatePermission operation middleware
-func (siw *ServerInterfaceWrapper) UpdatePermission(w http.ResponseWriter, r *http.Request) {
+// GetFlowCostAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowCostAnalytics(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "permission_id" -------------
-	var permissionId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlowCostAnalyticsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdatePermission(w, r, permissionId)
+		siw.Handler.GetFlowCostAnalytics(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1635,11 +5199,11 @@ func (siw *ServerInterfaceWrapper) UpdatePermission(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// ListRoles operation middleware
-func (siw *ServerInterfaceWrapper) ListRoles(w http.ResponseWriter, r *http.Request) {
+// GetPromptCacheAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) GetPromptCacheAnalytics(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListRoles(w, r)
+		siw.Handler.GetPromptCacheAnalytics(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1649,11 +5213,11 @@ func (siw *ServerInterfaceWrapper) ListRoles(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateRole operation middleware
-func (siw *ServerInterfaceWrapper) CreateRole(w http.ResponseWriter, r *http.Request) {
+// GetThinkingBudgetAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) GetThinkingBudgetAnalytics(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateRole(w, r)
+		siw.Handler.GetThinkingBudgetAnalytics(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1663,22 +5227,32 @@ func (siw *ServerInterfaceWrapper) CreateRole(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteRole operation middleware
-func (siw *ServerInterfaceWrapper) DeleteRole(w http.ResponseWriter, r *http.Request) {
+// GetUserFlowCostAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) GetUserFlowCostAnalytics(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "role_id" -------------
-	var roleId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserFlowCostAnalyticsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteRole(w, r, roleId)
+		siw.Handler.GetUserFlowCostAnalytics(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1688,47 +5262,88 @@ func (siw *ServerInterfaceWrapper) DeleteRole(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetRole operation middleware
-func (siw *ServerInterfaceWrapper) GetRole(w http.ResponseWriter, r *http.Request) {
+// ListToolRunAudit operation middleware
+func (siw *ServerInterfaceWrapper) ListToolRunAudit(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "role_id" -------------
-	var roleId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListToolRunAuditParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "tool_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tool_id", r.URL.Query(), &params.ToolId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRole(w, r, roleId)
-	}))
+	// ------------- Optional query parameter "agent_id" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "agent_id", r.URL.Query(), &params.AgentId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "agent_id", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "user_id" -------------
 
-// UpdateRole operation middleware
-func (siw *ServerInterfaceWrapper) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
 
-	var err error
+	// ------------- Optional query parameter "task_id" -------------
 
-	// ------------- Path parameter "role_id" -------------
-	var roleId openapi_types.UUID
+	err = runtime.BindQueryParameter("form", true, false, "task_id", r.URL.Query(), &params.TaskId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateRole(w, r, roleId)
+		siw.Handler.ListToolRunAudit(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1738,22 +5353,22 @@ func (siw *ServerInterfaceWrapper) UpdateRole(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ListPermissionsForRole operation middleware
-func (siw *ServerInterfaceWrapper) ListPermissionsForRole(w http.ResponseWriter, r *http.Request) {
+// GetToolRunAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetToolRunAudit(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "role_id" -------------
-	var roleId openapi_types.UUID
+	// ------------- Path parameter "tool_run_id" -------------
+	var toolRunId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_run_id", chi.URLParam(r, "tool_run_id"), &toolRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListPermissionsForRole(w, r, roleId)
+		siw.Handler.GetToolRunAudit(w, r, toolRunId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1763,22 +5378,80 @@ func (siw *ServerInterfaceWrapper) ListPermissionsForRole(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// AddPermissionToRole operation middleware
-func (siw *ServerInterfaceWrapper) AddPermissionToRole(w http.ResponseWriter, r *http.Request) {
+// ListFeedback operation middleware
+func (siw *ServerInterfaceWrapper) ListFeedback(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "role_id" -------------
-	var roleId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFeedbackParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "rating" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rating", r.URL.Query(), &params.Rating)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rating", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "thread_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "thread_id", r.URL.Query(), &params.ThreadId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AddPermissionToRole(w, r, roleId)
+		siw.Handler.ListFeedback(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1788,31 +5461,64 @@ func (siw *ServerInterfaceWrapper) AddPermissionToRole(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// RemovePermissionFromRole operation middleware
-func (siw *ServerInterfaceWrapper) RemovePermissionFromRole(w http.ResponseWriter, r *http.Request) {
+// ExportFeedback operation middleware
+func (siw *ServerInterfaceWrapper) ExportFeedback(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "role_id" -------------
-	var roleId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportFeedbackParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "rating" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rating", r.URL.Query(), &params.Rating)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rating", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "permission_id" -------------
-	var permissionId openapi_types.UUID
+	// ------------- Optional query parameter "thread_id" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "thread_id", r.URL.Query(), &params.ThreadId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemovePermissionFromRole(w, r, roleId, permissionId)
+		siw.Handler.ExportFeedback(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1822,32 +5528,32 @@ func (siw *ServerInterfaceWrapper) RemovePermissionFromRole(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// ListTasks operation middleware
-func (siw *ServerInterfaceWrapper) ListTasks(w http.ResponseWriter, r *http.Request) {
+// ListFiles operation middleware
+func (siw *ServerInterfaceWrapper) ListFiles(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListTasksParams
+	var params ListFilesParams
 
-	// ------------- Optional query parameter "per_page" -------------
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "page" -------------
+	// ------------- Optional query parameter "cursor" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTasks(w, r, params)
+		siw.Handler.ListFiles(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1857,11 +5563,11 @@ func (siw *ServerInterfaceWrapper) ListTasks(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateTask operation middleware
-func (siw *ServerInterfaceWrapper) CreateTask(w http.ResponseWriter, r *http.Request) {
+// CreateFile operation middleware
+func (siw *ServerInterfaceWrapper) CreateFile(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateTask(w, r)
+		siw.Handler.CreateFile(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1871,22 +5577,22 @@ func (siw *ServerInterfaceWrapper) CreateTask(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteTask operation middleware
-func (siw *ServerInterfaceWrapper) DeleteTask(w http.ResponseWriter, r *http.Request) {
+// DeleteFile operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFile(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "task_id" -------------
-	var taskId openapi_types.UUID
+	// ------------- Path parameter "file_id" -------------
+	var fileId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "file_id", chi.URLParam(r, "file_id"), &fileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTask(w, r, taskId)
+		siw.Handler.DeleteFile(w, r, fileId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1896,22 +5602,22 @@ func (siw *ServerInterfaceWrapper) DeleteTask(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetTask operation middleware
-func (siw *ServerInterfaceWrapper) GetTask(w http.ResponseWriter, r *http.Request) {
+// GetFile operation middleware
+func (siw *ServerInterfaceWrapper) GetFile(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "task_id" -------------
-	var taskId openapi_types.UUID
+	// ------------- Path parameter "file_id" -------------
+	var fileId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "file_id", chi.URLParam(r, "file_id"), &fileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTask(w, r, taskId)
+		siw.Handler.GetFile(w, r, fileId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1921,22 +5627,22 @@ func (siw *ServerInterfaceWrapper) GetTask(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateTask operation middleware
-func (siw *ServerInterfaceWrapper) UpdateTask(w http.ResponseWriter, r *http.Request) {
+// CompleteFileUpload operation middleware
+func (siw *ServerInterfaceWrapper) CompleteFileUpload(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "task_id" -------------
-	var taskId openapi_types.UUID
+	// ------------- Path parameter "file_id" -------------
+	var fileId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "file_id", chi.URLParam(r, "file_id"), &fileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateTask(w, r, taskId)
+		siw.Handler.CompleteFileUpload(w, r, fileId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1946,22 +5652,22 @@ func (siw *ServerInterfaceWrapper) UpdateTask(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ExecuteTask operation middleware
-func (siw *ServerInterfaceWrapper) ExecuteTask(w http.ResponseWriter, r *http.Request) {
+// DownloadFile operation middleware
+func (siw *ServerInterfaceWrapper) DownloadFile(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "task_id" -------------
-	var taskId openapi_types.UUID
+	// ------------- Path parameter "file_id" -------------
+	var fileId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "file_id", chi.URLParam(r, "file_id"), &fileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ExecuteTask(w, r, taskId)
+		siw.Handler.DownloadFile(w, r, fileId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1971,22 +5677,22 @@ func (siw *ServerInterfaceWrapper) ExecuteTask(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// ListTaskRuns operation middleware
-func (siw *ServerInterfaceWrapper) ListTaskRuns(w http.ResponseWriter, r *http.Request) {
+// RetryFlowRun operation middleware
+func (siw *ServerInterfaceWrapper) RetryFlowRun(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "task_id" -------------
-	var taskId openapi_types.UUID
+	// ------------- Path parameter "flow_run_id" -------------
+	var flowRunId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_run_id", chi.URLParam(r, "flow_run_id"), &flowRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTaskRuns(w, r, taskId)
+		siw.Handler.RetryFlowRun(w, r, flowRunId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1996,36 +5702,40 @@ func (siw *ServerInterfaceWrapper) ListTaskRuns(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetTaskRun operation middleware
-func (siw *ServerInterfaceWrapper) GetTaskRun(w http.ResponseWriter, r *http.Request) {
+// ListFlows operation middleware
+func (siw *ServerInterfaceWrapper) ListFlows(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "task_run_id" -------------
-	var taskRunId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFlowsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "task_run_id", chi.URLParam(r, "task_run_id"), &taskRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_run_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTaskRun(w, r, taskRunId)
-	}))
+	// ------------- Optional query parameter "page" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "deleted" -------------
 
-// ListThreads operation middleware
-func (siw *ServerInterfaceWrapper) ListThreads(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "deleted", r.URL.Query(), &params.Deleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deleted", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListThreads(w, r)
+		siw.Handler.ListFlows(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2035,11 +5745,11 @@ func (siw *ServerInterfaceWrapper) ListThreads(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// CreateThread operation middleware
-func (siw *ServerInterfaceWrapper) CreateThread(w http.ResponseWriter, r *http.Request) {
+// CreateFlow operation middleware
+func (siw *ServerInterfaceWrapper) CreateFlow(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateThread(w, r)
+		siw.Handler.CreateFlow(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2049,22 +5759,22 @@ func (siw *ServerInterfaceWrapper) CreateThread(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteThread operation middleware
-func (siw *ServerInterfaceWrapper) DeleteThread(w http.ResponseWriter, r *http.Request) {
+// DeleteFlow operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFlow(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteThread(w, r, threadId)
+		siw.Handler.DeleteFlow(w, r, flowId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2074,22 +5784,22 @@ func (siw *ServerInterfaceWrapper) DeleteThread(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetThread operation middleware
-func (siw *ServerInterfaceWrapper) GetThread(w http.ResponseWriter, r *http.Request) {
+// GetFlow operation middleware
+func (siw *ServerInterfaceWrapper) GetFlow(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetThread(w, r, threadId)
+		siw.Handler.GetFlow(w, r, flowId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2099,22 +5809,22 @@ func (siw *ServerInterfaceWrapper) GetThread(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateThreadTitle operation middleware
-func (siw *ServerInterfaceWrapper) UpdateThreadTitle(w http.ResponseWriter, r *http.Request) {
+// UpdateFlow operation middleware
+func (siw *ServerInterfaceWrapper) UpdateFlow(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateThreadTitle(w, r, threadId)
+		siw.Handler.UpdateFlow(w, r, flowId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2124,22 +5834,46 @@ func (siw *ServerInterfaceWrapper) UpdateThreadTitle(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// ListMessages operation middleware
-func (siw *ServerInterfaceWrapper) ListMessages(w http.ResponseWriter, r *http.Request) {
+// ExecuteFlow operation middleware
+func (siw *ServerInterfaceWrapper) ExecuteFlow(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExecuteFlowParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListMessages(w, r, threadId)
+		siw.Handler.ExecuteFlow(w, r, flowId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2149,22 +5883,41 @@ func (siw *ServerInterfaceWrapper) ListMessages(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// CreateMessage operation middleware
-func (siw *ServerInterfaceWrapper) CreateMessage(w http.ResponseWriter, r *http.Request) {
+// GetFlowHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowHistory(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlowHistoryParams
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateMessage(w, r, threadId)
+		siw.Handler.GetFlowHistory(w, r, flowId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2174,31 +5927,22 @@ func (siw *ServerInterfaceWrapper) CreateMessage(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteMessage operation middleware
-func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+// PurgeFlow operation middleware
+func (siw *ServerInterfaceWrapper) PurgeFlow(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
-		return
-	}
-
-	// ------------- Path parameter "message_id" -------------
-	var messageId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteMessage(w, r, threadId, messageId)
+		siw.Handler.PurgeFlow(w, r, flowId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2208,31 +5952,22 @@ func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetMessage operation middleware
-func (siw *ServerInterfaceWrapper) GetMessage(w http.ResponseWriter, r *http.Request) {
+// RestoreFlow operation middleware
+func (siw *ServerInterfaceWrapper) RestoreFlow(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
-		return
-	}
-
-	// ------------- Path parameter "message_id" -------------
-	var messageId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetMessage(w, r, threadId, messageId)
+		siw.Handler.RestoreFlow(w, r, flowId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2242,59 +5977,81 @@ func (siw *ServerInterfaceWrapper) GetMessage(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateMessage operation middleware
-func (siw *ServerInterfaceWrapper) UpdateMessage(w http.ResponseWriter, r *http.Request) {
+// ListFlowRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListFlowRuns(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "thread_id" -------------
-	var threadId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "message_id" -------------
-	var messageId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFlowRunsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateMessage(w, r, threadId, messageId)
-	}))
+	// ------------- Optional query parameter "created_after" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "created_before" -------------
 
-// ListTools operation middleware
-func (siw *ServerInterfaceWrapper) ListTools(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTools(w, r)
-	}))
+	// ------------- Optional query parameter "sort" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "order" -------------
 
-// CreateTool operation middleware
-func (siw *ServerInterfaceWrapper) CreateTool(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateTool(w, r)
+		siw.Handler.ListFlowRuns(w, r, flowId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2304,22 +6061,31 @@ func (siw *ServerInterfaceWrapper) CreateTool(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteTool operation middleware
-func (siw *ServerInterfaceWrapper) DeleteTool(w http.ResponseWriter, r *http.Request) {
+// ListFlowRunArtifacts operation middleware
+func (siw *ServerInterfaceWrapper) ListFlowRunArtifacts(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "tool_id" -------------
-	var toolId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTool(w, r, toolId)
+		siw.Handler.ListFlowRunArtifacts(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2329,22 +6095,40 @@ func (siw *ServerInterfaceWrapper) DeleteTool(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetToolById operation middleware
-func (siw *ServerInterfaceWrapper) GetToolById(w http.ResponseWriter, r *http.Request) {
+// DownloadFlowRunArtifact operation middleware
+func (siw *ServerInterfaceWrapper) DownloadFlowRunArtifact(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "tool_id" -------------
-	var toolId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetToolById(w, r, toolId)
+		siw.Handler.DownloadFlowRunArtifact(w, r, flowId, runId, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2354,22 +6138,31 @@ func (siw *ServerInterfaceWrapper) GetToolById(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateTool operation middleware
-func (siw *ServerInterfaceWrapper) UpdateTool(w http.ResponseWriter, r *http.Request) {
+// CancelFlowRun operation middleware
+func (siw *ServerInterfaceWrapper) CancelFlowRun(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "tool_id" -------------
-	var toolId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateTool(w, r, toolId)
+		siw.Handler.CancelFlowRun(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2379,25 +6172,31 @@ func (siw *ServerInterfaceWrapper) UpdateTool(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ListUsers operation middleware
-func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+// ListChildFlowRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListChildFlowRuns(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListUsers(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
 
-// CreateUser operation middleware
-func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateUser(w, r)
+		siw.Handler.ListChildFlowRuns(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2407,22 +6206,31 @@ func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteUser operation middleware
-func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+// GetFlowRunCost operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowRunCost(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteUser(w, r, userId)
+		siw.Handler.GetFlowRunCost(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2432,22 +6240,31 @@ func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetUser operation middleware
-func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+// GetFlowRunGraph operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowRunGraph(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUser(w, r, userId)
+		siw.Handler.GetFlowRunGraph(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2457,22 +6274,58 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateUser operation middleware
-func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+// GetFlowRunLogs operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowRunLogs(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlowRunLogsParams
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "follow" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "follow", r.URL.Query(), &params.Follow)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "follow", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateUser(w, r, userId)
+		siw.Handler.GetFlowRunLogs(w, r, flowId, runId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2482,22 +6335,31 @@ func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ListRoleForUser operation middleware
-func (siw *ServerInterfaceWrapper) ListRoleForUser(w http.ResponseWriter, r *http.Request) {
+// PauseFlowRun operation middleware
+func (siw *ServerInterfaceWrapper) PauseFlowRun(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListRoleForUser(w, r, userId)
+		siw.Handler.PauseFlowRun(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2507,22 +6369,31 @@ func (siw *ServerInterfaceWrapper) ListRoleForUser(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// AddRoleToUser operation middleware
-func (siw *ServerInterfaceWrapper) AddRoleToUser(w http.ResponseWriter, r *http.Request) {
+// ResumeFlowRun operation middleware
+func (siw *ServerInterfaceWrapper) ResumeFlowRun(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AddRoleToUser(w, r, userId)
+		siw.Handler.ResumeFlowRun(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2532,31 +6403,31 @@ func (siw *ServerInterfaceWrapper) AddRoleToUser(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// RemoveRoleFromUser operation middleware
-func (siw *ServerInterfaceWrapper) RemoveRoleFromUser(w http.ResponseWriter, r *http.Request) {
+// GetFlowRunTimeline operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowRunTimeline(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "role_id" -------------
-	var roleId openapi_types.UUID
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemoveRoleFromUser(w, r, userId, roleId)
+		siw.Handler.GetFlowRunTimeline(w, r, flowId, runId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2566,2010 +6437,10469 @@ func (siw *ServerInterfaceWrapper) RemoveRoleFromUser(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// ListFlowSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListFlowSchedules(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFlowSchedules(w, r, flowId)
+	}))
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RequiredParamError struct {
-	ParamName string
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+// CreateFlowSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateFlowSchedule(w http.ResponseWriter, r *http.Request) {
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateFlowSchedule(w, r, flowId)
+	}))
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r)
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+// DeleteFlowSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFlowSchedule(w http.ResponseWriter, r *http.Request) {
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	var err error
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFlowSchedule(w, r, flowId, scheduleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GetFlowSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowSchedule(w http.ResponseWriter, r *http.Request) {
 
-	if r == nil {
-		r = chi.NewRouter()
+	var err error
+
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlowSchedule(w, r, flowId, scheduleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/agents", wrapper.ListAgents)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/agents", wrapper.CreateAgent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/agents/{agent_id}", wrapper.DeleteAgent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/agents/{agent_id}", wrapper.GetAgent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/agents/{agent_id}", wrapper.UpdateAgent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/agents/{agent_id}/permissions", wrapper.ListPermissionsForAgent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/agents/{agent_id}/permissions", wrapper.AddPermissionToAgent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/agents/{agent_id}/permissions/{permission_id}", wrapper.RemovePermissionFromAgent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/flows", wrapper.ListFlows)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/flows", wrapper.CreateFlow)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/flows/{flow_id}", wrapper.DeleteFlow)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/flows/{flow_id}", wrapper.GetFlow)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/flows/{flow_id}", wrapper.UpdateFlow)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/flows/{flow_id}/execute", wrapper.ExecuteFlow)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/flows/{flow_run_id}/status", wrapper.GetFlowRun)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/mock/tool", wrapper.MockStandaloneTool)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/mock/tool_with_delay", wrapper.MockStandaloneToolWithDelay)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/permissions", wrapper.ListPermissions)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/permissions", wrapper.CreatePermission)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/permissions/{permission_id}", wrapper.DeletePermission)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/permissions/{permission_id}", wrapper.GetPermission)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/permissions/{permission_id}", wrapper.UpdatePermission)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/roles", wrapper.ListRoles)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/roles", wrapper.CreateRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/roles/{role_id}", wrapper.DeleteRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/roles/{role_id}", wrapper.GetRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/roles/{role_id}", wrapper.UpdateRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/roles/{role_id}/permissions", wrapper.ListPermissionsForRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/roles/{role_id}/permissions", wrapper.AddPermissionToRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/roles/{role_id}/permissions/{permission_id}", wrapper.RemovePermissionFromRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/tasks", wrapper.ListTasks)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/tasks", wrapper.CreateTask)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/tasks/{task_id}", wrapper.DeleteTask)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/tasks/{task_id}", wrapper.GetTask)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/tasks/{task_id}", wrapper.UpdateTask)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/tasks/{task_id}/execute", wrapper.ExecuteTask)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/tasks/{task_id}/runs", wrapper.ListTaskRuns)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/tasks/{task_run_id}/status", wrapper.GetTaskRun)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/threads", wrapper.ListThreads)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/threads", wrapper.CreateThread)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/threads/{thread_id}", wrapper.DeleteThread)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/threads/{thread_id}", wrapper.GetThread)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/threads/{thread_id}", wrapper.UpdateThreadTitle)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/threads/{thread_id}/messages", wrapper.ListMessages)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/threads/{thread_id}/messages", wrapper.CreateMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}", wrapper.DeleteMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}", wrapper.GetMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}", wrapper.UpdateMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/tools", wrapper.ListTools)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/tools", wrapper.CreateTool)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/tools/{tool_id}", wrapper.DeleteTool)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/tools/{tool_id}", wrapper.GetToolById)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/tools/{tool_id}", wrapper.UpdateTool)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/users", wrapper.ListUsers)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/users", wrapper.CreateUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/users/{user_id}", wrapper.DeleteUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/users/{user_id}", wrapper.GetUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/v1/users/{user_id}", wrapper.UpdateUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/users/{user_id}/roles", wrapper.ListRoleForUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/users/{user_id}/roles", wrapper.AddRoleToUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/v1/users/{user_id}/roles/{role_id}", wrapper.RemoveRoleFromUser)
-	})
-
-	return r
-}
-
-type ListAgentsRequestObject struct {
+	handler.ServeHTTP(w, r)
 }
 
-type ListAgentsResponseObject interface {
-	VisitListAgentsResponse(w http.ResponseWriter) error
-}
+// UpdateFlowSchedule operation middleware
+func (siw *ServerInterfaceWrapper) UpdateFlowSchedule(w http.ResponseWriter, r *http.Request) {
 
-type ListAgents200JSONResponse AgentList
+	var err error
 
-func (response ListAgents200JSONResponse) VisitListAgentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
 
-type CreateAgentRequestObject struct {
-	Body *CreateAgentJSONRequestBody
-}
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-type CreateAgentResponseObject interface {
-	VisitCreateAgentResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
 
-type CreateAgent201JSONResponse Agent
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateFlowSchedule(w, r, flowId, scheduleId)
+	}))
 
-func (response CreateAgent201JSONResponse) VisitCreateAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreateAgent400JSONResponse BadRequest
-
-func (response CreateAgent400JSONResponse) VisitCreateAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ListFlowScheduleBackfills operation middleware
+func (siw *ServerInterfaceWrapper) ListFlowScheduleBackfills(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type DeleteAgentRequestObject struct {
-	AgentId openapi_types.UUID `json:"agent_id"`
-}
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-type DeleteAgentResponseObject interface {
-	VisitDeleteAgentResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
 
-type DeleteAgent204Response struct {
-}
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-func (response DeleteAgent204Response) VisitDeleteAgentResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
 
-type DeleteAgent404JSONResponse NotFound
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFlowScheduleBackfills(w, r, flowId, scheduleId)
+	}))
 
-func (response DeleteAgent404JSONResponse) VisitDeleteAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetAgentRequestObject struct {
-	AgentId openapi_types.UUID `json:"agent_id"`
-}
+// CreateFlowScheduleBackfill operation middleware
+func (siw *ServerInterfaceWrapper) CreateFlowScheduleBackfill(w http.ResponseWriter, r *http.Request) {
 
-type GetAgentResponseObject interface {
-	VisitGetAgentResponse(w http.ResponseWriter) error
-}
-
-type GetAgent200JSONResponse Agent
+	var err error
 
-func (response GetAgent200JSONResponse) VisitGetAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
 
-type GetAgent404JSONResponse NotFound
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-func (response GetAgent404JSONResponse) VisitGetAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateFlowScheduleBackfill(w, r, flowId, scheduleId)
+	}))
 
-type UpdateAgentRequestObject struct {
-	AgentId openapi_types.UUID `json:"agent_id"`
-	Body    *UpdateAgentJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type UpdateAgentResponseObject interface {
-	VisitUpdateAgentResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateAgent200JSONResponse Agent
+// CancelFlowScheduleBackfill operation middleware
+func (siw *ServerInterfaceWrapper) CancelFlowScheduleBackfill(w http.ResponseWriter, r *http.Request) {
 
-func (response UpdateAgent200JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "flow_id" -------------
+	var flowId openapi_types.UUID
 
-type UpdateAgent400JSONResponse BadRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", chi.URLParam(r, "flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_id", Err: err})
+		return
+	}
 
-func (response UpdateAgent400JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
 
-type UpdateAgent404JSONResponse NotFound
+	// ------------- Path parameter "backfill_id" -------------
+	var backfillId openapi_types.UUID
 
-func (response UpdateAgent404JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindStyledParameterWithOptions("simple", "backfill_id", chi.URLParam(r, "backfill_id"), &backfillId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "backfill_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelFlowScheduleBackfill(w, r, flowId, scheduleId, backfillId)
+	}))
 
-type ListPermissionsForAgentRequestObject struct {
-	AgentId openapi_types.UUID `json:"agent_id"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListPermissionsForAgentResponseObject interface {
-	VisitListPermissionsForAgentResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type ListPermissionsForAgent200JSONResponse AgentPermissionMappingList
+// GetFlowRun operation middleware
+func (siw *ServerInterfaceWrapper) GetFlowRun(w http.ResponseWriter, r *http.Request) {
 
-func (response ListPermissionsForAgent200JSONResponse) VisitListPermissionsForAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "flow_run_id" -------------
+	var flowRunId openapi_types.UUID
 
-type ListPermissionsForAgent404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_run_id", chi.URLParam(r, "flow_run_id"), &flowRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flow_run_id", Err: err})
+		return
+	}
 
-func (response ListPermissionsForAgent404JSONResponse) VisitListPermissionsForAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlowRun(w, r, flowRunId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type AddPermissionToAgentRequestObject struct {
-	AgentId openapi_types.UUID `json:"agent_id"`
-	Body    *AddPermissionToAgentJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type AddPermissionToAgentResponseObject interface {
-	VisitAddPermissionToAgentResponse(w http.ResponseWriter) error
-}
+// MockStandaloneTool operation middleware
+func (siw *ServerInterfaceWrapper) MockStandaloneTool(w http.ResponseWriter, r *http.Request) {
 
-type AddPermissionToAgent201JSONResponse AgentPermissionMapping
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MockStandaloneTool(w, r)
+	}))
 
-func (response AddPermissionToAgent201JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type AddPermissionToAgent400JSONResponse BadRequest
+// MockStandaloneToolWithDelay operation middleware
+func (siw *ServerInterfaceWrapper) MockStandaloneToolWithDelay(w http.ResponseWriter, r *http.Request) {
 
-func (response AddPermissionToAgent400JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MockStandaloneToolWithDelay(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type AddPermissionToAgent404JSONResponse NotFound
+// ListOrganizations operation middleware
+func (siw *ServerInterfaceWrapper) ListOrganizations(w http.ResponseWriter, r *http.Request) {
 
-func (response AddPermissionToAgent404JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrganizations(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type AddPermissionToAgent409JSONResponse ResourceAlreadyExists
+// CreateOrganization operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrganization(w http.ResponseWriter, r *http.Request) {
 
-func (response AddPermissionToAgent409JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateOrganization(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RemovePermissionFromAgentRequestObject struct {
-	AgentId      openapi_types.UUID `json:"agent_id"`
-	PermissionId openapi_types.UUID `json:"permission_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type RemovePermissionFromAgentResponseObject interface {
-	VisitRemovePermissionFromAgentResponse(w http.ResponseWriter) error
-}
+// ListOrganizationMembers operation middleware
+func (siw *ServerInterfaceWrapper) ListOrganizationMembers(w http.ResponseWriter, r *http.Request) {
 
-type RemovePermissionFromAgent204Response struct {
-}
+	var err error
 
-func (response RemovePermissionFromAgent204Response) VisitRemovePermissionFromAgentResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId openapi_types.UUID
 
-type RemovePermissionFromAgent404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "organization_id", chi.URLParam(r, "organization_id"), &organizationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "organization_id", Err: err})
+		return
+	}
 
-func (response RemovePermissionFromAgent404JSONResponse) VisitRemovePermissionFromAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrganizationMembers(w, r, organizationId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListFlowsRequestObject struct {
-	Params ListFlowsParams
+	handler.ServeHTTP(w, r)
 }
 
-type ListFlowsResponseObject interface {
-	VisitListFlowsResponse(w http.ResponseWriter) error
-}
+// RemoveOrganizationMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveOrganizationMember(w http.ResponseWriter, r *http.Request) {
 
-type ListFlows200JSONResponse FlowList
+	var err error
 
-func (response ListFlows200JSONResponse) VisitListFlowsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "organization_id", chi.URLParam(r, "organization_id"), &organizationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "organization_id", Err: err})
+		return
+	}
 
-type CreateFlowRequestObject struct {
-	Body *CreateFlowJSONRequestBody
-}
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
 
-type CreateFlowResponseObject interface {
-	VisitCreateFlowResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
 
-type CreateFlow201JSONResponse Flow
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveOrganizationMember(w, r, organizationId, userId)
+	}))
 
-func (response CreateFlow201JSONResponse) VisitCreateFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreateFlow400JSONResponse NotFound
-
-func (response CreateFlow400JSONResponse) VisitCreateFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// SetOrganizationMember operation middleware
+func (siw *ServerInterfaceWrapper) SetOrganizationMember(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type DeleteFlowRequestObject struct {
-	FlowId openapi_types.UUID `json:"flow_id"`
-}
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId openapi_types.UUID
 
-type DeleteFlowResponseObject interface {
-	VisitDeleteFlowResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "organization_id", chi.URLParam(r, "organization_id"), &organizationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "organization_id", Err: err})
+		return
+	}
 
-type DeleteFlow204Response struct {
-}
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
 
-func (response DeleteFlow204Response) VisitDeleteFlowResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
-
-type DeleteFlow404JSONResponse NotFound
-
-func (response DeleteFlow404JSONResponse) VisitDeleteFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetOrganizationMember(w, r, organizationId, userId)
+	}))
 
-type GetFlowRequestObject struct {
-	FlowId openapi_types.UUID `json:"flow_id"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetFlowResponseObject interface {
-	VisitGetFlowResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type GetFlow200JSONResponse Flow
-
-func (response GetFlow200JSONResponse) VisitGetFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ListOrganizationWorkspaces operation middleware
+func (siw *ServerInterfaceWrapper) ListOrganizationWorkspaces(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetFlow404JSONResponse NotFound
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId openapi_types.UUID
 
-func (response GetFlow404JSONResponse) VisitGetFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindStyledParameterWithOptions("simple", "organization_id", chi.URLParam(r, "organization_id"), &organizationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "organization_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrganizationWorkspaces(w, r, organizationId)
+	}))
 
-type UpdateFlowRequestObject struct {
-	FlowId openapi_types.UUID `json:"flow_id"`
-	Body   *UpdateFlowJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type UpdateFlowResponseObject interface {
-	VisitUpdateFlowResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateFlow200JSONResponse Flow
+// CreateWorkspace operation middleware
+func (siw *ServerInterfaceWrapper) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
 
-func (response UpdateFlow200JSONResponse) VisitUpdateFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId openapi_types.UUID
 
-type UpdateFlow404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "organization_id", chi.URLParam(r, "organization_id"), &organizationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "organization_id", Err: err})
+		return
+	}
 
-func (response UpdateFlow404JSONResponse) VisitUpdateFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWorkspace(w, r, organizationId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ExecuteFlowRequestObject struct {
-	FlowId openapi_types.UUID `json:"flow_id"`
-	Body   *ExecuteFlowJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type ExecuteFlowResponseObject interface {
-	VisitExecuteFlowResponse(w http.ResponseWriter) error
-}
+// ListPermissions operation middleware
+func (siw *ServerInterfaceWrapper) ListPermissions(w http.ResponseWriter, r *http.Request) {
 
-type ExecuteFlow200JSONResponse FlowRun
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPermissions(w, r)
+	}))
 
-func (response ExecuteFlow200JSONResponse) VisitExecuteFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ExecuteFlow404JSONResponse NotFound
+// CreatePermission operation middleware
+func (siw *ServerInterfaceWrapper) CreatePermission(w http.ResponseWriter, r *http.Request) {
 
-func (response ExecuteFlow404JSONResponse) VisitExecuteFlowResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePermission(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetFlowRunRequestObject struct {
-	FlowRunId openapi_types.UUID `json:"flow_run_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type GetFlowRunResponseObject interface {
-	VisitGetFlowRunResponse(w http.ResponseWriter) error
-}
+// DeletePermission operation middleware
+func (siw *ServerInterfaceWrapper) DeletePermission(w http.ResponseWriter, r *http.Request) {
 
-type GetFlowRun200JSONResponse FlowRun
+	var err error
 
-func (response GetFlowRun200JSONResponse) VisitGetFlowRunResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "permission_id" -------------
+	var permissionId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		return
+	}
 
-type GetFlowRun404JSONResponse NotFound
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePermission(w, r, permissionId)
+	}))
 
-func (response GetFlowRun404JSONResponse) VisitGetFlowRunResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type MockStandaloneToolRequestObject struct {
-	Body *MockStandaloneToolJSONRequestBody
-}
+// GetPermission operation middleware
+func (siw *ServerInterfaceWrapper) GetPermission(w http.ResponseWriter, r *http.Request) {
 
-type MockStandaloneToolResponseObject interface {
-	VisitMockStandaloneToolResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type MockStandaloneTool200JSONResponse MockToolResponse
+	// ------------- Path parameter "permission_id" -------------
+	var permissionId openapi_types.UUID
 
-func (response MockStandaloneTool200JSONResponse) VisitMockStandaloneToolResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPermission(w, r, permissionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type MockStandaloneTool400JSONResponse BadRequest
+// UpdatePermission operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePermission(w http.ResponseWriter, r *http.Request) {
 
-func (response MockStandaloneTool400JSONResponse) VisitMockStandaloneToolResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "permission_id" -------------
+	var permissionId openapi_types.UUID
 
-type MockStandaloneToolWithDelayRequestObject struct {
-	Body *MockStandaloneToolWithDelayJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		return
+	}
 
-type MockStandaloneToolWithDelayResponseObject interface {
-	VisitMockStandaloneToolWithDelayResponse(w http.ResponseWriter) error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdatePermission(w, r, permissionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type MockStandaloneToolWithDelay200JSONResponse MockToolWithDelayResponse
+// Quickstart operation middleware
+func (siw *ServerInterfaceWrapper) Quickstart(w http.ResponseWriter, r *http.Request) {
 
-func (response MockStandaloneToolWithDelay200JSONResponse) VisitMockStandaloneToolWithDelayResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params QuickstartParams
 
-type MockStandaloneToolWithDelay400JSONResponse BadRequest
+	// ------------- Optional query parameter "events" -------------
 
-func (response MockStandaloneToolWithDelay400JSONResponse) VisitMockStandaloneToolWithDelayResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindQueryParameter("form", true, false, "events", r.URL.Query(), &params.Events)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "events", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Quickstart(w, r, params)
+	}))
 
-type ListPermissionsRequestObject struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListPermissionsResponseObject interface {
-	VisitListPermissionsResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type ListPermissions200JSONResponse PermissionList
+// ListRoles operation middleware
+func (siw *ServerInterfaceWrapper) ListRoles(w http.ResponseWriter, r *http.Request) {
 
-func (response ListPermissions200JSONResponse) VisitListPermissionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRoles(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreatePermissionRequestObject struct {
-	Body *CreatePermissionJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type CreatePermissionResponseObject interface {
-	VisitCreatePermissionResponse(w http.ResponseWriter) error
-}
+// CreateRole operation middleware
+func (siw *ServerInterfaceWrapper) CreateRole(w http.ResponseWriter, r *http.Request) {
 
-type CreatePermission201JSONResponse Permission
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRole(w, r)
+	}))
 
-func (response CreatePermission201JSONResponse) VisitCreatePermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreatePermission400JSONResponse BadRequest
+// DeleteRole operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRole(w http.ResponseWriter, r *http.Request) {
 
-func (response CreatePermission400JSONResponse) VisitCreatePermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "role_id" -------------
+	var roleId openapi_types.UUID
 
-type DeletePermissionRequestObject struct {
-	PermissionId openapi_types.UUID `json:"permission_id"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		return
+	}
 
-type DeletePermissionResponseObject interface {
-	VisitDeletePermissionResponse(w http.ResponseWriter) error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRole(w, r, roleId)
+	}))
 
-type DeletePermission204Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response DeletePermission204Response) VisitDeletePermissionResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+	handler.ServeHTTP(w, r)
 }
 
-type DeletePermission404JSONResponse NotFound
-
-func (response DeletePermission404JSONResponse) VisitDeletePermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// GetRole operation middleware
+func (siw *ServerInterfaceWrapper) GetRole(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetPermissionRequestObject struct {
-	PermissionId openapi_types.UUID `json:"permission_id"`
-}
+	// ------------- Path parameter "role_id" -------------
+	var roleId openapi_types.UUID
 
-type GetPermissionResponseObject interface {
-	VisitGetPermissionResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		return
+	}
 
-type GetPermission200JSONResponse Permission
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRole(w, r, roleId)
+	}))
 
-func (response GetPermission200JSONResponse) VisitGetPermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetPermission404JSONResponse NotFound
-
-func (response GetPermission404JSONResponse) VisitGetPermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// UpdateRole operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRole(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type UpdatePermissionRequestObject struct {
-	PermissionId openapi_types.UUID `json:"permission_id"`
-	Body         *UpdatePermissionJSONRequestBody
-}
+	// ------------- Path parameter "role_id" -------------
+	var roleId openapi_types.UUID
 
-type UpdatePermissionResponseObject interface {
-	VisitUpdatePermissionResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		return
+	}
 
-type UpdatePermission200JSONResponse Permission
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateRole(w, r, roleId)
+	}))
 
-func (response UpdatePermission200JSONResponse) VisitUpdatePermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type UpdatePermission400JSONResponse BadRequest
+// ListPermissionsForRole operation middleware
+func (siw *ServerInterfaceWrapper) ListPermissionsForRole(w http.ResponseWriter, r *http.Request) {
 
-func (response UpdatePermission400JSONResponse) VisitUpdatePermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "role_id" -------------
+	var roleId openapi_types.UUID
 
-type UpdatePermission404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		return
+	}
 
-func (response UpdatePermission404JSONResponse) VisitUpdatePermissionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPermissionsForRole(w, r, roleId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListRolesRequestObject struct {
+	handler.ServeHTTP(w, r)
 }
 
-type ListRolesResponseObject interface {
-	VisitListRolesResponse(w http.ResponseWriter) error
-}
+// AddPermissionToRole operation middleware
+func (siw *ServerInterfaceWrapper) AddPermissionToRole(w http.ResponseWriter, r *http.Request) {
 
-type ListRoles200JSONResponse RoleList
+	var err error
 
-func (response ListRoles200JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "role_id" -------------
+	var roleId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		return
+	}
 
-type CreateRoleRequestObject struct {
-	Body *CreateRoleJSONRequestBody
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddPermissionToRole(w, r, roleId)
+	}))
 
-type CreateRoleResponseObject interface {
-	VisitCreateRoleResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type CreateRole201JSONResponse Role
+// RemovePermissionFromRole operation middleware
+func (siw *ServerInterfaceWrapper) RemovePermissionFromRole(w http.ResponseWriter, r *http.Request) {
 
-func (response CreateRole201JSONResponse) VisitCreateRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "role_id" -------------
+	var roleId openapi_types.UUID
 
-type CreateRole400JSONResponse BadRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		return
+	}
 
-func (response CreateRole400JSONResponse) VisitCreateRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Path parameter "permission_id" -------------
+	var permissionId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "permission_id", chi.URLParam(r, "permission_id"), &permissionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission_id", Err: err})
+		return
+	}
 
-type DeleteRoleRequestObject struct {
-	RoleId openapi_types.UUID `json:"role_id"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemovePermissionFromRole(w, r, roleId, permissionId)
+	}))
 
-type DeleteRoleResponseObject interface {
-	VisitDeleteRoleResponse(w http.ResponseWriter) error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type DeleteRole204Response struct {
+	handler.ServeHTTP(w, r)
 }
 
-func (response DeleteRole204Response) VisitDeleteRoleResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+// SearchMessages operation middleware
+func (siw *ServerInterfaceWrapper) SearchMessages(w http.ResponseWriter, r *http.Request) {
 
-type DeleteRole404JSONResponse NotFound
+	var err error
 
-func (response DeleteRole404JSONResponse) VisitDeleteRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchMessagesParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Required query parameter "q" -------------
 
-type GetRoleRequestObject struct {
-	RoleId openapi_types.UUID `json:"role_id"`
-}
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
 
-type GetRoleResponseObject interface {
-	VisitGetRoleResponse(w http.ResponseWriter) error
-}
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
 
-type GetRole200JSONResponse Role
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
 
-func (response GetRole200JSONResponse) VisitGetRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Optional query parameter "thread_id" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "thread_id", r.URL.Query(), &params.ThreadId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-type GetRole404JSONResponse NotFound
+	// ------------- Optional query parameter "per_page" -------------
 
-func (response GetRole404JSONResponse) VisitGetRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "page" -------------
 
-type UpdateRoleRequestObject struct {
-	RoleId openapi_types.UUID `json:"role_id"`
-	Body   *UpdateRoleJSONRequestBody
-}
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
 
-type UpdateRoleResponseObject interface {
-	VisitUpdateRoleResponse(w http.ResponseWriter) error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchMessages(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateRole200JSONResponse Role
+// SemanticSearchMessages operation middleware
+func (siw *ServerInterfaceWrapper) SemanticSearchMessages(w http.ResponseWriter, r *http.Request) {
 
-func (response UpdateRole200JSONResponse) VisitUpdateRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SemanticSearchMessagesParams
 
-type UpdateRole400JSONResponse BadRequest
+	// ------------- Required query parameter "q" -------------
 
-func (response UpdateRole400JSONResponse) VisitUpdateRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
 
-type UpdateRole404JSONResponse NotFound
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
 
-func (response UpdateRole404JSONResponse) VisitUpdateRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// ------------- Optional query parameter "thread_id" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "thread_id", r.URL.Query(), &params.ThreadId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-type ListPermissionsForRoleRequestObject struct {
-	RoleId openapi_types.UUID `json:"role_id"`
-}
+	// ------------- Optional query parameter "limit" -------------
 
-type ListPermissionsForRoleResponseObject interface {
-	VisitListPermissionsForRoleResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-type ListPermissionsForRole200JSONResponse RolePermissionMappingList
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SemanticSearchMessages(w, r, params)
+	}))
 
-func (response ListPermissionsForRole200JSONResponse) VisitListPermissionsForRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ListPermissionsForRole404JSONResponse NotFound
+// CreateServiceAccount operation middleware
+func (siw *ServerInterfaceWrapper) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
 
-func (response ListPermissionsForRole404JSONResponse) VisitListPermissionsForRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateServiceAccount(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type AddPermissionToRoleRequestObject struct {
-	RoleId openapi_types.UUID `json:"role_id"`
-	Body   *AddPermissionToRoleJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type AddPermissionToRoleResponseObject interface {
-	VisitAddPermissionToRoleResponse(w http.ResponseWriter) error
-}
+// ListTasks operation middleware
+func (siw *ServerInterfaceWrapper) ListTasks(w http.ResponseWriter, r *http.Request) {
 
-type AddPermissionToRole201JSONResponse RolePermissionMapping
+	var err error
 
-func (response AddPermissionToRole201JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTasksParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "status" -------------
 
-type AddPermissionToRole400JSONResponse BadRequest
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
 
-func (response AddPermissionToRole400JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Optional query parameter "created_by" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "created_by", r.URL.Query(), &params.CreatedBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_by", Err: err})
+		return
+	}
 
-type AddPermissionToRole404JSONResponse NotFound
+	// ------------- Optional query parameter "created_after" -------------
 
-func (response AddPermissionToRole404JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "created_before" -------------
 
-type AddPermissionToRole409JSONResponse ResourceAlreadyExists
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
 
-func (response AddPermissionToRole409JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	// ------------- Optional query parameter "sort" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
 
-type RemovePermissionFromRoleRequestObject struct {
-	RoleId       openapi_types.UUID `json:"role_id"`
-	PermissionId openapi_types.UUID `json:"permission_id"`
-}
+	// ------------- Optional query parameter "order" -------------
 
-type RemovePermissionFromRoleResponseObject interface {
-	VisitRemovePermissionFromRoleResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
 
-type RemovePermissionFromRole204Response struct {
-}
+	// ------------- Optional query parameter "limit" -------------
 
-func (response RemovePermissionFromRole204Response) VisitRemovePermissionFromRoleResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-type RemovePermissionFromRole404JSONResponse NotFound
+	// ------------- Optional query parameter "cursor" -------------
 
-func (response RemovePermissionFromRole404JSONResponse) VisitRemovePermissionFromRoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTasks(w, r, params)
+	}))
 
-type ListTasksRequestObject struct {
-	Params ListTasksParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListTasksResponseObject interface {
-	VisitListTasksResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type ListTasks200JSONResponse TaskList
+// CreateTask operation middleware
+func (siw *ServerInterfaceWrapper) CreateTask(w http.ResponseWriter, r *http.Request) {
 
-func (response ListTasks200JSONResponse) VisitListTasksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateTaskParams
 
-type CreateTaskRequestObject struct {
-	Body *CreateTaskJSONRequestBody
-}
+	headers := r.Header
 
-type CreateTaskResponseObject interface {
-	VisitCreateTaskResponse(w http.ResponseWriter) error
-}
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
 
-type CreateTask201JSONResponse Task
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
 
-func (response CreateTask201JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+		params.IdempotencyKey = &IdempotencyKey
 
-	return json.NewEncoder(w).Encode(response)
-}
+	}
 
-type CreateTask400JSONResponse BadRequest
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTask(w, r, params)
+	}))
 
-func (response CreateTask400JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreateTask404JSONResponse NotFound
+// ListTaskSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListTaskSchedules(w http.ResponseWriter, r *http.Request) {
 
-func (response CreateTask404JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTaskSchedules(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type DeleteTaskRequestObject struct {
-	TaskId openapi_types.UUID `json:"task_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteTaskResponseObject interface {
-	VisitDeleteTaskResponse(w http.ResponseWriter) error
-}
+// CreateTaskSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateTaskSchedule(w http.ResponseWriter, r *http.Request) {
 
-type DeleteTask204Response struct {
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTaskSchedule(w, r)
+	}))
 
-func (response DeleteTask204Response) VisitDeleteTaskResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteTask404JSONResponse NotFound
+// DeleteTaskSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTaskSchedule(w http.ResponseWriter, r *http.Request) {
 
-func (response DeleteTask404JSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-type GetTaskRequestObject struct {
-	TaskId openapi_types.UUID `json:"task_id"`
-}
-
-type GetTaskResponseObject interface {
-	VisitGetTaskResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
 
-type GetTask200JSONResponse Task
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTaskSchedule(w, r, scheduleId)
+	}))
 
-func (response GetTask200JSONResponse) VisitGetTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetTask404JSONResponse NotFound
-
-func (response GetTask404JSONResponse) VisitGetTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// GetTaskSchedule operation middleware
+func (siw *ServerInterfaceWrapper) GetTaskSchedule(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type UpdateTaskRequestObject struct {
-	TaskId openapi_types.UUID `json:"task_id"`
-	Body   *UpdateTaskJSONRequestBody
-}
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-type UpdateTaskResponseObject interface {
-	VisitUpdateTaskResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
 
-type UpdateTask200JSONResponse Task
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTaskSchedule(w, r, scheduleId)
+	}))
 
-func (response UpdateTask200JSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateTask404JSONResponse NotFound
+// UpdateTaskSchedule operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTaskSchedule(w http.ResponseWriter, r *http.Request) {
 
-func (response UpdateTask404JSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "schedule_id" -------------
+	var scheduleId openapi_types.UUID
 
-type ExecuteTaskRequestObject struct {
-	TaskId openapi_types.UUID `json:"task_id"`
-	Body   *ExecuteTaskJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "schedule_id", chi.URLParam(r, "schedule_id"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schedule_id", Err: err})
+		return
+	}
 
-type ExecuteTaskResponseObject interface {
-	VisitExecuteTaskResponse(w http.ResponseWriter) error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTaskSchedule(w, r, scheduleId)
+	}))
 
-type ExecuteTask200ResponseHeaders struct {
-	CacheControl string
-	Connection   string
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ExecuteTask200JSONResponse struct {
-	Body    TaskRun
-	Headers ExecuteTask200ResponseHeaders
+	handler.ServeHTTP(w, r)
 }
 
-func (response ExecuteTask200JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
-	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
-	w.WriteHeader(200)
+// DeleteTask operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTask(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	var err error
 
-type ExecuteTask200TexteventStreamResponse struct {
-	Body          io.Reader
-	Headers       ExecuteTask200ResponseHeaders
-	ContentLength int64
-}
+	// ------------- Path parameter "task_id" -------------
+	var taskId openapi_types.UUID
 
-func (response ExecuteTask200TexteventStreamResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/event-stream")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
 	}
-	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
-	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
-	w.WriteHeader(200)
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTask(w, r, taskId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
-	_, err := io.Copy(w, response.Body)
-	return err
+
+	handler.ServeHTTP(w, r)
 }
 
-type ExecuteTask400JSONResponse BadRequest
+// GetTask operation middleware
+func (siw *ServerInterfaceWrapper) GetTask(w http.ResponseWriter, r *http.Request) {
 
-func (response ExecuteTask400JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "task_id" -------------
+	var taskId openapi_types.UUID
 
-type ExecuteTask404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
+	}
 
-func (response ExecuteTask404JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTask(w, r, taskId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListTaskRunsRequestObject struct {
-	TaskId openapi_types.UUID `json:"task_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type ListTaskRunsResponseObject interface {
-	VisitListTaskRunsResponse(w http.ResponseWriter) error
-}
+// UpdateTask operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTask(w http.ResponseWriter, r *http.Request) {
 
-type ListTaskRuns200JSONResponse []TaskRun
+	var err error
 
-func (response ListTaskRuns200JSONResponse) VisitListTaskRunsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "task_id" -------------
+	var taskId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
+	}
 
-type ListTaskRuns404JSONResponse NotFound
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTask(w, r, taskId)
+	}))
 
-func (response ListTaskRuns404JSONResponse) VisitListTaskRunsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetTaskRunRequestObject struct {
-	TaskRunId openapi_types.UUID `json:"task_run_id"`
-}
+// ExecuteTask operation middleware
+func (siw *ServerInterfaceWrapper) ExecuteTask(w http.ResponseWriter, r *http.Request) {
 
-type GetTaskRunResponseObject interface {
-	VisitGetTaskRunResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetTaskRun200JSONResponse TaskRun
+	// ------------- Path parameter "task_id" -------------
+	var taskId openapi_types.UUID
 
-func (response GetTaskRun200JSONResponse) VisitGetTaskRunResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExecuteTaskParams
 
-type GetTaskRun404JSONResponse NotFound
+	// ------------- Optional query parameter "events" -------------
 
-func (response GetTaskRun404JSONResponse) VisitGetTaskRunResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindQueryParameter("form", true, false, "events", r.URL.Query(), &params.Events)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "events", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	headers := r.Header
 
-type ListThreadsRequestObject struct {
-}
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
 
-type ListThreadsResponseObject interface {
-	VisitListThreadsResponse(w http.ResponseWriter) error
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
 
-type ListThreads200JSONResponse ThreadList
+		params.IdempotencyKey = &IdempotencyKey
 
-func (response ListThreads200JSONResponse) VisitListThreadsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExecuteTask(w, r, taskId, params)
+	}))
 
-type CreateThreadRequestObject struct {
-	Body *CreateThreadJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreateThreadResponseObject interface {
-	VisitCreateThreadResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type CreateThread201JSONResponse Thread
+// ListTaskRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListTaskRuns(w http.ResponseWriter, r *http.Request) {
 
-func (response CreateThread201JSONResponse) VisitCreateThreadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "task_id" -------------
+	var taskId openapi_types.UUID
 
-type CreateThread400JSONResponse BadRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
+	}
 
-func (response CreateThread400JSONResponse) VisitCreateThreadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTaskRuns(w, r, taskId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type DeleteThreadRequestObject struct {
-	ThreadId openapi_types.UUID `json:"thread_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteThreadResponseObject interface {
-	VisitDeleteThreadResponse(w http.ResponseWriter) error
-}
+// CancelTaskRun operation middleware
+func (siw *ServerInterfaceWrapper) CancelTaskRun(w http.ResponseWriter, r *http.Request) {
 
-type DeleteThread204Response struct {
-}
+	var err error
 
-func (response DeleteThread204Response) VisitDeleteThreadResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	// ------------- Path parameter "task_id" -------------
+	var taskId openapi_types.UUID
 
-type DeleteThread404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
+	}
 
-func (response DeleteThread404JSONResponse) VisitDeleteThreadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", chi.URLParam(r, "run_id"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run_id", Err: err})
+		return
+	}
 
-type GetThreadRequestObject struct {
-	ThreadId openapi_types.UUID `json:"thread_id"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelTaskRun(w, r, taskId, runId)
+	}))
 
-type GetThreadResponseObject interface {
-	VisitGetThreadResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetThread200JSONResponse Thread
+// StreamTask operation middleware
+func (siw *ServerInterfaceWrapper) StreamTask(w http.ResponseWriter, r *http.Request) {
 
-func (response GetThread200JSONResponse) VisitGetThreadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "task_id" -------------
+	var taskId openapi_types.UUID
 
-type GetThread404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "task_id", chi.URLParam(r, "task_id"), &taskId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_id", Err: err})
+		return
+	}
 
-func (response GetThread404JSONResponse) VisitGetThreadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamTaskParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	headers := r.Header
 
-type UpdateThreadTitleRequestObject struct {
-	ThreadId openapi_types.UUID `json:"thread_id"`
-	Body     *UpdateThreadTitleJSONRequestBody
-}
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
 
-type UpdateThreadTitleResponseObject interface {
-	VisitUpdateThreadTitleResponse(w http.ResponseWriter) error
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
 
-type UpdateThreadTitle200JSONResponse Thread
+		params.LastEventID = &LastEventID
 
-func (response UpdateThreadTitle200JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamTask(w, r, taskId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateThreadTitle400JSONResponse BadRequest
+// GetTaskRun operation middleware
+func (siw *ServerInterfaceWrapper) GetTaskRun(w http.ResponseWriter, r *http.Request) {
 
-func (response UpdateThreadTitle400JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "task_run_id" -------------
+	var taskRunId openapi_types.UUID
 
-type UpdateThreadTitle404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "task_run_id", chi.URLParam(r, "task_run_id"), &taskRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task_run_id", Err: err})
+		return
+	}
 
-func (response UpdateThreadTitle404JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTaskRun(w, r, taskRunId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListMessagesRequestObject struct {
-	ThreadId openapi_types.UUID `json:"thread_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type ListMessagesResponseObject interface {
-	VisitListMessagesResponse(w http.ResponseWriter) error
-}
+// ListThreads operation middleware
+func (siw *ServerInterfaceWrapper) ListThreads(w http.ResponseWriter, r *http.Request) {
 
-type ListMessages200JSONResponse MessageList
+	var err error
 
-func (response ListMessages200JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListThreadsParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "title" -------------
 
-type ListMessages404JSONResponse NotFound
+	err = runtime.BindQueryParameter("form", true, false, "title", r.URL.Query(), &params.Title)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "title", Err: err})
+		return
+	}
 
-func (response ListMessages404JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// ------------- Optional query parameter "created_after" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
 
-type CreateMessageRequestObject struct {
-	ThreadId openapi_types.UUID `json:"thread_id"`
-	Body     *CreateMessageJSONRequestBody
-}
+	// ------------- Optional query parameter "created_before" -------------
 
-type CreateMessageResponseObject interface {
-	VisitCreateMessageResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
 
-type CreateMessage201JSONResponse Message
+	// ------------- Optional query parameter "deleted" -------------
 
-func (response CreateMessage201JSONResponse) VisitCreateMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	err = runtime.BindQueryParameter("form", true, false, "deleted", r.URL.Query(), &params.Deleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deleted", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "sort" -------------
 
-type CreateMessage400JSONResponse BadRequest
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
 
-func (response CreateMessage400JSONResponse) VisitCreateMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Optional query parameter "order" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
 
-type CreateMessage404JSONResponse NotFound
+	// ------------- Optional query parameter "limit" -------------
 
-func (response CreateMessage404JSONResponse) VisitCreateMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "cursor" -------------
 
-type DeleteMessageRequestObject struct {
-	ThreadId  openapi_types.UUID `json:"thread_id"`
-	MessageId openapi_types.UUID `json:"message_id"`
-}
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
 
-type DeleteMessageResponseObject interface {
-	VisitDeleteMessageResponse(w http.ResponseWriter) error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListThreads(w, r, params)
+	}))
 
-type DeleteMessage204Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response DeleteMessage204Response) VisitDeleteMessageResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteMessage404JSONResponse NotFound
+// CreateThread operation middleware
+func (siw *ServerInterfaceWrapper) CreateThread(w http.ResponseWriter, r *http.Request) {
 
-func (response DeleteMessage404JSONResponse) VisitDeleteMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateThread(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetMessageRequestObject struct {
-	ThreadId  openapi_types.UUID `json:"thread_id"`
-	MessageId openapi_types.UUID `json:"message_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type GetMessageResponseObject interface {
-	VisitGetMessageResponse(w http.ResponseWriter) error
-}
+// ImportThread operation middleware
+func (siw *ServerInterfaceWrapper) ImportThread(w http.ResponseWriter, r *http.Request) {
 
-type GetMessage200JSONResponse Message
+	var err error
 
-func (response GetMessage200JSONResponse) VisitGetMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportThreadParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "title" -------------
 
-type GetMessage404JSONResponse NotFound
+	err = runtime.BindQueryParameter("form", true, false, "title", r.URL.Query(), &params.Title)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "title", Err: err})
+		return
+	}
 
-func (response GetMessage404JSONResponse) VisitGetMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportThread(w, r, params)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type UpdateMessageRequestObject struct {
-	ThreadId  openapi_types.UUID `json:"thread_id"`
-	MessageId openapi_types.UUID `json:"message_id"`
-	Body      *UpdateMessageJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateMessageResponseObject interface {
-	VisitUpdateMessageResponse(w http.ResponseWriter) error
-}
-
-type UpdateMessage200JSONResponse Message
-
-func (response UpdateMessage200JSONResponse) VisitUpdateMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
+// DeleteThread operation middleware
+func (siw *ServerInterfaceWrapper) DeleteThread(w http.ResponseWriter, r *http.Request) {
 
-type UpdateMessage400JSONResponse BadRequest
+	var err error
 
-func (response UpdateMessage400JSONResponse) VisitUpdateMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-type UpdateMessage404JSONResponse NotFound
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteThread(w, r, threadId)
+	}))
 
-func (response UpdateMessage404JSONResponse) VisitUpdateMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ListToolsRequestObject struct {
-}
+// GetThread operation middleware
+func (siw *ServerInterfaceWrapper) GetThread(w http.ResponseWriter, r *http.Request) {
 
-type ListToolsResponseObject interface {
-	VisitListToolsResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type ListTools200JSONResponse ToolList
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-func (response ListTools200JSONResponse) VisitListToolsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetThread(w, r, threadId)
+	}))
 
-type CreateToolRequestObject struct {
-	Body *CreateToolJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreateToolResponseObject interface {
-	VisitCreateToolResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type CreateTool201JSONResponse Tool
+// UpdateThreadTitle operation middleware
+func (siw *ServerInterfaceWrapper) UpdateThreadTitle(w http.ResponseWriter, r *http.Request) {
 
-func (response CreateTool201JSONResponse) VisitCreateToolResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-type CreateTool400JSONResponse BadRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-func (response CreateTool400JSONResponse) VisitCreateToolResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateThreadTitleParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	headers := r.Header
 
-type DeleteToolRequestObject struct {
-	ToolId openapi_types.UUID `json:"tool_id"`
-}
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
 
-type DeleteToolResponseObject interface {
-	VisitDeleteToolResponse(w http.ResponseWriter) error
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
 
-type DeleteTool204Response struct {
-}
+		params.IfMatch = &IfMatch
 
-func (response DeleteTool204Response) VisitDeleteToolResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	}
 
-type DeleteTool404JSONResponse NotFound
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateThreadTitle(w, r, threadId, params)
+	}))
 
-func (response DeleteTool404JSONResponse) VisitDeleteToolResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetToolByIdRequestObject struct {
-	ToolId openapi_types.UUID `json:"tool_id"`
-}
+// StreamThreadEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamThreadEvents(w http.ResponseWriter, r *http.Request) {
 
-type GetToolByIdResponseObject interface {
-	VisitGetToolByIdResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetToolById200JSONResponse Tool
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-func (response GetToolById200JSONResponse) VisitGetToolByIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamThreadEventsParams
 
-type GetToolById404JSONResponse NotFound
+	// ------------- Optional query parameter "events" -------------
 
-func (response GetToolById404JSONResponse) VisitGetToolByIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindQueryParameter("form", true, false, "events", r.URL.Query(), &params.Events)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "events", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamThreadEvents(w, r, threadId, params)
+	}))
 
-type UpdateToolRequestObject struct {
-	ToolId openapi_types.UUID `json:"tool_id"`
-	Body   *UpdateToolJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type UpdateToolResponseObject interface {
-	VisitUpdateToolResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateTool200JSONResponse Tool
-
-func (response UpdateTool200JSONResponse) VisitUpdateToolResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ExportThread operation middleware
+func (siw *ServerInterfaceWrapper) ExportThread(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type UpdateTool404JSONResponse NotFound
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-func (response UpdateTool404JSONResponse) VisitUpdateToolResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportThreadParams
 
-type ListUsersRequestObject struct {
-}
+	// ------------- Optional query parameter "format" -------------
 
-type ListUsersResponseObject interface {
-	VisitListUsersResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
 
-type ListUsers200JSONResponse UserList
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportThread(w, r, threadId, params)
+	}))
 
-func (response ListUsers200JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreateUserRequestObject struct {
-	Body *CreateUserJSONRequestBody
-}
+// ForkThread operation middleware
+func (siw *ServerInterfaceWrapper) ForkThread(w http.ResponseWriter, r *http.Request) {
 
-type CreateUserResponseObject interface {
-	VisitCreateUserResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type CreateUser201JSONResponse User
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-func (response CreateUser201JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ForkThreadParams
 
-type CreateUser400JSONResponse BadRequest
+	// ------------- Optional query parameter "from_message" -------------
 
-func (response CreateUser400JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindQueryParameter("form", true, false, "from_message", r.URL.Query(), &params.FromMessage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from_message", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "title" -------------
 
-type CreateUser409JSONResponse ResourceAlreadyExists
+	err = runtime.BindQueryParameter("form", true, false, "title", r.URL.Query(), &params.Title)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "title", Err: err})
+		return
+	}
 
-func (response CreateUser409JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ForkThread(w, r, threadId, params)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type DeleteUserRequestObject struct {
-	UserId openapi_types.UUID `json:"user_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteUserResponseObject interface {
-	VisitDeleteUserResponse(w http.ResponseWriter) error
-}
+// ListThreadGrants operation middleware
+func (siw *ServerInterfaceWrapper) ListThreadGrants(w http.ResponseWriter, r *http.Request) {
 
-type DeleteUser204Response struct {
-}
+	var err error
 
-func (response DeleteUser204Response) VisitDeleteUserResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-type DeleteUser404JSONResponse NotFound
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-func (response DeleteUser404JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListThreadGrants(w, r, threadId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetUserRequestObject struct {
-	UserId openapi_types.UUID `json:"user_id"`
+	handler.ServeHTTP(w, r)
 }
 
-type GetUserResponseObject interface {
-	VisitGetUserResponse(w http.ResponseWriter) error
-}
+// SetThreadGrant operation middleware
+func (siw *ServerInterfaceWrapper) SetThreadGrant(w http.ResponseWriter, r *http.Request) {
 
-type GetUser200JSONResponse User
+	var err error
 
-func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-type GetUser404JSONResponse NotFound
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetThreadGrant(w, r, threadId)
+	}))
 
-func (response GetUser404JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateUserRequestObject struct {
-	UserId openapi_types.UUID `json:"user_id"`
-	Body   *UpdateUserJSONRequestBody
-}
+// RemoveThreadGrant operation middleware
+func (siw *ServerInterfaceWrapper) RemoveThreadGrant(w http.ResponseWriter, r *http.Request) {
 
-type UpdateUserResponseObject interface {
-	VisitUpdateUserResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type UpdateUser200JSONResponse User
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-func (response UpdateUser200JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "grantee_type" -------------
+	var granteeType GranteeType
 
-type UpdateUser400JSONResponse BadRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "grantee_type", chi.URLParam(r, "grantee_type"), &granteeType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "grantee_type", Err: err})
+		return
+	}
 
-func (response UpdateUser400JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Path parameter "grantee_id" -------------
+	var granteeId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "grantee_id", chi.URLParam(r, "grantee_id"), &granteeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "grantee_id", Err: err})
+		return
+	}
 
-type UpdateUser404JSONResponse NotFound
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveThreadGrant(w, r, threadId, granteeType, granteeId)
+	}))
 
-func (response UpdateUser404JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type UpdateUser409JSONResponse ResourceAlreadyExists
+// ListMessages operation middleware
+func (siw *ServerInterfaceWrapper) ListMessages(w http.ResponseWriter, r *http.Request) {
 
-func (response UpdateUser409JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-type ListRoleForUserRequestObject struct {
-	UserId openapi_types.UUID `json:"user_id"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-type ListRoleForUserResponseObject interface {
-	VisitListRoleForUserResponse(w http.ResponseWriter) error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListMessagesParams
 
-type ListRoleForUser200JSONResponse UserRoleMappingList
+	// ------------- Optional query parameter "include_history" -------------
 
-func (response ListRoleForUser200JSONResponse) VisitListRoleForUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindQueryParameter("form", true, false, "include_history", r.URL.Query(), &params.IncludeHistory)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_history", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListMessages(w, r, threadId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type ListRoleForUser404JSONResponse NotFound
+// CreateMessage operation middleware
+func (siw *ServerInterfaceWrapper) CreateMessage(w http.ResponseWriter, r *http.Request) {
 
-func (response ListRoleForUser404JSONResponse) VisitListRoleForUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-type AddRoleToUserRequestObject struct {
-	UserId openapi_types.UUID `json:"user_id"`
-	Body   *AddRoleToUserJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-type AddRoleToUserResponseObject interface {
-	VisitAddRoleToUserResponse(w http.ResponseWriter) error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateMessage(w, r, threadId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type AddRoleToUser201JSONResponse UserRoleMapping
+// ImportMessages operation middleware
+func (siw *ServerInterfaceWrapper) ImportMessages(w http.ResponseWriter, r *http.Request) {
 
-func (response AddRoleToUser201JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-type AddRoleToUser400JSONResponse BadRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-func (response AddRoleToUser400JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportMessages(w, r, threadId)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type AddRoleToUser404JSONResponse NotFound
+// DeleteMessage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.Request) {
 
-func (response AddRoleToUser404JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
 
-type AddRoleToUser409JSONResponse ResourceAlreadyExists
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
 
-func (response AddRoleToUser409JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
 
-type RemoveRoleFromUserRequestObject struct {
-	UserId openapi_types.UUID `json:"user_id"`
-	RoleId openapi_types.UUID `json:"role_id"`
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteMessageParams
 
-type RemoveRoleFromUserResponseObject interface {
-	VisitRemoveRoleFromUserResponse(w http.ResponseWriter) error
-}
+	// ------------- Optional query parameter "keep_history" -------------
 
-type RemoveRoleFromUser204Response struct {
-}
+	err = runtime.BindQueryParameter("form", true, false, "keep_history", r.URL.Query(), &params.KeepHistory)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keep_history", Err: err})
+		return
+	}
 
-func (response RemoveRoleFromUser204Response) VisitRemoveRoleFromUserResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteMessage(w, r, threadId, messageId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type RemoveRoleFromUser404JSONResponse NotFound
+// GetMessage operation middleware
+func (siw *ServerInterfaceWrapper) GetMessage(w http.ResponseWriter, r *http.Request) {
 
-func (response RemoveRoleFromUser404JSONResponse) VisitRemoveRoleFromUserResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMessage(w, r, threadId, messageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateMessage operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMessage(w, r, threadId, messageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListMessageAttachments operation middleware
+func (siw *ServerInterfaceWrapper) ListMessageAttachments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListMessageAttachments(w, r, threadId, messageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EditMessage operation middleware
+func (siw *ServerInterfaceWrapper) EditMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EditMessage(w, r, threadId, messageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteMessageFeedback operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMessageFeedback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteMessageFeedback(w, r, threadId, messageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetMessageFeedback operation middleware
+func (siw *ServerInterfaceWrapper) SetMessageFeedback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetMessageFeedback(w, r, threadId, messageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RegenerateMessage operation middleware
+func (siw *ServerInterfaceWrapper) RegenerateMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegenerateMessage(w, r, threadId, messageId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeThread operation middleware
+func (siw *ServerInterfaceWrapper) PurgeThread(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeThread(w, r, threadId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreThread operation middleware
+func (siw *ServerInterfaceWrapper) RestoreThread(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreThread(w, r, threadId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteToolRunFeedback operation middleware
+func (siw *ServerInterfaceWrapper) DeleteToolRunFeedback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "tool_run_id" -------------
+	var toolRunId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_run_id", chi.URLParam(r, "tool_run_id"), &toolRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_run_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteToolRunFeedback(w, r, threadId, toolRunId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetToolRunFeedback operation middleware
+func (siw *ServerInterfaceWrapper) SetToolRunFeedback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "thread_id" -------------
+	var threadId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "thread_id", chi.URLParam(r, "thread_id"), &threadId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "tool_run_id" -------------
+	var toolRunId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_run_id", chi.URLParam(r, "tool_run_id"), &toolRunId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_run_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetToolRunFeedback(w, r, threadId, toolRunId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTools operation middleware
+func (siw *ServerInterfaceWrapper) ListTools(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListToolsParams
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "search" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "search", r.URL.Query(), &params.Search)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "search", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_by", r.URL.Query(), &params.CreatedBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "deleted", r.URL.Query(), &params.Deleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTools(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateTool operation middleware
+func (siw *ServerInterfaceWrapper) CreateTool(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateToolParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTool(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchCreateTools operation middleware
+func (siw *ServerInterfaceWrapper) BatchCreateTools(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchCreateTools(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchDeleteTools operation middleware
+func (siw *ServerInterfaceWrapper) BatchDeleteTools(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchDeleteTools(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchUpdateTools operation middleware
+func (siw *ServerInterfaceWrapper) BatchUpdateTools(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchUpdateTools(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetToolCatalog operation middleware
+func (siw *ServerInterfaceWrapper) GetToolCatalog(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetToolCatalogParams
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "search" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "search", r.URL.Query(), &params.Search)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "search", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetToolCatalog(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateToolFromDefinition operation middleware
+func (siw *ServerInterfaceWrapper) CreateToolFromDefinition(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateToolFromDefinition(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportTools operation middleware
+func (siw *ServerInterfaceWrapper) ExportTools(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportToolsParams
+
+	// ------------- Optional query parameter "tool_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tool_id", r.URL.Query(), &params.ToolId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportTools(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportTools operation middleware
+func (siw *ServerInterfaceWrapper) ImportTools(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportToolsParams
+
+	// ------------- Optional query parameter "on_conflict" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "on_conflict", r.URL.Query(), &params.OnConflict)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "on_conflict", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportTools(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteTool operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTool(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTool(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetToolById operation middleware
+func (siw *ServerInterfaceWrapper) GetToolById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetToolById(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateTool operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTool(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateToolParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchParam
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTool(w, r, toolId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListToolGrants operation middleware
+func (siw *ServerInterfaceWrapper) ListToolGrants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListToolGrants(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetToolGrant operation middleware
+func (siw *ServerInterfaceWrapper) SetToolGrant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetToolGrant(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveToolGrant operation middleware
+func (siw *ServerInterfaceWrapper) RemoveToolGrant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "grantee_type" -------------
+	var granteeType GranteeType
+
+	err = runtime.BindStyledParameterWithOptions("simple", "grantee_type", chi.URLParam(r, "grantee_type"), &granteeType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "grantee_type", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "grantee_id" -------------
+	var granteeId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "grantee_id", chi.URLParam(r, "grantee_id"), &granteeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "grantee_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveToolGrant(w, r, toolId, granteeType, granteeId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetToolHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetToolHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetToolHistoryParams
+
+	// ------------- Optional query parameter "per_page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "per_page", r.URL.Query(), &params.PerPage)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "per_page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetToolHistory(w, r, toolId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeTool operation middleware
+func (siw *ServerInterfaceWrapper) PurgeTool(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeTool(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreTool operation middleware
+func (siw *ServerInterfaceWrapper) RestoreTool(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreTool(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListToolRevisions operation middleware
+func (siw *ServerInterfaceWrapper) ListToolRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListToolRevisions(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DiffToolRevisions operation middleware
+func (siw *ServerInterfaceWrapper) DiffToolRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffToolRevisionsParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DiffToolRevisions(w, r, toolId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PromoteToolRevision operation middleware
+func (siw *ServerInterfaceWrapper) PromoteToolRevision(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", chi.URLParam(r, "revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PromoteToolRevision(w, r, toolId, revision)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TestTool operation middleware
+func (siw *ServerInterfaceWrapper) TestTool(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tool_id" -------------
+	var toolId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tool_id", chi.URLParam(r, "tool_id"), &toolId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tool_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TestTool(w, r, toolId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetUsage(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUserApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListUserApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserApiKeys(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUserApiKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateUserApiKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUserApiKey(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeUserApiKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeUserApiKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "key_id" -------------
+	var keyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key_id", chi.URLParam(r, "key_id"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeUserApiKey(w, r, userId, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserDefaultAgent operation middleware
+func (siw *ServerInterfaceWrapper) SetUserDefaultAgent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserDefaultAgent(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListRoleForUser operation middleware
+func (siw *ServerInterfaceWrapper) ListRoleForUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRoleForUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddRoleToUser operation middleware
+func (siw *ServerInterfaceWrapper) AddRoleToUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddRoleToUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveRoleFromUser operation middleware
+func (siw *ServerInterfaceWrapper) RemoveRoleFromUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role_id" -------------
+	var roleId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role_id", chi.URLParam(r, "role_id"), &roleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveRoleFromUser(w, r, userId, roleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", chi.URLParam(r, "webhook_id"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", chi.URLParam(r, "webhook_id"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", chi.URLParam(r, "webhook_id"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", chi.URLParam(r, "webhook_id"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveries(w, r, webhookId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RedeliverWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", chi.URLParam(r, "webhook_id"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "delivery_id", chi.URLParam(r, "delivery_id"), &deliveryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "delivery_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RedeliverWebhookDelivery(w, r, webhookId, deliveryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RotateWebhookSecret operation middleware
+func (siw *ServerInterfaceWrapper) RotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", chi.URLParam(r, "webhook_id"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateWebhookSecret(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWorkspaceMembers operation middleware
+func (siw *ServerInterfaceWrapper) ListWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workspace_id" -------------
+	var workspaceId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workspace_id", chi.URLParam(r, "workspace_id"), &workspaceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workspace_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWorkspaceMembers(w, r, workspaceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveWorkspaceMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workspace_id" -------------
+	var workspaceId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workspace_id", chi.URLParam(r, "workspace_id"), &workspaceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workspace_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveWorkspaceMember(w, r, workspaceId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetWorkspaceMember operation middleware
+func (siw *ServerInterfaceWrapper) SetWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workspace_id" -------------
+	var workspaceId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workspace_id", chi.URLParam(r, "workspace_id"), &workspaceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workspace_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetWorkspaceMember(w, r, workspaceId, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/admin/dead-letters", wrapper.ListDeadLetters)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/admin/services", wrapper.ListServices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents", wrapper.ListAgents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agents", wrapper.CreateAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/agents/{agent_id}", wrapper.DeleteAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents/{agent_id}", wrapper.GetAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/agents/{agent_id}", wrapper.UpdateAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents/{agent_id}/grants", wrapper.ListAgentGrants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/agents/{agent_id}/grants", wrapper.SetAgentGrant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/agents/{agent_id}/grants/{grantee_type}/{grantee_id}", wrapper.RemoveAgentGrant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents/{agent_id}/history", wrapper.GetAgentHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agents/{agent_id}/invoke", wrapper.InvokeAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents/{agent_id}/permissions", wrapper.ListPermissionsForAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agents/{agent_id}/permissions", wrapper.AddPermissionToAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/agents/{agent_id}/permissions/{permission_id}", wrapper.RemovePermissionFromAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents/{agent_id}/probes", wrapper.ListAgentProbes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agents/{agent_id}/probes", wrapper.CreateAgentProbe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/agents/{agent_id}/probes/{probe_id}", wrapper.DeleteAgentProbe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents/{agent_id}/probes/{probe_id}", wrapper.GetAgentProbe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/agents/{agent_id}/probes/{probe_id}", wrapper.UpdateAgentProbe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/agents/{agent_id}/probes/{probe_id}/runs", wrapper.ListAgentProbeRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agents/{agent_id}/purge", wrapper.PurgeAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agents/{agent_id}/restore", wrapper.RestoreAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/analytics/flow-costs", wrapper.GetFlowCostAnalytics)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/analytics/prompt-cache", wrapper.GetPromptCacheAnalytics)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/analytics/thinking-budget", wrapper.GetThinkingBudgetAnalytics)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/analytics/user-flow-costs", wrapper.GetUserFlowCostAnalytics)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/audit/tool-runs", wrapper.ListToolRunAudit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/audit/tool-runs/{tool_run_id}", wrapper.GetToolRunAudit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/feedback", wrapper.ListFeedback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/feedback/export", wrapper.ExportFeedback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/files", wrapper.ListFiles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/files", wrapper.CreateFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/files/{file_id}", wrapper.DeleteFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/files/{file_id}", wrapper.GetFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/files/{file_id}/complete", wrapper.CompleteFileUpload)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/files/{file_id}/content", wrapper.DownloadFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flow-runs/{flow_run_id}/retry", wrapper.RetryFlowRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows", wrapper.ListFlows)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows", wrapper.CreateFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/flows/{flow_id}", wrapper.DeleteFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}", wrapper.GetFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/flows/{flow_id}", wrapper.UpdateFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/execute", wrapper.ExecuteFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/history", wrapper.GetFlowHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/purge", wrapper.PurgeFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/restore", wrapper.RestoreFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs", wrapper.ListFlowRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/artifacts", wrapper.ListFlowRunArtifacts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/artifacts/{name}", wrapper.DownloadFlowRunArtifact)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/cancel", wrapper.CancelFlowRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/children", wrapper.ListChildFlowRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/cost", wrapper.GetFlowRunCost)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/graph", wrapper.GetFlowRunGraph)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/logs", wrapper.GetFlowRunLogs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/pause", wrapper.PauseFlowRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/resume", wrapper.ResumeFlowRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/runs/{run_id}/timeline", wrapper.GetFlowRunTimeline)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/schedules", wrapper.ListFlowSchedules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/schedules", wrapper.CreateFlowSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/flows/{flow_id}/schedules/{schedule_id}", wrapper.DeleteFlowSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/schedules/{schedule_id}", wrapper.GetFlowSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/flows/{flow_id}/schedules/{schedule_id}", wrapper.UpdateFlowSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills", wrapper.ListFlowScheduleBackfills)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills", wrapper.CreateFlowScheduleBackfill)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills/{backfill_id}/cancel", wrapper.CancelFlowScheduleBackfill)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flows/{flow_run_id}/status", wrapper.GetFlowRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/mock/tool", wrapper.MockStandaloneTool)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/mock/tool_with_delay", wrapper.MockStandaloneToolWithDelay)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/organizations", wrapper.ListOrganizations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/organizations", wrapper.CreateOrganization)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/organizations/{organization_id}/members", wrapper.ListOrganizationMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/organizations/{organization_id}/members/{user_id}", wrapper.RemoveOrganizationMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/organizations/{organization_id}/members/{user_id}", wrapper.SetOrganizationMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/organizations/{organization_id}/workspaces", wrapper.ListOrganizationWorkspaces)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/organizations/{organization_id}/workspaces", wrapper.CreateWorkspace)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/permissions", wrapper.ListPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/permissions", wrapper.CreatePermission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/permissions/{permission_id}", wrapper.DeletePermission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/permissions/{permission_id}", wrapper.GetPermission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/permissions/{permission_id}", wrapper.UpdatePermission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/quickstart", wrapper.Quickstart)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/roles", wrapper.ListRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/roles", wrapper.CreateRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/roles/{role_id}", wrapper.DeleteRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/roles/{role_id}", wrapper.GetRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/roles/{role_id}", wrapper.UpdateRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/roles/{role_id}/permissions", wrapper.ListPermissionsForRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/roles/{role_id}/permissions", wrapper.AddPermissionToRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/roles/{role_id}/permissions/{permission_id}", wrapper.RemovePermissionFromRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/search/messages", wrapper.SearchMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/search/messages/semantic", wrapper.SemanticSearchMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/service-accounts", wrapper.CreateServiceAccount)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks", wrapper.ListTasks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tasks", wrapper.CreateTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/schedules", wrapper.ListTaskSchedules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tasks/schedules", wrapper.CreateTaskSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/tasks/schedules/{schedule_id}", wrapper.DeleteTaskSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/schedules/{schedule_id}", wrapper.GetTaskSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/tasks/schedules/{schedule_id}", wrapper.UpdateTaskSchedule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/tasks/{task_id}", wrapper.DeleteTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/{task_id}", wrapper.GetTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/tasks/{task_id}", wrapper.UpdateTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tasks/{task_id}/execute", wrapper.ExecuteTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/{task_id}/runs", wrapper.ListTaskRuns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tasks/{task_id}/runs/{run_id}/cancel", wrapper.CancelTaskRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/{task_id}/stream", wrapper.StreamTask)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/{task_run_id}/status", wrapper.GetTaskRun)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads", wrapper.ListThreads)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads", wrapper.CreateThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/import", wrapper.ImportThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/threads/{thread_id}", wrapper.DeleteThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads/{thread_id}", wrapper.GetThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/threads/{thread_id}", wrapper.UpdateThreadTitle)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads/{thread_id}/events", wrapper.StreamThreadEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads/{thread_id}/export", wrapper.ExportThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/{thread_id}/fork", wrapper.ForkThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads/{thread_id}/grants", wrapper.ListThreadGrants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/threads/{thread_id}/grants", wrapper.SetThreadGrant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/threads/{thread_id}/grants/{grantee_type}/{grantee_id}", wrapper.RemoveThreadGrant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads/{thread_id}/messages", wrapper.ListMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/{thread_id}/messages", wrapper.CreateMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/{thread_id}/messages/batch", wrapper.ImportMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}", wrapper.DeleteMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}", wrapper.GetMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}", wrapper.UpdateMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}/attachments", wrapper.ListMessageAttachments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}/edit", wrapper.EditMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}/feedback", wrapper.DeleteMessageFeedback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}/feedback", wrapper.SetMessageFeedback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/{thread_id}/messages/{message_id}/regenerate", wrapper.RegenerateMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/{thread_id}/purge", wrapper.PurgeThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/threads/{thread_id}/restore", wrapper.RestoreThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback", wrapper.DeleteToolRunFeedback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback", wrapper.SetToolRunFeedback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools", wrapper.ListTools)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools", wrapper.CreateTool)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/batch-create", wrapper.BatchCreateTools)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/batch-delete", wrapper.BatchDeleteTools)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/batch-update", wrapper.BatchUpdateTools)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools/catalog", wrapper.GetToolCatalog)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/definitions", wrapper.CreateToolFromDefinition)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools/export", wrapper.ExportTools)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/import", wrapper.ImportTools)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/tools/{tool_id}", wrapper.DeleteTool)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools/{tool_id}", wrapper.GetToolById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/tools/{tool_id}", wrapper.UpdateTool)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools/{tool_id}/grants", wrapper.ListToolGrants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/tools/{tool_id}/grants", wrapper.SetToolGrant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/tools/{tool_id}/grants/{grantee_type}/{grantee_id}", wrapper.RemoveToolGrant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools/{tool_id}/history", wrapper.GetToolHistory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/{tool_id}/purge", wrapper.PurgeTool)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/{tool_id}/restore", wrapper.RestoreTool)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools/{tool_id}/revisions", wrapper.ListToolRevisions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tools/{tool_id}/revisions/diff", wrapper.DiffToolRevisions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/{tool_id}/revisions/{revision}/promote", wrapper.PromoteToolRevision)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tools/{tool_id}/test", wrapper.TestTool)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/usage", wrapper.GetUsage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users", wrapper.ListUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/users", wrapper.CreateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/users/{user_id}", wrapper.DeleteUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{user_id}", wrapper.GetUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/users/{user_id}", wrapper.UpdateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{user_id}/api-keys", wrapper.ListUserApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/users/{user_id}/api-keys", wrapper.CreateUserApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/users/{user_id}/api-keys/{key_id}", wrapper.RevokeUserApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/users/{user_id}/default-agent", wrapper.SetUserDefaultAgent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{user_id}/roles", wrapper.ListRoleForUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/users/{user_id}/roles", wrapper.AddRoleToUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/users/{user_id}/roles/{role_id}", wrapper.RemoveRoleFromUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhooks", wrapper.ListWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/webhooks", wrapper.CreateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/webhooks/{webhook_id}", wrapper.DeleteWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhooks/{webhook_id}", wrapper.GetWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/webhooks/{webhook_id}", wrapper.UpdateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhooks/{webhook_id}/deliveries", wrapper.ListWebhookDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver", wrapper.RedeliverWebhookDelivery)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/webhooks/{webhook_id}/rotate-secret", wrapper.RotateWebhookSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/workspaces/{workspace_id}/members", wrapper.ListWorkspaceMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/workspaces/{workspace_id}/members/{user_id}", wrapper.RemoveWorkspaceMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/workspaces/{workspace_id}/members/{user_id}", wrapper.SetWorkspaceMember)
+	})
+
+	return r
+}
+
+type ListDeadLettersRequestObject struct {
+	Params ListDeadLettersParams
+}
+
+type ListDeadLettersResponseObject interface {
+	VisitListDeadLettersResponse(w http.ResponseWriter) error
+}
+
+type ListDeadLetters200JSONResponse DeadLetterList
+
+func (response ListDeadLetters200JSONResponse) VisitListDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListServicesRequestObject struct {
+	Params ListServicesParams
+}
+
+type ListServicesResponseObject interface {
+	VisitListServicesResponse(w http.ResponseWriter) error
+}
+
+type ListServices200JSONResponse ServiceOverview
+
+func (response ListServices200JSONResponse) VisitListServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListServices400JSONResponse BadRequest
+
+func (response ListServices400JSONResponse) VisitListServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentsRequestObject struct {
+	Params ListAgentsParams
+}
+
+type ListAgentsResponseObject interface {
+	VisitListAgentsResponse(w http.ResponseWriter) error
+}
+
+type ListAgents200JSONResponse AgentList
+
+func (response ListAgents200JSONResponse) VisitListAgentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgents400JSONResponse BadRequest
+
+func (response ListAgents400JSONResponse) VisitListAgentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAgentRequestObject struct {
+	Body *CreateAgentJSONRequestBody
+}
+
+type CreateAgentResponseObject interface {
+	VisitCreateAgentResponse(w http.ResponseWriter) error
+}
+
+type CreateAgent201JSONResponse Agent
+
+func (response CreateAgent201JSONResponse) VisitCreateAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAgent400JSONResponse BadRequest
+
+func (response CreateAgent400JSONResponse) VisitCreateAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+}
+
+type DeleteAgentResponseObject interface {
+	VisitDeleteAgentResponse(w http.ResponseWriter) error
+}
+
+type DeleteAgent204Response struct {
+}
+
+func (response DeleteAgent204Response) VisitDeleteAgentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAgent403JSONResponse Forbidden
+
+func (response DeleteAgent403JSONResponse) VisitDeleteAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAgent404JSONResponse NotFound
+
+func (response DeleteAgent404JSONResponse) VisitDeleteAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+}
+
+type GetAgentResponseObject interface {
+	VisitGetAgentResponse(w http.ResponseWriter) error
+}
+
+type GetAgent200ResponseHeaders struct {
+	ETag string
+}
+
+type GetAgent200JSONResponse struct {
+	Body    Agent
+	Headers GetAgent200ResponseHeaders
+}
+
+func (response GetAgent200JSONResponse) VisitGetAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAgent404JSONResponse NotFound
+
+func (response GetAgent404JSONResponse) VisitGetAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	Params  UpdateAgentParams
+	Body    *UpdateAgentJSONRequestBody
+}
+
+type UpdateAgentResponseObject interface {
+	VisitUpdateAgentResponse(w http.ResponseWriter) error
+}
+
+type UpdateAgent200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdateAgent200JSONResponse struct {
+	Body    Agent
+	Headers UpdateAgent200ResponseHeaders
+}
+
+func (response UpdateAgent200JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateAgent400JSONResponse BadRequest
+
+func (response UpdateAgent400JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgent403JSONResponse Forbidden
+
+func (response UpdateAgent403JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgent404JSONResponse NotFound
+
+func (response UpdateAgent404JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgent412JSONResponse PreconditionFailed
+
+func (response UpdateAgent412JSONResponse) VisitUpdateAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentGrantsRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+}
+
+type ListAgentGrantsResponseObject interface {
+	VisitListAgentGrantsResponse(w http.ResponseWriter) error
+}
+
+type ListAgentGrants200JSONResponse ResourceGrantList
+
+func (response ListAgentGrants200JSONResponse) VisitListAgentGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentGrants403JSONResponse Forbidden
+
+func (response ListAgentGrants403JSONResponse) VisitListAgentGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentGrants404JSONResponse NotFound
+
+func (response ListAgentGrants404JSONResponse) VisitListAgentGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAgentGrantRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	Body    *SetAgentGrantJSONRequestBody
+}
+
+type SetAgentGrantResponseObject interface {
+	VisitSetAgentGrantResponse(w http.ResponseWriter) error
+}
+
+type SetAgentGrant200JSONResponse ResourceGrant
+
+func (response SetAgentGrant200JSONResponse) VisitSetAgentGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAgentGrant400JSONResponse BadRequest
+
+func (response SetAgentGrant400JSONResponse) VisitSetAgentGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAgentGrant403JSONResponse Forbidden
+
+func (response SetAgentGrant403JSONResponse) VisitSetAgentGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetAgentGrant404JSONResponse NotFound
+
+func (response SetAgentGrant404JSONResponse) VisitSetAgentGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveAgentGrantRequestObject struct {
+	AgentId     openapi_types.UUID `json:"agent_id"`
+	GranteeType GranteeType        `json:"grantee_type"`
+	GranteeId   openapi_types.UUID `json:"grantee_id"`
+}
+
+type RemoveAgentGrantResponseObject interface {
+	VisitRemoveAgentGrantResponse(w http.ResponseWriter) error
+}
+
+type RemoveAgentGrant204Response struct {
+}
+
+func (response RemoveAgentGrant204Response) VisitRemoveAgentGrantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveAgentGrant403JSONResponse Forbidden
+
+func (response RemoveAgentGrant403JSONResponse) VisitRemoveAgentGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveAgentGrant404JSONResponse NotFound
+
+func (response RemoveAgentGrant404JSONResponse) VisitRemoveAgentGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAgentHistoryRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	Params  GetAgentHistoryParams
+}
+
+type GetAgentHistoryResponseObject interface {
+	VisitGetAgentHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetAgentHistory200JSONResponse ResourceHistory
+
+func (response GetAgentHistory200JSONResponse) VisitGetAgentHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAgentHistory404JSONResponse NotFound
+
+func (response GetAgentHistory404JSONResponse) VisitGetAgentHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InvokeAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	Params  InvokeAgentParams
+	Body    *InvokeAgentJSONRequestBody
+}
+
+type InvokeAgentResponseObject interface {
+	VisitInvokeAgentResponse(w http.ResponseWriter) error
+}
+
+type InvokeAgent200ResponseHeaders struct {
+	CacheControl string
+	Connection   string
+}
+
+type InvokeAgent200JSONResponse struct {
+	Body    InvokeAgentResponse
+	Headers InvokeAgent200ResponseHeaders
+}
+
+func (response InvokeAgent200JSONResponse) VisitInvokeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type InvokeAgent200TexteventStreamResponse struct {
+	Body          io.Reader
+	Headers       InvokeAgent200ResponseHeaders
+	ContentLength int64
+}
+
+func (response InvokeAgent200TexteventStreamResponse) VisitInvokeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type InvokeAgent400JSONResponse BadRequest
+
+func (response InvokeAgent400JSONResponse) VisitInvokeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InvokeAgent403JSONResponse Forbidden
+
+func (response InvokeAgent403JSONResponse) VisitInvokeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InvokeAgent404JSONResponse NotFound
+
+func (response InvokeAgent404JSONResponse) VisitInvokeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type InvokeAgent429JSONResponse QuotaExceeded
+
+func (response InvokeAgent429JSONResponse) VisitInvokeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPermissionsForAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+}
+
+type ListPermissionsForAgentResponseObject interface {
+	VisitListPermissionsForAgentResponse(w http.ResponseWriter) error
+}
+
+type ListPermissionsForAgent200JSONResponse AgentPermissionMappingList
+
+func (response ListPermissionsForAgent200JSONResponse) VisitListPermissionsForAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPermissionsForAgent404JSONResponse NotFound
+
+func (response ListPermissionsForAgent404JSONResponse) VisitListPermissionsForAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	Body    *AddPermissionToAgentJSONRequestBody
+}
+
+type AddPermissionToAgentResponseObject interface {
+	VisitAddPermissionToAgentResponse(w http.ResponseWriter) error
+}
+
+type AddPermissionToAgent201JSONResponse AgentPermissionMapping
+
+func (response AddPermissionToAgent201JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToAgent400JSONResponse BadRequest
+
+func (response AddPermissionToAgent400JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToAgent403JSONResponse Forbidden
+
+func (response AddPermissionToAgent403JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToAgent404JSONResponse NotFound
+
+func (response AddPermissionToAgent404JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToAgent409JSONResponse ResourceAlreadyExists
+
+func (response AddPermissionToAgent409JSONResponse) VisitAddPermissionToAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemovePermissionFromAgentRequestObject struct {
+	AgentId      openapi_types.UUID `json:"agent_id"`
+	PermissionId openapi_types.UUID `json:"permission_id"`
+}
+
+type RemovePermissionFromAgentResponseObject interface {
+	VisitRemovePermissionFromAgentResponse(w http.ResponseWriter) error
+}
+
+type RemovePermissionFromAgent204Response struct {
+}
+
+func (response RemovePermissionFromAgent204Response) VisitRemovePermissionFromAgentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemovePermissionFromAgent403JSONResponse Forbidden
+
+func (response RemovePermissionFromAgent403JSONResponse) VisitRemovePermissionFromAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemovePermissionFromAgent404JSONResponse NotFound
+
+func (response RemovePermissionFromAgent404JSONResponse) VisitRemovePermissionFromAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentProbesRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+}
+
+type ListAgentProbesResponseObject interface {
+	VisitListAgentProbesResponse(w http.ResponseWriter) error
+}
+
+type ListAgentProbes200JSONResponse AgentProbeList
+
+func (response ListAgentProbes200JSONResponse) VisitListAgentProbesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentProbes404JSONResponse NotFound
+
+func (response ListAgentProbes404JSONResponse) VisitListAgentProbesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAgentProbeRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	Body    *CreateAgentProbeJSONRequestBody
+}
+
+type CreateAgentProbeResponseObject interface {
+	VisitCreateAgentProbeResponse(w http.ResponseWriter) error
+}
+
+type CreateAgentProbe201JSONResponse AgentProbe
+
+func (response CreateAgentProbe201JSONResponse) VisitCreateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAgentProbe400JSONResponse BadRequest
+
+func (response CreateAgentProbe400JSONResponse) VisitCreateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAgentProbe403JSONResponse Forbidden
+
+func (response CreateAgentProbe403JSONResponse) VisitCreateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAgentProbe404JSONResponse NotFound
+
+func (response CreateAgentProbe404JSONResponse) VisitCreateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAgentProbe409JSONResponse ResourceAlreadyExists
+
+func (response CreateAgentProbe409JSONResponse) VisitCreateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAgentProbeRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	ProbeId openapi_types.UUID `json:"probe_id"`
+}
+
+type DeleteAgentProbeResponseObject interface {
+	VisitDeleteAgentProbeResponse(w http.ResponseWriter) error
+}
+
+type DeleteAgentProbe204Response struct {
+}
+
+func (response DeleteAgentProbe204Response) VisitDeleteAgentProbeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAgentProbe403JSONResponse Forbidden
+
+func (response DeleteAgentProbe403JSONResponse) VisitDeleteAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAgentProbe404JSONResponse NotFound
+
+func (response DeleteAgentProbe404JSONResponse) VisitDeleteAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAgentProbeRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	ProbeId openapi_types.UUID `json:"probe_id"`
+}
+
+type GetAgentProbeResponseObject interface {
+	VisitGetAgentProbeResponse(w http.ResponseWriter) error
+}
+
+type GetAgentProbe200JSONResponse AgentProbe
+
+func (response GetAgentProbe200JSONResponse) VisitGetAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAgentProbe404JSONResponse NotFound
+
+func (response GetAgentProbe404JSONResponse) VisitGetAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgentProbeRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	ProbeId openapi_types.UUID `json:"probe_id"`
+	Body    *UpdateAgentProbeJSONRequestBody
+}
+
+type UpdateAgentProbeResponseObject interface {
+	VisitUpdateAgentProbeResponse(w http.ResponseWriter) error
+}
+
+type UpdateAgentProbe200JSONResponse AgentProbe
+
+func (response UpdateAgentProbe200JSONResponse) VisitUpdateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgentProbe400JSONResponse BadRequest
+
+func (response UpdateAgentProbe400JSONResponse) VisitUpdateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgentProbe403JSONResponse Forbidden
+
+func (response UpdateAgentProbe403JSONResponse) VisitUpdateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgentProbe404JSONResponse NotFound
+
+func (response UpdateAgentProbe404JSONResponse) VisitUpdateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateAgentProbe409JSONResponse ResourceAlreadyExists
+
+func (response UpdateAgentProbe409JSONResponse) VisitUpdateAgentProbeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentProbeRunsRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+	ProbeId openapi_types.UUID `json:"probe_id"`
+	Params  ListAgentProbeRunsParams
+}
+
+type ListAgentProbeRunsResponseObject interface {
+	VisitListAgentProbeRunsResponse(w http.ResponseWriter) error
+}
+
+type ListAgentProbeRuns200JSONResponse AgentProbeRunList
+
+func (response ListAgentProbeRuns200JSONResponse) VisitListAgentProbeRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentProbeRuns404JSONResponse NotFound
+
+func (response ListAgentProbeRuns404JSONResponse) VisitListAgentProbeRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+}
+
+type PurgeAgentResponseObject interface {
+	VisitPurgeAgentResponse(w http.ResponseWriter) error
+}
+
+type PurgeAgent204Response struct {
+}
+
+func (response PurgeAgent204Response) VisitPurgeAgentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PurgeAgent403JSONResponse Forbidden
+
+func (response PurgeAgent403JSONResponse) VisitPurgeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeAgent404JSONResponse NotFound
+
+func (response PurgeAgent404JSONResponse) VisitPurgeAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreAgentRequestObject struct {
+	AgentId openapi_types.UUID `json:"agent_id"`
+}
+
+type RestoreAgentResponseObject interface {
+	VisitRestoreAgentResponse(w http.ResponseWriter) error
+}
+
+type RestoreAgent200JSONResponse Agent
+
+func (response RestoreAgent200JSONResponse) VisitRestoreAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreAgent403JSONResponse Forbidden
+
+func (response RestoreAgent403JSONResponse) VisitRestoreAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreAgent404JSONResponse NotFound
+
+func (response RestoreAgent404JSONResponse) VisitRestoreAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreAgent409JSONResponse ResourceAlreadyExists
+
+func (response RestoreAgent409JSONResponse) VisitRestoreAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowCostAnalyticsRequestObject struct {
+	Params GetFlowCostAnalyticsParams
+}
+
+type GetFlowCostAnalyticsResponseObject interface {
+	VisitGetFlowCostAnalyticsResponse(w http.ResponseWriter) error
+}
+
+type GetFlowCostAnalytics200JSONResponse FlowCostAnalytics
+
+func (response GetFlowCostAnalytics200JSONResponse) VisitGetFlowCostAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowCostAnalytics400JSONResponse BadRequest
+
+func (response GetFlowCostAnalytics400JSONResponse) VisitGetFlowCostAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPromptCacheAnalyticsRequestObject struct {
+}
+
+type GetPromptCacheAnalyticsResponseObject interface {
+	VisitGetPromptCacheAnalyticsResponse(w http.ResponseWriter) error
+}
+
+type GetPromptCacheAnalytics200JSONResponse PromptCacheAnalytics
+
+func (response GetPromptCacheAnalytics200JSONResponse) VisitGetPromptCacheAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetThinkingBudgetAnalyticsRequestObject struct {
+}
+
+type GetThinkingBudgetAnalyticsResponseObject interface {
+	VisitGetThinkingBudgetAnalyticsResponse(w http.ResponseWriter) error
+}
+
+type GetThinkingBudgetAnalytics200JSONResponse ThinkingBudgetAnalytics
+
+func (response GetThinkingBudgetAnalytics200JSONResponse) VisitGetThinkingBudgetAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserFlowCostAnalyticsRequestObject struct {
+	Params GetUserFlowCostAnalyticsParams
+}
+
+type GetUserFlowCostAnalyticsResponseObject interface {
+	VisitGetUserFlowCostAnalyticsResponse(w http.ResponseWriter) error
+}
+
+type GetUserFlowCostAnalytics200JSONResponse UserFlowCostAnalytics
+
+func (response GetUserFlowCostAnalytics200JSONResponse) VisitGetUserFlowCostAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserFlowCostAnalytics400JSONResponse BadRequest
+
+func (response GetUserFlowCostAnalytics400JSONResponse) VisitGetUserFlowCostAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListToolRunAuditRequestObject struct {
+	Params ListToolRunAuditParams
+}
+
+type ListToolRunAuditResponseObject interface {
+	VisitListToolRunAuditResponse(w http.ResponseWriter) error
+}
+
+type ListToolRunAudit200JSONResponse ToolRunAuditList
+
+func (response ListToolRunAudit200JSONResponse) VisitListToolRunAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetToolRunAuditRequestObject struct {
+	ToolRunId string `json:"tool_run_id"`
+}
+
+type GetToolRunAuditResponseObject interface {
+	VisitGetToolRunAuditResponse(w http.ResponseWriter) error
+}
+
+type GetToolRunAudit200JSONResponse ToolRunAudit
+
+func (response GetToolRunAudit200JSONResponse) VisitGetToolRunAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetToolRunAudit404JSONResponse NotFound
+
+func (response GetToolRunAudit404JSONResponse) VisitGetToolRunAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFeedbackRequestObject struct {
+	Params ListFeedbackParams
+}
+
+type ListFeedbackResponseObject interface {
+	VisitListFeedbackResponse(w http.ResponseWriter) error
+}
+
+type ListFeedback200JSONResponse FeedbackList
+
+func (response ListFeedback200JSONResponse) VisitListFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFeedback403JSONResponse Forbidden
+
+func (response ListFeedback403JSONResponse) VisitListFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportFeedbackRequestObject struct {
+	Params ExportFeedbackParams
+}
+
+type ExportFeedbackResponseObject interface {
+	VisitExportFeedbackResponse(w http.ResponseWriter) error
+}
+
+type ExportFeedback200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportFeedback200ApplicationxNdjsonResponse) VisitExportFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportFeedback403JSONResponse Forbidden
+
+func (response ExportFeedback403JSONResponse) VisitExportFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFilesRequestObject struct {
+	Params ListFilesParams
+}
+
+type ListFilesResponseObject interface {
+	VisitListFilesResponse(w http.ResponseWriter) error
+}
+
+type ListFiles200JSONResponse FileList
+
+func (response ListFiles200JSONResponse) VisitListFilesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFiles400JSONResponse BadRequest
+
+func (response ListFiles400JSONResponse) VisitListFilesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFileRequestObject struct {
+	JSONBody      *CreateFileJSONRequestBody
+	MultipartBody *multipart.Reader
+}
+
+type CreateFileResponseObject interface {
+	VisitCreateFileResponse(w http.ResponseWriter) error
+}
+
+type CreateFile201JSONResponse CreatedFile
+
+func (response CreateFile201JSONResponse) VisitCreateFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFile400JSONResponse BadRequest
+
+func (response CreateFile400JSONResponse) VisitCreateFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFileRequestObject struct {
+	FileId openapi_types.UUID `json:"file_id"`
+}
+
+type DeleteFileResponseObject interface {
+	VisitDeleteFileResponse(w http.ResponseWriter) error
+}
+
+type DeleteFile204Response struct {
+}
+
+func (response DeleteFile204Response) VisitDeleteFileResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFile400JSONResponse BadRequest
+
+func (response DeleteFile400JSONResponse) VisitDeleteFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFile404JSONResponse NotFound
+
+func (response DeleteFile404JSONResponse) VisitDeleteFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFileRequestObject struct {
+	FileId openapi_types.UUID `json:"file_id"`
+}
+
+type GetFileResponseObject interface {
+	VisitGetFileResponse(w http.ResponseWriter) error
+}
+
+type GetFile200JSONResponse File
+
+func (response GetFile200JSONResponse) VisitGetFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFile404JSONResponse NotFound
+
+func (response GetFile404JSONResponse) VisitGetFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteFileUploadRequestObject struct {
+	FileId openapi_types.UUID `json:"file_id"`
+}
+
+type CompleteFileUploadResponseObject interface {
+	VisitCompleteFileUploadResponse(w http.ResponseWriter) error
+}
+
+type CompleteFileUpload200JSONResponse File
+
+func (response CompleteFileUpload200JSONResponse) VisitCompleteFileUploadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteFileUpload400JSONResponse BadRequest
+
+func (response CompleteFileUpload400JSONResponse) VisitCompleteFileUploadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteFileUpload404JSONResponse NotFound
+
+func (response CompleteFileUpload404JSONResponse) VisitCompleteFileUploadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadFileRequestObject struct {
+	FileId openapi_types.UUID `json:"file_id"`
+}
+
+type DownloadFileResponseObject interface {
+	VisitDownloadFileResponse(w http.ResponseWriter) error
+}
+
+type DownloadFile200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type DownloadFile200AsteriskResponse struct {
+	Body          io.Reader
+	Headers       DownloadFile200ResponseHeaders
+	ContentType   string
+	ContentLength int64
+}
+
+func (response DownloadFile200AsteriskResponse) VisitDownloadFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", response.ContentType)
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type DownloadFile404JSONResponse NotFound
+
+func (response DownloadFile404JSONResponse) VisitDownloadFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryFlowRunRequestObject struct {
+	FlowRunId openapi_types.UUID `json:"flow_run_id"`
+}
+
+type RetryFlowRunResponseObject interface {
+	VisitRetryFlowRunResponse(w http.ResponseWriter) error
+}
+
+type RetryFlowRun201JSONResponse FlowRun
+
+func (response RetryFlowRun201JSONResponse) VisitRetryFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryFlowRun400JSONResponse BadRequest
+
+func (response RetryFlowRun400JSONResponse) VisitRetryFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryFlowRun404JSONResponse NotFound
+
+func (response RetryFlowRun404JSONResponse) VisitRetryFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowsRequestObject struct {
+	Params ListFlowsParams
+}
+
+type ListFlowsResponseObject interface {
+	VisitListFlowsResponse(w http.ResponseWriter) error
+}
+
+type ListFlows200JSONResponse FlowList
+
+func (response ListFlows200JSONResponse) VisitListFlowsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowRequestObject struct {
+	Body *CreateFlowJSONRequestBody
+}
+
+type CreateFlowResponseObject interface {
+	VisitCreateFlowResponse(w http.ResponseWriter) error
+}
+
+type CreateFlow201JSONResponse Flow
+
+func (response CreateFlow201JSONResponse) VisitCreateFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlow400JSONResponse NotFound
+
+func (response CreateFlow400JSONResponse) VisitCreateFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFlowRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+}
+
+type DeleteFlowResponseObject interface {
+	VisitDeleteFlowResponse(w http.ResponseWriter) error
+}
+
+type DeleteFlow204Response struct {
+}
+
+func (response DeleteFlow204Response) VisitDeleteFlowResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFlow404JSONResponse NotFound
+
+func (response DeleteFlow404JSONResponse) VisitDeleteFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+}
+
+type GetFlowResponseObject interface {
+	VisitGetFlowResponse(w http.ResponseWriter) error
+}
+
+type GetFlow200JSONResponse Flow
+
+func (response GetFlow200JSONResponse) VisitGetFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlow404JSONResponse NotFound
+
+func (response GetFlow404JSONResponse) VisitGetFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFlowRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	Body   *UpdateFlowJSONRequestBody
+}
+
+type UpdateFlowResponseObject interface {
+	VisitUpdateFlowResponse(w http.ResponseWriter) error
+}
+
+type UpdateFlow200JSONResponse Flow
+
+func (response UpdateFlow200JSONResponse) VisitUpdateFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFlow400JSONResponse BadRequest
+
+func (response UpdateFlow400JSONResponse) VisitUpdateFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFlow404JSONResponse NotFound
+
+func (response UpdateFlow404JSONResponse) VisitUpdateFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteFlowRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	Params ExecuteFlowParams
+	Body   *ExecuteFlowJSONRequestBody
+}
+
+type ExecuteFlowResponseObject interface {
+	VisitExecuteFlowResponse(w http.ResponseWriter) error
+}
+
+type ExecuteFlow200JSONResponse FlowRun
+
+func (response ExecuteFlow200JSONResponse) VisitExecuteFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteFlow400JSONResponse InvalidFlowParameters
+
+func (response ExecuteFlow400JSONResponse) VisitExecuteFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteFlow404JSONResponse NotFound
+
+func (response ExecuteFlow404JSONResponse) VisitExecuteFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteFlow429JSONResponse QuotaExceeded
+
+func (response ExecuteFlow429JSONResponse) VisitExecuteFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowHistoryRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	Params GetFlowHistoryParams
+}
+
+type GetFlowHistoryResponseObject interface {
+	VisitGetFlowHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetFlowHistory200JSONResponse ResourceHistory
+
+func (response GetFlowHistory200JSONResponse) VisitGetFlowHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowHistory404JSONResponse NotFound
+
+func (response GetFlowHistory404JSONResponse) VisitGetFlowHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeFlowRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+}
+
+type PurgeFlowResponseObject interface {
+	VisitPurgeFlowResponse(w http.ResponseWriter) error
+}
+
+type PurgeFlow204Response struct {
+}
+
+func (response PurgeFlow204Response) VisitPurgeFlowResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PurgeFlow404JSONResponse NotFound
+
+func (response PurgeFlow404JSONResponse) VisitPurgeFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreFlowRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+}
+
+type RestoreFlowResponseObject interface {
+	VisitRestoreFlowResponse(w http.ResponseWriter) error
+}
+
+type RestoreFlow200JSONResponse Flow
+
+func (response RestoreFlow200JSONResponse) VisitRestoreFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreFlow404JSONResponse NotFound
+
+func (response RestoreFlow404JSONResponse) VisitRestoreFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowRunsRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	Params ListFlowRunsParams
+}
+
+type ListFlowRunsResponseObject interface {
+	VisitListFlowRunsResponse(w http.ResponseWriter) error
+}
+
+type ListFlowRuns200JSONResponse FlowRunList
+
+func (response ListFlowRuns200JSONResponse) VisitListFlowRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowRuns400JSONResponse BadRequest
+
+func (response ListFlowRuns400JSONResponse) VisitListFlowRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowRuns404JSONResponse NotFound
+
+func (response ListFlowRuns404JSONResponse) VisitListFlowRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowRunArtifactsRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type ListFlowRunArtifactsResponseObject interface {
+	VisitListFlowRunArtifactsResponse(w http.ResponseWriter) error
+}
+
+type ListFlowRunArtifacts200JSONResponse FlowRunArtifactList
+
+func (response ListFlowRunArtifacts200JSONResponse) VisitListFlowRunArtifactsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowRunArtifacts404JSONResponse NotFound
+
+func (response ListFlowRunArtifacts404JSONResponse) VisitListFlowRunArtifactsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadFlowRunArtifactRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+	Name   string             `json:"name"`
+}
+
+type DownloadFlowRunArtifactResponseObject interface {
+	VisitDownloadFlowRunArtifactResponse(w http.ResponseWriter) error
+}
+
+type DownloadFlowRunArtifact200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type DownloadFlowRunArtifact200AsteriskResponse struct {
+	Body          io.Reader
+	Headers       DownloadFlowRunArtifact200ResponseHeaders
+	ContentType   string
+	ContentLength int64
+}
+
+func (response DownloadFlowRunArtifact200AsteriskResponse) VisitDownloadFlowRunArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", response.ContentType)
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type DownloadFlowRunArtifact404JSONResponse NotFound
+
+func (response DownloadFlowRunArtifact404JSONResponse) VisitDownloadFlowRunArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFlowRunRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+	Body   *CancelFlowRunJSONRequestBody
+}
+
+type CancelFlowRunResponseObject interface {
+	VisitCancelFlowRunResponse(w http.ResponseWriter) error
+}
+
+type CancelFlowRun200JSONResponse FlowRun
+
+func (response CancelFlowRun200JSONResponse) VisitCancelFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFlowRun400JSONResponse BadRequest
+
+func (response CancelFlowRun400JSONResponse) VisitCancelFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFlowRun404JSONResponse NotFound
+
+func (response CancelFlowRun404JSONResponse) VisitCancelFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListChildFlowRunsRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type ListChildFlowRunsResponseObject interface {
+	VisitListChildFlowRunsResponse(w http.ResponseWriter) error
+}
+
+type ListChildFlowRuns200JSONResponse FlowRunChildren
+
+func (response ListChildFlowRuns200JSONResponse) VisitListChildFlowRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListChildFlowRuns404JSONResponse NotFound
+
+func (response ListChildFlowRuns404JSONResponse) VisitListChildFlowRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunCostRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type GetFlowRunCostResponseObject interface {
+	VisitGetFlowRunCostResponse(w http.ResponseWriter) error
+}
+
+type GetFlowRunCost200JSONResponse FlowRunCost
+
+func (response GetFlowRunCost200JSONResponse) VisitGetFlowRunCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunCost404JSONResponse NotFound
+
+func (response GetFlowRunCost404JSONResponse) VisitGetFlowRunCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunGraphRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type GetFlowRunGraphResponseObject interface {
+	VisitGetFlowRunGraphResponse(w http.ResponseWriter) error
+}
+
+type GetFlowRunGraph200JSONResponse FlowRunGraph
+
+func (response GetFlowRunGraph200JSONResponse) VisitGetFlowRunGraphResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunGraph404JSONResponse NotFound
+
+func (response GetFlowRunGraph404JSONResponse) VisitGetFlowRunGraphResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunLogsRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+	Params GetFlowRunLogsParams
+}
+
+type GetFlowRunLogsResponseObject interface {
+	VisitGetFlowRunLogsResponse(w http.ResponseWriter) error
+}
+
+type GetFlowRunLogs200ResponseHeaders struct {
+	CacheControl string
+	Connection   string
+}
+
+type GetFlowRunLogs200JSONResponse struct {
+	Body    FlowRunLogList
+	Headers GetFlowRunLogs200ResponseHeaders
+}
+
+func (response GetFlowRunLogs200JSONResponse) VisitGetFlowRunLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlowRunLogs200TexteventStreamResponse struct {
+	Body          io.Reader
+	Headers       GetFlowRunLogs200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetFlowRunLogs200TexteventStreamResponse) VisitGetFlowRunLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFlowRunLogs400JSONResponse BadRequest
+
+func (response GetFlowRunLogs400JSONResponse) VisitGetFlowRunLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunLogs404JSONResponse NotFound
+
+func (response GetFlowRunLogs404JSONResponse) VisitGetFlowRunLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseFlowRunRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type PauseFlowRunResponseObject interface {
+	VisitPauseFlowRunResponse(w http.ResponseWriter) error
+}
+
+type PauseFlowRun202JSONResponse FlowRun
+
+func (response PauseFlowRun202JSONResponse) VisitPauseFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseFlowRun400JSONResponse BadRequest
+
+func (response PauseFlowRun400JSONResponse) VisitPauseFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseFlowRun404JSONResponse NotFound
+
+func (response PauseFlowRun404JSONResponse) VisitPauseFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeFlowRunRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type ResumeFlowRunResponseObject interface {
+	VisitResumeFlowRunResponse(w http.ResponseWriter) error
+}
+
+type ResumeFlowRun200JSONResponse FlowRun
+
+func (response ResumeFlowRun200JSONResponse) VisitResumeFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeFlowRun400JSONResponse BadRequest
+
+func (response ResumeFlowRun400JSONResponse) VisitResumeFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeFlowRun404JSONResponse NotFound
+
+func (response ResumeFlowRun404JSONResponse) VisitResumeFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunTimelineRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type GetFlowRunTimelineResponseObject interface {
+	VisitGetFlowRunTimelineResponse(w http.ResponseWriter) error
+}
+
+type GetFlowRunTimeline200JSONResponse FlowRunTimeline
+
+func (response GetFlowRunTimeline200JSONResponse) VisitGetFlowRunTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunTimeline404JSONResponse NotFound
+
+func (response GetFlowRunTimeline404JSONResponse) VisitGetFlowRunTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowSchedulesRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+}
+
+type ListFlowSchedulesResponseObject interface {
+	VisitListFlowSchedulesResponse(w http.ResponseWriter) error
+}
+
+type ListFlowSchedules200JSONResponse FlowScheduleList
+
+func (response ListFlowSchedules200JSONResponse) VisitListFlowSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowSchedules404JSONResponse NotFound
+
+func (response ListFlowSchedules404JSONResponse) VisitListFlowSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowScheduleRequestObject struct {
+	FlowId openapi_types.UUID `json:"flow_id"`
+	Body   *CreateFlowScheduleJSONRequestBody
+}
+
+type CreateFlowScheduleResponseObject interface {
+	VisitCreateFlowScheduleResponse(w http.ResponseWriter) error
+}
+
+type CreateFlowSchedule201JSONResponse FlowSchedule
+
+func (response CreateFlowSchedule201JSONResponse) VisitCreateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowSchedule400JSONResponse BadRequest
+
+func (response CreateFlowSchedule400JSONResponse) VisitCreateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowSchedule404JSONResponse NotFound
+
+func (response CreateFlowSchedule404JSONResponse) VisitCreateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowSchedule409JSONResponse ResourceAlreadyExists
+
+func (response CreateFlowSchedule409JSONResponse) VisitCreateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFlowScheduleRequestObject struct {
+	FlowId     openapi_types.UUID `json:"flow_id"`
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+}
+
+type DeleteFlowScheduleResponseObject interface {
+	VisitDeleteFlowScheduleResponse(w http.ResponseWriter) error
+}
+
+type DeleteFlowSchedule204Response struct {
+}
+
+func (response DeleteFlowSchedule204Response) VisitDeleteFlowScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFlowSchedule404JSONResponse NotFound
+
+func (response DeleteFlowSchedule404JSONResponse) VisitDeleteFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowScheduleRequestObject struct {
+	FlowId     openapi_types.UUID `json:"flow_id"`
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+}
+
+type GetFlowScheduleResponseObject interface {
+	VisitGetFlowScheduleResponse(w http.ResponseWriter) error
+}
+
+type GetFlowSchedule200JSONResponse FlowSchedule
+
+func (response GetFlowSchedule200JSONResponse) VisitGetFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowSchedule404JSONResponse NotFound
+
+func (response GetFlowSchedule404JSONResponse) VisitGetFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFlowScheduleRequestObject struct {
+	FlowId     openapi_types.UUID `json:"flow_id"`
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+	Body       *UpdateFlowScheduleJSONRequestBody
+}
+
+type UpdateFlowScheduleResponseObject interface {
+	VisitUpdateFlowScheduleResponse(w http.ResponseWriter) error
+}
+
+type UpdateFlowSchedule200JSONResponse FlowSchedule
+
+func (response UpdateFlowSchedule200JSONResponse) VisitUpdateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFlowSchedule400JSONResponse BadRequest
+
+func (response UpdateFlowSchedule400JSONResponse) VisitUpdateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFlowSchedule404JSONResponse NotFound
+
+func (response UpdateFlowSchedule404JSONResponse) VisitUpdateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFlowSchedule409JSONResponse ResourceAlreadyExists
+
+func (response UpdateFlowSchedule409JSONResponse) VisitUpdateFlowScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowScheduleBackfillsRequestObject struct {
+	FlowId     openapi_types.UUID `json:"flow_id"`
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+}
+
+type ListFlowScheduleBackfillsResponseObject interface {
+	VisitListFlowScheduleBackfillsResponse(w http.ResponseWriter) error
+}
+
+type ListFlowScheduleBackfills200JSONResponse FlowScheduleBackfillList
+
+func (response ListFlowScheduleBackfills200JSONResponse) VisitListFlowScheduleBackfillsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFlowScheduleBackfills404JSONResponse NotFound
+
+func (response ListFlowScheduleBackfills404JSONResponse) VisitListFlowScheduleBackfillsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowScheduleBackfillRequestObject struct {
+	FlowId     openapi_types.UUID `json:"flow_id"`
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+	Body       *CreateFlowScheduleBackfillJSONRequestBody
+}
+
+type CreateFlowScheduleBackfillResponseObject interface {
+	VisitCreateFlowScheduleBackfillResponse(w http.ResponseWriter) error
+}
+
+type CreateFlowScheduleBackfill201JSONResponse FlowScheduleBackfill
+
+func (response CreateFlowScheduleBackfill201JSONResponse) VisitCreateFlowScheduleBackfillResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowScheduleBackfill400JSONResponse InvalidFlowParameters
+
+func (response CreateFlowScheduleBackfill400JSONResponse) VisitCreateFlowScheduleBackfillResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFlowScheduleBackfill404JSONResponse NotFound
+
+func (response CreateFlowScheduleBackfill404JSONResponse) VisitCreateFlowScheduleBackfillResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFlowScheduleBackfillRequestObject struct {
+	FlowId     openapi_types.UUID `json:"flow_id"`
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+	BackfillId openapi_types.UUID `json:"backfill_id"`
+}
+
+type CancelFlowScheduleBackfillResponseObject interface {
+	VisitCancelFlowScheduleBackfillResponse(w http.ResponseWriter) error
+}
+
+type CancelFlowScheduleBackfill200JSONResponse FlowScheduleBackfill
+
+func (response CancelFlowScheduleBackfill200JSONResponse) VisitCancelFlowScheduleBackfillResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFlowScheduleBackfill400JSONResponse BadRequest
+
+func (response CancelFlowScheduleBackfill400JSONResponse) VisitCancelFlowScheduleBackfillResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFlowScheduleBackfill404JSONResponse NotFound
+
+func (response CancelFlowScheduleBackfill404JSONResponse) VisitCancelFlowScheduleBackfillResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRunRequestObject struct {
+	FlowRunId openapi_types.UUID `json:"flow_run_id"`
+}
+
+type GetFlowRunResponseObject interface {
+	VisitGetFlowRunResponse(w http.ResponseWriter) error
+}
+
+type GetFlowRun200JSONResponse FlowRun
+
+func (response GetFlowRun200JSONResponse) VisitGetFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlowRun404JSONResponse NotFound
+
+func (response GetFlowRun404JSONResponse) VisitGetFlowRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MockStandaloneToolRequestObject struct {
+	Body *MockStandaloneToolJSONRequestBody
+}
+
+type MockStandaloneToolResponseObject interface {
+	VisitMockStandaloneToolResponse(w http.ResponseWriter) error
+}
+
+type MockStandaloneTool200JSONResponse MockToolResponse
+
+func (response MockStandaloneTool200JSONResponse) VisitMockStandaloneToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MockStandaloneTool400JSONResponse BadRequest
+
+func (response MockStandaloneTool400JSONResponse) VisitMockStandaloneToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MockStandaloneToolWithDelayRequestObject struct {
+	Body *MockStandaloneToolWithDelayJSONRequestBody
+}
+
+type MockStandaloneToolWithDelayResponseObject interface {
+	VisitMockStandaloneToolWithDelayResponse(w http.ResponseWriter) error
+}
+
+type MockStandaloneToolWithDelay200JSONResponse MockToolWithDelayResponse
+
+func (response MockStandaloneToolWithDelay200JSONResponse) VisitMockStandaloneToolWithDelayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MockStandaloneToolWithDelay400JSONResponse BadRequest
+
+func (response MockStandaloneToolWithDelay400JSONResponse) VisitMockStandaloneToolWithDelayResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizationsRequestObject struct {
+}
+
+type ListOrganizationsResponseObject interface {
+	VisitListOrganizationsResponse(w http.ResponseWriter) error
+}
+
+type ListOrganizations200JSONResponse UserOrganizationList
+
+func (response ListOrganizations200JSONResponse) VisitListOrganizationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrganizationRequestObject struct {
+	Body *CreateOrganizationJSONRequestBody
+}
+
+type CreateOrganizationResponseObject interface {
+	VisitCreateOrganizationResponse(w http.ResponseWriter) error
+}
+
+type CreateOrganization201JSONResponse Organization
+
+func (response CreateOrganization201JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrganization400JSONResponse BadRequest
+
+func (response CreateOrganization400JSONResponse) VisitCreateOrganizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizationMembersRequestObject struct {
+	OrganizationId openapi_types.UUID `json:"organization_id"`
+}
+
+type ListOrganizationMembersResponseObject interface {
+	VisitListOrganizationMembersResponse(w http.ResponseWriter) error
+}
+
+type ListOrganizationMembers200JSONResponse OrganizationMemberList
+
+func (response ListOrganizationMembers200JSONResponse) VisitListOrganizationMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizationMembers404JSONResponse NotFound
+
+func (response ListOrganizationMembers404JSONResponse) VisitListOrganizationMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveOrganizationMemberRequestObject struct {
+	OrganizationId openapi_types.UUID `json:"organization_id"`
+	UserId         openapi_types.UUID `json:"user_id"`
+}
+
+type RemoveOrganizationMemberResponseObject interface {
+	VisitRemoveOrganizationMemberResponse(w http.ResponseWriter) error
+}
+
+type RemoveOrganizationMember204Response struct {
+}
+
+func (response RemoveOrganizationMember204Response) VisitRemoveOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveOrganizationMember400JSONResponse BadRequest
+
+func (response RemoveOrganizationMember400JSONResponse) VisitRemoveOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveOrganizationMember403JSONResponse Forbidden
+
+func (response RemoveOrganizationMember403JSONResponse) VisitRemoveOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveOrganizationMember404JSONResponse NotFound
+
+func (response RemoveOrganizationMember404JSONResponse) VisitRemoveOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetOrganizationMemberRequestObject struct {
+	OrganizationId openapi_types.UUID `json:"organization_id"`
+	UserId         openapi_types.UUID `json:"user_id"`
+	Body           *SetOrganizationMemberJSONRequestBody
+}
+
+type SetOrganizationMemberResponseObject interface {
+	VisitSetOrganizationMemberResponse(w http.ResponseWriter) error
+}
+
+type SetOrganizationMember200JSONResponse OrganizationMember
+
+func (response SetOrganizationMember200JSONResponse) VisitSetOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetOrganizationMember400JSONResponse BadRequest
+
+func (response SetOrganizationMember400JSONResponse) VisitSetOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetOrganizationMember403JSONResponse Forbidden
+
+func (response SetOrganizationMember403JSONResponse) VisitSetOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetOrganizationMember404JSONResponse NotFound
+
+func (response SetOrganizationMember404JSONResponse) VisitSetOrganizationMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizationWorkspacesRequestObject struct {
+	OrganizationId openapi_types.UUID `json:"organization_id"`
+}
+
+type ListOrganizationWorkspacesResponseObject interface {
+	VisitListOrganizationWorkspacesResponse(w http.ResponseWriter) error
+}
+
+type ListOrganizationWorkspaces200JSONResponse WorkspaceList
+
+func (response ListOrganizationWorkspaces200JSONResponse) VisitListOrganizationWorkspacesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOrganizationWorkspaces404JSONResponse NotFound
+
+func (response ListOrganizationWorkspaces404JSONResponse) VisitListOrganizationWorkspacesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWorkspaceRequestObject struct {
+	OrganizationId openapi_types.UUID `json:"organization_id"`
+	Body           *CreateWorkspaceJSONRequestBody
+}
+
+type CreateWorkspaceResponseObject interface {
+	VisitCreateWorkspaceResponse(w http.ResponseWriter) error
+}
+
+type CreateWorkspace201JSONResponse Workspace
+
+func (response CreateWorkspace201JSONResponse) VisitCreateWorkspaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWorkspace400JSONResponse BadRequest
+
+func (response CreateWorkspace400JSONResponse) VisitCreateWorkspaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWorkspace403JSONResponse Forbidden
+
+func (response CreateWorkspace403JSONResponse) VisitCreateWorkspaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWorkspace404JSONResponse NotFound
+
+func (response CreateWorkspace404JSONResponse) VisitCreateWorkspaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWorkspace409JSONResponse ResourceAlreadyExists
+
+func (response CreateWorkspace409JSONResponse) VisitCreateWorkspaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPermissionsRequestObject struct {
+}
+
+type ListPermissionsResponseObject interface {
+	VisitListPermissionsResponse(w http.ResponseWriter) error
+}
+
+type ListPermissions200JSONResponse PermissionList
+
+func (response ListPermissions200JSONResponse) VisitListPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePermissionRequestObject struct {
+	Body *CreatePermissionJSONRequestBody
+}
+
+type CreatePermissionResponseObject interface {
+	VisitCreatePermissionResponse(w http.ResponseWriter) error
+}
+
+type CreatePermission201JSONResponse Permission
+
+func (response CreatePermission201JSONResponse) VisitCreatePermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePermission400JSONResponse BadRequest
+
+func (response CreatePermission400JSONResponse) VisitCreatePermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeletePermissionRequestObject struct {
+	PermissionId openapi_types.UUID `json:"permission_id"`
+}
+
+type DeletePermissionResponseObject interface {
+	VisitDeletePermissionResponse(w http.ResponseWriter) error
+}
+
+type DeletePermission204Response struct {
+}
+
+func (response DeletePermission204Response) VisitDeletePermissionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeletePermission404JSONResponse NotFound
+
+func (response DeletePermission404JSONResponse) VisitDeletePermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPermissionRequestObject struct {
+	PermissionId openapi_types.UUID `json:"permission_id"`
+}
+
+type GetPermissionResponseObject interface {
+	VisitGetPermissionResponse(w http.ResponseWriter) error
+}
+
+type GetPermission200JSONResponse Permission
+
+func (response GetPermission200JSONResponse) VisitGetPermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPermission404JSONResponse NotFound
+
+func (response GetPermission404JSONResponse) VisitGetPermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePermissionRequestObject struct {
+	PermissionId openapi_types.UUID `json:"permission_id"`
+	Body         *UpdatePermissionJSONRequestBody
+}
+
+type UpdatePermissionResponseObject interface {
+	VisitUpdatePermissionResponse(w http.ResponseWriter) error
+}
+
+type UpdatePermission200JSONResponse Permission
+
+func (response UpdatePermission200JSONResponse) VisitUpdatePermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePermission400JSONResponse BadRequest
+
+func (response UpdatePermission400JSONResponse) VisitUpdatePermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePermission404JSONResponse NotFound
+
+func (response UpdatePermission404JSONResponse) VisitUpdatePermissionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type QuickstartRequestObject struct {
+	Params QuickstartParams
+	Body   *QuickstartJSONRequestBody
+}
+
+type QuickstartResponseObject interface {
+	VisitQuickstartResponse(w http.ResponseWriter) error
+}
+
+type Quickstart201JSONResponse QuickstartResponse
+
+func (response Quickstart201JSONResponse) VisitQuickstartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Quickstart400JSONResponse BadRequest
+
+func (response Quickstart400JSONResponse) VisitQuickstartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Quickstart404JSONResponse NotFound
+
+func (response Quickstart404JSONResponse) VisitQuickstartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Quickstart429JSONResponse QuotaExceeded
+
+func (response Quickstart429JSONResponse) VisitQuickstartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRolesRequestObject struct {
+}
+
+type ListRolesResponseObject interface {
+	VisitListRolesResponse(w http.ResponseWriter) error
+}
+
+type ListRoles200JSONResponse RoleList
+
+func (response ListRoles200JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRoleRequestObject struct {
+	Body *CreateRoleJSONRequestBody
+}
+
+type CreateRoleResponseObject interface {
+	VisitCreateRoleResponse(w http.ResponseWriter) error
+}
+
+type CreateRole201JSONResponse Role
+
+func (response CreateRole201JSONResponse) VisitCreateRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRole400JSONResponse BadRequest
+
+func (response CreateRole400JSONResponse) VisitCreateRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRoleRequestObject struct {
+	RoleId openapi_types.UUID `json:"role_id"`
+}
+
+type DeleteRoleResponseObject interface {
+	VisitDeleteRoleResponse(w http.ResponseWriter) error
+}
+
+type DeleteRole204Response struct {
+}
+
+func (response DeleteRole204Response) VisitDeleteRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteRole404JSONResponse NotFound
+
+func (response DeleteRole404JSONResponse) VisitDeleteRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRoleRequestObject struct {
+	RoleId openapi_types.UUID `json:"role_id"`
+}
+
+type GetRoleResponseObject interface {
+	VisitGetRoleResponse(w http.ResponseWriter) error
+}
+
+type GetRole200JSONResponse Role
+
+func (response GetRole200JSONResponse) VisitGetRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRole404JSONResponse NotFound
+
+func (response GetRole404JSONResponse) VisitGetRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRoleRequestObject struct {
+	RoleId openapi_types.UUID `json:"role_id"`
+	Body   *UpdateRoleJSONRequestBody
+}
+
+type UpdateRoleResponseObject interface {
+	VisitUpdateRoleResponse(w http.ResponseWriter) error
+}
+
+type UpdateRole200JSONResponse Role
+
+func (response UpdateRole200JSONResponse) VisitUpdateRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRole400JSONResponse BadRequest
+
+func (response UpdateRole400JSONResponse) VisitUpdateRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRole404JSONResponse NotFound
+
+func (response UpdateRole404JSONResponse) VisitUpdateRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPermissionsForRoleRequestObject struct {
+	RoleId openapi_types.UUID `json:"role_id"`
+}
+
+type ListPermissionsForRoleResponseObject interface {
+	VisitListPermissionsForRoleResponse(w http.ResponseWriter) error
+}
+
+type ListPermissionsForRole200JSONResponse RolePermissionMappingList
+
+func (response ListPermissionsForRole200JSONResponse) VisitListPermissionsForRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPermissionsForRole404JSONResponse NotFound
+
+func (response ListPermissionsForRole404JSONResponse) VisitListPermissionsForRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToRoleRequestObject struct {
+	RoleId openapi_types.UUID `json:"role_id"`
+	Body   *AddPermissionToRoleJSONRequestBody
+}
+
+type AddPermissionToRoleResponseObject interface {
+	VisitAddPermissionToRoleResponse(w http.ResponseWriter) error
+}
+
+type AddPermissionToRole201JSONResponse RolePermissionMapping
+
+func (response AddPermissionToRole201JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToRole400JSONResponse BadRequest
+
+func (response AddPermissionToRole400JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToRole404JSONResponse NotFound
+
+func (response AddPermissionToRole404JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPermissionToRole409JSONResponse ResourceAlreadyExists
+
+func (response AddPermissionToRole409JSONResponse) VisitAddPermissionToRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemovePermissionFromRoleRequestObject struct {
+	RoleId       openapi_types.UUID `json:"role_id"`
+	PermissionId openapi_types.UUID `json:"permission_id"`
+}
+
+type RemovePermissionFromRoleResponseObject interface {
+	VisitRemovePermissionFromRoleResponse(w http.ResponseWriter) error
+}
+
+type RemovePermissionFromRole204Response struct {
+}
+
+func (response RemovePermissionFromRole204Response) VisitRemovePermissionFromRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemovePermissionFromRole404JSONResponse NotFound
+
+func (response RemovePermissionFromRole404JSONResponse) VisitRemovePermissionFromRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchMessagesRequestObject struct {
+	Params SearchMessagesParams
+}
+
+type SearchMessagesResponseObject interface {
+	VisitSearchMessagesResponse(w http.ResponseWriter) error
+}
+
+type SearchMessages200JSONResponse MessageSearchResults
+
+func (response SearchMessages200JSONResponse) VisitSearchMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchMessages400JSONResponse BadRequest
+
+func (response SearchMessages400JSONResponse) VisitSearchMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchMessages404JSONResponse NotFound
+
+func (response SearchMessages404JSONResponse) VisitSearchMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SemanticSearchMessagesRequestObject struct {
+	Params SemanticSearchMessagesParams
+}
+
+type SemanticSearchMessagesResponseObject interface {
+	VisitSemanticSearchMessagesResponse(w http.ResponseWriter) error
+}
+
+type SemanticSearchMessages200JSONResponse SemanticSearchResults
+
+func (response SemanticSearchMessages200JSONResponse) VisitSemanticSearchMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SemanticSearchMessages400JSONResponse BadRequest
+
+func (response SemanticSearchMessages400JSONResponse) VisitSemanticSearchMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SemanticSearchMessages404JSONResponse NotFound
+
+func (response SemanticSearchMessages404JSONResponse) VisitSemanticSearchMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateServiceAccountRequestObject struct {
+	Body *CreateServiceAccountJSONRequestBody
+}
+
+type CreateServiceAccountResponseObject interface {
+	VisitCreateServiceAccountResponse(w http.ResponseWriter) error
+}
+
+type CreateServiceAccount201JSONResponse User
+
+func (response CreateServiceAccount201JSONResponse) VisitCreateServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateServiceAccount400JSONResponse BadRequest
+
+func (response CreateServiceAccount400JSONResponse) VisitCreateServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateServiceAccount409JSONResponse ResourceAlreadyExists
+
+func (response CreateServiceAccount409JSONResponse) VisitCreateServiceAccountResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTasksRequestObject struct {
+	Params ListTasksParams
+}
+
+type ListTasksResponseObject interface {
+	VisitListTasksResponse(w http.ResponseWriter) error
+}
+
+type ListTasks200JSONResponse TaskList
+
+func (response ListTasks200JSONResponse) VisitListTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTasks400JSONResponse BadRequest
+
+func (response ListTasks400JSONResponse) VisitListTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTaskRequestObject struct {
+	Params CreateTaskParams
+	Body   *CreateTaskJSONRequestBody
+}
+
+type CreateTaskResponseObject interface {
+	VisitCreateTaskResponse(w http.ResponseWriter) error
+}
+
+type CreateTask201JSONResponse Task
+
+func (response CreateTask201JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTask400JSONResponse BadRequest
+
+func (response CreateTask400JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTask403JSONResponse Forbidden
+
+func (response CreateTask403JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTask404JSONResponse NotFound
+
+func (response CreateTask404JSONResponse) VisitCreateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskSchedulesRequestObject struct {
+}
+
+type ListTaskSchedulesResponseObject interface {
+	VisitListTaskSchedulesResponse(w http.ResponseWriter) error
+}
+
+type ListTaskSchedules200JSONResponse TaskScheduleList
+
+func (response ListTaskSchedules200JSONResponse) VisitListTaskSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTaskScheduleRequestObject struct {
+	Body *CreateTaskScheduleJSONRequestBody
+}
+
+type CreateTaskScheduleResponseObject interface {
+	VisitCreateTaskScheduleResponse(w http.ResponseWriter) error
+}
+
+type CreateTaskSchedule201JSONResponse TaskSchedule
+
+func (response CreateTaskSchedule201JSONResponse) VisitCreateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTaskSchedule400JSONResponse BadRequest
+
+func (response CreateTaskSchedule400JSONResponse) VisitCreateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTaskSchedule403JSONResponse Forbidden
+
+func (response CreateTaskSchedule403JSONResponse) VisitCreateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTaskSchedule404JSONResponse NotFound
+
+func (response CreateTaskSchedule404JSONResponse) VisitCreateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTaskSchedule409JSONResponse ResourceAlreadyExists
+
+func (response CreateTaskSchedule409JSONResponse) VisitCreateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTaskScheduleRequestObject struct {
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+}
+
+type DeleteTaskScheduleResponseObject interface {
+	VisitDeleteTaskScheduleResponse(w http.ResponseWriter) error
+}
+
+type DeleteTaskSchedule204Response struct {
+}
+
+func (response DeleteTaskSchedule204Response) VisitDeleteTaskScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteTaskSchedule404JSONResponse NotFound
+
+func (response DeleteTaskSchedule404JSONResponse) VisitDeleteTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskScheduleRequestObject struct {
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+}
+
+type GetTaskScheduleResponseObject interface {
+	VisitGetTaskScheduleResponse(w http.ResponseWriter) error
+}
+
+type GetTaskSchedule200JSONResponse TaskSchedule
+
+func (response GetTaskSchedule200JSONResponse) VisitGetTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskSchedule404JSONResponse NotFound
+
+func (response GetTaskSchedule404JSONResponse) VisitGetTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTaskScheduleRequestObject struct {
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+	Body       *UpdateTaskScheduleJSONRequestBody
+}
+
+type UpdateTaskScheduleResponseObject interface {
+	VisitUpdateTaskScheduleResponse(w http.ResponseWriter) error
+}
+
+type UpdateTaskSchedule200JSONResponse TaskSchedule
+
+func (response UpdateTaskSchedule200JSONResponse) VisitUpdateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTaskSchedule400JSONResponse BadRequest
+
+func (response UpdateTaskSchedule400JSONResponse) VisitUpdateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTaskSchedule403JSONResponse Forbidden
+
+func (response UpdateTaskSchedule403JSONResponse) VisitUpdateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTaskSchedule404JSONResponse NotFound
+
+func (response UpdateTaskSchedule404JSONResponse) VisitUpdateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTaskSchedule409JSONResponse ResourceAlreadyExists
+
+func (response UpdateTaskSchedule409JSONResponse) VisitUpdateTaskScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTaskRequestObject struct {
+	TaskId openapi_types.UUID `json:"task_id"`
+}
+
+type DeleteTaskResponseObject interface {
+	VisitDeleteTaskResponse(w http.ResponseWriter) error
+}
+
+type DeleteTask204Response struct {
+}
+
+func (response DeleteTask204Response) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteTask403JSONResponse Forbidden
+
+func (response DeleteTask403JSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTask404JSONResponse NotFound
+
+func (response DeleteTask404JSONResponse) VisitDeleteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskRequestObject struct {
+	TaskId openapi_types.UUID `json:"task_id"`
+}
+
+type GetTaskResponseObject interface {
+	VisitGetTaskResponse(w http.ResponseWriter) error
+}
+
+type GetTask200JSONResponse Task
+
+func (response GetTask200JSONResponse) VisitGetTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTask404JSONResponse NotFound
+
+func (response GetTask404JSONResponse) VisitGetTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTaskRequestObject struct {
+	TaskId openapi_types.UUID `json:"task_id"`
+	Body   *UpdateTaskJSONRequestBody
+}
+
+type UpdateTaskResponseObject interface {
+	VisitUpdateTaskResponse(w http.ResponseWriter) error
+}
+
+type UpdateTask200JSONResponse Task
+
+func (response UpdateTask200JSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTask403JSONResponse Forbidden
+
+func (response UpdateTask403JSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTask404JSONResponse NotFound
+
+func (response UpdateTask404JSONResponse) VisitUpdateTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteTaskRequestObject struct {
+	TaskId openapi_types.UUID `json:"task_id"`
+	Params ExecuteTaskParams
+	Body   *ExecuteTaskJSONRequestBody
+}
+
+type ExecuteTaskResponseObject interface {
+	VisitExecuteTaskResponse(w http.ResponseWriter) error
+}
+
+type ExecuteTask200ResponseHeaders struct {
+	CacheControl string
+	Connection   string
+}
+
+type ExecuteTask200JSONResponse struct {
+	Body    TaskRun
+	Headers ExecuteTask200ResponseHeaders
+}
+
+func (response ExecuteTask200JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ExecuteTask200TexteventStreamResponse struct {
+	Body          io.Reader
+	Headers       ExecuteTask200ResponseHeaders
+	ContentLength int64
+}
+
+func (response ExecuteTask200TexteventStreamResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExecuteTask202JSONResponse TaskRun
+
+func (response ExecuteTask202JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteTask400JSONResponse BadRequest
+
+func (response ExecuteTask400JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteTask403JSONResponse Forbidden
+
+func (response ExecuteTask403JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteTask404JSONResponse NotFound
+
+func (response ExecuteTask404JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteTask409JSONResponse ResourceAlreadyExists
+
+func (response ExecuteTask409JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecuteTask429JSONResponse QuotaExceeded
+
+func (response ExecuteTask429JSONResponse) VisitExecuteTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskRunsRequestObject struct {
+	TaskId openapi_types.UUID `json:"task_id"`
+}
+
+type ListTaskRunsResponseObject interface {
+	VisitListTaskRunsResponse(w http.ResponseWriter) error
+}
+
+type ListTaskRuns200JSONResponse []TaskRun
+
+func (response ListTaskRuns200JSONResponse) VisitListTaskRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskRuns404JSONResponse NotFound
+
+func (response ListTaskRuns404JSONResponse) VisitListTaskRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelTaskRunRequestObject struct {
+	TaskId openapi_types.UUID `json:"task_id"`
+	RunId  openapi_types.UUID `json:"run_id"`
+}
+
+type CancelTaskRunResponseObject interface {
+	VisitCancelTaskRunResponse(w http.ResponseWriter) error
+}
+
+type CancelTaskRun200JSONResponse TaskRun
+
+func (response CancelTaskRun200JSONResponse) VisitCancelTaskRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelTaskRun400JSONResponse BadRequest
+
+func (response CancelTaskRun400JSONResponse) VisitCancelTaskRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelTaskRun403JSONResponse Forbidden
+
+func (response CancelTaskRun403JSONResponse) VisitCancelTaskRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelTaskRun404JSONResponse NotFound
+
+func (response CancelTaskRun404JSONResponse) VisitCancelTaskRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamTaskRequestObject struct {
+	TaskId openapi_types.UUID `json:"task_id"`
+	Params StreamTaskParams
+}
+
+type StreamTaskResponseObject interface {
+	VisitStreamTaskResponse(w http.ResponseWriter) error
+}
+
+type StreamTask200ResponseHeaders struct {
+	CacheControl string
+	Connection   string
+}
+
+type StreamTask200TexteventStreamResponse struct {
+	Body          io.Reader
+	Headers       StreamTask200ResponseHeaders
+	ContentLength int64
+}
+
+func (response StreamTask200TexteventStreamResponse) VisitStreamTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type StreamTask400JSONResponse BadRequest
+
+func (response StreamTask400JSONResponse) VisitStreamTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamTask404JSONResponse NotFound
+
+func (response StreamTask404JSONResponse) VisitStreamTaskResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskRunRequestObject struct {
+	TaskRunId openapi_types.UUID `json:"task_run_id"`
+}
+
+type GetTaskRunResponseObject interface {
+	VisitGetTaskRunResponse(w http.ResponseWriter) error
+}
+
+type GetTaskRun200JSONResponse TaskRun
+
+func (response GetTaskRun200JSONResponse) VisitGetTaskRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskRun404JSONResponse NotFound
+
+func (response GetTaskRun404JSONResponse) VisitGetTaskRunResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListThreadsRequestObject struct {
+	Params ListThreadsParams
+}
+
+type ListThreadsResponseObject interface {
+	VisitListThreadsResponse(w http.ResponseWriter) error
+}
+
+type ListThreads200JSONResponse ThreadList
+
+func (response ListThreads200JSONResponse) VisitListThreadsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListThreads400JSONResponse BadRequest
+
+func (response ListThreads400JSONResponse) VisitListThreadsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateThreadRequestObject struct {
+	Body *CreateThreadJSONRequestBody
+}
+
+type CreateThreadResponseObject interface {
+	VisitCreateThreadResponse(w http.ResponseWriter) error
+}
+
+type CreateThread201JSONResponse Thread
+
+func (response CreateThread201JSONResponse) VisitCreateThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateThread400JSONResponse BadRequest
+
+func (response CreateThread400JSONResponse) VisitCreateThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportThreadRequestObject struct {
+	Params ImportThreadParams
+	Body   *ImportThreadJSONRequestBody
+}
+
+type ImportThreadResponseObject interface {
+	VisitImportThreadResponse(w http.ResponseWriter) error
+}
+
+type ImportThread201JSONResponse Thread
+
+func (response ImportThread201JSONResponse) VisitImportThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportThread400JSONResponse BadRequest
+
+func (response ImportThread400JSONResponse) VisitImportThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteThreadRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+}
+
+type DeleteThreadResponseObject interface {
+	VisitDeleteThreadResponse(w http.ResponseWriter) error
+}
+
+type DeleteThread204Response struct {
+}
+
+func (response DeleteThread204Response) VisitDeleteThreadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteThread404JSONResponse NotFound
+
+func (response DeleteThread404JSONResponse) VisitDeleteThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetThreadRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+}
+
+type GetThreadResponseObject interface {
+	VisitGetThreadResponse(w http.ResponseWriter) error
+}
+
+type GetThread200ResponseHeaders struct {
+	ETag string
+}
+
+type GetThread200JSONResponse struct {
+	Body    Thread
+	Headers GetThread200ResponseHeaders
+}
+
+func (response GetThread200JSONResponse) VisitGetThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetThread404JSONResponse NotFound
+
+func (response GetThread404JSONResponse) VisitGetThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateThreadTitleRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Params   UpdateThreadTitleParams
+	Body     *UpdateThreadTitleJSONRequestBody
+}
+
+type UpdateThreadTitleResponseObject interface {
+	VisitUpdateThreadTitleResponse(w http.ResponseWriter) error
+}
+
+type UpdateThreadTitle200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdateThreadTitle200JSONResponse struct {
+	Body    Thread
+	Headers UpdateThreadTitle200ResponseHeaders
+}
+
+func (response UpdateThreadTitle200JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateThreadTitle400JSONResponse BadRequest
+
+func (response UpdateThreadTitle400JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateThreadTitle403JSONResponse Forbidden
+
+func (response UpdateThreadTitle403JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateThreadTitle404JSONResponse NotFound
+
+func (response UpdateThreadTitle404JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateThreadTitle412JSONResponse PreconditionFailed
+
+func (response UpdateThreadTitle412JSONResponse) VisitUpdateThreadTitleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamThreadEventsRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Params   StreamThreadEventsParams
+}
+
+type StreamThreadEventsResponseObject interface {
+	VisitStreamThreadEventsResponse(w http.ResponseWriter) error
+}
+
+type StreamThreadEvents200ResponseHeaders struct {
+	CacheControl string
+	Connection   string
+}
+
+type StreamThreadEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	Headers       StreamThreadEvents200ResponseHeaders
+	ContentLength int64
+}
+
+func (response StreamThreadEvents200TexteventStreamResponse) VisitStreamThreadEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Connection", fmt.Sprint(response.Headers.Connection))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type StreamThreadEvents400JSONResponse BadRequest
+
+func (response StreamThreadEvents400JSONResponse) VisitStreamThreadEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StreamThreadEvents404JSONResponse NotFound
+
+func (response StreamThreadEvents404JSONResponse) VisitStreamThreadEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportThreadRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Params   ExportThreadParams
+}
+
+type ExportThreadResponseObject interface {
+	VisitExportThreadResponse(w http.ResponseWriter) error
+}
+
+type ExportThread200JSONResponse ThreadExport
+
+func (response ExportThread200JSONResponse) VisitExportThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportThread200TextmarkdownResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportThread200TextmarkdownResponse) VisitExportThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/markdown")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportThread404JSONResponse NotFound
+
+func (response ExportThread404JSONResponse) VisitExportThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForkThreadRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Params   ForkThreadParams
+}
+
+type ForkThreadResponseObject interface {
+	VisitForkThreadResponse(w http.ResponseWriter) error
+}
+
+type ForkThread201JSONResponse Thread
+
+func (response ForkThread201JSONResponse) VisitForkThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForkThread400JSONResponse BadRequest
+
+func (response ForkThread400JSONResponse) VisitForkThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForkThread404JSONResponse NotFound
+
+func (response ForkThread404JSONResponse) VisitForkThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListThreadGrantsRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+}
+
+type ListThreadGrantsResponseObject interface {
+	VisitListThreadGrantsResponse(w http.ResponseWriter) error
+}
+
+type ListThreadGrants200JSONResponse ResourceGrantList
+
+func (response ListThreadGrants200JSONResponse) VisitListThreadGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListThreadGrants403JSONResponse Forbidden
+
+func (response ListThreadGrants403JSONResponse) VisitListThreadGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListThreadGrants404JSONResponse NotFound
+
+func (response ListThreadGrants404JSONResponse) VisitListThreadGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetThreadGrantRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Body     *SetThreadGrantJSONRequestBody
+}
+
+type SetThreadGrantResponseObject interface {
+	VisitSetThreadGrantResponse(w http.ResponseWriter) error
+}
+
+type SetThreadGrant200JSONResponse ResourceGrant
+
+func (response SetThreadGrant200JSONResponse) VisitSetThreadGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetThreadGrant400JSONResponse BadRequest
+
+func (response SetThreadGrant400JSONResponse) VisitSetThreadGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetThreadGrant403JSONResponse Forbidden
+
+func (response SetThreadGrant403JSONResponse) VisitSetThreadGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetThreadGrant404JSONResponse NotFound
+
+func (response SetThreadGrant404JSONResponse) VisitSetThreadGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveThreadGrantRequestObject struct {
+	ThreadId    openapi_types.UUID `json:"thread_id"`
+	GranteeType GranteeType        `json:"grantee_type"`
+	GranteeId   openapi_types.UUID `json:"grantee_id"`
+}
+
+type RemoveThreadGrantResponseObject interface {
+	VisitRemoveThreadGrantResponse(w http.ResponseWriter) error
+}
+
+type RemoveThreadGrant204Response struct {
+}
+
+func (response RemoveThreadGrant204Response) VisitRemoveThreadGrantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveThreadGrant403JSONResponse Forbidden
+
+func (response RemoveThreadGrant403JSONResponse) VisitRemoveThreadGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveThreadGrant404JSONResponse NotFound
+
+func (response RemoveThreadGrant404JSONResponse) VisitRemoveThreadGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessagesRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Params   ListMessagesParams
+}
+
+type ListMessagesResponseObject interface {
+	VisitListMessagesResponse(w http.ResponseWriter) error
+}
+
+type ListMessages200JSONResponse MessageList
+
+func (response ListMessages200JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessages404JSONResponse NotFound
+
+func (response ListMessages404JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateMessageRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Body     *CreateMessageJSONRequestBody
+}
+
+type CreateMessageResponseObject interface {
+	VisitCreateMessageResponse(w http.ResponseWriter) error
+}
+
+type CreateMessage201JSONResponse Message
+
+func (response CreateMessage201JSONResponse) VisitCreateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateMessage400JSONResponse BadRequest
+
+func (response CreateMessage400JSONResponse) VisitCreateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateMessage403JSONResponse Forbidden
+
+func (response CreateMessage403JSONResponse) VisitCreateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateMessage404JSONResponse NotFound
+
+func (response CreateMessage404JSONResponse) VisitCreateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMessagesRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+	Body     *ImportMessagesJSONRequestBody
+}
+
+type ImportMessagesResponseObject interface {
+	VisitImportMessagesResponse(w http.ResponseWriter) error
+}
+
+type ImportMessages200JSONResponse BatchResult
+
+func (response ImportMessages200JSONResponse) VisitImportMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMessages400JSONResponse BadRequest
+
+func (response ImportMessages400JSONResponse) VisitImportMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMessages403JSONResponse Forbidden
+
+func (response ImportMessages403JSONResponse) VisitImportMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMessages404JSONResponse NotFound
+
+func (response ImportMessages404JSONResponse) VisitImportMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteMessageRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+	Params    DeleteMessageParams
+}
+
+type DeleteMessageResponseObject interface {
+	VisitDeleteMessageResponse(w http.ResponseWriter) error
+}
+
+type DeleteMessage204Response struct {
+}
+
+func (response DeleteMessage204Response) VisitDeleteMessageResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteMessage403JSONResponse Forbidden
+
+func (response DeleteMessage403JSONResponse) VisitDeleteMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteMessage404JSONResponse NotFound
+
+func (response DeleteMessage404JSONResponse) VisitDeleteMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteMessage409JSONResponse Conflict
+
+func (response DeleteMessage409JSONResponse) VisitDeleteMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMessageRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+}
+
+type GetMessageResponseObject interface {
+	VisitGetMessageResponse(w http.ResponseWriter) error
+}
+
+type GetMessage200JSONResponse Message
+
+func (response GetMessage200JSONResponse) VisitGetMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMessage404JSONResponse NotFound
+
+func (response GetMessage404JSONResponse) VisitGetMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMessageRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+	Body      *UpdateMessageJSONRequestBody
+}
+
+type UpdateMessageResponseObject interface {
+	VisitUpdateMessageResponse(w http.ResponseWriter) error
+}
+
+type UpdateMessage200JSONResponse Message
+
+func (response UpdateMessage200JSONResponse) VisitUpdateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMessage400JSONResponse BadRequest
+
+func (response UpdateMessage400JSONResponse) VisitUpdateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMessage403JSONResponse Forbidden
+
+func (response UpdateMessage403JSONResponse) VisitUpdateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMessage404JSONResponse NotFound
+
+func (response UpdateMessage404JSONResponse) VisitUpdateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessageAttachmentsRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+}
+
+type ListMessageAttachmentsResponseObject interface {
+	VisitListMessageAttachmentsResponse(w http.ResponseWriter) error
+}
+
+type ListMessageAttachments200JSONResponse AttachmentList
+
+func (response ListMessageAttachments200JSONResponse) VisitListMessageAttachmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessageAttachments404JSONResponse NotFound
+
+func (response ListMessageAttachments404JSONResponse) VisitListMessageAttachmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EditMessageRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+	Body      *EditMessageJSONRequestBody
+}
+
+type EditMessageResponseObject interface {
+	VisitEditMessageResponse(w http.ResponseWriter) error
+}
+
+type EditMessage200JSONResponse MessageRevision
+
+func (response EditMessage200JSONResponse) VisitEditMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EditMessage400JSONResponse BadRequest
+
+func (response EditMessage400JSONResponse) VisitEditMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EditMessage403JSONResponse Forbidden
+
+func (response EditMessage403JSONResponse) VisitEditMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EditMessage404JSONResponse NotFound
+
+func (response EditMessage404JSONResponse) VisitEditMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EditMessage409JSONResponse Conflict
+
+func (response EditMessage409JSONResponse) VisitEditMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EditMessage429JSONResponse QuotaExceeded
+
+func (response EditMessage429JSONResponse) VisitEditMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteMessageFeedbackRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+}
+
+type DeleteMessageFeedbackResponseObject interface {
+	VisitDeleteMessageFeedbackResponse(w http.ResponseWriter) error
+}
+
+type DeleteMessageFeedback204Response struct {
+}
+
+func (response DeleteMessageFeedback204Response) VisitDeleteMessageFeedbackResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteMessageFeedback404JSONResponse NotFound
+
+func (response DeleteMessageFeedback404JSONResponse) VisitDeleteMessageFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMessageFeedbackRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+	Body      *SetMessageFeedbackJSONRequestBody
+}
+
+type SetMessageFeedbackResponseObject interface {
+	VisitSetMessageFeedbackResponse(w http.ResponseWriter) error
+}
+
+type SetMessageFeedback200JSONResponse Feedback
+
+func (response SetMessageFeedback200JSONResponse) VisitSetMessageFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMessageFeedback400JSONResponse BadRequest
+
+func (response SetMessageFeedback400JSONResponse) VisitSetMessageFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMessageFeedback404JSONResponse NotFound
+
+func (response SetMessageFeedback404JSONResponse) VisitSetMessageFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegenerateMessageRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	MessageId openapi_types.UUID `json:"message_id"`
+	Body      *RegenerateMessageJSONRequestBody
+}
+
+type RegenerateMessageResponseObject interface {
+	VisitRegenerateMessageResponse(w http.ResponseWriter) error
+}
+
+type RegenerateMessage201JSONResponse MessageRevision
+
+func (response RegenerateMessage201JSONResponse) VisitRegenerateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegenerateMessage400JSONResponse BadRequest
+
+func (response RegenerateMessage400JSONResponse) VisitRegenerateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegenerateMessage403JSONResponse Forbidden
+
+func (response RegenerateMessage403JSONResponse) VisitRegenerateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegenerateMessage404JSONResponse NotFound
+
+func (response RegenerateMessage404JSONResponse) VisitRegenerateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegenerateMessage409JSONResponse Conflict
+
+func (response RegenerateMessage409JSONResponse) VisitRegenerateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegenerateMessage429JSONResponse QuotaExceeded
+
+func (response RegenerateMessage429JSONResponse) VisitRegenerateMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeThreadRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+}
+
+type PurgeThreadResponseObject interface {
+	VisitPurgeThreadResponse(w http.ResponseWriter) error
+}
+
+type PurgeThread204Response struct {
+}
+
+func (response PurgeThread204Response) VisitPurgeThreadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PurgeThread404JSONResponse NotFound
+
+func (response PurgeThread404JSONResponse) VisitPurgeThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreThreadRequestObject struct {
+	ThreadId openapi_types.UUID `json:"thread_id"`
+}
+
+type RestoreThreadResponseObject interface {
+	VisitRestoreThreadResponse(w http.ResponseWriter) error
+}
+
+type RestoreThread200JSONResponse Thread
+
+func (response RestoreThread200JSONResponse) VisitRestoreThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreThread404JSONResponse NotFound
+
+func (response RestoreThread404JSONResponse) VisitRestoreThreadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteToolRunFeedbackRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	ToolRunId string             `json:"tool_run_id"`
+}
+
+type DeleteToolRunFeedbackResponseObject interface {
+	VisitDeleteToolRunFeedbackResponse(w http.ResponseWriter) error
+}
+
+type DeleteToolRunFeedback204Response struct {
+}
+
+func (response DeleteToolRunFeedback204Response) VisitDeleteToolRunFeedbackResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteToolRunFeedback404JSONResponse NotFound
+
+func (response DeleteToolRunFeedback404JSONResponse) VisitDeleteToolRunFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetToolRunFeedbackRequestObject struct {
+	ThreadId  openapi_types.UUID `json:"thread_id"`
+	ToolRunId string             `json:"tool_run_id"`
+	Body      *SetToolRunFeedbackJSONRequestBody
+}
+
+type SetToolRunFeedbackResponseObject interface {
+	VisitSetToolRunFeedbackResponse(w http.ResponseWriter) error
+}
+
+type SetToolRunFeedback200JSONResponse Feedback
+
+func (response SetToolRunFeedback200JSONResponse) VisitSetToolRunFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetToolRunFeedback400JSONResponse BadRequest
+
+func (response SetToolRunFeedback400JSONResponse) VisitSetToolRunFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetToolRunFeedback404JSONResponse NotFound
+
+func (response SetToolRunFeedback404JSONResponse) VisitSetToolRunFeedbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListToolsRequestObject struct {
+	Params ListToolsParams
+}
+
+type ListToolsResponseObject interface {
+	VisitListToolsResponse(w http.ResponseWriter) error
+}
+
+type ListTools200JSONResponse ToolList
+
+func (response ListTools200JSONResponse) VisitListToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTools400JSONResponse BadRequest
+
+func (response ListTools400JSONResponse) VisitListToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateToolRequestObject struct {
+	Params CreateToolParams
+	Body   *CreateToolJSONRequestBody
+}
+
+type CreateToolResponseObject interface {
+	VisitCreateToolResponse(w http.ResponseWriter) error
+}
+
+type CreateTool201JSONResponse Tool
+
+func (response CreateTool201JSONResponse) VisitCreateToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTool400JSONResponse BadRequest
+
+func (response CreateTool400JSONResponse) VisitCreateToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreateToolsRequestObject struct {
+	Body *BatchCreateToolsJSONRequestBody
+}
+
+type BatchCreateToolsResponseObject interface {
+	VisitBatchCreateToolsResponse(w http.ResponseWriter) error
+}
+
+type BatchCreateTools200JSONResponse BatchResult
+
+func (response BatchCreateTools200JSONResponse) VisitBatchCreateToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchCreateTools400JSONResponse BadRequest
+
+func (response BatchCreateTools400JSONResponse) VisitBatchCreateToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchDeleteToolsRequestObject struct {
+	Body *BatchDeleteToolsJSONRequestBody
+}
+
+type BatchDeleteToolsResponseObject interface {
+	VisitBatchDeleteToolsResponse(w http.ResponseWriter) error
+}
+
+type BatchDeleteTools200JSONResponse BatchResult
+
+func (response BatchDeleteTools200JSONResponse) VisitBatchDeleteToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchDeleteTools400JSONResponse BadRequest
+
+func (response BatchDeleteTools400JSONResponse) VisitBatchDeleteToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdateToolsRequestObject struct {
+	Body *BatchUpdateToolsJSONRequestBody
+}
+
+type BatchUpdateToolsResponseObject interface {
+	VisitBatchUpdateToolsResponse(w http.ResponseWriter) error
+}
+
+type BatchUpdateTools200JSONResponse BatchResult
+
+func (response BatchUpdateTools200JSONResponse) VisitBatchUpdateToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchUpdateTools400JSONResponse BadRequest
+
+func (response BatchUpdateTools400JSONResponse) VisitBatchUpdateToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetToolCatalogRequestObject struct {
+	Params GetToolCatalogParams
+}
+
+type GetToolCatalogResponseObject interface {
+	VisitGetToolCatalogResponse(w http.ResponseWriter) error
+}
+
+type GetToolCatalog200JSONResponse ToolCatalog
+
+func (response GetToolCatalog200JSONResponse) VisitGetToolCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateToolFromDefinitionRequestObject struct {
+	Body *CreateToolFromDefinitionJSONRequestBody
+}
+
+type CreateToolFromDefinitionResponseObject interface {
+	VisitCreateToolFromDefinitionResponse(w http.ResponseWriter) error
+}
+
+type CreateToolFromDefinition201JSONResponse Tool
+
+func (response CreateToolFromDefinition201JSONResponse) VisitCreateToolFromDefinitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateToolFromDefinition400JSONResponse BadRequest
+
+func (response CreateToolFromDefinition400JSONResponse) VisitCreateToolFromDefinitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportToolsRequestObject struct {
+	Params ExportToolsParams
+}
+
+type ExportToolsResponseObject interface {
+	VisitExportToolsResponse(w http.ResponseWriter) error
+}
+
+type ExportTools200JSONResponse ToolBundle
+
+func (response ExportTools200JSONResponse) VisitExportToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTools200ApplicationyamlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportTools200ApplicationyamlResponse) VisitExportToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/yaml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportTools404JSONResponse NotFound
+
+func (response ExportTools404JSONResponse) VisitExportToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportToolsRequestObject struct {
+	Params ImportToolsParams
+	Body   *ImportToolsJSONRequestBody
+}
+
+type ImportToolsResponseObject interface {
+	VisitImportToolsResponse(w http.ResponseWriter) error
+}
+
+type ImportTools200JSONResponse ToolBundleImportResponse
+
+func (response ImportTools200JSONResponse) VisitImportToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportTools400JSONResponse BadRequest
+
+func (response ImportTools400JSONResponse) VisitImportToolsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteToolRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+}
+
+type DeleteToolResponseObject interface {
+	VisitDeleteToolResponse(w http.ResponseWriter) error
+}
+
+type DeleteTool204Response struct {
+}
+
+func (response DeleteTool204Response) VisitDeleteToolResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteTool403JSONResponse Forbidden
+
+func (response DeleteTool403JSONResponse) VisitDeleteToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTool404JSONResponse NotFound
+
+func (response DeleteTool404JSONResponse) VisitDeleteToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetToolByIdRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+}
+
+type GetToolByIdResponseObject interface {
+	VisitGetToolByIdResponse(w http.ResponseWriter) error
+}
+
+type GetToolById200ResponseHeaders struct {
+	ETag string
+}
+
+type GetToolById200JSONResponse struct {
+	Body    Tool
+	Headers GetToolById200ResponseHeaders
+}
+
+func (response GetToolById200JSONResponse) VisitGetToolByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetToolById404JSONResponse NotFound
+
+func (response GetToolById404JSONResponse) VisitGetToolByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateToolRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+	Params UpdateToolParams
+	Body   *UpdateToolJSONRequestBody
+}
+
+type UpdateToolResponseObject interface {
+	VisitUpdateToolResponse(w http.ResponseWriter) error
+}
+
+type UpdateTool200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdateTool200JSONResponse struct {
+	Body    Tool
+	Headers UpdateTool200ResponseHeaders
+}
+
+func (response UpdateTool200JSONResponse) VisitUpdateToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateTool400JSONResponse BadRequest
+
+func (response UpdateTool400JSONResponse) VisitUpdateToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTool403JSONResponse Forbidden
+
+func (response UpdateTool403JSONResponse) VisitUpdateToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTool404JSONResponse NotFound
+
+func (response UpdateTool404JSONResponse) VisitUpdateToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTool412JSONResponse PreconditionFailed
+
+func (response UpdateTool412JSONResponse) VisitUpdateToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListToolGrantsRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+}
+
+type ListToolGrantsResponseObject interface {
+	VisitListToolGrantsResponse(w http.ResponseWriter) error
+}
+
+type ListToolGrants200JSONResponse ResourceGrantList
+
+func (response ListToolGrants200JSONResponse) VisitListToolGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListToolGrants403JSONResponse Forbidden
+
+func (response ListToolGrants403JSONResponse) VisitListToolGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListToolGrants404JSONResponse NotFound
+
+func (response ListToolGrants404JSONResponse) VisitListToolGrantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetToolGrantRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+	Body   *SetToolGrantJSONRequestBody
+}
+
+type SetToolGrantResponseObject interface {
+	VisitSetToolGrantResponse(w http.ResponseWriter) error
+}
+
+type SetToolGrant200JSONResponse ResourceGrant
+
+func (response SetToolGrant200JSONResponse) VisitSetToolGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetToolGrant400JSONResponse BadRequest
+
+func (response SetToolGrant400JSONResponse) VisitSetToolGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetToolGrant403JSONResponse Forbidden
+
+func (response SetToolGrant403JSONResponse) VisitSetToolGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetToolGrant404JSONResponse NotFound
+
+func (response SetToolGrant404JSONResponse) VisitSetToolGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveToolGrantRequestObject struct {
+	ToolId      openapi_types.UUID `json:"tool_id"`
+	GranteeType GranteeType        `json:"grantee_type"`
+	GranteeId   openapi_types.UUID `json:"grantee_id"`
+}
+
+type RemoveToolGrantResponseObject interface {
+	VisitRemoveToolGrantResponse(w http.ResponseWriter) error
+}
+
+type RemoveToolGrant204Response struct {
+}
+
+func (response RemoveToolGrant204Response) VisitRemoveToolGrantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveToolGrant403JSONResponse Forbidden
+
+func (response RemoveToolGrant403JSONResponse) VisitRemoveToolGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveToolGrant404JSONResponse NotFound
+
+func (response RemoveToolGrant404JSONResponse) VisitRemoveToolGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetToolHistoryRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+	Params GetToolHistoryParams
+}
+
+type GetToolHistoryResponseObject interface {
+	VisitGetToolHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetToolHistory200JSONResponse ResourceHistory
+
+func (response GetToolHistory200JSONResponse) VisitGetToolHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetToolHistory404JSONResponse NotFound
+
+func (response GetToolHistory404JSONResponse) VisitGetToolHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeToolRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+}
+
+type PurgeToolResponseObject interface {
+	VisitPurgeToolResponse(w http.ResponseWriter) error
+}
+
+type PurgeTool204Response struct {
+}
+
+func (response PurgeTool204Response) VisitPurgeToolResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PurgeTool403JSONResponse Forbidden
+
+func (response PurgeTool403JSONResponse) VisitPurgeToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeTool404JSONResponse NotFound
+
+func (response PurgeTool404JSONResponse) VisitPurgeToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreToolRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+}
+
+type RestoreToolResponseObject interface {
+	VisitRestoreToolResponse(w http.ResponseWriter) error
+}
+
+type RestoreTool200JSONResponse Tool
+
+func (response RestoreTool200JSONResponse) VisitRestoreToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreTool403JSONResponse Forbidden
+
+func (response RestoreTool403JSONResponse) VisitRestoreToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreTool404JSONResponse NotFound
+
+func (response RestoreTool404JSONResponse) VisitRestoreToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreTool409JSONResponse ResourceAlreadyExists
+
+func (response RestoreTool409JSONResponse) VisitRestoreToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListToolRevisionsRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+}
+
+type ListToolRevisionsResponseObject interface {
+	VisitListToolRevisionsResponse(w http.ResponseWriter) error
+}
+
+type ListToolRevisions200JSONResponse ToolRevisionList
+
+func (response ListToolRevisions200JSONResponse) VisitListToolRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListToolRevisions404JSONResponse NotFound
+
+func (response ListToolRevisions404JSONResponse) VisitListToolRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffToolRevisionsRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+	Params DiffToolRevisionsParams
+}
+
+type DiffToolRevisionsResponseObject interface {
+	VisitDiffToolRevisionsResponse(w http.ResponseWriter) error
+}
+
+type DiffToolRevisions200JSONResponse ToolSchemaDiff
+
+func (response DiffToolRevisions200JSONResponse) VisitDiffToolRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffToolRevisions404JSONResponse NotFound
+
+func (response DiffToolRevisions404JSONResponse) VisitDiffToolRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteToolRevisionRequestObject struct {
+	ToolId   openapi_types.UUID `json:"tool_id"`
+	Revision int32              `json:"revision"`
+}
+
+type PromoteToolRevisionResponseObject interface {
+	VisitPromoteToolRevisionResponse(w http.ResponseWriter) error
+}
+
+type PromoteToolRevision200JSONResponse Tool
+
+func (response PromoteToolRevision200JSONResponse) VisitPromoteToolRevisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteToolRevision403JSONResponse Forbidden
+
+func (response PromoteToolRevision403JSONResponse) VisitPromoteToolRevisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteToolRevision404JSONResponse NotFound
+
+func (response PromoteToolRevision404JSONResponse) VisitPromoteToolRevisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestToolRequestObject struct {
+	ToolId openapi_types.UUID `json:"tool_id"`
+	Body   *TestToolJSONRequestBody
+}
+
+type TestToolResponseObject interface {
+	VisitTestToolResponse(w http.ResponseWriter) error
+}
+
+type TestTool200JSONResponse TestToolResponse
+
+func (response TestTool200JSONResponse) VisitTestToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestTool403JSONResponse Forbidden
+
+func (response TestTool403JSONResponse) VisitTestToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TestTool404JSONResponse NotFound
+
+func (response TestTool404JSONResponse) VisitTestToolResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsageRequestObject struct {
+}
+
+type GetUsageResponseObject interface {
+	VisitGetUsageResponse(w http.ResponseWriter) error
+}
+
+type GetUsage200JSONResponse UsageReport
+
+func (response GetUsage200JSONResponse) VisitGetUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsersRequestObject struct {
+}
+
+type ListUsersResponseObject interface {
+	VisitListUsersResponse(w http.ResponseWriter) error
+}
+
+type ListUsers200JSONResponse UserList
+
+func (response ListUsers200JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserRequestObject struct {
+	Body *CreateUserJSONRequestBody
+}
+
+type CreateUserResponseObject interface {
+	VisitCreateUserResponse(w http.ResponseWriter) error
+}
+
+type CreateUser201JSONResponse User
+
+func (response CreateUser201JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser400JSONResponse BadRequest
+
+func (response CreateUser400JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser409JSONResponse ResourceAlreadyExists
+
+func (response CreateUser409JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+type DeleteUserResponseObject interface {
+	VisitDeleteUserResponse(w http.ResponseWriter) error
+}
+
+type DeleteUser204Response struct {
+}
+
+func (response DeleteUser204Response) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteUser404JSONResponse NotFound
+
+func (response DeleteUser404JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+type GetUserResponseObject interface {
+	VisitGetUserResponse(w http.ResponseWriter) error
+}
+
+type GetUser200JSONResponse User
+
+func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser404JSONResponse NotFound
+
+func (response GetUser404JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+	Body   *UpdateUserJSONRequestBody
+}
+
+type UpdateUserResponseObject interface {
+	VisitUpdateUserResponse(w http.ResponseWriter) error
+}
+
+type UpdateUser200JSONResponse User
+
+func (response UpdateUser200JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUser400JSONResponse BadRequest
+
+func (response UpdateUser400JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUser404JSONResponse NotFound
+
+func (response UpdateUser404JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUser409JSONResponse ResourceAlreadyExists
+
+func (response UpdateUser409JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserApiKeysRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+type ListUserApiKeysResponseObject interface {
+	VisitListUserApiKeysResponse(w http.ResponseWriter) error
+}
+
+type ListUserApiKeys200JSONResponse ApiKeyList
+
+func (response ListUserApiKeys200JSONResponse) VisitListUserApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserApiKeys404JSONResponse NotFound
+
+func (response ListUserApiKeys404JSONResponse) VisitListUserApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserApiKeyRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+	Body   *CreateUserApiKeyJSONRequestBody
+}
+
+type CreateUserApiKeyResponseObject interface {
+	VisitCreateUserApiKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateUserApiKey201JSONResponse CreatedApiKey
+
+func (response CreateUserApiKey201JSONResponse) VisitCreateUserApiKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserApiKey400JSONResponse BadRequest
+
+func (response CreateUserApiKey400JSONResponse) VisitCreateUserApiKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserApiKey403JSONResponse Forbidden
+
+func (response CreateUserApiKey403JSONResponse) VisitCreateUserApiKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserApiKey404JSONResponse NotFound
+
+func (response CreateUserApiKey404JSONResponse) VisitCreateUserApiKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserApiKeyRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+	KeyId  openapi_types.UUID `json:"key_id"`
+}
+
+type RevokeUserApiKeyResponseObject interface {
+	VisitRevokeUserApiKeyResponse(w http.ResponseWriter) error
+}
+
+type RevokeUserApiKey200JSONResponse ApiKey
+
+func (response RevokeUserApiKey200JSONResponse) VisitRevokeUserApiKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserApiKey400JSONResponse BadRequest
+
+func (response RevokeUserApiKey400JSONResponse) VisitRevokeUserApiKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeUserApiKey404JSONResponse NotFound
+
+func (response RevokeUserApiKey404JSONResponse) VisitRevokeUserApiKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserDefaultAgentRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+	Body   *SetUserDefaultAgentJSONRequestBody
+}
+
+type SetUserDefaultAgentResponseObject interface {
+	VisitSetUserDefaultAgentResponse(w http.ResponseWriter) error
+}
+
+type SetUserDefaultAgent200JSONResponse UserDefaultAgent
+
+func (response SetUserDefaultAgent200JSONResponse) VisitSetUserDefaultAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserDefaultAgent404JSONResponse NotFound
+
+func (response SetUserDefaultAgent404JSONResponse) VisitSetUserDefaultAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRoleForUserRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+type ListRoleForUserResponseObject interface {
+	VisitListRoleForUserResponse(w http.ResponseWriter) error
+}
+
+type ListRoleForUser200JSONResponse UserRoleMappingList
+
+func (response ListRoleForUser200JSONResponse) VisitListRoleForUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRoleForUser404JSONResponse NotFound
+
+func (response ListRoleForUser404JSONResponse) VisitListRoleForUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddRoleToUserRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+	Body   *AddRoleToUserJSONRequestBody
+}
+
+type AddRoleToUserResponseObject interface {
+	VisitAddRoleToUserResponse(w http.ResponseWriter) error
+}
+
+type AddRoleToUser201JSONResponse UserRoleMapping
+
+func (response AddRoleToUser201JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddRoleToUser400JSONResponse BadRequest
+
+func (response AddRoleToUser400JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddRoleToUser404JSONResponse NotFound
+
+func (response AddRoleToUser404JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddRoleToUser409JSONResponse ResourceAlreadyExists
+
+func (response AddRoleToUser409JSONResponse) VisitAddRoleToUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveRoleFromUserRequestObject struct {
+	UserId openapi_types.UUID `json:"user_id"`
+	RoleId openapi_types.UUID `json:"role_id"`
+}
+
+type RemoveRoleFromUserResponseObject interface {
+	VisitRemoveRoleFromUserResponse(w http.ResponseWriter) error
+}
+
+type RemoveRoleFromUser204Response struct {
+}
+
+func (response RemoveRoleFromUser204Response) VisitRemoveRoleFromUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveRoleFromUser404JSONResponse NotFound
+
+func (response RemoveRoleFromUser404JSONResponse) VisitRemoveRoleFromUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhooksRequestObject struct {
+}
+
+type ListWebhooksResponseObject interface {
+	VisitListWebhooksResponse(w http.ResponseWriter) error
+}
+
+type ListWebhooks200JSONResponse WebhookList
+
+func (response ListWebhooks200JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhookRequestObject struct {
+	Body *CreateWebhookJSONRequestBody
+}
+
+type CreateWebhookResponseObject interface {
+	VisitCreateWebhookResponse(w http.ResponseWriter) error
+}
+
+type CreateWebhook201JSONResponse CreatedWebhook
+
+func (response CreateWebhook201JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhook400JSONResponse BadRequest
+
+func (response CreateWebhook400JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhookRequestObject struct {
+	WebhookId openapi_types.UUID `json:"webhook_id"`
+}
+
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(w http.ResponseWriter) error
+}
+
+type DeleteWebhook204Response struct {
+}
+
+func (response DeleteWebhook204Response) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteWebhook404JSONResponse NotFound
+
+func (response DeleteWebhook404JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhookRequestObject struct {
+	WebhookId openapi_types.UUID `json:"webhook_id"`
+}
+
+type GetWebhookResponseObject interface {
+	VisitGetWebhookResponse(w http.ResponseWriter) error
+}
+
+type GetWebhook200JSONResponse Webhook
+
+func (response GetWebhook200JSONResponse) VisitGetWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhook404JSONResponse NotFound
+
+func (response GetWebhook404JSONResponse) VisitGetWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhookRequestObject struct {
+	WebhookId openapi_types.UUID `json:"webhook_id"`
+	Body      *UpdateWebhookJSONRequestBody
+}
+
+type UpdateWebhookResponseObject interface {
+	VisitUpdateWebhookResponse(w http.ResponseWriter) error
+}
+
+type UpdateWebhook200JSONResponse Webhook
+
+func (response UpdateWebhook200JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhook400JSONResponse BadRequest
+
+func (response UpdateWebhook400JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWebhook404JSONResponse NotFound
+
+func (response UpdateWebhook404JSONResponse) VisitUpdateWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveriesRequestObject struct {
+	WebhookId openapi_types.UUID `json:"webhook_id"`
+	Params    ListWebhookDeliveriesParams
+}
+
+type ListWebhookDeliveriesResponseObject interface {
+	VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookDeliveries200JSONResponse WebhookDeliveryList
+
+func (response ListWebhookDeliveries200JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries400JSONResponse BadRequest
+
+func (response ListWebhookDeliveries400JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeliveries404JSONResponse NotFound
+
+func (response ListWebhookDeliveries404JSONResponse) VisitListWebhookDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDeliveryRequestObject struct {
+	WebhookId  openapi_types.UUID `json:"webhook_id"`
+	DeliveryId openapi_types.UUID `json:"delivery_id"`
+}
+
+type RedeliverWebhookDeliveryResponseObject interface {
+	VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error
+}
+
+type RedeliverWebhookDelivery200JSONResponse WebhookDelivery
+
+func (response RedeliverWebhookDelivery200JSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDelivery400JSONResponse BadRequest
+
+func (response RedeliverWebhookDelivery400JSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeliverWebhookDelivery404JSONResponse NotFound
+
+func (response RedeliverWebhookDelivery404JSONResponse) VisitRedeliverWebhookDeliveryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateWebhookSecretRequestObject struct {
+	WebhookId openapi_types.UUID `json:"webhook_id"`
+}
+
+type RotateWebhookSecretResponseObject interface {
+	VisitRotateWebhookSecretResponse(w http.ResponseWriter) error
+}
+
+type RotateWebhookSecret200JSONResponse CreatedWebhook
+
+func (response RotateWebhookSecret200JSONResponse) VisitRotateWebhookSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateWebhookSecret404JSONResponse NotFound
+
+func (response RotateWebhookSecret404JSONResponse) VisitRotateWebhookSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWorkspaceMembersRequestObject struct {
+	WorkspaceId openapi_types.UUID `json:"workspace_id"`
+}
+
+type ListWorkspaceMembersResponseObject interface {
+	VisitListWorkspaceMembersResponse(w http.ResponseWriter) error
+}
+
+type ListWorkspaceMembers200JSONResponse WorkspaceMemberList
+
+func (response ListWorkspaceMembers200JSONResponse) VisitListWorkspaceMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWorkspaceMembers404JSONResponse NotFound
+
+func (response ListWorkspaceMembers404JSONResponse) VisitListWorkspaceMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveWorkspaceMemberRequestObject struct {
+	WorkspaceId openapi_types.UUID `json:"workspace_id"`
+	UserId      openapi_types.UUID `json:"user_id"`
+}
+
+type RemoveWorkspaceMemberResponseObject interface {
+	VisitRemoveWorkspaceMemberResponse(w http.ResponseWriter) error
+}
+
+type RemoveWorkspaceMember204Response struct {
+}
+
+func (response RemoveWorkspaceMember204Response) VisitRemoveWorkspaceMemberResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveWorkspaceMember403JSONResponse Forbidden
+
+func (response RemoveWorkspaceMember403JSONResponse) VisitRemoveWorkspaceMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveWorkspaceMember404JSONResponse NotFound
+
+func (response RemoveWorkspaceMember404JSONResponse) VisitRemoveWorkspaceMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWorkspaceMemberRequestObject struct {
+	WorkspaceId openapi_types.UUID `json:"workspace_id"`
+	UserId      openapi_types.UUID `json:"user_id"`
+	Body        *SetWorkspaceMemberJSONRequestBody
+}
+
+type SetWorkspaceMemberResponseObject interface {
+	VisitSetWorkspaceMemberResponse(w http.ResponseWriter) error
+}
+
+type SetWorkspaceMember200JSONResponse WorkspaceMember
+
+func (response SetWorkspaceMember200JSONResponse) VisitSetWorkspaceMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWorkspaceMember400JSONResponse BadRequest
+
+func (response SetWorkspaceMember400JSONResponse) VisitSetWorkspaceMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWorkspaceMember403JSONResponse Forbidden
+
+func (response SetWorkspaceMember403JSONResponse) VisitSetWorkspaceMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetWorkspaceMember404JSONResponse NotFound
+
+func (response SetWorkspaceMember404JSONResponse) VisitSetWorkspaceMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List parked messages
+	// (GET /v1/admin/dead-letters)
+	ListDeadLetters(ctx context.Context, request ListDeadLettersRequestObject) (ListDeadLettersResponseObject, error)
+	// List the running services
+	// (GET /v1/admin/services)
+	ListServices(ctx context.Context, request ListServicesRequestObject) (ListServicesResponseObject, error)
 	// List all agents
 	// (GET /v1/agents)
 	ListAgents(ctx context.Context, request ListAgentsRequestObject) (ListAgentsResponseObject, error)
@@ -4585,6 +16915,21 @@ type StrictServerInterface interface {
 	// Update agent
 	// (PUT /v1/agents/{agent_id})
 	UpdateAgent(ctx context.Context, request UpdateAgentRequestObject) (UpdateAgentResponseObject, error)
+	// List the grants of an agent
+	// (GET /v1/agents/{agent_id}/grants)
+	ListAgentGrants(ctx context.Context, request ListAgentGrantsRequestObject) (ListAgentGrantsResponseObject, error)
+	// Share an agent
+	// (PUT /v1/agents/{agent_id}/grants)
+	SetAgentGrant(ctx context.Context, request SetAgentGrantRequestObject) (SetAgentGrantResponseObject, error)
+	// Revoke a grant of an agent
+	// (DELETE /v1/agents/{agent_id}/grants/{grantee_type}/{grantee_id})
+	RemoveAgentGrant(ctx context.Context, request RemoveAgentGrantRequestObject) (RemoveAgentGrantResponseObject, error)
+	// Get agent change history
+	// (GET /v1/agents/{agent_id}/history)
+	GetAgentHistory(ctx context.Context, request GetAgentHistoryRequestObject) (GetAgentHistoryResponseObject, error)
+	// Invoke an agent
+	// (POST /v1/agents/{agent_id}/invoke)
+	InvokeAgent(ctx context.Context, request InvokeAgentRequestObject) (InvokeAgentResponseObject, error)
 	// List permissions for agent mapping
 	// (GET /v1/agents/{agent_id}/permissions)
 	ListPermissionsForAgent(ctx context.Context, request ListPermissionsForAgentRequestObject) (ListPermissionsForAgentResponseObject, error)
@@ -4594,6 +16939,75 @@ type StrictServerInterface interface {
 	// Remove permission from agent
 	// (DELETE /v1/agents/{agent_id}/permissions/{permission_id})
 	RemovePermissionFromAgent(ctx context.Context, request RemovePermissionFromAgentRequestObject) (RemovePermissionFromAgentResponseObject, error)
+	// List agent probes
+	// (GET /v1/agents/{agent_id}/probes)
+	ListAgentProbes(ctx context.Context, request ListAgentProbesRequestObject) (ListAgentProbesResponseObject, error)
+	// Create agent probe
+	// (POST /v1/agents/{agent_id}/probes)
+	CreateAgentProbe(ctx context.Context, request CreateAgentProbeRequestObject) (CreateAgentProbeResponseObject, error)
+	// Delete agent probe
+	// (DELETE /v1/agents/{agent_id}/probes/{probe_id})
+	DeleteAgentProbe(ctx context.Context, request DeleteAgentProbeRequestObject) (DeleteAgentProbeResponseObject, error)
+	// Get agent probe
+	// (GET /v1/agents/{agent_id}/probes/{probe_id})
+	GetAgentProbe(ctx context.Context, request GetAgentProbeRequestObject) (GetAgentProbeResponseObject, error)
+	// Update agent probe
+	// (PUT /v1/agents/{agent_id}/probes/{probe_id})
+	UpdateAgentProbe(ctx context.Context, request UpdateAgentProbeRequestObject) (UpdateAgentProbeResponseObject, error)
+	// List agent probe runs
+	// (GET /v1/agents/{agent_id}/probes/{probe_id}/runs)
+	ListAgentProbeRuns(ctx context.Context, request ListAgentProbeRunsRequestObject) (ListAgentProbeRunsResponseObject, error)
+	// Purge an agent
+	// (POST /v1/agents/{agent_id}/purge)
+	PurgeAgent(ctx context.Context, request PurgeAgentRequestObject) (PurgeAgentResponseObject, error)
+	// Restore an agent
+	// (POST /v1/agents/{agent_id}/restore)
+	RestoreAgent(ctx context.Context, request RestoreAgentRequestObject) (RestoreAgentResponseObject, error)
+	// Get flow cost analytics
+	// (GET /v1/analytics/flow-costs)
+	GetFlowCostAnalytics(ctx context.Context, request GetFlowCostAnalyticsRequestObject) (GetFlowCostAnalyticsResponseObject, error)
+	// Get prompt cache analytics
+	// (GET /v1/analytics/prompt-cache)
+	GetPromptCacheAnalytics(ctx context.Context, request GetPromptCacheAnalyticsRequestObject) (GetPromptCacheAnalyticsResponseObject, error)
+	// Get thinking budget analytics
+	// (GET /v1/analytics/thinking-budget)
+	GetThinkingBudgetAnalytics(ctx context.Context, request GetThinkingBudgetAnalyticsRequestObject) (GetThinkingBudgetAnalyticsResponseObject, error)
+	// Get user flow cost analytics
+	// (GET /v1/analytics/user-flow-costs)
+	GetUserFlowCostAnalytics(ctx context.Context, request GetUserFlowCostAnalyticsRequestObject) (GetUserFlowCostAnalyticsResponseObject, error)
+	// List tool run audit entries
+	// (GET /v1/audit/tool-runs)
+	ListToolRunAudit(ctx context.Context, request ListToolRunAuditRequestObject) (ListToolRunAuditResponseObject, error)
+	// Get a tool run audit entry
+	// (GET /v1/audit/tool-runs/{tool_run_id})
+	GetToolRunAudit(ctx context.Context, request GetToolRunAuditRequestObject) (GetToolRunAuditResponseObject, error)
+	// List feedback
+	// (GET /v1/feedback)
+	ListFeedback(ctx context.Context, request ListFeedbackRequestObject) (ListFeedbackResponseObject, error)
+	// Export feedback as a dataset
+	// (GET /v1/feedback/export)
+	ExportFeedback(ctx context.Context, request ExportFeedbackRequestObject) (ExportFeedbackResponseObject, error)
+	// List files
+	// (GET /v1/files)
+	ListFiles(ctx context.Context, request ListFilesRequestObject) (ListFilesResponseObject, error)
+	// Upload a file
+	// (POST /v1/files)
+	CreateFile(ctx context.Context, request CreateFileRequestObject) (CreateFileResponseObject, error)
+	// Delete file
+	// (DELETE /v1/files/{file_id})
+	DeleteFile(ctx context.Context, request DeleteFileRequestObject) (DeleteFileResponseObject, error)
+	// Get file by ID
+	// (GET /v1/files/{file_id})
+	GetFile(ctx context.Context, request GetFileRequestObject) (GetFileResponseObject, error)
+	// Complete a presigned upload
+	// (POST /v1/files/{file_id}/complete)
+	CompleteFileUpload(ctx context.Context, request CompleteFileUploadRequestObject) (CompleteFileUploadResponseObject, error)
+	// Download file
+	// (GET /v1/files/{file_id}/content)
+	DownloadFile(ctx context.Context, request DownloadFileRequestObject) (DownloadFileResponseObject, error)
+	// Retry a flow run
+	// (POST /v1/flow-runs/{flow_run_id}/retry)
+	RetryFlowRun(ctx context.Context, request RetryFlowRunRequestObject) (RetryFlowRunResponseObject, error)
 	// List all flows
 	// (GET /v1/flows)
 	ListFlows(ctx context.Context, request ListFlowsRequestObject) (ListFlowsResponseObject, error)
@@ -4612,6 +17026,72 @@ type StrictServerInterface interface {
 	// Execute a flow
 	// (POST /v1/flows/{flow_id}/execute)
 	ExecuteFlow(ctx context.Context, request ExecuteFlowRequestObject) (ExecuteFlowResponseObject, error)
+	// Get flow change history
+	// (GET /v1/flows/{flow_id}/history)
+	GetFlowHistory(ctx context.Context, request GetFlowHistoryRequestObject) (GetFlowHistoryResponseObject, error)
+	// Purge a flow
+	// (POST /v1/flows/{flow_id}/purge)
+	PurgeFlow(ctx context.Context, request PurgeFlowRequestObject) (PurgeFlowResponseObject, error)
+	// Restore a flow
+	// (POST /v1/flows/{flow_id}/restore)
+	RestoreFlow(ctx context.Context, request RestoreFlowRequestObject) (RestoreFlowResponseObject, error)
+	// List flow runs
+	// (GET /v1/flows/{flow_id}/runs)
+	ListFlowRuns(ctx context.Context, request ListFlowRunsRequestObject) (ListFlowRunsResponseObject, error)
+	// List flow run artifacts
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts)
+	ListFlowRunArtifacts(ctx context.Context, request ListFlowRunArtifactsRequestObject) (ListFlowRunArtifactsResponseObject, error)
+	// Download flow run artifact
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts/{name})
+	DownloadFlowRunArtifact(ctx context.Context, request DownloadFlowRunArtifactRequestObject) (DownloadFlowRunArtifactResponseObject, error)
+	// Cancel a flow run
+	// (POST /v1/flows/{flow_id}/runs/{run_id}/cancel)
+	CancelFlowRun(ctx context.Context, request CancelFlowRunRequestObject) (CancelFlowRunResponseObject, error)
+	// List child flow runs
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/children)
+	ListChildFlowRuns(ctx context.Context, request ListChildFlowRunsRequestObject) (ListChildFlowRunsResponseObject, error)
+	// Get flow run cost
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/cost)
+	GetFlowRunCost(ctx context.Context, request GetFlowRunCostRequestObject) (GetFlowRunCostResponseObject, error)
+	// Get flow run graph
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/graph)
+	GetFlowRunGraph(ctx context.Context, request GetFlowRunGraphRequestObject) (GetFlowRunGraphResponseObject, error)
+	// Get flow run logs
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/logs)
+	GetFlowRunLogs(ctx context.Context, request GetFlowRunLogsRequestObject) (GetFlowRunLogsResponseObject, error)
+	// Pause a flow run
+	// (POST /v1/flows/{flow_id}/runs/{run_id}/pause)
+	PauseFlowRun(ctx context.Context, request PauseFlowRunRequestObject) (PauseFlowRunResponseObject, error)
+	// Resume a flow run
+	// (POST /v1/flows/{flow_id}/runs/{run_id}/resume)
+	ResumeFlowRun(ctx context.Context, request ResumeFlowRunRequestObject) (ResumeFlowRunResponseObject, error)
+	// Get flow run timeline
+	// (GET /v1/flows/{flow_id}/runs/{run_id}/timeline)
+	GetFlowRunTimeline(ctx context.Context, request GetFlowRunTimelineRequestObject) (GetFlowRunTimelineResponseObject, error)
+	// List flow schedules
+	// (GET /v1/flows/{flow_id}/schedules)
+	ListFlowSchedules(ctx context.Context, request ListFlowSchedulesRequestObject) (ListFlowSchedulesResponseObject, error)
+	// Create flow schedule
+	// (POST /v1/flows/{flow_id}/schedules)
+	CreateFlowSchedule(ctx context.Context, request CreateFlowScheduleRequestObject) (CreateFlowScheduleResponseObject, error)
+	// Delete flow schedule
+	// (DELETE /v1/flows/{flow_id}/schedules/{schedule_id})
+	DeleteFlowSchedule(ctx context.Context, request DeleteFlowScheduleRequestObject) (DeleteFlowScheduleResponseObject, error)
+	// Get flow schedule
+	// (GET /v1/flows/{flow_id}/schedules/{schedule_id})
+	GetFlowSchedule(ctx context.Context, request GetFlowScheduleRequestObject) (GetFlowScheduleResponseObject, error)
+	// Update flow schedule
+	// (PUT /v1/flows/{flow_id}/schedules/{schedule_id})
+	UpdateFlowSchedule(ctx context.Context, request UpdateFlowScheduleRequestObject) (UpdateFlowScheduleResponseObject, error)
+	// List flow schedule backfills
+	// (GET /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+	ListFlowScheduleBackfills(ctx context.Context, request ListFlowScheduleBackfillsRequestObject) (ListFlowScheduleBackfillsResponseObject, error)
+	// Create flow schedule backfill
+	// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+	CreateFlowScheduleBackfill(ctx context.Context, request CreateFlowScheduleBackfillRequestObject) (CreateFlowScheduleBackfillResponseObject, error)
+	// Cancel flow schedule backfill
+	// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills/{backfill_id}/cancel)
+	CancelFlowScheduleBackfill(ctx context.Context, request CancelFlowScheduleBackfillRequestObject) (CancelFlowScheduleBackfillResponseObject, error)
 	// Get flow run by ID
 	// (GET /v1/flows/{flow_run_id}/status)
 	GetFlowRun(ctx context.Context, request GetFlowRunRequestObject) (GetFlowRunResponseObject, error)
@@ -4621,6 +17101,27 @@ type StrictServerInterface interface {
 	// Mock standalone server with delay
 	// (POST /v1/mock/tool_with_delay)
 	MockStandaloneToolWithDelay(ctx context.Context, request MockStandaloneToolWithDelayRequestObject) (MockStandaloneToolWithDelayResponseObject, error)
+	// List the organizations of the user
+	// (GET /v1/organizations)
+	ListOrganizations(ctx context.Context, request ListOrganizationsRequestObject) (ListOrganizationsResponseObject, error)
+	// Create an organization
+	// (POST /v1/organizations)
+	CreateOrganization(ctx context.Context, request CreateOrganizationRequestObject) (CreateOrganizationResponseObject, error)
+	// List the members of an organization
+	// (GET /v1/organizations/{organization_id}/members)
+	ListOrganizationMembers(ctx context.Context, request ListOrganizationMembersRequestObject) (ListOrganizationMembersResponseObject, error)
+	// Remove a member from an organization
+	// (DELETE /v1/organizations/{organization_id}/members/{user_id})
+	RemoveOrganizationMember(ctx context.Context, request RemoveOrganizationMemberRequestObject) (RemoveOrganizationMemberResponseObject, error)
+	// Add a member to an organization
+	// (PUT /v1/organizations/{organization_id}/members/{user_id})
+	SetOrganizationMember(ctx context.Context, request SetOrganizationMemberRequestObject) (SetOrganizationMemberResponseObject, error)
+	// List the workspaces of an organization
+	// (GET /v1/organizations/{organization_id}/workspaces)
+	ListOrganizationWorkspaces(ctx context.Context, request ListOrganizationWorkspacesRequestObject) (ListOrganizationWorkspacesResponseObject, error)
+	// Create a workspace
+	// (POST /v1/organizations/{organization_id}/workspaces)
+	CreateWorkspace(ctx context.Context, request CreateWorkspaceRequestObject) (CreateWorkspaceResponseObject, error)
 	// List all permissions
 	// (GET /v1/permissions)
 	ListPermissions(ctx context.Context, request ListPermissionsRequestObject) (ListPermissionsResponseObject, error)
@@ -4636,6 +17137,9 @@ type StrictServerInterface interface {
 	// Update permission
 	// (PUT /v1/permissions/{permission_id})
 	UpdatePermission(ctx context.Context, request UpdatePermissionRequestObject) (UpdatePermissionResponseObject, error)
+	// Send a message to the default agent
+	// (POST /v1/quickstart)
+	Quickstart(ctx context.Context, request QuickstartRequestObject) (QuickstartResponseObject, error)
 	// List all roles
 	// (GET /v1/roles)
 	ListRoles(ctx context.Context, request ListRolesRequestObject) (ListRolesResponseObject, error)
@@ -4660,12 +17164,36 @@ type StrictServerInterface interface {
 	// Remove permission from role
 	// (DELETE /v1/roles/{role_id}/permissions/{permission_id})
 	RemovePermissionFromRole(ctx context.Context, request RemovePermissionFromRoleRequestObject) (RemovePermissionFromRoleResponseObject, error)
+	// Search the messages
+	// (GET /v1/search/messages)
+	SearchMessages(ctx context.Context, request SearchMessagesRequestObject) (SearchMessagesResponseObject, error)
+	// Search the messages by meaning
+	// (GET /v1/search/messages/semantic)
+	SemanticSearchMessages(ctx context.Context, request SemanticSearchMessagesRequestObject) (SemanticSearchMessagesResponseObject, error)
+	// Create a service account
+	// (POST /v1/service-accounts)
+	CreateServiceAccount(ctx context.Context, request CreateServiceAccountRequestObject) (CreateServiceAccountResponseObject, error)
 	// List all tasks
 	// (GET /v1/tasks)
 	ListTasks(ctx context.Context, request ListTasksRequestObject) (ListTasksResponseObject, error)
 	// Create a new task
 	// (POST /v1/tasks)
 	CreateTask(ctx context.Context, request CreateTaskRequestObject) (CreateTaskResponseObject, error)
+	// List task schedules
+	// (GET /v1/tasks/schedules)
+	ListTaskSchedules(ctx context.Context, request ListTaskSchedulesRequestObject) (ListTaskSchedulesResponseObject, error)
+	// Create task schedule
+	// (POST /v1/tasks/schedules)
+	CreateTaskSchedule(ctx context.Context, request CreateTaskScheduleRequestObject) (CreateTaskScheduleResponseObject, error)
+	// Delete task schedule
+	// (DELETE /v1/tasks/schedules/{schedule_id})
+	DeleteTaskSchedule(ctx context.Context, request DeleteTaskScheduleRequestObject) (DeleteTaskScheduleResponseObject, error)
+	// Get task schedule
+	// (GET /v1/tasks/schedules/{schedule_id})
+	GetTaskSchedule(ctx context.Context, request GetTaskScheduleRequestObject) (GetTaskScheduleResponseObject, error)
+	// Update task schedule
+	// (PUT /v1/tasks/schedules/{schedule_id})
+	UpdateTaskSchedule(ctx context.Context, request UpdateTaskScheduleRequestObject) (UpdateTaskScheduleResponseObject, error)
 	// Delete a task
 	// (DELETE /v1/tasks/{task_id})
 	DeleteTask(ctx context.Context, request DeleteTaskRequestObject) (DeleteTaskResponseObject, error)
@@ -4681,6 +17209,12 @@ type StrictServerInterface interface {
 	// Get all task runs for a task
 	// (GET /v1/tasks/{task_id}/runs)
 	ListTaskRuns(ctx context.Context, request ListTaskRunsRequestObject) (ListTaskRunsResponseObject, error)
+	// Cancel a task run
+	// (POST /v1/tasks/{task_id}/runs/{run_id}/cancel)
+	CancelTaskRun(ctx context.Context, request CancelTaskRunRequestObject) (CancelTaskRunResponseObject, error)
+	// Stream a task execution
+	// (GET /v1/tasks/{task_id}/stream)
+	StreamTask(ctx context.Context, request StreamTaskRequestObject) (StreamTaskResponseObject, error)
 	// Get task run by ID
 	// (GET /v1/tasks/{task_run_id}/status)
 	GetTaskRun(ctx context.Context, request GetTaskRunRequestObject) (GetTaskRunResponseObject, error)
@@ -4690,6 +17224,9 @@ type StrictServerInterface interface {
 	// Create a new thread
 	// (POST /v1/threads)
 	CreateThread(ctx context.Context, request CreateThreadRequestObject) (CreateThreadResponseObject, error)
+	// Import a thread
+	// (POST /v1/threads/import)
+	ImportThread(ctx context.Context, request ImportThreadRequestObject) (ImportThreadResponseObject, error)
 	// Delete thread
 	// (DELETE /v1/threads/{thread_id})
 	DeleteThread(ctx context.Context, request DeleteThreadRequestObject) (DeleteThreadResponseObject, error)
@@ -4699,12 +17236,33 @@ type StrictServerInterface interface {
 	// Update thread title
 	// (PUT /v1/threads/{thread_id})
 	UpdateThreadTitle(ctx context.Context, request UpdateThreadTitleRequestObject) (UpdateThreadTitleResponseObject, error)
+	// Stream the events of a thread
+	// (GET /v1/threads/{thread_id}/events)
+	StreamThreadEvents(ctx context.Context, request StreamThreadEventsRequestObject) (StreamThreadEventsResponseObject, error)
+	// Export a thread
+	// (GET /v1/threads/{thread_id}/export)
+	ExportThread(ctx context.Context, request ExportThreadRequestObject) (ExportThreadResponseObject, error)
+	// Fork a thread
+	// (POST /v1/threads/{thread_id}/fork)
+	ForkThread(ctx context.Context, request ForkThreadRequestObject) (ForkThreadResponseObject, error)
+	// List the grants of a thread
+	// (GET /v1/threads/{thread_id}/grants)
+	ListThreadGrants(ctx context.Context, request ListThreadGrantsRequestObject) (ListThreadGrantsResponseObject, error)
+	// Share a thread
+	// (PUT /v1/threads/{thread_id}/grants)
+	SetThreadGrant(ctx context.Context, request SetThreadGrantRequestObject) (SetThreadGrantResponseObject, error)
+	// Revoke a grant of a thread
+	// (DELETE /v1/threads/{thread_id}/grants/{grantee_type}/{grantee_id})
+	RemoveThreadGrant(ctx context.Context, request RemoveThreadGrantRequestObject) (RemoveThreadGrantResponseObject, error)
 	// List all messages in a thread
 	// (GET /v1/threads/{thread_id}/messages)
 	ListMessages(ctx context.Context, request ListMessagesRequestObject) (ListMessagesResponseObject, error)
 	// Create a new message in a thread
 	// (POST /v1/threads/{thread_id}/messages)
 	CreateMessage(ctx context.Context, request CreateMessageRequestObject) (CreateMessageResponseObject, error)
+	// Import messages in a thread
+	// (POST /v1/threads/{thread_id}/messages/batch)
+	ImportMessages(ctx context.Context, request ImportMessagesRequestObject) (ImportMessagesResponseObject, error)
 	// Delete message
 	// (DELETE /v1/threads/{thread_id}/messages/{message_id})
 	DeleteMessage(ctx context.Context, request DeleteMessageRequestObject) (DeleteMessageResponseObject, error)
@@ -4714,12 +17272,60 @@ type StrictServerInterface interface {
 	// Update message
 	// (PUT /v1/threads/{thread_id}/messages/{message_id})
 	UpdateMessage(ctx context.Context, request UpdateMessageRequestObject) (UpdateMessageResponseObject, error)
+	// List message attachments
+	// (GET /v1/threads/{thread_id}/messages/{message_id}/attachments)
+	ListMessageAttachments(ctx context.Context, request ListMessageAttachmentsRequestObject) (ListMessageAttachmentsResponseObject, error)
+	// Edit a user message
+	// (POST /v1/threads/{thread_id}/messages/{message_id}/edit)
+	EditMessage(ctx context.Context, request EditMessageRequestObject) (EditMessageResponseObject, error)
+	// Delete the feedback on a message
+	// (DELETE /v1/threads/{thread_id}/messages/{message_id}/feedback)
+	DeleteMessageFeedback(ctx context.Context, request DeleteMessageFeedbackRequestObject) (DeleteMessageFeedbackResponseObject, error)
+	// Rate a message
+	// (PUT /v1/threads/{thread_id}/messages/{message_id}/feedback)
+	SetMessageFeedback(ctx context.Context, request SetMessageFeedbackRequestObject) (SetMessageFeedbackResponseObject, error)
+	// Regenerate a thread from a message
+	// (POST /v1/threads/{thread_id}/messages/{message_id}/regenerate)
+	RegenerateMessage(ctx context.Context, request RegenerateMessageRequestObject) (RegenerateMessageResponseObject, error)
+	// Purge a thread
+	// (POST /v1/threads/{thread_id}/purge)
+	PurgeThread(ctx context.Context, request PurgeThreadRequestObject) (PurgeThreadResponseObject, error)
+	// Restore a thread
+	// (POST /v1/threads/{thread_id}/restore)
+	RestoreThread(ctx context.Context, request RestoreThreadRequestObject) (RestoreThreadResponseObject, error)
+	// Delete the feedback on a tool run
+	// (DELETE /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+	DeleteToolRunFeedback(ctx context.Context, request DeleteToolRunFeedbackRequestObject) (DeleteToolRunFeedbackResponseObject, error)
+	// Rate a tool run
+	// (PUT /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+	SetToolRunFeedback(ctx context.Context, request SetToolRunFeedbackRequestObject) (SetToolRunFeedbackResponseObject, error)
 	// List all tools
 	// (GET /v1/tools)
 	ListTools(ctx context.Context, request ListToolsRequestObject) (ListToolsResponseObject, error)
 	// Create a new tool
 	// (POST /v1/tools)
 	CreateTool(ctx context.Context, request CreateToolRequestObject) (CreateToolResponseObject, error)
+	// Create tools in a batch
+	// (POST /v1/tools/batch-create)
+	BatchCreateTools(ctx context.Context, request BatchCreateToolsRequestObject) (BatchCreateToolsResponseObject, error)
+	// Delete tools in a batch
+	// (POST /v1/tools/batch-delete)
+	BatchDeleteTools(ctx context.Context, request BatchDeleteToolsRequestObject) (BatchDeleteToolsResponseObject, error)
+	// Update tools in a batch
+	// (POST /v1/tools/batch-update)
+	BatchUpdateTools(ctx context.Context, request BatchUpdateToolsRequestObject) (BatchUpdateToolsResponseObject, error)
+	// Get the tool catalog
+	// (GET /v1/tools/catalog)
+	GetToolCatalog(ctx context.Context, request GetToolCatalogRequestObject) (GetToolCatalogResponseObject, error)
+	// Create a tool from a function definition
+	// (POST /v1/tools/definitions)
+	CreateToolFromDefinition(ctx context.Context, request CreateToolFromDefinitionRequestObject) (CreateToolFromDefinitionResponseObject, error)
+	// Export tools as a bundle
+	// (GET /v1/tools/export)
+	ExportTools(ctx context.Context, request ExportToolsRequestObject) (ExportToolsResponseObject, error)
+	// Import a tool bundle
+	// (POST /v1/tools/import)
+	ImportTools(ctx context.Context, request ImportToolsRequestObject) (ImportToolsResponseObject, error)
 	// Delete a tool
 	// (DELETE /v1/tools/{tool_id})
 	DeleteTool(ctx context.Context, request DeleteToolRequestObject) (DeleteToolResponseObject, error)
@@ -4729,6 +17335,39 @@ type StrictServerInterface interface {
 	// Update a tool
 	// (PUT /v1/tools/{tool_id})
 	UpdateTool(ctx context.Context, request UpdateToolRequestObject) (UpdateToolResponseObject, error)
+	// List the grants of a tool
+	// (GET /v1/tools/{tool_id}/grants)
+	ListToolGrants(ctx context.Context, request ListToolGrantsRequestObject) (ListToolGrantsResponseObject, error)
+	// Share a tool
+	// (PUT /v1/tools/{tool_id}/grants)
+	SetToolGrant(ctx context.Context, request SetToolGrantRequestObject) (SetToolGrantResponseObject, error)
+	// Revoke a grant of a tool
+	// (DELETE /v1/tools/{tool_id}/grants/{grantee_type}/{grantee_id})
+	RemoveToolGrant(ctx context.Context, request RemoveToolGrantRequestObject) (RemoveToolGrantResponseObject, error)
+	// Get tool change history
+	// (GET /v1/tools/{tool_id}/history)
+	GetToolHistory(ctx context.Context, request GetToolHistoryRequestObject) (GetToolHistoryResponseObject, error)
+	// Purge a tool
+	// (POST /v1/tools/{tool_id}/purge)
+	PurgeTool(ctx context.Context, request PurgeToolRequestObject) (PurgeToolResponseObject, error)
+	// Restore a tool
+	// (POST /v1/tools/{tool_id}/restore)
+	RestoreTool(ctx context.Context, request RestoreToolRequestObject) (RestoreToolResponseObject, error)
+	// List tool revisions
+	// (GET /v1/tools/{tool_id}/revisions)
+	ListToolRevisions(ctx context.Context, request ListToolRevisionsRequestObject) (ListToolRevisionsResponseObject, error)
+	// Diff tool revision schemas
+	// (GET /v1/tools/{tool_id}/revisions/diff)
+	DiffToolRevisions(ctx context.Context, request DiffToolRevisionsRequestObject) (DiffToolRevisionsResponseObject, error)
+	// Promote a tool revision
+	// (POST /v1/tools/{tool_id}/revisions/{revision}/promote)
+	PromoteToolRevision(ctx context.Context, request PromoteToolRevisionRequestObject) (PromoteToolRevisionResponseObject, error)
+	// Test a tool
+	// (POST /v1/tools/{tool_id}/test)
+	TestTool(ctx context.Context, request TestToolRequestObject) (TestToolResponseObject, error)
+	// Get the usage of the quotas
+	// (GET /v1/usage)
+	GetUsage(ctx context.Context, request GetUsageRequestObject) (GetUsageResponseObject, error)
 	// List all users
 	// (GET /v1/users)
 	ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error)
@@ -4744,6 +17383,18 @@ type StrictServerInterface interface {
 	// Update user
 	// (PUT /v1/users/{user_id})
 	UpdateUser(ctx context.Context, request UpdateUserRequestObject) (UpdateUserResponseObject, error)
+	// List the API keys of a user
+	// (GET /v1/users/{user_id}/api-keys)
+	ListUserApiKeys(ctx context.Context, request ListUserApiKeysRequestObject) (ListUserApiKeysResponseObject, error)
+	// Create an API key for a user
+	// (POST /v1/users/{user_id}/api-keys)
+	CreateUserApiKey(ctx context.Context, request CreateUserApiKeyRequestObject) (CreateUserApiKeyResponseObject, error)
+	// Revoke an API key
+	// (DELETE /v1/users/{user_id}/api-keys/{key_id})
+	RevokeUserApiKey(ctx context.Context, request RevokeUserApiKeyRequestObject) (RevokeUserApiKeyResponseObject, error)
+	// Set the default agent of a user
+	// (PUT /v1/users/{user_id}/default-agent)
+	SetUserDefaultAgent(ctx context.Context, request SetUserDefaultAgentRequestObject) (SetUserDefaultAgentResponseObject, error)
 	// List role for user mapping
 	// (GET /v1/users/{user_id}/roles)
 	ListRoleForUser(ctx context.Context, request ListRoleForUserRequestObject) (ListRoleForUserResponseObject, error)
@@ -4753,54 +17404,3370 @@ type StrictServerInterface interface {
 	// Remove role from user
 	// (DELETE /v1/users/{user_id}/roles/{role_id})
 	RemoveRoleFromUser(ctx context.Context, request RemoveRoleFromUserRequestObject) (RemoveRoleFromUserResponseObject, error)
+	// List webhooks
+	// (GET /v1/webhooks)
+	ListWebhooks(ctx context.Context, request ListWebhooksRequestObject) (ListWebhooksResponseObject, error)
+	// Create a webhook
+	// (POST /v1/webhooks)
+	CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error)
+	// Delete webhook
+	// (DELETE /v1/webhooks/{webhook_id})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
+	// Get webhook by ID
+	// (GET /v1/webhooks/{webhook_id})
+	GetWebhook(ctx context.Context, request GetWebhookRequestObject) (GetWebhookResponseObject, error)
+	// Update webhook
+	// (PUT /v1/webhooks/{webhook_id})
+	UpdateWebhook(ctx context.Context, request UpdateWebhookRequestObject) (UpdateWebhookResponseObject, error)
+	// List webhook deliveries
+	// (GET /v1/webhooks/{webhook_id}/deliveries)
+	ListWebhookDeliveries(ctx context.Context, request ListWebhookDeliveriesRequestObject) (ListWebhookDeliveriesResponseObject, error)
+	// Redeliver a webhook delivery
+	// (POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+	RedeliverWebhookDelivery(ctx context.Context, request RedeliverWebhookDeliveryRequestObject) (RedeliverWebhookDeliveryResponseObject, error)
+	// Rotate webhook secret
+	// (POST /v1/webhooks/{webhook_id}/rotate-secret)
+	RotateWebhookSecret(ctx context.Context, request RotateWebhookSecretRequestObject) (RotateWebhookSecretResponseObject, error)
+	// List the members of a workspace
+	// (GET /v1/workspaces/{workspace_id}/members)
+	ListWorkspaceMembers(ctx context.Context, request ListWorkspaceMembersRequestObject) (ListWorkspaceMembersResponseObject, error)
+	// Remove a member from a workspace
+	// (DELETE /v1/workspaces/{workspace_id}/members/{user_id})
+	RemoveWorkspaceMember(ctx context.Context, request RemoveWorkspaceMemberRequestObject) (RemoveWorkspaceMemberResponseObject, error)
+	// Add a member to a workspace
+	// (PUT /v1/workspaces/{workspace_id}/members/{user_id})
+	SetWorkspaceMember(ctx context.Context, request SetWorkspaceMemberRequestObject) (SetWorkspaceMemberResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListDeadLetters operation middleware
+func (sh *strictHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request, params ListDeadLettersParams) {
+	var request ListDeadLettersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDeadLetters(ctx, request.(ListDeadLettersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDeadLetters")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDeadLettersResponseObject); ok {
+		if err := validResponse.VisitListDeadLettersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListServices operation middleware
+func (sh *strictHandler) ListServices(w http.ResponseWriter, r *http.Request, params ListServicesParams) {
+	var request ListServicesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListServices(ctx, request.(ListServicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListServices")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListServicesResponseObject); ok {
+		if err := validResponse.VisitListServicesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAgents operation middleware
+func (sh *strictHandler) ListAgents(w http.ResponseWriter, r *http.Request, params ListAgentsParams) {
+	var request ListAgentsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAgents(ctx, request.(ListAgentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAgents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAgentsResponseObject); ok {
+		if err := validResponse.VisitListAgentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAgent operation middleware
+func (sh *strictHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
+	var request CreateAgentRequestObject
+
+	var body CreateAgentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAgent(ctx, request.(CreateAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAgentResponseObject); ok {
+		if err := validResponse.VisitCreateAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAgent operation middleware
+func (sh *strictHandler) DeleteAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request DeleteAgentRequestObject
+
+	request.AgentId = agentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAgent(ctx, request.(DeleteAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAgentResponseObject); ok {
+		if err := validResponse.VisitDeleteAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAgent operation middleware
+func (sh *strictHandler) GetAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request GetAgentRequestObject
+
+	request.AgentId = agentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAgent(ctx, request.(GetAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAgentResponseObject); ok {
+		if err := validResponse.VisitGetAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateAgent operation middleware
+func (sh *strictHandler) UpdateAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params UpdateAgentParams) {
+	var request UpdateAgentRequestObject
+
+	request.AgentId = agentId
+	request.Params = params
+
+	var body UpdateAgentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateAgent(ctx, request.(UpdateAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateAgentResponseObject); ok {
+		if err := validResponse.VisitUpdateAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAgentGrants operation middleware
+func (sh *strictHandler) ListAgentGrants(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request ListAgentGrantsRequestObject
+
+	request.AgentId = agentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAgentGrants(ctx, request.(ListAgentGrantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAgentGrants")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAgentGrantsResponseObject); ok {
+		if err := validResponse.VisitListAgentGrantsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetAgentGrant operation middleware
+func (sh *strictHandler) SetAgentGrant(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request SetAgentGrantRequestObject
+
+	request.AgentId = agentId
+
+	var body SetAgentGrantJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetAgentGrant(ctx, request.(SetAgentGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetAgentGrant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetAgentGrantResponseObject); ok {
+		if err := validResponse.VisitSetAgentGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveAgentGrant operation middleware
+func (sh *strictHandler) RemoveAgentGrant(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID) {
+	var request RemoveAgentGrantRequestObject
+
+	request.AgentId = agentId
+	request.GranteeType = granteeType
+	request.GranteeId = granteeId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveAgentGrant(ctx, request.(RemoveAgentGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveAgentGrant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveAgentGrantResponseObject); ok {
+		if err := validResponse.VisitRemoveAgentGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAgentHistory operation middleware
+func (sh *strictHandler) GetAgentHistory(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params GetAgentHistoryParams) {
+	var request GetAgentHistoryRequestObject
+
+	request.AgentId = agentId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAgentHistory(ctx, request.(GetAgentHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAgentHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAgentHistoryResponseObject); ok {
+		if err := validResponse.VisitGetAgentHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// InvokeAgent operation middleware
+func (sh *strictHandler) InvokeAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, params InvokeAgentParams) {
+	var request InvokeAgentRequestObject
+
+	request.AgentId = agentId
+	request.Params = params
+
+	var body InvokeAgentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.InvokeAgent(ctx, request.(InvokeAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "InvokeAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(InvokeAgentResponseObject); ok {
+		if err := validResponse.VisitInvokeAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPermissionsForAgent operation middleware
+func (sh *strictHandler) ListPermissionsForAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request ListPermissionsForAgentRequestObject
+
+	request.AgentId = agentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPermissionsForAgent(ctx, request.(ListPermissionsForAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPermissionsForAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPermissionsForAgentResponseObject); ok {
+		if err := validResponse.VisitListPermissionsForAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddPermissionToAgent operation middleware
+func (sh *strictHandler) AddPermissionToAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request AddPermissionToAgentRequestObject
+
+	request.AgentId = agentId
+
+	var body AddPermissionToAgentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddPermissionToAgent(ctx, request.(AddPermissionToAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPermissionToAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddPermissionToAgentResponseObject); ok {
+		if err := validResponse.VisitAddPermissionToAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemovePermissionFromAgent operation middleware
+func (sh *strictHandler) RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, permissionId openapi_types.UUID) {
+	var request RemovePermissionFromAgentRequestObject
+
+	request.AgentId = agentId
+	request.PermissionId = permissionId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemovePermissionFromAgent(ctx, request.(RemovePermissionFromAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemovePermissionFromAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemovePermissionFromAgentResponseObject); ok {
+		if err := validResponse.VisitRemovePermissionFromAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAgentProbes operation middleware
+func (sh *strictHandler) ListAgentProbes(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request ListAgentProbesRequestObject
+
+	request.AgentId = agentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAgentProbes(ctx, request.(ListAgentProbesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAgentProbes")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAgentProbesResponseObject); ok {
+		if err := validResponse.VisitListAgentProbesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAgentProbe operation middleware
+func (sh *strictHandler) CreateAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request CreateAgentProbeRequestObject
+
+	request.AgentId = agentId
+
+	var body CreateAgentProbeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAgentProbe(ctx, request.(CreateAgentProbeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAgentProbe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAgentProbeResponseObject); ok {
+		if err := validResponse.VisitCreateAgentProbeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAgentProbe operation middleware
+func (sh *strictHandler) DeleteAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID) {
+	var request DeleteAgentProbeRequestObject
+
+	request.AgentId = agentId
+	request.ProbeId = probeId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAgentProbe(ctx, request.(DeleteAgentProbeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAgentProbe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAgentProbeResponseObject); ok {
+		if err := validResponse.VisitDeleteAgentProbeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAgentProbe operation middleware
+func (sh *strictHandler) GetAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID) {
+	var request GetAgentProbeRequestObject
+
+	request.AgentId = agentId
+	request.ProbeId = probeId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAgentProbe(ctx, request.(GetAgentProbeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAgentProbe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAgentProbeResponseObject); ok {
+		if err := validResponse.VisitGetAgentProbeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateAgentProbe operation middleware
+func (sh *strictHandler) UpdateAgentProbe(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID) {
+	var request UpdateAgentProbeRequestObject
+
+	request.AgentId = agentId
+	request.ProbeId = probeId
+
+	var body UpdateAgentProbeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateAgentProbe(ctx, request.(UpdateAgentProbeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateAgentProbe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateAgentProbeResponseObject); ok {
+		if err := validResponse.VisitUpdateAgentProbeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAgentProbeRuns operation middleware
+func (sh *strictHandler) ListAgentProbeRuns(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, probeId openapi_types.UUID, params ListAgentProbeRunsParams) {
+	var request ListAgentProbeRunsRequestObject
+
+	request.AgentId = agentId
+	request.ProbeId = probeId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAgentProbeRuns(ctx, request.(ListAgentProbeRunsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAgentProbeRuns")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAgentProbeRunsResponseObject); ok {
+		if err := validResponse.VisitListAgentProbeRunsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PurgeAgent operation middleware
+func (sh *strictHandler) PurgeAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request PurgeAgentRequestObject
+
+	request.AgentId = agentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PurgeAgent(ctx, request.(PurgeAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PurgeAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PurgeAgentResponseObject); ok {
+		if err := validResponse.VisitPurgeAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RestoreAgent operation middleware
+func (sh *strictHandler) RestoreAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
+	var request RestoreAgentRequestObject
+
+	request.AgentId = agentId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreAgent(ctx, request.(RestoreAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreAgent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RestoreAgentResponseObject); ok {
+		if err := validResponse.VisitRestoreAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowCostAnalytics operation middleware
+func (sh *strictHandler) GetFlowCostAnalytics(w http.ResponseWriter, r *http.Request, params GetFlowCostAnalyticsParams) {
+	var request GetFlowCostAnalyticsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowCostAnalytics(ctx, request.(GetFlowCostAnalyticsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowCostAnalytics")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowCostAnalyticsResponseObject); ok {
+		if err := validResponse.VisitGetFlowCostAnalyticsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPromptCacheAnalytics operation middleware
+func (sh *strictHandler) GetPromptCacheAnalytics(w http.ResponseWriter, r *http.Request) {
+	var request GetPromptCacheAnalyticsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPromptCacheAnalytics(ctx, request.(GetPromptCacheAnalyticsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPromptCacheAnalytics")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPromptCacheAnalyticsResponseObject); ok {
+		if err := validResponse.VisitGetPromptCacheAnalyticsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetThinkingBudgetAnalytics operation middleware
+func (sh *strictHandler) GetThinkingBudgetAnalytics(w http.ResponseWriter, r *http.Request) {
+	var request GetThinkingBudgetAnalyticsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetThinkingBudgetAnalytics(ctx, request.(GetThinkingBudgetAnalyticsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetThinkingBudgetAnalytics")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetThinkingBudgetAnalyticsResponseObject); ok {
+		if err := validResponse.VisitGetThinkingBudgetAnalyticsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserFlowCostAnalytics operation middleware
+func (sh *strictHandler) GetUserFlowCostAnalytics(w http.ResponseWriter, r *http.Request, params GetUserFlowCostAnalyticsParams) {
+	var request GetUserFlowCostAnalyticsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserFlowCostAnalytics(ctx, request.(GetUserFlowCostAnalyticsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserFlowCostAnalytics")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserFlowCostAnalyticsResponseObject); ok {
+		if err := validResponse.VisitGetUserFlowCostAnalyticsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListToolRunAudit operation middleware
+func (sh *strictHandler) ListToolRunAudit(w http.ResponseWriter, r *http.Request, params ListToolRunAuditParams) {
+	var request ListToolRunAuditRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListToolRunAudit(ctx, request.(ListToolRunAuditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListToolRunAudit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListToolRunAuditResponseObject); ok {
+		if err := validResponse.VisitListToolRunAuditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetToolRunAudit operation middleware
+func (sh *strictHandler) GetToolRunAudit(w http.ResponseWriter, r *http.Request, toolRunId string) {
+	var request GetToolRunAuditRequestObject
+
+	request.ToolRunId = toolRunId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetToolRunAudit(ctx, request.(GetToolRunAuditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetToolRunAudit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetToolRunAuditResponseObject); ok {
+		if err := validResponse.VisitGetToolRunAuditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFeedback operation middleware
+func (sh *strictHandler) ListFeedback(w http.ResponseWriter, r *http.Request, params ListFeedbackParams) {
+	var request ListFeedbackRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFeedback(ctx, request.(ListFeedbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFeedback")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFeedbackResponseObject); ok {
+		if err := validResponse.VisitListFeedbackResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportFeedback operation middleware
+func (sh *strictHandler) ExportFeedback(w http.ResponseWriter, r *http.Request, params ExportFeedbackParams) {
+	var request ExportFeedbackRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportFeedback(ctx, request.(ExportFeedbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportFeedback")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportFeedbackResponseObject); ok {
+		if err := validResponse.VisitExportFeedbackResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFiles operation middleware
+func (sh *strictHandler) ListFiles(w http.ResponseWriter, r *http.Request, params ListFilesParams) {
+	var request ListFilesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFiles(ctx, request.(ListFilesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFiles")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFilesResponseObject); ok {
+		if err := validResponse.VisitListFilesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateFile operation middleware
+func (sh *strictHandler) CreateFile(w http.ResponseWriter, r *http.Request) {
+	var request CreateFileRequestObject
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+
+		var body CreateFileJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if reader, err := r.MultipartReader(); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+			return
+		} else {
+			request.MultipartBody = reader
+		}
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFile(ctx, request.(CreateFileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFile")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateFileResponseObject); ok {
+		if err := validResponse.VisitCreateFileResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFile operation middleware
+func (sh *strictHandler) DeleteFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	var request DeleteFileRequestObject
+
+	request.FileId = fileId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFile(ctx, request.(DeleteFileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFile")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFileResponseObject); ok {
+		if err := validResponse.VisitDeleteFileResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFile operation middleware
+func (sh *strictHandler) GetFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	var request GetFileRequestObject
+
+	request.FileId = fileId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFile(ctx, request.(GetFileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFile")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFileResponseObject); ok {
+		if err := validResponse.VisitGetFileResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CompleteFileUpload operation middleware
+func (sh *strictHandler) CompleteFileUpload(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	var request CompleteFileUploadRequestObject
+
+	request.FileId = fileId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CompleteFileUpload(ctx, request.(CompleteFileUploadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CompleteFileUpload")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CompleteFileUploadResponseObject); ok {
+		if err := validResponse.VisitCompleteFileUploadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DownloadFile operation middleware
+func (sh *strictHandler) DownloadFile(w http.ResponseWriter, r *http.Request, fileId openapi_types.UUID) {
+	var request DownloadFileRequestObject
+
+	request.FileId = fileId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DownloadFile(ctx, request.(DownloadFileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DownloadFile")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DownloadFileResponseObject); ok {
+		if err := validResponse.VisitDownloadFileResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RetryFlowRun operation middleware
+func (sh *strictHandler) RetryFlowRun(w http.ResponseWriter, r *http.Request, flowRunId openapi_types.UUID) {
+	var request RetryFlowRunRequestObject
+
+	request.FlowRunId = flowRunId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RetryFlowRun(ctx, request.(RetryFlowRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetryFlowRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RetryFlowRunResponseObject); ok {
+		if err := validResponse.VisitRetryFlowRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFlows operation middleware
+func (sh *strictHandler) ListFlows(w http.ResponseWriter, r *http.Request, params ListFlowsParams) {
+	var request ListFlowsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFlows(ctx, request.(ListFlowsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFlows")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFlowsResponseObject); ok {
+		if err := validResponse.VisitListFlowsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateFlow operation middleware
+func (sh *strictHandler) CreateFlow(w http.ResponseWriter, r *http.Request) {
+	var request CreateFlowRequestObject
+
+	var body CreateFlowJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFlow(ctx, request.(CreateFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFlow")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateFlowResponseObject); ok {
+		if err := validResponse.VisitCreateFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFlow operation middleware
+func (sh *strictHandler) DeleteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	var request DeleteFlowRequestObject
+
+	request.FlowId = flowId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFlow(ctx, request.(DeleteFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFlow")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFlowResponseObject); ok {
+		if err := validResponse.VisitDeleteFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlow operation middleware
+func (sh *strictHandler) GetFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	var request GetFlowRequestObject
+
+	request.FlowId = flowId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlow(ctx, request.(GetFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlow")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowResponseObject); ok {
+		if err := validResponse.VisitGetFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateFlow operation middleware
+func (sh *strictHandler) UpdateFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	var request UpdateFlowRequestObject
+
+	request.FlowId = flowId
+
+	var body UpdateFlowJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateFlow(ctx, request.(UpdateFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateFlow")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateFlowResponseObject); ok {
+		if err := validResponse.VisitUpdateFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExecuteFlow operation middleware
+func (sh *strictHandler) ExecuteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params ExecuteFlowParams) {
+	var request ExecuteFlowRequestObject
+
+	request.FlowId = flowId
+	request.Params = params
+
+	var body ExecuteFlowJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExecuteFlow(ctx, request.(ExecuteFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExecuteFlow")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExecuteFlowResponseObject); ok {
+		if err := validResponse.VisitExecuteFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowHistory operation middleware
+func (sh *strictHandler) GetFlowHistory(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params GetFlowHistoryParams) {
+	var request GetFlowHistoryRequestObject
+
+	request.FlowId = flowId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowHistory(ctx, request.(GetFlowHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowHistoryResponseObject); ok {
+		if err := validResponse.VisitGetFlowHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PurgeFlow operation middleware
+func (sh *strictHandler) PurgeFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	var request PurgeFlowRequestObject
+
+	request.FlowId = flowId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PurgeFlow(ctx, request.(PurgeFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PurgeFlow")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PurgeFlowResponseObject); ok {
+		if err := validResponse.VisitPurgeFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RestoreFlow operation middleware
+func (sh *strictHandler) RestoreFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	var request RestoreFlowRequestObject
+
+	request.FlowId = flowId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreFlow(ctx, request.(RestoreFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreFlow")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RestoreFlowResponseObject); ok {
+		if err := validResponse.VisitRestoreFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFlowRuns operation middleware
+func (sh *strictHandler) ListFlowRuns(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, params ListFlowRunsParams) {
+	var request ListFlowRunsRequestObject
+
+	request.FlowId = flowId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFlowRuns(ctx, request.(ListFlowRunsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFlowRuns")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFlowRunsResponseObject); ok {
+		if err := validResponse.VisitListFlowRunsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFlowRunArtifacts operation middleware
+func (sh *strictHandler) ListFlowRunArtifacts(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request ListFlowRunArtifactsRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFlowRunArtifacts(ctx, request.(ListFlowRunArtifactsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFlowRunArtifacts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFlowRunArtifactsResponseObject); ok {
+		if err := validResponse.VisitListFlowRunArtifactsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DownloadFlowRunArtifact operation middleware
+func (sh *strictHandler) DownloadFlowRunArtifact(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID, name string) {
+	var request DownloadFlowRunArtifactRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DownloadFlowRunArtifact(ctx, request.(DownloadFlowRunArtifactRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DownloadFlowRunArtifact")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DownloadFlowRunArtifactResponseObject); ok {
+		if err := validResponse.VisitDownloadFlowRunArtifactResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelFlowRun operation middleware
+func (sh *strictHandler) CancelFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request CancelFlowRunRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	var body CancelFlowRunJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelFlowRun(ctx, request.(CancelFlowRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelFlowRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelFlowRunResponseObject); ok {
+		if err := validResponse.VisitCancelFlowRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListChildFlowRuns operation middleware
+func (sh *strictHandler) ListChildFlowRuns(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request ListChildFlowRunsRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListChildFlowRuns(ctx, request.(ListChildFlowRunsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListChildFlowRuns")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListChildFlowRunsResponseObject); ok {
+		if err := validResponse.VisitListChildFlowRunsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowRunCost operation middleware
+func (sh *strictHandler) GetFlowRunCost(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request GetFlowRunCostRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowRunCost(ctx, request.(GetFlowRunCostRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowRunCost")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowRunCostResponseObject); ok {
+		if err := validResponse.VisitGetFlowRunCostResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowRunGraph operation middleware
+func (sh *strictHandler) GetFlowRunGraph(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request GetFlowRunGraphRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowRunGraph(ctx, request.(GetFlowRunGraphRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowRunGraph")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowRunGraphResponseObject); ok {
+		if err := validResponse.VisitGetFlowRunGraphResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowRunLogs operation middleware
+func (sh *strictHandler) GetFlowRunLogs(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID, params GetFlowRunLogsParams) {
+	var request GetFlowRunLogsRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowRunLogs(ctx, request.(GetFlowRunLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowRunLogs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowRunLogsResponseObject); ok {
+		if err := validResponse.VisitGetFlowRunLogsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PauseFlowRun operation middleware
+func (sh *strictHandler) PauseFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request PauseFlowRunRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PauseFlowRun(ctx, request.(PauseFlowRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PauseFlowRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PauseFlowRunResponseObject); ok {
+		if err := validResponse.VisitPauseFlowRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResumeFlowRun operation middleware
+func (sh *strictHandler) ResumeFlowRun(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request ResumeFlowRunRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResumeFlowRun(ctx, request.(ResumeFlowRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResumeFlowRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResumeFlowRunResponseObject); ok {
+		if err := validResponse.VisitResumeFlowRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowRunTimeline operation middleware
+func (sh *strictHandler) GetFlowRunTimeline(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, runId openapi_types.UUID) {
+	var request GetFlowRunTimelineRequestObject
+
+	request.FlowId = flowId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowRunTimeline(ctx, request.(GetFlowRunTimelineRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowRunTimeline")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowRunTimelineResponseObject); ok {
+		if err := validResponse.VisitGetFlowRunTimelineResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFlowSchedules operation middleware
+func (sh *strictHandler) ListFlowSchedules(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	var request ListFlowSchedulesRequestObject
+
+	request.FlowId = flowId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFlowSchedules(ctx, request.(ListFlowSchedulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFlowSchedules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFlowSchedulesResponseObject); ok {
+		if err := validResponse.VisitListFlowSchedulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateFlowSchedule operation middleware
+func (sh *strictHandler) CreateFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
+	var request CreateFlowScheduleRequestObject
+
+	request.FlowId = flowId
+
+	var body CreateFlowScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFlowSchedule(ctx, request.(CreateFlowScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFlowSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateFlowScheduleResponseObject); ok {
+		if err := validResponse.VisitCreateFlowScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFlowSchedule operation middleware
+func (sh *strictHandler) DeleteFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	var request DeleteFlowScheduleRequestObject
+
+	request.FlowId = flowId
+	request.ScheduleId = scheduleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFlowSchedule(ctx, request.(DeleteFlowScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFlowSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFlowScheduleResponseObject); ok {
+		if err := validResponse.VisitDeleteFlowScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowSchedule operation middleware
+func (sh *strictHandler) GetFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	var request GetFlowScheduleRequestObject
+
+	request.FlowId = flowId
+	request.ScheduleId = scheduleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowSchedule(ctx, request.(GetFlowScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowScheduleResponseObject); ok {
+		if err := validResponse.VisitGetFlowScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateFlowSchedule operation middleware
+func (sh *strictHandler) UpdateFlowSchedule(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	var request UpdateFlowScheduleRequestObject
+
+	request.FlowId = flowId
+	request.ScheduleId = scheduleId
+
+	var body UpdateFlowScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateFlowSchedule(ctx, request.(UpdateFlowScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateFlowSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateFlowScheduleResponseObject); ok {
+		if err := validResponse.VisitUpdateFlowScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFlowScheduleBackfills operation middleware
+func (sh *strictHandler) ListFlowScheduleBackfills(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	var request ListFlowScheduleBackfillsRequestObject
+
+	request.FlowId = flowId
+	request.ScheduleId = scheduleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFlowScheduleBackfills(ctx, request.(ListFlowScheduleBackfillsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFlowScheduleBackfills")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFlowScheduleBackfillsResponseObject); ok {
+		if err := validResponse.VisitListFlowScheduleBackfillsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateFlowScheduleBackfill operation middleware
+func (sh *strictHandler) CreateFlowScheduleBackfill(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID) {
+	var request CreateFlowScheduleBackfillRequestObject
+
+	request.FlowId = flowId
+	request.ScheduleId = scheduleId
+
+	var body CreateFlowScheduleBackfillJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFlowScheduleBackfill(ctx, request.(CreateFlowScheduleBackfillRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFlowScheduleBackfill")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateFlowScheduleBackfillResponseObject); ok {
+		if err := validResponse.VisitCreateFlowScheduleBackfillResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelFlowScheduleBackfill operation middleware
+func (sh *strictHandler) CancelFlowScheduleBackfill(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID, scheduleId openapi_types.UUID, backfillId openapi_types.UUID) {
+	var request CancelFlowScheduleBackfillRequestObject
+
+	request.FlowId = flowId
+	request.ScheduleId = scheduleId
+	request.BackfillId = backfillId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelFlowScheduleBackfill(ctx, request.(CancelFlowScheduleBackfillRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelFlowScheduleBackfill")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelFlowScheduleBackfillResponseObject); ok {
+		if err := validResponse.VisitCancelFlowScheduleBackfillResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlowRun operation middleware
+func (sh *strictHandler) GetFlowRun(w http.ResponseWriter, r *http.Request, flowRunId openapi_types.UUID) {
+	var request GetFlowRunRequestObject
+
+	request.FlowRunId = flowRunId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlowRun(ctx, request.(GetFlowRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlowRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlowRunResponseObject); ok {
+		if err := validResponse.VisitGetFlowRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MockStandaloneTool operation middleware
+func (sh *strictHandler) MockStandaloneTool(w http.ResponseWriter, r *http.Request) {
+	var request MockStandaloneToolRequestObject
+
+	var body MockStandaloneToolJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MockStandaloneTool(ctx, request.(MockStandaloneToolRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MockStandaloneTool")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MockStandaloneToolResponseObject); ok {
+		if err := validResponse.VisitMockStandaloneToolResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MockStandaloneToolWithDelay operation middleware
+func (sh *strictHandler) MockStandaloneToolWithDelay(w http.ResponseWriter, r *http.Request) {
+	var request MockStandaloneToolWithDelayRequestObject
+
+	var body MockStandaloneToolWithDelayJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MockStandaloneToolWithDelay(ctx, request.(MockStandaloneToolWithDelayRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MockStandaloneToolWithDelay")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MockStandaloneToolWithDelayResponseObject); ok {
+		if err := validResponse.VisitMockStandaloneToolWithDelayResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListOrganizations operation middleware
+func (sh *strictHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	var request ListOrganizationsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListOrganizations(ctx, request.(ListOrganizationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListOrganizations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListOrganizationsResponseObject); ok {
+		if err := validResponse.VisitListOrganizationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateOrganization operation middleware
+func (sh *strictHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var request CreateOrganizationRequestObject
+
+	var body CreateOrganizationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateOrganization(ctx, request.(CreateOrganizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateOrganization")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateOrganizationResponseObject); ok {
+		if err := validResponse.VisitCreateOrganizationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListOrganizationMembers operation middleware
+func (sh *strictHandler) ListOrganizationMembers(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID) {
+	var request ListOrganizationMembersRequestObject
+
+	request.OrganizationId = organizationId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListOrganizationMembers(ctx, request.(ListOrganizationMembersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListOrganizationMembers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListOrganizationMembersResponseObject); ok {
+		if err := validResponse.VisitListOrganizationMembersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveOrganizationMember operation middleware
+func (sh *strictHandler) RemoveOrganizationMember(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID, userId openapi_types.UUID) {
+	var request RemoveOrganizationMemberRequestObject
+
+	request.OrganizationId = organizationId
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveOrganizationMember(ctx, request.(RemoveOrganizationMemberRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveOrganizationMember")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveOrganizationMemberResponseObject); ok {
+		if err := validResponse.VisitRemoveOrganizationMemberResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetOrganizationMember operation middleware
+func (sh *strictHandler) SetOrganizationMember(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID, userId openapi_types.UUID) {
+	var request SetOrganizationMemberRequestObject
+
+	request.OrganizationId = organizationId
+	request.UserId = userId
+
+	var body SetOrganizationMemberJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetOrganizationMember(ctx, request.(SetOrganizationMemberRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetOrganizationMember")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetOrganizationMemberResponseObject); ok {
+		if err := validResponse.VisitSetOrganizationMemberResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListOrganizationWorkspaces operation middleware
+func (sh *strictHandler) ListOrganizationWorkspaces(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID) {
+	var request ListOrganizationWorkspacesRequestObject
+
+	request.OrganizationId = organizationId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListOrganizationWorkspaces(ctx, request.(ListOrganizationWorkspacesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListOrganizationWorkspaces")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListOrganizationWorkspacesResponseObject); ok {
+		if err := validResponse.VisitListOrganizationWorkspacesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateWorkspace operation middleware
+func (sh *strictHandler) CreateWorkspace(w http.ResponseWriter, r *http.Request, organizationId openapi_types.UUID) {
+	var request CreateWorkspaceRequestObject
+
+	request.OrganizationId = organizationId
+
+	var body CreateWorkspaceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateWorkspace(ctx, request.(CreateWorkspaceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateWorkspace")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateWorkspaceResponseObject); ok {
+		if err := validResponse.VisitCreateWorkspaceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPermissions operation middleware
+func (sh *strictHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	var request ListPermissionsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPermissions(ctx, request.(ListPermissionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPermissions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPermissionsResponseObject); ok {
+		if err := validResponse.VisitListPermissionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreatePermission operation middleware
+func (sh *strictHandler) CreatePermission(w http.ResponseWriter, r *http.Request) {
+	var request CreatePermissionRequestObject
+
+	var body CreatePermissionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePermission(ctx, request.(CreatePermissionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePermission")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreatePermissionResponseObject); ok {
+		if err := validResponse.VisitCreatePermissionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeletePermission operation middleware
+func (sh *strictHandler) DeletePermission(w http.ResponseWriter, r *http.Request, permissionId openapi_types.UUID) {
+	var request DeletePermissionRequestObject
+
+	request.PermissionId = permissionId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeletePermission(ctx, request.(DeletePermissionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeletePermission")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeletePermissionResponseObject); ok {
+		if err := validResponse.VisitDeletePermissionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPermission operation middleware
+func (sh *strictHandler) GetPermission(w http.ResponseWriter, r *http.Request, permissionId openapi_types.UUID) {
+	var request GetPermissionRequestObject
+
+	request.PermissionId = permissionId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPermission(ctx, request.(GetPermissionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPermission")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPermissionResponseObject); ok {
+		if err := validResponse.VisitGetPermissionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdatePermission operation middleware
+func (sh *strictHandler) UpdatePermission(w http.ResponseWriter, r *http.Request, permissionId openapi_types.UUID) {
+	var request UpdatePermissionRequestObject
+
+	request.PermissionId = permissionId
+
+	var body UpdatePermissionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdatePermission(ctx, request.(UpdatePermissionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdatePermission")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdatePermissionResponseObject); ok {
+		if err := validResponse.VisitUpdatePermissionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Quickstart operation middleware
+func (sh *strictHandler) Quickstart(w http.ResponseWriter, r *http.Request, params QuickstartParams) {
+	var request QuickstartRequestObject
+
+	request.Params = params
+
+	var body QuickstartJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Quickstart(ctx, request.(QuickstartRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Quickstart")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(QuickstartResponseObject); ok {
+		if err := validResponse.VisitQuickstartResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListRoles operation middleware
+func (sh *strictHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	var request ListRolesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListRoles(ctx, request.(ListRolesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListRoles")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListRolesResponseObject); ok {
+		if err := validResponse.VisitListRolesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateRole operation middleware
+func (sh *strictHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	var request CreateRoleRequestObject
+
+	var body CreateRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateRole(ctx, request.(CreateRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateRoleResponseObject); ok {
+		if err := validResponse.VisitCreateRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteRole operation middleware
+func (sh *strictHandler) DeleteRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	var request DeleteRoleRequestObject
+
+	request.RoleId = roleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteRole(ctx, request.(DeleteRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteRoleResponseObject); ok {
+		if err := validResponse.VisitDeleteRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRole operation middleware
+func (sh *strictHandler) GetRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	var request GetRoleRequestObject
+
+	request.RoleId = roleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRole(ctx, request.(GetRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRoleResponseObject); ok {
+		if err := validResponse.VisitGetRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateRole operation middleware
+func (sh *strictHandler) UpdateRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	var request UpdateRoleRequestObject
+
+	request.RoleId = roleId
+
+	var body UpdateRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateRole(ctx, request.(UpdateRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateRoleResponseObject); ok {
+		if err := validResponse.VisitUpdateRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPermissionsForRole operation middleware
+func (sh *strictHandler) ListPermissionsForRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	var request ListPermissionsForRoleRequestObject
+
+	request.RoleId = roleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPermissionsForRole(ctx, request.(ListPermissionsForRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPermissionsForRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPermissionsForRoleResponseObject); ok {
+		if err := validResponse.VisitListPermissionsForRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddPermissionToRole operation middleware
+func (sh *strictHandler) AddPermissionToRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
+	var request AddPermissionToRoleRequestObject
+
+	request.RoleId = roleId
+
+	var body AddPermissionToRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddPermissionToRole(ctx, request.(AddPermissionToRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPermissionToRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddPermissionToRoleResponseObject); ok {
+		if err := validResponse.VisitAddPermissionToRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemovePermissionFromRole operation middleware
+func (sh *strictHandler) RemovePermissionFromRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID, permissionId openapi_types.UUID) {
+	var request RemovePermissionFromRoleRequestObject
+
+	request.RoleId = roleId
+	request.PermissionId = permissionId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemovePermissionFromRole(ctx, request.(RemovePermissionFromRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemovePermissionFromRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemovePermissionFromRoleResponseObject); ok {
+		if err := validResponse.VisitRemovePermissionFromRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SearchMessages operation middleware
+func (sh *strictHandler) SearchMessages(w http.ResponseWriter, r *http.Request, params SearchMessagesParams) {
+	var request SearchMessagesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchMessages(ctx, request.(SearchMessagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchMessages")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchMessagesResponseObject); ok {
+		if err := validResponse.VisitSearchMessagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SemanticSearchMessages operation middleware
+func (sh *strictHandler) SemanticSearchMessages(w http.ResponseWriter, r *http.Request, params SemanticSearchMessagesParams) {
+	var request SemanticSearchMessagesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SemanticSearchMessages(ctx, request.(SemanticSearchMessagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SemanticSearchMessages")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SemanticSearchMessagesResponseObject); ok {
+		if err := validResponse.VisitSemanticSearchMessagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateServiceAccount operation middleware
+func (sh *strictHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var request CreateServiceAccountRequestObject
+
+	var body CreateServiceAccountJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateServiceAccount(ctx, request.(CreateServiceAccountRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateServiceAccount")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateServiceAccountResponseObject); ok {
+		if err := validResponse.VisitCreateServiceAccountResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTasks operation middleware
+func (sh *strictHandler) ListTasks(w http.ResponseWriter, r *http.Request, params ListTasksParams) {
+	var request ListTasksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTasks(ctx, request.(ListTasksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTasks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTasksResponseObject); ok {
+		if err := validResponse.VisitListTasksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateTask operation middleware
+func (sh *strictHandler) CreateTask(w http.ResponseWriter, r *http.Request, params CreateTaskParams) {
+	var request CreateTaskRequestObject
+
+	request.Params = params
+
+	var body CreateTaskJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTask(ctx, request.(CreateTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTaskResponseObject); ok {
+		if err := validResponse.VisitCreateTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTaskSchedules operation middleware
+func (sh *strictHandler) ListTaskSchedules(w http.ResponseWriter, r *http.Request) {
+	var request ListTaskSchedulesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTaskSchedules(ctx, request.(ListTaskSchedulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTaskSchedules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTaskSchedulesResponseObject); ok {
+		if err := validResponse.VisitListTaskSchedulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateTaskSchedule operation middleware
+func (sh *strictHandler) CreateTaskSchedule(w http.ResponseWriter, r *http.Request) {
+	var request CreateTaskScheduleRequestObject
+
+	var body CreateTaskScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTaskSchedule(ctx, request.(CreateTaskScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTaskSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTaskScheduleResponseObject); ok {
+		if err := validResponse.VisitCreateTaskScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteTaskSchedule operation middleware
+func (sh *strictHandler) DeleteTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID) {
+	var request DeleteTaskScheduleRequestObject
+
+	request.ScheduleId = scheduleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTaskSchedule(ctx, request.(DeleteTaskScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTaskSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteTaskScheduleResponseObject); ok {
+		if err := validResponse.VisitDeleteTaskScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTaskSchedule operation middleware
+func (sh *strictHandler) GetTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID) {
+	var request GetTaskScheduleRequestObject
+
+	request.ScheduleId = scheduleId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTaskSchedule(ctx, request.(GetTaskScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTaskSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTaskScheduleResponseObject); ok {
+		if err := validResponse.VisitGetTaskScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateTaskSchedule operation middleware
+func (sh *strictHandler) UpdateTaskSchedule(w http.ResponseWriter, r *http.Request, scheduleId openapi_types.UUID) {
+	var request UpdateTaskScheduleRequestObject
+
+	request.ScheduleId = scheduleId
+
+	var body UpdateTaskScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateTaskSchedule(ctx, request.(UpdateTaskScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateTaskSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateTaskScheduleResponseObject); ok {
+		if err := validResponse.VisitUpdateTaskScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteTask operation middleware
+func (sh *strictHandler) DeleteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
+	var request DeleteTaskRequestObject
+
+	request.TaskId = taskId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTask(ctx, request.(DeleteTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteTaskResponseObject); ok {
+		if err := validResponse.VisitDeleteTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTask operation middleware
+func (sh *strictHandler) GetTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
+	var request GetTaskRequestObject
+
+	request.TaskId = taskId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTask(ctx, request.(GetTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTaskResponseObject); ok {
+		if err := validResponse.VisitGetTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateTask operation middleware
+func (sh *strictHandler) UpdateTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
+	var request UpdateTaskRequestObject
+
+	request.TaskId = taskId
+
+	var body UpdateTaskJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateTask(ctx, request.(UpdateTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateTaskResponseObject); ok {
+		if err := validResponse.VisitUpdateTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExecuteTask operation middleware
+func (sh *strictHandler) ExecuteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, params ExecuteTaskParams) {
+	var request ExecuteTaskRequestObject
+
+	request.TaskId = taskId
+	request.Params = params
+
+	var body ExecuteTaskJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExecuteTask(ctx, request.(ExecuteTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExecuteTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExecuteTaskResponseObject); ok {
+		if err := validResponse.VisitExecuteTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTaskRuns operation middleware
+func (sh *strictHandler) ListTaskRuns(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
+	var request ListTaskRunsRequestObject
+
+	request.TaskId = taskId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTaskRuns(ctx, request.(ListTaskRunsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTaskRuns")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTaskRunsResponseObject); ok {
+		if err := validResponse.VisitListTaskRunsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelTaskRun operation middleware
+func (sh *strictHandler) CancelTaskRun(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, runId openapi_types.UUID) {
+	var request CancelTaskRunRequestObject
+
+	request.TaskId = taskId
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelTaskRun(ctx, request.(CancelTaskRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelTaskRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelTaskRunResponseObject); ok {
+		if err := validResponse.VisitCancelTaskRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// StreamTask operation middleware
+func (sh *strictHandler) StreamTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID, params StreamTaskParams) {
+	var request StreamTaskRequestObject
+
+	request.TaskId = taskId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamTask(ctx, request.(StreamTaskRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamTask")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StreamTaskResponseObject); ok {
+		if err := validResponse.VisitStreamTaskResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTaskRun operation middleware
+func (sh *strictHandler) GetTaskRun(w http.ResponseWriter, r *http.Request, taskRunId openapi_types.UUID) {
+	var request GetTaskRunRequestObject
+
+	request.TaskRunId = taskRunId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTaskRun(ctx, request.(GetTaskRunRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTaskRun")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTaskRunResponseObject); ok {
+		if err := validResponse.VisitGetTaskRunResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListThreads operation middleware
+func (sh *strictHandler) ListThreads(w http.ResponseWriter, r *http.Request, params ListThreadsParams) {
+	var request ListThreadsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListThreads(ctx, request.(ListThreadsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListThreads")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListThreadsResponseObject); ok {
+		if err := validResponse.VisitListThreadsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateThread operation middleware
+func (sh *strictHandler) CreateThread(w http.ResponseWriter, r *http.Request) {
+	var request CreateThreadRequestObject
+
+	var body CreateThreadJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateThread(ctx, request.(CreateThreadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateThread")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateThreadResponseObject); ok {
+		if err := validResponse.VisitCreateThreadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportThread operation middleware
+func (sh *strictHandler) ImportThread(w http.ResponseWriter, r *http.Request, params ImportThreadParams) {
+	var request ImportThreadRequestObject
+
+	request.Params = params
+
+	var body ImportThreadJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportThread(ctx, request.(ImportThreadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportThread")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportThreadResponseObject); ok {
+		if err := validResponse.VisitImportThreadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteThread operation middleware
+func (sh *strictHandler) DeleteThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request DeleteThreadRequestObject
+
+	request.ThreadId = threadId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteThread(ctx, request.(DeleteThreadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteThread")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteThreadResponseObject); ok {
+		if err := validResponse.VisitDeleteThreadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// GetThread operation middleware
+func (sh *strictHandler) GetThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request GetThreadRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	request.ThreadId = threadId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetThread(ctx, request.(GetThreadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetThread")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetThreadResponseObject); ok {
+		if err := validResponse.VisitGetThreadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
+// UpdateThreadTitle operation middleware
+func (sh *strictHandler) UpdateThreadTitle(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params UpdateThreadTitleParams) {
+	var request UpdateThreadTitleRequestObject
+
+	request.ThreadId = threadId
+	request.Params = params
+
+	var body UpdateThreadTitleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateThreadTitle(ctx, request.(UpdateThreadTitleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateThreadTitle")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateThreadTitleResponseObject); ok {
+		if err := validResponse.VisitUpdateThreadTitleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+// StreamThreadEvents operation middleware
+func (sh *strictHandler) StreamThreadEvents(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params StreamThreadEventsParams) {
+	var request StreamThreadEventsRequestObject
+
+	request.ThreadId = threadId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamThreadEvents(ctx, request.(StreamThreadEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamThreadEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StreamThreadEventsResponseObject); ok {
+		if err := validResponse.VisitStreamThreadEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+// ExportThread operation middleware
+func (sh *strictHandler) ExportThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ExportThreadParams) {
+	var request ExportThreadRequestObject
+
+	request.ThreadId = threadId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportThread(ctx, request.(ExportThreadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportThread")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportThreadResponseObject); ok {
+		if err := validResponse.VisitExportThreadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// ListAgents operation middleware
-func (sh *strictHandler) ListAgents(w http.ResponseWriter, r *http.Request) {
-	var request ListAgentsRequestObject
+// ForkThread operation middleware
+func (sh *strictHandler) ForkThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ForkThreadParams) {
+	var request ForkThreadRequestObject
+
+	request.ThreadId = threadId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListAgents(ctx, request.(ListAgentsRequestObject))
+		return sh.ssi.ForkThread(ctx, request.(ForkThreadRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListAgents")
+		handler = middleware(handler, "ForkThread")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListAgentsResponseObject); ok {
-		if err := validResponse.VisitListAgentsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ForkThreadResponseObject); ok {
+		if err := validResponse.VisitForkThreadResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -4808,11 +20775,160 @@ func (sh *strictHandler) ListAgents(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateAgent operation middleware
-func (sh *strictHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
-	var request CreateAgentRequestObject
+// ListThreadGrants operation middleware
+func (sh *strictHandler) ListThreadGrants(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request ListThreadGrantsRequestObject
+
+	request.ThreadId = threadId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListThreadGrants(ctx, request.(ListThreadGrantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListThreadGrants")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListThreadGrantsResponseObject); ok {
+		if err := validResponse.VisitListThreadGrantsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetThreadGrant operation middleware
+func (sh *strictHandler) SetThreadGrant(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request SetThreadGrantRequestObject
+
+	request.ThreadId = threadId
+
+	var body SetThreadGrantJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetThreadGrant(ctx, request.(SetThreadGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetThreadGrant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetThreadGrantResponseObject); ok {
+		if err := validResponse.VisitSetThreadGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveThreadGrant operation middleware
+func (sh *strictHandler) RemoveThreadGrant(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID) {
+	var request RemoveThreadGrantRequestObject
+
+	request.ThreadId = threadId
+	request.GranteeType = granteeType
+	request.GranteeId = granteeId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveThreadGrant(ctx, request.(RemoveThreadGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveThreadGrant")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveThreadGrantResponseObject); ok {
+		if err := validResponse.VisitRemoveThreadGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListMessages operation middleware
+func (sh *strictHandler) ListMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, params ListMessagesParams) {
+	var request ListMessagesRequestObject
+
+	request.ThreadId = threadId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListMessages(ctx, request.(ListMessagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListMessages")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListMessagesResponseObject); ok {
+		if err := validResponse.VisitListMessagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateMessage operation middleware
+func (sh *strictHandler) CreateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request CreateMessageRequestObject
+
+	request.ThreadId = threadId
+
+	var body CreateMessageJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateMessage(ctx, request.(CreateMessageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateMessage")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateMessageResponseObject); ok {
+		if err := validResponse.VisitCreateMessageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	var body CreateAgentJSONRequestBody
+// ImportMessages operation middleware
+func (sh *strictHandler) ImportMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request ImportMessagesRequestObject
+
+	request.ThreadId = threadId
+
+	var body ImportMessagesJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -4820,18 +20936,18 @@ func (sh *strictHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateAgent(ctx, request.(CreateAgentRequestObject))
+		return sh.ssi.ImportMessages(ctx, request.(ImportMessagesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateAgent")
+		handler = middleware(handler, "ImportMessages")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateAgentResponseObject); ok {
-		if err := validResponse.VisitCreateAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(ImportMessagesResponseObject); ok {
+		if err := validResponse.VisitImportMessagesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -4839,25 +20955,27 @@ func (sh *strictHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteAgent operation middleware
-func (sh *strictHandler) DeleteAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
-	var request DeleteAgentRequestObject
+// DeleteMessage operation middleware
+func (sh *strictHandler) DeleteMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID, params DeleteMessageParams) {
+	var request DeleteMessageRequestObject
 
-	request.AgentId = agentId
+	request.ThreadId = threadId
+	request.MessageId = messageId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteAgent(ctx, request.(DeleteAgentRequestObject))
+		return sh.ssi.DeleteMessage(ctx, request.(DeleteMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteAgent")
+		handler = middleware(handler, "DeleteMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteAgentResponseObject); ok {
-		if err := validResponse.VisitDeleteAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteMessageResponseObject); ok {
+		if err := validResponse.VisitDeleteMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -4865,25 +20983,26 @@ func (sh *strictHandler) DeleteAgent(w http.ResponseWriter, r *http.Request, age
 	}
 }
 
-// GetAgent operation middleware
-func (sh *strictHandler) GetAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
-	var request GetAgentRequestObject
+// GetMessage operation middleware
+func (sh *strictHandler) GetMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+	var request GetMessageRequestObject
 
-	request.AgentId = agentId
+	request.ThreadId = threadId
+	request.MessageId = messageId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetAgent(ctx, request.(GetAgentRequestObject))
+		return sh.ssi.GetMessage(ctx, request.(GetMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetAgent")
+		handler = middleware(handler, "GetMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetAgentResponseObject); ok {
-		if err := validResponse.VisitGetAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetMessageResponseObject); ok {
+		if err := validResponse.VisitGetMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -4891,13 +21010,14 @@ func (sh *strictHandler) GetAgent(w http.ResponseWriter, r *http.Request, agentI
 	}
 }
 
-// UpdateAgent operation middleware
-func (sh *strictHandler) UpdateAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
-	var request UpdateAgentRequestObject
+// UpdateMessage operation middleware
+func (sh *strictHandler) UpdateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+	var request UpdateMessageRequestObject
 
-	request.AgentId = agentId
+	request.ThreadId = threadId
+	request.MessageId = messageId
 
-	var body UpdateAgentJSONRequestBody
+	var body UpdateMessageJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -4905,18 +21025,18 @@ func (sh *strictHandler) UpdateAgent(w http.ResponseWriter, r *http.Request, age
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateAgent(ctx, request.(UpdateAgentRequestObject))
+		return sh.ssi.UpdateMessage(ctx, request.(UpdateMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateAgent")
+		handler = middleware(handler, "UpdateMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateAgentResponseObject); ok {
-		if err := validResponse.VisitUpdateAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateMessageResponseObject); ok {
+		if err := validResponse.VisitUpdateMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -4924,25 +21044,26 @@ func (sh *strictHandler) UpdateAgent(w http.ResponseWriter, r *http.Request, age
 	}
 }
 
-// ListPermissionsForAgent operation middleware
-func (sh *strictHandler) ListPermissionsForAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
-	var request ListPermissionsForAgentRequestObject
+// ListMessageAttachments operation middleware
+func (sh *strictHandler) ListMessageAttachments(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+	var request ListMessageAttachmentsRequestObject
 
-	request.AgentId = agentId
+	request.ThreadId = threadId
+	request.MessageId = messageId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListPermissionsForAgent(ctx, request.(ListPermissionsForAgentRequestObject))
+		return sh.ssi.ListMessageAttachments(ctx, request.(ListMessageAttachmentsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListPermissionsForAgent")
+		handler = middleware(handler, "ListMessageAttachments")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListPermissionsForAgentResponseObject); ok {
-		if err := validResponse.VisitListPermissionsForAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListMessageAttachmentsResponseObject); ok {
+		if err := validResponse.VisitListMessageAttachmentsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -4950,13 +21071,14 @@ func (sh *strictHandler) ListPermissionsForAgent(w http.ResponseWriter, r *http.
 	}
 }
 
-// AddPermissionToAgent operation middleware
-func (sh *strictHandler) AddPermissionToAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID) {
-	var request AddPermissionToAgentRequestObject
+// EditMessage operation middleware
+func (sh *strictHandler) EditMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+	var request EditMessageRequestObject
 
-	request.AgentId = agentId
+	request.ThreadId = threadId
+	request.MessageId = messageId
 
-	var body AddPermissionToAgentJSONRequestBody
+	var body EditMessageJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -4964,18 +21086,18 @@ func (sh *strictHandler) AddPermissionToAgent(w http.ResponseWriter, r *http.Req
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AddPermissionToAgent(ctx, request.(AddPermissionToAgentRequestObject))
+		return sh.ssi.EditMessage(ctx, request.(EditMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AddPermissionToAgent")
+		handler = middleware(handler, "EditMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AddPermissionToAgentResponseObject); ok {
-		if err := validResponse.VisitAddPermissionToAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(EditMessageResponseObject); ok {
+		if err := validResponse.VisitEditMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -4983,26 +21105,26 @@ func (sh *strictHandler) AddPermissionToAgent(w http.ResponseWriter, r *http.Req
 	}
 }
 
-// RemovePermissionFromAgent operation middleware
-func (sh *strictHandler) RemovePermissionFromAgent(w http.ResponseWriter, r *http.Request, agentId openapi_types.UUID, permissionId openapi_types.UUID) {
-	var request RemovePermissionFromAgentRequestObject
+// DeleteMessageFeedback operation middleware
+func (sh *strictHandler) DeleteMessageFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+	var request DeleteMessageFeedbackRequestObject
 
-	request.AgentId = agentId
-	request.PermissionId = permissionId
+	request.ThreadId = threadId
+	request.MessageId = messageId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RemovePermissionFromAgent(ctx, request.(RemovePermissionFromAgentRequestObject))
+		return sh.ssi.DeleteMessageFeedback(ctx, request.(DeleteMessageFeedbackRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RemovePermissionFromAgent")
+		handler = middleware(handler, "DeleteMessageFeedback")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RemovePermissionFromAgentResponseObject); ok {
-		if err := validResponse.VisitRemovePermissionFromAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteMessageFeedbackResponseObject); ok {
+		if err := validResponse.VisitDeleteMessageFeedbackResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5010,25 +21132,33 @@ func (sh *strictHandler) RemovePermissionFromAgent(w http.ResponseWriter, r *htt
 	}
 }
 
-// ListFlows operation middleware
-func (sh *strictHandler) ListFlows(w http.ResponseWriter, r *http.Request, params ListFlowsParams) {
-	var request ListFlowsRequestObject
+// SetMessageFeedback operation middleware
+func (sh *strictHandler) SetMessageFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+	var request SetMessageFeedbackRequestObject
 
-	request.Params = params
+	request.ThreadId = threadId
+	request.MessageId = messageId
+
+	var body SetMessageFeedbackJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListFlows(ctx, request.(ListFlowsRequestObject))
+		return sh.ssi.SetMessageFeedback(ctx, request.(SetMessageFeedbackRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListFlows")
+		handler = middleware(handler, "SetMessageFeedback")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListFlowsResponseObject); ok {
-		if err := validResponse.VisitListFlowsResponse(w); err != nil {
+	} else if validResponse, ok := response.(SetMessageFeedbackResponseObject); ok {
+		if err := validResponse.VisitSetMessageFeedbackResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5036,11 +21166,14 @@ func (sh *strictHandler) ListFlows(w http.ResponseWriter, r *http.Request, param
 	}
 }
 
-// CreateFlow operation middleware
-func (sh *strictHandler) CreateFlow(w http.ResponseWriter, r *http.Request) {
-	var request CreateFlowRequestObject
+// RegenerateMessage operation middleware
+func (sh *strictHandler) RegenerateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
+	var request RegenerateMessageRequestObject
 
-	var body CreateFlowJSONRequestBody
+	request.ThreadId = threadId
+	request.MessageId = messageId
+
+	var body RegenerateMessageJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5048,18 +21181,18 @@ func (sh *strictHandler) CreateFlow(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateFlow(ctx, request.(CreateFlowRequestObject))
+		return sh.ssi.RegenerateMessage(ctx, request.(RegenerateMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateFlow")
+		handler = middleware(handler, "RegenerateMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateFlowResponseObject); ok {
-		if err := validResponse.VisitCreateFlowResponse(w); err != nil {
+	} else if validResponse, ok := response.(RegenerateMessageResponseObject); ok {
+		if err := validResponse.VisitRegenerateMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5067,25 +21200,25 @@ func (sh *strictHandler) CreateFlow(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteFlow operation middleware
-func (sh *strictHandler) DeleteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
-	var request DeleteFlowRequestObject
+// PurgeThread operation middleware
+func (sh *strictHandler) PurgeThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request PurgeThreadRequestObject
 
-	request.FlowId = flowId
+	request.ThreadId = threadId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteFlow(ctx, request.(DeleteFlowRequestObject))
+		return sh.ssi.PurgeThread(ctx, request.(PurgeThreadRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteFlow")
+		handler = middleware(handler, "PurgeThread")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteFlowResponseObject); ok {
-		if err := validResponse.VisitDeleteFlowResponse(w); err != nil {
+	} else if validResponse, ok := response.(PurgeThreadResponseObject); ok {
+		if err := validResponse.VisitPurgeThreadResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5093,25 +21226,25 @@ func (sh *strictHandler) DeleteFlow(w http.ResponseWriter, r *http.Request, flow
 	}
 }
 
-// GetFlow operation middleware
-func (sh *strictHandler) GetFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
-	var request GetFlowRequestObject
+// RestoreThread operation middleware
+func (sh *strictHandler) RestoreThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
+	var request RestoreThreadRequestObject
 
-	request.FlowId = flowId
+	request.ThreadId = threadId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetFlow(ctx, request.(GetFlowRequestObject))
+		return sh.ssi.RestoreThread(ctx, request.(RestoreThreadRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetFlow")
+		handler = middleware(handler, "RestoreThread")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetFlowResponseObject); ok {
-		if err := validResponse.VisitGetFlowResponse(w); err != nil {
+	} else if validResponse, ok := response.(RestoreThreadResponseObject); ok {
+		if err := validResponse.VisitRestoreThreadResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5119,32 +21252,26 @@ func (sh *strictHandler) GetFlow(w http.ResponseWriter, r *http.Request, flowId
 	}
 }
 
-// UpdateFlow operation middleware
-func (sh *strictHandler) UpdateFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
-	var request UpdateFlowRequestObject
-
-	request.FlowId = flowId
+// DeleteToolRunFeedback operation middleware
+func (sh *strictHandler) DeleteToolRunFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, toolRunId string) {
+	var request DeleteToolRunFeedbackRequestObject
 
-	var body UpdateFlowJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.ThreadId = threadId
+	request.ToolRunId = toolRunId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateFlow(ctx, request.(UpdateFlowRequestObject))
+		return sh.ssi.DeleteToolRunFeedback(ctx, request.(DeleteToolRunFeedbackRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateFlow")
+		handler = middleware(handler, "DeleteToolRunFeedback")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateFlowResponseObject); ok {
-		if err := validResponse.VisitUpdateFlowResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteToolRunFeedbackResponseObject); ok {
+		if err := validResponse.VisitDeleteToolRunFeedbackResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5152,13 +21279,14 @@ func (sh *strictHandler) UpdateFlow(w http.ResponseWriter, r *http.Request, flow
 	}
 }
 
-// ExecuteFlow operation middleware
-func (sh *strictHandler) ExecuteFlow(w http.ResponseWriter, r *http.Request, flowId openapi_types.UUID) {
-	var request ExecuteFlowRequestObject
+// SetToolRunFeedback operation middleware
+func (sh *strictHandler) SetToolRunFeedback(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, toolRunId string) {
+	var request SetToolRunFeedbackRequestObject
 
-	request.FlowId = flowId
+	request.ThreadId = threadId
+	request.ToolRunId = toolRunId
 
-	var body ExecuteFlowJSONRequestBody
+	var body SetToolRunFeedbackJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5166,18 +21294,18 @@ func (sh *strictHandler) ExecuteFlow(w http.ResponseWriter, r *http.Request, flo
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ExecuteFlow(ctx, request.(ExecuteFlowRequestObject))
+		return sh.ssi.SetToolRunFeedback(ctx, request.(SetToolRunFeedbackRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ExecuteFlow")
+		handler = middleware(handler, "SetToolRunFeedback")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ExecuteFlowResponseObject); ok {
-		if err := validResponse.VisitExecuteFlowResponse(w); err != nil {
+	} else if validResponse, ok := response.(SetToolRunFeedbackResponseObject); ok {
+		if err := validResponse.VisitSetToolRunFeedbackResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5185,25 +21313,25 @@ func (sh *strictHandler) ExecuteFlow(w http.ResponseWriter, r *http.Request, flo
 	}
 }
 
-// GetFlowRun operation middleware
-func (sh *strictHandler) GetFlowRun(w http.ResponseWriter, r *http.Request, flowRunId openapi_types.UUID) {
-	var request GetFlowRunRequestObject
+// ListTools operation middleware
+func (sh *strictHandler) ListTools(w http.ResponseWriter, r *http.Request, params ListToolsParams) {
+	var request ListToolsRequestObject
 
-	request.FlowRunId = flowRunId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetFlowRun(ctx, request.(GetFlowRunRequestObject))
+		return sh.ssi.ListTools(ctx, request.(ListToolsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetFlowRun")
+		handler = middleware(handler, "ListTools")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetFlowRunResponseObject); ok {
-		if err := validResponse.VisitGetFlowRunResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListToolsResponseObject); ok {
+		if err := validResponse.VisitListToolsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5211,11 +21339,13 @@ func (sh *strictHandler) GetFlowRun(w http.ResponseWriter, r *http.Request, flow
 	}
 }
 
-// MockStandaloneTool operation middleware
-func (sh *strictHandler) MockStandaloneTool(w http.ResponseWriter, r *http.Request) {
-	var request MockStandaloneToolRequestObject
+// CreateTool operation middleware
+func (sh *strictHandler) CreateTool(w http.ResponseWriter, r *http.Request, params CreateToolParams) {
+	var request CreateToolRequestObject
 
-	var body MockStandaloneToolJSONRequestBody
+	request.Params = params
+
+	var body CreateToolJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5223,18 +21353,18 @@ func (sh *strictHandler) MockStandaloneTool(w http.ResponseWriter, r *http.Reque
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.MockStandaloneTool(ctx, request.(MockStandaloneToolRequestObject))
+		return sh.ssi.CreateTool(ctx, request.(CreateToolRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "MockStandaloneTool")
+		handler = middleware(handler, "CreateTool")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(MockStandaloneToolResponseObject); ok {
-		if err := validResponse.VisitMockStandaloneToolResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateToolResponseObject); ok {
+		if err := validResponse.VisitCreateToolResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5242,11 +21372,11 @@ func (sh *strictHandler) MockStandaloneTool(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// MockStandaloneToolWithDelay operation middleware
-func (sh *strictHandler) MockStandaloneToolWithDelay(w http.ResponseWriter, r *http.Request) {
-	var request MockStandaloneToolWithDelayRequestObject
+// BatchCreateTools operation middleware
+func (sh *strictHandler) BatchCreateTools(w http.ResponseWriter, r *http.Request) {
+	var request BatchCreateToolsRequestObject
 
-	var body MockStandaloneToolWithDelayJSONRequestBody
+	var body BatchCreateToolsJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5254,42 +21384,18 @@ func (sh *strictHandler) MockStandaloneToolWithDelay(w http.ResponseWriter, r *h
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.MockStandaloneToolWithDelay(ctx, request.(MockStandaloneToolWithDelayRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "MockStandaloneToolWithDelay")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(MockStandaloneToolWithDelayResponseObject); ok {
-		if err := validResponse.VisitMockStandaloneToolWithDelayResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
-// ListPermissions operation middleware
-func (sh *strictHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
-	var request ListPermissionsRequestObject
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListPermissions(ctx, request.(ListPermissionsRequestObject))
+		return sh.ssi.BatchCreateTools(ctx, request.(BatchCreateToolsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListPermissions")
+		handler = middleware(handler, "BatchCreateTools")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListPermissionsResponseObject); ok {
-		if err := validResponse.VisitListPermissionsResponse(w); err != nil {
+	} else if validResponse, ok := response.(BatchCreateToolsResponseObject); ok {
+		if err := validResponse.VisitBatchCreateToolsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5297,11 +21403,11 @@ func (sh *strictHandler) ListPermissions(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// CreatePermission operation middleware
-func (sh *strictHandler) CreatePermission(w http.ResponseWriter, r *http.Request) {
-	var request CreatePermissionRequestObject
+// BatchDeleteTools operation middleware
+func (sh *strictHandler) BatchDeleteTools(w http.ResponseWriter, r *http.Request) {
+	var request BatchDeleteToolsRequestObject
 
-	var body CreatePermissionJSONRequestBody
+	var body BatchDeleteToolsJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5309,18 +21415,18 @@ func (sh *strictHandler) CreatePermission(w http.ResponseWriter, r *http.Request
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreatePermission(ctx, request.(CreatePermissionRequestObject))
+		return sh.ssi.BatchDeleteTools(ctx, request.(BatchDeleteToolsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreatePermission")
+		handler = middleware(handler, "BatchDeleteTools")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreatePermissionResponseObject); ok {
-		if err := validResponse.VisitCreatePermissionResponse(w); err != nil {
+	} else if validResponse, ok := response.(BatchDeleteToolsResponseObject); ok {
+		if err := validResponse.VisitBatchDeleteToolsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5328,25 +21434,30 @@ func (sh *strictHandler) CreatePermission(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// DeletePermission operation middleware
-func (sh *strictHandler) DeletePermission(w http.ResponseWriter, r *http.Request, permissionId openapi_types.UUID) {
-	var request DeletePermissionRequestObject
+// BatchUpdateTools operation middleware
+func (sh *strictHandler) BatchUpdateTools(w http.ResponseWriter, r *http.Request) {
+	var request BatchUpdateToolsRequestObject
 
-	request.PermissionId = permissionId
+	var body BatchUpdateToolsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeletePermission(ctx, request.(DeletePermissionRequestObject))
+		return sh.ssi.BatchUpdateTools(ctx, request.(BatchUpdateToolsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeletePermission")
+		handler = middleware(handler, "BatchUpdateTools")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeletePermissionResponseObject); ok {
-		if err := validResponse.VisitDeletePermissionResponse(w); err != nil {
+	} else if validResponse, ok := response.(BatchUpdateToolsResponseObject); ok {
+		if err := validResponse.VisitBatchUpdateToolsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5354,25 +21465,25 @@ func (sh *strictHandler) DeletePermission(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetPermission operation middleware
-func (sh *strictHandler) GetPermission(w http.ResponseWriter, r *http.Request, permissionId openapi_types.UUID) {
-	var request GetPermissionRequestObject
+// GetToolCatalog operation middleware
+func (sh *strictHandler) GetToolCatalog(w http.ResponseWriter, r *http.Request, params GetToolCatalogParams) {
+	var request GetToolCatalogRequestObject
 
-	request.PermissionId = permissionId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetPermission(ctx, request.(GetPermissionRequestObject))
+		return sh.ssi.GetToolCatalog(ctx, request.(GetToolCatalogRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetPermission")
+		handler = middleware(handler, "GetToolCatalog")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetPermissionResponseObject); ok {
-		if err := validResponse.VisitGetPermissionResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetToolCatalogResponseObject); ok {
+		if err := validResponse.VisitGetToolCatalogResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5380,13 +21491,11 @@ func (sh *strictHandler) GetPermission(w http.ResponseWriter, r *http.Request, p
 	}
 }
 
-// UpdatePermission operation middleware
-func (sh *strictHandler) UpdatePermission(w http.ResponseWriter, r *http.Request, permissionId openapi_types.UUID) {
-	var request UpdatePermissionRequestObject
-
-	request.PermissionId = permissionId
+// CreateToolFromDefinition operation middleware
+func (sh *strictHandler) CreateToolFromDefinition(w http.ResponseWriter, r *http.Request) {
+	var request CreateToolFromDefinitionRequestObject
 
-	var body UpdatePermissionJSONRequestBody
+	var body CreateToolFromDefinitionJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5394,18 +21503,18 @@ func (sh *strictHandler) UpdatePermission(w http.ResponseWriter, r *http.Request
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdatePermission(ctx, request.(UpdatePermissionRequestObject))
+		return sh.ssi.CreateToolFromDefinition(ctx, request.(CreateToolFromDefinitionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdatePermission")
+		handler = middleware(handler, "CreateToolFromDefinition")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdatePermissionResponseObject); ok {
-		if err := validResponse.VisitUpdatePermissionResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateToolFromDefinitionResponseObject); ok {
+		if err := validResponse.VisitCreateToolFromDefinitionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5413,23 +21522,25 @@ func (sh *strictHandler) UpdatePermission(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// ListRoles operation middleware
-func (sh *strictHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
-	var request ListRolesRequestObject
+// ExportTools operation middleware
+func (sh *strictHandler) ExportTools(w http.ResponseWriter, r *http.Request, params ExportToolsParams) {
+	var request ExportToolsRequestObject
+
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListRoles(ctx, request.(ListRolesRequestObject))
+		return sh.ssi.ExportTools(ctx, request.(ExportToolsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListRoles")
+		handler = middleware(handler, "ExportTools")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListRolesResponseObject); ok {
-		if err := validResponse.VisitListRolesResponse(w); err != nil {
+	} else if validResponse, ok := response.(ExportToolsResponseObject); ok {
+		if err := validResponse.VisitExportToolsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5437,11 +21548,13 @@ func (sh *strictHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateRole operation middleware
-func (sh *strictHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
-	var request CreateRoleRequestObject
+// ImportTools operation middleware
+func (sh *strictHandler) ImportTools(w http.ResponseWriter, r *http.Request, params ImportToolsParams) {
+	var request ImportToolsRequestObject
 
-	var body CreateRoleJSONRequestBody
+	request.Params = params
+
+	var body ImportToolsJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5449,18 +21562,18 @@ func (sh *strictHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateRole(ctx, request.(CreateRoleRequestObject))
+		return sh.ssi.ImportTools(ctx, request.(ImportToolsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateRole")
+		handler = middleware(handler, "ImportTools")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateRoleResponseObject); ok {
-		if err := validResponse.VisitCreateRoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(ImportToolsResponseObject); ok {
+		if err := validResponse.VisitImportToolsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5468,25 +21581,25 @@ func (sh *strictHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteRole operation middleware
-func (sh *strictHandler) DeleteRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
-	var request DeleteRoleRequestObject
+// DeleteTool operation middleware
+func (sh *strictHandler) DeleteTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request DeleteToolRequestObject
 
-	request.RoleId = roleId
+	request.ToolId = toolId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteRole(ctx, request.(DeleteRoleRequestObject))
+		return sh.ssi.DeleteTool(ctx, request.(DeleteToolRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteRole")
+		handler = middleware(handler, "DeleteTool")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteRoleResponseObject); ok {
-		if err := validResponse.VisitDeleteRoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteToolResponseObject); ok {
+		if err := validResponse.VisitDeleteToolResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5494,25 +21607,25 @@ func (sh *strictHandler) DeleteRole(w http.ResponseWriter, r *http.Request, role
 	}
 }
 
-// GetRole operation middleware
-func (sh *strictHandler) GetRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
-	var request GetRoleRequestObject
+// GetToolById operation middleware
+func (sh *strictHandler) GetToolById(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request GetToolByIdRequestObject
 
-	request.RoleId = roleId
+	request.ToolId = toolId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetRole(ctx, request.(GetRoleRequestObject))
+		return sh.ssi.GetToolById(ctx, request.(GetToolByIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetRole")
+		handler = middleware(handler, "GetToolById")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetRoleResponseObject); ok {
-		if err := validResponse.VisitGetRoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetToolByIdResponseObject); ok {
+		if err := validResponse.VisitGetToolByIdResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5520,13 +21633,14 @@ func (sh *strictHandler) GetRole(w http.ResponseWriter, r *http.Request, roleId
 	}
 }
 
-// UpdateRole operation middleware
-func (sh *strictHandler) UpdateRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
-	var request UpdateRoleRequestObject
+// UpdateTool operation middleware
+func (sh *strictHandler) UpdateTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params UpdateToolParams) {
+	var request UpdateToolRequestObject
 
-	request.RoleId = roleId
+	request.ToolId = toolId
+	request.Params = params
 
-	var body UpdateRoleJSONRequestBody
+	var body UpdateToolJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5534,18 +21648,18 @@ func (sh *strictHandler) UpdateRole(w http.ResponseWriter, r *http.Request, role
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateRole(ctx, request.(UpdateRoleRequestObject))
+		return sh.ssi.UpdateTool(ctx, request.(UpdateToolRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateRole")
+		handler = middleware(handler, "UpdateTool")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateRoleResponseObject); ok {
-		if err := validResponse.VisitUpdateRoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateToolResponseObject); ok {
+		if err := validResponse.VisitUpdateToolResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5553,25 +21667,25 @@ func (sh *strictHandler) UpdateRole(w http.ResponseWriter, r *http.Request, role
 	}
 }
 
-// ListPermissionsForRole operation middleware
-func (sh *strictHandler) ListPermissionsForRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
-	var request ListPermissionsForRoleRequestObject
+// ListToolGrants operation middleware
+func (sh *strictHandler) ListToolGrants(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request ListToolGrantsRequestObject
 
-	request.RoleId = roleId
+	request.ToolId = toolId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListPermissionsForRole(ctx, request.(ListPermissionsForRoleRequestObject))
+		return sh.ssi.ListToolGrants(ctx, request.(ListToolGrantsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListPermissionsForRole")
+		handler = middleware(handler, "ListToolGrants")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListPermissionsForRoleResponseObject); ok {
-		if err := validResponse.VisitListPermissionsForRoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListToolGrantsResponseObject); ok {
+		if err := validResponse.VisitListToolGrantsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5579,13 +21693,13 @@ func (sh *strictHandler) ListPermissionsForRole(w http.ResponseWriter, r *http.R
 	}
 }
 
-// AddPermissionToRole operation middleware
-func (sh *strictHandler) AddPermissionToRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID) {
-	var request AddPermissionToRoleRequestObject
+// SetToolGrant operation middleware
+func (sh *strictHandler) SetToolGrant(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request SetToolGrantRequestObject
 
-	request.RoleId = roleId
+	request.ToolId = toolId
 
-	var body AddPermissionToRoleJSONRequestBody
+	var body SetToolGrantJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5593,18 +21707,18 @@ func (sh *strictHandler) AddPermissionToRole(w http.ResponseWriter, r *http.Requ
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AddPermissionToRole(ctx, request.(AddPermissionToRoleRequestObject))
+		return sh.ssi.SetToolGrant(ctx, request.(SetToolGrantRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AddPermissionToRole")
+		handler = middleware(handler, "SetToolGrant")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AddPermissionToRoleResponseObject); ok {
-		if err := validResponse.VisitAddPermissionToRoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(SetToolGrantResponseObject); ok {
+		if err := validResponse.VisitSetToolGrantResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5612,26 +21726,27 @@ func (sh *strictHandler) AddPermissionToRole(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// RemovePermissionFromRole operation middleware
-func (sh *strictHandler) RemovePermissionFromRole(w http.ResponseWriter, r *http.Request, roleId openapi_types.UUID, permissionId openapi_types.UUID) {
-	var request RemovePermissionFromRoleRequestObject
+// RemoveToolGrant operation middleware
+func (sh *strictHandler) RemoveToolGrant(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, granteeType GranteeType, granteeId openapi_types.UUID) {
+	var request RemoveToolGrantRequestObject
 
-	request.RoleId = roleId
-	request.PermissionId = permissionId
+	request.ToolId = toolId
+	request.GranteeType = granteeType
+	request.GranteeId = granteeId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RemovePermissionFromRole(ctx, request.(RemovePermissionFromRoleRequestObject))
+		return sh.ssi.RemoveToolGrant(ctx, request.(RemoveToolGrantRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RemovePermissionFromRole")
+		handler = middleware(handler, "RemoveToolGrant")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RemovePermissionFromRoleResponseObject); ok {
-		if err := validResponse.VisitRemovePermissionFromRoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(RemoveToolGrantResponseObject); ok {
+		if err := validResponse.VisitRemoveToolGrantResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5639,25 +21754,26 @@ func (sh *strictHandler) RemovePermissionFromRole(w http.ResponseWriter, r *http
 	}
 }
 
-// ListTasks operation middleware
-func (sh *strictHandler) ListTasks(w http.ResponseWriter, r *http.Request, params ListTasksParams) {
-	var request ListTasksRequestObject
+// GetToolHistory operation middleware
+func (sh *strictHandler) GetToolHistory(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params GetToolHistoryParams) {
+	var request GetToolHistoryRequestObject
 
+	request.ToolId = toolId
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListTasks(ctx, request.(ListTasksRequestObject))
+		return sh.ssi.GetToolHistory(ctx, request.(GetToolHistoryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListTasks")
+		handler = middleware(handler, "GetToolHistory")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListTasksResponseObject); ok {
-		if err := validResponse.VisitListTasksResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetToolHistoryResponseObject); ok {
+		if err := validResponse.VisitGetToolHistoryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5665,30 +21781,25 @@ func (sh *strictHandler) ListTasks(w http.ResponseWriter, r *http.Request, param
 	}
 }
 
-// CreateTask operation middleware
-func (sh *strictHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
-	var request CreateTaskRequestObject
+// PurgeTool operation middleware
+func (sh *strictHandler) PurgeTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request PurgeToolRequestObject
 
-	var body CreateTaskJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.ToolId = toolId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateTask(ctx, request.(CreateTaskRequestObject))
+		return sh.ssi.PurgeTool(ctx, request.(PurgeToolRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateTask")
+		handler = middleware(handler, "PurgeTool")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateTaskResponseObject); ok {
-		if err := validResponse.VisitCreateTaskResponse(w); err != nil {
+	} else if validResponse, ok := response.(PurgeToolResponseObject); ok {
+		if err := validResponse.VisitPurgeToolResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5696,25 +21807,25 @@ func (sh *strictHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteTask operation middleware
-func (sh *strictHandler) DeleteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
-	var request DeleteTaskRequestObject
+// RestoreTool operation middleware
+func (sh *strictHandler) RestoreTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request RestoreToolRequestObject
 
-	request.TaskId = taskId
+	request.ToolId = toolId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteTask(ctx, request.(DeleteTaskRequestObject))
+		return sh.ssi.RestoreTool(ctx, request.(RestoreToolRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteTask")
+		handler = middleware(handler, "RestoreTool")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteTaskResponseObject); ok {
-		if err := validResponse.VisitDeleteTaskResponse(w); err != nil {
+	} else if validResponse, ok := response.(RestoreToolResponseObject); ok {
+		if err := validResponse.VisitRestoreToolResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5722,25 +21833,25 @@ func (sh *strictHandler) DeleteTask(w http.ResponseWriter, r *http.Request, task
 	}
 }
 
-// GetTask operation middleware
-func (sh *strictHandler) GetTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
-	var request GetTaskRequestObject
+// ListToolRevisions operation middleware
+func (sh *strictHandler) ListToolRevisions(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request ListToolRevisionsRequestObject
 
-	request.TaskId = taskId
+	request.ToolId = toolId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetTask(ctx, request.(GetTaskRequestObject))
+		return sh.ssi.ListToolRevisions(ctx, request.(ListToolRevisionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetTask")
+		handler = middleware(handler, "ListToolRevisions")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetTaskResponseObject); ok {
-		if err := validResponse.VisitGetTaskResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListToolRevisionsResponseObject); ok {
+		if err := validResponse.VisitListToolRevisionsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5748,32 +21859,26 @@ func (sh *strictHandler) GetTask(w http.ResponseWriter, r *http.Request, taskId
 	}
 }
 
-// UpdateTask operation middleware
-func (sh *strictHandler) UpdateTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
-	var request UpdateTaskRequestObject
-
-	request.TaskId = taskId
+// DiffToolRevisions operation middleware
+func (sh *strictHandler) DiffToolRevisions(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, params DiffToolRevisionsParams) {
+	var request DiffToolRevisionsRequestObject
 
-	var body UpdateTaskJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.ToolId = toolId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateTask(ctx, request.(UpdateTaskRequestObject))
+		return sh.ssi.DiffToolRevisions(ctx, request.(DiffToolRevisionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateTask")
+		handler = middleware(handler, "DiffToolRevisions")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateTaskResponseObject); ok {
-		if err := validResponse.VisitUpdateTaskResponse(w); err != nil {
+	} else if validResponse, ok := response.(DiffToolRevisionsResponseObject); ok {
+		if err := validResponse.VisitDiffToolRevisionsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5781,32 +21886,26 @@ func (sh *strictHandler) UpdateTask(w http.ResponseWriter, r *http.Request, task
 	}
 }
 
-// ExecuteTask operation middleware
-func (sh *strictHandler) ExecuteTask(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
-	var request ExecuteTaskRequestObject
-
-	request.TaskId = taskId
+// PromoteToolRevision operation middleware
+func (sh *strictHandler) PromoteToolRevision(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID, revision int32) {
+	var request PromoteToolRevisionRequestObject
 
-	var body ExecuteTaskJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.ToolId = toolId
+	request.Revision = revision
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ExecuteTask(ctx, request.(ExecuteTaskRequestObject))
+		return sh.ssi.PromoteToolRevision(ctx, request.(PromoteToolRevisionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ExecuteTask")
+		handler = middleware(handler, "PromoteToolRevision")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ExecuteTaskResponseObject); ok {
-		if err := validResponse.VisitExecuteTaskResponse(w); err != nil {
+	} else if validResponse, ok := response.(PromoteToolRevisionResponseObject); ok {
+		if err := validResponse.VisitPromoteToolRevisionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5814,25 +21913,32 @@ func (sh *strictHandler) ExecuteTask(w http.ResponseWriter, r *http.Request, tas
 	}
 }
 
-// ListTaskRuns operation middleware
-func (sh *strictHandler) ListTaskRuns(w http.ResponseWriter, r *http.Request, taskId openapi_types.UUID) {
-	var request ListTaskRunsRequestObject
+// TestTool operation middleware
+func (sh *strictHandler) TestTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
+	var request TestToolRequestObject
 
-	request.TaskId = taskId
+	request.ToolId = toolId
+
+	var body TestToolJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListTaskRuns(ctx, request.(ListTaskRunsRequestObject))
+		return sh.ssi.TestTool(ctx, request.(TestToolRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListTaskRuns")
+		handler = middleware(handler, "TestTool")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListTaskRunsResponseObject); ok {
-		if err := validResponse.VisitListTaskRunsResponse(w); err != nil {
+	} else if validResponse, ok := response.(TestToolResponseObject); ok {
+		if err := validResponse.VisitTestToolResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5840,25 +21946,23 @@ func (sh *strictHandler) ListTaskRuns(w http.ResponseWriter, r *http.Request, ta
 	}
 }
 
-// GetTaskRun operation middleware
-func (sh *strictHandler) GetTaskRun(w http.ResponseWriter, r *http.Request, taskRunId openapi_types.UUID) {
-	var request GetTaskRunRequestObject
-
-	request.TaskRunId = taskRunId
+// GetUsage operation middleware
+func (sh *strictHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	var request GetUsageRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetTaskRun(ctx, request.(GetTaskRunRequestObject))
+		return sh.ssi.GetUsage(ctx, request.(GetUsageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetTaskRun")
+		handler = middleware(handler, "GetUsage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetTaskRunResponseObject); ok {
-		if err := validResponse.VisitGetTaskRunResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetUsageResponseObject); ok {
+		if err := validResponse.VisitGetUsageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5866,23 +21970,23 @@ func (sh *strictHandler) GetTaskRun(w http.ResponseWriter, r *http.Request, task
 	}
 }
 
-// ListThreads operation middleware
-func (sh *strictHandler) ListThreads(w http.ResponseWriter, r *http.Request) {
-	var request ListThreadsRequestObject
+// ListUsers operation middleware
+func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	var request ListUsersRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListThreads(ctx, request.(ListThreadsRequestObject))
+		return sh.ssi.ListUsers(ctx, request.(ListUsersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListThreads")
+		handler = middleware(handler, "ListUsers")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListThreadsResponseObject); ok {
-		if err := validResponse.VisitListThreadsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListUsersResponseObject); ok {
+		if err := validResponse.VisitListUsersResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5890,11 +21994,11 @@ func (sh *strictHandler) ListThreads(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateThread operation middleware
-func (sh *strictHandler) CreateThread(w http.ResponseWriter, r *http.Request) {
-	var request CreateThreadRequestObject
+// CreateUser operation middleware
+func (sh *strictHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var request CreateUserRequestObject
 
-	var body CreateThreadJSONRequestBody
+	var body CreateUserJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5902,18 +22006,18 @@ func (sh *strictHandler) CreateThread(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateThread(ctx, request.(CreateThreadRequestObject))
+		return sh.ssi.CreateUser(ctx, request.(CreateUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateThread")
+		handler = middleware(handler, "CreateUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateThreadResponseObject); ok {
-		if err := validResponse.VisitCreateThreadResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateUserResponseObject); ok {
+		if err := validResponse.VisitCreateUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5921,25 +22025,25 @@ func (sh *strictHandler) CreateThread(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteThread operation middleware
-func (sh *strictHandler) DeleteThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
-	var request DeleteThreadRequestObject
+// DeleteUser operation middleware
+func (sh *strictHandler) DeleteUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request DeleteUserRequestObject
 
-	request.ThreadId = threadId
+	request.UserId = userId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteThread(ctx, request.(DeleteThreadRequestObject))
+		return sh.ssi.DeleteUser(ctx, request.(DeleteUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteThread")
+		handler = middleware(handler, "DeleteUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteThreadResponseObject); ok {
-		if err := validResponse.VisitDeleteThreadResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteUserResponseObject); ok {
+		if err := validResponse.VisitDeleteUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5947,25 +22051,25 @@ func (sh *strictHandler) DeleteThread(w http.ResponseWriter, r *http.Request, th
 	}
 }
 
-// GetThread operation middleware
-func (sh *strictHandler) GetThread(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
-	var request GetThreadRequestObject
+// GetUser operation middleware
+func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request GetUserRequestObject
 
-	request.ThreadId = threadId
+	request.UserId = userId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetThread(ctx, request.(GetThreadRequestObject))
+		return sh.ssi.GetUser(ctx, request.(GetUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetThread")
+		handler = middleware(handler, "GetUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetThreadResponseObject); ok {
-		if err := validResponse.VisitGetThreadResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetUserResponseObject); ok {
+		if err := validResponse.VisitGetUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5973,13 +22077,13 @@ func (sh *strictHandler) GetThread(w http.ResponseWriter, r *http.Request, threa
 	}
 }
 
-// UpdateThreadTitle operation middleware
-func (sh *strictHandler) UpdateThreadTitle(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
-	var request UpdateThreadTitleRequestObject
+// UpdateUser operation middleware
+func (sh *strictHandler) UpdateUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request UpdateUserRequestObject
 
-	request.ThreadId = threadId
+	request.UserId = userId
 
-	var body UpdateThreadTitleJSONRequestBody
+	var body UpdateUserJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5987,18 +22091,18 @@ func (sh *strictHandler) UpdateThreadTitle(w http.ResponseWriter, r *http.Reques
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateThreadTitle(ctx, request.(UpdateThreadTitleRequestObject))
+		return sh.ssi.UpdateUser(ctx, request.(UpdateUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateThreadTitle")
+		handler = middleware(handler, "UpdateUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateThreadTitleResponseObject); ok {
-		if err := validResponse.VisitUpdateThreadTitleResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateUserResponseObject); ok {
+		if err := validResponse.VisitUpdateUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6006,25 +22110,25 @@ func (sh *strictHandler) UpdateThreadTitle(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// ListMessages operation middleware
-func (sh *strictHandler) ListMessages(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
-	var request ListMessagesRequestObject
+// ListUserApiKeys operation middleware
+func (sh *strictHandler) ListUserApiKeys(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request ListUserApiKeysRequestObject
 
-	request.ThreadId = threadId
+	request.UserId = userId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListMessages(ctx, request.(ListMessagesRequestObject))
+		return sh.ssi.ListUserApiKeys(ctx, request.(ListUserApiKeysRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListMessages")
+		handler = middleware(handler, "ListUserApiKeys")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListMessagesResponseObject); ok {
-		if err := validResponse.VisitListMessagesResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListUserApiKeysResponseObject); ok {
+		if err := validResponse.VisitListUserApiKeysResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6032,13 +22136,13 @@ func (sh *strictHandler) ListMessages(w http.ResponseWriter, r *http.Request, th
 	}
 }
 
-// CreateMessage operation middleware
-func (sh *strictHandler) CreateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID) {
-	var request CreateMessageRequestObject
+// CreateUserApiKey operation middleware
+func (sh *strictHandler) CreateUserApiKey(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request CreateUserApiKeyRequestObject
 
-	request.ThreadId = threadId
+	request.UserId = userId
 
-	var body CreateMessageJSONRequestBody
+	var body CreateUserApiKeyJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6046,18 +22150,18 @@ func (sh *strictHandler) CreateMessage(w http.ResponseWriter, r *http.Request, t
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateMessage(ctx, request.(CreateMessageRequestObject))
+		return sh.ssi.CreateUserApiKey(ctx, request.(CreateUserApiKeyRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateMessage")
+		handler = middleware(handler, "CreateUserApiKey")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateMessageResponseObject); ok {
-		if err := validResponse.VisitCreateMessageResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateUserApiKeyResponseObject); ok {
+		if err := validResponse.VisitCreateUserApiKeyResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6065,26 +22169,26 @@ func (sh *strictHandler) CreateMessage(w http.ResponseWriter, r *http.Request, t
 	}
 }
 
-// DeleteMessage operation middleware
-func (sh *strictHandler) DeleteMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
-	var request DeleteMessageRequestObject
+// RevokeUserApiKey operation middleware
+func (sh *strictHandler) RevokeUserApiKey(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, keyId openapi_types.UUID) {
+	var request RevokeUserApiKeyRequestObject
 
-	request.ThreadId = threadId
-	request.MessageId = messageId
+	request.UserId = userId
+	request.KeyId = keyId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteMessage(ctx, request.(DeleteMessageRequestObject))
+		return sh.ssi.RevokeUserApiKey(ctx, request.(RevokeUserApiKeyRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteMessage")
+		handler = middleware(handler, "RevokeUserApiKey")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteMessageResponseObject); ok {
-		if err := validResponse.VisitDeleteMessageResponse(w); err != nil {
+	} else if validResponse, ok := response.(RevokeUserApiKeyResponseObject); ok {
+		if err := validResponse.VisitRevokeUserApiKeyResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6092,26 +22196,32 @@ func (sh *strictHandler) DeleteMessage(w http.ResponseWriter, r *http.Request, t
 	}
 }
 
-// GetMessage operation middleware
-func (sh *strictHandler) GetMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
-	var request GetMessageRequestObject
+// SetUserDefaultAgent operation middleware
+func (sh *strictHandler) SetUserDefaultAgent(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request SetUserDefaultAgentRequestObject
 
-	request.ThreadId = threadId
-	request.MessageId = messageId
+	request.UserId = userId
+
+	var body SetUserDefaultAgentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetMessage(ctx, request.(GetMessageRequestObject))
+		return sh.ssi.SetUserDefaultAgent(ctx, request.(SetUserDefaultAgentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetMessage")
+		handler = middleware(handler, "SetUserDefaultAgent")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetMessageResponseObject); ok {
-		if err := validResponse.VisitGetMessageResponse(w); err != nil {
+	} else if validResponse, ok := response.(SetUserDefaultAgentResponseObject); ok {
+		if err := validResponse.VisitSetUserDefaultAgentResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6119,33 +22229,25 @@ func (sh *strictHandler) GetMessage(w http.ResponseWriter, r *http.Request, thre
 	}
 }
 
-// UpdateMessage operation middleware
-func (sh *strictHandler) UpdateMessage(w http.ResponseWriter, r *http.Request, threadId openapi_types.UUID, messageId openapi_types.UUID) {
-	var request UpdateMessageRequestObject
-
-	request.ThreadId = threadId
-	request.MessageId = messageId
+// ListRoleForUser operation middleware
+func (sh *strictHandler) ListRoleForUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request ListRoleForUserRequestObject
 
-	var body UpdateMessageJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.UserId = userId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateMessage(ctx, request.(UpdateMessageRequestObject))
+		return sh.ssi.ListRoleForUser(ctx, request.(ListRoleForUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateMessage")
+		handler = middleware(handler, "ListRoleForUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateMessageResponseObject); ok {
-		if err := validResponse.VisitUpdateMessageResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListRoleForUserResponseObject); ok {
+		if err := validResponse.VisitListRoleForUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6153,23 +22255,32 @@ func (sh *strictHandler) UpdateMessage(w http.ResponseWriter, r *http.Request, t
 	}
 }
 
-// ListTools operation middleware
-func (sh *strictHandler) ListTools(w http.ResponseWriter, r *http.Request) {
-	var request ListToolsRequestObject
+// AddRoleToUser operation middleware
+func (sh *strictHandler) AddRoleToUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request AddRoleToUserRequestObject
+
+	request.UserId = userId
+
+	var body AddRoleToUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListTools(ctx, request.(ListToolsRequestObject))
+		return sh.ssi.AddRoleToUser(ctx, request.(AddRoleToUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListTools")
+		handler = middleware(handler, "AddRoleToUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListToolsResponseObject); ok {
-		if err := validResponse.VisitListToolsResponse(w); err != nil {
+	} else if validResponse, ok := response.(AddRoleToUserResponseObject); ok {
+		if err := validResponse.VisitAddRoleToUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6177,30 +22288,26 @@ func (sh *strictHandler) ListTools(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateTool operation middleware
-func (sh *strictHandler) CreateTool(w http.ResponseWriter, r *http.Request) {
-	var request CreateToolRequestObject
+// RemoveRoleFromUser operation middleware
+func (sh *strictHandler) RemoveRoleFromUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, roleId openapi_types.UUID) {
+	var request RemoveRoleFromUserRequestObject
 
-	var body CreateToolJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.UserId = userId
+	request.RoleId = roleId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateTool(ctx, request.(CreateToolRequestObject))
+		return sh.ssi.RemoveRoleFromUser(ctx, request.(RemoveRoleFromUserRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateTool")
+		handler = middleware(handler, "RemoveRoleFromUser")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateToolResponseObject); ok {
-		if err := validResponse.VisitCreateToolResponse(w); err != nil {
+	} else if validResponse, ok := response.(RemoveRoleFromUserResponseObject); ok {
+		if err := validResponse.VisitRemoveRoleFromUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6208,25 +22315,23 @@ func (sh *strictHandler) CreateTool(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteTool operation middleware
-func (sh *strictHandler) DeleteTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
-	var request DeleteToolRequestObject
-
-	request.ToolId = toolId
+// ListWebhooks operation middleware
+func (sh *strictHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	var request ListWebhooksRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteTool(ctx, request.(DeleteToolRequestObject))
+		return sh.ssi.ListWebhooks(ctx, request.(ListWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteTool")
+		handler = middleware(handler, "ListWebhooks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteToolResponseObject); ok {
-		if err := validResponse.VisitDeleteToolResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListWebhooksResponseObject); ok {
+		if err := validResponse.VisitListWebhooksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6234,25 +22339,30 @@ func (sh *strictHandler) DeleteTool(w http.ResponseWriter, r *http.Request, tool
 	}
 }
 
-// GetToolById operation middleware
-func (sh *strictHandler) GetToolById(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
-	var request GetToolByIdRequestObject
+// CreateWebhook operation middleware
+func (sh *strictHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var request CreateWebhookRequestObject
 
-	request.ToolId = toolId
+	var body CreateWebhookJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetToolById(ctx, request.(GetToolByIdRequestObject))
+		return sh.ssi.CreateWebhook(ctx, request.(CreateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetToolById")
+		handler = middleware(handler, "CreateWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetToolByIdResponseObject); ok {
-		if err := validResponse.VisitGetToolByIdResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateWebhookResponseObject); ok {
+		if err := validResponse.VisitCreateWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6260,32 +22370,25 @@ func (sh *strictHandler) GetToolById(w http.ResponseWriter, r *http.Request, too
 	}
 }
 
-// UpdateTool operation middleware
-func (sh *strictHandler) UpdateTool(w http.ResponseWriter, r *http.Request, toolId openapi_types.UUID) {
-	var request UpdateToolRequestObject
-
-	request.ToolId = toolId
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	var request DeleteWebhookRequestObject
 
-	var body UpdateToolJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.WebhookId = webhookId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateTool(ctx, request.(UpdateToolRequestObject))
+		return sh.ssi.DeleteWebhook(ctx, request.(DeleteWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateTool")
+		handler = middleware(handler, "DeleteWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateToolResponseObject); ok {
-		if err := validResponse.VisitUpdateToolResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6293,23 +22396,25 @@ func (sh *strictHandler) UpdateTool(w http.ResponseWriter, r *http.Request, tool
 	}
 }
 
-// ListUsers operation middleware
-func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
-	var request ListUsersRequestObject
+// GetWebhook operation middleware
+func (sh *strictHandler) GetWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	var request GetWebhookRequestObject
+
+	request.WebhookId = webhookId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListUsers(ctx, request.(ListUsersRequestObject))
+		return sh.ssi.GetWebhook(ctx, request.(GetWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListUsers")
+		handler = middleware(handler, "GetWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListUsersResponseObject); ok {
-		if err := validResponse.VisitListUsersResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetWebhookResponseObject); ok {
+		if err := validResponse.VisitGetWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6317,11 +22422,13 @@ func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateUser operation middleware
-func (sh *strictHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
-	var request CreateUserRequestObject
+// UpdateWebhook operation middleware
+func (sh *strictHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	var request UpdateWebhookRequestObject
 
-	var body CreateUserJSONRequestBody
+	request.WebhookId = webhookId
+
+	var body UpdateWebhookJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6329,18 +22436,18 @@ func (sh *strictHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateUser(ctx, request.(CreateUserRequestObject))
+		return sh.ssi.UpdateWebhook(ctx, request.(UpdateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateUser")
+		handler = middleware(handler, "UpdateWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateUserResponseObject); ok {
-		if err := validResponse.VisitCreateUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateWebhookResponseObject); ok {
+		if err := validResponse.VisitUpdateWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6348,25 +22455,26 @@ func (sh *strictHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteUser operation middleware
-func (sh *strictHandler) DeleteUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
-	var request DeleteUserRequestObject
+// ListWebhookDeliveries operation middleware
+func (sh *strictHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID, params ListWebhookDeliveriesParams) {
+	var request ListWebhookDeliveriesRequestObject
 
-	request.UserId = userId
+	request.WebhookId = webhookId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteUser(ctx, request.(DeleteUserRequestObject))
+		return sh.ssi.ListWebhookDeliveries(ctx, request.(ListWebhookDeliveriesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteUser")
+		handler = middleware(handler, "ListWebhookDeliveries")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteUserResponseObject); ok {
-		if err := validResponse.VisitDeleteUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitListWebhookDeliveriesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6374,25 +22482,26 @@ func (sh *strictHandler) DeleteUser(w http.ResponseWriter, r *http.Request, user
 	}
 }
 
-// GetUser operation middleware
-func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
-	var request GetUserRequestObject
+// RedeliverWebhookDelivery operation middleware
+func (sh *strictHandler) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID, deliveryId openapi_types.UUID) {
+	var request RedeliverWebhookDeliveryRequestObject
 
-	request.UserId = userId
+	request.WebhookId = webhookId
+	request.DeliveryId = deliveryId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetUser(ctx, request.(GetUserRequestObject))
+		return sh.ssi.RedeliverWebhookDelivery(ctx, request.(RedeliverWebhookDeliveryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetUser")
+		handler = middleware(handler, "RedeliverWebhookDelivery")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetUserResponseObject); ok {
-		if err := validResponse.VisitGetUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(RedeliverWebhookDeliveryResponseObject); ok {
+		if err := validResponse.VisitRedeliverWebhookDeliveryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6400,32 +22509,25 @@ func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, userId
 	}
 }
 
-// UpdateUser operation middleware
-func (sh *strictHandler) UpdateUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
-	var request UpdateUserRequestObject
-
-	request.UserId = userId
+// RotateWebhookSecret operation middleware
+func (sh *strictHandler) RotateWebhookSecret(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID) {
+	var request RotateWebhookSecretRequestObject
 
-	var body UpdateUserJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.WebhookId = webhookId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateUser(ctx, request.(UpdateUserRequestObject))
+		return sh.ssi.RotateWebhookSecret(ctx, request.(RotateWebhookSecretRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateUser")
+		handler = middleware(handler, "RotateWebhookSecret")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UpdateUserResponseObject); ok {
-		if err := validResponse.VisitUpdateUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(RotateWebhookSecretResponseObject); ok {
+		if err := validResponse.VisitRotateWebhookSecretResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6433,25 +22535,25 @@ func (sh *strictHandler) UpdateUser(w http.ResponseWriter, r *http.Request, user
 	}
 }
 
-// ListRoleForUser operation middleware
-func (sh *strictHandler) ListRoleForUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
-	var request ListRoleForUserRequestObject
+// ListWorkspaceMembers operation middleware
+func (sh *strictHandler) ListWorkspaceMembers(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID) {
+	var request ListWorkspaceMembersRequestObject
 
-	request.UserId = userId
+	request.WorkspaceId = workspaceId
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListRoleForUser(ctx, request.(ListRoleForUserRequestObject))
+		return sh.ssi.ListWorkspaceMembers(ctx, request.(ListWorkspaceMembersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListRoleForUser")
+		handler = middleware(handler, "ListWorkspaceMembers")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListRoleForUserResponseObject); ok {
-		if err := validResponse.VisitListRoleForUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListWorkspaceMembersResponseObject); ok {
+		if err := validResponse.VisitListWorkspaceMembersResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6459,32 +22561,26 @@ func (sh *strictHandler) ListRoleForUser(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// AddRoleToUser operation middleware
-func (sh *strictHandler) AddRoleToUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
-	var request AddRoleToUserRequestObject
+// RemoveWorkspaceMember operation middleware
+func (sh *strictHandler) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID, userId openapi_types.UUID) {
+	var request RemoveWorkspaceMemberRequestObject
 
+	request.WorkspaceId = workspaceId
 	request.UserId = userId
 
-	var body AddRoleToUserJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AddRoleToUser(ctx, request.(AddRoleToUserRequestObject))
+		return sh.ssi.RemoveWorkspaceMember(ctx, request.(RemoveWorkspaceMemberRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AddRoleToUser")
+		handler = middleware(handler, "RemoveWorkspaceMember")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AddRoleToUserResponseObject); ok {
-		if err := validResponse.VisitAddRoleToUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(RemoveWorkspaceMemberResponseObject); ok {
+		if err := validResponse.VisitRemoveWorkspaceMemberResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6492,26 +22588,33 @@ func (sh *strictHandler) AddRoleToUser(w http.ResponseWriter, r *http.Request, u
 	}
 }
 
-// RemoveRoleFromUser operation middleware
-func (sh *strictHandler) RemoveRoleFromUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, roleId openapi_types.UUID) {
-	var request RemoveRoleFromUserRequestObject
+// SetWorkspaceMember operation middleware
+func (sh *strictHandler) SetWorkspaceMember(w http.ResponseWriter, r *http.Request, workspaceId openapi_types.UUID, userId openapi_types.UUID) {
+	var request SetWorkspaceMemberRequestObject
 
+	request.WorkspaceId = workspaceId
 	request.UserId = userId
-	request.RoleId = roleId
+
+	var body SetWorkspaceMemberJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RemoveRoleFromUser(ctx, request.(RemoveRoleFromUserRequestObject))
+		return sh.ssi.SetWorkspaceMember(ctx, request.(SetWorkspaceMemberRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RemoveRoleFromUser")
+		handler = middleware(handler, "SetWorkspaceMember")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RemoveRoleFromUserResponseObject); ok {
-		if err := validResponse.VisitRemoveRoleFromUserResponse(w); err != nil {
+	} else if validResponse, ok := response.(SetWorkspaceMemberResponseObject); ok {
+		if err := validResponse.VisitSetWorkspaceMemberResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+const API_KEY_RESOURCE = "ApiKey"
+
+// serviceAccountEmailDomain fills the required email of the service accounts, the reserved .invalid domain keeps it
+// unique without reaching anyone
+const serviceAccountEmailDomain = "@service-accounts.invalid"
+
+// authenticateAPIKey returns the principal of an API key that is not revoked nor expired, and records its use
+func (s *Server) authenticateAPIKey(ctx context.Context, key string) (*custom_middleware.Principal, error) {
+	apiKey, err := s.queries.GetActiveAPIKeyByHash(ctx, db.HashAPIKey(key))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, custom_middleware.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	if err := s.queries.TouchAPIKey(ctx, apiKey.ID); err != nil {
+		s.log.Warn("Failed to record API key use", "api_key_id", apiKey.ID, "error", err)
+	}
+	return &custom_middleware.Principal{UserID: apiKey.UserID, APIKeyID: apiKey.ID, Scopes: apiKey.Scopes}, nil
+}
+
+// Create a service account
+// (POST /v1/service-accounts)
+func (s *Server) CreateServiceAccount(ctx context.Context, request CreateServiceAccountRequestObject) (CreateServiceAccountResponseObject, error) {
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return CreateServiceAccount400JSONResponse{Message: "service account name is required"}, nil
+	}
+	if len(name)+len(serviceAccountEmailDomain) > 255 {
+		return CreateServiceAccount400JSONResponse{Message: "name is too long"}, nil
+	}
+	info := map[string]any{}
+	if request.Body.Description != nil {
+		info["description"] = *request.Body.Description
+	}
+	additionalInfo, err := db.NewJsonRaw(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal additional_info JSON: %w", err)
+	}
+
+	// No password matches the hash of a service account
+	user, err := s.queries.CreateUser(ctx, db.CreateUserParams{
+		Name:           name,
+		Email:          name + serviceAccountEmailDomain,
+		AdditionalInfo: additionalInfo,
+		PasswordHash:   "!",
+		ProviderName:   db.ProviderNameServiceAccount,
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+			return CreateServiceAccount409JSONResponse{Resource: USER_RESOURCE, Id: uuid.Nil, Message: "User already exists"}, nil
+		}
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+	s.log.Info("Created service account", "user_id", user.ID, "name", user.Name)
+	return CreateServiceAccount201JSONResponse(user), nil
+}
+
+// List the API keys of a user
+// (GET /v1/users/{user_id}/api-keys)
+func (s *Server) ListUserApiKeys(ctx context.Context, request ListUserApiKeysRequestObject) (ListUserApiKeysResponseObject, error) {
+	if _, err := s.queries.GetUserByID(ctx, request.UserId); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListUserApiKeys404JSONResponse{Message: "User not found", Resource: USER_RESOURCE, Id: request.UserId}, nil
+		}
+		return nil, err
+	}
+	apiKeys, err := s.queries.ListAPIKeysByUser(ctx, request.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	list := make([]ApiKey, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		list = append(list, toApiKey(apiKey))
+	}
+	return ListUserApiKeys200JSONResponse(ApiKeyList{ApiKeys: list}), nil
+}
+
+// Create an API key for a user
+// (POST /v1/users/{user_id}/api-keys)
+func (s *Server) CreateUserApiKey(ctx context.Context, request CreateUserApiKeyRequestObject) (CreateUserApiKeyResponseObject, error) {
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return CreateUserApiKey400JSONResponse{Message: "API key name is required"}, nil
+	}
+	if len(name) > 255 {
+		return CreateUserApiKey400JSONResponse{Message: "name is too long"}, nil
+	}
+	requested := make([]string, 0, len(request.Body.Scopes))
+	for _, scope := range request.Body.Scopes {
+		requested = append(requested, string(scope))
+	}
+	scopes, err := db.NormalizeAPIKeyScopes(requested)
+	if err != nil {
+		return CreateUserApiKey400JSONResponse{Message: err.Error()}, nil
+	}
+	// An admin creates the keys of any user, the other users only their own keys, without the admin scope
+	if principal := custom_middleware.GetPrincipal(ctx); principal == nil || !principal.IsAdmin() {
+		if request.UserId != custom_middleware.RequestUserID(ctx) {
+			return CreateUserApiKey403JSONResponse{Message: "only an admin creates the API keys of another user"}, nil
+		}
+		if db.APIKeyScopesAllow(scopes, db.APIKeyScopeAdmin) {
+			return CreateUserApiKey403JSONResponse{Message: "only an admin creates API keys with the admin scope"}, nil
+		}
+	}
+	var expiresAt pgtype.Timestamptz
+	if request.Body.ExpiresAt != nil {
+		if !request.Body.ExpiresAt.After(time.Now()) {
+			return CreateUserApiKey400JSONResponse{Message: "expires_at must be in the future"}, nil
+		}
+		expiresAt = pgtype.Timestamptz{Time: *request.Body.ExpiresAt, Valid: true}
+	}
+	if _, err := s.queries.GetUserByID(ctx, request.UserId); err != nil {
+		if err == pgx.ErrNoRows {
+			return CreateUserApiKey404JSONResponse{Message: "User not found", Resource: USER_RESOURCE, Id: request.UserId}, nil
+		}
+		return nil, err
+	}
+
+	key, prefix, hash, err := db.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := s.queries.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		UserID:    request.UserId,
+		Name:      name,
+		KeyPrefix: prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedBy: custom_middleware.RequestUserID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	s.log.Info("Created API key", "api_key_id", apiKey.ID, "user_id", apiKey.UserID, "key_prefix", apiKey.KeyPrefix, "scopes", apiKey.Scopes)
+	return CreateUserApiKey201JSONResponse(CreatedApiKey{ApiKey: toApiKey(apiKey), Key: key}), nil
+}
+
+// Revoke an API key
+// (DELETE /v1/users/{user_id}/api-keys/{key_id})
+func (s *Server) RevokeUserApiKey(ctx context.Context, request RevokeUserApiKeyRequestObject) (RevokeUserApiKeyResponseObject, error) {
+	apiKey, err := s.queries.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{ID: request.KeyId, UserID: request.UserId})
+	if err == nil {
+		s.log.Info("Revoked API key", "api_key_id", apiKey.ID, "user_id", apiKey.UserID, "key_prefix", apiKey.KeyPrefix)
+		return RevokeUserApiKey200JSONResponse(toApiKey(apiKey)), nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	// Tell a missing key from one that is already revoked
+	if _, err := s.queries.GetAPIKey(ctx, db.GetAPIKeyParams{ID: request.KeyId, UserID: request.UserId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return RevokeUserApiKey404JSONResponse{Message: "API key not found", Resource: API_KEY_RESOURCE, Id: request.KeyId}, nil
+		}
+		return nil, err
+	}
+	return RevokeUserApiKey400JSONResponse{Message: "API key is already revoked"}, nil
+}
+
+// toApiKey converts an API key without its hash
+func toApiKey(apiKey db.ApiKey) ApiKey {
+	scopes := make([]ApiKeyScopes, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, ApiKeyScopes(scope))
+	}
+	return ApiKey{
+		Id:         apiKey.ID,
+		UserId:     apiKey.UserID,
+		Name:       apiKey.Name,
+		KeyPrefix:  apiKey.KeyPrefix,
+		Scopes:     scopes,
+		ExpiresAt:  timestamptzPtr(apiKey.ExpiresAt),
+		LastUsedAt: timestamptzPtr(apiKey.LastUsedAt),
+		RevokedAt:  timestamptzPtr(apiKey.RevokedAt),
+		CreatedBy:  apiKey.CreatedBy,
+		CreatedAt:  apiKey.CreatedAt.Time,
+	}
+}
+
+// timestamptzPtr returns the time of a nullable timestamp, nil when it is null
+func timestamptzPtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/pinazu/internal/db"
+)
+
+// List tool run audit entries
+// (GET /v1/audit/tool-runs)
+func (s *Server) ListToolRunAudit(ctx context.Context, request ListToolRunAuditRequestObject) (ListToolRunAuditResponseObject, error) {
+	var perPage int32 = 10
+	var page int32 = 1
+	if request.Params.PerPage != nil {
+		perPage = *request.Params.PerPage
+	}
+	if request.Params.Page != nil {
+		page = *request.Params.Page
+	}
+
+	filter := db.CountToolRunAuditParams{}
+	if p := request.Params; p.ToolId != nil {
+		filter.ToolID = pgtype.UUID{Bytes: *p.ToolId, Valid: true}
+	}
+	if p := request.Params; p.AgentId != nil {
+		filter.AgentID = pgtype.UUID{Bytes: *p.AgentId, Valid: true}
+	}
+	if p := request.Params; p.UserId != nil {
+		filter.UserID = pgtype.UUID{Bytes: *p.UserId, Valid: true}
+	}
+	if p := request.Params; p.TaskId != nil {
+		filter.TaskID = pgtype.Text{String: *p.TaskId, Valid: true}
+	}
+	if p := request.Params; p.Status != nil {
+		filter.Status = pgtype.Text{String: string(*p.Status), Valid: true}
+	}
+	if p := request.Params; p.Since != nil {
+		filter.Since = pgtype.Timestamptz{Time: *p.Since, Valid: true}
+	}
+	if p := request.Params; p.Until != nil {
+		filter.Until = pgtype.Timestamptz{Time: *p.Until, Valid: true}
+	}
+
+	entries, err := s.queries.ListToolRunAudit(ctx, db.ListToolRunAuditParams{
+		ToolID:    filter.ToolID,
+		AgentID:   filter.AgentID,
+		UserID:    filter.UserID,
+		TaskID:    filter.TaskID,
+		Status:    filter.Status,
+		Since:     filter.Since,
+		Until:     filter.Until,
+		RowLimit:  perPage,
+		RowOffset: (page - 1) * perPage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tool run audit entries: %w", err)
+	}
+	total, err := s.queries.CountToolRunAudit(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tool run audit entries: %w", err)
+	}
+
+	return ListToolRunAudit200JSONResponse(ToolRunAuditList{
+		Entries:    entries,
+		Page:       page,
+		PerPage:    perPage,
+		Total:      int(total),
+		TotalPages: (int(total) + int(perPage) - 1) / int(perPage),
+	}), nil
+}
+
+// Get a tool run audit entry
+// (GET /v1/audit/tool-runs/{tool_run_id})
+func (s *Server) GetToolRunAudit(ctx context.Context, request GetToolRunAuditRequestObject) (GetToolRunAuditResponseObject, error) {
+	entry, err := s.queries.GetToolRunAudit(ctx, request.ToolRunId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			// Tool run IDs are not always UUIDs, the ID is only set when it is one
+			id, _ := uuid.Parse(request.ToolRunId)
+			return GetToolRunAudit404JSONResponse{
+				Message:  fmt.Sprintf("Audit entry of tool run %s not found", request.ToolRunId),
+				Resource: "ToolRunAudit",
+				Id:       id,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get tool run audit entry: %w", err)
+	}
+	return GetToolRunAudit200JSONResponse(entry), nil
+}
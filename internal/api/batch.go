@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	db "github.com/pinazu/internal/db"
+)
+
+const (
+	// maxBatchOperations bounds the operations of a batch request on resources
+	maxBatchOperations = 100
+
+	// maxImportedMessages bounds the messages of a thread import
+	maxImportedMessages = 1000
+
+	// maxImportedThreadMessages bounds the messages of an imported thread export
+	maxImportedThreadMessages = 5000
+)
+
+// batchItemError is the failure of an operation of a batch, reported in the result of the operation rather than
+// failing the request
+type batchItemError struct {
+	message string
+}
+
+func (e batchItemError) Error() string {
+	return e.message
+}
+
+// itemFailed fails an operation of a batch with a message for the client
+func itemFailed(format string, args ...any) error {
+	return batchItemError{message: fmt.Sprintf(format, args...)}
+}
+
+// batchSizeError checks the number of operations of a batch request, it returns an empty string for a valid batch
+func batchSizeError(size, max int) string {
+	if size == 0 {
+		return "the batch has no operation"
+	}
+	if size > max {
+		return fmt.Sprintf("the batch has %d operations, at most %d are allowed", size, max)
+	}
+	return ""
+}
+
+// runBatch runs the operations of a batch request in a single transaction. Each operation runs under a savepoint, so
+// a failed operation leaves the transaction usable and every operation gets its result. The transaction is committed
+// when every operation succeeded and rolled back otherwise, the succeeded operations are then reported rolled back.
+// An error of op that is not an item error fails the whole request.
+func (s *Server) runBatch(ctx context.Context, size int, op func(queries *db.Queries, index int) (BatchItemResult, error)) (BatchResult, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return BatchResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := BatchResult{Results: make([]BatchItemResult, 0, size)}
+	failed := false
+	for index := range size {
+		item, err := s.runBatchItem(ctx, tx, index, op)
+		if err != nil {
+			return BatchResult{}, err
+		}
+		failed = failed || item.Status == Failed
+		result.Results = append(result.Results, item)
+	}
+
+	if failed {
+		for i := range result.Results {
+			if result.Results[i].Status != Failed {
+				result.Results[i].Status = RolledBack
+			}
+		}
+		return result, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return BatchResult{}, fmt.Errorf("failed to commit batch: %w", err)
+	}
+	result.Committed = true
+	return result, nil
+}
+
+// runBatchItem runs an operation of a batch under a savepoint of the transaction of the batch
+func (s *Server) runBatchItem(ctx context.Context, tx pgx.Tx, index int, op func(queries *db.Queries, index int) (BatchItemResult, error)) (BatchItemResult, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return BatchItemResult{}, fmt.Errorf("failed to begin savepoint: %w", err)
+	}
+	defer savepoint.Rollback(ctx)
+
+	item, err := op(s.queries.WithTx(savepoint), index)
+	var itemErr batchItemError
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &itemErr):
+		return BatchItemResult{Index: index, Status: Failed, Message: &itemErr.message}, nil
+	case errors.As(err, &pgErr) && db.IsConflictError(pgErr):
+		// A unique violation is a conflict of the operation with an existing resource or another operation
+		message := fmt.Sprintf("conflict with an existing resource: %s", pgErr.Detail)
+		return BatchItemResult{Index: index, Status: Failed, Message: &message}, nil
+	case err != nil:
+		return BatchItemResult{}, err
+	}
+	if err := savepoint.Commit(ctx); err != nil {
+		return BatchItemResult{}, fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	item.Index = index
+	return item, nil
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+)
+
+// preconditionFailed returns the body of the 412 response to an update of a resource changed since the version of
+// its If-Match header, with the entity tag of the current version when it is known
+func preconditionFailed(resource string, id uuid.UUID, updatedAt pgtype.Timestamptz) PreconditionFailed {
+	body := PreconditionFailed{
+		Resource: resource,
+		Id:       id,
+		Message:  fmt.Sprintf("%s %s changed since the version of the If-Match header", resource, id),
+	}
+	if updatedAt.Valid {
+		etag := db.ETag(updatedAt)
+		body.Etag = &etag
+	}
+	return body
+}
@@ -0,0 +1,336 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+const (
+	FEEDBACK_RESOURCE = "Feedback"
+	TOOL_RUN_RESOURCE = "ToolRun"
+)
+
+// maxFeedbackExport is the number of feedback exported at most by a request
+const maxFeedbackExport = 10000
+
+// checkFeedback validates the body of a feedback request and returns its categories trimmed, or the message of the
+// 400 response
+func checkFeedback(body *FeedbackRequest) ([]string, string) {
+	if body == nil {
+		return nil, "body is required"
+	}
+	if !body.Rating.Valid() {
+		return nil, fmt.Sprintf("invalid rating %q, must be UP or DOWN", body.Rating)
+	}
+	categories := []string{}
+	if body.Categories != nil {
+		if len(*body.Categories) > 10 {
+			return nil, "at most 10 categories can be given"
+		}
+		for _, category := range *body.Categories {
+			category = strings.TrimSpace(category)
+			if category == "" || len(category) > 50 {
+				return nil, "categories must be between 1 and 50 characters"
+			}
+			categories = append(categories, category)
+		}
+	}
+	if body.Comment != nil && len(*body.Comment) > 4000 {
+		return nil, "comment exceeds maximum length of 4000 characters"
+	}
+	return categories, ""
+}
+
+// optionalComment converts the comment of a feedback request to a nullable text, null when blank
+func optionalComment(comment *string) pgtype.Text {
+	if comment == nil || strings.TrimSpace(*comment) == "" {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *comment, Valid: true}
+}
+
+// toolRunNotFound returns the body of the 404 response of a tool run, tool run IDs are not always UUIDs and the ID is
+// only set when it is one
+func toolRunNotFound(toolRunID string) NotFound {
+	id, _ := uuid.Parse(toolRunID)
+	return NotFound{Message: fmt.Sprintf("Tool run %s not found", toolRunID), Resource: TOOL_RUN_RESOURCE, Id: id}
+}
+
+// Rate a message
+// (PUT /v1/threads/{thread_id}/messages/{message_id}/feedback)
+func (s *Server) SetMessageFeedback(ctx context.Context, request SetMessageFeedbackRequestObject) (SetMessageFeedbackResponseObject, error) {
+	categories, invalid := checkFeedback(request.Body)
+	if invalid != "" {
+		return SetMessageFeedback400JSONResponse{Message: invalid}, nil
+	}
+
+	thread, _, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SetMessageFeedback404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ThreadID != thread.ID {
+		return SetMessageFeedback404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
+	if message.SenderType != db.SenderMessageTypeAssistant {
+		return SetMessageFeedback400JSONResponse{Message: "only the messages of the agents can be rated"}, nil
+	}
+
+	feedback, err := s.queries.UpsertMessageFeedback(ctx, db.UpsertMessageFeedbackParams{
+		WorkspaceID: thread.WorkspaceID,
+		ThreadID:    thread.ID,
+		MessageID:   pgtype.UUID{Bytes: message.ID, Valid: true},
+		UserID:      custom_middleware.RequestUserID(ctx),
+		Rating:      request.Body.Rating,
+		Categories:  categories,
+		Comment:     optionalComment(request.Body.Comment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save message feedback: %w", err)
+	}
+	return SetMessageFeedback200JSONResponse(feedback), nil
+}
+
+// Delete the feedback on a message
+// (DELETE /v1/threads/{thread_id}/messages/{message_id}/feedback)
+func (s *Server) DeleteMessageFeedback(ctx context.Context, request DeleteMessageFeedbackRequestObject) (DeleteMessageFeedbackResponseObject, error) {
+	if _, _, err := s.threadAccess(ctx, request.ThreadId); err != nil {
+		if err == pgx.ErrNoRows {
+			return DeleteMessageFeedback404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ThreadID != request.ThreadId {
+		return DeleteMessageFeedback404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
+
+	deleted, err := s.queries.DeleteMessageFeedback(ctx, db.DeleteMessageFeedbackParams{
+		UserID:    custom_middleware.RequestUserID(ctx),
+		MessageID: pgtype.UUID{Bytes: message.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete message feedback: %w", err)
+	}
+	if deleted == 0 {
+		return DeleteMessageFeedback404JSONResponse{Message: "Feedback not found", Resource: FEEDBACK_RESOURCE, Id: request.MessageId}, nil
+	}
+	return DeleteMessageFeedback204Response{}, nil
+}
+
+// Rate a tool run
+// (PUT /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+func (s *Server) SetToolRunFeedback(ctx context.Context, request SetToolRunFeedbackRequestObject) (SetToolRunFeedbackResponseObject, error) {
+	categories, invalid := checkFeedback(request.Body)
+	if invalid != "" {
+		return SetToolRunFeedback400JSONResponse{Message: invalid}, nil
+	}
+
+	thread, _, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SetToolRunFeedback404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	run, err := s.queries.GetToolRunStatusByID(ctx, request.ToolRunId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get tool run: %w", err)
+	}
+	if err == pgx.ErrNoRows || run.ThreadID != thread.ID {
+		return SetToolRunFeedback404JSONResponse(toolRunNotFound(request.ToolRunId)), nil
+	}
+
+	feedback, err := s.queries.UpsertToolRunFeedback(ctx, db.UpsertToolRunFeedbackParams{
+		WorkspaceID: thread.WorkspaceID,
+		ThreadID:    thread.ID,
+		ToolRunID:   pgtype.Text{String: run.ID, Valid: true},
+		UserID:      custom_middleware.RequestUserID(ctx),
+		Rating:      request.Body.Rating,
+		Categories:  categories,
+		Comment:     optionalComment(request.Body.Comment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save tool run feedback: %w", err)
+	}
+	return SetToolRunFeedback200JSONResponse(feedback), nil
+}
+
+// Delete the feedback on a tool run
+// (DELETE /v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback)
+func (s *Server) DeleteToolRunFeedback(ctx context.Context, request DeleteToolRunFeedbackRequestObject) (DeleteToolRunFeedbackResponseObject, error) {
+	if _, _, err := s.threadAccess(ctx, request.ThreadId); err != nil {
+		if err == pgx.ErrNoRows {
+			return DeleteToolRunFeedback404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	run, err := s.queries.GetToolRunStatusByID(ctx, request.ToolRunId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get tool run: %w", err)
+	}
+	if err == pgx.ErrNoRows || run.ThreadID != request.ThreadId {
+		return DeleteToolRunFeedback404JSONResponse(toolRunNotFound(request.ToolRunId)), nil
+	}
+
+	deleted, err := s.queries.DeleteToolRunFeedback(ctx, db.DeleteToolRunFeedbackParams{
+		UserID:    custom_middleware.RequestUserID(ctx),
+		ToolRunID: pgtype.Text{String: run.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete tool run feedback: %w", err)
+	}
+	if deleted == 0 {
+		notFound := toolRunNotFound(request.ToolRunId)
+		notFound.Message, notFound.Resource = "Feedback not found", FEEDBACK_RESOURCE
+		return DeleteToolRunFeedback404JSONResponse(notFound), nil
+	}
+	return DeleteToolRunFeedback204Response{}, nil
+}
+
+// feedbackFilter returns the filter of a feedback list or export. The workspace admins see the feedback of all the
+// users, the other users their own feedback only: it returns false when they ask for the feedback of another user.
+func (s *Server) feedbackFilter(ctx context.Context, rating *FeedbackRating, threadID, userID *uuid.UUID, category *string, since, until *time.Time) (db.CountFeedbackParams, bool, error) {
+	filter := db.CountFeedbackParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		UserID:      optionalUUID(userID),
+		ThreadID:    optionalUUID(threadID),
+		Since:       optionalTime(since),
+		Until:       optionalTime(until),
+	}
+	if rating != nil {
+		filter.Rating = pgtype.Text{String: string(*rating), Valid: true}
+	}
+	if category != nil {
+		filter.Category = pgtype.Text{String: *category, Valid: true}
+	}
+
+	requestUserID := custom_middleware.RequestUserID(ctx)
+	if userID != nil && *userID == requestUserID {
+		return filter, true, nil
+	}
+	workspace, err := s.queries.GetWorkspace(ctx, filter.WorkspaceID)
+	if err != nil {
+		return filter, false, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	admin, err := s.managesWorkspaceMembers(ctx, workspace, requestUserID)
+	if err != nil {
+		return filter, false, err
+	}
+	if admin {
+		return filter, true, nil
+	}
+	if userID != nil {
+		return filter, false, nil
+	}
+	filter.UserID = pgtype.UUID{Bytes: requestUserID, Valid: true}
+	return filter, true, nil
+}
+
+// List feedback
+// (GET /v1/feedback)
+func (s *Server) ListFeedback(ctx context.Context, request ListFeedbackRequestObject) (ListFeedbackResponseObject, error) {
+	var perPage int32 = 10
+	var page int32 = 1
+	if request.Params.PerPage != nil {
+		perPage = *request.Params.PerPage
+	}
+	if request.Params.Page != nil {
+		page = *request.Params.Page
+	}
+
+	p := request.Params
+	filter, allowed, err := s.feedbackFilter(ctx, p.Rating, p.ThreadId, p.UserId, p.Category, p.Since, p.Until)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return ListFeedback403JSONResponse{Message: "only the workspace admins can list the feedback of other users"}, nil
+	}
+
+	feedback, err := s.queries.ListFeedback(ctx, db.ListFeedbackParams{
+		WorkspaceID: filter.WorkspaceID,
+		UserID:      filter.UserID,
+		ThreadID:    filter.ThreadID,
+		Rating:      filter.Rating,
+		Category:    filter.Category,
+		Since:       filter.Since,
+		Until:       filter.Until,
+		RowLimit:    perPage,
+		RowOffset:   (page - 1) * perPage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+	total, err := s.queries.CountFeedback(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count feedback: %w", err)
+	}
+
+	return ListFeedback200JSONResponse(FeedbackList{
+		Feedback:   feedback,
+		Page:       page,
+		PerPage:    perPage,
+		Total:      int(total),
+		TotalPages: (int(total) + int(perPage) - 1) / int(perPage),
+	}), nil
+}
+
+// Export feedback as a dataset
+// (GET /v1/feedback/export)
+func (s *Server) ExportFeedback(ctx context.Context, request ExportFeedbackRequestObject) (ExportFeedbackResponseObject, error) {
+	p := request.Params
+	filter, allowed, err := s.feedbackFilter(ctx, p.Rating, p.ThreadId, p.UserId, p.Category, p.Since, p.Until)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return ExportFeedback403JSONResponse{Message: "only the workspace admins can export the feedback of other users"}, nil
+	}
+
+	feedback, err := s.queries.ListFeedback(ctx, db.ListFeedbackParams{
+		WorkspaceID: filter.WorkspaceID,
+		UserID:      filter.UserID,
+		ThreadID:    filter.ThreadID,
+		Rating:      filter.Rating,
+		Category:    filter.Category,
+		Since:       filter.Since,
+		Until:       filter.Until,
+		RowLimit:    maxFeedbackExport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+	examples, err := s.queries.FeedbackExamples(ctx, feedback)
+	if err != nil {
+		return nil, err
+	}
+
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	for _, example := range examples {
+		if err := encoder.Encode(example); err != nil {
+			return nil, fmt.Errorf("failed to marshal feedback example: %w", err)
+		}
+	}
+	return ExportFeedback200ApplicationxNdjsonResponse{Body: &data, ContentLength: int64(data.Len())}, nil
+}
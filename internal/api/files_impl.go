@@ -0,0 +1,391 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/files"
+)
+
+const FILE_RESOURCE = "File"
+
+// maxFileNameLength bounds the names of the files, the length of their column
+const maxFileNameLength = 255
+
+// List files
+// (GET /v1/files)
+func (s *Server) ListFiles(ctx context.Context, request ListFilesRequestObject) (ListFilesResponseObject, error) {
+	limit, cursor, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return ListFiles400JSONResponse{Message: err.Error()}, nil
+	}
+	params := db.ListFilesParams{UserID: custom_middleware.RequestUserID(ctx), RowLimit: limit + 1}
+	if params.CursorTime, err = cursor.timeKey(); err != nil {
+		return ListFiles400JSONResponse{Message: err.Error()}, nil
+	}
+	if params.CursorID, err = cursor.uuidID(); err != nil {
+		return ListFiles400JSONResponse{Message: err.Error()}, nil
+	}
+
+	rows, err := s.queries.ListFiles(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	total, err := s.queries.CountFiles(ctx, params.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count files: %w", err)
+	}
+
+	rows, hasMore, next := pageRows(rows, limit, func(file db.File) pageCursor {
+		return pageCursor{Time: &file.CreatedAt.Time, ID: file.ID.String()}
+	})
+	list := make([]File, 0, len(rows))
+	for _, file := range rows {
+		list = append(list, toFile(file))
+	}
+	return ListFiles200JSONResponse(FileList{
+		Files:      list,
+		Limit:      limit,
+		HasMore:    hasMore,
+		NextCursor: next,
+		Total:      int(total),
+	}), nil
+}
+
+// Upload a file
+// (POST /v1/files)
+func (s *Server) CreateFile(ctx context.Context, request CreateFileRequestObject) (CreateFileResponseObject, error) {
+	if s.files == nil {
+		return nil, fmt.Errorf("failed to upload file: the S3 storage is not configured")
+	}
+	if request.MultipartBody != nil {
+		return s.uploadFile(ctx, request.MultipartBody)
+	}
+	if request.JSONBody == nil {
+		return CreateFile400JSONResponse{Message: "body is required"}, nil
+	}
+	return s.createFileUpload(ctx, *request.JSONBody)
+}
+
+// uploadFile stores the file of a multipart/form-data request
+func (s *Server) uploadFile(ctx context.Context, reader *multipart.Reader) (CreateFileResponseObject, error) {
+	maxBytes := s.files.Config().MaxFileBytes
+	var name, contentType string
+	var content []byte
+	found := false
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return CreateFile400JSONResponse{Message: fmt.Sprintf("invalid multipart body: %v", err)}, nil
+		}
+		switch part.FormName() {
+		case "file":
+			content, err = io.ReadAll(io.LimitReader(part, maxBytes+1))
+			if err != nil {
+				return CreateFile400JSONResponse{Message: fmt.Sprintf("failed to read file: %v", err)}, nil
+			}
+			if int64(len(content)) > maxBytes {
+				return CreateFile400JSONResponse{Message: fmt.Sprintf("the file is above the maximum size of %d bytes", maxBytes)}, nil
+			}
+			if name == "" {
+				name = part.FileName()
+			}
+			contentType = part.Header.Get("Content-Type")
+			found = true
+		case "name":
+			value, err := io.ReadAll(io.LimitReader(part, maxFileNameLength+1))
+			if err != nil {
+				return CreateFile400JSONResponse{Message: fmt.Sprintf("failed to read name: %v", err)}, nil
+			}
+			name = string(value)
+		}
+		part.Close()
+	}
+	if !found {
+		return CreateFile400JSONResponse{Message: "file is required"}, nil
+	}
+	// The content type of a part without one is detected from its content
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(content)
+	}
+	if message := validateFile(name, contentType); message != "" {
+		return CreateFile400JSONResponse{Message: message}, nil
+	}
+
+	userID := custom_middleware.RequestUserID(ctx)
+	fileID := uuid.New()
+	key := s.files.ObjectKey(userID, fileID)
+	if err := s.files.Put(ctx, key, contentType, content); err != nil {
+		return nil, err
+	}
+	file, err := s.queries.CreateFile(ctx, db.CreateFileParams{
+		ID:          fileID,
+		UserID:      userID,
+		Name:        name,
+		ContentType: contentType,
+		SizeBytes:   int64(len(content)),
+		Status:      db.FileStatusUploaded,
+		Bucket:      s.files.Bucket(),
+		ObjectKey:   key,
+		UploadedAt:  pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	s.log.Info("Uploaded file", "file_id", file.ID, "user_id", userID, "content_type", contentType, "size_bytes", file.SizeBytes)
+	return CreateFile201JSONResponse{File: toFile(file)}, nil
+}
+
+// createFileUpload creates a file uploaded to a presigned URL
+func (s *Server) createFileUpload(ctx context.Context, body CreateFileUploadRequest) (CreateFileResponseObject, error) {
+	maxBytes := s.files.Config().MaxFileBytes
+	if message := validateFile(body.Name, body.ContentType); message != "" {
+		return CreateFile400JSONResponse{Message: message}, nil
+	}
+	if body.SizeBytes < 0 {
+		return CreateFile400JSONResponse{Message: "size_bytes must not be negative"}, nil
+	}
+	if body.SizeBytes > maxBytes {
+		return CreateFile400JSONResponse{Message: fmt.Sprintf("the file is above the maximum size of %d bytes", maxBytes)}, nil
+	}
+
+	userID := custom_middleware.RequestUserID(ctx)
+	fileID := uuid.New()
+	key := s.files.ObjectKey(userID, fileID)
+	url, expiresAt, err := s.files.PresignUpload(ctx, key, body.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	file, err := s.queries.CreateFile(ctx, db.CreateFileParams{
+		ID:          fileID,
+		UserID:      userID,
+		Name:        body.Name,
+		ContentType: body.ContentType,
+		SizeBytes:   body.SizeBytes,
+		Status:      db.FileStatusPending,
+		Bucket:      s.files.Bucket(),
+		ObjectKey:   key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	return CreateFile201JSONResponse{File: toFile(file), UploadUrl: &url, UploadExpiresAt: &expiresAt}, nil
+}
+
+// Get file by ID
+// (GET /v1/files/{file_id})
+func (s *Server) GetFile(ctx context.Context, request GetFileRequestObject) (GetFileResponseObject, error) {
+	file, err := s.queries.GetFile(ctx, db.GetFileParams{ID: request.FileId, UserID: custom_middleware.RequestUserID(ctx)})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return GetFile404JSONResponse{Message: "File not found", Resource: FILE_RESOURCE, Id: request.FileId}, nil
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	return GetFile200JSONResponse(toFile(file)), nil
+}
+
+// Delete file
+// (DELETE /v1/files/{file_id})
+func (s *Server) DeleteFile(ctx context.Context, request DeleteFileRequestObject) (DeleteFileResponseObject, error) {
+	file, err := s.queries.DeleteFile(ctx, db.DeleteFileParams{ID: request.FileId, UserID: custom_middleware.RequestUserID(ctx)})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return DeleteFile404JSONResponse{Message: "File not found", Resource: FILE_RESOURCE, Id: request.FileId}, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return DeleteFile400JSONResponse{Message: "the file is attached to messages"}, nil
+		}
+		return nil, fmt.Errorf("failed to delete file: %w", err)
+	}
+	// The file is gone once its record is, a content left behind is only storage
+	if err := s.files.Delete(ctx, file); err != nil {
+		s.log.Warn("Failed to delete the content of a deleted file", "file_id", file.ID, "object_key", file.ObjectKey, "error", err)
+	}
+	return DeleteFile204Response{}, nil
+}
+
+// Complete a presigned upload
+// (POST /v1/files/{file_id}/complete)
+func (s *Server) CompleteFileUpload(ctx context.Context, request CompleteFileUploadRequestObject) (CompleteFileUploadResponseObject, error) {
+	userID := custom_middleware.RequestUserID(ctx)
+	file, err := s.queries.GetFile(ctx, db.GetFileParams{ID: request.FileId, UserID: userID})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return CompleteFileUpload404JSONResponse{Message: "File not found", Resource: FILE_RESOURCE, Id: request.FileId}, nil
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	if file.Status != db.FileStatusPending {
+		return CompleteFileUpload400JSONResponse{Message: "the upload of the file is already completed"}, nil
+	}
+	if s.files == nil {
+		return nil, fmt.Errorf("failed to complete file upload: the S3 storage is not configured")
+	}
+
+	size, contentType, err := s.files.Head(ctx, file)
+	if err != nil {
+		s.log.Debug("Content of a pending file not found", "file_id", file.ID, "error", err)
+		return CompleteFileUpload400JSONResponse{Message: "the content of the file is not uploaded"}, nil
+	}
+	if maxBytes := s.files.Config().MaxFileBytes; size > maxBytes {
+		return CompleteFileUpload400JSONResponse{Message: fmt.Sprintf("the file is above the maximum size of %d bytes", maxBytes)}, nil
+	}
+	if contentType == "" {
+		contentType = file.ContentType
+	}
+	file, err = s.queries.CompleteFileUpload(ctx, db.CompleteFileUploadParams{
+		ID:          file.ID,
+		UserID:      userID,
+		SizeBytes:   size,
+		ContentType: contentType,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return CompleteFileUpload400JSONResponse{Message: "the upload of the file is already completed"}, nil
+		}
+		return nil, fmt.Errorf("failed to complete file upload: %w", err)
+	}
+	return CompleteFileUpload200JSONResponse(toFile(file)), nil
+}
+
+// Download file
+// (GET /v1/files/{file_id}/content)
+func (s *Server) DownloadFile(ctx context.Context, request DownloadFileRequestObject) (DownloadFileResponseObject, error) {
+	file, err := s.queries.GetFile(ctx, db.GetFileParams{ID: request.FileId, UserID: custom_middleware.RequestUserID(ctx)})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return DownloadFile404JSONResponse{Message: "File not found", Resource: FILE_RESOURCE, Id: request.FileId}, nil
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	if file.Status != db.FileStatusUploaded {
+		return DownloadFile404JSONResponse{Message: "The content of the file is not uploaded", Resource: FILE_RESOURCE, Id: request.FileId}, nil
+	}
+
+	object, err := s.files.Get(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	return DownloadFile200AsteriskResponse{
+		Body:          object.Body,
+		ContentType:   file.ContentType,
+		ContentLength: aws.ToInt64(object.ContentLength),
+		Headers: DownloadFile200ResponseHeaders{
+			ContentDisposition: mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}),
+		},
+	}, nil
+}
+
+// List message attachments
+// (GET /v1/threads/{thread_id}/messages/{message_id}/attachments)
+func (s *Server) ListMessageAttachments(ctx context.Context, request ListMessageAttachmentsRequestObject) (ListMessageAttachmentsResponseObject, error) {
+	if _, err := s.queries.GetThreadByID(ctx, db.GetThreadByIDParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), UserID: custom_middleware.RequestUserID(ctx), ID: request.ThreadId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListMessageAttachments404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ThreadID != request.ThreadId {
+		return ListMessageAttachments404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
+
+	rows, err := s.queries.ListMessageAttachments(ctx, []uuid.UUID{request.MessageId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message attachments: %w", err)
+	}
+	attachments := make([]File, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, toFile(db.File{
+			ID:          row.ID,
+			UserID:      row.UserID,
+			Name:        row.Name,
+			ContentType: row.ContentType,
+			SizeBytes:   row.SizeBytes,
+			Status:      row.Status,
+			CreatedAt:   row.CreatedAt,
+			UploadedAt:  row.UploadedAt,
+		}))
+	}
+	return ListMessageAttachments200JSONResponse(AttachmentList{Attachments: attachments}), nil
+}
+
+// messageAttachments returns the files of a user attached to a new message, and the error message of the request
+// when a file cannot be attached
+func (s *Server) messageAttachments(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]db.File, string, error) {
+	if len(ids) > files.MaxAttachments {
+		return nil, fmt.Sprintf("a message has at most %d attachments", files.MaxAttachments), nil
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	attachments := make([]db.File, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fmt.Sprintf("file %s is attached twice", id), nil
+		}
+		seen[id] = true
+		file, err := s.queries.GetFile(ctx, db.GetFileParams{ID: id, UserID: userID})
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return nil, fmt.Sprintf("file %s not found", id), nil
+			}
+			return nil, "", fmt.Errorf("failed to get file: %w", err)
+		}
+		if file.Status != db.FileStatusUploaded {
+			return nil, fmt.Sprintf("the content of file %s is not uploaded", id), nil
+		}
+		if !files.Attachable(file.ContentType) {
+			return nil, fmt.Sprintf("file %s of type %s cannot be attached, only images, PDF and text documents can", id, file.ContentType), nil
+		}
+		attachments = append(attachments, file)
+	}
+	return attachments, "", nil
+}
+
+// validateFile returns the error message of an invalid file name or content type
+func validateFile(name, contentType string) string {
+	if strings.TrimSpace(name) == "" {
+		return "name is required"
+	}
+	if len(name) > maxFileNameLength {
+		return fmt.Sprintf("name must be at most %d characters", maxFileNameLength)
+	}
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return fmt.Sprintf("invalid content_type %q", contentType)
+	}
+	return ""
+}
+
+func toFile(file db.File) File {
+	return File{
+		Id:          file.ID,
+		UserId:      file.UserID,
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		SizeBytes:   file.SizeBytes,
+		Status:      FileStatus(file.Status),
+		CreatedAt:   file.CreatedAt.Time,
+		UploadedAt:  timestamptzPtr(file.UploadedAt),
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"mime"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/jackc/pgx/v5"
+	"github.com/pinazu/internal/db"
+)
+
+const FLOW_RUN_ARTIFACT_RESOURCE = "FlowRunArtifact"
+
+// artifactStore holds the contents of the flow run artifacts, implemented by the S3 client
+type artifactStore interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// List flow run artifacts
+// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts)
+func (s *Server) ListFlowRunArtifacts(ctx context.Context, req ListFlowRunArtifactsRequestObject) (ListFlowRunArtifactsResponseObject, error) {
+	if _, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId); err != nil {
+		return nil, err
+	} else if !found {
+		return ListFlowRunArtifacts404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	artifacts, err := s.queries.ListFlowRunArtifacts(ctx, req.RunId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow run artifacts: %w", err)
+	}
+	return ListFlowRunArtifacts200JSONResponse{Artifacts: artifacts}, nil
+}
+
+// Download flow run artifact
+// (GET /v1/flows/{flow_id}/runs/{run_id}/artifacts/{name})
+func (s *Server) DownloadFlowRunArtifact(ctx context.Context, req DownloadFlowRunArtifactRequestObject) (DownloadFlowRunArtifactResponseObject, error) {
+	if _, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId); err != nil {
+		return nil, err
+	} else if !found {
+		return DownloadFlowRunArtifact404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	artifact, err := s.queries.GetFlowRunArtifact(ctx, db.GetFlowRunArtifactParams{FlowRunID: req.RunId, Name: req.Name})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return DownloadFlowRunArtifact404JSONResponse{
+				Resource: FLOW_RUN_ARTIFACT_RESOURCE,
+				Id:       req.RunId,
+				Message:  fmt.Sprintf("Artifact %s not found for flow run %s", req.Name, req.RunId),
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get flow run artifact: %w", err)
+	}
+	if s.artifacts == nil {
+		return nil, fmt.Errorf("failed to download flow run artifact: the S3 storage is not configured")
+	}
+
+	object, err := s.artifacts.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &artifact.Bucket,
+		Key:    &artifact.ObjectKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download flow run artifact: %w", err)
+	}
+	return DownloadFlowRunArtifact200AsteriskResponse{
+		Body:          object.Body,
+		ContentType:   artifact.ContentType,
+		ContentLength: aws.ToInt64(object.ContentLength),
+		Headers: DownloadFlowRunArtifact200ResponseHeaders{
+			ContentDisposition: mime.FormatMediaType("attachment", map[string]string{"filename": artifact.Name}),
+		},
+	}, nil
+}
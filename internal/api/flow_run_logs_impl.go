@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+const (
+	flowRunLogsDefaultLimit = 500
+	flowRunLogsMaxLimit     = 5000
+	flowRunLogsPollInterval = time.Second      // How often the followed logs are looked up
+	flowRunLogsHeartbeat    = 15 * time.Second // Keeps the followed logs stream alive while the run writes nothing
+	flowRunLogsGracePeriod  = 5 * time.Second  // The last output of a process may be stored after its run ended
+)
+
+// Get flow run logs
+// (GET /v1/flows/{flow_id}/runs/{run_id}/logs)
+func (s *Server) GetFlowRunLogs(ctx context.Context, req GetFlowRunLogsRequestObject) (GetFlowRunLogsResponseObject, error) {
+	after := int64(0)
+	if req.Params.After != nil {
+		if *req.Params.After < 0 {
+			return GetFlowRunLogs400JSONResponse{Message: "after must not be negative"}, nil
+		}
+		after = *req.Params.After
+	}
+	limit := int32(flowRunLogsDefaultLimit)
+	if req.Params.Limit != nil {
+		if *req.Params.Limit < 1 || *req.Params.Limit > flowRunLogsMaxLimit {
+			return GetFlowRunLogs400JSONResponse{Message: fmt.Sprintf("limit must be between 1 and %d", flowRunLogsMaxLimit)}, nil
+		}
+		limit = *req.Params.Limit
+	}
+
+	if _, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId); err != nil {
+		return nil, err
+	} else if !found {
+		return GetFlowRunLogs404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	headers := GetFlowRunLogs200ResponseHeaders{
+		CacheControl: "no-cache",
+		Connection:   "keep-alive",
+	}
+
+	if req.Params.Follow != nil && *req.Params.Follow {
+		pipeReader, pipeWriter := io.Pipe()
+		go s.followFlowRunLogs(ctx, req.RunId, after, pipeWriter)
+		return GetFlowRunLogs200TexteventStreamResponse{Body: pipeReader, Headers: headers}, nil
+	}
+
+	logs, err := s.queries.ListFlowRunLogs(ctx, db.ListFlowRunLogsParams{FlowRunID: req.RunId, AfterID: after, MaxChunks: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow run logs: %w", err)
+	}
+	nextCursor := after
+	if len(logs) > 0 {
+		nextCursor = logs[len(logs)-1].ID
+	}
+	return GetFlowRunLogs200JSONResponse{
+		Body:    FlowRunLogList{Logs: logs, NextCursor: nextCursor},
+		Headers: headers,
+	}, nil
+}
+
+// followFlowRunLogs writes the log chunks of a flow run to w as Server-Sent Events as they are stored,
+// until the run ended and its last output was stored, or the context is cancelled. w is then closed.
+// The id of each event is the cursor of its chunk, a client reconnects from it with the after parameter.
+func (s *Server) followFlowRunLogs(ctx context.Context, flowRunID uuid.UUID, after int64, w io.WriteCloser) {
+	defer w.Close()
+
+	ticker := time.NewTicker(flowRunLogsPollInterval)
+	defer ticker.Stop()
+	lastWrite := time.Now()
+	for {
+		logs, err := s.queries.ListFlowRunLogs(ctx, db.ListFlowRunLogsParams{FlowRunID: flowRunID, AfterID: after, MaxChunks: flowRunLogsDefaultLimit})
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Error("Failed to list flow run logs", "flow_run_id", flowRunID, "error", err)
+			}
+			return
+		}
+		for _, chunk := range logs {
+			data, err := json.Marshal(chunk)
+			if err != nil {
+				s.log.Error("Failed to marshal flow run log", "flow_run_id", flowRunID, "error", err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: log\ndata: %s\n\n", chunk.ID, data); err != nil {
+				return
+			}
+			after = chunk.ID
+			lastWrite = time.Now()
+		}
+		if len(logs) == flowRunLogsDefaultLimit {
+			// More chunks are stored, read them without waiting
+			continue
+		}
+
+		if len(logs) == 0 {
+			flowRun, err := s.queries.GetFlowRun(ctx, flowRunID)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.log.Error("Failed to get flow run", "flow_run_id", flowRunID, "error", err)
+				}
+				return
+			}
+			if flowRunEnded(flowRun, time.Now()) {
+				fmt.Fprintf(w, "event: end\ndata: {\"status\":%q}\n\n", flowRun.Status)
+				return
+			}
+		}
+		if time.Since(lastWrite) >= flowRunLogsHeartbeat {
+			if _, err := fmt.Fprintf(w, "event: heartbeat\ndata: {\"type\":\"heartbeat\",\"timestamp\":\"%s\"}\n\n", time.Now().UTC().Format(time.RFC3339)); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// flowRunEnded reports whether a flow run ended long enough ago for the last output of its process to be stored
+func flowRunEnded(flowRun db.FlowRun, now time.Time) bool {
+	switch flowRun.Status {
+	case db.FlowStatusSuccess, db.FlowStatusFailed, db.FlowStatusCancelled, db.FlowStatusPaused:
+	default:
+		return false
+	}
+	endedAt := flowRun.FinishedAt.Time
+	if !flowRun.FinishedAt.Valid {
+		endedAt = flowRun.UpdatedAt.Time
+	}
+	return now.Sub(endedAt) >= flowRunLogsGracePeriod
+}
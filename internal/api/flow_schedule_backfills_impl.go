@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/db"
+)
+
+const FLOW_SCHEDULE_BACKFILL_RESOURCE = "FlowScheduleBackfill"
+
+// List flow schedule backfills
+// (GET /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+func (s *Server) ListFlowScheduleBackfills(ctx context.Context, request ListFlowScheduleBackfillsRequestObject) (ListFlowScheduleBackfillsResponseObject, error) {
+	if _, err := s.queries.GetFlowSchedule(ctx, db.GetFlowScheduleParams{ID: request.ScheduleId, FlowID: request.FlowId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListFlowScheduleBackfills404JSONResponse{Message: "Schedule not found", Resource: FLOW_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+	backfills, err := s.queries.ListFlowScheduleBackfills(ctx, db.ListFlowScheduleBackfillsParams{ScheduleID: request.ScheduleId, FlowID: request.FlowId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow schedule backfills: %w", err)
+	}
+	return ListFlowScheduleBackfills200JSONResponse{Backfills: backfills}, nil
+}
+
+// Create flow schedule backfill
+// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills)
+func (s *Server) CreateFlowScheduleBackfill(ctx context.Context, request CreateFlowScheduleBackfillRequestObject) (CreateFlowScheduleBackfillResponseObject, error) {
+	schedule, err := s.queries.GetFlowSchedule(ctx, db.GetFlowScheduleParams{ID: request.ScheduleId, FlowID: request.FlowId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return CreateFlowScheduleBackfill404JSONResponse{Message: "Schedule not found", Resource: FLOW_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+	flow, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: schedule.FlowID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow: %w", err)
+	}
+
+	maxConcurrentRuns := int32(db.DefaultFlowScheduleBackfillConcurrency)
+	if request.Body.MaxConcurrentRuns != nil {
+		maxConcurrentRuns = *request.Body.MaxConcurrentRuns
+	}
+	fires, msg := validateFlowScheduleBackfill(schedule, request.Body.StartTime, request.Body.EndTime, maxConcurrentRuns)
+	if msg != "" {
+		return CreateFlowScheduleBackfill400JSONResponse{Message: msg}, nil
+	}
+
+	// The runs receive the parameters of the schedule with their scheduled time, validated once for the first run
+	var parameters map[string]any
+	if err := json.Unmarshal(schedule.Parameters, &parameters); err != nil {
+		return CreateFlowScheduleBackfill400JSONResponse{Message: fmt.Sprintf("invalid parameters of the schedule: %s", err)}, nil
+	}
+	violations, err := db.ValidateFlowParameters(flow, db.FlowScheduleBackfillParameters(parameters, fires[0]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate flow schedule backfill parameters: %w", err)
+	}
+	if len(violations) > 0 {
+		return CreateFlowScheduleBackfill400JSONResponse{
+			Message:    fmt.Sprintf("Parameters of the schedule do not match the parameters schema of flow %s", flow.Name),
+			Violations: &violations,
+		}, nil
+	}
+
+	backfill, err := s.queries.CreateFlowScheduleBackfill(ctx, db.CreateFlowScheduleBackfillParams{
+		ScheduleID:        schedule.ID,
+		FlowID:            schedule.FlowID,
+		CronExpression:    schedule.CronExpression,
+		Timezone:          schedule.Timezone,
+		Parameters:        schedule.Parameters,
+		Engine:            schedule.Engine,
+		StartAt:           pgtype.Timestamptz{Time: request.Body.StartTime, Valid: true},
+		EndAt:             pgtype.Timestamptz{Time: request.Body.EndTime, Valid: true},
+		MaxConcurrentRuns: maxConcurrentRuns,
+		TotalRuns:         int32(len(fires)),
+		CreatedBy:         schedule.CreatedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flow schedule backfill: %w", err)
+	}
+	s.log.Info("Created flow schedule backfill", "backfill_id", backfill.ID, "schedule_id", schedule.ID, "total_runs", backfill.TotalRuns)
+	return CreateFlowScheduleBackfill201JSONResponse(backfill), nil
+}
+
+// Cancel flow schedule backfill
+// (POST /v1/flows/{flow_id}/schedules/{schedule_id}/backfills/{backfill_id}/cancel)
+func (s *Server) CancelFlowScheduleBackfill(ctx context.Context, request CancelFlowScheduleBackfillRequestObject) (CancelFlowScheduleBackfillResponseObject, error) {
+	backfill, err := s.queries.CancelFlowScheduleBackfill(ctx, db.CancelFlowScheduleBackfillParams{
+		ID:         request.BackfillId,
+		ScheduleID: request.ScheduleId,
+		FlowID:     request.FlowId,
+	})
+	if err == nil {
+		return CancelFlowScheduleBackfill200JSONResponse(backfill), nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to cancel flow schedule backfill: %w", err)
+	}
+
+	// Only a running backfill is cancelled, tell a missing backfill from one that already ended
+	backfill, err = s.queries.GetFlowScheduleBackfill(ctx, db.GetFlowScheduleBackfillParams{
+		ID:         request.BackfillId,
+		ScheduleID: request.ScheduleId,
+		FlowID:     request.FlowId,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return CancelFlowScheduleBackfill404JSONResponse{Message: "Backfill not found", Resource: FLOW_SCHEDULE_BACKFILL_RESOURCE, Id: request.BackfillId}, nil
+		}
+		return nil, err
+	}
+	return CancelFlowScheduleBackfill400JSONResponse{Message: fmt.Sprintf("Backfill is %s, only a running backfill can be cancelled", backfill.Status)}, nil
+}
+
+// validateFlowScheduleBackfill returns the times the schedule fired over the range of a backfill, and why the
+// backfill settings are invalid, empty when they are valid
+func validateFlowScheduleBackfill(schedule db.FlowSchedule, start, end time.Time, maxConcurrentRuns int32) ([]time.Time, string) {
+	switch {
+	case !end.After(start):
+		return nil, "end_time must be after start_time"
+	case end.After(time.Now()):
+		return nil, "end_time must not be in the future"
+	case maxConcurrentRuns < 1 || maxConcurrentRuns > 100:
+		return nil, "max_concurrent_runs must be between 1 and 100"
+	}
+	cron, err := db.ParseCronExpression(schedule.CronExpression)
+	if err != nil {
+		return nil, err.Error()
+	}
+	loc, err := db.LoadScheduleLocation(schedule.Timezone)
+	if err != nil {
+		return nil, err.Error()
+	}
+	fires := cron.FireTimes(start.In(loc), end, db.MaxFlowScheduleBackfillRuns+1)
+	switch {
+	case len(fires) == 0:
+		return nil, "the schedule does not fire between start_time and end_time"
+	case len(fires) > db.MaxFlowScheduleBackfillRuns:
+		return nil, fmt.Sprintf("the schedule fires more than %d times between start_time and end_time, split the range in several backfills", db.MaxFlowScheduleBackfillRuns)
+	}
+	return fires, ""
+}
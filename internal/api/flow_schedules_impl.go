@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/db"
+)
+
+const FLOW_SCHEDULE_RESOURCE = "FlowSchedule"
+
+// List flow schedules
+// (GET /v1/flows/{flow_id}/schedules)
+func (s *Server) ListFlowSchedules(ctx context.Context, request ListFlowSchedulesRequestObject) (ListFlowSchedulesResponseObject, error) {
+	if _, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.FlowId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListFlowSchedules404JSONResponse{Message: "Flow not found", Resource: FLOW_RESOURCE, Id: request.FlowId}, nil
+		}
+		return nil, err
+	}
+	schedules, err := s.queries.ListFlowSchedules(ctx, request.FlowId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow schedules: %w", err)
+	}
+	return ListFlowSchedules200JSONResponse{Schedules: schedules}, nil
+}
+
+// Create flow schedule
+// (POST /v1/flows/{flow_id}/schedules)
+func (s *Server) CreateFlowSchedule(ctx context.Context, request CreateFlowScheduleRequestObject) (CreateFlowScheduleResponseObject, error) {
+	createdBy := custom_middleware.RequestUserID(ctx)
+
+	params := db.CreateFlowScheduleParams{
+		FlowID:         request.FlowId,
+		Name:           request.Body.Name,
+		CronExpression: request.Body.CronExpression,
+		Timezone:       "UTC",
+		Parameters:     db.JsonRaw("{}"),
+		Enabled:        true,
+		CatchUpPolicy:  db.FlowScheduleCatchUpPolicySkip,
+		CreatedBy:      createdBy,
+	}
+	if request.Body.Timezone != nil {
+		params.Timezone = *request.Body.Timezone
+	}
+	if request.Body.Parameters != nil {
+		var err error
+		if params.Parameters, err = db.NewJsonRaw(*request.Body.Parameters); err != nil {
+			return CreateFlowSchedule400JSONResponse{Message: fmt.Sprintf("invalid parameters: %s", err)}, nil
+		}
+	}
+	if request.Body.Engine != nil && *request.Body.Engine != "" {
+		params.Engine = pgtype.Text{String: *request.Body.Engine, Valid: true}
+	}
+	if request.Body.Enabled != nil {
+		params.Enabled = *request.Body.Enabled
+	}
+	if request.Body.CatchUpPolicy != nil {
+		params.CatchUpPolicy = db.FlowScheduleCatchUpPolicy(*request.Body.CatchUpPolicy)
+	}
+	nextRunAt, msg := validateFlowSchedule(params.Name, params.CronExpression, params.Timezone, params.CatchUpPolicy)
+	if msg != "" {
+		return CreateFlowSchedule400JSONResponse{Message: msg}, nil
+	}
+	params.NextRunAt = pgtype.Timestamptz{Time: nextRunAt, Valid: true}
+
+	if _, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.FlowId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return CreateFlowSchedule404JSONResponse{Message: "Flow not found", Resource: FLOW_RESOURCE, Id: request.FlowId}, nil
+		}
+		return nil, err
+	}
+
+	schedule, err := s.queries.CreateFlowSchedule(ctx, params)
+	if err != nil {
+		if db.IsConflictError(err) {
+			return CreateFlowSchedule409JSONResponse{Message: fmt.Sprintf("Schedule %s already exists for this flow", params.Name), Resource: FLOW_SCHEDULE_RESOURCE, Id: request.FlowId}, nil
+		}
+		return nil, fmt.Errorf("failed to create flow schedule: %w", err)
+	}
+	return CreateFlowSchedule201JSONResponse(schedule), nil
+}
+
+// Get flow schedule
+// (GET /v1/flows/{flow_id}/schedules/{schedule_id})
+func (s *Server) GetFlowSchedule(ctx context.Context, request GetFlowScheduleRequestObject) (GetFlowScheduleResponseObject, error) {
+	schedule, err := s.queries.GetFlowSchedule(ctx, db.GetFlowScheduleParams{ID: request.ScheduleId, FlowID: request.FlowId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return GetFlowSchedule404JSONResponse{Message: "Schedule not found", Resource: FLOW_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+	return GetFlowSchedule200JSONResponse(schedule), nil
+}
+
+// Update flow schedule
+// (PUT /v1/flows/{flow_id}/schedules/{schedule_id})
+func (s *Server) UpdateFlowSchedule(ctx context.Context, request UpdateFlowScheduleRequestObject) (UpdateFlowScheduleResponseObject, error) {
+	schedule, err := s.queries.GetFlowSchedule(ctx, db.GetFlowScheduleParams{ID: request.ScheduleId, FlowID: request.FlowId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return UpdateFlowSchedule404JSONResponse{Message: "Schedule not found", Resource: FLOW_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+
+	params := db.UpdateFlowScheduleParams{
+		ID:             schedule.ID,
+		FlowID:         schedule.FlowID,
+		Name:           schedule.Name,
+		CronExpression: schedule.CronExpression,
+		Timezone:       schedule.Timezone,
+		Parameters:     schedule.Parameters,
+		Engine:         schedule.Engine,
+		Enabled:        schedule.Enabled,
+		CatchUpPolicy:  schedule.CatchUpPolicy,
+		NextRunAt:      schedule.NextRunAt,
+	}
+	if request.Body.Name != nil {
+		params.Name = *request.Body.Name
+	}
+	if request.Body.CronExpression != nil {
+		params.CronExpression = *request.Body.CronExpression
+	}
+	if request.Body.Timezone != nil {
+		params.Timezone = *request.Body.Timezone
+	}
+	if request.Body.Parameters != nil {
+		if params.Parameters, err = db.NewJsonRaw(*request.Body.Parameters); err != nil {
+			return UpdateFlowSchedule400JSONResponse{Message: fmt.Sprintf("invalid parameters: %s", err)}, nil
+		}
+	}
+	if request.Body.Engine != nil {
+		params.Engine = pgtype.Text{String: *request.Body.Engine, Valid: *request.Body.Engine != ""}
+	}
+	if request.Body.Enabled != nil {
+		params.Enabled = *request.Body.Enabled
+	}
+	if request.Body.CatchUpPolicy != nil {
+		params.CatchUpPolicy = db.FlowScheduleCatchUpPolicy(*request.Body.CatchUpPolicy)
+	}
+	nextRunAt, msg := validateFlowSchedule(params.Name, params.CronExpression, params.Timezone, params.CatchUpPolicy)
+	if msg != "" {
+		return UpdateFlowSchedule400JSONResponse{Message: msg}, nil
+	}
+	// A new expression, or a schedule enabled again, starts from now instead of catching up
+	if params.CronExpression != schedule.CronExpression || params.Timezone != schedule.Timezone || (params.Enabled && !schedule.Enabled) {
+		params.NextRunAt = pgtype.Timestamptz{Time: nextRunAt, Valid: true}
+	}
+
+	schedule, err = s.queries.UpdateFlowSchedule(ctx, params)
+	if err != nil {
+		if db.IsConflictError(err) {
+			return UpdateFlowSchedule409JSONResponse{Message: fmt.Sprintf("Schedule %s already exists for this flow", params.Name), Resource: FLOW_SCHEDULE_RESOURCE, Id: request.FlowId}, nil
+		}
+		return nil, fmt.Errorf("failed to update flow schedule: %w", err)
+	}
+	return UpdateFlowSchedule200JSONResponse(schedule), nil
+}
+
+// Delete flow schedule
+// (DELETE /v1/flows/{flow_id}/schedules/{schedule_id})
+func (s *Server) DeleteFlowSchedule(ctx context.Context, request DeleteFlowScheduleRequestObject) (DeleteFlowScheduleResponseObject, error) {
+	params := db.GetFlowScheduleParams{ID: request.ScheduleId, FlowID: request.FlowId}
+	if _, err := s.queries.GetFlowSchedule(ctx, params); err != nil {
+		if err == pgx.ErrNoRows {
+			return DeleteFlowSchedule404JSONResponse{Message: "Schedule not found", Resource: FLOW_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+	if err := s.queries.DeleteFlowSchedule(ctx, db.DeleteFlowScheduleParams(params)); err != nil {
+		return nil, fmt.Errorf("failed to delete flow schedule: %w", err)
+	}
+	return DeleteFlowSchedule204Response{}, nil
+}
+
+// validateFlowSchedule returns the next run of the schedule from now, and why the schedule settings are invalid,
+// empty when they are valid
+func validateFlowSchedule(name, cronExpression, timezone string, policy db.FlowScheduleCatchUpPolicy) (time.Time, string) {
+	switch {
+	case name == "":
+		return time.Time{}, "name is required"
+	case len(name) > 255:
+		return time.Time{}, "name must be less than 255 characters"
+	case cronExpression == "":
+		return time.Time{}, "cron_expression is required"
+	}
+	switch policy {
+	case db.FlowScheduleCatchUpPolicySkip, db.FlowScheduleCatchUpPolicyOnce, db.FlowScheduleCatchUpPolicyAll:
+	default:
+		return time.Time{}, fmt.Sprintf("invalid catch_up_policy %q, must be skip, once or all", policy)
+	}
+	next, err := db.NextCronRun(cronExpression, timezone, time.Now())
+	if err != nil {
+		return time.Time{}, err.Error()
+	}
+	return next, ""
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/service"
 	"github.com/pinazu/internal/utils"
@@ -25,12 +26,41 @@ func (s *Server) CreateFlow(ctx context.Context, req CreateFlowRequestObject) (C
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal parameters schema: %w", err)
 	}
+	if _, err := db.ParseFlowParametersSchema(parametersSchema); err != nil {
+		return CreateFlow400JSONResponse(NotFound{
+			Resource: FLOW_RESOURCE,
+			Message:  err.Error(),
+		}), nil
+	}
 	if req.Body.Name == "" {
 		return CreateFlow400JSONResponse(NotFound{
 			Resource: FLOW_RESOURCE,
 			Message:  "Flow name is required",
 		}), nil
 	}
+	notifications := []db.FlowNotification{}
+	if req.Body.Notifications != nil {
+		notifications = *req.Body.Notifications
+	}
+	if err := db.ValidateFlowNotifications(notifications); err != nil {
+		return CreateFlow400JSONResponse(NotFound{
+			Resource: FLOW_RESOURCE,
+			Message:  err.Error(),
+		}), nil
+	}
+	notificationsJsonRaw, err := db.NewJsonRaw(notifications)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notifications: %w", err)
+	}
+	var requiredLabels []string
+	if req.Body.RequiredLabels != nil {
+		if requiredLabels, err = db.NormalizeWorkerLabels(*req.Body.RequiredLabels); err != nil {
+			return CreateFlow400JSONResponse(NotFound{
+				Resource: FLOW_RESOURCE,
+				Message:  err.Error(),
+			}), nil
+		}
+	}
 	params := &db.CreateFlowParams{
 		ID:   uuid.Must(uuid.NewV7()),
 		Name: req.Body.Name,
@@ -44,6 +74,16 @@ func (s *Server) CreateFlow(ctx context.Context, req CreateFlowRequestObject) (C
 		Tags:             []string{},
 		CodeLocation:     pgtype.Text{String: req.Body.CodeLocation, Valid: true},
 		Entrypoint:       pgtype.Text{String: req.Body.Entrypoint, Valid: true},
+		Notifications:    notificationsJsonRaw,
+		RequiredLabels:   requiredLabels,
+		WorkspaceID:      custom_middleware.RequestWorkspaceID(ctx),
+	}
+	params.ConcurrencyPolicy = db.FlowConcurrencyPolicyQueue
+	if msg := applyFlowConcurrency(req.Body.MaxConcurrentRuns, (*string)(req.Body.ConcurrencyPolicy), &params.MaxConcurrentRuns, &params.ConcurrencyPolicy); msg != "" {
+		return CreateFlow400JSONResponse(NotFound{
+			Resource: FLOW_RESOURCE,
+			Message:  msg,
+		}), nil
 	}
 	if req.Body.Tags != nil {
 		params.Tags = *req.Body.Tags
@@ -55,11 +95,12 @@ func (s *Server) CreateFlow(ctx context.Context, req CreateFlowRequestObject) (C
 	if err != nil {
 		return nil, fmt.Errorf("failed to create flow: %w", err)
 	}
+	s.recordResourceChange(ctx, db.ResourceTypeFlow, flow.ID, db.ResourceChangeActionCreate, nil, flow)
 	return CreateFlow201JSONResponse(flow), nil
 }
 
 func (s *Server) GetFlow(ctx context.Context, req GetFlowRequestObject) (GetFlowResponseObject, error) {
-	flow, err := s.queries.GetFlowById(ctx, req.FlowId)
+	flow, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: req.FlowId})
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return GetFlow404JSONResponse(NotFound{
@@ -76,7 +117,7 @@ func (s *Server) GetFlow(ctx context.Context, req GetFlowRequestObject) (GetFlow
 func (s *Server) UpdateFlow(ctx context.Context, req UpdateFlowRequestObject) (UpdateFlowResponseObject, error) {
 	flow_id := req.FlowId
 	// Validate request
-	flow, err := s.queries.GetFlowById(ctx, flow_id)
+	flow, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: flow_id})
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return UpdateFlow404JSONResponse(NotFound{
@@ -88,15 +129,23 @@ func (s *Server) UpdateFlow(ctx context.Context, req UpdateFlowRequestObject) (U
 		return nil, fmt.Errorf("failed to get flow: %w", err)
 	}
 	params := &db.UpdateFlowParams{
-		ID:               flow_id,
-		Name:             flow.Name,
-		Description:      flow.Description,
-		ParametersSchema: flow.ParametersSchema,
-		Engine:           flow.Engine,
-		AdditionalInfo:   flow.AdditionalInfo, // Assuming AdditionalInfo is optional and can be nil
-		Tags:             flow.Tags,
-		CodeLocation:     flow.CodeLocation,
-		Entrypoint:       flow.Entrypoint,
+		ID:                flow_id,
+		Name:              flow.Name,
+		Description:       flow.Description,
+		ParametersSchema:  flow.ParametersSchema,
+		Engine:            flow.Engine,
+		AdditionalInfo:    flow.AdditionalInfo, // Assuming AdditionalInfo is optional and can be nil
+		Tags:              flow.Tags,
+		CodeLocation:      flow.CodeLocation,
+		Entrypoint:        flow.Entrypoint,
+		Notifications:     flow.Notifications,
+		RequiredLabels:    flow.RequiredLabels,
+		MaxConcurrentRuns: flow.MaxConcurrentRuns,
+		ConcurrencyPolicy: flow.ConcurrencyPolicy,
+		WorkspaceID:       custom_middleware.RequestWorkspaceID(ctx),
+	}
+	if msg := applyFlowConcurrency(req.Body.MaxConcurrentRuns, (*string)(req.Body.ConcurrencyPolicy), &params.MaxConcurrentRuns, &params.ConcurrencyPolicy); msg != "" {
+		return UpdateFlow400JSONResponse{Message: msg}, nil
 	}
 	if req.Body.Name != nil {
 		params.Name = *req.Body.Name
@@ -123,17 +172,35 @@ func (s *Server) UpdateFlow(ctx context.Context, req UpdateFlowRequestObject) (U
 	if req.Body.Entrypoint != nil {
 		params.Entrypoint = pgtype.Text{String: *req.Body.Entrypoint, Valid: true}
 	}
+	if req.Body.Notifications != nil {
+		if err := db.ValidateFlowNotifications(*req.Body.Notifications); err != nil {
+			return UpdateFlow400JSONResponse{Message: err.Error()}, nil
+		}
+		notifications, err := db.NewJsonRaw(*req.Body.Notifications)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal notifications: %w", err)
+		}
+		params.Notifications = notifications
+	}
+	if req.Body.RequiredLabels != nil {
+		requiredLabels, err := db.NormalizeWorkerLabels(*req.Body.RequiredLabels)
+		if err != nil {
+			return UpdateFlow400JSONResponse{Message: err.Error()}, nil
+		}
+		params.RequiredLabels = requiredLabels
+	}
 	updatedFlow, err := s.queries.UpdateFlow(ctx, *params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update flow: %w", err)
 	}
+	s.recordResourceChange(ctx, db.ResourceTypeFlow, updatedFlow.ID, db.ResourceChangeActionUpdate, flow, updatedFlow)
 	return UpdateFlow200JSONResponse(updatedFlow), nil
 }
 
 func (s *Server) DeleteFlow(ctx context.Context, req DeleteFlowRequestObject) (DeleteFlowResponseObject, error) {
 	flow_id := req.FlowId
 	// Validate request
-	flow, err := s.queries.GetFlowById(ctx, flow_id)
+	flow, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: flow_id})
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return DeleteFlow404JSONResponse(NotFound{
@@ -144,17 +211,58 @@ func (s *Server) DeleteFlow(ctx context.Context, req DeleteFlowRequestObject) (D
 		}
 		return nil, fmt.Errorf("failed to get flow: %w", err)
 	}
-	err = s.queries.DeleteFlow(ctx, flow.ID)
+	deleted, err := s.queries.SoftDeleteFlow(ctx, db.SoftDeleteFlowParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: flow.ID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete flow: %w", err)
 	}
+	if deleted == 0 {
+		return DeleteFlow404JSONResponse(NotFound{
+			Resource: FLOW_RESOURCE,
+			Id:       flow_id,
+			Message:  fmt.Sprintf("Flow with ID %s not found", flow_id),
+		}), nil
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeFlow, flow.ID, db.ResourceChangeActionDelete, flow, nil)
 	return DeleteFlow204Response{}, nil
 }
 
+func (s *Server) RestoreFlow(ctx context.Context, req RestoreFlowRequestObject) (RestoreFlowResponseObject, error) {
+	flow, err := s.queries.RestoreFlow(ctx, db.RestoreFlowParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: req.FlowId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RestoreFlow404JSONResponse(NotFound{
+				Resource: FLOW_RESOURCE,
+				Id:       req.FlowId,
+				Message:  fmt.Sprintf("Flow with ID %s not found in the trash", req.FlowId),
+			}), nil
+		}
+		return nil, fmt.Errorf("failed to restore flow: %w", err)
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeFlow, flow.ID, db.ResourceChangeActionRestore, nil, flow)
+	return RestoreFlow200JSONResponse(flow), nil
+}
+
+func (s *Server) PurgeFlow(ctx context.Context, req PurgeFlowRequestObject) (PurgeFlowResponseObject, error) {
+	purged, err := s.queries.PurgeFlow(ctx, db.PurgeFlowParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: req.FlowId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge flow: %w", err)
+	}
+	if purged == 0 {
+		return PurgeFlow404JSONResponse(NotFound{
+			Resource: FLOW_RESOURCE,
+			Id:       req.FlowId,
+			Message:  fmt.Sprintf("Flow with ID %s not found in the trash", req.FlowId),
+		}), nil
+	}
+	return PurgeFlow204Response{}, nil
+}
+
 func (s *Server) ListFlows(ctx context.Context, req ListFlowsRequestObject) (ListFlowsResponseObject, error) {
 	params := db.GetFlowsParams{
-		Limit:  10,
-		Offset: 0,
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		Deleted:     listTrash(req.Params.Deleted),
+		Limit:       10,
+		Offset:      0,
 	}
 	var page int32 = 1
 	if req.Params.PerPage != nil {
@@ -168,18 +276,22 @@ func (s *Server) ListFlows(ctx context.Context, req ListFlowsRequestObject) (Lis
 	if err != nil {
 		return nil, fmt.Errorf("failed to list flows: %w", err)
 	}
+	total, err := s.queries.CountFlows(ctx, db.CountFlowsParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), Deleted: params.Deleted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count flows: %w", err)
+	}
 
 	return ListFlows200JSONResponse(FlowList{
 		Flows:      flows,
 		Page:       page,
 		PerPage:    params.Limit,
-		Total:      len(flows),
-		TotalPages: (len(flows) + int(params.Limit) - 1) / int(params.Limit),
+		Total:      int(total),
+		TotalPages: (int(total) + int(params.Limit) - 1) / int(params.Limit),
 	}), nil
 }
 
 func (s *Server) ExecuteFlow(ctx context.Context, req ExecuteFlowRequestObject) (ExecuteFlowResponseObject, error) {
-	flow, err := s.queries.GetFlowById(ctx, req.FlowId)
+	flow, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: req.FlowId})
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return ExecuteFlow404JSONResponse(NotFound{
@@ -190,16 +302,42 @@ func (s *Server) ExecuteFlow(ctx context.Context, req ExecuteFlowRequestObject)
 		}
 		return nil, fmt.Errorf("failed to get flow: %w", err)
 	}
+	if req.Body.MaxRetries != nil && *req.Body.MaxRetries < 0 {
+		return ExecuteFlow400JSONResponse{Message: "max_retries must not be negative"}, nil
+	}
+	if req.Body.ParentRunId != nil {
+		parent, err := s.queries.GetWorkspaceFlowRun(ctx, db.GetWorkspaceFlowRunParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), FlowRunID: *req.Body.ParentRunId})
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ExecuteFlow400JSONResponse{Message: fmt.Sprintf("Parent flow run %s not found", *req.Body.ParentRunId)}, nil
+			}
+			return nil, fmt.Errorf("failed to get parent flow run: %w", err)
+		}
+		if parent.IsCompleted() {
+			return ExecuteFlow400JSONResponse{Message: fmt.Sprintf("Parent flow run %s already ended with status %s", parent.FlowRunID, parent.Status)}, nil
+		}
+	}
+	exceeded, err := s.checkQuotas(ctx, db.QuotaFlowMinutesPerDay)
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return ExecuteFlow429JSONResponse(*exceeded), nil
+	}
+	// Parameters are validated against the parameters schema of the flow by the flows service
 	event := service.Event[*service.FlowRunExecuteRequestEventMessage]{
 		H: &service.EventHeaders{
-			UserID:       uuid.New(), // TODO: Get from authentication context
-			ThreadID:     nil,        // Set to nil - will be omitted from JSON
-			ConnectionID: nil,        // Set to nil - will be omitted from JSON
+			UserID:       custom_middleware.RequestUserID(ctx),
+			WorkspaceID:  custom_middleware.RequestWorkspaceID(ctx),
+			ThreadID:     nil, // Set to nil - will be omitted from JSON
+			ConnectionID: nil, // Set to nil - will be omitted from JSON
 		},
 		Msg: &service.FlowRunExecuteRequestEventMessage{
-			FlowId:     req.FlowId,
-			Parameters: req.Body.Parameters,
-			Engine:     flow.Engine,
+			FlowId:      req.FlowId,
+			Parameters:  req.Body.Parameters,
+			Engine:      flow.Engine,
+			MaxRetries:  req.Body.MaxRetries,
+			ParentRunId: req.Body.ParentRunId,
 		},
 		M: &service.EventMetadata{
 			TraceID:   utils.GenerateTraceID(),
@@ -213,6 +351,15 @@ func (s *Server) ExecuteFlow(ctx context.Context, req ExecuteFlowRequestObject)
 		time.Second*5,
 	)
 	if err != nil {
+		if resp != nil && resp.Msg != nil && resp.Msg.ConcurrencyLimited {
+			return ExecuteFlow429JSONResponse{Message: fmt.Sprintf("Flow %s reached its limit of %d concurrent runs", flow.Name, flow.MaxConcurrentRuns.Int32)}, nil
+		}
+		if resp != nil && resp.Msg != nil && len(resp.Msg.Violations) > 0 {
+			return ExecuteFlow400JSONResponse{
+				Message:    fmt.Sprintf("Parameters do not match the parameters schema of flow %s", flow.Name),
+				Violations: &resp.Msg.Violations,
+			}, nil
+		}
 		s.log.Error("Failed to request flow execution", "error", err)
 		return nil, fmt.Errorf("failed to request flow execution: %w", err)
 	}
@@ -222,8 +369,334 @@ func (s *Server) ExecuteFlow(ctx context.Context, req ExecuteFlowRequestObject)
 	return ExecuteFlow200JSONResponse(resp.Msg.FlowRun), nil
 }
 
+// List the runs of a flow
+// (GET /v1/flows/{flow_id}/runs)
+func (s *Server) ListFlowRuns(ctx context.Context, req ListFlowRunsRequestObject) (ListFlowRunsResponseObject, error) {
+	sortField := string(ListFlowRunsParamsSortCreatedAt)
+	if req.Params.Sort != nil {
+		sortField = string(*req.Params.Sort)
+	}
+	sort := newListSort(sortField, req.Params.Order)
+
+	params := db.ListFlowRunsByFlowIDParams{
+		FlowID:     req.FlowId,
+		Sort:       sort.Field,
+		Descending: sort.Descending,
+	}
+	if req.Params.Status != nil {
+		params.Status = pgtype.Text{String: string(*req.Params.Status), Valid: true}
+	}
+	var err error
+	if params.CreatedAfter, params.CreatedBefore, err = createdRange(req.Params.CreatedAfter, req.Params.CreatedBefore); err != nil {
+		return ListFlowRuns400JSONResponse{Message: err.Error()}, nil
+	}
+
+	// A cursor is a position in the list of a sort and order
+	limit, cursor, err := parsePage(req.Params.Limit, req.Params.Cursor)
+	if err == nil {
+		err = sort.check(cursor)
+	}
+	if err != nil {
+		return ListFlowRuns400JSONResponse{Message: err.Error()}, nil
+	}
+	if params.CursorTime, err = cursor.timeKey(); err != nil {
+		return ListFlowRuns400JSONResponse{Message: err.Error()}, nil
+	}
+	if params.CursorID, err = cursor.uuidID(); err != nil {
+		return ListFlowRuns400JSONResponse{Message: err.Error()}, nil
+	}
+	params.RowLimit = limit + 1
+
+	if _, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: req.FlowId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListFlowRuns404JSONResponse{Resource: FLOW_RESOURCE, Id: req.FlowId, Message: fmt.Sprintf("Flow with ID %s not found", req.FlowId)}, nil
+		}
+		return nil, fmt.Errorf("failed to get flow: %w", err)
+	}
+	runs, err := s.queries.ListFlowRunsByFlowID(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow runs: %w", err)
+	}
+	total, err := s.queries.CountFlowRunsByFlowID(ctx, db.CountFlowRunsByFlowIDParams{
+		FlowID:        req.FlowId,
+		Status:        params.Status,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count flow runs: %w", err)
+	}
+
+	runs, hasMore, next := pageRows(runs, limit, func(run db.FlowRun) pageCursor {
+		c := pageCursor{Sort: sort.String(), Time: &run.CreatedAt.Time, ID: run.FlowRunID.String()}
+		if sort.Field == string(ListFlowRunsParamsSortUpdatedAt) {
+			c.Time = &run.UpdatedAt.Time
+		}
+		return c
+	})
+	return ListFlowRuns200JSONResponse(FlowRunList{
+		FlowRuns:   runs,
+		Limit:      limit,
+		HasMore:    hasMore,
+		NextCursor: next,
+		Total:      int(total),
+	}), nil
+}
+
+// Cancel a flow run
+// (POST /v1/flows/{flow_id}/runs/{run_id}/cancel)
+func (s *Server) CancelFlowRun(ctx context.Context, req CancelFlowRunRequestObject) (CancelFlowRunResponseObject, error) {
+	flowRun, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return CancelFlowRun404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	switch flowRun.Status {
+	case db.FlowStatusScheduled, db.FlowStatusPending, db.FlowStatusRunning, db.FlowStatusPaused:
+	default:
+		return CancelFlowRun400JSONResponse{Message: fmt.Sprintf("Flow run %s already ended with status %s", req.RunId, flowRun.Status)}, nil
+	}
+
+	var reason string
+	if req.Body != nil && req.Body.Reason != nil {
+		reason = *req.Body.Reason
+	}
+	event := service.Event[*service.FlowRunCancelRequestEventMessage]{
+		H: &service.EventHeaders{
+			UserID:      custom_middleware.RequestUserID(ctx),
+			WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		},
+		Msg: &service.FlowRunCancelRequestEventMessage{
+			FlowRunId: req.RunId,
+			Reason:    reason,
+		},
+		M: &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	resp, err := service.Request[*service.FlowRunCancelResponseEventMessage](s.nc, &event, time.Second*5)
+	if err != nil {
+		s.log.Error("Failed to request flow run cancellation", "flow_run_id", req.RunId, "error", err)
+		return nil, fmt.Errorf("failed to request flow run cancellation: %w", err)
+	}
+	return CancelFlowRun200JSONResponse(resp.Msg.FlowRun), nil
+}
+
+// Pause a flow run
+// (POST /v1/flows/{flow_id}/runs/{run_id}/pause)
+func (s *Server) PauseFlowRun(ctx context.Context, req PauseFlowRunRequestObject) (PauseFlowRunResponseObject, error) {
+	flowRun, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return PauseFlowRun404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	if flowRun.Status != db.FlowStatusRunning {
+		return PauseFlowRun400JSONResponse{Message: fmt.Sprintf("Flow run %s is not running, its status is %s", req.RunId, flowRun.Status)}, nil
+	}
+
+	event := service.Event[*service.FlowRunPauseRequestEventMessage]{
+		H: &service.EventHeaders{
+			UserID:      custom_middleware.RequestUserID(ctx),
+			WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		},
+		Msg: &service.FlowRunPauseRequestEventMessage{
+			FlowRunId: req.RunId,
+		},
+		M: &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	resp, err := service.Request[*service.FlowRunPauseResponseEventMessage](s.nc, &event, time.Second*5)
+	if err != nil {
+		s.log.Error("Failed to request flow run pause", "flow_run_id", req.RunId, "error", err)
+		return nil, fmt.Errorf("failed to request flow run pause: %w", err)
+	}
+	return PauseFlowRun202JSONResponse(resp.Msg.FlowRun), nil
+}
+
+// Resume a flow run
+// (POST /v1/flows/{flow_id}/runs/{run_id}/resume)
+func (s *Server) ResumeFlowRun(ctx context.Context, req ResumeFlowRunRequestObject) (ResumeFlowRunResponseObject, error) {
+	flowRun, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return ResumeFlowRun404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	if flowRun.Status != db.FlowStatusPaused {
+		return ResumeFlowRun400JSONResponse{Message: fmt.Sprintf("Flow run %s is not paused, its status is %s", req.RunId, flowRun.Status)}, nil
+	}
+
+	event := service.Event[*service.FlowRunResumeRequestEventMessage]{
+		H: &service.EventHeaders{
+			UserID:      custom_middleware.RequestUserID(ctx),
+			WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		},
+		Msg: &service.FlowRunResumeRequestEventMessage{
+			FlowRunId: req.RunId,
+		},
+		M: &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	resp, err := service.Request[*service.FlowRunResumeResponseEventMessage](s.nc, &event, time.Second*5)
+	if err != nil {
+		s.log.Error("Failed to request flow run resume", "flow_run_id", req.RunId, "error", err)
+		return nil, fmt.Errorf("failed to request flow run resume: %w", err)
+	}
+	return ResumeFlowRun200JSONResponse(resp.Msg.FlowRun), nil
+}
+
+// RetryFlowRun creates a new run of a failed or cancelled flow run, the tasks that succeeded are not run again
+// (POST /v1/flow-runs/{flow_run_id}/retry)
+func (s *Server) RetryFlowRun(ctx context.Context, req RetryFlowRunRequestObject) (RetryFlowRunResponseObject, error) {
+	flowRun, err := s.queries.GetWorkspaceFlowRun(ctx, db.GetWorkspaceFlowRunParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), FlowRunID: req.FlowRunId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RetryFlowRun404JSONResponse(flowRunNotFound(req.FlowRunId)), nil
+		}
+		return nil, fmt.Errorf("failed to get flow run: %w", err)
+	}
+	if !flowRun.CanRetry() {
+		if flowRun.Status == db.FlowStatusFailed {
+			return RetryFlowRun400JSONResponse{Message: fmt.Sprintf("Flow run %s has automatic retries left", req.FlowRunId)}, nil
+		}
+		return RetryFlowRun400JSONResponse{Message: fmt.Sprintf("Flow run %s is not failed or cancelled, its status is %s", req.FlowRunId, flowRun.Status)}, nil
+	}
+
+	event := service.Event[*service.FlowRunRetryRequestEventMessage]{
+		H: &service.EventHeaders{
+			UserID:      custom_middleware.RequestUserID(ctx),
+			WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		},
+		Msg: &service.FlowRunRetryRequestEventMessage{
+			FlowRunId: req.FlowRunId,
+		},
+		M: &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	resp, err := service.Request[*service.FlowRunRetryResponseEventMessage](s.nc, &event, time.Second*5)
+	if err != nil {
+		s.log.Error("Failed to request flow run retry", "flow_run_id", req.FlowRunId, "error", err)
+		return nil, fmt.Errorf("failed to request flow run retry: %w", err)
+	}
+	return RetryFlowRun201JSONResponse(resp.Msg.FlowRun), nil
+}
+
+// Get flow run graph
+// (GET /v1/flows/{flow_id}/runs/{run_id}/graph)
+func (s *Server) GetFlowRunGraph(ctx context.Context, req GetFlowRunGraphRequestObject) (GetFlowRunGraphResponseObject, error) {
+	flowRun, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return GetFlowRunGraph404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	tasks, err := s.queries.ListFlowRunGraphTasks(ctx, req.RunId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow run graph tasks: %w", err)
+	}
+	taskRuns, err := s.queries.GetFlowTaskRunsByFlowRun(ctx, req.RunId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow task runs: %w", err)
+	}
+	return GetFlowRunGraph200JSONResponse(db.NewFlowRunGraph(flowRun, tasks, taskRuns)), nil
+}
+
+// GetFlowRunTimeline returns the state transitions of a flow run and its tasks, with the time spent between them
+func (s *Server) GetFlowRunTimeline(ctx context.Context, req GetFlowRunTimelineRequestObject) (GetFlowRunTimelineResponseObject, error) {
+	flowRun, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return GetFlowRunTimeline404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	events, err := s.queries.GetFlowRunEventsByFlowRun(ctx, pgtype.UUID{Bytes: req.RunId, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flow run events: %w", err)
+	}
+	return GetFlowRunTimeline200JSONResponse(db.NewFlowRunTimeline(flowRun, events)), nil
+}
+
+// GetFlowRunCost returns the wall time, CPU time and LLM tokens consumed by a flow run
+func (s *Server) GetFlowRunCost(ctx context.Context, req GetFlowRunCostRequestObject) (GetFlowRunCostResponseObject, error) {
+	_, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return GetFlowRunCost404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	cost, err := s.queries.GetFlowRunCost(ctx, req.RunId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow run cost: %w", err)
+	}
+	return GetFlowRunCost200JSONResponse(cost), nil
+}
+
+// ListChildFlowRuns returns the sub-flow runs of a flow run with their aggregated statuses
+func (s *Server) ListChildFlowRuns(ctx context.Context, req ListChildFlowRunsRequestObject) (ListChildFlowRunsResponseObject, error) {
+	_, found, err := s.getFlowRunOfFlow(ctx, req.FlowId, req.RunId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return ListChildFlowRuns404JSONResponse(flowRunNotFound(req.RunId)), nil
+	}
+	children, err := s.queries.GetChildFlowRunsByParentID(ctx, pgtype.UUID{Bytes: req.RunId, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child flow runs: %w", err)
+	}
+	summary := db.SummarizeChildFlowRuns(children)
+	statuses := make(map[string]int, len(summary.Statuses))
+	for status, count := range summary.Statuses {
+		statuses[string(status)] = count
+	}
+	return ListChildFlowRuns200JSONResponse{
+		FlowRunId: req.RunId,
+		Children:  children,
+		Total:     summary.Total,
+		Statuses:  statuses,
+		Completed: summary.Completed,
+		Failed:    summary.Failed,
+	}, nil
+}
+
+// getFlowRunOfFlow returns a flow run of the workspace, not found when it does not exist or belongs to another flow
+func (s *Server) getFlowRunOfFlow(ctx context.Context, flowID, flowRunID uuid.UUID) (db.FlowRun, bool, error) {
+	flowRun, err := s.queries.GetWorkspaceFlowRun(ctx, db.GetWorkspaceFlowRunParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), FlowRunID: flowRunID})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return db.FlowRun{}, false, nil
+		}
+		return db.FlowRun{}, false, fmt.Errorf("failed to get flow run: %w", err)
+	}
+	return flowRun, flowRun.FlowID == flowID, nil
+}
+
+// flowRunNotFound returns the not found error of a flow run
+func flowRunNotFound(flowRunID uuid.UUID) NotFound {
+	return NotFound{
+		Resource: "FlowRun",
+		Id:       flowRunID,
+		Message:  fmt.Sprintf("FlowRun with ID %s not found", flowRunID),
+	}
+}
+
 func (s *Server) GetFlowRun(ctx context.Context, req GetFlowRunRequestObject) (GetFlowRunResponseObject, error) {
-	flowRun, err := s.queries.GetFlowRun(ctx, req.FlowRunId)
+	flowRun, err := s.queries.GetWorkspaceFlowRun(ctx, db.GetWorkspaceFlowRunParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), FlowRunID: req.FlowRunId})
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return GetFlowRun404JSONResponse(NotFound{
@@ -236,3 +709,23 @@ func (s *Server) GetFlowRun(ctx context.Context, req GetFlowRunRequestObject) (G
 	}
 	return GetFlowRun200JSONResponse(flowRun), nil
 }
+
+// applyFlowConcurrency applies the concurrency settings of a flow request to the flow, a max_concurrent_runs of 0
+// removes the limit. It returns why the settings are invalid, empty when they are valid.
+func applyFlowConcurrency(maxConcurrentRuns *int32, policy *string, limit *pgtype.Int4, current *db.FlowConcurrencyPolicy) string {
+	if maxConcurrentRuns != nil {
+		if *maxConcurrentRuns < 0 {
+			return "max_concurrent_runs must not be negative"
+		}
+		*limit = pgtype.Int4{Int32: *maxConcurrentRuns, Valid: *maxConcurrentRuns > 0}
+	}
+	if policy != nil {
+		switch p := db.FlowConcurrencyPolicy(*policy); p {
+		case db.FlowConcurrencyPolicyQueue, db.FlowConcurrencyPolicyReject:
+			*current = p
+		default:
+			return fmt.Sprintf("invalid concurrency_policy %q, valid options are QUEUE and REJECT", *policy)
+		}
+	}
+	return ""
+}
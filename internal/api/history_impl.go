@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+const redactedValue = "[REDACTED]"
+
+var (
+	// historySensitiveKeys are redacted from the snapshots so secrets do not outlive a rotation in the history
+	historySensitiveKeys = []string{"api_key"}
+
+	// historyIgnoredFields change on every write and are left out of the change set
+	historyIgnoredFields = []string{"updated_at"}
+)
+
+// fieldChange is the previous and new value of a changed field
+type fieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// resourceSnapshot converts a resource into its JSON object form with the secrets redacted
+func resourceSnapshot(resource any) (map[string]any, error) {
+	if resource == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot map[string]any
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, err
+	}
+	redactSnapshot(snapshot)
+	return snapshot, nil
+}
+
+// redactSnapshot replaces the values of the sensitive keys at any depth
+func redactSnapshot(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if slices.Contains(historySensitiveKeys, k) && child != nil {
+				v[k] = redactedValue
+				continue
+			}
+			redactSnapshot(child)
+		}
+	case []any:
+		for _, child := range v {
+			redactSnapshot(child)
+		}
+	}
+}
+
+// diffSnapshots returns the top-level fields that differ between two snapshots
+func diffSnapshots(before, after map[string]any) map[string]fieldChange {
+	changes := make(map[string]fieldChange)
+	for k, to := range after {
+		if slices.Contains(historyIgnoredFields, k) {
+			continue
+		}
+		if from, ok := before[k]; !ok || !reflect.DeepEqual(from, to) {
+			changes[k] = fieldChange{From: before[k], To: to}
+		}
+	}
+	for k, from := range before {
+		if _, ok := after[k]; !ok && !slices.Contains(historyIgnoredFields, k) {
+			changes[k] = fieldChange{From: from, To: nil}
+		}
+	}
+	return changes
+}
+
+// recordResourceChange appends a version to the history of a resource.
+// before is nil on creation and after is nil on deletion. Updates that change nothing are not recorded.
+// The change is already committed when this runs, so a failure is logged and not returned.
+func (s *Server) recordResourceChange(ctx context.Context, resourceType db.ResourceType, resourceID uuid.UUID, action db.ResourceChangeAction, before, after any) {
+	changedBy := custom_middleware.RequestUserID(ctx)
+
+	err := func() error {
+		beforeSnapshot, err := resourceSnapshot(before)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot previous state: %w", err)
+		}
+		afterSnapshot, err := resourceSnapshot(after)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot new state: %w", err)
+		}
+
+		changes := map[string]fieldChange{}
+		if action == db.ResourceChangeActionUpdate {
+			changes = diffSnapshots(beforeSnapshot, afterSnapshot)
+			if len(changes) == 0 {
+				return nil
+			}
+		}
+
+		params := db.CreateResourceChangeParams{
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			Action:       action,
+			ChangedBy:    changedBy,
+		}
+		if afterSnapshot != nil {
+			if params.Snapshot, err = db.NewJsonRaw(afterSnapshot); err != nil {
+				return fmt.Errorf("failed to marshal snapshot: %w", err)
+			}
+		}
+		if params.Changes, err = db.NewJsonRaw(changes); err != nil {
+			return fmt.Errorf("failed to marshal changes: %w", err)
+		}
+		origin := custom_middleware.GetRequestOrigin(ctx)
+		params.SourceIp = pgtype.Text{String: origin.IP, Valid: origin.IP != ""}
+		params.UserAgent = pgtype.Text{String: origin.UserAgent, Valid: origin.UserAgent != ""}
+
+		if _, err := s.queries.CreateResourceChange(ctx, params); err != nil {
+			return fmt.Errorf("failed to create resource change: %w", err)
+		}
+		return nil
+	}()
+	if err != nil {
+		s.log.Error("Failed to record resource change", "resource_type", resourceType, "resource_id", resourceID, "action", action, "error", err)
+	}
+}
+
+// getResourceHistory returns a page of the history of a resource, newest version first
+func (s *Server) getResourceHistory(ctx context.Context, resourceType db.ResourceType, resourceID uuid.UUID, perPage *PerPageParam, page *PageParam) (ResourceHistory, error) {
+	params := db.ListResourceChangesParams{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Limit:        10,
+		Offset:       0,
+	}
+	var currentPage int32 = 1
+	if perPage != nil {
+		params.Limit = *perPage
+	}
+	if page != nil {
+		currentPage = *page
+	}
+	params.Offset = (currentPage - 1) * params.Limit
+
+	total, err := s.queries.CountResourceChanges(ctx, db.CountResourceChangesParams{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	})
+	if err != nil {
+		return ResourceHistory{}, fmt.Errorf("failed to count resource changes: %w", err)
+	}
+	changes, err := s.queries.ListResourceChanges(ctx, params)
+	if err != nil {
+		return ResourceHistory{}, fmt.Errorf("failed to list resource changes: %w", err)
+	}
+
+	return ResourceHistory{
+		Changes:    changes,
+		Page:       currentPage,
+		PerPage:    params.Limit,
+		Total:      int(total),
+		TotalPages: (int(total) + int(params.Limit) - 1) / int(params.Limit),
+	}, nil
+}
+
+// Get agent change history
+// (GET /v1/agents/{agent_id}/history)
+func (s *Server) GetAgentHistory(ctx context.Context, request GetAgentHistoryRequestObject) (GetAgentHistoryResponseObject, error) {
+	history, err := s.getResourceHistory(ctx, db.ResourceTypeAgent, request.AgentId, request.Params.PerPage, request.Params.Page)
+	if err != nil {
+		return nil, err
+	}
+	// Agents created before the history existed have no entries yet
+	if history.Total == 0 {
+		if _, err := s.queries.GetAgentByID(ctx, db.GetAgentByIDParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.AgentId}); err != nil {
+			if err == pgx.ErrNoRows {
+				return GetAgentHistory404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+			}
+			return nil, err
+		}
+	}
+	return GetAgentHistory200JSONResponse(history), nil
+}
+
+// Get tool change history
+// (GET /v1/tools/{tool_id}/history)
+func (s *Server) GetToolHistory(ctx context.Context, request GetToolHistoryRequestObject) (GetToolHistoryResponseObject, error) {
+	history, err := s.getResourceHistory(ctx, db.ResourceTypeTool, request.ToolId, request.Params.PerPage, request.Params.Page)
+	if err != nil {
+		return nil, err
+	}
+	if history.Total == 0 {
+		if _, err := s.queries.GetToolById(ctx, db.GetToolByIdParams{ID: request.ToolId, WorkspaceID: custom_middleware.RequestWorkspaceID(ctx)}); err != nil {
+			if err == pgx.ErrNoRows {
+				return GetToolHistory404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+			}
+			return nil, err
+		}
+	}
+	return GetToolHistory200JSONResponse(history), nil
+}
+
+// Get flow change history
+// (GET /v1/flows/{flow_id}/history)
+func (s *Server) GetFlowHistory(ctx context.Context, request GetFlowHistoryRequestObject) (GetFlowHistoryResponseObject, error) {
+	history, err := s.getResourceHistory(ctx, db.ResourceTypeFlow, request.FlowId, request.Params.PerPage, request.Params.Page)
+	if err != nil {
+		return nil, err
+	}
+	if history.Total == 0 {
+		if _, err := s.queries.GetFlowById(ctx, db.GetFlowByIdParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.FlowId}); err != nil {
+			if err == pgx.ErrNoRows {
+				return GetFlowHistory404JSONResponse(NotFound{
+					Resource: FLOW_RESOURCE,
+					Id:       request.FlowId,
+					Message:  fmt.Sprintf("Flow with ID %s not found", request.FlowId),
+				}), nil
+			}
+			return nil, fmt.Errorf("failed to get flow: %w", err)
+		}
+	}
+	return GetFlowHistory200JSONResponse(history), nil
+}
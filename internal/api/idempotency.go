@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+// IdempotencyKeyTTL is how long the response of the first request of an idempotency key is replayed to its retries
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// idempotentPaths are the POST endpoints honoring the Idempotency-Key header, the endpoints creating a task or a tool
+// and starting a task or a flow run
+var idempotentPaths = []string{"/v1/tasks", "/v1/tasks/*/execute", "/v1/tools", "/v1/flows/*/execute"}
+
+// idempotencyStore holds the idempotency keys in the database
+type idempotencyStore struct {
+	queries *db.Queries
+	log     hclog.Logger
+}
+
+func (s *idempotencyStore) Claim(ctx context.Context, userID uuid.UUID, key, requestHash string) (*custom_middleware.IdempotentResponse, error) {
+	// A key released between the claim and its lookup is claimed again
+	for range 2 {
+		_, err := s.queries.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   pgtype.Timestamptz{Time: time.Now().Add(IdempotencyKeyTTL), Valid: true},
+		})
+		if err == nil {
+			// The expired keys are removed as new keys are claimed
+			if removed, err := s.queries.DeleteExpiredIdempotencyKeys(ctx); err != nil {
+				s.log.Warn("Failed to remove expired idempotency keys", "error", err)
+			} else if removed > 0 {
+				s.log.Debug("Removed expired idempotency keys", "count", removed)
+			}
+			return nil, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+		}
+
+		held, err := s.queries.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{UserID: userID, Key: key})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+		return &custom_middleware.IdempotentResponse{
+			RequestHash: held.RequestHash,
+			Completed:   held.CompletedAt.Valid,
+			StatusCode:  int(held.StatusCode.Int32),
+			ContentType: held.ContentType,
+			Body:        held.ResponseBody,
+		}, custom_middleware.ErrIdempotencyKeyInUse
+	}
+	return nil, custom_middleware.ErrIdempotencyKeyInUse
+}
+
+func (s *idempotencyStore) Complete(ctx context.Context, userID uuid.UUID, key string, response custom_middleware.IdempotentResponse) error {
+	err := s.queries.CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		UserID:       userID,
+		Key:          key,
+		StatusCode:   pgtype.Int4{Int32: int32(response.StatusCode), Valid: true},
+		ContentType:  response.ContentType,
+		ResponseBody: response.Body,
+	})
+	if err != nil {
+		s.log.Error("Failed to record idempotent response", "user_id", userID, "key", key, "error", err)
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (s *idempotencyStore) Release(ctx context.Context, userID uuid.UUID, key string) error {
+	if err := s.queries.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{UserID: userID, Key: key}); err != nil {
+		s.log.Error("Failed to release idempotency key", "user_id", userID, "key", key, "error", err)
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+// Edit a user message
+// (POST /v1/threads/{thread_id}/messages/{message_id}/edit)
+func (s *Server) EditMessage(ctx context.Context, request EditMessageRequestObject) (EditMessageResponseObject, error) {
+	if request.Body.Message == nil {
+		return EditMessage400JSONResponse{Message: "message is required"}, nil
+	}
+
+	_, access, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return EditMessage404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return EditMessage403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ThreadID != request.ThreadId {
+		return EditMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
+	if message.SenderType != db.SenderMessageTypeUser {
+		return EditMessage400JSONResponse{Message: "only the user messages can be edited"}, nil
+	}
+	if message.SupersededAt.Valid {
+		return EditMessage400JSONResponse{Message: "the message is superseded, only the live messages can be edited"}, nil
+	}
+
+	// The thread is only regenerated by an agent the user can invoke, within the quotas
+	if request.Body.AgentId != nil {
+		_, agentAccess, err := s.agentAccess(ctx, *request.Body.AgentId)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return EditMessage404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: *request.Body.AgentId}, nil
+			}
+			return nil, fmt.Errorf("failed to get agent: %w", err)
+		}
+		if !agentAccess.Allows(db.GrantAccessInvoke) {
+			return EditMessage403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessInvoke)}, nil
+		}
+		exceeded, err := s.checkQuotas(ctx, db.QuotaTokensPerDay, db.QuotaConcurrentTasks)
+		if err != nil {
+			return nil, err
+		}
+		if exceeded != nil {
+			return EditMessage429JSONResponse(*exceeded), nil
+		}
+	}
+	running, err := s.queries.CountActiveThreadTaskRuns(ctx, request.ThreadId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count the running tasks of the thread: %w", err)
+	}
+	if running > 0 {
+		return EditMessage409JSONResponse{Message: "a task of the thread is running, wait for it to finish before editing a message"}, nil
+	}
+
+	content, err := db.NewJsonRaw(request.Body.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message JSON: %w", err)
+	}
+
+	// The message and the messages after it are superseded by the new version in a single transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := s.queries.WithTx(tx)
+
+	superseded, err := queries.SupersedeMessages(ctx, db.SupersedeMessagesParams{
+		ThreadID:  message.ThreadID,
+		CreatedAt: message.CreatedAt,
+		MessageID: message.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to supersede messages: %w", err)
+	}
+	version, err := queries.CreateMessageVersion(ctx, db.CreateMessageVersionParams{
+		Message:   content,
+		VersionOf: message.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create message version: %w", err)
+	}
+	err = queries.CopyMessageAttachments(ctx, db.CopyMessageAttachmentsParams{
+		MessageID:       version.ID,
+		SourceMessageID: message.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy message attachments: %w", err)
+	}
+	rows, err := queries.GetMessageContents(ctx, message.ThreadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages for thread %s: %w", message.ThreadID, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit message version: %w", err)
+	}
+
+	revision := MessageRevision{Message: &version, Superseded: superseded}
+	if request.Body.AgentId != nil {
+		if err := s.regenerateThread(ctx, message.ThreadID, *request.Body.AgentId, rows, &revision); err != nil {
+			return nil, err
+		}
+	}
+	return EditMessage200JSONResponse(revision), nil
+}
+
+// Regenerate a thread from a message
+// (POST /v1/threads/{thread_id}/messages/{message_id}/regenerate)
+func (s *Server) RegenerateMessage(ctx context.Context, request RegenerateMessageRequestObject) (RegenerateMessageResponseObject, error) {
+	agentID := request.Body.AgentId
+	if agentID == uuid.Nil {
+		return RegenerateMessage400JSONResponse{Message: "agent_id is required"}, nil
+	}
+
+	_, access, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RegenerateMessage404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return RegenerateMessage403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ThreadID != request.ThreadId {
+		return RegenerateMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
+	if message.SupersededAt.Valid {
+		return RegenerateMessage400JSONResponse{Message: "the message is superseded, only the live messages can be regenerated"}, nil
+	}
+	_, agentAccess, err := s.agentAccess(ctx, agentID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RegenerateMessage404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: agentID}, nil
+		}
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+	if !agentAccess.Allows(db.GrantAccessInvoke) {
+		return RegenerateMessage403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+	exceeded, err := s.checkQuotas(ctx, db.QuotaTokensPerDay, db.QuotaConcurrentTasks)
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return RegenerateMessage429JSONResponse(*exceeded), nil
+	}
+	running, err := s.queries.CountActiveThreadTaskRuns(ctx, request.ThreadId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count the running tasks of the thread: %w", err)
+	}
+	if running > 0 {
+		return RegenerateMessage409JSONResponse{Message: "a task of the thread is running, wait for it to finish before regenerating a message"}, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := s.queries.WithTx(tx)
+
+	// A user message is answered again, the answers to it are superseded. Any other message is superseded with the
+	// messages after it and the messages before it are answered again.
+	superseded, err := queries.SupersedeMessages(ctx, db.SupersedeMessagesParams{
+		ThreadID:    message.ThreadID,
+		CreatedAt:   message.CreatedAt,
+		MessageID:   message.ID,
+		KeepMessage: message.SenderType == db.SenderMessageTypeUser,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to supersede messages: %w", err)
+	}
+	rows, err := queries.GetMessageContents(ctx, message.ThreadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages for thread %s: %w", message.ThreadID, err)
+	}
+	if len(rows) == 0 {
+		return RegenerateMessage400JSONResponse{Message: "no message is left in the thread to answer"}, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit superseded messages: %w", err)
+	}
+
+	revision := MessageRevision{Superseded: superseded}
+	if err := s.regenerateThread(ctx, message.ThreadID, agentID, rows, &revision); err != nil {
+		return nil, err
+	}
+	return RegenerateMessage201JSONResponse(revision), nil
+}
+
+// regenerateThread runs a new task of the agent with the live messages of the thread. The execution outlives the
+// request like the quickstart one, the task, its run and the URL of its stream are set on the revision.
+func (s *Server) regenerateThread(ctx context.Context, threadID, agentID uuid.UUID, rows []db.GetMessageContentsRow, revision *MessageRevision) error {
+	userID := custom_middleware.RequestUserID(ctx)
+
+	// The files attached to the messages are given to the agent as content blocks
+	ids := make([]uuid.UUID, len(rows))
+	messages := make([]db.JsonRaw, len(rows))
+	for i, row := range rows {
+		ids[i], messages[i] = row.ID, row.Message
+	}
+	messages, err := s.files.ExpandAttachments(ctx, s.queries, ids, messages)
+	if err != nil {
+		return err
+	}
+
+	task, err := s.queries.CreateTask(ctx, db.CreateTaskParams{
+		ThreadID:       threadID,
+		MaxRequestLoop: 20,
+		CreatedBy:      userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+	taskRun, err := s.queries.CreateTasksRun(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create task run: %w", err)
+	}
+
+	streamCtx, stream, err := s.streams.start(taskRun.TaskRunID)
+	if err != nil {
+		return err
+	}
+	if err := s.streamTaskRun(streamCtx, taskRun, userID, nil, stream); err != nil {
+		return err
+	}
+	if err := s.publishAgentInvoke(custom_middleware.RequestWorkspaceID(ctx), agentID, userID, task, messages, false); err != nil {
+		stream.Close()
+		return err
+	}
+
+	streamURL := fmt.Sprintf("/v1/tasks/%s/stream", task.ID)
+	revision.Task, revision.TaskRun, revision.StreamUrl = &task, &taskRun, &streamURL
+	return nil
+}
@@ -3,10 +3,16 @@ package api
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	db "github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
 )
 
 const MESSAGE_RESOURCE = "Message"
@@ -15,18 +21,15 @@ const MESSAGE_RESOURCE = "Message"
 // (GET /v1/threads/{thread_id}/messages)
 func (s *Server) ListMessages(ctx context.Context, request ListMessagesRequestObject) (ListMessagesResponseObject, error) {
 	// Check if the thread exists
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	userId, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
-	}
+	userId := custom_middleware.RequestUserID(ctx)
 
 	checkParams := db.GetThreadByIDParams{
-		UserID: userId,
-		ID:     request.ThreadId,
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		UserID:      userId,
+		ID:          request.ThreadId,
 	}
 
-	_, err = s.queries.GetThreadByID(ctx, checkParams)
+	_, err := s.queries.GetThreadByID(ctx, checkParams)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return ListMessages404JSONResponse{Message: "Thread for messages not found", Resource: MESSAGE_RESOURCE, Id: request.ThreadId}, nil
@@ -34,7 +37,12 @@ func (s *Server) ListMessages(ctx context.Context, request ListMessagesRequestOb
 		return nil, err
 	}
 
-	messages, err := s.queries.GetMessages(ctx, request.ThreadId)
+	// The superseded messages are only listed with the history of the thread
+	getMessages := s.queries.GetMessages
+	if request.Params.IncludeHistory != nil && *request.Params.IncludeHistory {
+		getMessages = s.queries.GetMessageHistory
+	}
+	messages, err := getMessages(ctx, request.ThreadId)
 	if err != nil {
 		return nil, err
 	}
@@ -45,24 +53,18 @@ func (s *Server) ListMessages(ctx context.Context, request ListMessagesRequestOb
 // (POST /v1/threads/{thread_id}/messages)
 func (s *Server) CreateMessage(ctx context.Context, request CreateMessageRequestObject) (CreateMessageResponseObject, error) {
 	// Check if the thread exists
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	userId, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
-	}
+	userId := custom_middleware.RequestUserID(ctx)
 
-	checkParams := db.GetThreadByIDParams{
-		UserID: userId,
-		ID:     request.ThreadId,
-	}
-
-	_, err = s.queries.GetThreadByID(ctx, checkParams)
+	_, access, err := s.threadAccess(ctx, request.ThreadId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return CreateMessage404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
 		}
 		return nil, err
 	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return CreateMessage403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
 
 	// Validate required fields
 	if request.Body.Message == nil {
@@ -88,25 +90,148 @@ func (s *Server) CreateMessage(ctx context.Context, request CreateMessageRequest
 		RecipientID: request.Body.RecipientId,
 	}
 
-	message, err := s.queries.CreateUserMessage(ctx, params)
+	if request.Body.Attachments == nil || len(*request.Body.Attachments) == 0 {
+		message, err := s.queries.CreateUserMessage(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		return CreateMessage201JSONResponse(message), nil
+	}
+
+	// The message is created with its attachments in a single transaction
+	attachments, invalid, err := s.messageAttachments(ctx, userId, *request.Body.Attachments)
 	if err != nil {
 		return nil, err
 	}
+	if invalid != "" {
+		return CreateMessage400JSONResponse{Message: invalid}, nil
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := s.queries.WithTx(tx)
+
+	message, err := queries.CreateUserMessage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	for position, file := range attachments {
+		err := queries.AttachFileToMessage(ctx, db.AttachFileToMessageParams{
+			MessageID: message.ID,
+			FileID:    file.ID,
+			Position:  int32(position),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to attach file %s to message: %w", file.ID, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit message: %w", err)
+	}
 
 	return CreateMessage201JSONResponse(message), nil
 }
 
+// Import messages in a thread
+// (POST /v1/threads/{thread_id}/messages/batch)
+func (s *Server) ImportMessages(ctx context.Context, request ImportMessagesRequestObject) (ImportMessagesResponseObject, error) {
+	_, access, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ImportMessages404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return ImportMessages403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+	if request.Body == nil {
+		return ImportMessages400JSONResponse{Message: "body is required"}, nil
+	}
+	if message := batchSizeError(len(request.Body.Messages), maxImportedMessages); message != "" {
+		return ImportMessages400JSONResponse{Message: message}, nil
+	}
+
+	result, err := s.runBatch(ctx, len(request.Body.Messages), func(queries *db.Queries, index int) (BatchItemResult, error) {
+		imported := request.Body.Messages[index]
+		if imported.Message == nil {
+			return BatchItemResult{}, itemFailed("message is required")
+		}
+		if imported.SenderId == uuid.Nil {
+			return BatchItemResult{}, itemFailed("sender_id is required")
+		}
+		if imported.RecipientId == uuid.Nil {
+			return BatchItemResult{}, itemFailed("recipient_id is required")
+		}
+		switch imported.SenderType {
+		case ImportMessageSenderTypeUser, ImportMessageSenderTypeAssistant, ImportMessageSenderTypeSystem:
+		default:
+			return BatchItemResult{}, itemFailed("invalid sender_type %q, must be user, assistant or system", imported.SenderType)
+		}
+
+		message, err := queries.ImportMessage(ctx, db.ImportMessageParams{
+			ThreadID:    request.ThreadId,
+			Message:     imported.Message,
+			SenderType:  db.SenderMessageType(imported.SenderType),
+			SenderID:    imported.SenderId,
+			RecipientID: imported.RecipientId,
+			CreatedAt:   optionalTime(imported.CreatedAt),
+		})
+		if err != nil {
+			return BatchItemResult{}, fmt.Errorf("failed to insert message: %w", err)
+		}
+		return BatchItemResult{Id: &message.ID, Status: Created}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import messages: %w", err)
+	}
+	return ImportMessages200JSONResponse(result), nil
+}
+
 // Delete message
 // (DELETE /v1/threads/{thread_id}/messages/{message_id})
 func (s *Server) DeleteMessage(ctx context.Context, request DeleteMessageRequestObject) (DeleteMessageResponseObject, error) {
-	// Check if message exists first
-	_, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	_, access, err := s.threadAccess(ctx, request.ThreadId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return DeleteMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+			return DeleteMessage404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
 		}
 		return nil, err
 	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return DeleteMessage403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+
+	// Check if message exists first
+	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ThreadID != request.ThreadId {
+		return DeleteMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
+
+	// The message and the messages after it are kept as the history of the thread
+	if request.Params.KeepHistory != nil && *request.Params.KeepHistory {
+		running, err := s.queries.CountActiveThreadTaskRuns(ctx, request.ThreadId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count the running tasks of the thread: %w", err)
+		}
+		if running > 0 {
+			return DeleteMessage409JSONResponse{Message: "a task of the thread is running, wait for it to finish before deleting a message"}, nil
+		}
+		_, err = s.queries.SupersedeMessages(ctx, db.SupersedeMessagesParams{
+			ThreadID:  message.ThreadID,
+			CreatedAt: message.CreatedAt,
+			MessageID: message.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to supersede messages: %w", err)
+		}
+		return DeleteMessage204Response{}, nil
+	}
 
 	err = s.queries.DeleteMessage(ctx, request.MessageId)
 	if err != nil {
@@ -119,14 +244,21 @@ func (s *Server) DeleteMessage(ctx context.Context, request DeleteMessageRequest
 // Get message by ID
 // (GET /v1/threads/{thread_id}/messages/{message_id})
 func (s *Server) GetMessage(ctx context.Context, request GetMessageRequestObject) (GetMessageResponseObject, error) {
-	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
-	if err != nil {
+	if _, _, err := s.threadAccess(ctx, request.ThreadId); err != nil {
 		if err == pgx.ErrNoRows {
-			return GetMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+			return GetMessage404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
 		}
 		return nil, err
 	}
 
+	message, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || message.ThreadID != request.ThreadId {
+		return GetMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
+
 	return GetMessage200JSONResponse(message), nil
 }
 
@@ -138,14 +270,25 @@ func (s *Server) UpdateMessage(ctx context.Context, request UpdateMessageRequest
 		return UpdateMessage400JSONResponse{Message: "message is required"}, nil
 	}
 
-	// Check if message exists first
-	_, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	_, access, err := s.threadAccess(ctx, request.ThreadId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return UpdateMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+			return UpdateMessage404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
 		}
 		return nil, err
 	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return UpdateMessage403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+
+	// Check if message exists first
+	existing, err := s.queries.GetMessageByID(ctx, request.MessageId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if err == pgx.ErrNoRows || existing.ThreadID != request.ThreadId {
+		return UpdateMessage404JSONResponse{Message: "Message not found", Resource: MESSAGE_RESOURCE, Id: request.MessageId}, nil
+	}
 
 	// Convert the message JSON to db.JsonRaw
 	messageJSON, err := db.NewJsonRaw(request.Body.Message)
@@ -165,3 +308,126 @@ func (s *Server) UpdateMessage(ctx context.Context, request UpdateMessageRequest
 
 	return UpdateMessage200JSONResponse(message), nil
 }
+
+// maxSearchQueryLength bounds the length of the message search queries
+const maxSearchQueryLength = 500
+
+// Search the messages
+// (GET /v1/search/messages)
+func (s *Server) SearchMessages(ctx context.Context, request SearchMessagesRequestObject) (SearchMessagesResponseObject, error) {
+	query := strings.TrimSpace(request.Params.Q)
+	if query == "" {
+		return SearchMessages400JSONResponse{Message: "q is required"}, nil
+	}
+	if len(query) > maxSearchQueryLength {
+		return SearchMessages400JSONResponse{Message: fmt.Sprintf("q must be at most %d characters", maxSearchQueryLength)}, nil
+	}
+	var perPage int32 = 10
+	var page int32 = 1
+	if request.Params.PerPage != nil {
+		perPage = *request.Params.PerPage
+	}
+	if request.Params.Page != nil {
+		page = *request.Params.Page
+	}
+
+	// The search is scoped to a thread visible to the user, the threads hidden from the user are not found
+	var threadID pgtype.UUID
+	if request.Params.ThreadId != nil {
+		if _, _, err := s.threadAccess(ctx, *request.Params.ThreadId); err != nil {
+			if err == pgx.ErrNoRows {
+				return SearchMessages404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: *request.Params.ThreadId}, nil
+			}
+			return nil, fmt.Errorf("failed to get thread: %w", err)
+		}
+		threadID = pgtype.UUID{Bytes: *request.Params.ThreadId, Valid: true}
+	}
+
+	workspaceID := custom_middleware.RequestWorkspaceID(ctx)
+	userID := custom_middleware.RequestUserID(ctx)
+	results, err := s.queries.SearchMessages(ctx, db.SearchMessagesParams{
+		Query:       query,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		ThreadID:    threadID,
+		RowLimit:    perPage,
+		RowOffset:   (page - 1) * perPage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+	total, err := s.queries.CountMessageMatches(ctx, db.CountMessageMatchesParams{
+		Query:       query,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		ThreadID:    threadID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count message matches: %w", err)
+	}
+	if results == nil {
+		results = []db.SearchMessagesRow{}
+	}
+
+	return SearchMessages200JSONResponse(MessageSearchResults{
+		Results:    results,
+		Page:       page,
+		PerPage:    perPage,
+		Total:      int(total),
+		TotalPages: (int(total) + int(perPage) - 1) / int(perPage),
+	}), nil
+}
+
+// maxSemanticSearchQueryLength bounds the length of the semantic search queries
+const maxSemanticSearchQueryLength = 2000
+
+// Search the messages by meaning
+// (GET /v1/search/messages/semantic)
+func (s *Server) SemanticSearchMessages(ctx context.Context, request SemanticSearchMessagesRequestObject) (SemanticSearchMessagesResponseObject, error) {
+	query := strings.TrimSpace(request.Params.Q)
+	if query == "" {
+		return SemanticSearchMessages400JSONResponse{Message: "q is required"}, nil
+	}
+	if len(query) > maxSemanticSearchQueryLength {
+		return SemanticSearchMessages400JSONResponse{Message: fmt.Sprintf("q must be at most %d characters", maxSemanticSearchQueryLength)}, nil
+	}
+	limit := 10
+	if request.Params.Limit != nil {
+		limit = int(*request.Params.Limit)
+	}
+	if request.Params.ThreadId != nil {
+		if _, _, err := s.threadAccess(ctx, *request.Params.ThreadId); err != nil {
+			if err == pgx.ErrNoRows {
+				return SemanticSearchMessages404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: *request.Params.ThreadId}, nil
+			}
+			return nil, fmt.Errorf("failed to get thread: %w", err)
+		}
+	}
+
+	// The embeddings service embeds the query with the model of the messages
+	event := service.Event[*service.MessageRecallRequestEventMessage]{
+		H: &service.EventHeaders{
+			UserID:      custom_middleware.RequestUserID(ctx),
+			WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		},
+		Msg: &service.MessageRecallRequestEventMessage{
+			Query:    query,
+			ThreadId: request.Params.ThreadId,
+			Limit:    limit,
+		},
+		M: &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	resp, err := service.Request[*service.MessageRecallResponseEventMessage](s.nc, &event, time.Second*10)
+	if err != nil {
+		s.log.Error("Failed to request message recall", "error", err)
+		return nil, fmt.Errorf("failed to request message recall: %w", err)
+	}
+	results := resp.Msg.Snippets
+	if results == nil {
+		results = []db.SemanticSearchMessagesRow{}
+	}
+	return SemanticSearchMessages200JSONResponse(SemanticSearchResults{Results: results}), nil
+}
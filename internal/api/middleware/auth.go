@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+// SessionCookie holds the session token of the users logged in with the OIDC provider
+const SessionCookie = "pinazu_session"
+
+// DefaultUserID is the user of the unauthenticated requests, accepted unless the authentication is required
+var DefaultUserID = uuid.MustParse("550e8400-c95b-4444-6666-446655440000")
+
+// WebSocketPath is the path of the WebSocket, whose clients can authenticate with a token query parameter or with
+// their first frame, the browsers cannot send an Authorization header with the upgrade request
+const WebSocketPath = "/v1/ws"
+
+// WebSocketTokenParam is the query parameter of the API key or the session token of a WebSocket upgrade request
+const WebSocketTokenParam = "token"
+
+// ErrInvalidCredentials is returned by an Authenticator for an API key or a session that is unknown, revoked or expired
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ErrMissingScope is returned by AuthenticateToken for an API key whose scopes do not grant the request
+var ErrMissingScope = errors.New("missing scope")
+
+// ErrAdminRequired is returned by AuthenticateToken for a session of a user without the admin role on the admin paths
+var ErrAdminRequired = errors.New("admin role required")
+
+// adminPathPrefixes are the paths managing the users, their API keys, roles and permissions, and the audit of the
+// tool runs of every user, they need the admin scope of an API key or the admin role of the user of a session
+var adminPathPrefixes = []string{"/v1/admin", "/v1/users", "/v1/service-accounts", "/v1/roles", "/v1/permissions", "/v1/audit"}
+
+type (
+	// Principal is the user a request is authenticated as, with the API key or the session authenticating it
+	Principal struct {
+		UserID    uuid.UUID
+		APIKeyID  uuid.UUID // API key of the request, uuid.Nil for a session
+		SessionID uuid.UUID // Session of the request, uuid.Nil for an API key
+		Scopes    []string  // Scopes of the API key, a session is not restricted
+		Admin     bool      // The user of a session has the admin role
+	}
+
+	// Authenticator returns the principal of an API key or a session token, or ErrInvalidCredentials
+	Authenticator func(ctx context.Context, credential string) (*Principal, error)
+
+	// Authenticators authenticate the API keys and the session tokens, a nil authenticator disables its credential
+	Authenticators struct {
+		APIKey  Authenticator
+		Session Authenticator
+	}
+
+	principalKey struct{}
+)
+
+// AuthMiddleware authenticates the requests sending an API key as Authorization: Bearer pk_..., or the session cookie
+// of a logged in user, and stores their principal in the request context. A request is rejected with 401 when its
+// API key is invalid, and with 403 when the scopes of its key do not grant the method and path, or when the user of its
+// session is not an admin on the admin paths, except for the API keys of the user. An invalid session is cleared. The requests without credentials are
+// rejected on the admin paths, and on the other /v1 paths when required is set, they go through unauthenticated
+// otherwise. The WebSocket upgrade requests can send their token as a query parameter instead, and go through without
+// credentials for the WebSocket to authenticate their first frame.
+func AuthMiddleware(auth Authenticators, required bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var principal *Principal
+			if key, ok := bearerAPIKey(r); ok && auth.APIKey != nil {
+				var err error
+				principal, err = auth.APIKey(r.Context(), key)
+				if err != nil {
+					authError(w, err, "invalid API key")
+					return
+				}
+				if scope := requiredAPIKeyScope(r.Method, r.URL.Path); !db.APIKeyScopesAllow(principal.Scopes, scope) {
+					http.Error(w, "API key is missing the "+scope+" scope", http.StatusForbidden)
+					return
+				}
+			} else if cookie, err := r.Cookie(SessionCookie); err == nil && auth.Session != nil {
+				principal, err = auth.Session(r.Context(), cookie.Value)
+				if err != nil && !errors.Is(err, ErrInvalidCredentials) {
+					authError(w, err, "")
+					return
+				}
+				if principal == nil {
+					ClearSessionCookie(w)
+				} else if hasPathPrefix(r.URL.Path, adminPathPrefixes) && !principal.Admin && !ownAPIKeysPath(r.URL.Path, principal.UserID) {
+					http.Error(w, ErrAdminRequired.Error(), http.StatusForbidden)
+					return
+				}
+			} else if token := r.URL.Query().Get(WebSocketTokenParam); token != "" && r.URL.Path == WebSocketPath {
+				var err error
+				principal, err = AuthenticateToken(r.Context(), auth, token, r.Method, r.URL.Path)
+				if errors.Is(err, ErrMissingScope) || errors.Is(err, ErrAdminRequired) {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+				if err != nil {
+					authError(w, err, "invalid token")
+					return
+				}
+			}
+
+			if principal == nil {
+				if hasPathPrefix(r.URL.Path, adminPathPrefixes) {
+					unauthorized(w, "authentication required")
+					return
+				}
+				if required && strings.HasPrefix(r.URL.Path, "/v1/") && !strings.HasPrefix(r.URL.Path, "/v1/auth/") && r.URL.Path != WebSocketPath {
+					unauthorized(w, "authentication required")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+		})
+	}
+}
+
+// AuthenticateToken authenticates an API key or a session token sent outside of the headers, such as by a WebSocket
+// client, for a request of a method and path. It returns ErrInvalidCredentials for an unknown token, ErrMissingScope
+// when the scopes of the API key do not grant the request, and ErrAdminRequired when the user of the session is not an
+// admin on the admin paths other than its API keys.
+func AuthenticateToken(ctx context.Context, auth Authenticators, token, method, path string) (*Principal, error) {
+	var authenticate Authenticator
+	switch {
+	case strings.HasPrefix(token, db.APIKeyPrefix):
+		authenticate = auth.APIKey
+	case strings.HasPrefix(token, db.SessionTokenPrefix):
+		authenticate = auth.Session
+	}
+	if authenticate == nil {
+		return nil, ErrInvalidCredentials
+	}
+	principal, err := authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if principal == nil {
+		return nil, ErrInvalidCredentials
+	}
+	if principal.APIKeyID != uuid.Nil {
+		if scope := requiredAPIKeyScope(method, path); !db.APIKeyScopesAllow(principal.Scopes, scope) {
+			return nil, fmt.Errorf("%w: API key is missing the %s scope", ErrMissingScope, scope)
+		}
+	} else if hasPathPrefix(path, adminPathPrefixes) && !principal.Admin && !ownAPIKeysPath(path, principal.UserID) {
+		return nil, ErrAdminRequired
+	}
+	return principal, nil
+}
+
+// GetPrincipal returns the principal stored by AuthMiddleware, nil for an unauthenticated request
+func GetPrincipal(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// IsAdmin reports whether the principal manages the users, their API keys, roles and permissions: an API key with the
+// admin scope, or a session of a user with the admin role
+func (p *Principal) IsAdmin() bool {
+	if p.APIKeyID != uuid.Nil {
+		return db.APIKeyScopesAllow(p.Scopes, db.APIKeyScopeAdmin)
+	}
+	return p.Admin
+}
+
+// RequestUserID returns the user the request is authenticated as, or DefaultUserID for an unauthenticated request
+func RequestUserID(ctx context.Context) uuid.UUID {
+	if principal := GetPrincipal(ctx); principal != nil {
+		return principal.UserID
+	}
+	return DefaultUserID
+}
+
+// ClearSessionCookie removes the session cookie from the browser
+func ClearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ownAPIKeysPath reports whether a path manages the API keys of a user, the user of a session manages its own keys
+// without the admin role
+func ownAPIKeysPath(path string, userID uuid.UUID) bool {
+	prefix := "/v1/users/" + userID.String() + "/api-keys"
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// bearerAPIKey returns the API key of the Authorization header, the other bearer tokens are not API keys
+func bearerAPIKey(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, strings.HasPrefix(token, db.APIKeyPrefix)
+}
+
+// requiredAPIKeyScope returns the scope granting a request. The WebSocket sends events executing the tasks, it needs
+// the write scope.
+func requiredAPIKeyScope(method, path string) string {
+	switch {
+	case hasPathPrefix(path, adminPathPrefixes):
+		return db.APIKeyScopeAdmin
+	case path == WebSocketPath:
+		return db.APIKeyScopeWrite
+	case method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions:
+		return db.APIKeyScopeRead
+	default:
+		return db.APIKeyScopeWrite
+	}
+}
+
+// authError rejects a request whose credentials failed to authenticate
+func authError(w http.ResponseWriter, err error, invalidMessage string) {
+	switch {
+	case errors.Is(err, ErrInvalidCredentials):
+		unauthorized(w, invalidMessage)
+	case db.IsUnavailable(err):
+		http.Error(w, "database unavailable, retry later", http.StatusServiceUnavailable)
+	default:
+		http.Error(w, "failed to authenticate", http.StatusInternalServerError)
+	}
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="pinazu"`)
+	http.Error(w, message, http.StatusUnauthorized)
+}
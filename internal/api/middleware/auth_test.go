@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	userID := uuid.New()
+	keys := map[string][]string{
+		"pk_reader": {"read"},
+		"pk_writer": {"write"},
+		"pk_admin":  {"admin"},
+	}
+	auth := Authenticators{
+		APIKey: func(ctx context.Context, key string) (*Principal, error) {
+			if key == "pk_down" {
+				return nil, errors.New("connection refused")
+			}
+			scopes, ok := keys[key]
+			if !ok {
+				return nil, ErrInvalidCredentials
+			}
+			return &Principal{UserID: userID, APIKeyID: uuid.New(), Scopes: scopes}, nil
+		},
+		Session: func(ctx context.Context, token string) (*Principal, error) {
+			if token != "ps_valid" && token != "ps_admin" {
+				return nil, ErrInvalidCredentials
+			}
+			return &Principal{UserID: userID, SessionID: uuid.New(), Admin: token == "ps_admin"}, nil
+		},
+	}
+	var principal *Principal
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = GetPrincipal(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	serve := func(required bool, method, target, authorization, session string) *httptest.ResponseRecorder {
+		principal = nil
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		if session != "" {
+			req.AddCookie(&http.Cookie{Name: SessionCookie, Value: session})
+		}
+		AuthMiddleware(auth, required)(next).ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A valid key authenticates the request as its user
+	rec := serve(false, http.MethodGet, "/v1/flows", "Bearer pk_reader", "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	if assert.NotNil(t, principal) {
+		assert.Equal(t, userID, principal.UserID)
+		assert.NotEqual(t, uuid.Nil, principal.APIKeyID)
+	}
+
+	// An unknown key is rejected, the other bearer tokens and the requests without credentials go through
+	rec = serve(false, http.MethodGet, "/v1/flows", "Bearer pk_unknown", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodGet, "/v1/flows", "Bearer eyJhbGciOi", "").Code)
+	assert.Nil(t, principal)
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodGet, "/v1/flows", "", "").Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(false, http.MethodGet, "/v1/flows", "Bearer pk_down", "").Code)
+
+	// The scopes grant the methods and the paths
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodPost, "/v1/flows", "Bearer pk_reader", "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodPost, "/v1/flows", "Bearer pk_writer", "").Code)
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodGet, "/v1/ws", "Bearer pk_reader", "").Code)
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodGet, "/v1/users", "Bearer pk_writer", "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodPost, "/v1/users/"+userID.String()+"/api-keys", "Bearer pk_admin", "").Code)
+
+	// A session authenticates the request without scopes, the admin paths need the admin role except for the API keys of
+	// the user, an invalid session is cleared
+	rec = serve(false, http.MethodPost, "/v1/flows", "", "ps_valid")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	if assert.NotNil(t, principal) {
+		assert.NotEqual(t, uuid.Nil, principal.SessionID)
+	}
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodPost, "/v1/users", "", "ps_valid").Code)
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodPost, "/v1/users/"+uuid.NewString()+"/api-keys", "", "ps_valid").Code)
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodGet, "/v1/users/"+userID.String(), "", "ps_valid").Code)
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodGet, "/v1/users/"+userID.String()+"/api-keys-other", "", "ps_valid").Code)
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodPost, "/v1/users/"+userID.String()+"/api-keys", "", "ps_valid").Code)
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodDelete, "/v1/users/"+userID.String()+"/api-keys/"+uuid.NewString(), "", "ps_valid").Code)
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodPost, "/v1/users/"+userID.String()+"/api-keys", "Bearer pk_writer", "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodPost, "/v1/users", "", "ps_admin").Code)
+	assert.Equal(t, http.StatusForbidden, serve(false, http.MethodGet, "/v1/audit/tool-runs", "", "ps_valid").Code)
+	assert.Equal(t, http.StatusNoContent, serve(false, http.MethodGet, "/v1/audit/tool-runs", "", "ps_admin").Code)
+	rec = serve(false, http.MethodGet, "/v1/flows", "", "ps_expired")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Nil(t, principal)
+	assert.Contains(t, rec.Header().Get("Set-Cookie"), SessionCookie+"=;")
+
+	// The admin paths need credentials even when they are not required
+	assert.Equal(t, http.StatusUnauthorized, serve(false, http.MethodGet, "/v1/users", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(false, http.MethodPost, "/v1/roles", "", "ps_expired").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(false, http.MethodGet, "/v1/admin/dead-letters", "Bearer eyJhbGciOi", "").Code)
+
+	// Required credentials apply to the API only, the login stays reachable
+	assert.Equal(t, http.StatusUnauthorized, serve(true, http.MethodGet, "/v1/flows", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(true, http.MethodGet, "/v1/flows", "", "ps_expired").Code)
+	assert.Equal(t, http.StatusNoContent, serve(true, http.MethodGet, "/v1/flows", "bearer pk_reader", "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(true, http.MethodGet, "/v1/flows", "", "ps_valid").Code)
+	assert.Equal(t, http.StatusNoContent, serve(true, http.MethodGet, "/v1/auth/login", "", "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(true, http.MethodGet, "/docs", "", "").Code)
+
+	// The WebSocket upgrades send their token as a query parameter, or authenticate their first frame
+	rec = serve(true, http.MethodGet, "/v1/ws?token=pk_writer", "", "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	if assert.NotNil(t, principal) {
+		assert.Equal(t, userID, principal.UserID)
+	}
+	assert.Equal(t, http.StatusNoContent, serve(true, http.MethodGet, "/v1/ws?token=ps_valid", "", "").Code)
+	assert.NotNil(t, principal)
+	assert.Equal(t, http.StatusUnauthorized, serve(true, http.MethodGet, "/v1/ws?token=ps_expired", "", "").Code)
+	assert.Equal(t, http.StatusForbidden, serve(true, http.MethodGet, "/v1/ws?token=pk_reader", "", "").Code)
+	assert.Equal(t, http.StatusNoContent, serve(true, http.MethodGet, "/v1/ws", "", "").Code)
+	assert.Nil(t, principal)
+	assert.Equal(t, http.StatusUnauthorized, serve(true, http.MethodGet, "/v1/flows?token=pk_writer", "", "").Code)
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	userID := uuid.New()
+	auth := Authenticators{
+		APIKey: func(ctx context.Context, key string) (*Principal, error) {
+			if key != "pk_reader" {
+				return nil, ErrInvalidCredentials
+			}
+			return &Principal{UserID: userID, APIKeyID: uuid.New(), Scopes: []string{"read"}}, nil
+		},
+		Session: func(ctx context.Context, token string) (*Principal, error) {
+			if token != "ps_valid" {
+				return nil, nil
+			}
+			return &Principal{UserID: userID, SessionID: uuid.New()}, nil
+		},
+	}
+
+	principal, err := AuthenticateToken(context.Background(), auth, "pk_reader", http.MethodGet, "/v1/flows")
+	assert.NoError(t, err)
+	if assert.NotNil(t, principal) {
+		assert.Equal(t, userID, principal.UserID)
+	}
+	_, err = AuthenticateToken(context.Background(), auth, "pk_reader", http.MethodGet, WebSocketPath)
+	assert.ErrorIs(t, err, ErrMissingScope)
+	_, err = AuthenticateToken(context.Background(), auth, "ps_valid", http.MethodGet, "/v1/roles")
+	assert.ErrorIs(t, err, ErrAdminRequired)
+	_, err = AuthenticateToken(context.Background(), auth, "pk_unknown", http.MethodGet, "/v1/flows")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = AuthenticateToken(context.Background(), auth, "ps_expired", http.MethodGet, "/v1/flows")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = AuthenticateToken(context.Background(), auth, "eyJhbGciOi", http.MethodGet, "/v1/flows")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestRequestUserID(t *testing.T) {
+	assert.Equal(t, DefaultUserID, RequestUserID(context.Background()))
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), principalKey{}, &Principal{UserID: userID, SessionID: uuid.New()})
+	assert.Equal(t, userID, RequestUserID(ctx))
+}
+
+func TestPrincipalIsAdmin(t *testing.T) {
+	assert.True(t, (&Principal{APIKeyID: uuid.New(), Scopes: []string{"admin"}}).IsAdmin())
+	assert.False(t, (&Principal{APIKeyID: uuid.New(), Scopes: []string{"write"}, Admin: true}).IsAdmin())
+	assert.True(t, (&Principal{SessionID: uuid.New(), Admin: true}).IsAdmin())
+	assert.False(t, (&Principal{SessionID: uuid.New()}).IsAdmin())
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	reached := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { reached = true })
+	serve := func(policy CORSPolicy, method, origin string, preflight bool) *httptest.ResponseRecorder {
+		reached = false
+		req := httptest.NewRequest(method, "/v1/agents", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+		}
+		rec := httptest.NewRecorder()
+		CORSMiddleware(policy)(next).ServeHTTP(rec, req)
+		return rec
+	}
+	policy := CORSPolicy{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.example.org"},
+		AllowedMethods: []string{"GET", "PUT"},
+		AllowedHeaders: []string{"Authorization", "If-Match"},
+		ExposedHeaders: []string{"ETag"},
+		MaxAgeSeconds:  600,
+	}
+
+	t.Run("Allowed origin", func(t *testing.T) {
+		rec := serve(policy, http.MethodGet, "https://app.example.com", false)
+		assert.True(t, reached)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+		assert.Equal(t, "ETag", rec.Header().Get("Access-Control-Expose-Headers"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Wildcard origin", func(t *testing.T) {
+		rec := serve(policy, http.MethodGet, "https://eu.example.org", false)
+		assert.Equal(t, "https://eu.example.org", rec.Header().Get("Access-Control-Allow-Origin"))
+		rec = serve(policy, http.MethodGet, "https://example.org", false)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Other origin", func(t *testing.T) {
+		rec := serve(policy, http.MethodGet, "https://evil.example.net", false)
+		assert.True(t, reached)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+		rec = serve(policy, http.MethodOptions, "https://evil.example.net", true)
+		assert.False(t, reached)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("Preflight", func(t *testing.T) {
+		rec := serve(policy, http.MethodOptions, "https://app.example.com", true)
+		assert.False(t, reached)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "GET, PUT", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization, If-Match", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("Any origin", func(t *testing.T) {
+		anyPolicy := policy
+		anyPolicy.AllowedOrigins = []string{"*"}
+		rec := serve(anyPolicy, http.MethodGet, "https://app.example.com", false)
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rec.Header().Get("Vary"))
+
+		// Any origin is not echoed with credentials
+		anyPolicy.AllowCredentials = true
+		rec = serve(anyPolicy, http.MethodGet, "https://app.example.com", false)
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Same origin request", func(t *testing.T) {
+		rec := serve(policy, http.MethodGet, "", false)
+		assert.True(t, reached)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SecurityHeadersMiddleware(map[string]string{"X-Frame-Options": "DENY", "X-Content-Type-Options": "nosniff"})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaleHeader marks a response served from the cache because the database is unavailable
+const StaleHeader = "X-Pinazu-Stale"
+
+// maxCachedResponseBytes bounds the size of a cached response, larger responses are not cached
+const maxCachedResponseBytes = 1 << 20
+
+type (
+	// staleResponseCache holds the last successful JSON response of each cached URL
+	staleResponseCache struct {
+		mu         sync.Mutex
+		responses  map[string]*cachedResponse
+		maxEntries int
+	}
+
+	cachedResponse struct {
+		status   int
+		header   http.Header
+		body     []byte
+		storedAt time.Time
+	}
+
+	// staleResponseWriter records the response for the cache and holds back a 503 response when a cached
+	// response can replace it
+	staleResponseWriter struct {
+		http.ResponseWriter
+		canServeStale bool
+		status        int
+		held          bool
+		body          bytes.Buffer
+		overflow      bool
+	}
+)
+
+// DegradedReadCacheMiddleware serves the critical read paths from a cache while the database is unavailable.
+// The last successful JSON response of each GET request under the path prefixes is kept per workspace and user, as the
+// lists differ with the resources visible to each user, and replaces a 503 response to the same request of the user.
+// Stale responses carry the X-Pinazu-Stale and Age headers.
+func DegradedReadCacheMiddleware(maxEntries int, prefixes ...string) func(http.Handler) http.Handler {
+	cache := &staleResponseCache{responses: make(map[string]*cachedResponse), maxEntries: maxEntries}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || !hasPathPrefix(r.URL.Path, prefixes) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := RequestWorkspaceID(r.Context()).String() + " " + RequestUserID(r.Context()).String() + " " + r.URL.RequestURI()
+			cached := cache.get(key)
+			sw := &staleResponseWriter{ResponseWriter: w, canServeStale: cached != nil}
+			next.ServeHTTP(sw, r)
+
+			switch {
+			case sw.held:
+				serveStale(w, cached)
+			case sw.status >= 200 && sw.status < 300 && !sw.overflow && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"):
+				cache.put(key, &cachedResponse{
+					status:   sw.status,
+					header:   w.Header().Clone(),
+					body:     bytes.Clone(sw.body.Bytes()),
+					storedAt: time.Now(),
+				})
+			}
+		})
+	}
+}
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// serveStale writes a cached response with the staleness markers
+func serveStale(w http.ResponseWriter, cached *cachedResponse) {
+	header := w.Header()
+	for k := range header {
+		delete(header, k)
+	}
+	for k, v := range cached.header {
+		header[k] = v
+	}
+	header.Set(StaleHeader, "true")
+	header.Set("Age", strconv.Itoa(int(time.Since(cached.storedAt).Seconds())))
+	header.Set("Warning", `110 - "Response is Stale"`)
+	w.WriteHeader(cached.status)
+	w.Write(cached.body)
+}
+
+func (c *staleResponseCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.responses[key]
+}
+
+func (c *staleResponseCache) put(key string, response *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.responses[key]; !ok && len(c.responses) >= c.maxEntries {
+		var oldest string
+		var oldestAt time.Time
+		for k, cached := range c.responses {
+			if oldestAt.IsZero() || cached.storedAt.Before(oldestAt) {
+				oldest, oldestAt = k, cached.storedAt
+			}
+		}
+		delete(c.responses, oldest)
+	}
+	c.responses[key] = response
+}
+
+// WriteHeader holds back a 503 response that a cached response replaces
+func (s *staleResponseWriter) WriteHeader(statusCode int) {
+	if s.status != 0 {
+		return
+	}
+	s.status = statusCode
+	if statusCode == http.StatusServiceUnavailable && s.canServeStale {
+		s.held = true
+		return
+	}
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write records the body of the response up to maxCachedResponseBytes
+func (s *staleResponseWriter) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.WriteHeader(http.StatusOK)
+	}
+	if s.held {
+		return len(b), nil
+	}
+	if !s.overflow {
+		if s.body.Len()+len(b) > maxCachedResponseBytes {
+			s.overflow = true
+			s.body.Reset()
+		} else {
+			s.body.Write(b)
+		}
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+// Flush sends the buffered data of a streamed response, such as server sent events
+func (s *staleResponseWriter) Flush() {
+	if s.held {
+		return
+	}
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *staleResponseWriter) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
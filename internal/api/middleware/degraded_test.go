@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDegradedReadCacheMiddleware(t *testing.T) {
+	available := true
+	handler := DegradedReadCacheMiddleware(10, "/v1/agents")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.Header().Set("Retry-After", "5")
+			http.Error(w, "database unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"agents":[]}`))
+	}))
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec
+	}
+
+	// Without a cached response the 503 goes through
+	available = false
+	rec := serve(http.MethodGet, "/v1/agents")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get("Retry-After"))
+
+	available = true
+	rec = serve(http.MethodGet, "/v1/agents")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(StaleHeader))
+
+	// The cached response replaces the 503 with the staleness markers
+	available = false
+	rec = serve(http.MethodGet, "/v1/agents")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"agents":[]}`, rec.Body.String())
+	assert.Equal(t, "true", rec.Header().Get(StaleHeader))
+	assert.NotEmpty(t, rec.Header().Get("Age"))
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+
+	// Other queries and paths are not served from the cache
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/v1/agents?page=2").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/v1/users").Code)
+}
+
+func TestDegradedReadCacheMiddleware_PerUser(t *testing.T) {
+	available := true
+	handler := DegradedReadCacheMiddleware(10, "/v1/threads")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			http.Error(w, "database unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user_id":"` + RequestUserID(r.Context()).String() + `"}`))
+	}))
+	serve := func(userID uuid.UUID) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/threads", nil)
+		req = req.WithContext(context.WithValue(req.Context(), principalKey{}, &Principal{UserID: userID}))
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	alice, bob := uuid.New(), uuid.New()
+
+	assert.Equal(t, http.StatusOK, serve(alice).Code)
+
+	// The cached response of a user is not served to another user of the workspace
+	available = false
+	rec := serve(alice)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"user_id":"`+alice.String()+`"}`, rec.Body.String())
+	assert.Equal(t, http.StatusServiceUnavailable, serve(bob).Code)
+}
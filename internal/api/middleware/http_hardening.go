@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// HSTSMiddleware sends the Strict-Transport-Security header on the responses to the HTTPS requests, the browsers then
+// refuse plain HTTP to the host for max age seconds. A max age of zero or below disables the header.
+func HSTSMiddleware(maxAgeSeconds int, includeSubdomains bool) func(http.Handler) http.Handler {
+	value := fmt.Sprintf("max-age=%d", maxAgeSeconds)
+	if includeSubdomains {
+		value += "; includeSubDomains"
+	}
+	return func(next http.Handler) http.Handler {
+		if maxAgeSeconds <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS != nil {
+				w.Header().Set("Strict-Transport-Security", value)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MaxBodyMiddleware rejects the requests whose body is above a size. A request announcing a larger Content-Length is
+// answered with 413 right away, the reads past the limit of a body without length fail with an *http.MaxBytesError.
+func MaxBodyMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				http.Error(w, fmt.Sprintf("request body above %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHSTSMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	serve := func(handler http.Handler, https bool) string {
+		req := httptest.NewRequest(http.MethodGet, "/v1/agents", nil)
+		if https {
+			req.TLS = &tls.ConnectionState{}
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Header().Get("Strict-Transport-Security")
+	}
+
+	assert.Equal(t, "max-age=31536000", serve(HSTSMiddleware(31536000, false)(ok), true))
+	assert.Equal(t, "max-age=600; includeSubDomains", serve(HSTSMiddleware(600, true)(ok), true))
+	// The header is ignored over plain HTTP, it is not sent
+	assert.Empty(t, serve(HSTSMiddleware(600, true)(ok), false))
+	assert.Empty(t, serve(HSTSMiddleware(-1, true)(ok), true))
+}
+
+func TestMaxBodyMiddleware(t *testing.T) {
+	var readErr error
+	handler := MaxBodyMiddleware(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/agents", strings.NewReader("12345678")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoError(t, readErr)
+
+	// A larger announced body is rejected before the handler
+	readErr = nil
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/agents", strings.NewReader("123456789")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.NoError(t, readErr)
+
+	// A body without length fails past the limit
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents", io.NopCloser(strings.NewReader("123456789")))
+	req.ContentLength = -1
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	var maxBytesErr *http.MaxBytesError
+	assert.True(t, errors.As(readErr, &maxBytesErr))
+}
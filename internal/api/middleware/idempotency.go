@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+const (
+	// IdempotencyKeyHeader carries the key a client sends with a POST request to retry it safely
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader marks a response replayed from the first request of its idempotency key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength bounds the length of an idempotency key
+	maxIdempotencyKeyLength = 255
+)
+
+// ErrIdempotencyKeyInUse is returned by an IdempotencyStore claiming a key held by another request
+var ErrIdempotencyKeyInUse = errors.New("idempotency key in use")
+
+type (
+	// IdempotentResponse is the response recorded for the first request of an idempotency key
+	IdempotentResponse struct {
+		RequestHash string
+		Completed   bool   // Unset while the first request is in progress
+		StatusCode  int    // Status of a completed request
+		ContentType string // Content type of a completed request
+		Body        []byte // Body of a completed request, nil when it cannot be replayed
+	}
+
+	// IdempotencyStore holds the idempotency keys of the users. Claim returns ErrIdempotencyKeyInUse with the
+	// response recorded for a key held by another request.
+	IdempotencyStore interface {
+		Claim(ctx context.Context, userID uuid.UUID, key, requestHash string) (*IdempotentResponse, error)
+		Complete(ctx context.Context, userID uuid.UUID, key string, response IdempotentResponse) error
+		Release(ctx context.Context, userID uuid.UUID, key string) error
+	}
+
+	// idempotentResponseWriter records the response of the first request of an idempotency key
+	idempotentResponseWriter struct {
+		http.ResponseWriter
+		status   int
+		body     bytes.Buffer
+		overflow bool
+	}
+)
+
+// IdempotencyMiddleware honors the Idempotency-Key header of the POST requests whose path matches one of the
+// patterns, a * matching a path segment. The response of the first request of a key is recorded and replayed to its
+// retries with the Idempotent-Replayed header, the retries of a request in progress are rejected with 409 and a key
+// reused for another workspace, method, URI or body with 422. A 5xx response releases the key for the retries to run
+// the request again. The keys are scoped to the user of the request, which the middleware must follow the
+// authentication and the workspace selection to know.
+func IdempotencyMiddleware(store IdempotencyStore, patterns ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || r.Method != http.MethodPost || !matchesPathPattern(r.URL.Path, patterns) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				http.Error(w, "Idempotency-Key is longer than 255 characters", http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "failed to read the request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			requestHash := hashRequest(RequestWorkspaceID(r.Context()), r.Method, r.URL.RequestURI(), body)
+
+			userID := RequestUserID(r.Context())
+			recorded, err := store.Claim(r.Context(), userID, key, requestHash)
+			switch {
+			case errors.Is(err, ErrIdempotencyKeyInUse):
+				replayIdempotentResponse(w, recorded, requestHash)
+				return
+			case err != nil:
+				idempotencyError(w, err)
+				return
+			}
+
+			iw := &idempotentResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(iw, r)
+
+			// The response is recorded even when the client left before its end, the retries do not run the request
+			// again but cannot replay a truncated response
+			ctx := context.WithoutCancel(r.Context())
+			if iw.status == 0 || iw.status >= http.StatusInternalServerError {
+				store.Release(ctx, userID, key)
+				return
+			}
+			response := IdempotentResponse{
+				RequestHash: requestHash,
+				Completed:   true,
+				StatusCode:  iw.status,
+				ContentType: w.Header().Get("Content-Type"),
+			}
+			if !iw.overflow && r.Context().Err() == nil {
+				response.Body = append([]byte{}, iw.body.Bytes()...)
+			}
+			store.Complete(ctx, userID, key, response)
+		})
+	}
+}
+
+// replayIdempotentResponse answers a retry with the response recorded for the first request of its key
+func replayIdempotentResponse(w http.ResponseWriter, recorded *IdempotentResponse, requestHash string) {
+	switch {
+	case recorded == nil || recorded.RequestHash != requestHash:
+		http.Error(w, "Idempotency-Key was used for another request", http.StatusUnprocessableEntity)
+	case !recorded.Completed:
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "a request with this Idempotency-Key is in progress", http.StatusConflict)
+	case recorded.Body == nil:
+		http.Error(w, "the response of the request with this Idempotency-Key cannot be replayed", http.StatusConflict)
+	default:
+		if recorded.ContentType != "" {
+			w.Header().Set("Content-Type", recorded.ContentType)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(recorded.StatusCode)
+		w.Write(recorded.Body)
+	}
+}
+
+func idempotencyError(w http.ResponseWriter, err error) {
+	if db.IsUnavailable(err) {
+		http.Error(w, "database unavailable, retry later", http.StatusServiceUnavailable)
+		return
+	}
+	http.Error(w, "failed to claim the idempotency key", http.StatusInternalServerError)
+}
+
+func matchesPathPattern(urlPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, urlPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hashRequest identifies a request by its workspace, method, URI and body
+func hashRequest(workspaceID uuid.UUID, method, uri string, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, workspaceID.String()+" "+method+" "+uri+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (i *idempotentResponseWriter) WriteHeader(statusCode int) {
+	if i.status != 0 {
+		return
+	}
+	i.status = statusCode
+	i.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write records the body of the response up to maxCachedResponseBytes
+func (i *idempotentResponseWriter) Write(b []byte) (int, error) {
+	if i.status == 0 {
+		i.WriteHeader(http.StatusOK)
+	}
+	if !i.overflow {
+		if i.body.Len()+len(b) > maxCachedResponseBytes {
+			i.overflow = true
+			i.body.Reset()
+		} else {
+			i.body.Write(b)
+		}
+	}
+	return i.ResponseWriter.Write(b)
+}
+
+// Flush sends the buffered data of a streamed response, such as the server sent events of a task execution
+func (i *idempotentResponseWriter) Flush() {
+	if flusher, ok := i.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (i *idempotentResponseWriter) Unwrap() http.ResponseWriter {
+	return i.ResponseWriter
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeIdempotencyStore struct {
+	responses map[string]*IdempotentResponse
+}
+
+func (f *fakeIdempotencyStore) Claim(_ context.Context, userID uuid.UUID, key, requestHash string) (*IdempotentResponse, error) {
+	if held, ok := f.responses[userID.String()+key]; ok {
+		return held, ErrIdempotencyKeyInUse
+	}
+	f.responses[userID.String()+key] = &IdempotentResponse{RequestHash: requestHash}
+	return nil, nil
+}
+
+func (f *fakeIdempotencyStore) Complete(_ context.Context, userID uuid.UUID, key string, response IdempotentResponse) error {
+	f.responses[userID.String()+key] = &response
+	return nil
+}
+
+func (f *fakeIdempotencyStore) Release(_ context.Context, userID uuid.UUID, key string) error {
+	delete(f.responses, userID.String()+key)
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	store := &fakeIdempotencyStore{responses: make(map[string]*IdempotentResponse)}
+	runs := 0
+	status := http.StatusCreated
+	handler := IdempotencyMiddleware(store, "/v1/tasks", "/v1/tasks/*/execute")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"run":1}`))
+	}))
+	serve := func(target, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/v1/tasks", "key-1", `{"name":"task"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 1, runs)
+
+	// A retry is answered with the recorded response without running the request again
+	rec = serve("/v1/tasks", "key-1", `{"name":"task"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `{"run":1}`, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "true", rec.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 1, runs)
+
+	// The key cannot be reused for another request
+	assert.Equal(t, http.StatusUnprocessableEntity, serve("/v1/tasks", "key-1", `{"name":"other"}`).Code)
+	assert.Equal(t, 1, runs)
+
+	// A retry of a request in progress is rejected
+	store.responses[DefaultUserID.String()+"key-2"] = &IdempotentResponse{RequestHash: hashRequest(db.DefaultWorkspaceID, http.MethodPost, "/v1/tasks", []byte(`{}`))}
+	rec = serve("/v1/tasks", "key-2", `{}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	// A failed request releases its key
+	status = http.StatusServiceUnavailable
+	assert.Equal(t, http.StatusServiceUnavailable, serve("/v1/tasks/123/execute", "key-3", `{}`).Code)
+	status = http.StatusOK
+	assert.Equal(t, http.StatusOK, serve("/v1/tasks/123/execute", "key-3", `{}`).Code)
+	assert.Equal(t, 3, runs)
+
+	// The requests without a key and the other paths are not recorded
+	serve("/v1/tasks", "", `{}`)
+	serve("/v1/tools", "key-4", `{}`)
+	assert.Equal(t, 5, runs)
+	assert.Len(t, store.responses, 3)
+
+	assert.Equal(t, http.StatusBadRequest, serve("/v1/tasks", strings.Repeat("k", 256), `{}`).Code)
+}
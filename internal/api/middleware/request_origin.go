@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RequestOrigin describes where a request came from
+type RequestOrigin struct {
+	IP        string
+	UserAgent string
+}
+
+type requestOriginKey struct{}
+
+// RequestOriginMiddleware stores the origin of each request in its context.
+// The client IP is taken from the proxy headers when present, so the API must sit behind a trusted proxy
+// for the recorded IP to be reliable.
+func RequestOriginMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := RequestOrigin{IP: clientIP(r), UserAgent: r.UserAgent()}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestOriginKey{}, origin)))
+		})
+	}
+}
+
+// GetRequestOrigin returns the origin stored by RequestOriginMiddleware, or an empty origin
+func GetRequestOrigin(ctx context.Context) RequestOrigin {
+	origin, _ := ctx.Value(requestOriginKey{}).(RequestOrigin)
+	return origin
+}
+
+// clientIP returns the IP of the client, preferring the first address of X-Forwarded-For
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		first, _, _ := strings.Cut(forwarded, ",")
+		if ip := strings.TrimSpace(first); ip != "" {
+			return ip
+		}
+	}
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // SSEFlushWriter wraps http.ResponseWriter to automatically flush SSE data
@@ -38,8 +39,6 @@ func (s *SSEFlushWriter) WriteHeader(statusCode int) {
 		// Ensure SSE headers are properly set for immediate streaming
 		s.Header().Set("Cache-Control", "no-cache")
 		s.Header().Set("Connection", "keep-alive")
-		s.Header().Set("Access-Control-Allow-Origin", "*")
-		s.Header().Set("Access-Control-Allow-Headers", "Cache-Control")
 
 		// Additional headers for better streaming support
 		s.Header().Set("Transfer-Encoding", "chunked")
@@ -48,6 +47,9 @@ func (s *SSEFlushWriter) WriteHeader(statusCode int) {
 
 		// Keep-alive with longer timeout
 		s.Header().Set("Keep-Alive", "timeout=300, max=1000")
+
+		// The streams, such as the events of a thread, outlive the write timeout of the server
+		http.NewResponseController(s.ResponseWriter).SetWriteDeadline(time.Time{})
 	}
 	s.ResponseWriter.WriteHeader(statusCode)
 }
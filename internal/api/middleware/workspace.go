@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+// WorkspaceHeader selects the workspace a request acts in by its ID, the default workspace when missing
+const WorkspaceHeader = "X-Pinazu-Workspace"
+
+// ErrWorkspaceForbidden is returned by a WorkspaceAuthorizer for a user who cannot act in the workspace
+var ErrWorkspaceForbidden = errors.New("not a member of the workspace")
+
+type (
+	// WorkspaceAuthorizer checks the user can act in a workspace, or returns ErrWorkspaceForbidden
+	WorkspaceAuthorizer func(ctx context.Context, userID, workspaceID uuid.UUID) error
+
+	workspaceKey struct{}
+)
+
+// WorkspaceMiddleware stores the workspace selected by the X-Pinazu-Workspace header in the request context. A
+// request is rejected with 400 when the header is not a workspace ID, and with 403 when its user cannot act in the
+// workspace. Every user can act in the default workspace. The middleware must follow the authentication to know the
+// user of the request.
+func WorkspaceMiddleware(authorize WorkspaceAuthorizer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get(WorkspaceHeader)
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			workspaceID, err := uuid.Parse(header)
+			if err != nil {
+				http.Error(w, WorkspaceHeader+" is not a workspace ID", http.StatusBadRequest)
+				return
+			}
+			if workspaceID != db.DefaultWorkspaceID {
+				if err := authorize(r.Context(), RequestUserID(r.Context()), workspaceID); err != nil {
+					workspaceError(w, err)
+					return
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(WithWorkspace(r.Context(), workspaceID)))
+		})
+	}
+}
+
+// WithWorkspace returns a context acting in the workspace
+func WithWorkspace(ctx context.Context, workspaceID uuid.UUID) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, workspaceID)
+}
+
+// RequestWorkspaceID returns the workspace the request acts in, or db.DefaultWorkspaceID when none was selected
+func RequestWorkspaceID(ctx context.Context) uuid.UUID {
+	if workspaceID, ok := ctx.Value(workspaceKey{}).(uuid.UUID); ok {
+		return workspaceID
+	}
+	return db.DefaultWorkspaceID
+}
+
+func workspaceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrWorkspaceForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case db.IsUnavailable(err):
+		http.Error(w, "database unavailable, retry later", http.StatusServiceUnavailable)
+	default:
+		http.Error(w, "failed to check the workspace", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceMiddleware(t *testing.T) {
+	member := uuid.New()
+	workspace := uuid.New()
+	handler := WorkspaceMiddleware(func(ctx context.Context, userID, workspaceID uuid.UUID) error {
+		if userID == member && workspaceID == workspace {
+			return nil
+		}
+		return ErrWorkspaceForbidden
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(RequestWorkspaceID(r.Context()).String()))
+	}))
+	serve := func(userID uuid.UUID, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/agents", nil)
+		if header != "" {
+			req.Header.Set(WorkspaceHeader, header)
+		}
+		req = req.WithContext(context.WithValue(req.Context(), principalKey{}, &Principal{UserID: userID}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Without the header the request acts in the default workspace, open to every user
+	rec := serve(uuid.New(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, db.DefaultWorkspaceID.String(), rec.Body.String())
+	rec = serve(uuid.New(), db.DefaultWorkspaceID.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, db.DefaultWorkspaceID.String(), rec.Body.String())
+
+	rec = serve(member, workspace.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, workspace.String(), rec.Body.String())
+
+	assert.Equal(t, http.StatusForbidden, serve(uuid.New(), workspace.String()).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(member, "not-a-workspace").Code)
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// oidcStateCookie holds the state, nonce and PKCE verifier of a login until its callback
+	oidcStateCookie = "pinazu_oidc"
+
+	// oidcStateTTL bounds the time a user takes to log in with the provider
+	oidcStateTTL = 10 * time.Minute
+)
+
+// errOIDCLogin rejects a login, its message is shown to the user
+var errOIDCLogin = errors.New("login rejected")
+
+type (
+	// oidcLogin logs the users in with the OIDC provider, provisions them on their first login and manages their sessions
+	oidcLogin struct {
+		cfg     *service.OIDCConfig
+		queries *db.Queries
+		log     hclog.Logger
+
+		mu       sync.Mutex
+		provider *oidc.Provider // Discovered on the first login, the provider may be unreachable when the gateway starts
+	}
+
+	// oidcLoginState is the state of a login kept in the oidcStateCookie
+	oidcLoginState struct {
+		State    string `json:"state"`
+		Nonce    string `json:"nonce"`
+		Verifier string `json:"verifier"`
+		Redirect string `json:"redirect"`
+	}
+
+	// oidcClaims are the claims of the ID token provisioning a user
+	oidcClaims struct {
+		Email             string `json:"email"`
+		EmailVerified     *bool  `json:"email_verified"`
+		Name              string `json:"name"`
+		PreferredUsername string `json:"preferred_username"`
+	}
+)
+
+func newOIDCLogin(cfg *service.OIDCConfig, queries *db.Queries, log hclog.Logger) *oidcLogin {
+	return &oidcLogin{cfg: cfg, queries: queries, log: log}
+}
+
+// register adds the login, callback, logout and session endpoints to the router
+func (l *oidcLogin) register(router chi.Router) {
+	router.Get("/v1/auth/login", l.handleLogin)
+	router.Get("/v1/auth/callback", l.handleCallback)
+	router.Post("/v1/auth/logout", l.handleLogout)
+	router.Get("/v1/auth/session", l.handleSession)
+}
+
+// oauth2Config returns the provider, discovered from its issuer on the first call, with the OAuth2 client of the login
+func (l *oidcLogin) oauth2Config(ctx context.Context) (*oidc.Provider, *oauth2.Config, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.provider == nil {
+		provider, err := oidc.NewProvider(ctx, l.cfg.IssuerURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to discover OIDC provider %s: %w", l.cfg.IssuerURL, err)
+		}
+		l.provider = provider
+	}
+	return l.provider, &oauth2.Config{
+		ClientID:     l.cfg.ClientID,
+		ClientSecret: l.cfg.ClientSecret,
+		RedirectURL:  l.cfg.RedirectURL,
+		Endpoint:     l.provider.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, l.cfg.Scopes...),
+	}, nil
+}
+
+// handleLogin redirects the user to the provider, back to the page of the redirect query parameter after the login
+// (GET /v1/auth/login)
+func (l *oidcLogin) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if l.cfg.IssuerURL == "" {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+	_, config, err := l.oauth2Config(r.Context())
+	if err != nil {
+		l.log.Error("Failed to start OIDC login", "error", err)
+		http.Error(w, "OIDC provider unavailable, retry later", http.StatusBadGateway)
+		return
+	}
+
+	state := oidcLoginState{
+		State:    randomHex(16),
+		Nonce:    randomHex(16),
+		Verifier: oauth2.GenerateVerifier(),
+		Redirect: localRedirect(r.URL.Query().Get("redirect"), l.cfg.PostLoginRedirect),
+	}
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+	// The callback is a top-level navigation from the provider, SameSite=Lax sends the cookie with it
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    base64.RawURLEncoding.EncodeToString(encoded),
+		Path:     "/v1/auth/",
+		MaxAge:   int(oidcStateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   !l.cfg.InsecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, config.AuthCodeURL(state.State, oidc.Nonce(state.Nonce), oauth2.S256ChallengeOption(state.Verifier)), http.StatusFound)
+}
+
+// handleCallback exchanges the code of the provider for the ID token of the user, provisions the user on their first
+// login and starts their session
+// (GET /v1/auth/callback)
+func (l *oidcLogin) handleCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if l.cfg.IssuerURL == "" {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+	state, ok := l.loginState(r)
+	http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: "/v1/auth/", MaxAge: -1, HttpOnly: true, Secure: !l.cfg.InsecureCookies, SameSite: http.SameSiteLaxMode})
+	if !ok || subtle.ConstantTimeCompare([]byte(state.State), []byte(r.URL.Query().Get("state"))) != 1 {
+		http.Error(w, "invalid or expired login state, start the login again", http.StatusBadRequest)
+		return
+	}
+	if providerErr := r.URL.Query().Get("error"); providerErr != "" {
+		l.log.Warn("OIDC provider rejected the login", "error", providerErr, "description", r.URL.Query().Get("error_description"))
+		http.Error(w, "login rejected by the provider: "+providerErr, http.StatusUnauthorized)
+		return
+	}
+
+	provider, config, err := l.oauth2Config(ctx)
+	if err != nil {
+		l.log.Error("Failed to complete OIDC login", "error", err)
+		http.Error(w, "OIDC provider unavailable, retry later", http.StatusBadGateway)
+		return
+	}
+	token, err := config.Exchange(ctx, r.URL.Query().Get("code"), oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		l.log.Warn("Failed to exchange OIDC code", "error", err)
+		http.Error(w, "failed to exchange the login code", http.StatusUnauthorized)
+		return
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		http.Error(w, "the provider returned no ID token", http.StatusUnauthorized)
+		return
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: l.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		l.log.Warn("Failed to verify OIDC ID token", "error", err)
+		http.Error(w, "invalid ID token", http.StatusUnauthorized)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(state.Nonce)) != 1 {
+		http.Error(w, "invalid ID token nonce", http.StatusUnauthorized)
+		return
+	}
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		http.Error(w, "invalid ID token claims", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := l.provisionUser(ctx, idToken.Issuer, idToken.Subject, claims)
+	if err != nil {
+		if errors.Is(err, errOIDCLogin) {
+			l.log.Warn("Rejected OIDC login", "subject", idToken.Subject, "error", err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		l.log.Error("Failed to provision OIDC user", "subject", idToken.Subject, "error", err)
+		http.Error(w, "failed to provision user", http.StatusInternalServerError)
+		return
+	}
+	if err := l.startSession(w, r, user); err != nil {
+		l.log.Error("Failed to start session", "user_id", user.ID, "error", err)
+		http.Error(w, "failed to start session", http.StatusInternalServerError)
+		return
+	}
+	l.log.Info("User logged in", "user_id", user.ID, "name", user.Name)
+	http.Redirect(w, r, state.Redirect, http.StatusFound)
+}
+
+// handleLogout revokes the session of the request and clears its cookie
+// (POST /v1/auth/logout)
+func (l *oidcLogin) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(custom_middleware.SessionCookie); err == nil {
+		if err := l.queries.RevokeUserSession(r.Context(), db.HashSessionToken(cookie.Value)); err != nil {
+			l.log.Error("Failed to revoke session", "error", err)
+			http.Error(w, "failed to revoke session", http.StatusInternalServerError)
+			return
+		}
+	}
+	custom_middleware.ClearSessionCookie(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleSession returns the user of the session of the request
+// (GET /v1/auth/session)
+func (l *oidcLogin) handleSession(w http.ResponseWriter, r *http.Request) {
+	principal := custom_middleware.GetPrincipal(r.Context())
+	if principal == nil || principal.SessionID == uuid.Nil {
+		http.Error(w, "not logged in", http.StatusUnauthorized)
+		return
+	}
+	user, err := l.queries.GetUserByID(r.Context(), principal.UserID)
+	if err != nil {
+		http.Error(w, "failed to get user", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// authenticateSession returns the principal of a session token that is not revoked nor expired, an admin when its
+// user has the admin role
+func (l *oidcLogin) authenticateSession(ctx context.Context, token string) (*custom_middleware.Principal, error) {
+	if !strings.HasPrefix(token, db.SessionTokenPrefix) {
+		return nil, custom_middleware.ErrInvalidCredentials
+	}
+	session, err := l.queries.GetActiveUserSessionByHash(ctx, db.HashSessionToken(token))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, custom_middleware.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	admin, err := l.queries.IsUserAdmin(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the roles of the user: %w", err)
+	}
+	return &custom_middleware.Principal{UserID: session.UserID, SessionID: session.ID, Admin: admin}, nil
+}
+
+// provisionUser returns the user of an email, created on their first login. The email of a user of another provider,
+// or an unverified email, is rejected: the provider would otherwise log in as users it does not authenticate.
+func (l *oidcLogin) provisionUser(ctx context.Context, issuer, subject string, claims oidcClaims) (db.GetUserByIDRow, error) {
+	if claims.Email == "" {
+		return db.GetUserByIDRow{}, fmt.Errorf("%w: the ID token has no email, request the email scope", errOIDCLogin)
+	}
+	if claims.EmailVerified != nil && !*claims.EmailVerified {
+		return db.GetUserByIDRow{}, fmt.Errorf("%w: the email %s is not verified by the provider", errOIDCLogin, claims.Email)
+	}
+	provider := db.ProviderName(l.cfg.ProviderName)
+
+	existing, err := l.queries.GetUserByEmail(ctx, claims.Email)
+	if err == nil {
+		if existing.ProviderName != provider {
+			return db.GetUserByIDRow{}, fmt.Errorf("%w: the email %s belongs to a user of the %s provider", errOIDCLogin, claims.Email, existing.ProviderName)
+		}
+		return l.queries.GetUserByID(ctx, existing.ID)
+	}
+	if err != pgx.ErrNoRows {
+		return db.GetUserByIDRow{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	additionalInfo, err := db.NewJsonRaw(map[string]any{"oidc_issuer": issuer, "oidc_subject": subject})
+	if err != nil {
+		return db.GetUserByIDRow{}, err
+	}
+	params := db.CreateUserParams{
+		Name:           claims.PreferredUsername,
+		Email:          claims.Email,
+		AdditionalInfo: additionalInfo,
+		PasswordHash:   "!", // No password matches, the user logs in with the provider
+		ProviderName:   provider,
+	}
+	if params.Name == "" {
+		params.Name = claims.Name
+	}
+	if params.Name == "" {
+		params.Name = claims.Email
+	}
+	created, err := l.queries.CreateUser(ctx, params)
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" && strings.Contains(pgErr.Detail, "name") && params.Name != claims.Email {
+		// The name is taken by another user, the email is unique
+		params.Name = claims.Email
+		created, err = l.queries.CreateUser(ctx, params)
+	}
+	if err != nil {
+		return db.GetUserByIDRow{}, fmt.Errorf("failed to create user: %w", err)
+	}
+	l.log.Info("Provisioned OIDC user", "user_id", created.ID, "name", created.Name, "provider_name", created.ProviderName)
+	return l.queries.GetUserByID(ctx, created.ID)
+}
+
+// startSession creates a session of a user and sets its cookie
+func (l *oidcLogin) startSession(w http.ResponseWriter, r *http.Request, user db.GetUserByIDRow) error {
+	ctx := r.Context()
+	token, hash, err := db.GenerateSessionToken()
+	if err != nil {
+		return err
+	}
+	origin := custom_middleware.GetRequestOrigin(ctx)
+	expiresAt := time.Now().Add(time.Duration(l.cfg.SessionTTLSeconds) * time.Second)
+	if _, err := l.queries.CreateUserSession(ctx, db.CreateUserSessionParams{
+		UserID:    user.ID,
+		TokenHash: hash,
+		UserAgent: pgtype.Text{String: origin.UserAgent, Valid: origin.UserAgent != ""},
+		IpAddress: pgtype.Text{String: origin.IP, Valid: origin.IP != ""},
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	if err := l.queries.UpdateUserLastLogin(ctx, user.ID); err != nil {
+		l.log.Warn("Failed to update last login", "user_id", user.ID, "error", err)
+	}
+	// The sessions ended a day ago are removed as the users log in
+	if removed, err := l.queries.DeleteExpiredUserSessions(ctx, pgtype.Timestamptz{Time: time.Now().Add(-24 * time.Hour), Valid: true}); err != nil {
+		l.log.Warn("Failed to remove expired sessions", "error", err)
+	} else if removed > 0 {
+		l.log.Debug("Removed expired sessions", "count", removed)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     custom_middleware.SessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   !l.cfg.InsecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// loginState returns the state of the login of the callback request
+func (l *oidcLogin) loginState(r *http.Request) (oidcLoginState, bool) {
+	var state oidcLoginState
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		return state, false
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || json.Unmarshal(decoded, &state) != nil || state.State == "" {
+		return state, false
+	}
+	return state, true
+}
+
+// localRedirect returns a redirect path of this site, the fallback for an absolute or protocol relative URL
+func localRedirect(redirect, fallback string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.HasPrefix(redirect, "/\\") {
+		return fallback
+	}
+	return redirect
+}
+
+// randomHex returns n random bytes in hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// defaultPageLimit is the number of items of a page when the request sets no limit
+	defaultPageLimit = 20
+
+	// maxPageLimit bounds the number of items of a page
+	maxPageLimit = 100
+)
+
+var (
+	// errInvalidCursor rejects a cursor that was not returned as the next_cursor of the list
+	errInvalidCursor = errors.New("invalid cursor, pass the next_cursor of the previous page")
+
+	// errCursorSort rejects a cursor of a list requested with another sort or order
+	errCursorSort = errors.New("cursor is not valid with the sort and order of the request")
+)
+
+// pageCursor is the sort key of the last item of a page, the next page starts after it. The clients get it encoded as
+// an opaque string, so the key can change without breaking them.
+type pageCursor struct {
+	Sort string     `json:"sort,omitempty"` // Sort and order of the lists sorted on request, a cursor is only valid with them
+	Time *time.Time `json:"time,omitempty"` // Key of the lists sorted by a date
+	Text *string    `json:"text,omitempty"` // Key of the lists sorted by a name, nil for a null key
+	ID   string     `json:"id"`             // Breaks the ties between the items with the same key
+}
+
+// parsePage returns the limit of a list request and its cursor, nil for the first page
+func parsePage(limit *int32, cursor *string) (int32, *pageCursor, error) {
+	pageLimit := int32(defaultPageLimit)
+	if limit != nil {
+		if *limit < 1 || *limit > maxPageLimit {
+			return 0, nil, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+		}
+		pageLimit = *limit
+	}
+	if cursor == nil || *cursor == "" {
+		return pageLimit, nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(*cursor)
+	if err != nil {
+		return 0, nil, errInvalidCursor
+	}
+	var c pageCursor
+	if err := json.Unmarshal(decoded, &c); err != nil || c.ID == "" {
+		return 0, nil, errInvalidCursor
+	}
+	return pageLimit, &c, nil
+}
+
+// String encodes the cursor for the next_cursor of a page
+func (c pageCursor) String() string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// timeKey returns the date key of a cursor, a null date for the first page
+func (c *pageCursor) timeKey() (pgtype.Timestamptz, error) {
+	if c == nil {
+		return pgtype.Timestamptz{}, nil
+	}
+	if c.Time == nil {
+		return pgtype.Timestamptz{}, errInvalidCursor
+	}
+	return pgtype.Timestamptz{Time: *c.Time, Valid: true}, nil
+}
+
+// textKey returns the name key of a cursor, null for the first page and for a null key
+func (c *pageCursor) textKey() pgtype.Text {
+	if c == nil || c.Text == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *c.Text, Valid: true}
+}
+
+// uuidID returns the id of a cursor of a list of items with UUIDs, uuid.Nil for the first page
+func (c *pageCursor) uuidID() (uuid.UUID, error) {
+	if c == nil {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.Parse(c.ID)
+	if err != nil {
+		return uuid.Nil, errInvalidCursor
+	}
+	return id, nil
+}
+
+// listSort is the sort of a list request
+type listSort struct {
+	Field      string
+	Descending bool
+}
+
+// newListSort returns the sort of a list by field. The dates are sorted from the most recent by default, the other
+// fields alphabetically.
+func newListSort(field string, order *SortOrder) listSort {
+	sort := listSort{Field: field, Descending: strings.HasSuffix(field, "_at")}
+	if order != nil {
+		sort.Descending = *order == Desc
+	}
+	return sort
+}
+
+// String returns the sort of the cursors of the list
+func (s listSort) String() string {
+	return fmt.Sprintf("%s:%t", s.Field, s.Descending)
+}
+
+// check rejects a cursor of a list with another sort or order
+func (s listSort) check(c *pageCursor) error {
+	if c != nil && c.Sort != s.String() {
+		return errCursorSort
+	}
+	return nil
+}
+
+// createdRange returns the bounds of the creation dates of a list filter, null when unset
+func createdRange(after, before *time.Time) (pgtype.Timestamptz, pgtype.Timestamptz, error) {
+	if after != nil && before != nil && !after.Before(*before) {
+		return pgtype.Timestamptz{}, pgtype.Timestamptz{}, errors.New("created_after must be before created_before")
+	}
+	return optionalTime(after), optionalTime(before), nil
+}
+
+// optionalTime converts an optional date of a request to a nullable timestamp
+func optionalTime(v *time.Time) pgtype.Timestamptz {
+	if v == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *v, Valid: true}
+}
+
+// optionalUUID converts an optional id of a request to a nullable UUID
+func optionalUUID(v *uuid.UUID) pgtype.UUID {
+	if v == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *v, Valid: true}
+}
+
+// listTrash tells whether a list request asks for the items in the trash rather than the live ones
+func listTrash(deleted *DeletedParam) bool {
+	return deleted != nil && *deleted
+}
+
+// pageRows trims the row fetched beyond the limit, which tells that a next page follows, and returns the cursor of
+// the next page. The queries fetch limit+1 rows.
+func pageRows[T any](rows []T, limit int32, cursorOf func(T) pageCursor) ([]T, bool, *string) {
+	if len(rows) <= int(limit) {
+		return rows, false, nil
+	}
+	rows = rows[:limit]
+	next := cursorOf(rows[len(rows)-1]).String()
+	return rows, true, &next
+}
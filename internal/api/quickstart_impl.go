@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// quickstartTitleLength bounds the thread titles taken from the beginning of the message
+const quickstartTitleLength = 80
+
+// Send a message to the default agent
+// (POST /v1/quickstart)
+func (s *Server) Quickstart(ctx context.Context, request QuickstartRequestObject) (QuickstartResponseObject, error) {
+	if strings.TrimSpace(request.Body.Message) == "" {
+		return Quickstart400JSONResponse{Message: "message is required"}, nil
+	}
+	title := quickstartTitle(request.Body.Message)
+	if request.Body.Title != nil && *request.Body.Title != "" {
+		title = *request.Body.Title
+	}
+	if len(title) > 255 {
+		return Quickstart400JSONResponse{Message: "thread title must be less than 255 characters"}, nil
+	}
+	filter, err := service.ParseStreamEventFilter(aws.ToString(request.Params.Events))
+	if err != nil {
+		return Quickstart400JSONResponse{Message: err.Error()}, nil
+	}
+
+	userID := custom_middleware.RequestUserID(ctx)
+
+	agentID, err := s.resolveDefaultAgent(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if agentID == uuid.Nil {
+		return Quickstart404JSONResponse{Message: "No default agent is set for the user or the workspace", Resource: AGENT_RESOURCE, Id: agentID}, nil
+	}
+	if _, err := s.queries.GetAgentByID(ctx, db.GetAgentByIDParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: agentID}); err != nil {
+		if err == pgx.ErrNoRows {
+			return Quickstart404JSONResponse{Message: fmt.Sprintf("Default agent with ID %s not found", agentID), Resource: AGENT_RESOURCE, Id: agentID}, nil
+		}
+		return nil, fmt.Errorf("failed to get default agent: %w", err)
+	}
+	exceeded, err := s.checkQuotas(ctx, db.QuotaTokensPerDay, db.QuotaConcurrentTasks)
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return Quickstart429JSONResponse(*exceeded), nil
+	}
+
+	content, err := db.NewJsonRaw(anthropic.NewUserMessage(anthropic.NewTextBlock(request.Body.Message)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message JSON: %w", err)
+	}
+
+	now := time.Now()
+	thread, err := s.queries.CreateThread(ctx, db.CreateThreadParams{
+		Title:       title,
+		UserID:      userID,
+		CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create thread: %w", err)
+	}
+
+	message, err := s.queries.CreateUserMessage(ctx, db.CreateUserMessageParams{
+		ThreadID:    thread.ID,
+		Message:     content,
+		SenderID:    userID,
+		RecipientID: agentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	task, err := s.queries.CreateTask(ctx, db.CreateTaskParams{
+		ThreadID:       thread.ID,
+		MaxRequestLoop: 20,
+		CreatedBy:      userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	taskRun, err := s.queries.CreateTasksRun(ctx, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task run: %w", err)
+	}
+
+	// The execution outlives the request, its events are buffered until the client connects to the stream
+	streamCtx, stream, err := s.streams.start(taskRun.TaskRunID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.streamTaskRun(streamCtx, taskRun, userID, filter, stream); err != nil {
+		return nil, err
+	}
+	if err := s.publishAgentInvoke(custom_middleware.RequestWorkspaceID(ctx), agentID, userID, task, []db.JsonRaw{content}, false); err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	return Quickstart201JSONResponse{
+		AgentId:   agentID,
+		Thread:    thread,
+		Message:   message,
+		Task:      task,
+		TaskRun:   taskRun,
+		StreamUrl: fmt.Sprintf("/v1/tasks/%s/stream", task.ID),
+	}, nil
+}
+
+// Stream a task execution
+// (GET /v1/tasks/{task_id}/stream)
+func (s *Server) StreamTask(ctx context.Context, request StreamTaskRequestObject) (StreamTaskResponseObject, error) {
+	var lastEventID uint64
+	if request.Params.LastEventID != nil && *request.Params.LastEventID != "" {
+		id, err := strconv.ParseUint(*request.Params.LastEventID, 10, 64)
+		if err != nil {
+			return StreamTask400JSONResponse{Message: fmt.Sprintf("Invalid Last-Event-ID %q", *request.Params.LastEventID)}, nil
+		}
+		lastEventID = id
+	}
+
+	notStreamed := StreamTask404JSONResponse{Message: fmt.Sprintf("No execution of task %s is streamed", request.TaskId), Resource: TASK_RESOURCE, Id: request.TaskId}
+	task, err := s.queries.GetTaskById(ctx, request.TaskId.String())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return notStreamed, nil
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	// The task of a thread of another user is not found
+	if _, _, err := s.threadAccess(ctx, task.ThreadID); err != nil {
+		if err == pgx.ErrNoRows {
+			return notStreamed, nil
+		}
+		return nil, fmt.Errorf("failed to get thread of task: %w", err)
+	}
+	// The queued runs are not streamed, the run started last is
+	taskRun, err := s.queries.GetLastStartedTaskRunByTaskID(ctx, task.ID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return notStreamed, nil
+		}
+		return nil, fmt.Errorf("failed to get task runs: %w", err)
+	}
+	streamed, err := s.streams.exists(ctx, taskRun.TaskRunID)
+	if err != nil {
+		return nil, err
+	}
+	if !streamed {
+		return notStreamed, nil
+	}
+
+	body, err := s.streams.reader(ctx, taskRun.TaskRunID, lastEventID)
+	if err != nil {
+		return nil, err
+	}
+	return StreamTask200TexteventStreamResponse{
+		Body: body,
+		Headers: StreamTask200ResponseHeaders{
+			CacheControl: "no-cache",
+			Connection:   "keep-alive",
+		},
+	}, nil
+}
+
+// resolveDefaultAgent returns the default agent of the user, or the workspace default agent when the user has none.
+// It returns uuid.Nil when neither is set.
+func (s *Server) resolveDefaultAgent(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	agentID, err := s.queries.GetUserDefaultAgent(ctx, userID)
+	if err != nil && err != pgx.ErrNoRows {
+		return uuid.Nil, fmt.Errorf("failed to get default agent of user: %w", err)
+	}
+	if agentID.Valid {
+		return agentID.Bytes, nil
+	}
+	return s.defaultAgentID, nil
+}
+
+// quickstartTitle returns the title of a quickstart thread, the first line of the message
+func quickstartTitle(message string) string {
+	title, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	title = strings.TrimSpace(title)
+	if runes := []rune(title); len(runes) > quickstartTitleLength {
+		title = strings.TrimSpace(string(runes[:quickstartTitleLength])) + "..."
+	}
+	return title
+}
@@ -0,0 +1,411 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/db"
+)
+
+// accessMessage returns the message of the 403 response of a request lacking an access on a resource
+func accessMessage(resource string, access db.GrantAccess) string {
+	return fmt.Sprintf("the %s access on the %s is required", access, strings.ToLower(resource))
+}
+
+// resourceAccess returns the access of the user of the request on a resource created by ownerID
+func (s *Server) resourceAccess(ctx context.Context, resourceType db.ResourceType, resourceID, ownerID uuid.UUID) (db.GrantAccess, error) {
+	return s.queries.ResourceAccess(ctx, resourceType, resourceID, ownerID, custom_middleware.RequestUserID(ctx))
+}
+
+// agentAccess gets a live agent of the workspace of the request and the access of the user on it, pgx.ErrNoRows when
+// the agent is not found or hidden from the user
+func (s *Server) agentAccess(ctx context.Context, agentID uuid.UUID) (db.Agent, db.GrantAccess, error) {
+	agent, err := s.queries.GetAgentByID(ctx, db.GetAgentByIDParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: agentID})
+	if err != nil {
+		return db.Agent{}, db.GrantAccessNil, err
+	}
+	access, err := s.visibleAccess(ctx, db.ResourceTypeAgent, agent.ID, agent.CreatedBy)
+	if err != nil {
+		return db.Agent{}, db.GrantAccessNil, err
+	}
+	return agent, access, nil
+}
+
+// toolAccess gets a live tool of the workspace of the request or a shared tool, and the access of the user on it,
+// pgx.ErrNoRows when the tool is not found or hidden from the user
+func (s *Server) toolAccess(ctx context.Context, toolID uuid.UUID) (db.Tool, db.GrantAccess, error) {
+	tool, err := s.queries.GetToolById(ctx, db.GetToolByIdParams{ID: toolID, WorkspaceID: custom_middleware.RequestWorkspaceID(ctx)})
+	if err != nil {
+		return db.Tool{}, db.GrantAccessNil, err
+	}
+	access, err := s.visibleAccess(ctx, db.ResourceTypeTool, tool.ID, tool.CreatedBy)
+	if err != nil {
+		return db.Tool{}, db.GrantAccessNil, err
+	}
+	return tool, access, nil
+}
+
+// threadAccess gets a live thread of the workspace of the request owned by the user or shared with the user, and the
+// access of the user on it, pgx.ErrNoRows when the thread is not found or hidden from the user
+func (s *Server) threadAccess(ctx context.Context, threadID uuid.UUID) (db.Thread, db.GrantAccess, error) {
+	thread, err := s.queries.GetThreadByID(ctx, db.GetThreadByIDParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		UserID:      custom_middleware.RequestUserID(ctx),
+		ID:          threadID,
+	})
+	if err != nil {
+		return db.Thread{}, db.GrantAccessNil, err
+	}
+	access, err := s.visibleAccess(ctx, db.ResourceTypeThread, thread.ID, thread.UserID)
+	if err != nil {
+		return db.Thread{}, db.GrantAccessNil, err
+	}
+	return thread, access, nil
+}
+
+// taskAccess gets a task of a thread of the workspace visible to the user of the request, and the access of the user on
+// the thread, pgx.ErrNoRows when the task is not found or its thread is hidden from the user
+func (s *Server) taskAccess(ctx context.Context, taskID string) (db.Task, db.GrantAccess, error) {
+	task, err := s.queries.GetWorkspaceTask(ctx, db.GetWorkspaceTaskParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		ID:          taskID,
+	})
+	if err != nil {
+		return db.Task{}, db.GrantAccessNil, err
+	}
+	_, access, err := s.threadAccess(ctx, task.ThreadID)
+	if err != nil {
+		return db.Task{}, db.GrantAccessNil, err
+	}
+	return task, access, nil
+}
+
+// trashedAgentAccess returns the access of the user of the request on an agent of the trash, pgx.ErrNoRows when the
+// agent is not found or hidden from the user
+func (s *Server) trashedAgentAccess(ctx context.Context, agentID uuid.UUID) (db.GrantAccess, error) {
+	ownerID, err := s.queries.GetAgentOwner(ctx, db.GetAgentOwnerParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: agentID})
+	if err != nil {
+		return db.GrantAccessNil, err
+	}
+	return s.visibleAccess(ctx, db.ResourceTypeAgent, agentID, ownerID)
+}
+
+// trashedToolAccess returns the access of the user of the request on a tool of the trash, pgx.ErrNoRows when the tool
+// is not found or hidden from the user
+func (s *Server) trashedToolAccess(ctx context.Context, toolID uuid.UUID) (db.GrantAccess, error) {
+	ownerID, err := s.queries.GetToolOwner(ctx, db.GetToolOwnerParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: toolID})
+	if err != nil {
+		return db.GrantAccessNil, err
+	}
+	return s.visibleAccess(ctx, db.ResourceTypeTool, toolID, ownerID)
+}
+
+// visibleAccess returns the access of the user of the request on a resource, pgx.ErrNoRows when the resource is hidden
+// from the user
+func (s *Server) visibleAccess(ctx context.Context, resourceType db.ResourceType, resourceID, ownerID uuid.UUID) (db.GrantAccess, error) {
+	access, err := s.resourceAccess(ctx, resourceType, resourceID, ownerID)
+	if err != nil {
+		return db.GrantAccessNil, err
+	}
+	if !access.Valid() {
+		return db.GrantAccessNil, pgx.ErrNoRows
+	}
+	return access, nil
+}
+
+// checkGrant validates the grant of a request. It returns the message of the 400 response, or the 404 response of a
+// grantee not found.
+func (s *Server) checkGrant(ctx context.Context, body *SetResourceGrantRequest) (string, *NotFound, error) {
+	if body == nil {
+		return "body is required", nil, nil
+	}
+	if !body.Access.Valid() {
+		return fmt.Sprintf("invalid access %q, expected READ, INVOKE or MANAGE", body.Access), nil, nil
+	}
+	switch body.GranteeType {
+	case db.GranteeTypeUser:
+		if _, err := s.queries.GetUserByID(ctx, body.GranteeId); err != nil {
+			if err == pgx.ErrNoRows {
+				return "", &NotFound{Message: "User not found", Resource: USER_RESOURCE, Id: body.GranteeId}, nil
+			}
+			return "", nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	case db.GranteeTypeRole:
+		if _, err := s.queries.GetRoleByID(ctx, body.GranteeId); err != nil {
+			if err == pgx.ErrNoRows {
+				return "", &NotFound{Message: "Role not found", Resource: ROLE_RESOURCE, Id: body.GranteeId}, nil
+			}
+			return "", nil, fmt.Errorf("failed to get role: %w", err)
+		}
+	default:
+		return fmt.Sprintf("invalid grantee_type %q, expected USER or ROLE", body.GranteeType), nil, nil
+	}
+	return "", nil, nil
+}
+
+// saveGrant grants the access of a request on a resource, the user of the request being the granter
+func (s *Server) saveGrant(ctx context.Context, resourceType db.ResourceType, resourceID uuid.UUID, body *SetResourceGrantRequest) (db.ResourceGrant, error) {
+	grant, err := s.queries.UpsertResourceGrant(ctx, db.UpsertResourceGrantParams{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		GranteeType:  body.GranteeType,
+		GranteeID:    body.GranteeId,
+		Access:       body.Access,
+		GrantedBy:    custom_middleware.RequestUserID(ctx),
+	})
+	if err != nil {
+		return db.ResourceGrant{}, fmt.Errorf("failed to save %s grant: %w", resourceType, err)
+	}
+	s.log.Info("Resource shared", "resource_type", resourceType, "resource_id", resourceID, "grantee_type", grant.GranteeType, "grantee_id", grant.GranteeID, "access", grant.Access)
+	return grant, nil
+}
+
+// listGrants returns the grants of a resource
+func (s *Server) listGrants(ctx context.Context, resourceType db.ResourceType, resourceID uuid.UUID) (ResourceGrantList, error) {
+	grants, err := s.queries.ListResourceGrants(ctx, db.ListResourceGrantsParams{ResourceType: resourceType, ResourceID: resourceID})
+	if err != nil {
+		return ResourceGrantList{}, fmt.Errorf("failed to list %s grants: %w", resourceType, err)
+	}
+	return ResourceGrantList{Grants: grants}, nil
+}
+
+// deleteGrant revokes the grant of a grantee on a resource, it tells whether the grant existed
+func (s *Server) deleteGrant(ctx context.Context, resourceType db.ResourceType, resourceID uuid.UUID, granteeType db.GranteeType, granteeID uuid.UUID) (bool, error) {
+	deleted, err := s.queries.DeleteResourceGrant(ctx, db.DeleteResourceGrantParams{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		GranteeType:  granteeType,
+		GranteeID:    granteeID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete %s grant: %w", resourceType, err)
+	}
+	return deleted > 0, nil
+}
+
+// List the grants of an agent
+// (GET /v1/agents/{agent_id}/grants)
+func (s *Server) ListAgentGrants(ctx context.Context, request ListAgentGrantsRequestObject) (ListAgentGrantsResponseObject, error) {
+	agent, access, err := s.agentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ListAgentGrants404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return ListAgentGrants403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	grants, err := s.listGrants(ctx, db.ResourceTypeAgent, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ListAgentGrants200JSONResponse(grants), nil
+}
+
+// Share an agent
+// (PUT /v1/agents/{agent_id}/grants)
+func (s *Server) SetAgentGrant(ctx context.Context, request SetAgentGrantRequestObject) (SetAgentGrantResponseObject, error) {
+	agent, access, err := s.agentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SetAgentGrant404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return SetAgentGrant403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	message, notFound, err := s.checkGrant(ctx, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if message != "" {
+		return SetAgentGrant400JSONResponse{Message: message}, nil
+	}
+	if notFound != nil {
+		return SetAgentGrant404JSONResponse(*notFound), nil
+	}
+	grant, err := s.saveGrant(ctx, db.ResourceTypeAgent, agent.ID, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return SetAgentGrant200JSONResponse(grant), nil
+}
+
+// Revoke a grant of an agent
+// (DELETE /v1/agents/{agent_id}/grants/{grantee_type}/{grantee_id})
+func (s *Server) RemoveAgentGrant(ctx context.Context, request RemoveAgentGrantRequestObject) (RemoveAgentGrantResponseObject, error) {
+	agent, access, err := s.agentAccess(ctx, request.AgentId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RemoveAgentGrant404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: request.AgentId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return RemoveAgentGrant403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	deleted, err := s.deleteGrant(ctx, db.ResourceTypeAgent, agent.ID, request.GranteeType, request.GranteeId)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return RemoveAgentGrant404JSONResponse{Message: "Grant not found", Resource: "ResourceGrant", Id: request.GranteeId}, nil
+	}
+	return RemoveAgentGrant204Response{}, nil
+}
+
+// List the grants of a tool
+// (GET /v1/tools/{tool_id}/grants)
+func (s *Server) ListToolGrants(ctx context.Context, request ListToolGrantsRequestObject) (ListToolGrantsResponseObject, error) {
+	tool, access, err := s.toolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ListToolGrants404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return ListToolGrants403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	grants, err := s.listGrants(ctx, db.ResourceTypeTool, tool.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ListToolGrants200JSONResponse(grants), nil
+}
+
+// Share a tool
+// (PUT /v1/tools/{tool_id}/grants)
+func (s *Server) SetToolGrant(ctx context.Context, request SetToolGrantRequestObject) (SetToolGrantResponseObject, error) {
+	tool, access, err := s.toolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SetToolGrant404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return SetToolGrant403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	// A grant would hide the shared tools from the other workspaces
+	if !tool.WorkspaceID.Valid {
+		return SetToolGrant400JSONResponse{Message: "the tool is shared by every workspace and cannot be granted"}, nil
+	}
+	message, notFound, err := s.checkGrant(ctx, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if message != "" {
+		return SetToolGrant400JSONResponse{Message: message}, nil
+	}
+	if notFound != nil {
+		return SetToolGrant404JSONResponse(*notFound), nil
+	}
+	grant, err := s.saveGrant(ctx, db.ResourceTypeTool, tool.ID, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return SetToolGrant200JSONResponse(grant), nil
+}
+
+// Revoke a grant of a tool
+// (DELETE /v1/tools/{tool_id}/grants/{grantee_type}/{grantee_id})
+func (s *Server) RemoveToolGrant(ctx context.Context, request RemoveToolGrantRequestObject) (RemoveToolGrantResponseObject, error) {
+	tool, access, err := s.toolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RemoveToolGrant404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return RemoveToolGrant403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	deleted, err := s.deleteGrant(ctx, db.ResourceTypeTool, tool.ID, request.GranteeType, request.GranteeId)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return RemoveToolGrant404JSONResponse{Message: "Grant not found", Resource: "ResourceGrant", Id: request.GranteeId}, nil
+	}
+	return RemoveToolGrant204Response{}, nil
+}
+
+// List the grants of a thread
+// (GET /v1/threads/{thread_id}/grants)
+func (s *Server) ListThreadGrants(ctx context.Context, request ListThreadGrantsRequestObject) (ListThreadGrantsResponseObject, error) {
+	thread, access, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ListThreadGrants404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return ListThreadGrants403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	grants, err := s.listGrants(ctx, db.ResourceTypeThread, thread.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ListThreadGrants200JSONResponse(grants), nil
+}
+
+// Share a thread
+// (PUT /v1/threads/{thread_id}/grants)
+func (s *Server) SetThreadGrant(ctx context.Context, request SetThreadGrantRequestObject) (SetThreadGrantResponseObject, error) {
+	thread, access, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SetThreadGrant404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return SetThreadGrant403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	message, notFound, err := s.checkGrant(ctx, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if message != "" {
+		return SetThreadGrant400JSONResponse{Message: message}, nil
+	}
+	if notFound != nil {
+		return SetThreadGrant404JSONResponse(*notFound), nil
+	}
+	grant, err := s.saveGrant(ctx, db.ResourceTypeThread, thread.ID, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return SetThreadGrant200JSONResponse(grant), nil
+}
+
+// Revoke a grant of a thread
+// (DELETE /v1/threads/{thread_id}/grants/{grantee_type}/{grantee_id})
+func (s *Server) RemoveThreadGrant(ctx context.Context, request RemoveThreadGrantRequestObject) (RemoveThreadGrantResponseObject, error) {
+	thread, access, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RemoveThreadGrant404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return RemoveThreadGrant403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	deleted, err := s.deleteGrant(ctx, db.ResourceTypeThread, thread.ID, request.GranteeType, request.GranteeId)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return RemoveThreadGrant404JSONResponse{Message: "Grant not found", Resource: "ResourceGrant", Id: request.GranteeId}, nil
+	}
+	return RemoveThreadGrant204Response{}, nil
+}
@@ -2,11 +2,11 @@ package api
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	db "github.com/pinazu/internal/db"
 )
 
@@ -179,17 +179,12 @@ func (s Server) AddPermissionToRole(ctx context.Context, request AddPermissionTo
 		return AddPermissionToRole409JSONResponse{Message: "Permission already exists in role"}, nil
 	}
 
-	// TODO: should be replaced with the actual user ID from the context or authentication system
 	var assignedBy uuid.UUID
 	if request.Body.AssignedBy != nil {
 		assignedBy = *request.Body.AssignedBy
 	} else {
-		// Default assigned_by if not provided
-		var err error
-		assignedBy, err = uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-		if err != nil {
-			return nil, fmt.Errorf("invalid default UUID format: %v", err)
-		}
+		// Default assigned_by to the user of the request
+		assignedBy = custom_middleware.RequestUserID(ctx)
 	}
 
 	params := db.AddPermissionToRoleParams{
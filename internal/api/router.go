@@ -2,39 +2,72 @@ package api
 
 import (
 	"embed"
+	"errors"
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	custom_middleware "github.com/pinazu/internal/api/middleware"
 	"github.com/pinazu/internal/api/websocket"
 	db "github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/files"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// DatabaseRetryAfterSeconds is the Retry-After of the responses failing because the database is unavailable
+	DatabaseRetryAfterSeconds = 5
+
+	// StaleResponseCacheEntries bounds the responses kept to serve the critical reads while the database is unavailable
+	StaleResponseCacheEntries = 1000
 )
 
 type Server struct {
-	queries *db.Queries
-	nc      *nats.Conn
-	log     hclog.Logger
+	queries        *db.Queries
+	pool           *pgxpool.Pool // Begins the transactions of the batch requests
+	nc             *nats.Conn
+	log            hclog.Logger
+	defaultAgentID uuid.UUID      // Workspace default agent of the quickstart endpoint
+	streams        *taskStreams   // Events of the streamed task runs, buffered in JetStream
+	artifacts      artifactStore  // Contents of the flow run artifacts, nil when the S3 storage is not configured
+	files          *files.Storage // Files uploaded by the users, nil when the S3 storage is not configured
+	quotas         *db.Quotas     // Usage quotas checked before running tasks and flows, unlimited when nil
 }
 
-func NewServer(dbPool *pgxpool.Pool, nc *nats.Conn, log hclog.Logger) *Server {
+func NewServer(dbPool *pgxpool.Pool, nc *nats.Conn, js *service.JetStreamService, defaultAgentID uuid.UUID, artifacts artifactStore, fileStorage *files.Storage, quotas *db.Quotas, log hclog.Logger) *Server {
 	return &Server{
-		queries: db.New(dbPool),
-		nc:      nc,
-		log:     log,
+		queries:        db.New(dbPool),
+		pool:           dbPool,
+		nc:             nc,
+		log:            log,
+		defaultAgentID: defaultAgentID,
+		streams:        newTaskStreams(js),
+		artifacts:      artifacts,
+		files:          fileStorage,
+		quotas:         quotas,
 	}
 }
 
-func LoadRoutes(db *pgxpool.Pool, natsConn *nats.Conn, wsHandler *websocket.Handler, log hclog.Logger) http.Handler {
-	server := NewStrictHandlerWithOptions(NewServer(db, natsConn, log), []StrictMiddlewareFunc{},
+func LoadRoutes(dbPool *pgxpool.Pool, natsConn *nats.Conn, js *service.JetStreamService, wsHandler *websocket.Handler, defaultAgentID uuid.UUID, artifacts artifactStore, fileStorage *files.Storage, quotas *db.Quotas, httpConfig *service.HttpServerConfig, oidcConfig *service.OIDCConfig, log hclog.Logger) http.Handler {
+	apiServer := NewServer(dbPool, natsConn, js, defaultAgentID, artifacts, fileStorage, quotas, log)
+	login := newOIDCLogin(oidcConfig, apiServer.queries, log)
+	server := NewStrictHandlerWithOptions(apiServer, []StrictMiddlewareFunc{},
 		StrictHTTPServerOptions{
 			RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+					return
+				}
 				http.Error(w, err.Error(), http.StatusBadRequest)
 			},
 			ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
@@ -43,6 +76,11 @@ func LoadRoutes(db *pgxpool.Pool, natsConn *nats.Conn, wsHandler *websocket.Hand
 					http.Error(w, err.Error(), http.StatusNotFound)
 					return
 				}
+				if db.IsUnavailable(err) {
+					w.Header().Set("Retry-After", strconv.Itoa(DatabaseRetryAfterSeconds))
+					http.Error(w, "database unavailable, retry later", http.StatusServiceUnavailable)
+					return
+				}
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 			},
 		},
@@ -52,11 +90,57 @@ func LoadRoutes(db *pgxpool.Pool, natsConn *nats.Conn, wsHandler *websocket.Hand
 	router := chi.NewRouter()
 	// Use logging middleware
 	router.Use(middleware.Logger)
+	// Tell the browsers to keep to HTTPS, and bound the size of the request bodies
+	if httpConfig.TLS != nil {
+		router.Use(custom_middleware.HSTSMiddleware(httpConfig.TLS.HSTSMaxAgeSeconds, httpConfig.TLS.HSTSIncludeSubdomains))
+	}
+	router.Use(custom_middleware.MaxBodyMiddleware(httpConfig.MaxBodyBytes))
+	// Send the security headers and answer the cross-origin requests of the allowed origins
+	router.Use(custom_middleware.SecurityHeadersMiddleware(httpConfig.SecurityHeaders.Headers()))
+	router.Use(custom_middleware.CORSMiddleware(custom_middleware.CORSPolicy{
+		AllowedOrigins:   httpConfig.CORS.AllowedOrigins,
+		AllowedMethods:   httpConfig.CORS.AllowedMethods,
+		AllowedHeaders:   httpConfig.CORS.AllowedHeaders,
+		ExposedHeaders:   httpConfig.CORS.ExposedHeaders,
+		AllowCredentials: httpConfig.CORS.AllowCredentials,
+		MaxAgeSeconds:    httpConfig.CORS.MaxAgeSeconds,
+	}))
 	// Use SSE auto-flush middleware for immediate streaming
 	router.Use(custom_middleware.SSEAutoFlushMiddleware())
+	// Record the request origin for the resource change history
+	router.Use(custom_middleware.RequestOriginMiddleware())
+	// Authenticate the programmatic clients sending an API key and the users logged in, before serving anything from the cache
+	router.Use(custom_middleware.AuthMiddleware(custom_middleware.Authenticators{
+		APIKey:  apiServer.authenticateAPIKey,
+		Session: login.authenticateSession,
+	}, httpConfig.RequireAPIKey))
+	// Act in the workspace selected by the request, once its user is known
+	router.Use(custom_middleware.WorkspaceMiddleware(apiServer.authorizeWorkspace))
+	// Serve the critical reads from the last responses while the database is unavailable
+	router.Use(custom_middleware.DegradedReadCacheMiddleware(StaleResponseCacheEntries, "/v1/agents", "/v1/tools", "/v1/threads", "/v1/flows"))
+	// Replay the response of the task, tool and flow run creations to the retries sending the same Idempotency-Key
+	router.Use(custom_middleware.IdempotencyMiddleware(&idempotencyStore{queries: apiServer.queries, log: log}, idempotentPaths...))
+
+	// Define websocket handlers, the connections upgraded without credentials authenticate with their first frame
+	wsHandler.SetAuth(websocket.Auth{
+		Authenticators:     custom_middleware.Authenticators{APIKey: apiServer.authenticateAPIKey, Session: login.authenticateSession},
+		AuthorizeWorkspace: apiServer.authorizeWorkspace,
+		Required:           httpConfig.RequireAPIKey,
+		AllowedOrigins:     httpConfig.CORS.AllowedOrigins,
+	})
+	wsHandler.SetDelivery(websocket.Delivery{
+		QueueSize:           httpConfig.WebSocket.QueueSize,
+		OverflowPolicy:      httpConfig.WebSocket.OverflowPolicy,
+		SlowConsumerTimeout: time.Duration(httpConfig.WebSocket.SlowConsumerTimeoutSeconds) * time.Second,
+	})
+	router.Handle(custom_middleware.WebSocketPath, wsHandler)
+
+	// Define the OIDC login handlers
+	login.register(router)
 
-	// Define websocket handlers
-	router.Handle("/v1/ws", wsHandler)
+	// Serve the liveness and readiness of the services of the process, outside of /v1 for the probes to go through unauthenticated
+	router.Handle(service.HealthPath, service.HealthHandler(false))
+	router.Handle(service.ReadyPath, service.HealthHandler(true))
 
 	// Serve Swagger UI
 	router.Get("/docs", redocHandler(false))
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/pinazu/internal/api/websocket"
 	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/files"
 	"github.com/pinazu/internal/service"
 	"github.com/pinazu/internal/utils"
 )
@@ -29,6 +30,11 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("externalDependenciesConfig is nil")
 	}
 
+	defaultAgentID, err := externalDependenciesConfig.GetDefaultAgentID()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a new service instance
 	config := &service.Config{
 		Name:                 "api-gateway-service",
@@ -42,30 +48,76 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("failed to create API gateway service: %w", err)
 	}
 
+	// The events of the task runs are buffered for the clients reattaching to a run
+	js, err := service.NewJetStreamService(ctx, s.GetNATS(), log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream service: %w", err)
+	}
+
 	// Create WebSocket connections map and handler
 	wsConns := utils.NewSyncMap[uuid.UUID, *ws.Conn]()
 	wsHandler := websocket.NewHandler(ctx, s.GetDB(), s.GetNATS(), wsConns, log)
+	// The connections are shared with the other gateway nodes, a client resumes its connection on any node
+	if registry, err := websocket.NewRegistry(ctx, js, s.GetNATS(), log); err != nil {
+		log.Warn("Failed to open the WebSocket connection registry, the connections can only be resumed on this node", "error", err)
+	} else if err := wsHandler.SetRegistry(registry); err != nil {
+		log.Warn("Failed to share the WebSocket connections with the other nodes", "error", err)
+	} else {
+		log.Info("WebSocket connections shared with the other gateway nodes", "node_id", registry.NodeID())
+	}
 
 	// Create a API Gateway Service
 	ags := &ApiGatewayService{s: s, log: log, wg: wg, ctx: ctx}
 
-	s.RegisterHandler("v1.svc.api._info", nil)
-	s.RegisterHandler("v1.svc.api._stats", nil)
+	s.RegisterHandler("v1.svc.api._info", service.InfoHandler(s))
+	s.RegisterHandler("v1.svc.api._stats", service.StatsHandler(s))
 	// Migrate Database
 	if err := db.MigrateDb(s.GetDB()); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
+	// Verify the migrated schema matches what the compiled queries expect
+	if err := db.VerifySchema(ctx, s.GetDB()); err != nil {
+		return nil, fmt.Errorf("failed to verify database schema: %w", err)
+	}
+	// The flow run artifacts are downloaded from the S3 storage
+	var artifacts artifactStore
+	if externalDependenciesConfig.Storage != nil && externalDependenciesConfig.Storage.S3 != nil {
+		client, err := service.NewS3Client(ctx, externalDependenciesConfig.Storage.S3)
+		if err != nil {
+			log.Warn("Failed to create S3 client, flow run artifacts cannot be downloaded", "error", err)
+		} else {
+			artifacts = client
+		}
+	}
+	// The files uploaded by the users are stored in the S3 storage
+	fileStorage, err := files.NewStorage(ctx, externalDependenciesConfig)
+	if err != nil {
+		log.Warn("Failed to create the file storage, files cannot be uploaded", "error", err)
+	}
 	// Create HTTP server instance fo API Gateway
+	httpConfig, err := externalDependenciesConfig.GetHttpServerConfig()
+	if err != nil {
+		return nil, err
+	}
 	httpServer := &http.Server{
-		Addr:         fmt.Sprintf("0.0.0.0:%s", config.ExternalDependencies.Http.Port),
-		Handler:      LoadRoutes(s.GetDB(), s.GetNATS(), wsHandler, log),
-		ReadTimeout:  120 * time.Second, // Increased for long streaming responses
-		WriteTimeout: 120 * time.Second, // Increased for long streaming responses
+		Addr:              fmt.Sprintf("0.0.0.0:%s", httpConfig.Port),
+		Handler:           LoadRoutes(s.GetDB(), s.GetNATS(), js, wsHandler, defaultAgentID, artifacts, fileStorage, externalDependenciesConfig.GetQuotas(), httpConfig, externalDependenciesConfig.GetOIDCConfig(), log),
+		ReadTimeout:       time.Duration(httpConfig.ReadTimeoutSeconds) * time.Second,
+		ReadHeaderTimeout: time.Duration(httpConfig.ReadHeaderTimeoutSeconds) * time.Second,
+		WriteTimeout:      time.Duration(httpConfig.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:       time.Duration(httpConfig.IdleTimeoutSeconds) * time.Second,
 	}
 
 	// Start a goroutine to wait for context cancellation and then shutdown
 	go func() {
 		<-ctx.Done()
+		// Stop accepting new requests and let the requests in flight finish before closing the connections
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(httpConfig.ShutdownTimeoutSeconds)*time.Second)
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			ags.log.Warn("HTTP server requests still in flight at the end of the shutdown timeout", "error", err)
+			httpServer.Close()
+		}
+		cancel()
 		if err := ags.s.Shutdown(); err != nil {
 			ags.log.Error("Error during API Gateway service shutdown", "error", err)
 		}
@@ -74,8 +126,15 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 	}()
 
 	go func() {
-		ags.log.Info("Frontend available at", "url", fmt.Sprintf("http://0.0.0.0:%s", config.ExternalDependencies.Http.Port))
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if httpConfig.TLS != nil {
+			ags.log.Info("Frontend available at", "url", fmt.Sprintf("https://0.0.0.0:%s", httpConfig.Port))
+			err = httpServer.ListenAndServeTLS(httpConfig.TLS.CertFile, httpConfig.TLS.KeyFile)
+		} else {
+			ags.log.Info("Frontend available at", "url", fmt.Sprintf("http://0.0.0.0:%s", httpConfig.Port))
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			ags.log.Error("HTTP server error", "error", err)
 		}
 	}()
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/db"
+)
+
+const TASK_SCHEDULE_RESOURCE = "TaskSchedule"
+
+// List task schedules
+// (GET /v1/tasks/schedules)
+func (s *Server) ListTaskSchedules(ctx context.Context, request ListTaskSchedulesRequestObject) (ListTaskSchedulesResponseObject, error) {
+	schedules, err := s.queries.ListTaskSchedules(ctx, db.ListTaskSchedulesParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		CreatedBy:   custom_middleware.RequestUserID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task schedules: %w", err)
+	}
+	return ListTaskSchedules200JSONResponse{Schedules: schedules}, nil
+}
+
+// Create task schedule
+// (POST /v1/tasks/schedules)
+func (s *Server) CreateTaskSchedule(ctx context.Context, request CreateTaskScheduleRequestObject) (CreateTaskScheduleResponseObject, error) {
+	params := db.CreateTaskScheduleParams{
+		WorkspaceID:    custom_middleware.RequestWorkspaceID(ctx),
+		AgentID:        request.Body.AgentId,
+		Name:           request.Body.Name,
+		Prompt:         request.Body.Prompt,
+		CronExpression: request.Body.CronExpression,
+		Timezone:       "UTC",
+		Enabled:        true,
+		OverlapPolicy:  db.TaskScheduleOverlapPolicySkip,
+		CreatedBy:      custom_middleware.RequestUserID(ctx),
+	}
+	if request.Body.ThreadId != nil {
+		params.ThreadID = pgtype.UUID{Bytes: *request.Body.ThreadId, Valid: true}
+	}
+	if request.Body.Timezone != nil {
+		params.Timezone = *request.Body.Timezone
+	}
+	if request.Body.Enabled != nil {
+		params.Enabled = *request.Body.Enabled
+	}
+	if request.Body.OverlapPolicy != nil {
+		params.OverlapPolicy = db.TaskScheduleOverlapPolicy(*request.Body.OverlapPolicy)
+	}
+	nextRunAt, msg := validateTaskSchedule(params.Name, params.Prompt, params.CronExpression, params.Timezone, params.OverlapPolicy, params.ThreadID.Valid)
+	if msg != "" {
+		return CreateTaskSchedule400JSONResponse{Message: msg}, nil
+	}
+	params.NextRunAt = pgtype.Timestamptz{Time: nextRunAt, Valid: true}
+
+	notFound, forbidden, err := s.checkTaskScheduleTarget(ctx, params.AgentID, params.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+	if notFound != nil {
+		return CreateTaskSchedule404JSONResponse(*notFound), nil
+	}
+	if forbidden != "" {
+		return CreateTaskSchedule403JSONResponse{Message: forbidden}, nil
+	}
+
+	schedule, err := s.queries.CreateTaskSchedule(ctx, params)
+	if err != nil {
+		if db.IsConflictError(err) {
+			return CreateTaskSchedule409JSONResponse{Message: fmt.Sprintf("Schedule %s already exists", params.Name), Resource: TASK_SCHEDULE_RESOURCE, Id: params.AgentID}, nil
+		}
+		return nil, fmt.Errorf("failed to create task schedule: %w", err)
+	}
+	return CreateTaskSchedule201JSONResponse(schedule), nil
+}
+
+// Get task schedule
+// (GET /v1/tasks/schedules/{schedule_id})
+func (s *Server) GetTaskSchedule(ctx context.Context, request GetTaskScheduleRequestObject) (GetTaskScheduleResponseObject, error) {
+	schedule, err := s.getTaskSchedule(ctx, request.ScheduleId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return GetTaskSchedule404JSONResponse{Message: "Schedule not found", Resource: TASK_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+	return GetTaskSchedule200JSONResponse(schedule), nil
+}
+
+// Update task schedule
+// (PUT /v1/tasks/schedules/{schedule_id})
+func (s *Server) UpdateTaskSchedule(ctx context.Context, request UpdateTaskScheduleRequestObject) (UpdateTaskScheduleResponseObject, error) {
+	schedule, err := s.getTaskSchedule(ctx, request.ScheduleId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return UpdateTaskSchedule404JSONResponse{Message: "Schedule not found", Resource: TASK_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+
+	params := db.UpdateTaskScheduleParams{
+		ID:             schedule.ID,
+		AgentID:        schedule.AgentID,
+		ThreadID:       schedule.ThreadID,
+		Name:           schedule.Name,
+		Prompt:         schedule.Prompt,
+		CronExpression: schedule.CronExpression,
+		Timezone:       schedule.Timezone,
+		Enabled:        schedule.Enabled,
+		OverlapPolicy:  schedule.OverlapPolicy,
+		NextRunAt:      schedule.NextRunAt,
+	}
+	if request.Body.AgentId != nil {
+		params.AgentID = *request.Body.AgentId
+	}
+	if request.Body.ThreadId != nil {
+		params.ThreadID = pgtype.UUID{Bytes: *request.Body.ThreadId, Valid: true}
+	}
+	if request.Body.Name != nil {
+		params.Name = *request.Body.Name
+	}
+	if request.Body.Prompt != nil {
+		params.Prompt = *request.Body.Prompt
+	}
+	if request.Body.CronExpression != nil {
+		params.CronExpression = *request.Body.CronExpression
+	}
+	if request.Body.Timezone != nil {
+		params.Timezone = *request.Body.Timezone
+	}
+	if request.Body.Enabled != nil {
+		params.Enabled = *request.Body.Enabled
+	}
+	if request.Body.OverlapPolicy != nil {
+		params.OverlapPolicy = db.TaskScheduleOverlapPolicy(*request.Body.OverlapPolicy)
+	}
+	nextRunAt, msg := validateTaskSchedule(params.Name, params.Prompt, params.CronExpression, params.Timezone, params.OverlapPolicy, params.ThreadID.Valid)
+	if msg != "" {
+		return UpdateTaskSchedule400JSONResponse{Message: msg}, nil
+	}
+	// A new expression, or a schedule enabled again, starts from now instead of running the missed run
+	if params.CronExpression != schedule.CronExpression || params.Timezone != schedule.Timezone || (params.Enabled && !schedule.Enabled) {
+		params.NextRunAt = pgtype.Timestamptz{Time: nextRunAt, Valid: true}
+	}
+
+	if params.AgentID != schedule.AgentID || params.ThreadID != schedule.ThreadID {
+		notFound, forbidden, err := s.checkTaskScheduleTarget(ctx, params.AgentID, params.ThreadID)
+		if err != nil {
+			return nil, err
+		}
+		if notFound != nil {
+			return UpdateTaskSchedule404JSONResponse(*notFound), nil
+		}
+		if forbidden != "" {
+			return UpdateTaskSchedule403JSONResponse{Message: forbidden}, nil
+		}
+	}
+
+	schedule, err = s.queries.UpdateTaskSchedule(ctx, params)
+	if err != nil {
+		if db.IsConflictError(err) {
+			return UpdateTaskSchedule409JSONResponse{Message: fmt.Sprintf("Schedule %s already exists", params.Name), Resource: TASK_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, fmt.Errorf("failed to update task schedule: %w", err)
+	}
+	return UpdateTaskSchedule200JSONResponse(schedule), nil
+}
+
+// Delete task schedule
+// (DELETE /v1/tasks/schedules/{schedule_id})
+func (s *Server) DeleteTaskSchedule(ctx context.Context, request DeleteTaskScheduleRequestObject) (DeleteTaskScheduleResponseObject, error) {
+	if _, err := s.getTaskSchedule(ctx, request.ScheduleId); err != nil {
+		if err == pgx.ErrNoRows {
+			return DeleteTaskSchedule404JSONResponse{Message: "Schedule not found", Resource: TASK_SCHEDULE_RESOURCE, Id: request.ScheduleId}, nil
+		}
+		return nil, err
+	}
+	if err := s.queries.DeleteTaskSchedule(ctx, request.ScheduleId); err != nil {
+		return nil, fmt.Errorf("failed to delete task schedule: %w", err)
+	}
+	return DeleteTaskSchedule204Response{}, nil
+}
+
+// getTaskSchedule returns a schedule of the user of the request in the workspace of the request
+func (s *Server) getTaskSchedule(ctx context.Context, scheduleID uuid.UUID) (db.TaskSchedule, error) {
+	return s.queries.GetTaskSchedule(ctx, db.GetTaskScheduleParams{
+		ID:          scheduleID,
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		CreatedBy:   custom_middleware.RequestUserID(ctx),
+	})
+}
+
+// checkTaskScheduleTarget checks that the agent and the thread of a schedule exist and that the user of the request
+// can invoke them, a schedule runs the agent on behalf of its creator. It returns the body of the 404 response when
+// one of them is not found, and the message of the 403 response when the user cannot invoke it.
+func (s *Server) checkTaskScheduleTarget(ctx context.Context, agentID uuid.UUID, threadID pgtype.UUID) (*NotFound, string, error) {
+	_, access, err := s.agentAccess(ctx, agentID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return &NotFound{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: agentID}, "", nil
+		}
+		return nil, "", err
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return nil, accessMessage(AGENT_RESOURCE, db.GrantAccessInvoke), nil
+	}
+	if !threadID.Valid {
+		return nil, "", nil
+	}
+	_, access, err = s.threadAccess(ctx, threadID.Bytes)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return &NotFound{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: threadID.Bytes}, "", nil
+		}
+		return nil, "", err
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return nil, accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke), nil
+	}
+	return nil, "", nil
+}
+
+// validateTaskSchedule returns the next run of the schedule from now, and why the schedule settings are invalid,
+// empty when they are valid
+func validateTaskSchedule(name, prompt, cronExpression, timezone string, policy db.TaskScheduleOverlapPolicy, hasThread bool) (time.Time, string) {
+	switch {
+	case name == "":
+		return time.Time{}, "name is required"
+	case len(name) > 255:
+		return time.Time{}, "name must be less than 255 characters"
+	case prompt == "":
+		return time.Time{}, "prompt is required"
+	case cronExpression == "":
+		return time.Time{}, "cron_expression is required"
+	}
+	switch policy {
+	case db.TaskScheduleOverlapPolicySkip, db.TaskScheduleOverlapPolicyQueue:
+	case db.TaskScheduleOverlapPolicyAllow:
+		// The runs of a thread follow each other, only the runs in their own thread can overlap
+		if hasThread {
+			return time.Time{}, "overlap_policy allow is only valid for a schedule without thread_id"
+		}
+	default:
+		return time.Time{}, fmt.Sprintf("invalid overlap_policy %q, must be skip, queue or allow", policy)
+	}
+	next, err := db.NextCronRun(cronExpression, timezone, time.Now())
+	if err != nil {
+		return time.Time{}, err.Error()
+	}
+	if _, err := db.RenderTaskSchedulePrompt(name, prompt, next); err != nil {
+		return time.Time{}, err.Error()
+	}
+	return next, ""
+}
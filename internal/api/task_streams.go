@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+)
+
+// taskStreamTimeout bounds the execution of a streamed task run, the run outlives the requests of its clients
+const taskStreamTimeout = 30 * time.Minute
+
+type (
+	// taskStreams buffers the Server-Sent Events of the task runs in JetStream. A client reads the events of a run
+	// from its start or reattaches after the last event it received, from any instance of the API.
+	taskStreams struct {
+		js *service.JetStreamService
+	}
+
+	// taskStream is the writer of the events of a task run, closing it stops its execution context
+	taskStream struct {
+		*service.TaskRunEventWriter
+		cancel context.CancelFunc
+	}
+)
+
+func newTaskStreams(js *service.JetStreamService) *taskStreams {
+	return &taskStreams{js: js}
+}
+
+// start creates the stream of a task run, written until the returned context is done
+func (ts *taskStreams) start(taskRunID uuid.UUID) (context.Context, io.WriteCloser, error) {
+	events, err := ts.js.TaskRunEvents(taskRunID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), taskStreamTimeout)
+	return ctx, &taskStream{TaskRunEventWriter: events, cancel: cancel}, nil
+}
+
+// exists reports whether a task run is streamed, its events are kept for a while after the end of the run
+func (ts *taskStreams) exists(ctx context.Context, taskRunID uuid.UUID) (bool, error) {
+	return ts.js.HasTaskRunEvents(ctx, taskRunID)
+}
+
+// reader returns a reader of the stream of a task run after the event with the last event ID, from its first event
+// when it is 0. It stops when ctx is done.
+func (ts *taskStreams) reader(ctx context.Context, taskRunID uuid.UUID, lastEventID uint64) (io.Reader, error) {
+	return ts.js.ReadTaskRunEvents(ctx, taskRunID, lastEventID)
+}
+
+// Close ends the stream and stops its execution context
+func (t *taskStream) Close() error {
+	defer t.cancel()
+	return t.TaskRunEventWriter.Close()
+}
@@ -11,19 +11,18 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/nats-io/nats.go"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
 )
 
 const TASK_RESOURCE = "Task"
 
 func (s *Server) CreateTask(ctx context.Context, req CreateTaskRequestObject) (CreateTaskResponseObject, error) {
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	userId, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
-	}
+	userId := custom_middleware.RequestUserID(ctx)
 
 	// Validate required fields
 	if req.Body.ThreadId == uuid.Nil {
@@ -36,14 +35,16 @@ func (s *Server) CreateTask(ctx context.Context, req CreateTaskRequestObject) (C
 	}
 
 	// Check if thread exists
-	p := db.GetThreadByIDParams{UserID: userId, ID: req.Body.ThreadId}
-	_, err = s.queries.GetThreadByID(ctx, p)
+	_, access, err := s.threadAccess(ctx, req.Body.ThreadId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return CreateTask404JSONResponse{Resource: "Thread", Id: req.Body.ThreadId, Message: fmt.Sprintf("Thread with ID %s not found", req.Body.ThreadId)}, nil
 		}
 		return nil, fmt.Errorf("failed to validate thread: %w", err)
 	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return CreateTask403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
 
 	// Validate request
 	addInfo, err := db.NewJsonRaw(req.Body.AdditionalInfo)
@@ -60,7 +61,17 @@ func (s *Server) CreateTask(ctx context.Context, req CreateTaskRequestObject) (C
 		ThreadID:       req.Body.ThreadId,
 		MaxRequestLoop: maxRequestLoop,
 		AdditionalInfo: addInfo,
-		CreatedBy:      uuid.MustParse("550e8400-c95b-4444-6666-446655440000"), // TODO: Get from authentication context
+		CreatedBy:      userId,
+	}
+
+	if req.Body.FlowRunId != nil {
+		if _, err := s.queries.GetWorkspaceFlowRun(ctx, db.GetWorkspaceFlowRunParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), FlowRunID: *req.Body.FlowRunId}); err != nil {
+			if err == pgx.ErrNoRows {
+				return CreateTask400JSONResponse{Message: fmt.Sprintf("Flow run with ID %s not found", *req.Body.FlowRunId)}, nil
+			}
+			return nil, fmt.Errorf("failed to validate flow run: %w", err)
+		}
+		params.FlowRunID = pgtype.UUID{Bytes: *req.Body.FlowRunId, Valid: true}
 	}
 
 	task, err := s.queries.CreateTask(ctx, *params)
@@ -76,7 +87,8 @@ func (s *Server) GetTask(ctx context.Context, req GetTaskRequestObject) (GetTask
 		return GetTask404JSONResponse{Resource: TASK_RESOURCE, Id: req.TaskId, Message: "Task ID cannot be nil"}, nil
 	}
 
-	task, err := s.queries.GetTaskById(ctx, req.TaskId.String())
+	// The task of a thread of another user is not found
+	task, _, err := s.taskAccess(ctx, req.TaskId.String())
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return GetTask404JSONResponse{Resource: TASK_RESOURCE, Id: req.TaskId, Message: fmt.Sprintf("Task with ID %s not found", req.TaskId)}, nil
@@ -88,17 +100,21 @@ func (s *Server) GetTask(ctx context.Context, req GetTaskRequestObject) (GetTask
 
 func (s *Server) UpdateTask(ctx context.Context, req UpdateTaskRequestObject) (UpdateTaskResponseObject, error) {
 	taskID := req.TaskId
-	// Validate request - check if task exists
-	task, err := s.queries.GetTaskById(ctx, taskID.String())
+	// Validate request - check if task exists, the task of a thread of another user is not found
+	task, access, err := s.taskAccess(ctx, taskID.String())
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return UpdateTask404JSONResponse{Resource: TASK_RESOURCE, Id: taskID, Message: fmt.Sprintf("Task with ID %s not found", taskID)}, nil
 		}
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
+	if !access.Allows(db.GrantAccessManage) {
+		return UpdateTask403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessManage)}, nil
+	}
 
 	params := &db.UpdateTaskParams{
 		ID:             taskID.String(),
+		WorkspaceID:    custom_middleware.RequestWorkspaceID(ctx),
 		MaxRequestLoop: task.MaxRequestLoop,
 		AdditionalInfo: task.AdditionalInfo,
 	}
@@ -123,16 +139,19 @@ func (s *Server) UpdateTask(ctx context.Context, req UpdateTaskRequestObject) (U
 
 func (s *Server) DeleteTask(ctx context.Context, req DeleteTaskRequestObject) (DeleteTaskResponseObject, error) {
 	taskID := req.TaskId
-	// Validate request - check if task exists
-	_, err := s.queries.GetTaskById(ctx, taskID.String())
+	// Validate request - check if task exists, the task of a thread of another user is not found
+	_, access, err := s.taskAccess(ctx, taskID.String())
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return DeleteTask404JSONResponse{Resource: TASK_RESOURCE, Id: taskID, Message: fmt.Sprintf("Task with ID %s not found", taskID)}, nil
 		}
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
+	if !access.Allows(db.GrantAccessManage) {
+		return DeleteTask403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessManage)}, nil
+	}
 
-	err = s.queries.DeleteTask(ctx, taskID.String())
+	err = s.queries.DeleteTask(ctx, db.DeleteTaskParams{ID: taskID.String(), WorkspaceID: custom_middleware.RequestWorkspaceID(ctx)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -140,38 +159,81 @@ func (s *Server) DeleteTask(ctx context.Context, req DeleteTaskRequestObject) (D
 }
 
 func (s *Server) ListTasks(ctx context.Context, req ListTasksRequestObject) (ListTasksResponseObject, error) {
+	sortField := string(ListTasksParamsSortCreatedAt)
+	if req.Params.Sort != nil {
+		sortField = string(*req.Params.Sort)
+	}
+	sort := newListSort(sortField, req.Params.Order)
+
+	// Only the tasks of the threads of the workspace visible to the user are listed
 	params := db.GetTasksParams{
-		Limit:  10,
-		Offset: 0,
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		UserID:      custom_middleware.RequestUserID(ctx),
+		CreatedBy:   optionalUUID(req.Params.CreatedBy),
+		Sort:        sort.Field,
+		Descending:  sort.Descending,
+	}
+	if req.Params.Status != nil {
+		params.Status = pgtype.Text{String: string(*req.Params.Status), Valid: true}
+	}
+	var err error
+	if params.CreatedAfter, params.CreatedBefore, err = createdRange(req.Params.CreatedAfter, req.Params.CreatedBefore); err != nil {
+		return ListTasks400JSONResponse{Message: err.Error()}, nil
 	}
-	var page int32 = 1
-	if req.Params.PerPage != nil {
-		params.Limit = *req.Params.PerPage
+
+	// A cursor is a position in the list of a sort and order
+	limit, cursor, err := parsePage(req.Params.Limit, req.Params.Cursor)
+	if err == nil {
+		err = sort.check(cursor)
+	}
+	if err != nil {
+		return ListTasks400JSONResponse{Message: err.Error()}, nil
 	}
-	if req.Params.Page != nil {
-		page = *req.Params.Page
+	if params.CursorTime, err = cursor.timeKey(); err != nil {
+		return ListTasks400JSONResponse{Message: err.Error()}, nil
 	}
-	params.Offset = (page - 1) * params.Limit
+	if cursor != nil {
+		params.CursorID = cursor.ID
+	}
+	params.RowLimit = limit + 1
 
 	tasks, err := s.queries.GetTasks(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
+	total, err := s.queries.CountTasks(ctx, db.CountTasksParams{
+		WorkspaceID:   params.WorkspaceID,
+		UserID:        params.UserID,
+		Status:        params.Status,
+		CreatedBy:     params.CreatedBy,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks: %w", err)
+	}
 
+	tasks, hasMore, next := pageRows(tasks, limit, func(task db.Task) pageCursor {
+		c := pageCursor{Sort: sort.String(), Time: &task.CreatedAt.Time, ID: task.ID}
+		if sort.Field == string(ListTasksParamsSortUpdatedAt) {
+			c.Time = &task.UpdatedAt.Time
+		}
+		return c
+	})
 	return ListTasks200JSONResponse(TaskList{
 		Tasks:      tasks,
-		Page:       page,
-		PerPage:    params.Limit,
-		Total:      len(tasks),
-		TotalPages: (len(tasks) + int(params.Limit) - 1) / int(params.Limit),
+		Limit:      limit,
+		HasMore:    hasMore,
+		NextCursor: next,
+		Total:      int(total),
 	}), nil
 }
 
 // Get all task runs for a task
 // (GET /v1/tasks/{task_id}/runs)
 func (s *Server) ListTaskRuns(ctx context.Context, request ListTaskRunsRequestObject) (ListTaskRunsResponseObject, error) {
-	// First check if the task exists
-	_, err := s.queries.GetTaskById(ctx, request.TaskId.String())
+	// First check if the task exists, the task of a thread of another user is not found
+	_, _, err := s.taskAccess(ctx, request.TaskId.String())
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return ListTaskRuns404JSONResponse{Resource: "Task", Id: request.TaskId, Message: fmt.Sprintf("Task with ID %s not found", request.TaskId)}, nil
@@ -187,6 +249,69 @@ func (s *Server) ListTaskRuns(ctx context.Context, request ListTaskRunsRequestOb
 	return ListTaskRuns200JSONResponse(taskRuns), nil
 }
 
+// Cancel a task run
+// (POST /v1/tasks/{task_id}/runs/{run_id}/cancel)
+func (s *Server) CancelTaskRun(ctx context.Context, req CancelTaskRunRequestObject) (CancelTaskRunResponseObject, error) {
+	taskNotFound := CancelTaskRun404JSONResponse{Resource: TASK_RESOURCE, Id: req.TaskId, Message: fmt.Sprintf("Task with ID %s not found", req.TaskId)}
+	task, err := s.queries.GetTaskById(ctx, req.TaskId.String())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return taskNotFound, nil
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	// The task of a thread of another user is not found
+	_, threadAccess, err := s.threadAccess(ctx, task.ThreadID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return taskNotFound, nil
+		}
+		return nil, fmt.Errorf("failed to get thread of task: %w", err)
+	}
+	if !threadAccess.Allows(db.GrantAccessInvoke) {
+		return CancelTaskRun403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+
+	taskRun, err := s.queries.GetTasksRun(ctx, req.RunId)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get task run: %w", err)
+	}
+	if err == pgx.ErrNoRows || taskRun.TaskID != task.ID {
+		return CancelTaskRun404JSONResponse{Resource: "TaskRun", Id: req.RunId, Message: fmt.Sprintf("TaskRun with ID %s not found", req.RunId)}, nil
+	}
+	alreadyEnded := CancelTaskRun400JSONResponse{Message: fmt.Sprintf("Task run %s already ended with status %s", req.RunId, taskRun.Status)}
+	if taskRun.Status.Ended() {
+		return alreadyEnded, nil
+	}
+	cancelled, err := s.queries.CancelTaskRun(ctx, req.RunId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return alreadyEnded, nil
+		}
+		return nil, fmt.Errorf("failed to cancel task run: %w", err)
+	}
+	// A queued run never reached the agent, the started run of the task keeps running
+	if taskRun.Status == db.TaskRunStatusScheduled {
+		return CancelTaskRun200JSONResponse(cancelled), nil
+	}
+
+	// The task service stops the agent loop and the tool runs of the cancelled run
+	event := service.NewEvent(&service.TaskCancelEventMessage{}, &service.EventHeaders{
+		UserID:      custom_middleware.RequestUserID(ctx),
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		ThreadID:    &task.ThreadID,
+		TaskID:      aws.String(task.ID),
+	}, &service.EventMetadata{
+		TraceID:   utils.GenerateTraceID(),
+		Timestamp: time.Now().UTC(),
+	})
+	if err := event.Publish(s.nc); err != nil {
+		s.log.Error("Failed to publish task cancel event", "task_id", task.ID, "task_run_id", req.RunId, "error", err)
+		return nil, fmt.Errorf("failed to publish task cancel event: %w", err)
+	}
+	return CancelTaskRun200JSONResponse(cancelled), nil
+}
+
 func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject) (ExecuteTaskResponseObject, error) {
 	taskID := req.TaskId
 	agentID := req.Body.AgentId
@@ -197,13 +322,13 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 	if req.Body.AgentId == uuid.Nil {
 		return ExecuteTask400JSONResponse{Message: "agent_id is required"}, nil
 	}
-
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	userID, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
+	filter, err := service.ParseStreamEventFilter(aws.ToString(req.Params.Events))
 	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
+		return ExecuteTask400JSONResponse{Message: err.Error()}, nil
 	}
 
+	userID := custom_middleware.RequestUserID(ctx)
+
 	// Validate task exists and get task details
 	task, err := s.queries.GetTaskById(ctx, taskID.String())
 	if err != nil {
@@ -212,21 +337,79 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 		}
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
+	// The task of a thread of another user is not found
+	_, threadAccess, err := s.threadAccess(ctx, task.ThreadID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ExecuteTask404JSONResponse{Resource: TASK_RESOURCE, Id: taskID, Message: fmt.Sprintf("Task with ID %s not found", taskID)}, nil
+		}
+		return nil, fmt.Errorf("failed to get thread of task: %w", err)
+	}
+	if !threadAccess.Allows(db.GrantAccessInvoke) {
+		return ExecuteTask403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+	_, agentAccess, err := s.agentAccess(ctx, agentID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ExecuteTask404JSONResponse{Resource: AGENT_RESOURCE, Id: agentID, Message: "Agent not found"}, nil
+		}
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+	if !agentAccess.Allows(db.GrantAccessInvoke) {
+		return ExecuteTask403JSONResponse{Message: accessMessage(AGENT_RESOURCE, db.GrantAccessInvoke)}, nil
+	}
+	exceeded, err := s.checkQuotas(ctx, db.QuotaTokensPerDay, db.QuotaConcurrentTasks)
+	if err != nil {
+		return nil, err
+	}
+	if exceeded != nil {
+		return ExecuteTask429JSONResponse(*exceeded), nil
+	}
 
-	// TODO: Will need to update this as a single task can be run multiple time.
-	// Check if there's already a running task run for this task
+	// A task runs once at a time, a new run is queued behind the started run or rejected
 	existingTaskRun, err := s.queries.GetCurrentTaskRunByTaskID(ctx, taskID.String())
 	if err != nil && err != pgx.ErrNoRows {
 		return nil, fmt.Errorf("failed to check for existing task runs: %w", err)
 	}
-	if err == nil {
-		// Task run already exists and is running/scheduled - return error via 404 with custom message
-		return ExecuteTask404JSONResponse{Resource: TASK_RESOURCE, Id: taskID, Message: fmt.Sprintf("Task %s already has a running task run with status %s", taskID, existingTaskRun.Status)}, nil
+	busy := err == nil
+	if !busy {
+		// A new run does not overtake the runs queued before it
+		queued, err := s.queries.CountQueuedTaskRuns(ctx, taskID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to count queued task runs: %w", err)
+		}
+		busy = queued > 0
+	}
+	dryRun := req.Body.DryRun != nil && *req.Body.DryRun
+	if busy {
+		if req.Body.Queue == nil || !*req.Body.Queue {
+			message := fmt.Sprintf("Task %s has queued runs, set queue to run it after them", taskID)
+			if existingTaskRun.Status.Started() {
+				message = fmt.Sprintf("Task %s already has a started run with status %s, set queue to run it afterwards", taskID, existingTaskRun.Status)
+			}
+			return ExecuteTask409JSONResponse{Resource: TASK_RESOURCE, Id: taskID, Message: message}, nil
+		}
+		// The task service starts the queued run once the runs before it end
+		taskRun, err := s.queries.CreateQueuedTasksRun(ctx, db.CreateQueuedTasksRunParams{
+			TaskID:       taskID.String(),
+			CurrentLoops: int32(currentLoops),
+			AgentID:      pgtype.UUID{Bytes: agentID, Valid: true},
+			RequestedBy:  pgtype.UUID{Bytes: userID, Valid: true},
+			WorkspaceID:  pgtype.UUID{Bytes: custom_middleware.RequestWorkspaceID(ctx), Valid: true},
+			DryRun:       dryRun,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to queue task run: %w", err)
+		}
+		return ExecuteTask202JSONResponse(taskRun), nil
 	}
 
 	// Create new task run
 	taskRun, err := s.queries.CreateTasksRun(ctx, taskID.String())
 	if err != nil {
+		if db.IsConflictError(err) {
+			return ExecuteTask409JSONResponse{Resource: TASK_RESOURCE, Id: taskID, Message: fmt.Sprintf("Task %s already has a started run, set queue to run it afterwards", taskID)}, nil
+		}
 		return nil, fmt.Errorf("failed to create task run: %w", err)
 	}
 
@@ -247,14 +430,56 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 	}
 
 	// Get all the messages from the threads
-	messages, err := s.queries.GetMessageContents(ctx, task.ThreadID)
+	rows, err := s.queries.GetMessageContents(ctx, task.ThreadID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages for thread %s: %w", task.ThreadID, err)
 	}
+	// The files attached to the messages are given to the agent as content blocks
+	ids := make([]uuid.UUID, len(rows))
+	messages := make([]db.JsonRaw, len(rows))
+	for i, row := range rows {
+		ids[i], messages[i] = row.ID, row.Message
+	}
+	messages, err = s.files.ExpandAttachments(ctx, s.queries, ids, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// The execution outlives the request, the client reattaches to the stream with the ID of the last event received
+	streamCtx, stream, err := s.streams.start(taskRun.TaskRunID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.streamTaskRun(streamCtx, taskRun, userID, filter, stream); err != nil {
+		return nil, err
+	}
+	body, err := s.streams.reader(ctx, taskRun.TaskRunID, 0)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	// Now publish the agent invoke event to trigger execution
+	err = s.publishAgentInvoke(custom_middleware.RequestWorkspaceID(ctx), agentID, userID, task, messages, dryRun)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
 
-	// Create a pipe for SSE streaming
-	pipeReader, pipeWriter := io.Pipe()
+	// Return SSE response with proper headers
+	return ExecuteTask200TexteventStreamResponse{
+		Body: body,
+		Headers: ExecuteTask200ResponseHeaders{
+			CacheControl: "no-cache",
+			Connection:   "keep-alive",
+		},
+	}, nil
+}
 
+// streamTaskRun subscribes to the events sent to the user and writes them to w as Server-Sent Events
+// until the task run finishes or the context is cancelled. The status of the task run is then stored and w is closed.
+// Only the event classes allowed by the filter are written, errors are always written.
+func (s *Server) streamTaskRun(ctx context.Context, taskRun db.TasksRun, userID uuid.UUID, filter *service.StreamEventFilter, w io.WriteCloser) error {
 	// Create a buffered channel for responses with buffer size of 100 to handle bursts
 	responseChan := make(chan *nats.Msg, 100)
 
@@ -263,8 +488,8 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 	sub, err := s.nc.ChanSubscribe(event.SubjectWithUser(userID).String(), responseChan)
 	if err != nil {
 		s.log.Error("Failed to subscribe to response channel", "user_id", userID, "error", err)
-		pipeWriter.Close()
-		return nil, fmt.Errorf("failed to get response streaming from model: %w", err)
+		w.Close()
+		return fmt.Errorf("failed to get response streaming from model: %w", err)
 	}
 
 	// Subscribe to task lifecycle events
@@ -272,8 +497,9 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 	taskSub, err := s.nc.ChanSubscribe(taskEvent.SubjectWithUser(userID).String(), responseChan)
 	if err != nil {
 		s.log.Error("Failed to subscribe to task lifecycle channel", "user_id", userID, "error", err)
-		pipeWriter.Close()
-		return nil, fmt.Errorf("failed to get response streaming from model: %w", err)
+		sub.Unsubscribe()
+		w.Close()
+		return fmt.Errorf("failed to get response streaming from model: %w", err)
 	}
 
 	// Handle incoming messages and stream as SSE
@@ -306,7 +532,7 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 			if err := taskSub.Unsubscribe(); err != nil {
 				s.log.Error("Failed to unsubscribe", "user_id", userID, "error", err)
 			}
-			pipeWriter.Close()
+			w.Close()
 		}()
 
 		for {
@@ -319,7 +545,7 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 				// Send heartbeat to keep connection alive
 				heartbeatEvent := fmt.Sprintf("event: heartbeat\ndata: {\"type\":\"heartbeat\",\"timestamp\":\"%s\"}\n\n",
 					time.Now().UTC().Format(time.RFC3339))
-				if _, err := pipeWriter.Write([]byte(heartbeatEvent)); err != nil {
+				if _, err := w.Write([]byte(heartbeatEvent)); err != nil {
 					s.log.Error("Failed to write heartbeat event", "error", err)
 					return
 				}
@@ -348,7 +574,10 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 										switch eventType {
 										case "task_stop":
 											taskStatus = db.TaskRunStatusFinished
-											s.log.Debug("Task completed successfully", "task_run_id", taskRun.TaskRunID)
+											if messageData["reason"] == "cancelled" {
+												taskStatus = db.TaskRunStatusCancelled
+											}
+											s.log.Debug("Task stopped", "task_run_id", taskRun.TaskRunID, "status", taskStatus)
 											return
 										case "task_error", "task_failed":
 											taskStatus = db.TaskRunStatusFailed
@@ -356,6 +585,9 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 											return
 										case "sub_task_start", "sub_task_stop":
 											// Ignore since this is for sub task
+											if !filter.Allows(service.StreamEventClassLifecycle) {
+												continue
+											}
 										default:
 											s.log.Debug("Unknown task lifecycle event type", "type", eventType)
 											// Keep default taskStatus = FAILED
@@ -369,7 +601,12 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 				}
 
 				// Parse the NATS message into WebsocketResponseEventMessage
-				event, _ := service.ParseEvent[*service.WebsocketResponseEventMessage](msg.Data)
+				event, err := service.ParseEvent[*service.WebsocketResponseEventMessage](msg.Data)
+
+				// Skip the event classes the client did not subscribe to, errors are always written
+				if err == nil && strings.Contains(msg.Subject, "ws.response") && !filter.AllowResponse(event.Msg) {
+					continue
+				}
 
 				// Convert the response event to JSON for SSE data
 				eventData, err := json.Marshal(event.Msg)
@@ -384,7 +621,7 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 				// s.log.Debug("SSE event sent to client: %s", sseEvent)
 
 				// Write the SSE event to the pipe
-				if _, err := pipeWriter.Write([]byte(sseEvent)); err != nil {
+				if _, err := w.Write([]byte(sseEvent)); err != nil {
 					s.log.Error("Failed to write SSE event", "error", err)
 					return
 				}
@@ -392,35 +629,31 @@ func (s *Server) ExecuteTask(ctx context.Context, req ExecuteTaskRequestObject)
 		}
 	}()
 
-	// Now publish the agent invoke event to trigger execution
+	return nil
+}
+
+// publishAgentInvoke sends the messages of the task thread to the agent, the task starts executing in the workspace
+func (s *Server) publishAgentInvoke(workspaceID, agentID, userID uuid.UUID, task db.Task, messages []db.JsonRaw, dryRun bool) error {
 	agentEvent := service.NewEvent(&service.AgentInvokeEventMessage{
 		AgentId:     agentID,
 		RecipientId: userID,
 		Messages:    messages,
 	}, &service.EventHeaders{
-		UserID:   userID,
-		ThreadID: &task.ThreadID,
-		TaskID:   aws.String(taskID.String()),
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		ThreadID:    &task.ThreadID,
+		TaskID:      aws.String(task.ID),
+		DryRun:      dryRun,
 	}, &service.EventMetadata{
 		TraceID:   "", // TODO: Get from request context
 		Timestamp: time.Now().UTC(),
 	})
 
 	// Publish using the service layer method
-	err = agentEvent.Publish(s.nc)
-	if err != nil {
-		pipeWriter.Close()
-		return nil, fmt.Errorf("failed to publish task execute event: %w", err)
+	if err := agentEvent.Publish(s.nc); err != nil {
+		return fmt.Errorf("failed to publish task execute event: %w", err)
 	}
-
-	// Return SSE response with proper headers
-	return ExecuteTask200TexteventStreamResponse{
-		Body: pipeReader,
-		Headers: ExecuteTask200ResponseHeaders{
-			CacheControl: "no-cache",
-			Connection:   "keep-alive",
-		},
-	}, nil
+	return nil
 }
 
 func (s *Server) GetTaskRun(ctx context.Context, req GetTaskRunRequestObject) (GetTaskRunResponseObject, error) {
@@ -431,5 +664,12 @@ func (s *Server) GetTaskRun(ctx context.Context, req GetTaskRunRequestObject) (G
 		}
 		return nil, fmt.Errorf("failed to get task run: %w", err)
 	}
+	// The run of a task of a thread of another user is not found
+	if _, _, err := s.taskAccess(ctx, taskRun.TaskID); err != nil {
+		if err == pgx.ErrNoRows {
+			return GetTaskRun404JSONResponse{Resource: "TaskRun", Id: req.TaskRunId, Message: fmt.Sprintf("TaskRun with ID %s not found", req.TaskRunId)}, nil
+		}
+		return nil, fmt.Errorf("failed to get task of task run: %w", err)
+	}
 	return GetTaskRun200JSONResponse(taskRun), nil
 }
@@ -1,14 +1,21 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats.go"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	db "github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
 )
 
 const THREAD_RESOURCE = "Thread"
@@ -16,19 +23,85 @@ const THREAD_RESOURCE = "Thread"
 // List all threads
 // (GET /v1/threads)
 func (s *Server) ListThreads(ctx context.Context, request ListThreadsRequestObject) (ListThreadsResponseObject, error) {
+	userId := custom_middleware.RequestUserID(ctx)
 
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	// Parse string to UUID
-	userId, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
+	sortField := string(ListThreadsParamsSortUpdatedAt)
+	if request.Params.Sort != nil {
+		sortField = string(*request.Params.Sort)
+	}
+	sort := newListSort(sortField, request.Params.Order)
+
+	params := db.GetThreadsParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		UserID:      userId,
+		Title:       optionalText(request.Params.Title),
+		Deleted:     listTrash(request.Params.Deleted),
+		Sort:        sort.Field,
+		Descending:  sort.Descending,
+	}
+	var err error
+	if params.CreatedAfter, params.CreatedBefore, err = createdRange(request.Params.CreatedAfter, request.Params.CreatedBefore); err != nil {
+		return ListThreads400JSONResponse{Message: err.Error()}, nil
+	}
+
+	// A cursor is a position in the list of a sort and order
+	limit, cursor, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err == nil {
+		err = sort.check(cursor)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
+		return ListThreads400JSONResponse{Message: err.Error()}, nil
 	}
+	if cursor != nil {
+		id, err := cursor.uuidID()
+		if err != nil {
+			return ListThreads400JSONResponse{Message: err.Error()}, nil
+		}
+		params.CursorID = pgtype.UUID{Bytes: id, Valid: true}
+		params.CursorText = cursor.textKey()
+		if sort.Field != string(ListThreadsParamsSortTitle) {
+			if params.CursorTime, err = cursor.timeKey(); err != nil {
+				return ListThreads400JSONResponse{Message: err.Error()}, nil
+			}
+		}
+	}
+	params.RowLimit = limit + 1
 
-	threads, err := s.queries.GetThreads(ctx, userId)
+	threads, err := s.queries.GetThreads(ctx, params)
 	if err != nil {
 		return nil, err
 	}
-	return ListThreads200JSONResponse(ThreadList{Threads: threads}), nil
+	total, err := s.queries.CountThreads(ctx, db.CountThreadsParams{
+		WorkspaceID:   custom_middleware.RequestWorkspaceID(ctx),
+		UserID:        userId,
+		Title:         params.Title,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+		Deleted:       params.Deleted,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count threads: %w", err)
+	}
+
+	threads, hasMore, next := pageRows(threads, limit, func(thread db.Thread) pageCursor {
+		c := pageCursor{Sort: sort.String(), ID: thread.ID.String()}
+		switch sort.Field {
+		case string(ListThreadsParamsSortTitle):
+			c.Text = &thread.Title
+		case string(ListThreadsParamsSortCreatedAt):
+			c.Time = &thread.CreatedAt.Time
+		default:
+			c.Time = &thread.UpdatedAt.Time
+		}
+		return c
+	})
+	return ListThreads200JSONResponse(ThreadList{
+		Threads:    threads,
+		Limit:      limit,
+		HasMore:    hasMore,
+		NextCursor: next,
+		Total:      int(total),
+	}), nil
 }
 
 // Create a new thread
@@ -41,6 +114,10 @@ func (s *Server) CreateThread(ctx context.Context, request CreateThreadRequestOb
 	if request.Body.UserId == uuid.Nil {
 		return CreateThread400JSONResponse{Message: "user_id is required"}, nil
 	}
+	// An authenticated request creates the threads of its own user
+	if principal := custom_middleware.GetPrincipal(ctx); principal != nil && request.Body.UserId != principal.UserID {
+		return CreateThread400JSONResponse{Message: "user_id must be the authenticated user"}, nil
+	}
 
 	// Check length of thread title
 	if len(request.Body.Title) > 255 {
@@ -50,10 +127,11 @@ func (s *Server) CreateThread(ctx context.Context, request CreateThreadRequestOb
 	now := time.Now()
 
 	params := db.CreateThreadParams{
-		Title:     request.Body.Title,
-		UserID:    request.Body.UserId,
-		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-		UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Title:       request.Body.Title,
+		UserID:      request.Body.UserId,
+		CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
 	}
 
 	thread, err := s.queries.CreateThread(ctx, params)
@@ -67,47 +145,71 @@ func (s *Server) CreateThread(ctx context.Context, request CreateThreadRequestOb
 // Delete thread
 // (DELETE /v1/threads/{thread_id})
 func (s *Server) DeleteThread(ctx context.Context, request DeleteThreadRequestObject) (DeleteThreadResponseObject, error) {
-	// Check if thread exists first
-	userId, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
+	// The thread moves to the trash, it can be restored until it is purged
+	userId := custom_middleware.RequestUserID(ctx)
+
+	params := db.SoftDeleteThreadParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		UserID:      userId,
+		ID:          request.ThreadId,
 	}
 
-	params := db.GetThreadByIDParams{
-		UserID: userId,
-		ID:     request.ThreadId,
+	deleted, err := s.queries.SoftDeleteThread(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return DeleteThread404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
 	}
 
-	_, err = s.queries.GetThreadByID(ctx, params)
+	return DeleteThread204Response{}, nil
+}
+
+// Restore thread
+// (POST /v1/threads/{thread_id}/restore)
+func (s *Server) RestoreThread(ctx context.Context, request RestoreThreadRequestObject) (RestoreThreadResponseObject, error) {
+	userId := custom_middleware.RequestUserID(ctx)
+
+	thread, err := s.queries.RestoreThread(ctx, db.RestoreThreadParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), UserID: userId, ID: request.ThreadId})
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return DeleteThread404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+			return RestoreThread404JSONResponse{Message: "Thread not found in the trash", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
 		}
 		return nil, err
 	}
 
-	err = s.queries.DeleteThread(ctx, request.ThreadId)
+	return RestoreThread200JSONResponse(thread), nil
+}
+
+// Purge thread
+// (POST /v1/threads/{thread_id}/purge)
+func (s *Server) PurgeThread(ctx context.Context, request PurgeThreadRequestObject) (PurgeThreadResponseObject, error) {
+	userId := custom_middleware.RequestUserID(ctx)
+
+	purged, err := s.queries.PurgeThread(ctx, db.PurgeThreadParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), UserID: userId, ID: request.ThreadId})
 	if err != nil {
 		return nil, err
 	}
+	if purged == 0 {
+		return PurgeThread404JSONResponse{Message: "Thread not found in the trash", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+	}
+	if err := s.queries.DeleteResourceGrants(ctx, db.DeleteResourceGrantsParams{ResourceType: db.ResourceTypeThread, ResourceID: request.ThreadId}); err != nil {
+		return nil, fmt.Errorf("failed to delete thread grants: %w", err)
+	}
 
-	return DeleteThread204Response{}, nil
+	return PurgeThread204Response{}, nil
 }
 
 // Get thread by ID
 // (GET /v1/threads/{thread_id})
 func (s *Server) GetThread(ctx context.Context, request GetThreadRequestObject) (GetThreadResponseObject, error) {
 
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	// Parse string to UUID
-	userId, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
-	}
+	userId := custom_middleware.RequestUserID(ctx)
 
 	params := db.GetThreadByIDParams{
-		UserID: userId,
-		ID:     request.ThreadId,
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		UserID:      userId,
+		ID:          request.ThreadId,
 	}
 
 	thread, err := s.queries.GetThreadByID(ctx, params)
@@ -118,7 +220,7 @@ func (s *Server) GetThread(ctx context.Context, request GetThreadRequestObject)
 		return nil, err
 	}
 
-	return GetThread200JSONResponse(thread), nil
+	return GetThread200JSONResponse{Body: thread, Headers: GetThread200ResponseHeaders{ETag: db.ETag(thread.UpdatedAt)}}, nil
 }
 
 // Update thread title
@@ -135,33 +237,266 @@ func (s *Server) UpdateThreadTitle(ctx context.Context, request UpdateThreadTitl
 	}
 
 	// Check if thread exists first
-	userId, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
+	current, access, err := s.threadAccess(ctx, request.ThreadId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid UUID format: %v", err)
+		if err == pgx.ErrNoRows {
+			return UpdateThreadTitle404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return UpdateThreadTitle403JSONResponse{Message: accessMessage(THREAD_RESOURCE, db.GrantAccessManage)}, nil
+	}
+	ifUpdatedAt, ok := db.MatchETag(aws.ToString(request.Params.IfMatch), current.UpdatedAt)
+	if !ok {
+		return UpdateThreadTitle412JSONResponse(preconditionFailed(THREAD_RESOURCE, request.ThreadId, current.UpdatedAt)), nil
 	}
 
-	checkParams := db.GetThreadByIDParams{
-		UserID: userId,
-		ID:     request.ThreadId,
+	params := db.UpdateThreadParams{
+		ID:          request.ThreadId,
+		Title:       request.Body.Title,
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		IfUpdatedAt: ifUpdatedAt,
 	}
 
-	_, err = s.queries.GetThreadByID(ctx, checkParams)
+	thread, err := s.queries.UpdateThread(ctx, params)
+	if err != nil {
+		// Another request updated the thread since it was read
+		if err == pgx.ErrNoRows && ifUpdatedAt.Valid {
+			return UpdateThreadTitle412JSONResponse(preconditionFailed(THREAD_RESOURCE, request.ThreadId, pgtype.Timestamptz{})), nil
+		}
+		return nil, err
+	}
+
+	return UpdateThreadTitle200JSONResponse{Body: thread, Headers: UpdateThreadTitle200ResponseHeaders{ETag: db.ETag(thread.UpdatedAt)}}, nil
+}
+
+// Export a thread
+// (GET /v1/threads/{thread_id}/export)
+func (s *Server) ExportThread(ctx context.Context, request ExportThreadRequestObject) (ExportThreadResponseObject, error) {
+	userId := custom_middleware.RequestUserID(ctx)
+
+	thread, err := s.queries.GetThreadByID(ctx, db.GetThreadByIDParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), UserID: userId, ID: request.ThreadId})
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return UpdateThreadTitle404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+			return ExportThread404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
 		}
 		return nil, err
 	}
 
-	params := db.UpdateThreadParams{
-		ID:    request.ThreadId,
-		Title: request.Body.Title,
+	export, err := s.queries.ExportThread(ctx, thread)
+	if err != nil {
+		return nil, err
 	}
+	if request.Params.Format == nil || *request.Params.Format == ExportThreadParamsFormatJson {
+		return ExportThread200JSONResponse(export), nil
+	}
+	data := db.RenderThreadMarkdown(export)
+	return ExportThread200TextmarkdownResponse{Body: bytes.NewReader(data), ContentLength: int64(len(data))}, nil
+}
 
-	thread, err := s.queries.UpdateThread(ctx, params)
+// Import a thread
+// (POST /v1/threads/import)
+func (s *Server) ImportThread(ctx context.Context, request ImportThreadRequestObject) (ImportThreadResponseObject, error) {
+	userId := custom_middleware.RequestUserID(ctx)
+
+	if request.Body == nil {
+		return ImportThread400JSONResponse{Message: "body is required"}, nil
+	}
+	if err := request.Body.Validate(); err != nil {
+		return ImportThread400JSONResponse{Message: err.Error()}, nil
+	}
+	if len(request.Body.Messages) > maxImportedThreadMessages {
+		return ImportThread400JSONResponse{Message: fmt.Sprintf("the export has %d messages, at most %d are imported", len(request.Body.Messages), maxImportedThreadMessages)}, nil
+	}
+	title := aws.ToString(request.Params.Title)
+	if title == "" {
+		title = request.Body.Thread.Title
+	}
+	if title == "" {
+		return ImportThread400JSONResponse{Message: "thread title is required"}, nil
+	}
+	if len(title) > 255 {
+		return ImportThread400JSONResponse{Message: "thread title must be less than 255 characters"}, nil
+	}
+
+	// The thread is imported whole or not at all
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	thread, err := s.queries.WithTx(tx).ImportThread(ctx, *request.Body, custom_middleware.RequestWorkspaceID(ctx), userId, title)
 	if err != nil {
 		return nil, err
 	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return ImportThread201JSONResponse(thread), nil
+}
+
+// Fork a thread
+// (POST /v1/threads/{thread_id}/fork)
+func (s *Server) ForkThread(ctx context.Context, request ForkThreadRequestObject) (ForkThreadResponseObject, error) {
+	userId := custom_middleware.RequestUserID(ctx)
+
+	// A thread the user can read is forked into a thread of the user
+	thread, _, err := s.threadAccess(ctx, request.ThreadId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ForkThread404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+	title := aws.ToString(request.Params.Title)
+	if title == "" {
+		title = thread.Title
+	}
+	if len(title) > 255 {
+		return ForkThread400JSONResponse{Message: "thread title must be less than 255 characters"}, nil
+	}
+	var fromMessage pgtype.UUID
+	if request.Params.FromMessage != nil {
+		message, err := s.queries.GetMessageByID(ctx, *request.Params.FromMessage)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, err
+		}
+		if err == pgx.ErrNoRows || message.ThreadID != thread.ID || message.SupersededAt.Valid {
+			return ForkThread400JSONResponse{Message: "from_message is not a live message of the thread"}, nil
+		}
+		fromMessage = pgtype.UUID{Bytes: message.ID, Valid: true}
+	}
+
+	// The thread is forked whole or not at all
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	fork, err := s.queries.WithTx(tx).ForkThread(ctx, thread, fromMessage, custom_middleware.RequestWorkspaceID(ctx), userId, title)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return ForkThread201JSONResponse(fork), nil
+}
+
+// threadEvent is the part of the events sent to the user needed to tell the events of a thread apart
+type threadEvent struct {
+	H   *service.EventHeaders `json:"header"`
+	Msg json.RawMessage       `json:"message"`
+	Err *service.EventError   `json:"error,omitempty"`
+}
+
+// Stream the events of a thread
+// (GET /v1/threads/{thread_id}/events)
+func (s *Server) StreamThreadEvents(ctx context.Context, request StreamThreadEventsRequestObject) (StreamThreadEventsResponseObject, error) {
+	filter, err := service.ParseStreamEventFilter(aws.ToString(request.Params.Events))
+	if err != nil {
+		return StreamThreadEvents400JSONResponse{Message: err.Error()}, nil
+	}
+
+	userId := custom_middleware.RequestUserID(ctx)
+	if _, err := s.queries.GetThreadByID(ctx, db.GetThreadByIDParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), UserID: userId, ID: request.ThreadId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return StreamThreadEvents404JSONResponse{Message: "Thread not found", Resource: THREAD_RESOURCE, Id: request.ThreadId}, nil
+		}
+		return nil, err
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	// Unblock the writes of the stream once the client is gone
+	context.AfterFunc(ctx, func() { pipeReader.CloseWithError(ctx.Err()) })
+	if err := s.streamThreadEvents(ctx, request.ThreadId, userId, filter, pipeWriter); err != nil {
+		return nil, err
+	}
+
+	return StreamThreadEvents200TexteventStreamResponse{
+		Body: pipeReader,
+		Headers: StreamThreadEvents200ResponseHeaders{
+			CacheControl: "no-cache",
+			Connection:   "keep-alive",
+		},
+	}, nil
+}
+
+// streamThreadEvents subscribes to the events sent to the user and writes the events of a thread to w as Server-Sent
+// Events until the context is cancelled, then closes w. Unlike streamTaskRun it follows every task of the thread and
+// leaves their runs untouched. Only the event classes allowed by the filter are written, errors are always written.
+func (s *Server) streamThreadEvents(ctx context.Context, threadID, userID uuid.UUID, filter *service.StreamEventFilter, w io.WriteCloser) error {
+	events := make(chan *nats.Msg, 100)
+	lifecycleSubject := (&service.WebsocketTaskLifecycleEventMessage{}).SubjectWithUser(userID).String()
+	responseSubject := (&service.WebsocketResponseEventMessage{}).SubjectWithUser(userID).String()
+	var subs []*nats.Subscription
+	unsubscribe := func() {
+		for _, sub := range subs {
+			if err := sub.Unsubscribe(); err != nil {
+				s.log.Error("Failed to unsubscribe", "user_id", userID, "thread_id", threadID, "error", err)
+			}
+		}
+	}
+	for _, subject := range []string{lifecycleSubject, responseSubject} {
+		sub, err := s.nc.ChanSubscribe(subject, events)
+		if err != nil {
+			s.log.Error("Failed to subscribe to thread events", "subject", subject, "thread_id", threadID, "error", err)
+			unsubscribe()
+			w.Close()
+			return fmt.Errorf("failed to subscribe to thread events: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	go func() {
+		heartbeatTicker := time.NewTicker(30 * time.Second)
+		defer heartbeatTicker.Stop()
+		defer func() {
+			unsubscribe()
+			w.Close()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.log.Debug("Context cancelled, stopping thread event stream", "thread_id", threadID)
+				return
+			case <-heartbeatTicker.C:
+				heartbeatEvent := fmt.Sprintf("event: heartbeat\ndata: {\"type\":\"heartbeat\",\"timestamp\":\"%s\"}\n\n",
+					time.Now().UTC().Format(time.RFC3339))
+				if _, err := w.Write([]byte(heartbeatEvent)); err != nil {
+					s.log.Debug("Failed to write heartbeat event", "thread_id", threadID, "error", err)
+					return
+				}
+			case msg := <-events:
+				var event threadEvent
+				if err := json.Unmarshal(msg.Data, &event); err != nil || event.H == nil || event.H.ThreadID == nil || *event.H.ThreadID != threadID {
+					continue
+				}
+				// Skip the event classes the client did not subscribe to, errors are always written
+				if event.Err == nil {
+					if msg.Subject == lifecycleSubject && !filter.Allows(service.StreamEventClassLifecycle) {
+						continue
+					}
+					if msg.Subject == responseSubject {
+						var response service.WebsocketResponseEventMessage
+						if err := json.Unmarshal(event.Msg, &response); err == nil && !filter.AllowResponse(&response) {
+							continue
+						}
+					}
+				}
+				if _, err := w.Write([]byte(fmt.Sprintf("data: %s\n\n", msg.Data))); err != nil {
+					s.log.Debug("Failed to write thread event", "thread_id", threadID, "error", err)
+					return
+				}
+			}
+		}
+	}()
 
-	return UpdateThreadTitle200JSONResponse(thread), nil
+	return nil
 }
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	db "github.com/pinazu/internal/db"
+)
+
+// List tool revisions
+// (GET /v1/tools/{tool_id}/revisions)
+func (s *Server) ListToolRevisions(ctx context.Context, request ListToolRevisionsRequestObject) (ListToolRevisionsResponseObject, error) {
+	tool, _, err := s.toolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ListToolRevisions404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+		}
+		return nil, err
+	}
+	revisions, err := s.queries.ListToolRevisions(ctx, request.ToolId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tool revisions: %w", err)
+	}
+	return ListToolRevisions200JSONResponse{PromotedRevision: tool.Revision, Revisions: revisions}, nil
+}
+
+// Diff tool revision schemas
+// (GET /v1/tools/{tool_id}/revisions/diff)
+func (s *Server) DiffToolRevisions(ctx context.Context, request DiffToolRevisionsRequestObject) (DiffToolRevisionsResponseObject, error) {
+	tool, _, err := s.toolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return DiffToolRevisions404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+		}
+		return nil, err
+	}
+	toRevision := tool.Revision
+	if request.Params.To != nil {
+		toRevision = *request.Params.To
+	}
+
+	revisions := make([]db.ToolRevision, 0, 2)
+	for _, revision := range []int32{request.Params.From, toRevision} {
+		r, err := s.queries.GetToolRevision(ctx, db.GetToolRevisionParams{ToolID: request.ToolId, Revision: revision})
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return DiffToolRevisions404JSONResponse{
+					Message:  fmt.Sprintf("Revision %d of tool %s not found", revision, request.ToolId),
+					Resource: "ToolRevision",
+					Id:       request.ToolId,
+				}, nil
+			}
+			return nil, fmt.Errorf("failed to get tool revision: %w", err)
+		}
+		revisions = append(revisions, r)
+	}
+
+	changes := db.DiffToolSchemas(revisions[0].Config.GetParams(), revisions[1].Config.GetParams())
+	return DiffToolRevisions200JSONResponse{
+		ToolId:       request.ToolId,
+		FromRevision: request.Params.From,
+		ToRevision:   toRevision,
+		Breaking:     slices.ContainsFunc(changes, func(c db.ToolSchemaChange) bool { return c.Breaking }),
+		Changes:      changes,
+	}, nil
+}
+
+// Promote a tool revision
+// (POST /v1/tools/{tool_id}/revisions/{revision}/promote)
+func (s *Server) PromoteToolRevision(ctx context.Context, request PromoteToolRevisionRequestObject) (PromoteToolRevisionResponseObject, error) {
+	currentTool, access, err := s.toolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return PromoteToolRevision404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return PromoteToolRevision403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	tool, err := s.queries.PromoteToolRevision(ctx, db.PromoteToolRevisionParams{ToolID: request.ToolId, Revision: request.Revision})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return PromoteToolRevision404JSONResponse{
+				Message:  fmt.Sprintf("Revision %d of tool %s not found", request.Revision, request.ToolId),
+				Resource: "ToolRevision",
+				Id:       request.ToolId,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to promote tool revision: %w", err)
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionUpdate, currentTool, tool)
+	return PromoteToolRevision200JSONResponse(tool), nil
+}
@@ -1,74 +1,512 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	db "github.com/pinazu/internal/db"
 )
 
+// uncategorizedToolCategory groups catalog tools that have no category set
+const uncategorizedToolCategory = "uncategorized"
+
 // List all tools
 // (GET /v1/tools)
 func (s *Server) ListTools(ctx context.Context, request ListToolsRequestObject) (ListToolsResponseObject, error) {
-	tools, err := s.queries.ListTools(ctx)
+	sortField := string(ListToolsParamsSortCreatedAt)
+	if request.Params.Sort != nil {
+		sortField = string(*request.Params.Sort)
+	}
+	sort := newListSort(sortField, request.Params.Order)
+
+	params := db.SearchToolsParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		Category:    optionalText(request.Params.Category),
+		Tag:         optionalText(request.Params.Tag),
+		Search:      optionalText(request.Params.Search),
+		CreatedBy:   optionalUUID(request.Params.CreatedBy),
+		Deleted:     listTrash(request.Params.Deleted),
+		UserID:      custom_middleware.RequestUserID(ctx),
+		Sort:        sort.Field,
+		Descending:  sort.Descending,
+	}
+	if request.Params.Type != nil {
+		params.Type = pgtype.Text{String: string(*request.Params.Type), Valid: true}
+	}
+	if request.Params.Status != nil {
+		params.Status = pgtype.Text{String: string(*request.Params.Status), Valid: true}
+	}
+	var err error
+	if params.CreatedAfter, params.CreatedBefore, err = createdRange(request.Params.CreatedAfter, request.Params.CreatedBefore); err != nil {
+		return ListTools400JSONResponse{Message: err.Error()}, nil
+	}
+
+	// A cursor is a position in the list of a sort and order
+	limit, cursor, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err == nil {
+		err = sort.check(cursor)
+	}
+	if err != nil {
+		return ListTools400JSONResponse{Message: err.Error()}, nil
+	}
+	if cursor != nil {
+		id, err := cursor.uuidID()
+		if err != nil {
+			return ListTools400JSONResponse{Message: err.Error()}, nil
+		}
+		params.CursorID = pgtype.UUID{Bytes: id, Valid: true}
+		params.CursorText = cursor.textKey()
+		if sort.Field == string(ListToolsParamsSortCreatedAt) || sort.Field == string(ListToolsParamsSortUpdatedAt) {
+			if params.CursorTime, err = cursor.timeKey(); err != nil {
+				return ListTools400JSONResponse{Message: err.Error()}, nil
+			}
+		}
+	}
+	params.RowLimit = limit + 1
+
+	tools, err := s.queries.SearchTools(ctx, params)
 	if err != nil {
 		return nil, err
 	}
+	total, err := s.queries.CountSearchTools(ctx, db.CountSearchToolsParams{
+		WorkspaceID:   custom_middleware.RequestWorkspaceID(ctx),
+		Category:      params.Category,
+		Tag:           params.Tag,
+		Search:        params.Search,
+		Type:          params.Type,
+		Status:        params.Status,
+		CreatedBy:     params.CreatedBy,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+		Deleted:       params.Deleted,
+		UserID:        params.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tools: %w", err)
+	}
+
+	tools, hasMore, next := pageRows(tools, limit, func(tool db.Tool) pageCursor {
+		c := pageCursor{Sort: sort.String(), ID: tool.ID.String()}
+		switch sort.Field {
+		case string(ListToolsParamsSortName):
+			c.Text = &tool.Name
+		case string(ListToolsParamsSortCategory):
+			if tool.Category.Valid {
+				c.Text = &tool.Category.String
+			}
+		case string(ListToolsParamsSortUpdatedAt):
+			c.Time = &tool.UpdatedAt.Time
+		default:
+			c.Time = &tool.CreatedAt.Time
+		}
+		return c
+	})
 	return ListTools200JSONResponse{
-		Tools: tools,
+		Tools:      tools,
+		Limit:      limit,
+		HasMore:    hasMore,
+		NextCursor: next,
+		Total:      int(total),
+	}, nil
+}
+
+// Get the tool catalog
+// (GET /v1/tools/catalog)
+func (s *Server) GetToolCatalog(ctx context.Context, request GetToolCatalogRequestObject) (GetToolCatalogResponseObject, error) {
+	tools, err := s.queries.ListToolCatalog(ctx, db.ListToolCatalogParams{
+		WorkspaceID: custom_middleware.RequestWorkspaceID(ctx),
+		Tag:         optionalText(request.Params.Tag),
+		Search:      optionalText(request.Params.Search),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Tools are ordered by category, the tools without a category as uncategorized, so consecutive tools with the same
+	// category form a group
+	categories := []ToolCatalogCategory{}
+	for _, tool := range tools {
+		name := uncategorizedToolCategory
+		if tool.Category.Valid && tool.Category.String != "" {
+			name = tool.Category.String
+		}
+		if len(categories) == 0 || categories[len(categories)-1].Name != name {
+			categories = append(categories, ToolCatalogCategory{Name: name, Tools: []Tool{}})
+		}
+		last := &categories[len(categories)-1]
+		last.Tools = append(last.Tools, tool)
+		last.Count++
+	}
+
+	return GetToolCatalog200JSONResponse{
+		Categories: categories,
+		Total:      len(tools),
 	}, nil
 }
 
 // Create a new tool
 // (POST /v1/tools)
 func (s *Server) CreateTool(ctx context.Context, request CreateToolRequestObject) (CreateToolResponseObject, error) {
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	createdBy, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		return nil, fmt.Errorf("invalid default UUID format: %v", err)
-	}
+	createdBy := custom_middleware.RequestUserID(ctx)
 
 	if request.Body == nil {
 		return CreateTool400JSONResponse{Message: "body is required"}, nil
 	}
+	createToolParams, err := newToolParams(*request.Body, custom_middleware.RequestWorkspaceID(ctx), createdBy)
+	if err != nil {
+		return CreateTool400JSONResponse{Message: err.Error()}, nil
+	}
 
-	if request.Body.Name == "" {
-		return CreateTool400JSONResponse{Message: "name is required"}, nil
+	tool, err := insertTool(ctx, s.queries, createToolParams)
+	if err != nil {
+		return nil, err
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionCreate, nil, tool)
+	return CreateTool201JSONResponse(tool), nil
+}
+
+// newToolParams validates a tool creation request and returns the parameters of the tool
+func newToolParams(body CreateToolRequest, workspaceID, createdBy uuid.UUID) (db.CreateToolParams, error) {
+	if body.Name == "" {
+		return db.CreateToolParams{}, errors.New("name is required")
+	}
+	// Built-in tools are matched by name in the model requests, no other tool can take their name
+	if err := db.ValidateToolName(body.Name); err != nil {
+		return db.CreateToolParams{}, err
 	}
 
 	// Try to parse the tool type from the union
 	// Validate the inner configuration
-	if err := request.Body.Config.Validate(); err != nil {
-		return CreateTool400JSONResponse{Message: err.Error()}, nil
+	if err := body.Config.Validate(); err != nil {
+		return db.CreateToolParams{}, err
 	}
 
 	// Create the base tool
-	createToolParams := db.CreateToolParams{
-		Name:        request.Body.Name,
+	params := db.CreateToolParams{
+		Name:        body.Name,
 		Description: pgtype.Text{Valid: false},
-		Config:      request.Body.Config,
+		Config:      body.Config,
 		CreatedBy:   createdBy,
+		WorkspaceID: db.WorkspaceUUID(workspaceID),
+	}
+
+	if body.Description != nil && body.Description.Valid {
+		params.Description = *body.Description
+	}
+	if body.Category != nil {
+		params.Category = *body.Category
+	}
+	if body.Icon != nil {
+		params.Icon = *body.Icon
+	}
+	if body.Tags != nil {
+		params.Tags = *body.Tags
+	}
+	if body.Documentation != nil {
+		params.Documentation = *body.Documentation
+	}
+	if body.Examples != nil {
+		examples, err := toolExamplesJSON(*body.Examples)
+		if err != nil {
+			return db.CreateToolParams{}, err
+		}
+		params.Examples = examples
+	}
+	return params, nil
+}
+
+// toolExamplesJSON returns the JSON of the examples of a tool request
+func toolExamplesJSON(examples []ToolExample) (db.JsonRaw, error) {
+	raw, err := db.NewJsonRaw(examples)
+	if err != nil {
+		return nil, fmt.Errorf("invalid examples: %v", err)
+	}
+	return raw, nil
+}
+
+// insertTool creates a tool and its first revision
+func insertTool(ctx context.Context, queries *db.Queries, params db.CreateToolParams) (db.Tool, error) {
+	tool, err := queries.CreateTool(ctx, params)
+	if err != nil {
+		return db.Tool{}, err
+	}
+	// The initial description and configuration are the first revision of the tool
+	tool, err = queries.CreateToolRevision(ctx, db.CreateToolRevisionParams{ToolID: tool.ID, CreatedBy: params.CreatedBy})
+	if err != nil {
+		return db.Tool{}, fmt.Errorf("failed to create tool revision: %w", err)
+	}
+	return tool, nil
+}
+
+// Create a tool from a function definition
+// (POST /v1/tools/definitions)
+func (s *Server) CreateToolFromDefinition(ctx context.Context, request CreateToolFromDefinitionRequestObject) (CreateToolFromDefinitionResponseObject, error) {
+	createdBy := custom_middleware.RequestUserID(ctx)
+
+	if request.Body == nil {
+		return CreateToolFromDefinition400JSONResponse{Message: "body is required"}, nil
+	}
+	if len(request.Body.Definition) == 0 {
+		return CreateToolFromDefinition400JSONResponse{Message: "definition is required"}, nil
+	}
+	var format db.ToolDefinitionFormat
+	if request.Body.Format != nil {
+		format = db.ToolDefinitionFormat(*request.Body.Format)
+	}
+	def, err := db.ParseToolDefinition(request.Body.Definition, format)
+	if err != nil {
+		return CreateToolFromDefinition400JSONResponse{Message: err.Error()}, nil
+	}
+
+	body := CreateToolRequest{
+		Name:     def.Name,
+		Config:   request.Body.Config,
+		Category: request.Body.Category,
+		Tags:     request.Body.Tags,
+	}
+	if request.Body.Name != nil && *request.Body.Name != "" {
+		body.Name = *request.Body.Name
+	}
+	if request.Body.Description != nil && *request.Body.Description != "" {
+		body.Description = &pgtype.Text{String: *request.Body.Description, Valid: true}
+	} else if def.Description != "" {
+		body.Description = &pgtype.Text{String: def.Description, Valid: true}
 	}
 
-	if request.Body.Description != nil && request.Body.Description.Valid {
-		createToolParams.Description = *request.Body.Description
+	// The parameters of the definition replace the ones of the configuration
+	switch c := body.Config.C.(type) {
+	case *db.ToolConfigStandalone:
+		c.Params = def.Params
+	case *db.ToolConfigWorkflow:
+		c.Params = def.Params
+	case *db.ToolConfigMCP:
+		c.Params = def.Params
+		// The tool keeps calling the MCP server under the name of the descriptor
+		if c.ToolName == "" && body.Name != def.Name {
+			c.ToolName = def.Name
+		}
+	default:
+		return CreateToolFromDefinition400JSONResponse{Message: "config must be a standalone, workflow or mcp tool"}, nil
+	}
+	createToolParams, err := newToolParams(body, custom_middleware.RequestWorkspaceID(ctx), createdBy)
+	if err != nil {
+		return CreateToolFromDefinition400JSONResponse{Message: err.Error()}, nil
 	}
 
-	tool, err := s.queries.CreateTool(ctx, createToolParams)
+	tool, err := insertTool(ctx, s.queries, createToolParams)
 	if err != nil {
 		return nil, err
 	}
-	return CreateTool201JSONResponse(tool), nil
+	s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionCreate, nil, tool)
+	return CreateToolFromDefinition201JSONResponse(tool), nil
+}
+
+// Export tools as a bundle
+// (GET /v1/tools/export)
+func (s *Server) ExportTools(ctx context.Context, request ExportToolsRequestObject) (ExportToolsResponseObject, error) {
+	var ids []uuid.UUID
+	if request.Params.ToolId != nil {
+		ids = *request.Params.ToolId
+	}
+	var names []string
+	if request.Params.Name != nil {
+		names = *request.Params.Name
+	}
+	tools, missing, err := s.queries.ToolsForBundle(ctx, custom_middleware.RequestWorkspaceID(ctx), custom_middleware.RequestUserID(ctx), ids, names)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		return ExportTools404JSONResponse{
+			Message:  fmt.Sprintf("Tools not found: %s", strings.Join(missing, ", ")),
+			Resource: "Tool",
+		}, nil
+	}
+
+	bundle := db.NewToolBundle(tools)
+	if request.Params.Format == nil || *request.Params.Format == ExportToolsParamsFormatJson {
+		return ExportTools200JSONResponse(bundle), nil
+	}
+	data, err := db.EncodeToolBundle(bundle, db.ToolBundleFormatYAML)
+	if err != nil {
+		return nil, err
+	}
+	return ExportTools200ApplicationyamlResponse{Body: bytes.NewReader(data), ContentLength: int64(len(data))}, nil
+}
+
+// Import a tool bundle
+// (POST /v1/tools/import)
+func (s *Server) ImportTools(ctx context.Context, request ImportToolsRequestObject) (ImportToolsResponseObject, error) {
+	createdBy := custom_middleware.RequestUserID(ctx)
+
+	if request.Body == nil {
+		return ImportTools400JSONResponse{Message: "body is required"}, nil
+	}
+	onConflict := db.ToolBundleConflictSkip
+	if request.Params.OnConflict != nil {
+		onConflict = db.ToolBundleConflict(*request.Params.OnConflict)
+	}
+	if !onConflict.Valid() {
+		return ImportTools400JSONResponse{Message: fmt.Sprintf("invalid on_conflict %q, must be create, update or skip", onConflict)}, nil
+	}
+	if err := request.Body.Validate(); err != nil {
+		return ImportTools400JSONResponse{Message: err.Error()}, nil
+	}
+
+	results, err := s.queries.ImportToolBundle(ctx, custom_middleware.RequestWorkspaceID(ctx), *request.Body, onConflict, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		switch result.Action {
+		case db.ToolBundleImportActionCreated:
+			s.recordResourceChange(ctx, db.ResourceTypeTool, *result.ToolID, db.ResourceChangeActionCreate, nil, *result.After)
+		case db.ToolBundleImportActionUpdated:
+			s.recordResourceChange(ctx, db.ResourceTypeTool, *result.ToolID, db.ResourceChangeActionUpdate, *result.Before, *result.After)
+		}
+	}
+	return ImportTools200JSONResponse{Results: results}, nil
+}
+
+// Create tools in a batch
+// (POST /v1/tools/batch-create)
+func (s *Server) BatchCreateTools(ctx context.Context, request BatchCreateToolsRequestObject) (BatchCreateToolsResponseObject, error) {
+	createdBy := custom_middleware.RequestUserID(ctx)
+
+	if request.Body == nil {
+		return BatchCreateTools400JSONResponse{Message: "body is required"}, nil
+	}
+	if message := batchSizeError(len(request.Body.Tools), maxBatchOperations); message != "" {
+		return BatchCreateTools400JSONResponse{Message: message}, nil
+	}
+
+	created := make([]db.Tool, 0, len(request.Body.Tools))
+	result, err := s.runBatch(ctx, len(request.Body.Tools), func(queries *db.Queries, index int) (BatchItemResult, error) {
+		params, err := newToolParams(request.Body.Tools[index], custom_middleware.RequestWorkspaceID(ctx), createdBy)
+		if err != nil {
+			return BatchItemResult{}, itemFailed("%s", err)
+		}
+		tool, err := insertTool(ctx, queries, params)
+		if err != nil {
+			return BatchItemResult{}, err
+		}
+		created = append(created, tool)
+		return BatchItemResult{Id: &tool.ID, Status: Created}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tools: %w", err)
+	}
+	if result.Committed {
+		for _, tool := range created {
+			s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionCreate, nil, tool)
+		}
+	}
+	return BatchCreateTools200JSONResponse(result), nil
+}
+
+// Update tools in a batch
+// (POST /v1/tools/batch-update)
+func (s *Server) BatchUpdateTools(ctx context.Context, request BatchUpdateToolsRequestObject) (BatchUpdateToolsResponseObject, error) {
+	updatedBy := custom_middleware.RequestUserID(ctx)
+
+	if request.Body == nil {
+		return BatchUpdateTools400JSONResponse{Message: "body is required"}, nil
+	}
+	if message := batchSizeError(len(request.Body.Tools), maxBatchOperations); message != "" {
+		return BatchUpdateTools400JSONResponse{Message: message}, nil
+	}
+
+	type toolUpdate struct{ before, after db.Tool }
+	updated := make([]toolUpdate, 0, len(request.Body.Tools))
+	result, err := s.runBatch(ctx, len(request.Body.Tools), func(queries *db.Queries, index int) (BatchItemResult, error) {
+		update := request.Body.Tools[index]
+		current, err := queries.GetToolById(ctx, db.GetToolByIdParams{ID: update.Id, WorkspaceID: custom_middleware.RequestWorkspaceID(ctx)})
+		if err == pgx.ErrNoRows {
+			return BatchItemResult{}, itemFailed("tool %s not found", update.Id)
+		}
+		if err != nil {
+			return BatchItemResult{}, fmt.Errorf("failed to get tool: %w", err)
+		}
+		if err := checkBatchToolAccess(ctx, queries, current); err != nil {
+			return BatchItemResult{}, err
+		}
+		if sharedToolLocked(ctx, current) {
+			return BatchItemResult{}, itemFailed("tool %s is shared by every workspace, it can only be changed from the default workspace", update.Id)
+		}
+		tool, err := saveToolUpdate(ctx, queries, current, update.Changes, updatedBy, pgtype.Timestamptz{})
+		if err != nil {
+			return BatchItemResult{}, err
+		}
+		updated = append(updated, toolUpdate{before: current, after: tool})
+		return BatchItemResult{Id: &tool.ID, Status: Updated}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tools: %w", err)
+	}
+	if result.Committed {
+		for _, update := range updated {
+			s.recordResourceChange(ctx, db.ResourceTypeTool, update.after.ID, db.ResourceChangeActionUpdate, update.before, update.after)
+		}
+	}
+	return BatchUpdateTools200JSONResponse(result), nil
+}
+
+// Delete tools in a batch
+// (POST /v1/tools/batch-delete)
+func (s *Server) BatchDeleteTools(ctx context.Context, request BatchDeleteToolsRequestObject) (BatchDeleteToolsResponseObject, error) {
+	if request.Body == nil {
+		return BatchDeleteTools400JSONResponse{Message: "body is required"}, nil
+	}
+	if message := batchSizeError(len(request.Body.Ids), maxBatchOperations); message != "" {
+		return BatchDeleteTools400JSONResponse{Message: message}, nil
+	}
+
+	deleted := make([]db.Tool, 0, len(request.Body.Ids))
+	result, err := s.runBatch(ctx, len(request.Body.Ids), func(queries *db.Queries, index int) (BatchItemResult, error) {
+		id := request.Body.Ids[index]
+		tool, err := queries.GetToolById(ctx, db.GetToolByIdParams{ID: id, WorkspaceID: custom_middleware.RequestWorkspaceID(ctx)})
+		if err == pgx.ErrNoRows {
+			return BatchItemResult{}, itemFailed("tool %s not found", id)
+		}
+		if err != nil {
+			return BatchItemResult{}, fmt.Errorf("failed to get tool: %w", err)
+		}
+		if err := checkBatchToolAccess(ctx, queries, tool); err != nil {
+			return BatchItemResult{}, err
+		}
+		if !tool.WorkspaceID.Valid {
+			return BatchItemResult{}, itemFailed("tool %s is shared by every workspace and cannot be deleted", id)
+		}
+		if _, err := queries.SoftDeleteTool(ctx, db.SoftDeleteToolParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: id}); err != nil {
+			return BatchItemResult{}, fmt.Errorf("failed to delete tool: %w", err)
+		}
+		deleted = append(deleted, tool)
+		return BatchItemResult{Id: &tool.ID, Status: Deleted}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete tools: %w", err)
+	}
+	if result.Committed {
+		for _, tool := range deleted {
+			s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionDelete, tool, nil)
+		}
+	}
+	return BatchDeleteTools200JSONResponse(result), nil
 }
 
 // Delete a tool
 // (DELETE /v1/tools/{tool_id})
 func (s *Server) DeleteTool(ctx context.Context, request DeleteToolRequestObject) (DeleteToolResponseObject, error) {
 	// Check if the tool exists
-	_, err := s.queries.GetToolById(ctx, request.ToolId)
+	tool, access, err := s.toolAccess(ctx, request.ToolId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return DeleteTool404JSONResponse{
@@ -79,18 +517,104 @@ func (s *Server) DeleteTool(ctx context.Context, request DeleteToolRequestObject
 		}
 		return nil, err
 	}
-	err = s.queries.DeleteTool(ctx, request.ToolId)
+	if !access.Allows(db.GrantAccessManage) {
+		return DeleteTool403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	deleted, err := s.queries.SoftDeleteTool(ctx, db.SoftDeleteToolParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.ToolId})
 	if err != nil {
 		return nil, err
 	}
+	if deleted == 0 {
+		return DeleteTool404JSONResponse{
+			Message:  "Tool not found",
+			Resource: "Tool",
+			Id:       request.ToolId,
+		}, nil
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionDelete, tool, nil)
 
 	return DeleteTool204Response{}, nil
 }
 
+// Restore a tool
+// (POST /v1/tools/{tool_id}/restore)
+func (s *Server) RestoreTool(ctx context.Context, request RestoreToolRequestObject) (RestoreToolResponseObject, error) {
+	access, err := s.trashedToolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RestoreTool404JSONResponse{
+				Message:  "Tool not found in the trash",
+				Resource: "Tool",
+				Id:       request.ToolId,
+			}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return RestoreTool403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	tool, err := s.queries.RestoreTool(ctx, db.RestoreToolParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.ToolId})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RestoreTool404JSONResponse{
+				Message:  "Tool not found in the trash",
+				Resource: "Tool",
+				Id:       request.ToolId,
+			}, nil
+		}
+		if db.IsConflictError(err) {
+			return RestoreTool409JSONResponse{
+				Message:  "Another tool took the name of the tool",
+				Resource: "Tool",
+				Id:       request.ToolId,
+			}, nil
+		}
+		return nil, err
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionRestore, nil, tool)
+
+	return RestoreTool200JSONResponse(tool), nil
+}
+
+// Purge a tool
+// (POST /v1/tools/{tool_id}/purge)
+func (s *Server) PurgeTool(ctx context.Context, request PurgeToolRequestObject) (PurgeToolResponseObject, error) {
+	access, err := s.trashedToolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return PurgeTool404JSONResponse{
+				Message:  "Tool not found in the trash",
+				Resource: "Tool",
+				Id:       request.ToolId,
+			}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return PurgeTool403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	purged, err := s.queries.PurgeTool(ctx, db.PurgeToolParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: request.ToolId})
+	if err != nil {
+		return nil, err
+	}
+	if purged == 0 {
+		return PurgeTool404JSONResponse{
+			Message:  "Tool not found in the trash",
+			Resource: "Tool",
+			Id:       request.ToolId,
+		}, nil
+	}
+	if err := s.queries.DeleteResourceGrants(ctx, db.DeleteResourceGrantsParams{ResourceType: db.ResourceTypeTool, ResourceID: request.ToolId}); err != nil {
+		return nil, fmt.Errorf("failed to delete tool grants: %w", err)
+	}
+
+	return PurgeTool204Response{}, nil
+}
+
 // Get a tool by ID
 // (GET /v1/tools/{tool_id})
 func (s *Server) GetToolById(ctx context.Context, request GetToolByIdRequestObject) (GetToolByIdResponseObject, error) {
-	tool, err := s.queries.GetToolById(ctx, request.ToolId)
+	tool, _, err := s.toolAccess(ctx, request.ToolId)
 	if err != nil {
 		// Check if the error is a not found error
 		if err == pgx.ErrNoRows {
@@ -102,7 +626,59 @@ func (s *Server) GetToolById(ctx context.Context, request GetToolByIdRequestObje
 		}
 		return nil, err
 	}
-	return GetToolById200JSONResponse(tool), nil
+	return GetToolById200JSONResponse{Body: tool, Headers: GetToolById200ResponseHeaders{ETag: db.ETag(tool.UpdatedAt)}}, nil
+}
+
+// Test a tool
+// (POST /v1/tools/{tool_id}/test)
+func (s *Server) TestTool(ctx context.Context, request TestToolRequestObject) (TestToolResponseObject, error) {
+	tool, access, err := s.toolAccess(ctx, request.ToolId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return TestTool404JSONResponse{Message: "Tool not found", Resource: "Tool", Id: request.ToolId}, nil
+		}
+		return nil, err
+	}
+	if !access.Allows(db.GrantAccessInvoke) {
+		return TestTool403JSONResponse{Message: accessMessage("Tool", db.GrantAccessInvoke)}, nil
+	}
+	if request.Body.Revision != nil && *request.Body.Revision != tool.Revision {
+		revision, err := s.queries.GetToolRevision(ctx, db.GetToolRevisionParams{ToolID: request.ToolId, Revision: *request.Body.Revision})
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return TestTool404JSONResponse{
+					Message:  fmt.Sprintf("Revision %d of tool %s not found", *request.Body.Revision, request.ToolId),
+					Resource: "ToolRevision",
+					Id:       request.ToolId,
+				}, nil
+			}
+			return nil, fmt.Errorf("failed to get tool revision: %w", err)
+		}
+		tool = tool.ApplyRevision(revision)
+	}
+
+	response := TestToolResponse{
+		ToolId:     tool.ID,
+		Revision:   tool.Revision,
+		Violations: db.ValidateToolInput(tool.Config.GetParams(), request.Body.Input),
+	}
+	if response.Violations == nil {
+		response.Violations = []db.ToolInputViolation{}
+	}
+	response.Valid = len(response.Violations) == 0
+	if !response.Valid {
+		// The agent would receive the violations as an error result
+		response.IsError = true
+		return TestTool200JSONResponse(response), nil
+	}
+
+	mock := tool.MockResponse()
+	if request.Body.Mock != nil {
+		mock = *request.Body.Mock
+	}
+	response.Result = mock.Response
+	response.IsError = mock.IsError
+	return TestTool200JSONResponse(response), nil
 }
 
 // Update a tool
@@ -111,36 +687,138 @@ func (s *Server) UpdateTool(ctx context.Context, request UpdateToolRequestObject
 	if request.Body == nil {
 		return UpdateTool404JSONResponse{}, nil
 	}
+	if request.Body.Examples != nil {
+		if _, err := toolExamplesJSON(*request.Body.Examples); err != nil {
+			return UpdateTool400JSONResponse{Message: err.Error()}, nil
+		}
+	}
 
 	// Get current tool to preserve existing values for optional fields
-	currentToolRow, err := s.queries.GetToolById(ctx, request.ToolId)
+	currentToolRow, access, err := s.toolAccess(ctx, request.ToolId)
 	if err != nil {
 		return UpdateTool404JSONResponse{}, nil
 	}
+	if !access.Allows(db.GrantAccessManage) {
+		return UpdateTool403JSONResponse{Message: accessMessage("Tool", db.GrantAccessManage)}, nil
+	}
+	if sharedToolLocked(ctx, currentToolRow) {
+		return UpdateTool404JSONResponse{
+			Message:  "Tool is shared by every workspace, it can only be changed from the default workspace",
+			Resource: "Tool",
+			Id:       request.ToolId,
+		}, nil
+	}
 
+	ifUpdatedAt, ok := db.MatchETag(aws.ToString(request.Params.IfMatch), currentToolRow.UpdatedAt)
+	if !ok {
+		return UpdateTool412JSONResponse(preconditionFailed("Tool", request.ToolId, currentToolRow.UpdatedAt)), nil
+	}
+
+	tool, err := saveToolUpdate(ctx, s.queries, currentToolRow, *request.Body, custom_middleware.RequestUserID(ctx), ifUpdatedAt)
+	if err != nil {
+		// Another request updated the tool since it was read
+		if err == pgx.ErrNoRows && ifUpdatedAt.Valid {
+			return UpdateTool412JSONResponse(preconditionFailed("Tool", request.ToolId, pgtype.Timestamptz{})), nil
+		}
+		return nil, err
+	}
+	s.recordResourceChange(ctx, db.ResourceTypeTool, tool.ID, db.ResourceChangeActionUpdate, currentToolRow, tool)
+
+	return UpdateTool200JSONResponse{Body: tool, Headers: UpdateTool200ResponseHeaders{ETag: db.ETag(tool.UpdatedAt)}}, nil
+}
+
+// checkBatchToolAccess fails an operation of a batch on a tool the user of the request does not manage
+func checkBatchToolAccess(ctx context.Context, queries *db.Queries, tool db.Tool) error {
+	access, err := queries.ResourceAccess(ctx, db.ResourceTypeTool, tool.ID, tool.CreatedBy, custom_middleware.RequestUserID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get tool access: %w", err)
+	}
+	if !access.Valid() {
+		return itemFailed("tool %s not found", tool.ID)
+	}
+	if !access.Allows(db.GrantAccessManage) {
+		return itemFailed("tool %s requires the %s access", tool.ID, db.GrantAccessManage)
+	}
+	return nil
+}
+
+// sharedToolLocked tells whether a tool is shared by every workspace and cannot be changed from the workspace of the
+// request, the shared tools are only changed from the default workspace
+func sharedToolLocked(ctx context.Context, tool db.Tool) bool {
+	return !tool.WorkspaceID.Valid && custom_middleware.RequestWorkspaceID(ctx) != db.DefaultWorkspaceID
+}
+
+// saveToolUpdate applies the changes of a request to a tool, the fields unset in the request keep their current values.
+// The tool is only updated while its update time is ifUpdatedAt when it is set, pgx.ErrNoRows is returned otherwise.
+func saveToolUpdate(ctx context.Context, queries *db.Queries, current db.Tool, changes UpdateToolRequest, updatedBy uuid.UUID, ifUpdatedAt pgtype.Timestamptz) (db.Tool, error) {
 	// Start with current values
 	params := db.UpdateToolParams{
-		ID:          request.ToolId,
-		Description: currentToolRow.Description,
-		Config:      currentToolRow.Config,
+		IfUpdatedAt:   ifUpdatedAt,
+		ID:            current.ID,
+		WorkspaceID:   current.WorkspaceID,
+		Description:   current.Description,
+		Config:        current.Config,
+		Category:      current.Category,
+		Icon:          current.Icon,
+		Tags:          current.Tags,
+		Examples:      current.Examples,
+		Documentation: current.Documentation,
 	}
 
 	// Update only provided fields
-	if request.Body.Description != nil {
-		params.Description = *request.Body.Description
+	if changes.Description != nil {
+		params.Description = *changes.Description
 	}
 
 	// Handle tool configuration updates if provided
-	if request.Body.Config != nil {
+	if changes.Config != nil {
 		// Try to parse the tool type from the union and update configuration
-		params.Config = *request.Body.Config
+		params.Config = *changes.Config
+		// Secrets are redacted in the responses, sending one back unchanged keeps the stored value
+		params.Config.RestoreRedactedSecrets(current.Config)
+	}
+
+	// Update catalog metadata if provided
+	if changes.Category != nil {
+		params.Category = *changes.Category
+	}
+	if changes.Icon != nil {
+		params.Icon = *changes.Icon
+	}
+	if changes.Tags != nil {
+		params.Tags = *changes.Tags
+	}
+	if changes.Documentation != nil {
+		params.Documentation = *changes.Documentation
+	}
+	if changes.Examples != nil {
+		examples, err := toolExamplesJSON(*changes.Examples)
+		if err != nil {
+			return db.Tool{}, itemFailed("%v", err)
+		}
+		params.Examples = examples
 	}
 
 	// Update the base tool
-	tool, err := s.queries.UpdateTool(ctx, params)
+	tool, err := queries.UpdateTool(ctx, params)
 	if err != nil {
-		return nil, err
+		return db.Tool{}, err
 	}
 
-	return UpdateTool200JSONResponse(tool), nil
+	// Changing what the agents see of the tool creates a new revision, catalog metadata is not revisioned
+	if db.ToolRevisionChanged(current, tool) {
+		tool, err = queries.CreateToolRevision(ctx, db.CreateToolRevisionParams{ToolID: tool.ID, CreatedBy: updatedBy})
+		if err != nil {
+			return db.Tool{}, fmt.Errorf("failed to create tool revision: %w", err)
+		}
+	}
+	return tool, nil
+}
+
+// optionalText converts an optional query parameter into a nullable text argument
+func optionalText(v *string) pgtype.Text {
+	if v == nil || *v == "" {
+		return pgtype.Text{Valid: false}
+	}
+	return pgtype.Text{String: *v, Valid: true}
 }
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+// Get the usage of the quotas
+// (GET /v1/usage)
+func (s *Server) GetUsage(ctx context.Context, request GetUsageRequestObject) (GetUsageResponseObject, error) {
+	userID := custom_middleware.RequestUserID(ctx)
+	workspaceID := custom_middleware.RequestWorkspaceID(ctx)
+	statuses, err := s.queries.QuotaStatuses(ctx, s.quotas, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return GetUsage200JSONResponse(UsageReport{UserId: userID, WorkspaceId: workspaceID, Quotas: statuses}), nil
+}
+
+// checkQuotas returns the body of the 429 response when the user or the workspace of the request exhausted one of the
+// checked quotas, nil when the request is within the quotas
+func (s *Server) checkQuotas(ctx context.Context, checked ...db.Quota) (*QuotaExceeded, error) {
+	err := s.queries.CheckQuotas(ctx, s.quotas, custom_middleware.RequestWorkspaceID(ctx), custom_middleware.RequestUserID(ctx), checked...)
+	var quotaErr *db.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		status := quotaErr.Status
+		return &QuotaExceeded{
+			Message:  quotaErr.Error(),
+			Quota:    &status.Quota,
+			Scope:    &status.Scope,
+			Used:     &status.Used,
+			Limit:    status.Limit,
+			ResetsAt: status.ResetsAt,
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check quotas: %w", err)
+	}
+	return nil, nil
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
 	db "github.com/pinazu/internal/db"
 )
 
@@ -221,17 +223,12 @@ func (s Server) AddRoleToUser(ctx context.Context, request AddRoleToUserRequestO
 		return nil, err
 	}
 
-	// TODO: should be replaced with the actual user ID from the context or authentication system
 	var assignedBy uuid.UUID
 	if request.Body.AssignedBy != nil {
 		assignedBy = *request.Body.AssignedBy
 	} else {
-		// Default assigned_by if not provided
-		var err error
-		assignedBy, err = uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-		if err != nil {
-			return AddRoleToUser400JSONResponse{Message: "assigned_by is required"}, nil
-		}
+		// Default assigned_by to the user of the request
+		assignedBy = custom_middleware.RequestUserID(ctx)
 	}
 
 	params := db.AddRoleToUserParams{
@@ -314,3 +311,38 @@ func (s *Server) DeleteUser(ctx context.Context, request DeleteUserRequestObject
 
 	return DeleteUser204Response{}, nil
 }
+
+// Set the default agent of a user
+// (PUT /v1/users/{user_id}/default-agent)
+func (s *Server) SetUserDefaultAgent(ctx context.Context, request SetUserDefaultAgentRequestObject) (SetUserDefaultAgentResponseObject, error) {
+	_, err := s.queries.GetUserByID(ctx, request.UserId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SetUserDefaultAgent404JSONResponse{Message: "User not found", Resource: USER_RESOURCE, Id: request.UserId}, nil
+		}
+		return nil, err
+	}
+
+	// A null agent falls back to the workspace default agent
+	defaultAgentID := pgtype.UUID{}
+	if request.Body.AgentId != nil {
+		_, err := s.queries.GetAgentByID(ctx, db.GetAgentByIDParams{WorkspaceID: custom_middleware.RequestWorkspaceID(ctx), ID: *request.Body.AgentId})
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return SetUserDefaultAgent404JSONResponse{Message: "Agent not found", Resource: AGENT_RESOURCE, Id: *request.Body.AgentId}, nil
+			}
+			return nil, err
+		}
+		defaultAgentID = pgtype.UUID{Bytes: *request.Body.AgentId, Valid: true}
+	}
+
+	err = s.queries.SetUserDefaultAgent(ctx, db.SetUserDefaultAgentParams{
+		DefaultAgentID: defaultAgentID,
+		ID:             request.UserId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set default agent: %w", err)
+	}
+
+	return SetUserDefaultAgent200JSONResponse{UserId: request.UserId, AgentId: request.Body.AgentId}, nil
+}
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+const (
+	WEBHOOK_RESOURCE          = "Webhook"
+	WEBHOOK_DELIVERY_RESOURCE = "WebhookDelivery"
+)
+
+// List webhooks
+// (GET /v1/webhooks)
+func (s *Server) ListWebhooks(ctx context.Context, request ListWebhooksRequestObject) (ListWebhooksResponseObject, error) {
+	webhooks, err := s.queries.ListWebhooksByUser(ctx, custom_middleware.RequestUserID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	list := make([]Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		list = append(list, toWebhook(webhook))
+	}
+	return ListWebhooks200JSONResponse(WebhookList{Webhooks: list}), nil
+}
+
+// Create a webhook
+// (POST /v1/webhooks)
+func (s *Server) CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error) {
+	url := strings.TrimSpace(request.Body.Url)
+	if err := db.ValidateWebhookURL(url); err != nil {
+		return CreateWebhook400JSONResponse{Message: err.Error()}, nil
+	}
+	events, err := webhookEvents(request.Body.Events)
+	if err != nil {
+		return CreateWebhook400JSONResponse{Message: err.Error()}, nil
+	}
+	enabled := true
+	if request.Body.Enabled != nil {
+		enabled = *request.Body.Enabled
+	}
+
+	secret, encrypted, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := s.queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		UserID:      custom_middleware.RequestUserID(ctx),
+		Url:         url,
+		Events:      events,
+		Secret:      encrypted,
+		Description: optionalText(request.Body.Description),
+		Enabled:     enabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	s.log.Info("Created webhook", "webhook_id", webhook.ID, "user_id", webhook.UserID, "events", webhook.Events)
+	return CreateWebhook201JSONResponse(CreatedWebhook{Webhook: toWebhook(webhook), Secret: secret}), nil
+}
+
+// Get webhook by ID
+// (GET /v1/webhooks/{webhook_id})
+func (s *Server) GetWebhook(ctx context.Context, request GetWebhookRequestObject) (GetWebhookResponseObject, error) {
+	webhook, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{ID: request.WebhookId, UserID: custom_middleware.RequestUserID(ctx)})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return GetWebhook404JSONResponse{Message: "Webhook not found", Resource: WEBHOOK_RESOURCE, Id: request.WebhookId}, nil
+		}
+		return nil, err
+	}
+	return GetWebhook200JSONResponse(toWebhook(webhook)), nil
+}
+
+// Update webhook
+// (PUT /v1/webhooks/{webhook_id})
+func (s *Server) UpdateWebhook(ctx context.Context, request UpdateWebhookRequestObject) (UpdateWebhookResponseObject, error) {
+	webhook, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{ID: request.WebhookId, UserID: custom_middleware.RequestUserID(ctx)})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return UpdateWebhook404JSONResponse{Message: "Webhook not found", Resource: WEBHOOK_RESOURCE, Id: request.WebhookId}, nil
+		}
+		return nil, err
+	}
+
+	params := db.UpdateWebhookParams{
+		ID:          webhook.ID,
+		UserID:      webhook.UserID,
+		Url:         webhook.Url,
+		Events:      webhook.Events,
+		Description: webhook.Description,
+		Enabled:     webhook.Enabled,
+	}
+	if request.Body.Url != nil {
+		params.Url = strings.TrimSpace(*request.Body.Url)
+		if err := db.ValidateWebhookURL(params.Url); err != nil {
+			return UpdateWebhook400JSONResponse{Message: err.Error()}, nil
+		}
+	}
+	if request.Body.Events != nil {
+		if params.Events, err = webhookEvents(*request.Body.Events); err != nil {
+			return UpdateWebhook400JSONResponse{Message: err.Error()}, nil
+		}
+	}
+	if request.Body.Description != nil {
+		params.Description = optionalText(request.Body.Description)
+	}
+	if request.Body.Enabled != nil {
+		params.Enabled = *request.Body.Enabled
+	}
+
+	webhook, err = s.queries.UpdateWebhook(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return UpdateWebhook404JSONResponse{Message: "Webhook not found", Resource: WEBHOOK_RESOURCE, Id: request.WebhookId}, nil
+		}
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return UpdateWebhook200JSONResponse(toWebhook(webhook)), nil
+}
+
+// Delete webhook
+// (DELETE /v1/webhooks/{webhook_id})
+func (s *Server) DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error) {
+	deleted, err := s.queries.DeleteWebhook(ctx, db.DeleteWebhookParams{ID: request.WebhookId, UserID: custom_middleware.RequestUserID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if deleted == 0 {
+		return DeleteWebhook404JSONResponse{Message: "Webhook not found", Resource: WEBHOOK_RESOURCE, Id: request.WebhookId}, nil
+	}
+	s.log.Info("Deleted webhook", "webhook_id", request.WebhookId)
+	return DeleteWebhook204Response{}, nil
+}
+
+// Rotate webhook secret
+// (POST /v1/webhooks/{webhook_id}/rotate-secret)
+func (s *Server) RotateWebhookSecret(ctx context.Context, request RotateWebhookSecretRequestObject) (RotateWebhookSecretResponseObject, error) {
+	secret, encrypted, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := s.queries.RotateWebhookSecret(ctx, db.RotateWebhookSecretParams{
+		ID:     request.WebhookId,
+		UserID: custom_middleware.RequestUserID(ctx),
+		Secret: encrypted,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RotateWebhookSecret404JSONResponse{Message: "Webhook not found", Resource: WEBHOOK_RESOURCE, Id: request.WebhookId}, nil
+		}
+		return nil, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+	s.log.Info("Rotated webhook secret", "webhook_id", webhook.ID)
+	return RotateWebhookSecret200JSONResponse(CreatedWebhook{Webhook: toWebhook(webhook), Secret: secret}), nil
+}
+
+// List webhook deliveries
+// (GET /v1/webhooks/{webhook_id}/deliveries)
+func (s *Server) ListWebhookDeliveries(ctx context.Context, request ListWebhookDeliveriesRequestObject) (ListWebhookDeliveriesResponseObject, error) {
+	limit, cursor, err := parsePage(request.Params.Limit, request.Params.Cursor)
+	if err != nil {
+		return ListWebhookDeliveries400JSONResponse{Message: err.Error()}, nil
+	}
+	params := db.ListWebhookDeliveriesParams{WebhookID: request.WebhookId, RowLimit: limit + 1}
+	if params.CursorTime, err = cursor.timeKey(); err != nil {
+		return ListWebhookDeliveries400JSONResponse{Message: err.Error()}, nil
+	}
+	if params.CursorID, err = cursor.uuidID(); err != nil {
+		return ListWebhookDeliveries400JSONResponse{Message: err.Error()}, nil
+	}
+	if request.Params.Status != nil {
+		params.Status = pgtype.Text{String: string(*request.Params.Status), Valid: true}
+	}
+
+	if _, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{ID: request.WebhookId, UserID: custom_middleware.RequestUserID(ctx)}); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListWebhookDeliveries404JSONResponse{Message: "Webhook not found", Resource: WEBHOOK_RESOURCE, Id: request.WebhookId}, nil
+		}
+		return nil, err
+	}
+	deliveries, err := s.queries.ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	total, err := s.queries.CountWebhookDeliveries(ctx, db.CountWebhookDeliveriesParams{WebhookID: params.WebhookID, Status: params.Status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	deliveries, hasMore, next := pageRows(deliveries, limit, func(delivery db.WebhookDelivery) pageCursor {
+		return pageCursor{Time: &delivery.CreatedAt.Time, ID: delivery.ID.String()}
+	})
+	list := make([]WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		list = append(list, toWebhookDelivery(delivery))
+	}
+	return ListWebhookDeliveries200JSONResponse(WebhookDeliveryList{
+		Deliveries: list,
+		Limit:      limit,
+		HasMore:    hasMore,
+		NextCursor: next,
+		Total:      int(total),
+	}), nil
+}
+
+// Redeliver a webhook delivery
+// (POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+func (s *Server) RedeliverWebhookDelivery(ctx context.Context, request RedeliverWebhookDeliveryRequestObject) (RedeliverWebhookDeliveryResponseObject, error) {
+	if _, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{ID: request.WebhookId, UserID: custom_middleware.RequestUserID(ctx)}); err != nil {
+		if err == pgx.ErrNoRows {
+			return RedeliverWebhookDelivery404JSONResponse{Message: "Webhook not found", Resource: WEBHOOK_RESOURCE, Id: request.WebhookId}, nil
+		}
+		return nil, err
+	}
+
+	delivery, err := s.queries.RedeliverWebhookDelivery(ctx, db.RedeliverWebhookDeliveryParams{ID: request.DeliveryId, WebhookID: request.WebhookId})
+	if err == nil {
+		s.log.Info("Scheduled webhook redelivery", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "event_id", delivery.EventID)
+		return RedeliverWebhookDelivery200JSONResponse(toWebhookDelivery(delivery)), nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+
+	// Tell a missing delivery from one that is still pending
+	if _, err := s.queries.GetWebhookDelivery(ctx, db.GetWebhookDeliveryParams{ID: request.DeliveryId, WebhookID: request.WebhookId}); err != nil {
+		if err == pgx.ErrNoRows {
+			return RedeliverWebhookDelivery404JSONResponse{Message: "Webhook delivery not found", Resource: WEBHOOK_DELIVERY_RESOURCE, Id: request.DeliveryId}, nil
+		}
+		return nil, err
+	}
+	return RedeliverWebhookDelivery400JSONResponse{Message: "Webhook delivery is still pending"}, nil
+}
+
+// newWebhookSecret returns a new signing secret of a webhook, and the secret encrypted for the database
+func newWebhookSecret() (string, string, error) {
+	secret, err := db.GenerateWebhookSecret()
+	if err != nil {
+		return "", "", err
+	}
+	encrypted, err := db.EncryptSecret(secret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+	return secret, encrypted, nil
+}
+
+// webhookEvents validates the events of a webhook request
+func webhookEvents(events []WebhookEvent) ([]string, error) {
+	requested := make([]string, 0, len(events))
+	for _, event := range events {
+		requested = append(requested, string(event))
+	}
+	return db.NormalizeWebhookEvents(requested)
+}
+
+// toWebhook converts a webhook without its secret
+func toWebhook(webhook db.Webhook) Webhook {
+	events := make([]WebhookEvent, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, WebhookEvent(event))
+	}
+	w := Webhook{
+		Id:        webhook.ID,
+		UserId:    webhook.UserID,
+		Url:       webhook.Url,
+		Events:    events,
+		Enabled:   webhook.Enabled,
+		CreatedAt: webhook.CreatedAt.Time,
+		UpdatedAt: webhook.UpdatedAt.Time,
+	}
+	if webhook.Description.Valid {
+		w.Description = &webhook.Description.String
+	}
+	return w
+}
+
+// toWebhookDelivery converts a delivery of the delivery log
+func toWebhookDelivery(delivery db.WebhookDelivery) WebhookDelivery {
+	d := WebhookDelivery{
+		Id:          delivery.ID,
+		WebhookId:   delivery.WebhookID,
+		EventId:     delivery.EventID,
+		EventType:   WebhookEvent(delivery.EventType),
+		Payload:     delivery.Payload,
+		Status:      WebhookDeliveryStatus(delivery.Status),
+		Attempts:    delivery.Attempts,
+		CreatedAt:   delivery.CreatedAt.Time,
+		DeliveredAt: timestamptzPtr(delivery.DeliveredAt),
+	}
+	if delivery.Status == db.WebhookDeliveryStatusPending {
+		d.NextAttemptAt = timestamptzPtr(delivery.NextAttemptAt)
+	}
+	if delivery.ResponseStatus.Valid {
+		d.ResponseStatus = &delivery.ResponseStatus.Int32
+	}
+	if delivery.ErrorMessage.Valid {
+		d.ErrorMessage = &delivery.ErrorMessage.String
+	}
+	return d
+}
@@ -0,0 +1,170 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/db"
+)
+
+const (
+	// StatusUnauthorized closes a connection without valid credentials
+	StatusUnauthorized websocket.StatusCode = 4401
+	// StatusForbidden closes a connection whose API key misses the write scope, or whose user cannot act in the workspace
+	StatusForbidden websocket.StatusCode = 4403
+	// StatusAuthTimeout closes a connection that did not send its auth frame in time
+	StatusAuthTimeout websocket.StatusCode = 4408
+
+	// AuthTimeout is the time a connection upgraded without credentials has to send its auth frame
+	AuthTimeout = 10 * time.Second
+)
+
+type (
+	// Auth configures the authentication of the connections. The upgrade requests are authenticated by the API
+	// middleware, the connections upgraded without credentials authenticate with an auth frame, which must be their
+	// first frame when the authentication is required.
+	Auth struct {
+		Authenticators     middleware.Authenticators
+		AuthorizeWorkspace middleware.WorkspaceAuthorizer
+		Required           bool     // Close the connections that do not authenticate, they act as the default user otherwise
+		AllowedOrigins     []string // Origins of the browsers allowed to connect, such as https://*.example.com, * for any origin
+	}
+
+	// AuthFrame is the first frame of a connection upgraded without credentials, such as
+	// {"type":"auth","token":"pk_...","workspace_id":"..."}, the payload of the auth envelope
+	AuthFrame struct {
+		Type        string     `json:"type,omitempty"` // auth, set by the legacy frames
+		Token       string     `json:"token"`
+		WorkspaceID *uuid.UUID `json:"workspace_id,omitempty"` // Workspace the tasks of the connection run in, the workspace of the upgrade request when unset
+	}
+
+	// connAuth is the user a connection acts as, in a workspace
+	connAuth struct {
+		userID      uuid.UUID
+		workspaceID uuid.UUID
+	}
+)
+
+// SetAuth sets the authentication of the connections, to set before the handler serves the first connection
+func (h *Handler) SetAuth(auth Auth) {
+	h.auth = auth
+}
+
+// acceptOptions returns the origin check of the upgrade requests, the request host is always allowed
+func (a Auth) acceptOptions() (insecureSkipVerify bool, originPatterns []string) {
+	for _, origin := range a.AllowedOrigins {
+		if origin == "*" {
+			return true, nil
+		}
+	}
+	return false, a.AllowedOrigins
+}
+
+// requestUser returns the user of the API key or the session of an upgrade request, and whether it is authenticated,
+// the default user otherwise
+func requestUser(ctx context.Context) (connAuth, bool) {
+	user := connAuth{userID: middleware.RequestUserID(ctx), workspaceID: middleware.RequestWorkspaceID(ctx)}
+	return user, middleware.GetPrincipal(ctx) != nil
+}
+
+// waitAuthFrame authenticates a connection upgraded without credentials with its first frame, when the authentication
+// is required. The connection is closed with a 44xx status and false is returned when the frame is not an auth frame,
+// fails to authenticate, or is not received within AuthTimeout.
+func (h *Handler) waitAuthFrame(ctx context.Context, conn *websocket.Conn, user connAuth) (connAuth, bool) {
+	timer := time.AfterFunc(AuthTimeout, func() {
+		conn.Close(StatusAuthTimeout, "authentication timeout")
+	})
+	msgType, msg, err := conn.Read(ctx)
+	if !timer.Stop() {
+		return user, false
+	}
+	if err != nil {
+		h.log.Debug("Connection closed before authentication", "error", err)
+		return user, false
+	}
+	if msgType != websocket.MessageText {
+		conn.Close(StatusUnauthorized, "authentication required")
+		return user, false
+	}
+	frame, err := decodeClientFrame(enveloped(conn), msg)
+	if err != nil || frame.Type != FrameAuth {
+		conn.Close(StatusUnauthorized, "authentication required")
+		return user, false
+	}
+	authFrame, err := parseAuthFrame(frame)
+	if err != nil {
+		conn.Close(StatusUnauthorized, "authentication required")
+		return user, false
+	}
+	user, err = h.authenticateFrame(ctx, authFrame, user)
+	if err != nil {
+		h.closeUnauthenticated(conn, err)
+		return user, false
+	}
+	h.sendAuthenticated(ctx, conn, frame.RequestID, user)
+	return user, true
+}
+
+// parseAuthFrame returns the payload of an auth frame
+func parseAuthFrame(frame clientFrame) (AuthFrame, error) {
+	var authFrame AuthFrame
+	if err := json.Unmarshal(frame.Payload, &authFrame); err != nil {
+		return AuthFrame{}, &frameError{ErrorPayload{ErrorCodeInvalidRequest, err.Error()}, "Invalid auth frame"}
+	}
+	return authFrame, nil
+}
+
+// authenticateFrame returns the user of the token of an auth frame, acting in the workspace of the frame or in the
+// workspace of the upgrade request. An API key needs the scope of the WebSocket.
+func (h *Handler) authenticateFrame(ctx context.Context, frame AuthFrame, user connAuth) (connAuth, error) {
+	principal, err := middleware.AuthenticateToken(ctx, h.auth.Authenticators, frame.Token, http.MethodGet, middleware.WebSocketPath)
+	if err != nil {
+		return user, err
+	}
+	user.userID = principal.UserID
+	if frame.WorkspaceID != nil {
+		user.workspaceID = *frame.WorkspaceID
+	}
+	if user.workspaceID != db.DefaultWorkspaceID && h.auth.AuthorizeWorkspace != nil {
+		if err := h.auth.AuthorizeWorkspace(ctx, user.userID, user.workspaceID); err != nil {
+			return user, err
+		}
+	}
+	return user, nil
+}
+
+// closeUnauthenticated closes a connection whose auth frame failed to authenticate
+func (h *Handler) closeUnauthenticated(conn *websocket.Conn, err error) {
+	h.log.Debug("Connection failed to authenticate", "error", err)
+	status, reason := authCloseStatus(err)
+	conn.Close(status, reason)
+}
+
+// sendAuthenticated acknowledges the auth frame of a connection with the user and the workspace it acts in
+func (h *Handler) sendAuthenticated(ctx context.Context, conn *websocket.Conn, requestID string, user connAuth) {
+	h.sendFrame(ctx, conn, serverFrame{
+		Type:      FrameAuthenticated,
+		RequestID: requestID,
+		Payload:   map[string]any{"user_id": user.userID, "workspace_id": user.workspaceID},
+	})
+}
+
+// authCloseStatus returns the status and the reason closing a connection failing to authenticate
+func authCloseStatus(err error) (websocket.StatusCode, string) {
+	switch {
+	case errors.Is(err, middleware.ErrInvalidCredentials):
+		return StatusUnauthorized, "invalid credentials"
+	case errors.Is(err, middleware.ErrMissingScope), errors.Is(err, middleware.ErrWorkspaceForbidden):
+		return StatusForbidden, err.Error()
+	case db.IsUnavailable(err):
+		return websocket.StatusTryAgainLater, "database unavailable, retry later"
+	default:
+		return websocket.StatusInternalError, "failed to authenticate"
+	}
+}
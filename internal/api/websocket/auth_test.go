@@ -0,0 +1,114 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/api/middleware"
+	"github.com/pinazu/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsocketHandler_AuthFrame(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	workspaceID := uuid.New()
+	handler := NewHandler(context.Background(), nil, nil, utils.NewSyncMap[uuid.UUID, *websocket.Conn](), setupTestLogger(t))
+	handler.SetAuth(Auth{
+		Authenticators: middleware.Authenticators{
+			APIKey: func(ctx context.Context, key string) (*middleware.Principal, error) {
+				scopes := map[string][]string{"pk_reader": {"read"}, "pk_writer": {"write"}}[key]
+				if scopes == nil {
+					return nil, middleware.ErrInvalidCredentials
+				}
+				return &middleware.Principal{UserID: userID, APIKeyID: uuid.New(), Scopes: scopes}, nil
+			},
+		},
+		AuthorizeWorkspace: func(ctx context.Context, user, workspace uuid.UUID) error {
+			if workspace != workspaceID {
+				return middleware.ErrWorkspaceForbidden
+			}
+			return nil
+		},
+		Required: true,
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	// send dials the WebSocket with the subprotocols, sends a first frame and returns the frame answering it, or the
+	// close status
+	send := func(t *testing.T, frame string, subprotocols ...string) (map[string]any, websocket.StatusCode) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{Subprotocols: subprotocols})
+		require.NoError(t, err)
+		defer conn.CloseNow()
+		require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte(frame)))
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return nil, websocket.CloseStatus(err)
+		}
+		var msg map[string]any
+		require.NoError(t, json.Unmarshal(data, &msg))
+		return msg, -1
+	}
+
+	t.Run("Authenticated", func(t *testing.T) {
+		msg, _ := send(t, `{"type":"auth","token":"pk_writer","workspace_id":"`+workspaceID.String()+`"}`)
+		assert.Equal(t, "authenticated", msg["type"])
+		assert.Equal(t, userID.String(), msg["user_id"])
+		assert.Equal(t, workspaceID.String(), msg["workspace_id"])
+	})
+
+	t.Run("Authenticated with an envelope", func(t *testing.T) {
+		msg, _ := send(t, `{"type":"auth","version":1,"request_id":"r1","payload":{"token":"pk_writer","workspace_id":"`+workspaceID.String()+`"}}`, SubprotocolV1)
+		assert.Equal(t, "authenticated", msg["type"])
+		assert.Equal(t, float64(EnvelopeVersion), msg["version"])
+		assert.Equal(t, "r1", msg["request_id"])
+		assert.Equal(t, map[string]any{"user_id": userID.String(), "workspace_id": workspaceID.String()}, msg["payload"])
+	})
+
+	t.Run("Legacy frame on an enveloped connection", func(t *testing.T) {
+		_, status := send(t, `{"type":"auth","token":"pk_writer"}`, SubprotocolV1)
+		assert.Equal(t, StatusUnauthorized, status)
+	})
+
+	t.Run("First frame not an auth frame", func(t *testing.T) {
+		_, status := send(t, `{"type":"ping"}`)
+		assert.Equal(t, StatusUnauthorized, status)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		_, status := send(t, `{"type":"auth","token":"pk_unknown"}`)
+		assert.Equal(t, StatusUnauthorized, status)
+	})
+
+	t.Run("Missing scope", func(t *testing.T) {
+		_, status := send(t, `{"type":"auth","token":"pk_reader"}`)
+		assert.Equal(t, StatusForbidden, status)
+	})
+
+	t.Run("Forbidden workspace", func(t *testing.T) {
+		_, status := send(t, `{"type":"auth","token":"pk_writer","workspace_id":"`+uuid.NewString()+`"}`)
+		assert.Equal(t, StatusForbidden, status)
+	})
+}
+
+func TestAuth_acceptOptions(t *testing.T) {
+	skip, patterns := Auth{AllowedOrigins: []string{"*"}}.acceptOptions()
+	assert.True(t, skip)
+	assert.Empty(t, patterns)
+
+	skip, patterns = Auth{AllowedOrigins: []string{"https://*.example.com"}}.acceptOptions()
+	assert.False(t, skip)
+	assert.Equal(t, []string{"https://*.example.com"}, patterns)
+}
@@ -0,0 +1,337 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/service"
+)
+
+// StatusSlowConsumer closes a connection whose client does not read its events fast enough
+const StatusSlowConsumer websocket.StatusCode = 4429
+
+type (
+	// Delivery configures the queues of the events of the connections. The events of a user are forwarded to the
+	// queues of its connections without waiting for their clients, a full queue applies the overflow policy.
+	Delivery struct {
+		QueueSize           int           // Events queued for a connection
+		OverflowPolicy      string        // One of the service.WebSocketOverflow policies
+		SlowConsumerTimeout time.Duration // Time a queue can stay full before its connection is closed
+	}
+
+	// pushResult is what became of an event pushed to a queue
+	pushResult int
+
+	// queuedEvent is an event waiting in the queue of a connection
+	queuedEvent struct {
+		msg   *nats.Msg
+		delta *textDelta // Text delta of the event, kept for the coalesce policy
+	}
+
+	// textDelta is the text of a content block delta, the deltas of the same block can be merged
+	textDelta struct {
+		taskID string
+		index  int64
+		text   string
+	}
+
+	// sendQueue holds the events of a connection until its writer writes them. The event being written stays at the
+	// head of the queue, it is buffered for the resuming client when the write fails.
+	sendQueue struct {
+		connectionID uuid.UUID
+		conn         *websocket.Conn
+		delivery     Delivery
+
+		mu        sync.Mutex
+		events    []queuedEvent
+		writing   bool      // The head of the queue is being written
+		dropped   int       // Events dropped since the last written event
+		fullSince time.Time // Time the queue became full, zero once it drained to half its size
+		hopeless  bool      // The connection is being closed as a slow consumer
+		closed    bool      // The writer stopped, the events are buffered for the resuming client
+
+		ready    chan struct{} // Signals the writer of a new event
+		cancel   context.CancelFunc
+		done     chan struct{} // Closed once the writer stopped
+		stopOnce sync.Once
+	}
+)
+
+const (
+	pushQueued   pushResult = iota // The event waits for the writer
+	pushDropped                    // The event or an older event was dropped by the overflow policy
+	pushClosed                     // The queue is stopped, the event is to buffer for the resuming client
+	pushHopeless                   // The client does not keep up, the connection is to close
+)
+
+// DefaultDelivery is the delivery of a handler without SetDelivery
+var DefaultDelivery = Delivery{
+	QueueSize:           256,
+	OverflowPolicy:      service.WebSocketOverflowDropOldest,
+	SlowConsumerTimeout: 30 * time.Second,
+}
+
+// SetDelivery sets the queues of the connections, to set before the handler serves the first connection
+func (h *Handler) SetDelivery(delivery Delivery) {
+	h.delivery = delivery
+}
+
+// startQueue starts writing the queued events of a connection
+func (h *Handler) startQueue(connectionID uuid.UUID, conn *websocket.Conn) *sendQueue {
+	ctx, cancel := context.WithCancel(h.ctx)
+	q := &sendQueue{
+		connectionID: connectionID,
+		conn:         conn,
+		delivery:     h.delivery,
+		ready:        make(chan struct{}, 1),
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+	h.queues.Store(connectionID, q)
+	go h.runQueue(ctx, q)
+	return q
+}
+
+// stopQueue stops the writer of a queue, the events it did not write are buffered for the resuming client
+func (h *Handler) stopQueue(q *sendQueue) {
+	q.stop(func(msg *nats.Msg) {
+		h.resume.buffer(q.connectionID, msg, true)
+	})
+}
+
+// runQueue writes the queued events of a connection until the queue is stopped or a write fails
+func (h *Handler) runQueue(ctx context.Context, q *sendQueue) {
+	defer close(q.done)
+	for {
+		msg, dropped, ok := q.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.ready:
+				continue
+			}
+		}
+		if dropped > 0 {
+			// The envelope clients learn the stream they received is incomplete
+			if err := writeFrame(ctx, q.conn, serverFrame{Type: FrameDropped, Payload: map[string]any{"count": dropped}, V1Only: true}); err != nil {
+				h.log.Debug("Failed to write the dropped events frame", "connection_id", q.connectionID, "error", err)
+				return
+			}
+		}
+		if err := h.writeMessage(ctx, q.conn, q.connectionID, msg); err != nil {
+			h.log.Debug("Failed to write the queued event", "connection_id", q.connectionID, "error", err)
+			return
+		}
+		q.written()
+	}
+}
+
+// push queues an event of the connection, applying the overflow policy when the queue is full
+func (q *sendQueue) push(msg *nats.Msg, now time.Time) pushResult {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return pushClosed
+	}
+	event := queuedEvent{msg: msg}
+	if q.delivery.OverflowPolicy == service.WebSocketOverflowCoalesce {
+		event.delta = parseTextDelta(msg)
+	}
+	if len(q.events) < q.delivery.QueueSize {
+		q.events = append(q.events, event)
+		q.signal()
+		return pushQueued
+	}
+
+	// The queue is full, a client that does not drain it in time is hopeless
+	if q.hopeless {
+		q.dropped++
+		return pushDropped
+	}
+	if q.fullSince.IsZero() {
+		q.fullSince = now
+	}
+	if now.Sub(q.fullSince) >= q.delivery.SlowConsumerTimeout || q.delivery.OverflowPolicy == service.WebSocketOverflowDisconnect {
+		q.hopeless = true
+		q.dropped++
+		return pushHopeless
+	}
+	switch q.delivery.OverflowPolicy {
+	case service.WebSocketOverflowDropNewest:
+		q.dropped++
+		return pushDropped
+	case service.WebSocketOverflowCoalesce:
+		q.events = append(q.events, event)
+		if q.coalesce() {
+			q.signal()
+			return pushQueued
+		}
+		q.events = q.events[:len(q.events)-1]
+	}
+	i := q.oldestDroppable()
+	if i < 0 {
+		q.dropped++
+		return pushDropped
+	}
+	q.events = append(append(q.events[:i], q.events[i+1:]...), event)
+	q.dropped++
+	q.signal()
+	return pushDropped
+}
+
+// next returns the head of the queue to write, with the events dropped since the last written event
+func (q *sendQueue) next() (*nats.Msg, int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.events) == 0 {
+		return nil, 0, false
+	}
+	q.writing = true
+	dropped := q.dropped
+	q.dropped = 0
+	return q.events[0].msg, dropped, true
+}
+
+// written removes the head of the queue once written
+func (q *sendQueue) written() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.events = q.events[1:]
+	q.writing = false
+	if len(q.events) <= q.delivery.QueueSize/2 {
+		q.fullSince = time.Time{}
+	}
+}
+
+// stop stops the writer and hands the events it did not write to buffer, in their order. The events pushed from now
+// on are not queued.
+func (q *sendQueue) stop(buffer func(*nats.Msg)) {
+	q.stopOnce.Do(func() {
+		q.cancel()
+		<-q.done
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.closed = true
+		for _, event := range q.events {
+			buffer(event.msg)
+		}
+		q.events = nil
+	})
+}
+
+// signal wakes the writer up
+func (q *sendQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// firstDroppable returns the first event the overflow policies can drop or merge, the event being written is not
+func (q *sendQueue) firstDroppable() int {
+	if q.writing {
+		return 1
+	}
+	return 0
+}
+
+// oldestDroppable returns the oldest response event of the queue, the lifecycle events are only dropped when the
+// queue holds nothing else. It returns -1 when no event can be dropped.
+func (q *sendQueue) oldestDroppable() int {
+	first := q.firstDroppable()
+	for i := first; i < len(q.events); i++ {
+		if !isLifecycleEvent(q.events[i].msg) {
+			return i
+		}
+	}
+	if first < len(q.events) {
+		return first
+	}
+	return -1
+}
+
+// coalesce merges the oldest two consecutive text deltas of the same content block, it reports whether two deltas
+// were merged
+func (q *sendQueue) coalesce() bool {
+	for i := q.firstDroppable(); i+1 < len(q.events); i++ {
+		a, b := q.events[i].delta, q.events[i+1].delta
+		if a == nil || b == nil || a.taskID != b.taskID || a.index != b.index {
+			continue
+		}
+		msg, err := mergeTextDeltas(q.events[i].msg, a.text+b.text)
+		if err != nil {
+			continue
+		}
+		q.events[i] = queuedEvent{msg: msg, delta: &textDelta{taskID: a.taskID, index: a.index, text: a.text + b.text}}
+		q.events = append(q.events[:i+1], q.events[i+2:]...)
+		return true
+	}
+	return false
+}
+
+// isLifecycleEvent reports whether an event is a task lifecycle event
+func isLifecycleEvent(msg *nats.Msg) bool {
+	return strings.Contains(msg.Subject, "task.lifecycle")
+}
+
+// parseTextDelta returns the text delta of a response event, nil for the other events
+func parseTextDelta(msg *nats.Msg) *textDelta {
+	if isLifecycleEvent(msg) {
+		return nil
+	}
+	var event struct {
+		H *struct {
+			TaskID *string `json:"task_id"`
+		} `json:"header"`
+		Msg struct {
+			Type  string `json:"type"`
+			Index int64  `json:"index"`
+			Delta struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"delta"`
+		} `json:"message"`
+		Err json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(msg.Data, &event); err != nil || len(event.Err) > 0 && string(event.Err) != "null" {
+		return nil
+	}
+	if event.Msg.Type != "content_block_delta" || event.Msg.Delta.Type != "text_delta" {
+		return nil
+	}
+	delta := &textDelta{index: event.Msg.Index, text: event.Msg.Delta.Text}
+	if event.H != nil && event.H.TaskID != nil {
+		delta.taskID = *event.H.TaskID
+	}
+	return delta
+}
+
+// mergeTextDeltas returns a text delta event with the text of two merged deltas
+func mergeTextDeltas(msg *nats.Msg, text string) (*nats.Msg, error) {
+	var event map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(msg.Data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		return nil, err
+	}
+	message, _ := event["message"].(map[string]any)
+	delta, _ := message["delta"].(map[string]any)
+	if delta == nil {
+		return nil, fmt.Errorf("event without delta")
+	}
+	delta["text"] = text
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &nats.Msg{Subject: msg.Subject, Data: data}, nil
+}
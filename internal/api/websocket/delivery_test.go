@@ -0,0 +1,118 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sendQueue_push(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	responseSubject := "v1.svc.api.ws.response.user"
+	lifecycleSubject := "v1.svc.api.ws.task.lifecycle.user"
+	event := func(subject, text string) *nats.Msg {
+		return &nats.Msg{Subject: subject, Data: []byte(`{"header":{"task_id":"t1"},"message":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"` + text + `"}}}`)}
+	}
+	newQueue := func(policy string) *sendQueue {
+		return &sendQueue{
+			delivery: Delivery{QueueSize: 2, OverflowPolicy: policy, SlowConsumerTimeout: time.Minute},
+			ready:    make(chan struct{}, 1),
+		}
+	}
+	texts := func(q *sendQueue) []string {
+		var texts []string
+		for _, e := range q.events {
+			var data struct {
+				Msg struct {
+					Delta struct {
+						Text string `json:"text"`
+					} `json:"delta"`
+				} `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(e.msg.Data, &data))
+			texts = append(texts, data.Msg.Delta.Text)
+		}
+		return texts
+	}
+
+	t.Run("Drop oldest", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowDropOldest)
+		assert.Equal(t, pushQueued, q.push(event(responseSubject, "a"), now))
+		assert.Equal(t, pushQueued, q.push(event(responseSubject, "b"), now))
+		assert.Equal(t, pushDropped, q.push(event(responseSubject, "c"), now))
+		assert.Equal(t, []string{"b", "c"}, texts(q))
+		assert.Equal(t, 1, q.dropped)
+	})
+
+	t.Run("Drop oldest keeps the lifecycle events and the event being written", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowDropOldest)
+		q.push(event(responseSubject, "a"), now)
+		q.push(event(lifecycleSubject, "b"), now)
+		q.next()
+		assert.Equal(t, pushDropped, q.push(event(responseSubject, "c"), now))
+		assert.Equal(t, []string{"a", "c"}, texts(q))
+	})
+
+	t.Run("Drop newest", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowDropNewest)
+		q.push(event(responseSubject, "a"), now)
+		q.push(event(responseSubject, "b"), now)
+		assert.Equal(t, pushDropped, q.push(event(responseSubject, "c"), now))
+		assert.Equal(t, []string{"a", "b"}, texts(q))
+	})
+
+	t.Run("Coalesce", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowCoalesce)
+		q.push(event(responseSubject, "a"), now)
+		q.push(event(responseSubject, "b"), now)
+		assert.Equal(t, pushQueued, q.push(event(responseSubject, "c"), now))
+		assert.Equal(t, []string{"ab", "c"}, texts(q))
+		assert.Equal(t, 0, q.dropped)
+	})
+
+	t.Run("Disconnect", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowDisconnect)
+		q.push(event(responseSubject, "a"), now)
+		q.push(event(responseSubject, "b"), now)
+		assert.Equal(t, pushHopeless, q.push(event(responseSubject, "c"), now))
+		assert.Equal(t, pushDropped, q.push(event(responseSubject, "d"), now))
+	})
+
+	t.Run("Slow consumer", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowDropOldest)
+		q.push(event(responseSubject, "a"), now)
+		q.push(event(responseSubject, "b"), now)
+		assert.Equal(t, pushDropped, q.push(event(responseSubject, "c"), now))
+		assert.Equal(t, pushDropped, q.push(event(responseSubject, "d"), now.Add(30*time.Second)))
+		assert.Equal(t, pushHopeless, q.push(event(responseSubject, "e"), now.Add(time.Minute)))
+	})
+
+	t.Run("Drained queue is not a slow consumer", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowDropOldest)
+		q.push(event(responseSubject, "a"), now)
+		q.push(event(responseSubject, "b"), now)
+		q.push(event(responseSubject, "c"), now)
+		q.next()
+		q.written()
+		q.push(event(responseSubject, "d"), now.Add(time.Minute))
+		assert.Equal(t, pushDropped, q.push(event(responseSubject, "e"), now.Add(time.Minute)))
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		q := newQueue(service.WebSocketOverflowDropOldest)
+		q.cancel, q.done = func() {}, make(chan struct{})
+		close(q.done)
+		q.push(event(responseSubject, "a"), now)
+		var buffered []*nats.Msg
+		q.stop(func(msg *nats.Msg) { buffered = append(buffered, msg) })
+		assert.Len(t, buffered, 1)
+		assert.Equal(t, pushClosed, q.push(event(responseSubject, "b"), now))
+	})
+}
@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// ScopeParam is the query parameter choosing the events a connection receives from the other connections of its
+	// user, e.g. ?scope=connection
+	ScopeParam = "scope"
+
+	// ThreadsParam is the query parameter restricting the events of the other connections to a comma-separated list
+	// of threads, e.g. ?threads=<thread_id>,<thread_id>
+	ThreadsParam = "threads"
+
+	// ScopeUser sends every event of the user in the workspace of the connection, whichever device started the task
+	ScopeUser = "user"
+
+	// ScopeConnection only sends the events of the tasks started by the connection
+	ScopeConnection = "connection"
+)
+
+// fanout holds the events a connection receives besides the events of its own requests
+type fanout struct {
+	scope       string
+	workspaceID uuid.UUID              // Workspace the connection acts in, the events of the other workspaces are not sent
+	threads     map[uuid.UUID]struct{} // Threads whose events are sent, every thread when empty
+}
+
+// parseFanout parses the fan-out options of the upgrade request of a connection acting in a workspace
+func parseFanout(query url.Values, workspaceID uuid.UUID) (*fanout, error) {
+	f := &fanout{scope: ScopeUser, workspaceID: workspaceID, threads: map[uuid.UUID]struct{}{}}
+	if scope := query.Get(ScopeParam); scope != "" {
+		if scope != ScopeUser && scope != ScopeConnection {
+			return nil, fmt.Errorf("invalid scope %q, must be %s or %s", scope, ScopeUser, ScopeConnection)
+		}
+		f.scope = scope
+	}
+	for _, item := range strings.Split(query.Get(ThreadsParam), ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		threadID, err := uuid.Parse(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid thread ID %q: %w", item, err)
+		}
+		f.threads[threadID] = struct{}{}
+	}
+	return f, nil
+}
+
+// inWorkspace returns the options of the connection once it acts in another workspace, after its auth frame
+func (f *fanout) inWorkspace(workspaceID uuid.UUID) *fanout {
+	moved := *f
+	moved.workspaceID = workspaceID
+	return &moved
+}
+
+// allows reports whether the connection receives an event of a task it did not start
+func (f *fanout) allows(header *service.EventHeaders) bool {
+	if f.scope != ScopeUser || header.WorkspaceID != f.workspaceID {
+		return false
+	}
+	if len(f.threads) == 0 {
+		return true
+	}
+	if header.ThreadID == nil {
+		return false
+	}
+	_, ok := f.threads[*header.ThreadID]
+	return ok
+}
+
+// eventTargets returns the connections of the gateway receiving an event of a user: the connection that started its
+// task, the connections following the task, such as when another connection stopped it, and the other connections of
+// the user allowing it, including the connections waiting to be resumed
+func (h *Handler) eventTargets(header *service.EventHeaders) []uuid.UUID {
+	var targets []uuid.UUID
+	if header.ConnectionID != nil {
+		targets = append(targets, *header.ConnectionID)
+	}
+	for _, connectionID := range h.resume.connections(header.UserID) {
+		if header.ConnectionID != nil && connectionID == *header.ConnectionID {
+			continue
+		}
+		if f, ok := h.fanouts.Load(connectionID); (ok && f.allows(header)) || h.followsTask(connectionID, header) {
+			targets = append(targets, connectionID)
+		}
+	}
+	return targets
+}
+
+// messageHeader returns the header of a NATS message addressed to the connections of a user
+func messageHeader(msg *nats.Msg) (*service.EventHeaders, bool) {
+	var event struct {
+		H *service.EventHeaders `json:"header"`
+	}
+	if err := json.Unmarshal(msg.Data, &event); err != nil || event.H == nil {
+		return nil, false
+	}
+	return event.H, true
+}
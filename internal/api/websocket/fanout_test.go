@@ -0,0 +1,71 @@
+package websocket
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFanout(t *testing.T) {
+	t.Parallel()
+
+	threadID := uuid.New()
+	f, err := parseFanout(url.Values{}, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, ScopeUser, f.scope)
+	assert.Empty(t, f.threads)
+
+	f, err = parseFanout(url.Values{ScopeParam: {ScopeConnection}, ThreadsParam: {threadID.String() + ", "}}, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, ScopeConnection, f.scope)
+	assert.Equal(t, map[uuid.UUID]struct{}{threadID: {}}, f.threads)
+
+	_, err = parseFanout(url.Values{ScopeParam: {"all"}}, uuid.Nil)
+	assert.Error(t, err)
+	_, err = parseFanout(url.Values{ThreadsParam: {"not-a-uuid"}}, uuid.Nil)
+	assert.Error(t, err)
+}
+
+func TestHandler_eventTargets(t *testing.T) {
+	t.Parallel()
+
+	handler := NewHandler(context.Background(), nil, nil, utils.NewSyncMap[uuid.UUID, *websocket.Conn](), setupTestLogger(t))
+	userID, workspaceID := uuid.New(), uuid.New()
+	origin, device, scoped, threaded, otherWorkspace := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	threadID := uuid.New()
+	connect := func(connectionID uuid.UUID, query url.Values, workspaceID uuid.UUID) {
+		f, err := parseFanout(query, uuid.Nil)
+		require.NoError(t, err)
+		handler.fanouts.Store(connectionID, f.inWorkspace(workspaceID))
+		handler.resume.issue(userID, connectionID)
+	}
+	connect(origin, url.Values{ScopeParam: {ScopeConnection}}, workspaceID)
+	connect(device, url.Values{}, workspaceID)
+	connect(scoped, url.Values{ScopeParam: {ScopeConnection}}, workspaceID)
+	connect(threaded, url.Values{ThreadsParam: {uuid.NewString()}}, workspaceID)
+	connect(otherWorkspace, url.Values{}, uuid.New())
+
+	// The connection that started the task and the connections of the user receiving every event of the workspace
+	targets := handler.eventTargets(&service.EventHeaders{UserID: userID, WorkspaceID: workspaceID, ConnectionID: &origin, ThreadID: &threadID})
+	assert.ElementsMatch(t, []uuid.UUID{origin, device}, targets)
+
+	// The events of the tasks started without connection, such as through the API
+	targets = handler.eventTargets(&service.EventHeaders{UserID: userID, WorkspaceID: workspaceID, ThreadID: &threadID})
+	assert.Equal(t, []uuid.UUID{device}, targets)
+
+	// The connection that started a task receives its end when another connection stopped it
+	taskID := "task-a"
+	handler.tagFrame(&serverFrame{}, origin, &service.EventHeaders{ConnectionID: &origin, ThreadID: &threadID, TaskID: &taskID}, "task_start")
+	targets = handler.eventTargets(&service.EventHeaders{UserID: userID, WorkspaceID: workspaceID, ConnectionID: &device, ThreadID: &threadID, TaskID: &taskID})
+	assert.ElementsMatch(t, []uuid.UUID{device, origin}, targets)
+
+	// The connections of the other users do not receive the event
+	assert.Empty(t, handler.eventTargets(&service.EventHeaders{UserID: uuid.New(), WorkspaceID: workspaceID}))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/coder/websocket"
@@ -21,12 +22,22 @@ import (
 type (
 	// Handler handles WebSocket connections and messages
 	Handler struct {
-		log     hclog.Logger
-		nc      *nats.Conn
-		queries *db.Queries
-		wsMap   *utils.SyncMap[uuid.UUID, *websocket.Conn]
-		resMap  *utils.SyncMap[uuid.UUID, chan *nats.Msg]
-		ctx     context.Context
+		log      hclog.Logger
+		nc       *nats.Conn
+		queries  *db.Queries
+		wsMap    *utils.SyncMap[uuid.UUID, *websocket.Conn]
+		filters  *utils.SyncMap[uuid.UUID, *service.StreamEventFilter] // Stream event classes sent to each connection
+		fanouts  *utils.SyncMap[uuid.UUID, *fanout]                    // Events of the other connections of its user sent to each connection
+		threads  *utils.SyncMap[uuid.UUID, *connThreads]               // Requests and tasks of the threads of each connection
+		queues   *utils.SyncMap[uuid.UUID, *sendQueue]                 // Events waiting to be written to each connection
+		delivery Delivery
+		auth     Auth
+		resume   *resumeStore // Sessions of the connections, resumed by their reconnecting clients
+		registry *Registry    // Connections of the gateway nodes, nil on a single node
+		ctx      context.Context
+
+		streamsMu sync.Mutex
+		streams   map[uuid.UUID]*userStream // Events of the users with a connection on the gateway
 	}
 
 	// HandlerRequestMessage represents the structure of the message sent from the client
@@ -38,19 +49,44 @@ type (
 )
 
 func NewHandler(ctx context.Context, dbPool *pgxpool.Pool, nc *nats.Conn, wsMap *utils.SyncMap[uuid.UUID, *websocket.Conn], log hclog.Logger) *Handler {
-	return &Handler{
-		log:     log,
-		wsMap:   wsMap,
-		nc:      nc,
-		queries: db.New(dbPool),
-		resMap:  utils.NewSyncMap[uuid.UUID, chan *nats.Msg](),
-		ctx:     ctx,
+	h := &Handler{
+		log:      log,
+		wsMap:    wsMap,
+		nc:       nc,
+		queries:  db.New(dbPool),
+		filters:  utils.NewSyncMap[uuid.UUID, *service.StreamEventFilter](),
+		fanouts:  utils.NewSyncMap[uuid.UUID, *fanout](),
+		threads:  utils.NewSyncMap[uuid.UUID, *connThreads](),
+		queues:   utils.NewSyncMap[uuid.UUID, *sendQueue](),
+		delivery: DefaultDelivery,
+		ctx:      ctx,
+		streams:  map[uuid.UUID]*userStream{},
 	}
+	h.resume = newResumeStore(ResumeTTL, ResumeMaxUserEvents, h.holdUserStream, h.releaseUserStream, h.forgetConnection)
+	return h
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The client chooses the stream event classes it receives, e.g. ?events=text,lifecycle
+	filter, err := service.ParseStreamEventFilter(r.URL.Query().Get("events"))
+	if err != nil {
+		h.log.Debug("Invalid stream event filter", "events", r.URL.Query().Get("events"), "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// The client chooses the events of the other connections of its user it receives, e.g. ?scope=connection
+	connFanout, err := parseFanout(r.URL.Query(), uuid.Nil)
+	if err != nil {
+		h.log.Debug("Invalid fan-out options", "scope", r.URL.Query().Get(ScopeParam), "threads", r.URL.Query().Get(ThreadsParam), "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	insecureSkipVerify, originPatterns := h.auth.acceptOptions()
 	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
-		InsecureSkipVerify: true, // Disable origin check for development
+		InsecureSkipVerify: insecureSkipVerify,
+		OriginPatterns:     originPatterns,
+		Subprotocols:       []string{SubprotocolV1}, // The clients negotiating no subprotocol exchange the legacy frames
 		OnPingReceived: func(ctx context.Context, payload []byte) bool {
 			h.log.Debug("Ping received", "payload", string(payload))
 			return true // Return true to send a pong response
@@ -61,144 +97,217 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate unique connection ID
-	connectionID := uuid.New()
-	h.wsMap.Store(connectionID, conn)
-	h.log.Debug("Stored new ws connection: ", "connection_id", connectionID)
-
-	// TODO: Extract userID from request (authentication/authorization)
-	// TODO: should be replaced with the actual user ID from the context or authentication system
-	userID, err := uuid.Parse("550e8400-c95b-4444-6666-446655440000")
-	if err != nil {
-		h.log.Error("Invalid UUID format for user ID", "error", err)
-		return
-	}
-
-	// Create a buffered channel for responses with buffer size of 100 to handle bursts
-	responseChan := make(chan *nats.Msg, 100)
-
-	// Check if channel already exists. If so delete the previous
-	if existingChan, exists := h.resMap.Load(userID); exists {
-		close(existingChan)
-		h.resMap.Delete(userID)
+	// The connection receives the events of the user of its API key or session, its tasks run in the workspace
+	// selected by the upgrade request or by its auth frame
+	ctx := r.Context()
+	user, authenticated := requestUser(ctx)
+	if !authenticated && h.auth.Required {
+		if user, authenticated = h.waitAuthFrame(ctx, conn, user); !authenticated {
+			return
+		}
 	}
-	h.resMap.Store(userID, responseChan)
 
-	// Subscribe to the user's response subjects using ChanSubscribe
-	event := service.WebsocketResponseEventMessage{}
-	sub, err := h.nc.ChanSubscribe(event.SubjectWithUser(userID).String(), responseChan)
-	if err != nil {
-		h.log.Error("Failed to subscribe to response channel", "user_id", userID, "error", err)
+	// The events of the user are forwarded from now on, the events of a resumed connection are buffered until replayed
+	if err := h.acquireUserStream(user.userID); err != nil {
+		h.log.Error("Failed to subscribe to the events of the user", "user_id", user.userID, "error", err)
+		conn.Close(websocket.StatusInternalError, "failed to subscribe to the events")
 		return
 	}
 
-	// Subscribe to task lifecycle events
-	taskEvent := service.WebsocketTaskLifecycleEventMessage{}
-	taskSub, err := h.nc.ChanSubscribe(taskEvent.SubjectWithUser(userID).String(), responseChan)
-	if err != nil {
-		h.log.Error("Failed to subscribe to task lifecycle channel", "user_id", userID, "error", err)
-		return
+	// Generate unique connection ID, a resumed connection takes over the ID of the connection it resumes
+	connectionID := uuid.New()
+	resumed := false
+	if token := r.URL.Query().Get(ResumeParam); token != "" {
+		if connectionID, resumed = h.resume.resume(token, user.userID); !resumed {
+			connectionID, resumed = h.resumeRemote(ctx, token, user.userID)
+		}
+		if !resumed {
+			connectionID = uuid.New()
+		}
+	}
+	h.filters.Store(connectionID, filter)
+	h.fanouts.Store(connectionID, connFanout.inWorkspace(user.workspaceID))
+	if resumed {
+		h.replaceConnection(connectionID)
 	}
-
-	// Start goroutine to handle messages from the channel and forward to WebSocket
-	// Use request context so goroutine dies when WebSocket connection closes
-	ctx := r.Context()
-	go h.handleUserMessages(ctx, responseChan)
 
 	// Ensure cleanup on exit
+	var queue *sendQueue
+	attached, closedByClient := false, false
 	defer func() {
-
-		// Unsubscribe from NATS
-		if err := sub.Unsubscribe(); err != nil {
-			h.log.Error("Failed to unsubscribe", "user_id", userID, "error", err)
-		}
-		if err := taskSub.Unsubscribe(); err != nil {
-			h.log.Error("Failed to unsubscribe from task lifecycle", "user_id", userID, "error", err)
-		}
-
 		// Close WebSocket connection
 		conn.Close(websocket.StatusNormalClosure, "Connection closed")
-		h.wsMap.Delete(connectionID)
 
-		// Clean up user response channel
-		if resChan, exists := h.resMap.Load(userID); exists {
-			close(resChan)
-			h.resMap.Delete(userID)
+		// The queued events are buffered for the resuming client
+		if queue != nil {
+			h.stopQueue(queue)
+			defer h.queues.CompareAndDelete(connectionID, queue)
 		}
 
-		h.log.Debug("Connection cleanup completed", "connection_id", connectionID, "user_id", userID)
+		// A connection closed abruptly can be resumed, its events are buffered meanwhile. A connection resumed by a
+		// new connection no longer belongs to this handler, a connection closed while replaying cannot be resumed.
+		if h.wsMap.CompareAndDelete(connectionID, conn) || !attached {
+			if closedByClient || !attached {
+				h.resume.close(connectionID)
+				h.forgetConnection(connectionID)
+			} else {
+				h.resume.detach(connectionID)
+			}
+		}
+		h.releaseUserStream(user.userID)
+
+		h.log.Debug("Connection cleanup completed", "connection_id", connectionID, "user_id", user.userID)
 	}()
 
-	h.log.Info("Websocket connection established", "connection_id", connectionID, "user_id", userID)
+	// Replay the events the client missed before delivering the new ones
+	replayed := 0
+	for {
+		pending := h.resume.drain(connectionID, func() {
+			h.wsMap.Store(connectionID, conn)
+			queue = h.startQueue(connectionID, conn)
+			attached = true
+		})
+		if len(pending) == 0 {
+			break
+		}
+		for _, msg := range pending {
+			if err := h.writeMessage(ctx, conn, connectionID, msg); err != nil {
+				h.log.Debug("Failed to replay the missed events", "connection_id", connectionID, "error", err)
+				return
+			}
+		}
+		replayed += len(pending)
+	}
+	h.log.Debug("Stored new ws connection: ", "connection_id", connectionID)
+	h.sendSession(ctx, conn, connectionID, user.userID, resumed, replayed)
+
+	h.log.Info("Websocket connection established", "connection_id", connectionID, "user_id", user.userID, "authenticated", authenticated, "resumed", resumed)
 
 	// Handle incoming messages - use simple blocking read for proper ping/pong handling
 	for {
 		msgType, msg, err := conn.Read(ctx)
 
-		if websocket.CloseStatus(err) != -1 {
+		if status := websocket.CloseStatus(err); status != -1 {
 			h.log.Debug("Connection closed by client", "connection_id", connectionID, "error", err)
+			closedByClient = status == websocket.StatusNormalClosure || status == websocket.StatusGoingAway
 			return
 		}
 		if err != nil {
 			h.log.Error("Failed to read message", "connection_id", connectionID, "error", err)
-			if err := conn.Write(ctx, websocket.MessageText, []byte(`{"error":"Failed to read message"}`)); err != nil {
-				h.log.Error("Failed to send read message error message", "connection_id", connectionID, "error", err)
-			}
+			h.sendFrame(ctx, conn, errorFrame("", ErrorCodeReadFailed, "Failed to read message", ""))
 			return
 		}
-
 		h.log.Debug("Received message", "connection_id", connectionID, "type", msgType, "data", string(msg))
 
-		// Handle different message types
-		switch msgType {
-		case websocket.MessageText:
-			// Parse client message for text messages
-			var msgStruct map[string]any
-			if err := json.Unmarshal(msg, &msgStruct); err != nil {
-				h.log.Error("Failed to parse client message", "connection_id", connectionID, "error", err)
-				if err := conn.Write(ctx, websocket.MessageText, []byte(`{"error":"Failed to parse message"}`)); err != nil {
-					h.log.Error("Failed to send parse client error message", "connection_id", connectionID, "error", err)
-				}
-				continue
+		// Other message types than text are not handled, ping/pong are handled by the websocket library
+		if msgType != websocket.MessageText {
+			if enveloped(conn) {
+				h.sendFrame(ctx, conn, errorFrame("", ErrorCodeInvalidFrame, "binary frames are not supported", ""))
 			}
-			// Check if the message is a ping
-			if msgStruct["type"] == "ping" {
-				// Handle ping message
-				if err := conn.Write(ctx, websocket.MessageText, []byte(`{"type":"pong"}`)); err != nil {
-					h.log.Error("Failed to send pong message", "connection_id", connectionID, "error", err)
-				}
-				h.log.Debug("Sent pong response", "connection_id", connectionID)
+			continue
+		}
+		frame, err := decodeClientFrame(enveloped(conn), msg)
+		if err != nil {
+			h.log.Debug("Invalid client frame", "connection_id", connectionID, "error", err)
+			h.sendFrame(ctx, conn, frameErrorFrame(frame.RequestID, err))
+			continue
+		}
+
+		// A connection of the default user can authenticate with an auth frame, it then acts as its user
+		if frame.Type == FrameAuth {
+			if authenticated {
+				h.sendFrame(ctx, conn, errorFrame(frame.RequestID, ErrorCodeAlreadyAuthed, "Already authenticated", ""))
 				continue
 			}
-			//Try cast type to WebscoketHandlerRequestMessage
-			var websocketHandlerRequestMsg HandlerRequestMessage
-			if err := json.Unmarshal(msg, &websocketHandlerRequestMsg); err != nil {
-				h.log.Error("Failed to parse client message", "connection_id", connectionID, "error", err)
-				if err := conn.Write(ctx, websocket.MessageText, []byte(`{"error":"Invalid message format"}`)); err != nil {
-					h.log.Error("Failed to send invalid format error message", "connection_id", connectionID, "error", err)
-				}
+			authFrame, err := parseAuthFrame(frame)
+			if err != nil {
+				h.sendFrame(ctx, conn, frameErrorFrame(frame.RequestID, err))
 				continue
 			}
-			// Process the text message (existing logic)
-			if err := h.processTextMessage(connectionID, userID, websocketHandlerRequestMsg); err != nil {
-				h.log.Error("Failed to process text message", "connection_id", connectionID, "error", err)
-				if err := conn.Write(ctx, websocket.MessageText, []byte(`{"error":"Failed to process message"}`)); err != nil {
-					h.log.Error("Failed to send process error message", "connection_id", connectionID, "error", err)
-				}
-				continue
+			frameUser, err := h.authenticateFrame(ctx, authFrame, user)
+			if err != nil {
+				h.closeUnauthenticated(conn, err)
+				return
 			}
-		case websocket.MessageBinary:
-			// For now, we don't handle binary messages
-			continue
-		default:
-			// Other message types (ping/pong are handled automatically by the websocket library)
+			if err := h.acquireUserStream(frameUser.userID); err != nil {
+				h.log.Error("Failed to subscribe to the events of the user", "user_id", frameUser.userID, "error", err)
+				return
+			}
+			h.releaseUserStream(user.userID)
+			user, authenticated = frameUser, true
+			h.fanouts.Store(connectionID, connFanout.inWorkspace(user.workspaceID))
+			h.sendAuthenticated(ctx, conn, frame.RequestID, user)
+			h.sendSession(ctx, conn, connectionID, user.userID, false, 0)
 			continue
 		}
+
+		h.handleClientFrame(ctx, conn, connectionID, user, frame)
+	}
+}
+
+// replaceConnection closes the previous connection of a resumed connection, it may still be open when its client
+// reconnected before it was seen closed. The events the previous connection did not write are replayed.
+func (h *Handler) replaceConnection(connectionID uuid.UUID) {
+	if previous, ok := h.wsMap.LoadAndDelete(connectionID); ok {
+		previous.Close(StatusReplaced, "resumed by a new connection")
+	}
+	if previous, ok := h.queues.LoadAndDelete(connectionID); ok {
+		h.stopQueue(previous)
+	}
+}
+
+// sendSession sends the ID and the resume token of a connection to its client. A client reconnecting with
+// ?resume=<token> within ResumeTTL receives the events it missed, the token is single use.
+func (h *Handler) sendSession(ctx context.Context, conn *websocket.Conn, connectionID, userID uuid.UUID, resumed bool, replayed int) {
+	token := h.resume.issue(userID, connectionID)
+	if h.registry != nil {
+		h.registry.register(connectionID, userID, token)
+	}
+	h.sendFrame(ctx, conn, serverFrame{
+		Type: FrameSession,
+		Payload: map[string]any{
+			"connection_id": connectionID,
+			"resume_token":  token,
+			"resumed":       resumed,
+			"replayed":      replayed,
+		},
+	})
+}
+
+// handleClientFrame handles a ping or a message frame sent by the client of a connection
+func (h *Handler) handleClientFrame(ctx context.Context, conn *websocket.Conn, connectionID uuid.UUID, user connAuth, frame clientFrame) {
+	switch frame.Type {
+	case FramePing:
+		h.sendFrame(ctx, conn, serverFrame{Type: FramePong, RequestID: frame.RequestID, Payload: map[string]any{}})
+		h.log.Debug("Sent pong response", "connection_id", connectionID)
+	case FrameMessage:
+		var request HandlerRequestMessage
+		if err := json.Unmarshal(frame.Payload, &request); err != nil {
+			h.log.Error("Failed to parse client message", "connection_id", connectionID, "error", err)
+			h.sendFrame(ctx, conn, errorFrame(frame.RequestID, ErrorCodeInvalidRequest, "Invalid message format", ""))
+			return
+		}
+		if err := h.processTextMessage(connectionID, user.userID, user.workspaceID, frame.RequestID, request); err != nil {
+			h.log.Error("Failed to process text message", "connection_id", connectionID, "error", err)
+			h.sendFrame(ctx, conn, errorFrame(frame.RequestID, ErrorCodeProcessingFailed, "Failed to process message", ""))
+			return
+		}
+		h.sendFrame(ctx, conn, serverFrame{
+			Type:      FrameAccepted,
+			RequestID: frame.RequestID,
+			Payload:   map[string]any{"agent_id": request.AgentID, "thread_id": request.ThreadId},
+			V1Only:    true,
+		})
+	case FrameStop:
+		h.stopTasks(ctx, conn, connectionID, user, frame)
+	default:
+		h.sendFrame(ctx, conn, errorFrame(frame.RequestID, ErrorCodeUnknownType, fmt.Sprintf("unknown frame type %q", frame.Type), ""))
 	}
 }
 
-// processTextMessage send the recieved message from Websocket to NATS with appropriate subject
-func (h *Handler) processTextMessage(connectionID, userId uuid.UUID, websocketHandlerRequestMsg HandlerRequestMessage) error {
+// processTextMessage send the recieved message from Websocket to NATS with appropriate subject, the events of the task
+// are tagged with the request of the client
+func (h *Handler) processTextMessage(connectionID, userId, workspaceID uuid.UUID, requestID string, websocketHandlerRequestMsg HandlerRequestMessage) error {
 	// Create the event using the service layer
 	event := service.NewEvent(&service.TaskExecuteEventMessage{
 		AgentId:     websocketHandlerRequestMsg.AgentID,
@@ -206,8 +315,10 @@ func (h *Handler) processTextMessage(connectionID, userId uuid.UUID, websocketHa
 		Messages:    websocketHandlerRequestMsg.Messages,
 	}, &service.EventHeaders{
 		UserID:       userId,
+		WorkspaceID:  workspaceID,
 		ThreadID:     websocketHandlerRequestMsg.ThreadId,
 		ConnectionID: &connectionID,
+		RequestID:    requestID,
 	}, &service.EventMetadata{
 		TraceID:   "", // Get from traceId set.
 		Timestamp: time.Now().UTC(),
@@ -246,124 +357,122 @@ func (h *Handler) handleUserMessages(ctx context.Context, msgChan chan *nats.Msg
 	}
 }
 
-// forwardMessageToWebSocket handles forwarding a single NATS message to the WebSocket connection
+// forwardMessageToWebSocket queues a single NATS message for the WebSocket connections it is sent to, the
+// connection that started its task and the other connections of its user. The messages of a connection waiting to be
+// resumed are buffered.
 func (h *Handler) forwardMessageToWebSocket(msg *nats.Msg) error {
+	header, ok := messageHeader(msg)
+	if !ok {
+		h.log.Warn("WebSocket event without header", "subject", msg.Subject)
+		return nil
+	}
+	for _, connectionID := range h.eventTargets(header) {
+		h.queueMessage(connectionID, msg)
+	}
+	return nil
+}
+
+// queueMessage queues a NATS message for a WebSocket connection, or buffers it while the connection waits to be
+// resumed
+func (h *Handler) queueMessage(connectionID uuid.UUID, msg *nats.Msg) {
+	// Get the queue of the WebSocket connection
+	queue, ok := h.queues.Load(connectionID)
+	if !ok {
+		if h.resume.buffer(connectionID, msg, false) {
+			return
+		}
+		// The connection might have been resumed meanwhile
+		if queue, ok = h.queues.Load(connectionID); !ok {
+			// Connection might have been closed, this is not necessarily an error
+			h.log.Debug("WebSocket connection not found, skipping message", "connection_id", connectionID, "subject", msg.Subject)
+			return
+		}
+	}
+
+	switch queue.push(msg, time.Now()) {
+	case pushClosed:
+		// The client can still receive the message by resuming the connection
+		h.resume.buffer(connectionID, msg, true)
+	case pushDropped:
+		h.log.Debug("WebSocket queue full, event dropped", "connection_id", connectionID, "policy", queue.delivery.OverflowPolicy)
+	case pushHopeless:
+		h.log.Warn("Closing slow WebSocket consumer", "connection_id", connectionID, "queue_size", queue.delivery.QueueSize, "policy", queue.delivery.OverflowPolicy)
+		// Closing waits for the client, the events of the other connections are not held meanwhile
+		go queue.conn.Close(StatusSlowConsumer, "slow consumer")
+	}
+}
+
+// writeMessage writes a NATS message to a WebSocket connection it is sent to
+func (h *Handler) writeMessage(ctx context.Context, ws *websocket.Conn, connectionID uuid.UUID, msg *nats.Msg) error {
 	// Determine event type based on NATS subject
+	var frame *serverFrame
+	var err error
 	if strings.Contains(msg.Subject, "task.lifecycle") {
-		return h.forwardTaskLifecycle(msg.Data)
+		frame, err = h.taskLifecycleFrame(connectionID, msg.Data)
 	} else if strings.Contains(msg.Subject, "ws.response") {
-		return h.forwardWebSocketResponse(msg.Data)
+		frame, err = h.webSocketResponseFrame(connectionID, msg.Data)
+	} else {
+		h.log.Warn("Unknown WebSocket event subject", "subject", msg.Subject)
+		return nil
 	}
-
-	h.log.Warn("Unknown WebSocket event subject", "subject", msg.Subject)
-	return nil
+	if err != nil || frame == nil {
+		return err
+	}
+	return writeFrame(ctx, ws, *frame)
 }
 
-// forwardWebSocketResponse handles AI streaming response events
-func (h *Handler) forwardWebSocketResponse(data []byte) error {
+// webSocketResponseFrame returns the frame of an AI streaming response event, nil when the connection did not
+// subscribe to its event class
+func (h *Handler) webSocketResponseFrame(connectionID uuid.UUID, data []byte) (*serverFrame, error) {
 	// Parse the event
 	event, err := service.ParseEvent[*service.WebsocketResponseEventMessage](data)
 
-	// Get the WebSocket connection
-	ws, ok := h.wsMap.Load(*event.H.ConnectionID)
-	if !ok {
-		// Connection might have been closed, this is not necessarily an error
-		h.log.Debug("WebSocket connection not found, skipping message",
-			"connection_id", event.H.ConnectionID,
-			"user_id", event.H.UserID,
-		)
-		return nil // Return nil to continue processing other messages
+	// Skip the event classes the client did not subscribe to, errors are always sent
+	if filter, ok := h.filters.Load(connectionID); ok && err == nil && !filter.AllowResponse(event.Msg) {
+		return nil, nil
 	}
 
-	var responseData []byte
-
 	// Check if the event contains an error
 	if err != nil {
 		h.log.Debug("Received error event from NATS",
-			"connection_id", *event.H.ConnectionID,
+			"connection_id", connectionID,
 			"user_id", event.H.UserID,
 			"error", err.Error(),
 		)
-		// Create simple error response for WebSocket client
-		var err error
-		responseData, err = json.Marshal(map[string]string{"error": event.Err.Error})
-		if err != nil {
-			return fmt.Errorf("failed to marshal error response: %w", err)
-		}
-	} else {
-		// Forward the original message data for successful responses
-		responseData = data
+		frame := errorFrame("", ErrorCodeAgentError, event.Err.Error, "")
+		h.tagFrame(&frame, connectionID, event.H, "")
+		return &frame, nil
 	}
-
-	// Send response to WebSocket client with timeout to prevent blocking
-	writeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := ws.Write(writeCtx, websocket.MessageText, responseData); err != nil {
-		// WebSocket write failed - connection might be closed
-		return fmt.Errorf("failed to write to websocket: %w", err)
-	}
-
-	h.log.Debug("Successfully forwarded AI response to WebSocket",
-		"connection_id", *event.H.ConnectionID,
-		"user_id", event.H.UserID,
-		"has_error", event.Err != nil,
-	)
-	return nil
+	// Forward the original message data for successful responses
+	frame := &serverFrame{Type: FrameResponse, Payload: json.RawMessage(data), Legacy: json.RawMessage(data)}
+	h.tagFrame(frame, connectionID, event.H, "")
+	return frame, nil
 }
 
-// forwardTaskLifecycle handles task lifecycle events
-func (h *Handler) forwardTaskLifecycle(data []byte) error {
+// taskLifecycleFrame returns the frame of a task lifecycle event, nil when the connection did not subscribe to the
+// lifecycle events
+func (h *Handler) taskLifecycleFrame(connectionID uuid.UUID, data []byte) (*serverFrame, error) {
 	// Parse the event
 	event, err := service.ParseEvent[*service.WebsocketTaskLifecycleEventMessage](data)
 
-	// Get the WebSocket connection
-	ws, ok := h.wsMap.Load(*event.H.ConnectionID)
-	if !ok {
-		// Connection might have been closed, this is not necessarily an error
-		h.log.Debug("WebSocket connection not found for task event, skipping message",
-			"connection_id", event.H.ConnectionID,
-			"user_id", event.H.UserID,
-		)
-		return nil
+	// Skip the lifecycle events when the client did not subscribe to them, errors are always sent
+	if filter, ok := h.filters.Load(connectionID); ok && err == nil && !filter.Allows(service.StreamEventClassLifecycle) {
+		return nil, nil
 	}
 
-	var responseData []byte
-
 	// Check if the event contains an error
 	if err != nil {
 		h.log.Debug("Received task lifecycle error event",
-			"connection_id", *event.H.ConnectionID,
+			"connection_id", connectionID,
 			"user_id", event.H.UserID,
 			"error", event.Err.Error,
 		)
-		// Create error response for WebSocket client
-		responseData, err = json.Marshal(map[string]any{
-			"type":  "task_error",
-			"error": event.Err.Error,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to marshal task error response: %w", err)
-		}
-	} else {
-		// Forward the task lifecycle event
-		responseData = data
+		frame := &serverFrame{Type: FrameTaskError, Payload: map[string]any{"error": event.Err.Error}}
+		h.tagFrame(frame, connectionID, event.H, "")
+		return frame, nil
 	}
-
-	// Send response to WebSocket client with timeout to prevent blocking
-	writeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := ws.Write(writeCtx, websocket.MessageText, responseData); err != nil {
-		// WebSocket write failed - connection might be closed
-		return fmt.Errorf("failed to write task lifecycle to websocket: %w", err)
-	}
-
-	h.log.Debug("Successfully forwarded task lifecycle to WebSocket",
-		"connection_id", *event.H.ConnectionID,
-		"user_id", event.H.UserID,
-		"event_type", event.Msg.Type,
-		"has_error", event.Err != nil,
-	)
-	return nil
+	// Forward the task lifecycle event
+	frame := &serverFrame{Type: FrameTaskLifecycle, Payload: json.RawMessage(data), Legacy: json.RawMessage(data)}
+	h.tagFrame(frame, connectionID, event.H, event.Msg.Type)
+	return frame, nil
 }
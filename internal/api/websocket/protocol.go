@@ -0,0 +1,213 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+)
+
+const (
+	// SubprotocolV1 is the subprotocol of the versioned envelopes, negotiated with Sec-WebSocket-Protocol: pinazu.v1.
+	// The connections negotiating no subprotocol exchange the legacy frames, the bare JSON messages.
+	SubprotocolV1 = "pinazu.v1"
+
+	// EnvelopeVersion is the version of the envelopes sent by the gateway, the clients send at most this version
+	EnvelopeVersion = 1
+
+	// writeTimeout bounds the write of a frame, a client not reading its frames does not block the gateway
+	writeTimeout = 5 * time.Second
+)
+
+// The types of the frames sent by the clients
+const (
+	FrameAuth    = "auth"    // Authenticates a connection upgraded without credentials, payload AuthFrame
+	FramePing    = "ping"    // Answered with a pong frame
+	FrameMessage = "message" // Sends messages to an agent, payload HandlerRequestMessage
+	FrameStop    = "stop"    // Cancels the active tasks of the connection, payload StopFrame
+)
+
+// The types of the frames sent by the gateway
+const (
+	FrameSession       = "session"        // ID and resume token of the connection
+	FrameAuthenticated = "authenticated"  // User and workspace of an authenticated connection
+	FramePong          = "pong"           // Answer to a ping frame
+	FrameAccepted      = "accepted"       // A message frame was sent to its agent, only sent with the envelopes
+	FrameResponse      = "response"       // Streaming response of an agent, payload the response event
+	FrameTaskLifecycle = "task_lifecycle" // Start and end of a task, payload the lifecycle event
+	FrameTaskError     = "task_error"     // A task failed
+	FrameDropped       = "dropped"        // Events were dropped by the overflow policy, only sent with the envelopes
+	FrameError         = "error"          // A frame failed, payload ErrorPayload
+)
+
+// The codes of the error frames
+const (
+	ErrorCodeInvalidFrame       = "invalid_frame"       // The frame is not a JSON envelope
+	ErrorCodeUnsupportedVersion = "unsupported_version" // The envelope version is above EnvelopeVersion
+	ErrorCodeUnknownType        = "unknown_type"        // The frame type is unknown
+	ErrorCodeInvalidRequest     = "invalid_request"     // The payload of the frame is invalid
+	ErrorCodeAlreadyAuthed      = "already_authenticated"
+	ErrorCodeProcessingFailed   = "processing_failed" // The gateway failed to handle a valid frame
+	ErrorCodeAgentError         = "agent_error"       // The agent failed to respond
+	ErrorCodeReadFailed         = "read_failed"       // The gateway failed to read the next frame, the connection closes
+	ErrorCodeNoActiveTask       = "no_active_task"    // A stop frame found no running task to cancel
+)
+
+type (
+	// Envelope is a frame of the connections negotiating SubprotocolV1
+	Envelope struct {
+		Type      string          `json:"type"`
+		Version   int             `json:"version"`
+		RequestID string          `json:"request_id,omitempty"` // Set by the client, echoed by the frames answering its frame
+		ThreadID  *uuid.UUID      `json:"thread_id,omitempty"`  // Thread of an event frame
+		TaskID    string          `json:"task_id,omitempty"`    // Task of an event frame
+		Payload   json.RawMessage `json:"payload,omitempty"`
+	}
+
+	// ErrorPayload is the payload of an error frame
+	ErrorPayload struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// clientFrame is a frame sent by a client, decoded from an envelope or from a legacy frame
+	clientFrame struct {
+		Type      string
+		RequestID string
+		Payload   json.RawMessage
+	}
+
+	// serverFrame is a frame sent to a client, encoded as an envelope or as a legacy frame by the protocol of its
+	// connection
+	serverFrame struct {
+		Type      string
+		RequestID string
+		ThreadID  *uuid.UUID
+		TaskID    string
+		Payload   any
+		Legacy    any  // Legacy frame, the payload with its type when nil
+		V1Only    bool // Not sent to the legacy connections
+	}
+
+	// frameError is a client frame the gateway cannot handle
+	frameError struct {
+		ErrorPayload
+		legacy string // Message of the legacy error frame
+	}
+)
+
+func (e *frameError) Error() string { return e.Message }
+
+// enveloped reports whether a connection exchanges envelopes
+func enveloped(conn *websocket.Conn) bool {
+	return conn.Subprotocol() == SubprotocolV1
+}
+
+// decodeClientFrame decodes a text frame of a client. A legacy frame is a message frame unless its type is auth, ping
+// or stop, as the clients predating the envelopes send their messages without type. An envelope without version is of
+// the current version.
+func decodeClientFrame(enveloped bool, msg []byte) (clientFrame, error) {
+	if !enveloped {
+		var legacy struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(msg, &legacy); err != nil {
+			return clientFrame{}, &frameError{ErrorPayload{ErrorCodeInvalidFrame, err.Error()}, "Failed to parse message"}
+		}
+		if legacy.Type != FrameAuth && legacy.Type != FramePing && legacy.Type != FrameStop {
+			legacy.Type = FrameMessage
+		}
+		return clientFrame{Type: legacy.Type, Payload: msg}, nil
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(msg, &envelope); err != nil {
+		return clientFrame{}, &frameError{ErrorPayload{ErrorCodeInvalidFrame, err.Error()}, ""}
+	}
+	frame := clientFrame{Type: envelope.Type, RequestID: envelope.RequestID, Payload: envelope.Payload}
+	if envelope.Version > EnvelopeVersion {
+		return frame, &frameError{ErrorPayload{ErrorCodeUnsupportedVersion, fmt.Sprintf("version %d is not supported, the latest version is %d", envelope.Version, EnvelopeVersion)}, ""}
+	}
+	if len(frame.Payload) == 0 {
+		frame.Payload = json.RawMessage("{}")
+	}
+	return frame, nil
+}
+
+// encode returns the frame sent to a connection, nil when the frame is not sent to its protocol
+func (f serverFrame) encode(enveloped bool) ([]byte, error) {
+	if enveloped {
+		payload, err := json.Marshal(f.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s payload: %w", f.Type, err)
+		}
+		return json.Marshal(Envelope{
+			Type:      f.Type,
+			Version:   EnvelopeVersion,
+			RequestID: f.RequestID,
+			ThreadID:  f.ThreadID,
+			TaskID:    f.TaskID,
+			Payload:   payload,
+		})
+	}
+	if f.V1Only {
+		return nil, nil
+	}
+	if f.Legacy != nil {
+		return json.Marshal(f.Legacy)
+	}
+	legacy := map[string]any{"type": f.Type}
+	if payload, ok := f.Payload.(map[string]any); ok {
+		for k, v := range payload {
+			legacy[k] = v
+		}
+	}
+	return json.Marshal(legacy)
+}
+
+// errorFrame returns the error frame answering a client frame, with the message of the legacy connections
+func errorFrame(requestID, code, message, legacy string) serverFrame {
+	if legacy == "" {
+		legacy = message
+	}
+	return serverFrame{
+		Type:      FrameError,
+		RequestID: requestID,
+		Payload:   ErrorPayload{Code: code, Message: message},
+		Legacy:    map[string]string{"error": legacy},
+	}
+}
+
+// frameErrorFrame returns the error frame of a client frame the gateway cannot handle
+func frameErrorFrame(requestID string, err error) serverFrame {
+	var fe *frameError
+	if errors.As(err, &fe) {
+		return errorFrame(requestID, fe.Code, fe.Message, fe.legacy)
+	}
+	return errorFrame(requestID, ErrorCodeInvalidFrame, err.Error(), "")
+}
+
+// writeFrame writes a frame to a connection in its protocol
+func writeFrame(ctx context.Context, conn *websocket.Conn, frame serverFrame) error {
+	data, err := frame.encode(enveloped(conn))
+	if err != nil || data == nil {
+		return err
+	}
+	writeCtx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	if err := conn.Write(writeCtx, websocket.MessageText, data); err != nil {
+		return fmt.Errorf("failed to write %s frame: %w", frame.Type, err)
+	}
+	return nil
+}
+
+// sendFrame writes a frame answering a client, a failed write is logged as the read loop notices the closed connection
+func (h *Handler) sendFrame(ctx context.Context, conn *websocket.Conn, frame serverFrame) {
+	if err := writeFrame(ctx, conn, frame); err != nil {
+		h.log.Error("Failed to send frame", "type", frame.Type, "error", err)
+	}
+}
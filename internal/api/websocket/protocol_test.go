@@ -0,0 +1,108 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeClientFrame(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Legacy message", func(t *testing.T) {
+		msg := `{"agent_id":"00000000-0000-0000-0000-000000000001","messages":[]}`
+		frame, err := decodeClientFrame(false, []byte(msg))
+		require.NoError(t, err)
+		assert.Equal(t, FrameMessage, frame.Type)
+		assert.JSONEq(t, msg, string(frame.Payload))
+	})
+
+	t.Run("Legacy ping", func(t *testing.T) {
+		frame, err := decodeClientFrame(false, []byte(`{"type":"ping"}`))
+		require.NoError(t, err)
+		assert.Equal(t, FramePing, frame.Type)
+	})
+
+	t.Run("Legacy stop", func(t *testing.T) {
+		frame, err := decodeClientFrame(false, []byte(`{"type":"stop","task_id":"t1"}`))
+		require.NoError(t, err)
+		assert.Equal(t, FrameStop, frame.Type)
+		assert.JSONEq(t, `{"type":"stop","task_id":"t1"}`, string(frame.Payload))
+	})
+
+	t.Run("Legacy invalid JSON", func(t *testing.T) {
+		_, err := decodeClientFrame(false, []byte(`not json`))
+		assert.Equal(t, `{"error":"Failed to parse message"}`, encodeFrame(t, frameErrorFrame("", err), false))
+	})
+
+	t.Run("Envelope", func(t *testing.T) {
+		frame, err := decodeClientFrame(true, []byte(`{"type":"message","version":1,"request_id":"r1","payload":{"messages":[]}}`))
+		require.NoError(t, err)
+		assert.Equal(t, clientFrame{Type: FrameMessage, RequestID: "r1", Payload: json.RawMessage(`{"messages":[]}`)}, frame)
+	})
+
+	t.Run("Envelope without version and payload", func(t *testing.T) {
+		frame, err := decodeClientFrame(true, []byte(`{"type":"ping"}`))
+		require.NoError(t, err)
+		assert.Equal(t, clientFrame{Type: FramePing, Payload: json.RawMessage(`{}`)}, frame)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		frame, err := decodeClientFrame(true, []byte(`{"type":"ping","version":2,"request_id":"r2"}`))
+		require.Error(t, err)
+		assert.JSONEq(t,
+			`{"type":"error","version":1,"request_id":"r2","payload":{"code":"unsupported_version","message":"version 2 is not supported, the latest version is 1"}}`,
+			encodeFrame(t, frameErrorFrame(frame.RequestID, err), true),
+		)
+	})
+
+	t.Run("Invalid envelope", func(t *testing.T) {
+		_, err := decodeClientFrame(true, []byte(`[]`))
+		var fe *frameError
+		require.ErrorAs(t, err, &fe)
+		assert.Equal(t, ErrorCodeInvalidFrame, fe.Code)
+	})
+}
+
+func Test_serverFrame_encode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Map payload", func(t *testing.T) {
+		frame := serverFrame{Type: FrameAuthenticated, RequestID: "r1", Payload: map[string]any{"user_id": "u1"}}
+		assert.JSONEq(t, `{"type":"authenticated","user_id":"u1"}`, encodeFrame(t, frame, false))
+		assert.JSONEq(t, `{"type":"authenticated","version":1,"request_id":"r1","payload":{"user_id":"u1"}}`, encodeFrame(t, frame, true))
+	})
+
+	t.Run("Event payload", func(t *testing.T) {
+		event := json.RawMessage(`{"header":{},"message":{"type":"text"}}`)
+		threadID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+		frame := serverFrame{Type: FrameResponse, RequestID: "r1", ThreadID: &threadID, TaskID: "t1", Payload: event, Legacy: event}
+		assert.JSONEq(t, string(event), encodeFrame(t, frame, false))
+		assert.JSONEq(t, `{"type":"response","version":1,"request_id":"r1","thread_id":"00000000-0000-0000-0000-000000000002","task_id":"t1","payload":`+string(event)+`}`, encodeFrame(t, frame, true))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		frame := errorFrame("r1", ErrorCodeInvalidRequest, "Invalid message format", "")
+		assert.JSONEq(t, `{"error":"Invalid message format"}`, encodeFrame(t, frame, false))
+		assert.JSONEq(t, `{"type":"error","version":1,"request_id":"r1","payload":{"code":"invalid_request","message":"Invalid message format"}}`, encodeFrame(t, frame, true))
+	})
+
+	t.Run("V1 only", func(t *testing.T) {
+		frame := serverFrame{Type: FrameAccepted, Payload: map[string]any{}, V1Only: true}
+		data, err := frame.encode(false)
+		require.NoError(t, err)
+		assert.Nil(t, data)
+		assert.JSONEq(t, `{"type":"accepted","version":1,"payload":{}}`, encodeFrame(t, frame, true))
+	})
+}
+
+// encodeFrame returns a frame encoded for a legacy or an enveloped connection
+func encodeFrame(t *testing.T, frame serverFrame, enveloped bool) string {
+	t.Helper()
+	data, err := frame.encode(enveloped)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,344 @@
+package websocket
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// ConnectionsBucket is the KV bucket of the WebSocket connections of the gateway nodes
+	ConnectionsBucket = "PINAZU_WS_CONNECTIONS"
+
+	// RegistryTTL is the time the connections of a node are kept after its last refresh, the connections of a stopped
+	// node expire
+	RegistryTTL = 3 * time.Minute
+
+	// handOffSubject is the subject of the requests of a node taking over a connection of another node
+	handOffSubject = "v1.svc.api.ws.node.%s.handoff"
+
+	// registryTimeout bounds the requests to the registry and to the other nodes
+	registryTimeout = 5 * time.Second
+
+	// handOffReplyOverhead is kept free in a hand-off reply besides the events
+	handOffReplyOverhead = 1024
+
+	connectionKeyPrefix = "connections."
+	tokenKeyPrefix      = "tokens."
+)
+
+type (
+	// Registry shares the WebSocket connections of the gateway nodes in a NATS KV bucket. Each node writes the
+	// connections it owns and the keys of their resume tokens, a client resuming its connection on another node has
+	// its session handed off by the owning node.
+	Registry struct {
+		nodeID uuid.UUID
+		kv     jetstream.KeyValue
+		nc     *nats.Conn
+		log    hclog.Logger
+
+		mu      sync.Mutex
+		entries map[uuid.UUID]*registryEntry // Connections of this node
+	}
+
+	// RegisteredConnection is the registry entry of a connection
+	RegisteredConnection struct {
+		NodeID       uuid.UUID `json:"node_id"`
+		ConnectionID uuid.UUID `json:"connection_id"`
+		UserID       uuid.UUID `json:"user_id"`
+	}
+
+	// registryEntry is a connection of this node with the revisions of its keys, a key written since by another node
+	// is left to it
+	registryEntry struct {
+		connection    RegisteredConnection
+		revision      uint64
+		tokenKey      string
+		tokenRevision uint64
+	}
+
+	// handOffRequest asks the owning node of a connection for its session
+	handOffRequest struct {
+		Token  string    `json:"token"`
+		UserID uuid.UUID `json:"user_id"`
+	}
+
+	// handOffReply holds the events buffered for the connection handed off, oldest first
+	handOffReply struct {
+		Events []handedOffEvent `json:"events,omitempty"`
+		Error  string           `json:"error,omitempty"`
+	}
+
+	// handedOffEvent is an event buffered for a connection handed off
+	handedOffEvent struct {
+		Subject string          `json:"subject"`
+		Data    json.RawMessage `json:"data"`
+	}
+)
+
+// NewRegistry opens the connections bucket and keeps the connections of this node in it until ctx is done
+func NewRegistry(ctx context.Context, js *service.JetStreamService, nc *nats.Conn, log hclog.Logger) (*Registry, error) {
+	kv, err := js.CreateOrUpdateKeyValue(jetstream.KeyValueConfig{
+		Bucket:      ConnectionsBucket,
+		Description: "WebSocket connections of the gateway nodes",
+		TTL:         RegistryTTL,
+		Storage:     jetstream.MemoryStorage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := &Registry{nodeID: uuid.New(), kv: kv, nc: nc, log: log, entries: map[uuid.UUID]*registryEntry{}}
+	go r.refreshLoop(ctx)
+	return r, nil
+}
+
+// NodeID returns the ID of this gateway node
+func (r *Registry) NodeID() uuid.UUID {
+	return r.nodeID
+}
+
+// SetRegistry shares the connections of the handler with the other gateway nodes, and hands off its connections
+// resumed on another node
+func (h *Handler) SetRegistry(registry *Registry) error {
+	if _, err := h.nc.Subscribe(fmt.Sprintf(handOffSubject, registry.nodeID), h.handOffCallback); err != nil {
+		return fmt.Errorf("failed to subscribe to the connection hand-offs: %w", err)
+	}
+	h.registry = registry
+	return nil
+}
+
+// register records a connection of this node with its current resume token, the previous token is dropped
+func (r *Registry) register(connectionID, userID uuid.UUID, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), registryTimeout)
+	defer cancel()
+	connection := RegisteredConnection{NodeID: r.nodeID, ConnectionID: connectionID, UserID: userID}
+	value, err := json.Marshal(connection)
+	if err != nil {
+		r.log.Error("Failed to encode the registered connection", "connection_id", connectionID, "error", err)
+		return
+	}
+	revision, err := r.kv.Put(ctx, connectionKeyPrefix+connectionID.String(), value)
+	if err != nil {
+		r.log.Error("Failed to register the connection", "connection_id", connectionID, "error", err)
+		return
+	}
+	tokenKey := tokenKeyPrefix + tokenHash(token)
+	tokenRevision, err := r.kv.Put(ctx, tokenKey, value)
+	if err != nil {
+		r.log.Error("Failed to register the resume token of the connection", "connection_id", connectionID, "error", err)
+	}
+
+	r.mu.Lock()
+	previous := r.entries[connectionID]
+	r.entries[connectionID] = &registryEntry{connection: connection, revision: revision, tokenKey: tokenKey, tokenRevision: tokenRevision}
+	r.mu.Unlock()
+	if previous != nil && previous.tokenRevision != 0 {
+		r.delete(ctx, previous.tokenKey, previous.tokenRevision)
+	}
+}
+
+// unregister drops a connection of this node from the registry. The keys are deleted in the background, they are
+// kept when another node wrote them since.
+func (r *Registry) unregister(connectionID uuid.UUID) {
+	r.mu.Lock()
+	entry, ok := r.entries[connectionID]
+	delete(r.entries, connectionID)
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), registryTimeout)
+		defer cancel()
+		r.delete(ctx, connectionKeyPrefix+connectionID.String(), entry.revision)
+		if entry.tokenRevision != 0 {
+			r.delete(ctx, entry.tokenKey, entry.tokenRevision)
+		}
+	}()
+}
+
+// delete deletes a key of the registry unless it was written after revision
+func (r *Registry) delete(ctx context.Context, key string, revision uint64) {
+	if err := r.kv.Delete(ctx, key, jetstream.LastRevision(revision)); err != nil && !errors.Is(err, jetstream.ErrKeyExists) {
+		r.log.Debug("Failed to delete the registry key", "key", key, "error", err)
+	}
+}
+
+// lookupToken returns the connection of a resume token, on any node
+func (r *Registry) lookupToken(ctx context.Context, token string) (RegisteredConnection, error) {
+	var connection RegisteredConnection
+	entry, err := r.kv.Get(ctx, tokenKeyPrefix+tokenHash(token))
+	if err != nil {
+		return connection, err
+	}
+	if err := json.Unmarshal(entry.Value(), &connection); err != nil {
+		return connection, fmt.Errorf("invalid registered connection: %w", err)
+	}
+	return connection, nil
+}
+
+// handOff asks the owning node of a connection for its session, it returns the events buffered for the connection
+func (r *Registry) handOff(ctx context.Context, nodeID uuid.UUID, token string, userID uuid.UUID) ([]*nats.Msg, error) {
+	request, err := json.Marshal(handOffRequest{Token: token, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := r.nc.RequestWithContext(ctx, fmt.Sprintf(handOffSubject, nodeID), request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request the hand-off: %w", err)
+	}
+	var reply handOffReply
+	if err := json.Unmarshal(msg.Data, &reply); err != nil {
+		return nil, fmt.Errorf("invalid hand-off reply: %w", err)
+	}
+	if reply.Error != "" {
+		return nil, errors.New(reply.Error)
+	}
+	events := make([]*nats.Msg, 0, len(reply.Events))
+	for _, event := range reply.Events {
+		events = append(events, &nats.Msg{Subject: event.Subject, Data: event.Data})
+	}
+	return events, nil
+}
+
+// refreshLoop rewrites the connections of this node before they expire, until ctx is done
+func (r *Registry) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(RegistryTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+// refresh rewrites the connections of this node, a connection taken over by another node is dropped
+func (r *Registry) refresh(ctx context.Context) {
+	r.mu.Lock()
+	entries := make([]*registryEntry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, entry)
+	}
+	r.mu.Unlock()
+
+	for _, entry := range entries {
+		value, err := json.Marshal(entry.connection)
+		if err != nil {
+			continue
+		}
+		revision, err := r.kv.Update(ctx, connectionKeyPrefix+entry.connection.ConnectionID.String(), value, entry.revision)
+		if err != nil && !errors.Is(err, jetstream.ErrKeyExists) {
+			r.log.Warn("Failed to refresh the registered connection", "connection_id", entry.connection.ConnectionID, "error", err)
+			continue
+		}
+		if err != nil {
+			r.log.Debug("Connection taken over by another node", "connection_id", entry.connection.ConnectionID)
+			r.mu.Lock()
+			if r.entries[entry.connection.ConnectionID] == entry {
+				delete(r.entries, entry.connection.ConnectionID)
+			}
+			r.mu.Unlock()
+			continue
+		}
+		tokenRevision := entry.tokenRevision
+		if tokenRevision != 0 {
+			if tokenRevision, err = r.kv.Update(ctx, entry.tokenKey, value, entry.tokenRevision); err != nil {
+				tokenRevision = 0
+			}
+		}
+		r.mu.Lock()
+		if r.entries[entry.connection.ConnectionID] == entry {
+			entry.revision, entry.tokenRevision = revision, tokenRevision
+		}
+		r.mu.Unlock()
+	}
+}
+
+// resumeRemote takes over a connection of another node resumed by its client on this node. The connection waits
+// here for the events buffered by its owning node, the events sent meanwhile are buffered on both nodes and may be
+// replayed twice.
+func (h *Handler) resumeRemote(ctx context.Context, token string, userID uuid.UUID) (uuid.UUID, bool) {
+	if h.registry == nil {
+		return uuid.Nil, false
+	}
+	ctx, cancel := context.WithTimeout(ctx, registryTimeout)
+	defer cancel()
+	owner, err := h.registry.lookupToken(ctx, token)
+	if err != nil || owner.NodeID == h.registry.nodeID || owner.UserID != userID {
+		h.log.Debug("Resume token not registered by another node", "node_id", owner.NodeID, "error", err)
+		return uuid.Nil, false
+	}
+	if !h.resume.adopt(userID, owner.ConnectionID) {
+		return uuid.Nil, false
+	}
+	events, err := h.registry.handOff(ctx, owner.NodeID, token, userID)
+	if err != nil {
+		h.log.Info("Failed to take over the connection of another node", "connection_id", owner.ConnectionID, "node_id", owner.NodeID, "error", err)
+		h.resume.close(owner.ConnectionID)
+		return uuid.Nil, false
+	}
+	h.resume.prepend(owner.ConnectionID, events)
+	h.log.Info("Connection taken over from another node", "connection_id", owner.ConnectionID, "node_id", owner.NodeID, "events", len(events))
+	return owner.ConnectionID, true
+}
+
+// handOffCallback hands off a connection of this node resumed on another node, with the events buffered for it. An
+// open connection is closed as replaced, its client reconnected before the gateway saw it closed.
+func (h *Handler) handOffCallback(msg *nats.Msg) {
+	reply := func(reply handOffReply) {
+		data, err := json.Marshal(reply)
+		if err == nil {
+			err = msg.Respond(data)
+		}
+		if err != nil {
+			h.log.Error("Failed to answer the connection hand-off", "error", err)
+		}
+	}
+	var request handOffRequest
+	if err := json.Unmarshal(msg.Data, &request); err != nil {
+		reply(handOffReply{Error: "invalid hand-off request"})
+		return
+	}
+	connectionID, ok := h.resume.resume(request.Token, request.UserID)
+	if !ok {
+		reply(handOffReply{Error: "unknown or expired resume token"})
+		return
+	}
+	h.replaceConnection(connectionID)
+	pending := h.resume.take(connectionID)
+	h.forgetConnection(connectionID)
+
+	// The events beyond the NATS payload limit are dropped, oldest first
+	events := make([]handedOffEvent, 0, len(pending))
+	size := 0
+	for _, event := range pending {
+		events = append(events, handedOffEvent{Subject: event.Subject, Data: event.Data})
+		size += len(event.Subject) + len(event.Data) + 32
+	}
+	for len(events) > 0 && int64(size) > h.nc.MaxPayload()-handOffReplyOverhead {
+		size -= len(events[0].Subject) + len(events[0].Data) + 32
+		events = events[1:]
+	}
+	h.log.Info("Connection handed off to another node", "connection_id", connectionID, "events", len(events), "dropped", len(pending)-len(events))
+	reply(handOffReply{Events: events})
+}
+
+// tokenHash returns the registry key of a resume token, the token itself is not shared
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,300 @@
+package websocket
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// ResumeParam is the query parameter of the resume token of a reconnecting client
+	ResumeParam = "resume"
+
+	// ResumeTTL is the time a disconnected connection can be resumed, its events are buffered meanwhile
+	ResumeTTL = 2 * time.Minute
+
+	// ResumeMaxUserEvents bounds the events buffered for the disconnected connections of a user, the oldest are dropped
+	ResumeMaxUserEvents = 1000
+
+	// StatusReplaced closes a connection resumed by a new connection of its client
+	StatusReplaced websocket.StatusCode = 4409
+
+	resumeTokenPrefix = "wr_"
+)
+
+type (
+	// resumeState is the state of the session of a connection
+	resumeState int
+
+	// resumeSession holds the events of a connection that could not be delivered, until its client resumes it
+	resumeSession struct {
+		token        string
+		userID       uuid.UUID
+		connectionID uuid.UUID
+		state        resumeState
+		pending      []*nats.Msg
+		timer        *time.Timer
+	}
+
+	// resumeStore holds the sessions of the connections of the gateway. A session outlives its connection for the TTL,
+	// the events addressed to the connection are buffered meanwhile and replayed to the client resuming it.
+	resumeStore struct {
+		mu            sync.Mutex
+		byToken       map[string]*resumeSession
+		byConn        map[uuid.UUID]*resumeSession
+		byUser        map[uuid.UUID]map[uuid.UUID]*resumeSession // Sessions of each user, by connection
+		userEvents    map[uuid.UUID]int                          // Events buffered for each user
+		ttl           time.Duration
+		maxUserEvents int
+		hold          func(userID uuid.UUID)       // Keeps the events of a user coming while a session waits for its client
+		release       func(userID uuid.UUID)       // Releases what hold kept, once the session is resumed or expired
+		expired       func(connectionID uuid.UUID) // Called when a waiting session is dropped
+	}
+)
+
+const (
+	resumeAttached resumeState = iota // The connection is open
+	resumeDetached                    // The connection is closed, the events are buffered until the TTL
+	resumeResuming                    // A new connection replays the buffered events
+)
+
+// newResumeStore returns a store keeping the sessions of the closed connections for the TTL. The callbacks are called
+// under the lock of the store.
+func newResumeStore(ttl time.Duration, maxUserEvents int, hold, release func(uuid.UUID), expired func(uuid.UUID)) *resumeStore {
+	return &resumeStore{
+		byToken:       map[string]*resumeSession{},
+		byConn:        map[uuid.UUID]*resumeSession{},
+		byUser:        map[uuid.UUID]map[uuid.UUID]*resumeSession{},
+		userEvents:    map[uuid.UUID]int{},
+		ttl:           ttl,
+		maxUserEvents: maxUserEvents,
+		hold:          hold,
+		release:       release,
+		expired:       expired,
+	}
+}
+
+// issue returns a new resume token of an open connection of a user, the previous token of the connection is revoked
+func (s *resumeStore) issue(userID, connectionID uuid.UUID) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.byConn[connectionID]
+	if !ok {
+		session = &resumeSession{connectionID: connectionID, state: resumeAttached}
+		s.byConn[connectionID] = session
+	}
+	delete(s.byToken, session.token)
+	if session.userID != userID || !ok {
+		s.dropPending(session)
+		s.unindex(session)
+		session.userID = userID
+		if s.byUser[userID] == nil {
+			s.byUser[userID] = map[uuid.UUID]*resumeSession{}
+		}
+		s.byUser[userID][connectionID] = session
+	}
+	session.token = newResumeToken()
+	s.byToken[session.token] = session
+	return session.token
+}
+
+// resume returns the connection of a resume token of a user, and starts replaying its events. The token is single
+// use. A connection still open is resumed as well, its client reconnected before the gateway saw it closed.
+func (s *resumeStore) resume(token string, userID uuid.UUID) (uuid.UUID, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.byToken[token]
+	if !ok || session.userID != userID || session.state == resumeResuming {
+		return uuid.Nil, false
+	}
+	if session.state == resumeDetached {
+		if !session.timer.Stop() {
+			// The session expired while the client was reconnecting
+			return uuid.Nil, false
+		}
+		// The new connection keeps the events of the user from now on
+		s.release(userID)
+	}
+	delete(s.byToken, token)
+	session.token = ""
+	session.state = resumeResuming
+	return session.connectionID, true
+}
+
+// adopt starts the session of a connection of another node resumed on this node, the events of the connection are
+// buffered until it replays them. It returns false when the connection already has a session here.
+func (s *resumeStore) adopt(userID, connectionID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.byConn[connectionID]; ok {
+		return false
+	}
+	session := &resumeSession{userID: userID, connectionID: connectionID, state: resumeResuming}
+	s.byConn[connectionID] = session
+	if s.byUser[userID] == nil {
+		s.byUser[userID] = map[uuid.UUID]*resumeSession{}
+	}
+	s.byUser[userID][connectionID] = session
+	return true
+}
+
+// prepend buffers the events handed off by the previous node of an adopted connection before the events buffered
+// since, the oldest are dropped beyond the events of the user
+func (s *resumeStore) prepend(connectionID uuid.UUID, msgs []*nats.Msg) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.byConn[connectionID]
+	if !ok || len(msgs) == 0 {
+		return
+	}
+	session.pending = append(append([]*nats.Msg{}, msgs...), session.pending...)
+	s.userEvents[session.userID] += len(msgs)
+	for s.userEvents[session.userID] > s.maxUserEvents && len(session.pending) > 0 {
+		session.pending = session.pending[1:]
+		s.userEvents[session.userID]--
+	}
+}
+
+// take drops the session of a connection handed off to another node, it returns the events buffered for it
+func (s *resumeStore) take(connectionID uuid.UUID) []*nats.Msg {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.byConn[connectionID]
+	if !ok {
+		return nil
+	}
+	pending := session.pending
+	s.remove(session)
+	return pending
+}
+
+// connections returns the connections of a user with a session, open or waiting to be resumed
+func (s *resumeStore) connections(userID uuid.UUID) []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	connections := make([]uuid.UUID, 0, len(s.byUser[userID]))
+	for connectionID := range s.byUser[userID] {
+		connections = append(connections, connectionID)
+	}
+	return connections
+}
+
+// drain returns the events buffered for a resuming connection. Once none is left, the connection is attached with
+// attach, called under the lock of the store for no event to be buffered after the last replayed event.
+func (s *resumeStore) drain(connectionID uuid.UUID, attach func()) []*nats.Msg {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.byConn[connectionID]
+	if !ok {
+		attach()
+		return nil
+	}
+	if len(session.pending) == 0 {
+		session.state = resumeAttached
+		attach()
+		return nil
+	}
+	pending := session.pending
+	s.dropPending(session)
+	return pending
+}
+
+// buffer keeps an event of a connection for its client to replay it. The events of an open connection are only kept
+// when undelivered is set, their write failed. It returns false when the event is not kept.
+func (s *resumeStore) buffer(connectionID uuid.UUID, msg *nats.Msg, undelivered bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.byConn[connectionID]
+	if !ok || (session.state == resumeAttached && !undelivered) {
+		return false
+	}
+	if s.userEvents[session.userID] >= s.maxUserEvents {
+		if len(session.pending) == 0 {
+			return false
+		}
+		session.pending = session.pending[1:]
+		s.userEvents[session.userID]--
+	}
+	session.pending = append(session.pending, msg)
+	s.userEvents[session.userID]++
+	return true
+}
+
+// detach keeps the session of a closed connection for the TTL
+func (s *resumeStore) detach(connectionID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.byConn[connectionID]
+	if !ok || session.state == resumeDetached {
+		return
+	}
+	if session.token == "" {
+		// The connection closed while replaying, its client can no longer resume it
+		s.remove(session)
+		return
+	}
+	session.state = resumeDetached
+	s.hold(session.userID)
+	session.timer = time.AfterFunc(s.ttl, func() { s.expire(session) })
+}
+
+// close drops the session of a connection its client closed, it will not be resumed
+func (s *resumeStore) close(connectionID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if session, ok := s.byConn[connectionID]; ok && session.state != resumeDetached {
+		s.remove(session)
+	}
+}
+
+// expire drops a session whose client did not resume it in time
+func (s *resumeStore) expire(session *resumeSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byConn[session.connectionID] != session || session.state != resumeDetached {
+		return
+	}
+	s.remove(session)
+	s.release(session.userID)
+	s.expired(session.connectionID)
+}
+
+// remove drops a session and its buffered events
+func (s *resumeStore) remove(session *resumeSession) {
+	s.dropPending(session)
+	delete(s.byToken, session.token)
+	delete(s.byConn, session.connectionID)
+	s.unindex(session)
+}
+
+// unindex drops a session from the sessions of its user
+func (s *resumeStore) unindex(session *resumeSession) {
+	delete(s.byUser[session.userID], session.connectionID)
+	if len(s.byUser[session.userID]) == 0 {
+		delete(s.byUser, session.userID)
+	}
+}
+
+// dropPending drops the buffered events of a session
+func (s *resumeStore) dropPending(session *resumeSession) {
+	if len(session.pending) == 0 {
+		return
+	}
+	s.userEvents[session.userID] -= len(session.pending)
+	if s.userEvents[session.userID] <= 0 {
+		delete(s.userEvents, session.userID)
+	}
+	session.pending = nil
+}
+
+// newResumeToken returns a random resume token
+func newResumeToken() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return resumeTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+}
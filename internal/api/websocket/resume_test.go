@@ -0,0 +1,162 @@
+package websocket
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumeStore(t *testing.T) {
+	t.Parallel()
+
+	newStore := func(ttl time.Duration, maxUserEvents int) (*resumeStore, map[uuid.UUID]int, chan uuid.UUID) {
+		holds := map[uuid.UUID]int{}
+		expired := make(chan uuid.UUID, 1)
+		store := newResumeStore(ttl, maxUserEvents,
+			func(userID uuid.UUID) { holds[userID]++ },
+			func(userID uuid.UUID) { holds[userID]-- },
+			func(connectionID uuid.UUID) { expired <- connectionID },
+		)
+		return store, holds, expired
+	}
+	msg := func(data string) *nats.Msg { return &nats.Msg{Data: []byte(data)} }
+	userID, connectionID := uuid.New(), uuid.New()
+
+	t.Run("Resume a closed connection", func(t *testing.T) {
+		store, holds, _ := newStore(time.Minute, 10)
+		token := store.issue(userID, connectionID)
+		assert.True(t, strings.HasPrefix(token, resumeTokenPrefix))
+
+		// The events of an open connection are only kept when their write failed
+		assert.False(t, store.buffer(connectionID, msg("delivered"), false))
+		assert.True(t, store.buffer(connectionID, msg("undelivered"), true))
+
+		store.detach(connectionID)
+		assert.Equal(t, 1, holds[userID])
+		assert.True(t, store.buffer(connectionID, msg("missed"), false))
+
+		// Another user cannot resume the connection
+		_, ok := store.resume(token, uuid.New())
+		assert.False(t, ok)
+
+		resumedID, ok := store.resume(token, userID)
+		assert.True(t, ok)
+		assert.Equal(t, connectionID, resumedID)
+		assert.Equal(t, 0, holds[userID])
+
+		// The events coming while replaying are replayed as well, then the connection is attached
+		assert.True(t, store.buffer(connectionID, msg("replaying"), false))
+		attached := false
+		var replayed []string
+		for {
+			pending := store.drain(connectionID, func() { attached = true })
+			if len(pending) == 0 {
+				break
+			}
+			for _, m := range pending {
+				replayed = append(replayed, string(m.Data))
+			}
+		}
+		assert.True(t, attached)
+		assert.Equal(t, []string{"undelivered", "missed", "replaying"}, replayed)
+		assert.False(t, store.buffer(connectionID, msg("delivered"), false))
+
+		// The token is single use
+		_, ok = store.resume(token, userID)
+		assert.False(t, ok)
+		assert.NotEqual(t, token, store.issue(userID, connectionID))
+	})
+
+	t.Run("Expired connection", func(t *testing.T) {
+		store, holds, expired := newStore(10*time.Millisecond, 10)
+		token := store.issue(userID, connectionID)
+		store.detach(connectionID)
+		assert.Equal(t, connectionID, <-expired)
+		assert.Equal(t, 0, holds[userID])
+
+		_, ok := store.resume(token, userID)
+		assert.False(t, ok)
+		assert.False(t, store.buffer(connectionID, msg("missed"), false))
+	})
+
+	t.Run("Connection closed by its client", func(t *testing.T) {
+		store, _, _ := newStore(time.Minute, 10)
+		token := store.issue(userID, connectionID)
+		store.close(connectionID)
+		_, ok := store.resume(token, userID)
+		assert.False(t, ok)
+	})
+
+	t.Run("Events bounded per user", func(t *testing.T) {
+		store, _, _ := newStore(time.Minute, 2)
+		otherID := uuid.New()
+		store.issue(userID, connectionID)
+		token := store.issue(userID, otherID)
+		store.detach(connectionID)
+		store.detach(otherID)
+
+		assert.True(t, store.buffer(connectionID, msg("first"), false))
+		assert.True(t, store.buffer(otherID, msg("second"), false))
+		// The oldest event of the connection is dropped for the new one
+		assert.True(t, store.buffer(otherID, msg("third"), false))
+		assert.Equal(t, 2, store.userEvents[userID])
+
+		_, ok := store.resume(token, userID)
+		assert.True(t, ok)
+		pending := store.drain(otherID, func() {})
+		if assert.Len(t, pending, 1) {
+			assert.Equal(t, "third", string(pending[0].Data))
+		}
+		assert.Equal(t, 1, store.userEvents[userID])
+	})
+
+	t.Run("Connections of a user", func(t *testing.T) {
+		store, _, _ := newStore(time.Minute, 10)
+		otherID, otherUserID := uuid.New(), uuid.New()
+		store.issue(userID, connectionID)
+		store.issue(userID, otherID)
+		assert.ElementsMatch(t, []uuid.UUID{connectionID, otherID}, store.connections(userID))
+
+		// A connection waiting to be resumed is still a connection of its user
+		store.detach(otherID)
+		assert.ElementsMatch(t, []uuid.UUID{connectionID, otherID}, store.connections(userID))
+
+		// A connection authenticated as another user moves to its connections
+		store.issue(otherUserID, connectionID)
+		assert.Equal(t, []uuid.UUID{otherID}, store.connections(userID))
+		assert.Equal(t, []uuid.UUID{connectionID}, store.connections(otherUserID))
+
+		store.close(connectionID)
+		assert.Empty(t, store.connections(otherUserID))
+	})
+
+	t.Run("Connection handed off to another node", func(t *testing.T) {
+		owner, holds, _ := newStore(time.Minute, 10)
+		node, _, _ := newStore(time.Minute, 10)
+		token := owner.issue(userID, connectionID)
+		owner.detach(connectionID)
+		owner.buffer(connectionID, msg("missed"), false)
+
+		// The new node buffers the events of the connection while the owning node hands it off
+		assert.True(t, node.adopt(userID, connectionID))
+		assert.False(t, node.adopt(userID, connectionID))
+		assert.True(t, node.buffer(connectionID, msg("meanwhile"), false))
+		_, ok := owner.resume(token, userID)
+		assert.True(t, ok)
+		node.prepend(connectionID, owner.take(connectionID))
+		assert.Equal(t, 0, holds[userID])
+		assert.Empty(t, owner.connections(userID))
+		assert.Equal(t, []uuid.UUID{connectionID}, node.connections(userID))
+
+		pending := node.drain(connectionID, func() {})
+		if assert.Len(t, pending, 2) {
+			assert.Equal(t, "missed", string(pending[0].Data))
+			assert.Equal(t, "meanwhile", string(pending[1].Data))
+		}
+		assert.Empty(t, node.drain(connectionID, func() {}))
+	})
+}
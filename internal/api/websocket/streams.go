@@ -0,0 +1,92 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/service"
+)
+
+// userStreamBuffer is the number of events of a user waiting to be queued for its connections
+const userStreamBuffer = 1024
+
+// userStream forwards the responses and the task lifecycle events of a user to its connections on the gateway, it is
+// kept while the user has an open connection or a connection waiting to be resumed
+type userStream struct {
+	refs   int
+	subs   []*nats.Subscription
+	cancel context.CancelFunc
+}
+
+// acquireUserStream starts forwarding the events of a user, or keeps forwarding them for one more holder
+func (h *Handler) acquireUserStream(userID uuid.UUID) error {
+	h.streamsMu.Lock()
+	defer h.streamsMu.Unlock()
+	if stream, ok := h.streams[userID]; ok {
+		stream.refs++
+		return nil
+	}
+
+	// Create a buffered channel for responses to handle bursts, it is never closed for NATS not to send on a closed
+	// channel. The events are moved to the queues of the connections without waiting for their clients.
+	responseChan := make(chan *nats.Msg, userStreamBuffer)
+	stream := &userStream{refs: 1}
+
+	// Subscribe to the user's response subjects using ChanSubscribe
+	event := service.WebsocketResponseEventMessage{}
+	sub, err := h.nc.ChanSubscribe(event.SubjectWithUser(userID).String(), responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to response channel: %w", err)
+	}
+	stream.subs = append(stream.subs, sub)
+
+	// Subscribe to task lifecycle events
+	taskEvent := service.WebsocketTaskLifecycleEventMessage{}
+	taskSub, err := h.nc.ChanSubscribe(taskEvent.SubjectWithUser(userID).String(), responseChan)
+	if err != nil {
+		h.unsubscribe(userID, stream.subs)
+		return fmt.Errorf("failed to subscribe to task lifecycle channel: %w", err)
+	}
+	stream.subs = append(stream.subs, taskSub)
+
+	// Start goroutine to handle messages from the channel and forward to WebSocket until the stream is released
+	ctx, cancel := context.WithCancel(h.ctx)
+	stream.cancel = cancel
+	go h.handleUserMessages(ctx, responseChan)
+	h.streams[userID] = stream
+	return nil
+}
+
+// releaseUserStream stops forwarding the events of a user once its last holder releases it
+func (h *Handler) releaseUserStream(userID uuid.UUID) {
+	h.streamsMu.Lock()
+	defer h.streamsMu.Unlock()
+	stream, ok := h.streams[userID]
+	if !ok {
+		return
+	}
+	if stream.refs--; stream.refs > 0 {
+		return
+	}
+	h.unsubscribe(userID, stream.subs)
+	stream.cancel()
+	delete(h.streams, userID)
+}
+
+// holdUserStream keeps the events of a user forwarded while one of its connections waits to be resumed
+func (h *Handler) holdUserStream(userID uuid.UUID) {
+	if err := h.acquireUserStream(userID); err != nil {
+		h.log.Error("Failed to keep the events of the user for a resumable connection", "user_id", userID, "error", err)
+	}
+}
+
+// unsubscribe stops the subscriptions to the events of a user
+func (h *Handler) unsubscribe(userID uuid.UUID, subs []*nats.Subscription) {
+	for _, sub := range subs {
+		if err := sub.Unsubscribe(); err != nil {
+			h.log.Error("Failed to unsubscribe", "user_id", userID, "subject", sub.Subject, "error", err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+type (
+	// connThreads holds the current task of each thread of a connection, with the request of the client that started
+	// it. A connection sends requests for several threads at once, the events of each thread are tagged with their
+	// request, and its client stops the tasks it knows of.
+	connThreads struct {
+		mu      sync.Mutex
+		threads map[uuid.UUID]*threadTask
+	}
+
+	// threadTask is the current task of a thread
+	threadTask struct {
+		requestID string
+		taskID    string
+	}
+
+	// StopFrame selects the tasks cancelled by a stop frame, every active task of the connection when empty, such as
+	// {"type":"stop","thread_id":"..."}
+	StopFrame struct {
+		Type     string     `json:"type,omitempty"` // stop, set by the legacy frames
+		ThreadID *uuid.UUID `json:"thread_id,omitempty"`
+		TaskID   string     `json:"task_id,omitempty"`
+	}
+
+	// activeTask is a task running in a thread of a connection
+	activeTask struct {
+		threadID uuid.UUID
+		taskID   string
+	}
+)
+
+// tagFrame tags the frame of an event with the thread, the task and the client request of the event. The events
+// built without the request, such as the events following a tool run, take the request of their thread. The events of
+// the tasks started by the other connections of the user are not tagged with a request, unknown to this client.
+func (h *Handler) tagFrame(frame *serverFrame, connectionID uuid.UUID, header *service.EventHeaders, lifecycleType string) {
+	if header == nil {
+		return
+	}
+	frame.ThreadID = header.ThreadID
+	if header.TaskID != nil {
+		frame.TaskID = *header.TaskID
+	}
+	own := header.ConnectionID != nil && *header.ConnectionID == connectionID
+	if own {
+		frame.RequestID = header.RequestID
+	}
+	if header.ThreadID == nil {
+		return
+	}
+
+	threads, _ := h.threads.LoadOrStore(connectionID, &connThreads{threads: map[uuid.UUID]*threadTask{}})
+	threads.mu.Lock()
+	defer threads.mu.Unlock()
+	thread, ok := threads.threads[*header.ThreadID]
+	if !ok {
+		thread = &threadTask{}
+		threads.threads[*header.ThreadID] = thread
+	}
+	if frame.RequestID != "" {
+		thread.requestID = frame.RequestID
+	} else if own {
+		frame.RequestID = thread.requestID
+	}
+	// The sub tasks of a task run in its thread with sub task lifecycle events, the task of the thread is the started
+	// task, or the first task seen by a connection resumed while it runs
+	if frame.TaskID != "" && (thread.taskID == "" || lifecycleType == "task_start") {
+		thread.taskID = frame.TaskID
+	}
+	// The next request of the thread starts a new task
+	if lifecycleType == "task_stop" {
+		delete(threads.threads, *header.ThreadID)
+	}
+}
+
+// activeTasks returns the tasks running in the threads of a connection, the task of a thread when threadID is set and
+// the task taskID when set
+func (h *Handler) activeTasks(connectionID uuid.UUID, threadID *uuid.UUID, taskID string) []activeTask {
+	threads, ok := h.threads.Load(connectionID)
+	if !ok {
+		return nil
+	}
+	threads.mu.Lock()
+	defer threads.mu.Unlock()
+	var tasks []activeTask
+	for id, thread := range threads.threads {
+		if thread.taskID == "" || (threadID != nil && id != *threadID) || (taskID != "" && thread.taskID != taskID) {
+			continue
+		}
+		tasks = append(tasks, activeTask{threadID: id, taskID: thread.taskID})
+	}
+	return tasks
+}
+
+// followsTask reports whether the task of an event is the active task of its thread on a connection
+func (h *Handler) followsTask(connectionID uuid.UUID, header *service.EventHeaders) bool {
+	if header.ThreadID == nil || header.TaskID == nil {
+		return false
+	}
+	threads, ok := h.threads.Load(connectionID)
+	if !ok {
+		return false
+	}
+	threads.mu.Lock()
+	defer threads.mu.Unlock()
+	thread, ok := threads.threads[*header.ThreadID]
+	return ok && thread.taskID == *header.TaskID
+}
+
+// stopTasks cancels the active tasks of a connection selected by a stop frame. The task service cancels their runs and
+// stops the model requests of their agents, their task_stop events end them on the connection.
+func (h *Handler) stopTasks(ctx context.Context, conn *websocket.Conn, connectionID uuid.UUID, user connAuth, frame clientFrame) {
+	var stop StopFrame
+	if err := json.Unmarshal(frame.Payload, &stop); err != nil {
+		h.sendFrame(ctx, conn, errorFrame(frame.RequestID, ErrorCodeInvalidRequest, "Invalid stop format", ""))
+		return
+	}
+	tasks := h.activeTasks(connectionID, stop.ThreadID, stop.TaskID)
+	if len(tasks) == 0 {
+		h.sendFrame(ctx, conn, errorFrame(frame.RequestID, ErrorCodeNoActiveTask, "No active task to stop", ""))
+		return
+	}
+
+	taskIDs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		event := service.NewEvent(&service.TaskCancelEventMessage{}, &service.EventHeaders{
+			UserID:       user.userID,
+			WorkspaceID:  user.workspaceID,
+			ThreadID:     &task.threadID,
+			TaskID:       &task.taskID,
+			ConnectionID: &connectionID,
+			RequestID:    frame.RequestID,
+		}, &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		})
+		if err := event.Publish(h.nc); err != nil {
+			h.log.Error("Failed to publish task cancel event", "connection_id", connectionID, "task_id", task.taskID, "error", err)
+			h.sendFrame(ctx, conn, errorFrame(frame.RequestID, ErrorCodeProcessingFailed, "Failed to stop task", ""))
+			return
+		}
+		taskIDs = append(taskIDs, task.taskID)
+	}
+	h.log.Debug("Stopped the active tasks", "connection_id", connectionID, "task_ids", taskIDs)
+	h.sendFrame(ctx, conn, serverFrame{
+		Type:      FrameAccepted,
+		RequestID: frame.RequestID,
+		Payload:   map[string]any{"task_ids": taskIDs},
+		V1Only:    true,
+	})
+}
+
+// forgetConnection drops what the gateway keeps for a connection that will not be resumed
+func (h *Handler) forgetConnection(connectionID uuid.UUID) {
+	h.filters.Delete(connectionID)
+	h.fanouts.Delete(connectionID)
+	if h.registry != nil {
+		h.registry.unregister(connectionID)
+	}
+	h.threads.Delete(connectionID)
+}
@@ -0,0 +1,78 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_tagFrame(t *testing.T) {
+	t.Parallel()
+
+	handler := NewHandler(context.Background(), nil, nil, utils.NewSyncMap[uuid.UUID, *websocket.Conn](), setupTestLogger(t))
+	connectionID := uuid.New()
+	threadA, threadB := uuid.New(), uuid.New()
+	taskA, taskB := "task-a", "task-b"
+
+	tag := func(header *service.EventHeaders, lifecycleType string) serverFrame {
+		frame := serverFrame{Type: FrameResponse}
+		handler.tagFrame(&frame, connectionID, header, lifecycleType)
+		return frame
+	}
+
+	// Two threads started by two requests of the same connection
+	frame := tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadA, TaskID: &taskA, RequestID: "r1"}, "task_start")
+	assert.Equal(t, serverFrame{Type: FrameResponse, RequestID: "r1", ThreadID: &threadA, TaskID: taskA}, frame)
+	tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadB, TaskID: &taskB, RequestID: "r2"}, "task_start")
+
+	// The events built without the request take the request of their thread
+	assert.Equal(t, "r1", tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadA, TaskID: &taskA}, "").RequestID)
+	assert.Equal(t, "r2", tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadB, TaskID: &taskB}, "").RequestID)
+
+	// The end of the task of a thread is still tagged, the next events of the thread are not
+	assert.Equal(t, "r1", tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadA, TaskID: &taskA}, "task_stop").RequestID)
+	assert.Empty(t, tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadA}, "").RequestID)
+	assert.Equal(t, "r2", tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadB}, "").RequestID)
+
+	// The requests of the other connections of the user are not known to this client
+	other := uuid.New()
+	assert.Empty(t, tag(&service.EventHeaders{ConnectionID: &other, ThreadID: &threadB, TaskID: &taskB, RequestID: "r3"}, "").RequestID)
+	assert.Equal(t, "r2", tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadB}, "").RequestID)
+
+	// The requests of a connection are dropped with the connection
+	handler.forgetConnection(connectionID)
+	assert.Empty(t, tag(&service.EventHeaders{ConnectionID: &connectionID, ThreadID: &threadB}, "").RequestID)
+}
+
+func TestHandler_activeTasks(t *testing.T) {
+	t.Parallel()
+
+	handler := NewHandler(context.Background(), nil, nil, utils.NewSyncMap[uuid.UUID, *websocket.Conn](), setupTestLogger(t))
+	connectionID := uuid.New()
+	threadA, threadB := uuid.New(), uuid.New()
+	taskA, taskB, subTask := "task-a", "task-b", "sub-task"
+	tag := func(header *service.EventHeaders, lifecycleType string) {
+		handler.tagFrame(&serverFrame{Type: FrameTaskLifecycle}, connectionID, header, lifecycleType)
+	}
+
+	assert.Empty(t, handler.activeTasks(connectionID, nil, ""))
+	tag(&service.EventHeaders{ThreadID: &threadA, TaskID: &taskA}, "task_start")
+	tag(&service.EventHeaders{ThreadID: &threadB, TaskID: &taskB}, "task_start")
+
+	// The sub tasks run in the thread of their task
+	tag(&service.EventHeaders{ThreadID: &threadA, TaskID: &subTask}, "sub_task_start")
+	assert.ElementsMatch(t, []activeTask{{threadA, taskA}, {threadB, taskB}}, handler.activeTasks(connectionID, nil, ""))
+	assert.Equal(t, []activeTask{{threadB, taskB}}, handler.activeTasks(connectionID, &threadB, ""))
+	assert.Equal(t, []activeTask{{threadA, taskA}}, handler.activeTasks(connectionID, nil, taskA))
+	assert.Empty(t, handler.activeTasks(connectionID, &threadB, taskA))
+	assert.Empty(t, handler.activeTasks(uuid.New(), nil, ""))
+
+	// A stopped task is no longer active
+	tag(&service.EventHeaders{ThreadID: &threadA, TaskID: &taskA}, "task_stop")
+	assert.Equal(t, []activeTask{{threadB, taskB}}, handler.activeTasks(connectionID, nil, ""))
+}
@@ -0,0 +1,409 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	custom_middleware "github.com/pinazu/internal/api/middleware"
+	db "github.com/pinazu/internal/db"
+)
+
+const (
+	ORGANIZATION_RESOURCE        = "Organization"
+	ORGANIZATION_MEMBER_RESOURCE = "OrganizationMember"
+	WORKSPACE_RESOURCE           = "Workspace"
+	WORKSPACE_MEMBER_RESOURCE    = "WorkspaceMember"
+)
+
+const lastOwnerMessage = "the last owner of the organization cannot be removed, another owner must be added first"
+
+// authorizeWorkspace lets the members of a workspace and the owners and admins of its organization act in it
+func (s *Server) authorizeWorkspace(ctx context.Context, userID, workspaceID uuid.UUID) error {
+	allowed, err := s.queries.CanAccessWorkspace(ctx, db.CanAccessWorkspaceParams{WorkspaceID: workspaceID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to check workspace access: %w", err)
+	}
+	if !allowed {
+		return custom_middleware.ErrWorkspaceForbidden
+	}
+	return nil
+}
+
+// organizationRole returns the role of a user in an organization, db.OrganizationRoleNil when not a member
+func (s *Server) organizationRole(ctx context.Context, organizationID, userID uuid.UUID) (db.OrganizationRole, error) {
+	member, err := s.queries.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{OrganizationID: organizationID, UserID: userID})
+	if err == pgx.ErrNoRows {
+		return db.OrganizationRoleNil, nil
+	}
+	if err != nil {
+		return db.OrganizationRoleNil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return member.Role, nil
+}
+
+// managesWorkspaceMembers tells whether a user is an admin of a workspace or an owner or admin of its organization
+func (s *Server) managesWorkspaceMembers(ctx context.Context, workspace db.Workspace, userID uuid.UUID) (bool, error) {
+	member, err := s.queries.GetWorkspaceMember(ctx, db.GetWorkspaceMemberParams{WorkspaceID: workspace.ID, UserID: userID})
+	if err == nil && member.Role == db.WorkspaceRoleAdmin {
+		return true, nil
+	}
+	if err != nil && err != pgx.ErrNoRows {
+		return false, fmt.Errorf("failed to get workspace member: %w", err)
+	}
+	role, err := s.organizationRole(ctx, workspace.OrganizationID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role.ManagesWorkspaces(), nil
+}
+
+// visibleWorkspace returns a workspace the user can act in, pgx.ErrNoRows for the other workspaces so their
+// existence is not disclosed
+func (s *Server) visibleWorkspace(ctx context.Context, workspaceID, userID uuid.UUID) (db.Workspace, error) {
+	workspace, err := s.queries.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		return db.Workspace{}, err
+	}
+	if workspace.ID == db.DefaultWorkspaceID {
+		return workspace, nil
+	}
+	if err := s.authorizeWorkspace(ctx, userID, workspace.ID); err != nil {
+		if err == custom_middleware.ErrWorkspaceForbidden {
+			return db.Workspace{}, pgx.ErrNoRows
+		}
+		return db.Workspace{}, err
+	}
+	return workspace, nil
+}
+
+// List the organizations of the user
+// (GET /v1/organizations)
+func (s *Server) ListOrganizations(ctx context.Context, request ListOrganizationsRequestObject) (ListOrganizationsResponseObject, error) {
+	organizations, err := s.queries.ListUserOrganizations(ctx, custom_middleware.RequestUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return ListOrganizations200JSONResponse(UserOrganizationList{Organizations: organizations}), nil
+}
+
+// Create an organization
+// (POST /v1/organizations)
+func (s *Server) CreateOrganization(ctx context.Context, request CreateOrganizationRequestObject) (CreateOrganizationResponseObject, error) {
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return CreateOrganization400JSONResponse{Message: "organization name is required"}, nil
+	}
+	if len(name) > 255 {
+		return CreateOrganization400JSONResponse{Message: "organization name exceeds maximum length of 255 characters"}, nil
+	}
+
+	// The organization is created with its first owner or not at all
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+	organization, err := queries.CreateOrganization(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := queries.UpsertOrganizationMember(ctx, db.UpsertOrganizationMemberParams{
+		OrganizationID: organization.ID,
+		UserID:         custom_middleware.RequestUserID(ctx),
+		Role:           db.OrganizationRoleOwner,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to add organization owner: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return CreateOrganization201JSONResponse(organization), nil
+}
+
+// List the members of an organization
+// (GET /v1/organizations/{organization_id}/members)
+func (s *Server) ListOrganizationMembers(ctx context.Context, request ListOrganizationMembersRequestObject) (ListOrganizationMembersResponseObject, error) {
+	role, err := s.organizationRole(ctx, request.OrganizationId, custom_middleware.RequestUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if role == db.OrganizationRoleNil {
+		return ListOrganizationMembers404JSONResponse{Message: "Organization not found", Resource: ORGANIZATION_RESOURCE, Id: request.OrganizationId}, nil
+	}
+	members, err := s.queries.ListOrganizationMembers(ctx, request.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+	return ListOrganizationMembers200JSONResponse(OrganizationMemberList{Members: members}), nil
+}
+
+// Add a member to an organization
+// (PUT /v1/organizations/{organization_id}/members/{user_id})
+func (s *Server) SetOrganizationMember(ctx context.Context, request SetOrganizationMemberRequestObject) (SetOrganizationMemberResponseObject, error) {
+	if !request.Body.Role.Valid() {
+		return SetOrganizationMember400JSONResponse{Message: fmt.Sprintf("invalid role %q, must be OWNER, ADMIN or MEMBER", request.Body.Role)}, nil
+	}
+	callerRole, err := s.organizationRole(ctx, request.OrganizationId, custom_middleware.RequestUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if callerRole == db.OrganizationRoleNil {
+		return SetOrganizationMember404JSONResponse{Message: "Organization not found", Resource: ORGANIZATION_RESOURCE, Id: request.OrganizationId}, nil
+	}
+	if !callerRole.ManagesWorkspaces() {
+		return SetOrganizationMember403JSONResponse{Message: "only the owners and admins of the organization manage its members"}, nil
+	}
+	if _, err := s.queries.GetUserByID(ctx, request.UserId); err != nil {
+		if err == pgx.ErrNoRows {
+			return SetOrganizationMember404JSONResponse{Message: "User not found", Resource: USER_RESOURCE, Id: request.UserId}, nil
+		}
+		return nil, err
+	}
+
+	currentRole, err := s.organizationRole(ctx, request.OrganizationId, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if (request.Body.Role == db.OrganizationRoleOwner || currentRole == db.OrganizationRoleOwner) && callerRole != db.OrganizationRoleOwner {
+		return SetOrganizationMember403JSONResponse{Message: "only the owners of the organization manage its owners"}, nil
+	}
+	if currentRole == db.OrganizationRoleOwner && request.Body.Role != db.OrganizationRoleOwner {
+		lastOwner, err := s.isLastOwner(ctx, request.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		if lastOwner {
+			return SetOrganizationMember400JSONResponse{Message: lastOwnerMessage}, nil
+		}
+	}
+
+	member, err := s.queries.UpsertOrganizationMember(ctx, db.UpsertOrganizationMemberParams{
+		OrganizationID: request.OrganizationId,
+		UserID:         request.UserId,
+		Role:           request.Body.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return SetOrganizationMember200JSONResponse(member), nil
+}
+
+// Remove a member from an organization
+// (DELETE /v1/organizations/{organization_id}/members/{user_id})
+func (s *Server) RemoveOrganizationMember(ctx context.Context, request RemoveOrganizationMemberRequestObject) (RemoveOrganizationMemberResponseObject, error) {
+	callerRole, err := s.organizationRole(ctx, request.OrganizationId, custom_middleware.RequestUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if callerRole == db.OrganizationRoleNil {
+		return RemoveOrganizationMember404JSONResponse{Message: "Organization not found", Resource: ORGANIZATION_RESOURCE, Id: request.OrganizationId}, nil
+	}
+	if !callerRole.ManagesWorkspaces() {
+		return RemoveOrganizationMember403JSONResponse{Message: "only the owners and admins of the organization manage its members"}, nil
+	}
+	currentRole, err := s.organizationRole(ctx, request.OrganizationId, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if currentRole == db.OrganizationRoleNil {
+		return RemoveOrganizationMember404JSONResponse{Message: "Member not found", Resource: ORGANIZATION_MEMBER_RESOURCE, Id: request.UserId}, nil
+	}
+	if currentRole == db.OrganizationRoleOwner {
+		if callerRole != db.OrganizationRoleOwner {
+			return RemoveOrganizationMember403JSONResponse{Message: "only the owners of the organization manage its owners"}, nil
+		}
+		lastOwner, err := s.isLastOwner(ctx, request.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		if lastOwner {
+			return RemoveOrganizationMember400JSONResponse{Message: lastOwnerMessage}, nil
+		}
+	}
+
+	// The user leaves the workspaces of the organization with it
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+	if err := queries.DeleteOrganizationWorkspaceMembers(ctx, db.DeleteOrganizationWorkspaceMembersParams{OrganizationID: request.OrganizationId, UserID: request.UserId}); err != nil {
+		return nil, fmt.Errorf("failed to remove workspace members: %w", err)
+	}
+	if _, err := queries.DeleteOrganizationMember(ctx, db.DeleteOrganizationMemberParams{OrganizationID: request.OrganizationId, UserID: request.UserId}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return RemoveOrganizationMember204Response{}, nil
+}
+
+// isLastOwner tells whether an organization has a single owner, who cannot leave it or stop being an owner
+func (s *Server) isLastOwner(ctx context.Context, organizationID uuid.UUID) (bool, error) {
+	owners, err := s.queries.CountOrganizationOwners(ctx, organizationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to count organization owners: %w", err)
+	}
+	return owners <= 1, nil
+}
+
+// List the workspaces of an organization
+// (GET /v1/organizations/{organization_id}/workspaces)
+func (s *Server) ListOrganizationWorkspaces(ctx context.Context, request ListOrganizationWorkspacesRequestObject) (ListOrganizationWorkspacesResponseObject, error) {
+	role, err := s.organizationRole(ctx, request.OrganizationId, custom_middleware.RequestUserID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if role == db.OrganizationRoleNil {
+		return ListOrganizationWorkspaces404JSONResponse{Message: "Organization not found", Resource: ORGANIZATION_RESOURCE, Id: request.OrganizationId}, nil
+	}
+	workspaces, err := s.queries.ListOrganizationWorkspaces(ctx, request.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+	return ListOrganizationWorkspaces200JSONResponse(WorkspaceList{Workspaces: workspaces}), nil
+}
+
+// Create a workspace
+// (POST /v1/organizations/{organization_id}/workspaces)
+func (s *Server) CreateWorkspace(ctx context.Context, request CreateWorkspaceRequestObject) (CreateWorkspaceResponseObject, error) {
+	userID := custom_middleware.RequestUserID(ctx)
+	role, err := s.organizationRole(ctx, request.OrganizationId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == db.OrganizationRoleNil {
+		return CreateWorkspace404JSONResponse{Message: "Organization not found", Resource: ORGANIZATION_RESOURCE, Id: request.OrganizationId}, nil
+	}
+	if !role.ManagesWorkspaces() {
+		return CreateWorkspace403JSONResponse{Message: "only the owners and admins of the organization create its workspaces"}, nil
+	}
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return CreateWorkspace400JSONResponse{Message: "workspace name is required"}, nil
+	}
+	if len(name) > 255 {
+		return CreateWorkspace400JSONResponse{Message: "workspace name exceeds maximum length of 255 characters"}, nil
+	}
+
+	// The workspace is created with its first admin or not at all
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+	workspace, err := queries.CreateWorkspace(ctx, db.CreateWorkspaceParams{OrganizationID: request.OrganizationId, Name: name})
+	if err != nil {
+		if db.IsConflictError(err) {
+			return CreateWorkspace409JSONResponse{Message: "The organization has a workspace of this name", Resource: WORKSPACE_RESOURCE, Id: request.OrganizationId}, nil
+		}
+		return nil, err
+	}
+	if _, err := queries.UpsertWorkspaceMember(ctx, db.UpsertWorkspaceMemberParams{
+		WorkspaceID: workspace.ID,
+		UserID:      userID,
+		Role:        db.WorkspaceRoleAdmin,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to add workspace admin: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return CreateWorkspace201JSONResponse(workspace), nil
+}
+
+// List the members of a workspace
+// (GET /v1/workspaces/{workspace_id}/members)
+func (s *Server) ListWorkspaceMembers(ctx context.Context, request ListWorkspaceMembersRequestObject) (ListWorkspaceMembersResponseObject, error) {
+	if _, err := s.visibleWorkspace(ctx, request.WorkspaceId, custom_middleware.RequestUserID(ctx)); err != nil {
+		if err == pgx.ErrNoRows {
+			return ListWorkspaceMembers404JSONResponse{Message: "Workspace not found", Resource: WORKSPACE_RESOURCE, Id: request.WorkspaceId}, nil
+		}
+		return nil, err
+	}
+	members, err := s.queries.ListWorkspaceMembers(ctx, request.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+	return ListWorkspaceMembers200JSONResponse(WorkspaceMemberList{Members: members}), nil
+}
+
+// Add a member to a workspace
+// (PUT /v1/workspaces/{workspace_id}/members/{user_id})
+func (s *Server) SetWorkspaceMember(ctx context.Context, request SetWorkspaceMemberRequestObject) (SetWorkspaceMemberResponseObject, error) {
+	if !request.Body.Role.Valid() {
+		return SetWorkspaceMember400JSONResponse{Message: fmt.Sprintf("invalid role %q, must be ADMIN or MEMBER", request.Body.Role)}, nil
+	}
+	userID := custom_middleware.RequestUserID(ctx)
+	workspace, err := s.visibleWorkspace(ctx, request.WorkspaceId, userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return SetWorkspaceMember404JSONResponse{Message: "Workspace not found", Resource: WORKSPACE_RESOURCE, Id: request.WorkspaceId}, nil
+		}
+		return nil, err
+	}
+	manages, err := s.managesWorkspaceMembers(ctx, workspace, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !manages {
+		return SetWorkspaceMember403JSONResponse{Message: "only the admins of the workspace and of its organization manage its members"}, nil
+	}
+	// The members of a workspace are picked among the members of its organization
+	role, err := s.organizationRole(ctx, workspace.OrganizationID, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if role == db.OrganizationRoleNil {
+		return SetWorkspaceMember400JSONResponse{Message: fmt.Sprintf("user %s is not a member of the organization of the workspace", request.UserId)}, nil
+	}
+
+	member, err := s.queries.UpsertWorkspaceMember(ctx, db.UpsertWorkspaceMemberParams{
+		WorkspaceID: workspace.ID,
+		UserID:      request.UserId,
+		Role:        request.Body.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return SetWorkspaceMember200JSONResponse(member), nil
+}
+
+// Remove a member from a workspace
+// (DELETE /v1/workspaces/{workspace_id}/members/{user_id})
+func (s *Server) RemoveWorkspaceMember(ctx context.Context, request RemoveWorkspaceMemberRequestObject) (RemoveWorkspaceMemberResponseObject, error) {
+	userID := custom_middleware.RequestUserID(ctx)
+	workspace, err := s.visibleWorkspace(ctx, request.WorkspaceId, userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return RemoveWorkspaceMember404JSONResponse{Message: "Workspace not found", Resource: WORKSPACE_RESOURCE, Id: request.WorkspaceId}, nil
+		}
+		return nil, err
+	}
+	manages, err := s.managesWorkspaceMembers(ctx, workspace, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !manages {
+		return RemoveWorkspaceMember403JSONResponse{Message: "only the admins of the workspace and of its organization manage its members"}, nil
+	}
+
+	removed, err := s.queries.DeleteWorkspaceMember(ctx, db.DeleteWorkspaceMemberParams{WorkspaceID: workspace.ID, UserID: request.UserId})
+	if err != nil {
+		return nil, err
+	}
+	if removed == 0 {
+		return RemoveWorkspaceMember404JSONResponse{Message: "Member not found", Resource: WORKSPACE_MEMBER_RESOURCE, Id: request.UserId}, nil
+	}
+	return RemoveWorkspaceMember204Response{}, nil
+}
@@ -38,20 +38,51 @@ func (q *Queries) AddAgentPermission(ctx context.Context, arg AddAgentPermission
 }
 
 const checkAgentIDValid = `-- name: CheckAgentIDValid :one
-SELECT EXISTS(SELECT 1 FROM agents WHERE id = $1) AS is_valid
+SELECT EXISTS(SELECT 1 FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL) AS is_valid
 `
 
-func (q *Queries) CheckAgentIDValid(ctx context.Context, id uuid.UUID) (bool, error) {
-	row := q.db.QueryRow(ctx, checkAgentIDValid, id)
+type CheckAgentIDValidParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) CheckAgentIDValid(ctx context.Context, arg CheckAgentIDValidParams) (bool, error) {
+	row := q.db.QueryRow(ctx, checkAgentIDValid, arg.WorkspaceID, arg.ID)
 	var is_valid bool
 	err := row.Scan(&is_valid)
 	return is_valid, err
 }
 
+const countAgents = `-- name: CountAgents :one
+SELECT COUNT(*) FROM agents
+WHERE workspace_id = $1
+  AND (deleted_at IS NOT NULL) = $2::boolean
+  AND (created_by = $3
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'agent' AND g.resource_id = agents.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'agent' AND g.resource_id = agents.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = $3
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $3))))
+`
+
+type CountAgentsParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	Deleted     bool      `db:"deleted" json:"deleted"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+// Counts the agents of ListAgents
+func (q *Queries) CountAgents(ctx context.Context, arg CountAgentsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAgents, arg.WorkspaceID, arg.Deleted, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAgent = `-- name: CreateAgent :one
-INSERT INTO agents (name, description, specs, created_by, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, description, specs, created_by, created_at, updated_at
+INSERT INTO agents (name, description, specs, created_by, created_at, updated_at, workspace_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, name, description, specs, created_by, created_at, updated_at, deleted_at, workspace_id
 `
 
 type CreateAgentParams struct {
@@ -61,6 +92,7 @@ type CreateAgentParams struct {
 	CreatedBy   uuid.UUID          `db:"created_by" json:"created_by"`
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	WorkspaceID uuid.UUID          `db:"workspace_id" json:"workspace_id"`
 }
 
 func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent, error) {
@@ -71,6 +103,7 @@ func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent
 		arg.CreatedBy,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.WorkspaceID,
 	)
 	var i Agent
 	err := row.Scan(
@@ -81,6 +114,8 @@ func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
@@ -95,11 +130,16 @@ func (q *Queries) DeleteAgent(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAgentByID = `-- name: GetAgentByID :one
-SELECT id, name, description, specs, created_by, created_at, updated_at FROM agents WHERE id = $1 LIMIT 1
+SELECT id, name, description, specs, created_by, created_at, updated_at, deleted_at, workspace_id FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL LIMIT 1
 `
 
-func (q *Queries) GetAgentByID(ctx context.Context, id uuid.UUID) (Agent, error) {
-	row := q.db.QueryRow(ctx, getAgentByID, id)
+type GetAgentByIDParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) GetAgentByID(ctx context.Context, arg GetAgentByIDParams) (Agent, error) {
+	row := q.db.QueryRow(ctx, getAgentByID, arg.WorkspaceID, arg.ID)
 	var i Agent
 	err := row.Scan(
 		&i.ID,
@@ -109,23 +149,59 @@ func (q *Queries) GetAgentByID(ctx context.Context, id uuid.UUID) (Agent, error)
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
 
+const getAgentOwner = `-- name: GetAgentOwner :one
+SELECT created_by FROM agents WHERE workspace_id = $1 AND id = $2
+`
+
+type GetAgentOwnerParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Returns the creator of an agent of a workspace, live or in the trash
+func (q *Queries) GetAgentOwner(ctx context.Context, arg GetAgentOwnerParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getAgentOwner, arg.WorkspaceID, arg.ID)
+	var created_by uuid.UUID
+	err := row.Scan(&created_by)
+	return created_by, err
+}
+
 const getAgentSpecsByID = `-- name: GetAgentSpecsByID :one
-SELECT specs FROM agents WHERE id = $1 LIMIT 1
+SELECT specs FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL LIMIT 1
 `
 
-func (q *Queries) GetAgentSpecsByID(ctx context.Context, id uuid.UUID) (pgtype.Text, error) {
-	row := q.db.QueryRow(ctx, getAgentSpecsByID, id)
+type GetAgentSpecsByIDParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) GetAgentSpecsByID(ctx context.Context, arg GetAgentSpecsByIDParams) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getAgentSpecsByID, arg.WorkspaceID, arg.ID)
 	var specs pgtype.Text
 	err := row.Scan(&specs)
 	return specs, err
 }
 
+const getAgentWorkspaceID = `-- name: GetAgentWorkspaceID :one
+SELECT workspace_id FROM agents WHERE id = $1
+`
+
+// Returns the workspace of an agent, for the jobs acting for the agent outside of a request
+func (q *Queries) GetAgentWorkspaceID(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getAgentWorkspaceID, id)
+	var workspace_id uuid.UUID
+	err := row.Scan(&workspace_id)
+	return workspace_id, err
+}
+
 const getAgents = `-- name: GetAgents :many
-SELECT id, name, description, specs, created_by, created_at, updated_at FROM agents ORDER BY name
+SELECT id, name, description, specs, created_by, created_at, updated_at, deleted_at, workspace_id FROM agents WHERE deleted_at IS NULL ORDER BY name
 `
 
 func (q *Queries) GetAgents(ctx context.Context) ([]Agent, error) {
@@ -145,6 +221,72 @@ func (q *Queries) GetAgents(ctx context.Context) ([]Agent, error) {
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAgents = `-- name: ListAgents :many
+SELECT id, name, description, specs, created_by, created_at, updated_at, deleted_at, workspace_id FROM agents
+WHERE workspace_id = $1
+  AND (deleted_at IS NOT NULL) = $2::boolean
+  AND (created_by = $3
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'agent' AND g.resource_id = agents.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'agent' AND g.resource_id = agents.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = $3
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $3))))
+  AND ($4::text IS NULL
+       OR (name, id) > ($4::text, $5::uuid))
+ORDER BY name, id
+LIMIT $6
+`
+
+type ListAgentsParams struct {
+	WorkspaceID uuid.UUID   `db:"workspace_id" json:"workspace_id"`
+	Deleted     bool        `db:"deleted" json:"deleted"`
+	UserID      uuid.UUID   `db:"user_id" json:"user_id"`
+	CursorName  pgtype.Text `db:"cursor_name" json:"cursor_name"`
+	CursorID    uuid.UUID   `db:"cursor_id" json:"cursor_id"`
+	RowLimit    int32       `db:"row_limit" json:"row_limit"`
+}
+
+// Lists the agents or the agents in the trash of a workspace visible to a user by name, after the agent of the cursor
+// when set. The agents with grants are only visible to their creator and their grantees.
+func (q *Queries) ListAgents(ctx context.Context, arg ListAgentsParams) ([]Agent, error) {
+	rows, err := q.db.Query(ctx, listAgents,
+		arg.WorkspaceID,
+		arg.Deleted,
+		arg.UserID,
+		arg.CursorName,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Agent{}
+	for rows.Next() {
+		var i Agent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Specs,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
 		); err != nil {
 			return nil, err
 		}
@@ -186,6 +328,24 @@ func (q *Queries) ListPermissionsForAgent(ctx context.Context, agentID uuid.UUID
 	return items, nil
 }
 
+const purgeAgent = `-- name: PurgeAgent :execrows
+DELETE FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL
+`
+
+type PurgeAgentParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Deletes an agent of the trash of a workspace for good
+func (q *Queries) PurgeAgent(ctx context.Context, arg PurgeAgentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeAgent, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const removeAgentPermission = `-- name: RemoveAgentPermission :exec
 DELETE FROM agent_permission_mapping WHERE agent_id = $1 AND permission_id = $2
 `
@@ -200,26 +360,78 @@ func (q *Queries) RemoveAgentPermission(ctx context.Context, arg RemoveAgentPerm
 	return err
 }
 
+const restoreAgent = `-- name: RestoreAgent :one
+UPDATE agents SET deleted_at = NULL WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL
+RETURNING id, name, description, specs, created_by, created_at, updated_at, deleted_at, workspace_id
+`
+
+type RestoreAgentParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Restores an agent of a workspace from the trash
+func (q *Queries) RestoreAgent(ctx context.Context, arg RestoreAgentParams) (Agent, error) {
+	row := q.db.QueryRow(ctx, restoreAgent, arg.WorkspaceID, arg.ID)
+	var i Agent
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Specs,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const softDeleteAgent = `-- name: SoftDeleteAgent :execrows
+UPDATE agents SET deleted_at = NOW() WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+`
+
+type SoftDeleteAgentParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Moves an agent of a workspace to the trash
+func (q *Queries) SoftDeleteAgent(ctx context.Context, arg SoftDeleteAgentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteAgent, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateAgent = `-- name: UpdateAgent :one
 UPDATE agents
 SET name = $1, description = $2, specs = $3
-WHERE id = $4
-RETURNING id, name, description, specs, created_by, created_at, updated_at
+WHERE id = $4 AND workspace_id = $5 AND deleted_at IS NULL
+  AND ($6::timestamptz IS NULL OR updated_at = $6::timestamptz)
+RETURNING id, name, description, specs, created_by, created_at, updated_at, deleted_at, workspace_id
 `
 
 type UpdateAgentParams struct {
-	Name        string      `db:"name" json:"name"`
-	Description pgtype.Text `db:"description" json:"description"`
-	Specs       pgtype.Text `db:"specs" json:"specs"`
-	ID          uuid.UUID   `db:"id" json:"id"`
+	Name        string             `db:"name" json:"name"`
+	Description pgtype.Text        `db:"description" json:"description"`
+	Specs       pgtype.Text        `db:"specs" json:"specs"`
+	ID          uuid.UUID          `db:"id" json:"id"`
+	WorkspaceID uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	IfUpdatedAt pgtype.Timestamptz `db:"if_updated_at" json:"if_updated_at"`
 }
 
+// Updates an agent of a workspace, only while its update time is if_updated_at when set
 func (q *Queries) UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent, error) {
 	row := q.db.QueryRow(ctx, updateAgent,
 		arg.Name,
 		arg.Description,
 		arg.Specs,
 		arg.ID,
+		arg.WorkspaceID,
+		arg.IfUpdatedAt,
 	)
 	var i Agent
 	err := row.Scan(
@@ -230,6 +442,28 @@ func (q *Queries) UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
+
+const updateAgentSpecs = `-- name: UpdateAgentSpecs :execrows
+UPDATE agents
+SET specs = $1
+WHERE id = $2 AND specs = $3 AND deleted_at IS NULL
+`
+
+type UpdateAgentSpecsParams struct {
+	Specs         pgtype.Text `db:"specs" json:"specs"`
+	ID            uuid.UUID   `db:"id" json:"id"`
+	PreviousSpecs pgtype.Text `db:"previous_specs" json:"previous_specs"`
+}
+
+func (q *Queries) UpdateAgentSpecs(ctx context.Context, arg UpdateAgentSpecsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateAgentSpecs, arg.Specs, arg.ID, arg.PreviousSpecs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -54,6 +54,7 @@ system: |
 	// Create a new agent
 	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
 	createParams := CreateAgentParams{
+		WorkspaceID: DefaultWorkspaceID,
 		Name:        "Test Agent",
 		Description: pgtype.Text{String: "This is a test agent", Valid: true},
 		Specs:       pgtype.Text{String: agentSpec, Valid: true},
@@ -71,7 +72,7 @@ system: |
 	assert.Equal(t, createParams.CreatedBy, createdAgent.CreatedBy, "Created agent creator ID should match")
 
 	// Test GetAgentByID
-	agent, err := queries.GetAgentByID(t.Context(), createdAgent.ID)
+	agent, err := queries.GetAgentByID(t.Context(), GetAgentByIDParams{WorkspaceID: DefaultWorkspaceID, ID: createdAgent.ID})
 	if err != nil {
 		t.Fatalf("Failed to get agent by ID: %v", err)
 	}
@@ -109,6 +110,7 @@ system: |
 
 	// Test UpdateAgent
 	updateParams := UpdateAgentParams{
+		WorkspaceID: DefaultWorkspaceID,
 		ID:          createdAgent.ID,
 		Name:        "Updated Test Agent",
 		Description: pgtype.Text{String: "This is an updated test agent", Valid: true},
@@ -129,7 +131,7 @@ system: |
 	}
 
 	// Verify deletion
-	_, err = queries.GetAgentByID(t.Context(), createdAgent.ID)
+	_, err = queries.GetAgentByID(t.Context(), GetAgentByIDParams{WorkspaceID: DefaultWorkspaceID, ID: createdAgent.ID})
 	if err == nil {
 		t.Fatalf("Expected error when getting deleted agent, but got none")
 	}
@@ -167,6 +169,7 @@ func TestAgentPermissions(t *testing.T) {
 	// Create test agent
 	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
 	createAgentParams := CreateAgentParams{
+		WorkspaceID: DefaultWorkspaceID,
 		Name:        "Permission Test Agent",
 		Description: pgtype.Text{String: "Agent for permission testing", Valid: true},
 		Specs:       pgtype.Text{String: "Permission test specs", Valid: true},
@@ -197,3 +200,92 @@ func TestAgentPermissions(t *testing.T) {
 		t.Fatalf("Failed to clean up test agent: %v", err)
 	}
 }
+
+func TestSoftDeleteAgents(t *testing.T) {
+	t.Parallel()
+	db_pool := setupTestDB(t)
+	defer db_pool.Close()
+	queries := New(db_pool)
+
+	createUserParams := CreateUserParams{
+		Name:           "testuser_agents_trash_unique",
+		Email:          "agents_trash_unique@example.com",
+		AdditionalInfo: JsonRaw{},
+		PasswordHash:   "hashedpassword123",
+		ProviderName:   ProviderNameLocal,
+	}
+	createdUser, err := queries.CreateUser(t.Context(), createUserParams)
+	if err != nil {
+		t.Logf("User already exists, using existing user: %v", err)
+		user, err := queries.GetUserByEmail(t.Context(), createUserParams.Email)
+		if err != nil {
+			t.Fatalf("Failed to get existing user by email: %v", err)
+		}
+		createdUser = CreateUserRow(user)
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	createParams := CreateAgentParams{
+		WorkspaceID: DefaultWorkspaceID,
+		Name:        "Test Trash Agent",
+		CreatedBy:   createdUser.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	agent, err := queries.CreateAgent(t.Context(), createParams)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	assert.False(t, agent.DeletedAt.Valid, "A new agent should not be in the trash")
+
+	// A purge only deletes the agents of the trash
+	purged, err := queries.PurgeAgent(t.Context(), PurgeAgentParams{WorkspaceID: DefaultWorkspaceID, ID: agent.ID})
+	if err != nil {
+		t.Fatalf("Failed to purge agent: %v", err)
+	}
+	assert.Zero(t, purged, "A live agent should not be purged")
+
+	deleted, err := queries.SoftDeleteAgent(t.Context(), SoftDeleteAgentParams{WorkspaceID: DefaultWorkspaceID, ID: agent.ID})
+	if err != nil {
+		t.Fatalf("Failed to move agent to the trash: %v", err)
+	}
+	assert.Equal(t, int64(1), deleted)
+	_, err = queries.GetAgentByID(t.Context(), GetAgentByIDParams{WorkspaceID: DefaultWorkspaceID, ID: agent.ID})
+	assert.Equal(t, pgx.ErrNoRows, err, "An agent in the trash should not be found")
+
+	trash, err := queries.ListAgents(t.Context(), ListAgentsParams{WorkspaceID: DefaultWorkspaceID, Deleted: true, RowLimit: 100})
+	if err != nil {
+		t.Fatalf("Failed to list the trash: %v", err)
+	}
+	found := false
+	for _, trashed := range trash {
+		found = found || trashed.ID == agent.ID
+	}
+	assert.True(t, found, "The trash should list the deleted agent")
+
+	// The name of an agent in the trash can be taken, the agent cannot be restored while it is
+	other, err := queries.CreateAgent(t.Context(), createParams)
+	if err != nil {
+		t.Fatalf("Failed to create agent with the name of an agent in the trash: %v", err)
+	}
+	_, err = queries.RestoreAgent(t.Context(), RestoreAgentParams{WorkspaceID: DefaultWorkspaceID, ID: agent.ID})
+	assert.True(t, IsConflictError(err), "Restoring an agent with a taken name should conflict")
+	if err := queries.DeleteAgent(t.Context(), other.ID); err != nil {
+		t.Fatalf("Failed to delete agent: %v", err)
+	}
+
+	restored, err := queries.RestoreAgent(t.Context(), RestoreAgentParams{WorkspaceID: DefaultWorkspaceID, ID: agent.ID})
+	if err != nil {
+		t.Fatalf("Failed to restore agent: %v", err)
+	}
+	assert.False(t, restored.DeletedAt.Valid, "A restored agent should not be in the trash")
+
+	if _, err := queries.SoftDeleteAgent(t.Context(), SoftDeleteAgentParams{WorkspaceID: DefaultWorkspaceID, ID: agent.ID}); err != nil {
+		t.Fatalf("Failed to move agent to the trash: %v", err)
+	}
+	purged, err = queries.PurgeAgent(t.Context(), PurgeAgentParams{WorkspaceID: DefaultWorkspaceID, ID: agent.ID})
+	if err != nil {
+		t.Fatalf("Failed to purge agent: %v", err)
+	}
+	assert.Equal(t, int64(1), purged)
+}
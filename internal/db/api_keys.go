@@ -0,0 +1,89 @@
+package db
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// APIKeyPrefix starts the API keys, to tell them from the other bearer tokens
+	APIKeyPrefix = "pk_"
+
+	// apiKeyDisplayLength is the length of the start of a key kept in key_prefix, to recognize the key
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+	APIKeyScopeRead  = "read"  // Read the resources
+	APIKeyScopeWrite = "write" // Create, update, delete and execute the resources, on top of read
+	APIKeyScopeAdmin = "admin" // Manage the users, roles, permissions and API keys, on top of write
+)
+
+// APIKeyScopes are the scopes of the API keys, each scope grants the scopes before it
+var APIKeyScopes = []string{APIKeyScopeRead, APIKeyScopeWrite, APIKeyScopeAdmin}
+
+// GenerateAPIKey returns a new random API key, the start of the key kept to recognize it and the hash stored instead
+// of the key
+func GenerateAPIKey() (key string, prefix string, hash string, err error) {
+	key, err = randomToken(APIKeyPrefix)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return key, key[:apiKeyDisplayLength], HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the SHA-256 hash of an API key in hex
+func HashAPIKey(key string) string {
+	return hashToken(key)
+}
+
+// randomToken returns a prefix followed by 32 random bytes in hex
+func randomToken(prefix string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(secret), nil
+}
+
+// hashToken returns the SHA-256 hash of a token in hex. The tokens are random, a salt or a slow hash adds nothing and
+// would slow down the authentication of every request.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeAPIKeyScopes validates the scopes of an API key and returns them sorted without duplicates
+func NormalizeAPIKeyScopes(scopes []string) ([]string, error) {
+	var normalized []string
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(APIKeyScopes, scope) {
+			return nil, fmt.Errorf("invalid scope %q, valid scopes are: %s", scope, strings.Join(APIKeyScopes, ", "))
+		}
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("at least one scope is required, valid scopes are: %s", strings.Join(APIKeyScopes, ", "))
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// APIKeyScopesAllow reports whether the scopes of an API key grant a scope, directly or through a scope granting it
+func APIKeyScopesAllow(scopes []string, required string) bool {
+	level := slices.Index(APIKeyScopes, required)
+	if level < 0 {
+		return false
+	}
+	for _, scope := range scopes {
+		if slices.Index(APIKeyScopes, scope) >= level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_GenerateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	key, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) || len(key) != len(APIKeyPrefix)+64 {
+		t.Fatalf("expected a pk_ key of 64 hex characters, got %s", key)
+	}
+	if !strings.HasPrefix(key, prefix) || len(prefix) != apiKeyDisplayLength {
+		t.Fatalf("expected the prefix to start the key, got %s", prefix)
+	}
+	if hash != HashAPIKey(key) || strings.Contains(hash, key[len(APIKeyPrefix):]) {
+		t.Fatalf("expected the hash of the key, got %s", hash)
+	}
+	if other, _, _, _ := GenerateAPIKey(); other == key {
+		t.Fatalf("expected the keys to be random")
+	}
+}
+
+func Test_NormalizeAPIKeyScopes(t *testing.T) {
+	t.Parallel()
+
+	scopes, err := NormalizeAPIKeyScopes([]string{"write", " Read", "write"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"read", "write"}; !slices.Equal(scopes, expected) {
+		t.Fatalf("expected %v, got %v", expected, scopes)
+	}
+	for _, invalid := range [][]string{nil, {}, {"delete"}, {"read", "*"}} {
+		if _, err := NormalizeAPIKeyScopes(invalid); err == nil {
+			t.Fatalf("expected scopes %v to be rejected", invalid)
+		}
+	}
+}
+
+func Test_APIKeyScopesAllow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scopes   []string
+		required string
+		expected bool
+	}{
+		{[]string{"read"}, APIKeyScopeRead, true},
+		{[]string{"read"}, APIKeyScopeWrite, false},
+		{[]string{"write"}, APIKeyScopeRead, true},
+		{[]string{"write"}, APIKeyScopeAdmin, false},
+		{[]string{"admin"}, APIKeyScopeWrite, true},
+		{[]string{"read", "admin"}, APIKeyScopeAdmin, true},
+		{nil, APIKeyScopeRead, false},
+		{[]string{"admin"}, "unknown", false},
+	}
+	for _, tt := range tests {
+		if allowed := APIKeyScopesAllow(tt.scopes, tt.required); allowed != tt.expected {
+			t.Errorf("scopes %v granting %s: expected %v, got %v", tt.scopes, tt.required, tt.expected, allowed)
+		}
+	}
+}
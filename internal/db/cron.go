@@ -0,0 +1,260 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search of the next time of a cron expression, an expression such as
+// "0 0 30 2 *" never fires. The days of the week repeat on the same dates every 28 years.
+const cronSearchYears = 30
+
+// cronMacros are the cron expressions written as a macro
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type (
+	// CronSchedule is a parsed cron expression, with the minutes, hours, days of the month, months
+	// and days of the week it fires on as bit sets
+	CronSchedule struct {
+		minute, hour, dom, month, dow uint64
+		domStar, dowStar              bool // The field starts with *, the days match on the other field only
+	}
+
+	// cronField is the range of the values of a field of a cron expression
+	cronField struct {
+		name     string
+		min, max int
+		names    map[string]int
+	}
+)
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is Sunday as well as 0
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// ParseCronExpression parses a standard 5 fields cron expression: minute, hour, day of month, month and day of week.
+// The fields take lists, ranges and steps, the months and days of the week their 3 letters names.
+// The @yearly, @monthly, @weekly, @daily and @hourly macros are supported.
+func ParseCronExpression(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	s := &CronSchedule{
+		domStar: strings.HasPrefix(fields[2], "*") || fields[2] == "?",
+		dowStar: strings.HasPrefix(fields[4], "*") || fields[4] == "?",
+	}
+	var err error
+	for i, target := range []struct {
+		bits  *uint64
+		field cronField
+	}{{&s.minute, cronMinute}, {&s.hour, cronHour}, {&s.dom, cronDom}, {&s.month, cronMonth}, {&s.dow, cronDow}} {
+		if *target.bits, err = target.field.parse(fields[i]); err != nil {
+			return nil, err
+		}
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parse returns the bit set of the values of a field, written as a comma separated list of
+// *, values or ranges, each with an optional /step
+func (f cronField) parse(expr string) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q of the %s field", stepExpr, f.name)
+			}
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+		case strings.Contains(rangeExpr, "-"):
+			low, high, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if start, err = f.value(low); err != nil {
+				return 0, err
+			}
+			if end, err = f.value(high); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q of the %s field, the start is after the end", rangeExpr, f.name)
+			}
+		default:
+			var err error
+			if start, err = f.value(rangeExpr); err != nil {
+				return 0, err
+			}
+			// A single value with a step runs to the end of the range, as 5/15 for the minutes
+			end = start
+			if hasStep {
+				end = f.max
+			}
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of a field, a number or a name
+func (f cronField) value(expr string) (int, error) {
+	if v, ok := f.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of the %s field", expr, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d of the %s field is out of range [%d-%d]", v, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time the schedule fires strictly after the given time, evaluated on the wall clock of its location.
+// A time skipped by a daylight saving change does not fire, the zero time is returned when the schedule never fires.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	truncated := false
+	yearLimit := t.Year() + cronSearchYears
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for s.month&(1<<uint(t.Month())) == 0 {
+		if !truncated {
+			truncated = true
+			t = startOfDay(t.Year(), t.Month(), 1, loc)
+		}
+		t = startOfDay(t.Year(), t.Month()+1, 1, loc)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		if !truncated {
+			truncated = true
+			t = startOfDay(t.Year(), t.Month(), t.Day(), loc)
+		}
+		t = startOfDay(t.Year(), t.Month(), t.Day()+1, loc)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		if !truncated {
+			truncated = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		truncated = true
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	return t
+}
+
+// startOfDay returns the first minute of a day in a location, the day and month are normalized as by time.Date.
+// A midnight skipped by a daylight saving change is resolved by time.Date to the previous day, the day then
+// starts when the clocks were moved forward.
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC); t.Day() != date.Day() {
+		t = t.Add(time.Duration(24*60-t.Hour()*60-t.Minute()) * time.Minute)
+	}
+	return t
+}
+
+// FireTimes returns the times the schedule fires between start and end, both inclusive, evaluated on the wall clock
+// of the location of start. It stops after limit times, the caller checks the range is not larger.
+func (s *CronSchedule) FireTimes(start, end time.Time, limit int) []time.Time {
+	var fires []time.Time
+	for next := s.Next(start.Add(-time.Nanosecond)); !next.IsZero() && !next.After(end) && len(fires) < limit; next = s.Next(next) {
+		fires = append(fires, next)
+	}
+	return fires
+}
+
+// dayMatches reports whether the schedule fires on the day of the time. When both the day of the month and
+// the day of the week are restricted, a day matching either fires, as in the standard cron.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// LoadScheduleLocation returns the IANA time zone of a schedule, UTC when empty
+func LoadScheduleLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	return loc, nil
+}
+
+// NextCronRun returns the first time a cron expression fires after the given time in a time zone
+func NextCronRun(expr, timezone string, after time.Time) (time.Time, error) {
+	schedule, err := ParseCronExpression(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := LoadScheduleLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := schedule.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never fires", expr)
+	}
+	return next, nil
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_CronScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	at := func(loc *time.Location, value string) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		if err != nil {
+			t.Fatalf("invalid time %s: %v", value, err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		expr  string
+		loc   *time.Location
+		after string
+		want  string
+	}{
+		{"*/15 * * * *", time.UTC, "2026-03-10 10:07", "2026-03-10 10:15"},
+		{"*/15 * * * *", time.UTC, "2026-03-10 10:15", "2026-03-10 10:30"},
+		{"5/20 9-17 * * *", time.UTC, "2026-03-10 17:46", "2026-03-11 09:05"},
+		{"0 9 * * mon-fri", time.UTC, "2026-03-13 09:00", "2026-03-16 09:00"}, // Friday to Monday
+		{"30 8 1,15 * *", time.UTC, "2026-03-02 00:00", "2026-03-15 08:30"},
+		{"0 0 1 * 1", time.UTC, "2026-03-01 12:00", "2026-03-02 00:00"}, // Day of month or day of week
+		{"0 12 * jan,jul 7", time.UTC, "2026-03-01 00:00", "2026-07-05 12:00"},
+		{"@yearly", time.UTC, "2026-03-01 00:00", "2027-01-01 00:00"},
+		{"0 0 29 2 *", time.UTC, "2026-03-01 00:00", "2028-02-29 00:00"},
+		{"@daily", paris, "2026-03-10 12:00", "2026-03-11 00:00"},
+		{"30 2 * * *", paris, "2026-03-29 00:00", "2026-03-30 02:30"}, // 02:30 does not exist on the DST change
+		{"0 3 * * *", paris, "2026-03-29 00:00", "2026-03-29 03:00"},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseCronExpression(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: failed to parse: %v", tt.expr, err)
+		}
+		got := schedule.Next(at(tt.loc, tt.after))
+		if want := at(tt.loc, tt.want); !got.Equal(want) {
+			t.Errorf("%s after %s: got %s, want %s", tt.expr, tt.after, got, want)
+		}
+	}
+
+	if next := mustParseCron(t, "0 0 30 2 *").Next(at(time.UTC, "2026-01-01 00:00")); !next.IsZero() {
+		t.Errorf("expected a schedule on February 30 to never fire, got %s", next)
+	}
+
+	next, err := NextCronRun("0 9 * * *", "Europe/Paris", at(time.UTC, "2026-06-01 06:00"))
+	if err != nil {
+		t.Fatalf("failed to compute the next run: %v", err)
+	}
+	if want := at(time.UTC, "2026-06-01 07:00"); !next.Equal(want) {
+		t.Errorf("expected 9:00 in Paris to be %s, got %s", want, next.UTC())
+	}
+}
+
+func Test_ParseCronExpressionInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "10-5 * * * *", "* * * foo *", "@every 5m"} {
+		if _, err := ParseCronExpression(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+	if _, err := NextCronRun("@hourly", "Mars/Olympus", time.Now()); err == nil {
+		t.Errorf("expected an unknown time zone to be rejected")
+	}
+}
+
+func Test_CronScheduleFireTimes(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+	schedule := mustParseCron(t, "*/15 * * * *")
+
+	fires := schedule.FireTimes(start, start.Add(time.Hour), 100)
+	if len(fires) != 5 || !fires[0].Equal(start) || !fires[4].Equal(start.Add(time.Hour)) {
+		t.Fatalf("expected the 5 times from 10:00 to 11:00 included, got %v", fires)
+	}
+	if fires := schedule.FireTimes(start.Add(30*time.Second), start.Add(time.Hour), 100); !fires[0].Equal(start.Add(15 * time.Minute)) {
+		t.Errorf("expected a start after 10:00 to begin at 10:15, got %s", fires[0])
+	}
+	if fires := schedule.FireTimes(start, start.Add(time.Hour), 2); len(fires) != 2 {
+		t.Errorf("expected the times to stop at the limit, got %v", fires)
+	}
+	if fires := schedule.FireTimes(start.Add(time.Minute), start.Add(10*time.Minute), 100); len(fires) != 0 {
+		t.Errorf("expected no time in a range between two runs, got %v", fires)
+	}
+}
+
+func mustParseCron(t *testing.T, expr string) *CronSchedule {
+	t.Helper()
+	schedule, err := ParseCronExpression(expr)
+	if err != nil {
+		t.Fatalf("%s: failed to parse: %v", expr, err)
+	}
+	return schedule
+}
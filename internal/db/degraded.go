@@ -0,0 +1,91 @@
+package db
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// IsUnavailable reports whether an error means the database could not be reached, rather than a failed query.
+// Such errors are transient, the operation can be retried once the database is back.
+func IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is a connection exception, 57P01-57P03 are the server shutting down or starting up
+		return strings.HasPrefix(pgErr.Code, "08") || pgErr.Code == "57P01" || pgErr.Code == "57P02" || pgErr.Code == "57P03"
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	return pgconn.SafeToRetry(err) || pgconn.Timeout(err)
+}
+
+// StaleCache keeps the last value loaded for each key, to serve it when the database is unavailable.
+// The oldest entry is evicted once the cache holds maxEntries values.
+type StaleCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	entries    map[K]staleCacheEntry[V]
+	maxEntries int
+	now        func() time.Time
+}
+
+type staleCacheEntry[V any] struct {
+	value    V
+	loadedAt time.Time
+}
+
+// NewStaleCache creates a cache holding up to maxEntries values
+func NewStaleCache[K comparable, V any](maxEntries int) *StaleCache[K, V] {
+	return &StaleCache[K, V]{entries: make(map[K]staleCacheEntry[V]), maxEntries: maxEntries, now: time.Now}
+}
+
+// Load returns the value loaded by load and caches it. When load fails because the database is unavailable,
+// the cached value is returned instead with the time it was loaded, a zero time means the value is fresh.
+func (c *StaleCache[K, V]) Load(key K, load func() (V, error)) (V, time.Time, error) {
+	value, err := load()
+	if err == nil {
+		c.store(key, value)
+		return value, time.Time{}, nil
+	}
+	if !IsUnavailable(err) {
+		return value, time.Time{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return value, time.Time{}, err
+	}
+	return entry.value, entry.loadedAt, nil
+}
+
+func (c *StaleCache[K, V]) store(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		var oldest K
+		var oldestAt time.Time
+		for k, e := range c.entries {
+			if oldestAt.IsZero() || e.loadedAt.Before(oldestAt) {
+				oldest, oldestAt = k, e.loadedAt
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = staleCacheEntry[V]{value: value, loadedAt: c.now()}
+}
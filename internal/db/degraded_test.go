@@ -0,0 +1,72 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func Test_IsUnavailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "no rows", err: pgx.ErrNoRows, want: false},
+		{name: "constraint violation", err: &pgconn.PgError{Code: "23503"}, want: false},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "network error", err: fmt.Errorf("failed to fetch: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), want: true},
+		{name: "query error", err: errors.New("syntax error"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnavailable(tt.err); got != tt.want {
+				t.Errorf("IsUnavailable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_StaleCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewStaleCache[string, int](2)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { now = now.Add(time.Second); return now }
+	unavailable := func() (int, error) { return 0, &pgconn.PgError{Code: "08006"} }
+
+	if _, _, err := cache.Load("a", unavailable); err == nil {
+		t.Error("expected the error without a cached value")
+	}
+	if v, loadedAt, err := cache.Load("a", func() (int, error) { return 1, nil }); err != nil || v != 1 || !loadedAt.IsZero() {
+		t.Errorf("expected a fresh value, got %d %v %v", v, loadedAt, err)
+	}
+	v, loadedAt, err := cache.Load("a", unavailable)
+	if err != nil || v != 1 || loadedAt.IsZero() {
+		t.Errorf("expected the stale value, got %d %v %v", v, loadedAt, err)
+	}
+
+	// Query errors are returned even with a cached value
+	if _, _, err := cache.Load("a", func() (int, error) { return 0, pgx.ErrNoRows }); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expected pgx.ErrNoRows, got %v", err)
+	}
+
+	// The oldest entry is evicted
+	cache.Load("b", func() (int, error) { return 2, nil })
+	cache.Load("c", func() (int, error) { return 3, nil })
+	if _, _, err := cache.Load("a", unavailable); err == nil {
+		t.Error("expected the oldest entry to be evicted")
+	}
+	if v, _, err := cache.Load("c", unavailable); err != nil || v != 3 {
+		t.Errorf("expected the stale value of c, got %d %v", v, err)
+	}
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ETag returns the entity tag of a version of a resource. The update time of a row changes with each update of
+// the row, it identifies the version of the resource.
+func ETag(updatedAt pgtype.Timestamptz) string {
+	return fmt.Sprintf(`"%x"`, updatedAt.Time.UnixMicro())
+}
+
+// MatchETag checks the If-Match header of an update against the current version of a resource. It returns the
+// update time the update is conditioned on, null for an unconditional update without header or with "*", and
+// whether the header matches. Weak entity tags never match.
+func MatchETag(ifMatch string, updatedAt pgtype.Timestamptz) (pgtype.Timestamptz, bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return pgtype.Timestamptz{}, true
+	}
+	current := ETag(updatedAt)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		if strings.TrimSpace(tag) == current {
+			return updatedAt, true
+		}
+	}
+	return pgtype.Timestamptz{}, false
+}
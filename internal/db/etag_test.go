@@ -0,0 +1,44 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MatchETag(t *testing.T) {
+	t.Parallel()
+	updatedAt := pgtype.Timestamptz{Time: time.Date(2026, 3, 10, 8, 30, 0, 123456000, time.UTC), Valid: true}
+	current := ETag(updatedAt)
+
+	t.Run("Without header", func(t *testing.T) {
+		since, ok := MatchETag("", updatedAt)
+		assert.True(t, ok)
+		assert.False(t, since.Valid)
+	})
+
+	t.Run("Any version", func(t *testing.T) {
+		since, ok := MatchETag("*", updatedAt)
+		assert.True(t, ok)
+		assert.False(t, since.Valid)
+	})
+
+	t.Run("Current version", func(t *testing.T) {
+		since, ok := MatchETag(`"0", `+current, updatedAt)
+		assert.True(t, ok)
+		assert.Equal(t, updatedAt, since)
+	})
+
+	t.Run("Changed version", func(t *testing.T) {
+		older := ETag(pgtype.Timestamptz{Time: updatedAt.Time.Add(-time.Microsecond), Valid: true})
+		_, ok := MatchETag(older, updatedAt)
+		assert.False(t, ok)
+	})
+
+	t.Run("Weak tag", func(t *testing.T) {
+		_, ok := MatchETag("W/"+current, updatedAt)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// FeedbackExample is a feedback exported for the evaluation and the fine-tuning of the agents. The feedback on a
+	// message comes with the conversation the message answered, the feedback on a tool run with the run.
+	FeedbackExample struct {
+		Feedback Feedback         `json:"feedback"`
+		Messages []JsonRaw        `json:"messages,omitempty"` // Messages before the rated message as the agent was given them
+		Response JsonRaw          `json:"response,omitempty"` // The rated message
+		ToolRun  *FeedbackToolRun `json:"tool_run,omitempty"`
+	}
+
+	// FeedbackToolRun is the rated tool run of a feedback
+	FeedbackToolRun struct {
+		ToolID  uuid.UUID     `json:"tool_id"`
+		AgentID uuid.UUID     `json:"agent_id"`
+		Status  ToolRunStatus `json:"status"`
+		Input   JsonRaw       `json:"input,omitempty"`
+		Result  JsonRaw       `json:"result,omitempty"`
+	}
+)
+
+// Valid tells whether the rating is a thumbs up or down
+func (r FeedbackRating) Valid() bool {
+	return r == FeedbackRatingUp || r == FeedbackRatingDown
+}
+
+// FeedbackExamples returns the examples of the feedback with the conversation of the rated messages or the rated tool
+// runs. The history of each thread is read once.
+func (q *Queries) FeedbackExamples(ctx context.Context, feedback []Feedback) ([]FeedbackExample, error) {
+	histories := map[uuid.UUID][]ThreadMessage{}
+	examples := make([]FeedbackExample, 0, len(feedback))
+	for _, f := range feedback {
+		example := FeedbackExample{Feedback: f}
+		if f.ToolRunID.Valid {
+			run, err := q.GetToolRunStatusByID(ctx, f.ToolRunID.String)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tool run %s: %w", f.ToolRunID.String, err)
+			}
+			example.ToolRun = &FeedbackToolRun{
+				ToolID:  run.ToolID,
+				AgentID: run.AgentID,
+				Status:  run.Status,
+				Input:   run.Input,
+				Result:  run.Result,
+			}
+			examples = append(examples, example)
+			continue
+		}
+
+		history, ok := histories[f.ThreadID]
+		if !ok {
+			var err error
+			history, err = q.GetMessageHistory(ctx, f.ThreadID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get messages for thread %s: %w", f.ThreadID, err)
+			}
+			histories[f.ThreadID] = history
+		}
+		conversation := conversationAt(history, uuid.UUID(f.MessageID.Bytes))
+		if len(conversation) > 0 {
+			example.Messages = make([]JsonRaw, 0, len(conversation)-1)
+			for _, message := range conversation[:len(conversation)-1] {
+				example.Messages = append(example.Messages, message.Message)
+			}
+			example.Response = conversation[len(conversation)-1].Message
+		}
+		examples = append(examples, example)
+	}
+	return examples, nil
+}
+
+// conversationAt returns the conversation of a thread as it was when a message was created: the messages up to the
+// message that were not superseded yet, the message last. It is empty when the message is not in the history.
+func conversationAt(history []ThreadMessage, messageID uuid.UUID) []ThreadMessage {
+	var rated *ThreadMessage
+	for i := range history {
+		if history[i].ID == messageID {
+			rated = &history[i]
+			break
+		}
+	}
+	if rated == nil {
+		return nil
+	}
+	conversation := []ThreadMessage{}
+	for _, message := range history {
+		if message.ID == messageID || message.CreatedAt.Time.After(rated.CreatedAt.Time) {
+			continue
+		}
+		if message.SupersededAt.Valid && !message.SupersededAt.Time.After(rated.CreatedAt.Time) {
+			continue
+		}
+		conversation = append(conversation, message)
+	}
+	return append(conversation, *rated)
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConversationAt(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: start.Add(time.Duration(minutes) * time.Minute), Valid: true}
+	}
+	// A question answered, edited at minute 3 and answered again
+	question := ThreadMessage{ID: uuid.New(), CreatedAt: at(0), SupersededAt: at(3)}
+	answer := ThreadMessage{ID: uuid.New(), CreatedAt: at(1), SupersededAt: at(3)}
+	edited := ThreadMessage{ID: uuid.New(), CreatedAt: at(3)}
+	newAnswer := ThreadMessage{ID: uuid.New(), CreatedAt: at(4)}
+	followUp := ThreadMessage{ID: uuid.New(), CreatedAt: at(5)}
+	history := []ThreadMessage{question, answer, edited, newAnswer, followUp}
+
+	tests := []struct {
+		name      string
+		messageID uuid.UUID
+		expected  []ThreadMessage
+	}{
+		{name: "superseded answer", messageID: answer.ID, expected: []ThreadMessage{question, answer}},
+		{name: "answer of the edited question", messageID: newAnswer.ID, expected: []ThreadMessage{edited, newAnswer}},
+		{name: "first message", messageID: question.ID, expected: []ThreadMessage{question}},
+		{name: "unknown message", messageID: uuid.New(), expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, conversationAt(history, tt.messageID))
+		})
+	}
+}
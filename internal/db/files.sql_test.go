@@ -0,0 +1,112 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileAttachments(t *testing.T) {
+	t.Parallel()
+	db_pool := setupTestDB(t)
+	defer db_pool.Close()
+	queries := New(db_pool)
+
+	createUserParams := CreateUserParams{
+		Name:           "testuser_files_unique",
+		Email:          "files_unique@example.com",
+		AdditionalInfo: JsonRaw{},
+		PasswordHash:   "hashedpassword123",
+		ProviderName:   ProviderNameLocal,
+	}
+	createdUser, err := queries.CreateUser(t.Context(), createUserParams)
+	if err != nil {
+		t.Logf("User already exists, using existing user: %v", err)
+		user, err := queries.GetUserByEmail(t.Context(), createUserParams.Email)
+		if err != nil {
+			t.Fatalf("Failed to get existing user by email: %v", err)
+		}
+		createdUser = CreateUserRow(user)
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	thread, err := queries.CreateThread(t.Context(), CreateThreadParams{
+		WorkspaceID: DefaultWorkspaceID,
+		Title:       "Files Thread",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		UserID:      createdUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test thread: %v", err)
+	}
+
+	fileID := uuid.New()
+	file, err := queries.CreateFile(t.Context(), CreateFileParams{
+		ID:          fileID,
+		UserID:      createdUser.ID,
+		Name:        "photo.png",
+		ContentType: "image/png",
+		SizeBytes:   1024,
+		Status:      FileStatusPending,
+		Bucket:      "files",
+		ObjectKey:   "files/" + createdUser.ID.String() + "/" + fileID.String(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	assert.Equal(t, FileStatusPending, file.Status)
+	assert.False(t, file.UploadedAt.Valid)
+
+	// A pending upload is completed once
+	file, err = queries.CompleteFileUpload(t.Context(), CompleteFileUploadParams{ID: file.ID, UserID: createdUser.ID, SizeBytes: 2048, ContentType: "image/png"})
+	if err != nil {
+		t.Fatalf("Failed to complete file upload: %v", err)
+	}
+	assert.Equal(t, FileStatusUploaded, file.Status)
+	assert.Equal(t, int64(2048), file.SizeBytes)
+	assert.True(t, file.UploadedAt.Valid)
+	_, err = queries.CompleteFileUpload(t.Context(), CompleteFileUploadParams{ID: file.ID, UserID: createdUser.ID, SizeBytes: 2048, ContentType: "image/png"})
+	assert.Error(t, err, "An uploaded file should not be completed again")
+
+	message, err := queries.CreateUserMessage(t.Context(), CreateUserMessageParams{
+		ThreadID:    thread.ID,
+		Message:     JsonRaw(`{"role": "user", "content": "Describe the photo"}`),
+		SenderID:    createdUser.ID,
+		RecipientID: uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create message: %v", err)
+	}
+	err = queries.AttachFileToMessage(t.Context(), AttachFileToMessageParams{MessageID: message.ID, FileID: file.ID, Position: 0})
+	if err != nil {
+		t.Fatalf("Failed to attach file to message: %v", err)
+	}
+
+	attachments, err := queries.ListMessageAttachments(t.Context(), []uuid.UUID{message.ID, uuid.New()})
+	if err != nil {
+		t.Fatalf("Failed to list message attachments: %v", err)
+	}
+	if assert.Len(t, attachments, 1) {
+		assert.Equal(t, message.ID, attachments[0].MessageID)
+		assert.Equal(t, file.ID, attachments[0].ID)
+	}
+
+	// An attached file cannot be deleted
+	_, err = queries.DeleteFile(t.Context(), DeleteFileParams{ID: file.ID, UserID: createdUser.ID})
+	var pgErr *pgconn.PgError
+	if assert.ErrorAs(t, err, &pgErr) {
+		assert.Equal(t, "23503", pgErr.Code)
+	}
+
+	// Deleting the thread removes the attachments of its messages
+	if err := queries.DeleteThread(t.Context(), thread.ID); err != nil {
+		t.Fatalf("Failed to delete thread: %v", err)
+	}
+	_, err = queries.DeleteFile(t.Context(), DeleteFileParams{ID: file.ID, UserID: createdUser.ID})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	// FlowTaskDefinition is a task of the DAG of a flow, as reported by the flow library when a run starts
+	FlowTaskDefinition struct {
+		Name              string   `json:"name"`
+		DependsOn         []string `json:"depends_on,omitempty"`
+		MaxRetries        int32    `json:"max_retries"`
+		RetryDelaySeconds int32    `json:"retry_delay_seconds"`
+	}
+
+	// FlowRunGraph is the task DAG of a flow run with the live status of each task
+	FlowRunGraph struct {
+		FlowRunID uuid.UUID          `json:"flow_run_id"`
+		Status    FlowStatus         `json:"status"`
+		Nodes     []FlowRunGraphNode `json:"nodes"`
+		Edges     []FlowRunGraphEdge `json:"edges"`
+	}
+
+	// FlowRunGraphNode is a task of a flow run graph
+	FlowRunGraphNode struct {
+		TaskName          string             `json:"task_name"`
+		DependsOn         []string           `json:"depends_on"`
+		MaxRetries        int32              `json:"max_retries"`
+		RetryDelaySeconds int32              `json:"retry_delay_seconds"`
+		Status            FlowStatus         `json:"status"`
+		RetryCount        int32              `json:"retry_count"`
+		ErrorMessage      pgtype.Text        `json:"error_message"`
+		StartedAt         pgtype.Timestamptz `json:"started_at"`
+		FinishedAt        pgtype.Timestamptz `json:"finished_at"`
+		DurationSeconds   pgtype.Float8      `json:"duration_seconds"`
+	}
+
+	// FlowRunGraphEdge is a dependency of a flow run graph, the To task waits for the From task
+	FlowRunGraphEdge struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+)
+
+// ValidateFlowTaskGraph checks the tasks reported for a flow form a DAG: the names are unique and
+// every dependency is a task of the graph, without cycle
+func ValidateFlowTaskGraph(tasks []FlowTaskDefinition) error {
+	dependsOn := make(map[string][]string, len(tasks))
+	for _, task := range tasks {
+		if task.Name == "" {
+			return fmt.Errorf("task name is required")
+		}
+		if _, ok := dependsOn[task.Name]; ok {
+			return fmt.Errorf("task %s is defined twice", task.Name)
+		}
+		if task.MaxRetries < 0 || task.RetryDelaySeconds < 0 {
+			return fmt.Errorf("task %s has a negative retry setting", task.Name)
+		}
+		dependsOn[task.Name] = task.DependsOn
+	}
+
+	// Depth first search, a task reached again while its dependencies are visited closes a cycle
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(tasks))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("task %s depends on itself through a cycle", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range dependsOn[name] {
+			if _, ok := dependsOn[dep]; !ok {
+				return fmt.Errorf("task %s depends on unknown task %s", name, dep)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, task := range tasks {
+		if err := visit(task.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewFlowRunGraph builds the graph of a flow run from its reported tasks and the runs of its tasks.
+// A task without run yet is pending, a task run outside the reported graph is added without dependency.
+func NewFlowRunGraph(flowRun FlowRun, tasks []FlowRunGraphTask, taskRuns []FlowTaskRun) FlowRunGraph {
+	graph := FlowRunGraph{
+		FlowRunID: flowRun.FlowRunID,
+		Status:    flowRun.Status,
+		Nodes:     []FlowRunGraphNode{},
+		Edges:     []FlowRunGraphEdge{},
+	}
+
+	runs := make(map[string]FlowTaskRun, len(taskRuns))
+	for _, run := range taskRuns {
+		runs[run.TaskName] = run
+	}
+	nodes := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		node := FlowRunGraphNode{
+			TaskName:          task.TaskName,
+			DependsOn:         task.DependsOn,
+			MaxRetries:        task.MaxRetries,
+			RetryDelaySeconds: task.RetryDelaySeconds,
+			Status:            FlowStatusPending,
+		}
+		if node.DependsOn == nil {
+			node.DependsOn = []string{}
+		}
+		if run, ok := runs[task.TaskName]; ok {
+			node.setRun(run)
+		}
+		graph.Nodes = append(graph.Nodes, node)
+		nodes[task.TaskName] = true
+	}
+	for _, run := range taskRuns {
+		if nodes[run.TaskName] {
+			continue
+		}
+		node := FlowRunGraphNode{TaskName: run.TaskName, DependsOn: []string{}, MaxRetries: run.MaxRetries.Int32}
+		node.setRun(run)
+		graph.Nodes = append(graph.Nodes, node)
+		nodes[run.TaskName] = true
+	}
+
+	for _, node := range graph.Nodes {
+		for _, dep := range node.DependsOn {
+			if nodes[dep] {
+				graph.Edges = append(graph.Edges, FlowRunGraphEdge{From: dep, To: node.TaskName})
+			}
+		}
+	}
+	return graph
+}
+
+// setRun sets the live status of a node from the run of its task
+func (n *FlowRunGraphNode) setRun(run FlowTaskRun) {
+	n.Status = run.Status
+	n.RetryCount = run.RetryCount.Int32
+	n.ErrorMessage = run.ErrorMessage
+	n.StartedAt = run.StartedAt
+	n.FinishedAt = run.FinishedAt
+	n.DurationSeconds = run.DurationSeconds
+}
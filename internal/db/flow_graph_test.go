@@ -0,0 +1,72 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func Test_ValidateFlowTaskGraph(t *testing.T) {
+	t.Parallel()
+
+	valid := []FlowTaskDefinition{
+		{Name: "extract"},
+		{Name: "transform", DependsOn: []string{"extract"}, MaxRetries: 2, RetryDelaySeconds: 5},
+		{Name: "load", DependsOn: []string{"transform", "extract"}},
+	}
+	if err := ValidateFlowTaskGraph(valid); err != nil {
+		t.Fatalf("expected a valid graph, got %v", err)
+	}
+
+	tests := map[string][]FlowTaskDefinition{
+		"empty name":      {{Name: ""}},
+		"duplicate":       {{Name: "a"}, {Name: "a"}},
+		"unknown":         {{Name: "a", DependsOn: []string{"b"}}},
+		"self dependency": {{Name: "a", DependsOn: []string{"a"}}},
+		"cycle":           {{Name: "a", DependsOn: []string{"c"}}, {Name: "b", DependsOn: []string{"a"}}, {Name: "c", DependsOn: []string{"b"}}},
+		"negative retry":  {{Name: "a", MaxRetries: -1}},
+	}
+	for name, tasks := range tests {
+		if err := ValidateFlowTaskGraph(tasks); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func Test_NewFlowRunGraph(t *testing.T) {
+	t.Parallel()
+
+	flowRunID := uuid.New()
+	flowRun := FlowRun{FlowRunID: flowRunID, Status: FlowStatusRunning}
+	tasks := []FlowRunGraphTask{
+		{FlowRunID: flowRunID, TaskName: "extract", DependsOn: []string{}},
+		{FlowRunID: flowRunID, TaskName: "load", DependsOn: []string{"extract", "transform"}, MaxRetries: 3},
+	}
+	taskRuns := []FlowTaskRun{
+		{FlowRunID: flowRunID, TaskName: "extract", Status: FlowStatusSuccess, DurationSeconds: pgtype.Float8{Float64: 1.5, Valid: true}},
+		{FlowRunID: flowRunID, TaskName: "notify", Status: FlowStatusRunning, RetryCount: pgtype.Int4{Int32: 1, Valid: true}},
+	}
+
+	graph := NewFlowRunGraph(flowRun, tasks, taskRuns)
+	if graph.FlowRunID != flowRunID || graph.Status != FlowStatusRunning {
+		t.Fatalf("unexpected graph run: %s %s", graph.FlowRunID, graph.Status)
+	}
+	if len(graph.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %d", len(graph.Nodes))
+	}
+	if node := graph.Nodes[0]; node.Status != FlowStatusSuccess || node.DurationSeconds.Float64 != 1.5 {
+		t.Errorf("expected the extract node to succeed in 1.5s, got %s %v", node.Status, node.DurationSeconds)
+	}
+	if node := graph.Nodes[1]; node.Status != FlowStatusPending || node.MaxRetries != 3 {
+		t.Errorf("expected the load node to be pending with 3 retries, got %s %d", node.Status, node.MaxRetries)
+	}
+	if node := graph.Nodes[2]; node.TaskName != "notify" || node.Status != FlowStatusRunning || node.RetryCount != 1 {
+		t.Errorf("expected the unreported notify task to be added, got %+v", node)
+	}
+
+	// The dependency on the unknown transform task has no edge
+	if len(graph.Edges) != 1 || graph.Edges[0] != (FlowRunGraphEdge{From: "extract", To: "load"}) {
+		t.Errorf("unexpected edges: %+v", graph.Edges)
+	}
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/pinazu/internal/secrets"
+)
+
+// FlowNotificationType is the kind of endpoint notified of the runs of a flow
+type FlowNotificationType string
+
+const (
+	FlowNotificationWebhook FlowNotificationType = "webhook" // JSON payload of the run, signed when a secret is set
+	FlowNotificationSlack   FlowNotificationType = "slack"   // Slack incoming webhook message
+)
+
+// flowNotificationEvents are the statuses of a flow run which can be notified
+var flowNotificationEvents = []FlowStatus{FlowStatusSuccess, FlowStatusFailed}
+
+// FlowNotification is an endpoint notified by the flows service when a run of the flow succeeds or fails
+type FlowNotification struct {
+	Type   FlowNotificationType `json:"type"`
+	URL    string               `json:"url"`
+	Secret string               `json:"secret,omitempty"` // Secret reference of the HMAC key signing the webhook payloads
+	Events []FlowStatus         `json:"events,omitempty"` // Statuses notified, SUCCESS and FAILED when empty
+}
+
+// Notifies reports whether the notification is sent when a run of the flow ends with status
+func (n FlowNotification) Notifies(status FlowStatus) bool {
+	if len(n.Events) == 0 {
+		return slices.Contains(flowNotificationEvents, status)
+	}
+	return slices.Contains(n.Events, status)
+}
+
+// ValidateFlowNotifications checks the notifications of a flow
+func ValidateFlowNotifications(notifications []FlowNotification) error {
+	for i, n := range notifications {
+		switch n.Type {
+		case FlowNotificationWebhook, FlowNotificationSlack:
+		default:
+			return fmt.Errorf("notification %d: invalid type %q, valid options are: %s, %s", i, n.Type, FlowNotificationWebhook, FlowNotificationSlack)
+		}
+		if err := ValidateWebhookURL(n.URL); err != nil {
+			return fmt.Errorf("notification %d: %w", i, err)
+		}
+		if n.Secret != "" && !secrets.IsReference(n.Secret) {
+			return fmt.Errorf("notification %d: secret must be a secret reference such as env://NAME, not the secret itself", i)
+		}
+		for _, event := range n.Events {
+			if !slices.Contains(flowNotificationEvents, event) {
+				return fmt.Errorf("notification %d: invalid event %q, valid options are: %s, %s", i, event, FlowStatusSuccess, FlowStatusFailed)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseFlowNotifications parses the notifications of a flow. It returns none when the flow has no notifications.
+func ParseFlowNotifications(raw JsonRaw) ([]FlowNotification, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var notifications []FlowNotification
+	if err := json.Unmarshal(raw, &notifications); err != nil {
+		return nil, fmt.Errorf("invalid notifications: %w", err)
+	}
+	if err := ValidateFlowNotifications(notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
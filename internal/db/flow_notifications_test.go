@@ -0,0 +1,44 @@
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ParseFlowNotifications(t *testing.T) {
+	t.Parallel()
+
+	notifications, err := ParseFlowNotifications(JsonRaw(`[
+		{"type": "webhook", "url": "https://hooks.example.com/flows", "secret": "env://FLOW_WEBHOOK_SECRET"},
+		{"type": "slack", "url": "https://hooks.slack.com/services/T/B/X", "events": ["FAILED"]}
+	]`))
+	if err != nil || len(notifications) != 2 {
+		t.Fatalf("expected 2 notifications, got %v, %v", notifications, err)
+	}
+	if !notifications[0].Notifies(FlowStatusSuccess) || !notifications[0].Notifies(FlowStatusFailed) {
+		t.Fatalf("expected a notification without events to notify SUCCESS and FAILED")
+	}
+	if notifications[1].Notifies(FlowStatusSuccess) || !notifications[1].Notifies(FlowStatusFailed) {
+		t.Fatalf("expected a notification to notify only its events")
+	}
+	if notifications[0].Notifies(FlowStatusCancelled) {
+		t.Fatalf("expected cancelled runs not to be notified")
+	}
+
+	if notifications, err := ParseFlowNotifications(nil); err != nil || notifications != nil {
+		t.Fatalf("expected no notifications, got %v, %v", notifications, err)
+	}
+
+	for raw, want := range map[string]string{
+		`[{"type": "email", "url": "https://example.com"}]`:                          "invalid type",
+		`[{"type": "webhook", "url": "ftp://example.com"}]`:                          "absolute http or https URL",
+		`[{"type": "webhook", "url": "http://169.254.169.254/latest"}]`:              "private or link-local address",
+		`[{"type": "slack", "url": "http://localhost:8080/hook"}]`:                   "local host",
+		`[{"type": "webhook", "url": "https://example.com", "secret": "plain"}]`:     "secret reference",
+		`[{"type": "webhook", "url": "https://example.com", "events": ["RUNNING"]}]`: "invalid event",
+	} {
+		if _, err := ParseFlowNotifications(JsonRaw(raw)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q for %s, got %v", want, raw, err)
+		}
+	}
+}
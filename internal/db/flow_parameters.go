@@ -0,0 +1,46 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FlowParameterViolation is a single violation of the parameters schema of a flow by the parameters of a run
+type FlowParameterViolation = ToolInputViolation
+
+// ParseFlowParametersSchema parses the JSON schema of the parameters of a flow.
+// It returns nil when the flow has no parameters schema.
+func ParseFlowParametersSchema(raw JsonRaw) (*openapi3.Schema, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var schema openapi3.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("invalid parameters schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// ValidateFlowParameters validates the parameters of a flow run against the parameters schema of the flow.
+// It returns no violations when the flow has no parameters schema.
+func ValidateFlowParameters(flow Flow, parameters map[string]any) ([]FlowParameterViolation, error) {
+	schema, err := ParseFlowParametersSchema(flow.ParametersSchema)
+	if err != nil {
+		return nil, err
+	}
+	if parameters == nil {
+		parameters = map[string]any{}
+	}
+	// Round trip the parameters through JSON, so the validator sees the JSON value types
+	data, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+	var input map[string]any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters: %w", err)
+	}
+	return ValidateToolInput(schema, input), nil
+}
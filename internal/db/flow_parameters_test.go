@@ -0,0 +1,51 @@
+package db
+
+import (
+	"testing"
+)
+
+func Test_ValidateFlowParameters(t *testing.T) {
+	t.Parallel()
+
+	flow := Flow{ParametersSchema: JsonRaw(`{
+		"type": "object",
+		"properties": {
+			"input_text": {"type": "string"},
+			"limit": {"type": "integer", "minimum": 1}
+		},
+		"required": ["input_text"]
+	}`)}
+
+	violations, err := ValidateFlowParameters(flow, map[string]any{"input_text": "hello", "limit": 10})
+	if err != nil || len(violations) != 0 {
+		t.Fatalf("expected valid parameters, got %v, %v", violations, err)
+	}
+
+	violations, err = ValidateFlowParameters(flow, map[string]any{"limit": 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paths := map[string]bool{}
+	for _, v := range violations {
+		paths[v.Path] = true
+	}
+	if len(violations) != 2 || !paths["/input_text"] || !paths["/limit"] {
+		t.Fatalf("expected violations of /input_text and /limit, got %v", violations)
+	}
+
+	// Missing parameters are validated as an empty object
+	if violations, _ := ValidateFlowParameters(flow, nil); len(violations) != 1 {
+		t.Fatalf("expected the missing required parameter, got %v", violations)
+	}
+
+	// Flows without a parameters schema accept any parameters
+	for _, schema := range []JsonRaw{nil, JsonRaw(`null`), JsonRaw(`{}`)} {
+		if violations, err := ValidateFlowParameters(Flow{ParametersSchema: schema}, map[string]any{"any": 1}); err != nil || len(violations) != 0 {
+			t.Fatalf("expected no violations without schema %q, got %v, %v", schema, violations, err)
+		}
+	}
+
+	if _, err := ValidateFlowParameters(Flow{ParametersSchema: JsonRaw(`{"type": 1}`)}, nil); err == nil {
+		t.Fatal("expected an error for an invalid parameters schema")
+	}
+}
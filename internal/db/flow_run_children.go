@@ -0,0 +1,57 @@
+package db
+
+// FlowRunChildrenSummary aggregates the statuses of the sub-flow runs of a flow run
+type FlowRunChildrenSummary struct {
+	Total     int                `json:"total"`
+	Statuses  map[FlowStatus]int `json:"statuses"`
+	Completed bool               `json:"completed"` // Every child run reached its final status
+	Failed    int                `json:"failed"`    // Child runs failed with no retry left or cancelled
+}
+
+// IsCompleted reports whether a flow run reached its final status: succeeded, cancelled, or failed with no
+// retry left
+func (r FlowRun) IsCompleted() bool {
+	switch r.Status {
+	case FlowStatusSuccess, FlowStatusCancelled:
+		return true
+	case FlowStatusFailed:
+		return r.RetryCount.Int32 >= r.MaxRetries.Int32
+	default:
+		return false
+	}
+}
+
+// IsActive reports whether a flow run can still be cancelled
+func (r FlowRun) IsActive() bool {
+	switch r.Status {
+	case FlowStatusScheduled, FlowStatusPending, FlowStatusRunning, FlowStatusPaused:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanRetry reports whether a flow run can be retried as a new run: it failed with no automatic retry left or was
+// cancelled
+func (r FlowRun) CanRetry() bool {
+	return r.IsCompleted() && r.Status != FlowStatusSuccess
+}
+
+// SummarizeChildFlowRuns aggregates the statuses of the child runs of a flow run. A flow run without child runs
+// is completed.
+func SummarizeChildFlowRuns(children []FlowRun) FlowRunChildrenSummary {
+	summary := FlowRunChildrenSummary{
+		Total:     len(children),
+		Statuses:  make(map[FlowStatus]int),
+		Completed: true,
+	}
+	for _, child := range children {
+		summary.Statuses[child.Status]++
+		if !child.IsCompleted() {
+			summary.Completed = false
+		} else if child.Status != FlowStatusSuccess {
+			summary.Failed++
+		}
+	}
+	return summary
+}
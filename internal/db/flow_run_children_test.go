@@ -0,0 +1,62 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func Test_FlowRunIsCompleted(t *testing.T) {
+	t.Parallel()
+
+	retries := func(count, max int32) (pgtype.Int4, pgtype.Int4) {
+		return pgtype.Int4{Int32: count, Valid: true}, pgtype.Int4{Int32: max, Valid: true}
+	}
+	count, max := retries(1, 2)
+	if (FlowRun{Status: FlowStatusFailed, RetryCount: count, MaxRetries: max}).IsCompleted() {
+		t.Fatalf("expected a failed run with a retry left not to be completed")
+	}
+	count, max = retries(2, 2)
+	if !(FlowRun{Status: FlowStatusFailed, RetryCount: count, MaxRetries: max}).IsCompleted() {
+		t.Fatalf("expected a failed run with no retry left to be completed")
+	}
+	if !(FlowRun{Status: FlowStatusFailed}).IsCompleted() {
+		t.Fatalf("expected a failed run without retries to be completed")
+	}
+	if (FlowRun{Status: FlowStatusPaused}).IsCompleted() || !(FlowRun{Status: FlowStatusPaused}).IsActive() {
+		t.Fatalf("expected a paused run to be active")
+	}
+	if !(FlowRun{Status: FlowStatusCancelled}).CanRetry() || (FlowRun{Status: FlowStatusSuccess}).CanRetry() {
+		t.Fatalf("expected a cancelled run to be retryable and a successful run not to be")
+	}
+	count, max = retries(0, 1)
+	if (FlowRun{Status: FlowStatusFailed, RetryCount: count, MaxRetries: max}).CanRetry() {
+		t.Fatalf("expected a failed run with an automatic retry left not to be retryable")
+	}
+}
+
+func Test_SummarizeChildFlowRuns(t *testing.T) {
+	t.Parallel()
+
+	if summary := SummarizeChildFlowRuns(nil); !summary.Completed || summary.Total != 0 {
+		t.Fatalf("expected a run without children to be completed, got %+v", summary)
+	}
+
+	children := []FlowRun{
+		{Status: FlowStatusSuccess},
+		{Status: FlowStatusCancelled},
+		{Status: FlowStatusRunning},
+	}
+	summary := SummarizeChildFlowRuns(children)
+	if summary.Completed || summary.Total != 3 || summary.Failed != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if summary.Statuses[FlowStatusSuccess] != 1 || summary.Statuses[FlowStatusRunning] != 1 {
+		t.Fatalf("unexpected statuses %v", summary.Statuses)
+	}
+
+	children[2].Status = FlowStatusSuccess
+	if summary := SummarizeChildFlowRuns(children); !summary.Completed || summary.Statuses[FlowStatusSuccess] != 2 {
+		t.Fatalf("expected completed children, got %+v", summary)
+	}
+}
@@ -184,7 +184,7 @@ func (q *Queries) GetFlowRunEvent(ctx context.Context, eventID uuid.UUID) (FlowR
 const getFlowRunEventsByFlowRun = `-- name: GetFlowRunEventsByFlowRun :many
 SELECT event_id, flow_run_id, task_name, event_type, event_data, event_timestamp, source, created_at FROM flow_run_events 
 WHERE flow_run_id = $1 
-ORDER BY event_timestamp ASC
+ORDER BY event_timestamp ASC, event_id ASC
 `
 
 func (q *Queries) GetFlowRunEventsByFlowRun(ctx context.Context, flowRunID pgtype.UUID) ([]FlowRunEvent, error) {
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Event types of the timeline of a flow run, stored in flow_run_events
+const (
+	FlowRunEventRequest        = "FlowRunRequest"        // The run was created and scheduled
+	FlowRunEventExecute        = "FlowRunExecuteEvent"   // The run was dispatched to the workers
+	FlowRunEventWorkerAssigned = "FlowRunWorkerAssigned" // A worker picked up the run and started its process
+	FlowRunEventStatus         = "FlowRunStatusEvent"    // The status of the run changed
+	FlowRunEventTaskStatus     = "TaskRunStatusEvent"    // The status of a task of the run changed
+	FlowRunEventRetry          = "FlowRunRetry"          // The failed run was retried
+	FlowRunEventRescheduled    = "FlowRunRescheduled"    // The run was rescheduled after its worker stopped
+	FlowRunEventQueued         = "FlowRunQueued"         // The run waits for the concurrency limit of its flow
+)
+
+// Sources of the events of the timeline of a flow run
+const (
+	FlowRunEventSourceOrchestrator = "orchestrator"
+	FlowRunEventSourceWorker       = "worker"
+)
+
+type (
+	// FlowRunEventData holds the details of an event of the timeline of a flow run
+	FlowRunEventData struct {
+		Status        FlowStatus  `json:"status,omitempty"`
+		WorkerID      string      `json:"worker_id,omitempty"`
+		ErrorMessage  string      `json:"error_message,omitempty"`
+		FailureReason string      `json:"failure_reason,omitempty"`
+		RetryCount    int32       `json:"retry_count,omitempty"`
+		CachedTasks   int         `json:"cached_tasks,omitempty"` // Tasks skipped with the results of a previous attempt
+		RetryOf       pgtype.UUID `json:"retry_of,omitzero"`      // Flow run retried by a new run
+		Message       string      `json:"message,omitempty"`
+	}
+
+	// FlowRunTimeline is the ordered list of the state transitions of a flow run and its tasks
+	FlowRunTimeline struct {
+		FlowRunID       uuid.UUID              `json:"flow_run_id"`
+		Status          FlowStatus             `json:"status"`
+		Events          []FlowRunTimelineEvent `json:"events"`
+		DurationSeconds float64                `json:"duration_seconds"` // From the first to the last event
+	}
+
+	// FlowRunTimelineEvent is an event of a flow run timeline with the time spent until the next transition
+	FlowRunTimelineEvent struct {
+		EventID         uuid.UUID     `json:"event_id"`
+		EventType       string        `json:"event_type"`
+		TaskName        pgtype.Text   `json:"task_name"`
+		Source          pgtype.Text   `json:"source"`
+		Timestamp       time.Time     `json:"timestamp"`
+		Data            JsonRaw       `json:"data"`
+		ElapsedSeconds  float64       `json:"elapsed_seconds"`  // Since the first event of the run
+		DurationSeconds pgtype.Float8 `json:"duration_seconds"` // Until the next event of the run, or of the task for a task event
+	}
+)
+
+// RecordFlowRunEvent appends an event to the timeline of a flow run, the task name is empty for an event of the run
+func (q *Queries) RecordFlowRunEvent(ctx context.Context, flowRunID uuid.UUID, taskName string, eventType string, source string, timestamp time.Time, data FlowRunEventData) error {
+	eventData, err := NewJsonRaw(data)
+	if err != nil {
+		return err
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	_, err = q.CreateFlowRunEvent(ctx, CreateFlowRunEventParams{
+		EventID:        uuid.Must(uuid.NewV7()),
+		FlowRunID:      pgtype.UUID{Bytes: flowRunID, Valid: true},
+		TaskName:       pgtype.Text{String: taskName, Valid: taskName != ""},
+		EventType:      eventType,
+		EventData:      eventData,
+		EventTimestamp: pgtype.Timestamptz{Time: timestamp.UTC(), Valid: true},
+		Source:         pgtype.Text{String: source, Valid: source != ""},
+	})
+	return err
+}
+
+// NewFlowRunTimeline builds the timeline of a flow run from its events, ordered by timestamp. The duration of a run
+// event lasts until the next run event, the one of a task event until the next event of the task.
+func NewFlowRunTimeline(flowRun FlowRun, events []FlowRunEvent) FlowRunTimeline {
+	timeline := FlowRunTimeline{
+		FlowRunID: flowRun.FlowRunID,
+		Status:    flowRun.Status,
+		Events:    make([]FlowRunTimelineEvent, 0, len(events)),
+	}
+	if len(events) == 0 {
+		return timeline
+	}
+
+	start := events[0].EventTimestamp.Time
+	last := make(map[string]int) // Index of the last event of the run, key "", or of each task
+	for _, event := range events {
+		entry := FlowRunTimelineEvent{
+			EventID:        event.EventID,
+			EventType:      event.EventType,
+			TaskName:       event.TaskName,
+			Source:         event.Source,
+			Timestamp:      event.EventTimestamp.Time,
+			Data:           event.EventData,
+			ElapsedSeconds: event.EventTimestamp.Time.Sub(start).Seconds(),
+		}
+		scope := event.TaskName.String
+		if previous, ok := last[scope]; ok {
+			timeline.Events[previous].DurationSeconds = pgtype.Float8{
+				Float64: entry.Timestamp.Sub(timeline.Events[previous].Timestamp).Seconds(),
+				Valid:   true,
+			}
+		}
+		last[scope] = len(timeline.Events)
+		timeline.Events = append(timeline.Events, entry)
+	}
+	timeline.DurationSeconds = timeline.Events[len(timeline.Events)-1].ElapsedSeconds
+	return timeline
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func Test_NewFlowRunTimeline(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := func(eventType string, taskName string, after time.Duration) FlowRunEvent {
+		return FlowRunEvent{
+			EventID:        uuid.New(),
+			TaskName:       pgtype.Text{String: taskName, Valid: taskName != ""},
+			EventType:      eventType,
+			EventTimestamp: pgtype.Timestamptz{Time: start.Add(after), Valid: true},
+		}
+	}
+	flowRun := FlowRun{FlowRunID: uuid.New(), Status: FlowStatusSuccess}
+	timeline := NewFlowRunTimeline(flowRun, []FlowRunEvent{
+		event(FlowRunEventRequest, "", 0),
+		event(FlowRunEventWorkerAssigned, "", 2*time.Second),
+		event(FlowRunEventTaskStatus, "extract", 3*time.Second),
+		event(FlowRunEventTaskStatus, "load", 4*time.Second),
+		event(FlowRunEventTaskStatus, "extract", 13*time.Second),
+		event(FlowRunEventStatus, "", 15*time.Second),
+	})
+
+	if timeline.FlowRunID != flowRun.FlowRunID || timeline.Status != FlowStatusSuccess {
+		t.Fatalf("expected the timeline of the flow run, got %v %s", timeline.FlowRunID, timeline.Status)
+	}
+	if len(timeline.Events) != 6 || timeline.DurationSeconds != 15 {
+		t.Fatalf("expected 6 events over 15s, got %d over %vs", len(timeline.Events), timeline.DurationSeconds)
+	}
+	expected := []struct {
+		elapsed  float64
+		duration pgtype.Float8
+	}{
+		{0, pgtype.Float8{Float64: 2, Valid: true}},
+		{2, pgtype.Float8{Float64: 13, Valid: true}}, // Until the next event of the run, the task events are skipped
+		{3, pgtype.Float8{Float64: 10, Valid: true}}, // Until the next event of the extract task
+		{4, pgtype.Float8{}},                         // The last event of the load task
+		{13, pgtype.Float8{}},
+		{15, pgtype.Float8{}},
+	}
+	for i, e := range expected {
+		if timeline.Events[i].ElapsedSeconds != e.elapsed || timeline.Events[i].DurationSeconds != e.duration {
+			t.Fatalf("event %d: expected elapsed %vs and duration %v, got %vs and %v",
+				i, e.elapsed, e.duration, timeline.Events[i].ElapsedSeconds, timeline.Events[i].DurationSeconds)
+		}
+	}
+
+	if empty := NewFlowRunTimeline(flowRun, nil); empty.Events == nil || len(empty.Events) != 0 || empty.DurationSeconds != 0 {
+		t.Fatalf("expected an empty timeline, got %+v", empty)
+	}
+}
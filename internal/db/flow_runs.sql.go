@@ -12,6 +12,107 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const assignFlowRunWorker = `-- name: AssignFlowRunWorker :exec
+UPDATE flow_runs
+SET worker_id = $1,
+    updated_at = NOW()
+WHERE flow_run_id = $2
+`
+
+type AssignFlowRunWorkerParams struct {
+	WorkerID  pgtype.Text `db:"worker_id" json:"worker_id"`
+	FlowRunID uuid.UUID   `db:"flow_run_id" json:"flow_run_id"`
+}
+
+// Records the worker running the process of a flow run
+func (q *Queries) AssignFlowRunWorker(ctx context.Context, arg AssignFlowRunWorkerParams) error {
+	_, err := q.db.Exec(ctx, assignFlowRunWorker, arg.WorkerID, arg.FlowRunID)
+	return err
+}
+
+const cancelFlowRun = `-- name: CancelFlowRun :one
+UPDATE flow_runs
+SET status = 'CANCELLED',
+    error_message = $2,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'PAUSED')
+RETURNING flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id
+`
+
+type CancelFlowRunParams struct {
+	FlowRunID    uuid.UUID   `db:"flow_run_id" json:"flow_run_id"`
+	ErrorMessage pgtype.Text `db:"error_message" json:"error_message"`
+}
+
+// Ends an active flow run as cancelled, no row is returned when the run already ended
+func (q *Queries) CancelFlowRun(ctx context.Context, arg CancelFlowRunParams) (FlowRun, error) {
+	row := q.db.QueryRow(ctx, cancelFlowRun, arg.FlowRunID, arg.ErrorMessage)
+	var i FlowRun
+	err := row.Scan(
+		&i.FlowRunID,
+		&i.FlowID,
+		&i.Parameters,
+		&i.Status,
+		&i.Engine,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.TaskStatuses,
+		&i.SuccessTaskResults,
+		&i.ErrorMessage,
+		&i.RetryCount,
+		&i.MaxRetries,
+		&i.FailureReason,
+		&i.WorkerID,
+		&i.ParentRunID,
+	)
+	return i, err
+}
+
+const countActiveFlowRuns = `-- name: CountActiveFlowRuns :one
+SELECT COUNT(*) FROM flow_runs
+WHERE flow_run_id = ANY($1::uuid[])
+AND status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+`
+
+// Counts the flow runs among the IDs that are scheduled, pending or running
+func (q *Queries) CountActiveFlowRuns(ctx context.Context, flowRunIds []uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveFlowRuns, flowRunIds)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFlowRunsByFlowID = `-- name: CountFlowRunsByFlowID :one
+SELECT COUNT(*) FROM flow_runs r
+WHERE r.flow_id = $1
+  AND ($2::text IS NULL OR r.status::text = $2::text)
+  AND ($3::timestamptz IS NULL OR r.created_at >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR r.created_at < $4::timestamptz)
+`
+
+type CountFlowRunsByFlowIDParams struct {
+	FlowID        uuid.UUID          `db:"flow_id" json:"flow_id"`
+	Status        pgtype.Text        `db:"status" json:"status"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+}
+
+// Counts the runs of a flow matching the filters of ListFlowRunsByFlowID
+func (q *Queries) CountFlowRunsByFlowID(ctx context.Context, arg CountFlowRunsByFlowIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countFlowRunsByFlowID,
+		arg.FlowID,
+		arg.Status,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createFlowRun = `-- name: CreateFlowRun :one
 INSERT INTO flow_runs (
     flow_run_id,
@@ -21,10 +122,11 @@ INSERT INTO flow_runs (
     engine,
     task_statuses,
     success_task_results,
-    max_retries
+    max_retries,
+    parent_run_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id
 `
 
 type CreateFlowRunParams struct {
@@ -36,6 +138,7 @@ type CreateFlowRunParams struct {
 	TaskStatuses       JsonRaw     `db:"task_statuses" json:"task_statuses"`
 	SuccessTaskResults JsonRaw     `db:"success_task_results" json:"success_task_results"`
 	MaxRetries         pgtype.Int4 `db:"max_retries" json:"max_retries"`
+	ParentRunID        pgtype.UUID `db:"parent_run_id" json:"parent_run_id"`
 }
 
 func (q *Queries) CreateFlowRun(ctx context.Context, arg CreateFlowRunParams) (FlowRun, error) {
@@ -48,6 +151,7 @@ func (q *Queries) CreateFlowRun(ctx context.Context, arg CreateFlowRunParams) (F
 		arg.TaskStatuses,
 		arg.SuccessTaskResults,
 		arg.MaxRetries,
+		arg.ParentRunID,
 	)
 	var i FlowRun
 	err := row.Scan(
@@ -65,6 +169,9 @@ func (q *Queries) CreateFlowRun(ctx context.Context, arg CreateFlowRunParams) (F
 		&i.ErrorMessage,
 		&i.RetryCount,
 		&i.MaxRetries,
+		&i.FailureReason,
+		&i.WorkerID,
+		&i.ParentRunID,
 	)
 	return i, err
 }
@@ -89,8 +196,53 @@ func (q *Queries) DeleteOldFlowRuns(ctx context.Context, createdAt pgtype.Timest
 	return err
 }
 
+const getChildFlowRunsByParentID = `-- name: GetChildFlowRunsByParentID :many
+SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id FROM flow_runs
+WHERE parent_run_id = $1
+ORDER BY created_at ASC
+`
+
+// Lists the sub-flow runs triggered by a flow run, oldest first
+func (q *Queries) GetChildFlowRunsByParentID(ctx context.Context, parentRunID pgtype.UUID) ([]FlowRun, error) {
+	rows, err := q.db.Query(ctx, getChildFlowRunsByParentID, parentRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FlowRun{}
+	for rows.Next() {
+		var i FlowRun
+		if err := rows.Scan(
+			&i.FlowRunID,
+			&i.FlowID,
+			&i.Parameters,
+			&i.Status,
+			&i.Engine,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.TaskStatuses,
+			&i.SuccessTaskResults,
+			&i.ErrorMessage,
+			&i.RetryCount,
+			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFailedFlowRunsForRetry = `-- name: GetFailedFlowRunsForRetry :many
-SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries FROM flow_runs 
+SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id FROM flow_runs 
 WHERE status = 'FAILED' 
 AND retry_count < max_retries 
 ORDER BY created_at ASC
@@ -120,6 +272,9 @@ func (q *Queries) GetFailedFlowRunsForRetry(ctx context.Context) ([]FlowRun, err
 			&i.ErrorMessage,
 			&i.RetryCount,
 			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -132,7 +287,7 @@ func (q *Queries) GetFailedFlowRunsForRetry(ctx context.Context) ([]FlowRun, err
 }
 
 const getFlowRun = `-- name: GetFlowRun :one
-SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries FROM flow_runs WHERE flow_run_id = $1
+SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id FROM flow_runs WHERE flow_run_id = $1
 `
 
 func (q *Queries) GetFlowRun(ctx context.Context, flowRunID uuid.UUID) (FlowRun, error) {
@@ -153,12 +308,15 @@ func (q *Queries) GetFlowRun(ctx context.Context, flowRunID uuid.UUID) (FlowRun,
 		&i.ErrorMessage,
 		&i.RetryCount,
 		&i.MaxRetries,
+		&i.FailureReason,
+		&i.WorkerID,
+		&i.ParentRunID,
 	)
 	return i, err
 }
 
 const getFlowRunsByFlowID = `-- name: GetFlowRunsByFlowID :many
-SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries FROM flow_runs 
+SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id FROM flow_runs 
 WHERE flow_id = $1 
 ORDER BY created_at DESC
 `
@@ -187,6 +345,9 @@ func (q *Queries) GetFlowRunsByFlowID(ctx context.Context, flowID uuid.UUID) ([]
 			&i.ErrorMessage,
 			&i.RetryCount,
 			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -199,7 +360,7 @@ func (q *Queries) GetFlowRunsByFlowID(ctx context.Context, flowID uuid.UUID) ([]
 }
 
 const getFlowRunsByStatus = `-- name: GetFlowRunsByStatus :many
-SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries FROM flow_runs 
+SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id FROM flow_runs 
 WHERE status = $1 
 ORDER BY created_at DESC
 `
@@ -228,6 +389,9 @@ func (q *Queries) GetFlowRunsByStatus(ctx context.Context, status FlowStatus) ([
 			&i.ErrorMessage,
 			&i.RetryCount,
 			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -240,7 +404,7 @@ func (q *Queries) GetFlowRunsByStatus(ctx context.Context, status FlowStatus) ([
 }
 
 const getPendingFlowRuns = `-- name: GetPendingFlowRuns :many
-SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries FROM flow_runs 
+SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id FROM flow_runs 
 WHERE status IN ('SCHEDULED', 'PENDING') 
 ORDER BY created_at ASC
 `
@@ -269,6 +433,9 @@ func (q *Queries) GetPendingFlowRuns(ctx context.Context) ([]FlowRun, error) {
 			&i.ErrorMessage,
 			&i.RetryCount,
 			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -280,6 +447,44 @@ func (q *Queries) GetPendingFlowRuns(ctx context.Context) ([]FlowRun, error) {
 	return items, nil
 }
 
+const getWorkspaceFlowRun = `-- name: GetWorkspaceFlowRun :one
+SELECT r.flow_run_id, r.flow_id, r.parameters, r.status, r.engine, r.created_at, r.updated_at, r.started_at, r.finished_at, r.task_statuses, r.success_task_results, r.error_message, r.retry_count, r.max_retries, r.failure_reason, r.worker_id, r.parent_run_id FROM flow_runs r
+JOIN flows f ON f.id = r.flow_id
+WHERE f.workspace_id = $1 AND r.flow_run_id = $2
+LIMIT 1
+`
+
+type GetWorkspaceFlowRunParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	FlowRunID   uuid.UUID `db:"flow_run_id" json:"flow_run_id"`
+}
+
+// Gets a run of a flow of a workspace, the runs of the flows in the trash included
+func (q *Queries) GetWorkspaceFlowRun(ctx context.Context, arg GetWorkspaceFlowRunParams) (FlowRun, error) {
+	row := q.db.QueryRow(ctx, getWorkspaceFlowRun, arg.WorkspaceID, arg.FlowRunID)
+	var i FlowRun
+	err := row.Scan(
+		&i.FlowRunID,
+		&i.FlowID,
+		&i.Parameters,
+		&i.Status,
+		&i.Engine,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.TaskStatuses,
+		&i.SuccessTaskResults,
+		&i.ErrorMessage,
+		&i.RetryCount,
+		&i.MaxRetries,
+		&i.FailureReason,
+		&i.WorkerID,
+		&i.ParentRunID,
+	)
+	return i, err
+}
+
 const incrementFlowRunRetryCount = `-- name: IncrementFlowRunRetryCount :exec
 UPDATE flow_runs 
 SET retry_count = retry_count + 1,
@@ -296,7 +501,7 @@ func (q *Queries) IncrementFlowRunRetryCount(ctx context.Context, flowRunID uuid
 }
 
 const listFlowRuns = `-- name: ListFlowRuns :many
-SELECT fr.flow_run_id, fr.flow_id, fr.parameters, fr.status, fr.engine, fr.created_at, fr.updated_at, fr.started_at, fr.finished_at, fr.task_statuses, fr.success_task_results, fr.error_message, fr.retry_count, fr.max_retries, f.name as flow_name, f.description as flow_description
+SELECT fr.flow_run_id, fr.flow_id, fr.parameters, fr.status, fr.engine, fr.created_at, fr.updated_at, fr.started_at, fr.finished_at, fr.task_statuses, fr.success_task_results, fr.error_message, fr.retry_count, fr.max_retries, fr.failure_reason, fr.worker_id, fr.parent_run_id, f.name as flow_name, f.description as flow_description
 FROM flow_runs fr
 JOIN flows f ON fr.flow_id = f.id
 ORDER BY fr.created_at DESC
@@ -323,6 +528,9 @@ type ListFlowRunsRow struct {
 	ErrorMessage       pgtype.Text        `db:"error_message" json:"error_message"`
 	RetryCount         pgtype.Int4        `db:"retry_count" json:"retry_count"`
 	MaxRetries         pgtype.Int4        `db:"max_retries" json:"max_retries"`
+	FailureReason      pgtype.Text        `db:"failure_reason" json:"failure_reason"`
+	WorkerID           pgtype.Text        `db:"worker_id" json:"worker_id"`
+	ParentRunID        pgtype.UUID        `db:"parent_run_id" json:"parent_run_id"`
 	FlowName           string             `db:"flow_name" json:"flow_name"`
 	FlowDescription    pgtype.Text        `db:"flow_description" json:"flow_description"`
 }
@@ -351,6 +559,9 @@ func (q *Queries) ListFlowRuns(ctx context.Context, arg ListFlowRunsParams) ([]L
 			&i.ErrorMessage,
 			&i.RetryCount,
 			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
 			&i.FlowName,
 			&i.FlowDescription,
 		); err != nil {
@@ -364,22 +575,254 @@ func (q *Queries) ListFlowRuns(ctx context.Context, arg ListFlowRunsParams) ([]L
 	return items, nil
 }
 
+const listFlowRunsByFlowID = `-- name: ListFlowRunsByFlowID :many
+SELECT flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id FROM flow_runs r
+WHERE r.flow_id = $1
+  AND ($2::text IS NULL OR r.status::text = $2::text)
+  AND ($3::timestamptz IS NULL OR r.created_at >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR r.created_at < $4::timestamptz)
+  AND ($5::timestamptz IS NULL OR CASE
+    WHEN $6::text = 'updated_at' AND NOT $7::boolean
+      THEN (r.updated_at, r.flow_run_id) > ($5::timestamptz, $8::uuid)
+    WHEN $6::text = 'updated_at'
+      THEN (r.updated_at, r.flow_run_id) < ($5::timestamptz, $8::uuid)
+    WHEN NOT $7::boolean
+      THEN (r.created_at, r.flow_run_id) > ($5::timestamptz, $8::uuid)
+    ELSE (r.created_at, r.flow_run_id) < ($5::timestamptz, $8::uuid)
+  END)
+ORDER BY
+  CASE WHEN $6::text = 'updated_at' AND NOT $7::boolean THEN r.updated_at END ASC,
+  CASE WHEN $6::text = 'updated_at' AND $7::boolean THEN r.updated_at END DESC,
+  CASE WHEN $6::text <> 'updated_at' AND NOT $7::boolean THEN r.created_at END ASC,
+  CASE WHEN $6::text <> 'updated_at' AND $7::boolean THEN r.created_at END DESC,
+  CASE WHEN NOT $7::boolean THEN r.flow_run_id END ASC,
+  CASE WHEN $7::boolean THEN r.flow_run_id END DESC
+LIMIT $9
+`
+
+type ListFlowRunsByFlowIDParams struct {
+	FlowID        uuid.UUID          `db:"flow_id" json:"flow_id"`
+	Status        pgtype.Text        `db:"status" json:"status"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+	CursorTime    pgtype.Timestamptz `db:"cursor_time" json:"cursor_time"`
+	Sort          string             `db:"sort" json:"sort"`
+	Descending    bool               `db:"descending" json:"descending"`
+	CursorID      uuid.UUID          `db:"cursor_id" json:"cursor_id"`
+	RowLimit      int32              `db:"row_limit" json:"row_limit"`
+}
+
+// Lists the runs of a flow matching the filters in the order of the sort, after the run of the cursor when set
+func (q *Queries) ListFlowRunsByFlowID(ctx context.Context, arg ListFlowRunsByFlowIDParams) ([]FlowRun, error) {
+	rows, err := q.db.Query(ctx, listFlowRunsByFlowID,
+		arg.FlowID,
+		arg.Status,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorTime,
+		arg.Sort,
+		arg.Descending,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FlowRun{}
+	for rows.Next() {
+		var i FlowRun
+		if err := rows.Scan(
+			&i.FlowRunID,
+			&i.FlowID,
+			&i.Parameters,
+			&i.Status,
+			&i.Engine,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.TaskStatuses,
+			&i.SuccessTaskResults,
+			&i.ErrorMessage,
+			&i.RetryCount,
+			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rescheduleOrphanedFlowRuns = `-- name: RescheduleOrphanedFlowRuns :many
+UPDATE flow_runs
+SET status = 'SCHEDULED',
+    worker_id = NULL,
+    started_at = NULL,
+    updated_at = NOW()
+WHERE status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+AND worker_id IN (SELECT worker_id FROM worker_heartbeats WHERE status IN ('INACTIVE', 'FAILED'))
+RETURNING flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id
+`
+
+// Schedules again the active flow runs of the workers that stopped, each run is only returned to a single caller
+func (q *Queries) RescheduleOrphanedFlowRuns(ctx context.Context) ([]FlowRun, error) {
+	rows, err := q.db.Query(ctx, rescheduleOrphanedFlowRuns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FlowRun{}
+	for rows.Next() {
+		var i FlowRun
+		if err := rows.Scan(
+			&i.FlowRunID,
+			&i.FlowID,
+			&i.Parameters,
+			&i.Status,
+			&i.Engine,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.TaskStatuses,
+			&i.SuccessTaskResults,
+			&i.ErrorMessage,
+			&i.RetryCount,
+			&i.MaxRetries,
+			&i.FailureReason,
+			&i.WorkerID,
+			&i.ParentRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resumeFlowRun = `-- name: ResumeFlowRun :one
+UPDATE flow_runs
+SET status = 'SCHEDULED',
+    worker_id = NULL,
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status = 'PAUSED'
+RETURNING flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id
+`
+
+// Schedules a paused flow run again, no row is returned when the run is not paused
+func (q *Queries) ResumeFlowRun(ctx context.Context, flowRunID uuid.UUID) (FlowRun, error) {
+	row := q.db.QueryRow(ctx, resumeFlowRun, flowRunID)
+	var i FlowRun
+	err := row.Scan(
+		&i.FlowRunID,
+		&i.FlowID,
+		&i.Parameters,
+		&i.Status,
+		&i.Engine,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.TaskStatuses,
+		&i.SuccessTaskResults,
+		&i.ErrorMessage,
+		&i.RetryCount,
+		&i.MaxRetries,
+		&i.FailureReason,
+		&i.WorkerID,
+		&i.ParentRunID,
+	)
+	return i, err
+}
+
+const retryFlowRun = `-- name: RetryFlowRun :one
+UPDATE flow_runs
+SET retry_count = COALESCE(retry_count, 0) + 1,
+    status = 'SCHEDULED',
+    started_at = NULL,
+    finished_at = NULL,
+    error_message = NULL,
+    failure_reason = NULL,
+    worker_id = NULL,
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status = 'FAILED' AND COALESCE(retry_count, 0) < COALESCE(max_retries, 0)
+RETURNING flow_run_id, flow_id, parameters, status, engine, created_at, updated_at, started_at, finished_at, task_statuses, success_task_results, error_message, retry_count, max_retries, failure_reason, worker_id, parent_run_id
+`
+
+// Schedules a failed flow run for its next retry, no row is returned when the run has no retry left or was already retried
+func (q *Queries) RetryFlowRun(ctx context.Context, flowRunID uuid.UUID) (FlowRun, error) {
+	row := q.db.QueryRow(ctx, retryFlowRun, flowRunID)
+	var i FlowRun
+	err := row.Scan(
+		&i.FlowRunID,
+		&i.FlowID,
+		&i.Parameters,
+		&i.Status,
+		&i.Engine,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.TaskStatuses,
+		&i.SuccessTaskResults,
+		&i.ErrorMessage,
+		&i.RetryCount,
+		&i.MaxRetries,
+		&i.FailureReason,
+		&i.WorkerID,
+		&i.ParentRunID,
+	)
+	return i, err
+}
+
+const setFlowRunSuccessTaskResult = `-- name: SetFlowRunSuccessTaskResult :exec
+UPDATE flow_runs
+SET success_task_results = COALESCE(success_task_results, '{}'::jsonb) || jsonb_build_object($1::text, $2::text),
+    updated_at = NOW()
+WHERE flow_run_id = $3
+`
+
+type SetFlowRunSuccessTaskResultParams struct {
+	TaskName       string    `db:"task_name" json:"task_name"`
+	ResultCacheKey string    `db:"result_cache_key" json:"result_cache_key"`
+	FlowRunID      uuid.UUID `db:"flow_run_id" json:"flow_run_id"`
+}
+
+// Records the result cache key of a task that succeeded, a resumed run skips the task
+func (q *Queries) SetFlowRunSuccessTaskResult(ctx context.Context, arg SetFlowRunSuccessTaskResultParams) error {
+	_, err := q.db.Exec(ctx, setFlowRunSuccessTaskResult, arg.TaskName, arg.ResultCacheKey, arg.FlowRunID)
+	return err
+}
+
 const updateFlowRunError = `-- name: UpdateFlowRunError :exec
 UPDATE flow_runs 
 SET error_message = $2, 
+    failure_reason = $3,
     status = 'FAILED',
     finished_at = NOW(),
     updated_at = NOW()
-WHERE flow_run_id = $1
+WHERE flow_run_id = $1 AND status <> 'CANCELLED'
 `
 
 type UpdateFlowRunErrorParams struct {
-	FlowRunID    uuid.UUID   `db:"flow_run_id" json:"flow_run_id"`
-	ErrorMessage pgtype.Text `db:"error_message" json:"error_message"`
+	FlowRunID     uuid.UUID   `db:"flow_run_id" json:"flow_run_id"`
+	ErrorMessage  pgtype.Text `db:"error_message" json:"error_message"`
+	FailureReason pgtype.Text `db:"failure_reason" json:"failure_reason"`
 }
 
 func (q *Queries) UpdateFlowRunError(ctx context.Context, arg UpdateFlowRunErrorParams) error {
-	_, err := q.db.Exec(ctx, updateFlowRunError, arg.FlowRunID, arg.ErrorMessage)
+	_, err := q.db.Exec(ctx, updateFlowRunError, arg.FlowRunID, arg.ErrorMessage, arg.FailureReason)
 	return err
 }
 
@@ -418,7 +861,7 @@ SET status = $1::text,
     updated_at = NOW(),
     started_at = CASE WHEN $1::text = 'RUNNING' AND started_at IS NULL THEN NOW() ELSE started_at END,
     finished_at = CASE WHEN $1::text IN ('SUCCESS', 'FAILED') AND finished_at IS NULL THEN NOW() ELSE finished_at END
-WHERE flow_run_id = $2
+WHERE flow_run_id = $2 AND status <> 'CANCELLED'
 `
 
 type UpdateFlowRunStatusWithTimestampsParams struct {
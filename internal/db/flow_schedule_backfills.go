@@ -0,0 +1,26 @@
+package db
+
+import (
+	"maps"
+	"time"
+)
+
+const (
+	// MaxFlowScheduleBackfillRuns bounds the runs of a backfill, a larger range is split in several backfills
+	MaxFlowScheduleBackfillRuns = 1000
+
+	// DefaultFlowScheduleBackfillConcurrency is the number of runs of a backfill scheduled or running at once by default
+	DefaultFlowScheduleBackfillConcurrency = 5
+
+	// ScheduledTimeParameter is the parameter holding the time a run of a backfill was scheduled for, in RFC 3339
+	ScheduledTimeParameter = "scheduled_time"
+)
+
+// FlowScheduleBackfillParameters returns the parameters of the run of a backfill scheduled at a time: the parameters
+// of the backfill with the scheduled_time parameter set to the time in the time zone of the schedule
+func FlowScheduleBackfillParameters(parameters map[string]any, fireAt time.Time) map[string]any {
+	runParameters := make(map[string]any, len(parameters)+1)
+	maps.Copy(runParameters, parameters)
+	runParameters[ScheduledTimeParameter] = fireAt.Format(time.RFC3339)
+	return runParameters
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_FlowScheduleBackfillParameters(t *testing.T) {
+	t.Parallel()
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	parameters := map[string]any{"region": "eu", ScheduledTimeParameter: "overridden"}
+	fireAt := time.Date(2026, 3, 10, 9, 0, 0, 0, paris)
+
+	runParameters := FlowScheduleBackfillParameters(parameters, fireAt)
+	if runParameters["region"] != "eu" || runParameters[ScheduledTimeParameter] != "2026-03-10T09:00:00+01:00" {
+		t.Fatalf("expected the parameters with the scheduled time, got %v", runParameters)
+	}
+	if parameters[ScheduledTimeParameter] != "overridden" {
+		t.Fatalf("expected the parameters of the backfill to be left unchanged")
+	}
+	if runParameters := FlowScheduleBackfillParameters(nil, fireAt.UTC()); runParameters[ScheduledTimeParameter] != "2026-03-10T08:00:00Z" {
+		t.Fatalf("expected the scheduled time without parameters, got %v", runParameters)
+	}
+}
@@ -12,6 +12,24 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelFlowTaskRuns = `-- name: CancelFlowTaskRuns :execrows
+UPDATE flow_task_runs
+SET status = 'CANCELLED',
+    finished_at = NOW(),
+    duration_seconds = EXTRACT(EPOCH FROM (NOW() - started_at)),
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status IN ('PENDING', 'RUNNING')
+`
+
+// Finalizes the pending and running task runs of a cancelled flow run
+func (q *Queries) CancelFlowTaskRuns(ctx context.Context, flowRunID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelFlowTaskRuns, flowRunID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createFlowTaskRun = `-- name: CreateFlowTaskRun :one
 INSERT INTO flow_task_runs (
     flow_run_id,
@@ -82,6 +100,31 @@ func (q *Queries) DeleteFlowTaskRunsByFlowRun(ctx context.Context, flowRunID uui
 	return err
 }
 
+const ensureFlowTaskRun = `-- name: EnsureFlowTaskRun :exec
+INSERT INTO flow_task_runs (
+    flow_run_id,
+    task_name,
+    status,
+    max_retries
+) VALUES (
+    $1, $2, 'PENDING', $3
+)
+ON CONFLICT (flow_run_id, task_name) DO UPDATE SET
+    max_retries = EXCLUDED.max_retries
+`
+
+type EnsureFlowTaskRunParams struct {
+	FlowRunID  uuid.UUID   `db:"flow_run_id" json:"flow_run_id"`
+	TaskName   string      `db:"task_name" json:"task_name"`
+	MaxRetries pgtype.Int4 `db:"max_retries" json:"max_retries"`
+}
+
+// Creates the pending task run of a task of the flow run graph, an existing task run keeps its status
+func (q *Queries) EnsureFlowTaskRun(ctx context.Context, arg EnsureFlowTaskRunParams) error {
+	_, err := q.db.Exec(ctx, ensureFlowTaskRun, arg.FlowRunID, arg.TaskName, arg.MaxRetries)
+	return err
+}
+
 const getFailedFlowTaskRunsForRetry = `-- name: GetFailedFlowTaskRunsForRetry :many
 SELECT flow_run_id, task_name, status, result, result_cache_key, error_message, created_at, updated_at, started_at, finished_at, duration_seconds, retry_count, max_retries FROM flow_task_runs 
 WHERE status = 'FAILED' 
@@ -413,7 +456,7 @@ const updateFlowTaskRunStatus = `-- name: UpdateFlowTaskRunStatus :exec
 UPDATE flow_task_runs 
 SET status = $1, 
     updated_at = NOW()
-WHERE flow_run_id = $2 AND task_name = $3
+WHERE flow_run_id = $2 AND task_name = $3 AND status <> 'CANCELLED'
 `
 
 type UpdateFlowTaskRunStatusParams struct {
@@ -12,22 +12,44 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countFlows = `-- name: CountFlows :one
+SELECT COUNT(*) FROM flows WHERE workspace_id = $1 AND (deleted_at IS NOT NULL) = $2::boolean
+`
+
+type CountFlowsParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	Deleted     bool      `db:"deleted" json:"deleted"`
+}
+
+// Counts the flows or the flows in the trash of a workspace
+func (q *Queries) CountFlows(ctx context.Context, arg CountFlowsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countFlows, arg.WorkspaceID, arg.Deleted)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createFlow = `-- name: CreateFlow :one
-INSERT INTO flows (id, name, description, parameters_schema, engine, additional_info, tags, code_location, entrypoint)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint
+INSERT INTO flows (id, name, description, parameters_schema, engine, additional_info, tags, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, workspace_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, deleted_at, workspace_id
 `
 
 type CreateFlowParams struct {
-	ID               uuid.UUID   `db:"id" json:"id"`
-	Name             string      `db:"name" json:"name"`
-	Description      pgtype.Text `db:"description" json:"description"`
-	ParametersSchema JsonRaw     `db:"parameters_schema" json:"parameters_schema"`
-	Engine           string      `db:"engine" json:"engine"`
-	AdditionalInfo   JsonRaw     `db:"additional_info" json:"additional_info"`
-	Tags             []string    `db:"tags" json:"tags"`
-	CodeLocation     pgtype.Text `db:"code_location" json:"code_location"`
-	Entrypoint       pgtype.Text `db:"entrypoint" json:"entrypoint"`
+	ID                uuid.UUID             `db:"id" json:"id"`
+	Name              string                `db:"name" json:"name"`
+	Description       pgtype.Text           `db:"description" json:"description"`
+	ParametersSchema  JsonRaw               `db:"parameters_schema" json:"parameters_schema"`
+	Engine            string                `db:"engine" json:"engine"`
+	AdditionalInfo    JsonRaw               `db:"additional_info" json:"additional_info"`
+	Tags              []string              `db:"tags" json:"tags"`
+	CodeLocation      pgtype.Text           `db:"code_location" json:"code_location"`
+	Entrypoint        pgtype.Text           `db:"entrypoint" json:"entrypoint"`
+	Notifications     JsonRaw               `db:"notifications" json:"notifications"`
+	RequiredLabels    []string              `db:"required_labels" json:"required_labels"`
+	MaxConcurrentRuns pgtype.Int4           `db:"max_concurrent_runs" json:"max_concurrent_runs"`
+	ConcurrencyPolicy FlowConcurrencyPolicy `db:"concurrency_policy" json:"concurrency_policy"`
+	WorkspaceID       uuid.UUID             `db:"workspace_id" json:"workspace_id"`
 }
 
 func (q *Queries) CreateFlow(ctx context.Context, arg CreateFlowParams) (Flow, error) {
@@ -41,6 +63,11 @@ func (q *Queries) CreateFlow(ctx context.Context, arg CreateFlowParams) (Flow, e
 		arg.Tags,
 		arg.CodeLocation,
 		arg.Entrypoint,
+		arg.Notifications,
+		arg.RequiredLabels,
+		arg.MaxConcurrentRuns,
+		arg.ConcurrencyPolicy,
+		arg.WorkspaceID,
 	)
 	var i Flow
 	err := row.Scan(
@@ -55,6 +82,12 @@ func (q *Queries) CreateFlow(ctx context.Context, arg CreateFlowParams) (Flow, e
 		&i.ParametersSchema,
 		&i.CodeLocation,
 		&i.Entrypoint,
+		&i.Notifications,
+		&i.RequiredLabels,
+		&i.MaxConcurrentRuns,
+		&i.ConcurrencyPolicy,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
@@ -69,11 +102,16 @@ func (q *Queries) DeleteFlow(ctx context.Context, id uuid.UUID) error {
 }
 
 const getFlowById = `-- name: GetFlowById :one
-SELECT id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint FROM flows WHERE id = $1 LIMIT 1
+SELECT id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, deleted_at, workspace_id FROM flows WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL LIMIT 1
 `
 
-func (q *Queries) GetFlowById(ctx context.Context, id uuid.UUID) (Flow, error) {
-	row := q.db.QueryRow(ctx, getFlowById, id)
+type GetFlowByIdParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *Queries) GetFlowById(ctx context.Context, arg GetFlowByIdParams) (Flow, error) {
+	row := q.db.QueryRow(ctx, getFlowById, arg.WorkspaceID, arg.ID)
 	var i Flow
 	err := row.Scan(
 		&i.ID,
@@ -87,21 +125,68 @@ func (q *Queries) GetFlowById(ctx context.Context, id uuid.UUID) (Flow, error) {
 		&i.ParametersSchema,
 		&i.CodeLocation,
 		&i.Entrypoint,
+		&i.Notifications,
+		&i.RequiredLabels,
+		&i.MaxConcurrentRuns,
+		&i.ConcurrencyPolicy,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const getFlowByIdWithDeleted = `-- name: GetFlowByIdWithDeleted :one
+SELECT id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, deleted_at, workspace_id FROM flows WHERE id = $1 LIMIT 1
+`
+
+// Gets a flow of any workspace even from the trash, the runs started before the flow was deleted still complete
+func (q *Queries) GetFlowByIdWithDeleted(ctx context.Context, id uuid.UUID) (Flow, error) {
+	row := q.db.QueryRow(ctx, getFlowByIdWithDeleted, id)
+	var i Flow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Engine,
+		&i.AdditionalInfo,
+		&i.Tags,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ParametersSchema,
+		&i.CodeLocation,
+		&i.Entrypoint,
+		&i.Notifications,
+		&i.RequiredLabels,
+		&i.MaxConcurrentRuns,
+		&i.ConcurrencyPolicy,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
 
 const getFlows = `-- name: GetFlows :many
-SELECT id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint FROM flows ORDER BY name LIMIT $1 OFFSET $2
+SELECT id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, deleted_at, workspace_id FROM flows
+WHERE workspace_id = $1 AND (deleted_at IS NOT NULL) = $2::boolean
+ORDER BY name
+LIMIT $3 OFFSET $4
 `
 
 type GetFlowsParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	Deleted     bool      `db:"deleted" json:"deleted"`
+	Limit       int32     `db:"limit" json:"limit"`
+	Offset      int32     `db:"offset" json:"offset"`
 }
 
+// Lists the flows or the flows in the trash of a workspace by name
 func (q *Queries) GetFlows(ctx context.Context, arg GetFlowsParams) ([]Flow, error) {
-	rows, err := q.db.Query(ctx, getFlows, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getFlows,
+		arg.WorkspaceID,
+		arg.Deleted,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +206,12 @@ func (q *Queries) GetFlows(ctx context.Context, arg GetFlowsParams) ([]Flow, err
 			&i.ParametersSchema,
 			&i.CodeLocation,
 			&i.Entrypoint,
+			&i.Notifications,
+			&i.RequiredLabels,
+			&i.MaxConcurrentRuns,
+			&i.ConcurrencyPolicy,
+			&i.DeletedAt,
+			&i.WorkspaceID,
 		); err != nil {
 			return nil, err
 		}
@@ -132,23 +223,100 @@ func (q *Queries) GetFlows(ctx context.Context, arg GetFlowsParams) ([]Flow, err
 	return items, nil
 }
 
+const purgeFlow = `-- name: PurgeFlow :execrows
+DELETE FROM flows WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL
+`
+
+type PurgeFlowParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Deletes a flow of the trash of a workspace for good
+func (q *Queries) PurgeFlow(ctx context.Context, arg PurgeFlowParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeFlow, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreFlow = `-- name: RestoreFlow :one
+UPDATE flows SET deleted_at = NULL WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL
+RETURNING id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, deleted_at, workspace_id
+`
+
+type RestoreFlowParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Restores a flow of a workspace from the trash
+func (q *Queries) RestoreFlow(ctx context.Context, arg RestoreFlowParams) (Flow, error) {
+	row := q.db.QueryRow(ctx, restoreFlow, arg.WorkspaceID, arg.ID)
+	var i Flow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Engine,
+		&i.AdditionalInfo,
+		&i.Tags,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ParametersSchema,
+		&i.CodeLocation,
+		&i.Entrypoint,
+		&i.Notifications,
+		&i.RequiredLabels,
+		&i.MaxConcurrentRuns,
+		&i.ConcurrencyPolicy,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const softDeleteFlow = `-- name: SoftDeleteFlow :execrows
+UPDATE flows SET deleted_at = NOW() WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL
+`
+
+type SoftDeleteFlowParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Moves a flow of a workspace to the trash, its schedules stop triggering runs until it is restored
+func (q *Queries) SoftDeleteFlow(ctx context.Context, arg SoftDeleteFlowParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteFlow, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateFlow = `-- name: UpdateFlow :one
 UPDATE flows
-SET name = $1, description = $2, parameters_schema = $3, engine = $4, additional_info = $5, tags = $6, code_location = $7, entrypoint = $8, updated_at = CURRENT_TIMESTAMP
-WHERE id = $9
-RETURNING id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint
+SET name = $1, description = $2, parameters_schema = $3, engine = $4, additional_info = $5, tags = $6, code_location = $7, entrypoint = $8, notifications = $9, required_labels = $10, max_concurrent_runs = $11, concurrency_policy = $12, updated_at = CURRENT_TIMESTAMP
+WHERE id = $13 AND workspace_id = $14 AND deleted_at IS NULL
+RETURNING id, name, description, engine, additional_info, tags, created_at, updated_at, parameters_schema, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, deleted_at, workspace_id
 `
 
 type UpdateFlowParams struct {
-	Name             string      `db:"name" json:"name"`
-	Description      pgtype.Text `db:"description" json:"description"`
-	ParametersSchema JsonRaw     `db:"parameters_schema" json:"parameters_schema"`
-	Engine           string      `db:"engine" json:"engine"`
-	AdditionalInfo   JsonRaw     `db:"additional_info" json:"additional_info"`
-	Tags             []string    `db:"tags" json:"tags"`
-	CodeLocation     pgtype.Text `db:"code_location" json:"code_location"`
-	Entrypoint       pgtype.Text `db:"entrypoint" json:"entrypoint"`
-	ID               uuid.UUID   `db:"id" json:"id"`
+	Name              string                `db:"name" json:"name"`
+	Description       pgtype.Text           `db:"description" json:"description"`
+	ParametersSchema  JsonRaw               `db:"parameters_schema" json:"parameters_schema"`
+	Engine            string                `db:"engine" json:"engine"`
+	AdditionalInfo    JsonRaw               `db:"additional_info" json:"additional_info"`
+	Tags              []string              `db:"tags" json:"tags"`
+	CodeLocation      pgtype.Text           `db:"code_location" json:"code_location"`
+	Entrypoint        pgtype.Text           `db:"entrypoint" json:"entrypoint"`
+	Notifications     JsonRaw               `db:"notifications" json:"notifications"`
+	RequiredLabels    []string              `db:"required_labels" json:"required_labels"`
+	MaxConcurrentRuns pgtype.Int4           `db:"max_concurrent_runs" json:"max_concurrent_runs"`
+	ConcurrencyPolicy FlowConcurrencyPolicy `db:"concurrency_policy" json:"concurrency_policy"`
+	ID                uuid.UUID             `db:"id" json:"id"`
+	WorkspaceID       uuid.UUID             `db:"workspace_id" json:"workspace_id"`
 }
 
 func (q *Queries) UpdateFlow(ctx context.Context, arg UpdateFlowParams) (Flow, error) {
@@ -161,7 +329,12 @@ func (q *Queries) UpdateFlow(ctx context.Context, arg UpdateFlowParams) (Flow, e
 		arg.Tags,
 		arg.CodeLocation,
 		arg.Entrypoint,
+		arg.Notifications,
+		arg.RequiredLabels,
+		arg.MaxConcurrentRuns,
+		arg.ConcurrencyPolicy,
 		arg.ID,
+		arg.WorkspaceID,
 	)
 	var i Flow
 	err := row.Scan(
@@ -176,6 +349,12 @@ func (q *Queries) UpdateFlow(ctx context.Context, arg UpdateFlowParams) (Flow, e
 		&i.ParametersSchema,
 		&i.CodeLocation,
 		&i.Entrypoint,
+		&i.Notifications,
+		&i.RequiredLabels,
+		&i.MaxConcurrentRuns,
+		&i.ConcurrencyPolicy,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
@@ -23,6 +23,7 @@ func TestCRUDFlows(t *testing.T) {
 	parameterSchemaJsonRaw, _ := NewJsonRaw(map[string]any{"param1": "value1", "param2": "value2"})
 
 	createParams := CreateFlowParams{
+		WorkspaceID:      DefaultWorkspaceID,
 		ID:               new_uuid,
 		Name:             "Test Workflow",
 		Description:      pgtype.Text{String: "This is a test workflow", Valid: true},
@@ -41,8 +42,9 @@ func TestCRUDFlows(t *testing.T) {
 
 	// Test GetWorkflows
 	workflows, err := queries.GetFlows(t.Context(), GetFlowsParams{
-		Limit:  10,
-		Offset: 0,
+		WorkspaceID: DefaultWorkspaceID,
+		Limit:       10,
+		Offset:      0,
 	})
 	if err != nil {
 		t.Fatalf("Failed to get workflows: %v", err)
@@ -51,7 +53,7 @@ func TestCRUDFlows(t *testing.T) {
 	assert.Greater(t, len(workflows), 0, "There should be at least one workflow in the database")
 
 	// Test GetWorkflowByID
-	workflow, err := queries.GetFlowById(t.Context(), new_uuid)
+	workflow, err := queries.GetFlowById(t.Context(), GetFlowByIdParams{WorkspaceID: DefaultWorkspaceID, ID: new_uuid})
 	if err != nil {
 		t.Fatalf("Failed to get workflow by ID: %v", err)
 	}
@@ -63,6 +65,7 @@ func TestCRUDFlows(t *testing.T) {
 	parameterSchemaJsonRaw, _ = NewJsonRaw(map[string]any{"param1": "new_value1", "param2": "new_value2"})
 
 	updateParams := UpdateFlowParams{
+		WorkspaceID:      DefaultWorkspaceID,
 		ID:               new_uuid,
 		Name:             "Updated Test Workflow",
 		Description:      pgtype.Text{String: "This is an updated test workflow", Valid: true},
@@ -90,7 +93,7 @@ func TestCRUDFlows(t *testing.T) {
 		t.Fatalf("Failed to delete workflow: %v", err)
 	}
 	// Verify deletion
-	_, err = queries.GetFlowById(t.Context(), new_uuid)
+	_, err = queries.GetFlowById(t.Context(), GetFlowByIdParams{WorkspaceID: DefaultWorkspaceID, ID: new_uuid})
 	if err == nil {
 		t.Fatalf("Expected error when getting deleted workflow, but got none")
 	}
@@ -15,7 +15,7 @@ import (
 const createAgentMessage = `-- name: CreateAgentMessage :one
 INSERT INTO thread_messages (thread_id, message, sender_type, stop_reason, sender_id, citations, recipient_id)
 VALUES ($1, $2, 'assistant', $3, $4, $5, $6)
-RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id
+RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of
 `
 
 type CreateAgentMessageParams struct {
@@ -49,6 +49,8 @@ func (q *Queries) CreateAgentMessage(ctx context.Context, arg CreateAgentMessage
 		&i.SenderID,
 		&i.Citations,
 		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
 	)
 	return i, err
 }
@@ -56,7 +58,7 @@ func (q *Queries) CreateAgentMessage(ctx context.Context, arg CreateAgentMessage
 const createCustomMessage = `-- name: CreateCustomMessage :one
 INSERT INTO thread_messages (thread_id, message, sender_type, result_type, stop_reason, sender_id, citations, recipient_id)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id
+RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of
 `
 
 type CreateCustomMessageParams struct {
@@ -94,6 +96,8 @@ func (q *Queries) CreateCustomMessage(ctx context.Context, arg CreateCustomMessa
 		&i.SenderID,
 		&i.Citations,
 		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
 	)
 	return i, err
 }
@@ -101,7 +105,7 @@ func (q *Queries) CreateCustomMessage(ctx context.Context, arg CreateCustomMessa
 const createResultMessage = `-- name: CreateResultMessage :one
 INSERT INTO thread_messages (thread_id, message, sender_type, result_type, sender_id, recipient_id)
 VALUES ($1, $2, "result", $3, $4, $5)
-RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id
+RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of
 `
 
 type CreateResultMessageParams struct {
@@ -133,6 +137,8 @@ func (q *Queries) CreateResultMessage(ctx context.Context, arg CreateResultMessa
 		&i.SenderID,
 		&i.Citations,
 		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
 	)
 	return i, err
 }
@@ -140,7 +146,7 @@ func (q *Queries) CreateResultMessage(ctx context.Context, arg CreateResultMessa
 const createUserMessage = `-- name: CreateUserMessage :one
 INSERT INTO thread_messages (thread_id, message, sender_type, sender_id, recipient_id)
 VALUES ($1, $2, 'user', $3, $4)
-RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id
+RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of
 `
 
 type CreateUserMessageParams struct {
@@ -170,6 +176,8 @@ func (q *Queries) CreateUserMessage(ctx context.Context, arg CreateUserMessagePa
 		&i.SenderID,
 		&i.Citations,
 		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
 	)
 	return i, err
 }
@@ -184,7 +192,7 @@ func (q *Queries) DeleteMessage(ctx context.Context, id uuid.UUID) error {
 }
 
 const getMessageByID = `-- name: GetMessageByID :one
-SELECT id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id FROM thread_messages WHERE id = $1 LIMIT 1
+SELECT id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of FROM thread_messages WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetMessageByID(ctx context.Context, id uuid.UUID) (ThreadMessage, error) {
@@ -202,27 +210,34 @@ func (q *Queries) GetMessageByID(ctx context.Context, id uuid.UUID) (ThreadMessa
 		&i.SenderID,
 		&i.Citations,
 		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
 	)
 	return i, err
 }
 
 const getMessageContents = `-- name: GetMessageContents :many
-SELECT message FROM thread_messages WHERE thread_id = $1 ORDER BY created_at ASC
+SELECT id, message FROM thread_messages WHERE thread_id = $1 AND superseded_at IS NULL ORDER BY created_at ASC
 `
 
-func (q *Queries) GetMessageContents(ctx context.Context, threadID uuid.UUID) ([]JsonRaw, error) {
+type GetMessageContentsRow struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Message JsonRaw   `db:"message" json:"message"`
+}
+
+func (q *Queries) GetMessageContents(ctx context.Context, threadID uuid.UUID) ([]GetMessageContentsRow, error) {
 	rows, err := q.db.Query(ctx, getMessageContents, threadID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []JsonRaw{}
+	items := []GetMessageContentsRow{}
 	for rows.Next() {
-		var message JsonRaw
-		if err := rows.Scan(&message); err != nil {
+		var i GetMessageContentsRow
+		if err := rows.Scan(&i.ID, &i.Message); err != nil {
 			return nil, err
 		}
-		items = append(items, message)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -231,7 +246,7 @@ func (q *Queries) GetMessageContents(ctx context.Context, threadID uuid.UUID) ([
 }
 
 const getMessages = `-- name: GetMessages :many
-SELECT id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id FROM thread_messages WHERE thread_id = $1 ORDER BY created_at ASC
+SELECT id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of FROM thread_messages WHERE thread_id = $1 AND superseded_at IS NULL ORDER BY created_at ASC
 `
 
 func (q *Queries) GetMessages(ctx context.Context, threadID uuid.UUID) ([]ThreadMessage, error) {
@@ -255,6 +270,8 @@ func (q *Queries) GetMessages(ctx context.Context, threadID uuid.UUID) ([]Thread
 			&i.SenderID,
 			&i.Citations,
 			&i.RecipientID,
+			&i.SupersededAt,
+			&i.VersionOf,
 		); err != nil {
 			return nil, err
 		}
@@ -267,7 +284,7 @@ func (q *Queries) GetMessages(ctx context.Context, threadID uuid.UUID) ([]Thread
 }
 
 const getSenderRecipientMessages = `-- name: GetSenderRecipientMessages :many
-SELECT message FROM thread_messages WHERE thread_id = $1 AND ((sender_id = $2 AND recipient_id = $3) OR (sender_id = $3 AND recipient_id = $2)) ORDER BY created_at ASC
+SELECT id, message FROM thread_messages WHERE thread_id = $1 AND superseded_at IS NULL AND ((sender_id = $2 AND recipient_id = $3) OR (sender_id = $3 AND recipient_id = $2)) ORDER BY created_at ASC
 `
 
 type GetSenderRecipientMessagesParams struct {
@@ -276,19 +293,24 @@ type GetSenderRecipientMessagesParams struct {
 	RecipientID uuid.UUID `db:"recipient_id" json:"recipient_id"`
 }
 
-func (q *Queries) GetSenderRecipientMessages(ctx context.Context, arg GetSenderRecipientMessagesParams) ([]JsonRaw, error) {
+type GetSenderRecipientMessagesRow struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Message JsonRaw   `db:"message" json:"message"`
+}
+
+func (q *Queries) GetSenderRecipientMessages(ctx context.Context, arg GetSenderRecipientMessagesParams) ([]GetSenderRecipientMessagesRow, error) {
 	rows, err := q.db.Query(ctx, getSenderRecipientMessages, arg.ThreadID, arg.SenderID, arg.RecipientID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []JsonRaw{}
+	items := []GetSenderRecipientMessagesRow{}
 	for rows.Next() {
-		var message JsonRaw
-		if err := rows.Scan(&message); err != nil {
+		var i GetSenderRecipientMessagesRow
+		if err := rows.Scan(&i.ID, &i.Message); err != nil {
 			return nil, err
 		}
-		items = append(items, message)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -296,11 +318,113 @@ func (q *Queries) GetSenderRecipientMessages(ctx context.Context, arg GetSenderR
 	return items, nil
 }
 
+const importMessage = `-- name: ImportMessage :one
+INSERT INTO thread_messages (thread_id, message, sender_type, sender_id, recipient_id, created_at)
+VALUES (
+    $1, $2, $3, $4, $5,
+    COALESCE($6::timestamptz, clock_timestamp())
+)
+RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of
+`
+
+type ImportMessageParams struct {
+	ThreadID    uuid.UUID          `db:"thread_id" json:"thread_id"`
+	Message     JsonRaw            `db:"message" json:"message"`
+	SenderType  SenderMessageType  `db:"sender_type" json:"sender_type"`
+	SenderID    uuid.UUID          `db:"sender_id" json:"sender_id"`
+	RecipientID uuid.UUID          `db:"recipient_id" json:"recipient_id"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+// Inserts a message of an imported conversation. A message without a date is dated with the clock rather than the start
+// of the transaction, the messages of a batch keep their order.
+func (q *Queries) ImportMessage(ctx context.Context, arg ImportMessageParams) (ThreadMessage, error) {
+	row := q.db.QueryRow(ctx, importMessage,
+		arg.ThreadID,
+		arg.Message,
+		arg.SenderType,
+		arg.SenderID,
+		arg.RecipientID,
+		arg.CreatedAt,
+	)
+	var i ThreadMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ThreadID,
+		&i.Message,
+		&i.SenderType,
+		&i.ResultType,
+		&i.StopReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SenderID,
+		&i.Citations,
+		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
+	)
+	return i, err
+}
+
+const importThreadMessage = `-- name: ImportThreadMessage :one
+INSERT INTO thread_messages (thread_id, message, sender_type, result_type, stop_reason, sender_id, citations, recipient_id, created_at)
+VALUES (
+    $1, $2, $3, $4, $5,
+    $6, $7, $8,
+    COALESCE($9::timestamptz, clock_timestamp())
+)
+RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of
+`
+
+type ImportThreadMessageParams struct {
+	ThreadID    uuid.UUID          `db:"thread_id" json:"thread_id"`
+	Message     JsonRaw            `db:"message" json:"message"`
+	SenderType  SenderMessageType  `db:"sender_type" json:"sender_type"`
+	ResultType  *ResultMessageType `db:"result_type" json:"result_type"`
+	StopReason  pgtype.Text        `db:"stop_reason" json:"stop_reason"`
+	SenderID    uuid.UUID          `db:"sender_id" json:"sender_id"`
+	Citations   []JsonRaw          `db:"citations" json:"citations"`
+	RecipientID uuid.UUID          `db:"recipient_id" json:"recipient_id"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+// Inserts a message of an imported thread with its result type, stop reason and citations, dated like ImportMessage.
+func (q *Queries) ImportThreadMessage(ctx context.Context, arg ImportThreadMessageParams) (ThreadMessage, error) {
+	row := q.db.QueryRow(ctx, importThreadMessage,
+		arg.ThreadID,
+		arg.Message,
+		arg.SenderType,
+		arg.ResultType,
+		arg.StopReason,
+		arg.SenderID,
+		arg.Citations,
+		arg.RecipientID,
+		arg.CreatedAt,
+	)
+	var i ThreadMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ThreadID,
+		&i.Message,
+		&i.SenderType,
+		&i.ResultType,
+		&i.StopReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SenderID,
+		&i.Citations,
+		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
+	)
+	return i, err
+}
+
 const updateMessage = `-- name: UpdateMessage :one
 UPDATE thread_messages
 SET message = $1
 WHERE id = $2
-RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id
+RETURNING id, thread_id, message, sender_type, result_type, stop_reason, created_at, updated_at, sender_id, citations, recipient_id, superseded_at, version_of
 `
 
 type UpdateMessageParams struct {
@@ -323,6 +447,8 @@ func (q *Queries) UpdateMessage(ctx context.Context, arg UpdateMessageParams) (T
 		&i.SenderID,
 		&i.Citations,
 		&i.RecipientID,
+		&i.SupersededAt,
+		&i.VersionOf,
 	)
 	return i, err
 }
@@ -43,10 +43,11 @@ func TestCRUDMessages(t *testing.T) {
 
 	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
 	createThreadParams := CreateThreadParams{
-		Title:     "Test Thread",
-		CreatedAt: now,
-		UpdatedAt: now,
-		UserID:    userID,
+		WorkspaceID: DefaultWorkspaceID,
+		Title:       "Test Thread",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		UserID:      userID,
 	}
 
 	createdThread, err := queries.CreateThread(t.Context(), createThreadParams)
@@ -103,6 +104,7 @@ func TestCRUDMessages(t *testing.T) {
 	}
 	assert.NotEmpty(t, messageContents, "Message contents should not be empty")
 	assert.Greater(t, len(messageContents), 0, "There should be at least one message content")
+	assert.Equal(t, createdMessage.ID, messageContents[0].ID, "First message content should be the created message")
 
 	// Test UpdateMessage
 	messageJsonRaw, _ = NewJsonRaw(map[string]any{"content": "Updated test message", "type": "text"})
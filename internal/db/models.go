@@ -17,6 +17,8 @@ type Agent struct {
 	CreatedBy   uuid.UUID          `db:"created_by" json:"created_by"`
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	WorkspaceID uuid.UUID          `db:"workspace_id" json:"workspace_id"`
 }
 
 type AgentPermissionMapping struct {
@@ -27,18 +29,129 @@ type AgentPermissionMapping struct {
 	AssignedBy   uuid.UUID          `db:"assigned_by" json:"assigned_by"`
 }
 
+type AgentProbe struct {
+	ID              uuid.UUID          `db:"id" json:"id"`
+	AgentID         uuid.UUID          `db:"agent_id" json:"agent_id"`
+	Name            string             `db:"name" json:"name"`
+	Prompt          string             `db:"prompt" json:"prompt"`
+	ExpectedPattern string             `db:"expected_pattern" json:"expected_pattern"`
+	IntervalSeconds int32              `db:"interval_seconds" json:"interval_seconds"`
+	TimeoutSeconds  int32              `db:"timeout_seconds" json:"timeout_seconds"`
+	Enabled         bool               `db:"enabled" json:"enabled"`
+	DryRun          bool               `db:"dry_run" json:"dry_run"`
+	Status          AgentProbeStatus   `db:"status" json:"status"`
+	LastRunAt       pgtype.Timestamptz `db:"last_run_at" json:"last_run_at"`
+	CreatedBy       uuid.UUID          `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type AgentProbeRun struct {
+	ID        uuid.UUID           `db:"id" json:"id"`
+	ProbeID   uuid.UUID           `db:"probe_id" json:"probe_id"`
+	Status    AgentProbeRunStatus `db:"status" json:"status"`
+	LatencyMs int32               `db:"latency_ms" json:"latency_ms"`
+	Response  pgtype.Text         `db:"response" json:"response"`
+	Error     pgtype.Text         `db:"error" json:"error"`
+	ThreadID  pgtype.UUID         `db:"thread_id" json:"thread_id"`
+	CreatedAt pgtype.Timestamptz  `db:"created_at" json:"created_at"`
+}
+
+type AgentPromptCacheUsage struct {
+	AgentID                  uuid.UUID          `db:"agent_id" json:"agent_id"`
+	RequestCount             int64              `db:"request_count" json:"request_count"`
+	CacheHitCount            int64              `db:"cache_hit_count" json:"cache_hit_count"`
+	InputTokens              int64              `db:"input_tokens" json:"input_tokens"`
+	CacheReadInputTokens     int64              `db:"cache_read_input_tokens" json:"cache_read_input_tokens"`
+	CacheCreationInputTokens int64              `db:"cache_creation_input_tokens" json:"cache_creation_input_tokens"`
+	WarmupCount              int64              `db:"warmup_count" json:"warmup_count"`
+	WarmupInputTokens        int64              `db:"warmup_input_tokens" json:"warmup_input_tokens"`
+	LastWarmupAt             pgtype.Timestamptz `db:"last_warmup_at" json:"last_warmup_at"`
+	UpdatedAt                pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type AgentThinkingUsage struct {
+	AgentID           uuid.UUID          `db:"agent_id" json:"agent_id"`
+	RequestCount      int64              `db:"request_count" json:"request_count"`
+	BudgetTokens      int64              `db:"budget_tokens" json:"budget_tokens"`
+	TotalBudgetTokens int64              `db:"total_budget_tokens" json:"total_budget_tokens"`
+	ThinkingTokens    int64              `db:"thinking_tokens" json:"thinking_tokens"`
+	MaxThinkingTokens int64              `db:"max_thinking_tokens" json:"max_thinking_tokens"`
+	ExhaustedCount    int64              `db:"exhausted_count" json:"exhausted_count"`
+	EstimatedCount    int64              `db:"estimated_count" json:"estimated_count"`
+	UpdatedAt         pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type ApiKey struct {
+	ID         uuid.UUID          `db:"id" json:"id"`
+	UserID     uuid.UUID          `db:"user_id" json:"user_id"`
+	Name       string             `db:"name" json:"name"`
+	KeyPrefix  string             `db:"key_prefix" json:"key_prefix"`
+	KeyHash    string             `db:"key_hash" json:"key_hash"`
+	Scopes     []string           `db:"scopes" json:"scopes"`
+	ExpiresAt  pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `db:"last_used_at" json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `db:"revoked_at" json:"revoked_at"`
+	CreatedBy  uuid.UUID          `db:"created_by" json:"created_by"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type DailyUsage struct {
+	WorkspaceID  uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	UserID       uuid.UUID          `db:"user_id" json:"user_id"`
+	Day          pgtype.Date        `db:"day" json:"day"`
+	InputTokens  int64              `db:"input_tokens" json:"input_tokens"`
+	OutputTokens int64              `db:"output_tokens" json:"output_tokens"`
+	ToolRuns     int64              `db:"tool_runs" json:"tool_runs"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type Feedback struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	WorkspaceID uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	ThreadID    uuid.UUID          `db:"thread_id" json:"thread_id"`
+	MessageID   pgtype.UUID        `db:"message_id" json:"message_id"`
+	ToolRunID   pgtype.Text        `db:"tool_run_id" json:"tool_run_id"`
+	UserID      uuid.UUID          `db:"user_id" json:"user_id"`
+	Rating      FeedbackRating     `db:"rating" json:"rating"`
+	Categories  []string           `db:"categories" json:"categories"`
+	Comment     pgtype.Text        `db:"comment" json:"comment"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type File struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	UserID      uuid.UUID          `db:"user_id" json:"user_id"`
+	Name        string             `db:"name" json:"name"`
+	ContentType string             `db:"content_type" json:"content_type"`
+	SizeBytes   int64              `db:"size_bytes" json:"size_bytes"`
+	Status      FileStatus         `db:"status" json:"status"`
+	Bucket      string             `db:"bucket" json:"bucket"`
+	ObjectKey   string             `db:"object_key" json:"object_key"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	UploadedAt  pgtype.Timestamptz `db:"uploaded_at" json:"uploaded_at"`
+}
+
 type Flow struct {
-	ID               uuid.UUID        `db:"id" json:"id"`
-	Name             string           `db:"name" json:"name"`
-	Description      pgtype.Text      `db:"description" json:"description"`
-	Engine           string           `db:"engine" json:"engine"`
-	AdditionalInfo   JsonRaw          `db:"additional_info" json:"additional_info"`
-	Tags             []string         `db:"tags" json:"tags"`
-	CreatedAt        pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt        pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	ParametersSchema JsonRaw          `db:"parameters_schema" json:"parameters_schema"`
-	CodeLocation     pgtype.Text      `db:"code_location" json:"code_location"`
-	Entrypoint       pgtype.Text      `db:"entrypoint" json:"entrypoint"`
+	ID                uuid.UUID             `db:"id" json:"id"`
+	Name              string                `db:"name" json:"name"`
+	Description       pgtype.Text           `db:"description" json:"description"`
+	Engine            string                `db:"engine" json:"engine"`
+	AdditionalInfo    JsonRaw               `db:"additional_info" json:"additional_info"`
+	Tags              []string              `db:"tags" json:"tags"`
+	CreatedAt         pgtype.Timestamp      `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamp      `db:"updated_at" json:"updated_at"`
+	ParametersSchema  JsonRaw               `db:"parameters_schema" json:"parameters_schema"`
+	CodeLocation      pgtype.Text           `db:"code_location" json:"code_location"`
+	Entrypoint        pgtype.Text           `db:"entrypoint" json:"entrypoint"`
+	Notifications     JsonRaw               `db:"notifications" json:"notifications"`
+	RequiredLabels    []string              `db:"required_labels" json:"required_labels"`
+	MaxConcurrentRuns pgtype.Int4           `db:"max_concurrent_runs" json:"max_concurrent_runs"`
+	ConcurrencyPolicy FlowConcurrencyPolicy `db:"concurrency_policy" json:"concurrency_policy"`
+	DeletedAt         pgtype.Timestamptz    `db:"deleted_at" json:"deleted_at"`
+	WorkspaceID       uuid.UUID             `db:"workspace_id" json:"workspace_id"`
 }
 
 type FlowRun struct {
@@ -56,6 +169,9 @@ type FlowRun struct {
 	ErrorMessage       pgtype.Text        `db:"error_message" json:"error_message"`
 	RetryCount         pgtype.Int4        `db:"retry_count" json:"retry_count"`
 	MaxRetries         pgtype.Int4        `db:"max_retries" json:"max_retries"`
+	FailureReason      pgtype.Text        `db:"failure_reason" json:"failure_reason"`
+	WorkerID           pgtype.Text        `db:"worker_id" json:"worker_id"`
+	ParentRunID        pgtype.UUID        `db:"parent_run_id" json:"parent_run_id"`
 }
 
 type FlowRunEvent struct {
@@ -69,6 +185,93 @@ type FlowRunEvent struct {
 	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
+type FlowRunArtifact struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	FlowRunID   uuid.UUID          `db:"flow_run_id" json:"flow_run_id"`
+	Name        string             `db:"name" json:"name"`
+	TaskName    pgtype.Text        `db:"task_name" json:"task_name"`
+	ContentType string             `db:"content_type" json:"content_type"`
+	SizeBytes   int64              `db:"size_bytes" json:"size_bytes"`
+	Bucket      string             `db:"bucket" json:"bucket"`
+	ObjectKey   string             `db:"object_key" json:"object_key"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type FlowRunCost struct {
+	FlowRunID    uuid.UUID          `db:"flow_run_id" json:"flow_run_id"`
+	FlowID       uuid.UUID          `db:"flow_id" json:"flow_id"`
+	UserID       pgtype.UUID        `db:"user_id" json:"user_id"`
+	CpuSeconds   float64            `db:"cpu_seconds" json:"cpu_seconds"`
+	LlmRequests  int64              `db:"llm_requests" json:"llm_requests"`
+	InputTokens  int64              `db:"input_tokens" json:"input_tokens"`
+	OutputTokens int64              `db:"output_tokens" json:"output_tokens"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type FlowRunGraphTask struct {
+	FlowRunID         uuid.UUID          `db:"flow_run_id" json:"flow_run_id"`
+	TaskName          string             `db:"task_name" json:"task_name"`
+	DependsOn         []string           `db:"depends_on" json:"depends_on"`
+	MaxRetries        int32              `db:"max_retries" json:"max_retries"`
+	RetryDelaySeconds int32              `db:"retry_delay_seconds" json:"retry_delay_seconds"`
+	Position          int32              `db:"position" json:"position"`
+	CreatedAt         pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type FlowRunLog struct {
+	ID        int64              `db:"id" json:"id"`
+	FlowRunID uuid.UUID          `db:"flow_run_id" json:"flow_run_id"`
+	Stream    string             `db:"stream" json:"stream"`
+	Content   string             `db:"content" json:"content"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type FlowRunQueue struct {
+	FlowRunID uuid.UUID          `db:"flow_run_id" json:"flow_run_id"`
+	FlowID    uuid.UUID          `db:"flow_id" json:"flow_id"`
+	QueuedAt  pgtype.Timestamptz `db:"queued_at" json:"queued_at"`
+}
+
+type FlowSchedule struct {
+	ID             uuid.UUID                 `db:"id" json:"id"`
+	FlowID         uuid.UUID                 `db:"flow_id" json:"flow_id"`
+	Name           string                    `db:"name" json:"name"`
+	CronExpression string                    `db:"cron_expression" json:"cron_expression"`
+	Timezone       string                    `db:"timezone" json:"timezone"`
+	Parameters     JsonRaw                   `db:"parameters" json:"parameters"`
+	Engine         pgtype.Text               `db:"engine" json:"engine"`
+	Enabled        bool                      `db:"enabled" json:"enabled"`
+	CatchUpPolicy  FlowScheduleCatchUpPolicy `db:"catch_up_policy" json:"catch_up_policy"`
+	NextRunAt      pgtype.Timestamptz        `db:"next_run_at" json:"next_run_at"`
+	LastRunAt      pgtype.Timestamptz        `db:"last_run_at" json:"last_run_at"`
+	LastFlowRunID  pgtype.UUID               `db:"last_flow_run_id" json:"last_flow_run_id"`
+	CreatedBy      uuid.UUID                 `db:"created_by" json:"created_by"`
+	CreatedAt      pgtype.Timestamptz        `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
+}
+
+type FlowScheduleBackfill struct {
+	ID                uuid.UUID                  `db:"id" json:"id"`
+	ScheduleID        uuid.UUID                  `db:"schedule_id" json:"schedule_id"`
+	FlowID            uuid.UUID                  `db:"flow_id" json:"flow_id"`
+	CronExpression    string                     `db:"cron_expression" json:"cron_expression"`
+	Timezone          string                     `db:"timezone" json:"timezone"`
+	Parameters        JsonRaw                    `db:"parameters" json:"parameters"`
+	Engine            pgtype.Text                `db:"engine" json:"engine"`
+	StartAt           pgtype.Timestamptz         `db:"start_at" json:"start_at"`
+	EndAt             pgtype.Timestamptz         `db:"end_at" json:"end_at"`
+	MaxConcurrentRuns int32                      `db:"max_concurrent_runs" json:"max_concurrent_runs"`
+	Status            FlowScheduleBackfillStatus `db:"status" json:"status"`
+	TotalRuns         int32                      `db:"total_runs" json:"total_runs"`
+	TriggeredRuns     int32                      `db:"triggered_runs" json:"triggered_runs"`
+	CreatedBy         uuid.UUID                  `db:"created_by" json:"created_by"`
+	CreatedAt         pgtype.Timestamptz         `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz         `db:"updated_at" json:"updated_at"`
+	FinishedAt        pgtype.Timestamptz         `db:"finished_at" json:"finished_at"`
+}
+
 type FlowTaskRun struct {
 	FlowRunID       uuid.UUID          `db:"flow_run_id" json:"flow_run_id"`
 	TaskName        string             `db:"task_name" json:"task_name"`
@@ -85,6 +288,47 @@ type FlowTaskRun struct {
 	MaxRetries      pgtype.Int4        `db:"max_retries" json:"max_retries"`
 }
 
+type IdempotencyKey struct {
+	UserID       uuid.UUID          `db:"user_id" json:"user_id"`
+	Key          string             `db:"key" json:"key"`
+	RequestHash  string             `db:"request_hash" json:"request_hash"`
+	StatusCode   pgtype.Int4        `db:"status_code" json:"status_code"`
+	ContentType  string             `db:"content_type" json:"content_type"`
+	ResponseBody []byte             `db:"response_body" json:"response_body"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	CompletedAt  pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
+	ExpiresAt    pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+type MessageAttachment struct {
+	MessageID uuid.UUID          `db:"message_id" json:"message_id"`
+	FileID    uuid.UUID          `db:"file_id" json:"file_id"`
+	Position  int32              `db:"position" json:"position"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type MessageEmbedding struct {
+	MessageID  uuid.UUID          `db:"message_id" json:"message_id"`
+	ThreadID   uuid.UUID          `db:"thread_id" json:"thread_id"`
+	Model      string             `db:"model" json:"model"`
+	Embedding  Vector             `db:"embedding" json:"embedding"`
+	EmbeddedAt pgtype.Timestamptz `db:"embedded_at" json:"embedded_at"`
+}
+
+type Organization struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	Name      string             `db:"name" json:"name"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID          `db:"organization_id" json:"organization_id"`
+	UserID         uuid.UUID          `db:"user_id" json:"user_id"`
+	Role           OrganizationRole   `db:"role" json:"role"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type Permission struct {
 	ID          uuid.UUID          `db:"id" json:"id"`
 	Name        string             `db:"name" json:"name"`
@@ -94,6 +338,32 @@ type Permission struct {
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type ResourceChange struct {
+	ID           uuid.UUID            `db:"id" json:"id"`
+	ResourceType ResourceType         `db:"resource_type" json:"resource_type"`
+	ResourceID   uuid.UUID            `db:"resource_id" json:"resource_id"`
+	Version      int32                `db:"version" json:"version"`
+	Action       ResourceChangeAction `db:"action" json:"action"`
+	Snapshot     JsonRaw              `db:"snapshot" json:"snapshot"`
+	Changes      JsonRaw              `db:"changes" json:"changes"`
+	ChangedBy    uuid.UUID            `db:"changed_by" json:"changed_by"`
+	SourceIp     pgtype.Text          `db:"source_ip" json:"source_ip"`
+	UserAgent    pgtype.Text          `db:"user_agent" json:"user_agent"`
+	CreatedAt    pgtype.Timestamptz   `db:"created_at" json:"created_at"`
+}
+
+type ResourceGrant struct {
+	ID           uuid.UUID          `db:"id" json:"id"`
+	ResourceType ResourceType       `db:"resource_type" json:"resource_type"`
+	ResourceID   uuid.UUID          `db:"resource_id" json:"resource_id"`
+	GranteeType  GranteeType        `db:"grantee_type" json:"grantee_type"`
+	GranteeID    uuid.UUID          `db:"grantee_id" json:"grantee_id"`
+	Access       GrantAccess        `db:"access" json:"access"`
+	GrantedBy    uuid.UUID          `db:"granted_by" json:"granted_by"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type Role struct {
 	ID          uuid.UUID          `db:"id" json:"id"`
 	Name        string             `db:"name" json:"name"`
@@ -127,6 +397,26 @@ type Task struct {
 	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	CreatedBy      uuid.UUID          `db:"created_by" json:"created_by"`
 	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	FlowRunID      pgtype.UUID        `db:"flow_run_id" json:"flow_run_id"`
+}
+
+type TaskSchedule struct {
+	ID             uuid.UUID                 `db:"id" json:"id"`
+	WorkspaceID    uuid.UUID                 `db:"workspace_id" json:"workspace_id"`
+	AgentID        uuid.UUID                 `db:"agent_id" json:"agent_id"`
+	ThreadID       pgtype.UUID               `db:"thread_id" json:"thread_id"`
+	Name           string                    `db:"name" json:"name"`
+	Prompt         string                    `db:"prompt" json:"prompt"`
+	CronExpression string                    `db:"cron_expression" json:"cron_expression"`
+	Timezone       string                    `db:"timezone" json:"timezone"`
+	Enabled        bool                      `db:"enabled" json:"enabled"`
+	OverlapPolicy  TaskScheduleOverlapPolicy `db:"overlap_policy" json:"overlap_policy"`
+	NextRunAt      pgtype.Timestamptz        `db:"next_run_at" json:"next_run_at"`
+	LastRunAt      pgtype.Timestamptz        `db:"last_run_at" json:"last_run_at"`
+	LastThreadID   pgtype.UUID               `db:"last_thread_id" json:"last_thread_id"`
+	CreatedBy      uuid.UUID                 `db:"created_by" json:"created_by"`
+	CreatedAt      pgtype.Timestamptz        `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz        `db:"updated_at" json:"updated_at"`
 }
 
 type TasksRun struct {
@@ -138,14 +428,23 @@ type TasksRun struct {
 	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 	StartedAt    pgtype.Timestamptz `db:"started_at" json:"started_at"`
 	FinishedAt   pgtype.Timestamptz `db:"finished_at" json:"finished_at"`
+	DeadlineAt   pgtype.Timestamptz `db:"deadline_at" json:"deadline_at"`
+	AgentID      pgtype.UUID        `db:"agent_id" json:"agent_id"`
+	RequestedBy  pgtype.UUID        `db:"requested_by" json:"requested_by"`
+	WorkspaceID  pgtype.UUID        `db:"workspace_id" json:"workspace_id"`
+	DryRun       bool               `db:"dry_run" json:"dry_run"`
 }
 
 type Thread struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Title     string             `db:"title" json:"title"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	UserID    uuid.UUID          `db:"user_id" json:"user_id"`
+	ID                uuid.UUID          `db:"id" json:"id"`
+	Title             string             `db:"title" json:"title"`
+	CreatedAt         pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	UserID            uuid.UUID          `db:"user_id" json:"user_id"`
+	DeletedAt         pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	WorkspaceID       uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	ForkedFrom        pgtype.UUID        `db:"forked_from" json:"forked_from"`
+	ForkedFromMessage pgtype.UUID        `db:"forked_from_message" json:"forked_from_message"`
 }
 
 type ThreadContext struct {
@@ -158,43 +457,90 @@ type ThreadContext struct {
 }
 
 type ThreadMessage struct {
-	ID          uuid.UUID          `db:"id" json:"id"`
-	ThreadID    uuid.UUID          `db:"thread_id" json:"thread_id"`
-	Message     JsonRaw            `db:"message" json:"message"`
-	SenderType  SenderMessageType  `db:"sender_type" json:"sender_type"`
-	ResultType  *ResultMessageType `db:"result_type" json:"result_type"`
-	StopReason  pgtype.Text        `db:"stop_reason" json:"stop_reason"`
-	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	SenderID    uuid.UUID          `db:"sender_id" json:"sender_id"`
-	Citations   []JsonRaw          `db:"citations" json:"citations"`
-	RecipientID uuid.UUID          `db:"recipient_id" json:"recipient_id"`
+	ID           uuid.UUID          `db:"id" json:"id"`
+	ThreadID     uuid.UUID          `db:"thread_id" json:"thread_id"`
+	Message      JsonRaw            `db:"message" json:"message"`
+	SenderType   SenderMessageType  `db:"sender_type" json:"sender_type"`
+	ResultType   *ResultMessageType `db:"result_type" json:"result_type"`
+	StopReason   pgtype.Text        `db:"stop_reason" json:"stop_reason"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	SenderID     uuid.UUID          `db:"sender_id" json:"sender_id"`
+	Citations    []JsonRaw          `db:"citations" json:"citations"`
+	RecipientID  uuid.UUID          `db:"recipient_id" json:"recipient_id"`
+	SupersededAt pgtype.Timestamptz `db:"superseded_at" json:"superseded_at"`
+	VersionOf    pgtype.UUID        `db:"version_of" json:"version_of"`
 }
 
 type Tool struct {
-	ID          uuid.UUID          `db:"id" json:"id"`
-	Name        string             `db:"name" json:"name"`
+	ID              uuid.UUID          `db:"id" json:"id"`
+	Name            string             `db:"name" json:"name"`
+	Description     pgtype.Text        `db:"description" json:"description"`
+	Config          ToolConfig         `db:"config" json:"config"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	CreatedBy       uuid.UUID          `db:"created_by" json:"created_by"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Category        pgtype.Text        `db:"category" json:"category"`
+	Icon            pgtype.Text        `db:"icon" json:"icon"`
+	Tags            []string           `db:"tags" json:"tags"`
+	Examples        JsonRaw            `db:"examples" json:"examples"`
+	Documentation   pgtype.Text        `db:"documentation" json:"documentation"`
+	Revision        int32              `db:"revision" json:"revision"`
+	Status          ToolStatus         `db:"status" json:"status"`
+	StatusMessage   pgtype.Text        `db:"status_message" json:"status_message"`
+	StatusCheckedAt pgtype.Timestamptz `db:"status_checked_at" json:"status_checked_at"`
+	DeletedAt       pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	WorkspaceID     pgtype.UUID        `db:"workspace_id" json:"workspace_id"`
+}
+
+type ToolRevision struct {
+	ToolID      uuid.UUID          `db:"tool_id" json:"tool_id"`
+	Revision    int32              `db:"revision" json:"revision"`
 	Description pgtype.Text        `db:"description" json:"description"`
 	Config      ToolConfig         `db:"config" json:"config"`
-	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	CreatedBy   uuid.UUID          `db:"created_by" json:"created_by"`
-	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
 type ToolRun struct {
-	ID           string             `db:"id" json:"id"`
+	ID              string             `db:"id" json:"id"`
+	ToolID          uuid.UUID          `db:"tool_id" json:"tool_id"`
+	ConnectionID    uuid.UUID          `db:"connection_id" json:"connection_id"`
+	ThreadID        uuid.UUID          `db:"thread_id" json:"thread_id"`
+	AgentID         uuid.UUID          `db:"agent_id" json:"agent_id"`
+	RecipientID     uuid.UUID          `db:"recipient_id" json:"recipient_id"`
+	Input           JsonRaw            `db:"input" json:"input"`
+	Result          JsonRaw            `db:"result" json:"result"`
+	Status          ToolRunStatus      `db:"status" json:"status"`
+	Duration        pgtype.Float8      `db:"duration" json:"duration"`
+	ParentRunID     pgtype.Text        `db:"parent_run_id" json:"parent_run_id"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	InputHash       pgtype.Text        `db:"input_hash" json:"input_hash"`
+	CachedFromRunID pgtype.Text        `db:"cached_from_run_id" json:"cached_from_run_id"`
+	CacheHits       int32              `db:"cache_hits" json:"cache_hits"`
+}
+
+type ToolRunAudit struct {
+	ID           uuid.UUID          `db:"id" json:"id"`
+	ToolRunID    string             `db:"tool_run_id" json:"tool_run_id"`
+	ParentRunID  pgtype.Text        `db:"parent_run_id" json:"parent_run_id"`
 	ToolID       uuid.UUID          `db:"tool_id" json:"tool_id"`
-	ConnectionID uuid.UUID          `db:"connection_id" json:"connection_id"`
-	ThreadID     uuid.UUID          `db:"thread_id" json:"thread_id"`
+	ToolName     string             `db:"tool_name" json:"tool_name"`
+	ToolRevision int32              `db:"tool_revision" json:"tool_revision"`
+	UserID       uuid.UUID          `db:"user_id" json:"user_id"`
 	AgentID      uuid.UUID          `db:"agent_id" json:"agent_id"`
 	RecipientID  uuid.UUID          `db:"recipient_id" json:"recipient_id"`
+	ThreadID     uuid.UUID          `db:"thread_id" json:"thread_id"`
+	TaskID       pgtype.Text        `db:"task_id" json:"task_id"`
+	ConnectionID pgtype.UUID        `db:"connection_id" json:"connection_id"`
 	Input        JsonRaw            `db:"input" json:"input"`
-	Result       JsonRaw            `db:"result" json:"result"`
+	Output       JsonRaw            `db:"output" json:"output"`
 	Status       ToolRunStatus      `db:"status" json:"status"`
 	Duration     pgtype.Float8      `db:"duration" json:"duration"`
-	ParentRunID  pgtype.Text        `db:"parent_run_id" json:"parent_run_id"`
 	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	CompletedAt  pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
+	ExpiresAt    pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
 }
 
 type User struct {
@@ -208,6 +554,7 @@ type User struct {
 	LastLogin      pgtype.Timestamptz `db:"last_login" json:"last_login"`
 	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	DefaultAgentID pgtype.UUID        `db:"default_agent_id" json:"default_agent_id"`
 }
 
 type UserConnection struct {
@@ -224,6 +571,45 @@ type UserRoleMapping struct {
 	AssignedBy uuid.UUID          `db:"assigned_by" json:"assigned_by"`
 }
 
+type UserSession struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	UserID    uuid.UUID          `db:"user_id" json:"user_id"`
+	TokenHash string             `db:"token_hash" json:"token_hash"`
+	UserAgent pgtype.Text        `db:"user_agent" json:"user_agent"`
+	IpAddress pgtype.Text        `db:"ip_address" json:"ip_address"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `db:"revoked_at" json:"revoked_at"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID             `db:"id" json:"id"`
+	WebhookID      uuid.UUID             `db:"webhook_id" json:"webhook_id"`
+	EventID        uuid.UUID             `db:"event_id" json:"event_id"`
+	EventType      string                `db:"event_type" json:"event_type"`
+	Payload        JsonRaw               `db:"payload" json:"payload"`
+	Status         WebhookDeliveryStatus `db:"status" json:"status"`
+	Attempts       int32                 `db:"attempts" json:"attempts"`
+	ResponseStatus pgtype.Int4           `db:"response_status" json:"response_status"`
+	ErrorMessage   pgtype.Text           `db:"error_message" json:"error_message"`
+	NextAttemptAt  pgtype.Timestamptz    `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt      pgtype.Timestamptz    `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz    `db:"updated_at" json:"updated_at"`
+	DeliveredAt    pgtype.Timestamptz    `db:"delivered_at" json:"delivered_at"`
+}
+
+type Webhook struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	UserID      uuid.UUID          `db:"user_id" json:"user_id"`
+	Url         string             `db:"url" json:"url"`
+	Events      []string           `db:"events" json:"events"`
+	Secret      string             `db:"secret" json:"secret"`
+	Description pgtype.Text        `db:"description" json:"description"`
+	Enabled     bool               `db:"enabled" json:"enabled"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type WorkerHeartbeat struct {
 	WorkerID      string             `db:"worker_id" json:"worker_id"`
 	WorkerName    pgtype.Text        `db:"worker_name" json:"worker_name"`
@@ -233,3 +619,18 @@ type WorkerHeartbeat struct {
 	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
+
+type Workspace struct {
+	ID             uuid.UUID          `db:"id" json:"id"`
+	OrganizationID uuid.UUID          `db:"organization_id" json:"organization_id"`
+	Name           string             `db:"name" json:"name"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	UserID      uuid.UUID          `db:"user_id" json:"user_id"`
+	Role        WorkspaceRole      `db:"role" json:"role"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
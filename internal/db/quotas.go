@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Quota is a limit on the usage of a user or of a workspace
+type Quota string
+
+const (
+	QuotaTokensPerDay      Quota = "tokens_per_day"       // Input and output tokens of the agents
+	QuotaToolRunsPerDay    Quota = "tool_runs_per_day"    // Tool runs dispatched
+	QuotaConcurrentTasks   Quota = "concurrent_tasks"     // Task runs scheduled or running at once
+	QuotaFlowMinutesPerDay Quota = "flow_minutes_per_day" // Wall time of the flow runs
+)
+
+// quotas lists the quotas in the order they are reported
+var quotas = []Quota{QuotaTokensPerDay, QuotaToolRunsPerDay, QuotaConcurrentTasks, QuotaFlowMinutesPerDay}
+
+// QuotaScope tells whether a quota limits the usage of a user over all its workspaces or of a workspace
+type QuotaScope string
+
+const (
+	QuotaScopeUser      QuotaScope = "USER"
+	QuotaScopeWorkspace QuotaScope = "WORKSPACE"
+)
+
+// QuotaLimits are the limits of a scope, zero leaves a quota unlimited
+type QuotaLimits struct {
+	TokensPerDay      int64 `yaml:"tokens_per_day"`
+	ToolRunsPerDay    int64 `yaml:"tool_runs_per_day"`
+	ConcurrentTasks   int64 `yaml:"concurrent_tasks"`
+	FlowMinutesPerDay int64 `yaml:"flow_minutes_per_day"`
+}
+
+// Limit returns the limit of a quota, zero when it is unlimited
+func (l QuotaLimits) Limit(quota Quota) int64 {
+	switch quota {
+	case QuotaTokensPerDay:
+		return l.TokensPerDay
+	case QuotaToolRunsPerDay:
+		return l.ToolRunsPerDay
+	case QuotaConcurrentTasks:
+		return l.ConcurrentTasks
+	case QuotaFlowMinutesPerDay:
+		return l.FlowMinutesPerDay
+	}
+	return 0
+}
+
+// Quotas are the limits applied to every user and to every workspace
+type Quotas struct {
+	User      QuotaLimits `yaml:"user"`
+	Workspace QuotaLimits `yaml:"workspace"`
+}
+
+// Limits returns the limits of a scope, none when the quotas are not configured
+func (q *Quotas) Limits(scope QuotaScope) QuotaLimits {
+	if q == nil {
+		return QuotaLimits{}
+	}
+	if scope == QuotaScopeWorkspace {
+		return q.Workspace
+	}
+	return q.User
+}
+
+// Enforced tells whether any of the quotas is limited in a scope
+func (q *Quotas) Enforced(checked ...Quota) bool {
+	for _, quota := range checked {
+		if q.Limits(QuotaScopeUser).Limit(quota) > 0 || q.Limits(QuotaScopeWorkspace).Limit(quota) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// QuotaStatus is the usage of a quota in a scope
+type QuotaStatus struct {
+	Quota    Quota      `json:"quota"`
+	Scope    QuotaScope `json:"scope"`
+	Used     float64    `json:"used"`
+	Limit    *int64     `json:"limit,omitempty"`     // Unset when the quota is unlimited
+	ResetsAt *time.Time `json:"resets_at,omitempty"` // Next UTC midnight, unset for the concurrent tasks
+}
+
+// Exceeded tells whether the usage reached the limit, no further usage is allowed until the quota resets
+func (s QuotaStatus) Exceeded() bool {
+	return s.Limit != nil && s.Used >= float64(*s.Limit)
+}
+
+// QuotaExceededError is returned when a user or a workspace exhausted a quota
+type QuotaExceededError struct {
+	Status QuotaStatus
+}
+
+func (e *QuotaExceededError) Error() string {
+	scope := "user"
+	if e.Status.Scope == QuotaScopeWorkspace {
+		scope = "workspace"
+	}
+	msg := fmt.Sprintf("%s quota exceeded for the %s: %g of %d used", e.Status.Quota, scope, e.Status.Used, *e.Status.Limit)
+	if e.Status.ResetsAt != nil {
+		msg += fmt.Sprintf(", resets at %s", e.Status.ResetsAt.Format(time.RFC3339))
+	}
+	return msg
+}
+
+// NextQuotaReset returns the UTC midnight following now, when the daily quotas reset
+func NextQuotaReset(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// quotaStatuses builds the statuses of every quota of both scopes from the usage of the day
+func quotaStatuses(q *Quotas, usage GetQuotaUsageRow, now time.Time) []QuotaStatus {
+	used := map[QuotaScope]map[Quota]float64{
+		QuotaScopeUser: {
+			QuotaTokensPerDay:      float64(usage.UserTokens),
+			QuotaToolRunsPerDay:    float64(usage.UserToolRuns),
+			QuotaConcurrentTasks:   float64(usage.UserActiveTasks),
+			QuotaFlowMinutesPerDay: usage.UserFlowSeconds / 60,
+		},
+		QuotaScopeWorkspace: {
+			QuotaTokensPerDay:      float64(usage.WorkspaceTokens),
+			QuotaToolRunsPerDay:    float64(usage.WorkspaceToolRuns),
+			QuotaConcurrentTasks:   float64(usage.WorkspaceActiveTasks),
+			QuotaFlowMinutesPerDay: usage.WorkspaceFlowSeconds / 60,
+		},
+	}
+	reset := NextQuotaReset(now)
+	statuses := make([]QuotaStatus, 0, 2*len(quotas))
+	for _, scope := range []QuotaScope{QuotaScopeUser, QuotaScopeWorkspace} {
+		for _, quota := range quotas {
+			status := QuotaStatus{Quota: quota, Scope: scope, Used: used[scope][quota]}
+			if limit := q.Limits(scope).Limit(quota); limit > 0 {
+				status.Limit = &limit
+			}
+			if quota != QuotaConcurrentTasks {
+				status.ResetsAt = &reset
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// QuotaStatuses returns the usage of every quota of a user and of the workspace it acts in
+func (q *Queries) QuotaStatuses(ctx context.Context, quotas *Quotas, workspaceID, userID uuid.UUID) ([]QuotaStatus, error) {
+	usage, err := q.GetQuotaUsage(ctx, GetQuotaUsageParams{UserID: userID, WorkspaceID: workspaceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the quota usage: %w", err)
+	}
+	return quotaStatuses(quotas, usage, time.Now()), nil
+}
+
+// CheckQuotas returns a *QuotaExceededError when the user or its workspace exhausted one of the checked quotas. The
+// usage is not looked up when none of the checked quotas is limited.
+func (q *Queries) CheckQuotas(ctx context.Context, quotas *Quotas, workspaceID, userID uuid.UUID, checked ...Quota) error {
+	if !quotas.Enforced(checked...) {
+		return nil
+	}
+	statuses, err := q.QuotaStatuses(ctx, quotas, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if slices.Contains(checked, status.Quota) && status.Exceeded() {
+			return &QuotaExceededError{Status: status}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_NextQuotaReset(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 31, 23, 30, 0, 0, time.FixedZone("CET", 3600))
+	if reset := NextQuotaReset(now); !reset.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the next UTC midnight, got %s", reset)
+	}
+}
+
+func Test_QuotaStatuses(t *testing.T) {
+	t.Parallel()
+
+	quotas := &Quotas{
+		User:      QuotaLimits{TokensPerDay: 1000, ConcurrentTasks: 2},
+		Workspace: QuotaLimits{FlowMinutesPerDay: 10},
+	}
+	usage := GetQuotaUsageRow{UserTokens: 1000, UserActiveTasks: 1, WorkspaceFlowSeconds: 300}
+	statuses := quotaStatuses(quotas, usage, time.Now())
+	if len(statuses) != 8 {
+		t.Fatalf("expected 8 statuses, got %d", len(statuses))
+	}
+
+	exceeded := map[QuotaScope]map[Quota]bool{}
+	for _, s := range statuses {
+		if exceeded[s.Scope] == nil {
+			exceeded[s.Scope] = map[Quota]bool{}
+		}
+		exceeded[s.Scope][s.Quota] = s.Exceeded()
+		if (s.Quota == QuotaConcurrentTasks) != (s.ResetsAt == nil) {
+			t.Errorf("expected only the concurrent tasks to have no reset, got %s %s", s.Scope, s.Quota)
+		}
+		if s.Scope == QuotaScopeWorkspace && s.Quota == QuotaFlowMinutesPerDay && s.Used != 5 {
+			t.Errorf("expected 5 flow minutes used, got %g", s.Used)
+		}
+		if s.Scope == QuotaScopeWorkspace && s.Quota == QuotaTokensPerDay && s.Limit != nil {
+			t.Errorf("expected the workspace tokens to be unlimited, got %d", *s.Limit)
+		}
+	}
+	if !exceeded[QuotaScopeUser][QuotaTokensPerDay] {
+		t.Error("expected the tokens of the user to be exceeded at their limit")
+	}
+	if exceeded[QuotaScopeUser][QuotaConcurrentTasks] || exceeded[QuotaScopeWorkspace][QuotaFlowMinutesPerDay] {
+		t.Error("expected the quotas under their limit not to be exceeded")
+	}
+}
+
+func Test_QuotasEnforced(t *testing.T) {
+	t.Parallel()
+
+	var unset *Quotas
+	if unset.Enforced(QuotaTokensPerDay) {
+		t.Fatal("expected no quota enforced when the quotas are not configured")
+	}
+	quotas := &Quotas{Workspace: QuotaLimits{ToolRunsPerDay: 50}}
+	if !quotas.Enforced(QuotaTokensPerDay, QuotaToolRunsPerDay) {
+		t.Fatal("expected the tool runs to be enforced")
+	}
+	if quotas.Enforced(QuotaTokensPerDay) {
+		t.Fatal("expected the tokens not to be enforced")
+	}
+}
+
+func Test_QuotaExceededError(t *testing.T) {
+	t.Parallel()
+
+	limit := int64(60)
+	reset := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	var err error = &QuotaExceededError{Status: QuotaStatus{
+		Quota: QuotaFlowMinutesPerDay, Scope: QuotaScopeWorkspace, Used: 61.5, Limit: &limit, ResetsAt: &reset,
+	}}
+	expected := "flow_minutes_per_day quota exceeded for the workspace: 61.5 of 60 used, resets at 2026-04-01T00:00:00Z"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatal("expected a *QuotaExceededError")
+	}
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// EnsurePublication creates the logical replication publication for the given tables on the primary
+// database, or updates its table list when it already exists.
+func EnsurePublication(ctx context.Context, db DBTX, publication string, tables []string) error {
+	if len(tables) == 0 {
+		return fmt.Errorf("publication %s has no tables", publication)
+	}
+
+	var exists bool
+	if err := db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = $1)", publication).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to look up publication %s: %w", publication, err)
+	}
+
+	identifiers := make([]string, 0, len(tables))
+	for _, table := range tables {
+		identifiers = append(identifiers, pgx.Identifier{table}.Sanitize())
+	}
+	statement := "CREATE PUBLICATION %s FOR TABLE %s"
+	if exists {
+		statement = "ALTER PUBLICATION %s SET TABLE %s"
+	}
+	if _, err := db.Exec(ctx, fmt.Sprintf(statement, pgx.Identifier{publication}.Sanitize(), strings.Join(identifiers, ", "))); err != nil {
+		return fmt.Errorf("failed to configure publication %s: %w", publication, err)
+	}
+	return nil
+}
+
+// SubscriptionSQL returns the statement creating the standby subscription to the primary publication.
+func SubscriptionSQL(subscription, publication, primaryConnectionString string) string {
+	return fmt.Sprintf("CREATE SUBSCRIPTION %s CONNECTION '%s' PUBLICATION %s WITH (copy_data = true)",
+		pgx.Identifier{subscription}.Sanitize(),
+		strings.ReplaceAll(primaryConnectionString, "'", "''"),
+		pgx.Identifier{publication}.Sanitize(),
+	)
+}
+
+// EnsureSubscription creates the standby subscription to the primary publication when it does not exist yet.
+// CREATE SUBSCRIPTION cannot run inside a transaction block, so db must not be a transaction.
+func EnsureSubscription(ctx context.Context, db DBTX, subscription, publication, primaryConnectionString string) (bool, error) {
+	exists, err := subscriptionExists(ctx, db, subscription)
+	if err != nil || exists {
+		return false, err
+	}
+	if _, err := db.Exec(ctx, SubscriptionSQL(subscription, publication, primaryConnectionString)); err != nil {
+		return false, fmt.Errorf("failed to create subscription %s: %w", subscription, err)
+	}
+	return true, nil
+}
+
+// DisableSubscription stops the standby from applying changes of the former primary after a failover.
+// It returns false when the subscription does not exist.
+func DisableSubscription(ctx context.Context, db DBTX, subscription string) (bool, error) {
+	exists, err := subscriptionExists(ctx, db, subscription)
+	if err != nil || !exists {
+		return false, err
+	}
+	if _, err := db.Exec(ctx, fmt.Sprintf("ALTER SUBSCRIPTION %s DISABLE", pgx.Identifier{subscription}.Sanitize())); err != nil {
+		return false, fmt.Errorf("failed to disable subscription %s: %w", subscription, err)
+	}
+	return true, nil
+}
+
+// subscriptionExists reports whether the subscription is defined in the current database
+func subscriptionExists(ctx context.Context, db DBTX, subscription string) (bool, error) {
+	var name string
+	err := db.QueryRow(ctx, "SELECT subname FROM pg_subscription WHERE subname = $1 AND subdbid = (SELECT oid FROM pg_database WHERE datname = current_database())", subscription).Scan(&name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to look up subscription %s: %w", subscription, err)
+	}
+	return true, nil
+}
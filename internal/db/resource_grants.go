@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// level orders the accesses, an access includes the lower ones
+func (a GrantAccess) level() int {
+	switch a {
+	case GrantAccessRead:
+		return 1
+	case GrantAccessInvoke:
+		return 2
+	case GrantAccessManage:
+		return 3
+	}
+	return 0
+}
+
+// Valid tells whether the access is an access of the grants
+func (a GrantAccess) Valid() bool {
+	return a.level() > 0
+}
+
+// Allows tells whether the access includes the required access, MANAGE including INVOKE and INVOKE including READ
+func (a GrantAccess) Allows(required GrantAccess) bool {
+	return a.Valid() && a.level() >= required.level()
+}
+
+// HighestAccess returns the highest of the accesses, GrantAccessNil when there are none
+func HighestAccess(accesses []GrantAccess) GrantAccess {
+	highest := GrantAccessNil
+	for _, access := range accesses {
+		if access.level() > highest.level() {
+			highest = access
+		}
+	}
+	return highest
+}
+
+// Valid tells whether the type is a type of the grantees
+func (t GranteeType) Valid() bool {
+	return t == GranteeTypeUser || t == GranteeTypeRole
+}
+
+// ResourceAccess returns the access of a user on a resource created by ownerID, GrantAccessNil when the resource is
+// hidden from the user. The owner manages its resources. The agents and the tools without grants are managed by every
+// user of their workspace, the ones with grants and the threads are restricted to their owner and their grantees.
+func (q *Queries) ResourceAccess(ctx context.Context, resourceType ResourceType, resourceID, ownerID, userID uuid.UUID) (GrantAccess, error) {
+	if userID == ownerID {
+		return GrantAccessManage, nil
+	}
+	if resourceType != ResourceTypeThread {
+		restricted, err := q.HasResourceGrants(ctx, HasResourceGrantsParams{ResourceType: resourceType, ResourceID: resourceID})
+		if err != nil {
+			return GrantAccessNil, fmt.Errorf("failed to check the grants of the %s: %w", resourceType, err)
+		}
+		if !restricted {
+			return GrantAccessManage, nil
+		}
+	}
+	accesses, err := q.ListUserResourceAccess(ctx, ListUserResourceAccessParams{ResourceType: resourceType, ResourceID: resourceID, UserID: userID})
+	if err != nil {
+		return GrantAccessNil, fmt.Errorf("failed to get the access of the user on the %s: %w", resourceType, err)
+	}
+	return HighestAccess(accesses), nil
+}
@@ -0,0 +1,39 @@
+package db
+
+import "testing"
+
+func Test_GrantAccessAllows(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		access, required GrantAccess
+		allowed          bool
+	}{
+		{GrantAccessManage, GrantAccessManage, true},
+		{GrantAccessManage, GrantAccessRead, true},
+		{GrantAccessInvoke, GrantAccessRead, true},
+		{GrantAccessInvoke, GrantAccessManage, false},
+		{GrantAccessRead, GrantAccessInvoke, false},
+		{GrantAccessNil, GrantAccessRead, false},
+		{GrantAccess("OWNER"), GrantAccessRead, false},
+	}
+	for _, c := range cases {
+		if allowed := c.access.Allows(c.required); allowed != c.allowed {
+			t.Errorf("expected %q allowing %q to be %v", c.access, c.required, c.allowed)
+		}
+	}
+}
+
+func Test_HighestAccess(t *testing.T) {
+	t.Parallel()
+
+	if access := HighestAccess([]GrantAccess{GrantAccessRead, GrantAccessManage, GrantAccessInvoke}); access != GrantAccessManage {
+		t.Fatalf("expected %q, got %q", GrantAccessManage, access)
+	}
+	if access := HighestAccess([]GrantAccess{GrantAccessRead, GrantAccessRead}); access != GrantAccessRead {
+		t.Fatalf("expected %q, got %q", GrantAccessRead, access)
+	}
+	if access := HighestAccess(nil); access != GrantAccessNil {
+		t.Fatalf("expected no access, got %q", access)
+	}
+}
@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// contractTable describes a table the generated queries expect to exist.
+type contractTable struct {
+	Name    string
+	Model   string
+	Columns []contractColumn
+}
+
+// contractColumn describes a column the generated queries scan into a model field.
+type contractColumn struct {
+	Name     string
+	Field    string
+	GoType   string
+	UdtNames []string // Postgres types the Go type can scan
+	NotNull  bool     // The Go type cannot hold NULL values
+	Enum     string   // Go enum type whose values must cover the column CHECK constraint
+}
+
+// SchemaMismatchKind classifies a difference between the database and the schema contract.
+type SchemaMismatchKind string
+
+const (
+	SchemaMismatchMissingTable  SchemaMismatchKind = "missing_table"
+	SchemaMismatchMissingColumn SchemaMismatchKind = "missing_column"
+	SchemaMismatchType          SchemaMismatchKind = "type"
+	SchemaMismatchNullable      SchemaMismatchKind = "nullable"
+	SchemaMismatchEnum          SchemaMismatchKind = "enum"
+)
+
+// SchemaMismatch is a single difference between the database schema and what the compiled queries expect.
+type SchemaMismatch struct {
+	Kind    SchemaMismatchKind
+	Table   string
+	Column  string
+	Message string
+}
+
+func (m SchemaMismatch) String() string {
+	if m.Column == "" {
+		return fmt.Sprintf("%s: %s", m.Table, m.Message)
+	}
+	return fmt.Sprintf("%s.%s: %s", m.Table, m.Column, m.Message)
+}
+
+// SchemaMismatchError is returned by VerifySchema when the database does not match the schema contract.
+type SchemaMismatchError struct {
+	Mismatches []SchemaMismatch
+}
+
+func (e *SchemaMismatchError) Error() string {
+	lines := make([]string, 0, len(e.Mismatches)+1)
+	lines = append(lines, fmt.Sprintf("database schema has %d mismatch(es) with the compiled queries:", len(e.Mismatches)))
+	for _, m := range e.Mismatches {
+		lines = append(lines, "  - "+m.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// schemaColumn is a column as reported by the database catalog.
+type schemaColumn struct {
+	UdtName    string
+	IsNullable bool
+	Checks     []string // Values allowed by a single column CHECK (col IN (...)) constraint
+}
+
+const listSchemaColumns = `SELECT table_name::text, column_name::text, udt_name::text, is_nullable = 'YES'
+FROM information_schema.columns
+WHERE table_schema = current_schema()
+`
+
+const listSchemaChecks = `SELECT cl.relname::text, a.attname::text, pg_get_constraintdef(c.oid)
+FROM pg_constraint c
+JOIN pg_class cl ON cl.oid = c.conrelid
+JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = c.conkey[1]
+WHERE c.contype = 'c'
+  AND cardinality(c.conkey) = 1
+  AND cl.relnamespace = current_schema()::regnamespace
+`
+
+var checkLiteralRegexp = regexp.MustCompile(`'((?:[^']|'')*)'`)
+
+// VerifySchema checks that the tables, column types, nullability and CHECK enums in the database
+// match what the compiled queries expect. It returns a *SchemaMismatchError listing every difference.
+func VerifySchema(ctx context.Context, db DBTX) error {
+	columns, err := loadSchemaColumns(ctx, db)
+	if err != nil {
+		return err
+	}
+	if mismatches := compareSchema(schemaContract, schemaContractEnums, columns); len(mismatches) > 0 {
+		return &SchemaMismatchError{Mismatches: mismatches}
+	}
+	return nil
+}
+
+// loadSchemaColumns reads the columns and single column CHECK constraints of the current schema.
+func loadSchemaColumns(ctx context.Context, db DBTX) (map[string]map[string]schemaColumn, error) {
+	rows, err := db.Query(ctx, listSchemaColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list database columns: %w", err)
+	}
+	defer rows.Close()
+	tables := make(map[string]map[string]schemaColumn)
+	for rows.Next() {
+		var table, column string
+		var c schemaColumn
+		if err := rows.Scan(&table, &column, &c.UdtName, &c.IsNullable); err != nil {
+			return nil, fmt.Errorf("failed to scan database column: %w", err)
+		}
+		if tables[table] == nil {
+			tables[table] = make(map[string]schemaColumn)
+		}
+		tables[table][column] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list database columns: %w", err)
+	}
+
+	rows, err = db.Query(ctx, listSchemaChecks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check constraints: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, column, definition string
+		if err := rows.Scan(&table, &column, &definition); err != nil {
+			return nil, fmt.Errorf("failed to scan check constraint: %w", err)
+		}
+		c, ok := tables[table][column]
+		if !ok {
+			continue
+		}
+		c.Checks = append(c.Checks, parseCheckValues(definition)...)
+		tables[table][column] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list check constraints: %w", err)
+	}
+	return tables, nil
+}
+
+// parseCheckValues extracts the string literals from a CHECK constraint definition,
+// e.g. CHECK (((status)::text = ANY ((ARRAY['PENDING'::character varying, ...])::text[]))).
+func parseCheckValues(definition string) []string {
+	var values []string
+	for _, m := range checkLiteralRegexp.FindAllStringSubmatch(definition, -1) {
+		values = append(values, strings.ReplaceAll(m[1], "''", "'"))
+	}
+	return values
+}
+
+// compareSchema reports every difference between the contract and the database columns.
+func compareSchema(contract []contractTable, enums map[string][]string, tables map[string]map[string]schemaColumn) []SchemaMismatch {
+	var mismatches []SchemaMismatch
+	for _, t := range contract {
+		columns, ok := tables[t.Name]
+		if !ok {
+			mismatches = append(mismatches, SchemaMismatch{
+				Kind:    SchemaMismatchMissingTable,
+				Table:   t.Name,
+				Message: fmt.Sprintf("table does not exist (required by model %s)", t.Model),
+			})
+			continue
+		}
+		for _, c := range t.Columns {
+			actual, ok := columns[c.Name]
+			if !ok {
+				mismatches = append(mismatches, SchemaMismatch{
+					Kind:    SchemaMismatchMissingColumn,
+					Table:   t.Name,
+					Column:  c.Name,
+					Message: fmt.Sprintf("column does not exist (required by %s.%s %s)", t.Model, c.Field, c.GoType),
+				})
+				continue
+			}
+			if !slices.Contains(c.UdtNames, actual.UdtName) {
+				mismatches = append(mismatches, SchemaMismatch{
+					Kind:    SchemaMismatchType,
+					Table:   t.Name,
+					Column:  c.Name,
+					Message: fmt.Sprintf("column type is %s but %s.%s %s expects %s", actual.UdtName, t.Model, c.Field, c.GoType, strings.Join(c.UdtNames, " or ")),
+				})
+			}
+			if c.NotNull && actual.IsNullable {
+				mismatches = append(mismatches, SchemaMismatch{
+					Kind:    SchemaMismatchNullable,
+					Table:   t.Name,
+					Column:  c.Name,
+					Message: fmt.Sprintf("column is nullable but %s.%s %s cannot hold NULL", t.Model, c.Field, c.GoType),
+				})
+			}
+			if c.Enum == "" {
+				continue
+			}
+			var unknown []string
+			for _, v := range actual.Checks {
+				if !slices.Contains(enums[c.Enum], v) {
+					unknown = append(unknown, v)
+				}
+			}
+			if len(unknown) > 0 {
+				mismatches = append(mismatches, SchemaMismatch{
+					Kind:    SchemaMismatchEnum,
+					Table:   t.Name,
+					Column:  c.Name,
+					Message: fmt.Sprintf("CHECK constraint allows %s which %s does not define", strings.Join(unknown, ", "), c.Enum),
+				})
+			}
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySchema(t *testing.T) {
+	t.Parallel()
+	db_pool := setupTestDB(t)
+	defer db_pool.Close()
+
+	// The migrated test database must satisfy the contract of the compiled queries
+	err := VerifySchema(t.Context(), db_pool)
+	require.NoError(t, err)
+}
+
+func Test_parseCheckValues(t *testing.T) {
+	t.Parallel()
+	definition := `CHECK (((status)::text = ANY ((ARRAY['PENDING'::character varying, 'RUNNING'::character varying, 'it''s'::character varying])::text[])))`
+	assert.Equal(t, []string{"PENDING", "RUNNING", "it's"}, parseCheckValues(definition))
+	assert.Empty(t, parseCheckValues("CHECK ((retry_count >= 0))"))
+}
+
+func Test_compareSchema(t *testing.T) {
+	t.Parallel()
+	contract := []contractTable{
+		{
+			Name:  "tool_runs",
+			Model: "ToolRun",
+			Columns: []contractColumn{
+				{Name: "id", Field: "ID", GoType: "string", UdtNames: []string{"text", "varchar"}, NotNull: true},
+				{Name: "status", Field: "Status", GoType: "ToolRunStatus", UdtNames: []string{"text", "varchar"}, NotNull: true, Enum: "ToolRunStatus"},
+				{Name: "duration", Field: "Duration", GoType: "pgtype.Float8", UdtNames: []string{"float8"}},
+				{Name: "result", Field: "Result", GoType: "JsonRaw", UdtNames: []string{"jsonb"}},
+			},
+		},
+		{Name: "flows", Model: "Flow"},
+	}
+	enums := map[string][]string{"ToolRunStatus": {"PENDING", "RUNNING", "SUCCESS", "FAILED"}}
+
+	t.Run("Matching schema", func(t *testing.T) {
+		tables := map[string]map[string]schemaColumn{
+			"tool_runs": {
+				"id":       {UdtName: "varchar"},
+				"status":   {UdtName: "varchar", Checks: []string{"PENDING", "RUNNING", "SUCCESS", "FAILED"}},
+				"duration": {UdtName: "float8", IsNullable: true},
+				"result":   {UdtName: "jsonb", IsNullable: true},
+				"extra":    {UdtName: "text", IsNullable: true},
+			},
+			"flows": {},
+		}
+		assert.Empty(t, compareSchema(contract, enums, tables))
+	})
+
+	t.Run("Partial migration", func(t *testing.T) {
+		tables := map[string]map[string]schemaColumn{
+			"tool_runs": {
+				"id":       {UdtName: "uuid"},
+				"status":   {UdtName: "varchar", IsNullable: true, Checks: []string{"PENDING", "CANCELLED"}},
+				"duration": {UdtName: "float8", IsNullable: true},
+			},
+		}
+		mismatches := compareSchema(contract, enums, tables)
+		require.Len(t, mismatches, 5)
+		assert.Equal(t, SchemaMismatchType, mismatches[0].Kind)
+		assert.Equal(t, "tool_runs.id: column type is uuid but ToolRun.ID string expects text or varchar", mismatches[0].String())
+		assert.Equal(t, SchemaMismatchNullable, mismatches[1].Kind)
+		assert.Equal(t, "status", mismatches[1].Column)
+		assert.Equal(t, SchemaMismatchEnum, mismatches[2].Kind)
+		assert.Equal(t, "tool_runs.status: CHECK constraint allows CANCELLED which ToolRunStatus does not define", mismatches[2].String())
+		assert.Equal(t, SchemaMismatchMissingColumn, mismatches[3].Kind)
+		assert.Equal(t, "result", mismatches[3].Column)
+		assert.Equal(t, SchemaMismatchMissingTable, mismatches[4].Kind)
+		assert.Equal(t, "flows: table does not exist (required by model Flow)", mismatches[4].String())
+
+		err := &SchemaMismatchError{Mismatches: mismatches}
+		assert.Contains(t, err.Error(), "database schema has 5 mismatch(es)")
+	})
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pinazu/internal/secrets"
+)
+
+const (
+	// encryptedSecretPrefix marks a secret stored as an envelope: the ID of the key encryption key,
+	// the wrapped data key and the secret encrypted with the data key
+	encryptedSecretPrefix = "enc:v1:"
+
+	// RedactedSecret replaces the secrets in the JSON form of the tool configurations
+	RedactedSecret = "[REDACTED]"
+)
+
+// SecretKeyWrapper protects the data keys of the encrypted secrets with a key encryption key,
+// held in the configuration or in a key management service
+type SecretKeyWrapper interface {
+	// KeyID returns the ID of the key wrapping new data keys
+	KeyID() string
+	// WrapKey encrypts a data key with the current key
+	WrapKey(dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped by the key with the given ID
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// secretKeyWrapper encrypts the secrets written to the database, secrets are stored in plaintext when nil.
+// It is set once at startup, before the database is used.
+var secretKeyWrapper SecretKeyWrapper
+
+// SetSecretKeyWrapper enables the encryption of the secrets stored in the database
+func SetSecretKeyWrapper(w SecretKeyWrapper) {
+	secretKeyWrapper = w
+}
+
+// LocalKeyWrapper wraps the data keys with AES-256-GCM keys from the configuration.
+// Keys that are no longer current stay listed so the secrets they wrapped can still be read.
+type LocalKeyWrapper struct {
+	keyID string
+	keys  map[string]cipher.AEAD
+}
+
+// NewLocalKeyWrapper creates a key wrapper from 32 byte keys indexed by ID, keyID is the current key
+func NewLocalKeyWrapper(keyID string, keys map[string][]byte) (*LocalKeyWrapper, error) {
+	if _, ok := keys[keyID]; !ok {
+		return nil, fmt.Errorf("current key %q is not in the key list", keyID)
+	}
+	w := &LocalKeyWrapper{keyID: keyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid key ID %q, must be non-empty and must not contain ':'", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("key %q must be 32 bytes, got %d", id, len(key))
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher for key %q: %w", id, err)
+		}
+		w.keys[id] = aead
+	}
+	return w, nil
+}
+
+func (w *LocalKeyWrapper) KeyID() string {
+	return w.keyID
+}
+
+func (w *LocalKeyWrapper) WrapKey(dataKey []byte) ([]byte, error) {
+	return seal(w.keys[w.keyID], dataKey)
+}
+
+func (w *LocalKeyWrapper) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := w.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret key %q", keyID)
+	}
+	return open(aead, wrapped)
+}
+
+// EncryptSecret encrypts a secret with a new data key wrapped by the configured key wrapper.
+// The secret is returned unchanged when no key wrapper is configured.
+func EncryptSecret(secret string) (string, error) {
+	return encryptSecret(secretKeyWrapper, secret)
+}
+
+// DecryptSecret decrypts a secret written by EncryptSecret, secrets stored in plaintext are returned unchanged
+func DecryptSecret(value string) (string, error) {
+	return decryptSecret(secretKeyWrapper, value)
+}
+
+func encryptSecret(w SecretKeyWrapper, secret string) (string, error) {
+	if w == nil {
+		return secret, nil
+	}
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(aead, []byte(secret))
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := w.WrapKey(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return encryptedSecretPrefix + w.KeyID() + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+func decryptSecret(w SecretKeyWrapper, value string) (string, error) {
+	envelope, ok := strings.CutPrefix(value, encryptedSecretPrefix)
+	if !ok {
+		return value, nil
+	}
+	if w == nil {
+		return "", fmt.Errorf("secret is encrypted but no secret key is configured")
+	}
+	parts := strings.Split(envelope, ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed encrypted secret")
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed wrapped data key: %w", err)
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed secret ciphertext: %w", err)
+	}
+	dataKey, err := w.UnwrapKey(parts[0], wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	secret, err := open(aead, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// validateSecretReference checks the syntax of a secret given as a reference to a secrets manager
+func validateSecretReference(secret *string) error {
+	if secret == nil || !secrets.IsReference(*secret) {
+		return nil
+	}
+	if _, err := secrets.ParseReference(*secret); err != nil {
+		return fmt.Errorf("invalid api_key: %w", err)
+	}
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext and prepends the random nonce
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a ciphertext written by seal
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
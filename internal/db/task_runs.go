@@ -0,0 +1,29 @@
+package db
+
+// taskRunTransitions lists the statuses a task run moves to from each status. A task keeps the history of its runs,
+// a single run of a task is started at a time and the runs created meanwhile are queued until it ends.
+var taskRunTransitions = map[TaskRunStatus][]TaskRunStatus{
+	TaskRunStatusScheduled: {TaskRunStatusRunning, TaskRunStatusCancelled},
+	TaskRunStatusRunning:   {TaskRunStatusPending, TaskRunStatusFinished, TaskRunStatusFailed, TaskRunStatusCancelled},
+	TaskRunStatusPending:   {TaskRunStatusRunning, TaskRunStatusFinished, TaskRunStatusFailed, TaskRunStatusCancelled},
+}
+
+// Started reports whether a run with the status is the started run of its task
+func (s TaskRunStatus) Started() bool {
+	return s == TaskRunStatusRunning || s == TaskRunStatusPending
+}
+
+// Ended reports whether a run with the status ended, an ended run keeps its status
+func (s TaskRunStatus) Ended() bool {
+	return s == TaskRunStatusFinished || s == TaskRunStatusFailed || s == TaskRunStatusCancelled
+}
+
+// CanTransitionTo reports whether a run with the status can move to the next status
+func (s TaskRunStatus) CanTransitionTo(next TaskRunStatus) bool {
+	for _, status := range taskRunTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
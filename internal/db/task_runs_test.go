@@ -0,0 +1,39 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TaskRunStatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Queued run", func(t *testing.T) {
+		assert.False(t, TaskRunStatusScheduled.Started())
+		assert.False(t, TaskRunStatusScheduled.Ended())
+		assert.True(t, TaskRunStatusScheduled.CanTransitionTo(TaskRunStatusRunning))
+		assert.True(t, TaskRunStatusScheduled.CanTransitionTo(TaskRunStatusCancelled))
+		assert.False(t, TaskRunStatusScheduled.CanTransitionTo(TaskRunStatusFinished))
+	})
+
+	t.Run("Started run", func(t *testing.T) {
+		for _, status := range []TaskRunStatus{TaskRunStatusRunning, TaskRunStatusPending} {
+			assert.True(t, status.Started())
+			assert.False(t, status.Ended())
+			assert.True(t, status.CanTransitionTo(TaskRunStatusFinished))
+			assert.False(t, status.CanTransitionTo(TaskRunStatusScheduled))
+		}
+		assert.True(t, TaskRunStatusRunning.CanTransitionTo(TaskRunStatusPending))
+		assert.True(t, TaskRunStatusPending.CanTransitionTo(TaskRunStatusRunning))
+	})
+
+	t.Run("Ended run", func(t *testing.T) {
+		for _, status := range []TaskRunStatus{TaskRunStatusFinished, TaskRunStatusFailed, TaskRunStatusCancelled} {
+			assert.True(t, status.Ended())
+			assert.False(t, status.Started())
+			assert.False(t, status.CanTransitionTo(TaskRunStatusRunning))
+			assert.False(t, status.CanTransitionTo(TaskRunStatusFailed))
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TaskSchedulePromptData is the data of the prompt template of a task schedule, rendered at each run
+type TaskSchedulePromptData struct {
+	Name        string    // Name of the schedule
+	ScheduledAt time.Time // Time the run was due, in the time zone of the schedule
+	Date        string    // Day the run was due, formatted as 2006-01-02
+}
+
+// RenderTaskSchedulePrompt renders the prompt of a task schedule for a run due at a time. The prompt is a Go
+// template, such as "Summarize the sales of {{ .Date }}", a prompt without action is sent as written.
+func RenderTaskSchedulePrompt(name, prompt string, scheduledAt time.Time) (string, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(prompt)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+	var b strings.Builder
+	err = tmpl.Execute(&b, TaskSchedulePromptData{
+		Name:        name,
+		ScheduledAt: scheduledAt,
+		Date:        scheduledAt.Format(time.DateOnly),
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RenderTaskSchedulePrompt(t *testing.T) {
+	t.Parallel()
+	scheduledAt := time.Date(2026, 3, 10, 8, 30, 0, 0, time.UTC)
+
+	t.Run("Plain prompt", func(t *testing.T) {
+		prompt, err := RenderTaskSchedulePrompt("daily", "Summarize the open tickets", scheduledAt)
+		require.NoError(t, err)
+		assert.Equal(t, "Summarize the open tickets", prompt)
+	})
+
+	t.Run("Template prompt", func(t *testing.T) {
+		prompt, err := RenderTaskSchedulePrompt("daily", `{{ .Name }}: sales of {{ .Date }} at {{ .ScheduledAt.Format "15:04" }}`, scheduledAt)
+		require.NoError(t, err)
+		assert.Equal(t, "daily: sales of 2026-03-10 at 08:30", prompt)
+	})
+
+	t.Run("Invalid template", func(t *testing.T) {
+		_, err := RenderTaskSchedulePrompt("daily", "Sales of {{ .Date", scheduledAt)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		_, err := RenderTaskSchedulePrompt("daily", "Sales of {{ .Day }}", scheduledAt)
+		assert.Error(t, err)
+	})
+}
@@ -12,17 +12,57 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTasks = `-- name: CountTasks :one
+SELECT COUNT(*) FROM tasks t
+JOIN threads th ON th.id = t.thread_id
+WHERE ($1::text IS NULL
+       OR (SELECT r.status::text FROM tasks_runs r WHERE r.task_id = t.id ORDER BY r.created_at DESC LIMIT 1) = $1::text)
+  AND ($2::uuid IS NULL OR t.created_by = $2::uuid)
+  AND ($3::timestamptz IS NULL OR t.created_at >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR t.created_at < $4::timestamptz)
+  AND th.workspace_id = $5 AND th.deleted_at IS NULL
+  AND (th.user_id = $6 OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = th.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = $6
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT r.role_id FROM user_role_mapping r WHERE r.user_id = $6))))
+`
+
+type CountTasksParams struct {
+	Status        pgtype.Text        `db:"status" json:"status"`
+	CreatedBy     pgtype.UUID        `db:"created_by" json:"created_by"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+	WorkspaceID   uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
+}
+
+// Counts the tasks matching the filters of GetTasks
+func (q *Queries) CountTasks(ctx context.Context, arg CountTasksParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTasks,
+		arg.Status,
+		arg.CreatedBy,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.WorkspaceID,
+		arg.UserID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTask = `-- name: CreateTask :one
-INSERT INTO tasks (thread_id, max_request_loop, additional_info, created_by)
-VALUES ($1, $2, $3, $4)
-RETURNING id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at
+INSERT INTO tasks (thread_id, max_request_loop, additional_info, created_by, flow_run_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at, flow_run_id
 `
 
 type CreateTaskParams struct {
-	ThreadID       uuid.UUID `db:"thread_id" json:"thread_id"`
-	MaxRequestLoop int32     `db:"max_request_loop" json:"max_request_loop"`
-	AdditionalInfo JsonRaw   `db:"additional_info" json:"additional_info"`
-	CreatedBy      uuid.UUID `db:"created_by" json:"created_by"`
+	ThreadID       uuid.UUID   `db:"thread_id" json:"thread_id"`
+	MaxRequestLoop int32       `db:"max_request_loop" json:"max_request_loop"`
+	AdditionalInfo JsonRaw     `db:"additional_info" json:"additional_info"`
+	CreatedBy      uuid.UUID   `db:"created_by" json:"created_by"`
+	FlowRunID      pgtype.UUID `db:"flow_run_id" json:"flow_run_id"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
@@ -31,6 +71,7 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		arg.MaxRequestLoop,
 		arg.AdditionalInfo,
 		arg.CreatedBy,
+		arg.FlowRunID,
 	)
 	var i Task
 	err := row.Scan(
@@ -42,6 +83,7 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.FlowRunID,
 	)
 	return i, err
 }
@@ -49,7 +91,7 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 const createTaskWithID = `-- name: CreateTaskWithID :one
 INSERT INTO tasks (id, thread_id, max_request_loop, additional_info, created_by, parent_task_id)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at
+RETURNING id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at, flow_run_id
 `
 
 type CreateTaskWithIDParams struct {
@@ -80,21 +122,28 @@ func (q *Queries) CreateTaskWithID(ctx context.Context, arg CreateTaskWithIDPara
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.FlowRunID,
 	)
 	return i, err
 }
 
 const deleteTask = `-- name: DeleteTask :exec
-DELETE FROM tasks WHERE id = $1
+DELETE FROM tasks WHERE id = $1 AND thread_id IN (SELECT th.id FROM threads th WHERE th.workspace_id = $2)
 `
 
-func (q *Queries) DeleteTask(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, deleteTask, id)
+type DeleteTaskParams struct {
+	ID          string    `db:"id" json:"id"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+}
+
+// Deletes a task of a thread of a workspace
+func (q *Queries) DeleteTask(ctx context.Context, arg DeleteTaskParams) error {
+	_, err := q.db.Exec(ctx, deleteTask, arg.ID, arg.WorkspaceID)
 	return err
 }
 
 const getTaskById = `-- name: GetTaskById :one
-SELECT id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at FROM tasks WHERE id = $1 LIMIT 1
+SELECT id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at, flow_run_id FROM tasks WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetTaskById(ctx context.Context, id string) (Task, error) {
@@ -109,21 +158,73 @@ func (q *Queries) GetTaskById(ctx context.Context, id string) (Task, error) {
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.FlowRunID,
 	)
 	return i, err
 }
 
 const getTasks = `-- name: GetTasks :many
-SELECT id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at FROM tasks ORDER BY created_at DESC LIMIT $1 OFFSET $2
+SELECT t.id, t.thread_id, t.max_request_loop, t.additional_info, t.parent_task_id, t.created_at, t.created_by, t.updated_at, t.flow_run_id FROM tasks t
+JOIN threads th ON th.id = t.thread_id
+WHERE ($1::text IS NULL
+       OR (SELECT r.status::text FROM tasks_runs r WHERE r.task_id = t.id ORDER BY r.created_at DESC LIMIT 1) = $1::text)
+  AND ($2::uuid IS NULL OR t.created_by = $2::uuid)
+  AND ($3::timestamptz IS NULL OR t.created_at >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR t.created_at < $4::timestamptz)
+  AND ($5::timestamptz IS NULL OR CASE
+    WHEN $6::text = 'updated_at' AND NOT $7::boolean
+      THEN (t.updated_at, t.id) > ($5::timestamptz, $8::text)
+    WHEN $6::text = 'updated_at'
+      THEN (t.updated_at, t.id) < ($5::timestamptz, $8::text)
+    WHEN NOT $7::boolean
+      THEN (t.created_at, t.id) > ($5::timestamptz, $8::text)
+    ELSE (t.created_at, t.id) < ($5::timestamptz, $8::text)
+  END)
+  AND th.workspace_id = $9 AND th.deleted_at IS NULL
+  AND (th.user_id = $10 OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = th.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = $10
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT r.role_id FROM user_role_mapping r WHERE r.user_id = $10))))
+ORDER BY
+  CASE WHEN $6::text = 'updated_at' AND NOT $7::boolean THEN t.updated_at END ASC,
+  CASE WHEN $6::text = 'updated_at' AND $7::boolean THEN t.updated_at END DESC,
+  CASE WHEN $6::text <> 'updated_at' AND NOT $7::boolean THEN t.created_at END ASC,
+  CASE WHEN $6::text <> 'updated_at' AND $7::boolean THEN t.created_at END DESC,
+  CASE WHEN NOT $7::boolean THEN t.id END ASC,
+  CASE WHEN $7::boolean THEN t.id END DESC
+LIMIT $11
 `
 
 type GetTasksParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+	Status        pgtype.Text        `db:"status" json:"status"`
+	CreatedBy     pgtype.UUID        `db:"created_by" json:"created_by"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+	CursorTime    pgtype.Timestamptz `db:"cursor_time" json:"cursor_time"`
+	Sort          string             `db:"sort" json:"sort"`
+	Descending    bool               `db:"descending" json:"descending"`
+	CursorID      string             `db:"cursor_id" json:"cursor_id"`
+	WorkspaceID   uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
+	RowLimit      int32              `db:"row_limit" json:"row_limit"`
 }
 
+// Lists the tasks matching the filters in the order of the sort, after the task of the cursor when set. The status of a
+// task is the status of its latest run. Only the tasks of the live threads of the workspace visible to the user are listed.
 func (q *Queries) GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getTasks, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getTasks,
+		arg.Status,
+		arg.CreatedBy,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorTime,
+		arg.Sort,
+		arg.Descending,
+		arg.CursorID,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +241,7 @@ func (q *Queries) GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, err
 			&i.CreatedAt,
 			&i.CreatedBy,
 			&i.UpdatedAt,
+			&i.FlowRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -152,7 +254,7 @@ func (q *Queries) GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, err
 }
 
 const getTasksByThreadId = `-- name: GetTasksByThreadId :many
-SELECT id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at FROM tasks WHERE thread_id = $1 ORDER BY created_at DESC
+SELECT id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at, flow_run_id FROM tasks WHERE thread_id = $1 ORDER BY created_at DESC
 `
 
 func (q *Queries) GetTasksByThreadId(ctx context.Context, threadID uuid.UUID) ([]Task, error) {
@@ -173,6 +275,7 @@ func (q *Queries) GetTasksByThreadId(ctx context.Context, threadID uuid.UUID) ([
 			&i.CreatedAt,
 			&i.CreatedBy,
 			&i.UpdatedAt,
+			&i.FlowRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -184,21 +287,58 @@ func (q *Queries) GetTasksByThreadId(ctx context.Context, threadID uuid.UUID) ([
 	return items, nil
 }
 
+const getWorkspaceTask = `-- name: GetWorkspaceTask :one
+SELECT t.id, t.thread_id, t.max_request_loop, t.additional_info, t.parent_task_id, t.created_at, t.created_by, t.updated_at, t.flow_run_id FROM tasks t
+JOIN threads th ON th.id = t.thread_id
+WHERE th.workspace_id = $1 AND t.id = $2
+LIMIT 1
+`
+
+type GetWorkspaceTaskParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          string    `db:"id" json:"id"`
+}
+
+// Gets a task of a thread of a workspace
+func (q *Queries) GetWorkspaceTask(ctx context.Context, arg GetWorkspaceTaskParams) (Task, error) {
+	row := q.db.QueryRow(ctx, getWorkspaceTask, arg.WorkspaceID, arg.ID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.ThreadID,
+		&i.MaxRequestLoop,
+		&i.AdditionalInfo,
+		&i.ParentTaskID,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.FlowRunID,
+	)
+	return i, err
+}
+
 const updateTask = `-- name: UpdateTask :one
 UPDATE tasks
 SET max_request_loop = $1, additional_info = $2
-WHERE id = $3
-RETURNING id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at
+WHERE id = $3 AND thread_id IN (SELECT th.id FROM threads th WHERE th.workspace_id = $4)
+RETURNING id, thread_id, max_request_loop, additional_info, parent_task_id, created_at, created_by, updated_at, flow_run_id
 `
 
 type UpdateTaskParams struct {
-	MaxRequestLoop int32   `db:"max_request_loop" json:"max_request_loop"`
-	AdditionalInfo JsonRaw `db:"additional_info" json:"additional_info"`
-	ID             string  `db:"id" json:"id"`
+	MaxRequestLoop int32     `db:"max_request_loop" json:"max_request_loop"`
+	AdditionalInfo JsonRaw   `db:"additional_info" json:"additional_info"`
+	ID             string    `db:"id" json:"id"`
+	WorkspaceID    uuid.UUID `db:"workspace_id" json:"workspace_id"`
 }
 
+// Updates a task of a thread of a workspace
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error) {
-	row := q.db.QueryRow(ctx, updateTask, arg.MaxRequestLoop, arg.AdditionalInfo, arg.ID)
+	row := q.db.QueryRow(ctx, updateTask,
+		arg.MaxRequestLoop,
+		arg.AdditionalInfo,
+		arg.ID,
+		arg.WorkspaceID,
+	)
 	var i Task
 	err := row.Scan(
 		&i.ID,
@@ -209,6 +349,7 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.FlowRunID,
 	)
 	return i, err
 }
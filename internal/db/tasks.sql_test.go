@@ -0,0 +1,134 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasksWorkspaceIsolation(t *testing.T) {
+	t.Parallel()
+	db_pool := setupTestDB(t)
+	defer db_pool.Close()
+	queries := New(db_pool)
+
+	createUser := func(name string) uuid.UUID {
+		user, err := queries.CreateUser(t.Context(), CreateUserParams{
+			Name:           uniqueName(name),
+			Email:          uniqueName(name) + "@example.com",
+			AdditionalInfo: JsonRaw{},
+			PasswordHash:   "hashedpassword123",
+			ProviderName:   ProviderNameLocal,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test user: %v", err)
+		}
+		t.Cleanup(func() { queries.DeleteUser(t.Context(), user.ID) })
+		return user.ID
+	}
+	alice := createUser("testuser_tasks_alice")
+	bob := createUser("testuser_tasks_bob")
+
+	organization, err := queries.CreateOrganization(t.Context(), uniqueName("tasks_organization"))
+	if err != nil {
+		t.Fatalf("Failed to create test organization: %v", err)
+	}
+	workspace, err := queries.CreateWorkspace(t.Context(), CreateWorkspaceParams{OrganizationID: organization.ID, Name: "Tasks"})
+	if err != nil {
+		t.Fatalf("Failed to create test workspace: %v", err)
+	}
+
+	createTask := func(workspaceID, userID uuid.UUID) Task {
+		now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		thread, err := queries.CreateThread(t.Context(), CreateThreadParams{
+			WorkspaceID: workspaceID,
+			Title:       "Task Thread",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			UserID:      userID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test thread: %v", err)
+		}
+		t.Cleanup(func() { queries.DeleteThread(t.Context(), thread.ID) })
+		task, err := queries.CreateTask(t.Context(), CreateTaskParams{
+			ThreadID:       thread.ID,
+			MaxRequestLoop: 20,
+			AdditionalInfo: JsonRaw(`{}`),
+			CreatedBy:      userID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test task: %v", err)
+		}
+		return task
+	}
+	// Alice works in the default workspace and in the workspace of Bob, Bob only in his workspace
+	aliceTask := createTask(DefaultWorkspaceID, alice)
+	aliceOtherTask := createTask(workspace.ID, alice)
+	bobTask := createTask(workspace.ID, bob)
+
+	listTasks := func(workspaceID, userID uuid.UUID) []string {
+		tasks, err := queries.GetTasks(t.Context(), GetTasksParams{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+			Sort:        "created_at",
+			Descending:  true,
+			RowLimit:    100,
+		})
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+		ids := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	t.Run("List the tasks of the workspace visible to the user", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{aliceTask.ID}, listTasks(DefaultWorkspaceID, alice))
+		assert.ElementsMatch(t, []string{aliceOtherTask.ID}, listTasks(workspace.ID, alice))
+		assert.ElementsMatch(t, []string{bobTask.ID}, listTasks(workspace.ID, bob))
+		assert.Empty(t, listTasks(DefaultWorkspaceID, bob))
+
+		count, err := queries.CountTasks(t.Context(), CountTasksParams{WorkspaceID: workspace.ID, UserID: bob})
+		if err != nil {
+			t.Fatalf("Failed to count tasks: %v", err)
+		}
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Get a task of another workspace", func(t *testing.T) {
+		task, err := queries.GetWorkspaceTask(t.Context(), GetWorkspaceTaskParams{WorkspaceID: workspace.ID, ID: bobTask.ID})
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		assert.Equal(t, bobTask.ID, task.ID)
+
+		_, err = queries.GetWorkspaceTask(t.Context(), GetWorkspaceTaskParams{WorkspaceID: DefaultWorkspaceID, ID: bobTask.ID})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Update or delete a task of another workspace", func(t *testing.T) {
+		_, err := queries.UpdateTask(t.Context(), UpdateTaskParams{
+			ID:             bobTask.ID,
+			WorkspaceID:    DefaultWorkspaceID,
+			MaxRequestLoop: 5,
+			AdditionalInfo: JsonRaw(`{}`),
+		})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		if err := queries.DeleteTask(t.Context(), DeleteTaskParams{ID: bobTask.ID, WorkspaceID: DefaultWorkspaceID}); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+		task, err := queries.GetTaskById(t.Context(), bobTask.ID)
+		if err != nil {
+			t.Fatalf("Expected the task of the other workspace to be kept: %v", err)
+		}
+		assert.Equal(t, bobTask.MaxRequestLoop, task.MaxRequestLoop)
+	})
+}
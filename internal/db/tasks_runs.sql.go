@@ -12,10 +12,174 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelTaskRun = `-- name: CancelTaskRun :one
+UPDATE tasks_runs
+SET status = 'CANCELLED', updated_at = NOW(), finished_at = NOW()
+WHERE task_run_id = $1 AND status IN ('SCHEDULED', 'RUNNING', 'PENDING')
+RETURNING task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run
+`
+
+// Cancels a queued or started run of a task, no row is returned when the run already ended
+func (q *Queries) CancelTaskRun(ctx context.Context, taskRunID uuid.UUID) (TasksRun, error) {
+	row := q.db.QueryRow(ctx, cancelTaskRun, taskRunID)
+	var i TasksRun
+	err := row.Scan(
+		&i.TaskRunID,
+		&i.TaskID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CurrentLoops,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
+	)
+	return i, err
+}
+
+const completeTaskRunByTaskID = `-- name: CompleteTaskRunByTaskID :execrows
+UPDATE tasks_runs
+SET status = $1, updated_at = NOW(), finished_at = NOW()
+WHERE task_id = $2 AND status IN ('RUNNING', 'PENDING')
+`
+
+type CompleteTaskRunByTaskIDParams struct {
+	Status TaskRunStatus `db:"status" json:"status"`
+	TaskID string        `db:"task_id" json:"task_id"`
+}
+
+// Ends the started run of a task, no row is updated when the run already ended. The queued runs are left as they are.
+func (q *Queries) CompleteTaskRunByTaskID(ctx context.Context, arg CompleteTaskRunByTaskIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, completeTaskRunByTaskID, arg.Status, arg.TaskID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countQueuedTaskRuns = `-- name: CountQueuedTaskRuns :one
+SELECT COUNT(*) FROM tasks_runs
+WHERE task_id = $1 AND status = 'SCHEDULED'
+`
+
+func (q *Queries) CountQueuedTaskRuns(ctx context.Context, taskID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countQueuedTaskRuns, taskID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRunningThreadTaskRuns = `-- name: CountRunningThreadTaskRuns :one
+SELECT COUNT(*) FROM tasks_runs tr
+JOIN tasks t ON t.id = tr.task_id
+WHERE t.thread_id = $1 AND t.parent_task_id IS NULL AND tr.status IN ('SCHEDULED', 'RUNNING')
+`
+
+// Counts the runs of the top level tasks of a thread still in flight, the runs paused for the user are not counted
+func (q *Queries) CountRunningThreadTaskRuns(ctx context.Context, threadID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countRunningThreadTaskRuns, threadID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createQueuedTasksRun = `-- name: CreateQueuedTasksRun :one
+INSERT INTO tasks_runs (
+    task_id,
+    current_loops,
+    agent_id,
+    requested_by,
+    workspace_id,
+    dry_run
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run
+`
+
+type CreateQueuedTasksRunParams struct {
+	TaskID       string      `db:"task_id" json:"task_id"`
+	CurrentLoops int32       `db:"current_loops" json:"current_loops"`
+	AgentID      pgtype.UUID `db:"agent_id" json:"agent_id"`
+	RequestedBy  pgtype.UUID `db:"requested_by" json:"requested_by"`
+	WorkspaceID  pgtype.UUID `db:"workspace_id" json:"workspace_id"`
+	DryRun       bool        `db:"dry_run" json:"dry_run"`
+}
+
+// Creates a run of a task queued behind its started run, with the agent it invokes once started
+func (q *Queries) CreateQueuedTasksRun(ctx context.Context, arg CreateQueuedTasksRunParams) (TasksRun, error) {
+	row := q.db.QueryRow(ctx, createQueuedTasksRun,
+		arg.TaskID,
+		arg.CurrentLoops,
+		arg.AgentID,
+		arg.RequestedBy,
+		arg.WorkspaceID,
+		arg.DryRun,
+	)
+	var i TasksRun
+	err := row.Scan(
+		&i.TaskRunID,
+		&i.TaskID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CurrentLoops,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
+	)
+	return i, err
+}
+
+const createSubTasksRun = `-- name: CreateSubTasksRun :one
+INSERT INTO tasks_runs (task_id, status, started_at, deadline_at)
+VALUES (
+    $1,
+    'RUNNING',
+    NOW(),
+    CASE WHEN $2::INTEGER > 0 THEN NOW() + make_interval(secs => $2::INTEGER) END
+) RETURNING task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run
+`
+
+type CreateSubTasksRunParams struct {
+	TaskID         string `db:"task_id" json:"task_id"`
+	TimeoutSeconds int32  `db:"timeout_seconds" json:"timeout_seconds"`
+}
+
+// Run of a sub-agent invoked with invoke_agent, failed with a timeout result past its deadline when the timeout is set
+func (q *Queries) CreateSubTasksRun(ctx context.Context, arg CreateSubTasksRunParams) (TasksRun, error) {
+	row := q.db.QueryRow(ctx, createSubTasksRun, arg.TaskID, arg.TimeoutSeconds)
+	var i TasksRun
+	err := row.Scan(
+		&i.TaskRunID,
+		&i.TaskID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CurrentLoops,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
+	)
+	return i, err
+}
+
 const createTasksRun = `-- name: CreateTasksRun :one
-INSERT INTO tasks_runs (task_id) VALUES ($1) RETURNING task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at
+INSERT INTO tasks_runs (task_id, status, started_at) VALUES ($1, 'RUNNING', NOW()) RETURNING task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run
 `
 
+// Creates a started run of a task, fails with a unique violation when the task already has a started run
 func (q *Queries) CreateTasksRun(ctx context.Context, taskID string) (TasksRun, error) {
 	row := q.db.QueryRow(ctx, createTasksRun, taskID)
 	var i TasksRun
@@ -28,6 +192,11 @@ func (q *Queries) CreateTasksRun(ctx context.Context, taskID string) (TasksRun,
 		&i.UpdatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
 	)
 	return i, err
 }
@@ -35,7 +204,7 @@ func (q *Queries) CreateTasksRun(ctx context.Context, taskID string) (TasksRun,
 const deleteOldTaskRun = `-- name: DeleteOldTaskRun :exec
 DELETE FROM tasks_runs 
 WHERE created_at < $1 
-AND status IN ('FINISHED', 'FAILED')
+AND status IN ('FINISHED', 'FAILED', 'CANCELLED')
 `
 
 func (q *Queries) DeleteOldTaskRun(ctx context.Context, createdAt pgtype.Timestamptz) error {
@@ -53,10 +222,11 @@ func (q *Queries) DeleteTaskRun(ctx context.Context, taskRunID uuid.UUID) error
 }
 
 const getCurrentTaskRunByTaskID = `-- name: GetCurrentTaskRunByTaskID :one
-SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at FROM tasks_runs
-WHERE task_id = $1 AND status IN ('PAUSE', 'SCHEDULED', 'RUNNING')
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs
+WHERE task_id = $1 AND status IN ('PENDING', 'RUNNING')
 `
 
+// Gets the started run of a task, the queued runs are not started yet
 func (q *Queries) GetCurrentTaskRunByTaskID(ctx context.Context, taskID string) (TasksRun, error) {
 	row := q.db.QueryRow(ctx, getCurrentTaskRunByTaskID, taskID)
 	var i TasksRun
@@ -69,12 +239,46 @@ func (q *Queries) GetCurrentTaskRunByTaskID(ctx context.Context, taskID string)
 		&i.UpdatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
+	)
+	return i, err
+}
+
+const getLastStartedTaskRunByTaskID = `-- name: GetLastStartedTaskRunByTaskID :one
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs
+WHERE task_id = $1 AND started_at IS NOT NULL
+ORDER BY started_at DESC
+LIMIT 1
+`
+
+// Gets the run of a task started last, running or ended
+func (q *Queries) GetLastStartedTaskRunByTaskID(ctx context.Context, taskID string) (TasksRun, error) {
+	row := q.db.QueryRow(ctx, getLastStartedTaskRunByTaskID, taskID)
+	var i TasksRun
+	err := row.Scan(
+		&i.TaskRunID,
+		&i.TaskID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CurrentLoops,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
 	)
 	return i, err
 }
 
 const getPendingTaskRun = `-- name: GetPendingTaskRun :many
-SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at FROM tasks_runs 
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs 
 WHERE status IN ('SCHEDULED', 'PAUSE') 
 ORDER BY created_at ASC
 `
@@ -97,6 +301,11 @@ func (q *Queries) GetPendingTaskRun(ctx context.Context) ([]TasksRun, error) {
 			&i.UpdatedAt,
 			&i.StartedAt,
 			&i.FinishedAt,
+			&i.DeadlineAt,
+			&i.AgentID,
+			&i.RequestedBy,
+			&i.WorkspaceID,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -109,7 +318,7 @@ func (q *Queries) GetPendingTaskRun(ctx context.Context) ([]TasksRun, error) {
 }
 
 const getRunningTaskRun = `-- name: GetRunningTaskRun :many
-SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at FROM tasks_runs 
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs 
 WHERE status = 'RUNNING' 
 ORDER BY created_at ASC
 `
@@ -132,6 +341,11 @@ func (q *Queries) GetRunningTaskRun(ctx context.Context) ([]TasksRun, error) {
 			&i.UpdatedAt,
 			&i.StartedAt,
 			&i.FinishedAt,
+			&i.DeadlineAt,
+			&i.AgentID,
+			&i.RequestedBy,
+			&i.WorkspaceID,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -144,7 +358,7 @@ func (q *Queries) GetRunningTaskRun(ctx context.Context) ([]TasksRun, error) {
 }
 
 const getTaskRunByStatus = `-- name: GetTaskRunByStatus :many
-SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at FROM tasks_runs 
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs 
 WHERE status = $1 
 ORDER BY created_at DESC
 `
@@ -167,6 +381,11 @@ func (q *Queries) GetTaskRunByStatus(ctx context.Context, status TaskRunStatus)
 			&i.UpdatedAt,
 			&i.StartedAt,
 			&i.FinishedAt,
+			&i.DeadlineAt,
+			&i.AgentID,
+			&i.RequestedBy,
+			&i.WorkspaceID,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -179,7 +398,7 @@ func (q *Queries) GetTaskRunByStatus(ctx context.Context, status TaskRunStatus)
 }
 
 const getTaskRunByTaskID = `-- name: GetTaskRunByTaskID :many
-SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at FROM tasks_runs 
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs 
 WHERE task_id = $1 
 ORDER BY created_at DESC
 `
@@ -202,6 +421,11 @@ func (q *Queries) GetTaskRunByTaskID(ctx context.Context, taskID string) ([]Task
 			&i.UpdatedAt,
 			&i.StartedAt,
 			&i.FinishedAt,
+			&i.DeadlineAt,
+			&i.AgentID,
+			&i.RequestedBy,
+			&i.WorkspaceID,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -214,7 +438,7 @@ func (q *Queries) GetTaskRunByTaskID(ctx context.Context, taskID string) ([]Task
 }
 
 const getTasksRun = `-- name: GetTasksRun :one
-SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at FROM tasks_runs WHERE task_run_id = $1
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs WHERE task_run_id = $1
 `
 
 func (q *Queries) GetTasksRun(ctx context.Context, taskRunID uuid.UUID) (TasksRun, error) {
@@ -229,6 +453,11 @@ func (q *Queries) GetTasksRun(ctx context.Context, taskRunID uuid.UUID) (TasksRu
 		&i.UpdatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
 	)
 	return i, err
 }
@@ -245,8 +474,102 @@ func (q *Queries) IncrementTaskRunLoops(ctx context.Context, taskRunID uuid.UUID
 	return err
 }
 
+const listActiveSubTaskIDs = `-- name: ListActiveSubTaskIDs :many
+SELECT t.id FROM tasks t
+JOIN tasks_runs tr ON tr.task_id = t.id
+WHERE t.parent_task_id = $1 AND tr.status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+`
+
+func (q *Queries) ListActiveSubTaskIDs(ctx context.Context, parentTaskID pgtype.Text) ([]string, error) {
+	rows, err := q.db.Query(ctx, listActiveSubTaskIDs, parentTaskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiredTaskRuns = `-- name: ListExpiredTaskRuns :many
+SELECT task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run FROM tasks_runs
+WHERE deadline_at < NOW() AND status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+ORDER BY deadline_at ASC
+`
+
+func (q *Queries) ListExpiredTaskRuns(ctx context.Context) ([]TasksRun, error) {
+	rows, err := q.db.Query(ctx, listExpiredTaskRuns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TasksRun{}
+	for rows.Next() {
+		var i TasksRun
+		if err := rows.Scan(
+			&i.TaskRunID,
+			&i.TaskID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.CurrentLoops,
+			&i.UpdatedAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.DeadlineAt,
+			&i.AgentID,
+			&i.RequestedBy,
+			&i.WorkspaceID,
+			&i.DryRun,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStartableQueuedTaskIDs = `-- name: ListStartableQueuedTaskIDs :many
+SELECT DISTINCT q.task_id FROM tasks_runs q
+WHERE q.status = 'SCHEDULED' AND q.agent_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM tasks_runs s WHERE s.task_id = q.task_id AND s.status IN ('PENDING', 'RUNNING'))
+LIMIT $1
+`
+
+// Lists the tasks with a queued run and without started run
+func (q *Queries) ListStartableQueuedTaskIDs(ctx context.Context, limit int32) ([]string, error) {
+	rows, err := q.db.Query(ctx, listStartableQueuedTaskIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var task_id string
+		if err := rows.Scan(&task_id); err != nil {
+			return nil, err
+		}
+		items = append(items, task_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTaskRun = `-- name: ListTaskRun :many
-SELECT tr.task_run_id, tr.task_id, tr.status, tr.created_at, tr.current_loops, tr.updated_at, tr.started_at, tr.finished_at, t.thread_id, t.max_request_loop
+SELECT tr.task_run_id, tr.task_id, tr.status, tr.created_at, tr.current_loops, tr.updated_at, tr.started_at, tr.finished_at, tr.deadline_at, tr.agent_id, tr.requested_by, tr.workspace_id, tr.dry_run, t.thread_id, t.max_request_loop
 FROM tasks_runs tr
 JOIN tasks t ON tr.task_id = t.id
 ORDER BY tr.created_at DESC
@@ -267,6 +590,11 @@ type ListTaskRunRow struct {
 	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 	StartedAt      pgtype.Timestamptz `db:"started_at" json:"started_at"`
 	FinishedAt     pgtype.Timestamptz `db:"finished_at" json:"finished_at"`
+	DeadlineAt     pgtype.Timestamptz `db:"deadline_at" json:"deadline_at"`
+	AgentID        pgtype.UUID        `db:"agent_id" json:"agent_id"`
+	RequestedBy    pgtype.UUID        `db:"requested_by" json:"requested_by"`
+	WorkspaceID    pgtype.UUID        `db:"workspace_id" json:"workspace_id"`
+	DryRun         bool               `db:"dry_run" json:"dry_run"`
 	ThreadID       uuid.UUID          `db:"thread_id" json:"thread_id"`
 	MaxRequestLoop int32              `db:"max_request_loop" json:"max_request_loop"`
 }
@@ -289,6 +617,11 @@ func (q *Queries) ListTaskRun(ctx context.Context, arg ListTaskRunParams) ([]Lis
 			&i.UpdatedAt,
 			&i.StartedAt,
 			&i.FinishedAt,
+			&i.DeadlineAt,
+			&i.AgentID,
+			&i.RequestedBy,
+			&i.WorkspaceID,
+			&i.DryRun,
 			&i.ThreadID,
 			&i.MaxRequestLoop,
 		); err != nil {
@@ -302,6 +635,42 @@ func (q *Queries) ListTaskRun(ctx context.Context, arg ListTaskRunParams) ([]Lis
 	return items, nil
 }
 
+const startQueuedTaskRun = `-- name: StartQueuedTaskRun :one
+UPDATE tasks_runs
+SET status = 'RUNNING', started_at = NOW(), updated_at = NOW()
+WHERE task_run_id = (
+    SELECT q.task_run_id FROM tasks_runs q
+    WHERE q.task_id = $1 AND q.status = 'SCHEDULED' AND q.agent_id IS NOT NULL
+      AND NOT EXISTS (SELECT 1 FROM tasks_runs s WHERE s.task_id = q.task_id AND s.status IN ('PENDING', 'RUNNING'))
+    ORDER BY q.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+) AND status = 'SCHEDULED'
+RETURNING task_run_id, task_id, status, created_at, current_loops, updated_at, started_at, finished_at, deadline_at, agent_id, requested_by, workspace_id, dry_run
+`
+
+// Starts the oldest queued run of a task, no row is returned when the task has a started run or nothing queued
+func (q *Queries) StartQueuedTaskRun(ctx context.Context, taskID string) (TasksRun, error) {
+	row := q.db.QueryRow(ctx, startQueuedTaskRun, taskID)
+	var i TasksRun
+	err := row.Scan(
+		&i.TaskRunID,
+		&i.TaskID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CurrentLoops,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.DeadlineAt,
+		&i.AgentID,
+		&i.RequestedBy,
+		&i.WorkspaceID,
+		&i.DryRun,
+	)
+	return i, err
+}
+
 const updateTaskRunCurrentLoops = `-- name: UpdateTaskRunCurrentLoops :exec
 UPDATE tasks_runs 
 SET current_loops = $2, 
@@ -334,8 +703,9 @@ func (q *Queries) UpdateTaskRunStartedAt(ctx context.Context, taskRunID uuid.UUI
 const updateTaskRunStatus = `-- name: UpdateTaskRunStatus :exec
 UPDATE tasks_runs
 SET status = $1,
-    updated_at = NOW()
-WHERE task_run_id = $2
+    updated_at = NOW(),
+    finished_at = CASE WHEN $1 IN ('FINISHED', 'FAILED', 'CANCELLED') THEN NOW() ELSE finished_at END
+WHERE task_run_id = $2 AND status NOT IN ('FINISHED', 'FAILED', 'CANCELLED')
 `
 
 type UpdateTaskRunStatusParams struct {
@@ -343,6 +713,7 @@ type UpdateTaskRunStatusParams struct {
 	TaskRunID uuid.UUID     `db:"task_run_id" json:"task_run_id"`
 }
 
+// Moves a run to a status, an ended run keeps the status it ended with
 func (q *Queries) UpdateTaskRunStatus(ctx context.Context, arg UpdateTaskRunStatusParams) error {
 	_, err := q.db.Exec(ctx, updateTaskRunStatus, arg.Status, arg.TaskRunID)
 	return err
@@ -351,7 +722,7 @@ func (q *Queries) UpdateTaskRunStatus(ctx context.Context, arg UpdateTaskRunStat
 const updateTaskRunStatusByTaskID = `-- name: UpdateTaskRunStatusByTaskID :exec
 UPDATE tasks_runs
 SET status = $1, updated_at = NOW()
-WHERE task_id = $2 AND status IN ('SCHEDULED', 'RUNNING', 'PENDING')
+WHERE task_id = $2 AND status IN ('RUNNING', 'PENDING')
 `
 
 type UpdateTaskRunStatusByTaskIDParams struct {
@@ -359,6 +730,7 @@ type UpdateTaskRunStatusByTaskIDParams struct {
 	TaskID string        `db:"task_id" json:"task_id"`
 }
 
+// Moves the started run of a task between RUNNING and PENDING, the queued runs are left as they are
 func (q *Queries) UpdateTaskRunStatusByTaskID(ctx context.Context, arg UpdateTaskRunStatusByTaskIDParams) error {
 	_, err := q.db.Exec(ctx, updateTaskRunStatusByTaskID, arg.Status, arg.TaskID)
 	return err
@@ -369,7 +741,7 @@ UPDATE tasks_runs
 SET status = $1::text, 
     updated_at = NOW(),
     started_at = CASE WHEN $1::text = 'RUNNING' AND started_at IS NULL THEN NOW() ELSE started_at END,
-    finished_at = CASE WHEN $1::text IN ('FINISHED', 'FAILED') AND finished_at IS NULL THEN NOW() ELSE finished_at END
+    finished_at = CASE WHEN $1::text IN ('FINISHED', 'FAILED', 'CANCELLED') AND finished_at IS NULL THEN NOW() ELSE finished_at END
 WHERE task_run_id = $2
 `
 
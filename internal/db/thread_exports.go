@@ -0,0 +1,323 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ThreadExportVersion is the version of the thread export format written by this release
+const ThreadExportVersion = 1
+
+type ThreadExportFormat string
+
+const (
+	ThreadExportFormatJSON     ThreadExportFormat = "json"
+	ThreadExportFormatMarkdown ThreadExportFormat = "markdown"
+)
+
+type (
+	// ThreadExport is a portable export of a thread with its messages and tool runs, imported as a new thread
+	ThreadExport struct {
+		Version    int                   `json:"version"`
+		ExportedAt time.Time             `json:"exported_at"`
+		Thread     ThreadExportInfo      `json:"thread"`
+		Messages   []ThreadExportMessage `json:"messages"`
+		ToolRuns   []ThreadExportToolRun `json:"tool_runs"`
+	}
+
+	// ThreadExportInfo is the metadata of an exported thread
+	ThreadExportInfo struct {
+		ID        uuid.UUID `json:"id"` // ID of the thread in the environment it was exported from
+		Title     string    `json:"title"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// ThreadExportMessage is a message of an exported thread, in the form it was given to the agents
+	ThreadExportMessage struct {
+		ID          uuid.UUID          `json:"id"` // ID of the message in the environment it was exported from
+		SenderType  SenderMessageType  `json:"sender_type"`
+		SenderID    uuid.UUID          `json:"sender_id"`
+		RecipientID uuid.UUID          `json:"recipient_id"`
+		ResultType  *ResultMessageType `json:"result_type,omitempty"`
+		StopReason  string             `json:"stop_reason,omitempty"`
+		Citations   []JsonRaw          `json:"citations,omitempty"`
+		Message     JsonRaw            `json:"message"`
+		CreatedAt   time.Time          `json:"created_at"`
+	}
+
+	// ThreadExportToolRun is a tool run of an exported thread. The tool runs are a record of the thread, the tools
+	// they ran may not exist where the thread is imported and they are not imported.
+	ThreadExportToolRun struct {
+		ID              string        `json:"id"`
+		ToolID          uuid.UUID     `json:"tool_id"`
+		ToolName        string        `json:"tool_name,omitempty"`
+		AgentID         uuid.UUID     `json:"agent_id"`
+		RecipientID     uuid.UUID     `json:"recipient_id"`
+		ParentRunID     string        `json:"parent_run_id,omitempty"`
+		Status          ToolRunStatus `json:"status"`
+		Input           JsonRaw       `json:"input,omitempty"`
+		Result          JsonRaw       `json:"result,omitempty"`
+		DurationSeconds *float64      `json:"duration_seconds,omitempty"`
+		CreatedAt       time.Time     `json:"created_at"`
+	}
+)
+
+// ExportThread exports a thread with its messages and tool runs
+func (q *Queries) ExportThread(ctx context.Context, thread Thread) (ThreadExport, error) {
+	export := ThreadExport{
+		Version:    ThreadExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Thread: ThreadExportInfo{
+			ID:        thread.ID,
+			Title:     thread.Title,
+			CreatedAt: thread.CreatedAt.Time.UTC(),
+			UpdatedAt: thread.UpdatedAt.Time.UTC(),
+		},
+		Messages: []ThreadExportMessage{},
+		ToolRuns: []ThreadExportToolRun{},
+	}
+
+	messages, err := q.GetMessages(ctx, thread.ID)
+	if err != nil {
+		return export, fmt.Errorf("failed to get messages: %w", err)
+	}
+	for _, message := range messages {
+		export.Messages = append(export.Messages, ThreadExportMessage{
+			ID:          message.ID,
+			SenderType:  message.SenderType,
+			SenderID:    message.SenderID,
+			RecipientID: message.RecipientID,
+			ResultType:  message.ResultType,
+			StopReason:  message.StopReason.String,
+			Citations:   message.Citations,
+			Message:     message.Message,
+			CreatedAt:   message.CreatedAt.Time.UTC(),
+		})
+	}
+
+	runs, err := q.ListThreadToolRuns(ctx, thread.ID)
+	if err != nil {
+		return export, fmt.Errorf("failed to list tool runs: %w", err)
+	}
+	for _, run := range runs {
+		exported := ThreadExportToolRun{
+			ID:          run.ID,
+			ToolID:      run.ToolID,
+			ToolName:    run.ToolName.String,
+			AgentID:     run.AgentID,
+			RecipientID: run.RecipientID,
+			ParentRunID: run.ParentRunID.String,
+			Status:      run.Status,
+			Input:       run.Input,
+			Result:      run.Result,
+			CreatedAt:   run.CreatedAt.Time.UTC(),
+		}
+		if run.Duration.Valid {
+			exported.DurationSeconds = &run.Duration.Float64
+		}
+		export.ToolRuns = append(export.ToolRuns, exported)
+	}
+	return export, nil
+}
+
+// Validate checks the version of the export and its messages
+func (e ThreadExport) Validate() error {
+	if e.Version < 1 || e.Version > ThreadExportVersion {
+		return fmt.Errorf("unsupported thread export version %d, this release supports version %d", e.Version, ThreadExportVersion)
+	}
+	for i, message := range e.Messages {
+		switch message.SenderType {
+		case SenderMessageTypeUser, SenderMessageTypeAssistant, SenderMessageTypeSystem, SenderMessageTypeResult:
+		default:
+			return fmt.Errorf("messages[%d]: invalid sender_type %q, must be user, assistant, system or result", i, message.SenderType)
+		}
+		if len(message.Message) == 0 || !json.Valid(message.Message) {
+			return fmt.Errorf("messages[%d]: message is required", i)
+		}
+		if message.SenderID == uuid.Nil || message.RecipientID == uuid.Nil {
+			return fmt.Errorf("messages[%d]: sender_id and recipient_id are required", i)
+		}
+	}
+	return nil
+}
+
+// ImportThread creates a thread of a user in a workspace from an export, with its messages in their order and dates. The thread gets
+// the title of the export unless a title is given, and is dated with the import. The tool runs are not imported.
+// The thread and its messages are created by the queries, run them in a transaction to import the thread whole.
+func (q *Queries) ImportThread(ctx context.Context, export ThreadExport, workspaceID, userID uuid.UUID, title string) (Thread, error) {
+	if err := export.Validate(); err != nil {
+		return Thread{}, err
+	}
+	if title == "" {
+		title = export.Thread.Title
+	}
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	thread, err := q.CreateThread(ctx, CreateThreadParams{Title: title, CreatedAt: now, UpdatedAt: now, UserID: userID, WorkspaceID: workspaceID})
+	if err != nil {
+		return Thread{}, fmt.Errorf("failed to create thread: %w", err)
+	}
+	for i, message := range export.Messages {
+		_, err := q.ImportThreadMessage(ctx, ImportThreadMessageParams{
+			ThreadID:    thread.ID,
+			Message:     message.Message,
+			SenderType:  message.SenderType,
+			ResultType:  message.ResultType,
+			StopReason:  pgtype.Text{String: message.StopReason, Valid: message.StopReason != ""},
+			SenderID:    message.SenderID,
+			Citations:   message.Citations,
+			RecipientID: message.RecipientID,
+			CreatedAt:   pgtype.Timestamptz{Time: message.CreatedAt, Valid: !message.CreatedAt.IsZero()},
+		})
+		if err != nil {
+			return Thread{}, fmt.Errorf("failed to import message %d: %w", i, err)
+		}
+	}
+	return thread, nil
+}
+
+// RenderThreadMarkdown writes an export as a Markdown transcript: the text of the messages, the tool calls with their
+// input and the tool results. The attachments are named, their content is left out.
+func RenderThreadMarkdown(export ThreadExport) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n\n", markdownLine(export.Thread.Title))
+	fmt.Fprintf(&buf, "- Thread: `%s`\n", export.Thread.ID)
+	fmt.Fprintf(&buf, "- Created: %s\n", export.Thread.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&buf, "- Exported: %s\n", export.ExportedAt.Format(time.RFC3339))
+	fmt.Fprintf(&buf, "- Messages: %d\n", len(export.Messages))
+	fmt.Fprintf(&buf, "- Tool runs: %d\n", len(export.ToolRuns))
+
+	for _, message := range export.Messages {
+		fmt.Fprintf(&buf, "\n## %s · %s\n\n", markdownSender(message.SenderType), message.CreatedAt.Format(time.RFC3339))
+		writeMarkdownMessage(&buf, message.Message)
+	}
+
+	if len(export.ToolRuns) > 0 {
+		buf.WriteString("\n## Tool runs\n\n")
+		buf.WriteString("| Tool | Status | Duration | Started |\n|---|---|---|---|\n")
+		for _, run := range export.ToolRuns {
+			name := run.ToolName
+			if name == "" {
+				name = run.ToolID.String()
+			}
+			duration := ""
+			if run.DurationSeconds != nil {
+				duration = fmt.Sprintf("%.2fs", *run.DurationSeconds)
+			}
+			fmt.Fprintf(&buf, "| %s | %s | %s | %s |\n", markdownCell(name), run.Status, duration, run.CreatedAt.Format(time.RFC3339))
+		}
+	}
+	return buf.Bytes()
+}
+
+// markdownSender returns the heading of the messages of a sender
+func markdownSender(senderType SenderMessageType) string {
+	switch senderType {
+	case SenderMessageTypeUser:
+		return "User"
+	case SenderMessageTypeAssistant:
+		return "Assistant"
+	case SenderMessageTypeSystem:
+		return "System"
+	case SenderMessageTypeResult:
+		return "Result"
+	default:
+		return string(senderType)
+	}
+}
+
+// writeMarkdownMessage writes the content of a message, a message of an unknown form as its JSON
+func writeMarkdownMessage(buf *bytes.Buffer, message JsonRaw) {
+	var fields struct {
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(message, &fields); err == nil && len(fields.Content) > 0 {
+		var text string
+		if err := json.Unmarshal(fields.Content, &text); err == nil {
+			buf.WriteString(text + "\n")
+			return
+		}
+		var blocks []map[string]json.RawMessage
+		if err := json.Unmarshal(fields.Content, &blocks); err == nil {
+			for _, block := range blocks {
+				writeMarkdownBlock(buf, block)
+			}
+			return
+		}
+	}
+	writeMarkdownJSON(buf, message)
+}
+
+// writeMarkdownBlock writes a content block of a message
+func writeMarkdownBlock(buf *bytes.Buffer, block map[string]json.RawMessage) {
+	str := func(key string) string {
+		var s string
+		_ = json.Unmarshal(block[key], &s)
+		return s
+	}
+	switch str("type") {
+	case "text", "":
+		if text := str("text"); text != "" {
+			buf.WriteString(text + "\n\n")
+			return
+		}
+		writeMarkdownJSON(buf, JsonRaw(mustMarshal(block)))
+	case "thinking":
+		for _, line := range strings.Split(strings.TrimSpace(str("thinking")), "\n") {
+			buf.WriteString("> " + line + "\n")
+		}
+		buf.WriteString("\n")
+	case "redacted_thinking":
+	case "tool_use":
+		fmt.Fprintf(buf, "**Tool call** `%s` (`%s`)\n\n", str("name"), str("id"))
+		writeMarkdownJSON(buf, JsonRaw(block["input"]))
+	case "tool_result":
+		fmt.Fprintf(buf, "**Tool result** (`%s`)\n\n", str("tool_use_id"))
+		var text string
+		if err := json.Unmarshal(block["content"], &text); err == nil {
+			buf.WriteString("```\n" + text + "\n```\n\n")
+			return
+		}
+		writeMarkdownJSON(buf, JsonRaw(block["content"]))
+	case "image":
+		buf.WriteString("_[image]_\n\n")
+	case "document":
+		fmt.Fprintf(buf, "_[document: %s]_\n\n", markdownLine(str("title")))
+	default:
+		writeMarkdownJSON(buf, JsonRaw(mustMarshal(block)))
+	}
+}
+
+// writeMarkdownJSON writes JSON as an indented code block
+func writeMarkdownJSON(buf *bytes.Buffer, data JsonRaw) {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		indented.Reset()
+		indented.Write(data)
+	}
+	buf.WriteString("```json\n")
+	buf.Write(indented.Bytes())
+	buf.WriteString("\n```\n\n")
+}
+
+// markdownLine keeps a value on a single line
+func markdownLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// markdownCell keeps a value in its table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(markdownLine(s), "|", "\\|")
+}
+
+func mustMarshal(v any) []byte {
+	data, _ := json.Marshal(v)
+	return data
+}
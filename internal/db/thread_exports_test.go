@@ -0,0 +1,173 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RenderThreadMarkdown(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	duration := 1.25
+	export := ThreadExport{
+		Version:    ThreadExportVersion,
+		ExportedAt: created.Add(time.Hour),
+		Thread:     ThreadExportInfo{ID: uuid.New(), Title: "Weather\nin Paris", CreatedAt: created, UpdatedAt: created},
+		Messages: []ThreadExportMessage{
+			{SenderType: SenderMessageTypeUser, Message: JsonRaw(`{"role":"user","content":"What is the weather in Paris?"}`), CreatedAt: created},
+			{SenderType: SenderMessageTypeAssistant, Message: JsonRaw(`{"role":"assistant","content":[{"type":"thinking","thinking":"Call the tool"},{"type":"text","text":"Let me check."},{"type":"tool_use","id":"toolu_1","name":"weather","input":{"city":"Paris"}}]}`), CreatedAt: created},
+			{SenderType: SenderMessageTypeUser, Message: JsonRaw(`{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"Sunny, 21C"},{"type":"image","source":{"type":"base64","media_type":"image/png","data":"aGk="}}]}`), CreatedAt: created},
+			{SenderType: SenderMessageTypeResult, Message: JsonRaw(`{"answer":42}`), CreatedAt: created},
+		},
+		ToolRuns: []ThreadExportToolRun{
+			{ID: "run_1", ToolID: uuid.New(), ToolName: "weather", Status: ToolRunStatusSuccess, DurationSeconds: &duration, CreatedAt: created},
+		},
+	}
+
+	markdown := string(RenderThreadMarkdown(export))
+	for _, expected := range []string{
+		"# Weather in Paris\n",
+		"## User · 2026-03-01T09:30:00Z\n\nWhat is the weather in Paris?\n",
+		"> Call the tool\n",
+		"Let me check.\n",
+		"**Tool call** `weather` (`toolu_1`)\n\n```json\n{\n  \"city\": \"Paris\"\n}\n```\n",
+		"**Tool result** (`toolu_1`)\n\n```\nSunny, 21C\n```\n",
+		"_[image]_\n",
+		"## Result · 2026-03-01T09:30:00Z\n\n```json\n{\n  \"answer\": 42\n}\n```\n",
+		"| weather | SUCCESS | 1.25s | 2026-03-01T09:30:00Z |\n",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("expected the transcript to contain %q, got:\n%s", expected, markdown)
+		}
+	}
+	if strings.Contains(markdown, "aGk=") {
+		t.Errorf("expected the attachment content to be left out, got:\n%s", markdown)
+	}
+}
+
+func Test_ThreadExportValidate(t *testing.T) {
+	t.Parallel()
+
+	message := func(senderType SenderMessageType, content string) ThreadExportMessage {
+		return ThreadExportMessage{SenderType: senderType, SenderID: uuid.New(), RecipientID: uuid.New(), Message: JsonRaw(content)}
+	}
+	tests := []struct {
+		name   string
+		export ThreadExport
+		err    string
+	}{
+		{name: "newer version", export: ThreadExport{Version: 2}, err: "unsupported thread export version 2"},
+		{name: "missing version", export: ThreadExport{}, err: "unsupported thread export version 0"},
+		{name: "invalid sender", export: ThreadExport{Version: 1, Messages: []ThreadExportMessage{message("bot", `{}`)}}, err: `messages[0]: invalid sender_type "bot"`},
+		{name: "missing message", export: ThreadExport{Version: 1, Messages: []ThreadExportMessage{message(SenderMessageTypeUser, "")}}, err: "messages[0]: message is required"},
+		{name: "missing sender", export: ThreadExport{Version: 1, Messages: []ThreadExportMessage{{SenderType: SenderMessageTypeUser, Message: JsonRaw(`{}`)}}}, err: "messages[0]: sender_id and recipient_id are required"},
+		{name: "valid", export: ThreadExport{Version: 1, Messages: []ThreadExportMessage{message(SenderMessageTypeUser, `{"role":"user","content":"hi"}`)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.export.Validate()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestExportImportThread(t *testing.T) {
+	t.Parallel()
+	db_pool := setupTestDB(t)
+	defer db_pool.Close()
+	queries := New(db_pool)
+
+	createUserParams := CreateUserParams{
+		Name:           "testuser_thread_exports_unique",
+		Email:          "thread_exports_unique@example.com",
+		AdditionalInfo: JsonRaw{},
+		PasswordHash:   "hashedpassword123",
+		ProviderName:   ProviderNameLocal,
+	}
+	createdUser, err := queries.CreateUser(t.Context(), createUserParams)
+	if err != nil {
+		t.Logf("User already exists, using existing user: %v", err)
+		user, err := queries.GetUserByEmail(t.Context(), createUserParams.Email)
+		if err != nil {
+			t.Fatalf("Failed to get existing user by email: %v", err)
+		}
+		createdUser = CreateUserRow(user)
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	thread, err := queries.CreateThread(t.Context(), CreateThreadParams{
+		WorkspaceID: DefaultWorkspaceID,
+		Title:       "Exported Thread",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		UserID:      createdUser.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test thread: %v", err)
+	}
+	defer queries.DeleteThread(t.Context(), thread.ID)
+
+	agentID := uuid.New()
+	for _, content := range []string{"first", "second"} {
+		_, err := queries.CreateUserMessage(t.Context(), CreateUserMessageParams{
+			ThreadID:    thread.ID,
+			Message:     JsonRaw(`{"role": "user", "content": "` + content + `"}`),
+			SenderID:    createdUser.ID,
+			RecipientID: agentID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create message: %v", err)
+		}
+	}
+
+	export, err := queries.ExportThread(t.Context(), thread)
+	if err != nil {
+		t.Fatalf("Failed to export thread: %v", err)
+	}
+	assert.Equal(t, ThreadExportVersion, export.Version)
+	assert.Equal(t, thread.ID, export.Thread.ID)
+	assert.Len(t, export.Messages, 2)
+	assert.Empty(t, export.ToolRuns)
+
+	// The import is a new thread with the messages in their order and dates
+	imported, err := queries.ImportThread(t.Context(), export, DefaultWorkspaceID, createdUser.ID, "")
+	if err != nil {
+		t.Fatalf("Failed to import thread: %v", err)
+	}
+	defer queries.DeleteThread(t.Context(), imported.ID)
+	assert.NotEqual(t, thread.ID, imported.ID)
+	assert.Equal(t, "Exported Thread", imported.Title)
+
+	messages, err := queries.GetMessages(t.Context(), imported.ID)
+	if err != nil {
+		t.Fatalf("Failed to get imported messages: %v", err)
+	}
+	if assert.Len(t, messages, 2) {
+		for i, message := range messages {
+			assert.JSONEq(t, string(export.Messages[i].Message), string(message.Message))
+			assert.Equal(t, export.Messages[i].SenderType, message.SenderType)
+			assert.True(t, export.Messages[i].CreatedAt.Equal(message.CreatedAt.Time))
+		}
+	}
+
+	renamed, err := queries.ImportThread(t.Context(), export, DefaultWorkspaceID, createdUser.ID, "Renamed")
+	if err != nil {
+		t.Fatalf("Failed to import thread: %v", err)
+	}
+	defer queries.DeleteThread(t.Context(), renamed.ID)
+	assert.Equal(t, "Renamed", renamed.Title)
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ForkThread creates a thread of a user in a workspace starting with a copy of the live messages of another thread up
+// to a message, all of them when the message is not set. The copies keep the dates and the attachments of the
+// messages, the original thread is left untouched. The thread and its messages are created by the queries, run them in
+// a transaction to fork the thread whole.
+func (q *Queries) ForkThread(ctx context.Context, source Thread, fromMessage pgtype.UUID, workspaceID, userID uuid.UUID, title string) (Thread, error) {
+	messages, err := q.GetMessages(ctx, source.ID)
+	if err != nil {
+		return Thread{}, fmt.Errorf("failed to get messages: %w", err)
+	}
+	messages, ok := messagesUntil(messages, fromMessage)
+	if !ok {
+		return Thread{}, fmt.Errorf("message %s is not a live message of thread %s", uuid.UUID(fromMessage.Bytes), source.ID)
+	}
+	if !fromMessage.Valid && len(messages) > 0 {
+		fromMessage = pgtype.UUID{Bytes: messages[len(messages)-1].ID, Valid: true}
+	}
+
+	thread, err := q.CreateThreadFork(ctx, CreateThreadForkParams{
+		Title:             title,
+		UserID:            userID,
+		WorkspaceID:       workspaceID,
+		ForkedFrom:        pgtype.UUID{Bytes: source.ID, Valid: true},
+		ForkedFromMessage: fromMessage,
+	})
+	if err != nil {
+		return Thread{}, fmt.Errorf("failed to create thread: %w", err)
+	}
+	for _, message := range messages {
+		copied, err := q.ImportThreadMessage(ctx, ImportThreadMessageParams{
+			ThreadID:    thread.ID,
+			Message:     message.Message,
+			SenderType:  message.SenderType,
+			ResultType:  message.ResultType,
+			StopReason:  message.StopReason,
+			SenderID:    message.SenderID,
+			Citations:   message.Citations,
+			RecipientID: message.RecipientID,
+			CreatedAt:   message.CreatedAt,
+		})
+		if err != nil {
+			return Thread{}, fmt.Errorf("failed to copy message %s: %w", message.ID, err)
+		}
+		err = q.CopyMessageAttachments(ctx, CopyMessageAttachmentsParams{MessageID: copied.ID, SourceMessageID: message.ID})
+		if err != nil {
+			return Thread{}, fmt.Errorf("failed to copy attachments of message %s: %w", message.ID, err)
+		}
+	}
+	return thread, nil
+}
+
+// messagesUntil returns the messages up to and including a message, all of them when the message is not set. It
+// reports false when the message is not among them.
+func messagesUntil(messages []ThreadMessage, until pgtype.UUID) ([]ThreadMessage, bool) {
+	if !until.Valid {
+		return messages, true
+	}
+	for i, message := range messages {
+		if message.ID == uuid.UUID(until.Bytes) {
+			return messages[:i+1], true
+		}
+	}
+	return nil, false
+}
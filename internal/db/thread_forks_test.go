@@ -0,0 +1,35 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MessagesUntil(t *testing.T) {
+	t.Parallel()
+
+	messages := []ThreadMessage{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	tests := []struct {
+		name     string
+		until    pgtype.UUID
+		expected []ThreadMessage
+		ok       bool
+	}{
+		{name: "all messages", until: pgtype.UUID{}, expected: messages, ok: true},
+		{name: "first message", until: pgtype.UUID{Bytes: messages[0].ID, Valid: true}, expected: messages[:1], ok: true},
+		{name: "middle message", until: pgtype.UUID{Bytes: messages[1].ID, Valid: true}, expected: messages[:2], ok: true},
+		{name: "last message", until: pgtype.UUID{Bytes: messages[2].ID, Valid: true}, expected: messages, ok: true},
+		{name: "unknown message", until: pgtype.UUID{Bytes: uuid.New(), Valid: true}, expected: nil, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, ok := messagesUntil(messages, tt.until)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -12,15 +12,54 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countThreads = `-- name: CountThreads :one
+SELECT COUNT(*) FROM threads t
+WHERE t.workspace_id = $1
+  AND (t.user_id = $2
+       OR (t.deleted_at IS NULL AND EXISTS (SELECT 1 FROM resource_grants g
+           WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+             AND (g.grantee_type = 'USER' AND g.grantee_id = $2
+                  OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $2)))))
+  AND ($3::text IS NULL OR t.title ILIKE '%' || $3::text || '%')
+  AND ($4::timestamptz IS NULL OR t.created_at >= $4::timestamptz)
+  AND ($5::timestamptz IS NULL OR t.created_at < $5::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = $6::boolean
+`
+
+type CountThreadsParams struct {
+	WorkspaceID   uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
+	Title         pgtype.Text        `db:"title" json:"title"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+	Deleted       bool               `db:"deleted" json:"deleted"`
+}
+
+// Counts the threads of a user in a workspace matching the filters of GetThreads
+func (q *Queries) CountThreads(ctx context.Context, arg CountThreadsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countThreads,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.Title,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Deleted,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createThread = `-- name: CreateThread :one
-INSERT INTO threads (title, created_at, updated_at, user_id) VALUES ($1, $2, $3, $4) RETURNING id, title, created_at, updated_at, user_id
+INSERT INTO threads (title, created_at, updated_at, user_id, workspace_id) VALUES ($1, $2, $3, $4, $5) RETURNING id, title, created_at, updated_at, user_id, deleted_at, workspace_id, forked_from, forked_from_message
 `
 
 type CreateThreadParams struct {
-	Title     string             `db:"title" json:"title"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	UserID    uuid.UUID          `db:"user_id" json:"user_id"`
+	Title       string             `db:"title" json:"title"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	UserID      uuid.UUID          `db:"user_id" json:"user_id"`
+	WorkspaceID uuid.UUID          `db:"workspace_id" json:"workspace_id"`
 }
 
 func (q *Queries) CreateThread(ctx context.Context, arg CreateThreadParams) (Thread, error) {
@@ -29,6 +68,7 @@ func (q *Queries) CreateThread(ctx context.Context, arg CreateThreadParams) (Thr
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.UserID,
+		arg.WorkspaceID,
 	)
 	var i Thread
 	err := row.Scan(
@@ -37,6 +77,48 @@ func (q *Queries) CreateThread(ctx context.Context, arg CreateThreadParams) (Thr
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+		&i.ForkedFrom,
+		&i.ForkedFromMessage,
+	)
+	return i, err
+}
+
+const createThreadFork = `-- name: CreateThreadFork :one
+INSERT INTO threads (title, user_id, workspace_id, forked_from, forked_from_message)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, title, created_at, updated_at, user_id, deleted_at, workspace_id, forked_from, forked_from_message
+`
+
+type CreateThreadForkParams struct {
+	Title             string      `db:"title" json:"title"`
+	UserID            uuid.UUID   `db:"user_id" json:"user_id"`
+	WorkspaceID       uuid.UUID   `db:"workspace_id" json:"workspace_id"`
+	ForkedFrom        pgtype.UUID `db:"forked_from" json:"forked_from"`
+	ForkedFromMessage pgtype.UUID `db:"forked_from_message" json:"forked_from_message"`
+}
+
+// Creates a thread of a user in a workspace forked from a message of another thread
+func (q *Queries) CreateThreadFork(ctx context.Context, arg CreateThreadForkParams) (Thread, error) {
+	row := q.db.QueryRow(ctx, createThreadFork,
+		arg.Title,
+		arg.UserID,
+		arg.WorkspaceID,
+		arg.ForkedFrom,
+		arg.ForkedFromMessage,
+	)
+	var i Thread
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+		&i.ForkedFrom,
+		&i.ForkedFromMessage,
 	)
 	return i, err
 }
@@ -51,16 +133,25 @@ func (q *Queries) DeleteThread(ctx context.Context, id uuid.UUID) error {
 }
 
 const getThreadByID = `-- name: GetThreadByID :one
-SELECT id, title, created_at, updated_at, user_id FROM threads WHERE user_id = $1 AND id = $2 LIMIT 1
+SELECT id, title, created_at, updated_at, user_id, deleted_at, workspace_id, forked_from, forked_from_message FROM threads t
+WHERE t.workspace_id = $1
+  AND (t.user_id = $2 OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = $2
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $2))))
+  AND t.id = $3 AND t.deleted_at IS NULL
+LIMIT 1
 `
 
 type GetThreadByIDParams struct {
-	UserID uuid.UUID `db:"user_id" json:"user_id"`
-	ID     uuid.UUID `db:"id" json:"id"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
 }
 
+// Gets a live thread of a workspace owned by the user or shared with the user
 func (q *Queries) GetThreadByID(ctx context.Context, arg GetThreadByIDParams) (Thread, error) {
-	row := q.db.QueryRow(ctx, getThreadByID, arg.UserID, arg.ID)
+	row := q.db.QueryRow(ctx, getThreadByID, arg.WorkspaceID, arg.UserID, arg.ID)
 	var i Thread
 	err := row.Scan(
 		&i.ID,
@@ -68,16 +159,83 @@ func (q *Queries) GetThreadByID(ctx context.Context, arg GetThreadByIDParams) (T
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+		&i.ForkedFrom,
+		&i.ForkedFromMessage,
 	)
 	return i, err
 }
 
 const getThreads = `-- name: GetThreads :many
-SELECT id, title, created_at, updated_at, user_id FROM threads WHERE user_id = $1 ORDER BY updated_at DESC
+SELECT id, title, created_at, updated_at, user_id, deleted_at, workspace_id, forked_from, forked_from_message FROM threads t
+WHERE t.workspace_id = $1
+  AND (t.user_id = $2
+       OR (t.deleted_at IS NULL AND EXISTS (SELECT 1 FROM resource_grants g
+           WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+             AND (g.grantee_type = 'USER' AND g.grantee_id = $2
+                  OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $2)))))
+  AND ($3::text IS NULL OR t.title ILIKE '%' || $3::text || '%')
+  AND ($4::timestamptz IS NULL OR t.created_at >= $4::timestamptz)
+  AND ($5::timestamptz IS NULL OR t.created_at < $5::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = $6::boolean
+  AND ($7::uuid IS NULL OR CASE
+    WHEN $8::text = 'title' AND NOT $9::boolean
+      THEN (t.title, t.id) > ($10::text, $7::uuid)
+    WHEN $8::text = 'title'
+      THEN (t.title, t.id) < ($10::text, $7::uuid)
+    WHEN $8::text = 'created_at' AND NOT $9::boolean
+      THEN (t.created_at, t.id) > ($11::timestamptz, $7::uuid)
+    WHEN $8::text = 'created_at'
+      THEN (t.created_at, t.id) < ($11::timestamptz, $7::uuid)
+    WHEN NOT $9::boolean
+      THEN (t.updated_at, t.id) > ($11::timestamptz, $7::uuid)
+    ELSE (t.updated_at, t.id) < ($11::timestamptz, $7::uuid)
+  END)
+ORDER BY
+  CASE WHEN $8::text = 'title' AND NOT $9::boolean THEN t.title END ASC,
+  CASE WHEN $8::text = 'title' AND $9::boolean THEN t.title END DESC,
+  CASE WHEN $8::text = 'created_at' AND NOT $9::boolean THEN t.created_at END ASC,
+  CASE WHEN $8::text = 'created_at' AND $9::boolean THEN t.created_at END DESC,
+  CASE WHEN $8::text NOT IN ('title', 'created_at') AND NOT $9::boolean THEN t.updated_at END ASC,
+  CASE WHEN $8::text NOT IN ('title', 'created_at') AND $9::boolean THEN t.updated_at END DESC,
+  CASE WHEN NOT $9::boolean THEN t.id END ASC,
+  CASE WHEN $9::boolean THEN t.id END DESC
+LIMIT $12
 `
 
-func (q *Queries) GetThreads(ctx context.Context, userID uuid.UUID) ([]Thread, error) {
-	rows, err := q.db.Query(ctx, getThreads, userID)
+type GetThreadsParams struct {
+	WorkspaceID   uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
+	Title         pgtype.Text        `db:"title" json:"title"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+	Deleted       bool               `db:"deleted" json:"deleted"`
+	CursorID      pgtype.UUID        `db:"cursor_id" json:"cursor_id"`
+	Sort          string             `db:"sort" json:"sort"`
+	Descending    bool               `db:"descending" json:"descending"`
+	CursorText    pgtype.Text        `db:"cursor_text" json:"cursor_text"`
+	CursorTime    pgtype.Timestamptz `db:"cursor_time" json:"cursor_time"`
+	RowLimit      int32              `db:"row_limit" json:"row_limit"`
+}
+
+// Lists the threads or the threads in the trash of a user in a workspace matching the filters in the order of the
+// sort, after the thread of the cursor when set. The live threads shared with the user are listed with its threads.
+func (q *Queries) GetThreads(ctx context.Context, arg GetThreadsParams) ([]Thread, error) {
+	rows, err := q.db.Query(ctx, getThreads,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.Title,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Deleted,
+		arg.CursorID,
+		arg.Sort,
+		arg.Descending,
+		arg.CursorText,
+		arg.CursorTime,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +249,10 @@ func (q *Queries) GetThreads(ctx context.Context, userID uuid.UUID) ([]Thread, e
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.UserID,
+			&i.DeletedAt,
+			&i.WorkspaceID,
+			&i.ForkedFrom,
+			&i.ForkedFromMessage,
 		); err != nil {
 			return nil, err
 		}
@@ -102,20 +264,96 @@ func (q *Queries) GetThreads(ctx context.Context, userID uuid.UUID) ([]Thread, e
 	return items, nil
 }
 
+const purgeThread = `-- name: PurgeThread :execrows
+DELETE FROM threads WHERE workspace_id = $1 AND user_id = $2 AND id = $3 AND deleted_at IS NOT NULL
+`
+
+type PurgeThreadParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Deletes a thread of the trash of a user in a workspace for good
+func (q *Queries) PurgeThread(ctx context.Context, arg PurgeThreadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeThread, arg.WorkspaceID, arg.UserID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreThread = `-- name: RestoreThread :one
+UPDATE threads SET deleted_at = NULL WHERE workspace_id = $1 AND user_id = $2 AND id = $3 AND deleted_at IS NOT NULL
+RETURNING id, title, created_at, updated_at, user_id, deleted_at, workspace_id, forked_from, forked_from_message
+`
+
+type RestoreThreadParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Restores a thread of a user in a workspace from the trash
+func (q *Queries) RestoreThread(ctx context.Context, arg RestoreThreadParams) (Thread, error) {
+	row := q.db.QueryRow(ctx, restoreThread, arg.WorkspaceID, arg.UserID, arg.ID)
+	var i Thread
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+		&i.ForkedFrom,
+		&i.ForkedFromMessage,
+	)
+	return i, err
+}
+
+const softDeleteThread = `-- name: SoftDeleteThread :execrows
+UPDATE threads SET deleted_at = NOW() WHERE workspace_id = $1 AND user_id = $2 AND id = $3 AND deleted_at IS NULL
+`
+
+type SoftDeleteThreadParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Moves a thread of a user in a workspace to the trash
+func (q *Queries) SoftDeleteThread(ctx context.Context, arg SoftDeleteThreadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteThread, arg.WorkspaceID, arg.UserID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateThread = `-- name: UpdateThread :one
 UPDATE threads
 SET title = $1
-WHERE id = $2
-RETURNING id, title, created_at, updated_at, user_id
+WHERE id = $2 AND workspace_id = $3 AND deleted_at IS NULL
+  AND ($4::timestamptz IS NULL OR updated_at = $4::timestamptz)
+RETURNING id, title, created_at, updated_at, user_id, deleted_at, workspace_id, forked_from, forked_from_message
 `
 
 type UpdateThreadParams struct {
-	Title string    `db:"title" json:"title"`
-	ID    uuid.UUID `db:"id" json:"id"`
+	Title       string             `db:"title" json:"title"`
+	ID          uuid.UUID          `db:"id" json:"id"`
+	WorkspaceID uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	IfUpdatedAt pgtype.Timestamptz `db:"if_updated_at" json:"if_updated_at"`
 }
 
+// Updates a thread of a workspace, only while its update time is if_updated_at when set
 func (q *Queries) UpdateThread(ctx context.Context, arg UpdateThreadParams) (Thread, error) {
-	row := q.db.QueryRow(ctx, updateThread, arg.Title, arg.ID)
+	row := q.db.QueryRow(ctx, updateThread,
+		arg.Title,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.IfUpdatedAt,
+	)
 	var i Thread
 	err := row.Scan(
 		&i.ID,
@@ -123,6 +361,10 @@ func (q *Queries) UpdateThread(ctx context.Context, arg UpdateThreadParams) (Thr
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UserID,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+		&i.ForkedFrom,
+		&i.ForkedFromMessage,
 	)
 	return i, err
 }
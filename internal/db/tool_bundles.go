@@ -0,0 +1,457 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"gopkg.in/yaml.v3"
+)
+
+// ToolBundleVersion is the version of the tool bundle format written by this release
+const ToolBundleVersion = 1
+
+// maxToolBundleNameAttempts bounds the names tried to import a tool as a copy of an existing tool
+const maxToolBundleNameAttempts = 100
+
+type ToolBundleFormat string
+
+const (
+	ToolBundleFormatJSON ToolBundleFormat = "json"
+	ToolBundleFormatYAML ToolBundleFormat = "yaml"
+)
+
+// ToolBundleConflict is how a bundle tool is imported when a tool with the same name exists
+type ToolBundleConflict string
+
+const (
+	ToolBundleConflictCreate ToolBundleConflict = "create" // Import the tool as a copy, under a new name
+	ToolBundleConflictUpdate ToolBundleConflict = "update" // Replace the existing tool with the bundle tool
+	ToolBundleConflictSkip   ToolBundleConflict = "skip"   // Keep the existing tool
+)
+
+// Valid reports whether the strategy is a known conflict strategy
+func (c ToolBundleConflict) Valid() bool {
+	switch c {
+	case ToolBundleConflictCreate, ToolBundleConflictUpdate, ToolBundleConflictSkip:
+		return true
+	}
+	return false
+}
+
+type ToolBundleImportAction string
+
+const (
+	ToolBundleImportActionCreated   ToolBundleImportAction = "created"
+	ToolBundleImportActionUpdated   ToolBundleImportAction = "updated"
+	ToolBundleImportActionUnchanged ToolBundleImportAction = "unchanged"
+	ToolBundleImportActionSkipped   ToolBundleImportAction = "skipped"
+	ToolBundleImportActionFailed    ToolBundleImportAction = "failed"
+)
+
+type (
+	// ToolBundle is a declarative export of tools, imported into another environment.
+	// The secrets of the tools are redacted, secret references are kept as is.
+	ToolBundle struct {
+		Version    int               `json:"version"`
+		ExportedAt time.Time         `json:"exported_at"`
+		Tools      []ToolBundleEntry `json:"tools"`
+	}
+
+	// ToolBundleEntry is a tool of a bundle, without the fields specific to the environment it was exported from
+	ToolBundleEntry struct {
+		Name          string     `json:"name"`
+		Description   string     `json:"description,omitempty"`
+		Config        ToolConfig `json:"config"`
+		Category      string     `json:"category,omitempty"`
+		Icon          string     `json:"icon,omitempty"`
+		Tags          []string   `json:"tags,omitempty"`
+		Examples      JsonRaw    `json:"examples,omitempty"`
+		Documentation string     `json:"documentation,omitempty"`
+	}
+
+	// ToolBundleImportResult is the outcome of the import of a bundle tool
+	ToolBundleImportResult struct {
+		Name     string                 `json:"name"`              // Name of the tool in the bundle
+		ToolID   *uuid.UUID             `json:"tool_id,omitempty"` // Tool created, updated or kept
+		ToolName string                 `json:"tool_name,omitempty"`
+		Action   ToolBundleImportAction `json:"action"`
+		Message  string                 `json:"message,omitempty"` // Why the tool failed, or what needs attention once imported
+		Before   *Tool                  `json:"-"`                 // Tool replaced by an update
+		After    *Tool                  `json:"-"`                 // Tool created or updated
+	}
+)
+
+// NewToolBundle exports tools as a bundle. The built-in tools, and the internal tools registered by the tools
+// service, exist in every environment and are left out.
+func NewToolBundle(tools []Tool) ToolBundle {
+	bundle := ToolBundle{Version: ToolBundleVersion, ExportedAt: time.Now().UTC(), Tools: []ToolBundleEntry{}}
+	for _, tool := range tools {
+		if tool.Config.Type == ToolTypeInternal {
+			continue
+		}
+		entry := ToolBundleEntry{
+			Name:          tool.Name,
+			Description:   tool.Description.String,
+			Config:        tool.Config,
+			Category:      tool.Category.String,
+			Icon:          tool.Icon.String,
+			Tags:          tool.Tags,
+			Documentation: tool.Documentation.String,
+		}
+		if len(tool.Examples) > 0 && string(tool.Examples) != "null" {
+			entry.Examples = tool.Examples
+		}
+		bundle.Tools = append(bundle.Tools, entry)
+	}
+	return bundle
+}
+
+// EncodeToolBundle writes a bundle in the given format, the secrets being redacted by the JSON form of the configurations
+func EncodeToolBundle(bundle ToolBundle, format ToolBundleFormat) ([]byte, error) {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool bundle: %w", err)
+	}
+	switch format {
+	case ToolBundleFormatJSON, "":
+		return data, nil
+	case ToolBundleFormatYAML:
+	default:
+		return nil, fmt.Errorf("unknown tool bundle format %q, must be json or yaml", format)
+	}
+
+	// JSON is YAML, decoding it as a node keeps the key order and only the flow style has to be dropped
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert tool bundle to YAML: %w", err)
+	}
+	blockStyle(&doc)
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode tool bundle: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode tool bundle: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeToolBundle reads a bundle written in JSON or YAML
+func DecodeToolBundle(data []byte) (ToolBundle, error) {
+	var bundle ToolBundle
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return bundle, fmt.Errorf("failed to parse tool bundle: %w", err)
+	}
+	// The tool configurations only implement the JSON decoding
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return bundle, fmt.Errorf("failed to parse tool bundle: %w", err)
+	}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return bundle, fmt.Errorf("failed to parse tool bundle: %w", err)
+	}
+	return bundle, bundle.Validate()
+}
+
+// Validate checks the version of the bundle and that each tool is named once
+func (b ToolBundle) Validate() error {
+	if b.Version < 1 || b.Version > ToolBundleVersion {
+		return fmt.Errorf("unsupported tool bundle version %d, this release supports version %d", b.Version, ToolBundleVersion)
+	}
+	names := make(map[string]bool, len(b.Tools))
+	for i, entry := range b.Tools {
+		if entry.Name == "" {
+			return fmt.Errorf("tools[%d]: name is required", i)
+		}
+		if names[entry.Name] {
+			return fmt.Errorf("tool %q appears more than once in the bundle", entry.Name)
+		}
+		names[entry.Name] = true
+	}
+	return nil
+}
+
+// ToolsForBundle returns the tools of a workspace visible to a user selected by ID or by name, every tool of the
+// workspace visible to the user when none is selected. The shared tools are included. The selected tools that do not
+// exist or are hidden from the user are returned as missing, by ID or name.
+func (q *Queries) ToolsForBundle(ctx context.Context, workspaceID, userID uuid.UUID, ids []uuid.UUID, names []string) (tools []Tool, missing []string, err error) {
+	visible := func(tool Tool) (bool, error) {
+		access, err := q.ResourceAccess(ctx, ResourceTypeTool, tool.ID, tool.CreatedBy, userID)
+		if err != nil {
+			return false, err
+		}
+		return access.Valid(), nil
+	}
+
+	if len(ids) == 0 && len(names) == 0 {
+		all, err := q.ListTools(ctx, workspaceID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		for _, tool := range all {
+			ok, err := visible(tool)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				tools = append(tools, tool)
+			}
+		}
+		return tools, nil, nil
+	}
+
+	selected := make(map[uuid.UUID]bool)
+	if len(ids) > 0 {
+		found, err := q.GetToolsByIDs(ctx, GetToolsByIDsParams{Ids: ids, WorkspaceID: workspaceID})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get tools: %w", err)
+		}
+		for _, tool := range found {
+			ok, err := visible(tool)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				selected[tool.ID] = true
+				tools = append(tools, tool)
+			}
+		}
+		for _, id := range ids {
+			if !selected[id] {
+				missing = append(missing, id.String())
+			}
+		}
+	}
+	for _, name := range names {
+		tool, err := q.GetToolInfoByName(ctx, GetToolInfoByNameParams{Name: name, WorkspaceID: WorkspaceUUID(workspaceID)})
+		if err == pgx.ErrNoRows {
+			missing = append(missing, name)
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get tool %s: %w", name, err)
+		}
+		ok, err := visible(tool)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if !selected[tool.ID] {
+			selected[tool.ID] = true
+			tools = append(tools, tool)
+		}
+	}
+	slices.SortFunc(tools, func(a, b Tool) int { return strings.Compare(a.Name, b.Name) })
+	return tools, missing, nil
+}
+
+// ImportToolBundle creates or updates the tools of a bundle in a workspace, each change being a new tool revision.
+// The existing tools are only updated when the importing user manages them and they are not shared tools imported
+// outside the default workspace. A tool failing to import is reported in its result, the other tools are still imported.
+// The error is only returned when the database cannot be read.
+func (q *Queries) ImportToolBundle(ctx context.Context, workspaceID uuid.UUID, bundle ToolBundle, onConflict ToolBundleConflict, createdBy uuid.UUID) ([]ToolBundleImportResult, error) {
+	if !onConflict.Valid() {
+		return nil, fmt.Errorf("unknown conflict strategy %q, must be create, update or skip", onConflict)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]ToolBundleImportResult, 0, len(bundle.Tools))
+	for _, entry := range bundle.Tools {
+		result, err := q.importToolBundleEntry(ctx, workspaceID, entry, onConflict, createdBy)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// importToolBundleEntry imports a bundle tool, the error is only returned when the database cannot be read
+func (q *Queries) importToolBundleEntry(ctx context.Context, workspaceID uuid.UUID, entry ToolBundleEntry, onConflict ToolBundleConflict, createdBy uuid.UUID) (ToolBundleImportResult, error) {
+	result := ToolBundleImportResult{Name: entry.Name}
+	failed := func(message string) (ToolBundleImportResult, error) {
+		result.Action = ToolBundleImportActionFailed
+		result.Message = message
+		return result, nil
+	}
+
+	if err := ValidateToolName(entry.Name); err != nil {
+		return failed(err.Error())
+	}
+	if entry.Config.Type == ToolTypeInternal {
+		return failed("internal tools are created by the tools service registering them and cannot be imported")
+	}
+	if err := entry.Config.Validate(); err != nil {
+		return failed(err.Error())
+	}
+
+	existing, err := q.GetToolInfoByName(ctx, GetToolInfoByNameParams{Name: entry.Name, WorkspaceID: WorkspaceUUID(workspaceID)})
+	if err != nil && err != pgx.ErrNoRows {
+		return result, fmt.Errorf("failed to get tool %s: %w", entry.Name, err)
+	}
+	name := entry.Name
+	if err == nil {
+		switch onConflict {
+		case ToolBundleConflictSkip:
+			result.ToolID, result.ToolName = &existing.ID, existing.Name
+			result.Action = ToolBundleImportActionSkipped
+			result.Message = "a tool with this name already exists"
+			return result, nil
+		case ToolBundleConflictUpdate:
+			return q.updateToolFromBundle(ctx, result, workspaceID, existing, entry, createdBy)
+		case ToolBundleConflictCreate:
+			if name, err = q.availableToolName(ctx, workspaceID, entry.Name); err != nil {
+				return result, err
+			}
+			if name == "" {
+				return failed("no name is available to import a copy of the tool")
+			}
+		}
+	}
+
+	config := entry.Config
+	if redacted := config.APIKey(); redacted != nil && *redacted == RedactedSecret {
+		// The secret did not leave the environment the tool was exported from
+		config.RestoreRedactedSecrets(ToolConfig{})
+		result.Message = "the api_key of the tool was redacted in the bundle, set it once imported"
+	}
+	params := entry.toolParams()
+	tool, err := q.CreateTool(ctx, CreateToolParams{
+		Name:          name,
+		Description:   params.Description,
+		Config:        config,
+		CreatedBy:     createdBy,
+		Category:      params.Category,
+		Icon:          params.Icon,
+		Tags:          params.Tags,
+		Examples:      params.Examples,
+		Documentation: params.Documentation,
+		WorkspaceID:   WorkspaceUUID(workspaceID),
+	})
+	if err != nil {
+		return failed(fmt.Sprintf("failed to create tool: %v", err))
+	}
+	// The imported description and configuration are the first revision of the tool
+	tool, err = q.CreateToolRevision(ctx, CreateToolRevisionParams{ToolID: tool.ID, CreatedBy: createdBy})
+	if err != nil {
+		return failed(fmt.Sprintf("failed to create tool revision: %v", err))
+	}
+	result.ToolID, result.ToolName, result.After = &tool.ID, tool.Name, &tool
+	result.Action = ToolBundleImportActionCreated
+	return result, nil
+}
+
+// updateToolFromBundle replaces an existing tool with a bundle tool, keeping its secrets when redacted in the bundle
+func (q *Queries) updateToolFromBundle(ctx context.Context, result ToolBundleImportResult, workspaceID uuid.UUID, existing Tool, entry ToolBundleEntry, createdBy uuid.UUID) (ToolBundleImportResult, error) {
+	failed := func(message string) (ToolBundleImportResult, error) {
+		result.Action = ToolBundleImportActionFailed
+		result.Message = message
+		return result, nil
+	}
+
+	access, err := q.ResourceAccess(ctx, ResourceTypeTool, existing.ID, existing.CreatedBy, createdBy)
+	if err != nil {
+		return result, err
+	}
+	if !access.Allows(GrantAccessManage) {
+		return failed(fmt.Sprintf("the existing tool requires the %s access to be replaced", GrantAccessManage))
+	}
+	result.ToolID, result.ToolName = &existing.ID, existing.Name
+	if !existing.WorkspaceID.Valid && workspaceID != DefaultWorkspaceID {
+		return failed("the existing tool is shared by every workspace, it can only be replaced from the default workspace")
+	}
+	if existing.Config.Type == ToolTypeInternal {
+		return failed("the existing tool is a built-in or internal tool and cannot be replaced")
+	}
+
+	params := entry.toolParams()
+	params.ID, params.WorkspaceID = existing.ID, existing.WorkspaceID
+	params.Config.RestoreRedactedSecrets(existing.Config)
+	tool, err := q.UpdateTool(ctx, params)
+	if err != nil {
+		return failed(fmt.Sprintf("failed to update tool: %v", err))
+	}
+
+	// Changing what the agents see of the tool creates a new revision, catalog metadata is not revisioned
+	if ToolRevisionChanged(existing, tool) {
+		tool, err = q.CreateToolRevision(ctx, CreateToolRevisionParams{ToolID: tool.ID, CreatedBy: createdBy})
+		if err != nil {
+			return failed(fmt.Sprintf("failed to create tool revision: %v", err))
+		}
+	} else if toolCatalogUnchanged(existing, tool) {
+		result.Action = ToolBundleImportActionUnchanged
+		return result, nil
+	}
+	result.Before, result.After = &existing, &tool
+	result.Action = ToolBundleImportActionUpdated
+	return result, nil
+}
+
+// availableToolName returns the first free name of the workspace among "<name>_2", "<name>_3"... empty when none is free
+func (q *Queries) availableToolName(ctx context.Context, workspaceID uuid.UUID, name string) (string, error) {
+	for i := 2; i < maxToolBundleNameAttempts+2; i++ {
+		candidate := fmt.Sprintf("%s_%d", name, i)
+		_, err := q.GetToolInfoByName(ctx, GetToolInfoByNameParams{Name: candidate, WorkspaceID: WorkspaceUUID(workspaceID)})
+		if err == pgx.ErrNoRows {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get tool %s: %w", candidate, err)
+		}
+	}
+	return "", nil
+}
+
+// toolParams converts a bundle tool into the fields of a tool update, without the ID
+func (e ToolBundleEntry) toolParams() UpdateToolParams {
+	text := func(s string) pgtype.Text {
+		return pgtype.Text{String: s, Valid: s != ""}
+	}
+	params := UpdateToolParams{
+		Description:   text(e.Description),
+		Config:        e.Config,
+		Category:      text(e.Category),
+		Icon:          text(e.Icon),
+		Tags:          e.Tags,
+		Documentation: text(e.Documentation),
+	}
+	if len(e.Examples) > 0 && string(e.Examples) != "null" {
+		params.Examples = e.Examples
+	}
+	return params
+}
+
+// toolCatalogUnchanged reports whether the catalog metadata of a tool is the same in two states
+func toolCatalogUnchanged(before, after Tool) bool {
+	return before.Category == after.Category && before.Icon == after.Icon &&
+		before.Documentation == after.Documentation && slices.Equal(before.Tags, after.Tags) &&
+		bytes.Equal(before.Examples, after.Examples)
+}
+
+// blockStyle drops the flow style of the nodes decoded from JSON, so the YAML is written in block style
+func blockStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		// Strings keep their quotes only when they need them
+		node.Style &^= yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func Test_ToolBundle(t *testing.T) {
+	t.Parallel()
+
+	apiKey := "test_api_key"
+	reference := "vault://secret/tools/weather#api_key"
+	tools := []Tool{
+		{
+			ID:          uuid.New(),
+			Name:        "weather",
+			Description: pgtype.Text{String: "Current weather of a city", Valid: true},
+			Config:      ToolConfig{Type: ToolTypeMCP, C: &ToolConfigMCP{ApiKey: &apiKey, Entrypoint: "https://mcp.example.com", Protocol: MCPProtocolSSE}},
+			Category:    pgtype.Text{String: "data", Valid: true},
+			Tags:        []string{"weather", "forecast"},
+			Examples:    JsonRaw(`[{"input":{"city":"Paris"}}]`),
+		},
+		{
+			ID:     uuid.New(),
+			Name:   "forecast",
+			Config: ToolConfig{Type: ToolTypeMCP, C: &ToolConfigMCP{ApiKey: &reference, Entrypoint: "https://mcp.example.com/forecast", Protocol: MCPProtocolSSE}},
+		},
+		{
+			ID:     BuiltinToolIDs["invoke_agent"],
+			Name:   "invoke_agent",
+			Config: ToolConfig{Type: ToolTypeInternal, C: &ToolConfigInternal{}},
+		},
+	}
+
+	bundle := NewToolBundle(tools)
+	if len(bundle.Tools) != 2 {
+		t.Fatalf("expected the built-in tool to be left out, got %d tools", len(bundle.Tools))
+	}
+
+	for _, format := range []ToolBundleFormat{ToolBundleFormatJSON, ToolBundleFormatYAML} {
+		data, err := EncodeToolBundle(bundle, format)
+		if err != nil {
+			t.Fatalf("EncodeToolBundle(%s) error: %v", format, err)
+		}
+		if strings.Contains(string(data), apiKey) {
+			t.Errorf("expected the API key to be redacted in the %s bundle:\n%s", format, data)
+		}
+		if format == ToolBundleFormatYAML && !strings.Contains(string(data), "\n  - name: weather\n") {
+			t.Errorf("expected a block style YAML bundle, got:\n%s", data)
+		}
+
+		decoded, err := DecodeToolBundle(data)
+		if err != nil {
+			t.Fatalf("DecodeToolBundle(%s) error: %v", format, err)
+		}
+		if len(decoded.Tools) != 2 || decoded.Tools[0].Name != "weather" || decoded.Tools[1].Name != "forecast" {
+			t.Fatalf("expected the tools in their exported order, got %+v", decoded.Tools)
+		}
+		weather := decoded.Tools[0]
+		if got := weather.Config.APIKey(); got == nil || *got != RedactedSecret {
+			t.Errorf("expected the redacted API key, got %v", got)
+		}
+		if weather.Description != "Current weather of a city" || weather.Category != "data" || len(weather.Tags) != 2 {
+			t.Errorf("expected the catalog metadata to be kept, got %+v", weather)
+		}
+		if got := decoded.Tools[1].Config.APIKey(); got == nil || *got != reference {
+			t.Errorf("expected the secret reference to be kept, got %v", got)
+		}
+	}
+}
+
+func Test_ToolBundleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{name: "newer version", input: "version: 2\ntools: []\n", err: "unsupported tool bundle version 2"},
+		{name: "missing version", input: "tools: []\n", err: "unsupported tool bundle version 0"},
+		{name: "unnamed tool", input: "version: 1\ntools:\n  - description: no name\n", err: "tools[0]: name is required"},
+		{name: "duplicate tool", input: "version: 1\ntools:\n  - name: weather\n  - name: weather\n", err: `tool "weather" appears more than once`},
+		{name: "valid", input: "version: 1\ntools: []\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeToolBundle([]byte(tt.input))
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	if ToolBundleConflict("replace").Valid() {
+		t.Error("expected an unknown conflict strategy to be invalid")
+	}
+}
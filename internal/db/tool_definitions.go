@@ -0,0 +1,279 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxToolDefinitionRefDepth bounds the nesting of the $ref inlined in a tool definition schema,
+// a recursive schema cannot be represented without references
+const maxToolDefinitionRefDepth = 32
+
+// maxToolDefinitionSchemas bounds the schemas of a tool definition once its $ref are inlined, a few shallow
+// definitions referencing each other several times would otherwise expand to an exponential number of schemas
+const maxToolDefinitionSchemas = 10000
+
+// ToolDefinitionFormat is the format of a tool definition written for another stack
+type ToolDefinitionFormat string
+
+const (
+	ToolDefinitionFormatOpenAI ToolDefinitionFormat = "openai" // OpenAI function definition
+	ToolDefinitionFormatMCP    ToolDefinitionFormat = "mcp"    // MCP tool descriptor, as listed by tools/list
+)
+
+// unsupportedSchemaKeywords are the JSON Schema keywords without OpenAPI 3.0 equivalent, dropped from the
+// tool definition schemas. The model requests only need the types, properties and constraints of the parameters.
+var unsupportedSchemaKeywords = []string{
+	"$schema", "$id", "$anchor", "$comment", "$defs", "definitions", "prefixItems", "contains",
+	"if", "then", "else", "dependentRequired", "dependentSchemas", "patternProperties", "propertyNames",
+	"unevaluatedProperties", "unevaluatedItems", "contentEncoding", "contentMediaType",
+}
+
+type (
+	// ToolDefinition is a tool definition converted to the internal representation of the tool parameters
+	ToolDefinition struct {
+		Name        string
+		Description string
+		Params      *openapi3.Schema
+	}
+
+	// openAIFunction is an OpenAI function definition, either wrapped as a Chat Completions tool
+	// ({"type": "function", "function": {...}}) or flat as a Responses tool
+	openAIFunction struct {
+		Type        string          `json:"type,omitempty"`
+		Function    *openAIFunction `json:"function,omitempty"`
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Parameters  map[string]any  `json:"parameters,omitempty"`
+	}
+
+	// mcpToolDescriptor is an MCP tool descriptor
+	mcpToolDescriptor struct {
+		Name        string         `json:"name"`
+		Title       string         `json:"title,omitempty"`
+		Description string         `json:"description,omitempty"`
+		InputSchema map[string]any `json:"inputSchema"`
+	}
+)
+
+// Valid reports whether the format is a known tool definition format
+func (f ToolDefinitionFormat) Valid() bool {
+	switch f {
+	case ToolDefinitionFormatOpenAI, ToolDefinitionFormatMCP:
+		return true
+	}
+	return false
+}
+
+// DetectToolDefinitionFormat returns the format of a tool definition from its fields,
+// an MCP descriptor has an inputSchema where an OpenAI function has parameters
+func DetectToolDefinitionFormat(raw JsonRaw) (ToolDefinitionFormat, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", fmt.Errorf("tool definition must be a JSON object: %w", err)
+	}
+	if _, ok := fields["inputSchema"]; ok {
+		return ToolDefinitionFormatMCP, nil
+	}
+	if _, ok := fields["function"]; ok {
+		return ToolDefinitionFormatOpenAI, nil
+	}
+	if _, ok := fields["parameters"]; ok {
+		return ToolDefinitionFormatOpenAI, nil
+	}
+	return "", fmt.Errorf("unknown tool definition format, expected an OpenAI function with parameters or an MCP tool with an inputSchema")
+}
+
+// ParseToolDefinition converts a tool definition to its name, description and parameter schema.
+// The format is detected when empty.
+func ParseToolDefinition(raw JsonRaw, format ToolDefinitionFormat) (ToolDefinition, error) {
+	if format == "" {
+		detected, err := DetectToolDefinitionFormat(raw)
+		if err != nil {
+			return ToolDefinition{}, err
+		}
+		format = detected
+	}
+
+	var def ToolDefinition
+	var schema map[string]any
+	switch format {
+	case ToolDefinitionFormatOpenAI:
+		var f openAIFunction
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return ToolDefinition{}, fmt.Errorf("invalid OpenAI function definition: %w", err)
+		}
+		if f.Function != nil {
+			if f.Type != "" && f.Type != "function" {
+				return ToolDefinition{}, fmt.Errorf("unsupported OpenAI tool type %q, only function tools can be imported", f.Type)
+			}
+			f = *f.Function
+		}
+		def.Name, def.Description, schema = f.Name, f.Description, f.Parameters
+	case ToolDefinitionFormatMCP:
+		var t mcpToolDescriptor
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return ToolDefinition{}, fmt.Errorf("invalid MCP tool descriptor: %w", err)
+		}
+		if t.InputSchema == nil {
+			return ToolDefinition{}, fmt.Errorf("inputSchema is required for an MCP tool")
+		}
+		def.Name, def.Description, schema = t.Name, t.Description, t.InputSchema
+		if def.Description == "" {
+			def.Description = t.Title
+		}
+	default:
+		return ToolDefinition{}, fmt.Errorf("unknown tool definition format %q, must be openai or mcp", format)
+	}
+	if def.Name == "" {
+		return ToolDefinition{}, fmt.Errorf("tool definition has no name")
+	}
+
+	params, err := ToolParamsFromJSONSchema(schema)
+	if err != nil {
+		return ToolDefinition{}, fmt.Errorf("invalid parameters of tool %s: %w", def.Name, err)
+	}
+	def.Params = params
+	return def, nil
+}
+
+// ToolParamsFromJSONSchema converts the JSON Schema of the parameters of a tool to an OpenAPI schema.
+// The local references are inlined, the nullable types are written with nullable and const with a single
+// value enum. A missing schema is a tool without parameters.
+func ToolParamsFromJSONSchema(schema map[string]any) (*openapi3.Schema, error) {
+	if schema == nil {
+		schema = map[string]any{}
+	}
+	defs := map[string]any{}
+	for _, key := range []string{"$defs", "definitions"} {
+		if d, ok := schema[key].(map[string]any); ok {
+			for name, s := range d {
+				defs["#/"+key+"/"+name] = s
+			}
+		}
+	}
+
+	budget := maxToolDefinitionSchemas
+	converted, err := convertJSONSchema(schema, defs, 0, &budget)
+	if err != nil {
+		return nil, err
+	}
+	root := converted.(map[string]any)
+	if _, ok := root["type"]; !ok {
+		root["type"] = "object"
+	}
+	if root["type"] != "object" {
+		return nil, fmt.Errorf("parameters must be an object schema, got %v", root["type"])
+	}
+	if _, ok := root["properties"]; !ok {
+		root["properties"] = map[string]any{}
+	}
+
+	data, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	params := &openapi3.Schema{}
+	if err := json.Unmarshal(data, params); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if err := params.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// convertJSONSchema rewrites a JSON Schema in the OpenAPI 3.0 dialect. Only the keywords holding schemas
+// are walked, so the values of enum, default or example and the property names are kept as is.
+// Each converted schema consumes the budget, the conversion fails once it is exhausted.
+func convertJSONSchema(v any, defs map[string]any, depth int, budget *int) (any, error) {
+	schema, ok := v.(map[string]any)
+	if !ok {
+		// true and false schemas
+		return v, nil
+	}
+	if *budget--; *budget < 0 {
+		return nil, fmt.Errorf("schema has more than %d schemas once its $ref are inlined", maxToolDefinitionSchemas)
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if depth >= maxToolDefinitionRefDepth {
+			return nil, fmt.Errorf("$ref %s nests too deep, recursive schemas are not supported", ref)
+		}
+		target, ok := defs[ref]
+		if !ok {
+			return nil, fmt.Errorf("unresolved $ref %s, only the local $defs and definitions can be referenced", ref)
+		}
+		return convertJSONSchema(target, defs, depth+1, budget)
+	}
+
+	out := make(map[string]any, len(schema))
+	for key, child := range schema {
+		var err error
+		switch key {
+		case "properties":
+			properties, ok := child.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("properties must be an object")
+			}
+			converted := make(map[string]any, len(properties))
+			for name, property := range properties {
+				if converted[name], err = convertJSONSchema(property, defs, depth, budget); err != nil {
+					return nil, fmt.Errorf("property %s: %w", name, err)
+				}
+			}
+			out[key] = converted
+		case "items", "additionalProperties", "not":
+			out[key], err = convertJSONSchema(child, defs, depth, budget)
+		case "allOf", "anyOf", "oneOf":
+			schemas, ok := child.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%s must be an array", key)
+			}
+			converted := make([]any, len(schemas))
+			for i, s := range schemas {
+				if converted[i], err = convertJSONSchema(s, defs, depth, budget); err != nil {
+					break
+				}
+			}
+			out[key] = converted
+		default:
+			out[key] = child
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range unsupportedSchemaKeywords {
+		delete(out, key)
+	}
+	if c, ok := out["const"]; ok {
+		out["enum"] = []any{c}
+		delete(out, "const")
+	}
+	if examples, ok := out["examples"].([]any); ok {
+		if len(examples) > 0 {
+			out["example"] = examples[0]
+		}
+		delete(out, "examples")
+	}
+	if types, ok := out["type"].([]any); ok {
+		nonNull := make([]any, 0, len(types))
+		for _, t := range types {
+			if t == "null" {
+				out["nullable"] = true
+				continue
+			}
+			nonNull = append(nonNull, t)
+		}
+		if len(nonNull) == 1 {
+			out["type"] = nonNull[0]
+		} else {
+			out["type"] = nonNull
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_ParseToolDefinition(t *testing.T) {
+	t.Parallel()
+
+	openAI := JsonRaw(`{
+		"type": "function",
+		"function": {
+			"name": "get_weather",
+			"description": "Current weather of a city",
+			"strict": true,
+			"parameters": {
+				"type": "object",
+				"properties": {
+					"city": {"type": "string", "examples": ["Paris"]},
+					"unit": {"type": ["string", "null"], "enum": ["celsius", "fahrenheit", null]},
+					"type": {"const": "current"},
+					"location": {"$ref": "#/$defs/location"}
+				},
+				"required": ["city", "unit", "type"],
+				"additionalProperties": false,
+				"$defs": {
+					"location": {"type": "object", "properties": {"lat": {"type": "number"}, "lon": {"type": "number"}}}
+				}
+			}
+		}
+	}`)
+	def, err := ParseToolDefinition(openAI, "")
+	if err != nil {
+		t.Fatalf("failed to parse OpenAI function: %v", err)
+	}
+	if def.Name != "get_weather" || def.Description != "Current weather of a city" {
+		t.Fatalf("unexpected name or description: %+v", def)
+	}
+	params := def.Params
+	if !params.Type.Is("object") || len(params.Required) != 3 {
+		t.Fatalf("expected an object schema with 3 required properties, got %+v", params)
+	}
+	if unit := params.Properties["unit"].Value; !unit.Type.Is("string") || !unit.Nullable {
+		t.Fatalf("expected unit to be a nullable string, got %+v", unit)
+	}
+	if kind := params.Properties["type"].Value; len(kind.Enum) != 1 || kind.Enum[0] != "current" {
+		t.Fatalf("expected const to be a single value enum, got %+v", kind.Enum)
+	}
+	if city := params.Properties["city"].Value; city.Example != "Paris" {
+		t.Fatalf("expected the first example to be kept, got %v", city.Example)
+	}
+	if location := params.Properties["location"].Value; location == nil || location.Properties["lat"] == nil {
+		t.Fatalf("expected the $ref to be inlined, got %+v", location)
+	}
+	data, _ := json.Marshal(params)
+	if violations := ValidateToolInput(params, map[string]any{"city": "Paris", "unit": nil, "type": "current"}); len(violations) != 0 {
+		t.Fatalf("expected a valid input for %s, got %+v", data, violations)
+	}
+	if violations := ValidateToolInput(params, map[string]any{"city": "Paris", "unit": "kelvin", "type": "current"}); len(violations) == 0 {
+		t.Fatalf("expected an invalid unit to be rejected by %s", data)
+	}
+
+	// Responses API function, without parameters
+	def, err = ParseToolDefinition(JsonRaw(`{"type": "function", "name": "ping", "parameters": {}}`), ToolDefinitionFormatOpenAI)
+	if err != nil {
+		t.Fatalf("failed to parse flat OpenAI function: %v", err)
+	}
+	if def.Name != "ping" || !def.Params.Type.Is("object") {
+		t.Fatalf("unexpected definition: %+v", def)
+	}
+
+	mcp := JsonRaw(`{"name": "search", "title": "Search the docs", "inputSchema": {"type": "object", "properties": {"query": {"type": "string"}}, "required": ["query"]}}`)
+	def, err = ParseToolDefinition(mcp, "")
+	if err != nil {
+		t.Fatalf("failed to parse MCP tool: %v", err)
+	}
+	if def.Name != "search" || def.Description != "Search the docs" || def.Params.Properties["query"] == nil {
+		t.Fatalf("unexpected definition: %+v", def)
+	}
+
+	invalid := map[string]JsonRaw{
+		"unknown format":  JsonRaw(`{"name": "x"}`),
+		"no name":         JsonRaw(`{"inputSchema": {"type": "object"}}`),
+		"not an object":   JsonRaw(`{"name": "x", "parameters": {"type": "string"}}`),
+		"remote ref":      JsonRaw(`{"name": "x", "parameters": {"properties": {"a": {"$ref": "https://example.com/a.json"}}}}`),
+		"recursive ref":   JsonRaw(`{"name": "x", "parameters": {"properties": {"a": {"$ref": "#/$defs/a"}}, "$defs": {"a": {"properties": {"b": {"$ref": "#/$defs/a"}}}}}}`),
+		"hosted tool":     JsonRaw(`{"type": "web_search", "function": {"name": "x"}}`),
+		"invalid pattern": JsonRaw(`{"name": "x", "parameters": {"properties": {"a": {"type": "string", "pattern": "("}}}}`),
+	}
+	for name, raw := range invalid {
+		if _, err := ParseToolDefinition(raw, ""); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func Test_ToolParamsFromJSONSchema_InlinedSize(t *testing.T) {
+	t.Parallel()
+
+	// Each definition references the previous one twice, inlining the root doubles the schemas at each level
+	defs := map[string]any{"d0": map[string]any{"type": "string"}}
+	for i := 1; i <= 20; i++ {
+		ref := map[string]any{"$ref": fmt.Sprintf("#/$defs/d%d", i-1)}
+		defs[fmt.Sprintf("d%d", i)] = map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"left": ref, "right": ref},
+		}
+	}
+	schema := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"root": map[string]any{"$ref": "#/$defs/d20"}},
+		"$defs":      defs,
+	}
+	_, err := ToolParamsFromJSONSchema(schema)
+	if err == nil || !strings.Contains(err.Error(), "once its $ref are inlined") {
+		t.Fatalf("expected the inlined schema to exceed the budget, got %v", err)
+	}
+
+	// The same references a few levels deep stay within the budget
+	schema["properties"] = map[string]any{"root": map[string]any{"$ref": "#/$defs/d5"}}
+	if _, err := ToolParamsFromJSONSchema(schema); err != nil {
+		t.Fatalf("failed to convert schema: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ToolInputViolation is a single violation of the parameter schema by a tool input
+type ToolInputViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ToolMock is the response returned instead of invoking the tool in dry runs
+type ToolMock struct {
+	Response any  `json:"response"`           // JSON result returned to the caller
+	IsError  bool `json:"is_error,omitempty"` // Return the response as an error tool result
+}
+
+// ValidateToolInput validates a tool input against the parameter schema of the tool.
+// It returns no violations when the tool has no parameter schema.
+func ValidateToolInput(schema *openapi3.Schema, input map[string]any) []ToolInputViolation {
+	if schema == nil {
+		return nil
+	}
+	// Tool inputs are decoded from JSON, so they already use the JSON value types the validator expects
+	err := schema.VisitJSON(input, openapi3.MultiErrors(), openapi3.VisitAsRequest())
+	if err == nil {
+		return nil
+	}
+	return collectToolInputViolations(err, nil)
+}
+
+// collectToolInputViolations flattens the nested schema errors returned by the validator
+func collectToolInputViolations(err error, violations []ToolInputViolation) []ToolInputViolation {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, child := range e {
+			violations = collectToolInputViolations(child, violations)
+		}
+	case *openapi3.SchemaError:
+		violations = append(violations, ToolInputViolation{
+			Path:    "/" + strings.Join(e.JSONPointer(), "/"),
+			Message: e.Reason,
+		})
+	default:
+		violations = append(violations, ToolInputViolation{Path: "/", Message: err.Error()})
+	}
+	return violations
+}
+
+// MockResponse returns the dry run response of the tool, a placeholder result when the tool has no mock configured
+func (t Tool) MockResponse() ToolMock {
+	if t.Config.Mock != nil {
+		return *t.Config.Mock
+	}
+	return ToolMock{Response: map[string]any{
+		"dry_run": true,
+		"message": "Dry run of tool " + t.Name + ", the tool was not invoked and has no mock response configured",
+	}}
+}
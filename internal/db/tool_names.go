@@ -0,0 +1,136 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ToolNameNamespaceSeparator separates a tool name from the namespace added when it collides with another tool
+	ToolNameNamespaceSeparator = "__"
+
+	// maxModelToolNameLength is the longest tool name accepted by every model provider
+	maxModelToolNameLength = 64
+	// toolNamespaceLength is the length of the tool ID prefix used as namespace
+	toolNamespaceLength = 8
+)
+
+// BuiltinToolIDs maps the names reserved for the built-in tools to the IDs of their seeded rows.
+// The tools service executes these tools itself, a new built-in tool adds its name here.
+var BuiltinToolIDs = map[string]uuid.UUID{
+	"batch_tool":       uuid.MustParse("550e8400-c00b-8888-2222-446655447896"),
+	"invoke_agent":     uuid.MustParse("550e8400-c00b-8888-3333-446655447896"),
+	"code_interpreter": uuid.MustParse("550e8400-c00b-8888-4444-446655447896"),
+	"web_search":       uuid.MustParse("550e8400-c00b-8888-4444-446655447897"),
+	"fetch_url":        uuid.MustParse("550e8400-c00b-8888-4444-446655447898"),
+}
+
+type (
+	// ToolNameCollision lists the tools of a model request sharing a name once sanitized, ignoring the case
+	ToolNameCollision struct {
+		Name  string
+		Tools []Tool
+	}
+)
+
+func (c ToolNameCollision) String() string {
+	names := make([]string, len(c.Tools))
+	for i, tool := range c.Tools {
+		names[i] = fmt.Sprintf("%s (%s)", tool.Name, tool.ID)
+	}
+	return fmt.Sprintf("%s: %s", c.Name, strings.Join(names, ", "))
+}
+
+// IsReservedToolName reports whether a name is reserved for a built-in tool, ignoring the case
+func IsReservedToolName(name string) bool {
+	_, ok := BuiltinToolIDs[strings.ToLower(ModelToolName(name))]
+	return ok
+}
+
+// ValidateToolName checks the name of a new tool, it cannot shadow a built-in tool or look like a namespaced name
+func ValidateToolName(name string) error {
+	if IsReservedToolName(name) {
+		return fmt.Errorf("tool name %q is reserved for a built-in tool", name)
+	}
+	if strings.Contains(name, ToolNameNamespaceSeparator) {
+		return fmt.Errorf("tool name %q cannot contain %q, reserved to namespace the colliding tool names", name, ToolNameNamespaceSeparator)
+	}
+	return nil
+}
+
+// BuiltinName returns the name of a built-in tool, empty for the other tools, including the tools named after a built-in one
+func (t Tool) BuiltinName() string {
+	if id, ok := BuiltinToolIDs[t.Name]; ok && id == t.ID && t.Config.Type == ToolTypeInternal {
+		return t.Name
+	}
+	return ""
+}
+
+// ModelToolName returns a name accepted by every model provider, replacing the characters outside [a-zA-Z0-9_-]
+// with underscores and truncating it to 64 characters
+func ModelToolName(name string) string {
+	sanitized := []byte(name)
+	for i, c := range sanitized {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			sanitized[i] = '_'
+		}
+	}
+	if len(sanitized) > maxModelToolNameLength {
+		sanitized = sanitized[:maxModelToolNameLength]
+	}
+	return string(sanitized)
+}
+
+// namespacedToolName returns the name of a colliding tool, followed by the beginning of its ID
+func namespacedToolName(tool Tool) string {
+	namespace := strings.ReplaceAll(tool.ID.String(), "-", "")[:toolNamespaceLength]
+	base := ModelToolName(tool.Name)
+	if limit := maxModelToolNameLength - len(ToolNameNamespaceSeparator) - toolNamespaceLength; len(base) > limit {
+		base = base[:limit]
+	}
+	return base + ToolNameNamespaceSeparator + namespace
+}
+
+// ModelToolNames returns the name given to the model for each tool, by tool ID, with the name collisions found.
+// Tools sharing a name once sanitized, ignoring the case, are namespaced with the beginning of their ID,
+// e.g. search__1b4e28ba. A built-in tool keeps its name, the tools colliding with it are namespaced.
+func ModelToolNames(tools []Tool) (map[uuid.UUID]string, []ToolNameCollision) {
+	groups := make(map[string][]Tool)
+	seen := make(map[uuid.UUID]bool, len(tools))
+	for _, tool := range tools {
+		if seen[tool.ID] {
+			continue
+		}
+		seen[tool.ID] = true
+		key := strings.ToLower(ModelToolName(tool.Name))
+		groups[key] = append(groups[key], tool)
+	}
+
+	names := make(map[uuid.UUID]string, len(tools))
+	collisions := []ToolNameCollision{}
+	for key, group := range groups {
+		if len(group) == 1 {
+			names[group[0].ID] = ModelToolName(group[0].Name)
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].ID.String() < group[j].ID.String() })
+		collisions = append(collisions, ToolNameCollision{Name: key, Tools: group})
+		for _, tool := range group {
+			if tool.BuiltinName() != "" {
+				names[tool.ID] = tool.Name
+				continue
+			}
+			names[tool.ID] = namespacedToolName(tool)
+		}
+	}
+	sort.Slice(collisions, func(i, j int) bool { return collisions[i].Name < collisions[j].Name })
+	return names, collisions
+}
+
+// MatchesModelToolName reports whether the model called the tool by name, its sanitized name, or its namespaced name
+func (t Tool) MatchesModelToolName(name string) bool {
+	return t.Name == name || ModelToolName(t.Name) == name || namespacedToolName(t) == name
+}
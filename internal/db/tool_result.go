@@ -0,0 +1,22 @@
+package db
+
+import "encoding/json"
+
+// OffloadedToolResult replaces in the tool runs a result too large for the database, uploaded to object storage
+type OffloadedToolResult struct {
+	Offloaded bool   `json:"offloaded"` // Always true, tells the reference apart from the results
+	URL       string `json:"url"`       // s3://<bucket>/<key>
+	Bucket    string `json:"bucket"`
+	Key       string `json:"key"`
+	SizeBytes int    `json:"size_bytes"`
+}
+
+// ParseOffloadedToolResult returns the reference stored in place of an offloaded tool result,
+// it returns false for the results stored in the database
+func ParseOffloadedToolResult(result JsonRaw) (*OffloadedToolResult, bool) {
+	var ref OffloadedToolResult
+	if err := json.Unmarshal(result, &ref); err != nil || !ref.Offloaded || ref.Bucket == "" || ref.Key == "" {
+		return nil, false
+	}
+	return &ref, true
+}
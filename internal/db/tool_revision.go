@@ -0,0 +1,162 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+type ToolSchemaChangeKind string
+
+const (
+	ToolSchemaChangeKindAdded              ToolSchemaChangeKind = "added"
+	ToolSchemaChangeKindRemoved            ToolSchemaChangeKind = "removed"
+	ToolSchemaChangeKindTypeChanged        ToolSchemaChangeKind = "type_changed"
+	ToolSchemaChangeKindRequiredAdded      ToolSchemaChangeKind = "required_added"
+	ToolSchemaChangeKindRequiredRemoved    ToolSchemaChangeKind = "required_removed"
+	ToolSchemaChangeKindEnumChanged        ToolSchemaChangeKind = "enum_changed"
+	ToolSchemaChangeKindDescriptionChanged ToolSchemaChangeKind = "description_changed"
+	ToolSchemaChangeKindNil                ToolSchemaChangeKind = ""
+)
+
+// ToolSchemaChange is a difference between the parameter schemas of two tool revisions
+type ToolSchemaChange struct {
+	Path     string               `json:"path"` // JSON pointer of the changed schema, empty for the root
+	Kind     ToolSchemaChangeKind `json:"kind"`
+	OldValue any                  `json:"old_value,omitempty"`
+	NewValue any                  `json:"new_value,omitempty"`
+	Breaking bool                 `json:"breaking"` // Inputs valid for the old schema may be rejected by the new one
+}
+
+// GetParams returns the parameter schema of the tool, nil for tools without one such as the MCP tools
+// whose schema was not discovered yet
+func (t *ToolConfig) GetParams() *openapi3.Schema {
+	switch c := t.C.(type) {
+	case *ToolConfigStandalone:
+		return c.Params
+	case *ToolConfigWorkflow:
+		return c.Params
+	case *ToolConfigInternal:
+		return c.Params
+	case *ToolConfigMCP:
+		return c.Params
+	default:
+		return nil
+	}
+}
+
+// ToolRevisionChanged reports whether the description or configuration of a tool differ between two states
+func ToolRevisionChanged(before, after Tool) bool {
+	if before.Description != after.Description {
+		return true
+	}
+	// The JSON form redacts the API key, so a rotated key is compared separately
+	if !reflect.DeepEqual(before.Config.APIKey(), after.Config.APIKey()) {
+		return true
+	}
+	beforeConfig, errBefore := json.Marshal(before.Config)
+	afterConfig, errAfter := json.Marshal(after.Config)
+	return errBefore != nil || errAfter != nil || !bytes.Equal(beforeConfig, afterConfig)
+}
+
+// ApplyRevision returns the tool with the description and configuration of the given revision
+func (t Tool) ApplyRevision(r ToolRevision) Tool {
+	t.Description = r.Description
+	t.Config = r.Config
+	t.Revision = r.Revision
+	return t
+}
+
+// DiffToolSchemas lists the changes between two tool parameter schemas, walking the properties in name order
+func DiffToolSchemas(from, to *openapi3.Schema) []ToolSchemaChange {
+	changes := []ToolSchemaChange{}
+	diffToolSchema("", from, to, &changes)
+	return changes
+}
+
+func diffToolSchema(path string, from, to *openapi3.Schema, changes *[]ToolSchemaChange) {
+	if from == nil && to == nil {
+		return
+	}
+	if from == nil {
+		*changes = append(*changes, ToolSchemaChange{Path: path, Kind: ToolSchemaChangeKindAdded, NewValue: to})
+		return
+	}
+	if to == nil {
+		*changes = append(*changes, ToolSchemaChange{Path: path, Kind: ToolSchemaChangeKindRemoved, OldValue: from, Breaking: true})
+		return
+	}
+
+	fromTypes, toTypes := schemaTypes(from), schemaTypes(to)
+	if !slices.Equal(fromTypes, toTypes) {
+		// Widening the accepted types keeps the previous inputs valid
+		breaking := len(toTypes) > 0 && slices.ContainsFunc(fromTypes, func(t string) bool { return !slices.Contains(toTypes, t) })
+		*changes = append(*changes, ToolSchemaChange{Path: path, Kind: ToolSchemaChangeKindTypeChanged, OldValue: fromTypes, NewValue: toTypes, Breaking: breaking})
+	}
+	if from.Description != to.Description {
+		*changes = append(*changes, ToolSchemaChange{Path: path, Kind: ToolSchemaChangeKindDescriptionChanged, OldValue: from.Description, NewValue: to.Description})
+	}
+	if !reflect.DeepEqual(from.Enum, to.Enum) {
+		// Removing a value rejects the inputs using it, an enum on a free field restricts it
+		breaking := len(to.Enum) > 0 && (len(from.Enum) == 0 || slices.ContainsFunc(from.Enum, func(v any) bool {
+			return !slices.ContainsFunc(to.Enum, func(w any) bool { return reflect.DeepEqual(v, w) })
+		}))
+		*changes = append(*changes, ToolSchemaChange{Path: path, Kind: ToolSchemaChangeKindEnumChanged, OldValue: from.Enum, NewValue: to.Enum, Breaking: breaking})
+	}
+
+	for _, name := range to.Required {
+		if !slices.Contains(from.Required, name) {
+			*changes = append(*changes, ToolSchemaChange{Path: joinSchemaPath(path, "properties", name), Kind: ToolSchemaChangeKindRequiredAdded, Breaking: true})
+		}
+	}
+	for _, name := range from.Required {
+		if !slices.Contains(to.Required, name) {
+			*changes = append(*changes, ToolSchemaChange{Path: joinSchemaPath(path, "properties", name), Kind: ToolSchemaChangeKindRequiredRemoved})
+		}
+	}
+
+	names := make([]string, 0, len(from.Properties)+len(to.Properties))
+	for name := range from.Properties {
+		names = append(names, name)
+	}
+	for name := range to.Properties {
+		if _, ok := from.Properties[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		diffToolSchema(joinSchemaPath(path, "properties", name), schemaRefValue(from.Properties[name]), schemaRefValue(to.Properties[name]), changes)
+	}
+	diffToolSchema(joinSchemaPath(path, "items"), schemaRefValue(from.Items), schemaRefValue(to.Items), changes)
+}
+
+// schemaTypes returns the sorted types accepted by a schema
+func schemaTypes(s *openapi3.Schema) []string {
+	if s.Type == nil {
+		return nil
+	}
+	types := slices.Clone(s.Type.Slice())
+	slices.Sort(types)
+	return types
+}
+
+func schemaRefValue(ref *openapi3.SchemaRef) *openapi3.Schema {
+	if ref == nil {
+		return nil
+	}
+	return ref.Value
+}
+
+// joinSchemaPath appends escaped JSON pointer tokens to a path
+func joinSchemaPath(path string, tokens ...string) string {
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, token := range tokens {
+		path += "/" + escaper.Replace(token)
+	}
+	return path
+}
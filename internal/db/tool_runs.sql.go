@@ -12,12 +12,46 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelThreadToolRuns = `-- name: CancelThreadToolRuns :many
+UPDATE tool_runs
+SET status = 'CANCELLED', result = $1, updated_at = NOW()
+WHERE thread_id = $2 AND status IN ('PENDING', 'RUNNING') AND created_at >= $3
+RETURNING id
+`
+
+type CancelThreadToolRunsParams struct {
+	Result   JsonRaw            `db:"result" json:"result"`
+	ThreadID uuid.UUID          `db:"thread_id" json:"thread_id"`
+	Since    pgtype.Timestamptz `db:"since" json:"since"`
+}
+
+// Cancels the tool runs of a thread still in flight that started since a time, their late results are dropped
+func (q *Queries) CancelThreadToolRuns(ctx context.Context, arg CancelThreadToolRunsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, cancelThreadToolRuns, arg.Result, arg.ThreadID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const checkIfAllChildToolRunStatusAreCompleted = `-- name: CheckIfAllChildToolRunStatusAreCompleted :one
 SELECT NOT EXISTS (
   SELECT 1
   FROM tool_runs
   WHERE parent_run_id = $1
-    AND status NOT IN ('SUCCESS', 'FAILED')
+    AND status NOT IN ('SUCCESS', 'FAILED', 'CANCELLED')
 ) AS all_completed
 `
 
@@ -30,8 +64,13 @@ func (q *Queries) CheckIfAllChildToolRunStatusAreCompleted(ctx context.Context,
 
 const createChildToolRunStatus = `-- name: CreateChildToolRunStatus :one
 INSERT INTO tool_runs (connection_id, thread_id, agent_id, recipient_id, id, tool_id, input, parent_run_id)
-VALUES ($1, $2, $3, $4, $5, (SELECT id FROM tools WHERE name = $6), $7, $8)
-RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, (
+    SELECT id FROM tools
+    WHERE name = $6 AND (workspace_id = $9 OR workspace_id IS NULL) AND deleted_at IS NULL
+    ORDER BY workspace_id NULLS LAST
+    LIMIT 1
+), $7, $8)
+RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits
 `
 
 type CreateChildToolRunStatusParams struct {
@@ -43,8 +82,10 @@ type CreateChildToolRunStatusParams struct {
 	Name         string      `db:"name" json:"name"`
 	Input        JsonRaw     `db:"input" json:"input"`
 	ParentRunID  pgtype.Text `db:"parent_run_id" json:"parent_run_id"`
+	WorkspaceID  pgtype.UUID `db:"workspace_id" json:"workspace_id"`
 }
 
+// The tool is looked up by name in the workspace of the run, then in the shared tools
 func (q *Queries) CreateChildToolRunStatus(ctx context.Context, arg CreateChildToolRunStatusParams) (ToolRun, error) {
 	row := q.db.QueryRow(ctx, createChildToolRunStatus,
 		arg.ConnectionID,
@@ -55,6 +96,7 @@ func (q *Queries) CreateChildToolRunStatus(ctx context.Context, arg CreateChildT
 		arg.Name,
 		arg.Input,
 		arg.ParentRunID,
+		arg.WorkspaceID,
 	)
 	var i ToolRun
 	err := row.Scan(
@@ -71,26 +113,36 @@ func (q *Queries) CreateChildToolRunStatus(ctx context.Context, arg CreateChildT
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
 
 const createToolRunStatus = `-- name: CreateToolRunStatus :one
 INSERT INTO tool_runs (connection_id, thread_id, agent_id, recipient_id, id, tool_id, input)
-VALUES ($1, $2, $3, $4, $5, (SELECT id FROM tools WHERE name = $6), $7)
-RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, (
+    SELECT id FROM tools
+    WHERE name = $6 AND (workspace_id = $8 OR workspace_id IS NULL) AND deleted_at IS NULL
+    ORDER BY workspace_id NULLS LAST
+    LIMIT 1
+), $7)
+RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits
 `
 
 type CreateToolRunStatusParams struct {
-	ConnectionID uuid.UUID `db:"connection_id" json:"connection_id"`
-	ThreadID     uuid.UUID `db:"thread_id" json:"thread_id"`
-	AgentID      uuid.UUID `db:"agent_id" json:"agent_id"`
-	RecipientID  uuid.UUID `db:"recipient_id" json:"recipient_id"`
-	ID           string    `db:"id" json:"id"`
-	Name         string    `db:"name" json:"name"`
-	Input        JsonRaw   `db:"input" json:"input"`
+	ConnectionID uuid.UUID   `db:"connection_id" json:"connection_id"`
+	ThreadID     uuid.UUID   `db:"thread_id" json:"thread_id"`
+	AgentID      uuid.UUID   `db:"agent_id" json:"agent_id"`
+	RecipientID  uuid.UUID   `db:"recipient_id" json:"recipient_id"`
+	ID           string      `db:"id" json:"id"`
+	Name         string      `db:"name" json:"name"`
+	Input        JsonRaw     `db:"input" json:"input"`
+	WorkspaceID  pgtype.UUID `db:"workspace_id" json:"workspace_id"`
 }
 
+// The tool is looked up by name in the workspace of the run, then in the shared tools
 func (q *Queries) CreateToolRunStatus(ctx context.Context, arg CreateToolRunStatusParams) (ToolRun, error) {
 	row := q.db.QueryRow(ctx, createToolRunStatus,
 		arg.ConnectionID,
@@ -100,6 +152,7 @@ func (q *Queries) CreateToolRunStatus(ctx context.Context, arg CreateToolRunStat
 		arg.ID,
 		arg.Name,
 		arg.Input,
+		arg.WorkspaceID,
 	)
 	var i ToolRun
 	err := row.Scan(
@@ -116,6 +169,9 @@ func (q *Queries) CreateToolRunStatus(ctx context.Context, arg CreateToolRunStat
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
@@ -129,8 +185,39 @@ func (q *Queries) DeleteToolRunStatusByID(ctx context.Context, id string) error
 	return err
 }
 
+const getCachedToolRun = `-- name: GetCachedToolRun :one
+SELECT id, result FROM tool_runs
+WHERE tool_id = $1
+  AND input_hash = $2
+  AND status = 'SUCCESS'
+  AND cached_from_run_id IS NULL
+  AND updated_at > NOW() - make_interval(secs => $3::INTEGER)
+ORDER BY updated_at DESC
+LIMIT 1
+`
+
+type GetCachedToolRunParams struct {
+	ToolID     uuid.UUID   `db:"tool_id" json:"tool_id"`
+	InputHash  pgtype.Text `db:"input_hash" json:"input_hash"`
+	TtlSeconds int32       `db:"ttl_seconds" json:"ttl_seconds"`
+}
+
+type GetCachedToolRunRow struct {
+	ID     string  `db:"id" json:"id"`
+	Result JsonRaw `db:"result" json:"result"`
+}
+
+// Latest successful run of the tool with the same input hash within the cache TTL, cache hits excluded. The hash covers
+// the revision of the tool, the workspace and the user with the input, so the key and its index scope the results
+func (q *Queries) GetCachedToolRun(ctx context.Context, arg GetCachedToolRunParams) (GetCachedToolRunRow, error) {
+	row := q.db.QueryRow(ctx, getCachedToolRun, arg.ToolID, arg.InputHash, arg.TtlSeconds)
+	var i GetCachedToolRunRow
+	err := row.Scan(&i.ID, &i.Result)
+	return i, err
+}
+
 const getChildToolRunStatusByID = `-- name: GetChildToolRunStatusByID :one
-SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at FROM tool_runs WHERE id = $1 AND parent_run_id IS NOT NULL LIMIT 1
+SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits FROM tool_runs WHERE id = $1 AND parent_run_id IS NOT NULL LIMIT 1
 `
 
 func (q *Queries) GetChildToolRunStatusByID(ctx context.Context, id string) (ToolRun, error) {
@@ -150,12 +237,15 @@ func (q *Queries) GetChildToolRunStatusByID(ctx context.Context, id string) (Too
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
 
 const getChildToolRunStatusByParentID = `-- name: GetChildToolRunStatusByParentID :many
-SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at FROM tool_runs WHERE parent_run_id = $1 ORDER BY CASE WHEN id::text ~ '_[0-9]+$' THEN CAST(SUBSTRING(id::text FROM '_([0-9]+)$') AS INTEGER) ELSE 0 END
+SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits FROM tool_runs WHERE parent_run_id = $1 ORDER BY CASE WHEN id::text ~ '_[0-9]+$' THEN CAST(SUBSTRING(id::text FROM '_([0-9]+)$') AS INTEGER) ELSE 0 END
 `
 
 func (q *Queries) GetChildToolRunStatusByParentID(ctx context.Context, parentRunID pgtype.Text) ([]ToolRun, error) {
@@ -181,6 +271,9 @@ func (q *Queries) GetChildToolRunStatusByParentID(ctx context.Context, parentRun
 			&i.ParentRunID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.InputHash,
+			&i.CachedFromRunID,
+			&i.CacheHits,
 		); err != nil {
 			return nil, err
 		}
@@ -231,7 +324,7 @@ func (q *Queries) GetToolExecutionCount(ctx context.Context, toolID uuid.UUID) (
 }
 
 const getToolRunStatus = `-- name: GetToolRunStatus :many
-SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at FROM tool_runs
+SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits FROM tool_runs
 `
 
 func (q *Queries) GetToolRunStatus(ctx context.Context) ([]ToolRun, error) {
@@ -257,6 +350,9 @@ func (q *Queries) GetToolRunStatus(ctx context.Context) ([]ToolRun, error) {
 			&i.ParentRunID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.InputHash,
+			&i.CachedFromRunID,
+			&i.CacheHits,
 		); err != nil {
 			return nil, err
 		}
@@ -269,7 +365,7 @@ func (q *Queries) GetToolRunStatus(ctx context.Context) ([]ToolRun, error) {
 }
 
 const getToolRunStatusByID = `-- name: GetToolRunStatusByID :one
-SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at FROM tool_runs WHERE id = $1 LIMIT 1
+SELECT id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits FROM tool_runs WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetToolRunStatusByID(ctx context.Context, id string) (ToolRun, error) {
@@ -289,6 +385,9 @@ func (q *Queries) GetToolRunStatusByID(ctx context.Context, id string) (ToolRun,
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
@@ -310,11 +409,99 @@ func (q *Queries) IsTempParallelToolManagement(ctx context.Context, id string) (
 	return is_temp_parallel_tool, err
 }
 
+const listThreadToolRuns = `-- name: ListThreadToolRuns :many
+SELECT tr.id, tr.tool_id, t.name AS tool_name, tr.agent_id, tr.recipient_id, tr.parent_run_id, tr.status, tr.input, tr.result,
+       tr.duration, tr.created_at
+FROM tool_runs tr
+LEFT JOIN tools t ON t.id = tr.tool_id
+WHERE tr.thread_id = $1
+ORDER BY tr.created_at, tr.id
+`
+
+type ListThreadToolRunsRow struct {
+	ID          string             `db:"id" json:"id"`
+	ToolID      uuid.UUID          `db:"tool_id" json:"tool_id"`
+	ToolName    pgtype.Text        `db:"tool_name" json:"tool_name"`
+	AgentID     uuid.UUID          `db:"agent_id" json:"agent_id"`
+	RecipientID uuid.UUID          `db:"recipient_id" json:"recipient_id"`
+	ParentRunID pgtype.Text        `db:"parent_run_id" json:"parent_run_id"`
+	Status      ToolRunStatus      `db:"status" json:"status"`
+	Input       JsonRaw            `db:"input" json:"input"`
+	Result      JsonRaw            `db:"result" json:"result"`
+	Duration    pgtype.Float8      `db:"duration" json:"duration"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+// Tool runs of a thread in the order they were requested, with the name of their tool
+func (q *Queries) ListThreadToolRuns(ctx context.Context, threadID uuid.UUID) ([]ListThreadToolRunsRow, error) {
+	rows, err := q.db.Query(ctx, listThreadToolRuns, threadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListThreadToolRunsRow{}
+	for rows.Next() {
+		var i ListThreadToolRunsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ToolID,
+			&i.ToolName,
+			&i.AgentID,
+			&i.RecipientID,
+			&i.ParentRunID,
+			&i.Status,
+			&i.Input,
+			&i.Result,
+			&i.Duration,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordToolRunCacheHit = `-- name: RecordToolRunCacheHit :exec
+WITH cached AS (
+    UPDATE tool_runs SET cache_hits = cache_hits + 1 WHERE tool_runs.id = $1
+)
+UPDATE tool_runs SET input_hash = $2, cached_from_run_id = $1 WHERE tool_runs.id = $3
+`
+
+type RecordToolRunCacheHitParams struct {
+	CachedFromRunID pgtype.Text `db:"cached_from_run_id" json:"cached_from_run_id"`
+	InputHash       pgtype.Text `db:"input_hash" json:"input_hash"`
+	ID              string      `db:"id" json:"id"`
+}
+
+func (q *Queries) RecordToolRunCacheHit(ctx context.Context, arg RecordToolRunCacheHitParams) error {
+	_, err := q.db.Exec(ctx, recordToolRunCacheHit, arg.CachedFromRunID, arg.InputHash, arg.ID)
+	return err
+}
+
+const setToolRunInputHash = `-- name: SetToolRunInputHash :exec
+UPDATE tool_runs SET input_hash = $1 WHERE id = $2
+`
+
+type SetToolRunInputHashParams struct {
+	InputHash pgtype.Text `db:"input_hash" json:"input_hash"`
+	ID        string      `db:"id" json:"id"`
+}
+
+func (q *Queries) SetToolRunInputHash(ctx context.Context, arg SetToolRunInputHashParams) error {
+	_, err := q.db.Exec(ctx, setToolRunInputHash, arg.InputHash, arg.ID)
+	return err
+}
+
 const updateToolRunStatusByID = `-- name: UpdateToolRunStatusByID :one
 UPDATE tool_runs
 SET result = $1, status = $2, duration = $3
 WHERE id = $4
-RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at
+RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits
 `
 
 type UpdateToolRunStatusByIDParams struct {
@@ -346,6 +533,9 @@ func (q *Queries) UpdateToolRunStatusByID(ctx context.Context, arg UpdateToolRun
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
@@ -354,7 +544,7 @@ const updateToolRunStatusToFailedByID = `-- name: UpdateToolRunStatusToFailedByI
 UPDATE tool_runs
 SET status = 'FAILED', duration = $1
 WHERE id = $2
-RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at
+RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits
 `
 
 type UpdateToolRunStatusToFailedByIDParams struct {
@@ -379,6 +569,9 @@ func (q *Queries) UpdateToolRunStatusToFailedByID(ctx context.Context, arg Updat
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
@@ -387,7 +580,7 @@ const updateToolRunStatusToRunningByID = `-- name: UpdateToolRunStatusToRunningB
 UPDATE tool_runs
 SET status = 'RUNNING'
 WHERE id = $1
-RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at
+RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits
 `
 
 func (q *Queries) UpdateToolRunStatusToRunningByID(ctx context.Context, id string) (ToolRun, error) {
@@ -407,6 +600,9 @@ func (q *Queries) UpdateToolRunStatusToRunningByID(ctx context.Context, id strin
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
@@ -415,7 +611,7 @@ const updateToolRunStatusToSuccessByID = `-- name: UpdateToolRunStatusToSuccessB
 UPDATE tool_runs
 SET status = 'SUCCESS', duration = $1
 WHERE id = $2
-RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at
+RETURNING id, tool_id, connection_id, thread_id, agent_id, recipient_id, input, result, status, duration, parent_run_id, created_at, updated_at, input_hash, cached_from_run_id, cache_hits
 `
 
 type UpdateToolRunStatusToSuccessByIDParams struct {
@@ -440,6 +636,9 @@ func (q *Queries) UpdateToolRunStatusToSuccessByID(ctx context.Context, arg Upda
 		&i.ParentRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputHash,
+		&i.CachedFromRunID,
+		&i.CacheHits,
 	)
 	return i, err
 }
@@ -12,22 +12,91 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countSearchTools = `-- name: CountSearchTools :one
+SELECT COUNT(*)
+FROM tools t
+WHERE (t.workspace_id = $1::uuid OR t.workspace_id IS NULL)
+  AND ($2::text IS NULL OR t.category = $2::text)
+  AND ($3::text IS NULL OR $3::text = ANY(t.tags))
+  AND ($4::text IS NULL
+       OR strpos(lower(t.name), lower($4::text)) > 0
+       OR strpos(lower(t.description), lower($4::text)) > 0)
+  AND ($5::text IS NULL OR t.config->>'type' = $5::text)
+  AND ($6::text IS NULL OR t.status = $6::text)
+  AND ($7::uuid IS NULL OR t.created_by = $7::uuid)
+  AND ($8::timestamptz IS NULL OR t.created_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR t.created_at < $9::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = $10::boolean
+  AND (t.created_by = $11::uuid
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'tool' AND g.resource_id = t.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'tool' AND g.resource_id = t.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = $11::uuid
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $11::uuid))))
+`
+
+type CountSearchToolsParams struct {
+	WorkspaceID   uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	Category      pgtype.Text        `db:"category" json:"category"`
+	Tag           pgtype.Text        `db:"tag" json:"tag"`
+	Search        pgtype.Text        `db:"search" json:"search"`
+	Type          pgtype.Text        `db:"type" json:"type"`
+	Status        pgtype.Text        `db:"status" json:"status"`
+	CreatedBy     pgtype.UUID        `db:"created_by" json:"created_by"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+	Deleted       bool               `db:"deleted" json:"deleted"`
+	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
+}
+
+// Counts the tools matching the filters of SearchTools
+func (q *Queries) CountSearchTools(ctx context.Context, arg CountSearchToolsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSearchTools,
+		arg.WorkspaceID,
+		arg.Category,
+		arg.Tag,
+		arg.Search,
+		arg.Type,
+		arg.Status,
+		arg.CreatedBy,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Deleted,
+		arg.UserID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTool = `-- name: CreateTool :one
 INSERT INTO tools (
     name,
     description, 
     config,
-    created_by
+    created_by,
+    category,
+    icon,
+    tags,
+    examples,
+    documentation,
+    workspace_id
 ) VALUES (
-    $1, $2, $3, $4
-) RETURNING id, name, description, config, created_at, created_by, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
 `
 
 type CreateToolParams struct {
-	Name        string      `db:"name" json:"name"`
-	Description pgtype.Text `db:"description" json:"description"`
-	Config      ToolConfig  `db:"config" json:"config"`
-	CreatedBy   uuid.UUID   `db:"created_by" json:"created_by"`
+	Name          string      `db:"name" json:"name"`
+	Description   pgtype.Text `db:"description" json:"description"`
+	Config        ToolConfig  `db:"config" json:"config"`
+	CreatedBy     uuid.UUID   `db:"created_by" json:"created_by"`
+	Category      pgtype.Text `db:"category" json:"category"`
+	Icon          pgtype.Text `db:"icon" json:"icon"`
+	Tags          []string    `db:"tags" json:"tags"`
+	Examples      JsonRaw     `db:"examples" json:"examples"`
+	Documentation pgtype.Text `db:"documentation" json:"documentation"`
+	WorkspaceID   pgtype.UUID `db:"workspace_id" json:"workspace_id"`
 }
 
 func (q *Queries) CreateTool(ctx context.Context, arg CreateToolParams) (Tool, error) {
@@ -36,6 +105,12 @@ func (q *Queries) CreateTool(ctx context.Context, arg CreateToolParams) (Tool, e
 		arg.Description,
 		arg.Config,
 		arg.CreatedBy,
+		arg.Category,
+		arg.Icon,
+		arg.Tags,
+		arg.Examples,
+		arg.Documentation,
+		arg.WorkspaceID,
 	)
 	var i Tool
 	err := row.Scan(
@@ -46,6 +121,17 @@ func (q *Queries) CreateTool(ctx context.Context, arg CreateToolParams) (Tool, e
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.Category,
+		&i.Icon,
+		&i.Tags,
+		&i.Examples,
+		&i.Documentation,
+		&i.Revision,
+		&i.Status,
+		&i.StatusMessage,
+		&i.StatusCheckedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
@@ -60,13 +146,21 @@ func (q *Queries) DeleteTool(ctx context.Context, id uuid.UUID) error {
 }
 
 const getToolById = `-- name: GetToolById :one
-SELECT id, name, description, config, created_at, created_by, updated_at
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
 FROM tools
 WHERE id = $1
+  AND (workspace_id = $2::uuid OR workspace_id IS NULL)
+  AND deleted_at IS NULL
 `
 
-func (q *Queries) GetToolById(ctx context.Context, id uuid.UUID) (Tool, error) {
-	row := q.db.QueryRow(ctx, getToolById, id)
+type GetToolByIdParams struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+}
+
+// Gets a tool of a workspace or a shared tool
+func (q *Queries) GetToolById(ctx context.Context, arg GetToolByIdParams) (Tool, error) {
+	row := q.db.QueryRow(ctx, getToolById, arg.ID, arg.WorkspaceID)
 	var i Tool
 	err := row.Scan(
 		&i.ID,
@@ -76,16 +170,36 @@ func (q *Queries) GetToolById(ctx context.Context, id uuid.UUID) (Tool, error) {
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.Category,
+		&i.Icon,
+		&i.Tags,
+		&i.Examples,
+		&i.Documentation,
+		&i.Revision,
+		&i.Status,
+		&i.StatusMessage,
+		&i.StatusCheckedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
 
 const getToolInfoByName = `-- name: GetToolInfoByName :one
-SELECT id, name, description, config, created_at, created_by, updated_at FROM tools WHERE name = $1
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id FROM tools
+WHERE name = $1 AND (workspace_id = $2 OR workspace_id IS NULL) AND deleted_at IS NULL
+ORDER BY workspace_id NULLS LAST
+LIMIT 1
 `
 
-func (q *Queries) GetToolInfoByName(ctx context.Context, name string) (Tool, error) {
-	row := q.db.QueryRow(ctx, getToolInfoByName, name)
+type GetToolInfoByNameParams struct {
+	Name        string      `db:"name" json:"name"`
+	WorkspaceID pgtype.UUID `db:"workspace_id" json:"workspace_id"`
+}
+
+// Gets a tool of a workspace by name, or the shared tool of the name. A null workspace only gets the shared tools.
+func (q *Queries) GetToolInfoByName(ctx context.Context, arg GetToolInfoByNameParams) (Tool, error) {
+	row := q.db.QueryRow(ctx, getToolInfoByName, arg.Name, arg.WorkspaceID)
 	var i Tool
 	err := row.Scan(
 		&i.ID,
@@ -95,18 +209,97 @@ func (q *Queries) GetToolInfoByName(ctx context.Context, name string) (Tool, err
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.Category,
+		&i.Icon,
+		&i.Tags,
+		&i.Examples,
+		&i.Documentation,
+		&i.Revision,
+		&i.Status,
+		&i.StatusMessage,
+		&i.StatusCheckedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
 
+const getToolOwner = `-- name: GetToolOwner :one
+SELECT created_by FROM tools WHERE workspace_id = $1::uuid AND id = $2
+`
+
+type GetToolOwnerParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Returns the creator of a tool of a workspace, live or in the trash
+func (q *Queries) GetToolOwner(ctx context.Context, arg GetToolOwnerParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getToolOwner, arg.WorkspaceID, arg.ID)
+	var created_by uuid.UUID
+	err := row.Scan(&created_by)
+	return created_by, err
+}
+
 const getToolsByIDs = `-- name: GetToolsByIDs :many
-SELECT id, name, description, config, created_at, created_by, updated_at FROM tools 
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id FROM tools
 WHERE id = ANY($1::uuid[])
+  AND (workspace_id = $2::uuid OR workspace_id IS NULL)
+  AND deleted_at IS NULL
 ORDER BY name
 `
 
-func (q *Queries) GetToolsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]Tool, error) {
-	rows, err := q.db.Query(ctx, getToolsByIDs, dollar_1)
+type GetToolsByIDsParams struct {
+	Ids         []uuid.UUID `db:"ids" json:"ids"`
+	WorkspaceID uuid.UUID   `db:"workspace_id" json:"workspace_id"`
+}
+
+func (q *Queries) GetToolsByIDs(ctx context.Context, arg GetToolsByIDsParams) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, getToolsByIDs, arg.Ids, arg.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tool{}
+	for rows.Next() {
+		var i Tool
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Config,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.Icon,
+			&i.Tags,
+			&i.Examples,
+			&i.Documentation,
+			&i.Revision,
+			&i.Status,
+			&i.StatusMessage,
+			&i.StatusCheckedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllTools = `-- name: ListAllTools :many
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id FROM tools WHERE deleted_at IS NULL ORDER BY name
+`
+
+// Lists the tools of every workspace and the shared tools, for the jobs of the tools service
+func (q *Queries) ListAllTools(ctx context.Context) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, listAllTools)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +315,76 @@ func (q *Queries) GetToolsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]To
 			&i.CreatedAt,
 			&i.CreatedBy,
 			&i.UpdatedAt,
+			&i.Category,
+			&i.Icon,
+			&i.Tags,
+			&i.Examples,
+			&i.Documentation,
+			&i.Revision,
+			&i.Status,
+			&i.StatusMessage,
+			&i.StatusCheckedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listToolCatalog = `-- name: ListToolCatalog :many
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
+FROM tools t
+WHERE (t.workspace_id = $1::uuid OR t.workspace_id IS NULL)
+  AND t.deleted_at IS NULL
+  AND ($2::text IS NULL OR $2::text = ANY(t.tags))
+  AND ($3::text IS NULL
+       OR strpos(lower(t.name), lower($3::text)) > 0
+       OR strpos(lower(t.description), lower($3::text)) > 0)
+ORDER BY COALESCE(NULLIF(t.category, ''), 'uncategorized'), t.name
+`
+
+type ListToolCatalogParams struct {
+	WorkspaceID uuid.UUID   `db:"workspace_id" json:"workspace_id"`
+	Tag         pgtype.Text `db:"tag" json:"tag"`
+	Search      pgtype.Text `db:"search" json:"search"`
+}
+
+// Lists the tools of the catalog of a workspace by category, the tools with a null or empty category sorted under
+// uncategorized where they are grouped. The search matches its text literally, % and _ are not wildcards.
+func (q *Queries) ListToolCatalog(ctx context.Context, arg ListToolCatalogParams) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, listToolCatalog, arg.WorkspaceID, arg.Tag, arg.Search)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tool{}
+	for rows.Next() {
+		var i Tool
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Config,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.Icon,
+			&i.Tags,
+			&i.Examples,
+			&i.Documentation,
+			&i.Revision,
+			&i.Status,
+			&i.StatusMessage,
+			&i.StatusCheckedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
 		); err != nil {
 			return nil, err
 		}
@@ -135,16 +398,116 @@ func (q *Queries) GetToolsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]To
 
 const listTools = `-- name: ListTools :many
 
-SELECT id, name, description, config, created_at, created_by, updated_at
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
 FROM tools t
+WHERE (t.workspace_id = $1::uuid OR t.workspace_id IS NULL) AND t.deleted_at IS NULL
 ORDER BY t.created_at DESC
 `
 
 // ==============================================
 // TOOL QUERIES FOR SQLC
 // ==============================================
-func (q *Queries) ListTools(ctx context.Context) ([]Tool, error) {
-	rows, err := q.db.Query(ctx, listTools)
+// Lists the tools of a workspace and the shared tools
+func (q *Queries) ListTools(ctx context.Context, workspaceID uuid.UUID) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, listTools, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tool{}
+	for rows.Next() {
+		var i Tool
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Config,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.Icon,
+			&i.Tags,
+			&i.Examples,
+			&i.Documentation,
+			&i.Revision,
+			&i.Status,
+			&i.StatusMessage,
+			&i.StatusCheckedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listToolsByTags = `-- name: ListToolsByTags :many
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id FROM tools
+WHERE tags && $1::text[]
+  AND (workspace_id = $2::uuid OR workspace_id IS NULL)
+  AND deleted_at IS NULL
+ORDER BY name
+`
+
+type ListToolsByTagsParams struct {
+	Tags        []string  `db:"tags" json:"tags"`
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+}
+
+func (q *Queries) ListToolsByTags(ctx context.Context, arg ListToolsByTagsParams) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, listToolsByTags, arg.Tags, arg.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tool{}
+	for rows.Next() {
+		var i Tool
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Config,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.Icon,
+			&i.Tags,
+			&i.Examples,
+			&i.Documentation,
+			&i.Revision,
+			&i.Status,
+			&i.StatusMessage,
+			&i.StatusCheckedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listToolsWithHealthCheck = `-- name: ListToolsWithHealthCheck :many
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
+FROM tools
+WHERE config ? 'health_check' AND deleted_at IS NULL
+ORDER BY name
+`
+
+func (q *Queries) ListToolsWithHealthCheck(ctx context.Context) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, listToolsWithHealthCheck)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +523,17 @@ func (q *Queries) ListTools(ctx context.Context) ([]Tool, error) {
 			&i.CreatedAt,
 			&i.CreatedBy,
 			&i.UpdatedAt,
+			&i.Category,
+			&i.Icon,
+			&i.Tags,
+			&i.Examples,
+			&i.Documentation,
+			&i.Revision,
+			&i.Status,
+			&i.StatusMessage,
+			&i.StatusCheckedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
 		); err != nil {
 			return nil, err
 		}
@@ -171,22 +545,264 @@ func (q *Queries) ListTools(ctx context.Context) ([]Tool, error) {
 	return items, nil
 }
 
+const purgeTool = `-- name: PurgeTool :execrows
+DELETE FROM tools
+WHERE workspace_id = $1::uuid AND id = $2 AND deleted_at IS NOT NULL
+`
+
+type PurgeToolParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Deletes a tool of the trash of a workspace for good
+func (q *Queries) PurgeTool(ctx context.Context, arg PurgeToolParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTool, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreTool = `-- name: RestoreTool :one
+UPDATE tools SET deleted_at = NULL
+WHERE workspace_id = $1::uuid AND id = $2 AND deleted_at IS NOT NULL
+RETURNING id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
+`
+
+type RestoreToolParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Restores a tool of a workspace from the trash
+func (q *Queries) RestoreTool(ctx context.Context, arg RestoreToolParams) (Tool, error) {
+	row := q.db.QueryRow(ctx, restoreTool, arg.WorkspaceID, arg.ID)
+	var i Tool
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Config,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+		&i.Category,
+		&i.Icon,
+		&i.Tags,
+		&i.Examples,
+		&i.Documentation,
+		&i.Revision,
+		&i.Status,
+		&i.StatusMessage,
+		&i.StatusCheckedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const searchTools = `-- name: SearchTools :many
+SELECT id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
+FROM tools t
+WHERE (t.workspace_id = $1::uuid OR t.workspace_id IS NULL)
+  AND ($2::text IS NULL OR t.category = $2::text)
+  AND ($3::text IS NULL OR $3::text = ANY(t.tags))
+  AND ($4::text IS NULL
+       OR strpos(lower(t.name), lower($4::text)) > 0
+       OR strpos(lower(t.description), lower($4::text)) > 0)
+  AND ($5::text IS NULL OR t.config->>'type' = $5::text)
+  AND ($6::text IS NULL OR t.status = $6::text)
+  AND ($7::uuid IS NULL OR t.created_by = $7::uuid)
+  AND ($8::timestamptz IS NULL OR t.created_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR t.created_at < $9::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = $10::boolean
+  AND (t.created_by = $11::uuid
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'tool' AND g.resource_id = t.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'tool' AND g.resource_id = t.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = $11::uuid
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $11::uuid))))
+  AND ($12::uuid IS NULL OR CASE
+    WHEN $13::text = 'name' AND NOT $14::boolean
+      THEN (t.name, t.id) > ($15::text, $12::uuid)
+    WHEN $13::text = 'name'
+      THEN (t.name, t.id) < ($15::text, $12::uuid)
+    WHEN $13::text = 'category' AND $15::text IS NULL
+      THEN t.category IS NULL AND CASE WHEN $14::boolean THEN t.id < $12::uuid ELSE t.id > $12::uuid END
+    WHEN $13::text = 'category' AND NOT $14::boolean
+      THEN t.category IS NULL OR (t.category, t.id) > ($15::text, $12::uuid)
+    WHEN $13::text = 'category'
+      THEN t.category IS NULL OR (t.category, t.id) < ($15::text, $12::uuid)
+    WHEN $13::text = 'updated_at' AND NOT $14::boolean
+      THEN (t.updated_at, t.id) > ($16::timestamptz, $12::uuid)
+    WHEN $13::text = 'updated_at'
+      THEN (t.updated_at, t.id) < ($16::timestamptz, $12::uuid)
+    WHEN NOT $14::boolean
+      THEN (t.created_at, t.id) > ($16::timestamptz, $12::uuid)
+    ELSE (t.created_at, t.id) < ($16::timestamptz, $12::uuid)
+  END)
+ORDER BY
+  CASE WHEN $13::text = 'name' AND NOT $14::boolean THEN t.name END ASC,
+  CASE WHEN $13::text = 'name' AND $14::boolean THEN t.name END DESC,
+  CASE WHEN $13::text = 'category' AND NOT $14::boolean THEN t.category END ASC NULLS LAST,
+  CASE WHEN $13::text = 'category' AND $14::boolean THEN t.category END DESC NULLS LAST,
+  CASE WHEN $13::text = 'updated_at' AND NOT $14::boolean THEN t.updated_at END ASC,
+  CASE WHEN $13::text = 'updated_at' AND $14::boolean THEN t.updated_at END DESC,
+  CASE WHEN $13::text NOT IN ('name', 'category', 'updated_at') AND NOT $14::boolean THEN t.created_at END ASC,
+  CASE WHEN $13::text NOT IN ('name', 'category', 'updated_at') AND $14::boolean THEN t.created_at END DESC,
+  CASE WHEN NOT $14::boolean THEN t.id END ASC,
+  CASE WHEN $14::boolean THEN t.id END DESC
+LIMIT $17
+`
+
+type SearchToolsParams struct {
+	WorkspaceID   uuid.UUID          `db:"workspace_id" json:"workspace_id"`
+	Category      pgtype.Text        `db:"category" json:"category"`
+	Tag           pgtype.Text        `db:"tag" json:"tag"`
+	Search        pgtype.Text        `db:"search" json:"search"`
+	Type          pgtype.Text        `db:"type" json:"type"`
+	Status        pgtype.Text        `db:"status" json:"status"`
+	CreatedBy     pgtype.UUID        `db:"created_by" json:"created_by"`
+	CreatedAfter  pgtype.Timestamptz `db:"created_after" json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `db:"created_before" json:"created_before"`
+	Deleted       bool               `db:"deleted" json:"deleted"`
+	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
+	CursorID      pgtype.UUID        `db:"cursor_id" json:"cursor_id"`
+	Sort          string             `db:"sort" json:"sort"`
+	Descending    bool               `db:"descending" json:"descending"`
+	CursorText    pgtype.Text        `db:"cursor_text" json:"cursor_text"`
+	CursorTime    pgtype.Timestamptz `db:"cursor_time" json:"cursor_time"`
+	RowLimit      int32              `db:"row_limit" json:"row_limit"`
+}
+
+// Lists the tools or the tools in the trash matching the filters in the order of the sort, after the tool of the cursor
+// when set. The id breaks the ties between equal sort keys, the tools without a category come last in both directions.
+// The shared tools are listed with the tools of the workspace, the tools with grants are only listed to their creator
+// and their grantees.
+func (q *Queries) SearchTools(ctx context.Context, arg SearchToolsParams) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, searchTools,
+		arg.WorkspaceID,
+		arg.Category,
+		arg.Tag,
+		arg.Search,
+		arg.Type,
+		arg.Status,
+		arg.CreatedBy,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Deleted,
+		arg.UserID,
+		arg.CursorID,
+		arg.Sort,
+		arg.Descending,
+		arg.CursorText,
+		arg.CursorTime,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tool{}
+	for rows.Next() {
+		var i Tool
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Config,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.Category,
+			&i.Icon,
+			&i.Tags,
+			&i.Examples,
+			&i.Documentation,
+			&i.Revision,
+			&i.Status,
+			&i.StatusMessage,
+			&i.StatusCheckedAt,
+			&i.DeletedAt,
+			&i.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteTool = `-- name: SoftDeleteTool :execrows
+UPDATE tools SET deleted_at = NOW()
+WHERE workspace_id = $1::uuid AND id = $2 AND deleted_at IS NULL
+`
+
+type SoftDeleteToolParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	ID          uuid.UUID `db:"id" json:"id"`
+}
+
+// Moves a tool of a workspace to the trash, the shared tools cannot be deleted
+func (q *Queries) SoftDeleteTool(ctx context.Context, arg SoftDeleteToolParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteTool, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateTool = `-- name: UpdateTool :one
 UPDATE tools SET
-    description = COALESCE($2, description),
-    config = COALESCE($3, config)
-WHERE id = $1
-RETURNING id, name, description, config, created_at, created_by, updated_at
+    description = COALESCE($1, description),
+    config = COALESCE($2, config),
+    category = COALESCE($3, category),
+    icon = COALESCE($4, icon),
+    tags = COALESCE($5, tags),
+    examples = COALESCE($6, examples),
+    documentation = COALESCE($7, documentation),
+    status = CASE WHEN COALESCE($2, config) ? 'health_check' THEN status ELSE 'unknown' END,
+    status_message = CASE WHEN COALESCE($2, config) ? 'health_check' THEN status_message ELSE NULL END,
+    status_checked_at = CASE WHEN COALESCE($2, config) ? 'health_check' THEN status_checked_at ELSE NULL END
+WHERE id = $8
+  AND workspace_id IS NOT DISTINCT FROM $9::uuid
+  AND deleted_at IS NULL
+  AND ($10::timestamptz IS NULL OR updated_at = $10::timestamptz)
+RETURNING id, name, description, config, created_at, created_by, updated_at, category, icon, tags, examples, documentation, revision, status, status_message, status_checked_at, deleted_at, workspace_id
 `
 
 type UpdateToolParams struct {
-	ID          uuid.UUID   `db:"id" json:"id"`
-	Description pgtype.Text `db:"description" json:"description"`
-	Config      ToolConfig  `db:"config" json:"config"`
+	Description   pgtype.Text        `db:"description" json:"description"`
+	Config        ToolConfig         `db:"config" json:"config"`
+	Category      pgtype.Text        `db:"category" json:"category"`
+	Icon          pgtype.Text        `db:"icon" json:"icon"`
+	Tags          []string           `db:"tags" json:"tags"`
+	Examples      JsonRaw            `db:"examples" json:"examples"`
+	Documentation pgtype.Text        `db:"documentation" json:"documentation"`
+	ID            uuid.UUID          `db:"id" json:"id"`
+	WorkspaceID   pgtype.UUID        `db:"workspace_id" json:"workspace_id"`
+	IfUpdatedAt   pgtype.Timestamptz `db:"if_updated_at" json:"if_updated_at"`
 }
 
+// Updates a tool of a workspace, or a shared tool when the workspace is null, only while its update time is
+// if_updated_at when set. A tool whose health check is removed is no longer probed, its status is reset.
 func (q *Queries) UpdateTool(ctx context.Context, arg UpdateToolParams) (Tool, error) {
-	row := q.db.QueryRow(ctx, updateTool, arg.ID, arg.Description, arg.Config)
+	row := q.db.QueryRow(ctx, updateTool,
+		arg.Description,
+		arg.Config,
+		arg.Category,
+		arg.Icon,
+		arg.Tags,
+		arg.Examples,
+		arg.Documentation,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.IfUpdatedAt,
+	)
 	var i Tool
 	err := row.Scan(
 		&i.ID,
@@ -196,6 +812,36 @@ func (q *Queries) UpdateTool(ctx context.Context, arg UpdateToolParams) (Tool, e
 		&i.CreatedAt,
 		&i.CreatedBy,
 		&i.UpdatedAt,
+		&i.Category,
+		&i.Icon,
+		&i.Tags,
+		&i.Examples,
+		&i.Documentation,
+		&i.Revision,
+		&i.Status,
+		&i.StatusMessage,
+		&i.StatusCheckedAt,
+		&i.DeletedAt,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
+
+const updateToolStatus = `-- name: UpdateToolStatus :exec
+UPDATE tools SET
+    status = $2,
+    status_message = $3,
+    status_checked_at = NOW()
+WHERE id = $1
+`
+
+type UpdateToolStatusParams struct {
+	ID            uuid.UUID   `db:"id" json:"id"`
+	Status        ToolStatus  `db:"status" json:"status"`
+	StatusMessage pgtype.Text `db:"status_message" json:"status_message"`
+}
+
+func (q *Queries) UpdateToolStatus(ctx context.Context, arg UpdateToolStatusParams) error {
+	_, err := q.db.Exec(ctx, updateToolStatus, arg.ID, arg.Status, arg.StatusMessage)
+	return err
+}
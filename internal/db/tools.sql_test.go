@@ -139,7 +139,7 @@ func TestCRUDTools(t *testing.T) {
 	assert.Equal(t, createMCPParams.Config.Type, createdMCPTool.Config.Type)
 
 	// Test ListTools
-	tools, err := queries.ListTools(t.Context())
+	tools, err := queries.ListTools(t.Context(), DefaultWorkspaceID)
 	require.NoError(t, err, "Failed to list tools")
 	assert.NotEmpty(t, tools, "Tools list should not be empty")
 
@@ -166,25 +166,25 @@ func TestCRUDTools(t *testing.T) {
 	}
 
 	// Test GetToolById
-	retrievedStandaloneTool, err := queries.GetToolById(t.Context(), createdStandaloneTool.ID)
+	retrievedStandaloneTool, err := queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdStandaloneTool.ID})
 	require.NoError(t, err, "Failed to get standalone tool by ID")
 	assert.Equal(t, createdStandaloneTool.ID, retrievedStandaloneTool.ID)
 	assert.Equal(t, createdStandaloneTool.Name, retrievedStandaloneTool.Name)
 	assert.Equal(t, createdStandaloneTool.Config.Type, retrievedStandaloneTool.Config.Type)
 	assert.Equal(t, createdStandaloneTool.Config.Type, retrievedStandaloneTool.Config.Type)
 
-	retrievedWorkflowTool, err := queries.GetToolById(t.Context(), createdWorkflowTool.ID)
+	retrievedWorkflowTool, err := queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdWorkflowTool.ID})
 	require.NoError(t, err, "Failed to get workflow tool by ID")
 	assert.Equal(t, createdWorkflowTool.ID, retrievedWorkflowTool.ID)
 	assert.Equal(t, createdWorkflowTool.Name, retrievedWorkflowTool.Name)
 
-	retrievedMCPTool, err := queries.GetToolById(t.Context(), createdMCPTool.ID)
+	retrievedMCPTool, err := queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdMCPTool.ID})
 	require.NoError(t, err, "Failed to get MCP tool by ID")
 	assert.Equal(t, createdMCPTool.ID, retrievedMCPTool.ID)
 	assert.Equal(t, createdMCPTool.Name, retrievedMCPTool.Name)
 
 	// Test GetToolInfoByName
-	retrievedByName, err := queries.GetToolInfoByName(t.Context(), createStandaloneParams.Name)
+	retrievedByName, err := queries.GetToolInfoByName(t.Context(), GetToolInfoByNameParams{Name: createStandaloneParams.Name})
 	require.NoError(t, err, "Failed to get tool by name")
 	assert.Equal(t, createdStandaloneTool.ID, retrievedByName.ID)
 	assert.Equal(t, createdStandaloneTool.Name, retrievedByName.Name)
@@ -231,7 +231,7 @@ func TestCRUDTools(t *testing.T) {
 	assert.Equal(t, updatedConfig.Type, updatedTool.Config.Type)
 
 	// Verify the update persisted
-	retrievedUpdatedTool, err := queries.GetToolById(t.Context(), createdStandaloneTool.ID)
+	retrievedUpdatedTool, err := queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdStandaloneTool.ID})
 	require.NoError(t, err, "Failed to get updated tool")
 	assert.Equal(t, updateParams.Description, retrievedUpdatedTool.Description)
 
@@ -246,14 +246,14 @@ func TestCRUDTools(t *testing.T) {
 	require.NoError(t, err, "Failed to delete MCP tool")
 
 	// Verify tools are deleted
-	_, err = queries.GetToolById(t.Context(), createdStandaloneTool.ID)
+	_, err = queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdStandaloneTool.ID})
 	assert.Error(t, err, "Getting deleted tool should return error")
 	assert.Equal(t, pgx.ErrNoRows, err, "Should return no rows error")
 
-	_, err = queries.GetToolById(t.Context(), createdWorkflowTool.ID)
+	_, err = queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdWorkflowTool.ID})
 	assert.Error(t, err, "Getting deleted workflow tool should return error")
 
-	_, err = queries.GetToolById(t.Context(), createdMCPTool.ID)
+	_, err = queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdMCPTool.ID})
 	assert.Error(t, err, "Getting deleted MCP tool should return error")
 }
 
@@ -265,12 +265,12 @@ func TestToolsErrorCases(t *testing.T) {
 
 	// Test GetToolById with non-existent ID
 	nonExistentID := uuid.New()
-	_, err := queries.GetToolById(t.Context(), nonExistentID)
+	_, err := queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: nonExistentID})
 	assert.Error(t, err, "Getting non-existent tool should return error")
 	assert.Equal(t, pgx.ErrNoRows, err, "Should return no rows error")
 
 	// Test GetToolInfoByName with non-existent name
-	_, err = queries.GetToolInfoByName(t.Context(), "NonExistentToolName")
+	_, err = queries.GetToolInfoByName(t.Context(), GetToolInfoByNameParams{Name: "NonExistentToolName"})
 	assert.Error(t, err, "Getting tool by non-existent name should return error")
 	assert.Equal(t, pgx.ErrNoRows, err, "Should return no rows error")
 
@@ -424,7 +424,7 @@ func TestToolConfigSerialization(t *testing.T) {
 	require.NoError(t, err, "Failed to create tool with complex params")
 
 	// Retrieve and verify serialization
-	retrievedTool, err := queries.GetToolById(t.Context(), createdTool.ID)
+	retrievedTool, err := queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdTool.ID})
 	require.NoError(t, err, "Failed to retrieve tool")
 
 	assert.Equal(t, config.Type, retrievedTool.Config.Type)
@@ -506,7 +506,7 @@ func TestMCPToolWithEnvironmentVariables(t *testing.T) {
 	require.NoError(t, err, "Failed to create MCP tool with env vars")
 
 	// Retrieve and verify environment variables
-	retrievedTool, err := queries.GetToolById(t.Context(), createdTool.ID)
+	retrievedTool, err := queries.GetToolById(t.Context(), GetToolByIdParams{WorkspaceID: DefaultWorkspaceID, ID: createdTool.ID})
 	require.NoError(t, err, "Failed to retrieve MCP tool")
 
 	mcpConfig := retrievedTool.Config.GetMCP()
@@ -584,7 +584,7 @@ func TestListToolsOrderingAndPagination(t *testing.T) {
 	}
 
 	// List tools and verify ordering (should be DESC by created_at)
-	tools, err := queries.ListTools(t.Context())
+	tools, err := queries.ListTools(t.Context(), DefaultWorkspaceID)
 	require.NoError(t, err, "Failed to list tools")
 
 	// Find our tools in the list
@@ -1,9 +1,15 @@
 package db
 
 import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
 )
 
 func Test_ToolConfig(t *testing.T) {
@@ -99,3 +105,389 @@ func Test_ToolConfig(t *testing.T) {
 		}
 	})
 }
+
+func Test_DiffToolSchemas(t *testing.T) {
+	t.Parallel()
+
+	var from, to openapi3.Schema
+	if err := json.Unmarshal([]byte(`{"type":"object","properties":{
+		"query":{"type":"string","description":"Search query"},
+		"limit":{"type":"integer"},
+		"mode":{"type":"string","enum":["fast","deep"]},
+		"tags":{"type":"array","items":{"type":"string"}}
+	},"required":["query"]}`), &from); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"type":"object","properties":{
+		"query":{"type":"string","description":"Full text query"},
+		"mode":{"type":"string","enum":["fast"]},
+		"tags":{"type":"array","items":{"type":["integer","string"]}},
+		"region":{"type":"string"}
+	},"required":["query","region"]}`), &to); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	type change struct {
+		Path     string
+		Kind     ToolSchemaChangeKind
+		Breaking bool
+	}
+	var got []change
+	for _, c := range DiffToolSchemas(&from, &to) {
+		got = append(got, change{c.Path, c.Kind, c.Breaking})
+	}
+	expected := []change{
+		{"/properties/region", ToolSchemaChangeKindRequiredAdded, true},
+		{"/properties/limit", ToolSchemaChangeKindRemoved, true},
+		{"/properties/mode", ToolSchemaChangeKindEnumChanged, true},
+		{"/properties/query", ToolSchemaChangeKindDescriptionChanged, false},
+		{"/properties/region", ToolSchemaChangeKindAdded, false},
+		{"/properties/tags/items", ToolSchemaChangeKindTypeChanged, false},
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected changes %v, got %v", expected, got)
+	}
+
+	if changes := DiffToolSchemas(&from, &from); len(changes) != 0 {
+		t.Errorf("expected no changes for identical schemas, got %v", changes)
+	}
+}
+
+func Test_EncryptSecret(t *testing.T) {
+	t.Parallel()
+
+	oldKey := []byte("0123456789abcdef0123456789abcdef")
+	newKey := []byte("fedcba9876543210fedcba9876543210")
+	oldWrapper, err := NewLocalKeyWrapper("k1", map[string][]byte{"k1": oldKey})
+	if err != nil {
+		t.Fatalf("NewLocalKeyWrapper() error: %v", err)
+	}
+	rotatedWrapper, err := NewLocalKeyWrapper("k2", map[string][]byte{"k1": oldKey, "k2": newKey})
+	if err != nil {
+		t.Fatalf("NewLocalKeyWrapper() error: %v", err)
+	}
+
+	encrypted, err := encryptSecret(oldWrapper, "test_api_key")
+	if err != nil {
+		t.Fatalf("encryptSecret() error: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, encryptedSecretPrefix+"k1:") || strings.Contains(encrypted, "test_api_key") {
+		t.Errorf("unexpected encrypted secret %s", encrypted)
+	}
+
+	// Secrets wrapped by a previous key stay readable after a rotation
+	for _, w := range []SecretKeyWrapper{oldWrapper, rotatedWrapper} {
+		decrypted, err := decryptSecret(w, encrypted)
+		if err != nil {
+			t.Fatalf("decryptSecret() error: %v", err)
+		}
+		if decrypted != "test_api_key" {
+			t.Errorf("expected test_api_key, got %s", decrypted)
+		}
+	}
+
+	if plaintext, err := decryptSecret(rotatedWrapper, "legacy_api_key"); err != nil || plaintext != "legacy_api_key" {
+		t.Errorf("expected plaintext secret to be returned unchanged, got %q, %v", plaintext, err)
+	}
+	if _, err := decryptSecret(nil, encrypted); err == nil {
+		t.Error("expected an error when decrypting without a key")
+	}
+	if _, err := decryptSecret(rotatedWrapper, encrypted[:len(encrypted)-4]+"AAAA"); err == nil {
+		t.Error("expected an error when the ciphertext was tampered with")
+	}
+	if _, err := NewLocalKeyWrapper("k1", map[string][]byte{"k1": []byte("short")}); err == nil {
+		t.Error("expected an error for a key that is not 32 bytes")
+	}
+}
+
+func Test_ToolConfigSecrets(t *testing.T) {
+	t.Parallel()
+
+	apiKey := "test_api_key"
+	config := ToolConfig{Type: ToolTypeMCP, C: &ToolConfigMCP{ApiKey: &apiKey, Entrypoint: "https://mcp.example.com", Protocol: MCPProtocolSSE}}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	expected := `{"type":"mcp","entrypoint":"https://mcp.example.com","protocol":"sse","api_key":"[REDACTED]"}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, string(b))
+	}
+	if *config.APIKey() != apiKey {
+		t.Error("redacting the JSON form must not change the configuration")
+	}
+
+	var submitted ToolConfig
+	if err := json.Unmarshal(b, &submitted); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	submitted.RestoreRedactedSecrets(config)
+	if got := submitted.APIKey(); got == nil || *got != apiKey {
+		t.Errorf("expected the redacted API key to be restored, got %v", got)
+	}
+}
+
+func Test_ToolConfigSecretReference(t *testing.T) {
+	t.Parallel()
+
+	apiKey := "vault://secret/tools/weather#api_key"
+	config := ToolConfig{Type: ToolTypeMCP, C: &ToolConfigMCP{ApiKey: &apiKey, Entrypoint: "https://mcp.example.com", Protocol: MCPProtocolSSE}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+
+	// References do not hold the secret, they are neither redacted nor encrypted
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if !strings.Contains(string(b), `"api_key":"vault://secret/tools/weather#api_key"`) {
+		t.Errorf("expected the secret reference in %s", string(b))
+	}
+	stored, err := config.withSecrets(func(string) (string, error) { return "encrypted", nil })
+	if err != nil {
+		t.Fatalf("withSecrets() error: %v", err)
+	}
+	if *stored.APIKey() != apiKey {
+		t.Errorf("expected the secret reference to be stored as is, got %s", *stored.APIKey())
+	}
+
+	invalid := "vault://#api_key"
+	config.C.(*ToolConfigMCP).ApiKey = &invalid
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for a secret reference without a name")
+	}
+}
+
+func Test_ToolHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	input := `{"type":"standalone","params":{"type":"object"},"url":"https://api.example.com","health_check":{"url":"https://api.example.com/health","failure_threshold":5}}`
+	var config ToolConfig
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if config.HealthCheck == nil || config.HealthCheck.URL != "https://api.example.com/health" || config.HealthCheck.FailureThreshold != 5 {
+		t.Fatalf("unexpected health check %+v", config.HealthCheck)
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if !strings.Contains(string(b), `"health_check":{"url":"https://api.example.com/health","failure_threshold":5}`) {
+		t.Errorf("expected the health check in %s", string(b))
+	}
+
+	invalid := []ToolHealthCheck{
+		{},
+		{URL: "https://api.example.com/health", Command: []string{"true"}},
+		{URL: "ftp://api.example.com/health"},
+		{Command: []string{"true"}, IntervalSeconds: -1},
+	}
+	for _, check := range invalid {
+		config.HealthCheck = &check
+		if err := config.Validate(); err == nil {
+			t.Errorf("expected an error for the health check %+v", check)
+		}
+	}
+}
+
+func Test_ValidateToolInput(t *testing.T) {
+	t.Parallel()
+
+	var schema openapi3.Schema
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"city": {"type": "string", "minLength": 1},
+			"days": {"type": "integer", "minimum": 1, "maximum": 7},
+			"units": {"type": "string", "enum": ["metric", "imperial"]}
+		},
+		"required": ["city"]
+	}`), &schema); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if violations := ValidateToolInput(&schema, map[string]any{"city": "Hanoi", "days": float64(3)}); len(violations) != 0 {
+		t.Errorf("expected no violations for a valid input, got %v", violations)
+	}
+	if violations := ValidateToolInput(nil, map[string]any{"anything": true}); len(violations) != 0 {
+		t.Errorf("expected no violations without a schema, got %v", violations)
+	}
+
+	violations := ValidateToolInput(&schema, map[string]any{"days": float64(10), "units": "kelvin"})
+	paths := make([]string, 0, len(violations))
+	for _, v := range violations {
+		paths = append(paths, v.Path)
+		if v.Message == "" {
+			t.Errorf("expected a message for the violation at %s", v.Path)
+		}
+	}
+	slices.Sort(paths)
+	if expected := []string{"/city", "/days", "/units"}; !slices.Equal(paths, expected) {
+		t.Errorf("expected violations at %v, got %v", expected, paths)
+	}
+}
+
+func Test_ToolMock(t *testing.T) {
+	t.Parallel()
+
+	input := `{"type":"mcp","entrypoint":"https://mcp.example.com","protocol":"sse","mock":{"response":{"temperature":21},"is_error":true}}`
+	var config ToolConfig
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	tool := Tool{Name: "weather", Config: config}
+	mock := tool.MockResponse()
+	if !mock.IsError || !reflect.DeepEqual(mock.Response, map[string]any{"temperature": float64(21)}) {
+		t.Errorf("unexpected mock response %+v", mock)
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if !strings.Contains(string(b), `"mock":{"response":{"temperature":21},"is_error":true}`) {
+		t.Errorf("expected the mock in %s", string(b))
+	}
+
+	// Tools without a mock answer dry runs with a placeholder
+	tool.Config.Mock = nil
+	if mock := tool.MockResponse(); mock.IsError || mock.Response == nil {
+		t.Errorf("unexpected placeholder response %+v", mock)
+	}
+}
+
+func Test_ToolConfigEdge(t *testing.T) {
+	t.Parallel()
+
+	config := &ToolConfigStandalone{Url: "http://weather.internal:8080", Params: &openapi3.Schema{}, Edge: true}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	if group := config.GetEdgeGroup(); group != DefaultToolEdgeGroup {
+		t.Errorf("expected the default edge group, got %s", group)
+	}
+
+	config.EdgeGroup = "datacenter-1"
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	config.EdgeGroup = "datacenter.1"
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for an edge group that is not a single subject token")
+	}
+	config.EdgeGroup, config.Edge = "datacenter-1", false
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for an edge group on a tool that is not an edge tool")
+	}
+}
+
+func Test_ParseOffloadedToolResult(t *testing.T) {
+	t.Parallel()
+
+	ref, ok := ParseOffloadedToolResult(JsonRaw(`{"offloaded":true,"url":"s3://results/tool-results/run-1.json","bucket":"results","key":"tool-results/run-1.json","size_bytes":300000}`))
+	if !ok {
+		t.Fatal("expected an offloaded tool result")
+	}
+	if ref.Bucket != "results" || ref.Key != "tool-results/run-1.json" || ref.SizeBytes != 300000 {
+		t.Errorf("unexpected reference %+v", ref)
+	}
+
+	for _, result := range []string{`{"text":"hello"}`, `{"offloaded":true}`, `"offloaded"`, `null`} {
+		if _, ok := ParseOffloadedToolResult(JsonRaw(result)); ok {
+			t.Errorf("expected %s to be stored in the database", result)
+		}
+	}
+}
+
+func Test_ToolCache(t *testing.T) {
+	t.Parallel()
+
+	input := `{"type":"mcp","entrypoint":"https://mcp.example.com","protocol":"sse","cache":{"cacheable":true,"ttl_seconds":60}}`
+	var config ToolConfig
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if ttl := config.Cache.TTL(); ttl != time.Minute {
+		t.Errorf("expected a TTL of one minute, got %s", ttl)
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if !strings.Contains(string(b), `"cache":{"cacheable":true,"ttl_seconds":60}`) {
+		t.Errorf("expected the cache in %s", string(b))
+	}
+
+	// The TTL defaults to 5 minutes, tools that are not cacheable are always invoked
+	if ttl := (&ToolCache{Cacheable: true}).TTL(); ttl != 5*time.Minute {
+		t.Errorf("expected the default TTL, got %s", ttl)
+	}
+	if ttl := (&ToolCache{TTLSeconds: 60}).TTL(); ttl != 0 {
+		t.Errorf("expected no TTL for a tool that is not cacheable, got %s", ttl)
+	}
+	var none *ToolCache
+	if ttl := none.TTL(); ttl != 0 {
+		t.Errorf("expected no TTL without a cache, got %s", ttl)
+	}
+
+	config.Cache.TTLSeconds = -1
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for a negative ttl_seconds")
+	}
+}
+
+func Test_ValidateToolName(t *testing.T) {
+	t.Parallel()
+
+	// The built-in names are reserved ignoring the case, and once sanitized
+	for _, name := range []string{"batch_tool", "Invoke_Agent", "web search"} {
+		if err := ValidateToolName(name); err == nil {
+			t.Errorf("expected %q to be reserved", name)
+		}
+	}
+	if err := ValidateToolName("fetch-url"); err != nil {
+		t.Errorf("expected fetch-url to be accepted, got %v", err)
+	}
+	if err := ValidateToolName("search__1b4e28ba"); err == nil {
+		t.Error("expected an error for a name containing the namespace separator")
+	}
+}
+
+func Test_ModelToolNames(t *testing.T) {
+	t.Parallel()
+
+	builtin := Tool{ID: BuiltinToolIDs["web_search"], Name: "web_search", Config: ToolConfig{Type: ToolTypeInternal}}
+	shadow := Tool{ID: uuid.MustParse("1b4e28ba-2fa1-11d2-883f-0016d3cca427"), Name: "Web Search", Config: ToolConfig{Type: ToolTypeStandalone}}
+	weather := Tool{ID: uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), Name: "get weather", Config: ToolConfig{Type: ToolTypeStandalone}}
+
+	names, collisions := ModelToolNames([]Tool{shadow, weather, builtin, weather})
+	if len(collisions) != 1 || collisions[0].Name != "web_search" || len(collisions[0].Tools) != 2 {
+		t.Fatalf("expected the web_search collision, got %v", collisions)
+	}
+	// The built-in tool keeps its name, the tool shadowing it is namespaced with its ID
+	if names[builtin.ID] != "web_search" {
+		t.Errorf("expected the built-in tool to keep its name, got %q", names[builtin.ID])
+	}
+	if names[shadow.ID] != "Web_Search__1b4e28ba" {
+		t.Errorf("expected a namespaced name, got %q", names[shadow.ID])
+	}
+	if names[weather.ID] != "get_weather" {
+		t.Errorf("expected a sanitized name, got %q", names[weather.ID])
+	}
+	if shadow.BuiltinName() != "" || builtin.BuiltinName() != "web_search" {
+		t.Error("expected only the seeded tool to be the built-in tool")
+	}
+	for _, tool := range []Tool{builtin, shadow, weather} {
+		if !tool.MatchesModelToolName(names[tool.ID]) {
+			t.Errorf("expected %q to match %q", tool.Name, names[tool.ID])
+		}
+	}
+	if weather.MatchesModelToolName(names[shadow.ID]) {
+		t.Error("expected the namespaced name to match its tool only")
+	}
+}
@@ -1,12 +1,16 @@
 package db
 
 import (
+	"crypto/x509"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"regexp"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pinazu/internal/secrets"
 )
 
 // JSONRaw is a type that represents a JSON object in Go.
@@ -60,11 +64,12 @@ const (
 type ProviderName string
 
 const (
-	ProviderNameLocal  ProviderName = "local"
-	ProviderNameGoogle ProviderName = "google"
-	ProviderNameAzure  ProviderName = "azure"
-	ProviderNameGithub ProviderName = "github"
-	ProviderNameNil    ProviderName = ""
+	ProviderNameLocal          ProviderName = "local"
+	ProviderNameGoogle         ProviderName = "google"
+	ProviderNameAzure          ProviderName = "azure"
+	ProviderNameGithub         ProviderName = "github"
+	ProviderNameServiceAccount ProviderName = "service_account"
+	ProviderNameNil            ProviderName = ""
 )
 
 type ProviderModel string
@@ -114,19 +119,48 @@ const (
 	FlowStatusScheduled FlowStatus = "SCHEDULED"
 	FlowStatusPending   FlowStatus = "PENDING"
 	FlowStatusRunning   FlowStatus = "RUNNING"
+	FlowStatusPaused    FlowStatus = "PAUSED"
 	FlowStatusSuccess   FlowStatus = "SUCCESS"
 	FlowStatusFailed    FlowStatus = "FAILED"
+	FlowStatusCancelled FlowStatus = "CANCELLED"
 	FlowStatusNil       FlowStatus = ""
 )
 
+type FlowScheduleCatchUpPolicy string
+
+const (
+	FlowScheduleCatchUpPolicySkip FlowScheduleCatchUpPolicy = "skip" // The runs missed while the scheduler was down are dropped
+	FlowScheduleCatchUpPolicyOnce FlowScheduleCatchUpPolicy = "once" // A single run stands for all the missed runs
+	FlowScheduleCatchUpPolicyAll  FlowScheduleCatchUpPolicy = "all"  // Every missed run is triggered, up to the catch-up limit
+	FlowScheduleCatchUpPolicyNil  FlowScheduleCatchUpPolicy = ""
+)
+
+type FlowScheduleBackfillStatus string
+
+const (
+	FlowScheduleBackfillStatusRunning   FlowScheduleBackfillStatus = "RUNNING"   // Runs of the range are left to trigger
+	FlowScheduleBackfillStatusCompleted FlowScheduleBackfillStatus = "COMPLETED" // Every run of the range was triggered
+	FlowScheduleBackfillStatusCancelled FlowScheduleBackfillStatus = "CANCELLED" // Stopped before every run was triggered
+	FlowScheduleBackfillStatusNil       FlowScheduleBackfillStatus = ""
+)
+
+type FlowConcurrencyPolicy string
+
+const (
+	FlowConcurrencyPolicyQueue  FlowConcurrencyPolicy = "QUEUE"  // The runs beyond the limit wait for a run of the flow to end
+	FlowConcurrencyPolicyReject FlowConcurrencyPolicy = "REJECT" // The runs beyond the limit are rejected
+	FlowConcurrencyPolicyNil    FlowConcurrencyPolicy = ""
+)
+
 type ToolRunStatus string
 
 const (
-	ToolRunStatusPending ToolRunStatus = "PENDING"
-	ToolRunStatusRunning ToolRunStatus = "RUNNING"
-	ToolRunStatusSuccess ToolRunStatus = "SUCCESS"
-	ToolRunStatusFailed  ToolRunStatus = "FAILED"
-	ToolRunStatusNil     ToolRunStatus = ""
+	ToolRunStatusPending   ToolRunStatus = "PENDING"
+	ToolRunStatusRunning   ToolRunStatus = "RUNNING"
+	ToolRunStatusSuccess   ToolRunStatus = "SUCCESS"
+	ToolRunStatusFailed    ToolRunStatus = "FAILED"
+	ToolRunStatusCancelled ToolRunStatus = "CANCELLED" // Still in flight when its task run was cancelled
+	ToolRunStatusNil       ToolRunStatus = ""
 )
 
 type WorkerStatus string
@@ -138,17 +172,135 @@ const (
 	WorkerStatusNil      WorkerStatus = ""
 )
 
+type ToolStatus string
+
+const (
+	ToolStatusUnknown     ToolStatus = "unknown"     // No health check, or not probed yet
+	ToolStatusHealthy     ToolStatus = "healthy"     // The last health check passed
+	ToolStatusDegraded    ToolStatus = "degraded"    // Recent health checks failed, below the failure threshold
+	ToolStatusUnreachable ToolStatus = "unreachable" // The health checks failed failure_threshold times in a row
+	ToolStatusNil         ToolStatus = ""
+)
+
+type AgentProbeStatus string
+
+const (
+	AgentProbeStatusUnknown AgentProbeStatus = "unknown" // Not run yet
+	AgentProbeStatusPassing AgentProbeStatus = "passing" // The last run passed
+	AgentProbeStatusFailing AgentProbeStatus = "failing" // The last run did not pass
+	AgentProbeStatusNil     AgentProbeStatus = ""
+)
+
+type AgentProbeRunStatus string
+
+const (
+	AgentProbeRunStatusPassed  AgentProbeRunStatus = "passed"  // The final response matched the expected pattern
+	AgentProbeRunStatusFailed  AgentProbeRunStatus = "failed"  // The final response did not match the expected pattern
+	AgentProbeRunStatusError   AgentProbeRunStatus = "error"   // The task failed before the agent responded
+	AgentProbeRunStatusTimeout AgentProbeRunStatus = "timeout" // The agent did not respond within the timeout of the probe
+	AgentProbeRunStatusNil     AgentProbeRunStatus = ""
+)
+
+type ResourceType string
+
+const (
+	ResourceTypeAgent  ResourceType = "agent"
+	ResourceTypeTool   ResourceType = "tool"
+	ResourceTypeFlow   ResourceType = "flow"
+	ResourceTypeThread ResourceType = "thread"
+	ResourceTypeNil    ResourceType = ""
+)
+
+type GranteeType string
+
+const (
+	GranteeTypeUser GranteeType = "USER" // The grant is given to a user
+	GranteeTypeRole GranteeType = "ROLE" // The grant is given to the users of a role
+	GranteeTypeNil  GranteeType = ""
+)
+
+type GrantAccess string
+
+const (
+	GrantAccessRead   GrantAccess = "READ"   // Shows the resource
+	GrantAccessInvoke GrantAccess = "INVOKE" // Also runs the agent or the tool, or posts to the thread
+	GrantAccessManage GrantAccess = "MANAGE" // Also changes the resource and its grants
+	GrantAccessNil    GrantAccess = ""
+)
+
+type ResourceChangeAction string
+
+const (
+	ResourceChangeActionCreate  ResourceChangeAction = "CREATE"
+	ResourceChangeActionUpdate  ResourceChangeAction = "UPDATE"
+	ResourceChangeActionDelete  ResourceChangeAction = "DELETE"
+	ResourceChangeActionRestore ResourceChangeAction = "RESTORE"
+	ResourceChangeActionNil     ResourceChangeAction = ""
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"   // Attempted again once its next attempt is due
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED" // The endpoint answered with a 2xx
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"    // Out of attempts or refused by the endpoint with a client error
+	WebhookDeliveryStatusNil       WebhookDeliveryStatus = ""
+)
+
+type FileStatus string
+
+const (
+	FileStatusPending  FileStatus = "PENDING"  // Waiting for its upload to the presigned URL to be completed
+	FileStatusUploaded FileStatus = "UPLOADED" // Stored in the S3 storage, it can be attached to messages
+	FileStatusNil      FileStatus = ""
+)
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner  OrganizationRole = "OWNER"  // Manages the members and the workspaces, and the other owners
+	OrganizationRoleAdmin  OrganizationRole = "ADMIN"  // Manages the members and the workspaces
+	OrganizationRoleMember OrganizationRole = "MEMBER" // Lists the workspaces
+	OrganizationRoleNil    OrganizationRole = ""
+)
+
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleAdmin  WorkspaceRole = "ADMIN"  // Manages the members of the workspace
+	WorkspaceRoleMember WorkspaceRole = "MEMBER" // Acts in the workspace
+	WorkspaceRoleNil    WorkspaceRole = ""
+)
+
+type FeedbackRating string
+
+const (
+	FeedbackRatingUp   FeedbackRating = "UP"   // Thumbs up
+	FeedbackRatingDown FeedbackRating = "DOWN" // Thumbs down
+	FeedbackRatingNil  FeedbackRating = ""
+)
+
 type TaskRunStatus string
 
 const (
-	TaskRunStatusScheduled TaskRunStatus = "SCHEDULED"
-	TaskRunStatusPending   TaskRunStatus = "PENDING"
-	TaskRunStatusRunning   TaskRunStatus = "RUNNING"
+	TaskRunStatusScheduled TaskRunStatus = "SCHEDULED" // Queued behind the started run of its task
+	TaskRunStatusPending   TaskRunStatus = "PENDING"   // Started, paused until the user or a sub-agent answers
+	TaskRunStatusRunning   TaskRunStatus = "RUNNING"   // Started, the agent loop is running
 	TaskRunStatusFinished  TaskRunStatus = "FINISHED"
 	TaskRunStatusFailed    TaskRunStatus = "FAILED"
+	TaskRunStatusCancelled TaskRunStatus = "CANCELLED"
 	TaskRunStatusNil       TaskRunStatus = ""
 )
 
+type TaskScheduleOverlapPolicy string
+
+const (
+	TaskScheduleOverlapPolicySkip  TaskScheduleOverlapPolicy = "skip"  // A run due while the previous run is running is dropped
+	TaskScheduleOverlapPolicyQueue TaskScheduleOverlapPolicy = "queue" // A run due while the previous run is running starts once it ends
+	TaskScheduleOverlapPolicyAllow TaskScheduleOverlapPolicy = "allow" // Runs start on time in their own thread, alongside the previous runs
+	TaskScheduleOverlapPolicyNil   TaskScheduleOverlapPolicy = ""
+)
+
 type (
 	// EventType is a type alias for string to represent event types
 	EventType string
@@ -186,10 +338,18 @@ type ToolConfigIntf interface {
 	Validate() error
 }
 
+// DefaultToolEdgeGroup is the edge group of the edge tools that do not set one
+const DefaultToolEdgeGroup = "default"
+
+// toolEdgeGroupPattern restricts the edge groups to a single NATS subject token
+var toolEdgeGroupPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 type ToolConfigStandalone struct {
-	ApiKey *string          `json:"api_key,omitempty"` // Optional API key for the tool, applicable for HTTP-based tools
-	Url    string           `json:"url"`
-	Params *openapi3.Schema `json:"params"` // Parameter schema for the tool
+	ApiKey    *string          `json:"api_key,omitempty"` // Optional API key for the tool, applicable for HTTP-based tools
+	Url       string           `json:"url"`
+	Params    *openapi3.Schema `json:"params"`               // Parameter schema for the tool
+	Edge      bool             `json:"edge,omitempty"`       // Called by a worker from its own network instead of the tools service
+	EdgeGroup string           `json:"edge_group,omitempty"` // Workers allowed to call an edge tool, defaults to DefaultToolEdgeGroup
 	// Note: The Param field is required and used to define the parameters for the tool.
 	// It should be a valid OpenAPI schema object.
 	// Example: {"type": "object", "properties": {"tool_arg1": {"type": "string", "description": "Description of tool_arg1"}}, "required": ["tool_arg1"]}
@@ -210,7 +370,23 @@ func (t *ToolConfigStandalone) Validate() error {
 	if _, err := url.ParseRequestURI(t.Url); err != nil {
 		return fmt.Errorf("invalid URL format: %v", err)
 	}
-	return nil
+	if t.EdgeGroup != "" {
+		if !t.Edge {
+			return fmt.Errorf("edge_group is only applicable to edge tools")
+		}
+		if !toolEdgeGroupPattern.MatchString(t.EdgeGroup) {
+			return fmt.Errorf("invalid edge_group %q, only letters, digits, '_' and '-' are allowed", t.EdgeGroup)
+		}
+	}
+	return validateSecretReference(t.ApiKey)
+}
+
+// GetEdgeGroup returns the edge group of the workers allowed to call the tool
+func (t *ToolConfigStandalone) GetEdgeGroup() string {
+	if t.EdgeGroup == "" {
+		return DefaultToolEdgeGroup
+	}
+	return t.EdgeGroup
 }
 
 type ToolConfigWorkflow struct {
@@ -241,10 +417,29 @@ func (t *ToolConfigWorkflow) Validate() error {
 }
 
 type ToolConfigMCP struct {
-	Entrypoint string             `json:"entrypoint"`         // In case of stdio, this is the path to the executable
-	Protocol   MCPProtocol        `json:"protocol"`           // Optional field for protocol, one of "stdio", "sse", "grpc"
-	EnvVars    *map[string]string `json:"env_vars,omitempty"` // Optional environment variables for the MCP tool, applicable for stdio
-	ApiKey     *string            `json:"api_key,omitempty"`  // Optional API key for the MCP tool, applicable for HTTP-based tools, i.e, "sse" or "grpc"
+	Entrypoint string             `json:"entrypoint"`          // In case of stdio, this is the path to the executable
+	Protocol   MCPProtocol        `json:"protocol"`            // Optional field for protocol, one of "stdio", "sse", "grpc"
+	EnvVars    *map[string]string `json:"env_vars,omitempty"`  // Optional environment variables for the MCP tool, applicable for stdio
+	ApiKey     *string            `json:"api_key,omitempty"`   // Optional API key for the MCP tool, applicable for HTTP-based tools, i.e, "sse" or "grpc"
+	ToolName   string             `json:"tool_name,omitempty"` // Name of the tool on the MCP server, defaults to the name of the tool
+	TLS        *ToolMCPTLS        `json:"tls,omitempty"`       // Optional TLS settings of the connection, applicable for "grpc"
+	Params     *openapi3.Schema   `json:"params,omitempty"`    // Parameter schema discovered from the MCP server, applicable for "grpc"
+}
+
+// ToolMCPTLS configures the TLS connection to an MCP server speaking gRPC.
+// The connection uses TLS unless the entrypoint has the grpc:// scheme.
+type ToolMCPTLS struct {
+	CACert             string `json:"ca_cert,omitempty"`              // PEM certificates of the authorities trusted for the server, the system roots when empty
+	ServerName         string `json:"server_name,omitempty"`          // Name checked against the server certificate, the host of the entrypoint when empty
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"` // Skip the verification of the server certificate, for development servers only
+}
+
+// RemoteToolName returns the name of the tool on the MCP server
+func (t *ToolConfigMCP) RemoteToolName(name string) string {
+	if t.ToolName != "" {
+		return t.ToolName
+	}
+	return name
 }
 
 func (t *ToolConfigMCP) GetType() ToolType {
@@ -263,7 +458,15 @@ func (t *ToolConfigMCP) Validate() error {
 	if t.Protocol == MCPProtocolStdio && t.EnvVars == nil {
 		return fmt.Errorf("env_vars are required for stdio protocol")
 	}
-	return nil
+	if t.TLS != nil {
+		if t.Protocol != MCPProtocolGRPC {
+			return fmt.Errorf("tls is only applicable for grpc protocol")
+		}
+		if t.TLS.CACert != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(t.TLS.CACert)) {
+			return fmt.Errorf("invalid tls ca_cert, must hold PEM certificates")
+		}
+	}
+	return validateSecretReference(t.ApiKey)
 }
 
 type ToolConfigInternal struct {
@@ -283,15 +486,112 @@ func (t *ToolConfigInternal) Validate() error {
 	return nil
 }
 
+// ToolLimits throttles the invocations of a tool, a zero value leaves the limit disabled
+type ToolLimits struct {
+	InvocationsPerMinute int32 `json:"invocations_per_minute,omitempty"` // Maximum invocations started within a sliding minute
+	MaxConcurrentRuns    int32 `json:"max_concurrent_runs,omitempty"`    // Maximum runs in progress at the same time
+	MaxWaitSeconds       int32 `json:"max_wait_seconds,omitempty"`       // How long an excess call queues before failing as throttled
+}
+
+// Enabled reports whether any limit is set
+func (l *ToolLimits) Enabled() bool {
+	return l != nil && (l.InvocationsPerMinute > 0 || l.MaxConcurrentRuns > 0)
+}
+
+func (l *ToolLimits) Validate() error {
+	if l.InvocationsPerMinute < 0 {
+		return fmt.Errorf("invocations_per_minute must not be negative")
+	}
+	if l.MaxConcurrentRuns < 0 {
+		return fmt.Errorf("max_concurrent_runs must not be negative")
+	}
+	if l.MaxWaitSeconds < 0 {
+		return fmt.Errorf("max_wait_seconds must not be negative")
+	}
+	return nil
+}
+
+// ToolHealthCheck probes a tool in the background, either with a GET request to a URL or by running a command.
+// A 2xx response or a zero exit code passes the check.
+type ToolHealthCheck struct {
+	URL              string   `json:"url,omitempty"`               // URL receiving a GET request
+	Command          []string `json:"command,omitempty"`           // Command and arguments, run by the tools service when allowed by its configuration
+	IntervalSeconds  int32    `json:"interval_seconds,omitempty"`  // Time between two checks, default 60
+	TimeoutSeconds   int32    `json:"timeout_seconds,omitempty"`   // Timeout of a check, default 10
+	FailureThreshold int32    `json:"failure_threshold,omitempty"` // Failed checks in a row before the tool is unreachable, default 3
+}
+
+func (h *ToolHealthCheck) Validate() error {
+	if (h.URL == "") == (len(h.Command) == 0) {
+		return fmt.Errorf("exactly one of url or command is required")
+	}
+	if h.URL != "" {
+		u, err := url.ParseRequestURI(h.URL)
+		if err != nil {
+			return fmt.Errorf("invalid URL format: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("URL must have 'http' or 'https' scheme, got %s", u.Scheme)
+		}
+	}
+	if h.IntervalSeconds < 0 || h.TimeoutSeconds < 0 || h.FailureThreshold < 0 {
+		return fmt.Errorf("interval_seconds, timeout_seconds and failure_threshold must not be negative")
+	}
+	return nil
+}
+
+// ToolCache reuses the results of an idempotent tool, identical calls within the TTL return the cached result without invoking the tool
+type ToolCache struct {
+	Cacheable  bool  `json:"cacheable"`
+	TTLSeconds int32 `json:"ttl_seconds,omitempty"` // How long a result is reused, default 300
+}
+
+// TTL returns how long a result of the tool is reused, zero when the tool is not cacheable
+func (c *ToolCache) TTL() time.Duration {
+	if c == nil || !c.Cacheable {
+		return 0
+	}
+	if c.TTLSeconds == 0 {
+		return 300 * time.Second
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+func (c *ToolCache) Validate() error {
+	if c.TTLSeconds < 0 {
+		return fmt.Errorf("ttl_seconds must not be negative")
+	}
+	return nil
+}
+
 type ToolConfig struct {
-	Type ToolType `json:"type"`
-	C    ToolConfigIntf
+	Type        ToolType `json:"type"`
+	C           ToolConfigIntf
+	Limits      *ToolLimits      `json:"limits,omitempty"`       // Optional invocation limits, shared by every type of tool
+	HealthCheck *ToolHealthCheck `json:"health_check,omitempty"` // Optional background health check, shared by every type of tool
+	Mock        *ToolMock        `json:"mock,omitempty"`         // Optional response returned in dry runs, shared by every type of tool
+	Cache       *ToolCache       `json:"cache,omitempty"`        // Optional result cache of idempotent tools, shared by every type of tool
 }
 
 func (t *ToolConfig) Validate() error {
 	if t.C == nil {
 		return fmt.Errorf("tool config is required")
 	}
+	if t.Limits != nil {
+		if err := t.Limits.Validate(); err != nil {
+			return fmt.Errorf("invalid limits: %w", err)
+		}
+	}
+	if t.HealthCheck != nil {
+		if err := t.HealthCheck.Validate(); err != nil {
+			return fmt.Errorf("invalid health_check: %w", err)
+		}
+	}
+	if t.Cache != nil {
+		if err := t.Cache.Validate(); err != nil {
+			return fmt.Errorf("invalid cache: %w", err)
+		}
+	}
 	return t.C.Validate()
 }
 
@@ -339,12 +639,72 @@ func (t *ToolConfig) GetInternal() *ToolConfigInternal {
 	return nil
 }
 
-// Value and Scan methods for ToolConfigWrapper to implement driver.Valuer and sql.Scanner interfaces
+// APIKey returns the API key of the tool, nil for tools without one
+func (t *ToolConfig) APIKey() *string {
+	switch c := t.C.(type) {
+	case *ToolConfigStandalone:
+		return c.ApiKey
+	case *ToolConfigMCP:
+		return c.ApiKey
+	default:
+		return nil
+	}
+}
+
+// withSecrets returns a copy of the configuration with each secret replaced by transform(secret).
+// Secret references are kept as is, they do not hold the secret.
+func (t ToolConfig) withSecrets(transform func(string) (string, error)) (ToolConfig, error) {
+	apply := func(secret *string) (*string, error) {
+		if secret == nil || secrets.IsReference(*secret) {
+			return secret, nil
+		}
+		v, err := transform(*secret)
+		return &v, err
+	}
+
+	var err error
+	switch c := t.C.(type) {
+	case *ToolConfigStandalone:
+		copied := *c
+		copied.ApiKey, err = apply(c.ApiKey)
+		t.C = &copied
+	case *ToolConfigMCP:
+		copied := *c
+		copied.ApiKey, err = apply(c.ApiKey)
+		t.C = &copied
+	}
+	return t, err
+}
+
+// RestoreRedactedSecrets keeps the secrets of current that were sent back redacted, so a configuration
+// read from the API can be submitted again without resetting its secrets
+func (t *ToolConfig) RestoreRedactedSecrets(current ToolConfig) {
+	restore := func(secret *string) *string {
+		if secret != nil && *secret == RedactedSecret {
+			return current.APIKey()
+		}
+		return secret
+	}
+
+	switch c := t.C.(type) {
+	case *ToolConfigStandalone:
+		c.ApiKey = restore(c.ApiKey)
+	case *ToolConfigMCP:
+		c.ApiKey = restore(c.ApiKey)
+	}
+}
+
+// Value and Scan methods for ToolConfigWrapper to implement driver.Valuer and sql.Scanner interfaces.
+// The secrets are encrypted at rest when a secret key wrapper is configured.
 func (t ToolConfig) Value() (driver.Value, error) {
 	if t.C == nil {
 		return []byte(""), nil
 	}
-	data, err := json.Marshal(t)
+	encrypted, err := t.withSecrets(EncryptSecret)
+	if err != nil {
+		return []byte(""), fmt.Errorf("failed to encrypt tool secrets: %w", err)
+	}
+	data, err := encrypted.marshalJSON()
 	if err != nil {
 		return []byte(""), err
 	}
@@ -365,29 +725,55 @@ func (t *ToolConfig) Scan(src interface{}) error {
 			t.C = nil
 			return nil
 		}
-		return json.Unmarshal(v, t)
+		if err := json.Unmarshal(v, t); err != nil {
+			return err
+		}
 	case string:
 		if v == "" {
 			t.Type = ToolTypeNil
 			t.C = nil
 			return nil
 		}
-		return json.Unmarshal([]byte(v), t)
+		if err := json.Unmarshal([]byte(v), t); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("cannot scan type %T into ToolConfigWrapper", v)
 	}
+
+	decrypted, err := t.withSecrets(DecryptSecret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt tool secrets: %w", err)
+	}
+	*t = decrypted
+	return nil
 }
 
+// MarshalJSON returns the JSON form of the configuration with the secrets redacted
 func (t ToolConfig) MarshalJSON() ([]byte, error) {
+	redacted, _ := t.withSecrets(func(string) (string, error) { return RedactedSecret, nil })
+	return redacted.marshalJSON()
+}
+
+// marshalJSON merges the type and the settings shared by every type of tool with the fields of the configuration
+func (t ToolConfig) marshalJSON() ([]byte, error) {
 	if t.C == nil {
 		return json.Marshal(map[string]interface{}{
 			"type": t.Type,
 		})
 	}
 	b1, err := json.Marshal(struct {
-		Type ToolType `json:"type"`
+		Type        ToolType         `json:"type"`
+		Limits      *ToolLimits      `json:"limits,omitempty"`
+		HealthCheck *ToolHealthCheck `json:"health_check,omitempty"`
+		Mock        *ToolMock        `json:"mock,omitempty"`
+		Cache       *ToolCache       `json:"cache,omitempty"`
 	}{
-		Type: t.Type,
+		Type:        t.Type,
+		Limits:      t.Limits,
+		HealthCheck: t.HealthCheck,
+		Mock:        t.Mock,
+		Cache:       t.Cache,
 	})
 	if err != nil {
 		return nil, err
@@ -398,7 +784,7 @@ func (t ToolConfig) MarshalJSON() ([]byte, error) {
 	}
 	s1 := string(b1[:len(b1)-1])
 	s2 := string(b2[1:])
-	return []byte(s1 + ", " + s2), nil
+	return []byte(s1 + "," + s2), nil
 }
 
 func (t *ToolConfig) UnmarshalJSON(data []byte) error {
@@ -419,6 +805,38 @@ func (t *ToolConfig) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	t.Limits = nil
+	if limitsData, ok := raw["limits"]; ok && string(limitsData) != "null" {
+		t.Limits = &ToolLimits{}
+		if err := json.Unmarshal(limitsData, t.Limits); err != nil {
+			return err
+		}
+	}
+
+	t.HealthCheck = nil
+	if healthCheckData, ok := raw["health_check"]; ok && string(healthCheckData) != "null" {
+		t.HealthCheck = &ToolHealthCheck{}
+		if err := json.Unmarshal(healthCheckData, t.HealthCheck); err != nil {
+			return err
+		}
+	}
+
+	t.Mock = nil
+	if mockData, ok := raw["mock"]; ok && string(mockData) != "null" {
+		t.Mock = &ToolMock{}
+		if err := json.Unmarshal(mockData, t.Mock); err != nil {
+			return err
+		}
+	}
+
+	t.Cache = nil
+	if cacheData, ok := raw["cache"]; ok && string(cacheData) != "null" {
+		t.Cache = &ToolCache{}
+		if err := json.Unmarshal(cacheData, t.Cache); err != nil {
+			return err
+		}
+	}
+
 	switch t.Type {
 	case ToolTypeStandalone:
 		t.C = &ToolConfigStandalone{}
@@ -433,3 +851,11 @@ func (t *ToolConfig) UnmarshalJSON(data []byte) error {
 	}
 	return json.Unmarshal(data, &t.C)
 }
+
+// FlowFailureReason classifies why a flow run failed, stored in the failure_reason column.
+// The failures of the flow itself have no reason.
+type FlowFailureReason string
+
+const (
+	FlowFailureReasonResourceExceeded FlowFailureReason = "RESOURCE_EXCEEDED" // The worker killed the process for going over its resource limits
+)
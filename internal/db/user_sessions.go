@@ -0,0 +1,20 @@
+package db
+
+import "fmt"
+
+// SessionTokenPrefix starts the session tokens of the users logged in with the OIDC provider
+const SessionTokenPrefix = "ps_"
+
+// GenerateSessionToken returns a new random session token and the hash stored instead of the token
+func GenerateSessionToken() (token string, hash string, err error) {
+	token, err = randomToken(SessionTokenPrefix)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	return token, HashSessionToken(token), nil
+}
+
+// HashSessionToken returns the SHA-256 hash of a session token in hex
+func HashSessionToken(token string) string {
+	return hashToken(token)
+}
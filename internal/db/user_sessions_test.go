@@ -0,0 +1,24 @@
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_GenerateSessionToken(t *testing.T) {
+	t.Parallel()
+
+	token, hash, err := GenerateSessionToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(token, SessionTokenPrefix) || len(token) != len(SessionTokenPrefix)+64 {
+		t.Fatalf("expected a ps_ token of 64 hex characters, got %s", token)
+	}
+	if hash != HashSessionToken(token) || len(hash) != 64 {
+		t.Fatalf("expected the hash of the token, got %s", hash)
+	}
+	if other, _, _ := GenerateSessionToken(); other == token {
+		t.Fatalf("expected the tokens to be random")
+	}
+}
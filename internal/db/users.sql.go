@@ -166,6 +166,17 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow
 	return i, err
 }
 
+const getUserDefaultAgent = `-- name: GetUserDefaultAgent :one
+SELECT default_agent_id FROM users WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserDefaultAgent(ctx context.Context, id uuid.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getUserDefaultAgent, id)
+	var default_agent_id pgtype.UUID
+	err := row.Scan(&default_agent_id)
+	return default_agent_id, err
+}
+
 const getUsers = `-- name: GetUsers :many
 SELECT id, name, email, additional_info, provider_name, is_online, created_at, updated_at FROM users ORDER BY name
 `
@@ -210,6 +221,21 @@ func (q *Queries) GetUsers(ctx context.Context) ([]GetUsersRow, error) {
 	return items, nil
 }
 
+const isUserAdmin = `-- name: IsUserAdmin :one
+SELECT EXISTS (
+    SELECT 1 FROM user_role_mapping m JOIN roles r ON r.id = m.role_id
+    WHERE m.user_id = $1 AND r.name = 'admin' AND r.is_system
+)
+`
+
+// Reports whether the user has the admin system role, managing the users, their API keys, roles and permissions
+func (q *Queries) IsUserAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isUserAdmin, userID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const listRolesForUser = `-- name: ListRolesForUser :many
 SELECT mapping_id, user_id, role_id, assigned_at, assigned_by FROM user_role_mapping WHERE user_id = $1 ORDER BY assigned_at DESC
 `
@@ -254,6 +280,22 @@ func (q *Queries) RemoveRoleFromUser(ctx context.Context, arg RemoveRoleFromUser
 	return err
 }
 
+const setUserDefaultAgent = `-- name: SetUserDefaultAgent :exec
+UPDATE users
+SET default_agent_id = $1
+WHERE id = $2
+`
+
+type SetUserDefaultAgentParams struct {
+	DefaultAgentID pgtype.UUID `db:"default_agent_id" json:"default_agent_id"`
+	ID             uuid.UUID   `db:"id" json:"id"`
+}
+
+func (q *Queries) SetUserDefaultAgent(ctx context.Context, arg SetUserDefaultAgentParams) error {
+	_, err := q.db.Exec(ctx, setUserDefaultAgent, arg.DefaultAgentID, arg.ID)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET name = $1, email = $2, additional_info = $3, provider_name = $4
@@ -302,6 +344,17 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 	return i, err
 }
 
+const updateUserLastLogin = `-- name: UpdateUserLastLogin :exec
+UPDATE users
+SET last_login = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, updateUserLastLogin, id)
+	return err
+}
+
 const updateUserOnlineState = `-- name: UpdateUserOnlineState :exec
 UPDATE users
 SET is_online = $1
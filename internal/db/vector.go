@@ -0,0 +1,79 @@
+package db
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EmbeddingDimensions is the size of the embeddings of the message_embeddings table, the embedding models must be
+// configured to return vectors of this size
+const EmbeddingDimensions = 1024
+
+// Vector is a pgvector vector, sent and scanned in its text format "[1,2,3]"
+type Vector []float32
+
+// String returns the text format of the vector
+func (v Vector) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func (v Vector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
+func (v *Vector) Scan(src any) error {
+	var text string
+	switch s := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		text = string(s)
+	case string:
+		text = s
+	default:
+		return fmt.Errorf("cannot scan type %T into Vector", src)
+	}
+	parsed, err := ParseVector(text)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// ParseVector parses the text format of a pgvector vector
+func ParseVector(text string) (Vector, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("invalid vector %q, expected [x,y,...]", text)
+	}
+	text = strings.TrimSpace(text[1 : len(text)-1])
+	if text == "" {
+		return Vector{}, nil
+	}
+	parts := strings.Split(text, ",")
+	v := make(Vector, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector element %q: %w", part, err)
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
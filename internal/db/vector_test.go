@@ -0,0 +1,35 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Vector(t *testing.T) {
+	t.Parallel()
+
+	v := Vector{0.5, -1, 0.125, 3e-7}
+	if got := v.String(); got != "[0.5,-1,0.125,3e-07]" {
+		t.Fatalf("unexpected text format %s", got)
+	}
+
+	var scanned Vector
+	if err := scanned.Scan([]byte(v.String())); err != nil {
+		t.Fatalf("failed to scan vector: %v", err)
+	}
+	if !reflect.DeepEqual(scanned, v) {
+		t.Fatalf("expected %v, got %v", v, scanned)
+	}
+
+	if err := scanned.Scan(nil); err != nil || scanned != nil {
+		t.Fatalf("expected a nil vector, got %v (%v)", scanned, err)
+	}
+	if err := scanned.Scan("[]"); err != nil || len(scanned) != 0 {
+		t.Fatalf("expected an empty vector, got %v (%v)", scanned, err)
+	}
+	for _, invalid := range []string{"1,2", "[1,x]", "[1,,2]"} {
+		if _, err := ParseVector(invalid); err == nil {
+			t.Fatalf("expected an error parsing %q", invalid)
+		}
+	}
+}
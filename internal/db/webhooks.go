@@ -0,0 +1,74 @@
+package db
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	// WebhookSecretPrefix starts the signing secrets of the webhooks
+	WebhookSecretPrefix = "whsec_"
+
+	WebhookEventTaskFinished     = "task.finished"      // A task of the user finished
+	WebhookEventFlowRunSucceeded = "flow_run.succeeded" // A flow run succeeded
+	WebhookEventFlowRunFailed    = "flow_run.failed"    // A flow run failed after its last retry
+)
+
+// WebhookEvents are the platform events a webhook can subscribe to
+var WebhookEvents = []string{WebhookEventTaskFinished, WebhookEventFlowRunSucceeded, WebhookEventFlowRunFailed}
+
+// GenerateWebhookSecret returns a new random secret signing the deliveries of a webhook
+func GenerateWebhookSecret() (string, error) {
+	secret, err := randomToken(WebhookSecretPrefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secret, nil
+}
+
+// ValidateWebhookURL checks the URL the deliveries of a webhook are posted to. The local hosts and the private
+// addresses are rejected, the host names resolving to them are refused when the deliveries are sent.
+func ValidateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("url must not target a local host")
+	}
+	if ip := net.ParseIP(host); ip != nil && IsPrivateNetworkAddress(ip) {
+		return fmt.Errorf("url must not target a loopback, private or link-local address")
+	}
+	return nil
+}
+
+// IsPrivateNetworkAddress reports whether the webhooks and the fetches of the tools cannot reach an address: a
+// loopback, private, link-local, multicast or unspecified address, such as the cloud metadata endpoint or an internal
+// service
+func IsPrivateNetworkAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
+
+// NormalizeWebhookEvents validates the events of a webhook and returns them sorted without duplicates
+func NormalizeWebhookEvents(events []string) ([]string, error) {
+	var normalized []string
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(WebhookEvents, event) {
+			return nil, fmt.Errorf("invalid event %q, valid events are: %s", event, strings.Join(WebhookEvents, ", "))
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("at least one event is required, valid events are: %s", strings.Join(WebhookEvents, ", "))
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	db_pool := setupTestDB(t)
+	defer db_pool.Close()
+	queries := New(db_pool)
+
+	createUserParams := CreateUserParams{
+		Name:           "testuser_webhooks_unique",
+		Email:          "webhooks_unique@example.com",
+		AdditionalInfo: JsonRaw{},
+		PasswordHash:   "hashedpassword123",
+		ProviderName:   ProviderNameLocal,
+	}
+	createdUser, err := queries.CreateUser(t.Context(), createUserParams)
+	if err != nil {
+		t.Logf("User already exists, using existing user: %v", err)
+		user, err := queries.GetUserByEmail(t.Context(), createUserParams.Email)
+		if err != nil {
+			t.Fatalf("Failed to get existing user by email: %v", err)
+		}
+		createdUser = CreateUserRow(user)
+	}
+
+	webhook, err := queries.CreateWebhook(t.Context(), CreateWebhookParams{
+		UserID:  createdUser.ID,
+		Url:     "https://hooks.example.com/pinazu",
+		Events:  []string{WebhookEventTaskFinished},
+		Secret:  "whsec_test",
+		Enabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	defer queries.DeleteWebhook(t.Context(), DeleteWebhookParams{ID: webhook.ID, UserID: createdUser.ID})
+
+	enqueue := func(eventID uuid.UUID, eventType string, userID uuid.UUID) int64 {
+		count, err := queries.EnqueueWebhookDeliveries(t.Context(), EnqueueWebhookDeliveriesParams{
+			EventID:   eventID,
+			EventType: eventType,
+			Payload:   JsonRaw(`{"type": "` + eventType + `"}`),
+			UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("Failed to enqueue webhook deliveries: %v", err)
+		}
+		return count
+	}
+
+	// Only the webhooks of the user subscribed to the event get a delivery, once per event
+	eventID := uuid.New()
+	assert.Equal(t, int64(1), enqueue(eventID, WebhookEventTaskFinished, createdUser.ID))
+	assert.Zero(t, enqueue(eventID, WebhookEventTaskFinished, createdUser.ID), "An event should be recorded once")
+	assert.Zero(t, enqueue(uuid.New(), WebhookEventTaskFinished, uuid.New()), "The webhooks of another user should not get the event")
+	assert.Zero(t, enqueue(uuid.New(), WebhookEventFlowRunFailed, createdUser.ID), "A webhook should only get its events")
+
+	deliveries, err := queries.ListWebhookDeliveries(t.Context(), ListWebhookDeliveriesParams{WebhookID: webhook.ID, RowLimit: 100})
+	if err != nil {
+		t.Fatalf("Failed to list webhook deliveries: %v", err)
+	}
+	if !assert.Len(t, deliveries, 1) {
+		return
+	}
+	delivery := deliveries[0]
+	assert.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, eventID, delivery.EventID)
+
+	// An attempt is claimed once
+	claim := ClaimWebhookDeliveryParams{
+		LeaseUntil: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+		ID:         delivery.ID,
+		DueAt:      delivery.NextAttemptAt,
+	}
+	claimed, err := queries.ClaimWebhookDelivery(t.Context(), claim)
+	if err != nil {
+		t.Fatalf("Failed to claim webhook delivery: %v", err)
+	}
+	assert.Equal(t, int64(1), claimed)
+	claimed, err = queries.ClaimWebhookDelivery(t.Context(), claim)
+	if err != nil {
+		t.Fatalf("Failed to claim webhook delivery: %v", err)
+	}
+	assert.Zero(t, claimed, "An attempt should not be claimed twice")
+
+	err = queries.RecordWebhookDeliveryAttempt(t.Context(), RecordWebhookDeliveryAttemptParams{
+		Status:        WebhookDeliveryStatusSucceeded,
+		NextAttemptAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		ID:            delivery.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to record webhook delivery attempt: %v", err)
+	}
+	delivery, err = queries.GetWebhookDelivery(t.Context(), GetWebhookDeliveryParams{ID: delivery.ID, WebhookID: webhook.ID})
+	if err != nil {
+		t.Fatalf("Failed to get webhook delivery: %v", err)
+	}
+	assert.Equal(t, WebhookDeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, int32(1), delivery.Attempts)
+	assert.True(t, delivery.DeliveredAt.Valid)
+
+	// An ended delivery can be delivered again
+	redelivered, err := queries.RedeliverWebhookDelivery(t.Context(), RedeliverWebhookDeliveryParams{ID: delivery.ID, WebhookID: webhook.ID})
+	if err != nil {
+		t.Fatalf("Failed to redeliver webhook delivery: %v", err)
+	}
+	assert.Equal(t, WebhookDeliveryStatusPending, redelivered.Status)
+	assert.Zero(t, redelivered.Attempts)
+}
+
+func TestWebhookDeliveriesWorkspace(t *testing.T) {
+	t.Parallel()
+	db_pool := setupTestDB(t)
+	defer db_pool.Close()
+	queries := New(db_pool)
+
+	user, err := queries.CreateUser(t.Context(), CreateUserParams{
+		Name:           uniqueName("testuser_webhooks_workspace"),
+		Email:          uniqueName("webhooks_workspace") + "@example.com",
+		AdditionalInfo: JsonRaw{},
+		PasswordHash:   "hashedpassword123",
+		ProviderName:   ProviderNameLocal,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	defer queries.DeleteUser(t.Context(), user.ID)
+
+	webhook, err := queries.CreateWebhook(t.Context(), CreateWebhookParams{
+		UserID:  user.ID,
+		Url:     "https://hooks.example.com/pinazu",
+		Events:  []string{WebhookEventFlowRunFailed},
+		Secret:  "whsec_test",
+		Enabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	defer queries.DeleteWebhook(t.Context(), DeleteWebhookParams{ID: webhook.ID, UserID: user.ID})
+
+	organization, err := queries.CreateOrganization(t.Context(), uniqueName("webhooks_organization"))
+	if err != nil {
+		t.Fatalf("Failed to create test organization: %v", err)
+	}
+	workspace, err := queries.CreateWorkspace(t.Context(), CreateWorkspaceParams{OrganizationID: organization.ID, Name: "Webhooks"})
+	if err != nil {
+		t.Fatalf("Failed to create test workspace: %v", err)
+	}
+
+	enqueue := func(workspaceID pgtype.UUID) int64 {
+		count, err := queries.EnqueueWebhookDeliveries(t.Context(), EnqueueWebhookDeliveriesParams{
+			EventID:     uuid.New(),
+			EventType:   WebhookEventFlowRunFailed,
+			Payload:     JsonRaw(`{"type": "flow_run.failed"}`),
+			WorkspaceID: workspaceID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to enqueue webhook deliveries: %v", err)
+		}
+		return count
+	}
+	countDeliveries := func() int {
+		deliveries, err := queries.ListWebhookDeliveries(t.Context(), ListWebhookDeliveriesParams{WebhookID: webhook.ID, RowLimit: 100})
+		if err != nil {
+			t.Fatalf("Failed to list webhook deliveries: %v", err)
+		}
+		return len(deliveries)
+	}
+
+	// The event of a workspace is only delivered to the users acting in it
+	enqueue(pgtype.UUID{Bytes: workspace.ID, Valid: true})
+	assert.Zero(t, countDeliveries(), "A user outside of the workspace should not get its events")
+
+	if _, err := queries.UpsertWorkspaceMember(t.Context(), UpsertWorkspaceMemberParams{WorkspaceID: workspace.ID, UserID: user.ID, Role: WorkspaceRoleMember}); err != nil {
+		t.Fatalf("Failed to add workspace member: %v", err)
+	}
+	enqueue(pgtype.UUID{Bytes: workspace.ID, Valid: true})
+	assert.Equal(t, 1, countDeliveries(), "A member of the workspace should get its events")
+
+	// The events without a workspace are delivered to every user subscribed to them
+	enqueue(pgtype.UUID{})
+	assert.Equal(t, 2, countDeliveries())
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_GenerateWebhookSecret(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(secret, WebhookSecretPrefix) || len(secret) != len(WebhookSecretPrefix)+64 {
+		t.Fatalf("expected a whsec_ secret of 64 hex characters, got %s", secret)
+	}
+	if other, _ := GenerateWebhookSecret(); other == secret {
+		t.Fatalf("expected the secrets to be random")
+	}
+}
+
+func Test_NormalizeWebhookEvents(t *testing.T) {
+	t.Parallel()
+
+	events, err := NormalizeWebhookEvents([]string{"task.finished", " Flow_Run.Failed", "task.finished"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"flow_run.failed", "task.finished"}; !slices.Equal(events, expected) {
+		t.Fatalf("expected %v, got %v", expected, events)
+	}
+	for _, invalid := range [][]string{nil, {}, {"task.started"}, {"task.finished", "*"}} {
+		if _, err := NormalizeWebhookEvents(invalid); err == nil {
+			t.Fatalf("expected events %v to be rejected", invalid)
+		}
+	}
+}
+
+func Test_ValidateWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	for _, valid := range []string{"https://hooks.example.com/pinazu", "http://93.184.215.14:8080/webhook", "https://[2606:4700::1111]/hook"} {
+		if err := ValidateWebhookURL(valid); err != nil {
+			t.Fatalf("expected %s to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{
+		"", "hooks.example.com", "ftp://example.com", "https://",
+		// The local and private targets
+		"http://localhost:8080/webhook", "http://api.localhost/hook", "http://LOCALHOST./hook",
+		"http://127.0.0.1/hook", "http://[::1]/hook", "http://0.0.0.0/hook", "http://[::ffff:127.0.0.1]/hook",
+		"http://169.254.169.254/latest/meta-data", "http://10.0.0.5/hook", "http://172.16.3.4/hook", "http://192.168.1.1/hook",
+		"http://[fd00::1]/hook", "http://[fe80::1]/hook",
+	} {
+		if err := ValidateWebhookURL(invalid); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -13,10 +13,11 @@ import (
 
 const deleteInactiveWorkers = `-- name: DeleteInactiveWorkers :exec
 DELETE FROM worker_heartbeats 
-WHERE status = 'INACTIVE' 
+WHERE status IN ('INACTIVE', 'FAILED') 
 AND updated_at < $1
 `
 
+// Removes the workers stopped or failed for longer than the retention
 func (q *Queries) DeleteInactiveWorkers(ctx context.Context, updatedAt pgtype.Timestamptz) error {
 	_, err := q.db.Exec(ctx, deleteInactiveWorkers, updatedAt)
 	return err
@@ -146,6 +147,44 @@ func (q *Queries) GetWorkerStats(ctx context.Context) (GetWorkerStatsRow, error)
 	return i, err
 }
 
+const markStaleWorkersFailed = `-- name: MarkStaleWorkersFailed :many
+UPDATE worker_heartbeats
+SET status = 'FAILED',
+    updated_at = NOW()
+WHERE last_heartbeat < $1
+AND status = 'ACTIVE'
+RETURNING worker_id, worker_name, status, last_heartbeat, worker_info, created_at, updated_at
+`
+
+// Marks the active workers without heartbeat since the given time as failed and returns them
+func (q *Queries) MarkStaleWorkersFailed(ctx context.Context, lastHeartbeat pgtype.Timestamptz) ([]WorkerHeartbeat, error) {
+	rows, err := q.db.Query(ctx, markStaleWorkersFailed, lastHeartbeat)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WorkerHeartbeat{}
+	for rows.Next() {
+		var i WorkerHeartbeat
+		if err := rows.Scan(
+			&i.WorkerID,
+			&i.WorkerName,
+			&i.Status,
+			&i.LastHeartbeat,
+			&i.WorkerInfo,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markStaleWorkersInactive = `-- name: MarkStaleWorkersInactive :exec
 UPDATE worker_heartbeats 
 SET status = 'INACTIVE',
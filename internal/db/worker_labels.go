@@ -0,0 +1,56 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// MaxWorkerLabels bounds the labels of a worker and the labels required by a flow. A worker consumes the flow runs
+// of every subset of its labels, the bound keeps the consumers of a worker under 2^MaxWorkerLabels.
+const MaxWorkerLabels = 6
+
+// workerLabelPattern restricts the labels, e.g. gpu, region=eu-west-1 or python3.12
+var workerLabelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.=-]{0,62}$`)
+
+// NormalizeWorkerLabels validates the labels of a worker or the labels required by a flow and returns them sorted
+// without duplicates. It returns nil when there are no labels.
+func NormalizeWorkerLabels(labels []string) ([]string, error) {
+	var normalized []string
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if !workerLabelPattern.MatchString(label) {
+			return nil, fmt.Errorf("invalid label %q, only letters, digits, '_', '.', '=' and '-' are allowed, up to 63 characters", label)
+		}
+		if !slices.Contains(normalized, label) {
+			normalized = append(normalized, label)
+		}
+	}
+	if len(normalized) > MaxWorkerLabels {
+		return nil, fmt.Errorf("too many labels, at most %d are allowed", MaxWorkerLabels)
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// WorkerLabelsKey returns the key of a set of labels, a single NATS subject token whatever the characters of the labels
+func WorkerLabelsKey(labels []string) string {
+	sorted := slices.Clone(labels)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// HasWorkerLabels reports whether a worker advertising a set of labels can execute the runs of a flow requiring others
+func HasWorkerLabels(advertised []string, required []string) bool {
+	for _, label := range required {
+		if !slices.Contains(advertised, label) {
+			return false
+		}
+	}
+	return true
+}
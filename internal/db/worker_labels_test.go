@@ -0,0 +1,55 @@
+package db
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_NormalizeWorkerLabels(t *testing.T) {
+	t.Parallel()
+
+	labels, err := NormalizeWorkerLabels([]string{"python3.12", " gpu", "region=eu-west-1", "gpu"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"gpu", "python3.12", "region=eu-west-1"}; !slices.Equal(labels, expected) {
+		t.Fatalf("expected %v, got %v", expected, labels)
+	}
+
+	if labels, err := NormalizeWorkerLabels(nil); err != nil || labels != nil {
+		t.Fatalf("expected no labels, got %v, %v", labels, err)
+	}
+	for _, invalid := range []string{"", "gpu a100", "gpu>", "-gpu", "region/eu"} {
+		if _, err := NormalizeWorkerLabels([]string{invalid}); err == nil {
+			t.Fatalf("expected label %q to be rejected", invalid)
+		}
+	}
+	if _, err := NormalizeWorkerLabels([]string{"a", "b", "c", "d", "e", "f", "g"}); err == nil {
+		t.Fatalf("expected more than %d labels to be rejected", MaxWorkerLabels)
+	}
+}
+
+func Test_WorkerLabelsKey(t *testing.T) {
+	t.Parallel()
+
+	key := WorkerLabelsKey([]string{"gpu", "python3.12"})
+	if key != WorkerLabelsKey([]string{"python3.12", "gpu", "gpu"}) {
+		t.Fatalf("expected the key not to depend on the order and the duplicates of the labels")
+	}
+	if key == WorkerLabelsKey([]string{"gpu.python3", "12"}) || key == WorkerLabelsKey([]string{"gpu"}) {
+		t.Fatalf("expected different label sets to have different keys")
+	}
+	if !toolEdgeGroupPattern.MatchString(key) {
+		t.Fatalf("expected the key %q to be a single subject token", key)
+	}
+
+	if !HasWorkerLabels([]string{"gpu", "python3.12", "region=eu"}, []string{"gpu", "region=eu"}) {
+		t.Fatalf("expected a worker with the required labels to match")
+	}
+	if HasWorkerLabels([]string{"python3.12"}, []string{"gpu"}) {
+		t.Fatalf("expected a worker without the required labels not to match")
+	}
+	if !HasWorkerLabels(nil, nil) {
+		t.Fatalf("expected any worker to match a flow without required labels")
+	}
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// DefaultOrganizationID is the organization of the default workspace
+	DefaultOrganizationID = uuid.MustParse("550e8400-c95b-4444-7777-000000000001")
+
+	// DefaultWorkspaceID is the workspace of the resources created before the workspaces and of the requests and
+	// events without a workspace. Every user can act in it.
+	DefaultWorkspaceID = uuid.MustParse("550e8400-c95b-4444-7777-000000000002")
+)
+
+// WorkspaceUUID returns a workspace as the nullable workspace of the tools, a null workspace being the shared tools
+func WorkspaceUUID(workspaceID uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: workspaceID, Valid: workspaceID != uuid.Nil}
+}
+
+// ManagesWorkspaces tells whether the role manages the members and the workspaces of its organization
+func (r OrganizationRole) ManagesWorkspaces() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRoleAdmin
+}
+
+// Valid tells whether the role is a role of the organization members
+func (r OrganizationRole) Valid() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRoleAdmin || r == OrganizationRoleMember
+}
+
+// Valid tells whether the role is a role of the workspace members
+func (r WorkspaceRole) Valid() bool {
+	return r == WorkspaceRoleAdmin || r == WorkspaceRoleMember
+}
@@ -0,0 +1,130 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// Embedder embeds texts into vectors of db.EmbeddingDimensions dimensions, in the order of the texts
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([]db.Vector, error)
+}
+
+// newEmbedder creates the embedder of the configured provider, nil when no provider is configured
+func newEmbedder(ctx context.Context, cfg *service.EmbeddingsConfig) (Embedder, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "openai":
+		var opts []option.RequestOption
+		if cfg.APIKey != "" {
+			opts = append(opts, option.WithAPIKey(cfg.APIKey))
+		}
+		client := openai.NewClient(opts...)
+		return &openAIEmbedder{client: &client, model: cfg.Model}, nil
+	case "bedrock":
+		var opts []func(*config.LoadOptions) error
+		if cfg.Region != "" {
+			opts = append(opts, config.WithRegion(cfg.Region))
+		}
+		awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+		}
+		return &bedrockEmbedder{client: bedrockruntime.NewFromConfig(awsCfg), model: cfg.Model}, nil
+	default:
+		return nil, fmt.Errorf("unsupported embeddings provider %q, expected openai or bedrock", cfg.Provider)
+	}
+}
+
+// openAIEmbedder embeds the texts with the OpenAI embeddings API, a request embedding every text
+type openAIEmbedder struct {
+	client *openai.Client
+	model  string
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([]db.Vector, error) {
+	res, err := e.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Input:      openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: texts},
+		Model:      openai.EmbeddingModel(e.model),
+		Dimensions: openai.Int(db.EmbeddingDimensions),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embeddings: %w", err)
+	}
+	vectors := make([]db.Vector, len(texts))
+	for _, d := range res.Data {
+		if d.Index < 0 || int(d.Index) >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		v := make(db.Vector, len(d.Embedding))
+		for i, f := range d.Embedding {
+			v[i] = float32(f)
+		}
+		vectors[d.Index] = v
+	}
+	return vectors, checkVectors(vectors)
+}
+
+// bedrockEmbedder embeds the texts with an Amazon Titan text embeddings model, a request per text
+type bedrockEmbedder struct {
+	client *bedrockruntime.Client
+	model  string
+}
+
+type (
+	titanEmbeddingRequest struct {
+		InputText  string `json:"inputText"`
+		Dimensions int    `json:"dimensions"`
+		Normalize  bool   `json:"normalize"`
+	}
+
+	titanEmbeddingResponse struct {
+		Embedding []float32 `json:"embedding"`
+	}
+)
+
+func (e *bedrockEmbedder) Embed(ctx context.Context, texts []string) ([]db.Vector, error) {
+	vectors := make([]db.Vector, len(texts))
+	for i, text := range texts {
+		body, err := json.Marshal(titanEmbeddingRequest{InputText: text, Dimensions: db.EmbeddingDimensions, Normalize: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal embedding request: %w", err)
+		}
+		out, err := e.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+			ModelId:     aws.String(e.model),
+			Body:        body,
+			ContentType: aws.String("application/json"),
+			Accept:      aws.String("application/json"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to invoke embedding model: %w", err)
+		}
+		var res titanEmbeddingResponse
+		if err := json.Unmarshal(out.Body, &res); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal embedding response: %w", err)
+		}
+		vectors[i] = res.Embedding
+	}
+	return vectors, checkVectors(vectors)
+}
+
+// checkVectors checks the embedder returned a vector of db.EmbeddingDimensions dimensions for every text, the
+// message_embeddings table rejecting the other sizes
+func checkVectors(vectors []db.Vector) error {
+	for i, v := range vectors {
+		if len(v) != db.EmbeddingDimensions {
+			return fmt.Errorf("embedding %d has %d dimensions, expected %d", i, len(v), db.EmbeddingDimensions)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package embeddings
+
+import (
+	"testing"
+
+	"github.com/pinazu/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateText(t *testing.T) {
+	assert.Equal(t, "hello", truncateText("hello", 10))
+	assert.Equal(t, "hel", truncateText("hello", 3))
+	// Multi-byte characters are not split
+	assert.Equal(t, "héé", truncateText("hééllo", 3))
+}
+
+func TestSimilarMessages(t *testing.T) {
+	rows := []db.SemanticSearchMessagesRow{{Similarity: 0.9}, {Similarity: 0.5}, {Similarity: 0.2}}
+	assert.Len(t, similarMessages(rows, 0.3), 2)
+	assert.Len(t, similarMessages(rows, 0.1), 3)
+	assert.Empty(t, similarMessages(rows, 0.95))
+}
+
+func TestCheckVectors(t *testing.T) {
+	assert.NoError(t, checkVectors([]db.Vector{make(db.Vector, db.EmbeddingDimensions)}))
+	assert.Error(t, checkVectors([]db.Vector{make(db.Vector, 1536)}))
+	// A text the provider returned no embedding for
+	assert.Error(t, checkVectors([]db.Vector{make(db.Vector, db.EmbeddingDimensions), nil}))
+}
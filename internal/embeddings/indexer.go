@@ -0,0 +1,78 @@
+package embeddings
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pinazu/internal/db"
+)
+
+// runIndexer embeds the messages missing an up to date embedding until the service shuts down. The instances of the
+// service may embed a message twice, the last embedding is kept.
+func (es *EmbeddingService) runIndexer() {
+	ticker := time.NewTicker(time.Duration(es.cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-es.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := es.embedPendingMessages(); err != nil && !db.IsUnavailable(err) {
+				es.log.Error("Failed to embed messages", "error", err)
+			}
+		}
+	}
+}
+
+// embedPendingMessages embeds batches of the messages missing an up to date embedding until none is left. A batch
+// failing to embed is attempted again at the next poll.
+func (es *EmbeddingService) embedPendingMessages() error {
+	queries := db.New(es.s.GetDB())
+	for es.ctx.Err() == nil {
+		messages, err := queries.ListMessagesToEmbed(es.ctx, db.ListMessagesToEmbedParams{
+			Model:    es.cfg.Model,
+			RowLimit: int32(es.cfg.BatchSize),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list messages to embed: %w", err)
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		texts := make([]string, len(messages))
+		for i, m := range messages {
+			texts[i] = truncateText(m.Text, es.cfg.MaxChars)
+		}
+		vectors, err := es.embedder.Embed(es.ctx, texts)
+		if err != nil {
+			return fmt.Errorf("failed to embed messages: %w", err)
+		}
+		for i, m := range messages {
+			if err := queries.UpsertMessageEmbedding(es.ctx, db.UpsertMessageEmbeddingParams{
+				MessageID: m.ID,
+				ThreadID:  m.ThreadID,
+				Model:     es.cfg.Model,
+				Embedding: vectors[i],
+			}); err != nil {
+				return fmt.Errorf("failed to save embedding of message %s: %w", m.ID, err)
+			}
+		}
+		es.log.Debug("Embedded messages", "count", len(messages), "model", es.cfg.Model)
+
+		if len(messages) < es.cfg.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// truncateText returns the first maxChars characters of a text
+func truncateText(text string, maxChars int) string {
+	if utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:maxChars])
+}
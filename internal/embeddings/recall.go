@@ -0,0 +1,98 @@
+package embeddings
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// maxRecallLimit bounds the messages recalled by a request
+const maxRecallLimit = 50
+
+// handleMessageRecall answers a recall request with the messages nearest to its query among the threads visible to
+// the user of the request
+func (es *EmbeddingService) handleMessageRecall(msg *nats.Msg) {
+	_, span := es.s.GetTracer().Start(es.ctx, "handleMessageRecall")
+	defer span.End()
+
+	data, err := service.ParseEvent[*service.MessageRecallRequestEventMessage](msg.Data)
+	if err != nil {
+		es.log.Error("Failed to parse message recall request", "error", err)
+		var (
+			header *service.EventHeaders
+			meta   *service.EventMetadata
+		)
+		if data != nil {
+			header, meta = data.H, data.M
+		}
+		service.NewErrorEvent[*service.MessageRecallResponseEventMessage](header, meta, err).Respond(msg)
+		return
+	}
+
+	snippets, err := es.recall(data.H, data.Msg)
+	if err != nil {
+		es.log.Error("Failed to recall messages", "error", err)
+		service.NewErrorEvent[*service.MessageRecallResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+	response := service.Event[*service.MessageRecallResponseEventMessage]{
+		H:   data.H,
+		Msg: &service.MessageRecallResponseEventMessage{Snippets: snippets},
+		M:   data.M,
+	}
+	if err := response.Respond(msg); err != nil {
+		es.log.Error("Failed to respond to message recall request", "error", err)
+	}
+}
+
+// recall embeds the query of a request and returns the messages nearest to it visible to the user of the headers
+func (es *EmbeddingService) recall(header *service.EventHeaders, req *service.MessageRecallRequestEventMessage) ([]db.SemanticSearchMessagesRow, error) {
+	if es.embedder == nil {
+		return nil, fmt.Errorf("no embeddings provider is configured")
+	}
+	if header == nil {
+		return nil, fmt.Errorf("the headers of the request are required")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = es.cfg.RecallLimit
+	}
+	limit = min(limit, maxRecallLimit)
+
+	vectors, err := es.embedder.Embed(es.ctx, []string{truncateText(req.Query, es.cfg.MaxChars)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	params := db.SemanticSearchMessagesParams{
+		Embedding:   vectors[0],
+		Model:       es.cfg.Model,
+		WorkspaceID: header.Workspace(),
+		UserID:      header.UserID,
+		RowLimit:    int32(limit),
+	}
+	if req.ThreadId != nil {
+		params.ThreadID = pgtype.UUID{Bytes: *req.ThreadId, Valid: true}
+	}
+	if req.ExcludeThreadId != nil {
+		params.ExcludeThreadID = pgtype.UUID{Bytes: *req.ExcludeThreadId, Valid: true}
+	}
+	rows, err := db.New(es.s.GetDB()).SemanticSearchMessages(es.ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+	return similarMessages(rows, es.cfg.MinSimilarity), nil
+}
+
+// similarMessages returns the messages at least as similar to the query as minSimilarity, the rows being ordered from
+// the most similar
+func similarMessages(rows []db.SemanticSearchMessagesRow, minSimilarity float32) []db.SemanticSearchMessagesRow {
+	for i, row := range rows {
+		if row.Similarity < minSimilarity {
+			return rows[:i]
+		}
+	}
+	return rows
+}
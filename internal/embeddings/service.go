@@ -0,0 +1,68 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/service"
+)
+
+type EmbeddingService struct {
+	s        service.Service
+	cfg      *service.EmbeddingsConfig
+	embedder Embedder // nil when no provider is configured
+	log      hclog.Logger
+	wg       *sync.WaitGroup
+	ctx      context.Context
+}
+
+// NewService creates a new EmbeddingService instance
+func NewService(ctx context.Context, externalDependenciesConfig *service.ExternalDependenciesConfig, log hclog.Logger, wg *sync.WaitGroup) (*EmbeddingService, error) {
+	if externalDependenciesConfig == nil {
+		return nil, fmt.Errorf("externalDependenciesConfig is nil")
+	}
+	cfg := externalDependenciesConfig.GetEmbeddingsConfig()
+	embedder, err := newEmbedder(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedder: %w", err)
+	}
+
+	// Create a new service instance
+	config := &service.Config{
+		Name:                 "embeddings-service",
+		Version:              "0.0.1",
+		Description:          "Embeddings service embedding the messages of the threads and recalling the messages nearest to a query.",
+		ExternalDependencies: externalDependenciesConfig,
+		ErrorHandler:         nil,
+	}
+	s, err := service.NewService(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embeddings service: %w", err)
+	}
+
+	es := &EmbeddingService{s: s, cfg: cfg, embedder: embedder, log: log, wg: wg, ctx: ctx}
+
+	// Answer the semantic searches of the API and the recalls of the agents
+	s.RegisterHandler(service.MessageRecallRequestEventSubject.String(), es.handleMessageRecall)
+
+	// Embed the messages once created or changed
+	if embedder != nil {
+		go es.runIndexer()
+	} else {
+		es.log.Warn("No embeddings provider is configured, the messages are not embedded")
+	}
+
+	// Start a goroutine to wait for context cancellation and then shutdown
+	go func() {
+		<-ctx.Done()
+		es.log.Warn("Embeddings service shutting down...")
+		if err := es.s.Shutdown(); err != nil {
+			es.log.Error("Error during embeddings service shutdown", "error", err)
+		}
+		es.wg.Done()
+	}()
+
+	return es, nil
+}
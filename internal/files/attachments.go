@@ -0,0 +1,159 @@
+package files
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+// MaxAttachments bounds the files attached to a message
+const MaxAttachments = 20
+
+var errNotConfigured = errors.New("the S3 storage of the files is not configured")
+
+type (
+	// blockSource is the source of an image or document content block
+	blockSource struct {
+		Type      string `json:"type"`
+		MediaType string `json:"media_type"`
+		Data      string `json:"data"`
+	}
+
+	// contentBlock is the image or document content block an attachment is given to the agents as
+	contentBlock struct {
+		Type   string      `json:"type"`
+		Source blockSource `json:"source"`
+		Title  string      `json:"title,omitempty"`
+	}
+)
+
+// mediaType returns the content type without its parameters
+func mediaType(contentType string) string {
+	media, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return media
+}
+
+// Attachable reports whether a file of a content type can be attached to a message: images, PDF and text documents
+func Attachable(contentType string) bool {
+	switch media := mediaType(contentType); media {
+	case "image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf", "application/json":
+		return true
+	default:
+		return strings.HasPrefix(media, "text/")
+	}
+}
+
+// ContentBlock returns the content block of an attached file: an image block for the images, a document block for
+// the PDF and text documents
+func ContentBlock(name, contentType string, content []byte) (json.RawMessage, error) {
+	media := mediaType(contentType)
+	if !Attachable(media) {
+		return nil, fmt.Errorf("file %s of type %s cannot be attached to a message", name, contentType)
+	}
+	var block contentBlock
+	switch {
+	case strings.HasPrefix(media, "image/"):
+		block = contentBlock{Type: "image", Source: blockSource{Type: "base64", MediaType: media, Data: base64.StdEncoding.EncodeToString(content)}}
+	case media == "application/pdf":
+		block = contentBlock{Type: "document", Source: blockSource{Type: "base64", MediaType: media, Data: base64.StdEncoding.EncodeToString(content)}, Title: name}
+	default:
+		block = contentBlock{Type: "document", Source: blockSource{Type: "text", MediaType: "text/plain", Data: string(content)}, Title: name}
+	}
+	return json.Marshal(block)
+}
+
+// prependBlocks returns a message with content blocks added before its content, a text content becoming a text block
+func prependBlocks(message db.JsonRaw, blocks []json.RawMessage) (db.JsonRaw, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(message, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	var content []json.RawMessage
+	var text string
+	if raw, ok := fields["content"]; ok && len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &text); err == nil {
+			if text != "" {
+				textBlock, err := json.Marshal(map[string]string{"type": "text", "text": text})
+				if err != nil {
+					return nil, err
+				}
+				content = []json.RawMessage{textBlock}
+			}
+		} else if err := json.Unmarshal(raw, &content); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal message content: %w", err)
+		}
+	}
+	expanded, err := json.Marshal(append(blocks[:len(blocks):len(blocks)], content...))
+	if err != nil {
+		return nil, err
+	}
+	fields["content"] = expanded
+	return json.Marshal(fields)
+}
+
+// ExpandAttachments returns the messages with their attached files added to their content as image or document
+// blocks, ids holding the IDs of the messages. The messages without attachment are returned as they are.
+func (st *Storage) ExpandAttachments(ctx context.Context, queries *db.Queries, ids []uuid.UUID, messages []db.JsonRaw) ([]db.JsonRaw, error) {
+	if len(ids) == 0 {
+		return messages, nil
+	}
+	attachments, err := queries.ListMessageAttachments(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message attachments: %w", err)
+	}
+	if len(attachments) == 0 {
+		return messages, nil
+	}
+	if st == nil {
+		return nil, fmt.Errorf("failed to read message attachments: %w", errNotConfigured)
+	}
+
+	// The attachments are ordered by message then position
+	blocks := make(map[uuid.UUID][]json.RawMessage)
+	for _, attachment := range attachments {
+		file := db.File{
+			ID:          attachment.ID,
+			UserID:      attachment.UserID,
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			SizeBytes:   attachment.SizeBytes,
+			Status:      attachment.Status,
+			Bucket:      attachment.Bucket,
+			ObjectKey:   attachment.ObjectKey,
+			CreatedAt:   attachment.CreatedAt,
+			UpdatedAt:   attachment.UpdatedAt,
+			UploadedAt:  attachment.UploadedAt,
+		}
+		content, err := st.Read(ctx, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment %s: %w", file.ID, err)
+		}
+		block, err := ContentBlock(file.Name, file.ContentType, content)
+		if err != nil {
+			return nil, err
+		}
+		blocks[attachment.MessageID] = append(blocks[attachment.MessageID], block)
+	}
+
+	expanded := make([]db.JsonRaw, len(messages))
+	for i, message := range messages {
+		expanded[i] = message
+		if i >= len(ids) || len(blocks[ids[i]]) == 0 {
+			continue
+		}
+		if expanded[i], err = prependBlocks(message, blocks[ids[i]]); err != nil {
+			return nil, fmt.Errorf("failed to add attachments to message %s: %w", ids[i], err)
+		}
+	}
+	return expanded, nil
+}
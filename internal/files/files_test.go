@@ -0,0 +1,66 @@
+package files
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pinazu/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachable(t *testing.T) {
+	assert.True(t, Attachable("image/png"))
+	assert.True(t, Attachable("application/pdf"))
+	assert.True(t, Attachable("text/csv; charset=utf-8"))
+	assert.True(t, Attachable("application/json"))
+	assert.False(t, Attachable("application/zip"))
+	assert.False(t, Attachable("image/svg+xml"))
+}
+
+func TestContentBlock(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		expected    string
+	}{
+		{"photo.png", "image/png", `{"type":"image","source":{"type":"base64","media_type":"image/png","data":"aGk="}}`},
+		{"report.pdf", "application/pdf", `{"type":"document","source":{"type":"base64","media_type":"application/pdf","data":"aGk="},"title":"report.pdf"}`},
+		{"notes.md", "text/markdown; charset=utf-8", `{"type":"document","source":{"type":"text","media_type":"text/plain","data":"hi"},"title":"notes.md"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := ContentBlock(tt.name, tt.contentType, []byte("hi"))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(block))
+		})
+	}
+
+	_, err := ContentBlock("archive.zip", "application/zip", []byte("hi"))
+	assert.Error(t, err)
+}
+
+func TestPrependBlocks(t *testing.T) {
+	image := json.RawMessage(`{"type":"image"}`)
+
+	// A text content becomes a text block after the attachments
+	message, err := prependBlocks(db.JsonRaw(`{"role":"user","content":"Describe it"}`), []json.RawMessage{image})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"role":"user","content":[{"type":"image"},{"type":"text","text":"Describe it"}]}`, string(message))
+
+	message, err = prependBlocks(db.JsonRaw(`{"role":"user","content":[{"type":"text","text":"Describe it"}]}`), []json.RawMessage{image})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"role":"user","content":[{"type":"image"},{"type":"text","text":"Describe it"}]}`, string(message))
+
+	message, err = prependBlocks(db.JsonRaw(`{"role":"user","content":""}`), []json.RawMessage{image})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"role":"user","content":[{"type":"image"}]}`, string(message))
+}
+
+func TestExpandAttachmentsWithoutMessages(t *testing.T) {
+	messages := []db.JsonRaw{db.JsonRaw(`{"role":"user","content":"hi"}`)}
+	var st *Storage
+	expanded, err := st.ExpandAttachments(t.Context(), nil, nil, messages)
+	require.NoError(t, err)
+	assert.Equal(t, messages, expanded)
+}
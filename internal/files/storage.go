@@ -0,0 +1,134 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// Storage holds the files uploaded by the users in the S3 storage. A nil storage has no file, its methods fail.
+type Storage struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	cfg     *service.FilesConfig
+}
+
+// NewStorage creates the storage of the files, it returns nil when the S3 storage is not configured
+func NewStorage(ctx context.Context, config *service.ExternalDependenciesConfig) (*Storage, error) {
+	if config.Storage == nil || config.Storage.S3 == nil {
+		return nil, nil
+	}
+	client, err := service.NewS3Client(ctx, config.Storage.S3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	return &Storage{client: client, presign: s3.NewPresignClient(client), cfg: config.GetFilesConfig()}, nil
+}
+
+// Config returns the file upload configuration of the storage
+func (st *Storage) Config() *service.FilesConfig {
+	return st.cfg
+}
+
+// Bucket returns the S3 bucket of the files
+func (st *Storage) Bucket() string {
+	return st.cfg.Bucket
+}
+
+// ObjectKey returns the key of the object of a file of a user
+func (st *Storage) ObjectKey(userID, fileID uuid.UUID) string {
+	return st.cfg.Prefix + userID.String() + "/" + fileID.String()
+}
+
+// Put uploads the content of a file
+func (st *Storage) Put(ctx context.Context, key, contentType string, content []byte) error {
+	if st == nil {
+		return errNotConfigured
+	}
+	_, err := st.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &st.cfg.Bucket,
+		Key:         &key,
+		Body:        bytes.NewReader(content),
+		ContentType: &contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
+
+// PresignUpload returns a URL the content of a file is uploaded to with a PUT request, with its content type, and when
+// the URL expires
+func (st *Storage) PresignUpload(ctx context.Context, key, contentType string) (string, time.Time, error) {
+	if st == nil {
+		return "", time.Time{}, errNotConfigured
+	}
+	expiry := time.Duration(st.cfg.UploadURLExpirySeconds) * time.Second
+	req, err := st.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &st.cfg.Bucket,
+		Key:         &key,
+		ContentType: &contentType,
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to presign file upload: %w", err)
+	}
+	return req.URL, time.Now().Add(expiry).UTC(), nil
+}
+
+// Head returns the size and the content type of the stored object of a file
+func (st *Storage) Head(ctx context.Context, file db.File) (int64, string, error) {
+	if st == nil {
+		return 0, "", errNotConfigured
+	}
+	out, err := st.client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: &file.Bucket, Key: &file.ObjectKey})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get uploaded file: %w", err)
+	}
+	return aws.ToInt64(out.ContentLength), aws.ToString(out.ContentType), nil
+}
+
+// Get returns the stored object of a file, its body is closed by the caller
+func (st *Storage) Get(ctx context.Context, file db.File) (*s3.GetObjectOutput, error) {
+	if st == nil {
+		return nil, errNotConfigured
+	}
+	out, err := st.client.GetObject(ctx, &s3.GetObjectInput{Bucket: &file.Bucket, Key: &file.ObjectKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return out, nil
+}
+
+// Read returns the content of a file
+func (st *Storage) Read(ctx context.Context, file db.File) ([]byte, error) {
+	out, err := st.Get(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	content, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return content, nil
+}
+
+// Delete removes the stored object of a file
+func (st *Storage) Delete(ctx context.Context, file db.File) error {
+	if st == nil {
+		return errNotConfigured
+	}
+	_, err := st.client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: &file.Bucket, Key: &file.ObjectKey})
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
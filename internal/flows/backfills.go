@@ -0,0 +1,109 @@
+package flows
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// triggerBackfills triggers the next runs of the running backfills, within the concurrency limit of each backfill
+func (fs *FlowService) triggerBackfills(cfg *service.FlowSchedulerConfig) {
+	queries := db.New(fs.s.GetDB())
+	backfills, err := queries.ListRunningFlowScheduleBackfills(fs.ctx, int32(cfg.MaxDuePerPoll))
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			fs.log.Error("Failed to list running flow schedule backfills", "error", err)
+		}
+		return
+	}
+	for _, backfill := range backfills {
+		fs.triggerBackfill(queries, backfill)
+	}
+}
+
+// triggerBackfill triggers the runs of a backfill in the order of their scheduled time, as many as the runs of the
+// backfill still scheduled or running leave room for. The runs share the IDs of the runs the schedule would have
+// triggered, a time the schedule already ran is not run again.
+func (fs *FlowService) triggerBackfill(queries *db.Queries, backfill db.FlowScheduleBackfill) {
+	cron, err := db.ParseCronExpression(backfill.CronExpression)
+	if err != nil {
+		fs.log.Error("Invalid cron expression of flow schedule backfill", "backfill_id", backfill.ID, "cron_expression", backfill.CronExpression, "error", err)
+		return
+	}
+	loc, err := db.LoadScheduleLocation(backfill.Timezone)
+	if err != nil {
+		fs.log.Error("Invalid timezone of flow schedule backfill", "backfill_id", backfill.ID, "timezone", backfill.Timezone, "error", err)
+		return
+	}
+
+	fires := cron.FireTimes(backfill.StartAt.Time.In(loc), backfill.EndAt.Time, int(backfill.TotalRuns))
+	triggered := min(int(backfill.TriggeredRuns), len(fires))
+	flowRunIDs := make([]uuid.UUID, triggered)
+	for i, fireAt := range fires[:triggered] {
+		flowRunIDs[i] = scheduledFlowRunID(backfill.ScheduleID, fireAt)
+	}
+	active, err := queries.CountActiveFlowRuns(fs.ctx, flowRunIDs)
+	if err != nil {
+		fs.log.Error("Failed to count the active runs of a flow schedule backfill", "backfill_id", backfill.ID, "error", err)
+		return
+	}
+	slots := int(backfill.MaxConcurrentRuns) - int(active)
+	if slots <= 0 && triggered < len(fires) {
+		fs.log.Debug("Flow schedule backfill at its concurrency limit", "backfill_id", backfill.ID, "active_runs", active)
+		return
+	}
+	batch := fires[triggered:min(triggered+max(slots, 0), len(fires))]
+
+	// The runs wait for the flow minutes of the day of the user owning the backfill and of the workspace of the flow
+	if len(batch) > 0 {
+		if _, err := fs.scheduledFlowRunQuota(queries, backfill.FlowID, backfill.CreatedBy); err != nil {
+			var quotaErr *db.QuotaExceededError
+			if errors.As(err, &quotaErr) {
+				fs.log.Debug("Flow schedule backfill waiting for its quota", "backfill_id", backfill.ID, "error", err)
+				return
+			}
+			fs.log.Error("Failed to check the quota of a flow schedule backfill", "backfill_id", backfill.ID, "error", err)
+			return
+		}
+	}
+
+	// The backfill completes with its last runs, the range may hold fewer runs than counted at its creation
+	next := int32(triggered + len(batch))
+	if int(next) >= len(fires) {
+		next = backfill.TotalRuns
+	}
+	claimed, err := queries.ClaimFlowScheduleBackfillRuns(fs.ctx, db.ClaimFlowScheduleBackfillRunsParams{
+		TriggeredRuns:         next,
+		ID:                    backfill.ID,
+		PreviousTriggeredRuns: backfill.TriggeredRuns,
+	})
+	if err != nil {
+		fs.log.Error("Failed to claim flow schedule backfill runs", "backfill_id", backfill.ID, "error", err)
+		return
+	}
+	if claimed == 0 {
+		fs.log.Debug("Flow schedule backfill runs already claimed", "backfill_id", backfill.ID)
+		return
+	}
+
+	var parameters map[string]interface{}
+	if err := json.Unmarshal(backfill.Parameters, &parameters); err != nil {
+		fs.log.Error("Invalid parameters of flow schedule backfill", "backfill_id", backfill.ID, "error", err)
+		return
+	}
+	for _, fireAt := range batch {
+		flowRunID := scheduledFlowRunID(backfill.ScheduleID, fireAt)
+		runParameters := db.FlowScheduleBackfillParameters(parameters, fireAt)
+		if err := fs.publishScheduledFlowRun(backfill.FlowID, flowRunID, runParameters, backfill.Engine.String, backfill.CreatedBy); err != nil {
+			fs.log.Error("Failed to publish backfill flow run", "backfill_id", backfill.ID, "flow_run_id", flowRunID, "error", err)
+			continue
+		}
+		fs.log.Info("Triggered backfill flow run", "backfill_id", backfill.ID, "schedule_id", backfill.ScheduleID, "flow_run_id", flowRunID, "scheduled_at", fireAt)
+	}
+	if next >= backfill.TotalRuns {
+		fs.log.Info("Flow schedule backfill completed", "backfill_id", backfill.ID, "schedule_id", backfill.ScheduleID, "total_runs", backfill.TotalRuns)
+	}
+}
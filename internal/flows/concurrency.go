@@ -0,0 +1,222 @@
+package flows
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+// errFlowConcurrencyLimited rejects a run of a flow at its concurrency limit with the REJECT policy
+var errFlowConcurrencyLimited = errors.New("flow reached its max_concurrent_runs")
+
+// createFlowRun creates a run of a flow. The runs of a flow with max_concurrent_runs are admitted one at a time under
+// a lock of the flow: a run beyond the limit is queued with the QUEUE policy, it stays SCHEDULED until runs of the
+// flow end, and rejected with errFlowConcurrencyLimited with the REJECT policy.
+func (fs *FlowService) createFlowRun(queries *db.Queries, flow db.Flow, params db.CreateFlowRunParams) (flowRun db.FlowRun, queued bool, err error) {
+	if !flow.MaxConcurrentRuns.Valid {
+		flowRun, err = queries.CreateFlowRun(fs.ctx, params)
+		return flowRun, false, err
+	}
+
+	tx, err := fs.s.GetDB().Begin(fs.ctx)
+	if err != nil {
+		return db.FlowRun{}, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(fs.ctx)
+	qtx := queries.WithTx(tx)
+
+	if _, err := qtx.LockFlowConcurrency(fs.ctx, flow.ID); err != nil {
+		return db.FlowRun{}, false, fmt.Errorf("failed to lock flow: %w", err)
+	}
+	dispatched, err := qtx.CountDispatchedFlowRuns(fs.ctx, flow.ID)
+	if err != nil {
+		return db.FlowRun{}, false, fmt.Errorf("failed to count flow runs: %w", err)
+	}
+	limited, err := flowConcurrencyLimited(flow, dispatched)
+	if err != nil {
+		return db.FlowRun{}, false, err
+	}
+	if !limited {
+		// A new run does not overtake the runs queued before it
+		waiting, err := qtx.CountQueuedFlowRuns(fs.ctx, flow.ID)
+		if err != nil {
+			return db.FlowRun{}, false, fmt.Errorf("failed to count queued flow runs: %w", err)
+		}
+		queued = waiting > 0
+	}
+	queued = queued || limited
+
+	flowRun, err = qtx.CreateFlowRun(fs.ctx, params)
+	if err != nil {
+		return db.FlowRun{}, false, err
+	}
+	if queued {
+		if err := qtx.EnqueueFlowRun(fs.ctx, db.EnqueueFlowRunParams{FlowRunID: flowRun.FlowRunID, FlowID: flow.ID}); err != nil {
+			return db.FlowRun{}, false, fmt.Errorf("failed to queue flow run: %w", err)
+		}
+	}
+	if err := tx.Commit(fs.ctx); err != nil {
+		return db.FlowRun{}, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if queued {
+		fs.log.Info("Queued flow run at the concurrency limit of its flow",
+			"flow_run_id", flowRun.FlowRunID,
+			"flow_id", flow.ID,
+			"max_concurrent_runs", flow.MaxConcurrentRuns.Int32,
+			"dispatched_runs", dispatched)
+		fs.recordFlowRunEvent(queries, flowRun.FlowRunID, "", db.FlowRunEventQueued, time.Now(), db.FlowRunEventData{
+			Message: fmt.Sprintf("Waiting for the limit of %d concurrent runs of the flow", flow.MaxConcurrentRuns.Int32),
+		})
+		// The runs queued before it may have room already, when a run ended since their admission
+		if !limited {
+			fs.dispatchQueuedFlowRuns(flow.ID)
+		}
+	}
+	return flowRun, queued, nil
+}
+
+// runConcurrencyDispatch dispatches the queued flow runs with room under the concurrency limit of their flow, until the
+// service stops. The runs are dispatched when a run of their flow ends, the lookup catches up on the ends missed and on
+// the limits raised or removed.
+func (fs *FlowService) runConcurrencyDispatch(cfg *service.FlowConcurrencyConfig) {
+	ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fs.ctx.Done():
+			return
+		case <-ticker.C:
+			fs.dispatchQueues()
+		}
+	}
+}
+
+// dispatchQueues removes the queued runs cancelled before their dispatch and dispatches the queued runs of every flow
+func (fs *FlowService) dispatchQueues() {
+	queries := db.New(fs.s.GetDB())
+	removed, err := queries.DeleteEndedQueuedFlowRuns(fs.ctx)
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			fs.log.Error("Failed to remove ended queued flow runs", "error", err)
+		}
+		return
+	}
+	if removed > 0 {
+		fs.log.Debug("Removed ended queued flow runs", "count", removed)
+	}
+	flowIDs, err := queries.ListQueuedFlows(fs.ctx)
+	if err != nil {
+		fs.log.Error("Failed to list flows with queued runs", "error", err)
+		return
+	}
+	for _, flowID := range flowIDs {
+		fs.dispatchQueuedFlowRuns(flowID)
+	}
+}
+
+// dispatchQueuedFlowRuns dispatches the oldest queued runs of a flow, as many as its concurrency limit leaves room for.
+// The runs of a flow whose limit was removed are all dispatched.
+func (fs *FlowService) dispatchQueuedFlowRuns(flowID uuid.UUID) {
+	queries := db.New(fs.s.GetDB())
+	flowRunIDs, err := fs.dequeueFlowRuns(queries, flowID)
+	if err != nil {
+		fs.log.Error("Failed to dequeue flow runs", "flow_id", flowID, "error", err)
+		return
+	}
+
+	for _, flowRunID := range flowRunIDs {
+		flowRun, err := queries.GetFlowRun(fs.ctx, flowRunID)
+		if err != nil {
+			fs.log.Error("Failed to get queued flow run", "flow_run_id", flowRunID, "error", err)
+			continue
+		}
+		flow, parameters, successTaskResults, err := fs.flowRunExecution(queries, flowRun)
+		if err == nil {
+			err = fs.publishFlowRunExecute(&service.EventHeaders{}, utils.GenerateTraceID(), flow, flowRunID, flowRun.Engine, parameters, successTaskResults)
+		}
+		if err != nil {
+			// The run left the queue, it fails instead of holding a slot of the flow it never runs in
+			fs.log.Error("Failed to dispatch queued flow run", "flow_run_id", flowRunID, "error", err)
+			if updateErr := queries.UpdateFlowRunError(fs.ctx, db.UpdateFlowRunErrorParams{
+				FlowRunID:    flowRunID,
+				ErrorMessage: pgtype.Text{String: err.Error(), Valid: true},
+			}); updateErr != nil {
+				fs.log.Error("Failed to update flow run error", "flow_run_id", flowRunID, "error", updateErr)
+				continue
+			}
+			flowRun.Status = db.FlowStatusFailed
+			flowRun.ErrorMessage = pgtype.Text{String: err.Error(), Valid: true}
+			fs.notifyFlowRunCompleted(&service.EventHeaders{}, utils.GenerateTraceID(), flowRun)
+			continue
+		}
+		fs.log.Info("Dispatched queued flow run", "flow_run_id", flowRunID, "flow_id", flowID, "queued_for", time.Since(flowRun.CreatedAt.Time).Round(time.Second))
+	}
+}
+
+// dequeueFlowRuns removes from the queue of a flow the runs its concurrency limit leaves room for, under the lock of
+// the flow admitting its new runs
+func (fs *FlowService) dequeueFlowRuns(queries *db.Queries, flowID uuid.UUID) ([]uuid.UUID, error) {
+	tx, err := fs.s.GetDB().Begin(fs.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(fs.ctx)
+	qtx := queries.WithTx(tx)
+
+	if _, err := qtx.LockFlowConcurrency(fs.ctx, flowID); err != nil {
+		if err == pgx.ErrNoRows {
+			// The queue of a deleted flow is deleted with it
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock flow: %w", err)
+	}
+	flow, err := qtx.GetFlowByIdWithDeleted(fs.ctx, flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow: %w", err)
+	}
+	var dispatched int64
+	if flow.MaxConcurrentRuns.Valid {
+		if dispatched, err = qtx.CountDispatchedFlowRuns(fs.ctx, flowID); err != nil {
+			return nil, fmt.Errorf("failed to count flow runs: %w", err)
+		}
+	}
+	slots := flowConcurrencySlots(flow, dispatched)
+	if slots <= 0 {
+		return nil, nil
+	}
+	flowRunIDs, err := qtx.DequeueFlowRuns(fs.ctx, db.DequeueFlowRunsParams{FlowID: flowID, Limit: int32(slots)})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(fs.ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return flowRunIDs, nil
+}
+
+// flowConcurrencyLimited reports whether the runs of a flow scheduled or running leave no room for a new run. The new
+// run is rejected with errFlowConcurrencyLimited under the REJECT policy.
+func flowConcurrencyLimited(flow db.Flow, dispatched int64) (bool, error) {
+	limited := flow.MaxConcurrentRuns.Valid && dispatched >= int64(flow.MaxConcurrentRuns.Int32)
+	if limited && flow.ConcurrencyPolicy == db.FlowConcurrencyPolicyReject {
+		return true, fmt.Errorf("%w, %d runs of flow %s are scheduled or running", errFlowConcurrencyLimited, dispatched, flow.Name)
+	}
+	return limited, nil
+}
+
+// flowConcurrencySlots returns how many queued runs of a flow can be dispatched, all of them when the flow has no limit
+func flowConcurrencySlots(flow db.Flow, dispatched int64) int64 {
+	if !flow.MaxConcurrentRuns.Valid {
+		return math.MaxInt32
+	}
+	return max(int64(flow.MaxConcurrentRuns.Int32)-dispatched, 0)
+}
@@ -0,0 +1,25 @@
+package flows
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// recordFlowRunUser starts the cost of a flow run with the user who requested it, for the per user rollups. A run
+// requested without a user, such as a scheduled run, is counted under an unknown user. A failure is logged without
+// failing the run.
+func (fs *FlowService) recordFlowRunUser(queries *db.Queries, flowRun db.FlowRun, h *service.EventHeaders) {
+	var userID pgtype.UUID
+	if h != nil && h.UserID != uuid.Nil {
+		userID = pgtype.UUID{Bytes: h.UserID, Valid: true}
+	}
+	if err := queries.CreateFlowRunCost(fs.ctx, db.CreateFlowRunCostParams{
+		FlowRunID: flowRun.FlowRunID,
+		FlowID:    flowRun.FlowID,
+		UserID:    userID,
+	}); err != nil {
+		fs.log.Warn("Failed to record flow run user", "flow_run_id", flowRun.FlowRunID, "error", err)
+	}
+}
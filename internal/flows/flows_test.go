@@ -1 +1,232 @@
 package flows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleFireTimes(t *testing.T) {
+	cron, err := db.ParseCronExpression("*/10 * * * *")
+	require.NoError(t, err)
+	dueAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	misfire := time.Minute
+
+	t.Run("on time", func(t *testing.T) {
+		fires, missed, next := scheduleFireTimes(cron, dueAt, dueAt.Add(20*time.Second), db.FlowScheduleCatchUpPolicySkip, misfire, 10)
+		assert.Equal(t, []time.Time{dueAt}, fires)
+		assert.Zero(t, missed)
+		assert.Equal(t, dueAt.Add(10*time.Minute), next)
+	})
+
+	// The scheduler was down from 9:00 to 9:45
+	now := dueAt.Add(45 * time.Minute)
+
+	t.Run("skip", func(t *testing.T) {
+		fires, missed, next := scheduleFireTimes(cron, dueAt, now, db.FlowScheduleCatchUpPolicySkip, misfire, 10)
+		assert.Empty(t, fires)
+		assert.Equal(t, 5, missed)
+		assert.Equal(t, dueAt.Add(50*time.Minute), next)
+	})
+
+	t.Run("once", func(t *testing.T) {
+		fires, missed, _ := scheduleFireTimes(cron, dueAt, now, db.FlowScheduleCatchUpPolicyOnce, misfire, 10)
+		assert.Equal(t, []time.Time{dueAt.Add(40 * time.Minute)}, fires)
+		assert.Equal(t, 4, missed)
+
+		// The run on time stands for the missed ones
+		fires, missed, _ = scheduleFireTimes(cron, dueAt, dueAt.Add(40*time.Minute+10*time.Second), db.FlowScheduleCatchUpPolicyOnce, misfire, 10)
+		assert.Equal(t, []time.Time{dueAt.Add(40 * time.Minute)}, fires)
+		assert.Equal(t, 4, missed)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		fires, missed, _ := scheduleFireTimes(cron, dueAt, now, db.FlowScheduleCatchUpPolicyAll, misfire, 10)
+		assert.Len(t, fires, 5)
+		assert.Zero(t, missed)
+
+		// Only the latest runs are caught up
+		fires, missed, _ = scheduleFireTimes(cron, dueAt, now, db.FlowScheduleCatchUpPolicyAll, misfire, 2)
+		assert.Equal(t, []time.Time{dueAt.Add(30 * time.Minute), dueAt.Add(40 * time.Minute)}, fires)
+		assert.Equal(t, 3, missed)
+	})
+}
+
+func TestCronNextSkippedMidnight(t *testing.T) {
+	// The clocks of Sao Paulo moved from 00:00 to 01:00 on Sunday 2018-11-04
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	friday := time.Date(2018, 11, 2, 12, 0, 0, 0, loc)
+
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		// The day starts at 01:00, it is not taken for the Saturday
+		{"0 12 * * 0", time.Date(2018, 11, 4, 12, 0, 0, 0, loc)},
+		{"0 23 * * 6", time.Date(2018, 11, 3, 23, 0, 0, 0, loc)},
+		{"0 * 4 11 *", time.Date(2018, 11, 4, 1, 0, 0, 0, loc)},
+		// The skipped midnight does not fire
+		{"0 0 * * 0", time.Date(2018, 11, 11, 0, 0, 0, 0, loc)},
+		{"0 0 * * *", time.Date(2018, 11, 3, 0, 0, 0, 0, loc)},
+	} {
+		cron, err := db.ParseCronExpression(tc.expr)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, cron.Next(friday), tc.expr)
+	}
+
+	// A schedule that never fires has no next run, the scheduler disables it
+	cron, err := db.ParseCronExpression("0 0 30 2 *")
+	require.NoError(t, err)
+	dueAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, _, next := scheduleFireTimes(cron, dueAt, dueAt, db.FlowScheduleCatchUpPolicySkip, time.Minute, 10)
+	assert.True(t, next.IsZero())
+}
+
+func TestScheduledFlowRunID(t *testing.T) {
+	scheduleID := uuid.New()
+	at := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	assert.Equal(t, scheduledFlowRunID(scheduleID, at), scheduledFlowRunID(scheduleID, at.In(paris)))
+	assert.NotEqual(t, scheduledFlowRunID(scheduleID, at), scheduledFlowRunID(scheduleID, at.Add(time.Minute)))
+	assert.NotEqual(t, scheduledFlowRunID(scheduleID, at), scheduledFlowRunID(uuid.New(), at))
+}
+
+func TestFlowRunRetryBackoff(t *testing.T) {
+	cfg := &service.FlowRetryConfig{InitialBackoffSeconds: 30, BackoffMultiplier: 2, MaxBackoffSeconds: 300}
+
+	assert.Equal(t, 30*time.Second, flowRunRetryBackoff(cfg, 0))
+	assert.Equal(t, 60*time.Second, flowRunRetryBackoff(cfg, 1))
+	assert.Equal(t, 240*time.Second, flowRunRetryBackoff(cfg, 3))
+	assert.Equal(t, 300*time.Second, flowRunRetryBackoff(cfg, 4))
+	assert.Equal(t, 300*time.Second, flowRunRetryBackoff(cfg, 100))
+}
+
+func TestFlowRunRetryDue(t *testing.T) {
+	cfg := &service.FlowRetryConfig{InitialBackoffSeconds: 30, BackoffMultiplier: 2, MaxBackoffSeconds: 300}
+	failedAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	flowRun := db.FlowRun{
+		Status:     db.FlowStatusFailed,
+		FinishedAt: pgtype.Timestamptz{Time: failedAt, Valid: true},
+		RetryCount: pgtype.Int4{Int32: 1, Valid: true},
+		MaxRetries: pgtype.Int4{Int32: 3, Valid: true},
+	}
+
+	assert.False(t, flowRunRetryDue(cfg, flowRun, failedAt.Add(59*time.Second)))
+	assert.True(t, flowRunRetryDue(cfg, flowRun, failedAt.Add(time.Minute)))
+
+	// Without end time the backoff counts from the last update
+	flowRun.FinishedAt = pgtype.Timestamptz{}
+	flowRun.UpdatedAt = pgtype.Timestamptz{Time: failedAt.Add(time.Minute), Valid: true}
+	assert.False(t, flowRunRetryDue(cfg, flowRun, failedAt.Add(time.Minute)))
+	assert.True(t, flowRunRetryDue(cfg, flowRun, failedAt.Add(2*time.Minute)))
+}
+
+func TestSeedSuccessTaskResults(t *testing.T) {
+	successTaskResults := map[string]string{"extract": "cache/extract"}
+	cacheKey := func(key string) pgtype.Text { return pgtype.Text{String: key, Valid: key != ""} }
+	seedSuccessTaskResults(successTaskResults, []db.FlowTaskRun{
+		{TaskName: "extract", Status: db.FlowStatusSuccess, ResultCacheKey: cacheKey("cache/extract-2")},
+		{TaskName: "transform", Status: db.FlowStatusSuccess, ResultCacheKey: cacheKey("cache/transform")},
+		{TaskName: "load", Status: db.FlowStatusFailed, ResultCacheKey: cacheKey("cache/load")},
+		{TaskName: "report", Status: db.FlowStatusSuccess},
+	})
+	assert.Equal(t, map[string]string{
+		"extract":   "cache/extract",
+		"transform": "cache/transform",
+	}, successTaskResults, "Only the successful tasks with a cache key are skipped by the new run")
+}
+
+func TestFlowNotificationBackoff(t *testing.T) {
+	cfg := &service.FlowNotificationsConfig{InitialBackoffSeconds: 2, MaxBackoffSeconds: 10}
+
+	assert.Equal(t, 2*time.Second, flowNotificationBackoff(cfg, 1))
+	assert.Equal(t, 4*time.Second, flowNotificationBackoff(cfg, 2))
+	assert.Equal(t, 8*time.Second, flowNotificationBackoff(cfg, 3))
+	assert.Equal(t, 10*time.Second, flowNotificationBackoff(cfg, 4))
+	assert.Equal(t, 10*time.Second, flowNotificationBackoff(cfg, 100))
+}
+
+func TestFlowNotificationSlackPreset(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	flow := db.Flow{ID: uuid.New(), Name: `nightly "etl"`}
+	flowRun := db.FlowRun{
+		FlowRunID:    uuid.New(),
+		FlowID:       flow.ID,
+		Status:       db.FlowStatusFailed,
+		ErrorMessage: pgtype.Text{String: "task load failed", Valid: true},
+		FinishedAt:   pgtype.Timestamptz{Time: time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC), Valid: true},
+	}
+	payload := newFlowNotification(flow, flowRun)
+	assert.Equal(t, "flow_run.FAILED", payload.Event)
+
+	alert, err := service.NewWebhookAlert(server.URL, flowNotificationPresets, service.WebhookPresetSlack, "", time.Second)
+	require.NoError(t, err)
+	require.NoError(t, alert.Send(context.Background(), payload))
+	assert.Equal(t, `:x: Flow nightly "etl" failed`, received["text"])
+	assert.Contains(t, fmt.Sprint(received["blocks"]), "2026-05-04T09:00:00Z task load failed")
+}
+
+func TestWorkerPauseError(t *testing.T) {
+	flowRunID := uuid.New()
+
+	// Nobody answers when no worker runs the process
+	err := workerPauseError(flowRunID, fmt.Errorf("failed to send request: %w", nats.ErrTimeout))
+	assert.EqualError(t, err, fmt.Sprintf("no worker runs the process of flow run %s", flowRunID))
+	err = workerPauseError(flowRunID, fmt.Errorf("failed to send request: %w", nats.ErrNoResponders))
+	assert.EqualError(t, err, fmt.Sprintf("no worker runs the process of flow run %s", flowRunID))
+
+	// The error of the worker is returned to the caller
+	workerErr := fmt.Errorf("Error Type: InternalError, Error: pausing a flow process is not supported on Windows")
+	assert.Equal(t, workerErr, workerPauseError(flowRunID, workerErr))
+}
+
+func TestFlowConcurrencyPolicy(t *testing.T) {
+	unlimited := db.Flow{Name: "etl"}
+	limited, err := flowConcurrencyLimited(unlimited, 100)
+	assert.NoError(t, err)
+	assert.False(t, limited)
+	assert.Equal(t, int64(math.MaxInt32), flowConcurrencySlots(unlimited, 100))
+
+	queue := db.Flow{Name: "etl", MaxConcurrentRuns: pgtype.Int4{Int32: 2, Valid: true}, ConcurrencyPolicy: db.FlowConcurrencyPolicyQueue}
+	limited, err = flowConcurrencyLimited(queue, 1)
+	assert.NoError(t, err)
+	assert.False(t, limited)
+	// The runs beyond the limit are queued
+	limited, err = flowConcurrencyLimited(queue, 2)
+	assert.NoError(t, err)
+	assert.True(t, limited)
+
+	reject := queue
+	reject.ConcurrencyPolicy = db.FlowConcurrencyPolicyReject
+	_, err = flowConcurrencyLimited(reject, 1)
+	assert.NoError(t, err)
+	limited, err = flowConcurrencyLimited(reject, 3)
+	assert.ErrorIs(t, err, errFlowConcurrencyLimited)
+	assert.True(t, limited)
+
+	// A lowered limit leaves no room until the runs above it end
+	assert.Equal(t, int64(2), flowConcurrencySlots(queue, 0))
+	assert.Equal(t, int64(1), flowConcurrencySlots(queue, 1))
+	assert.Equal(t, int64(0), flowConcurrencySlots(queue, 5))
+}
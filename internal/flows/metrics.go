@@ -0,0 +1,80 @@
+package flows
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// flowRunDurationBuckets are the bounds of the flow run duration histogram, in seconds
+var flowRunDurationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200, 21600}
+
+// flowMetrics are the metrics of the flow runs and their tasks, recorded by the flows service from the status events
+// and labeled by flow and engine
+type flowMetrics struct {
+	runDuration metric.Float64Histogram
+	taskRuns    metric.Int64Counter
+	flowNames   sync.Map // Names of the flows by ID, looked up once
+}
+
+// newFlowMetrics creates the instruments of the flow metrics, a no-op until the process serves its metrics
+func newFlowMetrics() (*flowMetrics, error) {
+	meter := otel.Meter("github.com/pinazu/internal/flows")
+	runDuration, err := meter.Float64Histogram("pinazu.flow_run.duration",
+		metric.WithDescription("Duration of the flow runs, from the start of the flow process until the run succeeds, fails or is cancelled"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(flowRunDurationBuckets...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flow run duration histogram: %w", err)
+	}
+	taskRuns, err := meter.Int64Counter("pinazu.flow_task_runs",
+		metric.WithDescription("Task runs of the flows that succeeded or failed"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flow task runs counter: %w", err)
+	}
+	return &flowMetrics{runDuration: runDuration, taskRuns: taskRuns}, nil
+}
+
+// recordFlowRunDuration records the duration of a flow run that ended
+func (fs *FlowService) recordFlowRunDuration(queries *db.Queries, flowRun db.FlowRun) {
+	if !flowRun.StartedAt.Valid || !flowRun.FinishedAt.Valid {
+		return
+	}
+	duration := flowRun.FinishedAt.Time.Sub(flowRun.StartedAt.Time).Seconds()
+	fs.metrics.runDuration.Record(fs.ctx, max(duration, 0), metric.WithAttributes(
+		append(fs.flowRunAttributes(queries, flowRun), attribute.String("status", string(flowRun.Status)))...))
+}
+
+// recordTaskRun counts a task run that succeeded or failed
+func (fs *FlowService) recordTaskRun(queries *db.Queries, flowRunID uuid.UUID, status db.FlowStatus) {
+	flowRun, err := queries.GetFlowRun(fs.ctx, flowRunID)
+	if err != nil {
+		fs.log.Debug("Failed to get the flow run of a task run metric", "flow_run_id", flowRunID, "error", err)
+		return
+	}
+	fs.metrics.taskRuns.Add(fs.ctx, 1, metric.WithAttributes(
+		append(fs.flowRunAttributes(queries, flowRun), attribute.String("status", string(status)))...))
+}
+
+// flowRunAttributes returns the flow and engine labels of the metrics of a flow run
+func (fs *FlowService) flowRunAttributes(queries *db.Queries, flowRun db.FlowRun) []attribute.KeyValue {
+	name, ok := fs.metrics.flowNames.Load(flowRun.FlowID)
+	if !ok {
+		flow, err := queries.GetFlowByIdWithDeleted(fs.ctx, flowRun.FlowID)
+		if err != nil {
+			name = flowRun.FlowID.String()
+		} else {
+			name = flow.Name
+			fs.metrics.flowNames.Store(flowRun.FlowID, name)
+		}
+	}
+	return []attribute.KeyValue{
+		attribute.String("flow", name.(string)),
+		attribute.String("engine", flowRun.Engine),
+	}
+}
@@ -0,0 +1,149 @@
+package flows
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// flowNotificationPresets are the payload templates of the notification types, a webhook receives the raw JSON
+var flowNotificationPresets = map[string]string{
+	service.WebhookPresetSlack: `{
+  "text": {{ if eq .Status "SUCCESS" }}{{ printf ":white_check_mark: Flow %s succeeded" .FlowName | json }}{{ else }}{{ printf ":x: Flow %s failed" .FlowName | json }}{{ end }},
+  "blocks": [
+    {"type": "header", "text": {"type": "plain_text", "text": {{ if eq .Status "SUCCESS" }}"Flow run succeeded"{{ else }}"Flow run failed"{{ end }}}},
+    {"type": "section", "fields": [
+      {"type": "mrkdwn", "text": {{ printf "*Flow*\n%s" .FlowName | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Run*\n%s" .FlowRunID | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Status*\n%s" .Status | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Retries*\n%d" .RetryCount | json }}}
+    ]},
+    {"type": "context", "elements": [
+      {"type": "mrkdwn", "text": {{ printf "Finished at %s %s" (formatTime .FinishedAt) (truncate 200 .ErrorMessage) | json }}}
+    ]}
+  ]
+}`,
+}
+
+// flowNotification is the body posted to the notifications of a flow when one of its runs succeeds or fails
+type flowNotification struct {
+	Event         string        `json:"event"`
+	FlowID        uuid.UUID     `json:"flow_id"`
+	FlowName      string        `json:"flow_name"`
+	FlowRunID     uuid.UUID     `json:"flow_run_id"`
+	Status        db.FlowStatus `json:"status"`
+	ErrorMessage  string        `json:"error_message,omitempty"`
+	FailureReason string        `json:"failure_reason,omitempty"`
+	RetryCount    int32         `json:"retry_count"`
+	ParentRunID   *uuid.UUID    `json:"parent_run_id,omitempty"`
+	StartedAt     *time.Time    `json:"started_at,omitempty"`
+	FinishedAt    time.Time     `json:"finished_at"`
+}
+
+// newFlowNotification returns the notification of an ended flow run
+func newFlowNotification(flow db.Flow, flowRun db.FlowRun) flowNotification {
+	n := flowNotification{
+		Event:         "flow_run." + string(flowRun.Status),
+		FlowID:        flow.ID,
+		FlowName:      flow.Name,
+		FlowRunID:     flowRun.FlowRunID,
+		Status:        flowRun.Status,
+		ErrorMessage:  flowRun.ErrorMessage.String,
+		FailureReason: flowRun.FailureReason.String,
+		RetryCount:    flowRun.RetryCount.Int32,
+		FinishedAt:    flowRun.FinishedAt.Time,
+	}
+	if flowRun.ParentRunID.Valid {
+		parentRunID := uuid.UUID(flowRun.ParentRunID.Bytes)
+		n.ParentRunID = &parentRunID
+	}
+	if flowRun.StartedAt.Valid {
+		n.StartedAt = &flowRun.StartedAt.Time
+	}
+	if !flowRun.FinishedAt.Valid {
+		n.FinishedAt = time.Now().UTC()
+	}
+	return n
+}
+
+// sendFlowNotifications sends the notifications of the flow of an ended run in the background, each notification is
+// attempted again after a failure
+func (fs *FlowService) sendFlowNotifications(flowRun db.FlowRun) {
+	if fs.notifications.Disabled {
+		return
+	}
+	flow, err := db.New(fs.s.GetDB()).GetFlowByIdWithDeleted(fs.ctx, flowRun.FlowID)
+	if err != nil {
+		fs.log.Error("Failed to get flow of the notifications", "flow_id", flowRun.FlowID, "flow_run_id", flowRun.FlowRunID, "error", err)
+		return
+	}
+	notifications, err := db.ParseFlowNotifications(flow.Notifications)
+	if err != nil {
+		fs.log.Error("Invalid flow notifications", "flow_id", flow.ID, "error", err)
+		return
+	}
+	payload := newFlowNotification(flow, flowRun)
+	for _, n := range notifications {
+		if n.Notifies(flowRun.Status) {
+			go fs.deliverFlowNotification(n, payload)
+		}
+	}
+}
+
+// deliverFlowNotification posts a notification until it is accepted, it is dropped after the last attempt or on a
+// client error of the endpoint
+func (fs *FlowService) deliverFlowNotification(n db.FlowNotification, payload flowNotification) {
+	cfg := fs.notifications
+	preset := ""
+	if n.Type == db.FlowNotificationSlack {
+		preset = service.WebhookPresetSlack
+	}
+	alert, err := service.NewWebhookAlert(n.URL, flowNotificationPresets, preset, "", time.Duration(cfg.TimeoutSeconds)*time.Second)
+	if err != nil {
+		fs.log.Error("Failed to create flow notification", "flow_run_id", payload.FlowRunID, "type", n.Type, "error", err)
+		return
+	}
+	alert.WithClient(fs.client)
+	if n.Secret != "" {
+		secret, err := fs.secrets.Resolve(fs.ctx, n.Secret)
+		if err != nil {
+			fs.log.Error("Failed to resolve flow notification secret", "flow_run_id", payload.FlowRunID, "type", n.Type, "error", err)
+			return
+		}
+		alert.WithSigningSecret(secret)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := alert.Send(fs.ctx, payload)
+		if err == nil {
+			fs.log.Info("Sent flow notification", "flow_run_id", payload.FlowRunID, "type", n.Type, "status", payload.Status, "attempt", attempt)
+			return
+		}
+		var statusErr *service.WebhookStatusError
+		if (errors.As(err, &statusErr) && !statusErr.Retryable()) || attempt >= cfg.MaxAttempts {
+			fs.log.Error("Failed to send flow notification", "flow_run_id", payload.FlowRunID, "type", n.Type, "attempt", attempt, "error", err)
+			return
+		}
+		backoff := flowNotificationBackoff(cfg, attempt)
+		fs.log.Warn("Failed to send flow notification, retrying", "flow_run_id", payload.FlowRunID, "type", n.Type, "attempt", attempt, "backoff", backoff, "error", err)
+		select {
+		case <-fs.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// flowNotificationBackoff returns the delay after the failed attempt of a notification: the initial backoff doubled
+// at each attempt, up to the max backoff
+func flowNotificationBackoff(cfg *service.FlowNotificationsConfig, attempt int) time.Duration {
+	backoff := time.Duration(cfg.InitialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(cfg.MaxBackoffSeconds) * time.Second
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
@@ -0,0 +1,224 @@
+package flows
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+// runRetries executes again the failed flow runs with retries left once their backoff elapsed, until the service stops.
+// Each instance of the flows service looks them up, a retry is only executed once.
+func (fs *FlowService) runRetries(cfg *service.FlowRetryConfig) {
+	ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fs.ctx.Done():
+			return
+		case <-ticker.C:
+			fs.retryFailedFlowRuns(cfg)
+		}
+	}
+}
+
+// retryFailedFlowRuns retries the failed flow runs whose backoff elapsed
+func (fs *FlowService) retryFailedFlowRuns(cfg *service.FlowRetryConfig) {
+	queries := db.New(fs.s.GetDB())
+	flowRuns, err := queries.GetFailedFlowRunsForRetry(fs.ctx)
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			fs.log.Error("Failed to list failed flow runs for retry", "error", err)
+		}
+		return
+	}
+	now := time.Now()
+	for _, flowRun := range flowRuns {
+		if !flowRunRetryDue(cfg, flowRun, now) {
+			continue
+		}
+		fs.retryFlowRun(queries, flowRun)
+	}
+}
+
+// retryFlowRun schedules a failed flow run again and executes it on a worker, the tasks that succeeded
+// are skipped with their cached results
+func (fs *FlowService) retryFlowRun(queries *db.Queries, failed db.FlowRun) {
+	flowRun, err := queries.RetryFlowRun(fs.ctx, failed.FlowRunID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			fs.log.Debug("Flow run retry already claimed", "flow_run_id", failed.FlowRunID)
+			return
+		}
+		fs.log.Error("Failed to retry flow run", "flow_run_id", failed.FlowRunID, "error", err)
+		return
+	}
+
+	fs.recordFlowRunEvent(queries, flowRun.FlowRunID, "", db.FlowRunEventRetry, time.Now(), db.FlowRunEventData{
+		Status:     flowRun.Status,
+		RetryCount: flowRun.RetryCount.Int32,
+	})
+
+	flow, parameters, successTaskResults, err := fs.flowRunExecution(queries, flowRun)
+	if err == nil {
+		err = fs.publishFlowRunExecute(&service.EventHeaders{}, utils.GenerateTraceID(), flow, flowRun.FlowRunID, flowRun.Engine, parameters, successTaskResults)
+	}
+	if err != nil {
+		// The run fails again, the next retry is attempted after a longer backoff
+		fs.log.Error("Failed to execute flow run retry", "flow_run_id", flowRun.FlowRunID, "error", err)
+		if updateErr := queries.UpdateFlowRunError(fs.ctx, db.UpdateFlowRunErrorParams{
+			FlowRunID:    flowRun.FlowRunID,
+			ErrorMessage: pgtype.Text{String: err.Error(), Valid: true},
+		}); updateErr != nil {
+			fs.log.Error("Failed to update flow run error", "flow_run_id", flowRun.FlowRunID, "error", updateErr)
+			return
+		}
+		flowRun.Status = db.FlowStatusFailed
+		flowRun.ErrorMessage = pgtype.Text{String: err.Error(), Valid: true}
+		fs.notifyFlowRunCompleted(&service.EventHeaders{}, utils.GenerateTraceID(), flowRun)
+		return
+	}
+
+	fs.log.Info("Retrying failed flow run",
+		"flow_run_id", flowRun.FlowRunID,
+		"retry", flowRun.RetryCount.Int32,
+		"max_retries", flowRun.MaxRetries.Int32,
+		"cached_tasks", len(successTaskResults))
+}
+
+// flowRunRetryDue reports whether the backoff of a failed flow run elapsed, counted from the end of its last attempt
+func flowRunRetryDue(cfg *service.FlowRetryConfig, flowRun db.FlowRun, now time.Time) bool {
+	failedAt := flowRun.FinishedAt.Time
+	if !flowRun.FinishedAt.Valid {
+		failedAt = flowRun.UpdatedAt.Time
+	}
+	return !now.Before(failedAt.Add(flowRunRetryBackoff(cfg, flowRun.RetryCount.Int32)))
+}
+
+// flowRunRetryBackoff returns the delay before the next retry of a flow run already retried retryCount times:
+// the initial backoff multiplied at each retry, up to the max backoff
+func flowRunRetryBackoff(cfg *service.FlowRetryConfig, retryCount int32) time.Duration {
+	backoff := float64(cfg.InitialBackoffSeconds) * math.Pow(cfg.BackoffMultiplier, float64(retryCount))
+	if backoff > float64(cfg.MaxBackoffSeconds) {
+		backoff = float64(cfg.MaxBackoffSeconds)
+	}
+	return time.Duration(backoff * float64(time.Second))
+}
+
+// handleFlowRunRetry handles the retry request of a failed or cancelled flow run: a new run of the flow is executed with
+// the parameters of the failed one, seeded with the result cache keys of its successful tasks so only the failed and
+// not run tasks execute again
+func (fs *FlowService) handleFlowRunRetry(msg *nats.Msg) {
+	_, span := fs.s.GetTracer().Start(fs.ctx, "handleFlowRunRetry")
+	defer span.End()
+
+	data, err := service.ParseEvent[*service.FlowRunRetryRequestEventMessage](msg.Data)
+	if err != nil {
+		fs.log.Error("Failed to parse flow run retry request", "error", err)
+		service.NewErrorEvent[*service.FlowRunRetryResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	req := data.Msg
+	queries := db.New(fs.s.GetDB())
+	failed, err := queries.GetWorkspaceFlowRun(fs.ctx, db.GetWorkspaceFlowRunParams{WorkspaceID: data.H.Workspace(), FlowRunID: req.FlowRunId})
+	if err == nil && !failed.CanRetry() {
+		err = fmt.Errorf("flow run %s cannot be retried, its status is %s", req.FlowRunId, failed.Status)
+	}
+	var (
+		flow               db.Flow
+		parameters         map[string]interface{}
+		successTaskResults map[string]string
+		taskRuns           []db.FlowTaskRun
+	)
+	if err == nil {
+		flow, parameters, successTaskResults, err = fs.flowRunExecution(queries, failed)
+	}
+	if err == nil {
+		taskRuns, err = queries.GetFlowTaskRunsByFlowRun(fs.ctx, failed.FlowRunID)
+	}
+	if err != nil {
+		fs.log.Error("Failed to retry flow run", "flow_run_id", req.FlowRunId, "error", err)
+		service.NewErrorEvent[*service.FlowRunRetryResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+	seedSuccessTaskResults(successTaskResults, taskRuns)
+
+	successResults, err := db.NewJsonRaw(successTaskResults)
+	if err != nil {
+		fs.log.Error("Failed to create JsonRaw from success task results", "error", err)
+		service.NewErrorEvent[*service.FlowRunRetryResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+	taskStatuses, _ := db.NewJsonRaw(map[string]interface{}{})
+	flowRun, err := queries.CreateFlowRun(fs.ctx, db.CreateFlowRunParams{
+		FlowRunID:          uuid.Must(uuid.NewV7()),
+		FlowID:             failed.FlowID,
+		Parameters:         failed.Parameters,
+		Status:             db.FlowStatusScheduled,
+		Engine:             failed.Engine,
+		TaskStatuses:       taskStatuses,
+		SuccessTaskResults: successResults,
+		MaxRetries:         failed.MaxRetries,
+	})
+	if err != nil {
+		fs.log.Error("Failed to create flow run", "error", err)
+		service.NewErrorEvent[*service.FlowRunRetryResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+	fs.recordFlowRunEvent(queries, flowRun.FlowRunID, "", db.FlowRunEventRequest, flowRun.CreatedAt.Time, db.FlowRunEventData{
+		Status:      flowRun.Status,
+		CachedTasks: len(successTaskResults),
+		RetryOf:     pgtype.UUID{Bytes: failed.FlowRunID, Valid: true},
+	})
+	fs.recordFlowRunUser(queries, flowRun, data.H)
+	fs.recordFlowRunEvent(queries, failed.FlowRunID, "", db.FlowRunEventRetry, time.Now(), db.FlowRunEventData{
+		Message: fmt.Sprintf("Retried as flow run %s", flowRun.FlowRunID),
+	})
+
+	err = fs.publishFlowRunExecute(data.H, data.M.TraceID, flow, flowRun.FlowRunID, flowRun.Engine, parameters, successTaskResults)
+	if err != nil {
+		fs.log.Error("Failed to publish flow execute event to JetStream", "error", err, "flow_run_id", flowRun.FlowRunID)
+		if updateErr := queries.UpdateFlowRunError(fs.ctx, db.UpdateFlowRunErrorParams{
+			FlowRunID:    flowRun.FlowRunID,
+			ErrorMessage: pgtype.Text{String: err.Error(), Valid: true},
+		}); updateErr != nil {
+			fs.log.Error("Failed to update flow run error", "flow_run_id", flowRun.FlowRunID, "error", updateErr)
+		}
+		service.NewErrorEvent[*service.FlowRunRetryResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	fs.log.Info("Retrying flow run as a new run",
+		"flow_run_id", failed.FlowRunID,
+		"new_flow_run_id", flowRun.FlowRunID,
+		"cached_tasks", len(successTaskResults))
+	response := service.Event[*service.FlowRunRetryResponseEventMessage]{
+		H: data.H,
+		Msg: &service.FlowRunRetryResponseEventMessage{
+			FlowRun: flowRun,
+		},
+		M: data.M,
+	}
+	response.Respond(msg)
+}
+
+// seedSuccessTaskResults adds the result cache keys of the successful task runs missing from the success task results
+// of a flow run
+func seedSuccessTaskResults(successTaskResults map[string]string, taskRuns []db.FlowTaskRun) {
+	for _, taskRun := range taskRuns {
+		if taskRun.Status != db.FlowStatusSuccess || !taskRun.ResultCacheKey.Valid || taskRun.ResultCacheKey.String == "" {
+			continue
+		}
+		if _, ok := successTaskResults[taskRun.TaskName]; !ok {
+			successTaskResults[taskRun.TaskName] = taskRun.ResultCacheKey.String
+		}
+	}
+}
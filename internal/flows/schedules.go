@@ -0,0 +1,204 @@
+package flows
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+// runScheduler triggers the flow runs of the schedules due for a run and of the running backfills until the service
+// stops. Each instance of the flows service looks them up, a run is only triggered once.
+func (fs *FlowService) runScheduler(cfg *service.FlowSchedulerConfig) {
+	ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fs.ctx.Done():
+			return
+		case <-ticker.C:
+			fs.triggerDueSchedules(cfg)
+			fs.triggerBackfills(cfg)
+		}
+	}
+}
+
+// triggerDueSchedules triggers the flow runs of the enabled schedules whose next run is due
+func (fs *FlowService) triggerDueSchedules(cfg *service.FlowSchedulerConfig) {
+	queries := db.New(fs.s.GetDB())
+	schedules, err := queries.ListDueFlowSchedules(fs.ctx, int32(cfg.MaxDuePerPoll))
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			fs.log.Error("Failed to list due flow schedules", "error", err)
+		}
+		return
+	}
+	now := time.Now()
+	for _, schedule := range schedules {
+		fs.triggerSchedule(queries, schedule, now, cfg)
+	}
+}
+
+// triggerSchedule moves a due schedule to its next run and triggers the runs its catch-up policy keeps
+func (fs *FlowService) triggerSchedule(queries *db.Queries, schedule db.FlowSchedule, now time.Time, cfg *service.FlowSchedulerConfig) {
+	cron, err := db.ParseCronExpression(schedule.CronExpression)
+	if err != nil {
+		fs.log.Error("Invalid cron expression of flow schedule", "schedule_id", schedule.ID, "cron_expression", schedule.CronExpression, "error", err)
+		fs.disableSchedule(queries, schedule)
+		return
+	}
+	loc, err := db.LoadScheduleLocation(schedule.Timezone)
+	if err != nil {
+		fs.log.Error("Invalid timezone of flow schedule", "schedule_id", schedule.ID, "timezone", schedule.Timezone, "error", err)
+		fs.disableSchedule(queries, schedule)
+		return
+	}
+
+	dueAt := schedule.NextRunAt.Time
+	fires, missed, next := scheduleFireTimes(cron, dueAt.In(loc), now, schedule.CatchUpPolicy, time.Duration(cfg.MisfireSeconds)*time.Second, cfg.MaxCatchUpRuns)
+	if next.IsZero() {
+		fs.log.Error("Flow schedule never fires again", "schedule_id", schedule.ID, "cron_expression", schedule.CronExpression)
+		fs.disableSchedule(queries, schedule)
+		return
+	}
+
+	claim := db.ClaimFlowScheduleRunParams{
+		ID:            schedule.ID,
+		DueAt:         schedule.NextRunAt,
+		NextRunAt:     pgtype.Timestamptz{Time: next, Valid: true},
+		LastRunAt:     schedule.LastRunAt,
+		LastFlowRunID: schedule.LastFlowRunID,
+	}
+	if len(fires) > 0 {
+		claim.LastRunAt = pgtype.Timestamptz{Time: now, Valid: true}
+		claim.LastFlowRunID = pgtype.UUID{Bytes: scheduledFlowRunID(schedule.ID, fires[len(fires)-1]), Valid: true}
+	}
+	claimed, err := queries.ClaimFlowScheduleRun(fs.ctx, claim)
+	if err != nil {
+		fs.log.Error("Failed to claim flow schedule run", "schedule_id", schedule.ID, "error", err)
+		return
+	}
+	if claimed == 0 {
+		fs.log.Debug("Flow schedule run already claimed", "schedule_id", schedule.ID)
+		return
+	}
+	if missed > 0 {
+		fs.log.Warn("Flow schedule runs missed", "schedule_id", schedule.ID, "missed", missed, "catch_up_policy", schedule.CatchUpPolicy)
+	}
+
+	var parameters map[string]interface{}
+	if err := json.Unmarshal(schedule.Parameters, &parameters); err != nil {
+		fs.log.Error("Invalid parameters of flow schedule", "schedule_id", schedule.ID, "error", err)
+		return
+	}
+	for _, fireAt := range fires {
+		flowRunID := scheduledFlowRunID(schedule.ID, fireAt)
+		if err := fs.publishScheduledFlowRun(schedule.FlowID, flowRunID, parameters, schedule.Engine.String, schedule.CreatedBy); err != nil {
+			var quotaErr *db.QuotaExceededError
+			if errors.As(err, &quotaErr) {
+				fs.log.Warn("Scheduled flow run skipped, quota exceeded", "schedule_id", schedule.ID, "flow_run_id", flowRunID, "error", err)
+				continue
+			}
+			fs.log.Error("Failed to publish scheduled flow run", "schedule_id", schedule.ID, "flow_run_id", flowRunID, "error", err)
+			continue
+		}
+		fs.log.Info("Triggered scheduled flow run", "schedule_id", schedule.ID, "flow_id", schedule.FlowID, "flow_run_id", flowRunID, "scheduled_at", fireAt)
+	}
+}
+
+// disableSchedule disables a due schedule that cannot fire, it would otherwise be listed again on every poll and
+// hold a place of the due schedules
+func (fs *FlowService) disableSchedule(queries *db.Queries, schedule db.FlowSchedule) {
+	disabled, err := queries.DisableFlowSchedule(fs.ctx, db.DisableFlowScheduleParams{
+		ID:        schedule.ID,
+		NextRunAt: schedule.NextRunAt,
+	})
+	if err != nil {
+		fs.log.Error("Failed to disable flow schedule", "schedule_id", schedule.ID, "error", err)
+		return
+	}
+	if disabled > 0 {
+		fs.log.Warn("Disabled flow schedule that cannot fire", "schedule_id", schedule.ID)
+	}
+}
+
+// publishScheduledFlowRun requests the execution of a run triggered by a schedule, as the user owning the schedule
+// in the workspace of the flow
+func (fs *FlowService) publishScheduledFlowRun(flowID, flowRunID uuid.UUID, parameters map[string]interface{}, engine string, userID uuid.UUID) error {
+	flow, err := fs.scheduledFlowRunQuota(db.New(fs.s.GetDB()), flowID, userID)
+	if err != nil {
+		return err
+	}
+	event := service.NewEvent(&service.FlowRunExecuteRequestEventMessage{
+		FlowId:     flowID,
+		FlowRunId:  &flowRunID,
+		Parameters: parameters,
+		Engine:     engine,
+	}, &service.EventHeaders{
+		UserID:      userID,
+		WorkspaceID: flow.WorkspaceID,
+	}, &service.EventMetadata{
+		TraceID:   utils.GenerateTraceID(),
+		Timestamp: time.Now().UTC(),
+	})
+	return event.Publish(fs.s.GetNATS())
+}
+
+// scheduledFlowRunQuota returns the flow of a run triggered by a schedule, and a *db.QuotaExceededError when the user
+// owning the schedule or the workspace of the flow used up its flow minutes of the day
+func (fs *FlowService) scheduledFlowRunQuota(queries *db.Queries, flowID, userID uuid.UUID) (db.Flow, error) {
+	flow, err := queries.GetFlowByIdWithDeleted(fs.ctx, flowID)
+	if err != nil {
+		return db.Flow{}, fmt.Errorf("failed to get flow: %w", err)
+	}
+	if err := queries.CheckQuotas(fs.ctx, fs.quotas, flow.WorkspaceID, userID, db.QuotaFlowMinutesPerDay); err != nil {
+		return flow, err
+	}
+	return flow, nil
+}
+
+// scheduleFireTimes returns the times of a due schedule to trigger now, the number of runs dropped by the
+// catch-up policy, and the next run after now. The runs started within the misfire delay are on time,
+// the older ones are missed: skip drops them, once triggers the latest and all triggers up to maxCatchUp of them.
+func scheduleFireTimes(cron *db.CronSchedule, dueAt, now time.Time, policy db.FlowScheduleCatchUpPolicy, misfire time.Duration, maxCatchUp int) (fires []time.Time, missed int, next time.Time) {
+	var onTime, late []time.Time
+	next = dueAt
+	for !next.IsZero() && !next.After(now) {
+		if now.Sub(next) <= misfire {
+			onTime = append(onTime, next)
+		} else {
+			late = append(late, next)
+			// Only the latest runs can be caught up, the schedule may have been down for long
+			if len(late) > maxCatchUp {
+				late = late[1:]
+				missed++
+			}
+		}
+		next = cron.Next(next)
+	}
+
+	switch policy {
+	case db.FlowScheduleCatchUpPolicyAll:
+		fires = late
+	case db.FlowScheduleCatchUpPolicyOnce:
+		if len(late) > 0 && len(onTime) == 0 {
+			fires = late[len(late)-1:]
+			late = late[:len(late)-1]
+		}
+		missed += len(late)
+	default:
+		missed += len(late)
+	}
+	return append(fires, onTime...), missed, next
+}
+
+// scheduledFlowRunID derives the ID of the flow run of a schedule at a time, so a run triggered twice is created once
+func scheduledFlowRunID(scheduleID uuid.UUID, fireAt time.Time) uuid.UUID {
+	return uuid.NewSHA1(scheduleID, []byte(fireAt.UTC().Format(time.RFC3339)))
+}
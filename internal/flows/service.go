@@ -2,25 +2,36 @@ package flows
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/secrets"
 	"github.com/pinazu/internal/service"
 )
 
 type FlowService struct {
-	s         service.Service
-	log       hclog.Logger
-	wg        *sync.WaitGroup
-	ctx       context.Context
-	jetstream *service.JetStreamService
+	s             service.Service
+	log           hclog.Logger
+	wg            *sync.WaitGroup
+	ctx           context.Context
+	jetstream     *service.JetStreamService
+	retry         *service.FlowRetryConfig
+	notifications *service.FlowNotificationsConfig
+	client        *http.Client      // Posts the flow notifications, refusing the private addresses and the redirects
+	secrets       *secrets.Resolver // Resolves the secrets signing the flow notifications
+	quotas        *db.Quotas        // Limits the flow minutes of the runs triggered by the schedules and the backfills
+	metrics       *flowMetrics
 }
 
 // NewService creates a new FlowService instance
@@ -29,6 +40,11 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("externalDependenciesConfig is nil")
 	}
 
+	resolver, err := externalDependenciesConfig.Secrets.NewSecretsResolver(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secrets resolver: %w", err)
+	}
+
 	// Create a new service instance
 	config := &service.Config{
 		Name:                 "flows-handler-service",
@@ -36,18 +52,40 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		Description:          "Flow service for handling flow execution, flow context management, and flow completion.",
 		ExternalDependencies: externalDependenciesConfig,
 		ErrorHandler:         nil,
+		Streams:              []string{"FLOWS_STATUS", "WORKER_FLOWS"},
 	}
 	s, err := service.NewService(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create flow service: %w", err)
 	}
 
-	fs := &FlowService{s: s, log: log, wg: wg, ctx: ctx}
+	metrics, err := newFlowMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := externalDependenciesConfig.GetFlowNotificationsConfig()
+	fs := &FlowService{
+		s:             s,
+		log:           log,
+		wg:            wg,
+		ctx:           ctx,
+		retry:         externalDependenciesConfig.GetFlowRetryConfig(),
+		notifications: notifications,
+		client:        service.NewWebhookClient(notifications.AllowPrivateNetworks),
+		secrets:       resolver,
+		quotas:        externalDependenciesConfig.GetQuotas(),
+		metrics:       metrics,
+	}
 
 	// Register all event handlers
 	s.RegisterHandler(service.FlowRunExecuteRequestEventSubject.String(), fs.handleFlowRunExecute)
-	s.RegisterHandler("v1.svc.flow._info", nil)
-	s.RegisterHandler("v1.svc.flow._stats", nil)
+	s.RegisterHandler(service.FlowRunCancelRequestEventSubject.String(), fs.handleFlowRunCancel)
+	s.RegisterHandler(service.FlowRunPauseRequestEventSubject.String(), fs.handleFlowRunPause)
+	s.RegisterHandler(service.FlowRunResumeRequestEventSubject.String(), fs.handleFlowRunResume)
+	s.RegisterHandler(service.FlowRunRetryRequestEventSubject.String(), fs.handleFlowRunRetry)
+	s.RegisterHandler("v1.svc.flow._info", service.InfoHandler(s))
+	s.RegisterHandler("v1.svc.flow._stats", service.StatsHandler(s))
 
 	// Register JetStream handlers for FlowRunStatusUpdateEvent only
 	if externalDependenciesConfig.Nats != nil && externalDependenciesConfig.Nats.JetStreamDefaultConfig != nil {
@@ -57,6 +95,24 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		}
 	}
 
+	// Trigger the flow runs of the schedules
+	if schedulerConfig := externalDependenciesConfig.GetFlowSchedulerConfig(); !schedulerConfig.Disabled {
+		go fs.runScheduler(schedulerConfig)
+	}
+
+	// Retry the failed flow runs with retries left
+	if !fs.retry.Disabled {
+		go fs.runRetries(fs.retry)
+	}
+
+	// Reschedule the flow runs of the workers whose heartbeat stopped
+	if workersConfig := externalDependenciesConfig.GetFlowWorkersConfig(); !workersConfig.Disabled {
+		go fs.runWorkerLiveness(workersConfig)
+	}
+
+	// Dispatch the flow runs queued by the concurrency limit of their flow
+	go fs.runConcurrencyDispatch(externalDependenciesConfig.GetFlowConcurrencyConfig())
+
 	// Start a goroutine to wait for context cancellation and then shutdown
 	go func() {
 		<-ctx.Done()
@@ -92,13 +148,30 @@ func (fs *FlowService) handleFlowRunExecute(msg *nats.Msg) {
 	req := data.Msg
 	queries := db.New(fs.s.GetDB())
 
-	// Check if flow exists
-	flow, err := queries.GetFlowById(fs.ctx, req.FlowId)
+	// Check if flow exists in the workspace of the request
+	flow, err := queries.GetFlowById(fs.ctx, db.GetFlowByIdParams{WorkspaceID: data.H.Workspace(), ID: req.FlowId})
 	if err != nil {
 		fs.log.Error("Failed to get flow", "error", err)
 		service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M, err).Respond(msg)
 		return
 	}
+
+	// Reject the parameters not matching the parameters schema of the flow, before the run is created
+	violations, err := db.ValidateFlowParameters(flow, req.Parameters)
+	if err != nil {
+		fs.log.Error("Failed to validate flow run parameters", "error", err, "flow_id", req.FlowId)
+		service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+	if len(violations) > 0 {
+		fs.log.Warn("Flow run parameters do not match the parameters schema", "flow_id", req.FlowId, "violations", violations)
+		response := service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M,
+			fmt.Errorf("parameters do not match the parameters schema of flow %s", flow.Name))
+		response.Msg.Violations = violations
+		response.Respond(msg)
+		return
+	}
+
 	// Set default engine if not provided
 	engine := req.Engine
 	if engine == "" {
@@ -120,6 +193,18 @@ func (fs *FlowService) handleFlowRunExecute(msg *nats.Msg) {
 		return
 	}
 
+	parentRunID, err := fs.parentFlowRun(queries, data.H.Workspace(), req.ParentRunId)
+	if err != nil {
+		fs.log.Error("Invalid parent flow run", "error", err, "flow_id", req.FlowId)
+		service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	maxRetries := fs.retry.DefaultMaxRetries
+	if req.MaxRetries != nil {
+		maxRetries = *req.MaxRetries
+	}
+
 	// Initialize empty JSON objects for task statuses and results
 	taskStatuses, _ := db.NewJsonRaw(map[string]interface{}{})
 	successResults, _ := db.NewJsonRaw(map[string]interface{}{})
@@ -132,60 +217,135 @@ func (fs *FlowService) handleFlowRunExecute(msg *nats.Msg) {
 		Engine:             engine,
 		TaskStatuses:       taskStatuses,
 		SuccessTaskResults: successResults,
-		MaxRetries:         pgtype.Int4{Int32: 0, Valid: true}, // Default to 0 retries
+		MaxRetries:         pgtype.Int4{Int32: maxRetries, Valid: true},
+		ParentRunID:        parentRunID,
 	}
 
-	flowRun, err := queries.CreateFlowRun(fs.ctx, flowRunParams)
+	flowRun, queued, err := fs.createFlowRun(queries, flow, flowRunParams)
 	if err != nil {
+		if errors.Is(err, errFlowConcurrencyLimited) {
+			fs.log.Warn("Rejected flow run at the concurrency limit of its flow", "flow_id", req.FlowId, "max_concurrent_runs", flow.MaxConcurrentRuns.Int32)
+			response := service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M, err)
+			response.Msg.ConcurrencyLimited = true
+			response.Respond(msg)
+			return
+		}
 		fs.log.Error("Failed to create flow run", "error", err)
 		service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M, err).Respond(msg)
 		return
 	}
+	fs.recordFlowRunEvent(queries, flowRunID, "", db.FlowRunEventRequest, flowRun.CreatedAt.Time, db.FlowRunEventData{Status: flowRun.Status})
+	fs.recordFlowRunUser(queries, flowRun, data.H)
+
+	// A queued run is dispatched once runs of its flow end
+	if queued {
+		response := service.Event[*service.FlowRunExecuteResponseEventMessage]{
+			H:   data.H,
+			Msg: &service.FlowRunExecuteResponseEventMessage{FlowRun: flowRun},
+			M:   data.M,
+		}
+		response.Respond(msg)
+		return
+	}
+
+	err = fs.publishFlowRunExecute(data.H, data.M.TraceID, flow, flowRunID, engine, req.Parameters, make(map[string]string))
+	if err != nil {
+		fs.log.Error("Failed to publish flow execute event to JetStream", "error", err, "flow_run_id", flowRunID)
+		service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
 
+	fs.log.Info("Successfully published flow execute event to Worker", "flow_run_id", flowRunID, "subject", service.FlowRunExecuteLabelsSubject(flow.RequiredLabels).String())
+	// Create response event
+	response := service.Event[*service.FlowRunExecuteResponseEventMessage]{
+		H: data.H,
+		Msg: &service.FlowRunExecuteResponseEventMessage{
+			FlowRun: flowRun,
+		},
+		M: data.M,
+	}
+	response.Respond(msg)
+}
+
+// publishFlowRunExecute publishes the event executing a flow run on a worker, the tasks of the success results
+// are not run again. The runs of a flow with required labels are routed to the workers advertising them.
+func (fs *FlowService) publishFlowRunExecute(h *service.EventHeaders, traceID string, flow db.Flow, flowRunID uuid.UUID, engine string, parameters map[string]interface{}, successTaskResults map[string]string) error {
 	// Get args from additional_info or use defaults
 	args := []string{}
 	// Fallback to request args if no args in additional_info
 	// Get code location and entrypoint from flow table, fallback to request
 	// Publish flow execute event for Worker
 	executeEvent := service.Event[*service.FlowRunExecuteEventMessage]{
-		H: data.H,
+		H: h,
 		Msg: &service.FlowRunExecuteEventMessage{
 			FlowRunId:          flowRunID,
-			Parameters:         req.Parameters,
+			Parameters:         parameters,
 			Engine:             engine,
+			FlowName:           flow.Name,
 			CodeLocation:       flow.CodeLocation.String,
 			Entrypoint:         flow.Entrypoint.String,
 			Args:               args,
-			SuccessTaskResults: make(map[string]string),
+			SuccessTaskResults: successTaskResults,
+			RequiredLabels:     flow.RequiredLabels,
 			EventTimestamp:     time.Now().UTC(),
 		},
 		M: &service.EventMetadata{
-			TraceID:   data.M.TraceID,
+			TraceID:   traceID,
 			Timestamp: time.Now().UTC(),
 		},
 	}
 
+	if len(flow.RequiredLabels) > 0 {
+		fs.checkWorkerLabels(flowRunID, flow.RequiredLabels)
+	}
+
 	fs.log.Info("Publishing flow execute event to Worker",
-		"subject", service.FlowRunExecuteEventSubject.String(),
+		"subject", executeEvent.Msg.Subject().String(),
 		"flow_run_id", flowRunID,
+		"required_labels", flow.RequiredLabels,
 		"code_location", flow.CodeLocation.String,
 		"entrypoint", flow.Entrypoint.String,
 		"args", args,
-		"parameters", req.Parameters)
+		"parameters", parameters)
 
 	// Publish to JetStream instead of regular NATS since worker consumes from JetStream
-	err = executeEvent.Publish(fs.s.GetNATS())
+	if err := executeEvent.Publish(fs.s.GetNATS()); err != nil {
+		return err
+	}
+	fs.recordFlowRunEvent(db.New(fs.s.GetDB()), flowRunID, "", db.FlowRunEventExecute, executeEvent.Msg.EventTimestamp, db.FlowRunEventData{
+		CachedTasks: len(successTaskResults),
+		Message:     fmt.Sprintf("Published to %s", executeEvent.Msg.Subject()),
+	})
+	return nil
+}
+
+// handleFlowRunCancel handles the flow run cancellation request: the run and its in-flight task runs are
+// marked CANCELLED, then the workers are told to terminate the flow process. Its active sub-flow runs are cancelled too.
+func (fs *FlowService) handleFlowRunCancel(msg *nats.Msg) {
+	_, span := fs.s.GetTracer().Start(fs.ctx, "handleFlowRunCancel")
+	defer span.End()
+
+	data, err := service.ParseEvent[*service.FlowRunCancelRequestEventMessage](msg.Data)
 	if err != nil {
-		fs.log.Error("Failed to publish flow execute event to JetStream", "error", err, "flow_run_id", flowRunID)
-		service.NewErrorEvent[*service.FlowRunExecuteResponseEventMessage](data.H, data.M, err).Respond(msg)
+		fs.log.Error("Failed to parse flow run cancel request", "error", err)
+		service.NewErrorEvent[*service.FlowRunCancelResponseEventMessage](data.H, data.M, err).Respond(msg)
 		return
 	}
 
-	fs.log.Info("Successfully published flow execute event to Worker", "flow_run_id", flowRunID, "subject", service.FlowRunExecuteEventSubject.String())
-	// Create response event
-	response := service.Event[*service.FlowRunExecuteResponseEventMessage]{
+	req := data.Msg
+	reason := req.Reason
+	if reason == "" {
+		reason = "Flow run cancelled"
+	}
+	flowRun, err := fs.cancelFlowRun(db.New(fs.s.GetDB()), data.H, data.M.TraceID, req.FlowRunId, reason)
+	if err != nil {
+		service.NewErrorEvent[*service.FlowRunCancelResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	response := service.Event[*service.FlowRunCancelResponseEventMessage]{
 		H: data.H,
-		Msg: &service.FlowRunExecuteResponseEventMessage{
+		Msg: &service.FlowRunCancelResponseEventMessage{
 			FlowRun: flowRun,
 		},
 		M: data.M,
@@ -193,6 +353,183 @@ func (fs *FlowService) handleFlowRunExecute(msg *nats.Msg) {
 	response.Respond(msg)
 }
 
+// cancelFlowRun ends an active flow run as cancelled, stops its process and cancels its active sub-flow runs
+func (fs *FlowService) cancelFlowRun(queries *db.Queries, h *service.EventHeaders, traceID string, flowRunID uuid.UUID, reason string) (db.FlowRun, error) {
+	flowRun, err := queries.CancelFlowRun(fs.ctx, db.CancelFlowRunParams{
+		FlowRunID:    flowRunID,
+		ErrorMessage: pgtype.Text{String: reason, Valid: true},
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = fmt.Errorf("flow run %s already ended", flowRunID)
+		}
+		fs.log.Error("Failed to cancel flow run", "flow_run_id", flowRunID, "error", err)
+		return db.FlowRun{}, err
+	}
+
+	cancelledTasks, err := queries.CancelFlowTaskRuns(fs.ctx, flowRunID)
+	if err != nil {
+		// The run is cancelled already, the task runs left are only reported
+		fs.log.Error("Failed to cancel flow task runs", "flow_run_id", flowRunID, "error", err)
+	}
+
+	cancelEvent := service.NewEvent(&service.FlowRunCancelEventMessage{
+		FlowRunId:      flowRunID,
+		EventTimestamp: time.Now().UTC(),
+	}, h, &service.EventMetadata{
+		TraceID:   traceID,
+		Timestamp: time.Now().UTC(),
+	})
+	if err := cancelEvent.Publish(fs.s.GetNATS()); err != nil {
+		fs.log.Error("Failed to publish flow cancel event to Worker", "flow_run_id", flowRunID, "error", err)
+		return db.FlowRun{}, err
+	}
+
+	fs.log.Info("Cancelled flow run", "flow_run_id", flowRunID, "cancelled_task_runs", cancelledTasks, "reason", reason)
+	fs.recordFlowRunEvent(queries, flowRunID, "", db.FlowRunEventStatus, time.Now(), db.FlowRunEventData{Status: db.FlowStatusCancelled, Message: reason})
+	fs.recordFlowRunDuration(queries, flowRun)
+	fs.notifyFlowRunCompleted(h, traceID, flowRun)
+	fs.cancelChildFlowRuns(queries, h, traceID, flowRunID)
+	fs.dispatchQueuedFlowRuns(flowRun.FlowID)
+	return flowRun, nil
+}
+
+// handleFlowRunPause handles the flow run pause request: the worker running the flow process is told to stop it
+// before its next task, the process reports the PAUSED status once it stopped. The request fails when the worker
+// cannot signal the process.
+func (fs *FlowService) handleFlowRunPause(msg *nats.Msg) {
+	_, span := fs.s.GetTracer().Start(fs.ctx, "handleFlowRunPause")
+	defer span.End()
+
+	data, err := service.ParseEvent[*service.FlowRunPauseRequestEventMessage](msg.Data)
+	if err != nil {
+		fs.log.Error("Failed to parse flow run pause request", "error", err)
+		service.NewErrorEvent[*service.FlowRunPauseResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	req := data.Msg
+	flowRun, err := db.New(fs.s.GetDB()).GetFlowRun(fs.ctx, req.FlowRunId)
+	if err == nil && flowRun.Status != db.FlowStatusRunning {
+		err = fmt.Errorf("flow run %s is not running, its status is %s", req.FlowRunId, flowRun.Status)
+	}
+	if err != nil {
+		fs.log.Error("Failed to pause flow run", "flow_run_id", req.FlowRunId, "error", err)
+		service.NewErrorEvent[*service.FlowRunPauseResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	pauseEvent := service.NewEvent(&service.FlowRunPauseEventMessage{
+		FlowRunId:      req.FlowRunId,
+		EventTimestamp: time.Now().UTC(),
+	}, data.H, &service.EventMetadata{
+		TraceID:   data.M.TraceID,
+		Timestamp: time.Now().UTC(),
+	})
+	if _, err := service.Request[*service.FlowRunPauseEventMessage](fs.s.GetNATS(), pauseEvent, flowRunPauseTimeout); err != nil {
+		err = workerPauseError(req.FlowRunId, err)
+		fs.log.Error("Failed to pause flow process on Worker", "flow_run_id", req.FlowRunId, "error", err)
+		service.NewErrorEvent[*service.FlowRunPauseResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	fs.log.Info("Requested flow run pause", "flow_run_id", req.FlowRunId)
+	response := service.Event[*service.FlowRunPauseResponseEventMessage]{
+		H: data.H,
+		Msg: &service.FlowRunPauseResponseEventMessage{
+			FlowRun: flowRun,
+		},
+		M: data.M,
+	}
+	response.Respond(msg)
+}
+
+// flowRunPauseTimeout is the time the worker running a flow process has to answer a pause request, below the
+// timeout of the API request
+const flowRunPauseTimeout = 3 * time.Second
+
+// workerPauseError returns the error of a pause request to the workers, nobody answers when no worker runs the
+// process of the flow run
+func workerPauseError(flowRunID uuid.UUID, err error) error {
+	if errors.Is(err, nats.ErrTimeout) || errors.Is(err, nats.ErrNoResponders) {
+		return fmt.Errorf("no worker runs the process of flow run %s", flowRunID)
+	}
+	return err
+}
+
+// handleFlowRunResume handles the flow run resume request: the paused run is executed again on a worker,
+// the tasks it completed are skipped from their cached results
+func (fs *FlowService) handleFlowRunResume(msg *nats.Msg) {
+	_, span := fs.s.GetTracer().Start(fs.ctx, "handleFlowRunResume")
+	defer span.End()
+
+	data, err := service.ParseEvent[*service.FlowRunResumeRequestEventMessage](msg.Data)
+	if err != nil {
+		fs.log.Error("Failed to parse flow run resume request", "error", err)
+		service.NewErrorEvent[*service.FlowRunResumeResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	req := data.Msg
+	queries := db.New(fs.s.GetDB())
+	flowRun, err := queries.ResumeFlowRun(fs.ctx, req.FlowRunId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = fmt.Errorf("flow run %s is not paused", req.FlowRunId)
+		}
+		fs.log.Error("Failed to resume flow run", "flow_run_id", req.FlowRunId, "error", err)
+		service.NewErrorEvent[*service.FlowRunResumeResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	fs.recordFlowRunEvent(queries, flowRun.FlowRunID, "", db.FlowRunEventStatus, time.Now(), db.FlowRunEventData{Status: flowRun.Status, Message: "Flow run resumed"})
+
+	flow, parameters, successTaskResults, err := fs.flowRunExecution(queries, flowRun)
+	if err == nil {
+		err = fs.publishFlowRunExecute(data.H, data.M.TraceID, flow, flowRun.FlowRunID, flowRun.Engine, parameters, successTaskResults)
+	}
+	if err != nil {
+		// Put the run back in pause so the resume can be requested again
+		if pauseErr := queries.UpdateFlowRunStatus(fs.ctx, db.UpdateFlowRunStatusParams{Status: db.FlowStatusPaused, FlowRunID: flowRun.FlowRunID}); pauseErr != nil {
+			fs.log.Error("Failed to pause flow run again", "flow_run_id", flowRun.FlowRunID, "error", pauseErr)
+		}
+		fs.log.Error("Failed to resume flow run", "flow_run_id", req.FlowRunId, "error", err)
+		service.NewErrorEvent[*service.FlowRunResumeResponseEventMessage](data.H, data.M, err).Respond(msg)
+		return
+	}
+
+	fs.log.Info("Resumed flow run", "flow_run_id", req.FlowRunId, "cached_tasks", len(successTaskResults))
+	response := service.Event[*service.FlowRunResumeResponseEventMessage]{
+		H: data.H,
+		Msg: &service.FlowRunResumeResponseEventMessage{
+			FlowRun: flowRun,
+		},
+		M: data.M,
+	}
+	response.Respond(msg)
+}
+
+// flowRunExecution returns the flow of a run, its parameters and the result cache keys of the tasks it completed
+func (fs *FlowService) flowRunExecution(queries *db.Queries, flowRun db.FlowRun) (db.Flow, map[string]interface{}, map[string]string, error) {
+	flow, err := queries.GetFlowByIdWithDeleted(fs.ctx, flowRun.FlowID)
+	if err != nil {
+		return db.Flow{}, nil, nil, fmt.Errorf("failed to get flow: %w", err)
+	}
+	parameters := make(map[string]interface{})
+	if len(flowRun.Parameters) > 0 {
+		if err := json.Unmarshal(flowRun.Parameters, &parameters); err != nil {
+			return db.Flow{}, nil, nil, fmt.Errorf("failed to unmarshal flow run parameters: %w", err)
+		}
+	}
+	successTaskResults := make(map[string]string)
+	if len(flowRun.SuccessTaskResults) > 0 {
+		if err := json.Unmarshal(flowRun.SuccessTaskResults, &successTaskResults); err != nil {
+			return db.Flow{}, nil, nil, fmt.Errorf("failed to unmarshal flow run success task results: %w", err)
+		}
+	}
+	return flow, parameters, successTaskResults, nil
+}
+
 // registerStreamHandler registers JetStream stream handlers for FlowRunStatus and TaskRunStatus events
 func (fs *FlowService) registerStreamHandler(s service.Service, config *service.ExternalDependenciesConfig) error {
 	// Create JetStream service
@@ -201,19 +538,30 @@ func (fs *FlowService) registerStreamHandler(s service.Service, config *service.
 		return fmt.Errorf("failed to create JetStream service: %w", err)
 	}
 
+	jetStreamService.SetRedeliveryConfig(config.Nats.GetRedeliveryConfig())
+
 	// Store jetstream service for later use
 	fs.jetstream = jetStreamService
 
+	// Resolve the replication role of the region, standby streams mirror the primary region
+	replication := config.GetReplicationConfig()
+	role, err := jetStreamService.ReplicationRole(replication)
+	if err != nil {
+		return fmt.Errorf("failed to resolve replication role: %w", err)
+	}
+
 	// Register handlers for both FlowRunStatus and TaskRunStatus events
 	streamConfig := service.CreateStreamConfigWithDefaults(
 		"FLOWS_STATUS",
 		[]string{
 			service.FlowRunStatusEventSubject.String(),
 			service.FlowTaskRunStatusEventSubject.String(),
+			service.FlowRunGraphEventSubject.String(),
 		},
 		"Stream for flow and task status updates",
 		config.Nats.GetJetStreamConfig(),
 	)
+	streamConfig = service.ApplyReplication(streamConfig, replication, role)
 
 	// Create or update the stream
 	_, err = jetStreamService.CreateOrUpdateStream(streamConfig)
@@ -224,10 +572,11 @@ func (fs *FlowService) registerStreamHandler(s service.Service, config *service.
 	// Also create the WORKER_FLOWS stream for publishing execution events
 	workerStreamConfig := service.CreateStreamConfigWithDefaults(
 		"WORKER_FLOWS",
-		[]string{service.FlowRunExecuteEventSubject.String()},
+		service.ManagedStreams["WORKER_FLOWS"],
 		"Stream for worker flow execution events",
 		config.Nats.GetJetStreamConfig(),
 	)
+	workerStreamConfig = service.ApplyReplication(workerStreamConfig, replication, role)
 
 	_, err = jetStreamService.CreateOrUpdateStream(workerStreamConfig)
 	if err != nil {
@@ -266,8 +615,44 @@ func (fs *FlowService) registerStreamHandler(s service.Service, config *service.
 		return fmt.Errorf("failed to create TaskRunStatus consumer: %w", err)
 	}
 
+	// Create consumer for FlowRunGraph events
+	graphConsumerConfig := service.ConsumerConfig{
+		Name:        "flow_run_graph_consumer",
+		StreamName:  "FLOWS_STATUS",
+		Subject:     service.FlowRunGraphEventSubject.String(),
+		Description: "Consumer for FlowRunGraph events",
+		AckWait:     60 * time.Second,
+		MaxDeliver:  0, // Will use config default
+		FilterBy:    service.FlowRunGraphEventSubject.String(),
+	}
+
+	_, err = jetStreamService.CreateOrUpdateConsumer(graphConsumerConfig, config.Nats.GetJetStreamConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create FlowRunGraph consumer: %w", err)
+	}
+
+	// A standby region keeps its consumers idle until it is promoted, so mirrored events are not applied twice
+	if role == service.ReplicationRoleStandby {
+		fs.log.Warn("Standby region, status consumers start after promotion", "region", replication.Region)
+		go func() {
+			if err := jetStreamService.WaitForPromotion(replication); err != nil {
+				fs.log.Error("Failed to wait for region promotion", "error", err)
+				return
+			}
+			if err := fs.consumeStatusEvents(jetStreamService); err != nil {
+				fs.log.Error("Failed to start status consumers after promotion", "error", err)
+			}
+		}()
+		return nil
+	}
+
+	return fs.consumeStatusEvents(jetStreamService)
+}
+
+// consumeStatusEvents starts consuming FlowRunStatus, TaskRunStatus and FlowRunGraph events from the FLOWS_STATUS stream
+func (fs *FlowService) consumeStatusEvents(jetStreamService *service.JetStreamService) error {
 	// Start consuming messages for FlowRunStatus events
-	err = jetStreamService.ConsumeMessages("flow_run_status_consumer", "FLOWS_STATUS", fs.handleFlowRunStatusUpdateJS)
+	err := jetStreamService.ConsumeMessages("flow_run_status_consumer", "FLOWS_STATUS", fs.handleFlowRunStatusUpdateJS)
 	if err != nil {
 		return fmt.Errorf("failed to start FlowRunStatus consumer: %w", err)
 	}
@@ -278,7 +663,13 @@ func (fs *FlowService) registerStreamHandler(s service.Service, config *service.
 		return fmt.Errorf("failed to start TaskRunStatus consumer: %w", err)
 	}
 
-	fs.log.Info("JetStream stream handlers registered successfully for FlowRunStatus and TaskRunStatus events")
+	// Start consuming messages for FlowRunGraph events
+	err = jetStreamService.ConsumeMessages("flow_run_graph_consumer", "FLOWS_STATUS", fs.handleFlowRunGraphJS)
+	if err != nil {
+		return fmt.Errorf("failed to start FlowRunGraph consumer: %w", err)
+	}
+
+	fs.log.Info("JetStream stream handlers registered successfully for FlowRunStatus, TaskRunStatus and FlowRunGraph events")
 	return nil
 }
 
@@ -296,7 +687,8 @@ func (fs *FlowService) handleFlowRunStatusUpdateJS(msg jetstream.Msg) error {
 		"flow_run_id", statusMsg.FlowRunId,
 		"status", statusMsg.Status,
 		"timestamp", statusMsg.EventTimestamp,
-		"error_message", statusMsg.ErrorMessage)
+		"error_message", statusMsg.ErrorMessage,
+		"failure_reason", statusMsg.FailureReason)
 
 	// Update flow run status in database
 	queries := db.New(fs.s.GetDB())
@@ -312,8 +704,9 @@ func (fs *FlowService) handleFlowRunStatusUpdateJS(msg jetstream.Msg) error {
 	// Use UpdateFlowRunError for FAILED status with error message
 	if statusMsg.Status == "FAILED" && statusMsg.ErrorMessage != "" {
 		if err := queries.UpdateFlowRunError(fs.ctx, db.UpdateFlowRunErrorParams{
-			FlowRunID:    statusMsg.FlowRunId,
-			ErrorMessage: pgtype.Text{String: statusMsg.ErrorMessage, Valid: true},
+			FlowRunID:     statusMsg.FlowRunId,
+			ErrorMessage:  pgtype.Text{String: statusMsg.ErrorMessage, Valid: true},
+			FailureReason: pgtype.Text{String: string(statusMsg.FailureReason), Valid: statusMsg.FailureReason != ""},
 		}); err != nil {
 			fs.log.Error("Failed to update flow run with error",
 				"flow_run_id", statusMsg.FlowRunId,
@@ -337,6 +730,27 @@ func (fs *FlowService) handleFlowRunStatusUpdateJS(msg jetstream.Msg) error {
 		"flow_run_id", statusMsg.FlowRunId,
 		"status", statusMsg.Status,
 		"error_message", statusMsg.ErrorMessage)
+	fs.recordFlowRunEvent(queries, statusMsg.FlowRunId, "", db.FlowRunEventStatus, statusMsg.EventTimestamp, db.FlowRunEventData{
+		Status:        statusMsg.Status,
+		ErrorMessage:  statusMsg.ErrorMessage,
+		FailureReason: string(statusMsg.FailureReason),
+	})
+
+	// Notify the parent flow run waiting for the run once it ended, the run no longer holds a slot of the
+	// concurrency limit of its flow once it ended or paused
+	switch statusMsg.Status {
+	case db.FlowStatusSuccess, db.FlowStatusFailed, db.FlowStatusCancelled, db.FlowStatusPaused:
+		flowRun, err := queries.GetFlowRun(fs.ctx, statusMsg.FlowRunId)
+		if err != nil {
+			fs.log.Error("Failed to get flow run", "flow_run_id", statusMsg.FlowRunId, "error", err)
+			break
+		}
+		if statusMsg.Status == db.FlowStatusSuccess || statusMsg.Status == db.FlowStatusFailed {
+			fs.recordFlowRunDuration(queries, flowRun)
+			fs.notifyFlowRunCompleted(eventData.H, eventData.M.TraceID, flowRun)
+		}
+		fs.dispatchQueuedFlowRuns(flowRun.FlowID)
+	}
 
 	// Acknowledge the message
 	return msg.Ack()
@@ -388,6 +802,18 @@ func (fs *FlowService) handleTaskRunStatusUpdateJS(msg jetstream.Msg) error {
 				"error", err)
 			return fmt.Errorf("failed to update task run result cache key: %w", err)
 		}
+		// The run keeps the cache keys of its completed tasks, a resumed run skips them
+		if err := queries.SetFlowRunSuccessTaskResult(fs.ctx, db.SetFlowRunSuccessTaskResultParams{
+			FlowRunID:      statusMsg.FlowRunId,
+			TaskName:       statusMsg.TaskName,
+			ResultCacheKey: *statusMsg.ResultCacheKey,
+		}); err != nil {
+			fs.log.Error("Failed to record flow run success task result",
+				"flow_run_id", statusMsg.FlowRunId,
+				"task_name", statusMsg.TaskName,
+				"error", err)
+			return fmt.Errorf("failed to record flow run success task result: %w", err)
+		}
 		fs.log.Debug("Updated task run result cache key",
 			"flow_run_id", statusMsg.FlowRunId,
 			"task_name", statusMsg.TaskName,
@@ -398,7 +824,56 @@ func (fs *FlowService) handleTaskRunStatusUpdateJS(msg jetstream.Msg) error {
 		"flow_run_id", statusMsg.FlowRunId,
 		"task_name", statusMsg.TaskName,
 		"status", statusMsg.Status)
+	fs.recordFlowRunEvent(queries, statusMsg.FlowRunId, statusMsg.TaskName, db.FlowRunEventTaskStatus, statusMsg.EventTimestamp, db.FlowRunEventData{
+		Status:       statusMsg.Status,
+		ErrorMessage: statusMsg.ErrorMessage,
+	})
+
+	if statusMsg.Status == db.FlowStatusSuccess || statusMsg.Status == db.FlowStatusFailed {
+		fs.recordTaskRun(queries, statusMsg.FlowRunId, statusMsg.Status)
+	}
 
 	// Acknowledge the message
 	return msg.Ack()
 }
+
+// handleFlowRunGraphJS handles FlowRunGraph events via JetStream, the task DAG of the run is stored and
+// a pending task run is created for each of its tasks
+func (fs *FlowService) handleFlowRunGraphJS(msg jetstream.Msg) error {
+	eventData, err := service.ParseEvent[*service.FlowRunGraphEventMessage](msg.Data())
+	if err != nil {
+		fs.log.Error("Failed to parse FlowRunGraph event", "error", err)
+		return err
+	}
+
+	graphMsg := eventData.Msg
+	queries := db.New(fs.s.GetDB())
+	for i, task := range graphMsg.Tasks {
+		dependsOn := task.DependsOn
+		if dependsOn == nil {
+			dependsOn = []string{}
+		}
+		if err := queries.UpsertFlowRunGraphTask(fs.ctx, db.UpsertFlowRunGraphTaskParams{
+			FlowRunID:         graphMsg.FlowRunId,
+			TaskName:          task.Name,
+			DependsOn:         dependsOn,
+			MaxRetries:        task.MaxRetries,
+			RetryDelaySeconds: task.RetryDelaySeconds,
+			Position:          int32(i),
+		}); err != nil {
+			fs.log.Error("Failed to store flow run graph task", "flow_run_id", graphMsg.FlowRunId, "task_name", task.Name, "error", err)
+			return fmt.Errorf("failed to store flow run graph task: %w", err)
+		}
+		if err := queries.EnsureFlowTaskRun(fs.ctx, db.EnsureFlowTaskRunParams{
+			FlowRunID:  graphMsg.FlowRunId,
+			TaskName:   task.Name,
+			MaxRetries: pgtype.Int4{Int32: task.MaxRetries, Valid: true},
+		}); err != nil {
+			fs.log.Error("Failed to create flow task run", "flow_run_id", graphMsg.FlowRunId, "task_name", task.Name, "error", err)
+			return fmt.Errorf("failed to create flow task run: %w", err)
+		}
+	}
+
+	fs.log.Info("FlowRunGraph stored successfully via JetStream", "flow_run_id", graphMsg.FlowRunId, "tasks", len(graphMsg.Tasks))
+	return msg.Ack()
+}
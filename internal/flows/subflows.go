@@ -0,0 +1,70 @@
+package flows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// parentFlowRun returns the parent of a sub-flow run requested by a flow run of the workspace, which must still be running
+func (fs *FlowService) parentFlowRun(queries *db.Queries, workspaceID uuid.UUID, parentRunID *uuid.UUID) (pgtype.UUID, error) {
+	if parentRunID == nil {
+		return pgtype.UUID{}, nil
+	}
+	parent, err := queries.GetWorkspaceFlowRun(fs.ctx, db.GetWorkspaceFlowRunParams{WorkspaceID: workspaceID, FlowRunID: *parentRunID})
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to get parent flow run %s: %w", *parentRunID, err)
+	}
+	if parent.IsCompleted() {
+		return pgtype.UUID{}, fmt.Errorf("parent flow run %s already ended with status %s", *parentRunID, parent.Status)
+	}
+	return pgtype.UUID{Bytes: *parentRunID, Valid: true}, nil
+}
+
+// notifyFlowRunCompleted publishes the completion of a flow run which reached its final status, to the parent
+// flow run waiting for it, and sends the notifications of its flow. A failed run with retries left is not completed yet.
+func (fs *FlowService) notifyFlowRunCompleted(h *service.EventHeaders, traceID string, flowRun db.FlowRun) {
+	if !flowRun.IsCompleted() {
+		return
+	}
+	msg := &service.FlowRunCompletedEventMessage{
+		FlowRunId:      flowRun.FlowRunID,
+		Status:         flowRun.Status,
+		ErrorMessage:   flowRun.ErrorMessage.String,
+		EventTimestamp: time.Now().UTC(),
+	}
+	if flowRun.ParentRunID.Valid {
+		parentRunID := uuid.UUID(flowRun.ParentRunID.Bytes)
+		msg.ParentRunId = &parentRunID
+	}
+	event := service.NewEvent(msg, h, &service.EventMetadata{
+		TraceID:   traceID,
+		Timestamp: time.Now().UTC(),
+	})
+	if err := event.Publish(fs.s.GetNATS()); err != nil {
+		fs.log.Error("Failed to publish flow run completion", "flow_run_id", flowRun.FlowRunID, "error", err)
+	} else {
+		fs.log.Debug("Published flow run completion", "flow_run_id", flowRun.FlowRunID, "status", flowRun.Status, "parent_run_id", msg.ParentRunId)
+	}
+	fs.sendFlowNotifications(flowRun)
+}
+
+// cancelChildFlowRuns cancels the active sub-flow runs of a cancelled flow run, with their own sub-flow runs
+func (fs *FlowService) cancelChildFlowRuns(queries *db.Queries, h *service.EventHeaders, traceID string, parentRunID uuid.UUID) {
+	children, err := queries.GetChildFlowRunsByParentID(fs.ctx, pgtype.UUID{Bytes: parentRunID, Valid: true})
+	if err != nil {
+		fs.log.Error("Failed to list child flow runs", "flow_run_id", parentRunID, "error", err)
+		return
+	}
+	for _, child := range children {
+		if !child.IsActive() {
+			continue
+		}
+		// Failures are logged by cancelFlowRun, a child which ended in between is skipped
+		fs.cancelFlowRun(queries, h, traceID, child.FlowRunID, fmt.Sprintf("Parent flow run %s cancelled", parentRunID))
+	}
+}
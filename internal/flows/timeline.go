@@ -0,0 +1,15 @@
+package flows
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+// recordFlowRunEvent appends an event to the timeline of a flow run, a failure is logged without failing the transition
+func (fs *FlowService) recordFlowRunEvent(queries *db.Queries, flowRunID uuid.UUID, taskName string, eventType string, timestamp time.Time, data db.FlowRunEventData) {
+	if err := queries.RecordFlowRunEvent(fs.ctx, flowRunID, taskName, eventType, db.FlowRunEventSourceOrchestrator, timestamp, data); err != nil {
+		fs.log.Warn("Failed to record flow run event", "flow_run_id", flowRunID, "task_name", taskName, "event_type", eventType, "error", err)
+	}
+}
@@ -0,0 +1,115 @@
+package flows
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+// runWorkerLiveness marks the workers whose heartbeat stopped as failed and reschedules the active flow runs of the
+// stopped workers, until the service stops. Each instance of the flows service looks them up, a run is only
+// rescheduled once.
+func (fs *FlowService) runWorkerLiveness(cfg *service.FlowWorkersConfig) {
+	ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fs.ctx.Done():
+			return
+		case <-ticker.C:
+			fs.checkWorkers(cfg, time.Now())
+		}
+	}
+}
+
+// checkWorkers fails the workers without heartbeat within the timeout, reschedules the runs they left and
+// removes the workers stopped for longer than the retention
+func (fs *FlowService) checkWorkers(cfg *service.FlowWorkersConfig, now time.Time) {
+	queries := db.New(fs.s.GetDB())
+	staleBefore := now.Add(-time.Duration(cfg.HeartbeatTimeoutSeconds) * time.Second)
+	failed, err := queries.MarkStaleWorkersFailed(fs.ctx, pgtype.Timestamptz{Time: staleBefore, Valid: true})
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			fs.log.Error("Failed to mark stale workers as failed", "error", err)
+		}
+		return
+	}
+	for _, worker := range failed {
+		fs.log.Warn("Worker heartbeat stopped, marked as failed",
+			"worker_id", worker.WorkerID,
+			"worker_name", worker.WorkerName.String,
+			"last_heartbeat", worker.LastHeartbeat.Time)
+	}
+
+	flowRuns, err := queries.RescheduleOrphanedFlowRuns(fs.ctx)
+	if err != nil {
+		fs.log.Error("Failed to reschedule the flow runs of stopped workers", "error", err)
+		return
+	}
+	for _, flowRun := range flowRuns {
+		fs.rescheduleFlowRun(queries, flowRun)
+	}
+
+	retainedAfter := now.Add(-time.Duration(cfg.RetentionHours) * time.Hour)
+	if err := queries.DeleteInactiveWorkers(fs.ctx, pgtype.Timestamptz{Time: retainedAfter, Valid: true}); err != nil {
+		fs.log.Error("Failed to remove inactive workers", "error", err)
+	}
+}
+
+// rescheduleFlowRun executes on another worker a flow run whose worker stopped, the tasks that succeeded
+// are skipped with their cached results
+func (fs *FlowService) rescheduleFlowRun(queries *db.Queries, flowRun db.FlowRun) {
+	fs.recordFlowRunEvent(queries, flowRun.FlowRunID, "", db.FlowRunEventRescheduled, time.Now(), db.FlowRunEventData{
+		Status:   flowRun.Status,
+		WorkerID: flowRun.WorkerID.String,
+		Message:  "The worker of the flow run stopped",
+	})
+	flow, parameters, successTaskResults, err := fs.flowRunExecution(queries, flowRun)
+	if err == nil {
+		err = fs.publishFlowRunExecute(&service.EventHeaders{}, utils.GenerateTraceID(), flow, flowRun.FlowRunID, flowRun.Engine, parameters, successTaskResults)
+	}
+	if err != nil {
+		fs.log.Error("Failed to reschedule flow run of a stopped worker", "flow_run_id", flowRun.FlowRunID, "error", err)
+		if updateErr := queries.UpdateFlowRunError(fs.ctx, db.UpdateFlowRunErrorParams{
+			FlowRunID:    flowRun.FlowRunID,
+			ErrorMessage: pgtype.Text{String: err.Error(), Valid: true},
+		}); updateErr != nil {
+			fs.log.Error("Failed to update flow run error", "flow_run_id", flowRun.FlowRunID, "error", updateErr)
+		}
+		return
+	}
+
+	fs.log.Info("Rescheduled flow run of a stopped worker",
+		"flow_run_id", flowRun.FlowRunID,
+		"cached_tasks", len(successTaskResults))
+}
+
+// checkWorkerLabels warns when no active worker advertises the labels required by a flow run, the run waits in the
+// stream of its labels until such a worker starts
+func (fs *FlowService) checkWorkerLabels(flowRunID uuid.UUID, requiredLabels []string) {
+	workers, err := db.New(fs.s.GetDB()).GetActiveWorkers(fs.ctx)
+	if err != nil {
+		fs.log.Warn("Failed to look up the workers of the required labels", "flow_run_id", flowRunID, "error", err)
+		return
+	}
+	for _, worker := range workers {
+		var info struct {
+			Labels []string `json:"labels"`
+		}
+		if err := json.Unmarshal(worker.WorkerInfo, &info); err != nil {
+			continue
+		}
+		if db.HasWorkerLabels(info.Labels, requiredLabels) {
+			return
+		}
+	}
+	fs.log.Warn("No active worker advertises the required labels, the flow run waits for one",
+		"flow_run_id", flowRunID,
+		"required_labels", requiredLabels,
+		"active_workers", len(workers))
+}
@@ -0,0 +1,106 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// AWSSecretsManagerConfig represents the configuration of AWS Secrets Manager.
+// Credentials come from the default credential chain.
+type AWSSecretsManagerConfig struct {
+	Region      string `yaml:"region"`
+	EndpointURL string `yaml:"endpoint_url"` // Optional custom endpoint, e.g. for LocalStack
+
+	AllowedPrefixes []string `yaml:"allowed_prefixes"` // Prefixes of the secret names the tools may reference, e.g. pinazu/tools/
+}
+
+// awsSecretsManagerProvider reads secrets with the GetSecretValue action, aws-sm://name#key
+type awsSecretsManagerProvider struct {
+	endpoint    string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	client      *http.Client
+}
+
+func newAWSSecretsManagerProvider(ctx context.Context, smConfig *AWSSecretsManagerConfig) (*awsSecretsManagerProvider, error) {
+	var configOptions []func(*config.LoadOptions) error
+	if smConfig.Region != "" {
+		configOptions = append(configOptions, config.WithRegion(smConfig.Region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, configOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("region is required for AWS Secrets Manager")
+	}
+
+	endpoint := smConfig.EndpointURL
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", cfg.Region)
+	}
+	return &awsSecretsManagerProvider{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		region:      cfg.Region,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Get reads the current version of the secret. The key selects a field of a secret string holding a JSON object.
+func (p *awsSecretsManagerProvider) Get(ctx context.Context, ref Reference) (string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": ref.Name})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	creds, err := p.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := p.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), "secretsmanager", p.region, time.Now()); err != nil {
+		return "", fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret value: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("secrets manager returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(errBody)))
+	}
+
+	var payload struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("failed to decode secrets manager response: %w", err)
+	}
+	if payload.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value, binary secrets are not supported", ref.Name)
+	}
+	return selectKey(*payload.SecretString, ref.Key)
+}
@@ -0,0 +1,23 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// EnvConfig represents the configuration of the secrets read from the environment variables
+type EnvConfig struct {
+	AllowedPrefixes []string `yaml:"allowed_prefixes"` // Prefixes of the variables the tools may reference, e.g. TOOL_
+}
+
+// envProvider reads secrets from the environment variables of the process, env://NAME
+type envProvider struct{}
+
+func (envProvider) Get(_ context.Context, ref Reference) (string, error) {
+	value, ok := os.LookupEnv(ref.Name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref.Name)
+	}
+	return selectKey(value, ref.Key)
+}
@@ -0,0 +1,228 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schemes of the supported secret references
+const (
+	SchemeEnv               = "env"
+	SchemeVault             = "vault"
+	SchemeAWSSecretsManager = "aws-sm"
+)
+
+// DefaultCacheTTL is how long a resolved secret is reused before it is read again from its secrets manager
+const DefaultCacheTTL = 5 * time.Minute
+
+var schemes = []string{SchemeEnv, SchemeVault, SchemeAWSSecretsManager}
+
+type (
+	// Reference locates a secret in a secrets manager, written as scheme://name#key.
+	// The optional key selects a field of a secret holding a JSON object.
+	Reference struct {
+		Scheme string
+		Name   string
+		Key    string
+	}
+
+	// Provider reads secrets from a secrets manager
+	Provider interface {
+		Get(ctx context.Context, ref Reference) (string, error)
+	}
+
+	// Config represents the configuration of the secrets managers, a scheme is only resolved once its manager is configured
+	Config struct {
+		Env               *EnvConfig               `yaml:"env"`
+		Vault             *VaultConfig             `yaml:"vault"`
+		AWSSecretsManager *AWSSecretsManagerConfig `yaml:"aws_secrets_manager"`
+		CacheTTLSeconds   int                      `yaml:"cache_ttl_seconds"` // How long a resolved secret is reused, default 300
+	}
+
+	// Resolver resolves the secret references with the provider of their scheme and caches the resolved secrets.
+	// References are resolved when a tool is executed, so rotated secrets are picked up without updating the tools.
+	Resolver struct {
+		providers map[string]Provider
+		ttl       time.Duration
+		now       func() time.Time
+
+		mu    sync.Mutex
+		cache map[string]cachedSecret
+	}
+
+	cachedSecret struct {
+		value     string
+		expiresAt time.Time
+	}
+
+	// allowedProvider restricts a provider to the secrets whose name starts with one of the allowed prefixes, the tools
+	// of any user reference secrets so a provider cannot read every secret its credentials can
+	allowedProvider struct {
+		Provider
+		prefixes []string
+	}
+)
+
+// IsReference reports whether a value is a secret reference rather than the secret itself
+func IsReference(value string) bool {
+	for _, scheme := range schemes {
+		if strings.HasPrefix(value, scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseReference parses a secret reference written as scheme://name#key
+func ParseReference(value string) (Reference, error) {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok || !IsReference(value) {
+		return Reference{}, fmt.Errorf("invalid secret reference %q, expected one of %s followed by ://", value, strings.Join(schemes, ", "))
+	}
+	name, key, _ := strings.Cut(rest, "#")
+	if name == "" {
+		return Reference{}, fmt.Errorf("invalid secret reference %q, the secret name is empty", value)
+	}
+	return Reference{Scheme: scheme, Name: name, Key: key}, nil
+}
+
+// String returns the reference in its scheme://name#key form
+func (r Reference) String() string {
+	if r.Key == "" {
+		return r.Scheme + "://" + r.Name
+	}
+	return r.Scheme + "://" + r.Name + "#" + r.Key
+}
+
+// NewResolver creates a resolver with the configured secrets managers, config may be nil to resolve no reference.
+// Each secrets manager requires the prefixes of the secret names it is allowed to read.
+func NewResolver(ctx context.Context, config *Config) (*Resolver, error) {
+	r := &Resolver{
+		providers: make(map[string]Provider),
+		ttl:       DefaultCacheTTL,
+		now:       time.Now,
+		cache:     make(map[string]cachedSecret),
+	}
+	if config == nil {
+		return r, nil
+	}
+	if config.CacheTTLSeconds > 0 {
+		r.ttl = time.Duration(config.CacheTTLSeconds) * time.Second
+	}
+	if config.Env != nil {
+		provider, err := newAllowedProvider(envProvider{}, config.Env.AllowedPrefixes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid environment variables provider: %w", err)
+		}
+		r.providers[SchemeEnv] = provider
+	}
+	if config.Vault != nil {
+		provider, err := newVaultProvider(config.Vault)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create vault provider: %w", err)
+		}
+		if r.providers[SchemeVault], err = newAllowedProvider(provider, config.Vault.AllowedPrefixes); err != nil {
+			return nil, fmt.Errorf("invalid vault provider: %w", err)
+		}
+	}
+	if config.AWSSecretsManager != nil {
+		provider, err := newAWSSecretsManagerProvider(ctx, config.AWSSecretsManager)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AWS Secrets Manager provider: %w", err)
+		}
+		if r.providers[SchemeAWSSecretsManager], err = newAllowedProvider(provider, config.AWSSecretsManager.AllowedPrefixes); err != nil {
+			return nil, fmt.Errorf("invalid AWS Secrets Manager provider: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Resolve returns the secret a value refers to, values that are not references are returned unchanged
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	ref, err := ParseReference(value)
+	if err != nil {
+		return "", err
+	}
+	if r == nil {
+		return "", fmt.Errorf("no secrets manager configured to resolve %s", ref)
+	}
+	provider, ok := r.providers[ref.Scheme]
+	if !ok {
+		return "", fmt.Errorf("no secrets manager configured for %s:// references", ref.Scheme)
+	}
+
+	r.mu.Lock()
+	cached, ok := r.cache[value]
+	r.mu.Unlock()
+	if ok && r.now().Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	secret, err := provider.Get(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret %s: %w", ref, err)
+	}
+
+	r.mu.Lock()
+	r.cache[value] = cachedSecret{value: secret, expiresAt: r.now().Add(r.ttl)}
+	r.mu.Unlock()
+	return secret, nil
+}
+
+// newAllowedProvider restricts a provider to the secret names starting with one of the prefixes, at least one is required
+func newAllowedProvider(provider Provider, prefixes []string) (*allowedProvider, error) {
+	if len(prefixes) == 0 {
+		return nil, fmt.Errorf("allowed_prefixes is required")
+	}
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			return nil, fmt.Errorf("allowed_prefixes cannot contain an empty prefix")
+		}
+	}
+	return &allowedProvider{Provider: provider, prefixes: prefixes}, nil
+}
+
+// Get reads the secret when its name starts with an allowed prefix
+func (p *allowedProvider) Get(ctx context.Context, ref Reference) (string, error) {
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(ref.Name, prefix) {
+			return p.Provider.Get(ctx, ref)
+		}
+	}
+	return "", fmt.Errorf("secret %s is not allowed, its name must start with one of %s", ref.Name, strings.Join(p.prefixes, ", "))
+}
+
+// selectKey returns the field key of a secret holding a JSON object, or the whole secret without a key
+func selectKey(secret, key string) (string, error) {
+	if key == "" {
+		return secret, nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object, cannot select key %q", key)
+	}
+	return fieldValue(fields, key)
+}
+
+// fieldValue returns a field of a secret object as a string
+func fieldValue(fields map[string]any, key string) (string, error) {
+	v, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("secret has no key %q", key)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
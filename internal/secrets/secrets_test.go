@@ -0,0 +1,156 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Reference
+		wantErr bool
+	}{
+		{value: "vault://secret/tools/weather#api_key", want: Reference{Scheme: SchemeVault, Name: "secret/tools/weather", Key: "api_key"}},
+		{value: "aws-sm://prod/weather", want: Reference{Scheme: SchemeAWSSecretsManager, Name: "prod/weather"}},
+		{value: "env://WEATHER_API_KEY", want: Reference{Scheme: SchemeEnv, Name: "WEATHER_API_KEY"}},
+		{value: "vault://#api_key", wantErr: true},
+		{value: "gcp://project/secret", wantErr: true},
+		{value: "sk-plain-key", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseReference(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.value, got.String())
+		})
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("PINAZU_TEST_TOOL_KEY", "env-secret")
+	t.Setenv("PINAZU_TEST_TOOL_JSON", `{"api_key":"json-secret","port":8080}`)
+
+	r, err := NewResolver(ctx, &Config{Env: &EnvConfig{AllowedPrefixes: []string{"PINAZU_TEST_TOOL_"}}})
+	require.NoError(t, err)
+
+	got, err := r.Resolve(ctx, "sk-plain-key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-plain-key", got)
+
+	got, err = r.Resolve(ctx, "env://PINAZU_TEST_TOOL_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, "env-secret", got)
+
+	got, err = r.Resolve(ctx, "env://PINAZU_TEST_TOOL_JSON#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "json-secret", got)
+
+	got, err = r.Resolve(ctx, "env://PINAZU_TEST_TOOL_JSON#port")
+	require.NoError(t, err)
+	assert.Equal(t, "8080", got)
+
+	_, err = r.Resolve(ctx, "env://PINAZU_TEST_TOOL_MISSING")
+	assert.Error(t, err)
+
+	// The variables outside the allowed prefixes are not read
+	t.Setenv("PINAZU_TEST_DB_PASSWORD", "db-secret")
+	_, err = r.Resolve(ctx, "env://PINAZU_TEST_DB_PASSWORD")
+	assert.ErrorContains(t, err, "is not allowed")
+
+	_, err = r.Resolve(ctx, "vault://secret/tools/weather#api_key")
+	assert.ErrorContains(t, err, "no secrets manager configured")
+
+	// The environment variables are only read once configured
+	unconfigured, err := NewResolver(ctx, nil)
+	require.NoError(t, err)
+	_, err = unconfigured.Resolve(ctx, "env://PINAZU_TEST_TOOL_KEY")
+	assert.ErrorContains(t, err, "no secrets manager configured")
+	_, err = NewResolver(ctx, &Config{Env: &EnvConfig{}})
+	assert.ErrorContains(t, err, "allowed_prefixes is required")
+
+	var nilResolver *Resolver
+	got, err = nilResolver.Resolve(ctx, "sk-plain-key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-plain-key", got)
+	_, err = nilResolver.Resolve(ctx, "env://PINAZU_TEST_TOOL_KEY")
+	assert.Error(t, err)
+}
+
+func TestResolver_Cache(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("PINAZU_TEST_TOOL_KEY", "first")
+
+	r, err := NewResolver(ctx, &Config{CacheTTLSeconds: 60, Env: &EnvConfig{AllowedPrefixes: []string{"PINAZU_TEST_"}}})
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	got, err := r.Resolve(ctx, "env://PINAZU_TEST_TOOL_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, "first", got)
+
+	// The rotated secret is picked up once the cached value expires
+	t.Setenv("PINAZU_TEST_TOOL_KEY", "second")
+	got, _ = r.Resolve(ctx, "env://PINAZU_TEST_TOOL_KEY")
+	assert.Equal(t, "first", got)
+
+	now = now.Add(time.Minute)
+	got, _ = r.Resolve(ctx, "env://PINAZU_TEST_TOOL_KEY")
+	assert.Equal(t, "second", got)
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/tools/weather":
+			w.Write([]byte(`{"data":{"data":{"api_key":"vault-secret","region":"eu"}}}`))
+		case "/v1/secret/data/tools/single":
+			w.Write([]byte(`{"data":{"data":{"token":"single-secret"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r, err := NewResolver(ctx, &Config{Vault: &VaultConfig{Address: server.URL, Token: "test-token", AllowedPrefixes: []string{"secret/tools/"}}})
+	require.NoError(t, err)
+
+	got, err := r.Resolve(ctx, "vault://secret/tools/weather#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "vault-secret", got)
+
+	got, err = r.Resolve(ctx, "vault://secret/tools/single")
+	require.NoError(t, err)
+	assert.Equal(t, "single-secret", got)
+
+	_, err = r.Resolve(ctx, "vault://secret/tools/weather")
+	assert.ErrorContains(t, err, "select one with #key")
+
+	_, err = r.Resolve(ctx, "vault://secret/tools/missing#api_key")
+	assert.ErrorContains(t, err, "HTTP 404")
+
+	_, err = r.Resolve(ctx, "vault://secret/admin/root#token")
+	assert.ErrorContains(t, err, "is not allowed")
+
+	t.Setenv("VAULT_TOKEN", "")
+	_, err = NewResolver(ctx, &Config{Vault: &VaultConfig{Address: server.URL}})
+	assert.ErrorContains(t, err, "vault token is required")
+}
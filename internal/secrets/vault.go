@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// VaultConfig represents the configuration of a HashiCorp Vault KV version 2 secrets engine
+type VaultConfig struct {
+	Address   string `yaml:"address"`   // Vault server address, defaults to VAULT_ADDR
+	Token     string `yaml:"token"`     // Vault token, defaults to VAULT_TOKEN
+	Namespace string `yaml:"namespace"` // Optional Vault Enterprise namespace
+
+	AllowedPrefixes []string `yaml:"allowed_prefixes"` // Prefixes of the mount/path the tools may reference, e.g. secret/tools/
+}
+
+// vaultProvider reads secrets from a KV version 2 engine, vault://mount/path#key
+type vaultProvider struct {
+	address   string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+func newVaultProvider(config *VaultConfig) (*vaultProvider, error) {
+	p := &vaultProvider{
+		address:   config.Address,
+		token:     config.Token,
+		namespace: config.Namespace,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+	if p.address == "" {
+		p.address = os.Getenv("VAULT_ADDR")
+	}
+	if p.token == "" {
+		p.token = os.Getenv("VAULT_TOKEN")
+	}
+	if p.address == "" {
+		return nil, fmt.Errorf("vault address is required")
+	}
+	if p.token == "" {
+		return nil, fmt.Errorf("vault token is required")
+	}
+	p.address = strings.TrimSuffix(p.address, "/")
+	return p, nil
+}
+
+// Get reads the secret at path of the KV engine mounted at mount. Without a key, the secret must hold a single field.
+func (p *vaultProvider) Get(ctx context.Context, ref Reference) (string, error) {
+	mount, path, ok := strings.Cut(ref.Name, "/")
+	if !ok || path == "" {
+		return "", fmt.Errorf("vault secret name must be mount/path, got %q", ref.Name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+"/v1/"+mount+"/data/"+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("vault returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	fields := payload.Data.Data
+
+	if ref.Key != "" {
+		return fieldValue(fields, ref.Key)
+	}
+	if len(fields) != 1 {
+		return "", fmt.Errorf("secret has %d keys, select one with #key", len(fields))
+	}
+	for key := range fields {
+		return fieldValue(fields, key)
+	}
+	return "", nil
+}
@@ -1,12 +1,19 @@
 package service
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/logger"
+	"github.com/pinazu/internal/secrets"
 	"github.com/pinazu/internal/telemetry"
 	"github.com/urfave/cli/v3"
 	"gopkg.in/yaml.v3"
@@ -29,31 +36,111 @@ type (
 
 		// ErrorHandler is invoked on any nats-related service error.
 		ErrorHandler ErrHandler
+
+		// Streams are the JetStream streams the service needs, checked by its readiness.
+		Streams []string `json:"streams"`
 	}
 
 	// ExternalDependenciesConfig represents the configuration for external dependencies.
 	ExternalDependenciesConfig struct {
-		Debug     bool              `yaml:"debug"`
-		Http      *HttpServerConfig `yaml:"http"`
-		Nats      *NatsConfig       `yaml:"nats"`
-		Database  *DatabaseConfig   `yaml:"database"`
-		Tracing   *TracingConfig    `yaml:"tracing"`
-		Storage   *StorageConfig    `yaml:"storage"`
-		Cache     *CacheConfig      `yaml:"cache"`
-		LLMConfig *LLMConfig        `yaml:"llm_config"`
+		Debug       bool               `yaml:"debug"`
+		Http        *HttpServerConfig  `yaml:"http"`
+		Nats        *NatsConfig        `yaml:"nats"`
+		Database    *DatabaseConfig    `yaml:"database"`
+		Tracing     *TracingConfig     `yaml:"tracing"`
+		Metrics     *MetricsConfig     `yaml:"metrics"`
+		Storage     *StorageConfig     `yaml:"storage"`
+		Cache       *CacheConfig       `yaml:"cache"`
+		LLMConfig   *LLMConfig         `yaml:"llm_config"`
+		Tools       *ToolsConfig       `yaml:"tools"`
+		Replication *ReplicationConfig `yaml:"replication"`
+		Secrets     *SecretsConfig     `yaml:"secrets"`
+		Worker      *WorkerConfig      `yaml:"worker"`
+		Probes      *ProbesConfig      `yaml:"probes"`
+		Tasks       *TasksConfig       `yaml:"tasks"`
+		Flows       *FlowsConfig       `yaml:"flows"`
+		Webhooks    *WebhooksConfig    `yaml:"webhooks"`
+		Embeddings  *EmbeddingsConfig  `yaml:"embeddings"`
+		Files       *FilesConfig       `yaml:"files"`
+		Health      *HealthConfig      `yaml:"health"`
+		Quotas      *db.Quotas         `yaml:"quotas"` // Daily usage limits of the users and of the workspaces, unlimited when unset
 	}
 
 	// CacheType represents the type of caching system to use
 	CacheType string
 
 	HttpServerConfig struct {
-		Port string `yaml:"port"`
+		Port                     string                 `yaml:"port"`
+		DefaultAgentID           string                 `yaml:"default_agent_id"` // Workspace default agent of the quickstart endpoint, for the users without a default agent
+		RequireAPIKey            bool                   `yaml:"require_api_key"`  // Reject the /v1 requests without an API key or a session, they go through unauthenticated otherwise
+		OIDC                     *OIDCConfig            `yaml:"oidc"`
+		TLS                      *TLSConfig             `yaml:"tls"`                         // Serve HTTPS, plain HTTP when unset
+		ReadTimeoutSeconds       int                    `yaml:"read_timeout_seconds"`        // Time to read a whole request, default 120
+		ReadHeaderTimeoutSeconds int                    `yaml:"read_header_timeout_seconds"` // Time to read the headers of a request, default 10
+		WriteTimeoutSeconds      int                    `yaml:"write_timeout_seconds"`       // Time to write a response, default 120 for the long streaming responses
+		IdleTimeoutSeconds       int                    `yaml:"idle_timeout_seconds"`        // Time a keep-alive connection waits for its next request, default 120
+		MaxBodyBytes             int64                  `yaml:"max_body_bytes"`              // Request bodies above this size are rejected with 413, default 32 MiB
+		ShutdownTimeoutSeconds   int                    `yaml:"shutdown_timeout_seconds"`    // Time the requests in flight are given to finish on shutdown, default 30
+		CORS                     *CORSConfig            `yaml:"cors"`
+		SecurityHeaders          *SecurityHeadersConfig `yaml:"security_headers"`
+		WebSocket                *WebSocketConfig       `yaml:"websocket"`
+	}
+
+	// WebSocketConfig represents the delivery of the events to the WebSocket connections. Each connection queues its
+	// events, a full queue applies the overflow policy.
+	WebSocketConfig struct {
+		QueueSize                  int    `yaml:"queue_size"`                    // Events queued for a connection, default 256
+		OverflowPolicy             string `yaml:"overflow_policy"`               // drop_oldest, drop_newest, coalesce or disconnect, default drop_oldest
+		SlowConsumerTimeoutSeconds int    `yaml:"slow_consumer_timeout_seconds"` // Time a queue can stay full before its connection is closed, default 30
+	}
+
+	// CORSConfig represents the cross-origin requests the browsers are allowed to send to the API.
+	CORSConfig struct {
+		AllowedOrigins   []string `yaml:"allowed_origins"`   // Origins allowed, such as https://app.example.com or https://*.example.com, default any origin
+		AllowedMethods   []string `yaml:"allowed_methods"`   // Methods allowed, default GET, POST, PUT, PATCH, DELETE and OPTIONS
+		AllowedHeaders   []string `yaml:"allowed_headers"`   // Request headers allowed, default the headers of the API
+		ExposedHeaders   []string `yaml:"exposed_headers"`   // Response headers the scripts can read, default the headers of the API
+		AllowCredentials bool     `yaml:"allow_credentials"` // Let the browsers send the session cookie, requires explicit allowed origins
+		MaxAgeSeconds    int      `yaml:"max_age_seconds"`   // Time the browsers cache a preflight response, default 600
+	}
+
+	// SecurityHeadersConfig represents the security headers sent with every response, "-" leaves a header out.
+	SecurityHeadersConfig struct {
+		ContentTypeOptions      string `yaml:"content_type_options"`       // X-Content-Type-Options, default nosniff
+		FrameOptions            string `yaml:"frame_options"`              // X-Frame-Options, default DENY
+		ReferrerPolicy          string `yaml:"referrer_policy"`            // Referrer-Policy, default strict-origin-when-cross-origin
+		CrossOriginOpenerPolicy string `yaml:"cross_origin_opener_policy"` // Cross-Origin-Opener-Policy, default same-origin
+		ContentSecurityPolicy   string `yaml:"content_security_policy"`    // Content-Security-Policy, not sent by default
+		PermissionsPolicy       string `yaml:"permissions_policy"`         // Permissions-Policy, not sent by default
+	}
+
+	// TLSConfig represents the certificate the API gateway serves HTTPS with, HSTS is only sent over HTTPS.
+	TLSConfig struct {
+		CertFile              string `yaml:"cert_file"`               // PEM certificate, with its intermediates
+		KeyFile               string `yaml:"key_file"`                // PEM private key of the certificate
+		HSTSMaxAgeSeconds     int    `yaml:"hsts_max_age_seconds"`    // Max age of the Strict-Transport-Security header, default 1 year, negative to disable it
+		HSTSIncludeSubdomains bool   `yaml:"hsts_include_subdomains"` // Apply HSTS to the subdomains as well
+	}
+
+	// OIDCConfig represents the OpenID Connect provider the users log in with, the login is disabled when no issuer is set.
+	// The users are provisioned on their first login, a user of another provider with the same email is not logged in.
+	OIDCConfig struct {
+		IssuerURL         string   `yaml:"issuer_url"`          // Issuer of the provider, e.g. https://accounts.google.com
+		ClientID          string   `yaml:"client_id"`           // Client registered with the provider
+		ClientSecret      string   `yaml:"client_secret"`       // Secret of the client
+		RedirectURL       string   `yaml:"redirect_url"`        // Callback registered with the provider, e.g. https://pinazu.example.com/v1/auth/callback
+		ProviderName      string   `yaml:"provider_name"`       // Provider of the provisioned users: google, azure or github, default google
+		Scopes            []string `yaml:"scopes"`              // Scopes requested on top of openid, default email and profile
+		SessionTTLSeconds int      `yaml:"session_ttl_seconds"` // Lifetime of a session, default 12 hours
+		InsecureCookies   bool     `yaml:"insecure_cookies"`    // Send the session cookie over plain HTTP, for local development only
+		PostLoginRedirect string   `yaml:"post_login_redirect"` // Page the users land on after their login, default /
 	}
 
 	// NatsConfig represents the configuration for NATS server.
 	NatsConfig struct {
-		URL                    string           `yaml:"url"`
-		JetStreamDefaultConfig *JetStreamConfig `yaml:"jetstream_default_config"`
+		URL                    string            `yaml:"url"`
+		JetStreamDefaultConfig *JetStreamConfig  `yaml:"jetstream_default_config"`
+		Redelivery             *RedeliveryConfig `yaml:"redelivery"`
 	}
 
 	// JetStreamConfig represents the configuration for JetStream streams.
@@ -65,6 +152,17 @@ type (
 		MaxDeliver    int   `yaml:"max_deliver"`     // Maximum delivery attempts for consumers
 	}
 
+	// RedeliveryConfig represents the configuration for the detection of redelivery storms.
+	// A message delivered more than the threshold across all the consumers of its stream is parked in the dead letter stream.
+	RedeliveryConfig struct {
+		StormThreshold       int    `yaml:"storm_threshold"`          // Redeliveries of a message across consumers before it is parked, default max_deliver - 2
+		AlertWebhookURL      string `yaml:"alert_webhook_url"`        // Optional URL receiving a JSON POST for every parked message
+		AlertPreset          string `yaml:"alert_preset"`             // Optional payload format of the alert, "slack" or "teams", raw JSON when unset
+		AlertTemplate        string `yaml:"alert_template"`           // Optional Go template of the alert payload, takes precedence over the preset
+		AlertTimeoutSeconds  int    `yaml:"alert_timeout_seconds"`    // Timeout of the alert webhook request, default 10
+		DeadLetterMaxAgeDays int    `yaml:"dead_letter_max_age_days"` // Retention of the parked messages, default 14
+	}
+
 	// DatabaseConfig represents the configuration for the database.
 	// Support only postgres for now.
 	DatabaseConfig struct {
@@ -76,6 +174,15 @@ type (
 		SSLMode  string `yaml:"sslmode"` // e.g., "disable", "require", "verify-ca", "verify-full". Certain DB setup may require SSL mode, e.g. AWS RDS 17+ need "require".
 	}
 
+	// SecretsConfig represents the keys encrypting the secrets stored in the database, such as the tool API keys.
+	// Each secret is encrypted with its own data key, wrapped by the current key. Secrets are stored in plaintext when no key is set.
+	// The secrets managers resolve the secret references, such as vault://secret/tools/weather#api_key, used instead of the secrets.
+	SecretsConfig struct {
+		KeyID    string            `yaml:"key_id"`   // ID of the key wrapping the data keys of new secrets
+		Keys     map[string]string `yaml:"keys"`     // Base64 encoded 32 byte keys by ID, previous keys stay listed to read the secrets they wrapped
+		Managers *secrets.Config   `yaml:"managers"` // Secrets managers resolving the secret references
+	}
+
 	// TracingConfig represents the configuration for OpenTelemetry tracing.
 	TracingConfig struct {
 		ServiceName      string  `yaml:"service_name"`
@@ -84,6 +191,19 @@ type (
 		SamplingRatio    float64 `yaml:"sampling_ratio"`
 	}
 
+	// MetricsConfig represents the configuration for the OpenTelemetry metrics of the services, served in the Prometheus
+	// text format.
+	MetricsConfig struct {
+		ListenAddress string `yaml:"listen_address"` // Address of the metrics endpoint, e.g. :9464, the metrics are not served when empty
+		Path          string `yaml:"path"`           // Path of the metrics endpoint, default /metrics
+	}
+
+	// HealthConfig represents the configuration of the liveness and readiness endpoints of the services. The API
+	// service also serves them on its own address.
+	HealthConfig struct {
+		ListenAddress string `yaml:"listen_address"` // Address of the health endpoints, e.g. :8081, may be the address of the metrics. Not served when empty
+	}
+
 	// StorageConfig represents the configuration for storage backends.
 	StorageConfig struct {
 		S3 *S3Config `yaml:"s3"`
@@ -108,8 +228,17 @@ type (
 	}
 
 	LLMConfig struct {
-		Bedrock *BedrockLLMServiceConfig `yaml:"bedrock"`
-		Google  *GoogleLLMServiceConfig  `yaml:"google"`
+		Bedrock     *BedrockLLMServiceConfig `yaml:"bedrock"`
+		Google      *GoogleLLMServiceConfig  `yaml:"google"`
+		PromptCache *PromptCacheConfig       `yaml:"prompt_cache"`
+	}
+
+	// PromptCacheConfig represents the configuration for warming the provider prompt cache of agents.
+	// The cached prefix is the system prompt and tools of the agent, uploaded with a minimal request.
+	PromptCacheConfig struct {
+		Warmup                 bool `yaml:"warmup"`                   // Upload the cached prefix when an agent is created or updated
+		RefreshIntervalSeconds int  `yaml:"refresh_interval_seconds"` // Refresh the cached prefix of active agents before the provider TTL expires, default 240
+		IdleTimeoutSeconds     int  `yaml:"idle_timeout_seconds"`     // Stop refreshing agents not invoked within this window, default 3600
 	}
 
 	// A separation for configuration in order to overcome the Quota limit put by AWS on various Bedrock services.
@@ -124,6 +253,358 @@ type (
 	GoogleLLMServiceConfig struct {
 		APIKey string `yaml:"api_key"` // API key for Google AI services
 	}
+
+	// ReplicationConfig represents the configuration for multi-region active/passive deployments.
+	// Replication is disabled when no region is set.
+	ReplicationConfig struct {
+		Region             string   `yaml:"region"`               // Region this deployment runs in
+		PrimaryRegion      string   `yaml:"primary_region"`       // Region serving traffic until a failover is recorded
+		SourceDomain       string   `yaml:"source_domain"`        // JetStream domain of the primary region, mirrored by the standby
+		SourceAPIPrefix    string   `yaml:"source_api_prefix"`    // JetStream API prefix of the primary region, alternative to source_domain
+		Streams            []string `yaml:"streams"`              // Streams mirrored to the standby, defaults to all managed streams
+		Tables             []string `yaml:"tables"`               // Tables in the logical replication publication, defaults to the key tables
+		Publication        string   `yaml:"publication"`          // Postgres publication name on the primary, default "pinazu_replication"
+		Subscription       string   `yaml:"subscription"`         // Postgres subscription name on the standby, default "pinazu_replication"
+		PrimaryDatabaseURL string   `yaml:"primary_database_url"` // Connection string the standby subscription uses to reach the primary database
+	}
+
+	// ToolsConfig represents the configuration for the built-in tools executed by the tools service.
+	ToolsConfig struct {
+		CodeInterpreter *CodeInterpreterConfig   `yaml:"code_interpreter"`
+		WebSearch       *WebSearchConfig         `yaml:"web_search"`
+		FetchURL        *FetchURLConfig          `yaml:"fetch_url"`
+		HealthCheck     *ToolHealthCheckConfig   `yaml:"health_check"`
+		ResultOffload   *ToolResultOffloadConfig `yaml:"result_offload"`
+		Batch           *BatchToolConfig         `yaml:"batch"`
+		Audit           *ToolAuditConfig         `yaml:"audit"`
+		InvokeAgent     *InvokeAgentConfig       `yaml:"invoke_agent"`
+		TaskBudget      *TaskToolBudgetConfig    `yaml:"task_budget"`
+	}
+
+	// TaskToolBudgetConfig represents the budget of the tool runs outstanding at once for a task, batch children included.
+	// The surplus runs queue in the order the agent requested them, so a turn requesting many tools does not flood
+	// the services they call.
+	TaskToolBudgetConfig struct {
+		Disabled          bool `yaml:"disabled"`            // The tool runs of a task all start at once
+		MaxConcurrentRuns int  `yaml:"max_concurrent_runs"` // Tool runs of a task outstanding at once, default 10
+	}
+
+	// InvokeAgentConfig represents the configuration for the sub-agent runs started by the invoke_agent tool.
+	InvokeAgentConfig struct {
+		TimeoutSeconds int `yaml:"timeout_seconds"` // A sub-agent not answering within it gives an error result to the calling agent, default 600
+	}
+
+	// ToolAuditConfig represents the configuration for the audit log of the tool runs, kept for compliance review.
+	// Each entry holds who triggered the run with its full input and output, redacted by the rules below.
+	ToolAuditConfig struct {
+		Disabled             bool            `yaml:"disabled"`               // The tool runs are no longer recorded
+		RetentionDays        int             `yaml:"retention_days"`         // Days the entries are kept before being pruned, default 90
+		RedactKeys           []string        `yaml:"redact_keys"`            // Input and output keys whose values are redacted, matched case-insensitively
+		RedactPatterns       []string        `yaml:"redact_patterns"`        // Regular expressions of the string values redacted, e.g. card numbers
+		Rules                []ToolAuditRule `yaml:"rules"`                  // Retention and redaction of specific tools, the first matching rule applies
+		PruneIntervalMinutes int             `yaml:"prune_interval_minutes"` // How often the expired entries are pruned, default 60
+	}
+
+	// ToolAuditRule overrides the audit settings of the tools it matches.
+	ToolAuditRule struct {
+		Tool           string   `yaml:"tool"`            // Name of the tool, a trailing * matches a name prefix
+		RetentionDays  int      `yaml:"retention_days"`  // Days the entries of the tool are kept, the default retention when unset
+		RedactKeys     []string `yaml:"redact_keys"`     // Keys redacted in addition to the default keys
+		RedactPatterns []string `yaml:"redact_patterns"` // Patterns redacted in addition to the default patterns
+		OmitInput      bool     `yaml:"omit_input"`      // The input of the runs is not kept
+		OmitOutput     bool     `yaml:"omit_output"`     // The output of the runs is not kept
+	}
+
+	// BatchToolConfig represents the limits of the batch_tool calls, the calls exceeding them get an error result.
+	BatchToolConfig struct {
+		MaxDepth    int `yaml:"max_depth"`    // Batches nested in a batch call, the top-level batch included, default 3
+		MaxChildren int `yaml:"max_children"` // Invocations run in parallel by a single batch, default 20
+	}
+
+	// ToolResultOffloadConfig represents the configuration for the offloading of the large tool results to object storage.
+	// A result above the threshold is uploaded to the bucket and replaced with a reference in the tool runs.
+	// How the agent receives it is set by the tool_results section of the agent specs.
+	ToolResultOffloadConfig struct {
+		Bucket         string `yaml:"bucket"`          // Bucket receiving the large results, offloading is disabled when empty
+		Prefix         string `yaml:"prefix"`          // Key prefix of the uploaded results, default "tool-results/"
+		ThresholdBytes int    `yaml:"threshold_bytes"` // Results above this size are offloaded, default 262144
+		MaxChars       int    `yaml:"max_chars"`       // Characters of an offloaded result given to the agents truncating them, default 20000
+	}
+
+	// ToolHealthCheckConfig represents the configuration for the background prober of the tool health checks.
+	ToolHealthCheckConfig struct {
+		Disabled            bool `yaml:"disabled"`              // Disables the prober, the tool statuses are no longer updated
+		PollIntervalSeconds int  `yaml:"poll_interval_seconds"` // How often the tools due for a check are looked up, default 15
+		// AllowCommands allows the health checks running a command on the tools service host.
+		// It is disabled by default since anyone able to edit a tool could run commands.
+		AllowCommands bool `yaml:"allow_commands"`
+		// AllowPrivateNetworks allows the health check URLs of loopback, private and link-local addresses.
+		// It is disabled by default so the health checks cannot probe internal services.
+		AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+	}
+
+	// WorkerConfig represents the configuration of the worker nodes.
+	WorkerConfig struct {
+		Labels      []string                 `yaml:"labels"` // Labels advertised by the worker, it executes the runs of the flows requiring a subset of them
+		EdgeTools   *WorkerEdgeToolsConfig   `yaml:"edge_tools"`
+		Docker      *WorkerDockerConfig      `yaml:"docker"`
+		Limits      *WorkerLimitsConfig      `yaml:"limits"`
+		Heartbeat   *WorkerHeartbeatConfig   `yaml:"heartbeat"`
+		Concurrency *WorkerConcurrencyConfig `yaml:"concurrency"`
+		Git         *WorkerGitConfig         `yaml:"git"`
+		Python      *WorkerPythonConfig      `yaml:"python"`
+		Node        *WorkerNodeConfig        `yaml:"node"`
+	}
+
+	// WorkerEdgeToolsConfig represents the configuration for the standalone tools marked as edge, called by the worker.
+	WorkerEdgeToolsConfig struct {
+		Disabled bool     `yaml:"disabled"` // The worker only executes flows
+		Groups   []string `yaml:"groups"`   // Edge groups of the tools called by the worker, default [default]
+	}
+
+	// WorkerConcurrencyConfig represents the bound of the flow runs executed at once by the worker. The runs received
+	// beyond it wait in a queue, the ones received when the queue is full are NAK'd and redelivered later, possibly
+	// to another worker.
+	WorkerConcurrencyConfig struct {
+		MaxConcurrentFlows  int `yaml:"max_concurrent_flows"`  // Flow runs executed at once, unlimited when 0
+		MaxQueuedFlows      int `yaml:"max_queued_flows"`      // Flow runs waiting for a free slot, default max_concurrent_flows
+		RequeueDelaySeconds int `yaml:"requeue_delay_seconds"` // Delay of the redelivery of a run received when the queue is full, default 5
+	}
+
+	// WorkerDockerConfig represents the configuration of the docker engine, the worker runs the flows of this engine
+	// inside a container of the image instead of a local process. The flows with an oci:// code location run in a
+	// container of their own image with the same settings.
+	WorkerDockerConfig struct {
+		Binary             string            `yaml:"binary"`               // Docker CLI called by the worker, default docker
+		Image              string            `yaml:"image"`                // Image of the flow containers with the entrypoints and the pinazu library, required by the engine
+		Network            string            `yaml:"network"`              // Network of the containers, default host so NATS and S3 are reached at the worker addresses
+		CPUs               float64           `yaml:"cpus"`                 // CPUs of a container, the worker limits when 0
+		MemoryMB           int               `yaml:"memory_mb"`            // Memory of a container in MiB, the worker limits when 0
+		PidsLimit          int               `yaml:"pids_limit"`           // Processes of a container, unlimited when 0
+		User               string            `yaml:"user"`                 // Optional user of the container processes, the image user when unset
+		Env                map[string]string `yaml:"env"`                  // Environment variables added to the containers
+		Volumes            []string          `yaml:"volumes"`              // Extra volumes of the containers, as host:container[:ro]
+		PullTimeoutSeconds int               `yaml:"pull_timeout_seconds"` // Timeout of the pull of the image of an oci:// code location, default 600
+	}
+
+	// WorkerGitConfig represents the configuration of the git code locations, git+https://host/repo.git@ref#subdirectory=path,
+	// shallow cloned by the worker before the flow process starts.
+	WorkerGitConfig struct {
+		Binary         string                      `yaml:"binary"`          // Git CLI called by the worker, default git
+		TimeoutSeconds int                         `yaml:"timeout_seconds"` // Timeout of a clone, default 300
+		Credentials    []WorkerGitCredentialConfig `yaml:"credentials"`     // Credentials of the private repositories
+	}
+
+	// WorkerGitCredentialConfig represents the credentials of the repositories under a URL, sent with HTTP basic authentication.
+	WorkerGitCredentialConfig struct {
+		URL      string `yaml:"url"`      // URL prefix of the repositories, e.g. https://github.com/acme/
+		Username string `yaml:"username"` // Username of the token, default x-access-token
+		Token    string `yaml:"token"`    // Access token or a secret reference, such as env://GIT_TOKEN
+	}
+
+	// WorkerPythonConfig represents the Python environments of the flows, built by the worker from the requirements.txt or
+	// the pyproject.toml of the flow code directory and cached by the hash of the dependencies.
+	WorkerPythonConfig struct {
+		Disabled       bool   `yaml:"disabled"`        // The flows run with the Python environment of the worker
+		Manager        string `yaml:"manager"`         // Builder of the environments, uv or venv, default uv when found on the PATH
+		Python         string `yaml:"python"`          // Interpreter of the environments, default python3
+		UvBinary       string `yaml:"uv_binary"`       // uv CLI called by the worker, default uv
+		CacheDir       string `yaml:"cache_dir"`       // Directory of the cached environments, default the pinazu/venvs user cache directory
+		Isolated       bool   `yaml:"isolated"`        // Hide the packages of the interpreter, the flow dependencies must then include the pinazu library
+		IndexURL       string `yaml:"index_url"`       // Optional package index of the dependencies
+		TimeoutSeconds int    `yaml:"timeout_seconds"` // Timeout of an environment build, default 600
+		RetentionHours int    `yaml:"retention_hours"` // Environments unused for longer are removed, default 168
+	}
+
+	// WorkerNodeConfig represents the dependencies of the flows of the node engine, installed by the worker with npm into
+	// the code directory of a flow with a package.json and no node_modules.
+	WorkerNodeConfig struct {
+		Disabled       bool   `yaml:"disabled"`        // The flows run with the node_modules of their code directory as is
+		NpmBinary      string `yaml:"npm_binary"`      // npm CLI called by the worker, default npm
+		Registry       string `yaml:"registry"`        // Optional package registry of the dependencies
+		TimeoutSeconds int    `yaml:"timeout_seconds"` // Timeout of an install, default 600
+	}
+
+	// WorkerHeartbeatConfig represents the registration of the worker, which publishes a heartbeat so the flows service
+	// reschedules the runs of a lost worker.
+	WorkerHeartbeatConfig struct {
+		IntervalSeconds int    `yaml:"interval_seconds"` // How often the worker publishes its heartbeat, default 10
+		Name            string `yaml:"name"`             // Name the worker registers with, default the hostname
+	}
+
+	// WorkerLimitsConfig represents the resource limits of the flow processes spawned by the worker, enforced with a
+	// cgroup per process on Linux and a job object on Windows. A process killed for going over a limit fails its run
+	// with the RESOURCE_EXCEEDED reason.
+	WorkerLimitsConfig struct {
+		CPUs           float64 `yaml:"cpus"`            // CPUs of a flow process, throttled above, unlimited when 0
+		MemoryMB       int     `yaml:"memory_mb"`       // Memory of a flow process and its children in MiB, killed above, unlimited when 0
+		TimeoutSeconds int     `yaml:"timeout_seconds"` // Run time of a flow process, killed above, unlimited when 0
+		CgroupRoot     string  `yaml:"cgroup_root"`     // cgroup v2 directory of the process cgroups on Linux, default /sys/fs/cgroup/pinazu
+	}
+
+	// ProbesConfig represents the configuration for the scheduler of the agent probes, run by the task service.
+	ProbesConfig struct {
+		Disabled            bool   `yaml:"disabled"`              // Disables the scheduler, the probes no longer run
+		PollIntervalSeconds int    `yaml:"poll_interval_seconds"` // How often the probes due for a run are looked up, default 30
+		MaxConcurrentRuns   int    `yaml:"max_concurrent_runs"`   // Probes run at once by a task service, default 10
+		AlertWebhookURL     string `yaml:"alert_webhook_url"`     // Optional URL receiving a JSON POST when a probe starts failing or recovers
+		AlertPreset         string `yaml:"alert_preset"`          // Optional payload format of the alert, "slack" or "teams", raw JSON when unset
+		AlertTemplate       string `yaml:"alert_template"`        // Optional Go template of the alert payload, takes precedence over the preset
+		AlertTimeoutSeconds int    `yaml:"alert_timeout_seconds"` // Timeout of the alert webhook request, default 10
+	}
+
+	// TasksConfig represents the configuration of the task service.
+	TasksConfig struct {
+		Scheduler *TaskSchedulerConfig `yaml:"scheduler"`
+	}
+
+	// TaskSchedulerConfig represents the configuration for the scheduler of the task schedules, run by the task service.
+	TaskSchedulerConfig struct {
+		Disabled            bool `yaml:"disabled"`              // Disables the scheduler, the schedules no longer invoke their agent
+		PollIntervalSeconds int  `yaml:"poll_interval_seconds"` // How often the schedules due for a run are looked up, default 15
+		MaxDuePerPoll       int  `yaml:"max_due_per_poll"`      // Schedules run by a task service per lookup, default 100
+	}
+
+	// FlowsConfig represents the configuration of the flows service.
+	FlowsConfig struct {
+		Scheduler     *FlowSchedulerConfig     `yaml:"scheduler"`
+		Retry         *FlowRetryConfig         `yaml:"retry"`
+		Artifacts     *FlowArtifactsConfig     `yaml:"artifacts"`
+		Workers       *FlowWorkersConfig       `yaml:"workers"`
+		Notifications *FlowNotificationsConfig `yaml:"notifications"`
+		Concurrency   *FlowConcurrencyConfig   `yaml:"concurrency"`
+	}
+
+	// FlowSchedulerConfig represents the configuration for the scheduler of the flow schedules, run by the flows service.
+	FlowSchedulerConfig struct {
+		Disabled            bool `yaml:"disabled"`              // Disables the scheduler, the schedules no longer trigger flow runs
+		PollIntervalSeconds int  `yaml:"poll_interval_seconds"` // How often the schedules due for a run are looked up, default 15
+		MaxDuePerPoll       int  `yaml:"max_due_per_poll"`      // Schedules and backfills triggered by a flows service per lookup, default 100
+		MisfireSeconds      int  `yaml:"misfire_seconds"`       // Delay after which a run counts as missed for the catch-up policy, default 60
+		MaxCatchUpRuns      int  `yaml:"max_catch_up_runs"`     // Missed runs triggered at once with the "all" catch-up policy, default 10
+	}
+
+	// FlowRetryConfig represents the configuration for the automatic retries of the failed flow runs, run by the flows service.
+	FlowRetryConfig struct {
+		Disabled              bool    `yaml:"disabled"`                // Disables the automatic retries, failed flow runs stay failed
+		DefaultMaxRetries     int32   `yaml:"default_max_retries"`     // Retries of a flow run executed without max_retries, default 0
+		PollIntervalSeconds   int     `yaml:"poll_interval_seconds"`   // How often the failed flow runs due for a retry are looked up, default 10
+		InitialBackoffSeconds int     `yaml:"initial_backoff_seconds"` // Delay before the first retry of a failed flow run, default 30
+		BackoffMultiplier     float64 `yaml:"backoff_multiplier"`      // Factor applied to the delay at each retry, default 2
+		MaxBackoffSeconds     int     `yaml:"max_backoff_seconds"`     // Upper bound of the delay before a retry, default 1800
+	}
+
+	// FlowWorkersConfig represents the configuration for the liveness tracking of the workers, run by the flows service.
+	FlowWorkersConfig struct {
+		Disabled                bool `yaml:"disabled"`                  // Disables the tracking, the runs of a lost worker are no longer rescheduled
+		PollIntervalSeconds     int  `yaml:"poll_interval_seconds"`     // How often the workers without recent heartbeat are looked up, default 15
+		HeartbeatTimeoutSeconds int  `yaml:"heartbeat_timeout_seconds"` // A worker without heartbeat for this long is failed and its runs rescheduled, default 60
+		RetentionHours          int  `yaml:"retention_hours"`           // Inactive and failed workers are removed after it, default 24
+	}
+
+	// FlowNotificationsConfig represents the configuration for the delivery of the flow notifications, sent by the flows
+	// service when a flow run succeeds or fails.
+	FlowNotificationsConfig struct {
+		Disabled              bool `yaml:"disabled"`                // Disables the notifications of every flow
+		MaxAttempts           int  `yaml:"max_attempts"`            // Deliveries attempted before a notification is dropped, default 5
+		InitialBackoffSeconds int  `yaml:"initial_backoff_seconds"` // Delay before the second attempt, doubled at each attempt, default 2
+		MaxBackoffSeconds     int  `yaml:"max_backoff_seconds"`     // Upper bound of the delay between two attempts, default 60
+		TimeoutSeconds        int  `yaml:"timeout_seconds"`         // Timeout of a notification request, default 10
+		// AllowPrivateNetworks allows notifying loopback, private and link-local addresses.
+		// It is disabled by default so the notifications of the flows cannot reach internal services.
+		AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+	}
+
+	// FlowConcurrencyConfig represents the configuration for the dispatch of the flow runs queued by the concurrency
+	// limit of their flow, run by the flows service.
+	FlowConcurrencyConfig struct {
+		PollIntervalSeconds int `yaml:"poll_interval_seconds"` // How often the queued runs are looked up, besides the end of a run of their flow, default 15
+	}
+
+	// WebhooksConfig represents the configuration for the delivery of the webhook subscriptions of the users, sent by the
+	// webhooks service when a platform event matches their events.
+	WebhooksConfig struct {
+		PollIntervalSeconds   int `yaml:"poll_interval_seconds"`   // How often the deliveries due for an attempt are looked up, default 2
+		MaxDeliveriesPerPoll  int `yaml:"max_deliveries_per_poll"` // Deliveries attempted by a webhooks service per lookup, default 50
+		MaxAttempts           int `yaml:"max_attempts"`            // Attempts before a delivery is failed, default 5
+		InitialBackoffSeconds int `yaml:"initial_backoff_seconds"` // Delay before the second attempt, doubled at each attempt, default 10
+		MaxBackoffSeconds     int `yaml:"max_backoff_seconds"`     // Upper bound of the delay between two attempts, default 3600
+		TimeoutSeconds        int `yaml:"timeout_seconds"`         // Timeout of a delivery request, default 10
+		RetentionDays         int `yaml:"retention_days"`          // Ended deliveries are removed after it, default 30
+		// AllowPrivateNetworks allows delivering to loopback, private and link-local addresses.
+		// It is disabled by default so the webhooks of the users cannot reach internal services.
+		AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+	}
+
+	// EmbeddingsConfig represents the configuration for the embeddings of the messages, written by the embeddings service
+	// and used by the semantic search and the recall of the past conversations.
+	EmbeddingsConfig struct {
+		Provider            string  `yaml:"provider"`              // Embedding provider: openai or bedrock, the messages are not embedded when empty
+		Model               string  `yaml:"model"`                 // Embedding model, default text-embedding-3-small for openai and amazon.titan-embed-text-v2:0 for bedrock
+		APIKey              string  `yaml:"api_key"`               // OpenAI API key, default the OPENAI_API_KEY environment variable
+		Region              string  `yaml:"region"`                // Bedrock region, default the region of the Bedrock LLM configuration
+		BatchSize           int     `yaml:"batch_size"`            // Messages embedded per request to the provider, default 32
+		PollIntervalSeconds int     `yaml:"poll_interval_seconds"` // How often the messages to embed are looked up, default 5
+		MaxChars            int     `yaml:"max_chars"`             // The text of a message is truncated beyond this length before it is embedded, default 8000
+		RecallLimit         int     `yaml:"recall_limit"`          // Messages recalled when the request sets no limit, default 5
+		MinSimilarity       float32 `yaml:"min_similarity"`        // Messages less similar to the query are not recalled, default 0.3
+	}
+
+	// FilesConfig represents the configuration for the files uploaded by the users, stored in the S3 storage and attached
+	// to the messages of their threads.
+	FilesConfig struct {
+		Bucket                 string `yaml:"bucket"`                    // S3 bucket of the files, default "files"
+		Prefix                 string `yaml:"prefix"`                    // Prefix of the file keys, default "files/"
+		MaxFileBytes           int64  `yaml:"max_file_bytes"`            // Files above this size are rejected, default 20 MiB
+		UploadURLExpirySeconds int    `yaml:"upload_url_expiry_seconds"` // Validity of a presigned upload URL, default 900
+	}
+
+	// FlowArtifactsConfig represents the configuration for the artifacts published by the flow tasks, uploaded by the workers to the S3 storage.
+	FlowArtifactsConfig struct {
+		Bucket           string `yaml:"bucket"`             // S3 bucket of the artifacts, default "flow-artifacts"
+		Prefix           string `yaml:"prefix"`             // Prefix of the artifact keys, default "artifacts/"
+		MaxArtifactBytes int64  `yaml:"max_artifact_bytes"` // Artifacts above this size are not uploaded, default 100 MiB
+	}
+
+	// CodeInterpreterConfig represents the configuration for the sandboxed code interpreter tool.
+	// The snippets run as an unprivileged user in their own PID and network namespaces, so the tools service needs the
+	// CAP_SETUID, CAP_SETGID and CAP_SYS_ADMIN capabilities, such as root in its container.
+	CodeInterpreterConfig struct {
+		PythonPath     string `yaml:"python_path"`      // Python interpreter binary, default "python3"
+		NodePath       string `yaml:"node_path"`        // Node.js binary, default "node"
+		TimeoutSeconds int    `yaml:"timeout_seconds"`  // Wall-clock limit for a single execution
+		MaxCPUSeconds  int    `yaml:"max_cpu_seconds"`  // CPU time limit for a single execution
+		MaxMemoryMB    int    `yaml:"max_memory_mb"`    // Memory limit for a single execution
+		MaxOutputBytes int    `yaml:"max_output_bytes"` // stdout/stderr are truncated beyond this size
+		MaxFileSizeMB  int    `yaml:"max_file_size_mb"` // Size limit of a file written by a snippet, default 64
+		MaxProcesses   int    `yaml:"max_processes"`    // Processes and threads of the sandbox user, shared by the running snippets, default 256
+		MaxFilesBytes  int64  `yaml:"max_files_bytes"`  // Total size of the generated files returned from an execution, default 100 MiB
+		Bucket         string `yaml:"bucket"`           // S3 bucket for generated files, files are not uploaded when empty
+		SandboxUID     int    `yaml:"sandbox_uid"`      // User running the snippets, default 65534 (nobody), never root
+		SandboxGID     int    `yaml:"sandbox_gid"`      // Group running the snippets, default 65534 (nogroup)
+		AllowNetwork   bool   `yaml:"allow_network"`    // The snippets run without any network interface unless set
+		MaxConcurrent  int    `yaml:"max_concurrent"`   // Snippets running at once in a tools service, default 4
+	}
+
+	// WebSearchConfig represents the configuration for the web search tool.
+	WebSearchConfig struct {
+		Provider       string `yaml:"provider"`        // Search provider: tavily, brave or serpapi
+		APIKey         string `yaml:"api_key"`         // API key for the search provider
+		Endpoint       string `yaml:"endpoint"`        // Optional override of the provider API endpoint
+		MaxResults     int    `yaml:"max_results"`     // Default number of results returned per query
+		TimeoutSeconds int    `yaml:"timeout_seconds"` // Timeout for a single search request
+	}
+
+	// FetchURLConfig represents the configuration for the URL fetch tool.
+	FetchURLConfig struct {
+		UserAgent      string `yaml:"user_agent"`      // User-Agent header sent with each request
+		TimeoutSeconds int    `yaml:"timeout_seconds"` // Timeout for a single fetch
+		MaxBytes       int64  `yaml:"max_bytes"`       // Response bodies are truncated beyond this size
+		MaxChars       int    `yaml:"max_chars"`       // Converted markdown is truncated beyond this length
+		// AllowPrivateNetworks allows fetching loopback, private and link-local addresses.
+		// It is disabled by default so agents cannot reach internal services.
+		AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+	}
 )
 
 const (
@@ -134,6 +615,22 @@ const (
 	CacheTypeS3 CacheType = "s3"
 )
 
+// The overflow policies of the WebSocket queues
+const (
+	// WebSocketOverflowDropOldest drops the oldest queued response event to queue a new event
+	WebSocketOverflowDropOldest = "drop_oldest"
+
+	// WebSocketOverflowDropNewest drops the new events until the queue drains
+	WebSocketOverflowDropNewest = "drop_newest"
+
+	// WebSocketOverflowCoalesce merges the queued text deltas of a content block, dropping the oldest event when none
+	// can be merged
+	WebSocketOverflowCoalesce = "coalesce"
+
+	// WebSocketOverflowDisconnect closes the connection whose queue is full
+	WebSocketOverflowDisconnect = "disconnect"
+)
+
 // String returns the string representation of CacheType
 func (ct CacheType) String() string {
 	return string(ct)
@@ -191,6 +688,16 @@ func LoadExternalConfigFile(cfgPath string, cmd *cli.Command) (*ExternalDependen
 			if err := cfg.ValidateCacheConfig(); err != nil {
 				return nil, fmt.Errorf("cache configuration validation failed: %w", err)
 			}
+
+			// Validate replication configuration
+			if err := cfg.ValidateReplicationConfig(); err != nil {
+				return nil, fmt.Errorf("replication configuration validation failed: %w", err)
+			}
+
+			// Validate redelivery configuration
+			if err := cfg.ValidateRedeliveryConfig(); err != nil {
+				return nil, fmt.Errorf("redelivery configuration validation failed: %w", err)
+			}
 		}
 	}
 
@@ -277,16 +784,21 @@ func (c *Config) valid() error {
 
 // getDatabaseConnectionString returns the database connection string based on the configuration.
 func (c *Config) getDatabaseConnectionString() string {
-	if c.ExternalDependencies.Database == nil {
+	return c.ExternalDependencies.GetDatabaseConnectionString()
+}
+
+// GetDatabaseConnectionString returns the database connection string based on the database configuration.
+func (ec *ExternalDependenciesConfig) GetDatabaseConnectionString() string {
+	if ec.Database == nil {
 		return "host=localhost port=5432 user=postgres password= dbname=postgres sslmode=disable"
 	}
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		c.ExternalDependencies.Database.Host,
-		c.ExternalDependencies.Database.Port,
-		c.ExternalDependencies.Database.User,
-		c.ExternalDependencies.Database.Password,
-		c.ExternalDependencies.Database.Dbname,
-		c.ExternalDependencies.Database.SSLMode,
+		ec.Database.Host,
+		ec.Database.Port,
+		ec.Database.User,
+		ec.Database.Password,
+		ec.Database.Dbname,
+		ec.Database.SSLMode,
 	)
 }
 
@@ -317,6 +829,840 @@ func (nc *NatsConfig) GetJetStreamConfig() *JetStreamConfig {
 	return nc.JetStreamDefaultConfig
 }
 
+// NewKeyWrapper creates the key wrapper of the configured secret keys
+func (sc *SecretsConfig) NewKeyWrapper() (*db.LocalKeyWrapper, error) {
+	keys := make(map[string][]byte, len(sc.Keys))
+	for id, encoded := range sc.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode secret key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return db.NewLocalKeyWrapper(sc.KeyID, keys)
+}
+
+// NewSecretsResolver creates the resolver of the secret references with the configured secrets managers.
+// No reference is resolved without a secrets configuration.
+func (sc *SecretsConfig) NewSecretsResolver(ctx context.Context) (*secrets.Resolver, error) {
+	if sc == nil {
+		return secrets.NewResolver(ctx, nil)
+	}
+	return secrets.NewResolver(ctx, sc.Managers)
+}
+
+// GetMaxDeliver returns the deliveries of a message by the consumers not setting their own, default 3.
+func (nc *NatsConfig) GetMaxDeliver() int {
+	if jsConfig := nc.GetJetStreamConfig(); jsConfig != nil && jsConfig.MaxDeliver > 0 {
+		return jsConfig.MaxDeliver
+	}
+	return 3
+}
+
+// GetRedeliveryConfig returns the redelivery storm detection configuration with defaults applied.
+func (nc *NatsConfig) GetRedeliveryConfig() *RedeliveryConfig {
+	cfg := RedeliveryConfig{}
+	if nc != nil && nc.Redelivery != nil {
+		cfg = *nc.Redelivery
+	}
+	if cfg.StormThreshold <= 0 {
+		// The consumers deliver a message at most MaxDeliver times, the threshold is reached before the last delivery
+		cfg.StormThreshold = max(nc.GetMaxDeliver()-2, 1)
+	}
+	if cfg.AlertTimeoutSeconds <= 0 {
+		cfg.AlertTimeoutSeconds = 10
+	}
+	if cfg.DeadLetterMaxAgeDays <= 0 {
+		cfg.DeadLetterMaxAgeDays = 14
+	}
+	return &cfg
+}
+
+// GetCodeInterpreterConfig returns the code interpreter configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetCodeInterpreterConfig() *CodeInterpreterConfig {
+	cfg := CodeInterpreterConfig{}
+	if ec.Tools != nil && ec.Tools.CodeInterpreter != nil {
+		cfg = *ec.Tools.CodeInterpreter
+	}
+	if cfg.PythonPath == "" {
+		cfg.PythonPath = "python3"
+	}
+	if cfg.NodePath == "" {
+		cfg.NodePath = "node"
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 30
+	}
+	if cfg.MaxCPUSeconds <= 0 {
+		cfg.MaxCPUSeconds = cfg.TimeoutSeconds
+	}
+	if cfg.MaxMemoryMB <= 0 {
+		cfg.MaxMemoryMB = 512
+	}
+	if cfg.MaxOutputBytes <= 0 {
+		cfg.MaxOutputBytes = 64 * 1024
+	}
+	if cfg.MaxFileSizeMB <= 0 {
+		cfg.MaxFileSizeMB = 64
+	}
+	if cfg.MaxProcesses <= 0 {
+		cfg.MaxProcesses = 256
+	}
+	if cfg.MaxFilesBytes <= 0 {
+		cfg.MaxFilesBytes = 100 * 1024 * 1024
+	}
+	if cfg.SandboxUID <= 0 {
+		cfg.SandboxUID = 65534
+	}
+	if cfg.SandboxGID <= 0 {
+		cfg.SandboxGID = 65534
+	}
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = 4
+	}
+	return &cfg
+}
+
+// GetPromptCacheConfig returns the prompt cache configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetPromptCacheConfig() *PromptCacheConfig {
+	cfg := PromptCacheConfig{}
+	if ec.LLMConfig != nil && ec.LLMConfig.PromptCache != nil {
+		cfg = *ec.LLMConfig.PromptCache
+	}
+	// The default provider cache TTL is 5 minutes, refresh ahead of it
+	if cfg.RefreshIntervalSeconds <= 0 {
+		cfg.RefreshIntervalSeconds = 240
+	}
+	if cfg.IdleTimeoutSeconds <= 0 {
+		cfg.IdleTimeoutSeconds = 3600
+	}
+	return &cfg
+}
+
+// GetMetricsConfig returns the metrics configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetMetricsConfig() *MetricsConfig {
+	cfg := MetricsConfig{}
+	if ec.Metrics != nil {
+		cfg = *ec.Metrics
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/metrics"
+	}
+	return &cfg
+}
+
+// GetHealthConfig returns the health endpoints configuration.
+func (ec *ExternalDependenciesConfig) GetHealthConfig() *HealthConfig {
+	if ec.Health != nil {
+		return ec.Health
+	}
+	return &HealthConfig{}
+}
+
+// GetQuotas returns the usage quotas, nil leaving every quota unlimited.
+func (ec *ExternalDependenciesConfig) GetQuotas() *db.Quotas {
+	return ec.Quotas
+}
+
+// GetNatsURL returns the NATS server URL, defaulting to a local server.
+func (ec *ExternalDependenciesConfig) GetNatsURL() string {
+	if ec.Nats != nil && ec.Nats.URL != "" {
+		return ec.Nats.URL
+	}
+	return "nats://localhost:4222"
+}
+
+// GetReplicationConfig returns the replication configuration with defaults applied.
+// It returns nil when replication is disabled.
+func (ec *ExternalDependenciesConfig) GetReplicationConfig() *ReplicationConfig {
+	if ec.Replication == nil || ec.Replication.Region == "" {
+		return nil
+	}
+	cfg := *ec.Replication
+	if len(cfg.Streams) == 0 {
+		cfg.Streams = ManagedStreamNames()
+	}
+	if len(cfg.Tables) == 0 {
+		cfg.Tables = DefaultReplicatedTables
+	}
+	if cfg.Publication == "" {
+		cfg.Publication = "pinazu_replication"
+	}
+	if cfg.Subscription == "" {
+		cfg.Subscription = "pinazu_replication"
+	}
+	return &cfg
+}
+
+// GetWebSearchConfig returns the web search configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWebSearchConfig() *WebSearchConfig {
+	cfg := WebSearchConfig{}
+	if ec.Tools != nil && ec.Tools.WebSearch != nil {
+		cfg = *ec.Tools.WebSearch
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = "tavily"
+	}
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = 5
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 15
+	}
+	return &cfg
+}
+
+// GetFetchURLConfig returns the URL fetch configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFetchURLConfig() *FetchURLConfig {
+	cfg := FetchURLConfig{}
+	if ec.Tools != nil && ec.Tools.FetchURL != nil {
+		cfg = *ec.Tools.FetchURL
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = "Pinazu/0.0.1"
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 20
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = 2 * 1024 * 1024
+	}
+	if cfg.MaxChars <= 0 {
+		cfg.MaxChars = 50000
+	}
+	return &cfg
+}
+
+// GetToolResultOffloadConfig returns the tool result offloading configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetToolResultOffloadConfig() *ToolResultOffloadConfig {
+	cfg := ToolResultOffloadConfig{}
+	if ec.Tools != nil && ec.Tools.ResultOffload != nil {
+		cfg = *ec.Tools.ResultOffload
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "tool-results/"
+	}
+	if cfg.ThresholdBytes <= 0 {
+		cfg.ThresholdBytes = 256 * 1024
+	}
+	if cfg.MaxChars <= 0 {
+		cfg.MaxChars = 20000
+	}
+	return &cfg
+}
+
+// GetBatchToolConfig returns the batch_tool limits with defaults applied.
+func (ec *ExternalDependenciesConfig) GetBatchToolConfig() *BatchToolConfig {
+	cfg := BatchToolConfig{}
+	if ec.Tools != nil && ec.Tools.Batch != nil {
+		cfg = *ec.Tools.Batch
+	}
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = 3
+	}
+	if cfg.MaxChildren <= 0 {
+		cfg.MaxChildren = 20
+	}
+	return &cfg
+}
+
+// GetToolAuditConfig returns the tool run audit log configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetToolAuditConfig() *ToolAuditConfig {
+	cfg := ToolAuditConfig{}
+	if ec.Tools != nil && ec.Tools.Audit != nil {
+		cfg = *ec.Tools.Audit
+	}
+	if cfg.RetentionDays <= 0 {
+		cfg.RetentionDays = 90
+	}
+	if len(cfg.RedactKeys) == 0 {
+		cfg.RedactKeys = []string{"password", "api_key", "token", "secret", "authorization"}
+	}
+	if cfg.PruneIntervalMinutes <= 0 {
+		cfg.PruneIntervalMinutes = 60
+	}
+	return &cfg
+}
+
+// GetInvokeAgentConfig returns the invoke_agent sub-agent run configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetInvokeAgentConfig() *InvokeAgentConfig {
+	cfg := InvokeAgentConfig{}
+	if ec.Tools != nil && ec.Tools.InvokeAgent != nil {
+		cfg = *ec.Tools.InvokeAgent
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 600
+	}
+	return &cfg
+}
+
+// GetTaskToolBudgetConfig returns the budget of the tool runs of a task with defaults applied.
+func (ec *ExternalDependenciesConfig) GetTaskToolBudgetConfig() *TaskToolBudgetConfig {
+	cfg := TaskToolBudgetConfig{}
+	if ec.Tools != nil && ec.Tools.TaskBudget != nil {
+		cfg = *ec.Tools.TaskBudget
+	}
+	if cfg.MaxConcurrentRuns <= 0 {
+		cfg.MaxConcurrentRuns = 10
+	}
+	return &cfg
+}
+
+// GetToolHealthCheckConfig returns the tool health check prober configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetToolHealthCheckConfig() *ToolHealthCheckConfig {
+	cfg := ToolHealthCheckConfig{}
+	if ec.Tools != nil && ec.Tools.HealthCheck != nil {
+		cfg = *ec.Tools.HealthCheck
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 15
+	}
+	return &cfg
+}
+
+// GetWorkerLabels returns the labels advertised by the worker, sorted without duplicates.
+func (ec *ExternalDependenciesConfig) GetWorkerLabels() ([]string, error) {
+	if ec.Worker == nil {
+		return nil, nil
+	}
+	labels, err := db.NormalizeWorkerLabels(ec.Worker.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid worker labels: %w", err)
+	}
+	return labels, nil
+}
+
+// GetWorkerEdgeToolsConfig returns the worker edge tools configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerEdgeToolsConfig() *WorkerEdgeToolsConfig {
+	cfg := WorkerEdgeToolsConfig{}
+	if ec.Worker != nil && ec.Worker.EdgeTools != nil {
+		cfg = *ec.Worker.EdgeTools
+	}
+	if len(cfg.Groups) == 0 {
+		cfg.Groups = []string{db.DefaultToolEdgeGroup}
+	}
+	return &cfg
+}
+
+// GetWorkerConcurrencyConfig returns the worker concurrency configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerConcurrencyConfig() *WorkerConcurrencyConfig {
+	cfg := WorkerConcurrencyConfig{}
+	if ec.Worker != nil && ec.Worker.Concurrency != nil {
+		cfg = *ec.Worker.Concurrency
+	}
+	if cfg.MaxConcurrentFlows < 0 {
+		cfg.MaxConcurrentFlows = 0
+	}
+	if cfg.MaxQueuedFlows <= 0 {
+		cfg.MaxQueuedFlows = cfg.MaxConcurrentFlows
+	}
+	if cfg.RequeueDelaySeconds <= 0 {
+		cfg.RequeueDelaySeconds = 5
+	}
+	return &cfg
+}
+
+// GetWorkerDockerConfig returns the worker docker engine configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerDockerConfig() *WorkerDockerConfig {
+	cfg := WorkerDockerConfig{}
+	if ec.Worker != nil && ec.Worker.Docker != nil {
+		cfg = *ec.Worker.Docker
+	}
+	if cfg.Binary == "" {
+		cfg.Binary = "docker"
+	}
+	if cfg.Network == "" {
+		cfg.Network = "host"
+	}
+	if cfg.PullTimeoutSeconds <= 0 {
+		cfg.PullTimeoutSeconds = 600
+	}
+	return &cfg
+}
+
+// GetWorkerGitConfig returns the worker git code locations configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerGitConfig() *WorkerGitConfig {
+	cfg := WorkerGitConfig{}
+	if ec.Worker != nil && ec.Worker.Git != nil {
+		cfg = *ec.Worker.Git
+	}
+	if cfg.Binary == "" {
+		cfg.Binary = "git"
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 300
+	}
+	cfg.Credentials = append([]WorkerGitCredentialConfig(nil), cfg.Credentials...)
+	for i := range cfg.Credentials {
+		if cfg.Credentials[i].Username == "" {
+			cfg.Credentials[i].Username = "x-access-token"
+		}
+	}
+	return &cfg
+}
+
+// GetWorkerPythonConfig returns the worker Python environments configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerPythonConfig() *WorkerPythonConfig {
+	cfg := WorkerPythonConfig{}
+	if ec.Worker != nil && ec.Worker.Python != nil {
+		cfg = *ec.Worker.Python
+	}
+	if cfg.Python == "" {
+		cfg.Python = "python3"
+	}
+	if cfg.UvBinary == "" {
+		cfg.UvBinary = "uv"
+	}
+	if cfg.CacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			dir = os.TempDir()
+		}
+		cfg.CacheDir = filepath.Join(dir, "pinazu", "venvs")
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 600
+	}
+	if cfg.RetentionHours <= 0 {
+		cfg.RetentionHours = 168
+	}
+	return &cfg
+}
+
+// GetWorkerNodeConfig returns the worker Node.js dependencies configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerNodeConfig() *WorkerNodeConfig {
+	cfg := WorkerNodeConfig{}
+	if ec.Worker != nil && ec.Worker.Node != nil {
+		cfg = *ec.Worker.Node
+	}
+	if cfg.NpmBinary == "" {
+		cfg.NpmBinary = "npm"
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 600
+	}
+	return &cfg
+}
+
+// GetWorkerHeartbeatConfig returns the worker heartbeat configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerHeartbeatConfig() *WorkerHeartbeatConfig {
+	cfg := WorkerHeartbeatConfig{}
+	if ec.Worker != nil && ec.Worker.Heartbeat != nil {
+		cfg = *ec.Worker.Heartbeat
+	}
+	if cfg.IntervalSeconds <= 0 {
+		cfg.IntervalSeconds = 10
+	}
+	if cfg.Name == "" {
+		cfg.Name, _ = os.Hostname()
+	}
+	return &cfg
+}
+
+// GetWorkerLimitsConfig returns the worker resource limits configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWorkerLimitsConfig() *WorkerLimitsConfig {
+	cfg := WorkerLimitsConfig{}
+	if ec.Worker != nil && ec.Worker.Limits != nil {
+		cfg = *ec.Worker.Limits
+	}
+	if cfg.CgroupRoot == "" {
+		cfg.CgroupRoot = "/sys/fs/cgroup/pinazu"
+	}
+	return &cfg
+}
+
+// GetProbesConfig returns the agent probe scheduler configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetProbesConfig() *ProbesConfig {
+	cfg := ProbesConfig{}
+	if ec.Probes != nil {
+		cfg = *ec.Probes
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 30
+	}
+	if cfg.MaxConcurrentRuns <= 0 {
+		cfg.MaxConcurrentRuns = 10
+	}
+	if cfg.AlertTimeoutSeconds <= 0 {
+		cfg.AlertTimeoutSeconds = 10
+	}
+	return &cfg
+}
+
+// GetTaskSchedulerConfig returns the task schedule scheduler configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetTaskSchedulerConfig() *TaskSchedulerConfig {
+	cfg := TaskSchedulerConfig{}
+	if ec.Tasks != nil && ec.Tasks.Scheduler != nil {
+		cfg = *ec.Tasks.Scheduler
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 15
+	}
+	if cfg.MaxDuePerPoll <= 0 {
+		cfg.MaxDuePerPoll = 100
+	}
+	return &cfg
+}
+
+// GetFlowSchedulerConfig returns the flow schedule scheduler configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFlowSchedulerConfig() *FlowSchedulerConfig {
+	cfg := FlowSchedulerConfig{}
+	if ec.Flows != nil && ec.Flows.Scheduler != nil {
+		cfg = *ec.Flows.Scheduler
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 15
+	}
+	if cfg.MaxDuePerPoll <= 0 {
+		cfg.MaxDuePerPoll = 100
+	}
+	if cfg.MisfireSeconds <= 0 {
+		cfg.MisfireSeconds = 60
+	}
+	if cfg.MaxCatchUpRuns <= 0 {
+		cfg.MaxCatchUpRuns = 10
+	}
+	return &cfg
+}
+
+// GetFlowRetryConfig returns the flow run retry configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFlowRetryConfig() *FlowRetryConfig {
+	cfg := FlowRetryConfig{}
+	if ec.Flows != nil && ec.Flows.Retry != nil {
+		cfg = *ec.Flows.Retry
+	}
+	if cfg.DefaultMaxRetries < 0 {
+		cfg.DefaultMaxRetries = 0
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 10
+	}
+	if cfg.InitialBackoffSeconds <= 0 {
+		cfg.InitialBackoffSeconds = 30
+	}
+	if cfg.BackoffMultiplier < 1 {
+		cfg.BackoffMultiplier = 2
+	}
+	if cfg.MaxBackoffSeconds <= 0 {
+		cfg.MaxBackoffSeconds = 1800
+	}
+	return &cfg
+}
+
+// GetFlowWorkersConfig returns the worker liveness tracking configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFlowWorkersConfig() *FlowWorkersConfig {
+	cfg := FlowWorkersConfig{}
+	if ec.Flows != nil && ec.Flows.Workers != nil {
+		cfg = *ec.Flows.Workers
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 15
+	}
+	if cfg.HeartbeatTimeoutSeconds <= 0 {
+		cfg.HeartbeatTimeoutSeconds = 60
+	}
+	if cfg.RetentionHours <= 0 {
+		cfg.RetentionHours = 24
+	}
+	return &cfg
+}
+
+// GetFlowConcurrencyConfig returns the queued flow runs dispatch configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFlowConcurrencyConfig() *FlowConcurrencyConfig {
+	cfg := FlowConcurrencyConfig{}
+	if ec.Flows != nil && ec.Flows.Concurrency != nil {
+		cfg = *ec.Flows.Concurrency
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 15
+	}
+	return &cfg
+}
+
+// GetFlowNotificationsConfig returns the flow notifications configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFlowNotificationsConfig() *FlowNotificationsConfig {
+	cfg := FlowNotificationsConfig{}
+	if ec.Flows != nil && ec.Flows.Notifications != nil {
+		cfg = *ec.Flows.Notifications
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.InitialBackoffSeconds <= 0 {
+		cfg.InitialBackoffSeconds = 2
+	}
+	if cfg.MaxBackoffSeconds <= 0 {
+		cfg.MaxBackoffSeconds = 60
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 10
+	}
+	return &cfg
+}
+
+// GetWebhooksConfig returns the webhook delivery configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWebhooksConfig() *WebhooksConfig {
+	cfg := WebhooksConfig{}
+	if ec.Webhooks != nil {
+		cfg = *ec.Webhooks
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 2
+	}
+	if cfg.MaxDeliveriesPerPoll <= 0 {
+		cfg.MaxDeliveriesPerPoll = 50
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.InitialBackoffSeconds <= 0 {
+		cfg.InitialBackoffSeconds = 10
+	}
+	if cfg.MaxBackoffSeconds <= 0 {
+		cfg.MaxBackoffSeconds = 3600
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 10
+	}
+	if cfg.RetentionDays <= 0 {
+		cfg.RetentionDays = 30
+	}
+	return &cfg
+}
+
+// GetEmbeddingsConfig returns the message embeddings configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetEmbeddingsConfig() *EmbeddingsConfig {
+	cfg := EmbeddingsConfig{}
+	if ec.Embeddings != nil {
+		cfg = *ec.Embeddings
+	}
+	if cfg.Model == "" {
+		switch cfg.Provider {
+		case "openai":
+			cfg.Model = "text-embedding-3-small"
+		case "bedrock":
+			cfg.Model = "amazon.titan-embed-text-v2:0"
+		}
+	}
+	if cfg.Region == "" && ec.LLMConfig != nil && ec.LLMConfig.Bedrock != nil {
+		cfg.Region = ec.LLMConfig.Bedrock.Region
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 32
+	}
+	if cfg.PollIntervalSeconds <= 0 {
+		cfg.PollIntervalSeconds = 5
+	}
+	if cfg.MaxChars <= 0 {
+		cfg.MaxChars = 8000
+	}
+	if cfg.RecallLimit <= 0 {
+		cfg.RecallLimit = 5
+	}
+	if cfg.MinSimilarity <= 0 {
+		cfg.MinSimilarity = 0.3
+	}
+	return &cfg
+}
+
+// GetFlowArtifactsConfig returns the flow artifacts configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFlowArtifactsConfig() *FlowArtifactsConfig {
+	cfg := FlowArtifactsConfig{}
+	if ec.Flows != nil && ec.Flows.Artifacts != nil {
+		cfg = *ec.Flows.Artifacts
+	}
+	if cfg.Bucket == "" {
+		cfg.Bucket = "flow-artifacts"
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "artifacts/"
+	}
+	if cfg.MaxArtifactBytes <= 0 {
+		cfg.MaxArtifactBytes = 100 << 20
+	}
+	return &cfg
+}
+
+// GetFilesConfig returns the file upload configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetFilesConfig() *FilesConfig {
+	cfg := FilesConfig{}
+	if ec.Files != nil {
+		cfg = *ec.Files
+	}
+	if cfg.Bucket == "" {
+		cfg.Bucket = "files"
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "files/"
+	}
+	if cfg.MaxFileBytes <= 0 {
+		cfg.MaxFileBytes = 20 << 20
+	}
+	if cfg.UploadURLExpirySeconds <= 0 {
+		cfg.UploadURLExpirySeconds = 900
+	}
+	return &cfg
+}
+
+// GetOIDCConfig returns the OIDC login configuration with defaults applied, its IssuerURL is empty when the login is disabled.
+func (ec *ExternalDependenciesConfig) GetOIDCConfig() *OIDCConfig {
+	cfg := OIDCConfig{}
+	if ec.Http != nil && ec.Http.OIDC != nil {
+		cfg = *ec.Http.OIDC
+	}
+	if cfg.ProviderName == "" {
+		cfg.ProviderName = "google"
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"email", "profile"}
+	}
+	if cfg.SessionTTLSeconds <= 0 {
+		cfg.SessionTTLSeconds = 12 * 60 * 60
+	}
+	if cfg.PostLoginRedirect == "" {
+		cfg.PostLoginRedirect = "/"
+	}
+	return &cfg
+}
+
+// GetHttpServerConfig returns the HTTP server configuration with defaults applied, CORS included, its TLS is nil when HTTPS is disabled.
+func (ec *ExternalDependenciesConfig) GetHttpServerConfig() (*HttpServerConfig, error) {
+	cfg := HttpServerConfig{}
+	if ec.Http != nil {
+		cfg = *ec.Http
+	}
+	if cfg.Port == "" {
+		cfg.Port = "8080"
+	}
+	if cfg.ReadTimeoutSeconds <= 0 {
+		cfg.ReadTimeoutSeconds = 120
+	}
+	if cfg.ReadHeaderTimeoutSeconds <= 0 {
+		cfg.ReadHeaderTimeoutSeconds = 10
+	}
+	if cfg.WriteTimeoutSeconds <= 0 {
+		cfg.WriteTimeoutSeconds = 120
+	}
+	if cfg.IdleTimeoutSeconds <= 0 {
+		cfg.IdleTimeoutSeconds = 120
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = 32 << 20
+	}
+	if cfg.ShutdownTimeoutSeconds <= 0 {
+		cfg.ShutdownTimeoutSeconds = 30
+	}
+	cors, err := ec.GetCORSConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.CORS = cors
+	webSocket, err := ec.GetWebSocketConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.WebSocket = webSocket
+	if cfg.TLS != nil {
+		tlsCfg := *cfg.TLS
+		if tlsCfg.CertFile == "" || tlsCfg.KeyFile == "" {
+			return nil, fmt.Errorf("http.tls requires both cert_file and key_file")
+		}
+		if tlsCfg.HSTSMaxAgeSeconds == 0 {
+			tlsCfg.HSTSMaxAgeSeconds = 365 * 24 * 60 * 60
+		}
+		cfg.TLS = &tlsCfg
+	}
+	return &cfg, nil
+}
+
+// GetWebSocketConfig returns the WebSocket delivery configuration with defaults applied.
+func (ec *ExternalDependenciesConfig) GetWebSocketConfig() (*WebSocketConfig, error) {
+	cfg := WebSocketConfig{}
+	if ec.Http != nil && ec.Http.WebSocket != nil {
+		cfg = *ec.Http.WebSocket
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 256
+	}
+	switch cfg.OverflowPolicy {
+	case "":
+		cfg.OverflowPolicy = WebSocketOverflowDropOldest
+	case WebSocketOverflowDropOldest, WebSocketOverflowDropNewest, WebSocketOverflowCoalesce, WebSocketOverflowDisconnect:
+	default:
+		return nil, fmt.Errorf("invalid http.websocket.overflow_policy %q, must be drop_oldest, drop_newest, coalesce or disconnect", cfg.OverflowPolicy)
+	}
+	if cfg.SlowConsumerTimeoutSeconds <= 0 {
+		cfg.SlowConsumerTimeoutSeconds = 30
+	}
+	return &cfg, nil
+}
+
+// GetCORSConfig returns the CORS configuration with defaults applied. The credentials require explicit allowed origins,
+// any site could otherwise read the responses to the requests sent with the session cookie of the user.
+func (ec *ExternalDependenciesConfig) GetCORSConfig() (*CORSConfig, error) {
+	cfg := CORSConfig{}
+	if ec.Http != nil && ec.Http.CORS != nil {
+		cfg = *ec.Http.CORS
+	}
+	if len(cfg.AllowedOrigins) == 0 {
+		cfg.AllowedOrigins = []string{"*"}
+	}
+	if len(cfg.AllowedMethods) == 0 {
+		cfg.AllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	}
+	if len(cfg.AllowedHeaders) == 0 {
+		cfg.AllowedHeaders = []string{"Authorization", "Content-Type", "Cache-Control", "Idempotency-Key", "If-Match", "Last-Event-ID", "X-Pinazu-Workspace"}
+	}
+	if len(cfg.ExposedHeaders) == 0 {
+		cfg.ExposedHeaders = []string{"ETag", "Retry-After", "Idempotent-Replayed", "X-Pinazu-Stale"}
+	}
+	if cfg.MaxAgeSeconds <= 0 {
+		cfg.MaxAgeSeconds = 600
+	}
+	if cfg.AllowCredentials && slices.Contains(cfg.AllowedOrigins, "*") {
+		return nil, fmt.Errorf("http.cors.allow_credentials requires explicit http.cors.allowed_origins, not *")
+	}
+	return &cfg, nil
+}
+
+// Headers returns the security headers sent with every response by header name, the defaults apply to a nil config.
+func (c *SecurityHeadersConfig) Headers() map[string]string {
+	cfg := SecurityHeadersConfig{}
+	if c != nil {
+		cfg = *c
+	}
+	headers := map[string]string{}
+	for _, h := range []struct{ name, value, fallback string }{
+		{"X-Content-Type-Options", cfg.ContentTypeOptions, "nosniff"},
+		{"X-Frame-Options", cfg.FrameOptions, "DENY"},
+		{"Referrer-Policy", cfg.ReferrerPolicy, "strict-origin-when-cross-origin"},
+		{"Cross-Origin-Opener-Policy", cfg.CrossOriginOpenerPolicy, "same-origin"},
+		{"Content-Security-Policy", cfg.ContentSecurityPolicy, ""},
+		{"Permissions-Policy", cfg.PermissionsPolicy, ""},
+	} {
+		value := h.value
+		if value == "" {
+			value = h.fallback
+		}
+		if value != "" && value != "-" {
+			headers[h.name] = value
+		}
+	}
+	return headers
+}
+
+// GetDefaultAgentID returns the workspace default agent, uuid.Nil when none is configured.
+func (ec *ExternalDependenciesConfig) GetDefaultAgentID() (uuid.UUID, error) {
+	if ec.Http == nil || ec.Http.DefaultAgentID == "" {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.Parse(ec.Http.DefaultAgentID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid http.default_agent_id: %w", err)
+	}
+	return id, nil
+}
+
 // GetLogLevel returns the appropriate log level based on the debug setting.
 // If debug is true, returns Debug level, otherwise returns Info level.
 func (ec *ExternalDependenciesConfig) GetLogLevel() hclog.Level {
@@ -360,6 +1706,46 @@ func (ec *ExternalDependenciesConfig) ValidateCacheConfig() error {
 	return nil
 }
 
+// ValidateReplicationConfig validates the replication configuration
+func (ec *ExternalDependenciesConfig) ValidateReplicationConfig() error {
+	rc := ec.Replication
+	if rc == nil || rc.Region == "" {
+		// No replication config is fine - the deployment runs as a single region
+		return nil
+	}
+
+	if rc.PrimaryRegion == "" {
+		return fmt.Errorf("primary_region must be specified when replication is enabled")
+	}
+
+	// A standby region mirrors the streams of the primary region
+	if rc.Region != rc.PrimaryRegion && rc.SourceDomain == "" && rc.SourceAPIPrefix == "" {
+		return fmt.Errorf("source_domain or source_api_prefix must be specified for standby region %s", rc.Region)
+	}
+
+	for _, name := range rc.Streams {
+		if _, ok := ManagedStreams[name]; !ok {
+			return fmt.Errorf("unknown replicated stream: %s (supported: %s)", name, strings.Join(ManagedStreamNames(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// ValidateRedeliveryConfig validates the redelivery storm detection configuration
+func (ec *ExternalDependenciesConfig) ValidateRedeliveryConfig() error {
+	rc := ec.Nats.GetRedeliveryConfig()
+	maxDeliver := ec.Nats.GetMaxDeliver()
+
+	// A consumer redelivers a message at most max_deliver - 1 times, a storm is only parked above the threshold
+	if rc.StormThreshold >= maxDeliver-1 {
+		return fmt.Errorf("storm_threshold %d is never reached by a consumer with max_deliver %d, it must be below %d",
+			rc.StormThreshold, maxDeliver, maxDeliver-1)
+	}
+
+	return nil
+}
+
 // getCommandString helper function to get the string value from the command line.
 func getCommandString(cmd any, name string) string {
 	type stringGetter interface {
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+// WorkerToolsStream holds the requests of the standalone tools marked as edge until a worker of their edge group calls them
+const WorkerToolsStream = "WORKER_TOOLS"
+
+// EdgeToolConsumerName returns the consumer shared by the workers of an edge group, it exists once a worker of the
+// group started
+func EdgeToolConsumerName(group string) string {
+	if group == "" {
+		group = db.DefaultToolEdgeGroup
+	}
+	return "worker-tool-consumer-" + group
+}
+
+// StandaloneToolEdgeSubject returns the subject of the standalone tool requests handled by the workers of an edge group
+func StandaloneToolEdgeSubject(group string) EventSubject {
+	if group == "" {
+		group = db.DefaultToolEdgeGroup
+	}
+	return StandaloneToolRequestEventSubject + EventSubject("."+group)
+}
+
+// FlowRunExecuteLabelsSubject returns the subject of the flow runs executed by the workers advertising a set of labels,
+// the flow runs without required labels are executed by any worker
+func FlowRunExecuteLabelsSubject(labels []string) EventSubject {
+	if len(labels) == 0 {
+		return FlowRunExecuteEventSubject
+	}
+	return FlowRunExecuteEventSubject + EventSubject(".labels."+db.WorkerLabelsKey(labels))
+}
+
+// FlowRunCompletedSubject returns the subject notifying the completion of a flow run
+func FlowRunCompletedSubject(flowRunID uuid.UUID) EventSubject {
+	return FlowRunCompletedEventSubject + EventSubject("."+flowRunID.String())
+}
@@ -6,6 +6,7 @@ package service
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -15,10 +16,22 @@ import (
 
 const (
 	AgentInvokeEventSubject            EventSubject = "v1.svc.agent.invoke"
+	AgentCacheWarmupEventSubject       EventSubject = "v1.svc.agent.cache.warmup"
+	AgentStreamCancelEventSubject      EventSubject = "v1.svc.agent.stream.cancel"
+	AgentInvokeRequestEventSubject     EventSubject = "v1.svc.agent.invoke.sync"
+	MessageRecallRequestEventSubject   EventSubject = "v1.svc.embeddings.recall"
 	FlowRunStatusEventSubject          EventSubject = "v1.svc.worker.flow.status"
 	FlowTaskRunStatusEventSubject      EventSubject = "v1.svc.worker.task.status"
+	FlowRunGraphEventSubject           EventSubject = "v1.svc.worker.flow.graph"
 	FlowRunExecuteEventSubject         EventSubject = "v1.svc.worker.flow.execute"
 	FlowRunExecuteRequestEventSubject  EventSubject = "v1.svc.flowrun.execute"
+	FlowRunCancelEventSubject          EventSubject = "v1.svc.worker.flow.cancel"
+	FlowRunCancelRequestEventSubject   EventSubject = "v1.svc.flowrun.cancel"
+	FlowRunPauseEventSubject           EventSubject = "v1.svc.worker.flow.pause"
+	FlowRunPauseRequestEventSubject    EventSubject = "v1.svc.flowrun.pause"
+	FlowRunResumeRequestEventSubject   EventSubject = "v1.svc.flowrun.resume"
+	FlowRunRetryRequestEventSubject    EventSubject = "v1.svc.flowrun.retry"
+	FlowRunCompletedEventSubject       EventSubject = "v1.svc.flowrun.completed"
 	TaskExecuteEventSubject            EventSubject = "v1.svc.task.execute"
 	TaskHandoffEventSubject            EventSubject = "v1.svc.task.handoff"
 	TaskFinishEventSubject             EventSubject = "v1.svc.task.finish"
@@ -57,14 +70,142 @@ func (msg *AgentInvokeEventMessage) Validate() error {
 	if msg.RecipientId == uuid.Nil {
 		return fmt.Errorf("recipient_id field is required")
 	}
+
+	return nil
+}
+
+type AgentCacheWarmupEventMessage struct {
+	AgentId uuid.UUID `json:"agent_id"`
+}
+
+// Subject returns the event subject for AgentCacheWarmup events
+func (msg *AgentCacheWarmupEventMessage) Subject() EventSubject {
+	return AgentCacheWarmupEventSubject
+}
+
+// Validate checks if the AgentCacheWarmup event message is valid
+func (msg *AgentCacheWarmupEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.AgentId == uuid.Nil {
+		return fmt.Errorf("agent_id field is required")
+	}
+
+	return nil
+}
+
+type AgentStreamCancelEventMessage struct {
+}
+
+// Subject returns the event subject for AgentStreamCancel events
+func (msg *AgentStreamCancelEventMessage) Subject() EventSubject {
+	return AgentStreamCancelEventSubject
+}
+
+// Validate checks if the AgentStreamCancel event message is valid
+func (msg *AgentStreamCancelEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	// The task is identified by the headers
+
+	return nil
+}
+
+type AgentInvokeRequestEventMessage struct {
+	AgentId  uuid.UUID    `json:"agent_id"`
+	Messages []db.JsonRaw `json:"messages"`
+}
+
+// Subject returns the event subject for AgentInvoke events
+func (msg *AgentInvokeRequestEventMessage) Subject() EventSubject {
+	return AgentInvokeRequestEventSubject
+}
+
+// Validate checks if the AgentInvoke event message is valid
+func (msg *AgentInvokeRequestEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.AgentId == uuid.Nil {
+		return fmt.Errorf("agent_id field is required")
+	}
+	if len(msg.Messages) < 1 {
+		return fmt.Errorf("messages field is required")
+	}
+
+	return nil
+}
+
+type AgentInvokeResponseEventMessage struct {
+	Message    db.JsonRaw `json:"message"`
+	StopReason string     `json:"stop_reason,omitempty"`
+}
+
+// Subject returns the event subject for AgentInvoke response events
+func (msg *AgentInvokeResponseEventMessage) Subject() EventSubject {
+	return AgentInvokeRequestEventSubject
+}
+
+// Validate checks if the AgentInvoke response event message is valid
+func (msg *AgentInvokeResponseEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	return nil
+}
+
+type MessageRecallRequestEventMessage struct {
+	Query           string     `json:"query"`
+	ThreadId        *uuid.UUID `json:"thread_id,omitempty"`
+	ExcludeThreadId *uuid.UUID `json:"exclude_thread_id,omitempty"`
+	Limit           int        `json:"limit,omitempty"`
+}
+
+// Subject returns the event subject for MessageRecall events
+func (msg *MessageRecallRequestEventMessage) Subject() EventSubject {
+	return MessageRecallRequestEventSubject
+}
+
+// Validate checks if the MessageRecall event message is valid
+func (msg *MessageRecallRequestEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if strings.TrimSpace(msg.Query) == "" {
+		return fmt.Errorf("query is required")
+	}
+	if msg.Limit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+
+	return nil
+}
+
+type MessageRecallResponseEventMessage struct {
+	Snippets []db.SemanticSearchMessagesRow `json:"snippets"`
+}
+
+// Subject returns the event subject for MessageRecall response events
+func (msg *MessageRecallResponseEventMessage) Subject() EventSubject {
+	return MessageRecallRequestEventSubject
+}
+
+// Validate checks if the MessageRecall response event message is valid
+func (msg *MessageRecallResponseEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
 	return nil
 }
 
 type FlowRunStatusEventMessage struct {
-	FlowRunId      uuid.UUID     `json:"flow_run_id"`
-	Status         db.FlowStatus `json:"status"`
-	EventTimestamp time.Time     `json:"event_timestamp"`
-	ErrorMessage   string        `json:"error_message,omitempty"`
+	FlowRunId      uuid.UUID            `json:"flow_run_id"`
+	Status         db.FlowStatus        `json:"status"`
+	EventTimestamp time.Time            `json:"event_timestamp"`
+	ErrorMessage   string               `json:"error_message,omitempty"`
+	FailureReason  db.FlowFailureReason `json:"failure_reason,omitempty"`
 }
 
 // Subject returns the event subject for FlowRunStatus events
@@ -125,20 +266,48 @@ func (msg *FlowTaskRunStatusEventMessage) Validate() error {
 	return nil
 }
 
+type FlowRunGraphEventMessage struct {
+	FlowRunId      uuid.UUID               `json:"flow_run_id"`
+	Tasks          []db.FlowTaskDefinition `json:"tasks"`
+	EventTimestamp time.Time               `json:"event_timestamp"`
+}
+
+// Subject returns the event subject for FlowRunGraph events
+func (msg *FlowRunGraphEventMessage) Subject() EventSubject {
+	return FlowRunGraphEventSubject
+}
+
+// Validate checks if the FlowRunGraph event message is valid
+func (msg *FlowRunGraphEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+	if err := db.ValidateFlowTaskGraph(msg.Tasks); err != nil {
+		return fmt.Errorf("invalid task graph: %w", err)
+	}
+
+	return nil
+}
+
 type FlowRunExecuteEventMessage struct {
 	FlowRunId          uuid.UUID              `json:"flow_run_id"`
 	Parameters         map[string]interface{} `json:"parameters,omitempty"`
 	Engine             string                 `json:"engine"`
+	FlowName           string                 `json:"flow_name,omitempty"`
 	CodeLocation       string                 `json:"code_location"`
 	Entrypoint         string                 `json:"entrypoint"`
 	Args               []string               `json:"args"`
 	SuccessTaskResults map[string]string      `json:"success_task_results"`
+	RequiredLabels     []string               `json:"required_labels,omitempty"`
 	EventTimestamp     time.Time              `json:"event_timestamp"`
 }
 
 // Subject returns the event subject for FlowRunExecute events
 func (msg *FlowRunExecuteEventMessage) Subject() EventSubject {
-	return FlowRunExecuteEventSubject
+	return FlowRunExecuteLabelsSubject(msg.RequiredLabels)
 }
 
 // Validate checks if the FlowRunExecute event message is valid
@@ -152,10 +321,11 @@ func (msg *FlowRunExecuteEventMessage) Validate() error {
 	if msg.Engine == "" {
 		return fmt.Errorf("engine is required")
 	}
-	if msg.Engine != "process" {
-		return fmt.Errorf("unsupported engine: %s, only 'process' is supported", msg.Engine)
+	if msg.Engine != "process" && msg.Engine != "docker" && msg.Engine != "node" {
+		return fmt.Errorf("unsupported engine: %s, only 'process', 'docker' and 'node' are supported", msg.Engine)
 	}
-	if msg.Entrypoint == "" {
+	// The image of an OCI code location runs its own entrypoint
+	if msg.Entrypoint == "" && !strings.HasPrefix(msg.CodeLocation, "oci://") {
 		return fmt.Errorf("entrypoint is required")
 	}
 
@@ -163,10 +333,12 @@ func (msg *FlowRunExecuteEventMessage) Validate() error {
 }
 
 type FlowRunExecuteRequestEventMessage struct {
-	FlowId     uuid.UUID              `json:"flow_id"`
-	FlowRunId  *uuid.UUID             `json:"flow_run_id,omitempty"`
-	Parameters map[string]interface{} `json:"parameters"`
-	Engine     string                 `json:"engine"`
+	FlowId      uuid.UUID              `json:"flow_id"`
+	FlowRunId   *uuid.UUID             `json:"flow_run_id,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters"`
+	Engine      string                 `json:"engine"`
+	MaxRetries  *int32                 `json:"max_retries,omitempty"`
+	ParentRunId *uuid.UUID             `json:"parent_run_id,omitempty"`
 }
 
 // Subject returns the event subject for FlowRunExecute events
@@ -185,12 +357,17 @@ func (msg *FlowRunExecuteRequestEventMessage) Validate() error {
 	if msg.Engine == "" {
 		msg.Engine = "process" // Default to process engine if not specified
 	}
+	if msg.MaxRetries != nil && *msg.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative")
+	}
 
 	return nil
 }
 
 type FlowRunExecuteResponseEventMessage struct {
-	FlowRun db.FlowRun `json:"flow_run"`
+	FlowRun            db.FlowRun                  `json:"flow_run"`
+	Violations         []db.FlowParameterViolation `json:"violations,omitempty"`
+	ConcurrencyLimited bool                        `json:"concurrency_limited,omitempty"`
 }
 
 // Subject returns the event subject for FlowRunExecute response events
@@ -206,6 +383,231 @@ func (msg *FlowRunExecuteResponseEventMessage) Validate() error {
 	return nil
 }
 
+type FlowRunCancelEventMessage struct {
+	FlowRunId      uuid.UUID `json:"flow_run_id"`
+	EventTimestamp time.Time `json:"event_timestamp"`
+}
+
+// Subject returns the event subject for FlowRunCancel events
+func (msg *FlowRunCancelEventMessage) Subject() EventSubject {
+	return FlowRunCancelEventSubject
+}
+
+// Validate checks if the FlowRunCancel event message is valid
+func (msg *FlowRunCancelEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+
+	return nil
+}
+
+type FlowRunCancelRequestEventMessage struct {
+	FlowRunId uuid.UUID `json:"flow_run_id"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// Subject returns the event subject for FlowRunCancel events
+func (msg *FlowRunCancelRequestEventMessage) Subject() EventSubject {
+	return FlowRunCancelRequestEventSubject
+}
+
+// Validate checks if the FlowRunCancel event message is valid
+func (msg *FlowRunCancelRequestEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+
+	return nil
+}
+
+type FlowRunCancelResponseEventMessage struct {
+	FlowRun db.FlowRun `json:"flow_run"`
+}
+
+// Subject returns the event subject for FlowRunCancel response events
+func (msg *FlowRunCancelResponseEventMessage) Subject() EventSubject {
+	return FlowRunCancelRequestEventSubject
+}
+
+// Validate checks if the FlowRunCancel response event message is valid
+func (msg *FlowRunCancelResponseEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	return nil
+}
+
+type FlowRunPauseEventMessage struct {
+	FlowRunId      uuid.UUID `json:"flow_run_id"`
+	EventTimestamp time.Time `json:"event_timestamp"`
+}
+
+// Subject returns the event subject for FlowRunPause events
+func (msg *FlowRunPauseEventMessage) Subject() EventSubject {
+	return FlowRunPauseEventSubject
+}
+
+// Validate checks if the FlowRunPause event message is valid
+func (msg *FlowRunPauseEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+
+	return nil
+}
+
+type FlowRunPauseRequestEventMessage struct {
+	FlowRunId uuid.UUID `json:"flow_run_id"`
+}
+
+// Subject returns the event subject for FlowRunPause events
+func (msg *FlowRunPauseRequestEventMessage) Subject() EventSubject {
+	return FlowRunPauseRequestEventSubject
+}
+
+// Validate checks if the FlowRunPause event message is valid
+func (msg *FlowRunPauseRequestEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+
+	return nil
+}
+
+type FlowRunPauseResponseEventMessage struct {
+	FlowRun db.FlowRun `json:"flow_run"`
+}
+
+// Subject returns the event subject for FlowRunPause response events
+func (msg *FlowRunPauseResponseEventMessage) Subject() EventSubject {
+	return FlowRunPauseRequestEventSubject
+}
+
+// Validate checks if the FlowRunPause response event message is valid
+func (msg *FlowRunPauseResponseEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	return nil
+}
+
+type FlowRunResumeRequestEventMessage struct {
+	FlowRunId uuid.UUID `json:"flow_run_id"`
+}
+
+// Subject returns the event subject for FlowRunResume events
+func (msg *FlowRunResumeRequestEventMessage) Subject() EventSubject {
+	return FlowRunResumeRequestEventSubject
+}
+
+// Validate checks if the FlowRunResume event message is valid
+func (msg *FlowRunResumeRequestEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+
+	return nil
+}
+
+type FlowRunResumeResponseEventMessage struct {
+	FlowRun db.FlowRun `json:"flow_run"`
+}
+
+// Subject returns the event subject for FlowRunResume response events
+func (msg *FlowRunResumeResponseEventMessage) Subject() EventSubject {
+	return FlowRunResumeRequestEventSubject
+}
+
+// Validate checks if the FlowRunResume response event message is valid
+func (msg *FlowRunResumeResponseEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	return nil
+}
+
+type FlowRunRetryRequestEventMessage struct {
+	FlowRunId uuid.UUID `json:"flow_run_id"`
+}
+
+// Subject returns the event subject for FlowRunRetry events
+func (msg *FlowRunRetryRequestEventMessage) Subject() EventSubject {
+	return FlowRunRetryRequestEventSubject
+}
+
+// Validate checks if the FlowRunRetry event message is valid
+func (msg *FlowRunRetryRequestEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+
+	return nil
+}
+
+type FlowRunRetryResponseEventMessage struct {
+	FlowRun db.FlowRun `json:"flow_run"`
+}
+
+// Subject returns the event subject for FlowRunRetry response events
+func (msg *FlowRunRetryResponseEventMessage) Subject() EventSubject {
+	return FlowRunRetryRequestEventSubject
+}
+
+// Validate checks if the FlowRunRetry response event message is valid
+func (msg *FlowRunRetryResponseEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	return nil
+}
+
+type FlowRunCompletedEventMessage struct {
+	FlowRunId      uuid.UUID     `json:"flow_run_id"`
+	ParentRunId    *uuid.UUID    `json:"parent_run_id,omitempty"`
+	Status         db.FlowStatus `json:"status"`
+	ErrorMessage   string        `json:"error_message,omitempty"`
+	EventTimestamp time.Time     `json:"event_timestamp"`
+}
+
+// Subject returns the event subject for FlowRunCompleted events
+func (msg *FlowRunCompletedEventMessage) Subject() EventSubject {
+	return FlowRunCompletedSubject(msg.FlowRunId)
+}
+
+// Validate checks if the FlowRunCompleted event message is valid
+func (msg *FlowRunCompletedEventMessage) Validate() error {
+	if msg == nil {
+		return fmt.Errorf("message is nil")
+	}
+	if msg.FlowRunId == uuid.Nil {
+		return fmt.Errorf("flow_run_id is required")
+	}
+	if msg.Status == "" {
+		return fmt.Errorf("status is required")
+	}
+
+	return nil
+}
+
 type TaskExecuteEventMessage struct {
 	AgentId     uuid.UUID    `json:"agent_id"`
 	RecipientId uuid.UUID    `json:"recipient_id"`
@@ -240,6 +642,7 @@ type TaskHandoffEventMessage struct {
 	AgentID          uuid.UUID    `json:"agent_i_d"`
 	AgentHandoffToID uuid.UUID    `json:"agent_handoff_to_i_d"`
 	Messages         []db.JsonRaw `json:"messages"`
+	TimeoutSeconds   int          `json:"timeout_seconds,omitempty"`
 }
 
 // Subject returns the event subject for TaskHandoff events
@@ -384,11 +787,12 @@ type StandaloneToolRequestEventMessage struct {
 	ToolInput  map[string]any `json:"tool_input"`
 	ToolURL    string         `json:"tool_u_r_l"`
 	ToolAPIKey *string        `json:"tool_a_p_i_key"`
+	EdgeGroup  string         `json:"edge_group,omitempty"`
 }
 
 // Subject returns the event subject for StandaloneToolRequest events
 func (msg *StandaloneToolRequestEventMessage) Subject() EventSubject {
-	return StandaloneToolRequestEventSubject
+	return StandaloneToolEdgeSubject(msg.EdgeGroup)
 }
 
 // Validate checks if the StandaloneToolRequest event message is valid
@@ -469,6 +873,7 @@ type WebsocketTaskLifecycleEventMessage struct {
 	TaskId   string    `json:"task_id,omitempty"`
 	ThreadId uuid.UUID `json:"thread_id,omitempty"`
 	Message  string    `json:"message,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
 }
 
 // Subject returns the event subject for WebsocketTaskLifecycle events
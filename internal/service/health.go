@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/pinazu/internal/telemetry"
+)
+
+const (
+	// HealthPath is the path of the liveness endpoint of the services
+	HealthPath = "/healthz"
+
+	// ReadyPath is the path of the readiness endpoint of the services
+	ReadyPath = "/readyz"
+
+	// healthCheckTimeout bounds each dependency check of the readiness
+	healthCheckTimeout = 2 * time.Second
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusOK     HealthStatus = "ok"
+	HealthStatusFailed HealthStatus = "failed"
+)
+
+type (
+	// DependencyCheck is the result of the check of a dependency of a service
+	DependencyCheck struct {
+		Name      string       `json:"name"` // nats, database, jetstream or stream:<name>
+		Status    HealthStatus `json:"status"`
+		LatencyMs float64      `json:"latency_ms"`
+		Error     string       `json:"error,omitempty"`
+	}
+
+	// ServiceHealth is the health of a service with the checks of its dependencies
+	ServiceHealth struct {
+		ServiceIdentity
+		Status HealthStatus      `json:"status"`
+		Checks []DependencyCheck `json:"checks"`
+	}
+
+	// HealthReport is the health of the services of the process, failed when one of them failed
+	HealthReport struct {
+		Status    HealthStatus    `json:"status"`
+		CheckedAt time.Time       `json:"checked_at"`
+		Services  []ServiceHealth `json:"services"`
+	}
+)
+
+var (
+	healthMu       sync.Mutex
+	healthServices []*service // Services of the process, reported by the health endpoints
+)
+
+// registerHealth adds a service to the services reported by the health endpoints of the process
+func registerHealth(s *service) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	healthServices = append(healthServices, s)
+}
+
+// CheckHealth checks the services of the process. The liveness only fails on a closed NATS connection or a stopped
+// service, the services outlive a database outage in degraded mode. The readiness also checks the round trip to
+// NATS, the database pool, JetStream and the streams needed by the services.
+func CheckHealth(ctx context.Context, ready bool) HealthReport {
+	healthMu.Lock()
+	services := append([]*service(nil), healthServices...)
+	healthMu.Unlock()
+
+	report := HealthReport{Status: HealthStatusOK, CheckedAt: time.Now().UTC(), Services: make([]ServiceHealth, 0, len(services))}
+	for _, s := range services {
+		health := s.health(ctx, ready)
+		if health.Status != HealthStatusOK {
+			report.Status = HealthStatusFailed
+		}
+		report.Services = append(report.Services, health)
+	}
+	return report
+}
+
+// HealthHandler returns the HTTP handler of the liveness or the readiness of the services of the process, answering
+// 503 Service Unavailable when a check failed
+func HealthHandler(ready bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := CheckHealth(r.Context(), ready)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if report.Status != HealthStatusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(report)
+	})
+}
+
+// ServeHealth serves the health endpoints of the services of the process at an address, shared with the metrics
+// when it is the address of the metrics
+func ServeHealth(ctx context.Context, address string) error {
+	for path, ready := range map[string]bool{HealthPath: false, ReadyPath: true} {
+		if err := telemetry.ServeHandler(ctx, address, path, HealthHandler(ready)); err != nil {
+			return fmt.Errorf("failed to serve %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// health checks the dependencies of a service
+func (s *service) health(ctx context.Context, ready bool) ServiceHealth {
+	health := ServiceHealth{ServiceIdentity: ServiceIdentity{Name: s.Config.Name, ID: s.id, Version: s.Config.Version}, Status: HealthStatusOK}
+	if s.Stopped() {
+		health.Status = HealthStatusFailed
+		health.Checks = []DependencyCheck{{Name: "service", Status: HealthStatusFailed, Error: "service stopped"}}
+		return health
+	}
+
+	health.Checks = append(health.Checks, runCheck("nats", func() error {
+		if s.nc.IsClosed() {
+			return errors.New("connection closed")
+		}
+		if !ready {
+			return nil
+		}
+		if !s.nc.IsConnected() {
+			return fmt.Errorf("connection %s", s.nc.Status())
+		}
+		return s.nc.FlushTimeout(healthCheckTimeout)
+	}))
+	if ready {
+		health.Checks = append(health.Checks, runCheck("database", func() error {
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			return s.pool.Ping(ctx)
+		}))
+		health.Checks = append(health.Checks, s.checkJetStream(ctx)...)
+	}
+
+	for _, check := range health.Checks {
+		if check.Status != HealthStatusOK {
+			health.Status = HealthStatusFailed
+		}
+	}
+	return health
+}
+
+// checkJetStream checks that JetStream is available and the streams needed by a service exist
+func (s *service) checkJetStream(ctx context.Context) []DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	js, err := jetstream.New(s.nc)
+	checks := []DependencyCheck{runCheck("jetstream", func() error {
+		if err != nil {
+			return err
+		}
+		_, err := js.AccountInfo(ctx)
+		return err
+	})}
+	if err != nil || checks[0].Status != HealthStatusOK {
+		return checks
+	}
+	for _, name := range s.Config.Streams {
+		checks = append(checks, runCheck("stream:"+name, func() error {
+			_, err := js.Stream(ctx, name)
+			return err
+		}))
+	}
+	return checks
+}
+
+// runCheck runs the check of a dependency and measures its latency
+func runCheck(name string, check func() error) DependencyCheck {
+	start := time.Now()
+	err := check()
+	result := DependencyCheck{Name: name, Status: HealthStatusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = HealthStatusFailed
+		result.Error = err.Error()
+	}
+	return result
+}
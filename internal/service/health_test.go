@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCheck(t *testing.T) {
+	check := runCheck("database", func() error { return nil })
+	assert.Equal(t, "database", check.Name)
+	assert.Equal(t, HealthStatusOK, check.Status)
+	assert.Empty(t, check.Error)
+
+	check = runCheck("stream:WORKER_FLOWS", func() error { return errors.New("stream not found") })
+	assert.Equal(t, HealthStatusFailed, check.Status)
+	assert.Equal(t, "stream not found", check.Error)
+}
+
+func TestHealthHandler_StoppedService(t *testing.T) {
+	healthMu.Lock()
+	registered := healthServices
+	healthServices = []*service{{Config: Config{Name: "test-service", Version: "1.0.0"}, id: "ID", stopped: true}}
+	healthMu.Unlock()
+	t.Cleanup(func() {
+		healthMu.Lock()
+		healthServices = registered
+		healthMu.Unlock()
+	})
+
+	for _, path := range []string{HealthPath, ReadyPath} {
+		recorder := httptest.NewRecorder()
+		HealthHandler(path == ReadyPath).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code, path)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		var report HealthReport
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+		assert.Equal(t, HealthStatusFailed, report.Status)
+		require.Len(t, report.Services, 1)
+		assert.Equal(t, "test-service", report.Services[0].Name)
+		assert.Equal(t, []DependencyCheck{{Name: "service", Status: HealthStatusFailed, Error: "service stopped"}}, report.Services[0].Checks)
+	}
+}
+
+func TestCheckHealth_NoService(t *testing.T) {
+	healthMu.Lock()
+	registered := healthServices
+	healthServices = nil
+	healthMu.Unlock()
+	t.Cleanup(func() {
+		healthMu.Lock()
+		healthServices = registered
+		healthMu.Unlock()
+	})
+
+	report := CheckHealth(t.Context(), true)
+	assert.Equal(t, HealthStatusOK, report.Status)
+	assert.Empty(t, report.Services)
+}
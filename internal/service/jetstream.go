@@ -13,10 +13,11 @@ import (
 type (
 	// JetStreamService wraps JetStream functionality for stream and consumer management
 	JetStreamService struct {
-		nc     *nats.Conn
-		js     jetstream.JetStream
-		ctx    context.Context
-		logger hclog.Logger
+		nc         *nats.Conn
+		js         jetstream.JetStream
+		ctx        context.Context
+		logger     hclog.Logger
+		redelivery *RedeliveryConfig
 	}
 
 
@@ -45,10 +46,11 @@ func NewJetStreamService(ctx context.Context, nc *nats.Conn, logger hclog.Logger
 	}
 
 	return &JetStreamService{
-		nc:     nc,
-		js:     js,
-		ctx:    ctx,
-		logger: logger.Named("jetstream"),
+		nc:         nc,
+		js:         js,
+		ctx:        ctx,
+		logger:     logger.Named("jetstream"),
+		redelivery: (&NatsConfig{}).GetRedeliveryConfig(),
 	}, nil
 }
 
@@ -88,6 +90,15 @@ func (jss *JetStreamService) CreateOrUpdateStream(config jetstream.StreamConfig)
 	return stream, nil
 }
 
+// CreateOrUpdateKeyValue creates or updates a JetStream key-value bucket
+func (jss *JetStreamService) CreateOrUpdateKeyValue(config jetstream.KeyValueConfig) (jetstream.KeyValue, error) {
+	kv, err := jss.js.CreateOrUpdateKeyValue(jss.ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update key-value bucket %s: %w", config.Bucket, err)
+	}
+	return kv, nil
+}
+
 // CreateOrUpdateConsumer creates or updates a JetStream consumer
 func (jss *JetStreamService) CreateOrUpdateConsumer(config ConsumerConfig, jsConfig *JetStreamConfig) (jetstream.Consumer, error) {
 	jss.logger.Info("Creating or updating consumer", "name", config.Name, "stream", config.StreamName)
@@ -147,6 +158,10 @@ func (jss *JetStreamService) ConsumeMessages(consumerName, streamName string, ha
 
 	// Wrap the handler to match MessageHandler signature
 	messageHandler := func(msg jetstream.Msg) {
+		// A message redelivered over and over is parked instead of pinning the consumers
+		if jss.parkRedeliveryStorm(msg) {
+			return
+		}
 		if err := handler(msg); err != nil {
 			jss.logger.Error("Error processing message", "error", err, "subject", msg.Subject())
 			// In case of error, we can choose to NAK the message
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/pinazu/internal/db"
+)
+
+const (
+	// MessageBufferStream holds the thread messages that could not be written while the database was unavailable
+	MessageBufferStream = "MESSAGE_BUFFER"
+
+	// MessageBufferSubject is the subject of the buffered thread messages
+	MessageBufferSubject = "v1.buffer.messages"
+
+	// messageBufferConsumer replays the buffered messages into the database
+	messageBufferConsumer = "message-buffer-replay"
+
+	// The buffer is bounded, new messages are rejected once it is full
+	messageBufferMaxMsgs  = 10000
+	messageBufferMaxBytes = 64 << 20
+	messageBufferMaxAge   = 24 * time.Hour
+
+	// messageBufferRetryDelay is the delay before a buffered message is replayed again while the database is unavailable
+	messageBufferRetryDelay = 5 * time.Second
+)
+
+// BufferedMessage is a thread message waiting to be written to the database, either a user or an agent message
+type BufferedMessage struct {
+	User       *db.CreateUserMessageParams  `json:"user,omitempty"`
+	Agent      *db.CreateAgentMessageParams `json:"agent,omitempty"`
+	BufferedAt time.Time                    `json:"buffered_at"`
+}
+
+// messageBufferStreamConfig returns the configuration of the message buffer stream
+func messageBufferStreamConfig() jetstream.StreamConfig {
+	return jetstream.StreamConfig{
+		Name:        MessageBufferStream,
+		Subjects:    []string{MessageBufferSubject},
+		Description: "Thread messages waiting for the database to be available",
+		Storage:     jetstream.FileStorage,
+		Retention:   jetstream.WorkQueuePolicy,
+		Discard:     jetstream.DiscardNew,
+		MaxMsgs:     messageBufferMaxMsgs,
+		MaxBytes:    messageBufferMaxBytes,
+		MaxAge:      messageBufferMaxAge,
+	}
+}
+
+// BufferMessage stores a thread message that could not be written because the database is unavailable.
+// The message is written by ReplayBufferedMessages once the database is back.
+func (jss *JetStreamService) BufferMessage(m *BufferedMessage) error {
+	if _, err := jss.js.CreateOrUpdateStream(jss.ctx, messageBufferStreamConfig()); err != nil {
+		return fmt.Errorf("failed to create message buffer stream: %w", err)
+	}
+	if m.BufferedAt.IsZero() {
+		m.BufferedAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal buffered message: %w", err)
+	}
+	if _, err := jss.js.PublishMsg(jss.ctx, &nats.Msg{Subject: MessageBufferSubject, Data: data}); err != nil {
+		return fmt.Errorf("failed to buffer message: %w", err)
+	}
+	return nil
+}
+
+// ReplayBufferedMessages writes the buffered thread messages to the database, in the order they were buffered.
+// A message is retried while the database is unavailable, and dropped when it can never be written.
+func (jss *JetStreamService) ReplayBufferedMessages(queries *db.Queries) error {
+	stream, err := jss.js.CreateOrUpdateStream(jss.ctx, messageBufferStreamConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create message buffer stream: %w", err)
+	}
+	consumer, err := stream.CreateOrUpdateConsumer(jss.ctx, jetstream.ConsumerConfig{
+		Durable:       messageBufferConsumer,
+		Description:   "Replays the buffered thread messages into the database",
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+		AckWait:       30 * time.Second,
+		MaxAckPending: 1, // Keep the messages of a thread in order
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create message buffer consumer: %w", err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		err := replayBufferedMessage(jss.ctx, queries, msg.Data())
+		switch {
+		case err == nil:
+			msg.Ack()
+		case db.IsUnavailable(err):
+			msg.NakWithDelay(messageBufferRetryDelay)
+		default:
+			jss.logger.Error("Dropping buffered message that cannot be written", "error", err)
+			msg.Term()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start replaying buffered messages: %w", err)
+	}
+
+	go func() {
+		<-jss.ctx.Done()
+		consumeCtx.Stop()
+	}()
+	return nil
+}
+
+// replayBufferedMessage writes a buffered message to the database.
+// A message of a thread deleted in the meantime is skipped.
+func replayBufferedMessage(ctx context.Context, queries *db.Queries, data []byte) error {
+	var m BufferedMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("failed to unmarshal buffered message: %w", err)
+	}
+
+	var err error
+	switch {
+	case m.User != nil:
+		_, err = queries.CreateUserMessage(ctx, *m.User)
+	case m.Agent != nil:
+		_, err = queries.CreateAgentMessage(ctx, *m.Agent)
+	default:
+		return errors.New("buffered message is empty")
+	}
+	if err != nil && strings.Contains(err.Error(), "foreign key constraint") {
+		return nil
+	}
+	return err
+}
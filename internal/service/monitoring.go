@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// ServiceInfoSubject is answered by every service instance with its Info, a request gathers the whole cluster
+	ServiceInfoSubject = "v1.svc._info"
+
+	// ServiceStatsSubject is answered by every service instance with its Stats, a request gathers the whole cluster
+	ServiceStatsSubject = "v1.svc._stats"
+)
+
+type (
+	// ServiceStatus is the info and stats of a service instance gathered by CollectServices
+	ServiceStatus struct {
+		ServiceIdentity
+		Description   string                   `json:"description"`
+		Started       time.Time                `json:"started"`
+		Uptime        time.Duration            `json:"uptime"`
+		NumMessages   uint64                   `json:"num_messages"`
+		NumErrors     uint64                   `json:"num_errors"`
+		Subscriptions []*SubscriptionStatsInfo `json:"subscriptions"`
+	}
+
+	// ServiceSummary totals the instances of a service
+	ServiceSummary struct {
+		Name        string `json:"name"`
+		Instances   int    `json:"instances"`
+		NumMessages uint64 `json:"num_messages"`
+		NumErrors   uint64 `json:"num_errors"`
+	}
+)
+
+// InfoHandler answers the monitoring requests with the Info of a service
+func InfoHandler(s Service) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		respondJSON(msg, s.Info())
+	}
+}
+
+// StatsHandler answers the monitoring requests with the Stats of a service
+func StatsHandler(s Service) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		respondJSON(msg, s.Stats())
+	}
+}
+
+func respondJSON(msg *nats.Msg, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_ = msg.Respond(data)
+}
+
+// CollectServices requests the info and stats of every service instance of the cluster, gathering the responses
+// received within the timeout. An instance is reported once its info or its stats is received, the instances are
+// ordered by name then ID.
+func CollectServices(ctx context.Context, nc *nats.Conn, timeout time.Duration) ([]ServiceStatus, error) {
+	responses := make(chan *nats.Msg, 256)
+	infoInbox, statsInbox := nc.NewRespInbox(), nc.NewRespInbox()
+	for _, inbox := range []string{infoInbox, statsInbox} {
+		sub, err := nc.ChanSubscribe(inbox, responses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to the monitoring responses: %w", err)
+		}
+		defer sub.Unsubscribe()
+	}
+	if err := nc.PublishRequest(ServiceInfoSubject, infoInbox, nil); err != nil {
+		return nil, fmt.Errorf("failed to request the service info: %w", err)
+	}
+	if err := nc.PublishRequest(ServiceStatsSubject, statsInbox, nil); err != nil {
+		return nil, fmt.Errorf("failed to request the service stats: %w", err)
+	}
+
+	var infos []Info
+	var stats []Stats
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return mergeServiceStatus(infos, stats, time.Now()), nil
+		case msg := <-responses:
+			if msg.Subject == infoInbox {
+				var info Info
+				if err := json.Unmarshal(msg.Data, &info); err == nil && info.Type == InfoResponseType {
+					infos = append(infos, info)
+				}
+				continue
+			}
+			var stat Stats
+			if err := json.Unmarshal(msg.Data, &stat); err == nil && stat.Type == StatsResponseType {
+				stats = append(stats, stat)
+			}
+		}
+	}
+}
+
+// mergeServiceStatus joins the info and stats responses of the instances by ID
+func mergeServiceStatus(infos []Info, stats []Stats, now time.Time) []ServiceStatus {
+	byID := make(map[string]*ServiceStatus)
+	instance := func(identity ServiceIdentity) *ServiceStatus {
+		status, ok := byID[identity.ID]
+		if !ok {
+			status = &ServiceStatus{ServiceIdentity: identity, Subscriptions: []*SubscriptionStatsInfo{}}
+			byID[identity.ID] = status
+		}
+		return status
+	}
+	for _, info := range infos {
+		status := instance(info.ServiceIdentity)
+		status.Description = info.Description
+		// The subscriptions are listed from the info until the stats give their counters
+		if len(status.Subscriptions) == 0 {
+			for _, sub := range info.Subscriptions {
+				status.Subscriptions = append(status.Subscriptions, &SubscriptionStatsInfo{SubscriptionStatsBase: SubscriptionStatsBase{Subject: sub.Subject}})
+			}
+		}
+	}
+	for _, stat := range stats {
+		status := instance(stat.ServiceIdentity)
+		status.Started = stat.Started
+		status.Uptime = now.Sub(stat.Started).Truncate(time.Second)
+		status.Subscriptions = stat.Subscriptions
+		for _, sub := range stat.Subscriptions {
+			status.NumMessages += sub.NumMessages
+			status.NumErrors += sub.NumErrors
+		}
+	}
+
+	statuses := make([]ServiceStatus, 0, len(byID))
+	for _, status := range byID {
+		sort.Slice(status.Subscriptions, func(i, j int) bool {
+			return status.Subscriptions[i].Subject < status.Subscriptions[j].Subject
+		})
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Name != statuses[j].Name {
+			return statuses[i].Name < statuses[j].Name
+		}
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses
+}
+
+// SummarizeServices totals the instances of each service, the statuses being ordered by name as CollectServices
+// returns them
+func SummarizeServices(statuses []ServiceStatus) []ServiceSummary {
+	summaries := []ServiceSummary{}
+	for _, status := range statuses {
+		if len(summaries) == 0 || summaries[len(summaries)-1].Name != status.Name {
+			summaries = append(summaries, ServiceSummary{Name: status.Name})
+		}
+		summary := &summaries[len(summaries)-1]
+		summary.Instances++
+		summary.NumMessages += status.NumMessages
+		summary.NumErrors += status.NumErrors
+	}
+	return summaries
+}
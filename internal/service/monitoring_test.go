@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeServiceStatus(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	agent := ServiceIdentity{Name: "agents-handler-service", ID: "B", Version: "1.0.0"}
+	api := ServiceIdentity{Name: "api-gateway-service", ID: "C", Version: "1.0.0"}
+	late := ServiceIdentity{Name: "agents-handler-service", ID: "A", Version: "1.0.0"}
+
+	infos := []Info{
+		{ServiceIdentity: agent, Type: InfoResponseType, Description: "Agents", Subscriptions: []SubscriptionInfo{{Subject: "v1.svc.agent.invoke"}}},
+		{ServiceIdentity: late, Type: InfoResponseType, Description: "Agents", Subscriptions: []SubscriptionInfo{{Subject: "v1.svc.agent.invoke"}, {Subject: "v1.svc._info"}}},
+	}
+	stats := []Stats{
+		{ServiceIdentity: agent, Type: StatsResponseType, Started: now.Add(-90 * time.Second), Subscriptions: []*SubscriptionStatsInfo{
+			{SubscriptionStatsBase: SubscriptionStatsBase{Subject: "v1.svc.agent.invoke"}, NumMessages: 7, NumErrors: 1},
+			{SubscriptionStatsBase: SubscriptionStatsBase{Subject: "v1.svc._stats"}, NumMessages: 2},
+		}},
+		{ServiceIdentity: api, Type: StatsResponseType, Started: now.Add(-time.Hour), Subscriptions: []*SubscriptionStatsInfo{
+			{SubscriptionStatsBase: SubscriptionStatsBase{Subject: "v1.svc.api._info"}, NumMessages: 3},
+		}},
+	}
+
+	statuses := mergeServiceStatus(infos, stats, now)
+	require.Len(t, statuses, 3)
+
+	// Ordered by name then ID
+	assert.Equal(t, late, statuses[0].ServiceIdentity)
+	assert.Equal(t, agent, statuses[1].ServiceIdentity)
+	assert.Equal(t, api, statuses[2].ServiceIdentity)
+
+	// The instance whose stats were not received lists the subscriptions of its info
+	assert.True(t, statuses[0].Started.IsZero())
+	assert.Equal(t, "v1.svc._info", statuses[0].Subscriptions[0].Subject)
+	assert.Len(t, statuses[0].Subscriptions, 2)
+
+	assert.Equal(t, "Agents", statuses[1].Description)
+	assert.Equal(t, 90*time.Second, statuses[1].Uptime)
+	assert.Equal(t, uint64(9), statuses[1].NumMessages)
+	assert.Equal(t, uint64(1), statuses[1].NumErrors)
+	assert.Equal(t, "v1.svc._stats", statuses[1].Subscriptions[0].Subject)
+
+	assert.Equal(t, "", statuses[2].Description)
+	assert.Equal(t, time.Hour, statuses[2].Uptime)
+
+	summaries := SummarizeServices(statuses)
+	assert.Equal(t, []ServiceSummary{
+		{Name: "agents-handler-service", Instances: 2, NumMessages: 9, NumErrors: 1},
+		{Name: "api-gateway-service", Instances: 1, NumMessages: 3},
+	}, summaries)
+	assert.Equal(t, []ServiceSummary{}, SummarizeServices(nil))
+}
+
+func TestService_RecordError(t *testing.T) {
+	var reported *NATSError
+	s := &service{Config: Config{ErrorHandler: func(_ Service, err *NATSError) { reported = err }}}
+	stat := &SubscriptionStats{SubscriptionStatsBase: SubscriptionStatsBase{Subject: "v1.svc.test"}}
+
+	s.recordError("v1.svc.test", stat, errors.New("handler panic: boom"))
+
+	assert.Equal(t, uint64(1), stat.NumErrors.Load())
+	assert.Equal(t, "handler panic: boom", stat.LastError)
+	require.NotNil(t, reported)
+	assert.Equal(t, "v1.svc.test", reported.Subject)
+}
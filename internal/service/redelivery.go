@@ -0,0 +1,373 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// DeadLetterStream is the stream holding the messages parked after a redelivery storm
+	DeadLetterStream = "DEAD_LETTERS"
+
+	// DeadLetterSubjectPrefix prefixes the subjects of the parked messages, followed by the name of their stream
+	DeadLetterSubjectPrefix = "v1.dlq"
+
+	// RedeliveryBucket is the KV bucket counting the redeliveries of a message per consumer
+	RedeliveryBucket = "PINAZU_REDELIVERIES"
+
+	// redeliveryCounterTTL expires the counters of messages that stopped being redelivered
+	redeliveryCounterTTL = time.Hour
+
+	// RedeliveryStormReason is the reason recorded on the messages parked by the storm detection
+	RedeliveryStormReason = "redelivery_storm"
+
+	// requeueKeySuffix suffixes the key counting the requeues of a message by a consumer, next to its redelivery counter
+	requeueKeySuffix = "requeues"
+)
+
+// Headers added to a parked message, next to the headers of the original message
+const (
+	DeadLetterStreamHeader         = "Pinazu-Dlq-Stream"
+	DeadLetterStreamSequenceHeader = "Pinazu-Dlq-Stream-Sequence"
+	DeadLetterSubjectHeader        = "Pinazu-Dlq-Subject"
+	DeadLetterConsumerHeader       = "Pinazu-Dlq-Consumer"
+	DeadLetterRedeliveriesHeader   = "Pinazu-Dlq-Redeliveries"
+	DeadLetterConsumersHeader      = "Pinazu-Dlq-Consumers"
+	DeadLetterReasonHeader         = "Pinazu-Dlq-Reason"
+	DeadLetterParkedAtHeader       = "Pinazu-Dlq-Parked-At"
+)
+
+// ParkedMessage is a message moved to the dead letter stream
+type ParkedMessage struct {
+	Sequence       uint64         `json:"sequence,omitempty"` // Sequence in the dead letter stream, zero until stored
+	Stream         string         `json:"stream"`
+	StreamSequence uint64         `json:"stream_sequence"`
+	Subject        string         `json:"subject"`
+	Consumer       string         `json:"consumer"`     // Consumer that detected the storm
+	Redeliveries   int            `json:"redeliveries"` // Redeliveries across every consumer of the stream
+	Consumers      map[string]int `json:"consumers"`    // Redeliveries per consumer
+	Reason         string         `json:"reason"`
+	ParkedAt       time.Time      `json:"parked_at"`
+	Data           []byte         `json:"-"`
+}
+
+// redeliveryAlert is the body posted to the alert webhook
+type redeliveryAlert struct {
+	Event string `json:"event"`
+	*ParkedMessage
+}
+
+// SetRedeliveryConfig sets the redelivery storm detection of the consumers started by ConsumeMessages
+func (jss *JetStreamService) SetRedeliveryConfig(rc *RedeliveryConfig) {
+	if rc == nil {
+		rc = (&NatsConfig{}).GetRedeliveryConfig()
+	}
+	if _, err := newWebhookTemplate(redeliveryAlertPresets, rc.AlertPreset, rc.AlertTemplate); err != nil {
+		jss.logger.Error("Invalid redelivery alert template, alerts will not be sent", "error", err)
+	}
+	jss.redelivery = rc
+}
+
+// redeliveryKV returns the redelivery counters bucket, creating it when missing
+func (jss *JetStreamService) redeliveryKV() (jetstream.KeyValue, error) {
+	kv, err := jss.js.CreateOrUpdateKeyValue(jss.ctx, jetstream.KeyValueConfig{
+		Bucket:      RedeliveryBucket,
+		Description: "Redeliveries of the messages per consumer",
+		Storage:     jetstream.FileStorage,
+		TTL:         redeliveryCounterTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open redelivery bucket: %w", err)
+	}
+	return kv, nil
+}
+
+// redeliveryKeyPrefix returns the prefix of the counter keys of a message
+func redeliveryKeyPrefix(stream string, streamSequence uint64) string {
+	return fmt.Sprintf("%s.%d", stream, streamSequence)
+}
+
+// requeueKey returns the key counting the requeues of a message by a consumer
+func requeueKey(md *jetstream.MsgMetadata) string {
+	return redeliveryKeyPrefix(md.Stream, md.Sequence.Stream) + "." + md.Consumer + "." + requeueKeySuffix
+}
+
+// requeues returns the times a consumer requeued a message
+func (jss *JetStreamService) requeues(kv jetstream.KeyValue, md *jetstream.MsgMetadata) (uint64, error) {
+	entry, err := kv.Get(jss.ctx, requeueKey(md))
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get requeue counter: %w", err)
+	}
+	n, err := strconv.ParseUint(string(entry.Value()), 10, 64)
+	if err != nil {
+		return 0, nil
+	}
+	return n, nil
+}
+
+// Requeue NAKs a message the consumer has no capacity for, so it is redelivered after the delay. The redeliveries
+// following a requeue are not counted by the storm detection, failing to record the requeue is only logged.
+func (jss *JetStreamService) Requeue(msg jetstream.Msg, delay time.Duration) error {
+	if md, err := msg.Metadata(); err == nil {
+		if err := jss.recordRequeue(md); err != nil {
+			jss.logger.Warn("Failed to record message requeue", "stream", md.Stream, "consumer", md.Consumer, "error", err)
+		}
+	}
+	if err := msg.NakWithDelay(delay); err != nil {
+		return fmt.Errorf("failed to requeue message: %w", err)
+	}
+	return nil
+}
+
+// recordRequeue increments the requeue counter of a message, a single consumer instance holds a delivery at once
+func (jss *JetStreamService) recordRequeue(md *jetstream.MsgMetadata) error {
+	kv, err := jss.redeliveryKV()
+	if err != nil {
+		return err
+	}
+	n, err := jss.requeues(kv, md)
+	if err != nil {
+		return err
+	}
+	if _, err := kv.PutString(jss.ctx, requeueKey(md), strconv.FormatUint(n+1, 10)); err != nil {
+		return fmt.Errorf("failed to record requeue: %w", err)
+	}
+	return nil
+}
+
+// countRedeliveries records the redeliveries of a message to a consumer and returns the redeliveries per consumer of the message,
+// the redeliveries following a requeue excluded
+func (jss *JetStreamService) countRedeliveries(kv jetstream.KeyValue, md *jetstream.MsgMetadata) (map[string]int, error) {
+	prefix := redeliveryKeyPrefix(md.Stream, md.Sequence.Stream)
+	requeues, err := jss.requeues(kv, md)
+	if err != nil {
+		return nil, err
+	}
+	redeliveries := strconv.FormatUint(md.NumDelivered-1-min(requeues, md.NumDelivered-1), 10)
+	if _, err := kv.PutString(jss.ctx, prefix+"."+md.Consumer, redeliveries); err != nil {
+		return nil, fmt.Errorf("failed to record redeliveries: %w", err)
+	}
+
+	lister, err := kv.ListKeysFiltered(jss.ctx, prefix+".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list redelivery counters: %w", err)
+	}
+	consumers := make(map[string]int)
+	for key := range lister.Keys() {
+		entry, err := kv.Get(jss.ctx, key)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get redelivery counter %s: %w", key, err)
+		}
+		n, err := strconv.Atoi(string(entry.Value()))
+		if err != nil {
+			continue
+		}
+		consumers[strings.TrimPrefix(key, prefix+".")] = n
+	}
+	return consumers, nil
+}
+
+// totalRedeliveries sums the redeliveries of a message across consumers
+func totalRedeliveries(consumers map[string]int) int {
+	total := 0
+	for _, n := range consumers {
+		total += n
+	}
+	return total
+}
+
+// parkRedeliveryStorm parks the message in the dead letter stream when it was redelivered more than the
+// storm threshold across the consumers of its stream. It returns true when the message was parked and must
+// not be handled. Detection failures are logged and the message is handled as usual.
+func (jss *JetStreamService) parkRedeliveryStorm(msg jetstream.Msg) bool {
+	md, err := msg.Metadata()
+	if err != nil || md.NumDelivered <= 1 {
+		return false
+	}
+	rc := jss.redelivery
+
+	kv, err := jss.redeliveryKV()
+	if err != nil {
+		jss.logger.Error("Failed to track message redelivery", "stream", md.Stream, "consumer", md.Consumer, "error", err)
+		return false
+	}
+	consumers, err := jss.countRedeliveries(kv, md)
+	if err != nil {
+		jss.logger.Error("Failed to track message redelivery", "stream", md.Stream, "consumer", md.Consumer, "error", err)
+		return false
+	}
+	redeliveries := totalRedeliveries(consumers)
+	if redeliveries <= rc.StormThreshold {
+		return false
+	}
+
+	parked := &ParkedMessage{
+		Stream:         md.Stream,
+		StreamSequence: md.Sequence.Stream,
+		Subject:        msg.Subject(),
+		Consumer:       md.Consumer,
+		Redeliveries:   redeliveries,
+		Consumers:      consumers,
+		Reason:         RedeliveryStormReason,
+		ParkedAt:       time.Now().UTC(),
+		Data:           msg.Data(),
+	}
+	jss.logger.Error("Redelivery storm detected, parking message in the dead letter stream",
+		"stream", parked.Stream,
+		"stream_sequence", parked.StreamSequence,
+		"subject", parked.Subject,
+		"consumer", parked.Consumer,
+		"redeliveries", parked.Redeliveries,
+		"consumers", parked.Consumers,
+	)
+	if err := jss.parkMessage(parked, msg.Headers()); err != nil {
+		jss.logger.Error("Failed to park message", "stream", md.Stream, "stream_sequence", md.Sequence.Stream, "error", err)
+		return false
+	}
+	if err := msg.TermWithReason(RedeliveryStormReason); err != nil {
+		jss.logger.Error("Failed to terminate parked message", "stream", md.Stream, "stream_sequence", md.Sequence.Stream, "error", err)
+	}
+	for consumer := range consumers {
+		if err := kv.Delete(jss.ctx, redeliveryKeyPrefix(md.Stream, md.Sequence.Stream)+"."+consumer); err != nil {
+			jss.logger.Warn("Failed to delete redelivery counter", "stream", md.Stream, "consumer", consumer, "error", err)
+		}
+	}
+	if err := kv.Delete(jss.ctx, requeueKey(md)); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		jss.logger.Warn("Failed to delete requeue counter", "stream", md.Stream, "consumer", md.Consumer, "error", err)
+	}
+
+	if rc.AlertWebhookURL != "" {
+		go jss.sendRedeliveryAlert(rc, parked)
+	}
+	return true
+}
+
+// deadLetterStreamConfig returns the configuration of the dead letter stream
+func deadLetterStreamConfig(rc *RedeliveryConfig) jetstream.StreamConfig {
+	return jetstream.StreamConfig{
+		Name:        DeadLetterStream,
+		Subjects:    []string{DeadLetterSubjectPrefix + ".>"},
+		Description: "Messages parked after a redelivery storm",
+		Storage:     jetstream.FileStorage,
+		Retention:   jetstream.LimitsPolicy,
+		Discard:     jetstream.DiscardOld,
+		MaxAge:      time.Duration(rc.DeadLetterMaxAgeDays) * 24 * time.Hour,
+	}
+}
+
+// deadLetterHeaders returns the headers of a parked message, keeping the headers of the original message
+func deadLetterHeaders(parked *ParkedMessage, original nats.Header) nats.Header {
+	header := nats.Header{}
+	for k, v := range original {
+		header[k] = append([]string(nil), v...)
+	}
+	consumers, _ := json.Marshal(parked.Consumers)
+	header.Set(DeadLetterStreamHeader, parked.Stream)
+	header.Set(DeadLetterStreamSequenceHeader, strconv.FormatUint(parked.StreamSequence, 10))
+	header.Set(DeadLetterSubjectHeader, parked.Subject)
+	header.Set(DeadLetterConsumerHeader, parked.Consumer)
+	header.Set(DeadLetterRedeliveriesHeader, strconv.Itoa(parked.Redeliveries))
+	header.Set(DeadLetterConsumersHeader, string(consumers))
+	header.Set(DeadLetterReasonHeader, parked.Reason)
+	header.Set(DeadLetterParkedAtHeader, parked.ParkedAt.Format(time.RFC3339Nano))
+	return header
+}
+
+// parseParkedMessage reads a message of the dead letter stream back into a parked message
+func parseParkedMessage(sequence uint64, header nats.Header, data []byte) *ParkedMessage {
+	parked := &ParkedMessage{
+		Sequence: sequence,
+		Stream:   header.Get(DeadLetterStreamHeader),
+		Subject:  header.Get(DeadLetterSubjectHeader),
+		Consumer: header.Get(DeadLetterConsumerHeader),
+		Reason:   header.Get(DeadLetterReasonHeader),
+		Data:     data,
+	}
+	parked.StreamSequence, _ = strconv.ParseUint(header.Get(DeadLetterStreamSequenceHeader), 10, 64)
+	parked.Redeliveries, _ = strconv.Atoi(header.Get(DeadLetterRedeliveriesHeader))
+	parked.ParkedAt, _ = time.Parse(time.RFC3339Nano, header.Get(DeadLetterParkedAtHeader))
+	if err := json.Unmarshal([]byte(header.Get(DeadLetterConsumersHeader)), &parked.Consumers); err != nil {
+		parked.Consumers = map[string]int{}
+	}
+	return parked
+}
+
+// parkMessage copies a message into the dead letter stream
+func (jss *JetStreamService) parkMessage(parked *ParkedMessage, original nats.Header) error {
+	if _, err := jss.js.CreateOrUpdateStream(jss.ctx, deadLetterStreamConfig(jss.redelivery)); err != nil {
+		return fmt.Errorf("failed to create dead letter stream: %w", err)
+	}
+
+	ack, err := jss.js.PublishMsg(jss.ctx, &nats.Msg{
+		Subject: DeadLetterSubjectPrefix + "." + parked.Stream,
+		Header:  deadLetterHeaders(parked, original),
+		Data:    parked.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to dead letter stream: %w", err)
+	}
+	parked.Sequence = ack.Sequence
+	return nil
+}
+
+// sendRedeliveryAlert posts the parked message, without its payload, to the alert webhook.
+// The payload is rendered with the configured template or preset, such as a Slack or Teams message.
+func (jss *JetStreamService) sendRedeliveryAlert(rc *RedeliveryConfig, parked *ParkedMessage) {
+	alert, err := NewWebhookAlert(rc.AlertWebhookURL, redeliveryAlertPresets, rc.AlertPreset, rc.AlertTemplate, time.Duration(rc.AlertTimeoutSeconds)*time.Second)
+	if err != nil {
+		jss.logger.Error("Invalid redelivery alert template", "error", err)
+		return
+	}
+	if err := alert.Send(jss.ctx, redeliveryAlert{Event: RedeliveryStormReason, ParkedMessage: parked}); err != nil {
+		jss.logger.Error("Failed to send redelivery alert", "error", err)
+		return
+	}
+	jss.logger.Info("Redelivery alert sent", "stream", parked.Stream, "stream_sequence", parked.StreamSequence)
+}
+
+// ListParkedMessages returns a page of the parked messages, newest first, and the number of parked messages
+func (jss *JetStreamService) ListParkedMessages(offset, limit int) ([]*ParkedMessage, int, error) {
+	stream, err := jss.js.Stream(jss.ctx, DeadLetterStream)
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		return []*ParkedMessage{}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get stream %s: %w", DeadLetterStream, err)
+	}
+	info, err := stream.Info(jss.ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get stream info: %w", err)
+	}
+
+	// Sequences of expired or deleted messages are skipped
+	messages := []*ParkedMessage{}
+	skipped := 0
+	for seq := info.State.LastSeq; seq >= info.State.FirstSeq && seq > 0 && len(messages) < limit; seq-- {
+		raw, err := stream.GetMsg(jss.ctx, seq)
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get parked message %d: %w", seq, err)
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		messages = append(messages, parseParkedMessage(raw.Sequence, raw.Header, raw.Data))
+	}
+	return messages, int(info.State.Msgs), nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterHeaders_RoundTrip(t *testing.T) {
+	parked := &ParkedMessage{
+		Stream:         "WORKER_FLOWS",
+		StreamSequence: 42,
+		Subject:        FlowRunExecuteEventSubject.String(),
+		Consumer:       "worker-flow-consumer",
+		Redeliveries:   7,
+		Consumers:      map[string]int{"worker-flow-consumer": 4, "audit-consumer": 3},
+		Reason:         RedeliveryStormReason,
+		ParkedAt:       time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+	original := nats.Header{"Nats-Msg-Id": []string{"abc"}}
+
+	header := deadLetterHeaders(parked, original)
+	assert.Equal(t, "abc", header.Get("Nats-Msg-Id"))
+
+	got := parseParkedMessage(9, header, []byte(`{"msg":{}}`))
+	want := *parked
+	want.Sequence = 9
+	want.Data = []byte(`{"msg":{}}`)
+	assert.Equal(t, &want, got)
+
+	// The original headers are copied, not shared
+	header.Set("Nats-Msg-Id", "changed")
+	assert.Equal(t, "abc", original.Get("Nats-Msg-Id"))
+}
+
+func TestTotalRedeliveries(t *testing.T) {
+	assert.Equal(t, 0, totalRedeliveries(nil))
+	assert.Equal(t, 7, totalRedeliveries(map[string]int{"a": 4, "b": 3}))
+}
+
+func TestGetRedeliveryConfig_Defaults(t *testing.T) {
+	var nc *NatsConfig
+	rc := nc.GetRedeliveryConfig()
+	require.NotNil(t, rc)
+	assert.Equal(t, 1, rc.StormThreshold)
+	assert.Equal(t, 10, rc.AlertTimeoutSeconds)
+	assert.Equal(t, 14, rc.DeadLetterMaxAgeDays)
+	assert.Empty(t, rc.AlertWebhookURL)
+
+	nc = &NatsConfig{Redelivery: &RedeliveryConfig{StormThreshold: 20, AlertWebhookURL: "https://hooks.example.com/pinazu"}}
+	rc = nc.GetRedeliveryConfig()
+	assert.Equal(t, 20, rc.StormThreshold)
+	assert.Equal(t, "https://hooks.example.com/pinazu", rc.AlertWebhookURL)
+	assert.Equal(t, 14*24*time.Hour, deadLetterStreamConfig(rc).MaxAge)
+
+	// The default threshold is reached before the last delivery of the consumers
+	nc = &NatsConfig{JetStreamDefaultConfig: &JetStreamConfig{MaxDeliver: 10}}
+	assert.Equal(t, 8, nc.GetRedeliveryConfig().StormThreshold)
+}
+
+func TestValidateRedeliveryConfig(t *testing.T) {
+	ec := &ExternalDependenciesConfig{}
+	assert.NoError(t, ec.ValidateRedeliveryConfig())
+
+	ec.Nats = &NatsConfig{Redelivery: &RedeliveryConfig{StormThreshold: 5}}
+	assert.ErrorContains(t, ec.ValidateRedeliveryConfig(), "storm_threshold 5 is never reached by a consumer with max_deliver 3")
+
+	ec.Nats.JetStreamDefaultConfig = &JetStreamConfig{MaxDeliver: 7}
+	assert.NoError(t, ec.ValidateRedeliveryConfig())
+	ec.Nats.JetStreamDefaultConfig.MaxDeliver = 6
+	assert.Error(t, ec.ValidateRedeliveryConfig())
+}
+
+func TestRequeueKey_OutsideRedeliveryCounters(t *testing.T) {
+	md := &jetstream.MsgMetadata{Stream: "WORKER_FLOWS", Consumer: "worker-flow-consumer"}
+	md.Sequence.Stream = 42
+	key := requeueKey(md)
+	assert.Equal(t, "WORKER_FLOWS.42.worker-flow-consumer.requeues", key)
+
+	// The redelivery counters of a message are listed with a single token wildcard, the requeue counter has two
+	prefix := redeliveryKeyPrefix(md.Stream, md.Sequence.Stream) + "."
+	assert.Len(t, strings.Split(strings.TrimPrefix(key, prefix), "."), 2)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// ReplicationRole is the role a region plays in a multi-region deployment
+type ReplicationRole string
+
+const (
+	// ReplicationRolePrimary serves traffic and owns the JetStream streams
+	ReplicationRolePrimary ReplicationRole = "primary"
+
+	// ReplicationRoleStandby mirrors the primary streams and stays passive until promoted
+	ReplicationRoleStandby ReplicationRole = "standby"
+)
+
+const (
+	// ReplicationBucket is the region-local KV bucket holding the replication state
+	ReplicationBucket = "PINAZU_REPLICATION"
+
+	// activeRegionKey is the key of the region currently serving traffic
+	activeRegionKey = "active_region"
+)
+
+// ManagedStreams are the JetStream streams created by the core services and the subjects they capture
+var ManagedStreams = map[string][]string{
+	"FLOWS_STATUS": {FlowRunStatusEventSubject.String(), FlowTaskRunStatusEventSubject.String()},
+	"WORKER_FLOWS": {FlowRunExecuteEventSubject.String(), FlowRunExecuteEventSubject.String() + ".labels.*"}, // With the runs requiring worker labels
+}
+
+// DefaultReplicatedTables are the key tables included in the logical replication publication, the referenced tables
+// before the tables referencing them. The caches and the liveness of the workers are left out.
+var DefaultReplicatedTables = []string{
+	"organizations", "workspaces", "users", "roles", "permissions", "user_role_mapping", "role_permission_mapping",
+	"organization_members", "workspace_members", "api_keys", "user_sessions", "resource_grants", "daily_usage",
+	"agents", "agent_permission_mapping", "agent_probes", "agent_probe_runs", "agent_prompt_cache_usage", "agent_thinking_usage",
+	"tools", "tool_revisions", "flows", "flow_schedules", "flow_schedule_backfills",
+	"threads", "thread_messages", "files", "message_attachments", "message_embeddings",
+	"flow_runs", "tasks", "tasks_runs", "task_schedules", "flow_task_runs", "flow_run_events", "flow_run_graph_tasks",
+	"flow_run_logs", "flow_run_artifacts", "flow_run_costs", "flow_run_queue", "tool_runs", "tool_run_audit", "feedback",
+	"webhooks", "webhook_deliveries", "resource_changes",
+}
+
+// ManagedStreamNames returns the names of the managed streams in a stable order
+func ManagedStreamNames() []string {
+	names := make([]string, 0, len(ManagedStreams))
+	for name := range ManagedStreams {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// roleFor returns the role of the region while activeRegion serves traffic
+func (rc *ReplicationConfig) roleFor(activeRegion string) ReplicationRole {
+	if rc == nil || rc.Region == activeRegion {
+		return ReplicationRolePrimary
+	}
+	return ReplicationRoleStandby
+}
+
+// mirrorSource returns the source of the standby mirror of a stream
+func (rc *ReplicationConfig) mirrorSource(streamName string) *jetstream.StreamSource {
+	source := &jetstream.StreamSource{Name: streamName, Domain: rc.SourceDomain}
+	if rc.SourceAPIPrefix != "" {
+		source.Domain = ""
+		source.External = &jetstream.ExternalStream{APIPrefix: rc.SourceAPIPrefix}
+	}
+	return source
+}
+
+// ApplyReplication adapts a stream configuration to the replication role of the region.
+// Replicated streams use limits retention so the standby can mirror them, and on a standby
+// they are created as mirrors of the primary stream instead of capturing subjects.
+// Changing the retention of an existing work queue stream is refused by JetStream, so the
+// streams must be recreated when replication is enabled on an existing deployment.
+func ApplyReplication(config jetstream.StreamConfig, rc *ReplicationConfig, role ReplicationRole) jetstream.StreamConfig {
+	if rc == nil || !slices.Contains(rc.Streams, config.Name) {
+		return config
+	}
+
+	config.Retention = jetstream.LimitsPolicy
+	if role == ReplicationRoleStandby {
+		config.Subjects = nil
+		config.Mirror = rc.mirrorSource(config.Name)
+	}
+	return config
+}
+
+// replicationKV returns the region-local replication state bucket, creating it when missing
+func (jss *JetStreamService) replicationKV() (jetstream.KeyValue, error) {
+	kv, err := jss.js.CreateOrUpdateKeyValue(jss.ctx, jetstream.KeyValueConfig{
+		Bucket:      ReplicationBucket,
+		Description: "Replication state of the region",
+		Storage:     jetstream.FileStorage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replication bucket: %w", err)
+	}
+	return kv, nil
+}
+
+// ActiveRegion returns the region currently serving traffic.
+// It defaults to the configured primary region until a failover is recorded.
+func (jss *JetStreamService) ActiveRegion(rc *ReplicationConfig) (string, error) {
+	if rc == nil {
+		return "", nil
+	}
+	kv, err := jss.replicationKV()
+	if err != nil {
+		return "", err
+	}
+	entry, err := kv.Get(jss.ctx, activeRegionKey)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return rc.PrimaryRegion, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get active region: %w", err)
+	}
+	return string(entry.Value()), nil
+}
+
+// SetActiveRegion records the region serving traffic in the region-local replication bucket
+func (jss *JetStreamService) SetActiveRegion(region string) error {
+	kv, err := jss.replicationKV()
+	if err != nil {
+		return err
+	}
+	if _, err := kv.PutString(jss.ctx, activeRegionKey, region); err != nil {
+		return fmt.Errorf("failed to set active region: %w", err)
+	}
+	jss.logger.Info("Active region updated", "region", region)
+	return nil
+}
+
+// ReplicationRole returns the role of the region. Without replication every region is primary.
+func (jss *JetStreamService) ReplicationRole(rc *ReplicationConfig) (ReplicationRole, error) {
+	if rc == nil {
+		return ReplicationRolePrimary, nil
+	}
+	activeRegion, err := jss.ActiveRegion(rc)
+	if err != nil {
+		return "", err
+	}
+	return rc.roleFor(activeRegion), nil
+}
+
+// WaitForPromotion blocks until the region is recorded as the active region or the context is cancelled
+func (jss *JetStreamService) WaitForPromotion(rc *ReplicationConfig) error {
+	kv, err := jss.replicationKV()
+	if err != nil {
+		return err
+	}
+	watcher, err := kv.Watch(jss.ctx, activeRegionKey)
+	if err != nil {
+		return fmt.Errorf("failed to watch active region: %w", err)
+	}
+	defer watcher.Stop()
+
+	jss.logger.Info("Standby region waiting for promotion", "region", rc.Region, "primary_region", rc.PrimaryRegion)
+	for {
+		select {
+		case <-jss.ctx.Done():
+			return jss.ctx.Err()
+		case entry, ok := <-watcher.Updates():
+			if !ok {
+				return fmt.Errorf("active region watcher closed")
+			}
+			// A nil entry marks the end of the initial values
+			if entry != nil && entry.Operation() == jetstream.KeyValuePut && string(entry.Value()) == rc.Region {
+				jss.logger.Info("Region promoted to primary", "region", rc.Region)
+				return nil
+			}
+		}
+	}
+}
+
+// PromoteStream turns a standby mirror into a regular stream capturing its subjects again.
+// It is a no-op for streams that are not mirrors.
+func (jss *JetStreamService) PromoteStream(streamName string) error {
+	subjects, ok := ManagedStreams[streamName]
+	if !ok {
+		return fmt.Errorf("stream %s is not managed by the core services", streamName)
+	}
+	info, err := jss.GetStreamInfo(streamName)
+	if err != nil {
+		return err
+	}
+	if info.Config.Mirror == nil {
+		jss.logger.Info("Stream is not a mirror, nothing to promote", "name", streamName)
+		return nil
+	}
+
+	config := info.Config
+	config.Mirror = nil
+	config.Subjects = subjects
+	if _, err := jss.js.UpdateStream(jss.ctx, config); err != nil {
+		return fmt.Errorf("failed to promote stream %s (removing a mirror requires nats-server 2.12 or later): %w", streamName, err)
+	}
+	jss.logger.Info("Stream promoted", "name", streamName, "subjects", subjects)
+	return nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyReplication(t *testing.T) {
+	base := CreateStreamConfigWithDefaults("WORKER_FLOWS", ManagedStreams["WORKER_FLOWS"], "Worker stream", nil)
+
+	t.Run("without replication", func(t *testing.T) {
+		config := ApplyReplication(base, nil, ReplicationRolePrimary)
+		assert.Equal(t, base, config)
+	})
+
+	t.Run("primary region", func(t *testing.T) {
+		rc := &ReplicationConfig{Region: "us-east-1", PrimaryRegion: "us-east-1", Streams: []string{"WORKER_FLOWS"}}
+		config := ApplyReplication(base, rc, ReplicationRolePrimary)
+		assert.Equal(t, jetstream.LimitsPolicy, config.Retention)
+		assert.Equal(t, ManagedStreams["WORKER_FLOWS"], config.Subjects)
+		assert.Nil(t, config.Mirror)
+	})
+
+	t.Run("standby region mirrors the primary domain", func(t *testing.T) {
+		rc := &ReplicationConfig{Region: "eu-west-1", PrimaryRegion: "us-east-1", SourceDomain: "us-east-1", Streams: []string{"WORKER_FLOWS"}}
+		config := ApplyReplication(base, rc, ReplicationRoleStandby)
+		assert.Equal(t, jetstream.LimitsPolicy, config.Retention)
+		assert.Nil(t, config.Subjects)
+		require.NotNil(t, config.Mirror)
+		assert.Equal(t, "WORKER_FLOWS", config.Mirror.Name)
+		assert.Equal(t, "us-east-1", config.Mirror.Domain)
+	})
+
+	t.Run("standby region mirrors through an API prefix", func(t *testing.T) {
+		rc := &ReplicationConfig{Region: "eu-west-1", PrimaryRegion: "us-east-1", SourceAPIPrefix: "$JS.primary.API", Streams: []string{"WORKER_FLOWS"}}
+		config := ApplyReplication(base, rc, ReplicationRoleStandby)
+		require.NotNil(t, config.Mirror)
+		assert.Empty(t, config.Mirror.Domain)
+		require.NotNil(t, config.Mirror.External)
+		assert.Equal(t, "$JS.primary.API", config.Mirror.External.APIPrefix)
+	})
+
+	t.Run("stream not replicated", func(t *testing.T) {
+		rc := &ReplicationConfig{Region: "eu-west-1", PrimaryRegion: "us-east-1", SourceDomain: "us-east-1", Streams: []string{"FLOWS_STATUS"}}
+		config := ApplyReplication(base, rc, ReplicationRoleStandby)
+		assert.Equal(t, base, config)
+	})
+}
+
+func TestValidateReplicationConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		rc      *ReplicationConfig
+		wantErr string
+	}{
+		{name: "disabled", rc: nil},
+		{name: "primary region", rc: &ReplicationConfig{Region: "us-east-1", PrimaryRegion: "us-east-1"}},
+		{name: "standby region", rc: &ReplicationConfig{Region: "eu-west-1", PrimaryRegion: "us-east-1", SourceDomain: "us-east-1"}},
+		{name: "missing primary region", rc: &ReplicationConfig{Region: "us-east-1"}, wantErr: "primary_region must be specified"},
+		{name: "standby without source", rc: &ReplicationConfig{Region: "eu-west-1", PrimaryRegion: "us-east-1"}, wantErr: "source_domain or source_api_prefix"},
+		{name: "unknown stream", rc: &ReplicationConfig{Region: "us-east-1", PrimaryRegion: "us-east-1", Streams: []string{"ORDERS"}}, wantErr: "unknown replicated stream: ORDERS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec := &ExternalDependenciesConfig{Replication: tt.rc}
+			err := ec.ValidateReplicationConfig()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGetReplicationConfig_Defaults(t *testing.T) {
+	ec := &ExternalDependenciesConfig{}
+	assert.Nil(t, ec.GetReplicationConfig())
+
+	ec.Replication = &ReplicationConfig{Region: "us-east-1", PrimaryRegion: "us-east-1"}
+	rc := ec.GetReplicationConfig()
+	require.NotNil(t, rc)
+	assert.Equal(t, []string{"FLOWS_STATUS", "WORKER_FLOWS"}, rc.Streams)
+	assert.Equal(t, DefaultReplicatedTables, rc.Tables)
+	assert.Equal(t, "pinazu_replication", rc.Publication)
+	assert.Equal(t, "pinazu_replication", rc.Subscription)
+	assert.Equal(t, ReplicationRolePrimary, rc.roleFor("us-east-1"))
+	assert.Equal(t, ReplicationRoleStandby, rc.roleFor("eu-west-1"))
+}
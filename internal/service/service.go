@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nuid"
+	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/telemetry"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
@@ -147,11 +148,7 @@ func NewService(ctx context.Context, config *Config, natsOptions ...nats.Option)
 	}
 
 	// Connect to NATS server
-	natsURL := "nats://localhost:4222" // default
-	if config.ExternalDependencies.Nats != nil && config.ExternalDependencies.Nats.URL != "" {
-		natsURL = config.ExternalDependencies.Nats.URL
-	}
-	nc, err := nats.Connect(natsURL, natsOptions...)
+	nc, err := nats.Connect(config.ExternalDependencies.GetNatsURL(), natsOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to NATS server: %w", err)
 	}
@@ -162,6 +159,15 @@ func NewService(ctx context.Context, config *Config, natsOptions ...nats.Option)
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Encrypt the secrets stored in the database with the configured keys
+	if sc := config.ExternalDependencies.Secrets; sc != nil && len(sc.Keys) > 0 {
+		wrapper, err := sc.NewKeyWrapper()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load secret keys: %w", err)
+		}
+		db.SetSecretKeyWrapper(wrapper)
+	}
+
 	// Create new OpenTelemetry tracer
 	traceProvider, err := telemetry.InitTracer(ctx, *config.getOpenTelemetryConfig())
 	if err != nil {
@@ -169,6 +175,20 @@ func NewService(ctx context.Context, config *Config, natsOptions ...nats.Option)
 	}
 	tracer := otel.Tracer(config.Name)
 
+	// Serve the metrics of the process, the services started with the same configuration share the endpoint
+	if metrics := config.ExternalDependencies.GetMetricsConfig(); metrics.ListenAddress != "" {
+		if err := telemetry.ServeMetrics(ctx, telemetry.MetricsConfig{ListenAddress: metrics.ListenAddress, Path: metrics.Path}); err != nil {
+			return nil, fmt.Errorf("failed to serve metrics: %w", err)
+		}
+	}
+
+	// Serve the health endpoints of the process, the services started with the same configuration share them
+	if health := config.ExternalDependencies.GetHealthConfig(); health.ListenAddress != "" {
+		if err := ServeHealth(ctx, health.ListenAddress); err != nil {
+			return nil, fmt.Errorf("failed to serve health endpoints: %w", err)
+		}
+	}
+
 	// Create context with cancel
 	serviceCtx, cancel := context.WithCancel(ctx)
 
@@ -191,6 +211,11 @@ func NewService(ctx context.Context, config *Config, natsOptions ...nats.Option)
 		stats:         make(map[string]*SubscriptionStats),
 	}
 
+	// Every instance answers the monitoring requests gathering the info and stats of the cluster
+	svc.RegisterHandler(ServiceInfoSubject, InfoHandler(svc))
+	svc.RegisterHandler(ServiceStatsSubject, StatsHandler(svc))
+	registerHealth(svc)
+
 	return svc, nil
 }
 
@@ -227,10 +252,18 @@ func (s *service) RegisterHandler(subject string, handler nats.MsgHandler) {
 			}
 
 			// Update stats through atomic operations
-			if stat, exists := s.stats[subject]; exists {
+			stat := s.stats[subject]
+			if stat != nil {
 				stat.NumMessages.Add(1)
 			}
 
+			// A failing handler is counted in the stats of its subject rather than stopping the service
+			defer func() {
+				if r := recover(); r != nil {
+					s.recordError(subject, stat, fmt.Errorf("handler panic: %v", r))
+				}
+			}()
+
 			// Handle the message
 			handler(msg)
 		}()
@@ -250,6 +283,23 @@ func (s *service) RegisterHandler(subject string, handler nats.MsgHandler) {
 	s.subscriptions = append(s.subscriptions, sub)
 }
 
+// recordError counts an error of the handler of a subject and reports it to the error handler
+func (s *service) recordError(subject string, stat *SubscriptionStats, err error) {
+	if stat != nil {
+		stat.NumErrors.Add(1)
+		s.mu.Lock()
+		stat.LastError = err.Error()
+		s.mu.Unlock()
+	}
+	if s.ErrorHandler != nil {
+		s.ErrorHandler(s, &NATSError{
+			Subject:     subject,
+			Description: err.Error(),
+			err:         err,
+		})
+	}
+}
+
 // Shutdown drains all subscriptions, stops all workers and marks the service as stopped.
 func (s *service) Shutdown() error {
 	s.mu.Lock()
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nuid"
@@ -1026,6 +1027,62 @@ func TestNatsConfig_URL(t *testing.T) {
 	assert.Equal(t, "nats://localhost:4222", cfg.URL)
 }
 
+func TestGetDefaultAgentID(t *testing.T) {
+	id, err := (&ExternalDependenciesConfig{}).GetDefaultAgentID()
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, id)
+
+	agentID := uuid.New()
+	id, err = (&ExternalDependenciesConfig{Http: &HttpServerConfig{DefaultAgentID: agentID.String()}}).GetDefaultAgentID()
+	assert.NoError(t, err)
+	assert.Equal(t, agentID, id)
+
+	_, err = (&ExternalDependenciesConfig{Http: &HttpServerConfig{DefaultAgentID: "not-a-uuid"}}).GetDefaultAgentID()
+	assert.Error(t, err)
+}
+
+func TestGetFlowWorkersConfig_Defaults(t *testing.T) {
+	cfg := (&ExternalDependenciesConfig{}).GetFlowWorkersConfig()
+	assert.False(t, cfg.Disabled)
+	assert.Equal(t, 15, cfg.PollIntervalSeconds)
+	assert.Equal(t, 60, cfg.HeartbeatTimeoutSeconds)
+	assert.Equal(t, 24, cfg.RetentionHours)
+
+	cfg = (&ExternalDependenciesConfig{Flows: &FlowsConfig{Workers: &FlowWorkersConfig{HeartbeatTimeoutSeconds: 30}}}).GetFlowWorkersConfig()
+	assert.Equal(t, 30, cfg.HeartbeatTimeoutSeconds)
+	assert.Equal(t, 15, cfg.PollIntervalSeconds)
+
+	// The worker registers with its hostname unless named
+	hostname, _ := os.Hostname()
+	heartbeat := (&ExternalDependenciesConfig{}).GetWorkerHeartbeatConfig()
+	assert.Equal(t, 10, heartbeat.IntervalSeconds)
+	assert.Equal(t, hostname, heartbeat.Name)
+	heartbeat = (&ExternalDependenciesConfig{Worker: &WorkerConfig{Heartbeat: &WorkerHeartbeatConfig{Name: "edge-1"}}}).GetWorkerHeartbeatConfig()
+	assert.Equal(t, "edge-1", heartbeat.Name)
+}
+
+func TestGetCORSConfig(t *testing.T) {
+	cfg, err := (&ExternalDependenciesConfig{}).GetCORSConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"*"}, cfg.AllowedOrigins)
+
+	cfg, err = (&ExternalDependenciesConfig{Http: &HttpServerConfig{CORS: &CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowCredentials: true,
+	}}}).GetCORSConfig()
+	assert.NoError(t, err)
+	assert.True(t, cfg.AllowCredentials)
+
+	// Any origin is rejected with credentials, explicitly or by default
+	for _, origins := range [][]string{{"*"}, {"https://app.example.com", "*"}, nil} {
+		_, err = (&ExternalDependenciesConfig{Http: &HttpServerConfig{CORS: &CORSConfig{
+			AllowedOrigins:   origins,
+			AllowCredentials: true,
+		}}}).GetCORSConfig()
+		assert.Error(t, err, "origins %v", origins)
+	}
+}
+
 // =============================================================================
 // Property-Based Tests
 // =============================================================================
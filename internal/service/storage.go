@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// NewS3Client creates an S3 client from the storage configuration.
+// It supports static credentials, assume role and the default credential chain, and custom endpoints for MinIO/S3-compatible services.
+func NewS3Client(ctx context.Context, s3Config *S3Config) (*s3.Client, error) {
+	if s3Config == nil {
+		return nil, fmt.Errorf("S3 configuration is required")
+	}
+
+	// Create AWS config with appropriate credential provider and endpoint
+	var configOptions []func(*config.LoadOptions) error
+
+	// Add region
+	configOptions = append(configOptions, config.WithRegion(s3Config.Region))
+
+	// Configure credentials based on type
+	switch s3Config.CredentialType {
+	case "static":
+		if s3Config.AccessKeyID == "" || s3Config.SecretAccessKey == "" {
+			return nil, fmt.Errorf("access_key_id and secret_access_key required for static credentials")
+		}
+		configOptions = append(configOptions, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(
+				s3Config.AccessKeyID,
+				s3Config.SecretAccessKey,
+				"", // token (empty for basic access keys)
+			),
+		))
+	case "assume_role":
+		if s3Config.AssumeRoleARN == "" {
+			return nil, fmt.Errorf("assume_role_arn required for assume_role credentials")
+		}
+		// Load default config first to get base credentials for assume role
+		baseCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(s3Config.Region))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load base AWS config for assume role: %w", err)
+		}
+
+		// Create STS client and assume role credentials
+		stsClient := sts.NewFromConfig(baseCfg)
+		sessionName := s3Config.AssumeRoleSession
+		if sessionName == "" {
+			sessionName = "pinazu-worker-session"
+		}
+
+		assumeRoleCreds := stscreds.NewAssumeRoleProvider(stsClient, s3Config.AssumeRoleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = sessionName
+		})
+
+		configOptions = append(configOptions, config.WithCredentialsProvider(assumeRoleCreds))
+	case "default", "":
+		// Use default credential chain (environment, instance profile, etc.)
+		// No additional credential provider needed
+	default:
+		return nil, fmt.Errorf("unsupported credential_type: %s (supported: static, assume_role, default)", s3Config.CredentialType)
+	}
+
+	// Configure custom endpoint if provided (for MinIO/S3-compatible services)
+	if s3Config.EndpointURL != "" {
+		configOptions = append(configOptions, config.WithBaseEndpoint(s3Config.EndpointURL))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, configOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	// Create S3 client with path-style configuration
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = s3Config.UsePathStyle
+	}), nil
+}
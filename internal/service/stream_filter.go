@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// StreamEventClass groups the events streamed to the WebSocket and Server-Sent Events clients
+type StreamEventClass string
+
+const (
+	// StreamEventClassText covers the text content blocks and their deltas
+	StreamEventClassText StreamEventClass = "text"
+	// StreamEventClassThinking covers the thinking content blocks and their deltas
+	StreamEventClassThinking StreamEventClass = "thinking"
+	// StreamEventClassTool covers the tool use and tool result content blocks, and the deltas of the tool inputs
+	StreamEventClassTool StreamEventClass = "tool"
+	// StreamEventClassMessage covers the start, stop and usage events of the model messages
+	StreamEventClassMessage StreamEventClass = "message"
+	// StreamEventClassLifecycle covers the task and sub task lifecycle events
+	StreamEventClassLifecycle StreamEventClass = "lifecycle"
+)
+
+// StreamEventClasses lists every stream event class
+var StreamEventClasses = []StreamEventClass{
+	StreamEventClassText,
+	StreamEventClassThinking,
+	StreamEventClassTool,
+	StreamEventClassMessage,
+	StreamEventClassLifecycle,
+}
+
+// StreamEventFilter selects the stream events sent to a client, by class. Error events are always sent.
+// The content_block_stop events only carry the index of their block, so the filter remembers the class
+// of each block started, it is used for a single stream of events.
+type StreamEventFilter struct {
+	mu      sync.Mutex
+	classes map[StreamEventClass]bool // nil sends every event
+	blocks  map[int64]StreamEventClass
+}
+
+// ParseStreamEventFilter parses a comma-separated list of stream event classes, e.g. "text,lifecycle".
+// A class prefixed with "-" is excluded, e.g. "-thinking" sends every event but the thinking ones.
+// An empty list sends every event.
+func ParseStreamEventFilter(s string) (*StreamEventFilter, error) {
+	f := &StreamEventFilter{blocks: make(map[int64]StreamEventClass)}
+	if strings.TrimSpace(s) == "" {
+		return f, nil
+	}
+
+	included := make(map[StreamEventClass]bool)
+	excluded := make(map[StreamEventClass]bool)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		name, exclude := strings.CutPrefix(item, "-")
+		class := StreamEventClass(name)
+		if !class.Valid() {
+			return nil, fmt.Errorf("unknown stream event class %q, must be one of %v", name, StreamEventClasses)
+		}
+		if exclude {
+			excluded[class] = true
+		} else {
+			included[class] = true
+		}
+	}
+
+	// Only exclusions start from every class
+	if len(included) == 0 {
+		for _, class := range StreamEventClasses {
+			included[class] = true
+		}
+	}
+	for class := range excluded {
+		delete(included, class)
+	}
+	f.classes = included
+	return f, nil
+}
+
+// Valid reports whether the class is a known stream event class
+func (c StreamEventClass) Valid() bool {
+	for _, class := range StreamEventClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the events of a class are sent, such as the task lifecycle events
+func (f *StreamEventFilter) Allows(class StreamEventClass) bool {
+	return f == nil || f.classes == nil || f.classes[class]
+}
+
+// AllowResponse reports whether a model response event is sent
+func (f *StreamEventFilter) AllowResponse(msg *WebsocketResponseEventMessage) bool {
+	if f == nil || f.classes == nil || msg == nil {
+		return true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch msg.Type {
+	case "content_block_start":
+		class := contentBlockClass(msg.ContentBlock.Type)
+		f.blocks[msg.Index] = class
+		return f.classes[class]
+	case "content_block_delta":
+		return f.classes[contentDeltaClass(msg.Delta.Type)]
+	case "content_block_stop":
+		class, ok := f.blocks[msg.Index]
+		if !ok {
+			return true
+		}
+		delete(f.blocks, msg.Index)
+		return f.classes[class]
+	case "message_start":
+		// The block indexes start over with every message
+		clear(f.blocks)
+		return f.classes[StreamEventClassMessage]
+	default:
+		return f.classes[StreamEventClassMessage]
+	}
+}
+
+// contentBlockClass returns the class of a content block by its type
+func contentBlockClass(blockType string) StreamEventClass {
+	switch blockType {
+	case "text":
+		return StreamEventClassText
+	case "thinking", "redacted_thinking":
+		return StreamEventClassThinking
+	default:
+		// tool_use, server_tool_use and the server tool results
+		return StreamEventClassTool
+	}
+}
+
+// contentDeltaClass returns the class of a content block delta by its type
+func contentDeltaClass(deltaType string) StreamEventClass {
+	switch deltaType {
+	case "text_delta", "citations_delta":
+		return StreamEventClassText
+	case "thinking_delta", "signature_delta":
+		return StreamEventClassThinking
+	default:
+		return StreamEventClassTool
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStreamEvents() []*WebsocketResponseEventMessage {
+	return []*WebsocketResponseEventMessage{
+		{Type: "message_start"},
+		{Type: "content_block_start", Index: 0, ContentBlock: anthropic.ContentBlockStartEventContentBlockUnion{Type: "thinking"}},
+		{Type: "content_block_delta", Index: 0, Delta: anthropic.MessageStreamEventUnionDelta{Type: "thinking_delta"}},
+		{Type: "content_block_stop", Index: 0},
+		{Type: "content_block_start", Index: 1, ContentBlock: anthropic.ContentBlockStartEventContentBlockUnion{Type: "text"}},
+		{Type: "content_block_delta", Index: 1, Delta: anthropic.MessageStreamEventUnionDelta{Type: "text_delta"}},
+		{Type: "content_block_stop", Index: 1},
+		{Type: "content_block_start", Index: 2, ContentBlock: anthropic.ContentBlockStartEventContentBlockUnion{Type: "tool_use"}},
+		{Type: "content_block_delta", Index: 2, Delta: anthropic.MessageStreamEventUnionDelta{Type: "input_json_delta"}},
+		{Type: "content_block_stop", Index: 2},
+		{Type: "message_delta"},
+		{Type: "message_stop"},
+	}
+}
+
+func TestStreamEventFilter_AllowResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		events string
+		want   []string
+	}{
+		{
+			name:   "every event when omitted",
+			events: "",
+			want: []string{
+				"message_start", "content_block_start", "content_block_delta", "content_block_stop",
+				"content_block_start", "content_block_delta", "content_block_stop",
+				"content_block_start", "content_block_delta", "content_block_stop",
+				"message_delta", "message_stop",
+			},
+		},
+		{
+			name:   "text only",
+			events: "text",
+			want:   []string{"content_block_start", "content_block_delta", "content_block_stop"},
+		},
+		{
+			name:   "no thinking",
+			events: "-thinking",
+			want: []string{
+				"message_start",
+				"content_block_start", "content_block_delta", "content_block_stop",
+				"content_block_start", "content_block_delta", "content_block_stop",
+				"message_delta", "message_stop",
+			},
+		},
+		{
+			name:   "lifecycle only",
+			events: "lifecycle",
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseStreamEventFilter(tt.events)
+			require.NoError(t, err)
+
+			got := []string{}
+			for _, event := range testStreamEvents() {
+				if filter.AllowResponse(event) {
+					got = append(got, event.Type)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseStreamEventFilter(t *testing.T) {
+	filter, err := ParseStreamEventFilter(" Text, lifecycle ,")
+	require.NoError(t, err)
+	assert.True(t, filter.Allows(StreamEventClassText))
+	assert.True(t, filter.Allows(StreamEventClassLifecycle))
+	assert.False(t, filter.Allows(StreamEventClassThinking))
+
+	filter, err = ParseStreamEventFilter("tool,-tool")
+	require.NoError(t, err)
+	assert.False(t, filter.Allows(StreamEventClassTool))
+
+	_, err = ParseStreamEventFilter("text,audio")
+	assert.ErrorContains(t, err, `unknown stream event class "audio"`)
+
+	var unset *StreamEventFilter
+	assert.True(t, unset.Allows(StreamEventClassThinking))
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// TaskRunEventsStream holds the Server-Sent Events of the task runs, a client reattaching to a task run reads the
+	// events it missed from there
+	TaskRunEventsStream = "TASK_RUN_EVENTS"
+
+	// TaskRunEventsSubject is the prefix of the subjects of the task run events, followed by the task run ID
+	TaskRunEventsSubject = "v1.buffer.taskrun"
+
+	// taskRunEventsEndHeader marks the last message of the events of a task run, it has no event
+	taskRunEventsEndHeader = "Pinazu-Task-Run-End"
+
+	// The events are kept for the clients reattaching after the end of the run, the oldest events are dropped first
+	taskRunEventsMaxBytes = 256 << 20
+	taskRunEventsMaxAge   = time.Hour
+)
+
+type (
+	// TaskRunEventWriter buffers the Server-Sent Events of a task run, each write is a single event.
+	// Closing the writer marks the end of the events.
+	TaskRunEventWriter struct {
+		jss     *JetStreamService
+		subject string
+		mu      sync.Mutex
+		closed  bool
+	}
+
+	// taskRunEventReader reads the events of a task run with their ID, waiting for the next events until their end
+	taskRunEventReader struct {
+		messages jetstream.MessagesContext
+		pending  []byte
+	}
+)
+
+// taskRunEventsStreamConfig returns the configuration of the task run events stream
+func taskRunEventsStreamConfig() jetstream.StreamConfig {
+	return jetstream.StreamConfig{
+		Name:        TaskRunEventsStream,
+		Subjects:    []string{TaskRunEventsSubject + ".>"},
+		Description: "Server-Sent Events of the task runs, replayed to the clients reattaching to a run",
+		Storage:     jetstream.FileStorage,
+		Retention:   jetstream.LimitsPolicy,
+		Discard:     jetstream.DiscardOld,
+		MaxBytes:    taskRunEventsMaxBytes,
+		MaxAge:      taskRunEventsMaxAge,
+	}
+}
+
+// taskRunEventsSubject returns the subject of the events of a task run
+func taskRunEventsSubject(taskRunID uuid.UUID) string {
+	return TaskRunEventsSubject + "." + taskRunID.String()
+}
+
+// TaskRunEvents returns the writer of the events of a task run
+func (jss *JetStreamService) TaskRunEvents(taskRunID uuid.UUID) (*TaskRunEventWriter, error) {
+	if _, err := jss.js.CreateOrUpdateStream(jss.ctx, taskRunEventsStreamConfig()); err != nil {
+		return nil, fmt.Errorf("failed to create task run events stream: %w", err)
+	}
+	return &TaskRunEventWriter{jss: jss, subject: taskRunEventsSubject(taskRunID)}, nil
+}
+
+// Write buffers an event of the task run
+func (w *TaskRunEventWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if _, err := w.jss.js.Publish(w.jss.ctx, w.subject, p); err != nil {
+		return 0, fmt.Errorf("failed to buffer task run event: %w", err)
+	}
+	return len(p), nil
+}
+
+// Close marks the end of the events of the task run, the readers receive io.EOF once they have read every event
+func (w *TaskRunEventWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	msg := nats.NewMsg(w.subject)
+	msg.Header.Set(taskRunEventsEndHeader, "true")
+	if _, err := w.jss.js.PublishMsg(w.jss.ctx, msg); err != nil {
+		return fmt.Errorf("failed to end task run events: %w", err)
+	}
+	return nil
+}
+
+// HasTaskRunEvents reports whether events of a task run are buffered
+func (jss *JetStreamService) HasTaskRunEvents(ctx context.Context, taskRunID uuid.UUID) (bool, error) {
+	stream, err := jss.js.CreateOrUpdateStream(ctx, taskRunEventsStreamConfig())
+	if err != nil {
+		return false, fmt.Errorf("failed to create task run events stream: %w", err)
+	}
+	if _, err := stream.GetLastMsgForSubject(ctx, taskRunEventsSubject(taskRunID)); err != nil {
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get task run events: %w", err)
+	}
+	return true, nil
+}
+
+// ReadTaskRunEvents returns a reader of the events of a task run following the event with the last event ID, from the
+// first event when it is 0. Each event is preceded by its ID, the Last-Event-ID of a client reattaching to the run.
+// The reader stops when ctx is done.
+func (jss *JetStreamService) ReadTaskRunEvents(ctx context.Context, taskRunID uuid.UUID, lastEventID uint64) (io.Reader, error) {
+	config := jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{taskRunEventsSubject(taskRunID)},
+		DeliverPolicy:  jetstream.DeliverAllPolicy,
+	}
+	if lastEventID > 0 {
+		config.DeliverPolicy, config.OptStartSeq = jetstream.DeliverByStartSequencePolicy, lastEventID+1
+	}
+	consumer, err := jss.js.OrderedConsumer(ctx, TaskRunEventsStream, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task run events: %w", err)
+	}
+	messages, err := consumer.Messages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task run events: %w", err)
+	}
+	context.AfterFunc(ctx, messages.Stop)
+	return &taskRunEventReader{messages: messages}, nil
+}
+
+func (r *taskRunEventReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		msg, err := r.messages.Next()
+		if err != nil {
+			if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+				return 0, io.EOF
+			}
+			return 0, err
+		}
+		if msg.Headers().Get(taskRunEventsEndHeader) != "" {
+			r.messages.Stop()
+			return 0, io.EOF
+		}
+		meta, err := msg.Metadata()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get task run event ID: %w", err)
+		}
+		r.pending = append([]byte("id: "+strconv.FormatUint(meta.Sequence.Stream, 10)+"\n"), msg.Data()...)
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskRunEventsStreamConfig(t *testing.T) {
+	config := taskRunEventsStreamConfig()
+	subject := taskRunEventsSubject(uuid.MustParse("8f14e45f-ceea-4672-a5ff-7b1e2f1c3a9d"))
+
+	assert.Equal(t, TaskRunEventsStream, config.Name)
+	assert.Equal(t, "v1.buffer.taskrun.8f14e45f-ceea-4672-a5ff-7b1e2f1c3a9d", subject)
+	assert.True(t, strings.HasPrefix(subject, strings.TrimSuffix(config.Subjects[0], ">")))
+	// The events stay readable by every client reattaching to a run, they are not consumed
+	assert.Equal(t, jetstream.LimitsPolicy, config.Retention)
+	assert.Equal(t, jetstream.DiscardOld, config.Discard)
+	assert.Equal(t, taskRunEventsMaxAge, config.MaxAge)
+}
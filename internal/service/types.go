@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
 )
 
 //go:generate go run ../../api/generate_event.go
@@ -46,6 +47,9 @@ type (
 		ThreadID     *uuid.UUID `json:"thread_id,omitempty"`
 		TaskID       *string    `json:"task_id,omitempty"`
 		ConnectionID *uuid.UUID `json:"connection_id,omitempty"`
+		RequestID    string     `json:"request_id,omitempty"` // Request of the WebSocket client that started the task, echoed in its events
+		DryRun       bool       `json:"dry_run,omitempty"`    // Tools return their mock response instead of being invoked
+		WorkspaceID  uuid.UUID  `json:"workspace_id"`         // Workspace the event acts in, uuid.Nil for the default workspace
 	}
 
 	EventError struct {
@@ -71,6 +75,14 @@ func WrapError(err error) *EventError {
 	}
 }
 
+// Workspace returns the workspace the event acts in, the default workspace for the events sent before the workspaces
+func (h *EventHeaders) Workspace() uuid.UUID {
+	if h == nil || h.WorkspaceID == uuid.Nil {
+		return db.DefaultWorkspaceID
+	}
+	return h.WorkspaceID
+}
+
 // NewEvent creates a new event with the given message, headers, and metadata
 func NewEvent[T EventMessage](msg T, headers *EventHeaders, metadata *EventMetadata) *Event[T] {
 	return &Event[T]{
@@ -36,3 +36,13 @@ func Test_NewEventErrorEvent(t *testing.T) {
 	_, err := event.toByte()
 	assert.NoError(t, err, "Failed to convert event to byte")
 }
+
+func Test_FlowRunExecuteEventMessage_Subject(t *testing.T) {
+	msg := &FlowRunExecuteEventMessage{}
+	assert.Equal(t, FlowRunExecuteEventSubject, msg.Subject(), "Runs without labels go to every worker")
+
+	msg.RequiredLabels = []string{"python3.12", "gpu"}
+	subject := msg.Subject().String()
+	assert.Equal(t, FlowRunExecuteLabelsSubject([]string{"gpu", "python3.12"}).String(), subject)
+	assert.Regexp(t, `^v1\.svc\.worker\.flow\.execute\.labels\.[0-9a-f]{16}$`, subject, "The labels are a single subject token")
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/pinazu/internal/db"
+)
+
+// Built-in presets of the webhook payload templates
+const (
+	WebhookPresetSlack = "slack" // Slack incoming webhook message with Block Kit blocks
+	WebhookPresetTeams = "teams" // Microsoft Teams workflow message with an Adaptive Card
+)
+
+// Headers of the webhook requests signed with a secret
+const (
+	WebhookTimestampHeader = "X-Pinazu-Timestamp" // Unix time of the request, in seconds
+	WebhookSignatureHeader = "X-Pinazu-Signature" // sha256= followed by the hex HMAC-SHA256 of "<timestamp>.<body>"
+	WebhookEventIDHeader   = "X-Pinazu-Event-Id"  // ID of the event of a webhook subscription delivery, the same for its retries
+	WebhookEventTypeHeader = "X-Pinazu-Event"     // Type of the event of a webhook subscription delivery
+)
+
+// ErrPrivateWebhookAddress is returned when a webhook request resolves to a loopback, private or link-local address
+var ErrPrivateWebhookAddress = errors.New("sending webhooks to private network addresses is not allowed")
+
+// NewWebhookClient creates the HTTP client posting the payloads of the webhooks set by the users. Unless private
+// networks are allowed, connections to loopback, private and link-local addresses are refused after DNS resolution. The
+// redirects are not followed, their response fails the request, so a webhook cannot send the payloads to another address.
+func NewWebhookClient(allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || db.IsPrivateNetworkAddress(ip) {
+				return ErrPrivateWebhookAddress
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// redeliveryAlertPresets are the payload templates of the built-in presets of the redelivery alerts
+var redeliveryAlertPresets = map[string]string{
+	WebhookPresetSlack: `{
+  "text": {{ printf ":rotating_light: Message parked after %d redeliveries on %s" .Redeliveries .Stream | json }},
+  "blocks": [
+    {"type": "header", "text": {"type": "plain_text", "text": "Redelivery storm detected"}},
+    {"type": "section", "fields": [
+      {"type": "mrkdwn", "text": {{ printf "*Stream*\n%s #%d" .Stream .StreamSequence | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Subject*\n%s" .Subject | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Consumer*\n%s" .Consumer | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Redeliveries*\n%d" .Redeliveries | json }}}
+    ]},
+    {"type": "context", "elements": [
+      {"type": "mrkdwn", "text": {{ printf "Parked at %s as %s" (formatTime .ParkedAt) .Reason | json }}}
+    ]}
+  ]
+}`,
+	WebhookPresetTeams: `{
+  "type": "message",
+  "attachments": [{
+    "contentType": "application/vnd.microsoft.card.adaptive",
+    "content": {
+      "$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+      "type": "AdaptiveCard",
+      "version": "1.4",
+      "body": [
+        {"type": "TextBlock", "text": "Redelivery storm detected", "weight": "Bolder", "size": "Medium", "color": "Attention"},
+        {"type": "TextBlock", "text": {{ printf "A message was parked after %d redeliveries." .Redeliveries | json }}, "wrap": true},
+        {"type": "FactSet", "facts": [
+          {"title": "Stream", "value": {{ printf "%s #%d" .Stream .StreamSequence | json }}},
+          {"title": "Subject", "value": {{ json .Subject }}},
+          {"title": "Consumer", "value": {{ json .Consumer }}},
+          {"title": "Parked at", "value": {{ formatTime .ParkedAt | json }}}
+        ]}
+      ]
+    }
+  }]
+}`,
+}
+
+// webhookTemplateFuncs are the helpers available to the webhook payload templates
+var webhookTemplateFuncs = template.FuncMap{
+	// json encodes a value, quoting and escaping strings so they can be placed in the JSON payload
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// formatTime formats a time as RFC 3339 in UTC
+	"formatTime": func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	},
+	// truncate shortens a string to n characters, ending with an ellipsis when shortened
+	"truncate": func(n int, s string) string {
+		r := []rune(s)
+		if len(r) <= n {
+			return s
+		}
+		if n <= 1 {
+			return string(r[:n])
+		}
+		return string(r[:n-1]) + "…"
+	},
+	// default returns def when value is empty
+	"default": func(def, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// newWebhookTemplate parses the payload template of a webhook, a custom template takes precedence over the preset.
+// It returns nil when neither is set, the webhook then receives the raw JSON of the event.
+func newWebhookTemplate(presets map[string]string, preset, text string) (*template.Template, error) {
+	if text == "" && preset != "" {
+		var ok bool
+		if text, ok = presets[preset]; !ok {
+			names := make([]string, 0, len(presets))
+			for name := range presets {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			return nil, fmt.Errorf("unknown webhook preset %q, must be one of %q", preset, names)
+		}
+	}
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("webhook").Funcs(webhookTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderWebhookPayload renders the payload of a webhook, it must be a JSON document
+func renderWebhookPayload(tmpl *template.Template, data any) ([]byte, error) {
+	if tmpl == nil {
+		return json.Marshal(data)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("webhook template rendered invalid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// SignWebhookPayload returns the signature of a webhook payload sent at timestamp, keyed by the secret. The receiver
+// recomputes it from the timestamp header and the raw body to authenticate the request.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookStatusError is returned when the webhook answers with an error status
+type WebhookStatusError struct {
+	StatusCode int
+}
+
+func (e *WebhookStatusError) Error() string {
+	return fmt.Sprintf("webhook returned status %d", e.StatusCode)
+}
+
+// Retryable reports whether the request may succeed when sent again, after a server error, a timeout or a rate limit
+func (e *WebhookStatusError) Retryable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusTooManyRequests
+}
+
+// WebhookAlert posts alerts as JSON to a webhook, such as a Slack or Teams channel
+type WebhookAlert struct {
+	url     string
+	timeout time.Duration
+	tmpl    *template.Template
+	secret  string            // Signs the payloads when set
+	headers map[string]string // Sent with every payload
+	client  *http.Client      // Posts the payloads, the default client when nil
+}
+
+// NewWebhookAlert creates a webhook alert rendering its payload with the custom template text, or else the preset
+// picked from presets. Without either the webhook receives the raw JSON of the alert.
+func NewWebhookAlert(url string, presets map[string]string, preset, text string, timeout time.Duration) (*WebhookAlert, error) {
+	tmpl, err := newWebhookTemplate(presets, preset, text)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookAlert{url: url, timeout: timeout, tmpl: tmpl}, nil
+}
+
+// WithSigningSecret signs the payloads posted to the webhook with the secret
+func (w *WebhookAlert) WithSigningSecret(secret string) *WebhookAlert {
+	w.secret = secret
+	return w
+}
+
+// WithClient posts the payloads with the client, such as a client refusing some addresses
+func (w *WebhookAlert) WithClient(client *http.Client) *WebhookAlert {
+	w.client = client
+	return w
+}
+
+// WithHeader sends a header with the payloads posted to the webhook
+func (w *WebhookAlert) WithHeader(key, value string) *WebhookAlert {
+	if w.headers == nil {
+		w.headers = map[string]string{}
+	}
+	w.headers[key] = value
+	return w
+}
+
+// Send renders the alert with data and posts it to the webhook
+func (w *WebhookAlert) Send(ctx context.Context, data any) error {
+	body, err := renderWebhookPayload(w.tmpl, data)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+	if w.secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(w.secret, timestamp, body))
+	}
+
+	client := w.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return &WebhookStatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRedeliveryAlert() redeliveryAlert {
+	return redeliveryAlert{Event: RedeliveryStormReason, ParkedMessage: &ParkedMessage{
+		Stream:         "WORKER_FLOWS",
+		StreamSequence: 42,
+		Subject:        `v1.flows.run "quoted"`,
+		Consumer:       "worker-flow-consumer",
+		Redeliveries:   7,
+		Reason:         RedeliveryStormReason,
+		ParkedAt:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+}
+
+func TestRenderWebhookPayload_Presets(t *testing.T) {
+	for _, preset := range []string{WebhookPresetSlack, WebhookPresetTeams} {
+		t.Run(preset, func(t *testing.T) {
+			tmpl, err := newWebhookTemplate(redeliveryAlertPresets, preset, "")
+			require.NoError(t, err)
+			body, err := renderWebhookPayload(tmpl, testRedeliveryAlert())
+			require.NoError(t, err)
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(body, &payload))
+			assert.Contains(t, string(body), `v1.flows.run \"quoted\"`)
+			assert.Contains(t, string(body), "2025-01-02T03:04:05Z")
+		})
+	}
+}
+
+func TestRenderWebhookPayload_Custom(t *testing.T) {
+	// A custom template takes precedence over the preset
+	tmpl, err := newWebhookTemplate(redeliveryAlertPresets, WebhookPresetSlack, `{"text": {{ printf "%s %s" (upper .Stream) (truncate 6 .Consumer) | json }}, "event": {{ default "none" .Event | json }}}`)
+	require.NoError(t, err)
+	body, err := renderWebhookPayload(tmpl, testRedeliveryAlert())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "WORKER_FLOWS worke…", "event": "redelivery_storm"}`, string(body))
+
+	tmpl, err = newWebhookTemplate(redeliveryAlertPresets, "", `{"text": {{ .Stream }}}`)
+	require.NoError(t, err)
+	_, err = renderWebhookPayload(tmpl, testRedeliveryAlert())
+	assert.ErrorContains(t, err, "invalid JSON")
+
+	_, err = newWebhookTemplate(redeliveryAlertPresets, "discord", "")
+	assert.ErrorContains(t, err, "unknown webhook preset")
+
+	// Without a template the webhook receives the raw JSON of the event
+	tmpl, err = newWebhookTemplate(redeliveryAlertPresets, "", "")
+	require.NoError(t, err)
+	assert.Nil(t, tmpl)
+	body, err = renderWebhookPayload(tmpl, testRedeliveryAlert())
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"event":"redelivery_storm"`)
+}
+
+func TestWebhookAlert_Send(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	alert, err := NewWebhookAlert(server.URL, redeliveryAlertPresets, "", `{"stream": {{ json .Stream }}}`, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, alert.Send(context.Background(), testRedeliveryAlert()))
+	assert.JSONEq(t, `{"stream": "WORKER_FLOWS"}`, string(received))
+
+	alert, err = NewWebhookAlert(server.URL+"/fail", redeliveryAlertPresets, "", "", time.Second)
+	require.NoError(t, err)
+	assert.ErrorContains(t, alert.Send(context.Background(), testRedeliveryAlert()), "status 400")
+
+	_, err = NewWebhookAlert(server.URL, redeliveryAlertPresets, "discord", "", time.Second)
+	assert.ErrorContains(t, err, `must be one of ["slack" "teams"]`)
+}
+
+func TestWebhookAlert_SendSigned(t *testing.T) {
+	var timestamp, signature, eventType string
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp = r.Header.Get(WebhookTimestampHeader)
+		signature = r.Header.Get(WebhookSignatureHeader)
+		eventType = r.Header.Get(WebhookEventTypeHeader)
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	alert, err := NewWebhookAlert(server.URL, redeliveryAlertPresets, "", "", time.Second)
+	require.NoError(t, err)
+	err = alert.WithSigningSecret("s3cret").WithHeader(WebhookEventTypeHeader, "task.finished").Send(context.Background(), testRedeliveryAlert())
+
+	var statusErr *WebhookStatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.True(t, statusErr.Retryable())
+	assert.False(t, (&WebhookStatusError{StatusCode: http.StatusBadRequest}).Retryable())
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, SignWebhookPayload("s3cret", unix, received), signature)
+	assert.NotEqual(t, SignWebhookPayload("other", unix, received), signature)
+	assert.Equal(t, "task.finished", eventType)
+}
@@ -1,10 +1,19 @@
 package tasks
 
 import (
+	"encoding/json"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/service"
 )
 
+// taskCancelledMessage is the error result of the tool runs still in flight when their task run was cancelled
+const taskCancelledMessage = "The task run was cancelled"
+
 // cancelEventCallback handles the task cancel event callback
 func (ts *TaskService) cancelEventCallback(msg *nats.Msg) {
 	// Check if context was cancelled
@@ -28,4 +37,154 @@ func (ts *TaskService) cancelEventCallback(msg *nats.Msg) {
 		"connection_id", req.H.ConnectionID,
 		"user_id", req.H.UserID,
 	)
+	if req.H.TaskID == nil {
+		return
+	}
+
+	// A cancelled sub task gives the cancellation to the calling agent, its own sub tasks are ended with it
+	queries := db.New(ts.s.GetDB())
+	task, err := queries.GetTaskById(ts.ctx, *req.H.TaskID)
+	if err != nil {
+		ts.log.Error("Failed to get cancelled task", "task_id", *req.H.TaskID, "error", err)
+		return
+	}
+	if task.ParentTaskID.Valid {
+		ts.failSubAgentRun(queries, task, req.M, "The invoked agent run was cancelled")
+		return
+	}
+
+	// The API cancels the run before sending the event, the run of a task cancelled by another service is cancelled here
+	if _, err := queries.CompleteTaskRunByTaskID(ts.ctx, db.CompleteTaskRunByTaskIDParams{
+		TaskID: task.ID,
+		Status: db.TaskRunStatusCancelled,
+	}); err != nil {
+		ts.log.Error("Failed to mark task run as CANCELLED", "task_id", task.ID, "error", err)
+		return
+	}
+	// The queued runs of the task are not started, the run started last is the cancelled one
+	taskRun, err := queries.GetLastStartedTaskRunByTaskID(ts.ctx, task.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		ts.log.Error("Failed to get cancelled task run", "task_id", task.ID, "error", err)
+		return
+	}
+	if err == pgx.ErrNoRows || taskRun.Status != db.TaskRunStatusCancelled {
+		ts.log.Info("Task run already ended, nothing to cancel", "task_id", task.ID)
+		return
+	}
+	ts.stopAgentStreams(req.H, task.ThreadID, task.ID, req.M)
+	ts.endSubAgentRuns(queries, req.H, task.ThreadID, task.ID, req.M)
+
+	// The results of the tool runs still in flight are dropped once they arrive
+	toolRunIDs, err := queries.CancelThreadToolRuns(ts.ctx, db.CancelThreadToolRunsParams{
+		Result:   subAgentErrorContent(taskCancelledMessage),
+		ThreadID: task.ThreadID,
+		Since:    taskRun.CreatedAt,
+	})
+	if err != nil {
+		ts.log.Error("Failed to cancel the tool runs of the task run", "task_id", task.ID, "error", err)
+	} else if len(toolRunIDs) > 0 {
+		ts.log.Info("Cancelled tool runs of the task run", "task_id", task.ID, "tool_run_ids", toolRunIDs)
+		if err := queries.CancelToolRunAudit(ts.ctx, toolRunIDs); err != nil {
+			ts.log.Error("Failed to complete the audit entries of the cancelled tool runs", "task_id", task.ID, "error", err)
+		}
+	}
+	ts.answerPendingToolUses(queries, task)
+
+	taskStopEvent := service.NewEvent(&service.WebsocketTaskLifecycleEventMessage{
+		Type:     "task_stop",
+		ThreadId: task.ThreadID,
+		TaskId:   task.ID,
+		Reason:   "cancelled",
+	}, &service.EventHeaders{
+		UserID:       req.H.UserID,
+		WorkspaceID:  req.H.WorkspaceID,
+		ThreadID:     &task.ThreadID,
+		TaskID:       &task.ID,
+		ConnectionID: req.H.ConnectionID,
+		RequestID:    req.H.RequestID,
+		DryRun:       req.H.DryRun,
+	}, req.M)
+	if err := taskStopEvent.PublishWithUser(ts.s.GetNATS(), req.H.UserID); err != nil {
+		ts.log.Error("Failed to publish task stop event", "task_id", task.ID, "error", err)
+	}
+	ts.log.Info("Task cancelled", "task_id", task.ID, "task_run_id", taskRun.TaskRunID)
+
+	// The next queued run of the task starts now
+	ts.startNextTaskRun(queries, task.ID)
+}
+
+// stopAgentStreams stops the model requests in flight of a cancelled or failed task, its agent does not answer
+func (ts *TaskService) stopAgentStreams(header *service.EventHeaders, threadID uuid.UUID, taskID string, meta *service.EventMetadata) {
+	event := service.NewEvent(&service.AgentStreamCancelEventMessage{}, &service.EventHeaders{
+		UserID:       header.UserID,
+		WorkspaceID:  header.WorkspaceID,
+		ThreadID:     &threadID,
+		TaskID:       &taskID,
+		ConnectionID: header.ConnectionID,
+		DryRun:       header.DryRun,
+	}, meta)
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("Failed to publish agent stream cancel event", "task_id", taskID, "error", err)
+	}
+}
+
+// answerPendingToolUses gives an error result to the tool uses of the last message of the thread of a cancelled task,
+// the model rejects a conversation with tool uses left without result when the thread is executed again
+func (ts *TaskService) answerPendingToolUses(queries *db.Queries, task db.Task) {
+	history, err := queries.GetMessageHistory(ts.ctx, task.ThreadID)
+	if err != nil {
+		ts.log.Error("Failed to get messages of the cancelled task thread", "thread_id", task.ThreadID, "error", err)
+		return
+	}
+	var last *db.ThreadMessage
+	for i := len(history) - 1; i >= 0; i-- {
+		if !history[i].SupersededAt.Valid {
+			last = &history[i]
+			break
+		}
+	}
+	if last == nil || last.StopReason.String != "tool_use" {
+		return
+	}
+
+	var message anthropic.MessageParam
+	if err := json.Unmarshal(last.Message, &message); err != nil {
+		ts.log.Error("Failed to parse the tool use message of the cancelled task", "message_id", last.ID, "error", err)
+		return
+	}
+	results := anthropic.MessageParam{Role: anthropic.MessageParamRoleUser}
+	for _, block := range message.Content {
+		if block.OfToolUse == nil {
+			continue
+		}
+		results.Content = append(results.Content, anthropic.NewToolResultBlock(block.OfToolUse.ID, taskCancelledMessage, true))
+	}
+	if len(results.Content) == 0 {
+		return
+	}
+	content, err := db.NewJsonRaw(results)
+	if err != nil {
+		ts.log.Error("Failed to create the cancelled tool results", "error", err)
+		return
+	}
+
+	// The results are sent by the recipient of the agent, as the results of the tool runs
+	params := db.CreateUserMessageParams{
+		ThreadID:    task.ThreadID,
+		Message:     content,
+		SenderID:    last.RecipientID,
+		RecipientID: last.SenderID,
+	}
+	if _, err := queries.CreateUserMessage(ts.ctx, params); err != nil {
+		if !db.IsUnavailable(err) {
+			ts.log.Error("Failed to add the cancelled tool results to the thread", "thread_id", task.ThreadID, "error", err)
+			return
+		}
+		// Keep the results to write them once the database is back
+		if bufErr := ts.js.BufferMessage(&service.BufferedMessage{User: &params}); bufErr != nil {
+			ts.log.Error("Failed to buffer the cancelled tool results while the database is unavailable", "error", bufErr)
+			return
+		}
+		ts.log.Warn("Database unavailable, cancelled tool results buffered for replay", "thread_id", task.ThreadID, "error", err)
+	}
 }
@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/nats-io/nats.go"
 	"github.com/pinazu/internal/db"
@@ -42,14 +44,23 @@ func (ts *TaskService) executeEventCallback(msg *nats.Msg) {
 	)
 	ts.log.Info("Execute message", "messages", req.Msg.Messages)
 
+	// Check if this is a new task (TaskID is nil) before processing
+	isNewTask := req.H.TaskID == nil
+
+	// The messages from the WebSocket, the task schedules and the probes start a new task, within the quotas of the user
+	if isNewTask {
+		if err := db.New(ts.s.GetDB()).CheckQuotas(ts.ctx, ts.quotas, req.H.Workspace(), req.H.UserID, db.QuotaTokensPerDay, db.QuotaConcurrentTasks); err != nil {
+			ts.log.Warn("Task not started, quota check failed", "agent_id", req.Msg.AgentId, "user_id", req.H.UserID, "error", err)
+			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
+			return
+		}
+	}
+
 	// Ensure thread exists (create if needed)
 	if err := ts.ensureThreadExists(req); err != nil {
 		return
 	}
 
-	// Check if this is a new task (TaskID is nil) before processing
-	isNewTask := req.H.TaskID == nil
-
 	// Process message operations sequentially, task operations concurrently
 	senderRecipientMessages, err := ts.processMessageOperations(req)
 	if err != nil {
@@ -99,22 +110,43 @@ func (ts *TaskService) executeEventCallback(msg *nats.Msg) {
 	)
 }
 
-// ensureThreadExists creates a new thread if one doesn't exist in the request
+// ensureThreadExists creates a new thread if one doesn't exist in the request, and checks that the user of the request
+// owns the thread of the request or was granted the INVOKE access on it
 func (ts *TaskService) ensureThreadExists(req *service.Event[*service.TaskExecuteEventMessage]) error {
+	// Get database queries
+	queries := db.New(ts.s.GetDB())
+
 	if req.H.ThreadID != nil {
+		thread, err := queries.GetThreadByID(ts.ctx, db.GetThreadByIDParams{WorkspaceID: req.H.Workspace(), UserID: req.H.UserID, ID: *req.H.ThreadID})
+		if err == nil {
+			var access db.GrantAccess
+			access, err = queries.ResourceAccess(ts.ctx, db.ResourceTypeThread, thread.ID, thread.UserID, req.H.UserID)
+			if err == nil && !access.Allows(db.GrantAccessInvoke) {
+				err = fmt.Errorf("the %s access on the thread %s is required", db.GrantAccessInvoke, thread.ID)
+			}
+		}
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				err = fmt.Errorf("thread %s not found", *req.H.ThreadID)
+			}
+			ts.log.Error("Failed to get thread of task execute event", "thread_id", *req.H.ThreadID, "user_id", req.H.UserID, "error", err)
+			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
+			return err
+		}
 		return nil
 	}
 
-	// Get database queries
-	queries := db.New(ts.s.GetDB())
-
 	ts.log.Info("ThreadId is nil, creating new thread")
+	// The title is static: no model call titles, summarizes or compacts the threads in the background, and
+	// thread_context.summary is never written. A summarization strategy configurable per workspace is to come
+	// with the first of these features, which gives it a caller and a configuration to extend.
 	now := time.Now()
 	thread, err := queries.CreateThread(ts.ctx, db.CreateThreadParams{
-		Title:     "Thread_" + req.H.UserID.String() + req.H.ConnectionID.String(),
-		UserID:    req.H.UserID,
-		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-		UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Title:       "Thread_" + req.H.UserID.String() + req.H.ConnectionID.String(),
+		UserID:      req.H.UserID,
+		CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		WorkspaceID: req.H.Workspace(),
 	})
 	if err != nil {
 		ts.log.Error("Failed to create new thread", "error", err)
@@ -128,6 +160,16 @@ func (ts *TaskService) ensureThreadExists(req *service.Event[*service.TaskExecut
 	return nil
 }
 
+// userMessageParams returns the parameters writing a message of the execute event to the thread
+func userMessageParams(req *service.Event[*service.TaskExecuteEventMessage], message db.JsonRaw) db.CreateUserMessageParams {
+	return db.CreateUserMessageParams{
+		ThreadID:    *req.H.ThreadID,
+		Message:     message,
+		SenderID:    req.Msg.RecipientId, // User is sender
+		RecipientID: req.Msg.AgentId,     // Agent is recipient
+	}
+}
+
 // processMessageOperations handles message operations sequentially and task operations concurrently
 func (ts *TaskService) processMessageOperations(req *service.Event[*service.TaskExecuteEventMessage]) ([]db.JsonRaw, error) {
 	queries := db.New(ts.s.GetDB())
@@ -142,13 +184,18 @@ func (ts *TaskService) processMessageOperations(req *service.Event[*service.Task
 	// Handle messages sequentially to avoid race condition
 	// 1. Insert new user messages FIRST
 	for i, message := range req.Msg.Messages {
-		_, err := queries.CreateUserMessage(ts.ctx, db.CreateUserMessageParams{
-			ThreadID:    *req.H.ThreadID,
-			Message:     message,
-			SenderID:    req.Msg.RecipientId, // User is sender
-			RecipientID: req.Msg.AgentId,     // Agent is recipient
-		})
+		_, err := queries.CreateUserMessage(ts.ctx, userMessageParams(req, message))
 		if err != nil {
+			// Keep the messages to write them once the database is back, the agent cannot run without its history
+			if db.IsUnavailable(err) {
+				for _, pending := range req.Msg.Messages[i:] {
+					params := userMessageParams(req, pending)
+					if bufErr := ts.js.BufferMessage(&service.BufferedMessage{User: &params}); bufErr != nil {
+						ts.log.Error("Failed to buffer message while the database is unavailable", "error", bufErr)
+						break
+					}
+				}
+			}
 			return nil, fmt.Errorf("failed to insert message %d: %w", i, err)
 		}
 	}
@@ -176,8 +223,24 @@ func (ts *TaskService) processMessageOperations(req *service.Event[*service.Task
 		}
 	}
 
-	ts.log.Info("Message operations completed", "message_count", len(messages))
-	return messages, nil
+	contents, err := ts.messageContents(queries, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.log.Info("Message operations completed", "message_count", len(contents))
+	return contents, nil
+}
+
+// messageContents returns the contents of the messages given to an agent, with their attached files added as image
+// or document content blocks
+func (ts *TaskService) messageContents(queries *db.Queries, messages []db.GetSenderRecipientMessagesRow) ([]db.JsonRaw, error) {
+	ids := make([]uuid.UUID, len(messages))
+	contents := make([]db.JsonRaw, len(messages))
+	for i, message := range messages {
+		ids[i], contents[i] = message.ID, message.Message
+	}
+	return ts.files.ExpandAttachments(ts.ctx, queries, ids, contents)
 }
 
 // manageTaskRuns handles task and task run creation/management
@@ -215,6 +278,13 @@ func (ts *TaskService) manageTaskRuns(req *service.Event[*service.TaskExecuteEve
 		go func() {
 			defer wg.Done()
 			taskRun, err = queries.GetCurrentTaskRunByTaskID(ts.ctx, *req.H.TaskID)
+			if err == pgx.ErrNoRows {
+				// The last run of the task ended, the new messages start a new run of the task
+				taskRun, err = queries.CreateTasksRun(ts.ctx, *req.H.TaskID)
+				if err == nil {
+					ts.log.Info("Created new task run", "task_run_id", taskRun.TaskRunID, "task_id", *req.H.TaskID)
+				}
+			}
 			if err != nil {
 				errChan <- fmt.Errorf("failed to get task runs: %w", err)
 				return
@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -45,17 +46,25 @@ func (ts *TaskService) finishEventCallback(msg *nats.Msg) {
 	queries := db.New(ts.s.GetDB())
 
 	// Create each new message into the database
-	_, err = queries.CreateAgentMessage(ts.ctx, db.CreateAgentMessageParams{
+	messageParams := db.CreateAgentMessageParams{
 		ThreadID:    *req.H.ThreadID,
 		Message:     req.Msg.Response,
 		StopReason:  pgtype.Text{String: "end_turn", Valid: true},
 		SenderID:    req.Msg.AgentId,
 		Citations:   req.Msg.Citations,
 		RecipientID: req.Msg.RecipientId,
-	})
+	}
+	_, err = queries.CreateAgentMessage(ts.ctx, messageParams)
 	if err != nil {
-		// Check if this is a foreign key constraint violation (thread was deleted)
-		if strings.Contains(err.Error(), "fk_thread_message") || strings.Contains(err.Error(), "foreign key constraint") {
+		// Keep the response of the agent to write it once the database is back
+		if db.IsUnavailable(err) {
+			if bufErr := ts.js.BufferMessage(&service.BufferedMessage{Agent: &messageParams}); bufErr != nil {
+				ts.log.Error("Failed to buffer completion message while the database is unavailable", "error", bufErr)
+				return
+			}
+			ts.log.Warn("Database unavailable, completion message buffered for replay", "thread_id", *req.H.ThreadID, "error", err)
+		} else if strings.Contains(err.Error(), "fk_thread_message") || strings.Contains(err.Error(), "foreign key constraint") {
+			// Check if this is a foreign key constraint violation (thread was deleted)
 			ts.log.Warn("Cannot create completion message: thread was deleted", "thread_id", *req.H.ThreadID, "error", err)
 			// Continue processing to ensure proper SSE stream closure
 		} else {
@@ -87,8 +96,8 @@ func (ts *TaskService) finishEventCallback(msg *nats.Msg) {
 	}
 
 	if !taskInfo.ParentTaskID.Valid {
-		// If not sub task, update task status to FINISHED
-		err = queries.UpdateTaskRunStatusByTaskID(ts.ctx, db.UpdateTaskRunStatusByTaskIDParams{
+		// If not sub task, update task status to FINISHED. A cancelled run already sent its stop event.
+		ended, err := queries.CompleteTaskRunByTaskID(ts.ctx, db.CompleteTaskRunByTaskIDParams{
 			TaskID: *req.H.TaskID,
 			Status: db.TaskRunStatusFinished,
 		})
@@ -97,6 +106,10 @@ func (ts *TaskService) finishEventCallback(msg *nats.Msg) {
 			service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
 			return
 		}
+		if ended == 0 {
+			ts.log.Info("Main task run already ended, skipping the task stop event", "task_id", *req.H.TaskID)
+			return
+		}
 		ts.log.Info("Main task marked as FINISHED", "task_id", *req.H.TaskID)
 
 		// Send stop event
@@ -110,12 +123,30 @@ func (ts *TaskService) finishEventCallback(msg *nats.Msg) {
 		if err != nil {
 			ts.log.Error("Failed to publish task stop event", "error", err)
 			service.NewErrorEvent[*service.WebsocketTaskLifecycleEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
-			return
 		}
 		ts.log.Info("Task finished")
+
+		// The next queued run of the task starts now
+		ts.startNextTaskRun(queries, *req.H.TaskID)
 		return // End here if not sub task
 	}
 
+	// Claim the sub task run first, a run which already ended has given its result to the calling agent
+	ended, err := queries.CompleteTaskRunByTaskID(ts.ctx, db.CompleteTaskRunByTaskIDParams{
+		TaskID: *req.H.TaskID,
+		Status: db.TaskRunStatusFinished,
+	})
+	if err != nil {
+		ts.log.Error("Failed to update sub task run status to FINISHED", "error", err)
+		service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
+		return
+	}
+	if ended == 0 {
+		ts.log.Warn("Sub task run already ended, dropping the late sub-agent response", "sub_task_id", *req.H.TaskID)
+		return
+	}
+	ts.log.Info("Sub task marked as FINISHED", "sub_task_id", *req.H.TaskID)
+
 	// If sub task, send stop sub start event
 	taskLifecycleMsg := &service.WebsocketTaskLifecycleEventMessage{
 		Type:     "sub_task_stop",
@@ -127,7 +158,6 @@ func (ts *TaskService) finishEventCallback(msg *nats.Msg) {
 	if err != nil {
 		ts.log.Error("Failed to publish task stop event", "error", err)
 		service.NewErrorEvent[*service.WebsocketTaskLifecycleEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
-		return
 	}
 
 	// Set the parent task run status back to RUNNING now that sub-agent is complete
@@ -138,28 +168,18 @@ func (ts *TaskService) finishEventCallback(msg *nats.Msg) {
 	if err != nil {
 		ts.log.Error("Failed to update parent task run status back to RUNNING", "error", err)
 		service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
-		return
 	}
 	ts.log.Info("Set parent task run status back to RUNNING after sub-agent completion", "parent_task_id", taskInfo.ParentTaskID.String, "sub_task_id", *req.H.TaskID)
 
-	// Update sub task status to FINISHED since it completed successfully
-	err = queries.UpdateTaskRunStatusByTaskID(ts.ctx, db.UpdateTaskRunStatusByTaskIDParams{
-		TaskID: *req.H.TaskID,
-		Status: db.TaskRunStatusFinished,
-	})
-	if err != nil {
-		ts.log.Error("Failed to update sub task run status to FINISHED", "error", err)
-		service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
-		return
-	}
-	ts.log.Info("Sub task marked as FINISHED", "sub_task_id", *req.H.TaskID)
-
 	// Create a new header with an old task id
 	oldTaskIDHeader := &service.EventHeaders{
 		UserID:       req.H.UserID,
+		WorkspaceID:  req.H.WorkspaceID,
 		ThreadID:     req.H.ThreadID,
 		ConnectionID: req.H.ConnectionID,
 		TaskID:       &taskInfo.ParentTaskID.String,
+		RequestID:    req.H.RequestID,
+		DryRun:       req.H.DryRun,
 	}
 
 	// Publish messages to tool handler
@@ -177,25 +197,45 @@ func (ts *TaskService) finishEventCallback(msg *nats.Msg) {
 	}
 }
 
+// errorEventCallback marks the run of a failed task as FAILED, a failed sub task gives the error to the calling agent
 func (ts *TaskService) errorEventCallback(req *service.Event[*service.TaskFinishEventMessage]) {
+	if req.H == nil || req.H.TaskID == nil {
+		ts.log.Error("Task error event without task")
+		return
+	}
+
 	// Get the database queries
 	queries := db.New(ts.s.GetDB())
 
-	// Get the task
-	taskRun, err := queries.GetTaskRunByTaskID(ts.ctx, *req.H.TaskID)
+	task, err := queries.GetTaskById(ts.ctx, *req.H.TaskID)
 	if err != nil {
 		ts.log.Error("Failed to get task by ID", "error", err)
 		return
 	}
+	if task.ParentTaskID.Valid {
+		message := "The invoked agent failed"
+		if req.Err != nil && req.Err.Error != "" {
+			message = fmt.Sprintf("The invoked agent failed: %s", req.Err.Error)
+		}
+		ts.failSubAgentRun(queries, task, req.M, message)
+		return
+	}
 
-	// Save the updated task
-	if err := queries.UpdateTaskRunStatus(ts.ctx, db.UpdateTaskRunStatusParams{
-		Status:    db.TaskRunStatusFailed,
-		TaskRunID: taskRun[0].TaskRunID,
-	}); err != nil {
+	// Fail the started run of the task, its queued runs are left to start
+	ended, err := queries.CompleteTaskRunByTaskID(ts.ctx, db.CompleteTaskRunByTaskIDParams{
+		TaskID: *req.H.TaskID,
+		Status: db.TaskRunStatusFailed,
+	})
+	if err != nil {
 		ts.log.Error("Failed to update task", "error", err)
 		return
 	}
+	if ended == 0 {
+		ts.log.Debug("Task run already ended", "task_id", *req.H.TaskID)
+		return
+	}
+	ts.endSubAgentRuns(queries, req.H, *req.H.ThreadID, *req.H.TaskID, req.M)
 
 	ts.log.Debug("Task marked as failed", "task_id", *req.H.TaskID)
+	ts.startNextTaskRun(queries, *req.H.TaskID)
 }
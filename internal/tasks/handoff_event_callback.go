@@ -1,6 +1,7 @@
 package tasks
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
@@ -41,17 +42,43 @@ func (ts *TaskService) handoffEventCallback(msg *nats.Msg) {
 	)
 	ts.log.Info("Execute message", "messages", req.Msg.Messages)
 
+	// The sub-agent answers a tool run of a task of the thread
+	if req.H.TaskID == nil || req.H.ThreadID == nil {
+		ts.log.Error("Task handoff event without task or thread", "tool_run_id", req.Msg.ToolRunId)
+		return
+	}
+
 	// Ensure the agent_id is exist
 	queries := db.New(ts.s.GetDB())
-	_, err = queries.GetAgentByID(ts.ctx, req.Msg.AgentID)
+	_, err = queries.GetAgentByID(ts.ctx, db.GetAgentByIDParams{WorkspaceID: req.H.Workspace(), ID: req.Msg.AgentID})
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			ts.log.Error("Agent not found", "agent_id", req.Msg.AgentID)
-			// Send error result back to the tool handler
+			ts.publishSubAgentResult(req.H, req.Msg.ToolRunId, subAgentErrorContent("The calling agent was not found"), true, req.M)
 			return
 		}
 		ts.log.Error("Failed to get agent by ID", "error", err)
-		// Send error result back to the tool handler
+		ts.publishSubAgentResult(req.H, req.Msg.ToolRunId, subAgentErrorContent("Failed to get the calling agent"), true, req.M)
+		return
+	}
+	if _, err = queries.GetAgentByID(ts.ctx, db.GetAgentByIDParams{WorkspaceID: req.H.Workspace(), ID: req.Msg.AgentHandoffToID}); err != nil {
+		ts.log.Error("Failed to get invoked agent", "agent_handoff_to_id", req.Msg.AgentHandoffToID, "error", err)
+		ts.publishSubAgentResult(req.H, req.Msg.ToolRunId, subAgentErrorContent(fmt.Sprintf("The agent %s was not found", req.Msg.AgentHandoffToID)), true, req.M)
+		return
+	}
+
+	// Keep what is needed to answer the calling agent when the sub-agent does not
+	additionalInfo, err := json.Marshal(subAgentTaskInfo{InvokeAgent: &subAgentRun{
+		AgentID:        req.Msg.AgentHandoffToID,
+		ParentAgentID:  req.Msg.AgentID,
+		ConnectionID:   req.H.ConnectionID,
+		WorkspaceID:    req.H.WorkspaceID,
+		DryRun:         req.H.DryRun,
+		TimeoutSeconds: req.Msg.TimeoutSeconds,
+	}})
+	if err != nil {
+		ts.log.Error("Failed to marshal sub task info", "error", err)
+		ts.publishSubAgentResult(req.H, req.Msg.ToolRunId, subAgentErrorContent("Failed to create the sub-agent task"), true, req.M)
 		return
 	}
 
@@ -59,21 +86,25 @@ func (ts *TaskService) handoffEventCallback(msg *nats.Msg) {
 	handoffTask, err := queries.CreateTaskWithID(ts.ctx, db.CreateTaskWithIDParams{
 		ID:             req.Msg.ToolRunId,
 		ThreadID:       *req.H.ThreadID,
-		MaxRequestLoop: 20,           // Default max loops
-		AdditionalInfo: []byte("{}"), // Empty JSON
+		MaxRequestLoop: 20, // Default max loops
+		AdditionalInfo: additionalInfo,
 		CreatedBy:      req.H.UserID,
 		ParentTaskID:   pgtype.Text{String: *req.H.TaskID, Valid: true},
 	})
 	if err != nil {
 		ts.log.Error("Failed to create sub task", "error", err)
-		// Send error result back to the tool handler
+		ts.publishSubAgentResult(req.H, req.Msg.ToolRunId, subAgentErrorContent("Failed to create the sub-agent task"), true, req.M)
 		return
 	}
 
-	// Create task_run for the sub-agent task
-	_, err = queries.CreateTasksRun(ts.ctx, handoffTask.ID)
+	// Create task_run for the sub-agent task, with the deadline of its answer
+	_, err = queries.CreateSubTasksRun(ts.ctx, db.CreateSubTasksRunParams{
+		TaskID:         handoffTask.ID,
+		TimeoutSeconds: int32(req.Msg.TimeoutSeconds),
+	})
 	if err != nil {
 		ts.log.Error("Failed to create task run for sub task", "error", err)
+		ts.publishSubAgentResult(req.H, req.Msg.ToolRunId, subAgentErrorContent("Failed to create the sub-agent task"), true, req.M)
 		return
 	}
 
@@ -84,6 +115,7 @@ func (ts *TaskService) handoffEventCallback(msg *nats.Msg) {
 	})
 	if err != nil {
 		ts.log.Error("Failed to update parent task run status to PENDING", "error", err)
+		ts.failSubAgentRun(queries, handoffTask, req.M, "Failed to start the sub-agent task")
 		return
 	}
 	ts.log.Info("Set parent task run status to PENDING while waiting for sub-agent", "parent_task_id", *req.H.TaskID, "sub_task_id", handoffTask.ID)
@@ -97,8 +129,8 @@ func (ts *TaskService) handoffEventCallback(msg *nats.Msg) {
 			RecipientID: req.Msg.AgentHandoffToID, // Handoff To Agent is recipient
 		})
 		if err != nil {
-			ts.log.Error("Failed to insert handoffs message %d: %w", i, err)
-			// Send error result back to the tool handler
+			ts.log.Error("Failed to insert handoffs message", "index", i, "error", err)
+			ts.failSubAgentRun(queries, handoffTask, req.M, "Failed to give the messages to the invoked agent")
 			return
 		}
 	}
@@ -110,17 +142,26 @@ func (ts *TaskService) handoffEventCallback(msg *nats.Msg) {
 		RecipientID: req.Msg.AgentHandoffToID,
 	})
 	if err != nil {
-		ts.log.Error("Failed to get sender-recipient messages: %w", err)
-		// Send error result back to the tool handler
+		ts.log.Error("Failed to get sender-recipient messages", "error", err)
+		ts.failSubAgentRun(queries, handoffTask, req.M, "Failed to give the messages to the invoked agent")
+		return
+	}
+	contents, err := ts.messageContents(queries, messages)
+	if err != nil {
+		ts.log.Error("Failed to add the attachments to the messages", "error", err)
+		ts.failSubAgentRun(queries, handoffTask, req.M, "Failed to give the messages to the invoked agent")
 		return
 	}
 
 	// Create a new header for handoffs task
 	newHandoffTaskHeader := &service.EventHeaders{
 		UserID:       req.H.UserID,
+		WorkspaceID:  req.H.WorkspaceID,
 		ThreadID:     req.H.ThreadID,
 		TaskID:       &handoffTask.ID,
 		ConnectionID: req.H.ConnectionID,
+		RequestID:    req.H.RequestID,
+		DryRun:       req.H.DryRun,
 	}
 
 	// Send sub task start event for new sub task
@@ -134,7 +175,7 @@ func (ts *TaskService) handoffEventCallback(msg *nats.Msg) {
 	if err != nil {
 		ts.log.Error("Failed to publish task start event", "error", err)
 		service.NewErrorEvent[*service.WebsocketTaskLifecycleEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
-		// Send error result back to the tool handler
+		ts.failSubAgentRun(queries, handoffTask, req.M, "Failed to start the sub-agent task")
 		return
 	}
 	ts.log.Info("Published task_start event for new task", "task_id", *req.H.TaskID)
@@ -143,13 +184,14 @@ func (ts *TaskService) handoffEventCallback(msg *nats.Msg) {
 	ts.log.Info("Publishing messages to agent", "agent_id", req.Msg.AgentHandoffToID)
 	invokeEvent := service.NewEvent(&service.AgentInvokeEventMessage{
 		AgentId:     req.Msg.AgentHandoffToID,
-		Messages:    messages,
+		Messages:    contents,
 		RecipientId: req.Msg.AgentID, // The user recieved this message is the parent agent
 	}, newHandoffTaskHeader, req.M)
 	err = invokeEvent.Publish(ts.s.GetNATS())
 	if err != nil {
 		ts.log.Error("Failed to publish agent invoke event", "error", err)
 		service.NewErrorEvent[*service.WebsocketResponseEventMessage](req.H, req.M, err).PublishWithUser(ts.s.GetNATS(), req.H.UserID)
+		ts.failSubAgentRun(queries, handoffTask, req.M, "Failed to invoke the agent")
 		return
 	}
 
@@ -0,0 +1,358 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// probeResponseMaxLength truncates the final responses recorded with the probe runs
+	probeResponseMaxLength = 4096
+
+	// Events of the probe alerts
+	probeAlertFailing   = "probe_failing"
+	probeAlertRecovered = "probe_recovered"
+)
+
+// probeAlertPresets are the payload templates of the built-in presets of the probe alerts
+var probeAlertPresets = map[string]string{
+	service.WebhookPresetSlack: `{
+  "text": {{ if eq .Event "probe_failing" }}{{ printf ":rotating_light: Probe %s of agent %s is failing" .ProbeName .AgentID | json }}{{ else }}{{ printf ":white_check_mark: Probe %s of agent %s recovered" .ProbeName .AgentID | json }}{{ end }},
+  "blocks": [
+    {"type": "header", "text": {"type": "plain_text", "text": {{ if eq .Event "probe_failing" }}"Agent probe failing"{{ else }}"Agent probe recovered"{{ end }}}},
+    {"type": "section", "fields": [
+      {"type": "mrkdwn", "text": {{ printf "*Probe*\n%s" .ProbeName | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Agent*\n%s" .AgentID | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Status*\n%s" .Status | json }}},
+      {"type": "mrkdwn", "text": {{ printf "*Latency*\n%d ms" .LatencyMs | json }}}
+    ]},
+    {"type": "context", "elements": [
+      {"type": "mrkdwn", "text": {{ printf "Ran at %s %s" (formatTime .RunAt) (truncate 200 .Error) | json }}}
+    ]}
+  ]
+}`,
+	service.WebhookPresetTeams: `{
+  "type": "message",
+  "attachments": [{
+    "contentType": "application/vnd.microsoft.card.adaptive",
+    "content": {
+      "$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+      "type": "AdaptiveCard",
+      "version": "1.4",
+      "body": [
+        {{ if eq .Event "probe_failing" }}{"type": "TextBlock", "text": "Agent probe failing", "weight": "Bolder", "size": "Medium", "color": "Attention"}{{ else }}{"type": "TextBlock", "text": "Agent probe recovered", "weight": "Bolder", "size": "Medium", "color": "Good"}{{ end }},
+        {"type": "FactSet", "facts": [
+          {"title": "Probe", "value": {{ json .ProbeName }}},
+          {"title": "Agent", "value": {{ printf "%s" .AgentID | json }}},
+          {"title": "Status", "value": {{ printf "%s" .Status | json }}},
+          {"title": "Latency", "value": {{ printf "%d ms" .LatencyMs | json }}},
+          {"title": "Ran at", "value": {{ formatTime .RunAt | json }}}
+        ]},
+        {"type": "TextBlock", "text": {{ default "-" (truncate 500 .Error) | json }}, "wrap": true}
+      ]
+    }
+  }]
+}`,
+}
+
+type (
+	// probeResult is the outcome of a single run of an agent probe
+	probeResult struct {
+		Status    db.AgentProbeRunStatus
+		LatencyMs int32
+		Response  string
+		Error     string
+		ThreadID  *uuid.UUID
+	}
+
+	// probeAlert is the body posted to the alert webhook when a probe starts failing or recovers
+	probeAlert struct {
+		Event     string                 `json:"event"`
+		ProbeID   uuid.UUID              `json:"probe_id"`
+		ProbeName string                 `json:"probe_name"`
+		AgentID   uuid.UUID              `json:"agent_id"`
+		Status    db.AgentProbeRunStatus `json:"status"`
+		LatencyMs int32                  `json:"latency_ms"`
+		Error     string                 `json:"error,omitempty"`
+		Response  string                 `json:"response,omitempty"`
+		ThreadID  *uuid.UUID             `json:"thread_id,omitempty"`
+		RunAt     time.Time              `json:"run_at"`
+	}
+
+	// probeEvent is the part of the events sent to the user needed to follow a probe conversation
+	probeEvent struct {
+		H   *service.EventHeaders `json:"header"`
+		Msg json.RawMessage       `json:"message"`
+		Err *service.EventError   `json:"error,omitempty"`
+	}
+)
+
+// runProbeScheduler runs the agent probes due for a run until the service stops
+func (ts *TaskService) runProbeScheduler(cfg *service.ProbesConfig) {
+	var alert *service.WebhookAlert
+	if cfg.AlertWebhookURL != "" {
+		var err error
+		alert, err = service.NewWebhookAlert(cfg.AlertWebhookURL, probeAlertPresets, cfg.AlertPreset, cfg.AlertTemplate, time.Duration(cfg.AlertTimeoutSeconds)*time.Second)
+		if err != nil {
+			ts.log.Error("Invalid probe alert template, alerts will not be sent", "error", err)
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+			ts.runDueProbes(cfg, alert)
+		}
+	}
+}
+
+// runDueProbes claims the probes due for a run and waits for their runs to finish
+func (ts *TaskService) runDueProbes(cfg *service.ProbesConfig, alert *service.WebhookAlert) {
+	queries := db.New(ts.s.GetDB())
+	probes, err := queries.ClaimDueAgentProbes(ts.ctx, int32(cfg.MaxConcurrentRuns))
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			ts.log.Error("Failed to claim due agent probes", "error", err)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, probe := range probes {
+		wg.Add(1)
+		go func(probe db.AgentProbe) {
+			defer wg.Done()
+			ts.runProbe(queries, probe, alert)
+		}(probe)
+	}
+	wg.Wait()
+}
+
+// runProbe runs a probe, records the run and alerts when the probe starts failing or recovers
+func (ts *TaskService) runProbe(queries *db.Queries, probe db.AgentProbe, alert *service.WebhookAlert) {
+	runAt := time.Now()
+	result := ts.executeProbe(queries, probe)
+
+	runParams := db.CreateAgentProbeRunParams{
+		ProbeID:   probe.ID,
+		Status:    result.Status,
+		LatencyMs: result.LatencyMs,
+		Response:  pgtype.Text{String: result.Response, Valid: result.Response != ""},
+		Error:     pgtype.Text{String: result.Error, Valid: result.Error != ""},
+	}
+	if result.ThreadID != nil {
+		runParams.ThreadID = pgtype.UUID{Bytes: *result.ThreadID, Valid: true}
+	}
+	if _, err := queries.CreateAgentProbeRun(ts.ctx, runParams); err != nil {
+		ts.log.Error("Failed to record agent probe run", "probe_id", probe.ID, "error", err)
+	}
+
+	status := db.AgentProbeStatusFailing
+	if result.Status == db.AgentProbeRunStatusPassed {
+		status = db.AgentProbeStatusPassing
+	}
+	ts.log.Info("Agent probe run", "probe_id", probe.ID, "agent_id", probe.AgentID, "status", result.Status, "latency_ms", result.LatencyMs)
+	if status == probe.Status {
+		return
+	}
+	if err := queries.UpdateAgentProbeStatus(ts.ctx, db.UpdateAgentProbeStatusParams{ID: probe.ID, Status: status}); err != nil {
+		ts.log.Error("Failed to update agent probe status", "probe_id", probe.ID, "error", err)
+	}
+
+	event := probeAlertEvent(probe.Status, status)
+	if event == "" || alert == nil {
+		return
+	}
+	err := alert.Send(ts.ctx, probeAlert{
+		Event:     event,
+		ProbeID:   probe.ID,
+		ProbeName: probe.Name,
+		AgentID:   probe.AgentID,
+		Status:    result.Status,
+		LatencyMs: result.LatencyMs,
+		Error:     result.Error,
+		Response:  result.Response,
+		ThreadID:  result.ThreadID,
+		RunAt:     runAt,
+	})
+	if err != nil {
+		ts.log.Error("Failed to send agent probe alert", "probe_id", probe.ID, "error", err)
+		return
+	}
+	ts.log.Info("Agent probe alert sent", "probe_id", probe.ID, "event", event)
+}
+
+// probeAlertEvent returns the alert raised by a change of the probe status, empty when the change raises none.
+// A probe passing on its first run raises no alert.
+func probeAlertEvent(from, to db.AgentProbeStatus) string {
+	switch {
+	case to == db.AgentProbeStatusFailing && from != db.AgentProbeStatusFailing:
+		return probeAlertFailing
+	case to == db.AgentProbeStatusPassing && from == db.AgentProbeStatusFailing:
+		return probeAlertRecovered
+	default:
+		return ""
+	}
+}
+
+// executeProbe sends the prompt of the probe to its agent in a new thread, as the user who created the probe,
+// and waits for the task to finish to check the final response against the expected pattern
+func (ts *TaskService) executeProbe(queries *db.Queries, probe db.AgentProbe) probeResult {
+	pattern, err := regexp.Compile(probe.ExpectedPattern)
+	if err != nil {
+		return probeResult{Status: db.AgentProbeRunStatusError, Error: fmt.Sprintf("invalid expected pattern: %s", err)}
+	}
+	message, err := db.NewJsonRaw(anthropic.NewUserMessage(anthropic.NewTextBlock(probe.Prompt)))
+	if err != nil {
+		return probeResult{Status: db.AgentProbeRunStatusError, Error: fmt.Sprintf("failed to marshal prompt: %s", err)}
+	}
+
+	// The probe talks to its agent in the workspace of the agent
+	workspaceID, err := queries.GetAgentWorkspaceID(ts.ctx, probe.AgentID)
+	if err != nil {
+		return probeResult{Status: db.AgentProbeRunStatusError, Error: fmt.Sprintf("failed to get agent workspace: %s", err)}
+	}
+
+	now := time.Now()
+	thread, err := queries.CreateThread(ts.ctx, db.CreateThreadParams{
+		Title:       "Probe: " + probe.Name,
+		UserID:      probe.CreatedBy,
+		CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return probeResult{Status: db.AgentProbeRunStatusError, Error: fmt.Sprintf("failed to create thread: %s", err)}
+	}
+	result := probeResult{ThreadID: &thread.ID}
+
+	// Follow the events of the conversation sent to the user, the thread tells them apart from the other conversations
+	events := make(chan *nats.Msg, 100)
+	lifecycleSubject := (&service.WebsocketTaskLifecycleEventMessage{}).SubjectWithUser(probe.CreatedBy).String()
+	responseSubject := (&service.WebsocketResponseEventMessage{}).SubjectWithUser(probe.CreatedBy).String()
+	for _, subject := range []string{lifecycleSubject, responseSubject} {
+		sub, err := ts.s.GetNATS().ChanSubscribe(subject, events)
+		if err != nil {
+			result.Status, result.Error = db.AgentProbeRunStatusError, fmt.Sprintf("failed to subscribe to %s: %s", subject, err)
+			return result
+		}
+		defer sub.Unsubscribe()
+	}
+
+	connectionID := uuid.New()
+	header := &service.EventHeaders{
+		UserID:       probe.CreatedBy,
+		WorkspaceID:  workspaceID,
+		ThreadID:     &thread.ID,
+		ConnectionID: &connectionID,
+		DryRun:       probe.DryRun,
+	}
+	start := time.Now()
+	err = service.NewEvent(&service.TaskExecuteEventMessage{
+		AgentId:     probe.AgentID,
+		RecipientId: probe.CreatedBy,
+		Messages:    []db.JsonRaw{message},
+	}, header, &service.EventMetadata{Timestamp: start}).Publish(ts.s.GetNATS())
+	if err != nil {
+		result.Status, result.Error = db.AgentProbeRunStatusError, fmt.Sprintf("failed to publish task execute event: %s", err)
+		return result
+	}
+
+	timeout := time.NewTimer(time.Duration(probe.TimeoutSeconds) * time.Second)
+	defer timeout.Stop()
+	for finished := false; !finished; {
+		select {
+		case <-ts.ctx.Done():
+			result.Status, result.Error = db.AgentProbeRunStatusError, "task service stopped"
+			return result
+		case <-timeout.C:
+			result.LatencyMs = int32(time.Since(start).Milliseconds())
+			result.Status, result.Error = db.AgentProbeRunStatusTimeout, fmt.Sprintf("no response within %d seconds", probe.TimeoutSeconds)
+			return result
+		case msg := <-events:
+			var event probeEvent
+			if err := json.Unmarshal(msg.Data, &event); err != nil || event.H == nil || event.H.ThreadID == nil || *event.H.ThreadID != thread.ID {
+				continue
+			}
+			if event.Err != nil {
+				result.LatencyMs = int32(time.Since(start).Milliseconds())
+				result.Status, result.Error = db.AgentProbeRunStatusError, event.Err.Error
+				return result
+			}
+			if msg.Subject == lifecycleSubject {
+				var lifecycle service.WebsocketTaskLifecycleEventMessage
+				finished = json.Unmarshal(event.Msg, &lifecycle) == nil && lifecycle.Type == "task_stop"
+			}
+		}
+	}
+	result.LatencyMs = int32(time.Since(start).Milliseconds())
+
+	messages, err := queries.GetMessages(ts.ctx, thread.ID)
+	if err != nil {
+		result.Status, result.Error = db.AgentProbeRunStatusError, fmt.Sprintf("failed to get thread messages: %s", err)
+		return result
+	}
+	response, err := finalResponseText(messages)
+	if err != nil {
+		result.Status, result.Error = db.AgentProbeRunStatusError, err.Error()
+		return result
+	}
+	result.Response = truncateProbeResponse(response)
+	result.Status = matchProbeResponse(pattern, response)
+	if result.Status == db.AgentProbeRunStatusFailed {
+		result.Error = fmt.Sprintf("response does not match %q", probe.ExpectedPattern)
+	}
+	return result
+}
+
+// finalResponseText returns the text of the last message of the agents in a thread
+func finalResponseText(messages []db.ThreadMessage) (string, error) {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].SenderType != db.SenderMessageTypeAssistant {
+			continue
+		}
+		var message anthropic.MessageParam
+		if err := json.Unmarshal(messages[i].Message, &message); err != nil {
+			return "", fmt.Errorf("failed to unmarshal final response: %w", err)
+		}
+		var texts []string
+		for _, block := range message.Content {
+			if block.OfText != nil {
+				texts = append(texts, block.OfText.Text)
+			}
+		}
+		return strings.Join(texts, "\n"), nil
+	}
+	return "", fmt.Errorf("the agent did not respond")
+}
+
+// matchProbeResponse checks the final response of the agent against the expected pattern
+func matchProbeResponse(pattern *regexp.Regexp, response string) db.AgentProbeRunStatus {
+	if pattern.MatchString(response) {
+		return db.AgentProbeRunStatusPassed
+	}
+	return db.AgentProbeRunStatusFailed
+}
+
+func truncateProbeResponse(response string) string {
+	r := []rune(response)
+	if len(r) <= probeResponseMaxLength {
+		return response
+	}
+	return string(r[:probeResponseMaxLength-1]) + "…"
+}
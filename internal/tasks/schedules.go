@@ -0,0 +1,186 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+// runTaskScheduler invokes the agents of the task schedules due for a run until the service stops. Each instance of
+// the task service looks them up, a run is only started once.
+func (ts *TaskService) runTaskScheduler(cfg *service.TaskSchedulerConfig) {
+	ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+			ts.runDueSchedules(cfg)
+		}
+	}
+}
+
+// runDueSchedules starts the runs of the enabled schedules whose next run is due
+func (ts *TaskService) runDueSchedules(cfg *service.TaskSchedulerConfig) {
+	queries := db.New(ts.s.GetDB())
+	schedules, err := queries.ListDueTaskSchedules(ts.ctx, int32(cfg.MaxDuePerPoll))
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			ts.log.Error("Failed to list due task schedules", "error", err)
+		}
+		return
+	}
+	now := time.Now()
+	for _, schedule := range schedules {
+		ts.runSchedule(queries, schedule, now)
+	}
+}
+
+// runSchedule moves a due schedule to its next run and starts the run its overlap policy lets through.
+// The runs missed while the task service was down are started once.
+func (ts *TaskService) runSchedule(queries *db.Queries, schedule db.TaskSchedule, now time.Time) {
+	next, err := db.NextCronRun(schedule.CronExpression, schedule.Timezone, now)
+	if err != nil {
+		ts.log.Error("Invalid task schedule", "schedule_id", schedule.ID, "cron_expression", schedule.CronExpression, "timezone", schedule.Timezone, "error", err)
+		return
+	}
+
+	running := false
+	if schedule.OverlapPolicy != db.TaskScheduleOverlapPolicyAllow {
+		if running, err = ts.scheduleRunning(queries, schedule); err != nil {
+			ts.log.Error("Failed to check the previous run of task schedule", "schedule_id", schedule.ID, "error", err)
+			return
+		}
+	}
+	start, claim := scheduleOverlap(schedule.OverlapPolicy, running)
+	if !claim {
+		ts.log.Debug("Task schedule run queued behind the previous run", "schedule_id", schedule.ID)
+		return
+	}
+
+	params := db.ClaimTaskScheduleRunParams{
+		ID:        schedule.ID,
+		DueAt:     schedule.NextRunAt,
+		NextRunAt: pgtype.Timestamptz{Time: next, Valid: true},
+		LastRunAt: schedule.LastRunAt,
+	}
+	if start {
+		params.LastRunAt = pgtype.Timestamptz{Time: now, Valid: true}
+	}
+	claimed, err := queries.ClaimTaskScheduleRun(ts.ctx, params)
+	if err != nil {
+		ts.log.Error("Failed to claim task schedule run", "schedule_id", schedule.ID, "error", err)
+		return
+	}
+	if claimed == 0 {
+		ts.log.Debug("Task schedule run already claimed", "schedule_id", schedule.ID)
+		return
+	}
+	if !start {
+		ts.log.Warn("Task schedule run skipped, the previous run is still running", "schedule_id", schedule.ID, "scheduled_at", schedule.NextRunAt.Time)
+		return
+	}
+
+	threadID, err := ts.startScheduledRun(queries, schedule)
+	if err != nil {
+		ts.log.Error("Failed to start scheduled task run", "schedule_id", schedule.ID, "error", err)
+		return
+	}
+	ts.log.Info("Started scheduled task run", "schedule_id", schedule.ID, "agent_id", schedule.AgentID, "thread_id", threadID, "scheduled_at", schedule.NextRunAt.Time)
+}
+
+// scheduleOverlap returns whether a due run starts, and whether the schedule moves to its next run. A run due while
+// the previous run is running is dropped with the skip policy, and waits for the end of the previous run with the
+// queue policy, the runs due meanwhile are started once.
+func scheduleOverlap(policy db.TaskScheduleOverlapPolicy, running bool) (start, claim bool) {
+	switch {
+	case !running || policy == db.TaskScheduleOverlapPolicyAllow:
+		return true, true
+	case policy == db.TaskScheduleOverlapPolicyQueue:
+		return false, false
+	default:
+		return false, true
+	}
+}
+
+// scheduleRunning reports whether the previous run of a schedule is still running, in the thread of the schedule or
+// in the thread of its last run
+func (ts *TaskService) scheduleRunning(queries *db.Queries, schedule db.TaskSchedule) (bool, error) {
+	threadID := schedule.ThreadID
+	if !threadID.Valid {
+		threadID = schedule.LastThreadID
+	}
+	if !threadID.Valid {
+		return false, nil
+	}
+	count, err := queries.CountRunningThreadTaskRuns(ts.ctx, uuid.UUID(threadID.Bytes))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// startScheduledRun sends the prompt of a schedule to its agent as the user owning the schedule, in the thread of the
+// schedule or in a new thread. It returns the thread of the run.
+func (ts *TaskService) startScheduledRun(queries *db.Queries, schedule db.TaskSchedule) (uuid.UUID, error) {
+	loc, err := db.LoadScheduleLocation(schedule.Timezone)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	prompt, err := db.RenderTaskSchedulePrompt(schedule.Name, schedule.Prompt, schedule.NextRunAt.Time.In(loc))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	message, err := db.NewJsonRaw(anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to marshal prompt: %w", err)
+	}
+
+	threadID := uuid.UUID(schedule.ThreadID.Bytes)
+	if !schedule.ThreadID.Valid {
+		now := time.Now()
+		thread, err := queries.CreateThread(ts.ctx, db.CreateThreadParams{
+			Title:       "Schedule: " + schedule.Name,
+			UserID:      schedule.CreatedBy,
+			CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+			UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+			WorkspaceID: schedule.WorkspaceID,
+		})
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to create thread: %w", err)
+		}
+		threadID = thread.ID
+		if err := queries.UpdateTaskScheduleLastThread(ts.ctx, db.UpdateTaskScheduleLastThreadParams{
+			ID:           schedule.ID,
+			LastThreadID: pgtype.UUID{Bytes: threadID, Valid: true},
+		}); err != nil {
+			ts.log.Error("Failed to record the thread of the task schedule run", "schedule_id", schedule.ID, "thread_id", threadID, "error", err)
+		}
+	}
+
+	connectionID := uuid.New()
+	event := service.NewEvent(&service.TaskExecuteEventMessage{
+		AgentId:     schedule.AgentID,
+		RecipientId: schedule.CreatedBy,
+		Messages:    []db.JsonRaw{message},
+	}, &service.EventHeaders{
+		UserID:       schedule.CreatedBy,
+		WorkspaceID:  schedule.WorkspaceID,
+		ThreadID:     &threadID,
+		ConnectionID: &connectionID,
+	}, &service.EventMetadata{
+		TraceID:   utils.GenerateTraceID(),
+		Timestamp: time.Now().UTC(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		return threadID, fmt.Errorf("failed to publish task execute event: %w", err)
+	}
+	return threadID, nil
+}
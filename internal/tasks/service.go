@@ -6,14 +6,19 @@ import (
 	"sync"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/files"
 	"github.com/pinazu/internal/service"
 )
 
 type TaskService struct {
-	s   service.Service
-	log hclog.Logger
-	wg  *sync.WaitGroup
-	ctx context.Context
+	s      service.Service
+	js     *service.JetStreamService // Buffers the thread messages while the database is unavailable
+	files  *files.Storage            // Attachments of the messages, nil when the S3 storage is not configured
+	quotas *db.Quotas                // Limits the tokens and the concurrent tasks of the users and of the workspaces
+	log    hclog.Logger
+	wg     *sync.WaitGroup
+	ctx    context.Context
 }
 
 // NewService creates a new TaskService instance
@@ -35,13 +40,45 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("failed to create task service: %w", err)
 	}
 
-	ts := &TaskService{s: s, log: log, wg: wg, ctx: ctx}
+	js, err := service.NewJetStreamService(ctx, s.GetNATS(), log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream service: %w", err)
+	}
+
+	// The files attached to the messages are given to the agents as content blocks
+	fileStorage, err := files.NewStorage(ctx, externalDependenciesConfig)
+	if err != nil {
+		log.Warn("Failed to create the file storage, the messages with attachments cannot be executed", "error", err)
+	}
+
+	ts := &TaskService{s: s, js: js, files: fileStorage, quotas: externalDependenciesConfig.GetQuotas(), log: log, wg: wg, ctx: ctx}
+
+	// Write the messages buffered during a database outage once it is back
+	if err := js.ReplayBufferedMessages(db.New(s.GetDB())); err != nil {
+		return nil, fmt.Errorf("failed to replay buffered messages: %w", err)
+	}
 
 	s.RegisterHandler(service.TaskExecuteEventSubject.String(), ts.executeEventCallback)
 	s.RegisterHandler(service.TaskHandoffEventSubject.String(), ts.handoffEventCallback)
 	s.RegisterHandler(service.TaskFinishEventSubject.String(), ts.finishEventCallback)
 	s.RegisterHandler(service.TaskCancelEventSubject.String(), ts.cancelEventCallback)
 
+	// Run the agent probes on their schedule
+	if probesConfig := externalDependenciesConfig.GetProbesConfig(); !probesConfig.Disabled {
+		go ts.runProbeScheduler(probesConfig)
+	}
+
+	// Invoke the agents of the task schedules on their cron expression
+	if schedulerConfig := externalDependenciesConfig.GetTaskSchedulerConfig(); !schedulerConfig.Disabled {
+		go ts.runTaskScheduler(schedulerConfig)
+	}
+
+	// Give a timeout error to the agents waiting for a sub-agent past its deadline
+	go ts.runSubAgentTimeouts()
+
+	// Start the queued task runs once the started run of their task ended
+	go ts.runTaskRunQueue()
+
 	// Start a goroutine to wait for context cancellation and then shutdown
 	go func() {
 		<-ctx.Done()
@@ -0,0 +1,195 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// subAgentTimeoutPollInterval is the time between two lookups of the sub-agent runs past their deadline
+const subAgentTimeoutPollInterval = 15 * time.Second
+
+type (
+	// subAgentTaskInfo is the additional info of the sub tasks created for the invoke_agent tool runs
+	subAgentTaskInfo struct {
+		InvokeAgent *subAgentRun `json:"invoke_agent,omitempty"`
+	}
+
+	// subAgentRun is the sub-agent run of an invoke_agent tool run, with what is needed to give its result
+	// to the calling agent once the sub-agent fails, times out or is cancelled
+	subAgentRun struct {
+		AgentID        uuid.UUID  `json:"agent_id"`        // Invoked agent
+		ParentAgentID  uuid.UUID  `json:"parent_agent_id"` // Agent waiting for the tool result
+		ConnectionID   *uuid.UUID `json:"connection_id,omitempty"`
+		WorkspaceID    uuid.UUID  `json:"workspace_id"` // Workspace of the calling task, uuid.Nil for the default workspace
+		DryRun         bool       `json:"dry_run,omitempty"`
+		TimeoutSeconds int        `json:"timeout_seconds,omitempty"`
+	}
+)
+
+// subAgentRunOf returns the sub-agent run of a sub task, nil for the tasks created before the runs were tracked
+func subAgentRunOf(task db.Task) *subAgentRun {
+	var info subAgentTaskInfo
+	if err := json.Unmarshal(task.AdditionalInfo, &info); err != nil {
+		return nil
+	}
+	return info.InvokeAgent
+}
+
+// parentTaskHeader returns the headers of the task waiting for the result of a sub task
+func parentTaskHeader(task db.Task, run *subAgentRun) *service.EventHeaders {
+	header := &service.EventHeaders{
+		UserID:   task.CreatedBy,
+		ThreadID: &task.ThreadID,
+		TaskID:   &task.ParentTaskID.String,
+	}
+	if run != nil {
+		header.ConnectionID = run.ConnectionID
+		header.WorkspaceID = run.WorkspaceID
+		header.DryRun = run.DryRun
+	}
+	return header
+}
+
+// subAgentErrorContent returns the content of the error result given to the calling agent
+func subAgentErrorContent(message string) db.JsonRaw {
+	content, _ := db.NewJsonRaw(map[string]any{"error": message})
+	return content
+}
+
+// publishSubAgentResult gives the result of a sub-agent run to the invoke_agent tool run of the calling agent
+func (ts *TaskService) publishSubAgentResult(header *service.EventHeaders, toolRunID string, content db.JsonRaw, isError bool, meta *service.EventMetadata) {
+	event := service.NewEvent(&service.ToolGatherEventMessage{
+		ToolRunId:  toolRunID,
+		Content:    content,
+		ResultType: db.ResultMessageTypeText,
+		IsError:    isError,
+	}, header, &service.EventMetadata{
+		TraceID:   meta.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("Failed to publish sub-agent result to Tools Handler", "tool_run_id", toolRunID, "error", err)
+		return
+	}
+	ts.log.Debug("Published sub-agent result to Tools Handler", "tool_run_id", toolRunID, "is_error", isError)
+}
+
+// failSubAgentRun ends the run of a sub task without the response of its agent and gives the error to the
+// calling agent. Nothing is published when the run already ended, so the calling agent gets a single result.
+func (ts *TaskService) failSubAgentRun(queries *db.Queries, task db.Task, meta *service.EventMetadata, message string) {
+	ended, err := queries.CompleteTaskRunByTaskID(ts.ctx, db.CompleteTaskRunByTaskIDParams{
+		TaskID: task.ID,
+		Status: db.TaskRunStatusFailed,
+	})
+	if err != nil {
+		ts.log.Error("Failed to mark sub task run as FAILED", "sub_task_id", task.ID, "error", err)
+		return
+	}
+	if ended == 0 {
+		ts.log.Debug("Sub task run already ended", "sub_task_id", task.ID)
+		return
+	}
+	ts.log.Warn("Sub-agent run failed", "sub_task_id", task.ID, "parent_task_id", task.ParentTaskID.String, "reason", message)
+	if meta == nil {
+		meta = &service.EventMetadata{Timestamp: time.Now()}
+	}
+
+	run := subAgentRunOf(task)
+	header := parentTaskHeader(task, run)
+
+	// The agent of the ended run stops answering, the sub-agents invoked by this one have nobody left to answer
+	ts.stopAgentStreams(header, task.ThreadID, task.ID, meta)
+	ts.endSubAgentRuns(queries, header, task.ThreadID, task.ID, meta)
+
+	subTaskID := task.ID
+	subTaskHeader := &service.EventHeaders{UserID: header.UserID, WorkspaceID: header.WorkspaceID, ThreadID: header.ThreadID, TaskID: &subTaskID, ConnectionID: header.ConnectionID, RequestID: header.RequestID, DryRun: header.DryRun}
+	taskStopEvent := service.NewEvent(&service.WebsocketTaskLifecycleEventMessage{
+		Type:     "sub_task_stop",
+		ThreadId: task.ThreadID,
+		TaskId:   task.ID,
+	}, subTaskHeader, meta)
+	if err := taskStopEvent.PublishWithUser(ts.s.GetNATS(), header.UserID); err != nil {
+		ts.log.Error("Failed to publish sub task stop event", "sub_task_id", task.ID, "error", err)
+	}
+
+	// The calling agent resumes with the error as the invoke_agent result
+	if err := queries.UpdateTaskRunStatusByTaskID(ts.ctx, db.UpdateTaskRunStatusByTaskIDParams{
+		TaskID: task.ParentTaskID.String,
+		Status: db.TaskRunStatusRunning,
+	}); err != nil {
+		ts.log.Error("Failed to update parent task run status back to RUNNING", "parent_task_id", task.ParentTaskID.String, "error", err)
+	}
+	ts.publishSubAgentResult(header, task.ID, subAgentErrorContent(message), true, meta)
+}
+
+// endSubAgentRuns fails the active runs of the sub tasks of a task and of their own sub tasks and stops their agents,
+// without result since the task waiting for them is no longer running. The sub tasks run in the thread of the task.
+func (ts *TaskService) endSubAgentRuns(queries *db.Queries, header *service.EventHeaders, threadID uuid.UUID, taskID string, meta *service.EventMetadata) {
+	subTaskIDs, err := queries.ListActiveSubTaskIDs(ts.ctx, pgtype.Text{String: taskID, Valid: true})
+	if err != nil {
+		ts.log.Error("Failed to list active sub tasks", "task_id", taskID, "error", err)
+		return
+	}
+	for _, subTaskID := range subTaskIDs {
+		if _, err := queries.CompleteTaskRunByTaskID(ts.ctx, db.CompleteTaskRunByTaskIDParams{
+			TaskID: subTaskID,
+			Status: db.TaskRunStatusFailed,
+		}); err != nil {
+			ts.log.Error("Failed to mark sub task run as FAILED", "sub_task_id", subTaskID, "error", err)
+			continue
+		}
+		ts.log.Info("Ended sub-agent run of an ended task", "sub_task_id", subTaskID, "task_id", taskID)
+		ts.stopAgentStreams(header, threadID, subTaskID, meta)
+		ts.endSubAgentRuns(queries, header, threadID, subTaskID, meta)
+	}
+}
+
+// runSubAgentTimeouts fails the sub-agent runs past their deadline in the background.
+// Each instance of the task service looks them up, a run is only failed once.
+func (ts *TaskService) runSubAgentTimeouts() {
+	ticker := time.NewTicker(subAgentTimeoutPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+			ts.expireSubAgentRuns()
+		}
+	}
+}
+
+// expireSubAgentRuns gives a timeout error result to the agents waiting for a sub-agent run past its deadline
+func (ts *TaskService) expireSubAgentRuns() {
+	queries := db.New(ts.s.GetDB())
+	runs, err := queries.ListExpiredTaskRuns(ts.ctx)
+	if err != nil {
+		ts.log.Error("Failed to list sub-agent runs past their deadline", "error", err)
+		return
+	}
+	for _, taskRun := range runs {
+		task, err := queries.GetTaskById(ts.ctx, taskRun.TaskID)
+		if err != nil {
+			ts.log.Error("Failed to get sub task", "sub_task_id", taskRun.TaskID, "error", err)
+			continue
+		}
+		if !task.ParentTaskID.Valid {
+			continue
+		}
+		ts.failSubAgentRun(queries, task, nil, subAgentTimeoutMessage(subAgentRunOf(task)))
+	}
+}
+
+// subAgentTimeoutMessage returns the error given to the calling agent when the sub-agent did not answer in time
+func subAgentTimeoutMessage(run *subAgentRun) string {
+	if run == nil {
+		return "The invoked agent did not answer in time"
+	}
+	return fmt.Sprintf("The invoked agent %s did not answer within %d seconds", run.AgentID, run.TimeoutSeconds)
+}
@@ -0,0 +1,125 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+// queuedTaskRunPollInterval is the time between two lookups of the queued task runs whose task has no started run
+const queuedTaskRunPollInterval = 5 * time.Second
+
+// maxQueuedTasksPerPoll is the number of tasks whose next queued run is started at each lookup
+const maxQueuedTasksPerPoll = 100
+
+// runTaskRunQueue starts the queued runs of the tasks without started run in the background, the runs ended outside
+// of the task service, such as a stream timeout of the API, are only seen here. Each instance of the task service
+// looks them up, a run is only started once.
+func (ts *TaskService) runTaskRunQueue() {
+	ticker := time.NewTicker(queuedTaskRunPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+			ts.startQueuedTaskRuns()
+		}
+	}
+}
+
+// startQueuedTaskRuns starts the next queued run of the tasks without started run
+func (ts *TaskService) startQueuedTaskRuns() {
+	queries := db.New(ts.s.GetDB())
+	taskIDs, err := queries.ListStartableQueuedTaskIDs(ts.ctx, maxQueuedTasksPerPoll)
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			ts.log.Error("Failed to list the tasks with queued runs", "error", err)
+		}
+		return
+	}
+	for _, taskID := range taskIDs {
+		ts.startNextTaskRun(queries, taskID)
+	}
+}
+
+// startNextTaskRun starts the oldest queued run of a task once its started run ended, and invokes the agent of the
+// run with the messages of the thread of the task. Nothing is started while the task has a started run.
+func (ts *TaskService) startNextTaskRun(queries *db.Queries, taskID string) {
+	taskRun, err := queries.StartQueuedTaskRun(ts.ctx, taskID)
+	if err != nil {
+		// Another instance started a run of the task first
+		if err != pgx.ErrNoRows && !db.IsConflictError(err) {
+			ts.log.Error("Failed to start queued task run", "task_id", taskID, "error", err)
+		}
+		return
+	}
+	if err := ts.invokeTaskRun(queries, taskRun); err != nil {
+		ts.log.Error("Failed to invoke the agent of the queued task run", "task_id", taskID, "task_run_id", taskRun.TaskRunID, "error", err)
+		if err := queries.UpdateTaskRunStatus(ts.ctx, db.UpdateTaskRunStatusParams{
+			Status:    db.TaskRunStatusFailed,
+			TaskRunID: taskRun.TaskRunID,
+		}); err != nil {
+			ts.log.Error("Failed to mark queued task run as FAILED", "task_run_id", taskRun.TaskRunID, "error", err)
+		}
+		return
+	}
+	ts.log.Info("Started queued task run", "task_id", taskID, "task_run_id", taskRun.TaskRunID, "agent_id", uuid.UUID(taskRun.AgentID.Bytes))
+}
+
+// invokeTaskRun sends the messages of the thread of a started run to its agent, as the user who queued it
+func (ts *TaskService) invokeTaskRun(queries *db.Queries, taskRun db.TasksRun) error {
+	task, err := queries.GetTaskById(ts.ctx, taskRun.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	rows, err := queries.GetMessageContents(ts.ctx, task.ThreadID)
+	if err != nil {
+		return fmt.Errorf("failed to get messages of thread %s: %w", task.ThreadID, err)
+	}
+	ids := make([]uuid.UUID, len(rows))
+	messages := make([]db.JsonRaw, len(rows))
+	for i, row := range rows {
+		ids[i], messages[i] = row.ID, row.Message
+	}
+	messages, err = ts.files.ExpandAttachments(ts.ctx, queries, ids, messages)
+	if err != nil {
+		return err
+	}
+
+	userID := uuid.UUID(taskRun.RequestedBy.Bytes)
+	header := &service.EventHeaders{
+		UserID:      userID,
+		WorkspaceID: uuid.UUID(taskRun.WorkspaceID.Bytes),
+		ThreadID:    &task.ThreadID,
+		TaskID:      &task.ID,
+		DryRun:      taskRun.DryRun,
+	}
+	meta := &service.EventMetadata{
+		TraceID:   utils.GenerateTraceID(),
+		Timestamp: time.Now().UTC(),
+	}
+	taskStartEvent := service.NewEvent(&service.WebsocketTaskLifecycleEventMessage{
+		Type:     "task_start",
+		ThreadId: task.ThreadID,
+		TaskId:   task.ID,
+	}, header, meta)
+	if err := taskStartEvent.PublishWithUser(ts.s.GetNATS(), userID); err != nil {
+		ts.log.Error("Failed to publish task start event", "task_id", task.ID, "error", err)
+	}
+
+	event := service.NewEvent(&service.AgentInvokeEventMessage{
+		AgentId:     uuid.UUID(taskRun.AgentID.Bytes),
+		RecipientId: userID,
+		Messages:    messages,
+	}, header, meta)
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		return fmt.Errorf("failed to publish agent invoke event: %w", err)
+	}
+	return nil
+}
@@ -1 +1,129 @@
 package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeAlertEvent(t *testing.T) {
+	assert.Equal(t, probeAlertFailing, probeAlertEvent(db.AgentProbeStatusUnknown, db.AgentProbeStatusFailing))
+	assert.Equal(t, probeAlertFailing, probeAlertEvent(db.AgentProbeStatusPassing, db.AgentProbeStatusFailing))
+	assert.Equal(t, probeAlertRecovered, probeAlertEvent(db.AgentProbeStatusFailing, db.AgentProbeStatusPassing))
+	assert.Empty(t, probeAlertEvent(db.AgentProbeStatusUnknown, db.AgentProbeStatusPassing))
+	assert.Empty(t, probeAlertEvent(db.AgentProbeStatusFailing, db.AgentProbeStatusFailing))
+}
+
+func TestFinalResponseText(t *testing.T) {
+	messages := []db.ThreadMessage{
+		{SenderType: db.SenderMessageTypeUser, Message: db.JsonRaw(`{"role": "user", "content": [{"type": "text", "text": "What is 2+2?"}]}`)},
+		{SenderType: db.SenderMessageTypeAssistant, Message: db.JsonRaw(`{"role": "assistant", "content": [{"type": "text", "text": "Let me check"}]}`)},
+		{SenderType: db.SenderMessageTypeResult, Message: db.JsonRaw(`{"role": "user", "content": [{"type": "tool_result", "tool_use_id": "t1", "content": "4"}]}`)},
+		{SenderType: db.SenderMessageTypeAssistant, Message: db.JsonRaw(`{"role": "assistant", "content": [{"type": "text", "text": "The answer"}, {"type": "text", "text": "is 4"}]}`)},
+	}
+	text, err := finalResponseText(messages)
+	require.NoError(t, err)
+	assert.Equal(t, "The answer\nis 4", text)
+	assert.Equal(t, db.AgentProbeRunStatusPassed, matchProbeResponse(regexp.MustCompile(`(?i)answer\s+is 4`), text))
+	assert.Equal(t, db.AgentProbeRunStatusFailed, matchProbeResponse(regexp.MustCompile(`^5$`), text))
+
+	_, err = finalResponseText(messages[:1])
+	assert.ErrorContains(t, err, "did not respond")
+
+	assert.Len(t, []rune(truncateProbeResponse(strings.Repeat("é", probeResponseMaxLength+10))), probeResponseMaxLength)
+}
+
+func TestProbeAlertPresets(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	for _, preset := range []string{service.WebhookPresetSlack, service.WebhookPresetTeams} {
+		for _, event := range []string{probeAlertFailing, probeAlertRecovered} {
+			t.Run(preset+"/"+event, func(t *testing.T) {
+				alert, err := service.NewWebhookAlert(server.URL, probeAlertPresets, preset, "", time.Second)
+				require.NoError(t, err)
+				err = alert.Send(t.Context(), probeAlert{
+					Event:     event,
+					ProbeID:   uuid.New(),
+					ProbeName: `smoke "quoted"`,
+					AgentID:   uuid.New(),
+					Status:    db.AgentProbeRunStatusTimeout,
+					LatencyMs: 120000,
+					Error:     "no response within 120 seconds",
+					RunAt:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				})
+				require.NoError(t, err)
+				assert.True(t, json.Valid(received))
+				assert.Contains(t, string(received), `smoke \"quoted\"`)
+				assert.Contains(t, string(received), "2025-01-02T03:04:05Z")
+			})
+		}
+	}
+}
+
+func TestSubAgentRun(t *testing.T) {
+	connectionID := uuid.New()
+	run := &subAgentRun{AgentID: uuid.New(), ParentAgentID: uuid.New(), ConnectionID: &connectionID, DryRun: true, TimeoutSeconds: 60}
+	info, err := json.Marshal(subAgentTaskInfo{InvokeAgent: run})
+	require.NoError(t, err)
+
+	task := db.Task{
+		ID:             "toolu_1",
+		ThreadID:       uuid.New(),
+		CreatedBy:      uuid.New(),
+		AdditionalInfo: info,
+		ParentTaskID:   pgtype.Text{String: "parent", Valid: true},
+	}
+	assert.Equal(t, run, subAgentRunOf(task))
+
+	header := parentTaskHeader(task, subAgentRunOf(task))
+	assert.Equal(t, "parent", *header.TaskID)
+	assert.Equal(t, task.ThreadID, *header.ThreadID)
+	assert.Equal(t, task.CreatedBy, header.UserID)
+	assert.Equal(t, &connectionID, header.ConnectionID)
+	assert.True(t, header.DryRun)
+	assert.Equal(t, fmt.Sprintf("The invoked agent %s did not answer within 60 seconds", run.AgentID), subAgentTimeoutMessage(run))
+
+	// Sub tasks created before the runs were tracked
+	task.AdditionalInfo = db.JsonRaw(`{}`)
+	assert.Nil(t, subAgentRunOf(task))
+	assert.Nil(t, parentTaskHeader(task, nil).ConnectionID)
+	assert.Equal(t, "The invoked agent did not answer in time", subAgentTimeoutMessage(nil))
+	assert.JSONEq(t, `{"error": "boom"}`, string(subAgentErrorContent("boom")))
+}
+
+func TestScheduleOverlap(t *testing.T) {
+	tests := []struct {
+		policy  db.TaskScheduleOverlapPolicy
+		running bool
+		start   bool
+		claim   bool
+	}{
+		{db.TaskScheduleOverlapPolicySkip, false, true, true},
+		{db.TaskScheduleOverlapPolicySkip, true, false, true},
+		{db.TaskScheduleOverlapPolicyQueue, false, true, true},
+		{db.TaskScheduleOverlapPolicyQueue, true, false, false},
+		{db.TaskScheduleOverlapPolicyAllow, true, true, true},
+	}
+	for _, tt := range tests {
+		start, claim := scheduleOverlap(tt.policy, tt.running)
+		assert.Equal(t, tt.start, start, "%s running=%v", tt.policy, tt.running)
+		assert.Equal(t, tt.claim, claim, "%s running=%v", tt.policy, tt.running)
+	}
+}
@@ -0,0 +1,206 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// MetricsConfig holds the configuration of the Prometheus endpoint of the metrics
+type MetricsConfig struct {
+	ListenAddress string
+	Path          string
+}
+
+var (
+	serveMu       sync.Mutex
+	metricsReader *sdkmetric.ManualReader
+	serveMuxes    = map[string]*http.ServeMux{} // HTTP listeners of the process by address
+	servedPaths   = map[string]bool{}           // Paths served by the listeners, by address and path
+)
+
+// ServeMetrics sets the OpenTelemetry meter provider of the process and serves its metrics in the Prometheus text
+// format. The services of a process share the provider and the endpoint, only the first call of an address listens.
+func ServeMetrics(ctx context.Context, cfg MetricsConfig) error {
+	serveMu.Lock()
+	defer serveMu.Unlock()
+
+	if metricsReader == nil {
+		metricsReader = sdkmetric.NewManualReader()
+		otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricsReader)))
+	}
+	return serveHandler(ctx, cfg.ListenAddress, cfg.Path, MetricsHandler(metricsReader))
+}
+
+// ServeHandler serves a handler at a path of the HTTP listener of an address. The listener is shared by the metrics
+// and the other endpoints of the process served at the same address, a path is served by the first call only.
+func ServeHandler(ctx context.Context, address, path string, handler http.Handler) error {
+	serveMu.Lock()
+	defer serveMu.Unlock()
+	return serveHandler(ctx, address, path, handler)
+}
+
+func serveHandler(ctx context.Context, address, path string, handler http.Handler) error {
+	mux, ok := serveMuxes[address]
+	if !ok {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return fmt.Errorf("failed to listen on address %s: %w", address, err)
+		}
+		mux = http.NewServeMux()
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go server.Serve(listener)
+		go func() {
+			<-ctx.Done()
+			server.Close()
+		}()
+		serveMuxes[address] = mux
+	}
+	if servedPaths[address+path] {
+		return nil
+	}
+	mux.Handle(path, handler)
+	servedPaths[address+path] = true
+	return nil
+}
+
+// MetricsHandler returns the HTTP handler writing the metrics collected by a reader in the Prometheus text format
+func MetricsHandler(reader sdkmetric.Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(r.Context(), &rm); err != nil && !errors.Is(err, sdkmetric.ErrReaderShutdown) {
+			http.Error(w, fmt.Sprintf("failed to collect metrics: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		buf := bufio.NewWriter(w)
+		WritePrometheusMetrics(buf, &rm)
+		buf.Flush()
+	})
+}
+
+// WritePrometheusMetrics writes the metrics in the Prometheus text format. The dots of the instrument names become
+// underscores, the durations in seconds are suffixed by _seconds and the counters by _total.
+func WritePrometheusMetrics(w *bufio.Writer, rm *metricdata.ResourceMetrics) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			name := prometheusName(m.Name, m.Unit)
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				writeSum(w, name, m.Description, data.IsMonotonic, data.DataPoints)
+			case metricdata.Sum[float64]:
+				writeSum(w, name, m.Description, data.IsMonotonic, data.DataPoints)
+			case metricdata.Gauge[int64]:
+				writeHeader(w, name, m.Description, "gauge")
+				writePoints(w, name, data.DataPoints)
+			case metricdata.Gauge[float64]:
+				writeHeader(w, name, m.Description, "gauge")
+				writePoints(w, name, data.DataPoints)
+			case metricdata.Histogram[float64]:
+				writeHistogram(w, name, m.Description, data.DataPoints)
+			case metricdata.Histogram[int64]:
+				writeHistogram(w, name, m.Description, data.DataPoints)
+			}
+		}
+	}
+}
+
+// prometheusName returns the Prometheus name of an instrument
+func prometheusName(name string, unit string) string {
+	name = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, name)
+	if unit == "s" && !strings.HasSuffix(name, "_seconds") {
+		name += "_seconds"
+	}
+	return name
+}
+
+func writeHeader(w *bufio.Writer, name string, description string, kind string) {
+	if description != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(description))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+func writeSum[N int64 | float64](w *bufio.Writer, name string, description string, monotonic bool, points []metricdata.DataPoint[N]) {
+	if !monotonic {
+		writeHeader(w, name, description, "gauge")
+		writePoints(w, name, points)
+		return
+	}
+	if !strings.HasSuffix(name, "_total") {
+		name += "_total"
+	}
+	writeHeader(w, name, description, "counter")
+	writePoints(w, name, points)
+}
+
+func writePoints[N int64 | float64](w *bufio.Writer, name string, points []metricdata.DataPoint[N]) {
+	for _, point := range points {
+		fmt.Fprintf(w, "%s%s %s\n", name, prometheusLabels(point.Attributes, ""), formatValue(float64(point.Value)))
+	}
+}
+
+func writeHistogram[N int64 | float64](w *bufio.Writer, name string, description string, points []metricdata.HistogramDataPoint[N]) {
+	writeHeader(w, name, description, "histogram")
+	for _, point := range points {
+		var cumulative uint64
+		for i, bound := range point.Bounds {
+			cumulative += point.BucketCounts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", name, prometheusLabels(point.Attributes, formatValue(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, prometheusLabels(point.Attributes, "+Inf"), point.Count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", name, prometheusLabels(point.Attributes, ""), formatValue(float64(point.Sum)))
+		fmt.Fprintf(w, "%s_count%s %d\n", name, prometheusLabels(point.Attributes, ""), point.Count)
+	}
+}
+
+// prometheusLabels formats the attributes of a data point, with the le label of a histogram bucket when set
+func prometheusLabels(attrs attribute.Set, le string) string {
+	labels := make([]string, 0, attrs.Len()+1)
+	for _, kv := range attrs.ToSlice() {
+		labels = append(labels, prometheusName(string(kv.Key), "")+`="`+escapeLabel(kv.Value.Emit())+`"`)
+	}
+	sort.Strings(labels)
+	if le != "" {
+		labels = append(labels, `le="`+le+`"`)
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
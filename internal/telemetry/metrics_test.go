@@ -0,0 +1,58 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer provider.Shutdown(ctx)
+	meter := provider.Meter("test")
+
+	runs, err := meter.Int64Counter("pinazu.flow_runs", metric.WithDescription("Completed flow runs"))
+	require.NoError(t, err)
+	runs.Add(ctx, 2, metric.WithAttributes(attribute.String("flow", `daily "etl"`), attribute.String("status", "SUCCESS")))
+
+	processes, err := meter.Int64UpDownCounter("pinazu.worker.flow_processes")
+	require.NoError(t, err)
+	processes.Add(ctx, 3)
+
+	duration, err := meter.Float64Histogram("pinazu.flow_run.duration",
+		metric.WithDescription("Duration of the flow runs"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(1, 5))
+	require.NoError(t, err)
+	duration.Record(ctx, 0.5)
+	duration.Record(ctx, 3)
+	duration.Record(ctx, 10)
+
+	rec := httptest.NewRecorder()
+	MetricsHandler(reader).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+
+	// The counters are suffixed by _total, the up-down counters are gauges
+	assert.Contains(t, string(body), "# HELP pinazu_flow_runs_total Completed flow runs\n# TYPE pinazu_flow_runs_total counter\n")
+	assert.Contains(t, string(body), `pinazu_flow_runs_total{flow="daily \"etl\"",status="SUCCESS"} 2`+"\n")
+	assert.Contains(t, string(body), "# TYPE pinazu_worker_flow_processes gauge\npinazu_worker_flow_processes 3\n")
+
+	// The histogram buckets are cumulative, the durations in seconds are suffixed by _seconds
+	assert.Contains(t, string(body), "# TYPE pinazu_flow_run_duration_seconds histogram\n"+
+		`pinazu_flow_run_duration_seconds_bucket{le="1"} 1`+"\n"+
+		`pinazu_flow_run_duration_seconds_bucket{le="5"} 2`+"\n"+
+		`pinazu_flow_run_duration_seconds_bucket{le="+Inf"} 3`+"\n"+
+		"pinazu_flow_run_duration_seconds_sum 13.5\n"+
+		"pinazu_flow_run_duration_seconds_count 3\n")
+}
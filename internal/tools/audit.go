@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+type (
+	// toolAuditor records the tool runs in the audit log with the retention and redaction of their tool
+	toolAuditor struct {
+		defaults toolAuditPolicy
+		rules    []toolAuditRule
+	}
+
+	// toolAuditRule is the audit policy of the tools matching a name, or a name prefix
+	toolAuditRule struct {
+		tool   string
+		prefix bool
+		policy toolAuditPolicy
+	}
+
+	// toolAuditPolicy is how long the entries of a tool are kept and what is redacted from them
+	toolAuditPolicy struct {
+		retentionDays int32
+		keys          map[string]bool // Lowercased
+		patterns      []*regexp.Regexp
+		omitInput     bool
+		omitOutput    bool
+	}
+)
+
+// newToolAuditor compiles the audit configuration, nil when the audit log is disabled
+func newToolAuditor(cfg *service.ToolAuditConfig) (*toolAuditor, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+	defaults := toolAuditPolicy{retentionDays: int32(cfg.RetentionDays), keys: make(map[string]bool)}
+	if err := defaults.add(cfg.RedactKeys, cfg.RedactPatterns); err != nil {
+		return nil, err
+	}
+
+	a := &toolAuditor{defaults: defaults}
+	for i, r := range cfg.Rules {
+		if r.Tool == "" {
+			return nil, fmt.Errorf("audit rules[%d]: tool is required", i)
+		}
+		policy := defaults.clone()
+		if r.RetentionDays > 0 {
+			policy.retentionDays = int32(r.RetentionDays)
+		}
+		if err := policy.add(r.RedactKeys, r.RedactPatterns); err != nil {
+			return nil, fmt.Errorf("audit rules[%d]: %w", i, err)
+		}
+		policy.omitInput, policy.omitOutput = r.OmitInput, r.OmitOutput
+		tool, prefix := strings.CutSuffix(r.Tool, "*")
+		a.rules = append(a.rules, toolAuditRule{tool: tool, prefix: prefix, policy: policy})
+	}
+	return a, nil
+}
+
+// add extends the policy with redacted keys and patterns
+func (p *toolAuditPolicy) add(keys, patterns []string) error {
+	for _, k := range keys {
+		p.keys[strings.ToLower(k)] = true
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		p.patterns = append(p.patterns, re)
+	}
+	return nil
+}
+
+// clone returns a copy of the policy that can be extended without changing the original
+func (p toolAuditPolicy) clone() toolAuditPolicy {
+	c := p
+	c.keys = make(map[string]bool, len(p.keys))
+	for k := range p.keys {
+		c.keys[k] = true
+	}
+	c.patterns = append([]*regexp.Regexp(nil), p.patterns...)
+	return c
+}
+
+// policy returns the policy of the first rule matching the tool, the default policy otherwise
+func (a *toolAuditor) policy(toolName string) toolAuditPolicy {
+	for _, r := range a.rules {
+		if r.tool == toolName || (r.prefix && strings.HasPrefix(toolName, r.tool)) {
+			return r.policy
+		}
+	}
+	return a.defaults
+}
+
+// redact returns the JSON value with the values of the redacted keys and the matches of the redacted patterns replaced
+func (p toolAuditPolicy) redact(raw db.JsonRaw) db.JsonRaw {
+	if len(raw) == 0 {
+		return raw
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		// Not kept rather than kept unredacted
+		return nil
+	}
+	redacted, err := json.Marshal(p.redactValue(v))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func (p toolAuditPolicy) redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if p.keys[strings.ToLower(k)] && child != nil {
+				v[k] = db.RedactedSecret
+				continue
+			}
+			v[k] = p.redactValue(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = p.redactValue(child)
+		}
+	case string:
+		for _, re := range p.patterns {
+			v = re.ReplaceAllString(v, db.RedactedSecret)
+		}
+		return v
+	}
+	return v
+}
+
+// recordToolRunAudit adds the run of a tool to the audit log with its redacted input.
+// A failure is logged without failing the run.
+func (ts *ToolService) recordToolRunAudit(queries *db.Queries, toolRunID string, parentRunID pgtype.Text, tool db.Tool, input db.JsonRaw, req *service.Event[*service.ToolDispatchEventMessage]) {
+	if ts.auditor == nil {
+		return
+	}
+	policy := ts.auditor.policy(tool.Name)
+	params := db.CreateToolRunAuditParams{
+		ToolRunID:     toolRunID,
+		ParentRunID:   parentRunID,
+		ToolID:        tool.ID,
+		ToolName:      tool.Name,
+		ToolRevision:  tool.Revision,
+		UserID:        req.H.UserID,
+		AgentID:       req.Msg.AgentId,
+		RecipientID:   req.Msg.RecipientId,
+		RetentionDays: policy.retentionDays,
+	}
+	if req.H.ThreadID != nil {
+		params.ThreadID = *req.H.ThreadID
+	}
+	if req.H.TaskID != nil {
+		params.TaskID = pgtype.Text{String: *req.H.TaskID, Valid: true}
+	}
+	if req.H.ConnectionID != nil {
+		params.ConnectionID = pgtype.UUID{Bytes: *req.H.ConnectionID, Valid: true}
+	}
+	if !policy.omitInput {
+		params.Input = policy.redact(input)
+	}
+	if err := queries.CreateToolRunAudit(ts.ctx, params); err != nil {
+		ts.log.Error("Failed to record tool run audit", "tool_run_id", toolRunID, "tool_name", tool.Name, "error", err)
+	}
+}
+
+// completeToolRunAudit records the redacted output and the outcome of an audited tool run
+func (ts *ToolService) completeToolRunAudit(queries *db.Queries, toolRunID string, output db.JsonRaw, status db.ToolRunStatus, duration pgtype.Float8) {
+	if ts.auditor == nil {
+		return
+	}
+	entry, err := queries.GetToolRunAudit(ts.ctx, toolRunID)
+	if err != nil {
+		// The runs dispatched while the audit log was disabled have no entry
+		if !errors.Is(err, pgx.ErrNoRows) {
+			ts.log.Error("Failed to get tool run audit", "tool_run_id", toolRunID, "error", err)
+		}
+		return
+	}
+	params := db.CompleteToolRunAuditParams{ToolRunID: toolRunID, Status: status, Duration: duration}
+	if policy := ts.auditor.policy(entry.ToolName); !policy.omitOutput {
+		params.Output = policy.redact(output)
+	}
+	if err := queries.CompleteToolRunAudit(ts.ctx, params); err != nil {
+		ts.log.Error("Failed to complete tool run audit", "tool_run_id", toolRunID, "error", err)
+	}
+}
+
+// runAuditPruner deletes the expired entries of the audit log in the background
+func (ts *ToolService) runAuditPruner(cfg *service.ToolAuditConfig) {
+	ticker := time.NewTicker(time.Duration(cfg.PruneIntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+	for {
+		deleted, err := db.New(ts.s.GetDB()).DeleteExpiredToolRunAudit(ts.ctx)
+		if err != nil {
+			ts.log.Error("Failed to prune expired tool run audit entries", "error", err)
+		} else if deleted > 0 {
+			ts.log.Info("Pruned expired tool run audit entries", "count", deleted)
+		}
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// maxAgentChainLength bounds the sub tasks walked up to find the agents invoking each other
+const maxAgentChainLength = 100
+
+// batchLimitError returns why a batch call cannot run, empty when it is within the batch limits.
+// depth is the number of batches enclosing the call, 0 for a batch called directly by the agent.
+func batchLimitError(cfg *service.BatchToolConfig, depth, children int) string {
+	if depth+1 > cfg.MaxDepth {
+		return fmt.Sprintf("batch_tool calls cannot be nested more than %d levels deep, call the tools of the nested batch directly instead", cfg.MaxDepth)
+	}
+	if children > cfg.MaxChildren {
+		return fmt.Sprintf("batch_tool calls cannot run more than %d invocations, got %d, split them into several batches", cfg.MaxChildren, children)
+	}
+	return ""
+}
+
+// agentChain returns the agents invoking each other through invoke_agent down to the agent executing the task,
+// from the agent of the top-level task. A sub task is created with the ID of the invoke_agent tool run
+// of its parent agent, which is how the chain is walked up.
+func (ts *ToolService) agentChain(queries *db.Queries, taskID *string, agentID uuid.UUID) ([]uuid.UUID, error) {
+	chain := []uuid.UUID{agentID}
+	if taskID == nil {
+		return chain, nil
+	}
+
+	id := *taskID
+	for range maxAgentChainLength {
+		task, err := queries.GetTaskById(ts.ctx, id)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				break
+			}
+			return nil, fmt.Errorf("failed to get task %s: %w", id, err)
+		}
+		if !task.ParentTaskID.Valid {
+			break
+		}
+		run, err := queries.GetToolRunStatusByID(ts.ctx, task.ID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				break
+			}
+			return nil, fmt.Errorf("failed to get invoke_agent tool run %s: %w", task.ID, err)
+		}
+		chain = append(chain, run.AgentID)
+		id = task.ParentTaskID.String
+	}
+	slices.Reverse(chain)
+	return chain, nil
+}
+
+// invokeAgentCycleError returns why invoking the agent would loop, empty when the agent is not already in the chain
+func invokeAgentCycleError(chain []uuid.UUID, target uuid.UUID) string {
+	if !slices.Contains(chain, target) {
+		return ""
+	}
+	agents := make([]string, 0, len(chain)+1)
+	for _, id := range append(chain, target) {
+		agents = append(agents, id.String())
+	}
+	return fmt.Sprintf("invoke_agent cannot invoke agent %s, it is already in the chain of invoked agents %s", target, strings.Join(agents, " -> "))
+}
+
+// publishToolError returns an error tool result to the agent instead of invoking the tool
+func (ts *ToolService) publishToolError(toolRunID, toolName, message string, header *service.EventHeaders, meta *service.EventMetadata) {
+	ts.log.Warn("Tool call rejected", "tool_name", toolName, "tool_run_id", toolRunID, "reason", message)
+
+	content, err := db.NewJsonRaw(map[string]any{"error": message})
+	if err != nil {
+		ts.log.Error("Failed to marshal tool error", "error", err)
+		return
+	}
+
+	event := service.NewEvent(&service.ToolGatherEventMessage{
+		ToolRunId:  toolRunID,
+		Content:    content,
+		ResultType: db.ResultMessageTypeText,
+		IsError:    true,
+	}, header, &service.EventMetadata{
+		TraceID:   meta.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("failed to publish result to tool gather event", "error", err)
+	}
+}
@@ -0,0 +1,373 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// CodeInterpreterToolName is the name of the built-in code interpreter tool
+	CodeInterpreterToolName = "code_interpreter"
+
+	// codeInterpreterMaxFiles caps the number of generated files returned from a single execution
+	codeInterpreterMaxFiles = 20
+
+	// codeInterpreterMaxImageBytes caps the size of a generated image returned inline as an image block
+	codeInterpreterMaxImageBytes = 5 * 1024 * 1024
+)
+
+// codeInterpreterImageTypes are the media types that can be returned to the model as image blocks
+var codeInterpreterImageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+type (
+	// codeInterpreterResult is the tool result content of a code interpreter execution
+	codeInterpreterResult struct {
+		Language string                 `json:"language"`
+		ExitCode int                    `json:"exit_code"`
+		TimedOut bool                   `json:"timed_out"`
+		Stdout   string                 `json:"stdout"`
+		Stderr   string                 `json:"stderr"`
+		Files    []codeInterpreterFile  `json:"files"`
+		Images   []codeInterpreterImage `json:"images"`
+	}
+
+	// codeInterpreterFile describes a file written by the snippet to its working directory
+	codeInterpreterFile struct {
+		Name      string `json:"name"`
+		MediaType string `json:"media_type"`
+		Size      int64  `json:"size"`
+		URL       string `json:"url,omitempty"` // S3 location, empty when no bucket is configured
+	}
+
+	// codeInterpreterImage is a generated image returned inline to the model
+	codeInterpreterImage struct {
+		Name      string `json:"name"`
+		MediaType string `json:"media_type"`
+		Data      string `json:"data"` // Base64 encoded image content
+	}
+
+	// limitedBuffer is an io.Writer that keeps the first limit bytes written to it and drops the rest
+	limitedBuffer struct {
+		buf       bytes.Buffer
+		limit     int
+		truncated bool
+	}
+)
+
+// executeCodeInterpreterTool runs each code snippet in a sandboxed process and publishes the results to the tool gather event.
+// The snippets beyond the concurrent runs of the configuration wait for a running snippet to end.
+func (ts *ToolService) executeCodeInterpreterTool(codeToolsToExecute []service.StandaloneToolRequestEventMessage, header *service.EventHeaders, meta *service.EventMetadata) {
+	if len(codeToolsToExecute) == 0 {
+		return
+	}
+
+	cfg := ts.config.GetCodeInterpreterConfig()
+	for _, t := range codeToolsToExecute {
+		go func(t service.StandaloneToolRequestEventMessage) {
+			select {
+			case ts.codeSlots <- struct{}{}:
+				defer func() { <-ts.codeSlots }()
+			case <-ts.ctx.Done():
+				return
+			}
+			msg := &service.ToolGatherEventMessage{ToolRunId: t.ToolRunId}
+
+			result, err := ts.runCodeSnippet(ts.ctx, cfg, t)
+			if err == nil {
+				msg.Content, err = db.NewJsonRaw(result)
+			}
+			if err != nil {
+				ts.log.Error("Failed to execute code interpreter tool", "tool_run_id", t.ToolRunId, "error", err)
+				msg.Content, _ = db.NewJsonRaw(map[string]any{"error": err.Error()})
+				msg.ResultType = db.ResultMessageTypeText
+				msg.IsError = true
+			} else {
+				msg.ResultType = db.ResultMessageTypeCode
+				msg.IsError = result.ExitCode != 0 || result.TimedOut
+			}
+
+			event := service.NewEvent(msg, header, &service.EventMetadata{
+				TraceID:   meta.TraceID,
+				Timestamp: time.Now(),
+			})
+			if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
+				ts.log.Error("failed to publish result to tool gather event", "error", publishErr)
+			}
+		}(t)
+	}
+
+	ts.log.Info("Started code interpreter executions", "count", len(codeToolsToExecute))
+}
+
+// runCodeSnippet executes a snippet in a fresh working directory with CPU, memory, file size, process count and
+// wall-clock limits, as the sandbox user without network access
+func (ts *ToolService) runCodeSnippet(ctx context.Context, cfg *service.CodeInterpreterConfig, t service.StandaloneToolRequestEventMessage) (*codeInterpreterResult, error) {
+	language, _ := t.ToolInput["language"].(string)
+	code, _ := t.ToolInput["code"].(string)
+	if code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+
+	// The limits are applied by the shell before exec-ing the interpreter. The file size is counted in blocks of 512
+	// bytes by a POSIX shell, and the process count is -u in bash and busybox but -p in dash.
+	limits := fmt.Sprintf("ulimit -t %d && ulimit -f %d && { ulimit -u %d 2>/dev/null || ulimit -p %d; }",
+		cfg.MaxCPUSeconds, cfg.MaxFileSizeMB*2048, cfg.MaxProcesses, cfg.MaxProcesses)
+	var fileName string
+	var args []string
+	switch language {
+	case "python":
+		fileName = "main.py"
+		limits += fmt.Sprintf(" && ulimit -v %d", cfg.MaxMemoryMB*1024)
+		args = []string{"-c", limits + ` && exec "$0" "$@"`, cfg.PythonPath, fileName}
+	case "javascript":
+		// V8 reserves far more address space than it uses, so the data segment, which counts the memory written but not
+		// the reservations, is limited instead of the address space, and the heap size below it
+		fileName = "main.js"
+		limits += fmt.Sprintf(" && ulimit -d %d", cfg.MaxMemoryMB*1024)
+		args = []string{"-c", limits + ` && exec "$0" "$@"`, cfg.NodePath, fmt.Sprintf("--max-old-space-size=%d", cfg.MaxMemoryMB), fileName}
+	default:
+		return nil, fmt.Errorf("unsupported language: %s (supported: python, javascript)", language)
+	}
+
+	workDir, err := os.MkdirTemp("", "code-interpreter-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create working directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := os.WriteFile(filepath.Join(workDir, fileName), []byte(code), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write code snippet: %w", err)
+	}
+	// The snippet reads its source and writes its files as the sandbox user
+	for _, path := range []string{workDir, filepath.Join(workDir, fileName)} {
+		if err := os.Chown(path, cfg.SandboxUID, cfg.SandboxGID); err != nil {
+			return nil, fmt.Errorf("failed to give the working directory to the sandbox user: %w", err)
+		}
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: cfg.MaxOutputBytes}
+	stderr := &limitedBuffer{limit: cfg.MaxOutputBytes}
+
+	cmd := exec.CommandContext(execCtx, "/bin/sh", args...)
+	cmd.Dir = workDir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = time.Second
+	if err := sandboxSnippet(cmd, cfg); err != nil {
+		return nil, err
+	}
+	// Do not leak the service environment (credentials, API keys) into the snippet
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + workDir,
+		"TMPDIR=" + workDir,
+		"LANG=C.UTF-8",
+		"MPLBACKEND=Agg",
+	}
+
+	ts.log.Debug("Running code interpreter snippet", "tool_run_id", t.ToolRunId, "language", language, "working_dir", workDir)
+	runErr := cmd.Run()
+
+	result := &codeInterpreterResult{
+		Language: language,
+		Files:    []codeInterpreterFile{},
+		Images:   []codeInterpreterImage{},
+	}
+	if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		result.ExitCode = -1
+	} else if runErr != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(runErr, &exitErr) {
+			return nil, fmt.Errorf("failed to run code snippet: %w", runErr)
+		}
+		result.ExitCode = exitErr.ExitCode()
+	}
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	if err := ts.collectGeneratedFiles(ctx, cfg, t.ToolRunId, workDir, fileName, result); err != nil {
+		ts.log.Warn("Failed to collect generated files", "tool_run_id", t.ToolRunId, "error", err)
+	}
+
+	ts.log.Info("Code interpreter snippet finished",
+		"tool_run_id", t.ToolRunId,
+		"language", language,
+		"exit_code", result.ExitCode,
+		"timed_out", result.TimedOut,
+		"files", len(result.Files),
+	)
+	return result, nil
+}
+
+// collectGeneratedFiles uploads files written by the snippet to S3 and inlines generated images. The files beyond the
+// total size of the configuration are listed without being uploaded nor inlined.
+func (ts *ToolService) collectGeneratedFiles(ctx context.Context, cfg *service.CodeInterpreterConfig, toolRunID string, workDir string, sourceFile string, result *codeInterpreterResult) error {
+	var s3Client *s3.Client
+	if cfg.Bucket != "" && ts.config.Storage != nil && ts.config.Storage.S3 != nil {
+		client, err := service.NewS3Client(ctx, ts.config.Storage.S3)
+		if err != nil {
+			ts.log.Warn("Failed to create S3 client, generated files will not be uploaded", "error", err)
+		} else {
+			s3Client = client
+		}
+	}
+
+	var totalBytes int64
+	return filepath.WalkDir(workDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Skip interpreter caches and hidden directories
+			if path != workDir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "__pycache__" || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		name, err := filepath.Rel(workDir, path)
+		if err != nil || name == sourceFile {
+			return nil
+		}
+		if len(result.Files) >= codeInterpreterMaxFiles {
+			return filepath.SkipAll
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		mediaType := mime.TypeByExtension(filepath.Ext(name))
+		if mediaType == "" {
+			mediaType = "application/octet-stream"
+		}
+		mediaType, _, _ = strings.Cut(mediaType, ";")
+		file := codeInterpreterFile{
+			Name:      filepath.ToSlash(name),
+			MediaType: mediaType,
+			Size:      info.Size(),
+		}
+
+		if totalBytes+info.Size() > cfg.MaxFilesBytes {
+			ts.log.Warn("Generated files exceed the total size, the file is not uploaded", "file", file.Name, "size", file.Size, "max_files_bytes", cfg.MaxFilesBytes)
+			result.Files = append(result.Files, file)
+			return nil
+		}
+		totalBytes += info.Size()
+
+		if s3Client != nil {
+			key := fmt.Sprintf("code_interpreter/%s/%s", toolRunID, file.Name)
+			if err := uploadFileToS3(ctx, s3Client, cfg.Bucket, key, path, mediaType); err != nil {
+				ts.log.Warn("Failed to upload generated file to S3", "file", file.Name, "error", err)
+			} else {
+				file.URL = fmt.Sprintf("s3://%s/%s", cfg.Bucket, key)
+			}
+		}
+
+		if codeInterpreterImageTypes[mediaType] && info.Size() <= codeInterpreterMaxImageBytes {
+			data, err := os.ReadFile(path)
+			if err == nil {
+				result.Images = append(result.Images, codeInterpreterImage{
+					Name:      file.Name,
+					MediaType: mediaType,
+					Data:      base64.StdEncoding.EncodeToString(data),
+				})
+			}
+		}
+
+		result.Files = append(result.Files, file)
+		return nil
+	})
+}
+
+// uploadFileToS3 uploads a local file to the given bucket and key
+func uploadFileToS3(ctx context.Context, client *s3.Client, bucket string, key string, path string, contentType string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &bucket,
+		Key:         &key,
+		Body:        f,
+		ContentType: &contentType,
+	})
+	return err
+}
+
+// Text renders the execution output as the text block returned to the model
+func (r *codeInterpreterResult) Text() string {
+	var sb strings.Builder
+	if r.TimedOut {
+		sb.WriteString("Execution timed out\n")
+	} else {
+		fmt.Fprintf(&sb, "Exit code: %d\n", r.ExitCode)
+	}
+	if r.Stdout != "" {
+		fmt.Fprintf(&sb, "\nstdout:\n%s\n", r.Stdout)
+	}
+	if r.Stderr != "" {
+		fmt.Fprintf(&sb, "\nstderr:\n%s\n", r.Stderr)
+	}
+	if len(r.Files) > 0 {
+		sb.WriteString("\nGenerated files:\n")
+		for _, f := range r.Files {
+			if f.URL != "" {
+				fmt.Fprintf(&sb, "- %s (%s, %d bytes): %s\n", f.Name, f.MediaType, f.Size, f.URL)
+			} else {
+				fmt.Fprintf(&sb, "- %s (%s, %d bytes)\n", f.Name, f.MediaType, f.Size)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Write keeps the bytes that fit within the limit and reports the whole input as written
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if len(p) > remaining {
+		b.truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	b.buf.Write(p)
+	return len(p), nil
+}
+
+// String returns the buffered output with a marker when output was dropped
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + "\n... [output truncated]"
+	}
+	return b.buf.String()
+}
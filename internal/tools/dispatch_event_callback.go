@@ -7,6 +7,7 @@ import (
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/nats-io/nats.go"
 	"github.com/pinazu/internal/agents"
@@ -43,17 +44,42 @@ func (ts *ToolService) dispatchEventCallback(msg *nats.Msg) {
 	// Get the database queries
 	queries := db.New(ts.s.GetDB())
 
+	// The tool uses answered after their task run was cancelled or failed are dropped, the agent loop stops there
+	if req.H.TaskID != nil {
+		taskRun, err := queries.GetLastStartedTaskRunByTaskID(ts.ctx, *req.H.TaskID)
+		if db.IsUnavailable(err) {
+			// The tool use message is buffered below, the cancellation is checked again by the tool runs
+			ts.log.Warn("Database unavailable, cannot check the task run of the tool use message", "task_id", *req.H.TaskID, "error", err)
+		} else if err != nil && err != pgx.ErrNoRows {
+			ts.log.Error("Failed to get task runs", "task_id", *req.H.TaskID, "error", err)
+			return
+		}
+		if err == nil && (taskRun.Status == db.TaskRunStatusCancelled || taskRun.Status == db.TaskRunStatusFailed) {
+			ts.log.Info("Task run ended, dropping the tool use message", "task_id", *req.H.TaskID, "status", taskRun.Status)
+			return
+		}
+	}
+
 	// Add tool request message to the database
-	_, err = queries.CreateAgentMessage(ts.ctx, db.CreateAgentMessageParams{
+	messageParams := db.CreateAgentMessageParams{
 		ThreadID:    *req.H.ThreadID,
 		Message:     req.Msg.Message,
 		SenderID:    req.Msg.AgentId,
 		RecipientID: req.Msg.RecipientId,
 		StopReason:  pgtype.Text{String: "tool_use", Valid: true},
-	})
+	}
+	_, err = queries.CreateAgentMessage(ts.ctx, messageParams)
 	if err != nil {
-		ts.log.Error("Failed to add tool use message to the database", "error", err)
-		return
+		// Keep the tool use of the agent to write it once the database is back
+		if !db.IsUnavailable(err) {
+			ts.log.Error("Failed to add tool use message to the database", "error", err)
+			return
+		}
+		if bufErr := ts.js.BufferMessage(&service.BufferedMessage{Agent: &messageParams}); bufErr != nil {
+			ts.log.Error("Failed to buffer tool use message while the database is unavailable", "error", bufErr)
+			return
+		}
+		ts.log.Warn("Database unavailable, tool use message buffered for replay", "thread_id", *req.H.ThreadID, "error", err)
 	}
 
 	switch req.Msg.Provider {
@@ -77,7 +103,14 @@ func (ts *ToolService) handleAnthropicToolUse(req *service.Event[*service.ToolDi
 	// State initialization
 	var standaloneToolsToExecute []service.StandaloneToolRequestEventMessage
 	var workflowToolsToExecute []service.FlowRunExecuteRequestEventMessage
-	var mcpToolsToExecute []service.StandaloneToolRequestEventMessage
+	var mcpToolsToExecute []mcpToolRequest
+	var codeToolsToExecute []service.StandaloneToolRequestEventMessage
+	var webToolsToExecute []service.StandaloneToolRequestEventMessage
+	var edgeToolsToExecute []service.StandaloneToolRequestEventMessage
+	var internalToolsToExecute []service.StandaloneToolRequestEventMessage
+	var budgetedTools []budgetedToolRun
+	var limitedTools int
+	var cachedTools int
 
 	msg, err := agents.ParseMessage[anthropic.MessageParam](req.Msg.Message)
 	if err != nil {
@@ -127,6 +160,12 @@ func (ts *ToolService) handleAnthropicToolUse(req *service.Event[*service.ToolDi
 		return
 	}
 
+	// Dry runs answer the tool uses with the mock responses of the tools, nothing is recorded in the tool runs
+	if req.H.DryRun {
+		ts.handleDryRunToolUse(req, toolUseBlocks, queries)
+		return
+	}
+
 	// Create a temp parent tool when process parallel, multiple tool use blocks
 	var tempParallelToolManagement db.ToolRun
 	if len(toolUseBlocks) > 1 {
@@ -139,6 +178,7 @@ func (ts *ToolService) handleAnthropicToolUse(req *service.Event[*service.ToolDi
 			ThreadID:     threadID,
 			AgentID:      req.Msg.AgentId,
 			RecipientID:  req.Msg.RecipientId,
+			WorkspaceID:  db.WorkspaceUUID(req.H.Workspace()),
 		})
 		if err != nil {
 			ts.log.Error("Failed to create temp parent tool status", "error", err)
@@ -156,7 +196,7 @@ func (ts *ToolService) handleAnthropicToolUse(req *service.Event[*service.ToolDi
 		}
 
 		// Get tool_type
-		tool, err := queries.GetToolInfoByName(ts.ctx, toolBlock.Name)
+		tool, err := ts.getAgentTool(queries, req.H.Workspace(), req.Msg.AgentId, toolBlock.Name)
 		if err != nil {
 			ts.log.Warn("Failed to get tool", "name", toolBlock.Name, "error", err)
 			continue // Skip if errord
@@ -178,11 +218,13 @@ func (ts *ToolService) handleAnthropicToolUse(req *service.Event[*service.ToolDi
 				ThreadID:     threadID,
 				AgentID:      req.Msg.AgentId,
 				RecipientID:  req.Msg.RecipientId,
+				WorkspaceID:  db.WorkspaceUUID(req.H.Workspace()),
 			})
 			if err != nil {
 				ts.log.Error("Failed to add tool run status to the database", "error", err)
 				continue
 			}
+			ts.recordToolRunAudit(queries, toolBlock.ID, pgtype.Text{}, tool, toolBlockInputJson, req)
 		} else if len(toolUseBlocks) > 1 && tempParallelToolManagement.ID != "" {
 			_, err = queries.CreateChildToolRunStatus(ts.ctx, db.CreateChildToolRunStatusParams{
 				ID:           toolBlock.ID,
@@ -193,64 +235,140 @@ func (ts *ToolService) handleAnthropicToolUse(req *service.Event[*service.ToolDi
 				AgentID:      req.Msg.AgentId,
 				RecipientID:  req.Msg.RecipientId,
 				ParentRunID:  pgtype.Text{String: tempParallelToolManagement.ID, Valid: true},
+				WorkspaceID:  db.WorkspaceUUID(req.H.Workspace()),
 			})
 			if err != nil {
 				ts.log.Error("Failed to add child tool run status to the database (non-batch)", "error", err)
 				continue
 			}
+			// The temp parallel run groups the results for the agent, it is left out of the audit log
+			ts.recordToolRunAudit(queries, toolBlock.ID, pgtype.Text{}, tool, toolBlockInputJson, req)
 		} else {
 			ts.log.Error("Failed to add tool run status to the database. Unexpected error")
 			continue
 		}
 
 		// Process tool recursively and collect all tools to execute
-		processResult := ts.processToolRecursively(toolBlock.ID, toolBlockInputMap, tool, req, queries)
+		processResult := ts.processToolRecursively(toolBlock.ID, toolBlockInputMap, tool, req, queries, 0)
 		standaloneToolsToExecute = append(standaloneToolsToExecute, processResult.StandaloneTools...)
 		workflowToolsToExecute = append(workflowToolsToExecute, processResult.WorkflowTools...)
 		mcpToolsToExecute = append(mcpToolsToExecute, processResult.MCPTools...)
+		codeToolsToExecute = append(codeToolsToExecute, processResult.CodeTools...)
+		webToolsToExecute = append(webToolsToExecute, processResult.WebTools...)
+		edgeToolsToExecute = append(edgeToolsToExecute, processResult.EdgeTools...)
+		internalToolsToExecute = append(internalToolsToExecute, processResult.InternalTools...)
+		budgetedTools = append(budgetedTools, processResult.BudgetedTools...)
+		limitedTools += processResult.LimitedTools
+		cachedTools += processResult.CachedTools
+	}
+
+	if len(standaloneToolsToExecute) == 0 && len(workflowToolsToExecute) == 0 && len(mcpToolsToExecute) == 0 && len(codeToolsToExecute) == 0 && len(webToolsToExecute) == 0 && len(edgeToolsToExecute) == 0 && len(internalToolsToExecute) == 0 && len(budgetedTools) == 0 && limitedTools == 0 && cachedTools == 0 {
+		ts.log.Warn("No tools to execute after processing tool use message")
 	}
 
 	// Execute tools by type using goroutines
+	ts.executeTools(ToolProcessResult{
+		StandaloneTools: standaloneToolsToExecute,
+		WorkflowTools:   workflowToolsToExecute,
+		MCPTools:        mcpToolsToExecute,
+		CodeTools:       codeToolsToExecute,
+		WebTools:        webToolsToExecute,
+		EdgeTools:       edgeToolsToExecute,
+		InternalTools:   internalToolsToExecute,
+	}, req.H, req.M)
+
+	// The tool runs of the task start in the order they were requested, within its budget
+	if len(budgetedTools) > 0 {
+		ts.submitTaskToolRuns(*req.H.TaskID, budgetedTools)
+	}
+}
+
+// executeTools starts the executors of each tool type and waits for them to hand off the tool runs
+func (ts *ToolService) executeTools(toolsToExecute ToolProcessResult, header *service.EventHeaders, meta *service.EventMetadata) {
 	var wg sync.WaitGroup
-	if len(standaloneToolsToExecute) > 0 {
+	if len(toolsToExecute.StandaloneTools) > 0 {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			ts.executeStandaloneTool(standaloneToolsToExecute, req.H, req.M)
+			ts.executeStandaloneTool(toolsToExecute.StandaloneTools, header, meta)
 		}()
 	}
-	if len(workflowToolsToExecute) > 0 {
+	if len(toolsToExecute.WorkflowTools) > 0 {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			ts.executeWorkflowTool(workflowToolsToExecute)
+			ts.executeWorkflowTool(header, toolsToExecute.WorkflowTools)
 		}()
 	}
-	if len(mcpToolsToExecute) > 0 {
+	if len(toolsToExecute.MCPTools) > 0 {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			ts.executeMCPTool(mcpToolsToExecute)
+			ts.executeMCPTool(toolsToExecute.MCPTools, header, meta)
 		}()
 	}
-	if len(standaloneToolsToExecute) == 0 && len(workflowToolsToExecute) == 0 && len(mcpToolsToExecute) == 0 {
-		ts.log.Warn("No tools to execute after processing tool use message")
+	if len(toolsToExecute.CodeTools) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ts.executeCodeInterpreterTool(toolsToExecute.CodeTools, header, meta)
+		}()
+	}
+	if len(toolsToExecute.WebTools) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ts.executeWebTool(toolsToExecute.WebTools, header, meta)
+		}()
+	}
+	if len(toolsToExecute.EdgeTools) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ts.executeEdgeTool(toolsToExecute.EdgeTools, header, meta)
+		}()
+	}
+	if len(toolsToExecute.InternalTools) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ts.executeInternalTool(toolsToExecute.InternalTools, header, meta)
+		}()
 	}
 
 	// Wait for all goroutines to complete
 	wg.Wait()
 }
 
-// processToolRecursively handles tool processing with recursive batch tool support
-func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[string]any, tool db.Tool, req *service.Event[*service.ToolDispatchEventMessage], queries *db.Queries) ToolProcessResult {
+// processToolRecursively handles tool processing with recursive batch tool support.
+// depth is the number of batches enclosing the tool call, 0 for a tool called directly by the agent.
+func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[string]any, tool db.Tool, req *service.Event[*service.ToolDispatchEventMessage], queries *db.Queries, depth int) ToolProcessResult {
 	result := ToolProcessResult{
 		StandaloneTools: []service.StandaloneToolRequestEventMessage{},
 		WorkflowTools:   []service.FlowRunExecuteRequestEventMessage{},
-		MCPTools:        []service.StandaloneToolRequestEventMessage{},
+		MCPTools:        []mcpToolRequest{},
+		CodeTools:       []service.StandaloneToolRequestEventMessage{},
+		WebTools:        []service.StandaloneToolRequestEventMessage{},
+		EdgeTools:       []service.StandaloneToolRequestEventMessage{},
+		InternalTools:   []service.StandaloneToolRequestEventMessage{},
+	}
+
+	// The tools denied by the agent specs are not invoked, whether called directly or in a batch
+	if rule := ts.deniedToolRule(queries, req, tool); rule != nil {
+		ts.publishToolDeniedError(toolRunID, tool, rule, req)
+		return result
+	}
+
+	// The tool runs count against the quota of the day, a batch counts as the tools it runs
+	if tool.BuiltinName() != "batch_tool" {
+		if reason := ts.useToolRunQuota(queries, req); reason != "" {
+			ts.publishToolError(toolRunID, tool.Name, reason, req.H, req.M)
+			return result
+		}
 	}
 
-	// Handle special tool name cases
-	switch tool.Name {
+	// Handle the built-in tools, matched by ID so that no other tool can shadow them
+	switch tool.BuiltinName() {
 	case "batch_tool":
 		ts.log.Info("Tool batch_tool is detected, processing child tools recursively")
 
@@ -260,6 +378,12 @@ func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[st
 			return result
 		}
 
+		// Batches nested too deep or fanning out to too many tools get an error result instead of their children
+		if reason := batchLimitError(ts.config.GetBatchToolConfig(), depth, len(invocations)); reason != "" {
+			ts.publishToolError(toolRunID, tool.Name, reason, req.H, req.M)
+			return result
+		}
+
 		for _, rawChild := range invocations {
 			child, ok := rawChild.(map[string]any)
 			if !ok {
@@ -286,7 +410,7 @@ func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[st
 			childToolInput := child["arguments"].(map[string]any)
 
 			// Validate tool
-			childTool, err := queries.GetToolInfoByName(ts.ctx, child["name"].(string))
+			childTool, err := ts.getAgentTool(queries, req.H.Workspace(), req.Msg.AgentId, child["name"].(string))
 			if err != nil {
 				ts.log.Warn("Failed to get child tool", "name", childTool.Name, "error", err)
 				continue // Skip if error
@@ -332,26 +456,52 @@ func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[st
 				Name:         childTool.Name,
 				Input:        inputJsonRaw,
 				ParentRunID:  pgtype.Text{String: toolRunID, Valid: true},
+				WorkspaceID:  db.WorkspaceUUID(req.H.Workspace()),
 			})
 			if err != nil {
 				ts.log.Error("Failed to add child tool run status to the database (batch)", "error", err)
 				continue
 			}
+			ts.recordToolRunAudit(queries, childToolRunStatus.ID, childToolRunStatus.ParentRunID, childTool, inputJsonRaw, req)
 
 			// Recursively process the child tool (this handles nested batch_tool cases)
-			childResult := ts.processToolRecursively(childToolRunStatus.ID, childToolInput, childTool, req, queries)
+			childResult := ts.processToolRecursively(childToolRunStatus.ID, childToolInput, childTool, req, queries, depth+1)
 			result.StandaloneTools = append(result.StandaloneTools, childResult.StandaloneTools...)
 			result.WorkflowTools = append(result.WorkflowTools, childResult.WorkflowTools...)
 			result.MCPTools = append(result.MCPTools, childResult.MCPTools...)
+			result.CodeTools = append(result.CodeTools, childResult.CodeTools...)
+			result.WebTools = append(result.WebTools, childResult.WebTools...)
+			result.EdgeTools = append(result.EdgeTools, childResult.EdgeTools...)
+			result.InternalTools = append(result.InternalTools, childResult.InternalTools...)
+			result.BudgetedTools = append(result.BudgetedTools, childResult.BudgetedTools...)
+			result.LimitedTools += childResult.LimitedTools
+			result.CachedTools += childResult.CachedTools
 		}
 	case "invoke_agent":
 		ts.log.Info("Tool invoke_tool_agent detected, transfer message to the agent")
 
+		// Every rejected call gets an error result so that the calling agent is not left waiting
+		if violations := db.ValidateToolInput(tool.Config.GetParams(), toolInput); len(violations) > 0 {
+			ts.publishToolInputError(toolRunID, tool.Name, violations, req.H, req.M)
+			return result
+		}
+		query, _ := toolInput["query"].(string)
+		agentID, _ := toolInput["agent_id"].(string)
+		agentHandoffToID, err := uuid.Parse(agentID)
+		if err != nil {
+			ts.publishToolError(toolRunID, tool.Name, fmt.Sprintf("agent_id %q is not a valid agent ID", agentID), req.H, req.M)
+			return result
+		}
+		if req.H.TaskID == nil {
+			ts.publishToolError(toolRunID, tool.Name, "invoke_agent can only be called within a task", req.H, req.M)
+			return result
+		}
+
 		// Create a new user message for the agent
 		message := map[string]any{
 			"role": "user",
 			"content": []map[string]any{
-				{"type": "text", "text": toolInput["query"].(string)},
+				{"type": "text", "text": query},
 			},
 		}
 
@@ -359,26 +509,51 @@ func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[st
 		messageJsonRaw, err := db.NewJsonRaw(message)
 		if err != nil {
 			ts.log.Error("Failed to marshal new created user message for invoke_agent", "error", err)
+			ts.publishToolError(toolRunID, tool.Name, "Failed to create the message of the invoked agent", req.H, req.M)
+			return result
 		}
-		// Parse agent_id from string to UUID
-		agentHandoffToID, err := uuid.Parse(toolInput["agent_id"].(string))
+
+		// An agent already in the chain of invoked agents would invoke the others again without end
+		chain, err := ts.agentChain(queries, req.H.TaskID, req.Msg.AgentId)
 		if err != nil {
-			ts.log.Error("Failed to parse agent_id as UUID", "agent_id", toolInput["agent_id"], "error", err)
+			ts.log.Error("Failed to get the chain of invoked agents", "task_id", req.H.TaskID, "error", err)
+			ts.publishToolError(toolRunID, tool.Name, "Failed to get the chain of invoked agents", req.H, req.M)
+			return result
+		}
+		if reason := invokeAgentCycleError(chain, agentHandoffToID); reason != "" {
+			ts.publishToolError(toolRunID, tool.Name, reason, req.H, req.M)
 			return result
 		}
 
+		// The task service tracks the sub-agent run and gives its response, or its failure, as the tool result
 		event := service.NewEvent(&service.TaskHandoffEventMessage{
 			ToolRunId:        toolRunID,
 			AgentID:          req.Msg.AgentId,
 			AgentHandoffToID: agentHandoffToID,
 			Messages:         []db.JsonRaw{messageJsonRaw},
+			TimeoutSeconds:   ts.config.GetInvokeAgentConfig().TimeoutSeconds,
 		}, req.H, req.M)
 		err = event.Publish(ts.s.GetNATS())
 		if err != nil {
 			ts.log.Error("Failed to publish task handoff event for invoke_agent", "error", err)
+			ts.publishToolError(toolRunID, tool.Name, "Failed to invoke the agent", req.H, req.M)
 		}
 
 		// Don't add invoke_tool_agent to any execution list as they are handled separately
+	case CodeInterpreterToolName:
+		ts.log.Info("Tool code_interpreter detected, executing snippet in a sandboxed process")
+		result.CodeTools = append(result.CodeTools, service.StandaloneToolRequestEventMessage{
+			ToolRunId: toolRunID,
+			ToolName:  tool.Name,
+			ToolInput: toolInput,
+		})
+	case WebSearchToolName, FetchURLToolName:
+		ts.log.Info("Built-in web tool detected", "tool_name", tool.Name)
+		result.WebTools = append(result.WebTools, service.StandaloneToolRequestEventMessage{
+			ToolRunId: toolRunID,
+			ToolName:  tool.Name,
+			ToolInput: toolInput,
+		})
 	default:
 		// Regular tool - categorize by tool tydepe
 		switch tool.Config.Type {
@@ -388,14 +563,30 @@ func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[st
 				ts.log.Error("Failed to get standalone config for tool", "tool_name", tool.Name)
 				break
 			}
-			result.StandaloneTools = append(result.StandaloneTools, service.StandaloneToolRequestEventMessage{
+			if violations := db.ValidateToolInput(standaloneConfig.Params, toolInput); len(violations) > 0 {
+				ts.publishToolInputError(toolRunID, tool.Name, violations, req.H, req.M)
+				break
+			}
+			request := service.StandaloneToolRequestEventMessage{
 				ToolRunId:  toolRunID,
 				ToolName:   tool.Name,
 				ToolInput:  toolInput,
 				ToolURL:    standaloneConfig.Url,
 				ToolAPIKey: standaloneConfig.ApiKey,
-			})
+			}
+			if standaloneConfig.Edge {
+				request.EdgeGroup = standaloneConfig.GetEdgeGroup()
+				result.EdgeTools = append(result.EdgeTools, request)
+				break
+			}
+			result.StandaloneTools = append(result.StandaloneTools, request)
 		case db.ToolTypeWorkflow:
+			if workflowConfig := tool.Config.GetWorkflow(); workflowConfig != nil {
+				if violations := db.ValidateToolInput(workflowConfig.Params, toolInput); len(violations) > 0 {
+					ts.publishToolInputError(toolRunID, tool.Name, violations, req.H, req.M)
+					break
+				}
+			}
 			flowRunID := uuid.NewSHA1(uuid.Nil, []byte(toolRunID))
 			result.WorkflowTools = append(result.WorkflowTools, service.FlowRunExecuteRequestEventMessage{
 				FlowId:     tool.ID,
@@ -404,7 +595,35 @@ func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[st
 				Engine:     "process", // Default engine
 			})
 		case db.ToolTypeMCP:
-			result.MCPTools = append(result.MCPTools, service.StandaloneToolRequestEventMessage{
+			mcpConfig := tool.Config.GetMCP()
+			if mcpConfig == nil {
+				ts.log.Error("Failed to get MCP config for tool", "tool_name", tool.Name)
+				break
+			}
+			// The schema is only known once discovered from the MCP server
+			if violations := db.ValidateToolInput(mcpConfig.Params, toolInput); len(violations) > 0 {
+				ts.publishToolInputError(toolRunID, tool.Name, violations, req.H, req.M)
+				break
+			}
+			result.MCPTools = append(result.MCPTools, mcpToolRequest{
+				StandaloneToolRequestEventMessage: service.StandaloneToolRequestEventMessage{
+					ToolRunId: toolRunID,
+					ToolName:  tool.Name,
+					ToolInput: toolInput,
+				},
+				Config: mcpConfig,
+			})
+		case db.ToolTypeInternal:
+			registered, ok := registeredInternalTool(tool)
+			if !ok {
+				ts.publishToolError(toolRunID, tool.Name, fmt.Sprintf("internal tool %s is not registered in this tools service", tool.Name), req.H, req.M)
+				break
+			}
+			if violations := db.ValidateToolInput(registered.schema, toolInput); len(violations) > 0 {
+				ts.publishToolInputError(toolRunID, tool.Name, violations, req.H, req.M)
+				break
+			}
+			result.InternalTools = append(result.InternalTools, service.StandaloneToolRequestEventMessage{
 				ToolRunId: toolRunID,
 				ToolName:  tool.Name,
 				ToolInput: toolInput,
@@ -417,5 +636,21 @@ func (ts *ToolService) processToolRecursively(toolRunID string, toolInput map[st
 		}
 	}
 
+	// Identical calls of a cacheable tool within its TTL reuse the cached result instead of invoking the tool
+	if tool.BuiltinName() != "batch_tool" && !result.isEmpty() && ts.serveCachedToolResult(toolRunID, toolInput, tool, queries, req.H, req.M) {
+		return ToolProcessResult{CachedTools: 1}
+	}
+
+	// Calls within a task share its budget of outstanding runs, they start once the dispatch is done
+	if tool.BuiltinName() != "batch_tool" && ts.budget != nil && req.H.TaskID != nil && result.budgeted() {
+		return ToolProcessResult{BudgetedTools: []budgetedToolRun{{toolRunID: toolRunID, tool: tool, tools: result, header: req.H, meta: req.M}}}
+	}
+
+	// Calls of a tool with limits queue on the limiter instead of starting with the rest of the message
+	if tool.BuiltinName() != "batch_tool" && tool.Config.Limits.Enabled() && !result.isEmpty() {
+		ts.executeLimitedTool(toolRunID, tool, result, req.H, req.M)
+		return ToolProcessResult{LimitedTools: 1}
+	}
+
 	return result
 }
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// handleDryRunToolUse answers the tool uses of a dry run with the mock responses of the tools instead of invoking them.
+// Nothing is recorded in the tool runs, the results are returned to the agent at once in a single message.
+func (ts *ToolService) handleDryRunToolUse(req *service.Event[*service.ToolDispatchEventMessage], toolUseBlocks []*anthropic.ToolUseBlockParam, queries *db.Queries) {
+	resultMessages := anthropic.MessageParam{
+		Role:    anthropic.MessageParamRoleUser,
+		Content: []anthropic.ContentBlockParamUnion{},
+	}
+	for _, toolBlock := range toolUseBlocks {
+		content, isError := ts.dryRunToolResult(req, queries, toolBlock.Name, toolBlock.Input, 0)
+		resultMessages.Content = append(resultMessages.Content, anthropic.ContentBlockParamUnion{
+			OfToolResult: ts.createToolResultBlock(toolBlock.ID, content, isError),
+		})
+	}
+
+	messages, err := db.NewJsonRaw(resultMessages)
+	if err != nil {
+		ts.log.Error("Unable to create new jsonRaw for dry run result message", "error", err)
+		return
+	}
+	event := service.NewEvent(&service.TaskExecuteEventMessage{
+		AgentId:     req.Msg.AgentId,
+		RecipientId: req.Msg.RecipientId,
+		Messages:    []db.JsonRaw{messages},
+	}, req.H, &service.EventMetadata{
+		TraceID:   req.M.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("Failed to publish to task execute event", "error", err)
+	}
+}
+
+// dryRunToolResult returns the result the agent receives for a tool call of a dry run. The calls are checked like
+// in a real run, the valid ones get the mock response of the tool and a batch gets the results of its invocations.
+// depth is the number of batches enclosing the tool call, 0 for a tool called directly by the agent.
+func (ts *ToolService) dryRunToolResult(req *service.Event[*service.ToolDispatchEventMessage], queries *db.Queries, name string, input any, depth int) ([]anthropic.ToolResultBlockParamContentUnion, bool) {
+	toolInput, ok := input.(map[string]any)
+	if !ok {
+		return ts.dryRunErrorResult(fmt.Sprintf("Invalid input for tool %s, the arguments must be a JSON object", name))
+	}
+	tool, err := ts.getAgentTool(queries, req.H.Workspace(), req.Msg.AgentId, name)
+	if err != nil {
+		ts.log.Warn("Failed to get tool", "name", name, "error", err)
+		return ts.dryRunErrorResult(fmt.Sprintf("Tool %s is not available to this agent", name))
+	}
+	ts.log.Info("Dry run, returning the mock response of the tool", "tool_name", tool.Name)
+
+	if rule := ts.deniedToolRule(queries, req, tool); rule != nil {
+		content, err := toolDeniedContent(tool, rule)
+		return ts.dryRunContent(content, err, true)
+	}
+
+	if tool.BuiltinName() == "batch_tool" {
+		invocations, ok := toolInput["invocations"].([]any)
+		if !ok {
+			return ts.dryRunErrorResult("Invalid input for tool batch_tool, 'invocations' must be a list")
+		}
+		if reason := batchLimitError(ts.config.GetBatchToolConfig(), depth, len(invocations)); reason != "" {
+			return ts.dryRunErrorResult(reason)
+		}
+
+		// Like in a real run, the batch result is the flat list of the results of its invocations
+		var content []anthropic.ToolResultBlockParamContentUnion
+		for _, rawChild := range invocations {
+			child, _ := rawChild.(map[string]any)
+			childName, _ := child["name"].(string)
+			childInput := child["arguments"]
+			if arguments, ok := childInput.(string); ok {
+				var parsed map[string]any
+				if err := json.Unmarshal([]byte(arguments), &parsed); err == nil {
+					childInput = parsed
+				}
+			}
+			childContent, _ := ts.dryRunToolResult(req, queries, childName, childInput, depth+1)
+			content = append(content, childContent...)
+		}
+		return content, false
+	}
+
+	if violations := db.ValidateToolInput(tool.Config.GetParams(), toolInput); len(violations) > 0 {
+		content, err := toolInputErrorContent(tool.Name, violations)
+		return ts.dryRunContent(content, err, true)
+	}
+	mock := tool.MockResponse()
+	content, err := db.NewJsonRaw(mock.Response)
+	if response, _ := mock.Response.(map[string]any); err == nil && !mock.IsError {
+		// The text results are read from their text field, the other mock responses are given to the agent as JSON
+		if _, ok := response["text"].(string); !ok {
+			content, err = db.NewJsonRaw(map[string]string{"text": string(content)})
+		}
+	}
+	return ts.dryRunContent(content, err, mock.IsError)
+}
+
+// dryRunErrorResult returns an error tool result with the reason
+func (ts *ToolService) dryRunErrorResult(reason string) ([]anthropic.ToolResultBlockParamContentUnion, bool) {
+	content, err := db.NewJsonRaw(map[string]string{"error": reason})
+	return ts.dryRunContent(content, err, true)
+}
+
+// dryRunContent converts a dry run result to the content of its tool result block
+func (ts *ToolService) dryRunContent(result db.JsonRaw, err error, isError bool) ([]anthropic.ToolResultBlockParamContentUnion, bool) {
+	if err == nil {
+		var content []anthropic.ToolResultBlockParamContentUnion
+		if content, err = ts.createToolResultContent(result, db.ResultMessageTypeText, isError); err == nil {
+			return content, isError
+		}
+	}
+	ts.log.Error("Failed to create dry run tool result", "error", err)
+	return []anthropic.ToolResultBlockParamContentUnion{{OfText: &anthropic.TextBlockParam{Text: "Failed to create the mock response of the tool"}}}, true
+}
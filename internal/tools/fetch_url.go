@@ -0,0 +1,494 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// FetchURLToolName is the name of the built-in URL fetch tool
+const FetchURLToolName = "fetch_url"
+
+var (
+	// errPrivateAddress is returned when a fetch resolves to a loopback, private, link-local or multicast address
+	errPrivateAddress = errors.New("fetching private network addresses is not allowed")
+
+	whitespaceRegexp = regexp.MustCompile(`\s+`)
+	blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+)
+
+// newFetchHTTPClient creates the HTTP client used to fetch URLs.
+// Unless private networks are allowed, connections to loopback, private, link-local and multicast addresses are refused after DNS resolution.
+func newFetchHTTPClient(cfg *service.FetchURLConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || db.IsPrivateNetworkAddress(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   time.Duration(cfg.TimeoutSeconds) * time.Second,
+		Transport: transport,
+	}
+}
+
+// runFetchURL downloads a web page and converts it to markdown
+func (ts *ToolService) runFetchURL(ctx context.Context, cfg *service.FetchURLConfig, input map[string]any) (string, error) {
+	rawURL, _ := input["url"].(string)
+	target, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || target.Host == "" || (target.Scheme != "http" && target.Scheme != "https") {
+		return "", fmt.Errorf("url must be an absolute http or https URL")
+	}
+
+	maxChars := cfg.MaxChars
+	if v, ok := input["max_chars"].(float64); ok && v > 0 {
+		maxChars = min(int(v), cfg.MaxChars)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9,*/*;q=0.5")
+
+	resp, err := newFetchHTTPClient(cfg).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to fetch %s: HTTP %d", target, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, cfg.MaxBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "" {
+		mediaType = http.DetectContentType(body)
+		mediaType, _, _ = mime.ParseMediaType(mediaType)
+	}
+
+	var title, content string
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		title, content, err = htmlToMarkdown(string(body), resp.Request.URL)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert HTML: %w", err)
+		}
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml"):
+		content = strings.TrimSpace(string(body))
+	default:
+		return "", fmt.Errorf("unsupported content type: %s", mediaType)
+	}
+	ts.log.Debug("Fetched URL", "url", resp.Request.URL.String(), "content_type", mediaType, "bytes", len(body))
+
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", title)
+	}
+	fmt.Fprintf(&b, "Source: %s\n\n", resp.Request.URL)
+	if len(content) > maxChars {
+		b.WriteString(truncateUTF8(content, maxChars))
+		fmt.Fprintf(&b, "\n\n... [content truncated at %d characters]", maxChars)
+	} else {
+		b.WriteString(content)
+	}
+	return b.String(), nil
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a multi-byte character
+func truncateUTF8(s string, n int) string {
+	for n > 0 && n < len(s) && s[n]&0xC0 == 0x80 {
+		n--
+	}
+	return s[:n]
+}
+
+// markdownWriter accumulates markdown output and tracks line starts, so block
+// elements can be separated by blank lines and nested content can be indented.
+type markdownWriter struct {
+	b             strings.Builder
+	prefix        string // written at the start of every line (list indentation, blockquote markers)
+	atLineStart   bool
+	endsWithLine  bool // the output ends with a newline
+	endsWithBlank bool // the output ends with a blank line
+	pendingBlank  bool // a blank line is written before the next text
+}
+
+// text writes inline text, collapsing leading whitespace at the start of a line
+func (w *markdownWriter) text(s string) {
+	if w.atLineStart {
+		s = strings.TrimLeft(s, " ")
+		if s == "" {
+			return
+		}
+		w.flushBlank()
+		w.b.WriteString(w.prefix)
+		w.atLineStart = false
+	}
+	if s == "" {
+		return
+	}
+	w.b.WriteString(s)
+	w.endsWithLine = false
+	w.endsWithBlank = false
+}
+
+// flushBlank writes a pending blank line. The blank line is written lazily so
+// it carries the prefix of the content that follows it.
+func (w *markdownWriter) flushBlank() {
+	if w.pendingBlank {
+		w.b.WriteString(strings.TrimRight(w.prefix, " ") + "\n")
+		w.pendingBlank = false
+		w.endsWithBlank = true
+	}
+}
+
+// lineBreak ends the current line
+func (w *markdownWriter) lineBreak() {
+	if w.b.Len() == 0 || w.endsWithLine {
+		return
+	}
+	w.b.WriteString("\n")
+	w.endsWithLine = true
+	w.atLineStart = true
+}
+
+// blankLine separates block elements with an empty line
+func (w *markdownWriter) blankLine() {
+	if w.b.Len() == 0 || w.endsWithBlank {
+		return
+	}
+	w.lineBreak()
+	w.pendingBlank = true
+}
+
+// markdownConverter converts an HTML tree to markdown
+type markdownConverter struct {
+	w         markdownWriter
+	base      *url.URL
+	listDepth int
+}
+
+// htmlToMarkdown converts an HTML document to markdown and returns its title.
+// Only the main content is converted when the page has a <main> or <article> element.
+func htmlToMarkdown(document string, base *url.URL) (string, string, error) {
+	doc, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return "", "", err
+	}
+
+	var title string
+	if n := findElement(doc, atom.Title); n != nil {
+		title = strings.TrimSpace(whitespaceRegexp.ReplaceAllString(textContent(n), " "))
+	}
+
+	root := findElement(doc, atom.Main)
+	if root == nil {
+		root = findElement(doc, atom.Article)
+	}
+	if root == nil {
+		root = findElement(doc, atom.Body)
+	}
+	if root == nil {
+		root = doc
+	}
+
+	c := &markdownConverter{base: base, w: markdownWriter{atLineStart: true}}
+	c.children(root)
+	content := blankLinesRegexp.ReplaceAllString(c.w.b.String(), "\n\n")
+	return title, strings.TrimSpace(content), nil
+}
+
+func (c *markdownConverter) children(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.node(child)
+	}
+}
+
+func (c *markdownConverter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.w.text(whitespaceRegexp.ReplaceAllString(n.Data, " "))
+		return
+	case html.ElementNode:
+	default:
+		c.children(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Template, atom.Svg, atom.Iframe, atom.Head,
+		atom.Form, atom.Button, atom.Select, atom.Input, atom.Textarea, atom.Nav, atom.Footer:
+		// Non-content elements are dropped
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		c.w.blankLine()
+		c.w.text(strings.Repeat("#", level) + " " + inlineText(n))
+		c.w.blankLine()
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Main, atom.Header, atom.Aside,
+		atom.Figure, atom.Figcaption, atom.Dl, atom.Dt, atom.Dd, atom.Details, atom.Summary:
+		c.w.blankLine()
+		c.children(n)
+		c.w.blankLine()
+	case atom.Br:
+		c.w.lineBreak()
+	case atom.Hr:
+		c.w.blankLine()
+		c.w.text("---")
+		c.w.blankLine()
+	case atom.Strong, atom.B:
+		c.inline(n, "**")
+	case atom.Em, atom.I:
+		c.inline(n, "*")
+	case atom.Code:
+		if text := strings.TrimSpace(textContent(n)); text != "" {
+			c.w.text("`" + text + "`")
+		}
+	case atom.Pre:
+		c.pre(n)
+	case atom.A:
+		c.link(n)
+	case atom.Img:
+		if src := c.resolve(attr(n, "src")); src != "" && !strings.HasPrefix(src, "data:") {
+			c.w.text(fmt.Sprintf("![%s](%s)", attr(n, "alt"), src))
+		}
+	case atom.Ul, atom.Ol:
+		c.list(n)
+	case atom.Blockquote:
+		c.w.blankLine()
+		c.w.flushBlank()
+		prefix := c.w.prefix
+		c.w.prefix += "> "
+		c.children(n)
+		c.w.prefix = prefix
+		c.w.blankLine()
+	case atom.Table:
+		c.table(n)
+	default:
+		c.children(n)
+	}
+}
+
+// inline wraps the text of an inline element in a markdown delimiter
+func (c *markdownConverter) inline(n *html.Node, delimiter string) {
+	raw := textContent(n)
+	text := inlineText(n)
+	if text == "" {
+		return
+	}
+	// Keep the surrounding whitespace outside of the delimiters
+	if strings.TrimLeft(raw, " \t\n") != raw {
+		c.w.text(" ")
+	}
+	c.w.text(delimiter + text + delimiter)
+	if strings.TrimRight(raw, " \t\n") != raw {
+		c.w.text(" ")
+	}
+}
+
+func (c *markdownConverter) link(n *html.Node) {
+	text := inlineText(n)
+	href := c.resolve(attr(n, "href"))
+	if text == "" {
+		return
+	}
+	if href == "" || strings.HasPrefix(href, "javascript:") || strings.HasPrefix(href, "#") {
+		c.w.text(text)
+		return
+	}
+	c.w.text(fmt.Sprintf("[%s](%s)", text, href))
+}
+
+func (c *markdownConverter) pre(n *html.Node) {
+	language := ""
+	if code := findElement(n, atom.Code); code != nil {
+		for _, class := range strings.Fields(attr(code, "class")) {
+			if lang, ok := strings.CutPrefix(class, "language-"); ok {
+				language = lang
+				break
+			}
+		}
+	}
+	c.w.blankLine()
+	c.w.text("```" + language)
+	for line := range strings.SplitSeq(strings.Trim(textContent(n), "\n"), "\n") {
+		c.w.lineBreak()
+		// Preserve indentation inside code blocks
+		c.w.b.WriteString(c.w.prefix + line)
+		c.w.atLineStart = false
+		c.w.endsWithLine = false
+	}
+	c.w.lineBreak()
+	c.w.text("```")
+	c.w.blankLine()
+}
+
+func (c *markdownConverter) list(n *html.Node) {
+	if c.listDepth == 0 {
+		c.w.blankLine()
+	} else {
+		c.w.lineBreak()
+	}
+	c.listDepth++
+
+	index := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		index = start
+	}
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", index)
+			index++
+		}
+		c.w.lineBreak()
+		c.w.text(marker)
+		prefix := c.w.prefix
+		c.w.prefix += strings.Repeat(" ", len(marker))
+		c.children(item)
+		c.w.prefix = prefix
+		c.w.lineBreak()
+	}
+
+	c.listDepth--
+	if c.listDepth == 0 {
+		c.w.blankLine()
+	}
+}
+
+func (c *markdownConverter) table(n *html.Node) {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom != atom.Tr {
+				walk(child)
+				continue
+			}
+			var row []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Th || cell.DataAtom == atom.Td) {
+					row = append(row, strings.ReplaceAll(inlineText(cell), "|", `\|`))
+				}
+			}
+			if len(row) > 0 {
+				rows = append(rows, row)
+			}
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	c.w.blankLine()
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		c.w.lineBreak()
+		c.w.text("| " + strings.Join(row, " | ") + " |")
+		if i == 0 {
+			c.w.lineBreak()
+			c.w.text("|" + strings.Repeat(" --- |", columns))
+		}
+	}
+	c.w.blankLine()
+}
+
+// resolve returns the absolute form of a link relative to the fetched page
+func (c *markdownConverter) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || c.base == nil {
+		return ref
+	}
+	u, err := c.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+// inlineText returns the whitespace-collapsed text of a node
+func inlineText(n *html.Node) string {
+	return strings.TrimSpace(whitespaceRegexp.ReplaceAllString(textContent(n), " "))
+}
+
+// textContent returns the concatenated text of a node, skipping scripts and styles
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.Type == html.ElementNode && (n.DataAtom == atom.Script || n.DataAtom == atom.Style) {
+		return ""
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+// findElement returns the first element of the given type in document order
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -10,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/agents"
 	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/service"
 )
@@ -41,6 +43,10 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 	)
 	ts.log.Debug("Tool result", "result", req.Msg.Content)
 
+	// The run is over, free its slots for the calls queued on the tool limits and on the task budget
+	ts.limiter.Release(req.Msg.ToolRunId)
+	ts.releaseTaskToolRun(req.Msg.ToolRunId)
+
 	// Get the database queries
 	queries := db.New(ts.s.GetDB())
 
@@ -54,6 +60,10 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 		ts.log.Error("Failed to get tool run status", "error", err)
 		return
 	}
+	if toolRunStatus.Status == db.ToolRunStatusCancelled {
+		ts.log.Info("Tool run cancelled with its task run, dropping the late result", "tool_run_id", req.Msg.ToolRunId)
+		return
+	}
 
 	// Calculate duration as the difference between timestamps in seconds
 	durationSeconds := req.M.Timestamp.Sub(toolRunStatus.CreatedAt.Time).Seconds()
@@ -65,11 +75,24 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 		status = db.ToolRunStatusFailed
 	}
 
+	// Upload the results too large for the database to object storage, the tool run keeps a reference
+	result := req.Msg.Content // Store the actual result content
+	offloaded, err := ts.offloader.offload(ts.ctx, req.Msg.ToolRunId, req.Msg.Content)
+	if err != nil {
+		ts.log.Warn("Failed to offload tool result, storing it in the database", "tool_run_id", req.Msg.ToolRunId, "error", err)
+	} else if offloaded != nil {
+		if result, err = db.NewJsonRaw(offloaded); err != nil {
+			ts.log.Error("Failed to create tool result reference", "tool_run_id", req.Msg.ToolRunId, "error", err)
+			return
+		}
+		ts.log.Info("Offloaded tool result", "tool_run_id", req.Msg.ToolRunId, "url", offloaded.URL, "size_bytes", offloaded.SizeBytes)
+	}
+
 	toolRunStatus, err = queries.UpdateToolRunStatusByID(
 		ts.ctx,
 		db.UpdateToolRunStatusByIDParams{
 			ID:       req.Msg.ToolRunId,
-			Result:   req.Msg.Content, // Store the actual result content
+			Result:   result,
 			Status:   status,
 			Duration: duration,
 		},
@@ -79,13 +102,21 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 		return
 	}
 	ts.log.Info("Updated tool run status", "tool_run_id", req.Msg.ToolRunId, "status", status)
+	ts.completeToolRunAudit(queries, req.Msg.ToolRunId, result, status, duration)
+
+	// How the agent receives the offloaded results, only looked up when a result was offloaded
+	agentID := toolRunStatus.AgentID
+	resultSpecs := sync.OnceValue(func() agents.ToolResultSpecs {
+		return ts.agentToolResultSpecs(queries, req.H.Workspace(), agentID)
+	})
 
 	// Create tool result block using helper function
-	toolResultBlock, err := ts.createToolResultBlock(toolRunStatus.ID, req.Msg.Content, req.Msg.ResultType, req.Msg.IsError)
+	content, err := ts.toolResultContent(req.Msg.Content, offloaded, req.Msg.ResultType, req.Msg.IsError, resultSpecs)
 	if err != nil {
 		ts.log.Error("Failed to create tool result block", "error", err)
 		return
 	}
+	toolResultBlock := ts.createToolResultBlock(toolRunStatus.ID, content, req.Msg.IsError)
 
 	// Create anthropic Message
 	resultMessages := anthropic.MessageParam{
@@ -135,6 +166,7 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 			return
 		}
 		ts.log.Info("Update parent tool run status to SUCCESS", "tool_run_id", parentToolRunStatus.ID)
+		ts.completeToolRunAudit(queries, parentToolRunStatus.ID, nil, db.ToolRunStatusSuccess, duration)
 
 		// Get the parent tool run name
 		isTempParallelToolManagement, err := queries.IsTempParallelToolManagement(ts.ctx, parentToolRunStatus.ID)
@@ -164,21 +196,17 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 				}
 
 				// Infer result type from stored content structure
-				var resultType db.ResultMessageType = db.ResultMessageTypeText // Default to text
-				var resultContent map[string]any
-				if err := json.Unmarshal(childToolRun.Result, &resultContent); err == nil {
-					if resultContent["type"] == "image" || resultContent["media_type"] != nil {
-						resultType = db.ResultMessageTypeImage
-					}
-				}
+				result, ref := ts.resolveStoredToolResult(childToolRun.ID, childToolRun.Result, resultSpecs)
+				resultType := inferResultType(result)
 
 				// Create tool result block using helper function
 				isError := childToolRun.Status == db.ToolRunStatusFailed
-				toolResultBlock, err := ts.createToolResultBlock(childToolRun.ID, childToolRun.Result, resultType, isError)
+				content, err := ts.toolResultContent(result, ref, resultType, isError, resultSpecs)
 				if err != nil {
 					ts.log.Error("Failed to create child tool result block", "child_id", childToolRun.ID, "error", err)
 					continue
 				}
+				toolResultBlock := ts.createToolResultBlock(childToolRun.ID, content, isError)
 
 				// Add this tool result to the message
 				resultMessages.Content = append(resultMessages.Content,
@@ -210,16 +238,13 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 
 				// Determine result type and error status
 				var resultType db.ResultMessageType = db.ResultMessageTypeText // Default to text
+				var ref *db.OffloadedToolResult
 				isError := childToolRun.Status == db.ToolRunStatusFailed
 
 				if childToolRun.Result != nil {
 					// Infer result type from stored content structure
-					var resultContent map[string]any
-					if err := json.Unmarshal(childToolRun.Result, &resultContent); err == nil {
-						if resultContent["type"] == "image" || resultContent["media_type"] != nil {
-							resultType = db.ResultMessageTypeImage
-						}
-					}
+					childToolRun.Result, ref = ts.resolveStoredToolResult(childToolRun.ID, childToolRun.Result, resultSpecs)
+					resultType = inferResultType(childToolRun.Result)
 				} else {
 					// Handle null result - create appropriate error/success message
 					var fallbackResult map[string]any
@@ -236,7 +261,7 @@ func (ts *ToolService) gatherEventCallback(msg *nats.Msg) {
 				}
 
 				// Use the existing createToolResultContent function to get proper content blocks
-				childContentBlocks, err := ts.createToolResultContent(childToolRun.Result, resultType, isError)
+				childContentBlocks, err := ts.toolResultContent(childToolRun.Result, ref, resultType, isError, resultSpecs)
 				if err != nil {
 					ts.log.Error("Failed to create content for child tool", "child_id", childToolRun.ID, "error", err)
 					// Create fallback error content block
@@ -404,6 +429,36 @@ func (ts *ToolService) createToolResultContent(resultContent db.JsonRaw, resultT
 		content = append(content, anthropic.ToolResultBlockParamContentUnion{
 			OfImage: imageBlock,
 		})
+
+	case db.ResultMessageTypeCode:
+		// Unmarshal JSON the code interpreter result
+		var codeResult codeInterpreterResult
+		if err := json.Unmarshal(resultContent, &codeResult); err != nil {
+			return nil, fmt.Errorf("unable to read the code interpreter result: %w", err)
+		}
+
+		content = append(content, anthropic.ToolResultBlockParamContentUnion{
+			OfText: &anthropic.TextBlockParam{
+				Type: "text",
+				Text: codeResult.Text(),
+			},
+		})
+
+		// Return generated images as image blocks so the model can see them
+		for _, image := range codeResult.Images {
+			content = append(content, anthropic.ToolResultBlockParamContentUnion{
+				OfImage: &anthropic.ImageBlockParam{
+					Type: "image",
+					Source: anthropic.ImageBlockParamSourceUnion{
+						OfBase64: &anthropic.Base64ImageSourceParam{
+							Type:      "base64",
+							Data:      image.Data,
+							MediaType: anthropic.Base64ImageSourceMediaType(image.MediaType),
+						},
+					},
+				},
+			})
+		}
 	}
 
 	return content, nil
@@ -431,14 +486,21 @@ func (ts *ToolService) shouldUseCacheControl(content []anthropic.ToolResultBlock
 	return false
 }
 
-// createToolResultBlock creates a complete tool result block with proper content and cache control
-func (ts *ToolService) createToolResultBlock(toolRunID string, resultContent db.JsonRaw, resultType db.ResultMessageType, isError bool) (*anthropic.ToolResultBlockParam, error) {
-	// Create tool result content
-	content, err := ts.createToolResultContent(resultContent, resultType, isError)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create tool result content: %w", err)
+// inferResultType infers the type of a result read from the tool runs from its content structure
+func inferResultType(result db.JsonRaw) db.ResultMessageType {
+	var resultContent map[string]any
+	if err := json.Unmarshal(result, &resultContent); err == nil {
+		if resultContent["type"] == "image" || resultContent["media_type"] != nil {
+			return db.ResultMessageTypeImage
+		} else if _, ok := resultContent["exit_code"]; ok {
+			return db.ResultMessageTypeCode
+		}
 	}
+	return db.ResultMessageTypeText // Default to text
+}
 
+// createToolResultBlock creates a complete tool result block with proper content and cache control
+func (ts *ToolService) createToolResultBlock(toolRunID string, content []anthropic.ToolResultBlockParamContentUnion, isError bool) *anthropic.ToolResultBlockParam {
 	// Create tool result block
 	toolResultBlock := &anthropic.ToolResultBlockParam{
 		Type:      "tool_result",
@@ -454,5 +516,5 @@ func (ts *ToolService) createToolResultBlock(toolRunID string, resultContent db.
 		}
 	}
 
-	return toolResultBlock, nil
+	return toolResultBlock
 }
@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// DefaultHealthCheckInterval is the time between two checks when the health check does not set one
+	DefaultHealthCheckInterval time.Duration = 60 * time.Second
+
+	// DefaultHealthCheckTimeout is the timeout of a check when the health check does not set one
+	DefaultHealthCheckTimeout time.Duration = 10 * time.Second
+
+	// DefaultHealthCheckFailureThreshold is the number of failed checks in a row before a tool is unreachable
+	DefaultHealthCheckFailureThreshold int32 = 3
+
+	// healthCheckMaxMessage bounds the error message recorded for a failed check
+	healthCheckMaxMessage = 512
+)
+
+// errCommandHealthCheckDisabled is recorded for the command health checks when the configuration does not allow them
+var errCommandHealthCheckDisabled = errors.New("command health checks are disabled by the tools service configuration")
+
+// toolHealthState is the probing state of a single tool
+type toolHealthState struct {
+	status    db.ToolStatus
+	failures  int32 // Failed checks in a row
+	nextCheck time.Time
+}
+
+// runHealthProber checks the tools with a health check in the background and records their status.
+// Each instance of the tools service probes every tool, the statuses are written by the last check.
+func (ts *ToolService) runHealthProber(cfg *service.ToolHealthCheckConfig) {
+	ticker := time.NewTicker(time.Duration(cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	states := make(map[uuid.UUID]*toolHealthState)
+	for {
+		ts.probeDueTools(cfg, states)
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeDueTools runs the health checks of the tools due for a check
+func (ts *ToolService) probeDueTools(cfg *service.ToolHealthCheckConfig, states map[uuid.UUID]*toolHealthState) {
+	queries := db.New(ts.s.GetDB())
+	tools, err := queries.ListToolsWithHealthCheck(ts.ctx)
+	if err != nil {
+		ts.log.Error("Failed to list tools with a health check", "error", err)
+		return
+	}
+
+	now := time.Now()
+	listed := make(map[uuid.UUID]bool, len(tools))
+	var wg sync.WaitGroup
+	for _, tool := range tools {
+		listed[tool.ID] = true
+		check := tool.Config.HealthCheck
+		if check == nil {
+			continue
+		}
+		state, ok := states[tool.ID]
+		if !ok {
+			state = &toolHealthState{status: tool.Status}
+			states[tool.ID] = state
+		}
+		if now.Before(state.nextCheck) {
+			continue
+		}
+		state.nextCheck = now.Add(healthCheckInterval(check))
+
+		wg.Add(1)
+		go func(tool db.Tool, state *toolHealthState) {
+			defer wg.Done()
+			err := ts.probeTool(cfg, tool.Config.HealthCheck)
+			previous := state.status
+			state.status, state.failures = nextToolStatus(state.failures, tool.Config.HealthCheck, err)
+			if state.status != previous {
+				ts.log.Info("Tool status changed", "tool_id", tool.ID, "tool_name", tool.Name, "from", previous, "to", state.status, "error", err)
+			}
+
+			message := pgtype.Text{}
+			if err != nil {
+				message = pgtype.Text{String: truncateHealthMessage(err.Error()), Valid: true}
+			}
+			if err := queries.UpdateToolStatus(ts.ctx, db.UpdateToolStatusParams{ID: tool.ID, Status: state.status, StatusMessage: message}); err != nil {
+				ts.log.Error("Failed to record tool status", "tool_id", tool.ID, "error", err)
+			}
+		}(tool, state)
+	}
+	wg.Wait()
+
+	// Forget the tools deleted or whose health check was removed
+	for id := range states {
+		if !listed[id] {
+			delete(states, id)
+		}
+	}
+}
+
+// probeTool runs a single health check, it returns nil when the check passes
+func (ts *ToolService) probeTool(cfg *service.ToolHealthCheckConfig, check *db.ToolHealthCheck) error {
+	timeout := DefaultHealthCheckTimeout
+	if check.TimeoutSeconds > 0 {
+		timeout = time.Duration(check.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ts.ctx, timeout)
+	defer cancel()
+
+	if len(check.Command) > 0 {
+		if !cfg.AllowCommands {
+			return errCommandHealthCheckDisabled
+		}
+		output, err := exec.CommandContext(ctx, check.Command[0], check.Command[1:]...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("health check command failed: %w: %s", err, output)
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.URL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check request: %w", err)
+	}
+	// The URL is set by the users, it cannot probe the private networks of the tools service unless allowed
+	resp, err := ts.health.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	// The body may hold anything the URL returns, only the status code is recorded in the status of the tool
+	io.Copy(io.Discard, io.LimitReader(resp.Body, healthCheckMaxMessage))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health check returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// nextToolStatus returns the status of a tool and its failed checks in a row after a check.
+// A failed check degrades the tool until the failure threshold is reached, a passed check makes it healthy.
+func nextToolStatus(failures int32, check *db.ToolHealthCheck, err error) (db.ToolStatus, int32) {
+	if err == nil {
+		return db.ToolStatusHealthy, 0
+	}
+	if errors.Is(err, errCommandHealthCheckDisabled) {
+		return db.ToolStatusUnknown, 0
+	}
+	threshold := DefaultHealthCheckFailureThreshold
+	if check.FailureThreshold > 0 {
+		threshold = check.FailureThreshold
+	}
+	failures++
+	if failures >= threshold {
+		return db.ToolStatusUnreachable, failures
+	}
+	return db.ToolStatusDegraded, failures
+}
+
+func healthCheckInterval(check *db.ToolHealthCheck) time.Duration {
+	if check.IntervalSeconds > 0 {
+		return time.Duration(check.IntervalSeconds) * time.Second
+	}
+	return DefaultHealthCheckInterval
+}
+
+func truncateHealthMessage(message string) string {
+	if len(message) <= healthCheckMaxMessage {
+		return message
+	}
+	return message[:healthCheckMaxMessage]
+}
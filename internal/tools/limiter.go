@@ -0,0 +1,193 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// DefaultToolLimitWait is how long an excess call queues when the tool limits do not set a wait
+	DefaultToolLimitWait time.Duration = 30 * time.Second
+
+	// ToolRunLeaseTimeout reclaims the concurrency slot of a run whose result never reaches this instance
+	ToolRunLeaseTimeout time.Duration = 10 * time.Minute
+
+	// toolLimitWindow is the sliding window of the invocations per minute limit
+	toolLimitWindow = time.Minute
+)
+
+// errToolThrottled is returned when a call could not start within the wait allowed by the tool limits
+var errToolThrottled = errors.New("tool throttled")
+
+type (
+	// toolLimiter enforces the tool limits across every dispatch handled by a tool service instance.
+	// The limits are not coordinated between instances, so each replica allows the configured rate.
+	toolLimiter struct {
+		mu    sync.Mutex
+		tools map[string]*toolLimitState // Keyed by tool ID
+		runs  map[string]string          // Tool run ID to the key of the tool holding its slot
+		now   func() time.Time
+	}
+
+	// toolLimitState is the usage of a single tool
+	toolLimitState struct {
+		starts  []time.Time          // Start times of the invocations within the window, oldest first
+		running map[string]time.Time // Tool run ID to the time its slot was acquired
+		changed chan struct{}        // Closed and replaced when a slot is released
+	}
+)
+
+func newToolLimiter() *toolLimiter {
+	return &toolLimiter{
+		tools: make(map[string]*toolLimitState),
+		runs:  make(map[string]string),
+		now:   time.Now,
+	}
+}
+
+// Acquire waits until the tool run can start within the limits.
+// It returns errToolThrottled when the wait allowed by the limits runs out first.
+func (l *toolLimiter) Acquire(ctx context.Context, key, toolRunID string, limits *db.ToolLimits) error {
+	if !limits.Enabled() {
+		return nil
+	}
+	maxWait := DefaultToolLimitWait
+	if limits.MaxWaitSeconds > 0 {
+		maxWait = time.Duration(limits.MaxWaitSeconds) * time.Second
+	}
+	deadline := time.NewTimer(maxWait)
+	defer deadline.Stop()
+
+	for {
+		retryAfter, changed, ok := l.tryAcquire(key, toolRunID, limits)
+		if ok {
+			return nil
+		}
+
+		// Without a retry time only a released slot or an expiring lease lets the call start
+		if retryAfter <= 0 {
+			retryAfter = ToolRunLeaseTimeout
+		}
+		retry := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			retry.Stop()
+			return ctx.Err()
+		case <-deadline.C:
+			retry.Stop()
+			return errToolThrottled
+		case <-changed:
+		case <-retry.C:
+		}
+		retry.Stop()
+	}
+}
+
+// tryAcquire takes a slot for the tool run when the limits allow it.
+// Otherwise it returns how long until the rate window frees up, and a channel closed on the next release.
+func (l *toolLimiter) tryAcquire(key, toolRunID string, limits *db.ToolLimits) (time.Duration, <-chan struct{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	state, ok := l.tools[key]
+	if !ok {
+		state = &toolLimitState{running: make(map[string]time.Time), changed: make(chan struct{})}
+		l.tools[key] = state
+	}
+
+	for runID, acquiredAt := range state.running {
+		if now.Sub(acquiredAt) >= ToolRunLeaseTimeout {
+			delete(state.running, runID)
+			delete(l.runs, runID)
+		}
+	}
+	expired := 0
+	for expired < len(state.starts) && now.Sub(state.starts[expired]) >= toolLimitWindow {
+		expired++
+	}
+	state.starts = state.starts[expired:]
+
+	if limits.MaxConcurrentRuns > 0 && len(state.running) >= int(limits.MaxConcurrentRuns) {
+		var retryAfter time.Duration
+		for _, acquiredAt := range state.running {
+			if wait := acquiredAt.Add(ToolRunLeaseTimeout).Sub(now); retryAfter == 0 || wait < retryAfter {
+				retryAfter = wait
+			}
+		}
+		return retryAfter, state.changed, false
+	}
+	if limits.InvocationsPerMinute > 0 && len(state.starts) >= int(limits.InvocationsPerMinute) {
+		return state.starts[0].Add(toolLimitWindow).Sub(now), state.changed, false
+	}
+
+	state.starts = append(state.starts, now)
+	state.running[toolRunID] = now
+	l.runs[toolRunID] = key
+	return 0, nil, true
+}
+
+// Release frees the concurrency slot held by a tool run, it is a no-op for runs without a slot
+func (l *toolLimiter) Release(toolRunID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key, ok := l.runs[toolRunID]
+	if !ok {
+		return
+	}
+	delete(l.runs, toolRunID)
+	if state, ok := l.tools[key]; ok {
+		delete(state.running, toolRunID)
+		close(state.changed)
+		state.changed = make(chan struct{})
+	}
+}
+
+// executeLimitedTool runs the tools of a single tool use block once the limits of the tool allow it.
+// The wait happens in a goroutine so a throttled tool does not hold up the dispatch of the others.
+func (ts *ToolService) executeLimitedTool(toolRunID string, tool db.Tool, toolsToExecute ToolProcessResult, header *service.EventHeaders, meta *service.EventMetadata) {
+	go func() {
+		err := ts.limiter.Acquire(ts.ctx, tool.ID.String(), toolRunID, tool.Config.Limits)
+		if err != nil {
+			if errors.Is(err, errToolThrottled) {
+				ts.publishToolThrottledError(toolRunID, tool.Name, header, meta)
+			}
+			return
+		}
+		ts.executeTools(toolsToExecute, header, meta)
+	}()
+}
+
+// publishToolThrottledError returns a throttled error tool result to the agent instead of invoking the tool
+func (ts *ToolService) publishToolThrottledError(toolRunID, toolName string, header *service.EventHeaders, meta *service.EventMetadata) {
+	ts.log.Warn("Tool call throttled by the tool limits", "tool_name", toolName, "tool_run_id", toolRunID)
+
+	content, err := db.NewJsonRaw(map[string]any{
+		"error":     fmt.Sprintf("Tool %s is throttled, too many calls are in progress or were made in the last minute. Try again later", toolName),
+		"throttled": true,
+	})
+	if err != nil {
+		ts.log.Error("Failed to marshal tool throttled error", "error", err)
+		return
+	}
+
+	event := service.NewEvent(&service.ToolGatherEventMessage{
+		ToolRunId:  toolRunID,
+		Content:    content,
+		ResultType: db.ResultMessageTypeText,
+		IsError:    true,
+	}, header, &service.EventMetadata{
+		TraceID:   meta.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("failed to publish result to tool gather event", "error", err)
+	}
+}
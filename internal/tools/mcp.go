@@ -1,12 +1,189 @@
 package tools
 
-import "github.com/pinazu/internal/service"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
-// executeMCPTool publish tool execute event to MCP server
-func (ts *ToolService) executeMCPTool(mcpToolsToExecute []service.StandaloneToolRequestEventMessage) {
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// mcpDiscoveryInterval is the time between two discoveries of the tool schemas of the MCP servers
+const mcpDiscoveryInterval = 5 * time.Minute
+
+// mcpToolRequest is the call of an MCP tool, with the configuration of its server
+type mcpToolRequest struct {
+	service.StandaloneToolRequestEventMessage
+	Config *db.ToolConfigMCP
+}
+
+// executeMCPTool calls the MCP servers and publishes the results to the tool gather event
+func (ts *ToolService) executeMCPTool(mcpToolsToExecute []mcpToolRequest, header *service.EventHeaders, meta *service.EventMetadata) {
 	if len(mcpToolsToExecute) == 0 {
 		return
 	}
-	// TODO: Have not support MCP server
-	ts.log.Error("No support MCP tool server connection")
+
+	for _, t := range mcpToolsToExecute {
+		go func(ctx context.Context, t mcpToolRequest) {
+			content, isError := ts.runMCPTool(ctx, t)
+			PublishToolResult(ts.s.GetNATS(), ts.log, t.ToolRunId, content, isError, header, meta)
+		}(ts.ctx, t)
+	}
+
+	ts.log.Info("Send concurrent request to MCP tool servers", "count", len(mcpToolsToExecute))
+}
+
+// runMCPTool calls the tool on its MCP server and returns the tool result content
+func (ts *ToolService) runMCPTool(ctx context.Context, t mcpToolRequest) (db.JsonRaw, bool) {
+	errorResult := func(message string) (db.JsonRaw, bool) {
+		content, _ := db.NewJsonRaw(map[string]any{"error": message})
+		return content, true
+	}
+	if t.Config.Protocol != db.MCPProtocolGRPC {
+		return errorResult(fmt.Sprintf("MCP protocol %s is not supported by the tools service", t.Config.Protocol))
+	}
+
+	c, cancel := context.WithTimeout(ctx, RequestTimeOut)
+	defer cancel()
+
+	apiKey, err := ts.mcpAPIKey(c, t.Config)
+	if err != nil {
+		ts.log.Error("Failed to resolve MCP tool API key", "tool_run_id", t.ToolRunId, "error", err)
+		return errorResult("Failed to resolve the API key of the tool")
+	}
+	result, err := ts.mcp.callTool(c, t.Config, apiKey, t.Config.RemoteToolName(t.ToolName), t.ToolInput, ts.log)
+	if err != nil {
+		ts.log.Error("Failed to call MCP tool", "tool_name", t.ToolName, "tool_run_id", t.ToolRunId, "error", err)
+		return errorResult(err.Error())
+	}
+
+	output := map[string]any{"content": result.Content}
+	if len(result.StructuredContent) > 0 {
+		output["structured_content"] = result.StructuredContent
+	}
+	content, err := db.NewJsonRaw(output)
+	if err != nil {
+		ts.log.Error("Failed to marshal MCP tool result", "tool_run_id", t.ToolRunId, "error", err)
+		return errorResult(err.Error())
+	}
+	return content, result.IsError
+}
+
+// mcpAPIKey decrypts the API key of an MCP server and resolves it from its secret reference, empty without API key
+func (ts *ToolService) mcpAPIKey(ctx context.Context, config *db.ToolConfigMCP) (string, error) {
+	if config.ApiKey == nil {
+		return "", nil
+	}
+	apiKey, err := db.DecryptSecret(*config.ApiKey)
+	if err != nil {
+		return "", err
+	}
+	return ts.secrets.Resolve(ctx, apiKey)
+}
+
+// runMCPDiscovery discovers the tool schemas of the MCP servers in the background
+func (ts *ToolService) runMCPDiscovery() {
+	ticker := time.NewTicker(mcpDiscoveryInterval)
+	defer ticker.Stop()
+	for {
+		ts.discoverMCPSchemas()
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// discoverMCPSchemas records the parameter schemas listed by the MCP servers speaking gRPC in their tools,
+// each changed schema being a new tool revision. The agents are only offered the MCP tools with a schema.
+func (ts *ToolService) discoverMCPSchemas() {
+	queries := db.New(ts.s.GetDB())
+	tools, err := queries.ListAllTools(ts.ctx)
+	if err != nil {
+		ts.log.Error("Failed to list tools for the MCP schema discovery", "error", err)
+		return
+	}
+
+	// The tools of a server are listed once per discovery
+	type listing struct {
+		tools map[string]mcpTool
+		err   error
+	}
+	servers := make(map[string]listing)
+	for _, tool := range tools {
+		config := tool.Config.GetMCP()
+		if config == nil || config.Protocol != db.MCPProtocolGRPC {
+			continue
+		}
+
+		key := config.Entrypoint
+		if config.ApiKey != nil {
+			key += "\x00" + *config.ApiKey
+		}
+		server, ok := servers[key]
+		if !ok {
+			server.tools = make(map[string]mcpTool)
+			c, cancel := context.WithTimeout(ts.ctx, RequestTimeOut)
+			apiKey, err := ts.mcpAPIKey(c, config)
+			var listed []mcpTool
+			if err == nil {
+				listed, err = ts.mcp.listTools(c, config, apiKey)
+			}
+			cancel()
+			server.err = err
+			for _, t := range listed {
+				server.tools[t.Name] = t
+			}
+			servers[key] = server
+		}
+		if server.err != nil {
+			ts.log.Warn("Failed to list the tools of MCP server", "tool_name", tool.Name, "entrypoint", config.Entrypoint, "error", server.err)
+			continue
+		}
+
+		remote, ok := server.tools[config.RemoteToolName(tool.Name)]
+		if !ok {
+			ts.log.Warn("MCP server does not list the tool", "tool_name", tool.Name, "remote_tool_name", config.RemoteToolName(tool.Name), "entrypoint", config.Entrypoint)
+			continue
+		}
+		if err := ts.recordMCPSchema(queries, tool, config, remote); err != nil {
+			ts.log.Error("Failed to record discovered MCP tool schema", "tool_name", tool.Name, "tool_id", tool.ID, "error", err)
+		}
+	}
+}
+
+// recordMCPSchema writes the schema listed by the MCP server in the tool configuration when it changed
+func (ts *ToolService) recordMCPSchema(queries *db.Queries, tool db.Tool, config *db.ToolConfigMCP, remote mcpTool) error {
+	schema := &openapi3.Schema{}
+	if err := json.Unmarshal(remote.InputSchema, schema); err != nil {
+		return fmt.Errorf("invalid input schema: %w", err)
+	}
+	before, errBefore := json.Marshal(config.Params)
+	after, errAfter := json.Marshal(schema)
+	if errBefore == nil && errAfter == nil && bytes.Equal(before, after) {
+		return nil
+	}
+
+	discovered := *config
+	discovered.Params = schema
+	updated := tool.Config
+	updated.C = &discovered
+	params := db.UpdateToolParams{ID: tool.ID, WorkspaceID: tool.WorkspaceID, Config: updated}
+	if !tool.Description.Valid && remote.Description != "" {
+		// The description written by the MCP server is used until one is set on the tool
+		params.Description.String, params.Description.Valid = remote.Description, true
+	}
+	if _, err := queries.UpdateTool(ts.ctx, params); err != nil {
+		return fmt.Errorf("failed to update tool: %w", err)
+	}
+	if _, err := queries.CreateToolRevision(ts.ctx, db.CreateToolRevisionParams{ToolID: tool.ID, CreatedBy: internalToolOwner}); err != nil {
+		return fmt.Errorf("failed to create tool revision: %w", err)
+	}
+	ts.log.Info("Discovered MCP tool schema", "tool_name", tool.Name, "tool_id", tool.ID)
+	return nil
 }
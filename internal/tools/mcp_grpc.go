@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// MCPGRPCCallMethod is the server streaming method of the MCP servers speaking gRPC. Each call carries
+	// an MCP JSON-RPC request, answered by the notifications it triggers and by one or more responses
+	// whose results are merged, encoded with the "json" gRPC codec.
+	MCPGRPCCallMethod = "/mcp.v1.MCP/Call"
+
+	// mcpProtocolVersion is the version of the MCP specification spoken to the servers
+	mcpProtocolVersion = "2025-06-18"
+
+	// maxMCPToolPages bounds the pages of tools listed from an MCP server
+	maxMCPToolPages = 50
+)
+
+type (
+	// mcpMessage is an MCP JSON-RPC message, a request, a response or a notification
+	mcpMessage struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      *int64          `json:"id,omitempty"`
+		Method  string          `json:"method,omitempty"`
+		Params  json.RawMessage `json:"params,omitempty"`
+		Result  json.RawMessage `json:"result,omitempty"`
+		Error   *mcpError       `json:"error,omitempty"`
+	}
+
+	mcpError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// mcpTool is a tool listed by an MCP server
+	mcpTool struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		InputSchema json.RawMessage `json:"inputSchema"`
+	}
+
+	mcpListToolsResult struct {
+		Tools      []mcpTool `json:"tools"`
+		NextCursor string    `json:"nextCursor,omitempty"`
+	}
+
+	// mcpCallToolResult is the result of a tool call, the content of a streamed result is split over several responses
+	mcpCallToolResult struct {
+		Content           []json.RawMessage `json:"content"`
+		StructuredContent json.RawMessage   `json:"structuredContent,omitempty"`
+		IsError           bool              `json:"isError,omitempty"`
+	}
+
+	// mcpJSONCodec encodes the gRPC messages exchanged with the MCP servers as JSON
+	mcpJSONCodec struct{}
+
+	// mcpGRPCClients holds the connections to the MCP servers speaking gRPC, shared by the tool calls
+	mcpGRPCClients struct {
+		mu     sync.Mutex
+		conns  map[string]*grpc.ClientConn // By entrypoint and TLS settings
+		nextID atomic.Int64
+	}
+)
+
+func (mcpJSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (mcpJSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (mcpJSONCodec) Name() string                       { return "json" }
+
+func (e *mcpError) Error() string {
+	return fmt.Sprintf("MCP error %d: %s", e.Code, e.Message)
+}
+
+func newMCPGRPCClients() *mcpGRPCClients {
+	return &mcpGRPCClients{conns: make(map[string]*grpc.ClientConn)}
+}
+
+// mcpGRPCTarget returns the address of an MCP server and whether the connection uses TLS.
+// Only the grpc:// scheme connects without TLS.
+func mcpGRPCTarget(entrypoint string) (string, bool) {
+	if target, ok := strings.CutPrefix(entrypoint, "grpc://"); ok {
+		return target, false
+	}
+	if target, ok := strings.CutPrefix(entrypoint, "grpcs://"); ok {
+		return target, true
+	}
+	return entrypoint, true
+}
+
+// mcpTransportCredentials returns the credentials of the connection to an MCP server
+func mcpTransportCredentials(config *db.ToolConfigMCP) (credentials.TransportCredentials, error) {
+	if _, secure := mcpGRPCTarget(config.Entrypoint); !secure {
+		return insecure.NewCredentials(), nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.TLS != nil {
+		tlsConfig.ServerName = config.TLS.ServerName
+		tlsConfig.InsecureSkipVerify = config.TLS.InsecureSkipVerify
+		if config.TLS.CACert != "" {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(config.TLS.CACert)) {
+				return nil, fmt.Errorf("invalid tls ca_cert, must hold PEM certificates")
+			}
+			tlsConfig.RootCAs = pool
+		}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// conn returns the connection to the MCP server of a tool, connecting on first use
+func (c *mcpGRPCClients) conn(config *db.ToolConfigMCP) (*grpc.ClientConn, error) {
+	key := config.Entrypoint
+	if config.TLS != nil {
+		settings, _ := json.Marshal(config.TLS)
+		key += "\x00" + string(settings)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn, ok := c.conns[key]; ok {
+		return conn, nil
+	}
+	creds, err := mcpTransportCredentials(config)
+	if err != nil {
+		return nil, err
+	}
+	target, _ := mcpGRPCTarget(config.Entrypoint)
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(mcpJSONCodec{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MCP server %s: %w", config.Entrypoint, err)
+	}
+	c.conns[key] = conn
+	return conn, nil
+}
+
+// close closes the connections to the MCP servers
+func (c *mcpGRPCClients) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, conn := range c.conns {
+		conn.Close()
+		delete(c.conns, key)
+	}
+}
+
+// call sends an MCP request and returns the results of its responses in the order they were streamed.
+// The notifications streamed before the responses are passed to onNotification.
+func (c *mcpGRPCClients) call(ctx context.Context, config *db.ToolConfigMCP, apiKey, method string, params any, onNotification func(mcpMessage)) ([]json.RawMessage, error) {
+	conn, err := c.conn(config)
+	if err != nil {
+		return nil, err
+	}
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal MCP %s params: %w", method, err)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "mcp-protocol-version", mcpProtocolVersion)
+	if apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+apiKey)
+	}
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, MCPGRPCCallMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call MCP server %s: %w", config.Entrypoint, err)
+	}
+	id := c.nextID.Add(1)
+	if err := stream.SendMsg(&mcpMessage{JSONRPC: "2.0", ID: &id, Method: method, Params: rawParams}); err != nil {
+		return nil, fmt.Errorf("failed to send MCP %s request: %w", method, err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, fmt.Errorf("failed to send MCP %s request: %w", method, err)
+	}
+
+	var results []json.RawMessage
+	for {
+		var msg mcpMessage
+		err := stream.RecvMsg(&msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive MCP %s response: %w", method, err)
+		}
+		switch {
+		case msg.ID == nil && msg.Method != "":
+			if onNotification != nil {
+				onNotification(msg)
+			}
+		case msg.ID != nil && *msg.ID == id:
+			if msg.Error != nil {
+				return nil, msg.Error
+			}
+			results = append(results, msg.Result)
+		}
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("MCP server %s closed the %s call without a response", config.Entrypoint, method)
+	}
+	return results, nil
+}
+
+// listTools lists the tools of an MCP server, following the pagination cursors
+func (c *mcpGRPCClients) listTools(ctx context.Context, config *db.ToolConfigMCP, apiKey string) ([]mcpTool, error) {
+	var tools []mcpTool
+	cursor := ""
+	for range maxMCPToolPages {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		results, err := c.call(ctx, config, apiKey, "tools/list", params, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page mcpListToolsResult
+		if err := json.Unmarshal(results[len(results)-1], &page); err != nil {
+			return nil, fmt.Errorf("failed to parse MCP tools/list result: %w", err)
+		}
+		tools = append(tools, page.Tools...)
+		if page.NextCursor == "" {
+			return tools, nil
+		}
+		cursor = page.NextCursor
+	}
+	return tools, fmt.Errorf("MCP server %s listed more than %d pages of tools", config.Entrypoint, maxMCPToolPages)
+}
+
+// callTool calls a tool of an MCP server. A streamed result has its content concatenated in the order it was received.
+func (c *mcpGRPCClients) callTool(ctx context.Context, config *db.ToolConfigMCP, apiKey, name string, arguments map[string]any, log hclog.Logger) (mcpCallToolResult, error) {
+	params := map[string]any{"name": name, "arguments": arguments}
+	results, err := c.call(ctx, config, apiKey, "tools/call", params, func(msg mcpMessage) {
+		log.Debug("MCP notification", "tool_name", name, "method", msg.Method, "params", string(msg.Params))
+	})
+	if err != nil {
+		return mcpCallToolResult{}, err
+	}
+
+	merged := mcpCallToolResult{Content: []json.RawMessage{}}
+	for _, raw := range results {
+		var part mcpCallToolResult
+		if err := json.Unmarshal(raw, &part); err != nil {
+			return mcpCallToolResult{}, fmt.Errorf("failed to parse MCP tools/call result: %w", err)
+		}
+		merged.Content = append(merged.Content, part.Content...)
+		merged.IsError = merged.IsError || part.IsError
+		if len(part.StructuredContent) > 0 {
+			merged.StructuredContent = part.StructuredContent
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"errors"
+
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// useToolRunQuota adds a tool run to the daily usage of the user, it returns the reason of the error result of the
+// tool when the user or its workspace exhausted its tool runs. The tool runs while the quota cannot be checked.
+func (ts *ToolService) useToolRunQuota(queries *db.Queries, req *service.Event[*service.ToolDispatchEventMessage]) string {
+	err := queries.CheckQuotas(ts.ctx, ts.config.GetQuotas(), req.H.Workspace(), req.H.UserID, db.QuotaToolRunsPerDay)
+	var quotaErr *db.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		ts.log.Warn("Tool run quota exceeded", "user_id", req.H.UserID, "workspace_id", req.H.Workspace(), "error", err)
+		return quotaErr.Error()
+	} else if err != nil {
+		ts.log.Warn("Failed to check the tool run quota", "user_id", req.H.UserID, "error", err)
+	}
+
+	if err := queries.AddDailyUsage(ts.ctx, db.AddDailyUsageParams{
+		WorkspaceID: req.H.Workspace(),
+		UserID:      req.H.UserID,
+		ToolRuns:    1,
+	}); err != nil {
+		ts.log.Warn("Failed to record the daily tool run usage", "user_id", req.H.UserID, "error", err)
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/utils"
+)
+
+const (
+	// RecallConversationsToolName is the name of the tool recalling the messages of the past conversations
+	RecallConversationsToolName = "recall_conversations"
+
+	// recallMaxLimit caps the number of messages a single recall can request
+	recallMaxLimit = 20
+
+	// recallMaxSnippetChars truncates the recalled messages given to the agent
+	recallMaxSnippetChars = 1000
+
+	// recallTimeout bounds the wait for the embeddings service
+	recallTimeout = 15 * time.Second
+)
+
+func init() {
+	query := openapi3.NewStringSchema().WithMinLength(1).WithMaxLength(2000)
+	query.Description = "What to recall, phrased like the messages looked for"
+	limit := openapi3.NewIntegerSchema().WithMin(1).WithMax(recallMaxLimit)
+	limit.Description = "Maximum number of messages recalled"
+	RegisterInternal(RecallConversationsToolName, &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Description: "Recall the messages of the past conversations of the user nearest in meaning to a query, with " +
+			"the title of their thread and their date. The messages of the current conversation are not recalled.",
+		Properties: openapi3.Schemas{
+			"query": query.NewRef(),
+			"limit": limit.NewRef(),
+		},
+		Required: []string{"query"},
+	}, recallConversations)
+}
+
+// recallConversations asks the embeddings service for the messages nearest to the query among the threads visible to
+// the user of the agent, excluding the thread of the agent
+func recallConversations(ctx context.Context, req InternalToolRequest) (string, error) {
+	query, _ := req.Input["query"].(string)
+	recall := &service.MessageRecallRequestEventMessage{Query: query}
+	if limit, ok := req.Input["limit"].(float64); ok {
+		recall.Limit = int(limit)
+	}
+	if req.Header != nil {
+		recall.ExcludeThreadId = req.Header.ThreadID
+	}
+
+	event := service.Event[*service.MessageRecallRequestEventMessage]{
+		H:   req.Header,
+		Msg: recall,
+		M: &service.EventMetadata{
+			TraceID:   utils.GenerateTraceID(),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	resp, err := service.Request[*service.MessageRecallResponseEventMessage](req.NATS, &event, recallTimeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to recall conversations: %w", err)
+	}
+	if len(resp.Msg.Snippets) == 0 {
+		return "No message of the past conversations matches the query.", nil
+	}
+
+	var b strings.Builder
+	for i, snippet := range resp.Msg.Snippets {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "[%d] Thread %q, %s message of %s (similarity %.2f):\n", i+1, snippet.ThreadTitle,
+			snippet.SenderType, snippet.CreatedAt.Time.UTC().Format(time.RFC3339), snippet.Similarity)
+		b.WriteString(truncateRecalledText(snippet.Text))
+	}
+	return b.String(), nil
+}
+
+// truncateRecalledText shortens a recalled message to recallMaxSnippetChars characters
+func truncateRecalledText(text string) string {
+	runes := []rune(text)
+	if len(runes) <= recallMaxSnippetChars {
+		return text
+	}
+	return string(runes[:recallMaxSnippetChars]) + "..."
+}
@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// internalToolCategory is the catalog category of the tools registered with RegisterInternal
+const internalToolCategory = "internal"
+
+// internalToolOwner is the system user creating the registered tools in the tools table
+var internalToolOwner = uuid.MustParse("550e8400-c95b-4444-6666-000000000000")
+
+type (
+	// InternalToolRequest is the call of a registered tool, with direct access to the database and NATS
+	InternalToolRequest struct {
+		ToolRunID string
+		Input     map[string]any // Validated against the schema of the tool
+		Queries   *db.Queries
+		NATS      *nats.Conn
+		Header    *service.EventHeaders
+		Log       hclog.Logger
+	}
+
+	// InternalToolHandler runs a registered tool. The returned text is the tool result,
+	// an error is returned to the agent as an error tool result.
+	InternalToolHandler func(ctx context.Context, req InternalToolRequest) (string, error)
+
+	// internalTool is a Go-native tool compiled into the tools service
+	internalTool struct {
+		name    string
+		schema  *openapi3.Schema
+		handler InternalToolHandler
+	}
+)
+
+var (
+	internalToolsMu sync.RWMutex
+	internalTools   = make(map[string]internalTool)
+)
+
+// RegisterInternal registers a Go-native tool run inside the tools service, typically from an init function.
+// The schema describes the input of the tool, its description is the tool description given to the models.
+// The tool is created in the tools table, or updated when its schema changed, when the tools service starts.
+// RegisterInternal panics when the name is invalid or already registered, like http.HandleFunc.
+func RegisterInternal(name string, schema *openapi3.Schema, handler InternalToolHandler) {
+	if err := db.ValidateToolName(name); err != nil {
+		panic(fmt.Sprintf("tools: %v", err))
+	}
+	if schema == nil || handler == nil {
+		panic(fmt.Sprintf("tools: internal tool %q needs a schema and a handler", name))
+	}
+
+	internalToolsMu.Lock()
+	defer internalToolsMu.Unlock()
+	if _, ok := internalTools[name]; ok {
+		panic(fmt.Sprintf("tools: internal tool %q is already registered", name))
+	}
+	internalTools[name] = internalTool{name: name, schema: schema, handler: handler}
+}
+
+// registeredInternalTool returns the registered tool of a tools table row, false for the other tools
+func registeredInternalTool(tool db.Tool) (internalTool, bool) {
+	if tool.Config.Type != db.ToolTypeInternal || tool.BuiltinName() != "" {
+		return internalTool{}, false
+	}
+	internalToolsMu.RLock()
+	defer internalToolsMu.RUnlock()
+	t, ok := internalTools[tool.Name]
+	return t, ok
+}
+
+// syncInternalTools creates the registered tools missing from the tools table and updates the ones whose
+// description or schema changed, each change being a new tool revision. A tool of another type holding
+// the name of a registered tool is left untouched.
+func (ts *ToolService) syncInternalTools() error {
+	internalToolsMu.RLock()
+	registered := make([]internalTool, 0, len(internalTools))
+	for _, t := range internalTools {
+		registered = append(registered, t)
+	}
+	internalToolsMu.RUnlock()
+	sort.Slice(registered, func(i, j int) bool { return registered[i].name < registered[j].name })
+
+	queries := db.New(ts.s.GetDB())
+	for _, t := range registered {
+		config := db.ToolConfig{Type: db.ToolTypeInternal, C: &db.ToolConfigInternal{Params: t.schema}}
+		description := pgtype.Text{String: t.schema.Description, Valid: t.schema.Description != ""}
+
+		existing, err := queries.GetToolInfoByName(ts.ctx, db.GetToolInfoByNameParams{Name: t.name})
+		if err == pgx.ErrNoRows {
+			tool, err := queries.CreateTool(ts.ctx, db.CreateToolParams{
+				Name:        t.name,
+				Description: description,
+				Config:      config,
+				CreatedBy:   internalToolOwner,
+				Category:    pgtype.Text{String: internalToolCategory, Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create internal tool %s: %w", t.name, err)
+			}
+			if _, err := queries.CreateToolRevision(ts.ctx, db.CreateToolRevisionParams{ToolID: tool.ID, CreatedBy: internalToolOwner}); err != nil {
+				return fmt.Errorf("failed to create revision of internal tool %s: %w", t.name, err)
+			}
+			ts.log.Info("Created internal tool", "tool_name", t.name, "tool_id", tool.ID)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get internal tool %s: %w", t.name, err)
+		}
+		if existing.Config.Type != db.ToolTypeInternal {
+			ts.log.Error("A tool of another type has the name of an internal tool, the internal tool is not available",
+				"tool_name", t.name, "tool_id", existing.ID, "tool_type", existing.Config.Type)
+			continue
+		}
+
+		// The limits, health check, mock and cache set on the tool are kept, only the schema follows the registration
+		before, errBefore := json.Marshal(existing.Config.C)
+		after, errAfter := json.Marshal(config.C)
+		if errBefore == nil && errAfter == nil && bytes.Equal(before, after) && existing.Description == description {
+			continue
+		}
+		updated := existing.Config
+		updated.C = config.C
+		if _, err := queries.UpdateTool(ts.ctx, db.UpdateToolParams{ID: existing.ID, Description: description, Config: updated}); err != nil {
+			return fmt.Errorf("failed to update internal tool %s: %w", t.name, err)
+		}
+		if _, err := queries.CreateToolRevision(ts.ctx, db.CreateToolRevisionParams{ToolID: existing.ID, CreatedBy: internalToolOwner}); err != nil {
+			return fmt.Errorf("failed to create revision of internal tool %s: %w", t.name, err)
+		}
+		ts.log.Info("Updated internal tool", "tool_name", t.name, "tool_id", existing.ID)
+	}
+	return nil
+}
+
+// executeInternalTool runs the registered tools and publishes the results to the tool gather event
+func (ts *ToolService) executeInternalTool(internalToolsToExecute []service.StandaloneToolRequestEventMessage, header *service.EventHeaders, meta *service.EventMetadata) {
+	if len(internalToolsToExecute) == 0 {
+		return
+	}
+
+	for _, t := range internalToolsToExecute {
+		go func(t service.StandaloneToolRequestEventMessage) {
+			internalToolsMu.RLock()
+			tool, ok := internalTools[t.ToolName]
+			internalToolsMu.RUnlock()
+
+			var text string
+			var err error
+			if !ok {
+				err = fmt.Errorf("internal tool %s is not registered in this tools service", t.ToolName)
+			} else {
+				text, err = tool.handler(ts.ctx, InternalToolRequest{
+					ToolRunID: t.ToolRunId,
+					Input:     t.ToolInput,
+					Queries:   db.New(ts.s.GetDB()),
+					NATS:      ts.s.GetNATS(),
+					Header:    header,
+					Log:       ts.log.Named(t.ToolName),
+				})
+			}
+
+			msg := &service.ToolGatherEventMessage{ToolRunId: t.ToolRunId, ResultType: db.ResultMessageTypeText}
+			if err != nil {
+				ts.log.Error("Failed to execute internal tool", "tool_name", t.ToolName, "tool_run_id", t.ToolRunId, "error", err)
+				msg.Content, _ = db.NewJsonRaw(map[string]any{"error": err.Error()})
+				msg.IsError = true
+			} else {
+				msg.Content, _ = db.NewJsonRaw(map[string]any{"text": text})
+			}
+
+			event := service.NewEvent(msg, header, &service.EventMetadata{
+				TraceID:   meta.TraceID,
+				Timestamp: time.Now(),
+			})
+			if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
+				ts.log.Error("failed to publish result to tool gather event", "error", publishErr)
+			}
+		}(t)
+	}
+
+	ts.log.Info("Started internal tool executions", "count", len(internalToolsToExecute))
+}
@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// toolCacheScope is who a cached tool result is shared with, the calls of the same revision of a tool by the same
+// user in the same workspace. The results of a tool may depend on its configuration and on the user calling it.
+type toolCacheScope struct {
+	ToolRevision int32     `json:"tool_revision"`
+	WorkspaceID  uuid.UUID `json:"workspace_id"`
+	UserID       uuid.UUID `json:"user_id"`
+}
+
+// toolInputHash returns the hash identifying the identical calls of a tool in a cache scope, the JSON encoding sorts
+// the map keys
+func toolInputHash(scope toolCacheScope, input map[string]any) (string, error) {
+	b, err := json.Marshal(struct {
+		toolCacheScope
+		Input map[string]any `json:"input"`
+	}{scope, input})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// serveCachedToolResult answers a call of a cacheable tool with the result of an identical call within the cache TTL.
+// It returns false when the tool has to be invoked, after recording the input hash of the run for the later calls.
+func (ts *ToolService) serveCachedToolResult(toolRunID string, toolInput map[string]any, tool db.Tool, queries *db.Queries, header *service.EventHeaders, meta *service.EventMetadata) bool {
+	ttl := tool.Config.Cache.TTL()
+	if ttl == 0 {
+		return false
+	}
+	hash, err := toolInputHash(toolCacheScope{ToolRevision: tool.Revision, WorkspaceID: header.WorkspaceID, UserID: header.UserID}, toolInput)
+	if err != nil {
+		ts.log.Warn("Failed to hash tool input, invoking the tool", "tool_name", tool.Name, "error", err)
+		return false
+	}
+	inputHash := pgtype.Text{String: hash, Valid: true}
+
+	cached, err := queries.GetCachedToolRun(ts.ctx, db.GetCachedToolRunParams{
+		ToolID:     tool.ID,
+		InputHash:  inputHash,
+		TtlSeconds: int32(ttl.Seconds()),
+	})
+	content := cached.Result
+	if err == nil {
+		// Offloaded results are downloaded, the gather callback offloads them again for this run
+		if ref, ok := ts.offloader.reference(cached.ID, cached.Result); ok {
+			content, err = ts.offloader.fetch(ts.ctx, ref)
+		}
+	}
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			ts.log.Warn("Failed to get cached tool result, invoking the tool", "tool_name", tool.Name, "error", err)
+		}
+		if err := queries.SetToolRunInputHash(ts.ctx, db.SetToolRunInputHashParams{InputHash: inputHash, ID: toolRunID}); err != nil {
+			ts.log.Warn("Failed to record tool input hash", "tool_run_id", toolRunID, "error", err)
+		}
+		return false
+	}
+
+	if err := queries.RecordToolRunCacheHit(ts.ctx, db.RecordToolRunCacheHitParams{
+		CachedFromRunID: pgtype.Text{String: cached.ID, Valid: true},
+		InputHash:       inputHash,
+		ID:              toolRunID,
+	}); err != nil {
+		ts.log.Warn("Failed to record tool cache hit", "tool_run_id", toolRunID, "error", err)
+	}
+	ts.log.Info("Returning the cached tool result", "tool_name", tool.Name, "tool_run_id", toolRunID, "cached_from_run_id", cached.ID)
+
+	event := service.NewEvent(&service.ToolGatherEventMessage{
+		ToolRunId:  toolRunID,
+		Content:    content,
+		ResultType: inferResultType(content),
+	}, header, &service.EventMetadata{
+		TraceID:   meta.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("failed to publish result to tool gather event", "error", err)
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/agents"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+type (
+	// resultStore holds the offloaded tool results, implemented by the S3 client
+	resultStore interface {
+		PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+		GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	}
+
+	// resultOffloader uploads the tool results too large for the database to object storage.
+	// A nil offloader keeps every result in the database.
+	resultOffloader struct {
+		store resultStore
+		cfg   *service.ToolResultOffloadConfig
+	}
+)
+
+// newResultOffloader creates the offloader of the large tool results, it returns nil when offloading is disabled
+func newResultOffloader(ctx context.Context, config *service.ExternalDependenciesConfig, log hclog.Logger) *resultOffloader {
+	cfg := config.GetToolResultOffloadConfig()
+	if cfg.Bucket == "" {
+		return nil
+	}
+	if config.Storage == nil || config.Storage.S3 == nil {
+		log.Warn("Tool result offloading needs the S3 storage configuration, large tool results are stored in the database")
+		return nil
+	}
+	client, err := service.NewS3Client(ctx, config.Storage.S3)
+	if err != nil {
+		log.Warn("Failed to create S3 client, large tool results are stored in the database", "error", err)
+		return nil
+	}
+	return &resultOffloader{store: client, cfg: cfg}
+}
+
+// offload uploads a result above the threshold and returns the reference stored in its place.
+// It returns nil for the results small enough for the database.
+func (o *resultOffloader) offload(ctx context.Context, toolRunID string, content db.JsonRaw) (*db.OffloadedToolResult, error) {
+	if o == nil || len(content) <= o.cfg.ThresholdBytes {
+		return nil, nil
+	}
+	key := o.resultKey(toolRunID)
+	contentType := "application/json"
+	_, err := o.store.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &o.cfg.Bucket,
+		Key:         &key,
+		Body:        bytes.NewReader(content),
+		ContentType: &contentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload tool result: %w", err)
+	}
+	return &db.OffloadedToolResult{
+		Offloaded: true,
+		URL:       fmt.Sprintf("s3://%s/%s", o.cfg.Bucket, key),
+		Bucket:    o.cfg.Bucket,
+		Key:       key,
+		SizeBytes: len(content),
+	}, nil
+}
+
+// resultKey returns the object key of the offloaded result of a tool run
+func (o *resultOffloader) resultKey(toolRunID string) string {
+	return o.cfg.Prefix + toolRunID + ".json"
+}
+
+// reference returns the reference stored in place of the offloaded result of a tool run. The tools write the results of
+// their runs, so only a reference to the object the run was offloaded to is accepted, other results are returned
+// as not offloaded.
+func (o *resultOffloader) reference(toolRunID string, result db.JsonRaw) (*db.OffloadedToolResult, bool) {
+	ref, ok := db.ParseOffloadedToolResult(result)
+	if !ok || o == nil || ref.Bucket != o.cfg.Bucket || ref.Key != o.resultKey(toolRunID) {
+		return nil, false
+	}
+	return ref, true
+}
+
+// fetch downloads an offloaded result, ref is returned by reference
+func (o *resultOffloader) fetch(ctx context.Context, ref *db.OffloadedToolResult) (db.JsonRaw, error) {
+	if o == nil {
+		return nil, fmt.Errorf("tool result offloading is not configured")
+	}
+	out, err := o.store.GetObject(ctx, &s3.GetObjectInput{Bucket: &ref.Bucket, Key: &ref.Key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download tool result: %w", err)
+	}
+	defer out.Body.Close()
+	content, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool result: %w", err)
+	}
+	return content, nil
+}
+
+// agentToolResultSpecs returns how an agent of a workspace receives the offloaded tool results, with the defaults applied
+func (ts *ToolService) agentToolResultSpecs(queries *db.Queries, workspaceID, agentID uuid.UUID) agents.ToolResultSpecs {
+	specs := agents.ToolResultSpecs{}
+	if agentSpecs := ts.getAgentSpecs(queries, workspaceID, agentID); agentSpecs != nil && agentSpecs.ToolResults != nil {
+		specs = *agentSpecs.ToolResults
+	}
+	if specs.Offloaded == "" {
+		specs.Offloaded = agents.ToolResultOffloadedTruncate
+	}
+	if specs.MaxChars <= 0 {
+		specs.MaxChars = ts.config.GetToolResultOffloadConfig().MaxChars
+	}
+	return specs
+}
+
+// resolveStoredToolResult returns the result of a tool run read from the tool runs, with its reference when it was
+// offloaded. The offloaded results are downloaded, unless the agent only receives their location; the content is then nil.
+func (ts *ToolService) resolveStoredToolResult(toolRunID string, result db.JsonRaw, specs func() agents.ToolResultSpecs) (db.JsonRaw, *db.OffloadedToolResult) {
+	ref, ok := ts.offloader.reference(toolRunID, result)
+	if !ok {
+		return result, nil
+	}
+	if specs().Offloaded == agents.ToolResultOffloadedLink {
+		return nil, ref
+	}
+	content, err := ts.offloader.fetch(ts.ctx, ref)
+	if err != nil {
+		ts.log.Warn("Failed to fetch offloaded tool result, the agent receives its location", "url", ref.URL, "error", err)
+		return nil, ref
+	}
+	return content, ref
+}
+
+// toolResultContent creates the content given to the agent for a tool result.
+// An offloaded result is truncated, or replaced with its location, as set by the agent specs.
+func (ts *ToolService) toolResultContent(content db.JsonRaw, ref *db.OffloadedToolResult, resultType db.ResultMessageType, isError bool, specs func() agents.ToolResultSpecs) ([]anthropic.ToolResultBlockParamContentUnion, error) {
+	if ref == nil {
+		return ts.createToolResultContent(content, resultType, isError)
+	}
+	s := specs()
+	if content == nil || s.Offloaded == agents.ToolResultOffloadedLink {
+		return []anthropic.ToolResultBlockParamContentUnion{{
+			OfText: &anthropic.TextBlockParam{
+				Type: "text",
+				Text: fmt.Sprintf("The tool result is too large to be included (%d bytes), it is stored at %s", ref.SizeBytes, ref.URL),
+			},
+		}}, nil
+	}
+	blocks, err := ts.createToolResultContent(content, resultType, isError)
+	if err != nil {
+		return nil, err
+	}
+	return truncateToolResultContent(blocks, ref, s.MaxChars), nil
+}
+
+// truncateToolResultContent keeps the first maxChars characters of the text of an offloaded result,
+// followed by a note giving the location of the full result. Other blocks, such as images, are kept.
+func truncateToolResultContent(blocks []anthropic.ToolResultBlockParamContentUnion, ref *db.OffloadedToolResult, maxChars int) []anthropic.ToolResultBlockParamContentUnion {
+	truncated := make([]anthropic.ToolResultBlockParamContentUnion, 0, len(blocks)+1)
+	remaining, cut := maxChars, false
+	for _, block := range blocks {
+		if block.OfText == nil {
+			truncated = append(truncated, block)
+			continue
+		}
+		text := []rune(block.OfText.Text)
+		if len(text) > remaining {
+			text, cut = text[:remaining], true
+		}
+		remaining -= len(text)
+		if len(text) == 0 {
+			continue
+		}
+		textBlock := *block.OfText
+		textBlock.Text = string(text)
+		truncated = append(truncated, anthropic.ToolResultBlockParamContentUnion{OfText: &textBlock})
+	}
+	if cut {
+		truncated = append(truncated, anthropic.ToolResultBlockParamContentUnion{
+			OfText: &anthropic.TextBlockParam{
+				Type: "text",
+				Text: fmt.Sprintf("[Result truncated to %d characters, the full result of %d bytes is stored at %s]", maxChars, ref.SizeBytes, ref.URL),
+			},
+		})
+	}
+	return truncated
+}
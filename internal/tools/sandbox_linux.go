@@ -0,0 +1,42 @@
+//go:build linux
+
+package tools
+
+import (
+	"os/exec"
+	"syscall"
+
+	"github.com/pinazu/internal/service"
+)
+
+// sandboxSnippet runs the process of a snippet as the sandbox user, which cannot read the environment of the tools
+// service from /proc. The process runs in its own process group and PID namespace, so the processes it spawns are
+// killed with it, and in an empty network namespace unless the network is allowed.
+func sandboxSnippet(cmd *exec.Cmd, cfg *service.CodeInterpreterConfig) error {
+	flags := uintptr(syscall.CLONE_NEWPID)
+	if !cfg.AllowNetwork {
+		flags |= syscall.CLONE_NEWNET
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:    true,
+		Pdeathsig:  syscall.SIGKILL,
+		Cloneflags: flags,
+		Credential: &syscall.Credential{Uid: uint32(cfg.SandboxUID), Gid: uint32(cfg.SandboxGID), Groups: []uint32{}},
+	}
+	cmd.Cancel = func() error {
+		return killSnippet(cmd)
+	}
+	return nil
+}
+
+// killSnippet kills the process of a snippet and every process of its group, the other processes of its PID namespace
+// are killed by the kernel once its first process exits
+func killSnippet(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package tools
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/pinazu/internal/service"
+)
+
+// sandboxSnippet fails on this platform, the snippets are not run without the isolation of the Linux namespaces
+func sandboxSnippet(cmd *exec.Cmd, cfg *service.CodeInterpreterConfig) error {
+	return fmt.Errorf("the code interpreter sandbox is only supported on Linux")
+}
@@ -3,9 +3,11 @@ package tools
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/secrets"
 	"github.com/pinazu/internal/service"
 )
 
@@ -13,14 +15,36 @@ import (
 type ToolProcessResult struct {
 	StandaloneTools []service.StandaloneToolRequestEventMessage
 	WorkflowTools   []service.FlowRunExecuteRequestEventMessage
-	MCPTools        []service.StandaloneToolRequestEventMessage
+	MCPTools        []mcpToolRequest
+	CodeTools       []service.StandaloneToolRequestEventMessage
+	WebTools        []service.StandaloneToolRequestEventMessage
+	EdgeTools       []service.StandaloneToolRequestEventMessage // Standalone tools called by the workers of their edge group
+	InternalTools   []service.StandaloneToolRequestEventMessage // Go-native tools registered with RegisterInternal
+	LimitedTools    int                                         // Tool runs queued on the limiter, executed once the tool limits allow them
+	CachedTools     int                                         // Tool runs answered with the cached result of an identical call
+	BudgetedTools   []budgetedToolRun                           // Tool runs of a task, started once its budget of outstanding runs allows them
+}
+
+// isEmpty reports whether there is no tool to execute
+func (r ToolProcessResult) isEmpty() bool {
+	return len(r.StandaloneTools) == 0 && len(r.WorkflowTools) == 0 && len(r.MCPTools) == 0 && len(r.CodeTools) == 0 && len(r.WebTools) == 0 && len(r.EdgeTools) == 0 && len(r.InternalTools) == 0
 }
 
 type ToolService struct {
-	s   service.Service
-	log hclog.Logger
-	wg  *sync.WaitGroup
-	ctx context.Context
+	s         service.Service
+	config    *service.ExternalDependenciesConfig
+	log       hclog.Logger
+	wg        *sync.WaitGroup
+	ctx       context.Context
+	limiter   *toolLimiter              // Shared by every dispatch handled by this instance
+	secrets   *secrets.Resolver         // Resolves the secret references of the tool configurations at execution time
+	offloader *resultOffloader          // Uploads the large tool results to object storage, nil when disabled
+	mcp       *mcpGRPCClients           // Connections to the MCP servers speaking gRPC
+	auditor   *toolAuditor              // Records the tool runs in the audit log, nil when disabled
+	budget    *taskToolBudget           // Bounds the outstanding tool runs of each task, nil when disabled
+	codeSlots chan struct{}             // Bounds the code interpreter snippets running at once
+	health    *http.Client              // Sends the requests of the URL health checks
+	js        *service.JetStreamService // Buffers the thread messages while the database is unavailable
 }
 
 // Create a new tool handlers service instance
@@ -29,6 +53,17 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("externalDependenciesConfig is nil")
 	}
 
+	resolver, err := externalDependenciesConfig.Secrets.NewSecretsResolver(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secrets resolver: %w", err)
+	}
+
+	auditConfig := externalDependenciesConfig.GetToolAuditConfig()
+	auditor, err := newToolAuditor(auditConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tools audit configuration: %w", err)
+	}
+
 	// Create a new service instance
 	config := &service.Config{
 		Name:                 "tools-handler-service",
@@ -42,15 +77,48 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("failed to create tool service: %w", err)
 	}
 
-	ts := &ToolService{s: s, log: log, wg: wg, ctx: ctx}
+	js, err := service.NewJetStreamService(ctx, s.GetNATS(), log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream service: %w", err)
+	}
+
+	ts := &ToolService{s: s, config: externalDependenciesConfig, log: log, wg: wg, ctx: ctx, limiter: newToolLimiter(), secrets: resolver, mcp: newMCPGRPCClients(), auditor: auditor, js: js}
+	ts.budget = newTaskToolBudget(externalDependenciesConfig.GetTaskToolBudgetConfig())
+	ts.codeSlots = make(chan struct{}, externalDependenciesConfig.GetCodeInterpreterConfig().MaxConcurrent)
+	ts.offloader = newResultOffloader(ctx, externalDependenciesConfig, log)
+	ts.health = service.NewWebhookClient(externalDependenciesConfig.GetToolHealthCheckConfig().AllowPrivateNetworks)
+
+	// The tools registered with RegisterInternal are offered to the agents once they are in the tools table
+	if err := ts.syncInternalTools(); err != nil {
+		log.Error("Failed to sync the internal tools to the database", "error", err)
+	}
 
 	s.RegisterHandler(service.ToolDispatchEventSubject.String(), ts.dispatchEventCallback)
 	s.RegisterHandler(service.ToolGatherEventSubject.String(), ts.gatherEventCallback)
 
+	// The schemas of the MCP tools are listed by their servers
+	go ts.runMCPDiscovery()
+
+	// Probe the tools with a health check and record their status
+	if cfg := externalDependenciesConfig.GetToolHealthCheckConfig(); !cfg.Disabled {
+		go ts.runHealthProber(cfg)
+	}
+
+	// Delete the audit entries of the tool runs past their retention
+	if auditor != nil {
+		go ts.runAuditPruner(auditConfig)
+	}
+
+	// Reclaim the budget slots of the tool runs whose result never came back
+	if ts.budget != nil {
+		go ts.runTaskBudgetReaper()
+	}
+
 	// Start a goroutine to wait for context cancellation and then shutdown
 	go func() {
 		<-ctx.Done()
 		ts.log.Warn("Tool service shutting down...")
+		ts.mcp.close()
 		if err := ts.s.Shutdown(); err != nil {
 			ts.log.Error("Error during tool service shutdown", "error", err)
 		}
@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/secrets"
 	"github.com/pinazu/internal/service"
 )
 
@@ -32,173 +37,173 @@ func (ts *ToolService) executeStandaloneTool(standaloneToolsToExecute []service.
 
 	for _, t := range standaloneToolsToExecute {
 		go func(ctx context.Context, t service.StandaloneToolRequestEventMessage) {
-			var resp *http.Response
-			var err error
+			content, isError := RunStandaloneTool(ctx, ts.secrets, ts.log, t)
+			PublishToolResult(ts.s.GetNATS(), ts.log, t.ToolRunId, content, isError, header, meta)
+		}(ts.ctx, t)
+	}
+
+	ts.log.Info("Send concurrent request to standalone tool", "tool_name", standaloneToolsToExecute)
+}
 
-			c, cancel := context.WithTimeout(ctx, RequestTimeOut)
-			defer cancel()
-			b, err := json.Marshal(t.ToolInput)
+// executeEdgeTool hands the standalone tools marked as edge over to the workers of their edge group.
+// The workers call the tool server from their own network and publish the result to the tool gather event.
+func (ts *ToolService) executeEdgeTool(edgeToolsToExecute []service.StandaloneToolRequestEventMessage, header *service.EventHeaders, meta *service.EventMetadata) {
+	for _, t := range edgeToolsToExecute {
+		// A request of an edge group without workers would wait for its tool run to time out
+		if !ts.edgeGroupConsumed(t.EdgeGroup) {
+			ts.log.Warn("No worker calls the edge tools of the edge group", "tool_run_id", t.ToolRunId, "edge_group", t.EdgeGroup)
+			errorContent, _ := db.NewJsonRaw(map[string]any{"error": fmt.Sprintf("No worker calls the tools of the edge group %s", t.EdgeGroup)})
+			PublishToolResult(ts.s.GetNATS(), ts.log, t.ToolRunId, errorContent, true, header, meta)
+			continue
+		}
+
+		// Plaintext API keys are encrypted with the secret key while they travel to the worker
+		if t.ToolAPIKey != nil && !secrets.IsReference(*t.ToolAPIKey) {
+			apiKey, err := db.EncryptSecret(*t.ToolAPIKey)
 			if err != nil {
-				ts.log.Error("Failed to marshal tool input", "error", err)
-				return
+				ts.log.Error("Failed to encrypt tool API key for the worker", "tool_run_id", t.ToolRunId, "error", err)
+				errorContent, _ := db.NewJsonRaw(map[string]any{"error": "Failed to hand the tool over to a worker"})
+				PublishToolResult(ts.s.GetNATS(), ts.log, t.ToolRunId, errorContent, true, header, meta)
+				continue
+			}
+			t.ToolAPIKey = &apiKey
+		}
+
+		event := service.NewEvent(&t, header, &service.EventMetadata{
+			TraceID:   meta.TraceID,
+			Timestamp: time.Now(),
+		})
+		if err := event.Publish(ts.s.GetNATS()); err != nil {
+			ts.log.Error("Failed to publish edge tool request", "tool_run_id", t.ToolRunId, "edge_group", t.EdgeGroup, "error", err)
+			errorContent, _ := db.NewJsonRaw(map[string]any{"error": "Failed to hand the tool over to a worker"})
+			PublishToolResult(ts.s.GetNATS(), ts.log, t.ToolRunId, errorContent, true, header, meta)
+			continue
+		}
+		ts.log.Info("Handed edge tool over to the workers", "tool_name", t.ToolName, "tool_run_id", t.ToolRunId, "edge_group", t.EdgeGroup)
+	}
+}
+
+// edgeGroupConsumed reports whether the workers of an edge group consume the edge tool requests, their consumer is
+// created by the first worker of the group. The requests are handed over when the consumers cannot be looked up.
+func (ts *ToolService) edgeGroupConsumed(group string) bool {
+	_, err := ts.js.GetConsumerInfo(service.WorkerToolsStream, service.EdgeToolConsumerName(group))
+	if errors.Is(err, jetstream.ErrStreamNotFound) || errors.Is(err, jetstream.ErrConsumerNotFound) {
+		return false
+	}
+	if err != nil {
+		ts.log.Warn("Failed to look up the consumer of the edge group", "edge_group", group, "error", err)
+	}
+	return true
+}
+
+// RunStandaloneTool sends the tool input to the tool server, retrying on server errors, and returns the tool result content.
+// The API key is decrypted and resolved from its secret reference on every call so rotated secrets are picked up.
+func RunStandaloneTool(ctx context.Context, resolver *secrets.Resolver, log hclog.Logger, t service.StandaloneToolRequestEventMessage) (db.JsonRaw, bool) {
+	c, cancel := context.WithTimeout(ctx, RequestTimeOut)
+	defer cancel()
+
+	errorResult := func(message string) (db.JsonRaw, bool) {
+		content, _ := db.NewJsonRaw(map[string]any{"error": message})
+		return content, true
+	}
+
+	b, err := json.Marshal(t.ToolInput)
+	if err != nil {
+		log.Error("Failed to marshal tool input", "error", err)
+		return errorResult(err.Error())
+	}
+
+	var apiKey string
+	if t.ToolAPIKey != nil {
+		apiKey, err = db.DecryptSecret(*t.ToolAPIKey)
+		if err == nil {
+			apiKey, err = resolver.Resolve(c, apiKey)
+		}
+		if err != nil {
+			log.Error("Failed to resolve tool API key", "tool_run_id", t.ToolRunId, "error", err)
+			return errorResult("Failed to resolve the API key of the tool")
+		}
+	}
+
+	client := &http.Client{}
+	var resp *http.Response
+	for i := range RequestRetries {
+		// Create a new request for each retry attempt to avoid body reader exhaustion
+		var req *http.Request
+		req, err = http.NewRequestWithContext(c, "POST", t.ToolURL, bytes.NewReader(b))
+		if err != nil {
+			log.Error("Failed to create new tool standalone request", "error", err)
+			return errorResult(err.Error())
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		if t.ToolAPIKey != nil {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+
+		resp, err = client.Do(req)
+		if resp != nil {
+			defer resp.Body.Close()
+		}
+
+		if err == nil && resp.StatusCode < 500 {
+			body, readErr := io.ReadAll(resp.Body)
+			if readErr != nil {
+				log.Error("Failed to read response body", "error", readErr)
+				return errorResult(readErr.Error())
 			}
 
-			client := &http.Client{}
-			var success bool
-
-			for i := range RequestRetries {
-				// Create a new request for each retry attempt to avoid body reader exhaustion
-				req, err := http.NewRequestWithContext(c, "POST", t.ToolURL, bytes.NewReader(b))
-				if err != nil {
-					ts.log.Error("Failed to create new tool standalone request", "error", err)
-					return
-				}
-				req.Header.Set("Content-Type", "application/json")
-				req.Header.Set("Accept", "application/json")
-				if t.ToolAPIKey != nil {
-					req.Header.Set("Authorization", "Bearer "+*t.ToolAPIKey)
-				}
-
-				resp, err = client.Do(req)
-				if resp != nil {
-					defer resp.Body.Close()
-				}
-
-				if err == nil && resp.StatusCode < 500 {
-					body, readErr := io.ReadAll(resp.Body)
-					if readErr != nil {
-						ts.log.Error("Failed to read response body", "error", readErr)
-						// Send error event for read body failure
-						errorContent, _ := db.NewJsonRaw(map[string]any{"error": readErr.Error()})
-						event := service.NewEvent(&service.ToolGatherEventMessage{
-							ToolRunId:  t.ToolRunId,
-							Content:    errorContent,
-							ResultType: db.ResultMessageTypeText,
-							IsError:    true,
-						}, header, &service.EventMetadata{
-							TraceID:   meta.TraceID,
-							Timestamp: time.Now(),
-						})
-						if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
-							ts.log.Error("failed to publish error to tool gather event", "error", publishErr)
-						}
-						success = true
-						break
-					}
-
-					// Check if HTTP status indicates error
-					if resp.StatusCode >= 400 {
-						// Send error event for HTTP errors
-						errorContent, _ := db.NewJsonRaw(map[string]any{"error": fmt.Sprintf("HTTP error %d: %s", resp.StatusCode, string(body))})
-						event := service.NewEvent(&service.ToolGatherEventMessage{
-							ToolRunId:  t.ToolRunId,
-							Content:    errorContent,
-							ResultType: db.ResultMessageTypeText,
-							IsError:    true,
-						}, header, &service.EventMetadata{
-							TraceID:   meta.TraceID,
-							Timestamp: time.Now(),
-						})
-						if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
-							ts.log.Error("failed to publish error to tool gather event", "error", publishErr)
-						}
-					} else {
-						// Success case - use NewEvent
-						// Parse the JSON response first, then convert to JsonRaw
-						var parsedResponse any
-						if err := json.Unmarshal(body, &parsedResponse); err != nil {
-							ts.log.Error("Failed to parse response JSON", "error", err)
-							// Send error event for JSON parsing failure
-							errorContent, _ := db.NewJsonRaw(map[string]any{"error": err.Error()})
-							event := service.NewEvent(&service.ToolGatherEventMessage{
-								ToolRunId:  t.ToolRunId,
-								Content:    errorContent,
-								ResultType: db.ResultMessageTypeText,
-								IsError:    true,
-							}, header, &service.EventMetadata{
-								TraceID:   meta.TraceID,
-								Timestamp: time.Now(),
-							})
-							if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
-								ts.log.Error("failed to publish error to tool gather event", "error", publishErr)
-							}
-							success = true
-							break
-						}
-
-						content, err := db.NewJsonRaw(parsedResponse)
-						if err != nil {
-							ts.log.Error("Failed to convert parsed response to JsonRaw", "error", err)
-							// Send error event for JSON conversion failure
-							errorContent, _ := db.NewJsonRaw(map[string]any{"error": err.Error()})
-							event := service.NewEvent(&service.ToolGatherEventMessage{
-								ToolRunId:  t.ToolRunId,
-								Content:    errorContent,
-								ResultType: db.ResultMessageTypeText,
-								IsError:    true,
-							}, header, &service.EventMetadata{
-								TraceID:   meta.TraceID,
-								Timestamp: time.Now(),
-							})
-							if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
-								ts.log.Error("failed to publish error to tool gather event", "error", publishErr)
-							}
-						} else {
-							// Success case - use NewEvent only for non-errors
-							event := service.NewEvent(&service.ToolGatherEventMessage{
-								ToolRunId:  t.ToolRunId,
-								Content:    content,
-								ResultType: db.ResultMessageTypeText,
-								IsError:    false,
-							}, header, &service.EventMetadata{
-								TraceID:   meta.TraceID,
-								Timestamp: time.Now(),
-							})
-							if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
-								ts.log.Error("failed to publish result to tool gather event", "error", publishErr)
-							}
-						}
-					}
-					success = true
-					break
-				}
-
-				if err != nil {
-					ts.log.Error("Failed to send post request to tool standalone", "name", t.ToolName, "error", err, "attempt", i+1)
-				}
-
-				if i < RequestRetries-1 {
-					time.Sleep(RequestDelay)
-				}
+			// Check if HTTP status indicates error
+			if resp.StatusCode >= 400 {
+				return errorResult(fmt.Sprintf("HTTP error %d: %s", resp.StatusCode, string(body)))
 			}
 
-			if !success {
-				errorMsg := "Tool execution failed after all retries"
-				if err != nil {
-					errorMsg = err.Error()
-				} else if resp != nil && resp.StatusCode >= 500 {
-					body, _ := io.ReadAll(resp.Body)
-					errorMsg = string(body)
-					ts.log.Error("Standalone tool response with 500", "name", t.ToolName, "error", errorMsg)
-				}
-
-				// Create error tool gather event with tool run ID
-				errorContent, _ := db.NewJsonRaw(map[string]any{"error": errorMsg})
-				event := service.NewEvent(&service.ToolGatherEventMessage{
-					ToolRunId:  t.ToolRunId,
-					Content:    errorContent,
-					ResultType: db.ResultMessageTypeText,
-					IsError:    true,
-				}, header, &service.EventMetadata{
-					TraceID:   meta.TraceID,
-					Timestamp: time.Now(),
-				})
-				if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
-					ts.log.Error("failed to publish error to tool gather event", "error", publishErr)
-				}
+			// Parse the JSON response first, then convert to JsonRaw
+			var parsedResponse any
+			if err := json.Unmarshal(body, &parsedResponse); err != nil {
+				log.Error("Failed to parse response JSON", "error", err)
+				return errorResult(err.Error())
 			}
-		}(ts.ctx, t)
+			content, err := db.NewJsonRaw(parsedResponse)
+			if err != nil {
+				log.Error("Failed to convert parsed response to JsonRaw", "error", err)
+				return errorResult(err.Error())
+			}
+			return content, false
+		}
+
+		if err != nil {
+			log.Error("Failed to send post request to tool standalone", "name", t.ToolName, "error", err, "attempt", i+1)
+		}
+
+		if i < RequestRetries-1 {
+			time.Sleep(RequestDelay)
+		}
 	}
 
-	ts.log.Info("Send concurrent request to standalone tool", "tool_name", standaloneToolsToExecute)
+	errorMsg := "Tool execution failed after all retries"
+	if err != nil {
+		errorMsg = err.Error()
+	} else if resp != nil && resp.StatusCode >= 500 {
+		body, _ := io.ReadAll(resp.Body)
+		errorMsg = string(body)
+		log.Error("Standalone tool response with 500", "name", t.ToolName, "error", errorMsg)
+	}
+	return errorResult(errorMsg)
+}
+
+// PublishToolResult publishes the result of a tool run to the tool gather event
+func PublishToolResult(nc *nats.Conn, log hclog.Logger, toolRunID string, content db.JsonRaw, isError bool, header *service.EventHeaders, meta *service.EventMetadata) {
+	event := service.NewEvent(&service.ToolGatherEventMessage{
+		ToolRunId:  toolRunID,
+		Content:    content,
+		ResultType: db.ResultMessageTypeText,
+		IsError:    isError,
+	}, header, &service.EventMetadata{
+		TraceID:   meta.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(nc); err != nil {
+		log.Error("failed to publish result to tool gather event", "error", err)
+	}
 }
@@ -0,0 +1,171 @@
+package tools
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// taskBudgetReapInterval is the time between two reclaims of the slots whose result never reached this instance
+const taskBudgetReapInterval = time.Minute
+
+type (
+	// taskToolBudget bounds the tool runs outstanding at once for each task, the batch children included.
+	// The surplus runs queue in the order they were requested and start as the outstanding runs complete.
+	// As the tool limits, the budget is not coordinated between the instances of the tools service.
+	taskToolBudget struct {
+		mu    sync.Mutex
+		max   int
+		tasks map[string]*taskBudgetState // Keyed by task ID
+		runs  map[string]string           // Tool run ID to the task holding its slot
+		now   func() time.Time
+	}
+
+	// taskBudgetState is the usage of the budget of a single task
+	taskBudgetState struct {
+		running map[string]time.Time // Tool run ID to the time its slot was acquired
+		queue   []budgetedToolRun    // Runs waiting for a slot, oldest first
+	}
+
+	// budgetedToolRun is a tool run started once its task has a slot for it
+	budgetedToolRun struct {
+		toolRunID string
+		tool      db.Tool
+		tools     ToolProcessResult
+		header    *service.EventHeaders
+		meta      *service.EventMetadata
+	}
+)
+
+// newTaskToolBudget returns the budget of the tool runs of each task, nil when disabled
+func newTaskToolBudget(cfg *service.TaskToolBudgetConfig) *taskToolBudget {
+	if cfg.Disabled {
+		return nil
+	}
+	return &taskToolBudget{
+		max:   cfg.MaxConcurrentRuns,
+		tasks: make(map[string]*taskBudgetState),
+		runs:  make(map[string]string),
+		now:   time.Now,
+	}
+}
+
+// Submit queues the tool runs of a task and returns the runs that can start now
+func (b *taskToolBudget) Submit(taskID string, runs ...budgetedToolRun) []budgetedToolRun {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.tasks[taskID]
+	if !ok {
+		state = &taskBudgetState{running: make(map[string]time.Time)}
+		b.tasks[taskID] = state
+	}
+	state.queue = append(state.queue, runs...)
+	return b.startable(taskID, state)
+}
+
+// Release frees the slot held by a tool run and returns the queued runs of its task that can start now.
+// It is a no-op for the runs without a slot.
+func (b *taskToolBudget) Release(toolRunID string) []budgetedToolRun {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	taskID, ok := b.runs[toolRunID]
+	if !ok {
+		return nil
+	}
+	delete(b.runs, toolRunID)
+	state := b.tasks[taskID]
+	delete(state.running, toolRunID)
+	return b.startable(taskID, state)
+}
+
+// Reap reclaims the slots held past the lease timeout and returns the queued runs that can start now
+func (b *taskToolBudget) Reap() []budgetedToolRun {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var started []budgetedToolRun
+	now := b.now()
+	for taskID, state := range b.tasks {
+		for runID, acquiredAt := range state.running {
+			if now.Sub(acquiredAt) >= ToolRunLeaseTimeout {
+				delete(state.running, runID)
+				delete(b.runs, runID)
+			}
+		}
+		started = append(started, b.startable(taskID, state)...)
+	}
+	return started
+}
+
+// startable takes the slots left for the oldest queued runs of a task, and forgets the task once it is idle.
+// The caller holds the lock.
+func (b *taskToolBudget) startable(taskID string, state *taskBudgetState) []budgetedToolRun {
+	var started []budgetedToolRun
+	now := b.now()
+	for len(state.queue) > 0 && len(state.running) < b.max {
+		run := state.queue[0]
+		state.queue = state.queue[1:]
+		state.running[run.toolRunID] = now
+		b.runs[run.toolRunID] = taskID
+		started = append(started, run)
+	}
+	if len(state.running) == 0 && len(state.queue) == 0 {
+		delete(b.tasks, taskID)
+	}
+	return started
+}
+
+// budgeted reports whether a tool run takes a slot of the budget of its task. The workflow runs
+// report their result to the flows service, they would hold their slot until the lease timeout.
+func (r ToolProcessResult) budgeted() bool {
+	return !r.isEmpty() && len(r.WorkflowTools) == 0
+}
+
+// submitTaskToolRuns queues the tool runs of a task on its budget and starts the ones it allows
+func (ts *ToolService) submitTaskToolRuns(taskID string, runs []budgetedToolRun) {
+	if len(runs) == 0 {
+		return
+	}
+	started := ts.budget.Submit(taskID, runs...)
+	if queued := len(runs) - len(started); queued > 0 {
+		ts.log.Info("Tool runs queued on the task budget", "task_id", taskID, "started", len(started), "queued", queued)
+	}
+	ts.startBudgetedToolRuns(started)
+}
+
+// releaseTaskToolRun frees the budget slot of a completed tool run and starts the runs queued behind it
+func (ts *ToolService) releaseTaskToolRun(toolRunID string) {
+	if ts.budget == nil {
+		return
+	}
+	ts.startBudgetedToolRuns(ts.budget.Release(toolRunID))
+}
+
+// startBudgetedToolRuns starts the tool runs given a slot, the ones with tool limits then queue on the limiter
+func (ts *ToolService) startBudgetedToolRuns(runs []budgetedToolRun) {
+	for _, run := range runs {
+		if run.tool.Config.Limits.Enabled() {
+			ts.executeLimitedTool(run.toolRunID, run.tool, run.tools, run.header, run.meta)
+			continue
+		}
+		go ts.executeTools(run.tools, run.header, run.meta)
+	}
+}
+
+// runTaskBudgetReaper reclaims in the background the slots of the runs whose result never came back
+func (ts *ToolService) runTaskBudgetReaper() {
+	ticker := time.NewTicker(taskBudgetReapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ts.ctx.Done():
+			return
+		case <-ticker.C:
+			ts.startBudgetedToolRuns(ts.budget.Reap())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pinazu/internal/agents"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// deniedToolRule returns the deny rule of the agent specs matching the tool, nil when the agent can call it
+func (ts *ToolService) deniedToolRule(queries *db.Queries, req *service.Event[*service.ToolDispatchEventMessage], tool db.Tool) *agents.ToolDenyRule {
+	specs := ts.getAgentSpecs(queries, req.H.Workspace(), req.Msg.AgentId)
+	if specs == nil {
+		// Calls from flows or deleted agents have no specs
+		return nil
+	}
+	return specs.DeniedTool(tool)
+}
+
+// publishToolDeniedError returns an error tool result to the agent instead of invoking a tool denied by its specs.
+// The result is recorded with the run in the audit log, flagged as denied with the rule.
+func (ts *ToolService) publishToolDeniedError(toolRunID string, tool db.Tool, rule *agents.ToolDenyRule, req *service.Event[*service.ToolDispatchEventMessage]) {
+	ts.log.Warn("Tool call denied by the agent specs", "tool_name", tool.Name, "tool_run_id", toolRunID, "agent_id", req.Msg.AgentId, "rule", rule.String())
+
+	content, err := toolDeniedContent(tool, rule)
+	if err != nil {
+		ts.log.Error("Failed to marshal tool denied error", "error", err)
+		return
+	}
+
+	event := service.NewEvent(&service.ToolGatherEventMessage{
+		ToolRunId:  toolRunID,
+		Content:    content,
+		ResultType: db.ResultMessageTypeText,
+		IsError:    true,
+	}, req.H, &service.EventMetadata{
+		TraceID:   req.M.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("failed to publish result to tool gather event", "error", err)
+	}
+}
+
+// toolDeniedContent returns the error tool result of a call denied by the agent specs
+func toolDeniedContent(tool db.Tool, rule *agents.ToolDenyRule) (db.JsonRaw, error) {
+	reason := rule.Reason
+	if reason == "" {
+		reason = rule.String()
+	}
+	return db.NewJsonRaw(map[string]any{
+		"error":  fmt.Sprintf("Tool %s is denied to this agent: %s. Do not call it again", tool.Name, reason),
+		"denied": true,
+		"rule":   rule.String(),
+	})
+}
@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pinazu/internal/agents"
+	"github.com/pinazu/internal/db"
+)
+
+// getAgentTool returns the tool called by an agent, with the revision pinned in the agent tool_refs applied
+// so the tool runs with the configuration the agent was given the schema of.
+// The tools offered by the agent are matched first, by the name given to the model, sanitized or namespaced on collisions.
+// The agent and the tools are looked up in the workspace of the call.
+func (ts *ToolService) getAgentTool(queries *db.Queries, workspaceID, agentID uuid.UUID, name string) (db.Tool, error) {
+	specs := ts.getAgentSpecs(queries, workspaceID, agentID)
+	tool, err := queries.GetToolInfoByName(ts.ctx, db.GetToolInfoByNameParams{Name: name, WorkspaceID: db.WorkspaceUUID(workspaceID)})
+	if err != nil && err != pgx.ErrNoRows {
+		return db.Tool{}, err
+	}
+	if specs != nil && (err != nil || !specs.OffersTool(tool)) {
+		if offered, ok := ts.findOfferedTool(queries, workspaceID, specs, name); ok {
+			tool, err = offered, nil
+		}
+	}
+	if err != nil {
+		return db.Tool{}, err
+	}
+	if specs == nil {
+		// Calls from flows or deleted agents have no specs, they use the promoted revision
+		return tool, nil
+	}
+
+	revision, ok := specs.PinnedToolRevisions()[tool.ID]
+	if !ok || revision == tool.Revision {
+		return tool, nil
+	}
+	r, err := queries.GetToolRevision(ts.ctx, db.GetToolRevisionParams{ToolID: tool.ID, Revision: revision})
+	if err != nil {
+		return db.Tool{}, fmt.Errorf("failed to get pinned revision %d of tool %s: %w", revision, tool.Name, err)
+	}
+	return tool.ApplyRevision(r), nil
+}
+
+// findOfferedTool looks up a tool offered by the agent by the name given to the model
+func (ts *ToolService) findOfferedTool(queries *db.Queries, workspaceID uuid.UUID, specs *agents.AgentSpecs, name string) (db.Tool, bool) {
+	ids := make([]uuid.UUID, 0, len(specs.ToolRefs))
+	for _, ref := range specs.ToolRefs {
+		ids = append(ids, ref.ID)
+	}
+	offered, err := queries.GetToolsByIDs(ts.ctx, db.GetToolsByIDsParams{Ids: ids, WorkspaceID: workspaceID})
+	if err != nil {
+		ts.log.Warn("Failed to get the tools offered by the agent", "error", err)
+		return db.Tool{}, false
+	}
+	if len(specs.ToolTags) > 0 {
+		tagged, err := queries.ListToolsByTags(ts.ctx, db.ListToolsByTagsParams{Tags: specs.ToolTags, WorkspaceID: workspaceID})
+		if err != nil {
+			ts.log.Warn("Failed to get the tools offered by the agent", "error", err)
+			return db.Tool{}, false
+		}
+		offered = append(offered, tagged...)
+	}
+	for _, tool := range offered {
+		if tool.MatchesModelToolName(name) {
+			return tool, true
+		}
+	}
+	return db.Tool{}, false
+}
+
+// getAgentSpecs returns the specs of an agent of a workspace, nil for the calls from flows, deleted agents or invalid
+// specs
+func (ts *ToolService) getAgentSpecs(queries *db.Queries, workspaceID, agentID uuid.UUID) *agents.AgentSpecs {
+	specsYAML, err := queries.GetAgentSpecsByID(ts.ctx, db.GetAgentSpecsByIDParams{WorkspaceID: workspaceID, ID: agentID})
+	if err != nil || !specsYAML.Valid {
+		return nil
+	}
+	specs, err := agents.ParseAgentSpecs(specsYAML.String)
+	if err != nil {
+		ts.log.Warn("Failed to parse agent specs", "agent_id", agentID, "error", err)
+		return nil
+	}
+	return specs
+}
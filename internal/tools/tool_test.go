@@ -1 +1,814 @@
 package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/agents"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestToolService() *ToolService {
+	return &ToolService{
+		config: &service.ExternalDependenciesConfig{},
+		log:    hclog.NewNullLogger(),
+		ctx:    context.Background(),
+	}
+}
+
+func Test_limitedBuffer(t *testing.T) {
+	b := &limitedBuffer{limit: 5}
+	n, err := b.Write([]byte("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, 11, n, "Write should report the whole input as written")
+	assert.Equal(t, "hello\n... [output truncated]", b.String())
+
+	b = &limitedBuffer{limit: 16}
+	_, _ = b.Write([]byte("hello"))
+	assert.Equal(t, "hello", b.String())
+}
+
+func Test_runCodeSnippet(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 is not available")
+	}
+	if runtime.GOOS != "linux" || os.Geteuid() != 0 {
+		t.Skip("the sandbox runs the snippets as another user in their own namespaces, which requires root on Linux")
+	}
+	ts := newTestToolService()
+	cfg := ts.config.GetCodeInterpreterConfig()
+
+	result, err := ts.runCodeSnippet(ts.ctx, cfg, service.StandaloneToolRequestEventMessage{
+		ToolRunId: "toolu_test",
+		ToolInput: map[string]any{
+			"language": "python",
+			"code":     "print('hi')\nopen('out.txt', 'w').write('data')",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.False(t, result.TimedOut)
+	assert.Equal(t, "hi\n", result.Stdout)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "out.txt", result.Files[0].Name)
+	assert.Equal(t, "text/plain", result.Files[0].MediaType)
+
+	result, err = ts.runCodeSnippet(ts.ctx, cfg, service.StandaloneToolRequestEventMessage{
+		ToolRunId: "toolu_test_exit",
+		ToolInput: map[string]any{"language": "python", "code": "import sys\nsys.exit(3)"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ExitCode)
+
+	// The snippet runs as the sandbox user without network access
+	result, err = ts.runCodeSnippet(ts.ctx, cfg, service.StandaloneToolRequestEventMessage{
+		ToolRunId: "toolu_test_sandbox",
+		ToolInput: map[string]any{
+			"language": "python",
+			"code":     "import os, socket\nprint(os.getuid())\ntry:\n    socket.create_connection(('1.1.1.1', 53), timeout=1)\n    print('online')\nexcept OSError:\n    print('offline')",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%d\noffline\n", cfg.SandboxUID), result.Stdout)
+
+	// The files and the processes of the snippet are limited
+	limited := *cfg
+	limited.MaxFileSizeMB = 1
+	limited.MaxProcesses = 4
+	result, err = ts.runCodeSnippet(ts.ctx, &limited, service.StandaloneToolRequestEventMessage{
+		ToolRunId: "toolu_test_file_size",
+		ToolInput: map[string]any{"language": "python", "code": "open('big.bin', 'wb').write(b'0' * 2 * 1024 * 1024)"},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, 0, result.ExitCode)
+	result, err = ts.runCodeSnippet(ts.ctx, &limited, service.StandaloneToolRequestEventMessage{
+		ToolRunId: "toolu_test_processes",
+		ToolInput: map[string]any{"language": "python", "code": "import subprocess\nfor _ in range(16):\n    subprocess.Popen(['sleep', '5'])"},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, 0, result.ExitCode)
+	assert.Contains(t, result.Stderr, "Resource temporarily unavailable")
+
+	// The processes spawned by the snippet are killed with it on timeout
+	cfg.TimeoutSeconds = 1
+	start := time.Now()
+	result, err = ts.runCodeSnippet(ts.ctx, cfg, service.StandaloneToolRequestEventMessage{
+		ToolRunId: "toolu_test_timeout",
+		ToolInput: map[string]any{"language": "python", "code": "import subprocess\nsubprocess.Popen(['sleep', '60'])\nsubprocess.run(['sleep', '60'])"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	_, err = ts.runCodeSnippet(ts.ctx, cfg, service.StandaloneToolRequestEventMessage{
+		ToolRunId: "toolu_test_lang",
+		ToolInput: map[string]any{"language": "ruby", "code": "puts 1"},
+	})
+	assert.Error(t, err, "Unsupported language should be rejected")
+}
+
+func Test_createToolResultContent_Code(t *testing.T) {
+	ts := newTestToolService()
+	content, err := db.NewJsonRaw(codeInterpreterResult{
+		Language: "python",
+		Stdout:   "hi\n",
+		Files:    []codeInterpreterFile{{Name: "plot.png", MediaType: "image/png", Size: 3}},
+		Images:   []codeInterpreterImage{{Name: "plot.png", MediaType: "image/png", Data: "AAAA"}},
+	})
+	require.NoError(t, err)
+
+	blocks, err := ts.createToolResultContent(content, db.ResultMessageTypeCode, false)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.NotNil(t, blocks[0].OfText)
+	assert.Contains(t, blocks[0].OfText.Text, "Exit code: 0")
+	assert.Contains(t, blocks[0].OfText.Text, "plot.png")
+	require.NotNil(t, blocks[1].OfImage)
+	assert.Equal(t, "AAAA", blocks[1].OfImage.Source.OfBase64.Data)
+}
+
+func Test_htmlToMarkdown(t *testing.T) {
+	base, _ := url.Parse("https://example.com/docs/page.html")
+	document := `<html><head><title> Example  Page </title><style>body{}</style></head><body>
+<nav><a href="/">Home</a></nav>
+<main>
+  <h1>Getting <em>started</em></h1>
+  <p>Read the <a href="guide.html">guide</a> and <strong>install</strong> it.<script>alert(1)</script></p>
+  <ul><li>One</li><li>Two<ol><li>Nested</li></ol></li></ul>
+  <pre><code class="language-go">func main() {
+	fmt.Println("hi")
+}</code></pre>
+  <blockquote><p>Quoted text</p></blockquote>
+  <table><tr><th>Name</th><th>Value</th></tr><tr><td>a|b</td><td>1</td></tr></table>
+</main>
+<footer>Copyright</footer>
+</body></html>`
+
+	title, content, err := htmlToMarkdown(document, base)
+	require.NoError(t, err)
+	assert.Equal(t, "Example Page", title)
+	assert.Equal(t, "# Getting started\n\n"+
+		"Read the [guide](https://example.com/docs/guide.html) and **install** it.\n\n"+
+		"- One\n- Two\n  1. Nested\n\n"+
+		"```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```\n\n"+
+		"> Quoted text\n\n"+
+		"| Name | Value |\n| --- | --- |\n| a\\|b | 1 |", content)
+}
+
+func Test_runFetchURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><head><title>Page</title></head><body><p>Hello <b>world</b></p></body></html>`))
+		case "/data":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"ok": true}`))
+		case "/binary":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte{0, 1, 2})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ts := newTestToolService()
+	cfg := ts.config.GetFetchURLConfig()
+
+	_, err := ts.runFetchURL(ts.ctx, cfg, map[string]any{"url": server.URL + "/page"})
+	require.Error(t, err, "Loopback addresses should be refused by default")
+	assert.ErrorIs(t, err, errPrivateAddress)
+
+	cfg.AllowPrivateNetworks = true
+	text, err := ts.runFetchURL(ts.ctx, cfg, map[string]any{"url": server.URL + "/page"})
+	require.NoError(t, err)
+	assert.Equal(t, "# Page\n\nSource: "+server.URL+"/page\n\nHello **world**", text)
+
+	text, err = ts.runFetchURL(ts.ctx, cfg, map[string]any{"url": server.URL + "/data", "max_chars": float64(5)})
+	require.NoError(t, err)
+	assert.Contains(t, text, `{"ok"`)
+	assert.Contains(t, text, "[content truncated at 5 characters]")
+
+	_, err = ts.runFetchURL(ts.ctx, cfg, map[string]any{"url": server.URL + "/binary"})
+	assert.ErrorContains(t, err, "unsupported content type")
+
+	_, err = ts.runFetchURL(ts.ctx, cfg, map[string]any{"url": server.URL + "/missing"})
+	assert.ErrorContains(t, err, "HTTP 404")
+
+	_, err = ts.runFetchURL(ts.ctx, cfg, map[string]any{"url": "file:///etc/passwd"})
+	assert.Error(t, err, "Only http and https URLs should be fetched")
+}
+
+func Test_runWebSearch(t *testing.T) {
+	tests := []struct {
+		provider string
+		handler  func(t *testing.T, w http.ResponseWriter, r *http.Request)
+	}{
+		{"tavily", func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "golang", body["query"])
+			assert.Equal(t, float64(2), body["max_results"])
+			_, _ = w.Write([]byte(`{"results": [{"title": "Go", "url": "https://go.dev", "content": "The Go\nlanguage"}]}`))
+		}},
+		{"brave", func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "test-key", r.Header.Get("X-Subscription-Token"))
+			assert.Equal(t, "golang", r.URL.Query().Get("q"))
+			assert.Equal(t, "2", r.URL.Query().Get("count"))
+			_, _ = w.Write([]byte(`{"web": {"results": [{"title": "Go", "url": "https://go.dev", "description": "The Go language"}]}}`))
+		}},
+		{"serpapi", func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "test-key", r.URL.Query().Get("api_key"))
+			assert.Equal(t, "golang", r.URL.Query().Get("q"))
+			_, _ = w.Write([]byte(`{"organic_results": [{"title": "Go", "link": "https://go.dev", "snippet": "The Go language"}]}`))
+		}},
+	}
+
+	ts := newTestToolService()
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.handler(t, w, r)
+			}))
+			defer server.Close()
+
+			cfg := &service.WebSearchConfig{Provider: tt.provider, APIKey: "test-key", Endpoint: server.URL, MaxResults: 5, TimeoutSeconds: 5}
+			text, err := ts.runWebSearch(ts.ctx, cfg, map[string]any{"query": "golang", "max_results": float64(2)})
+			require.NoError(t, err)
+			assert.Equal(t, "Search results for \"golang\":\n\n1. [Go](https://go.dev)\n   The Go language\n", text)
+		})
+	}
+
+	_, err := ts.runWebSearch(ts.ctx, &service.WebSearchConfig{Provider: "tavily"}, map[string]any{"query": "golang"})
+	assert.ErrorContains(t, err, "api_key is required")
+	_, err = ts.runWebSearch(ts.ctx, &service.WebSearchConfig{Provider: "bing", APIKey: "k"}, map[string]any{"query": "golang"})
+	assert.ErrorContains(t, err, "unsupported web search provider")
+	_, err = ts.runWebSearch(ts.ctx, ts.config.GetWebSearchConfig(), map[string]any{})
+	assert.ErrorContains(t, err, "query is required")
+}
+
+func Test_toolLimiter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newLimiter := func() *toolLimiter {
+		l := newToolLimiter()
+		l.now = func() time.Time { return now }
+		return l
+	}
+
+	t.Run("No limits", func(t *testing.T) {
+		l := newLimiter()
+		for i := range 5 {
+			require.NoError(t, l.Acquire(context.Background(), "tool", fmt.Sprint(i), nil))
+		}
+	})
+
+	t.Run("Invocations per minute", func(t *testing.T) {
+		l := newLimiter()
+		limits := &db.ToolLimits{InvocationsPerMinute: 2}
+		_, _, ok := l.tryAcquire("tool", "a", limits)
+		require.True(t, ok)
+		_, _, ok = l.tryAcquire("tool", "b", limits)
+		require.True(t, ok)
+		retryAfter, _, ok := l.tryAcquire("tool", "c", limits)
+		assert.False(t, ok)
+		assert.Equal(t, time.Minute, retryAfter)
+
+		// Other tools have their own budget
+		_, _, ok = l.tryAcquire("other", "d", limits)
+		assert.True(t, ok)
+
+		now = now.Add(time.Minute)
+		_, _, ok = l.tryAcquire("tool", "c", limits)
+		assert.True(t, ok)
+	})
+
+	t.Run("Max concurrent runs", func(t *testing.T) {
+		l := newLimiter()
+		limits := &db.ToolLimits{MaxConcurrentRuns: 1, MaxWaitSeconds: 5}
+		require.NoError(t, l.Acquire(context.Background(), "tool", "a", limits))
+
+		acquired := make(chan error)
+		go func() { acquired <- l.Acquire(context.Background(), "tool", "b", limits) }()
+		select {
+		case <-acquired:
+			t.Fatal("second run started while the first one holds the slot")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		l.Release("a")
+		require.NoError(t, <-acquired)
+	})
+
+	t.Run("Throttled after the wait", func(t *testing.T) {
+		l := newLimiter()
+		limits := &db.ToolLimits{MaxConcurrentRuns: 1, MaxWaitSeconds: 1}
+		require.NoError(t, l.Acquire(context.Background(), "tool", "a", limits))
+		assert.ErrorIs(t, l.Acquire(context.Background(), "tool", "b", limits), errToolThrottled)
+	})
+
+	t.Run("Expired lease frees the slot", func(t *testing.T) {
+		l := newLimiter()
+		limits := &db.ToolLimits{MaxConcurrentRuns: 1}
+		_, _, ok := l.tryAcquire("tool", "a", limits)
+		require.True(t, ok)
+		now = now.Add(ToolRunLeaseTimeout)
+		_, _, ok = l.tryAcquire("tool", "b", limits)
+		assert.True(t, ok)
+	})
+}
+
+func Test_toolHealthCheck(t *testing.T) {
+	ts := newTestToolService()
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("maintenance until 02:00, admin token abc"))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &service.ToolHealthCheckConfig{}
+	check := &db.ToolHealthCheck{URL: server.URL, FailureThreshold: 2}
+
+	t.Run("URL check", func(t *testing.T) {
+		ts.health = service.NewWebhookClient(true)
+		require.NoError(t, ts.probeTool(cfg, check))
+		healthy = false
+		err := ts.probeTool(cfg, check)
+		assert.ErrorContains(t, err, "HTTP 503")
+		assert.NotContains(t, err.Error(), "maintenance", "The body of the response is not recorded")
+		healthy = true
+
+		// The private networks are refused unless allowed
+		ts.health = service.NewWebhookClient(false)
+		assert.ErrorIs(t, ts.probeTool(cfg, check), service.ErrPrivateWebhookAddress)
+	})
+
+	t.Run("Status transitions", func(t *testing.T) {
+		failed := fmt.Errorf("connection refused")
+		status, failures := nextToolStatus(0, check, failed)
+		assert.Equal(t, db.ToolStatusDegraded, status)
+		status, failures = nextToolStatus(failures, check, failed)
+		assert.Equal(t, db.ToolStatusUnreachable, status)
+		status, failures = nextToolStatus(failures, check, nil)
+		assert.Equal(t, db.ToolStatusHealthy, status)
+		assert.Zero(t, failures)
+	})
+
+	t.Run("Commands disabled", func(t *testing.T) {
+		command := &db.ToolHealthCheck{Command: []string{"true"}}
+		err := ts.probeTool(cfg, command)
+		require.ErrorIs(t, err, errCommandHealthCheckDisabled)
+		status, _ := nextToolStatus(0, command, err)
+		assert.Equal(t, db.ToolStatusUnknown, status)
+
+		if _, err := exec.LookPath("false"); err != nil {
+			t.Skip("false is not available")
+		}
+		allowed := &service.ToolHealthCheckConfig{AllowCommands: true}
+		assert.NoError(t, ts.probeTool(allowed, command))
+		assert.Error(t, ts.probeTool(allowed, &db.ToolHealthCheck{Command: []string{"false"}}))
+	})
+}
+
+func Test_RunStandaloneTool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("invalid api key"))
+			return
+		}
+		var input map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		_ = json.NewEncoder(w).Encode(map[string]any{"echo": input["city"]})
+	}))
+	defer server.Close()
+
+	apiKey := "test-key"
+	request := service.StandaloneToolRequestEventMessage{
+		ToolRunId:  "run-1",
+		ToolName:   "weather",
+		ToolInput:  map[string]any{"city": "Hanoi"},
+		ToolURL:    server.URL,
+		ToolAPIKey: &apiKey,
+	}
+
+	content, isError := RunStandaloneTool(context.Background(), nil, hclog.NewNullLogger(), request)
+	assert.False(t, isError)
+	assert.JSONEq(t, `{"echo":"Hanoi"}`, string(content))
+
+	wrongKey := "wrong-key"
+	request.ToolAPIKey = &wrongKey
+	content, isError = RunStandaloneTool(context.Background(), nil, hclog.NewNullLogger(), request)
+	assert.True(t, isError)
+	assert.Contains(t, string(content), "HTTP error 401")
+
+	// Secret references need a secrets manager
+	reference := "vault://secret/tools/weather#api_key"
+	request.ToolAPIKey = &reference
+	content, isError = RunStandaloneTool(context.Background(), nil, hclog.NewNullLogger(), request)
+	assert.True(t, isError)
+	assert.Contains(t, string(content), "Failed to resolve the API key")
+}
+
+// memoryResultStore keeps the offloaded tool results in memory
+type memoryResultStore map[string][]byte
+
+func (m memoryResultStore) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	b, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	m[*params.Bucket+"/"+*params.Key] = b
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (m memoryResultStore) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	b, ok := m[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, fmt.Errorf("no such key %s", *params.Key)
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(b))}, nil
+}
+
+func Test_resultOffloader(t *testing.T) {
+	ts := newTestToolService()
+	store := memoryResultStore{}
+	ts.offloader = &resultOffloader{
+		store: store,
+		cfg:   &service.ToolResultOffloadConfig{Bucket: "results", Prefix: "tool-results/", ThresholdBytes: 64, MaxChars: 10},
+	}
+
+	// Results under the threshold stay in the database
+	ref, err := ts.offloader.offload(ts.ctx, "run-1", db.JsonRaw(`{"text":"small"}`))
+	require.NoError(t, err)
+	assert.Nil(t, ref)
+
+	content, err := db.NewJsonRaw(map[string]string{"text": strings.Repeat("abcdefghij", 10)})
+	require.NoError(t, err)
+	ref, err = ts.offloader.offload(ts.ctx, "run-2", content)
+	require.NoError(t, err)
+	require.NotNil(t, ref)
+	assert.Equal(t, "s3://results/tool-results/run-2.json", ref.URL)
+	assert.Equal(t, len(content), ref.SizeBytes)
+	assert.Equal(t, []byte(content), store["results/tool-results/run-2.json"])
+
+	stored, err := db.NewJsonRaw(ref)
+	require.NoError(t, err)
+	truncate := func() agents.ToolResultSpecs {
+		return agents.ToolResultSpecs{Offloaded: agents.ToolResultOffloadedTruncate, MaxChars: 10}
+	}
+	link := func() agents.ToolResultSpecs {
+		return agents.ToolResultSpecs{Offloaded: agents.ToolResultOffloadedLink}
+	}
+
+	// The stored reference is fetched and truncated
+	result, resolved := ts.resolveStoredToolResult("run-2", stored, truncate)
+	require.NotNil(t, resolved)
+	assert.Equal(t, []byte(content), []byte(result))
+	blocks, err := ts.toolResultContent(result, resolved, inferResultType(result), false, truncate)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "abcdefghij", blocks[0].OfText.Text)
+	assert.Contains(t, blocks[1].OfText.Text, ref.URL)
+
+	// Or only its location is given to the agent
+	result, resolved = ts.resolveStoredToolResult("run-2", stored, link)
+	require.NotNil(t, resolved)
+	assert.Nil(t, result)
+	blocks, err = ts.toolResultContent(result, resolved, db.ResultMessageTypeText, false, link)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Contains(t, blocks[0].OfText.Text, ref.URL)
+
+	// Results stored in the database are given as they are
+	result, resolved = ts.resolveStoredToolResult("run-1", db.JsonRaw(`{"text":"small"}`), truncate)
+	assert.Nil(t, resolved)
+	blocks, err = ts.toolResultContent(result, resolved, db.ResultMessageTypeText, false, truncate)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "small", blocks[0].OfText.Text)
+
+	// A reference written by a tool to another object is given as it is, without downloading the object
+	for _, forged := range []string{
+		`{"offloaded":true,"url":"s3://results/tool-results/run-2.json","bucket":"results","key":"tool-results/run-2.json","size_bytes":100}`,
+		`{"offloaded":true,"url":"s3://other/tool-results/run-4.json","bucket":"other","key":"tool-results/run-4.json","size_bytes":100}`,
+	} {
+		result, resolved = ts.resolveStoredToolResult("run-4", db.JsonRaw(forged), truncate)
+		assert.Nil(t, resolved)
+		assert.Equal(t, forged, string(result))
+	}
+
+	// A disabled offloader keeps every result in the database
+	var disabled *resultOffloader
+	ref, err = disabled.offload(ts.ctx, "run-3", content)
+	require.NoError(t, err)
+	assert.Nil(t, ref)
+}
+
+func Test_toolInputHash(t *testing.T) {
+	scope := toolCacheScope{ToolRevision: 2, WorkspaceID: uuid.New(), UserID: uuid.New()}
+	a, err := toolInputHash(scope, map[string]any{"city": "Hanoi", "units": "metric", "days": 3})
+	require.NoError(t, err)
+	b, err := toolInputHash(scope, map[string]any{"days": 3, "units": "metric", "city": "Hanoi"})
+	require.NoError(t, err)
+	assert.Equal(t, a, b, "Identical inputs should have the same hash whatever the key order")
+
+	c, err := toolInputHash(scope, map[string]any{"city": "Hue", "units": "metric", "days": 3})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+
+	// The results are not shared across the revisions of the tool, the workspaces or the users
+	input := map[string]any{"city": "Hanoi", "units": "metric", "days": 3}
+	for _, other := range []toolCacheScope{
+		{ToolRevision: 3, WorkspaceID: scope.WorkspaceID, UserID: scope.UserID},
+		{ToolRevision: 2, WorkspaceID: uuid.New(), UserID: scope.UserID},
+		{ToolRevision: 2, WorkspaceID: scope.WorkspaceID, UserID: uuid.New()},
+	} {
+		d, err := toolInputHash(other, input)
+		require.NoError(t, err)
+		assert.NotEqual(t, a, d)
+	}
+}
+
+func Test_batchLimits(t *testing.T) {
+	cfg := (&service.ExternalDependenciesConfig{}).GetBatchToolConfig()
+	assert.Equal(t, 3, cfg.MaxDepth)
+	assert.Equal(t, 20, cfg.MaxChildren)
+
+	assert.Empty(t, batchLimitError(cfg, 0, 20))
+	assert.Empty(t, batchLimitError(cfg, 2, 1), "A batch nested in two batches is within the default depth")
+	assert.Contains(t, batchLimitError(cfg, 3, 1), "more than 3 levels deep")
+	assert.Contains(t, batchLimitError(cfg, 0, 21), "more than 20 invocations, got 21")
+
+	root, sub := uuid.New(), uuid.New()
+	assert.Empty(t, invokeAgentCycleError([]uuid.UUID{root, sub}, uuid.New()))
+	reason := invokeAgentCycleError([]uuid.UUID{root, sub}, root)
+	assert.Contains(t, reason, fmt.Sprintf("%s -> %s -> %s", root, sub, root))
+	assert.NotEmpty(t, invokeAgentCycleError([]uuid.UUID{root}, root), "An agent cannot invoke itself")
+}
+
+func Test_dryRunToolResult(t *testing.T) {
+	ts := newTestToolService()
+	req := &service.Event[*service.ToolDispatchEventMessage]{Msg: &service.ToolDispatchEventMessage{}, H: &service.EventHeaders{DryRun: true}}
+
+	// Calls rejected before the tool is looked up get an error result
+	content, isError := ts.dryRunToolResult(req, nil, "weather", "not an object", 0)
+	assert.True(t, isError)
+	require.Len(t, content, 1)
+	assert.Contains(t, content[0].OfText.Text, "must be a JSON object")
+
+	content, isError = ts.dryRunErrorResult("Tool weather is not available to this agent")
+	assert.True(t, isError)
+	require.Len(t, content, 1)
+	assert.Equal(t, "Tool weather is not available to this agent", content[0].OfText.Text)
+}
+
+func Test_RegisterInternal(t *testing.T) {
+	schema := &openapi3.Schema{
+		Type:        &openapi3.Types{"object"},
+		Description: "Count the open tickets of a project",
+		Properties:  openapi3.Schemas{"project": openapi3.NewStringSchema().NewRef()},
+		Required:    []string{"project"},
+	}
+	handler := func(ctx context.Context, req InternalToolRequest) (string, error) {
+		return fmt.Sprintf("%s has 3 open tickets", req.Input["project"]), nil
+	}
+
+	RegisterInternal("count_open_tickets", schema, handler)
+	t.Cleanup(func() {
+		internalToolsMu.Lock()
+		delete(internalTools, "count_open_tickets")
+		internalToolsMu.Unlock()
+	})
+
+	assert.Panics(t, func() { RegisterInternal("count_open_tickets", schema, handler) }, "A name is registered once")
+	assert.Panics(t, func() { RegisterInternal("web_search", schema, handler) }, "Built-in tool names are reserved")
+	assert.Panics(t, func() { RegisterInternal("no_handler", schema, nil) })
+
+	registered, ok := registeredInternalTool(db.Tool{Name: "count_open_tickets", Config: db.ToolConfig{Type: db.ToolTypeInternal}})
+	require.True(t, ok)
+	text, err := registered.handler(context.Background(), InternalToolRequest{Input: map[string]any{"project": "pinazu"}})
+	require.NoError(t, err)
+	assert.Equal(t, "pinazu has 3 open tickets", text)
+	assert.Empty(t, db.ValidateToolInput(registered.schema, map[string]any{"project": "pinazu"}))
+	assert.NotEmpty(t, db.ValidateToolInput(registered.schema, map[string]any{}))
+
+	_, ok = registeredInternalTool(db.Tool{Name: "count_open_tickets", Config: db.ToolConfig{Type: db.ToolTypeStandalone}})
+	assert.False(t, ok, "Only the internal tools run the registered handlers")
+}
+
+func Test_mcpGRPCTarget(t *testing.T) {
+	tests := []struct {
+		entrypoint string
+		target     string
+		secure     bool
+	}{
+		{entrypoint: "grpc://localhost:50051", target: "localhost:50051", secure: false},
+		{entrypoint: "grpcs://mcp.example.com:443", target: "mcp.example.com:443", secure: true},
+		{entrypoint: "mcp.example.com:443", target: "mcp.example.com:443", secure: true},
+	}
+	for _, tt := range tests {
+		target, secure := mcpGRPCTarget(tt.entrypoint)
+		assert.Equal(t, tt.target, target, tt.entrypoint)
+		assert.Equal(t, tt.secure, secure, tt.entrypoint)
+	}
+
+	_, err := mcpTransportCredentials(&db.ToolConfigMCP{Entrypoint: "mcp.example.com:443", TLS: &db.ToolMCPTLS{CACert: "not a certificate"}})
+	assert.ErrorContains(t, err, "invalid tls ca_cert")
+}
+
+func Test_runMCPTool(t *testing.T) {
+	// The MCP server lists its tools over two pages and streams the result of a call in two responses
+	handler := func(srv any, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		if method != MCPGRPCCallMethod {
+			return fmt.Errorf("unexpected method %s", method)
+		}
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		if auth := md.Get("authorization"); len(auth) != 1 || auth[0] != "Bearer test_api_key" {
+			return status.Error(codes.Unauthenticated, "missing api key")
+		}
+		var req mcpMessage
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		respond := func(result string) error {
+			return stream.SendMsg(&mcpMessage{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(result)})
+		}
+
+		var params map[string]any
+		_ = json.Unmarshal(req.Params, &params)
+		switch req.Method {
+		case "tools/list":
+			if params["cursor"] == nil {
+				return respond(`{"tools":[{"name":"search","inputSchema":{"type":"object","properties":{"query":{"type":"string"}},"required":["query"]}}],"nextCursor":"page2"}`)
+			}
+			return respond(`{"tools":[{"name":"fetch","inputSchema":{"type":"object"}}]}`)
+		case "tools/call":
+			if params["name"] != "search" {
+				return stream.SendMsg(&mcpMessage{JSONRPC: "2.0", ID: req.ID, Error: &mcpError{Code: -32602, Message: "unknown tool"}})
+			}
+			if err := stream.SendMsg(&mcpMessage{JSONRPC: "2.0", Method: "notifications/progress", Params: json.RawMessage(`{"progress":1,"total":2}`)}); err != nil {
+				return err
+			}
+			if err := respond(`{"content":[{"type":"text","text":"first result"}]}`); err != nil {
+				return err
+			}
+			return respond(`{"content":[{"type":"text","text":"second result"}],"structuredContent":{"count":2}}`)
+		}
+		return stream.SendMsg(&mcpMessage{JSONRPC: "2.0", ID: req.ID, Error: &mcpError{Code: -32601, Message: "method not found"}})
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.ForceServerCodec(mcpJSONCodec{}), grpc.UnknownServiceHandler(handler))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	ts := newTestToolService()
+	ts.mcp = newMCPGRPCClients()
+	t.Cleanup(ts.mcp.close)
+
+	apiKey := "test_api_key"
+	config := &db.ToolConfigMCP{Entrypoint: "grpc://" + listener.Addr().String(), Protocol: db.MCPProtocolGRPC, ApiKey: &apiKey, ToolName: "search"}
+
+	tools, err := ts.mcp.listTools(context.Background(), config, apiKey)
+	require.NoError(t, err)
+	require.Len(t, tools, 2)
+	assert.Equal(t, "search", tools[0].Name)
+	assert.Equal(t, "fetch", tools[1].Name)
+
+	content, isError := ts.runMCPTool(context.Background(), mcpToolRequest{
+		StandaloneToolRequestEventMessage: service.StandaloneToolRequestEventMessage{ToolRunId: "run", ToolName: "docs_search", ToolInput: map[string]any{"query": "pinazu"}},
+		Config:                            config,
+	})
+	assert.False(t, isError)
+	assert.JSONEq(t, `{"content":[{"type":"text","text":"first result"},{"type":"text","text":"second result"}],"structured_content":{"count":2}}`, string(content))
+
+	config.ToolName = "unknown"
+	content, isError = ts.runMCPTool(context.Background(), mcpToolRequest{Config: config})
+	assert.True(t, isError)
+	assert.Contains(t, string(content), "unknown tool")
+
+	wrongKey := "wrong_key"
+	config = &db.ToolConfigMCP{Entrypoint: config.Entrypoint, Protocol: db.MCPProtocolGRPC, ApiKey: &wrongKey}
+	_, err = ts.mcp.listTools(context.Background(), config, wrongKey)
+	assert.ErrorContains(t, err, "missing api key")
+
+	content, isError = ts.runMCPTool(context.Background(), mcpToolRequest{Config: &db.ToolConfigMCP{Protocol: db.MCPProtocolSSE}})
+	assert.True(t, isError)
+	assert.Contains(t, string(content), "not supported")
+}
+
+func Test_toolAuditor(t *testing.T) {
+	cfg := (&service.ExternalDependenciesConfig{Tools: &service.ToolsConfig{Audit: &service.ToolAuditConfig{
+		RedactPatterns: []string{`\b\d{16}\b`},
+		Rules: []service.ToolAuditRule{
+			{Tool: "crm_*", RetentionDays: 30, RedactKeys: []string{"email"}},
+			{Tool: "code_interpreter", OmitOutput: true},
+		},
+	}}}).GetToolAuditConfig()
+	auditor, err := newToolAuditor(cfg)
+	require.NoError(t, err)
+
+	policy := auditor.policy("weather")
+	assert.Equal(t, int32(90), policy.retentionDays)
+	redacted := policy.redact(db.JsonRaw(`{"city":"Paris","API_Key":"secret","auth":{"token":"abc"},"notes":["card 4111111111111111"],"email":"a@example.com"}`))
+	assert.JSONEq(t, `{"city":"Paris","API_Key":"[REDACTED]","auth":{"token":"[REDACTED]"},"notes":["card [REDACTED]"],"email":"a@example.com"}`, string(redacted))
+
+	policy = auditor.policy("crm_contacts")
+	assert.Equal(t, int32(30), policy.retentionDays)
+	redacted = policy.redact(db.JsonRaw(`{"email":"a@example.com","password":"hunter2"}`))
+	assert.JSONEq(t, `{"email":"[REDACTED]","password":"[REDACTED]"}`, string(redacted))
+	assert.False(t, auditor.policy("weather").keys["email"], "Rule keys should not leak into the default policy")
+
+	assert.True(t, auditor.policy("code_interpreter").omitOutput)
+	assert.Nil(t, policy.redact(db.JsonRaw(`not json`)), "Invalid JSON should not be kept unredacted")
+
+	_, err = newToolAuditor(&service.ToolAuditConfig{RedactPatterns: []string{"("}})
+	assert.ErrorContains(t, err, "invalid redact pattern")
+	disabled, err := newToolAuditor(&service.ToolAuditConfig{Disabled: true})
+	require.NoError(t, err)
+	assert.Nil(t, disabled)
+}
+
+func Test_taskToolBudget(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newTaskToolBudget(&service.TaskToolBudgetConfig{MaxConcurrentRuns: 2})
+	b.now = func() time.Time { return now }
+	runIDs := func(runs []budgetedToolRun) []string {
+		ids := []string{}
+		for _, run := range runs {
+			ids = append(ids, run.toolRunID)
+		}
+		return ids
+	}
+
+	assert.Nil(t, newTaskToolBudget(&service.TaskToolBudgetConfig{Disabled: true}))
+
+	started := b.Submit("task", budgetedToolRun{toolRunID: "a"}, budgetedToolRun{toolRunID: "b"}, budgetedToolRun{toolRunID: "c"})
+	assert.Equal(t, []string{"a", "b"}, runIDs(started))
+	started = b.Submit("task", budgetedToolRun{toolRunID: "d"})
+	assert.Empty(t, started)
+
+	// Other tasks have their own budget
+	assert.Equal(t, []string{"e"}, runIDs(b.Submit("other", budgetedToolRun{toolRunID: "e"})))
+
+	// The queued runs start in the order they were submitted
+	assert.Equal(t, []string{"c"}, runIDs(b.Release("b")))
+	assert.Empty(t, b.Release("b"))
+	assert.Empty(t, b.Release("unknown"))
+	assert.Equal(t, []string{"d"}, runIDs(b.Release("a")))
+
+	// The slots of the runs whose result never came back are reclaimed
+	assert.Empty(t, b.Reap())
+	b.Submit("task", budgetedToolRun{toolRunID: "f"})
+	now = now.Add(ToolRunLeaseTimeout)
+	assert.Equal(t, []string{"f"}, runIDs(b.Reap()))
+
+	// Idle tasks are forgotten
+	b.Release("e")
+	b.Release("f")
+	_, ok := b.tasks["other"]
+	assert.False(t, ok)
+}
+
+func Test_RecallConversationsTool(t *testing.T) {
+	registered, ok := registeredInternalTool(db.Tool{Name: RecallConversationsToolName, Config: db.ToolConfig{Type: db.ToolTypeInternal}})
+	require.True(t, ok, "The recall tool is registered by the tools package")
+	assert.Empty(t, db.ValidateToolInput(registered.schema, map[string]any{"query": "deployment of the billing service", "limit": float64(3)}))
+	assert.NotEmpty(t, db.ValidateToolInput(registered.schema, map[string]any{}))
+	assert.NotEmpty(t, db.ValidateToolInput(registered.schema, map[string]any{"query": "billing", "limit": float64(100)}))
+
+	long := strings.Repeat("é", recallMaxSnippetChars+10)
+	assert.Equal(t, recallMaxSnippetChars+3, len([]rune(truncateRecalledText(long))))
+	assert.Equal(t, "short", truncateRecalledText("short"))
+}
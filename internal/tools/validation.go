@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// publishToolInputError returns the schema violations to the agent as an error tool result instead of invoking the tool
+func (ts *ToolService) publishToolInputError(toolRunID, toolName string, violations []db.ToolInputViolation, header *service.EventHeaders, meta *service.EventMetadata) {
+	ts.log.Warn("Tool input does not match the parameter schema", "tool_name", toolName, "tool_run_id", toolRunID, "violations", violations)
+
+	content, err := toolInputErrorContent(toolName, violations)
+	if err != nil {
+		ts.log.Error("Failed to marshal tool input violations", "error", err)
+		return
+	}
+
+	event := service.NewEvent(&service.ToolGatherEventMessage{
+		ToolRunId:  toolRunID,
+		Content:    content,
+		ResultType: db.ResultMessageTypeText,
+		IsError:    true,
+	}, header, &service.EventMetadata{
+		TraceID:   meta.TraceID,
+		Timestamp: time.Now(),
+	})
+	if err := event.Publish(ts.s.GetNATS()); err != nil {
+		ts.log.Error("failed to publish result to tool gather event", "error", err)
+	}
+}
+
+// toolInputErrorContent returns the error tool result listing the schema violations of a tool input
+func toolInputErrorContent(toolName string, violations []db.ToolInputViolation) (db.JsonRaw, error) {
+	return db.NewJsonRaw(map[string]any{
+		"error":      "Invalid input for tool " + toolName + ", fix the listed parameters and call the tool again",
+		"violations": violations,
+	})
+}
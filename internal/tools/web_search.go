@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// WebSearchToolName is the name of the built-in web search tool
+	WebSearchToolName = "web_search"
+
+	// webSearchMaxResults caps the number of results a single query can request
+	webSearchMaxResults = 20
+
+	// webSearchMaxErrorBody caps the provider error body included in error messages
+	webSearchMaxErrorBody = 512
+)
+
+type (
+	// webSearchResult is a single result returned by a search provider
+	webSearchResult struct {
+		Title   string `json:"title"`
+		URL     string `json:"url"`
+		Snippet string `json:"snippet"`
+	}
+
+	// webSearchProvider queries a web search API
+	webSearchProvider interface {
+		Search(ctx context.Context, query string, maxResults int) ([]webSearchResult, error)
+	}
+
+	// tavilySearchProvider queries the Tavily search API
+	tavilySearchProvider struct {
+		apiKey   string
+		endpoint string
+		client   *http.Client
+	}
+
+	// braveSearchProvider queries the Brave web search API
+	braveSearchProvider struct {
+		apiKey   string
+		endpoint string
+		client   *http.Client
+	}
+
+	// serpAPISearchProvider queries Google results through SerpAPI
+	serpAPISearchProvider struct {
+		apiKey   string
+		endpoint string
+		client   *http.Client
+	}
+)
+
+// newWebSearchProvider creates the search provider selected in the configuration
+func newWebSearchProvider(cfg *service.WebSearchConfig) (webSearchProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("web search is not configured: api_key is required for provider %s", cfg.Provider)
+	}
+
+	client := &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second}
+	endpoint := cfg.Endpoint
+	switch cfg.Provider {
+	case "tavily":
+		if endpoint == "" {
+			endpoint = "https://api.tavily.com/search"
+		}
+		return &tavilySearchProvider{apiKey: cfg.APIKey, endpoint: endpoint, client: client}, nil
+	case "brave":
+		if endpoint == "" {
+			endpoint = "https://api.search.brave.com/res/v1/web/search"
+		}
+		return &braveSearchProvider{apiKey: cfg.APIKey, endpoint: endpoint, client: client}, nil
+	case "serpapi":
+		if endpoint == "" {
+			endpoint = "https://serpapi.com/search.json"
+		}
+		return &serpAPISearchProvider{apiKey: cfg.APIKey, endpoint: endpoint, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported web search provider: %s (supported: tavily, brave, serpapi)", cfg.Provider)
+	}
+}
+
+func (p *tavilySearchProvider) Search(ctx context.Context, query string, maxResults int) ([]webSearchResult, error) {
+	body, err := json.Marshal(map[string]any{
+		"query":       query,
+		"max_results": maxResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	var resp struct {
+		Results []struct {
+			Title   string `json:"title"`
+			URL     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}
+	if err := doSearchRequest(p.client, req, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]webSearchResult, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		results = append(results, webSearchResult{Title: r.Title, URL: r.URL, Snippet: r.Content})
+	}
+	return results, nil
+}
+
+func (p *braveSearchProvider) Search(ctx context.Context, query string, maxResults int) ([]webSearchResult, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("count", strconv.Itoa(maxResults))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", p.apiKey)
+
+	var resp struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	if err := doSearchRequest(p.client, req, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]webSearchResult, 0, len(resp.Web.Results))
+	for _, r := range resp.Web.Results {
+		results = append(results, webSearchResult{Title: r.Title, URL: r.URL, Snippet: r.Description})
+	}
+	return results, nil
+}
+
+func (p *serpAPISearchProvider) Search(ctx context.Context, query string, maxResults int) ([]webSearchResult, error) {
+	params := url.Values{}
+	params.Set("engine", "google")
+	params.Set("q", query)
+	params.Set("num", strconv.Itoa(maxResults))
+	params.Set("api_key", p.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		OrganicResults []struct {
+			Title   string `json:"title"`
+			Link    string `json:"link"`
+			Snippet string `json:"snippet"`
+		} `json:"organic_results"`
+	}
+	if err := doSearchRequest(p.client, req, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]webSearchResult, 0, len(resp.OrganicResults))
+	for _, r := range resp.OrganicResults {
+		results = append(results, webSearchResult{Title: r.Title, URL: r.Link, Snippet: r.Snippet})
+	}
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+	return results, nil
+}
+
+// doSearchRequest sends a search request and decodes the JSON response into v
+func doSearchRequest(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webSearchMaxErrorBody))
+		return fmt.Errorf("search provider returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode search response: %w", err)
+	}
+	return nil
+}
+
+// runWebSearch queries the configured provider and formats the results as markdown
+func (ts *ToolService) runWebSearch(ctx context.Context, cfg *service.WebSearchConfig, input map[string]any) (string, error) {
+	query, _ := input["query"].(string)
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", fmt.Errorf("query is required")
+	}
+
+	maxResults := cfg.MaxResults
+	if v, ok := input["max_results"].(float64); ok && v > 0 {
+		maxResults = int(v)
+	}
+	maxResults = min(maxResults, webSearchMaxResults)
+
+	provider, err := newWebSearchProvider(cfg)
+	if err != nil {
+		return "", err
+	}
+	results, err := provider.Search(ctx, query, maxResults)
+	if err != nil {
+		return "", err
+	}
+	ts.log.Debug("Web search completed", "provider", cfg.Provider, "query", query, "results", len(results))
+
+	if len(results) == 0 {
+		return fmt.Sprintf("No results found for %q.", query), nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Search results for %q:\n", query)
+	for i, r := range results {
+		fmt.Fprintf(&b, "\n%d. [%s](%s)\n", i+1, strings.TrimSpace(r.Title), r.URL)
+		if snippet := strings.TrimSpace(r.Snippet); snippet != "" {
+			fmt.Fprintf(&b, "   %s\n", strings.Join(strings.Fields(snippet), " "))
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// executeWebTool runs the web search and URL fetch tools and publishes the results to the tool gather event
+func (ts *ToolService) executeWebTool(webToolsToExecute []service.StandaloneToolRequestEventMessage, header *service.EventHeaders, meta *service.EventMetadata) {
+	if len(webToolsToExecute) == 0 {
+		return
+	}
+
+	for _, t := range webToolsToExecute {
+		go func(t service.StandaloneToolRequestEventMessage) {
+			var text string
+			var err error
+			switch t.ToolName {
+			case WebSearchToolName:
+				text, err = ts.runWebSearch(ts.ctx, ts.config.GetWebSearchConfig(), t.ToolInput)
+			case FetchURLToolName:
+				text, err = ts.runFetchURL(ts.ctx, ts.config.GetFetchURLConfig(), t.ToolInput)
+			}
+
+			msg := &service.ToolGatherEventMessage{ToolRunId: t.ToolRunId, ResultType: db.ResultMessageTypeText}
+			if err != nil {
+				ts.log.Error("Failed to execute web tool", "tool_name", t.ToolName, "tool_run_id", t.ToolRunId, "error", err)
+				msg.Content, _ = db.NewJsonRaw(map[string]any{"error": err.Error()})
+				msg.IsError = true
+			} else {
+				msg.Content, _ = db.NewJsonRaw(map[string]any{"text": text})
+			}
+
+			event := service.NewEvent(msg, header, &service.EventMetadata{
+				TraceID:   meta.TraceID,
+				Timestamp: time.Now(),
+			})
+			if publishErr := event.Publish(ts.s.GetNATS()); publishErr != nil {
+				ts.log.Error("failed to publish result to tool gather event", "error", publishErr)
+			}
+		}(t)
+	}
+
+	ts.log.Info("Started web tool executions", "count", len(webToolsToExecute))
+}
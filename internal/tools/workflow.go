@@ -6,8 +6,8 @@ import (
 	"github.com/pinazu/internal/service"
 )
 
-// executeWorkflowTool publish tool execute event to workflow execution service
-func (ts *ToolService) executeWorkflowTool(workflowToolsToExecute []service.FlowRunExecuteRequestEventMessage) {
+// executeWorkflowTool publish tool execute event to workflow execution service, in the workspace of the tool run
+func (ts *ToolService) executeWorkflowTool(h *service.EventHeaders, workflowToolsToExecute []service.FlowRunExecuteRequestEventMessage) {
 	if len(workflowToolsToExecute) == 0 {
 		return
 	}
@@ -18,7 +18,7 @@ func (ts *ToolService) executeWorkflowTool(workflowToolsToExecute []service.Flow
 		// Create event for workflow tool execution
 		event := service.NewEvent(
 			&workflow,
-			&service.EventHeaders{},
+			&service.EventHeaders{WorkspaceID: h.WorkspaceID},
 			&service.EventMetadata{
 				Timestamp: time.Now().UTC(),
 			},
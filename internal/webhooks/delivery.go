@@ -0,0 +1,154 @@
+package webhooks
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// deliveryLeaseMargin is added to the timeout of a request to lease an attempt, an attempt of a stopped service is
+	// made again once its lease expired
+	deliveryLeaseMargin = 30 * time.Second
+
+	// deliveryPruneInterval is how often the ended deliveries past their retention are removed
+	deliveryPruneInterval = time.Hour
+)
+
+// runDeliveries attempts the deliveries due for an attempt until the service stops. Each instance of the webhooks
+// service looks them up, an attempt is only made once.
+func (ws *WebhookService) runDeliveries() {
+	ticker := time.NewTicker(time.Duration(ws.cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ws.ctx.Done():
+			return
+		case <-ticker.C:
+			ws.attemptDueDeliveries()
+		}
+	}
+}
+
+// attemptDueDeliveries claims the deliveries due for an attempt and posts them in the background
+func (ws *WebhookService) attemptDueDeliveries() {
+	queries := db.New(ws.s.GetDB())
+	deliveries, err := queries.ListDueWebhookDeliveries(ws.ctx, int32(ws.cfg.MaxDeliveriesPerPoll))
+	if err != nil {
+		if !db.IsUnavailable(err) {
+			ws.log.Error("Failed to list due webhook deliveries", "error", err)
+		}
+		return
+	}
+	leaseUntil := time.Now().Add(time.Duration(ws.cfg.TimeoutSeconds)*time.Second + deliveryLeaseMargin)
+	for _, delivery := range deliveries {
+		claimed, err := queries.ClaimWebhookDelivery(ws.ctx, db.ClaimWebhookDeliveryParams{
+			LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+			ID:         delivery.ID,
+			DueAt:      delivery.NextAttemptAt,
+		})
+		if err != nil {
+			ws.log.Error("Failed to claim webhook delivery", "delivery_id", delivery.ID, "error", err)
+			continue
+		}
+		if claimed == 0 {
+			ws.log.Debug("Webhook delivery already claimed", "delivery_id", delivery.ID)
+			continue
+		}
+		go ws.attemptDelivery(queries, delivery, delivery.Attempts+1)
+	}
+}
+
+// attemptDelivery posts a delivery to its webhook and records the outcome: the delivery succeeds, is attempted again
+// after a backoff, or fails after the last attempt or on a client error of the endpoint
+func (ws *WebhookService) attemptDelivery(queries *db.Queries, delivery db.ListDueWebhookDeliveriesRow, attempt int32) {
+	err := ws.send(delivery)
+	if ws.ctx.Err() != nil {
+		// The attempt is made again by another instance once its lease expires
+		return
+	}
+
+	now := time.Now()
+	params := db.RecordWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		Status:        db.WebhookDeliveryStatusSucceeded,
+		NextAttemptAt: pgtype.Timestamptz{Time: now, Valid: true},
+	}
+	var statusErr *service.WebhookStatusError
+	if err != nil {
+		params.ErrorMessage = pgtype.Text{String: err.Error(), Valid: true}
+		params.Status = db.WebhookDeliveryStatusFailed
+		retryable := true
+		if errors.As(err, &statusErr) {
+			params.ResponseStatus = pgtype.Int4{Int32: int32(statusErr.StatusCode), Valid: true}
+			retryable = statusErr.Retryable()
+		}
+		if retryable && int(attempt) < ws.cfg.MaxAttempts {
+			backoff := deliveryBackoff(ws.cfg, int(attempt))
+			params.Status = db.WebhookDeliveryStatusPending
+			params.NextAttemptAt = pgtype.Timestamptz{Time: now.Add(backoff), Valid: true}
+			ws.log.Warn("Failed to deliver webhook event, retrying", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "event_type", delivery.EventType, "attempt", attempt, "backoff", backoff, "error", err)
+		} else {
+			ws.log.Error("Failed to deliver webhook event", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "event_type", delivery.EventType, "attempt", attempt, "error", err)
+		}
+	} else {
+		ws.log.Info("Delivered webhook event", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "event_type", delivery.EventType, "attempt", attempt)
+	}
+
+	if err := queries.RecordWebhookDeliveryAttempt(ws.ctx, params); err != nil {
+		ws.log.Error("Failed to record webhook delivery attempt", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// send posts the payload of a delivery to its webhook, signed with the secret of the webhook
+func (ws *WebhookService) send(delivery db.ListDueWebhookDeliveriesRow) error {
+	secret, err := db.DecryptSecret(delivery.Secret)
+	if err != nil {
+		return err
+	}
+	alert, err := service.NewWebhookAlert(delivery.Url, nil, "", "", time.Duration(ws.cfg.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return err
+	}
+	alert.WithClient(ws.client).
+		WithSigningSecret(secret).
+		WithHeader(service.WebhookEventIDHeader, delivery.EventID.String()).
+		WithHeader(service.WebhookEventTypeHeader, delivery.EventType)
+	return alert.Send(ws.ctx, delivery.Payload)
+}
+
+// deliveryBackoff returns the delay after the failed attempt of a delivery: the initial backoff doubled at each
+// attempt, up to the max backoff
+func deliveryBackoff(cfg *service.WebhooksConfig, attempt int) time.Duration {
+	backoff := time.Duration(cfg.InitialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(cfg.MaxBackoffSeconds) * time.Second
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// runDeliveryPruner removes the ended deliveries past their retention from the delivery log in the background
+func (ws *WebhookService) runDeliveryPruner() {
+	ticker := time.NewTicker(deliveryPruneInterval)
+	defer ticker.Stop()
+	for {
+		before := time.Now().AddDate(0, 0, -ws.cfg.RetentionDays)
+		deleted, err := db.New(ws.s.GetDB()).DeleteEndedWebhookDeliveries(ws.ctx, pgtype.Timestamptz{Time: before, Valid: true})
+		if err != nil {
+			if !db.IsUnavailable(err) {
+				ws.log.Error("Failed to prune webhook deliveries", "error", err)
+			}
+		} else if deleted > 0 {
+			ws.log.Info("Pruned webhook deliveries", "count", deleted)
+		}
+		select {
+		case <-ws.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
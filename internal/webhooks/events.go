@@ -0,0 +1,168 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nats-io/nats.go"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// eventNamespace derives the IDs of the events from what identifies them, so the services receiving an event agree
+// on its ID
+var eventNamespace = uuid.MustParse("6f1c0b56-4e1d-4c55-9a55-2b8f0e7d9c31")
+
+// webhookEvent is the body posted to the webhooks subscribed to an event
+type webhookEvent struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// taskFinishedData is the data of a task.finished event
+type taskFinishedData struct {
+	TaskID     string    `json:"task_id"`
+	ThreadID   uuid.UUID `json:"thread_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// flowRunEndedData is the data of the flow_run.succeeded and flow_run.failed events
+type flowRunEndedData struct {
+	FlowID        uuid.UUID     `json:"flow_id"`
+	FlowName      string        `json:"flow_name"`
+	FlowRunID     uuid.UUID     `json:"flow_run_id"`
+	Status        db.FlowStatus `json:"status"`
+	ErrorMessage  string        `json:"error_message,omitempty"`
+	FailureReason string        `json:"failure_reason,omitempty"`
+	RetryCount    int32         `json:"retry_count"`
+	ParentRunID   *uuid.UUID    `json:"parent_run_id,omitempty"`
+	StartedAt     *time.Time    `json:"started_at,omitempty"`
+	FinishedAt    time.Time     `json:"finished_at"`
+}
+
+// newWebhookEvent returns an event of a type, its ID derived from key
+func newWebhookEvent(eventType, key string, createdAt time.Time, data any) webhookEvent {
+	return webhookEvent{
+		ID:        uuid.NewSHA1(eventNamespace, []byte(eventType+"/"+key)),
+		Type:      eventType,
+		CreatedAt: createdAt,
+		Data:      data,
+	}
+}
+
+// flowRunEventType returns the webhook event of the final status of a flow run, none for a cancelled run
+func flowRunEventType(status db.FlowStatus) string {
+	switch status {
+	case db.FlowStatusSuccess:
+		return db.WebhookEventFlowRunSucceeded
+	case db.FlowStatusFailed:
+		return db.WebhookEventFlowRunFailed
+	default:
+		return ""
+	}
+}
+
+// taskLifecycleEventCallback records the task.finished event of a main task of a user
+func (ws *WebhookService) taskLifecycleEventCallback(msg *nats.Msg) {
+	req, err := service.ParseEvent[*service.WebsocketTaskLifecycleEventMessage](msg.Data)
+	if err != nil {
+		ws.log.Error("Failed to parse task lifecycle message", "error", err)
+		return
+	}
+	if req.Msg.Type != "task_stop" || req.H == nil || req.H.UserID == uuid.Nil {
+		return
+	}
+
+	finishedAt := time.Now().UTC()
+	if req.M != nil && !req.M.Timestamp.IsZero() {
+		finishedAt = req.M.Timestamp.UTC()
+	}
+	// A task finishes once, its ID identifies the event
+	event := newWebhookEvent(db.WebhookEventTaskFinished, req.Msg.TaskId, finishedAt, taskFinishedData{
+		TaskID:     req.Msg.TaskId,
+		ThreadID:   req.Msg.ThreadId,
+		UserID:     req.H.UserID,
+		FinishedAt: finishedAt,
+	})
+	ws.enqueueEvent(event, pgtype.UUID{Bytes: req.H.UserID, Valid: true}, pgtype.UUID{})
+}
+
+// flowRunCompletedEventCallback records the flow_run.succeeded or flow_run.failed event of a flow run which reached
+// its final status. The flows have no owner, the event is delivered to the webhooks of the users subscribed to it acting
+// in the workspace of the flow, every user for the default workspace.
+func (ws *WebhookService) flowRunCompletedEventCallback(msg *nats.Msg) {
+	req, err := service.ParseEvent[*service.FlowRunCompletedEventMessage](msg.Data)
+	if err != nil {
+		ws.log.Error("Failed to parse flow run completion message", "error", err)
+		return
+	}
+	eventType := flowRunEventType(req.Msg.Status)
+	if eventType == "" {
+		return
+	}
+
+	queries := db.New(ws.s.GetDB())
+	flowRun, err := queries.GetFlowRun(ws.ctx, req.Msg.FlowRunId)
+	if err != nil {
+		ws.log.Error("Failed to get flow run of the webhook event", "flow_run_id", req.Msg.FlowRunId, "error", err)
+		return
+	}
+	flow, err := queries.GetFlowByIdWithDeleted(ws.ctx, flowRun.FlowID)
+	if err != nil {
+		ws.log.Error("Failed to get flow of the webhook event", "flow_id", flowRun.FlowID, "flow_run_id", flowRun.FlowRunID, "error", err)
+		return
+	}
+
+	data := flowRunEndedData{
+		FlowID:        flow.ID,
+		FlowName:      flow.Name,
+		FlowRunID:     flowRun.FlowRunID,
+		Status:        req.Msg.Status,
+		ErrorMessage:  flowRun.ErrorMessage.String,
+		FailureReason: flowRun.FailureReason.String,
+		RetryCount:    flowRun.RetryCount.Int32,
+		ParentRunID:   req.Msg.ParentRunId,
+		FinishedAt:    flowRun.FinishedAt.Time.UTC(),
+	}
+	if flowRun.StartedAt.Valid {
+		data.StartedAt = &flowRun.StartedAt.Time
+	}
+	if !flowRun.FinishedAt.Valid {
+		data.FinishedAt = req.Msg.EventTimestamp.UTC()
+	}
+	// A run executed again after its final status, through a manual retry, ends at another time
+	key := flowRun.FlowRunID.String() + "/" + data.FinishedAt.Format(time.RFC3339Nano)
+	workspaceID := pgtype.UUID{}
+	if flow.WorkspaceID != db.DefaultWorkspaceID {
+		workspaceID = pgtype.UUID{Bytes: flow.WorkspaceID, Valid: true}
+	}
+	ws.enqueueEvent(newWebhookEvent(eventType, key, data.FinishedAt, data), pgtype.UUID{}, workspaceID)
+}
+
+// enqueueEvent records a delivery of an event to the webhooks of a user subscribed to it, of every user when userID is
+// null, of the users acting in a workspace when workspaceID is set. The deliveries are attempted by the delivery loop.
+func (ws *WebhookService) enqueueEvent(event webhookEvent, userID pgtype.UUID, workspaceID pgtype.UUID) {
+	payload, err := db.NewJsonRaw(event)
+	if err != nil {
+		ws.log.Error("Failed to marshal webhook event", "event_id", event.ID, "type", event.Type, "error", err)
+		return
+	}
+	count, err := db.New(ws.s.GetDB()).EnqueueWebhookDeliveries(ws.ctx, db.EnqueueWebhookDeliveriesParams{
+		EventID:     event.ID,
+		EventType:   event.Type,
+		Payload:     payload,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		ws.log.Error("Failed to record webhook deliveries", "event_id", event.ID, "type", event.Type, "error", err)
+		return
+	}
+	if count > 0 {
+		ws.log.Debug("Recorded webhook deliveries", "event_id", event.ID, "type", event.Type, "count", count)
+	}
+}
@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/service"
+)
+
+type WebhookService struct {
+	s      service.Service
+	cfg    *service.WebhooksConfig
+	client *http.Client // Posts the deliveries
+	log    hclog.Logger
+	wg     *sync.WaitGroup
+	ctx    context.Context
+}
+
+// NewService creates a new WebhookService instance
+func NewService(ctx context.Context, externalDependenciesConfig *service.ExternalDependenciesConfig, log hclog.Logger, wg *sync.WaitGroup) (*WebhookService, error) {
+	if externalDependenciesConfig == nil {
+		return nil, fmt.Errorf("externalDependenciesConfig is nil")
+	}
+
+	// Create a new service instance
+	config := &service.Config{
+		Name:                 "webhooks-service",
+		Version:              "0.0.1",
+		Description:          "Webhook service delivering the platform events to the webhook subscriptions of the users.",
+		ExternalDependencies: externalDependenciesConfig,
+		ErrorHandler:         nil,
+	}
+	s, err := service.NewService(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook service: %w", err)
+	}
+
+	cfg := externalDependenciesConfig.GetWebhooksConfig()
+	ws := &WebhookService{s: s, cfg: cfg, client: service.NewWebhookClient(cfg.AllowPrivateNetworks), log: log, wg: wg, ctx: ctx}
+
+	// Record a delivery of the events to the webhooks subscribed to them, every instance of the service receives the
+	// events and an event is recorded once
+	s.RegisterHandler(service.WebsocketTaskLifecycleEventSubject.String()+".*", ws.taskLifecycleEventCallback)
+	s.RegisterHandler(service.FlowRunCompletedEventSubject.String()+".*", ws.flowRunCompletedEventCallback)
+
+	// Attempt the recorded deliveries once due and prune the delivery log
+	go ws.runDeliveries()
+	go ws.runDeliveryPruner()
+
+	// Start a goroutine to wait for context cancellation and then shutdown
+	go func() {
+		<-ctx.Done()
+		ws.log.Warn("Webhook service shutting down...")
+		if err := ws.s.Shutdown(); err != nil {
+			ws.log.Error("Error during webhook service shutdown", "error", err)
+		}
+		ws.wg.Done()
+	}()
+
+	return ws, nil
+}
@@ -0,0 +1,128 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliveryBackoff(t *testing.T) {
+	cfg := &service.WebhooksConfig{InitialBackoffSeconds: 10, MaxBackoffSeconds: 60}
+	assert.Equal(t, 10*time.Second, deliveryBackoff(cfg, 1))
+	assert.Equal(t, 20*time.Second, deliveryBackoff(cfg, 2))
+	assert.Equal(t, 40*time.Second, deliveryBackoff(cfg, 3))
+	assert.Equal(t, 60*time.Second, deliveryBackoff(cfg, 4))
+	assert.Equal(t, 60*time.Second, deliveryBackoff(cfg, 100))
+}
+
+func TestNewWebhookEvent(t *testing.T) {
+	createdAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	event := newWebhookEvent(db.WebhookEventTaskFinished, "task-1", createdAt, nil)
+
+	// The services receiving the same event derive the same ID
+	assert.Equal(t, event.ID, newWebhookEvent(db.WebhookEventTaskFinished, "task-1", time.Now(), nil).ID)
+	assert.NotEqual(t, event.ID, newWebhookEvent(db.WebhookEventTaskFinished, "task-2", createdAt, nil).ID)
+	assert.NotEqual(t, event.ID, newWebhookEvent(db.WebhookEventFlowRunFailed, "task-1", createdAt, nil).ID)
+}
+
+func TestFlowRunEventType(t *testing.T) {
+	assert.Equal(t, db.WebhookEventFlowRunSucceeded, flowRunEventType(db.FlowStatusSuccess))
+	assert.Equal(t, db.WebhookEventFlowRunFailed, flowRunEventType(db.FlowStatusFailed))
+	assert.Empty(t, flowRunEventType(db.FlowStatusCancelled))
+}
+
+func TestSendDelivery(t *testing.T) {
+	var headers http.Header
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	event := newWebhookEvent(db.WebhookEventTaskFinished, "task-1", time.Now().UTC(), taskFinishedData{TaskID: "task-1", ThreadID: uuid.New()})
+	payload, err := db.NewJsonRaw(event)
+	require.NoError(t, err)
+	delivery := db.ListDueWebhookDeliveriesRow{
+		ID:        uuid.New(),
+		EventID:   event.ID,
+		EventType: event.Type,
+		Payload:   payload,
+		Url:       server.URL,
+		Secret:    "whsec_test",
+	}
+
+	cfg := &service.WebhooksConfig{TimeoutSeconds: 1, AllowPrivateNetworks: true}
+	ws := &WebhookService{cfg: cfg, client: service.NewWebhookClient(cfg.AllowPrivateNetworks), ctx: context.Background()}
+	err = ws.send(delivery)
+
+	var statusErr *service.WebhookStatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.False(t, statusErr.Retryable())
+	assert.Equal(t, event.ID.String(), headers.Get(service.WebhookEventIDHeader))
+	assert.Equal(t, db.WebhookEventTaskFinished, headers.Get(service.WebhookEventTypeHeader))
+
+	unix, err := strconv.ParseInt(headers.Get(service.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, service.SignWebhookPayload("whsec_test", unix, received), headers.Get(service.WebhookSignatureHeader))
+
+	var body webhookEvent
+	require.NoError(t, json.Unmarshal(received, &body))
+	assert.Equal(t, event.ID, body.ID)
+	assert.Equal(t, db.WebhookEventTaskFinished, body.Type)
+}
+
+func TestSendDelivery_RejectedTargets(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusTemporaryRedirect)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	delivery := func(url string) db.ListDueWebhookDeliveriesRow {
+		return db.ListDueWebhookDeliveriesRow{
+			ID:        uuid.New(),
+			EventID:   uuid.New(),
+			EventType: db.WebhookEventTaskFinished,
+			Payload:   db.JsonRaw(`{}`),
+			Url:       url,
+			Secret:    "whsec_test",
+		}
+	}
+
+	// The loopback, private and link-local addresses are refused once resolved
+	cfg := &service.WebhooksConfig{TimeoutSeconds: 1}
+	ws := &WebhookService{cfg: cfg, client: service.NewWebhookClient(cfg.AllowPrivateNetworks), ctx: context.Background()}
+	localhost := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	for _, url := range []string{server.URL, localhost, "http://169.254.169.254/latest/meta-data", "http://[::1]:9/hook", "http://10.0.0.1:9/hook"} {
+		err := ws.send(delivery(url))
+		assert.ErrorIs(t, err, service.ErrPrivateWebhookAddress, url)
+	}
+	assert.Empty(t, requests)
+
+	// A redirect is not followed and fails the attempt
+	cfg = &service.WebhooksConfig{TimeoutSeconds: 1, AllowPrivateNetworks: true}
+	ws = &WebhookService{cfg: cfg, client: service.NewWebhookClient(cfg.AllowPrivateNetworks), ctx: context.Background()}
+	err := ws.send(delivery(server.URL + "/redirect"))
+	var statusErr *service.WebhookStatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTemporaryRedirect, statusErr.StatusCode)
+	assert.Equal(t, []string{"/redirect"}, requests)
+}
@@ -0,0 +1,239 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	flowRunArtifactsDirEnv   = "PINAZU_ARTIFACTS_DIR" // Directory where the flow library writes the artifacts of the run
+	flowRunArtifactsManifest = "manifest.jsonl"       // An artifact entry per line, appended by the flow library
+	flowRunArtifactsFiles    = "files"                // Directory of the artifact contents
+	flowRunArtifactsTimeout  = 5 * time.Minute
+)
+
+type (
+	// flowRunArtifactEntry is an artifact published by a flow process, a line of the manifest of its artifacts directory
+	flowRunArtifactEntry struct {
+		Name        string `json:"name"`
+		File        string `json:"file"` // Name of the content file in the files directory
+		ContentType string `json:"content_type"`
+		TaskName    string `json:"task_name,omitempty"`
+	}
+
+	// flowRunArtifacts is the directory where a flow process writes its artifacts, uploaded when the process exits
+	flowRunArtifacts struct {
+		dir       string
+		flowRunID uuid.UUID
+	}
+)
+
+// newFlowRunArtifacts creates the artifacts directory of a flow run. It returns nil when the S3 storage is not
+// configured, the flow library then keeps the artifacts on the local disk.
+func (ws *WorkerService) newFlowRunArtifacts(flowRunID uuid.UUID) (*flowRunArtifacts, error) {
+	if ws.config == nil || ws.config.Storage == nil || ws.config.Storage.S3 == nil {
+		return nil, nil
+	}
+	dir, err := os.MkdirTemp("", "flow-artifacts-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+	return &flowRunArtifacts{dir: dir, flowRunID: flowRunID}, nil
+}
+
+// env returns the environment variables telling the flow library where to write the artifacts
+func (a *flowRunArtifacts) env() []string {
+	if a == nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s=%s", flowRunArtifactsDirEnv, a.dir)}
+}
+
+// remove removes the artifacts directory of a flow run whose process did not start
+func (a *flowRunArtifacts) remove() {
+	if a == nil {
+		return
+	}
+	os.RemoveAll(a.dir)
+}
+
+// uploadFlowRunArtifacts uploads the artifacts published by a flow process to the S3 storage, records them
+// for the flow run and removes the artifacts directory. An artifact published twice keeps its last content.
+func (ws *WorkerService) uploadFlowRunArtifacts(artifacts *flowRunArtifacts) {
+	if artifacts == nil {
+		return
+	}
+	defer artifacts.remove()
+
+	// The flow process writes the directory, its symbolic links must not reach the files of the worker
+	root, err := os.OpenRoot(artifacts.dir)
+	if err != nil {
+		ws.log.Error("Failed to open flow run artifacts", "flow_run_id", artifacts.flowRunID, "error", err)
+		return
+	}
+	defer root.Close()
+
+	entries, err := readArtifactManifest(root)
+	if err != nil {
+		ws.log.Error("Failed to read flow run artifacts", "flow_run_id", artifacts.flowRunID, "error", err)
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flowRunArtifactsTimeout)
+	defer cancel()
+	client, err := service.NewS3Client(ctx, ws.config.Storage.S3)
+	if err != nil {
+		ws.log.Error("Failed to create S3 client, flow run artifacts are not uploaded", "flow_run_id", artifacts.flowRunID, "error", err)
+		return
+	}
+	cfg := ws.config.GetFlowArtifactsConfig()
+	queries := db.New(ws.s.GetDB())
+
+	uploaded := 0
+	for _, entry := range entries {
+		if err := ws.uploadFlowRunArtifact(ctx, client, queries, cfg, artifacts, root, entry); err != nil {
+			ws.log.Error("Failed to upload flow run artifact", "flow_run_id", artifacts.flowRunID, "name", entry.Name, "error", err)
+			continue
+		}
+		uploaded++
+	}
+	ws.log.Info("Uploaded flow run artifacts", "flow_run_id", artifacts.flowRunID, "uploaded", uploaded, "published", len(entries))
+}
+
+// uploadFlowRunArtifact uploads the content of an artifact and records it
+func (ws *WorkerService) uploadFlowRunArtifact(ctx context.Context, client *s3.Client, queries *db.Queries, cfg *service.FlowArtifactsConfig, artifacts *flowRunArtifacts, root *os.Root, entry flowRunArtifactEntry) error {
+	if err := validateArtifactName(entry.Name); err != nil {
+		return err
+	}
+	// The content must stay in the files directory
+	if entry.File == "" || filepath.Base(entry.File) != entry.File || entry.File == "." || entry.File == ".." {
+		return fmt.Errorf("invalid artifact file %q", entry.File)
+	}
+	file, info, err := openArtifactFile(root, filepath.Join(flowRunArtifactsFiles, entry.File))
+	if err != nil {
+		return fmt.Errorf("failed to open artifact content: %w", err)
+	}
+	defer file.Close()
+	if info.Size() > cfg.MaxArtifactBytes {
+		return fmt.Errorf("artifact of %d bytes is above the limit of %d bytes", info.Size(), cfg.MaxArtifactBytes)
+	}
+
+	contentType := entry.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	key := fmt.Sprintf("%sflow-%s/%s", cfg.Prefix, artifacts.flowRunID, entry.Name)
+	size := info.Size()
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        &cfg.Bucket,
+		Key:           &key,
+		Body:          file,
+		ContentLength: &size,
+		ContentType:   &contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	_, err = queries.UpsertFlowRunArtifact(ctx, db.UpsertFlowRunArtifactParams{
+		FlowRunID:   artifacts.flowRunID,
+		Name:        entry.Name,
+		TaskName:    pgtype.Text{String: entry.TaskName, Valid: entry.TaskName != ""},
+		ContentType: contentType,
+		SizeBytes:   size,
+		Bucket:      cfg.Bucket,
+		ObjectKey:   key,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record artifact: %w", err)
+	}
+	return nil
+}
+
+// readArtifactManifest returns the artifacts of the manifest of an artifacts directory in their publication order, the
+// last entry of a name wins
+func readArtifactManifest(root *os.Root) ([]flowRunArtifactEntry, error) {
+	f, _, err := openArtifactFile(root, flowRunArtifactsManifest)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []flowRunArtifactEntry
+	index := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry flowRunArtifactEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("invalid artifact manifest entry: %w", err)
+		}
+		if i, ok := index[entry.Name]; ok {
+			entries[i] = entry
+			continue
+		}
+		index[entry.Name] = len(entries)
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// openArtifactFile opens a regular file of an artifacts directory. The root keeps the symbolic links inside the
+// directory, and the other files, such as the named pipes blocking the worker, are refused.
+func openArtifactFile(root *os.Root, name string) (*os.File, os.FileInfo, error) {
+	info, err := root.Lstat(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, nil, fmt.Errorf("%s is not a regular file", name)
+	}
+	file, err := root.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The file may have been replaced since it was checked
+	if info, err = file.Stat(); err != nil || !info.Mode().IsRegular() {
+		file.Close()
+		return nil, nil, fmt.Errorf("%s is not a regular file", name)
+	}
+	return file, info, nil
+}
+
+// validateArtifactName checks an artifact name can be used in an object key and an URL path segment
+func validateArtifactName(name string) error {
+	if name == "" || len(name) > 255 {
+		return fmt.Errorf("artifact name must be between 1 and 255 characters")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid artifact name %q", name)
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("invalid artifact name %q", name)
+		}
+	}
+	return nil
+}
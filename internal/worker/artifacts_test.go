@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadArtifactManifest(t *testing.T) {
+	dir := t.TempDir()
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	// A run without artifacts has no manifest
+	entries, err := readArtifactManifest(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	manifest := `{"name":"report.csv","file":"a","content_type":"text/csv"}
+{"name":"chart.png","file":"b"}
+
+{"name":"report.csv","file":"c","task_name":"export"}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, flowRunArtifactsManifest), []byte(manifest), 0o644))
+	entries, err = readArtifactManifest(root)
+	require.NoError(t, err)
+	assert.Equal(t, []flowRunArtifactEntry{
+		{Name: "report.csv", File: "c", TaskName: "export"},
+		{Name: "chart.png", File: "b"},
+	}, entries)
+}
+
+func TestOpenArtifactFile(t *testing.T) {
+	outside := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(outside, []byte("worker secret"), 0o600))
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, flowRunArtifactsFiles), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, flowRunArtifactsFiles, "report"), []byte("a,b"), 0o644))
+	root, err := os.OpenRoot(dir)
+	require.NoError(t, err)
+	defer root.Close()
+
+	file, info, err := openArtifactFile(root, filepath.Join(flowRunArtifactsFiles, "report"))
+	require.NoError(t, err)
+	file.Close()
+	assert.Equal(t, int64(3), info.Size())
+
+	// The symbolic links written by the flow process are not followed, to the files of the worker or inside the directory
+	if err := os.Symlink(outside, filepath.Join(dir, flowRunArtifactsFiles, "leak")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	_, _, err = openArtifactFile(root, filepath.Join(flowRunArtifactsFiles, "leak"))
+	assert.Error(t, err)
+	require.NoError(t, os.Symlink("report", filepath.Join(dir, flowRunArtifactsFiles, "alias")))
+	_, _, err = openArtifactFile(root, filepath.Join(flowRunArtifactsFiles, "alias"))
+	assert.Error(t, err)
+
+	// Nor the symbolic links of the manifest or of the files directory
+	require.NoError(t, os.Symlink(outside, filepath.Join(dir, flowRunArtifactsManifest)))
+	_, err = readArtifactManifest(root)
+	assert.Error(t, err)
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, flowRunArtifactsFiles)))
+	require.NoError(t, os.Symlink(filepath.Dir(outside), filepath.Join(dir, flowRunArtifactsFiles)))
+	_, _, err = openArtifactFile(root, filepath.Join(flowRunArtifactsFiles, "secret"))
+	assert.Error(t, err)
+
+	// The directories are not artifact contents
+	_, _, err = openArtifactFile(root, ".")
+	assert.Error(t, err)
+}
+
+func TestValidateArtifactName(t *testing.T) {
+	for _, name := range []string{"report.csv", "chart 1.png", "données.json", ".hidden"} {
+		assert.NoError(t, validateArtifactName(name), name)
+	}
+	for _, name := range []string{"", ".", "..", "dir/report.csv", `dir\report.csv`, "line\nbreak", strings.Repeat("a", 256)} {
+		assert.Error(t, validateArtifactName(name), name)
+	}
+}
+
+func TestFlowRunArtifactsEnv(t *testing.T) {
+	// Without the S3 storage the flow library keeps the artifacts on the local disk
+	var none *flowRunArtifacts
+	assert.Empty(t, none.env())
+
+	artifacts := &flowRunArtifacts{dir: "/tmp/pinazu-artifacts"}
+	assert.Equal(t, []string{flowRunArtifactsDirEnv + "=/tmp/pinazu-artifacts"}, artifacts.env())
+}
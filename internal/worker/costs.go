@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/db"
+)
+
+// recordFlowRunCPU adds the user and system CPU time of an exited flow process and of its waited children to the cost
+// of its run. A flow running in a container is accounted by its docker client only, the container runs under the
+// daemon. A failure is logged without failing the run.
+func (ws *WorkerService) recordFlowRunCPU(flowRunID uuid.UUID, cmd *exec.Cmd) {
+	if cmd.ProcessState == nil {
+		return
+	}
+	cpu := processCPUTime(cmd.ProcessState)
+	err := db.New(ws.s.GetDB()).AddFlowRunCPUSeconds(ws.ctx, db.AddFlowRunCPUSecondsParams{
+		CpuSeconds: cpu.Seconds(),
+		FlowRunID:  flowRunID,
+	})
+	if err != nil {
+		ws.log.Warn("Failed to record flow run CPU time", "flow_run_id", flowRunID, "cpu_seconds", cpu.Seconds(), "error", err)
+	}
+}
+
+// processCPUTime returns the user and system CPU time of an exited process and of its waited children
+func processCPUTime(state *os.ProcessState) time.Duration {
+	return state.UserTime() + state.SystemTime()
+}
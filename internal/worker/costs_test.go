@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessCPUTime(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+	// The CPU time of the children waited by the process is included
+	cmd := exec.Command(sh, "-c", `sh -c 'i=0; while [ $i -lt 200000 ]; do i=$((i+1)); done'`)
+	require.NoError(t, cmd.Run())
+
+	cpu := processCPUTime(cmd.ProcessState)
+	assert.Positive(t, cpu)
+	assert.Equal(t, cmd.ProcessState.UserTime()+cmd.ProcessState.SystemTime(), cpu)
+}
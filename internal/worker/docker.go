@@ -0,0 +1,165 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	flowEngineDocker = "docker" // The flow runs inside a container of the configured image instead of a local process
+
+	dockerCodeDir      = "/flow"             // Where the code directory is mounted in the container
+	dockerArtifactsDir = "/pinazu/artifacts" // Where the artifacts directory is mounted in the container
+	dockerKillTimeout  = 30 * time.Second
+)
+
+// dockerContainerName returns the name of the container of a flow run, a run has a single container at once
+func dockerContainerName(flowRunID uuid.UUID) string {
+	return "pinazu-flow-" + flowRunID.String()
+}
+
+// buildDockerCommand wraps the command of a flow into a docker run of the configured image. The code directory is the
+// read only working directory of the container.
+func (ws *WorkerService) buildDockerCommand(event *service.FlowRunExecuteEventMessage, workingDir string, args []string, envVars []string, artifacts *flowRunArtifacts) (*exec.Cmd, error) {
+	if ws.config == nil {
+		return nil, fmt.Errorf("docker engine requires the worker.docker configuration")
+	}
+	cfg := ws.config.GetWorkerDockerConfig()
+	if cfg.Image == "" {
+		return nil, fmt.Errorf("docker engine requires the worker.docker.image configuration")
+	}
+
+	dockerArgs := ws.dockerRunArgs(cfg, event, envVars, artifacts)
+	dockerArgs = append(dockerArgs,
+		"--volume", fmt.Sprintf("%s:%s:ro", workingDir, dockerCodeDir),
+		"--workdir", dockerCodeDir,
+		cfg.Image, event.Entrypoint,
+	)
+	dockerArgs = append(dockerArgs, args...)
+
+	cpus, memoryMB := ws.dockerLimits()
+	ws.log.Info("Running flow process in a container",
+		"flow_run_id", event.FlowRunId,
+		"image", cfg.Image,
+		"network", cfg.Network,
+		"cpus", cpus,
+		"memory_mb", memoryMB,
+	)
+	cmd := exec.Command(cfg.Binary, dockerArgs...)
+	cmd.Dir = workingDir
+	cmd.Env = append(os.Environ(), envVars...)
+	return cmd, nil
+}
+
+// dockerRunArgs returns the arguments of the docker run of a flow container before its image: its name, network,
+// limits, volumes and environment. The environment of the flow is passed by name, so the secrets it holds are not
+// visible in the arguments of the docker CLI.
+func (ws *WorkerService) dockerRunArgs(cfg *service.WorkerDockerConfig, event *service.FlowRunExecuteEventMessage, envVars []string, artifacts *flowRunArtifacts) []string {
+	dockerArgs := []string{
+		"run", "--rm", "--init",
+		"--name", dockerContainerName(event.FlowRunId),
+		"--label", fmt.Sprintf("pinazu.flow_run_id=%s", event.FlowRunId),
+		"--network", cfg.Network,
+	}
+	cpus, memoryMB := ws.dockerLimits()
+	if cpus > 0 {
+		dockerArgs = append(dockerArgs, "--cpus", strconv.FormatFloat(cpus, 'f', -1, 64))
+	}
+	if memoryMB > 0 {
+		// The swap is limited to the memory, so the container is killed instead of swapping
+		dockerArgs = append(dockerArgs, "--memory", fmt.Sprintf("%dm", memoryMB), "--memory-swap", fmt.Sprintf("%dm", memoryMB))
+	}
+	if cfg.PidsLimit > 0 {
+		dockerArgs = append(dockerArgs, "--pids-limit", strconv.Itoa(cfg.PidsLimit))
+	}
+	if cfg.User != "" {
+		dockerArgs = append(dockerArgs, "--user", cfg.User)
+	}
+	for _, volume := range cfg.Volumes {
+		dockerArgs = append(dockerArgs, "--volume", volume)
+	}
+	if artifacts != nil {
+		dockerArgs = append(dockerArgs,
+			"--volume", fmt.Sprintf("%s:%s", artifacts.dir, dockerArtifactsDir),
+			"--env", fmt.Sprintf("%s=%s", flowRunArtifactsDirEnv, dockerArtifactsDir),
+		)
+	}
+
+	// The configured variables are set first, the variables of the flow run take precedence
+	keys := make([]string, 0, len(cfg.Env))
+	for key := range cfg.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		dockerArgs = append(dockerArgs, "--env", fmt.Sprintf("%s=%s", key, cfg.Env[key]))
+	}
+	for _, envVar := range envVars {
+		name, _, _ := strings.Cut(envVar, "=")
+		dockerArgs = append(dockerArgs, "--env", name)
+	}
+	return dockerArgs
+}
+
+// dockerLimits returns the CPUs and the memory of the flow containers, the worker limits when the docker
+// engine sets none
+func (ws *WorkerService) dockerLimits() (cpus float64, memoryMB int) {
+	if ws.config == nil {
+		return 0, 0
+	}
+	cfg, limits := ws.config.GetWorkerDockerConfig(), ws.config.GetWorkerLimitsConfig()
+	cpus, memoryMB = cfg.CPUs, cfg.MemoryMB
+	if cpus <= 0 {
+		cpus = limits.CPUs
+	}
+	if memoryMB <= 0 {
+		memoryMB = limits.MemoryMB
+	}
+	return cpus, memoryMB
+}
+
+// signalContainer sends a signal to the main process of the container of a flow run. The container exiting
+// in between is not an error.
+func (ws *WorkerService) signalContainer(container string, signal string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dockerKillTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, ws.config.GetWorkerDockerConfig().Binary, "kill", "--signal", signal, container).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "No such container") || strings.Contains(string(output), "is not running") {
+			return nil
+		}
+		return fmt.Errorf("failed to signal container %s: %w: %s", container, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// killFlowProcess kills a flow process, with its container for the docker engine: killing the docker CLI
+// leaves the container running
+func (ws *WorkerService) killFlowProcess(process *flowProcess) error {
+	var containerErr error
+	if process.container != "" {
+		containerErr = ws.signalContainer(process.container, "SIGKILL")
+	}
+	if err := killProcessGroup(process.cmd); err != nil {
+		return err
+	}
+	return containerErr
+}
+
+// pauseFlowProcess asks a flow process to stop before its next task, the signal is sent to the container
+// process for the docker engine
+func (ws *WorkerService) pauseFlowProcess(process *flowProcess) error {
+	if process.container != "" {
+		return ws.signalContainer(process.container, "SIGUSR1")
+	}
+	return signalPause(process.cmd)
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerRunArgs(t *testing.T) {
+	flowRunID := uuid.MustParse("8a0e5f0e-6d3b-4a3e-9b8e-2f4f3c1d2e10")
+	ws := &WorkerService{log: hclog.NewNullLogger(), config: &service.ExternalDependenciesConfig{
+		Worker: &service.WorkerConfig{
+			Docker: &service.WorkerDockerConfig{
+				Image:     "pinazu/flows:latest",
+				PidsLimit: 128,
+				User:      "1000:1000",
+				Env:       map[string]string{"TZ": "UTC", "LANG": "C.UTF-8"},
+				Volumes:   []string{"/data:/data:ro"},
+			},
+			Limits: &service.WorkerLimitsConfig{CPUs: 1.5, MemoryMB: 512},
+		},
+	}}
+	cfg := ws.config.GetWorkerDockerConfig()
+	event := &service.FlowRunExecuteEventMessage{FlowRunId: flowRunID}
+	artifacts := &flowRunArtifacts{dir: "/tmp/artifacts", flowRunID: flowRunID}
+
+	args := ws.dockerRunArgs(cfg, event, []string{"FLOW_RUN_ID=" + flowRunID.String(), "AWS_SECRET_ACCESS_KEY=secret"}, artifacts)
+	assert.Equal(t, []string{
+		"run", "--rm", "--init",
+		"--name", "pinazu-flow-" + flowRunID.String(),
+		"--label", "pinazu.flow_run_id=" + flowRunID.String(),
+		"--network", "host",
+		// The container takes the worker limits, without swap
+		"--cpus", "1.5",
+		"--memory", "512m", "--memory-swap", "512m",
+		"--pids-limit", "128",
+		"--user", "1000:1000",
+		"--volume", "/data:/data:ro",
+		"--volume", "/tmp/artifacts:" + dockerArtifactsDir,
+		"--env", flowRunArtifactsDirEnv + "=" + dockerArtifactsDir,
+		"--env", "LANG=C.UTF-8",
+		"--env", "TZ=UTC",
+		// The variables of the flow run are passed by name, the secrets are not in the arguments
+		"--env", "FLOW_RUN_ID",
+		"--env", "AWS_SECRET_ACCESS_KEY",
+	}, args)
+
+	// The limits of the docker engine take precedence over the worker limits
+	ws.config.Worker.Docker.CPUs = 2
+	ws.config.Worker.Docker.MemoryMB = 1024
+	cpus, memoryMB := ws.dockerLimits()
+	assert.Equal(t, 2.0, cpus)
+	assert.Equal(t, 1024, memoryMB)
+}
@@ -0,0 +1,193 @@
+package worker
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pinazu/internal/service"
+)
+
+// gitLocationPrefix prefixes the code locations cloned from a git repository
+const gitLocationPrefix = "git+"
+
+// gitCommitPattern matches a full commit SHA, fetched directly instead of cloning a branch or a tag
+var gitCommitPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// gitLocation is a parsed git+https://host/repo.git@ref#subdirectory=path code location
+type gitLocation struct {
+	URL          *url.URL // Repository URL, without the ref and the fragment
+	Ref          string   // Branch, tag or full commit SHA, the default branch when empty
+	Subdirectory string   // Flow file in the repository, or the directory holding flow.py
+}
+
+// parseGitLocation parses a git code location, the ref follows the last @ of the repository path
+func parseGitLocation(location string) (*gitLocation, error) {
+	u, err := url.Parse(strings.TrimPrefix(location, gitLocationPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid git code location: %w", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported git code location scheme %q, expected git+https://", u.Scheme)
+	}
+	if u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return nil, fmt.Errorf("invalid git code location, expected git+https://host/repo.git@ref: %s", u.Redacted())
+	}
+
+	loc := &gitLocation{}
+	if i := strings.LastIndex(u.Path, "@"); i >= 0 {
+		loc.Ref = u.Path[i+1:]
+		u.Path = u.Path[:i]
+		u.RawPath = ""
+		if loc.Ref == "" {
+			return nil, fmt.Errorf("empty ref in git code location %s", u.Redacted())
+		}
+	}
+
+	fragment, err := url.ParseQuery(u.Fragment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid git code location fragment: %w", err)
+	}
+	if subdirectory := strings.Trim(fragment.Get("subdirectory"), "/"); subdirectory != "" {
+		if !filepath.IsLocal(subdirectory) {
+			return nil, fmt.Errorf("subdirectory %q of the git code location is outside the repository", subdirectory)
+		}
+		loc.Subdirectory = filepath.FromSlash(subdirectory)
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	loc.URL = u
+	return loc, nil
+}
+
+// cloneGitRepository shallow clones the repository of a git code location into a temporary directory. A commit SHA
+// is fetched alone, so the flow runs the pinned code even when the branches move.
+func (ws *WorkerService) cloneGitRepository(location string) (workingDir string, fileName string, cleanup func(), err error) {
+	loc, err := parseGitLocation(location)
+	if err != nil {
+		return "", "", nil, err
+	}
+	cfg := (&service.ExternalDependenciesConfig{}).GetWorkerGitConfig()
+	if ws.config != nil {
+		cfg = ws.config.GetWorkerGitConfig()
+	}
+
+	ctx, cancel := context.WithTimeout(ws.ctx, time.Duration(cfg.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	env, err := ws.gitEnv(ctx, cfg)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	tempDir, err := os.MkdirTemp("", "flow-git-*")
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	cleanup = func() {
+		os.RemoveAll(tempDir)
+		ws.log.Debug("Cleaned up temporary directory", "path", tempDir)
+	}
+
+	ws.log.Info("Cloning flow repository",
+		"repository", loc.URL.Redacted(),
+		"ref", loc.Ref,
+		"subdirectory", loc.Subdirectory,
+		"temp_dir", tempDir,
+	)
+	git := func(args ...string) (string, error) {
+		cmd := exec.CommandContext(ctx, cfg.Binary, args...)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return "", fmt.Errorf("git %s timed out after %ds", args[0], cfg.TimeoutSeconds)
+			}
+			return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	repoURL := loc.URL.String()
+	if gitCommitPattern.MatchString(loc.Ref) {
+		_, err = git("init", "--quiet", tempDir)
+		if err == nil {
+			_, err = git("-C", tempDir, "fetch", "--quiet", "--depth", "1", "--no-tags", repoURL, loc.Ref)
+		}
+		if err == nil {
+			_, err = git("-C", tempDir, "checkout", "--quiet", "FETCH_HEAD")
+		}
+	} else {
+		args := []string{"clone", "--quiet", "--depth", "1", "--single-branch", "--no-tags"}
+		if loc.Ref != "" {
+			args = append(args, "--branch", loc.Ref)
+		}
+		_, err = git(append(args, "--", repoURL, tempDir)...)
+	}
+	if err != nil {
+		cleanup()
+		return "", "", nil, fmt.Errorf("failed to clone %s: %w", loc.URL.Redacted(), err)
+	}
+
+	commit, err := git("-C", tempDir, "rev-parse", "HEAD")
+	if err != nil {
+		cleanup()
+		return "", "", nil, fmt.Errorf("failed to resolve the commit of %s: %w", loc.URL.Redacted(), err)
+	}
+
+	// The subdirectory is the flow file, or a directory holding flow.py
+	workingDir, fileName = tempDir, "flow.py"
+	if loc.Subdirectory != "" {
+		path := filepath.Join(tempDir, loc.Subdirectory)
+		info, err := os.Stat(path)
+		if err != nil {
+			cleanup()
+			return "", "", nil, fmt.Errorf("subdirectory %s not found in %s at %s", loc.Subdirectory, loc.URL.Redacted(), commit)
+		}
+		if info.IsDir() {
+			workingDir = path
+		} else {
+			workingDir, fileName = filepath.Dir(path), filepath.Base(path)
+		}
+	}
+
+	ws.log.Info("Successfully cloned flow repository",
+		"repository", loc.URL.Redacted(),
+		"ref", loc.Ref,
+		"commit", commit,
+		"working_dir", workingDir,
+		"file_name", fileName,
+	)
+	return workingDir, fileName, cleanup, nil
+}
+
+// gitEnv returns the environment of the git commands. The credentials are passed as HTTP headers through the
+// environment, so they are neither visible in the arguments of the git CLI nor stored in the cloned repository.
+func (ws *WorkerService) gitEnv(ctx context.Context, cfg *service.WorkerGitConfig) ([]string, error) {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	count := 0
+	for _, credential := range cfg.Credentials {
+		if credential.URL == "" || credential.Token == "" {
+			continue
+		}
+		token, err := ws.secrets.Resolve(ctx, credential.Token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve git token of %s: %w", credential.URL, err)
+		}
+		auth := base64.StdEncoding.EncodeToString([]byte(credential.Username + ":" + token))
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=http.%s.extraHeader", count, credential.URL),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: Basic %s", count, auth),
+		)
+		count++
+	}
+	return append(env, "GIT_CONFIG_COUNT="+strconv.Itoa(count)), nil
+}
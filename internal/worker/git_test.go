@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitLocation(t *testing.T) {
+	for _, tc := range []struct {
+		location     string
+		url          string
+		ref          string
+		subdirectory string
+	}{
+		{"git+https://github.com/acme/flows.git", "https://github.com/acme/flows.git", "", ""},
+		{"git+https://github.com/acme/flows.git@main", "https://github.com/acme/flows.git", "main", ""},
+		{"git+https://github.com/acme/flows.git@v1.2.0#subdirectory=etl/daily.py", "https://github.com/acme/flows.git", "v1.2.0", filepath.FromSlash("etl/daily.py")},
+		{"git+https://github.com/acme/flows.git@3f786850e387550fdab836ed7e6dc881de23001b", "https://github.com/acme/flows.git", "3f786850e387550fdab836ed7e6dc881de23001b", ""},
+		// The ref follows the last @, the user info of the URL is kept
+		{"git+https://token@git.example.com/acme/flows.git@feature/pause#subdirectory=/etl/", "https://token@git.example.com/acme/flows.git", "feature/pause", "etl"},
+	} {
+		loc, err := parseGitLocation(tc.location)
+		require.NoError(t, err, tc.location)
+		assert.Equal(t, tc.url, loc.URL.String(), tc.location)
+		assert.Equal(t, tc.ref, loc.Ref, tc.location)
+		assert.Equal(t, tc.subdirectory, loc.Subdirectory, tc.location)
+	}
+
+	for _, location := range []string{
+		"git+ssh://git@github.com/acme/flows.git",
+		"git+https://github.com",
+		"git+https://github.com/acme/flows.git@",
+		"git+https://github.com/acme/flows.git@main#subdirectory=../../etc",
+		"git+https://github.com/acme/flows.git#subdirectory=%zz",
+	} {
+		_, err := parseGitLocation(location)
+		assert.Error(t, err, location)
+	}
+
+	assert.True(t, gitCommitPattern.MatchString("3f786850e387550fdab836ed7e6dc881de23001b"))
+	assert.False(t, gitCommitPattern.MatchString("3f78685"))
+	assert.False(t, gitCommitPattern.MatchString("main"))
+}
@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// workerInfo is the metadata a worker publishes with its heartbeat
+type workerInfo struct {
+	Hostname     string    `json:"hostname"`
+	PID          int       `json:"pid"`
+	StartedAt    time.Time `json:"started_at"`
+	RunningFlows int       `json:"running_flows"`
+	Labels       []string  `json:"labels,omitempty"` // Labels advertised by the worker, matched with the labels required by the flows
+
+	// Concurrency pool of the flow runs
+	QueuedFlows        int    `json:"queued_flows"`
+	RequeuedFlows      uint64 `json:"requeued_flows"` // Runs redelivered because the queue was full, since the worker started
+	MaxConcurrentFlows int    `json:"max_concurrent_flows,omitempty"`
+	MaxQueuedFlows     int    `json:"max_queued_flows,omitempty"`
+}
+
+// runHeartbeat registers the worker and publishes its heartbeat every interval until the service stops
+func (ws *WorkerService) runHeartbeat(cfg *service.WorkerHeartbeatConfig) {
+	startedAt := time.Now().UTC()
+	ws.sendHeartbeat(cfg, startedAt)
+
+	ticker := time.NewTicker(time.Duration(cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ws.ctx.Done():
+			return
+		case <-ticker.C:
+			ws.sendHeartbeat(cfg, startedAt)
+		}
+	}
+}
+
+// sendHeartbeat marks the worker as active. A worker the flows service marked as failed, e.g. after a network
+// partition, had its flow runs rescheduled on another worker: its flow processes are killed so the runs do not
+// execute twice.
+func (ws *WorkerService) sendHeartbeat(cfg *service.WorkerHeartbeatConfig, startedAt time.Time) {
+	queries := db.New(ws.s.GetDB())
+	if previous, err := queries.GetWorkerHeartbeat(ws.ctx, ws.workerID); err == nil && previous.Status == db.WorkerStatusFailed {
+		ws.log.Warn("Worker was marked as failed, its flow runs were rescheduled", "worker_id", ws.workerID)
+		for flowRunID, process := range ws.processes.cancelAll() {
+			if err := ws.killFlowProcess(process); err != nil {
+				ws.log.Error("Failed to kill flow process group", "flow_run_id", flowRunID, "error", err)
+			}
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	pool := ws.pool.stats()
+	info, err := db.NewJsonRaw(workerInfo{
+		Hostname:           hostname,
+		PID:                os.Getpid(),
+		StartedAt:          startedAt,
+		RunningFlows:       ws.processes.count(),
+		Labels:             ws.labels,
+		QueuedFlows:        pool.Queued,
+		RequeuedFlows:      pool.Requeued,
+		MaxConcurrentFlows: pool.MaxConcurrent,
+		MaxQueuedFlows:     pool.MaxQueued,
+	})
+	if err != nil {
+		ws.log.Error("Failed to marshal worker info", "error", err)
+		return
+	}
+	_, err = queries.UpsertWorkerHeartbeat(ws.ctx, db.UpsertWorkerHeartbeatParams{
+		WorkerID:      ws.workerID,
+		WorkerName:    pgtype.Text{String: cfg.Name, Valid: cfg.Name != ""},
+		Status:        db.WorkerStatusActive,
+		LastHeartbeat: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		WorkerInfo:    info,
+	})
+	if err != nil {
+		if ws.ctx.Err() == nil && !db.IsUnavailable(err) {
+			ws.log.Error("Failed to publish worker heartbeat", "worker_id", ws.workerID, "error", err)
+		}
+		return
+	}
+	ws.log.Trace("Published worker heartbeat", "worker_id", ws.workerID, "running_flows", pool.Running, "queued_flows", pool.Queued)
+}
+
+// deregister marks the worker as inactive when it stops, the flows service reschedules the runs it left at once
+func (ws *WorkerService) deregister() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := db.New(ws.s.GetDB()).UpdateWorkerStatus(ctx, db.UpdateWorkerStatusParams{
+		WorkerID: ws.workerID,
+		Status:   db.WorkerStatusInactive,
+	})
+	if err != nil {
+		ws.log.Error("Failed to deregister worker", "worker_id", ws.workerID, "error", err)
+		return
+	}
+	ws.log.Info("Worker deregistered", "worker_id", ws.workerID)
+}
+
+// assignFlowRun records the worker running the process of a flow run, so the run is rescheduled if the worker stops,
+// and adds the pickup to the timeline of the run
+func (ws *WorkerService) assignFlowRun(flowRunID uuid.UUID) {
+	err := db.New(ws.s.GetDB()).AssignFlowRunWorker(ws.ctx, db.AssignFlowRunWorkerParams{
+		WorkerID:  pgtype.Text{String: ws.workerID, Valid: true},
+		FlowRunID: flowRunID,
+	})
+	if err != nil {
+		ws.log.Warn("Failed to record the worker of the flow run", "flow_run_id", flowRunID, "worker_id", ws.workerID, "error", err)
+	}
+	err = db.New(ws.s.GetDB()).RecordFlowRunEvent(ws.ctx, flowRunID, "", db.FlowRunEventWorkerAssigned, db.FlowRunEventSourceWorker, time.Now(), db.FlowRunEventData{WorkerID: ws.workerID})
+	if err != nil {
+		ws.log.Warn("Failed to record flow run event", "flow_run_id", flowRunID, "event_type", db.FlowRunEventWorkerAssigned, "error", err)
+	}
+}
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/service"
+)
+
+// flowLabelConsumerName returns the consumer shared by the workers executing the flow runs requiring a set of labels
+func flowLabelConsumerName(labels []string) string {
+	return "worker-flow-consumer-" + db.WorkerLabelsKey(labels)
+}
+
+// workerLabelSubsets returns the non-empty subsets of the labels of the worker, a flow requiring any of them runs on it
+func workerLabelSubsets(labels []string) [][]string {
+	var subsets [][]string
+	for mask := 1; mask < 1<<len(labels); mask++ {
+		var subset []string
+		for i, label := range labels {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, label)
+			}
+		}
+		subsets = append(subsets, subset)
+	}
+	return subsets
+}
+
+// createFlowLabelConsumers creates a consumer for each subset of the labels of the worker. The flows service publishes
+// the runs of a flow to the subject of its required labels, the consumers are shared with the other workers
+// advertising the labels so a run is executed once.
+func (ws *WorkerService) createFlowLabelConsumers(jsConfig *service.JetStreamConfig) error {
+	for _, labels := range workerLabelSubsets(ws.labels) {
+		consumer, err := ws.js.CreateOrUpdateConsumer(service.ConsumerConfig{
+			Name:        flowLabelConsumerName(labels),
+			StreamName:  workerFlowsStream,
+			Description: fmt.Sprintf("Consumer for the flow runs requiring the labels %v", labels),
+			FilterBy:    service.FlowRunExecuteLabelsSubject(labels).String(),
+			AckWait:     flowRunAckWait,
+			MaxDeliver:  3,
+		}, jsConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create consumer for labels %v: %w", labels, err)
+		}
+		ws.log.Debug("JetStream consumer created/updated", "name", consumer.CachedInfo().Name, "labels", labels)
+	}
+	return nil
+}
+
+// consumeFlowLabels starts consuming the flow runs requiring a subset of the labels of the worker
+func (ws *WorkerService) consumeFlowLabels() error {
+	for _, labels := range workerLabelSubsets(ws.labels) {
+		if err := ws.js.ConsumeMessages(flowLabelConsumerName(labels), workerFlowsStream, ws.handleFlowRunExecute); err != nil {
+			return fmt.Errorf("failed to start consuming flow runs requiring labels %v: %w", labels, err)
+		}
+	}
+	if len(ws.labels) > 0 {
+		ws.log.Info("Started consuming the flow runs requiring the worker labels", "labels", ws.labels, "stream", workerFlowsStream)
+	}
+	return nil
+}
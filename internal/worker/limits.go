@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+)
+
+// dockerKilledExitCode is the exit code of docker run when the container was killed, 128 + SIGKILL
+const dockerKilledExitCode = 137
+
+// workerLimits returns the resource limits of the flow processes
+func (ws *WorkerService) workerLimits() *service.WorkerLimitsConfig {
+	if ws.config == nil {
+		return &service.WorkerLimitsConfig{}
+	}
+	return ws.config.GetWorkerLimitsConfig()
+}
+
+// exceededLimit returns the resource limit an exited flow process was killed for going over, empty when none
+func (ws *WorkerService) exceededLimit(process *flowProcess, err error) string {
+	if process.timedOut {
+		return fmt.Sprintf("time limit of %ds", ws.workerLimits().TimeoutSeconds)
+	}
+	if process.container != "" {
+		// The worker only kills the containers of the cancelled and timed out runs, the other kills are
+		// the kernel killing a container over its memory limit
+		_, memoryMB := ws.dockerLimits()
+		var exitErr *exec.ExitError
+		if memoryMB > 0 && !process.cancelled && errors.As(err, &exitErr) && exitErr.ExitCode() == dockerKilledExitCode {
+			return fmt.Sprintf("memory limit of %d MiB", memoryMB)
+		}
+		return ""
+	}
+	return process.limiter.exceeded()
+}
+
+// closeProcessLimiter releases the resource limits of an exited flow process
+func (ws *WorkerService) closeProcessLimiter(flowRunID uuid.UUID, limiter *processLimiter) {
+	if err := limiter.close(); err != nil {
+		ws.log.Warn("Failed to release flow process resource limits", "flow_run_id", flowRunID, "error", err)
+	}
+}
@@ -0,0 +1,195 @@
+//go:build linux
+
+package worker
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+)
+
+const cgroupCPUPeriod = 100000 // Period of the CPU quota in microseconds
+
+// processLimiter confines a flow process and its children to a cgroup v2 with the CPU and memory limits of the worker
+type processLimiter struct {
+	dir      string
+	fd       *os.File
+	memoryMB int
+}
+
+// newProcessLimiter creates the cgroup of a flow process, nil when the worker has no CPU or memory limit
+func newProcessLimiter(cfg *service.WorkerLimitsConfig, flowRunID uuid.UUID) (*processLimiter, error) {
+	if cfg.CPUs <= 0 && cfg.MemoryMB <= 0 {
+		return nil, nil
+	}
+	var controllers []string
+	if cfg.CPUs > 0 {
+		controllers = append(controllers, "cpu")
+	}
+	if cfg.MemoryMB > 0 {
+		controllers = append(controllers, "memory")
+	}
+	if err := enableCgroupControllers(cfg.CgroupRoot, controllers); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(cfg.CgroupRoot, "flow-"+flowRunID.String())
+	if err := os.Mkdir(dir, 0o755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	l := &processLimiter{dir: dir, memoryMB: cfg.MemoryMB}
+	if err := l.configure(cfg); err != nil {
+		l.close()
+		return nil, err
+	}
+	fd, err := os.Open(dir)
+	if err != nil {
+		l.close()
+		return nil, fmt.Errorf("failed to open cgroup: %w", err)
+	}
+	l.fd = fd
+	return l, nil
+}
+
+// configure writes the limits of the cgroup
+func (l *processLimiter) configure(cfg *service.WorkerLimitsConfig) error {
+	if cfg.CPUs > 0 {
+		quota := int64(cfg.CPUs * cgroupCPUPeriod)
+		if err := l.write("cpu.max", fmt.Sprintf("%d %d", quota, cgroupCPUPeriod)); err != nil {
+			return err
+		}
+	}
+	if cfg.MemoryMB > 0 {
+		if err := l.write("memory.max", strconv.FormatInt(int64(cfg.MemoryMB)<<20, 10)); err != nil {
+			return err
+		}
+		// The whole process tree is killed above the limit, not only its largest process
+		if err := l.write("memory.oom.group", "1"); err != nil {
+			return err
+		}
+		// Swapping would let the process go over the limit instead of being killed, the file is
+		// missing when the kernel does not account the swap
+		_ = l.write("memory.swap.max", "0")
+	}
+	return nil
+}
+
+// write sets a file of the cgroup
+func (l *processLimiter) write(name, value string) error {
+	if err := os.WriteFile(filepath.Join(l.dir, name), []byte(value), 0o644); err != nil {
+		return fmt.Errorf("failed to set cgroup %s: %w", name, err)
+	}
+	return nil
+}
+
+// prepare starts the process directly inside the cgroup, so the processes it spawns at once are confined too
+func (l *processLimiter) prepare(cmd *exec.Cmd) {
+	if l == nil {
+		return
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(l.fd.Fd())
+}
+
+// attach is a no-op on Linux, the process is started inside the cgroup
+func (l *processLimiter) attach(cmd *exec.Cmd) error {
+	return nil
+}
+
+// exceeded returns the limit the process was killed for going over, empty when none
+func (l *processLimiter) exceeded() string {
+	if l == nil || l.memoryMB <= 0 {
+		return ""
+	}
+	f, err := os.Open(filepath.Join(l.dir, "memory.events"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		if key != "oom_kill" && key != "oom_group_kill" {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return fmt.Sprintf("memory limit of %d MiB", l.memoryMB)
+		}
+	}
+	return ""
+}
+
+// close kills the processes left in the cgroup and removes it
+func (l *processLimiter) close() error {
+	if l == nil {
+		return nil
+	}
+	if l.fd != nil {
+		l.fd.Close()
+	}
+	// cgroup.kill is missing before Linux 5.14, the process group of the flow is killed anyway
+	_ = l.write("cgroup.kill", "1")
+
+	// The cgroup can only be removed once its killed processes exited
+	var err error
+	for range 10 {
+		if err = syscall.Rmdir(l.dir); err == nil || os.IsNotExist(err) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("failed to remove cgroup %s: %w", l.dir, err)
+}
+
+// enableCgroupControllers creates the root of the process cgroups and enables the controllers of the limits for them
+func enableCgroupControllers(root string, controllers []string) error {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return fmt.Errorf("failed to create cgroup root %s: %w", root, err)
+	}
+	// The controllers available in the root are the ones enabled by its parent
+	if err := enableSubtreeControllers(filepath.Dir(root), filepath.Join(root, "cgroup.controllers"), controllers); err != nil {
+		return err
+	}
+	return enableSubtreeControllers(root, filepath.Join(root, "cgroup.subtree_control"), controllers)
+}
+
+// enableSubtreeControllers enables the controllers for the children of a cgroup, unless the enabled file lists them already
+func enableSubtreeControllers(dir, enabledFile string, controllers []string) error {
+	data, err := os.ReadFile(enabledFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s, cgroup v2 is required for the resource limits: %w", enabledFile, err)
+	}
+	enabled := strings.Fields(string(data))
+	var missing []string
+	for _, controller := range controllers {
+		found := false
+		for _, e := range enabled {
+			if e == controller {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, "+"+controller)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte(strings.Join(missing, " ")), 0o644); err != nil {
+		return fmt.Errorf("failed to enable cgroup controllers %s in %s: %w", strings.Join(missing, " "), dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessLimiterConfigure(t *testing.T) {
+	dir := t.TempDir()
+	l := &processLimiter{dir: dir, memoryMB: 256}
+	require.NoError(t, l.configure(&service.WorkerLimitsConfig{CPUs: 1.5, MemoryMB: 256}))
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+	// The quota is the CPUs over the period, both in microseconds
+	assert.Equal(t, "150000 100000", read("cpu.max"))
+	assert.Equal(t, "268435456", read("memory.max"))
+	assert.Equal(t, "1", read("memory.oom.group"))
+	assert.Equal(t, "0", read("memory.swap.max"))
+
+	// Only the limits set are written
+	cpuOnly := t.TempDir()
+	require.NoError(t, (&processLimiter{dir: cpuOnly}).configure(&service.WorkerLimitsConfig{CPUs: 0.5}))
+	assert.NoFileExists(t, filepath.Join(cpuOnly, "memory.max"))
+}
+
+func TestProcessLimiterExceeded(t *testing.T) {
+	dir := t.TempDir()
+	l := &processLimiter{dir: dir, memoryMB: 256}
+	events := filepath.Join(dir, "memory.events")
+
+	// No events are read as no limit exceeded
+	assert.Empty(t, l.exceeded())
+	require.NoError(t, os.WriteFile(events, []byte("low 0\nhigh 0\nmax 3\noom 1\noom_kill 0\noom_group_kill 0\n"), 0o644))
+	assert.Empty(t, l.exceeded())
+
+	require.NoError(t, os.WriteFile(events, []byte("low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\noom_group_kill 1\n"), 0o644))
+	assert.Equal(t, "memory limit of 256 MiB", l.exceeded())
+
+	// A process without a memory limit is not killed by the cgroup
+	var none *processLimiter
+	assert.Empty(t, none.exceeded())
+	assert.Empty(t, (&processLimiter{dir: dir}).exceeded())
+}
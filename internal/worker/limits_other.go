@@ -0,0 +1,30 @@
+//go:build !linux && !windows
+
+package worker
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+)
+
+// processLimiter is not supported on this platform, only the time limit of the flow processes applies
+type processLimiter struct{}
+
+// newProcessLimiter fails when the worker has CPU or memory limits, which cannot be enforced on this platform
+func newProcessLimiter(cfg *service.WorkerLimitsConfig, flowRunID uuid.UUID) (*processLimiter, error) {
+	if cfg.CPUs <= 0 && cfg.MemoryMB <= 0 {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("CPU and memory limits of the flow processes are only supported on Linux and Windows")
+}
+
+func (l *processLimiter) prepare(cmd *exec.Cmd) {}
+
+func (l *processLimiter) attach(cmd *exec.Cmd) error { return nil }
+
+func (l *processLimiter) exceeded() string { return "" }
+
+func (l *processLimiter) close() error { return nil }
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExceededLimit(t *testing.T) {
+	ws := &WorkerService{log: hclog.NewNullLogger(), config: &service.ExternalDependenciesConfig{
+		Worker: &service.WorkerConfig{
+			Limits: &service.WorkerLimitsConfig{MemoryMB: 512, TimeoutSeconds: 60},
+		},
+	}}
+
+	assert.Equal(t, "time limit of 60s", ws.exceededLimit(&flowProcess{timedOut: true}, errors.New("signal: killed")))
+	assert.Empty(t, ws.exceededLimit(&flowProcess{}, nil))
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	// A killed container went over its memory limit, unless the worker killed it for a cancel
+	killed := exec.Command("sh", "-c", "exit 137").Run()
+	assert.Equal(t, "memory limit of 512 MiB", ws.exceededLimit(&flowProcess{container: "pinazu-flow"}, killed))
+	assert.Empty(t, ws.exceededLimit(&flowProcess{container: "pinazu-flow", cancelled: true}, killed))
+	failed := exec.Command("sh", "-c", "exit 1").Run()
+	assert.Empty(t, ws.exceededLimit(&flowProcess{container: "pinazu-flow"}, failed))
+}
@@ -0,0 +1,157 @@
+//go:build windows
+
+package worker
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	jobObjectCPURateControlEnable  = 0x1
+	jobObjectCPURateControlHardCap = 0x4
+	jobObjectMsgProcessMemoryLimit = 9
+	jobObjectMsgJobMemoryLimit     = 10
+)
+
+type (
+	// jobObjectCPURateControlInformation is the JOBOBJECT_CPU_RATE_CONTROL_INFORMATION structure
+	jobObjectCPURateControlInformation struct {
+		ControlFlags uint32
+		CPURate      uint32 // Share of the CPU cycles in 1/100 of a percent
+	}
+
+	// jobObjectAssociateCompletionPort is the JOBOBJECT_ASSOCIATE_COMPLETION_PORT structure
+	jobObjectAssociateCompletionPort struct {
+		CompletionKey  uintptr
+		CompletionPort windows.Handle
+	}
+
+	// processLimiter confines a flow process and its children to a job object with the CPU and memory limits of the worker
+	processLimiter struct {
+		job            windows.Handle
+		port           windows.Handle
+		memoryMB       int
+		memoryExceeded atomic.Bool
+	}
+)
+
+// newProcessLimiter creates the job object of a flow process, nil when the worker has no CPU or memory limit
+func newProcessLimiter(cfg *service.WorkerLimitsConfig, flowRunID uuid.UUID) (*processLimiter, error) {
+	if cfg.CPUs <= 0 && cfg.MemoryMB <= 0 {
+		return nil, nil
+	}
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job object: %w", err)
+	}
+	l := &processLimiter{job: job, memoryMB: cfg.MemoryMB}
+	if err := l.configure(cfg); err != nil {
+		l.close()
+		return nil, err
+	}
+	go l.watch()
+	return l, nil
+}
+
+// configure sets the limits of the job object and the completion port notified when the memory limit is reached
+func (l *processLimiter) configure(cfg *service.WorkerLimitsConfig) error {
+	// The processes left by the flow process are killed with the job
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	if cfg.MemoryMB > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_JOB_MEMORY
+		info.JobMemoryLimit = uintptr(cfg.MemoryMB) << 20
+	}
+	if _, err := windows.SetInformationJobObject(l.job, windows.JobObjectExtendedLimitInformation, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		return fmt.Errorf("failed to set job object limits: %w", err)
+	}
+
+	if cfg.CPUs > 0 {
+		rate := uint32(cfg.CPUs / float64(runtime.NumCPU()) * 10000)
+		rate = max(1, min(rate, 10000))
+		cpu := jobObjectCPURateControlInformation{
+			ControlFlags: jobObjectCPURateControlEnable | jobObjectCPURateControlHardCap,
+			CPURate:      rate,
+		}
+		if _, err := windows.SetInformationJobObject(l.job, windows.JobObjectCpuRateControlInformation, uintptr(unsafe.Pointer(&cpu)), uint32(unsafe.Sizeof(cpu))); err != nil {
+			return fmt.Errorf("failed to set job object CPU rate: %w", err)
+		}
+	}
+
+	port, err := windows.CreateIoCompletionPort(windows.InvalidHandle, 0, 0, 1)
+	if err != nil {
+		return fmt.Errorf("failed to create completion port: %w", err)
+	}
+	l.port = port
+	association := jobObjectAssociateCompletionPort{CompletionKey: uintptr(l.job), CompletionPort: port}
+	if _, err := windows.SetInformationJobObject(l.job, windows.JobObjectAssociateCompletionPortInformation, uintptr(unsafe.Pointer(&association)), uint32(unsafe.Sizeof(association))); err != nil {
+		return fmt.Errorf("failed to associate job object completion port: %w", err)
+	}
+	return nil
+}
+
+// watch kills the processes of the job when they reach the memory limit, a process over the limit only fails its
+// allocations otherwise. It returns when the completion port is closed.
+func (l *processLimiter) watch() {
+	for {
+		var message uint32
+		var key uintptr
+		var overlapped *windows.Overlapped
+		if err := windows.GetQueuedCompletionStatus(l.port, &message, &key, &overlapped, windows.INFINITE); err != nil {
+			return
+		}
+		if message == jobObjectMsgJobMemoryLimit || message == jobObjectMsgProcessMemoryLimit {
+			l.memoryExceeded.Store(true)
+			windows.TerminateJobObject(l.job, 1)
+		}
+	}
+}
+
+// prepare is a no-op on Windows, the process is assigned to the job once started
+func (l *processLimiter) prepare(cmd *exec.Cmd) {}
+
+// attach assigns the started process to the job, the processes it spawns afterwards belong to the job too
+func (l *processLimiter) attach(cmd *exec.Cmd) error {
+	if l == nil {
+		return nil
+	}
+	process, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		return fmt.Errorf("failed to open flow process: %w", err)
+	}
+	defer windows.CloseHandle(process)
+	if err := windows.AssignProcessToJobObject(l.job, process); err != nil {
+		return fmt.Errorf("failed to assign flow process to job object: %w", err)
+	}
+	return nil
+}
+
+// exceeded returns the limit the process was killed for going over, empty when none
+func (l *processLimiter) exceeded() string {
+	if l == nil || !l.memoryExceeded.Load() {
+		return ""
+	}
+	return fmt.Sprintf("memory limit of %d MiB", l.memoryMB)
+}
+
+// close kills the processes left in the job and releases it
+func (l *processLimiter) close() error {
+	if l == nil {
+		return nil
+	}
+	if l.port != 0 {
+		windows.CloseHandle(l.port)
+	}
+	if err := windows.CloseHandle(l.job); err != nil {
+		return fmt.Errorf("failed to close job object: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/db"
+)
+
+const (
+	flowRunLogStdout        = "stdout"
+	flowRunLogStderr        = "stderr"
+	flowRunLogFlushInterval = time.Second // How often the complete lines of the output are stored
+	flowRunLogMaxChunkBytes = 64 * 1024   // Size of output stored at once, before the next flush
+)
+
+type (
+	// flowRunLogs captures the output of a flow process and stores it in chunks of lines, so the logs
+	// of the run can be read and tailed from the API
+	flowRunLogs struct {
+		mu        sync.Mutex
+		queries   *db.Queries
+		flowRunID uuid.UUID
+		log       hclog.Logger
+		buffers   map[string]*bytes.Buffer
+		closed    bool
+		done      chan struct{}
+		wg        sync.WaitGroup
+	}
+
+	// flowRunLogWriter is the writer of a stream of a flow process
+	flowRunLogWriter struct {
+		logs   *flowRunLogs
+		stream string
+	}
+)
+
+// newFlowRunLogs starts capturing the output of the process of a flow run
+func newFlowRunLogs(queries *db.Queries, flowRunID uuid.UUID, log hclog.Logger) *flowRunLogs {
+	l := &flowRunLogs{
+		queries:   queries,
+		flowRunID: flowRunID,
+		log:       log,
+		buffers:   map[string]*bytes.Buffer{flowRunLogStdout: {}, flowRunLogStderr: {}},
+		done:      make(chan struct{}),
+	}
+	l.wg.Add(1)
+	go l.run()
+	return l
+}
+
+// writer returns the writer of a stream of the process, the output is also copied to the console
+func (l *flowRunLogs) writer(stream string, console io.Writer) io.Writer {
+	return io.MultiWriter(console, &flowRunLogWriter{logs: l, stream: stream})
+}
+
+// Write buffers the output, it never fails so the process output is not interrupted when it cannot be stored
+func (w *flowRunLogWriter) Write(p []byte) (int, error) {
+	w.logs.write(w.stream, p)
+	return len(p), nil
+}
+
+// write buffers the output of a stream and stores the full chunks
+func (l *flowRunLogs) write(stream string, p []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	buf := l.buffers[stream]
+	buf.Write(p)
+	for buf.Len() >= flowRunLogMaxChunkBytes {
+		l.store(stream, buf.Next(chunkSize(buf.Bytes())))
+	}
+}
+
+// run stores the complete lines of the output every flush interval until the logs are closed
+func (l *flowRunLogs) run() {
+	defer l.wg.Done()
+	ticker := time.NewTicker(flowRunLogFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			for _, stream := range []string{flowRunLogStdout, flowRunLogStderr} {
+				buf := l.buffers[stream]
+				if i := bytes.LastIndexByte(buf.Bytes(), '\n'); i >= 0 {
+					l.store(stream, buf.Next(i+1))
+				}
+			}
+			l.mu.Unlock()
+		}
+	}
+}
+
+// close stores the rest of the output, the output written afterwards is dropped
+func (l *flowRunLogs) close() {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return
+	}
+	l.closed = true
+	l.mu.Unlock()
+
+	close(l.done)
+	l.wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, stream := range []string{flowRunLogStdout, flowRunLogStderr} {
+		buf := l.buffers[stream]
+		for buf.Len() > 0 {
+			l.store(stream, buf.Next(chunkSize(buf.Bytes())))
+		}
+	}
+}
+
+// store writes a chunk of output to the database, a chunk that cannot be stored is lost
+func (l *flowRunLogs) store(stream string, chunk []byte) {
+	// Postgres text rejects invalid UTF-8 and NUL bytes
+	content := strings.ReplaceAll(strings.ToValidUTF8(string(chunk), "\uFFFD"), "\x00", "")
+	if content == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := l.queries.CreateFlowRunLog(ctx, db.CreateFlowRunLogParams{
+		FlowRunID: l.flowRunID,
+		Stream:    stream,
+		Content:   content,
+	})
+	if err != nil {
+		l.log.Warn("Failed to store flow run logs", "flow_run_id", l.flowRunID, "stream", stream, "bytes", len(chunk), "error", err)
+	}
+}
+
+// chunkSize returns the size of the next chunk of the output: up to the last line ending within the max size
+// of a chunk, or the max size without cutting a character when a line is longer
+func chunkSize(output []byte) int {
+	if len(output) <= flowRunLogMaxChunkBytes {
+		return len(output)
+	}
+	if i := bytes.LastIndexByte(output[:flowRunLogMaxChunkBytes], '\n'); i >= 0 {
+		return i + 1
+	}
+	size := flowRunLogMaxChunkBytes
+	for size > flowRunLogMaxChunkBytes-utf8.UTFMax && !utf8.RuneStart(output[size]) {
+		size--
+	}
+	return size
+}
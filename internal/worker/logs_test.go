@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkSize(t *testing.T) {
+	// Output within a chunk is stored at once
+	assert.Equal(t, 0, chunkSize(nil))
+	assert.Equal(t, 5, chunkSize([]byte("a\nbcd")))
+
+	// A larger output is cut after its last line ending within the chunk
+	lines := strings.Repeat("line\n", flowRunLogMaxChunkBytes/5) + "rest of the output"
+	size := chunkSize([]byte(lines))
+	assert.Equal(t, flowRunLogMaxChunkBytes/5*5, size)
+	assert.Equal(t, byte('\n'), lines[size-1])
+
+	// A line longer than a chunk is cut at the max size, without cutting a character
+	long := bytes.Repeat([]byte("a"), flowRunLogMaxChunkBytes+10)
+	assert.Equal(t, flowRunLogMaxChunkBytes, chunkSize(long))
+	multibyte := append(bytes.Repeat([]byte("a"), flowRunLogMaxChunkBytes-1), []byte("é and more")...)
+	size = chunkSize(multibyte)
+	assert.Equal(t, flowRunLogMaxChunkBytes-1, size)
+	assert.True(t, utf8.Valid(multibyte[:size]))
+	assert.True(t, utf8.Valid(multibyte[size:]))
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pinazu/internal/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// flowRunQueueLatencyBuckets are the bounds of the queue latency histogram, in seconds
+var flowRunQueueLatencyBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800}
+
+// workerMetrics are the metrics of the flow processes of the worker, labeled by flow and engine
+type workerMetrics struct {
+	queueLatency metric.Float64Histogram
+	processes    metric.Int64UpDownCounter
+}
+
+// newWorkerMetrics creates the instruments of the worker metrics, a no-op until the process serves its metrics
+func (ws *WorkerService) newWorkerMetrics() (*workerMetrics, error) {
+	meter := otel.Meter("github.com/pinazu/internal/worker")
+	queueLatency, err := meter.Float64Histogram("pinazu.flow_run.queue_latency",
+		metric.WithDescription("Time from the scheduling of a flow run until its process starts on a worker"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(flowRunQueueLatencyBuckets...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flow run queue latency histogram: %w", err)
+	}
+	processes, err := meter.Int64UpDownCounter("pinazu.worker.flow_processes",
+		metric.WithDescription("Flow processes running on the worker"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flow processes counter: %w", err)
+	}
+	_, err = meter.Int64ObservableGauge("pinazu.worker.queued_flow_runs",
+		metric.WithDescription("Flow runs waiting for a free slot of the worker"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(ws.pool.stats().Queued), metric.WithAttributes(attribute.String("worker_id", ws.workerID)))
+			return nil
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create queued flow runs gauge: %w", err)
+	}
+	return &workerMetrics{queueLatency: queueLatency, processes: processes}, nil
+}
+
+// flowProcessAttributes returns the flow and engine labels of the metrics of a flow run
+func flowProcessAttributes(event *service.FlowRunExecuteEventMessage) metric.MeasurementOption {
+	return metric.WithAttributes(
+		attribute.String("flow", event.FlowName),
+		attribute.String("engine", event.Engine),
+	)
+}
+
+// recordFlowProcessStarted records the time the flow run waited for its process and counts the process as running
+func (ws *WorkerService) recordFlowProcessStarted(event *service.FlowRunExecuteEventMessage) {
+	attrs := flowProcessAttributes(event)
+	if !event.EventTimestamp.IsZero() {
+		ws.metrics.queueLatency.Record(ws.ctx, max(time.Since(event.EventTimestamp).Seconds(), 0), attrs)
+	}
+	ws.metrics.processes.Add(ws.ctx, 1, attrs)
+}
+
+// recordFlowProcessExited stops counting the process of a flow run as running
+func (ws *WorkerService) recordFlowProcessExited(event *service.FlowRunExecuteEventMessage) {
+	ws.metrics.processes.Add(ws.ctx, -1, flowProcessAttributes(event))
+}
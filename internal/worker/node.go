@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	flowEngineNode = "node" // The flow runs as a Node.js process, with node or with tsx for TypeScript
+
+	nodeModulesDir = "node_modules"
+)
+
+var (
+	// nodeEntrypoints are the entrypoints of the flows of the node engine
+	nodeEntrypoints = []string{"node", "tsx"}
+
+	// nodeFlowFiles are the flow files looked up in a code directory without flow.py, in this order
+	nodeFlowFiles = []string{"flow.js", "flow.mjs", "flow.cjs", "flow.ts", "flow.mts"}
+)
+
+// nodeFlowFile returns the flow file of a node flow. A code directory holds flow.js or flow.ts instead of the flow.py
+// assumed when the code location names no file.
+func nodeFlowFile(workingDir string, fileName string) string {
+	if fileName != "flow.py" || fileExists(filepath.Join(workingDir, fileName)) {
+		return fileName
+	}
+	for _, name := range nodeFlowFiles {
+		if fileExists(filepath.Join(workingDir, name)) {
+			return name
+		}
+	}
+	return fileName
+}
+
+// prepareNodeModules installs the dependencies of a node flow into its code directory when it has a package.json and
+// no node_modules. The installs of the same directory by concurrent runs are serialized.
+func (ws *WorkerService) prepareNodeModules(flowRunID uuid.UUID, workingDir string) error {
+	if ws.config == nil {
+		return nil
+	}
+	cfg := ws.config.GetWorkerNodeConfig()
+	if cfg.Disabled || !fileExists(filepath.Join(workingDir, "package.json")) {
+		return nil
+	}
+
+	unlock := ws.nodeModules.lock(workingDir)
+	defer unlock()
+	if info, err := os.Stat(filepath.Join(workingDir, nodeModulesDir)); err == nil && info.IsDir() {
+		return nil
+	}
+
+	// npm ci installs the locked versions, npm install resolves them from the package.json
+	args := []string{"install", "--no-audit", "--no-fund"}
+	if fileExists(filepath.Join(workingDir, "package-lock.json")) {
+		args[0] = "ci"
+	}
+	if cfg.Registry != "" {
+		args = append(args, "--registry", cfg.Registry)
+	}
+	ws.log.Info("Installing Node.js dependencies", "flow_run_id", flowRunID, "working_dir", workingDir, "command", cfg.NpmBinary+" "+args[0])
+	startTime := time.Now()
+
+	ctx, cancel := context.WithTimeout(ws.ctx, time.Duration(cfg.TimeoutSeconds)*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, cfg.NpmBinary, args...)
+	cmd.Dir = workingDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		// A partial install would be taken for a complete one by the next run
+		os.RemoveAll(filepath.Join(workingDir, nodeModulesDir))
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out installing the Node.js dependencies after %ds", cfg.TimeoutSeconds)
+		}
+		tail := strings.TrimSpace(string(output))
+		if len(tail) > pythonBuildOutputTail {
+			tail = "..." + tail[len(tail)-pythonBuildOutputTail:]
+		}
+		return fmt.Errorf("failed to install Node.js dependencies, npm %s: %w: %s", args[0], err, tail)
+	}
+	ws.log.Info("Node.js dependencies installed", "flow_run_id", flowRunID, "working_dir", workingDir, "duration_ms", time.Since(startTime).Milliseconds())
+	return nil
+}
+
+// nodeCommand returns the entrypoint and the environment variables running a node flow. An entrypoint installed in
+// the node_modules of the flow, such as tsx, runs from it.
+func nodeCommand(workingDir string, entrypoint string) (string, []string) {
+	binDir := filepath.Join(workingDir, nodeModulesDir, ".bin")
+	if filepath.Base(entrypoint) == entrypoint {
+		path := filepath.Join(binDir, entrypoint)
+		if runtime.GOOS == "windows" {
+			path += ".cmd"
+		}
+		if fileExists(path) {
+			entrypoint = path
+		}
+	}
+	return entrypoint, []string{
+		"PATH=" + binDir + string(os.PathListSeparator) + os.Getenv("PATH"),
+	}
+}
+
+// validateNodeEntrypoint checks that the entrypoint of a node flow is node or tsx, by name or path
+func validateNodeEntrypoint(entrypoint string) error {
+	name := strings.TrimSuffix(filepath.Base(entrypoint), filepath.Ext(entrypoint))
+	if !slices.Contains(nodeEntrypoints, name) {
+		return fmt.Errorf("unsupported entrypoint %s for the node engine, valid options are: %s", entrypoint, strings.Join(nodeEntrypoints, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeFlowFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// Without a flow file the assumed flow.py is kept, the process reports it missing
+	assert.Equal(t, "flow.py", nodeFlowFile(dir, "flow.py"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flow.ts"), nil, 0o644))
+	assert.Equal(t, "flow.ts", nodeFlowFile(dir, "flow.py"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flow.js"), nil, 0o644))
+	assert.Equal(t, "flow.js", nodeFlowFile(dir, "flow.py"))
+
+	// A named file is run as is
+	assert.Equal(t, "etl.mjs", nodeFlowFile(dir, "etl.mjs"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flow.py"), nil, 0o644))
+	assert.Equal(t, "flow.py", nodeFlowFile(dir, "flow.py"))
+}
+
+func TestNodeCommand(t *testing.T) {
+	dir := t.TempDir()
+	tsx := filepath.Join(dir, nodeModulesDir, ".bin", "tsx")
+	if runtime.GOOS == "windows" {
+		tsx += ".cmd"
+	}
+	require.NoError(t, os.MkdirAll(filepath.Dir(tsx), 0o755))
+	require.NoError(t, os.WriteFile(tsx, nil, 0o755))
+
+	// An entrypoint installed in the node_modules of the flow runs from it
+	entrypoint, env := nodeCommand(dir, "tsx")
+	assert.Equal(t, tsx, entrypoint)
+	require.Len(t, env, 1)
+	assert.Contains(t, env[0], filepath.Join(dir, nodeModulesDir, ".bin"))
+	entrypoint, _ = nodeCommand(dir, "node")
+	assert.Equal(t, "node", entrypoint)
+
+	for _, valid := range []string{"node", "tsx", "/usr/local/bin/node", "node.exe"} {
+		assert.NoError(t, validateNodeEntrypoint(valid), valid)
+	}
+	for _, invalid := range []string{"python", "bash", "/usr/bin/npx", ""} {
+		assert.Error(t, validateNodeEntrypoint(invalid), invalid)
+	}
+}
@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	// ociLocationPrefix prefixes the code locations packaged as an OCI image
+	ociLocationPrefix = "oci://"
+
+	// flowParametersEnv holds the parameters of the run in JSON, for the image entrypoints not parsing the arguments
+	flowParametersEnv = "FLOW_PARAMETERS"
+)
+
+// ociReferencePattern matches an image reference, registry/repository with an optional tag and sha256 digest
+var ociReferencePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.-]*(?::[0-9]+)?(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*(?::[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(?:@sha256:[0-9a-f]{64})?$`)
+
+// isOCILocation reports whether a code location is an OCI image
+func isOCILocation(location string) bool {
+	return strings.HasPrefix(location, ociLocationPrefix)
+}
+
+// parseOCILocation returns the image reference of an oci://registry/repository:tag or @sha256:digest code location
+func parseOCILocation(location string) (string, error) {
+	image := strings.TrimPrefix(location, ociLocationPrefix)
+	if image == "" || !ociReferencePattern.MatchString(image) {
+		return "", fmt.Errorf("invalid OCI code location, expected oci://registry/repository:tag or @sha256:digest: %s", location)
+	}
+	return image, nil
+}
+
+// pullOCIImage pulls the image of an OCI code location. An image pinned by digest is pulled only when it is missing,
+// a tag is pulled again so the run gets the image it points to. The working directory of the run is an empty
+// temporary directory, the code is in the image.
+func (ws *WorkerService) pullOCIImage(location string) (workingDir string, fileName string, cleanup func(), err error) {
+	image, err := parseOCILocation(location)
+	if err != nil {
+		return "", "", nil, err
+	}
+	cfg := (&service.ExternalDependenciesConfig{}).GetWorkerDockerConfig()
+	if ws.config != nil {
+		cfg = ws.config.GetWorkerDockerConfig()
+	}
+
+	ctx, cancel := context.WithTimeout(ws.ctx, time.Duration(cfg.PullTimeoutSeconds)*time.Second)
+	defer cancel()
+	if strings.Contains(image, "@sha256:") && exec.CommandContext(ctx, cfg.Binary, "image", "inspect", image).Run() == nil {
+		ws.log.Info("Using pulled flow image", "image", image)
+	} else {
+		startTime := time.Now()
+		output, err := exec.CommandContext(ctx, cfg.Binary, "pull", "--quiet", image).CombinedOutput()
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return "", "", nil, fmt.Errorf("timed out pulling flow image %s after %ds", image, cfg.PullTimeoutSeconds)
+			}
+			return "", "", nil, fmt.Errorf("failed to pull flow image %s: %w: %s", image, err, strings.TrimSpace(string(output)))
+		}
+		ws.log.Info("Pulled flow image", "image", image, "duration_ms", time.Since(startTime).Milliseconds())
+	}
+
+	tempDir, err := os.MkdirTemp("", "pinazu-flow-*")
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	return tempDir, "", func() { os.RemoveAll(tempDir) }, nil
+}
+
+// buildOCICommand wraps a flow into a docker run of the image of its OCI code location. The image entrypoint runs with
+// the arguments of the flow, the entrypoint of the flow replaces it when set. The parameters are also passed in JSON
+// in the FLOW_PARAMETERS environment variable.
+func (ws *WorkerService) buildOCICommand(event *service.FlowRunExecuteEventMessage, workingDir string, args []string, envVars []string, artifacts *flowRunArtifacts) (*exec.Cmd, error) {
+	image, err := parseOCILocation(event.CodeLocation)
+	if err != nil {
+		return nil, err
+	}
+	cfg := (&service.ExternalDependenciesConfig{}).GetWorkerDockerConfig()
+	if ws.config != nil {
+		cfg = ws.config.GetWorkerDockerConfig()
+	}
+
+	parameters, err := json.Marshal(event.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+	envVars = append(envVars, fmt.Sprintf("%s=%s", flowParametersEnv, parameters))
+
+	dockerArgs := ws.dockerRunArgs(cfg, event, envVars, artifacts)
+	if event.Entrypoint != "" {
+		dockerArgs = append(dockerArgs, "--entrypoint", event.Entrypoint)
+	}
+	dockerArgs = append(dockerArgs, image)
+	dockerArgs = append(dockerArgs, args...)
+
+	cpus, memoryMB := ws.dockerLimits()
+	ws.log.Info("Running flow image in a container",
+		"flow_run_id", event.FlowRunId,
+		"image", image,
+		"entrypoint", event.Entrypoint,
+		"network", cfg.Network,
+		"cpus", cpus,
+		"memory_mb", memoryMB,
+	)
+	cmd := exec.Command(cfg.Binary, dockerArgs...)
+	cmd.Dir = workingDir
+	cmd.Env = append(os.Environ(), envVars...)
+	return cmd, nil
+}
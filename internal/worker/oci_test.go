@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOCILocation(t *testing.T) {
+	digest := "sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	for _, image := range []string{
+		"ghcr.io/acme/flows:1.2.0",
+		"registry.example.com:5000/acme/etl-flows",
+		"acme/flows@" + digest,
+		"ghcr.io/acme/flows:latest@" + digest,
+	} {
+		parsed, err := parseOCILocation("oci://" + image)
+		require.NoError(t, err, image)
+		assert.Equal(t, image, parsed)
+	}
+
+	for _, location := range []string{
+		"oci://",
+		"oci://ghcr.io/Acme/flows",
+		"oci://ghcr.io/acme/flows@sha256:1234",
+		"oci://ghcr.io/acme/flows --privileged",
+		"oci://-v/:/host",
+	} {
+		_, err := parseOCILocation(location)
+		assert.Error(t, err, location)
+	}
+
+	assert.True(t, isOCILocation("oci://ghcr.io/acme/flows"))
+	assert.False(t, isOCILocation("git+https://github.com/acme/flows.git"))
+}
+
+func TestBuildOCICommand(t *testing.T) {
+	ws := &WorkerService{log: hclog.NewNullLogger()}
+	flowRunID := uuid.New()
+	event := &service.FlowRunExecuteEventMessage{
+		FlowRunId:    flowRunID,
+		CodeLocation: "oci://ghcr.io/acme/flows:1.2.0",
+		Entrypoint:   "/app/run",
+		Parameters:   map[string]any{"day": "2026-10-16"},
+	}
+
+	cmd, err := ws.buildOCICommand(event, t.TempDir(), []string{"--day", "2026-10-16"}, nil, nil)
+	require.NoError(t, err)
+	args := cmd.Args[1:]
+	// The entrypoint of the flow replaces the one of the image, the arguments follow the image
+	assert.Equal(t, []string{"--entrypoint", "/app/run", "ghcr.io/acme/flows:1.2.0", "--day", "2026-10-16"}, args[len(args)-5:])
+	assert.Contains(t, args, flowParametersEnv)
+	assert.Contains(t, cmd.Env, flowParametersEnv+`={"day":"2026-10-16"}`)
+}
@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"sync"
+
+	"github.com/pinazu/internal/service"
+)
+
+type (
+	// flowRunPool bounds the flow runs executed at once by the worker. A run holds a slot from the preparation of its
+	// code until its process exits, the runs received beyond the slots wait in a bounded queue.
+	flowRunPool struct {
+		mu            sync.Mutex
+		maxConcurrent int // Slots of the pool, unlimited when 0
+		maxQueued     int
+		running       int
+		queue         []chan struct{} // Waiting runs, oldest first, closed when a slot is handed over
+		requeued      uint64          // Runs NAK'd because the queue was full, since the worker started
+	}
+
+	// flowRunPoolStats is a snapshot of the pool, published with the heartbeat of the worker
+	flowRunPoolStats struct {
+		Running       int
+		Queued        int
+		MaxConcurrent int
+		MaxQueued     int
+		Requeued      uint64
+	}
+)
+
+// newFlowRunPool creates the pool of the worker concurrency configuration
+func newFlowRunPool(cfg *service.WorkerConcurrencyConfig) *flowRunPool {
+	return &flowRunPool{maxConcurrent: cfg.MaxConcurrentFlows, maxQueued: cfg.MaxQueuedFlows}
+}
+
+// admit reserves a slot for a flow run. It returns a nil channel when the run can start at once, or a channel closed
+// once a slot is handed over to the run waiting in the queue. It returns false when the queue is full, unless the run
+// is forced into the queue because its message cannot be redelivered anymore.
+func (p *flowRunPool) admit(force bool) (<-chan struct{}, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.maxConcurrent <= 0 || (p.running < p.maxConcurrent && len(p.queue) == 0) {
+		p.running++
+		return nil, true
+	}
+	if len(p.queue) >= p.maxQueued && !force {
+		p.requeued++
+		return nil, false
+	}
+	wait := make(chan struct{})
+	p.queue = append(p.queue, wait)
+	return wait, true
+}
+
+// release frees the slot of an ended flow run, handing it over to the oldest run of the queue
+func (p *flowRunPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.queue) > 0 {
+		close(p.queue[0])
+		p.queue = p.queue[1:]
+		return
+	}
+	if p.running > 0 {
+		p.running--
+	}
+}
+
+// leave removes a run from the queue. It returns false when a slot was handed over to the run in between, the slot
+// must be released then.
+func (p *flowRunPool) leave(wait <-chan struct{}) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, queued := range p.queue {
+		if queued == wait {
+			p.queue = append(p.queue[:i], p.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// stats returns a snapshot of the pool
+func (p *flowRunPool) stats() flowRunPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return flowRunPoolStats{
+		Running:       p.running,
+		Queued:        len(p.queue),
+		MaxConcurrent: p.maxConcurrent,
+		MaxQueued:     p.maxQueued,
+		Requeued:      p.requeued,
+	}
+}
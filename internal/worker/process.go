@@ -10,35 +10,175 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
 	"github.com/pinazu/internal/db"
 	"github.com/pinazu/internal/service"
 )
 
-// executeFlowProcess spawns and monitors a Python flow process
-func (ws *WorkerService) executeFlowProcess(ctx context.Context, event *service.FlowRunExecuteEventMessage) {
+type (
+	// flowProcess is a flow process running on the worker
+	flowProcess struct {
+		cmd       *exec.Cmd
+		container string          // Container of the process for the docker engine
+		limiter   *processLimiter // Resource limits of the process for the process engine
+		cancelled bool            // The flow run was cancelled and the process killed
+		paused    bool            // The process was asked to stop before its next task
+		timedOut  bool            // The process was killed for going over the time limit
+		// The pinazu flow library runs in the process, reporting the status of the run and handling the pause signal.
+		// The worker reports the RUNNING and SUCCESS statuses of the other processes.
+		reportsStatus bool
+	}
+
+	// flowProcesses tracks the running flow processes by flow run ID, so a cancelled run can be terminated
+	flowProcesses struct {
+		mu        sync.Mutex
+		processes map[uuid.UUID]*flowProcess
+	}
+)
+
+// add tracks a started flow process
+func (p *flowProcesses) add(flowRunID uuid.UUID, process *flowProcess) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.processes == nil {
+		p.processes = make(map[uuid.UUID]*flowProcess)
+	}
+	p.processes[flowRunID] = process
+}
+
+// remove stops tracking a flow process and returns it, nil when it is not tracked
+func (p *flowProcesses) remove(flowRunID uuid.UUID) *flowProcess {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	process, ok := p.processes[flowRunID]
+	if !ok {
+		return nil
+	}
+	delete(p.processes, flowRunID)
+	return process
+}
+
+// cancel marks a flow process as cancelled and returns it, nil when the flow run has no process on this worker
+func (p *flowProcesses) cancel(flowRunID uuid.UUID) *flowProcess {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	process, ok := p.processes[flowRunID]
+	if !ok {
+		return nil
+	}
+	process.cancelled = true
+	return process
+}
+
+// pause marks a flow process as paused and returns it, nil when the flow run has no process on this worker
+func (p *flowProcesses) pause(flowRunID uuid.UUID) *flowProcess {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	process, ok := p.processes[flowRunID]
+	if !ok {
+		return nil
+	}
+	process.paused = process.reportsStatus
+	return process
+}
+
+// unpause clears the pause of a flow process that could not be signaled
+func (p *flowProcesses) unpause(flowRunID uuid.UUID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if process, ok := p.processes[flowRunID]; ok {
+		process.paused = false
+	}
+}
+
+// cancelAll marks every flow process as cancelled and returns them by flow run ID
+func (p *flowProcesses) cancelAll() map[uuid.UUID]*flowProcess {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	processes := make(map[uuid.UUID]*flowProcess, len(p.processes))
+	for flowRunID, process := range p.processes {
+		process.cancelled = true
+		processes[flowRunID] = process
+	}
+	return processes
+}
+
+// count returns the number of running flow processes
+func (p *flowProcesses) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.processes)
+}
+
+// timeout marks a flow process as timed out and returns it, nil when the flow run has no process on this worker
+func (p *flowProcesses) timeout(flowRunID uuid.UUID) *flowProcess {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	process, ok := p.processes[flowRunID]
+	if !ok {
+		return nil
+	}
+	process.timedOut = true
+	return process
+}
+
+// executeFlowProcess spawns and monitors a flow process, a Python process, a Node.js process for the node engine or a
+// container for the docker engine. It returns false when the process did not start, true when the monitoring of the
+// process took over the slot of the run.
+func (ws *WorkerService) executeFlowProcess(ctx context.Context, event *service.FlowRunExecuteEventMessage) bool {
 	// Prepare code location (local/S3)
 	workingDir, fileName, cleanup, err := ws.prepareCodeLocation(event.CodeLocation)
 	if err != nil {
 		ws.log.Error("Failed to prepare code location", "error", err, "flow_run_id", event.FlowRunId)
 		ws.reportFlowRunStatus(event.FlowRunId, "FAILED", err.Error())
-		return
+		return false
+	}
+
+	// The dependencies of the flow are installed in a cached virtualenv, or in the node_modules of its code directory
+	// for the node engine. The docker engine and the OCI code locations use the ones of their image.
+	pythonEnv := ""
+	switch {
+	case event.Engine == flowEngineDocker, isOCILocation(event.CodeLocation):
+	case event.Engine == flowEngineNode:
+		fileName = nodeFlowFile(workingDir, fileName)
+		if err := ws.prepareNodeModules(event.FlowRunId, workingDir); err != nil {
+			ws.log.Error("Failed to install Node.js dependencies", "error", err, "flow_run_id", event.FlowRunId)
+			ws.reportFlowRunStatus(event.FlowRunId, "FAILED", err.Error())
+			cleanup()
+			return false
+		}
+	default:
+		if pythonEnv, err = ws.preparePythonEnvironment(event.FlowRunId, workingDir); err != nil {
+			ws.log.Error("Failed to prepare Python environment", "error", err, "flow_run_id", event.FlowRunId)
+			ws.reportFlowRunStatus(event.FlowRunId, "FAILED", err.Error())
+			cleanup()
+			return false
+		}
+	}
+
+	// The artifacts published by the tasks are uploaded when the process exits
+	artifacts, err := ws.newFlowRunArtifacts(event.FlowRunId)
+	if err != nil {
+		ws.log.Warn("Flow run artifacts are kept on the local disk", "flow_run_id", event.FlowRunId, "error", err)
 	}
 
 	// Build command
-	cmd, err := ws.buildCommand(event, workingDir, fileName)
+	cmd, err := ws.buildCommand(event, workingDir, fileName, pythonEnv, artifacts)
 	if err != nil {
-		ws.log.Error("Failed to build Python command", "error", err, "flow_run_id", event.FlowRunId)
+		ws.log.Error("Failed to build flow command", "error", err, "flow_run_id", event.FlowRunId)
 		ws.reportFlowRunStatus(event.FlowRunId, "FAILED", err.Error())
+		artifacts.remove()
 		cleanup() // Cleanup before returning on error
-		return
+		return false
+	}
+	container := ""
+	if event.Engine == flowEngineDocker || isOCILocation(event.CodeLocation) {
+		container = dockerContainerName(event.FlowRunId)
 	}
 
 	// Start the process
@@ -48,23 +188,76 @@ func (ws *WorkerService) executeFlowProcess(ctx context.Context, event *service.
 		"args", event.Args,
 		"working_dir", workingDir,
 	)
-	// Setup to stream stdout and stderr to console
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// The run may have been cancelled while its code was prepared
+	if flowRun, err := db.New(ws.s.GetDB()).GetFlowRun(ctx, event.FlowRunId); err == nil && flowRun.Status == db.FlowStatusCancelled {
+		ws.log.Info("Flow run cancelled before its process started", "flow_run_id", event.FlowRunId)
+		artifacts.remove()
+		cleanup()
+		return false
+	}
+
+	// The process engine confines the process to the worker limits, the docker engine passes them to the container
+	var limiter *processLimiter
+	if container == "" {
+		limiter, err = newProcessLimiter(ws.workerLimits(), event.FlowRunId)
+		if err != nil {
+			ws.log.Error("Failed to apply flow process resource limits", "error", err, "flow_run_id", event.FlowRunId)
+			ws.reportFlowRunStatus(event.FlowRunId, "FAILED", fmt.Sprintf("failed to apply resource limits: %s", err))
+			artifacts.remove()
+			cleanup()
+			return false
+		}
+	}
+
+	// Stream stdout and stderr to console and store them as the logs of the run
+	logs := newFlowRunLogs(db.New(ws.s.GetDB()), event.FlowRunId, ws.log)
+	cmd.Stdout = logs.writer(flowRunLogStdout, os.Stdout)
+	cmd.Stderr = logs.writer(flowRunLogStderr, os.Stderr)
+	setProcessGroup(cmd)
+	limiter.prepare(cmd)
 	err = cmd.Start()
+	if err == nil {
+		// A process that cannot be confined is not left running
+		if err = limiter.attach(cmd); err != nil {
+			killProcessGroup(cmd)
+			cmd.Wait()
+		}
+	}
 	if err != nil {
-		ws.log.Error("Failed to start Python process", "error", err, "flow_run_id", event.FlowRunId)
+		ws.log.Error("Failed to start flow process", "error", err, "flow_run_id", event.FlowRunId)
+		limiter.close()
+		logs.close()
+		ws.uploadFlowRunArtifacts(artifacts)
 		ws.reportFlowRunStatus(event.FlowRunId, "FAILED", err.Error())
 		cleanup() // Cleanup before returning on error
-		return
+		return false
+	}
+	reportsStatus := event.Engine != flowEngineNode
+	ws.processes.add(event.FlowRunId, &flowProcess{cmd: cmd, container: container, limiter: limiter, reportsStatus: reportsStatus})
+	// A run cancelled since the check above sent its cancel event before the process was tracked, the status is
+	// checked again now that any later cancel event finds the process
+	if flowRun, err := db.New(ws.s.GetDB()).GetFlowRun(ctx, event.FlowRunId); err == nil && flowRun.Status == db.FlowStatusCancelled {
+		if _, err := ws.cancelFlowProcess(event.FlowRunId); err != nil {
+			ws.log.Error("Failed to kill flow process group", "flow_run_id", event.FlowRunId, "error", err)
+		}
 	}
+	ws.assignFlowRun(event.FlowRunId)
+	if !reportsStatus {
+		ws.reportFlowRunStatus(event.FlowRunId, db.FlowStatusRunning)
+	}
+
+	ws.recordFlowProcessStarted(event)
 
 	// Monitor the process in a separate goroutine
 	// Pass cleanup function to be called after process completes
-	go ws.monitorProcess(ctx, cmd, event.FlowRunId, cleanup)
+	go ws.monitorProcess(ctx, cmd, event.FlowRunId, logs, artifacts, func() {
+		ws.recordFlowProcessExited(event)
+		cleanup()
+	})
+	return true
 }
 
-// prepareCodeLocation handles code location preparation (local files, S3 downloads)
+// prepareCodeLocation handles code location preparation (local files, S3 downloads, git clones, OCI image pulls)
 func (ws *WorkerService) prepareCodeLocation(codeLocation string) (workingDir string, fileName string, cleanup func(), err error) {
 	if codeLocation == "" {
 		// No specific location, use current directory
@@ -77,6 +270,16 @@ func (ws *WorkerService) prepareCodeLocation(codeLocation string) (workingDir st
 		return ws.downloadFromS3(codeLocation)
 	}
 
+	if strings.HasPrefix(codeLocation, gitLocationPrefix) {
+		// Shallow clone the repository at the ref to temp directory
+		return ws.cloneGitRepository(codeLocation)
+	}
+
+	if isOCILocation(codeLocation) {
+		// Pull the image, the flow runs its entrypoint
+		return ws.pullOCIImage(codeLocation)
+	}
+
 	// Local file - get absolute path and directory
 	absPath, err := filepath.Abs(codeLocation)
 	if err != nil {
@@ -125,74 +328,12 @@ func (ws *WorkerService) downloadFromS3(s3Path string) (workingDir string, fileN
 		ws.log.Debug("Cleaned up temporary directory", "path", tempDir)
 	}
 
-	// Create AWS config with appropriate credential provider and endpoint
-	var configOptions []func(*config.LoadOptions) error
-
-	// Add region
-	configOptions = append(configOptions, config.WithRegion(s3Config.Region))
-
-	// Configure credentials based on type
-	switch s3Config.CredentialType {
-	case "static":
-		if s3Config.AccessKeyID == "" || s3Config.SecretAccessKey == "" {
-			cleanup()
-			return "", "", nil, fmt.Errorf("access_key_id and secret_access_key required for static credentials")
-		}
-		configOptions = append(configOptions, config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(
-				s3Config.AccessKeyID,
-				s3Config.SecretAccessKey,
-				"", // token (empty for basic access keys)
-			),
-		))
-	case "assume_role":
-		if s3Config.AssumeRoleARN == "" {
-			cleanup()
-			return "", "", nil, fmt.Errorf("assume_role_arn required for assume_role credentials")
-		}
-		// Load default config first to get base credentials for assume role
-		baseCfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(s3Config.Region))
-		if err != nil {
-			cleanup()
-			return "", "", nil, fmt.Errorf("failed to load base AWS config for assume role: %w", err)
-		}
-
-		// Create STS client and assume role credentials
-		stsClient := sts.NewFromConfig(baseCfg)
-		sessionName := s3Config.AssumeRoleSession
-		if sessionName == "" {
-			sessionName = "pinazu-worker-session"
-		}
-
-		assumeRoleCreds := stscreds.NewAssumeRoleProvider(stsClient, s3Config.AssumeRoleARN, func(o *stscreds.AssumeRoleOptions) {
-			o.RoleSessionName = sessionName
-		})
-
-		configOptions = append(configOptions, config.WithCredentialsProvider(assumeRoleCreds))
-	case "default", "":
-		// Use default credential chain (environment, instance profile, etc.)
-		// No additional credential provider needed
-	default:
-		cleanup()
-		return "", "", nil, fmt.Errorf("unsupported credential_type: %s (supported: static, assume_role, default)", s3Config.CredentialType)
-	}
-
-	// Configure custom endpoint if provided (for MinIO/S3-compatible services)
-	if s3Config.EndpointURL != "" {
-		configOptions = append(configOptions, config.WithBaseEndpoint(s3Config.EndpointURL))
-	}
-
-	cfg, err := config.LoadDefaultConfig(context.TODO(), configOptions...)
+	s3Client, err := service.NewS3Client(context.TODO(), s3Config)
 	if err != nil {
 		cleanup()
-		return "", "", nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return "", "", nil, err
 	}
 
-	// Create S3 client with path-style configuration
-	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.UsePathStyle = s3Config.UsePathStyle
-	})
-
 	ws.log.Info("Downloading file from S3",
 		"s3_path", s3Path,
 		"bucket", bucket,
@@ -244,12 +385,15 @@ func (ws *WorkerService) downloadFromS3(s3Path string) (workingDir string, fileN
 	return tempDir, filename, cleanup, nil
 }
 
-// buildCommand constructs the command with parameters using flexible entrypoint and args
-func (ws *WorkerService) buildCommand(event *service.FlowRunExecuteEventMessage, workingDir string, fileName string) (*exec.Cmd, error) {
+// buildCommand constructs the command with parameters using flexible entrypoint and args, the process runs in the
+// Python environment of the flow when it has one. A node flow receives the same arguments and environment variables.
+func (ws *WorkerService) buildCommand(event *service.FlowRunExecuteEventMessage, workingDir string, fileName string, pythonEnv string, artifacts *flowRunArtifacts) (*exec.Cmd, error) {
 	// Start with the provided args
 	args := make([]string, len(event.Args))
 	copy(args, event.Args)
-	args = append(args, fileName) // Append the file name to args
+	if fileName != "" {
+		args = append(args, fileName) // Append the file name to args, the code of an OCI image has none
+	}
 
 	// Add regular parameters
 	for key, value := range event.Parameters {
@@ -265,9 +409,6 @@ func (ws *WorkerService) buildCommand(event *service.FlowRunExecuteEventMessage,
 		args = append(args, "--success-task-results", string(resultsJSON))
 	}
 
-	cmd := exec.Command(event.Entrypoint, args...)
-	cmd.Dir = workingDir
-
 	// Get NATS URL from NATS connection
 	natsURL := "nats://localhost:4222" // default
 	if ws.s.GetNATS() != nil && len(ws.s.GetNATS().Servers()) > 0 {
@@ -367,12 +508,35 @@ func (ws *WorkerService) buildCommand(event *service.FlowRunExecuteEventMessage,
 		}
 	}
 
+	if isOCILocation(event.CodeLocation) {
+		return ws.buildOCICommand(event, workingDir, args, envVars, artifacts)
+	}
+	if event.Engine == flowEngineDocker {
+		return ws.buildDockerCommand(event, workingDir, args, envVars, artifacts)
+	}
+	entrypoint := event.Entrypoint
+	if event.Engine == flowEngineNode {
+		if err := validateNodeEntrypoint(entrypoint); err != nil {
+			return nil, err
+		}
+		var nodeEnvVars []string
+		entrypoint, nodeEnvVars = nodeCommand(workingDir, entrypoint)
+		envVars = append(envVars, nodeEnvVars...)
+	} else if pythonEnv != "" {
+		var pythonEnvVars []string
+		entrypoint, pythonEnvVars = pythonEnvCommand(pythonEnv, entrypoint)
+		envVars = append(envVars, pythonEnvVars...)
+	}
+	cmd := exec.Command(entrypoint, args...)
+	cmd.Dir = workingDir
 	cmd.Env = append(os.Environ(), envVars...)
+	cmd.Env = append(cmd.Env, artifacts.env()...)
 	return cmd, nil
 }
 
-// monitorProcess waits for the process to complete and handles errors
-func (ws *WorkerService) monitorProcess(ctx context.Context, cmd *exec.Cmd, flowRunID uuid.UUID, cleanup func()) {
+// monitorProcess waits for the process to complete and handles errors,
+// the logs and the artifacts of the process are stored before its status is reported
+func (ws *WorkerService) monitorProcess(ctx context.Context, cmd *exec.Cmd, flowRunID uuid.UUID, logs *flowRunLogs, artifacts *flowRunArtifacts, cleanup func()) {
 	// Wait for process completion or context cancellation
 	done := make(chan error, 1)
 	go func() {
@@ -382,30 +546,166 @@ func (ws *WorkerService) monitorProcess(ctx context.Context, cmd *exec.Cmd, flow
 	//cleanup when function exits
 	defer cleanup()
 
+	// The slot of the run is handed over to the next queued run
+	defer ws.pool.release()
+
+	// A process going over the time limit is killed
+	var timeout <-chan time.Time
+	if limits := ws.workerLimits(); limits.TimeoutSeconds > 0 {
+		timer := time.NewTimer(time.Duration(limits.TimeoutSeconds) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
 	select {
 	case <-ctx.Done():
 		// Context cancelled, kill the process
 		ws.log.Warn("Context cancelled, terminating flow process", "flow_run_id", flowRunID)
-		if cmd.Process != nil {
-			cmd.Process.Kill()
+		process := ws.processes.remove(flowRunID)
+		if process == nil {
+			process = &flowProcess{cmd: cmd}
+		}
+		if err := ws.killFlowProcess(process); err != nil {
+			ws.log.Error("Failed to kill flow process group", "flow_run_id", flowRunID, "error", err)
 		}
+		ws.closeProcessLimiter(flowRunID, process.limiter)
+		logs.close()
+		ws.uploadFlowRunArtifacts(artifacts)
 		ws.reportFlowRunStatus(flowRunID, "FAILED", "Process cancelled due to context cancellation")
+		return
 
-	case err := <-done:
-		if err != nil {
-			// Process terminated abnormally
-			ws.log.Error("Flow process terminated abnormally",
-				"error", err,
-				"flow_run_id", flowRunID,
-			)
-			ws.reportFlowRunStatus(flowRunID, "FAILED", err.Error())
-		} else {
-			// Process completed successfully - Flow library handles RUNNING/SUCCESS reporting
-			ws.log.Info("Flow process completed successfully", "flow_run_id", flowRunID)
+	case <-timeout:
+		if process := ws.processes.timeout(flowRunID); process != nil {
+			ws.log.Warn("Flow process went over its time limit, terminating it", "flow_run_id", flowRunID, "timeout_seconds", ws.workerLimits().TimeoutSeconds)
+			if err := ws.killFlowProcess(process); err != nil {
+				ws.log.Error("Failed to kill flow process group", "flow_run_id", flowRunID, "error", err)
+			}
+		}
+		err = <-done
+
+	case err = <-done:
+	}
+
+	logs.close()
+	ws.recordFlowRunCPU(flowRunID, cmd)
+	ws.uploadFlowRunArtifacts(artifacts)
+	process := ws.processes.remove(flowRunID)
+	if process == nil {
+		process = &flowProcess{cmd: cmd}
+	}
+	exceeded := ws.exceededLimit(process, err)
+	ws.closeProcessLimiter(flowRunID, process.limiter)
+	if process.cancelled {
+		// The flows service already marked the run and its tasks as cancelled
+		ws.log.Info("Cancelled flow process terminated", "flow_run_id", flowRunID)
+		return
+	}
+	if process.paused && err == nil {
+		// The flow library reported the PAUSED status, or SUCCESS when no task was left
+		ws.log.Info("Paused flow process exited", "flow_run_id", flowRunID)
+		return
+	}
+	if exceeded != "" {
+		ws.log.Warn("Flow process killed for going over its resource limits", "flow_run_id", flowRunID, "limit", exceeded)
+		ws.reportFlowRunFailure(flowRunID, db.FlowFailureReasonResourceExceeded, fmt.Sprintf("Flow process went over its %s", exceeded))
+		return
+	}
+	if err != nil {
+		// Process terminated abnormally
+		ws.log.Error("Flow process terminated abnormally",
+			"error", err,
+			"flow_run_id", flowRunID,
+		)
+		ws.reportFlowRunStatus(flowRunID, "FAILED", err.Error())
+	} else {
+		// Process completed successfully - Flow library handles RUNNING/SUCCESS reporting
+		ws.log.Info("Flow process completed successfully", "flow_run_id", flowRunID)
+		if !process.reportsStatus {
+			ws.reportFlowRunStatus(flowRunID, db.FlowStatusSuccess)
 		}
 	}
 }
 
+// handleFlowRunCancel kills the process of a cancelled flow run when it runs on this worker
+func (ws *WorkerService) handleFlowRunCancel(msg *nats.Msg) {
+	event, err := service.ParseEvent[*service.FlowRunCancelEventMessage](msg.Data)
+	if err != nil {
+		ws.log.Error("Failed to parse FlowRunCancelEvent", "error", err)
+		return
+	}
+
+	flowRunID := event.Msg.FlowRunId
+	found, err := ws.cancelFlowProcess(flowRunID)
+	if !found {
+		ws.log.Debug("Cancelled flow run has no process on this worker", "flow_run_id", flowRunID)
+		return
+	}
+	if err != nil {
+		ws.log.Error("Failed to kill flow process group", "flow_run_id", flowRunID, "error", err)
+	}
+}
+
+// cancelFlowProcess kills the process of a cancelled flow run, found is false when the flow run has no process on
+// this worker. The process is marked as cancelled, its exit is not reported.
+func (ws *WorkerService) cancelFlowProcess(flowRunID uuid.UUID) (found bool, err error) {
+	process := ws.processes.cancel(flowRunID)
+	if process == nil {
+		return false, nil
+	}
+	if err := ws.killFlowProcess(process); err != nil {
+		return true, err
+	}
+	ws.log.Info("Killed flow process of cancelled flow run", "flow_run_id", flowRunID, "pid", process.cmd.Process.Pid, "container", process.container)
+	return true, nil
+}
+
+// handleFlowRunPause asks the process of a flow run to pause when it runs on this worker. The worker running the
+// process answers the pause request of the flows service, with the error when the process cannot pause.
+func (ws *WorkerService) handleFlowRunPause(msg *nats.Msg) {
+	event, err := service.ParseEvent[*service.FlowRunPauseEventMessage](msg.Data)
+	if err != nil {
+		ws.log.Error("Failed to parse FlowRunPauseEvent", "error", err)
+		return
+	}
+
+	flowRunID := event.Msg.FlowRunId
+	found, err := ws.pauseFlowRun(flowRunID)
+	if !found {
+		ws.log.Debug("Paused flow run has no process on this worker", "flow_run_id", flowRunID)
+		return
+	}
+	if msg.Reply == "" {
+		return
+	}
+	if err != nil {
+		service.NewErrorEvent[*service.FlowRunPauseEventMessage](event.H, event.M, err).Respond(msg)
+		return
+	}
+	event.Respond(msg)
+}
+
+// pauseFlowRun signals the process of a flow run to stop before its next task. found is false when the flow run
+// has no process on this worker, the process is left running when the error is not nil.
+func (ws *WorkerService) pauseFlowRun(flowRunID uuid.UUID) (found bool, err error) {
+	process := ws.processes.pause(flowRunID)
+	if process == nil {
+		return false, nil
+	}
+	if !process.paused {
+		ws.log.Warn("Flow process cannot pause without the flow library, the run continues", "flow_run_id", flowRunID)
+		return true, fmt.Errorf("flow run %s cannot pause, its process does not run the pinazu flow library", flowRunID)
+	}
+	if err := ws.pauseFlowProcess(process); err != nil {
+		// The process keeps running, its exit is not a pause
+		ws.processes.unpause(flowRunID)
+		ws.log.Error("Failed to signal flow process to pause", "flow_run_id", flowRunID, "error", err)
+		return true, fmt.Errorf("failed to pause flow run %s: %w", flowRunID, err)
+	}
+	ws.log.Info("Signaled flow process to pause", "flow_run_id", flowRunID, "pid", process.cmd.Process.Pid, "container", process.container)
+	return true, nil
+}
+
 // reportFlowRunStatus sends a FlowRunStatusEvent to the Orchestrator via JetStream
 func (ws *WorkerService) reportFlowRunStatus(flowRunID uuid.UUID, status db.FlowStatus, errorMessage ...string) {
 	// Convert string FlowRunId to uuid.UUID
@@ -416,12 +716,28 @@ func (ws *WorkerService) reportFlowRunStatus(flowRunID uuid.UUID, status db.Flow
 	}
 
 	// Create the event using the correct structure expected by flows service
-	eventMessage := &service.FlowRunStatusEventMessage{
+	ws.publishFlowRunStatus(&service.FlowRunStatusEventMessage{
 		FlowRunId:      flowRunID,
 		Status:         status,
 		EventTimestamp: time.Now().UTC(),
 		ErrorMessage:   errMsg,
-	}
+	})
+}
+
+// reportFlowRunFailure reports a failed flow run with the reason of its failure
+func (ws *WorkerService) reportFlowRunFailure(flowRunID uuid.UUID, reason db.FlowFailureReason, errorMessage string) {
+	ws.publishFlowRunStatus(&service.FlowRunStatusEventMessage{
+		FlowRunId:      flowRunID,
+		Status:         db.FlowStatusFailed,
+		EventTimestamp: time.Now().UTC(),
+		ErrorMessage:   errorMessage,
+		FailureReason:  reason,
+	})
+}
+
+// publishFlowRunStatus publishes a FlowRunStatusEvent to the Orchestrator via JetStream
+func (ws *WorkerService) publishFlowRunStatus(eventMessage *service.FlowRunStatusEventMessage) {
+	flowRunID, status := eventMessage.FlowRunId, eventMessage.Status
 
 	// Create the service event wrapper
 	event := service.Event[*service.FlowRunStatusEventMessage]{
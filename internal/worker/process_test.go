@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseFlowRun(t *testing.T) {
+	ws := &WorkerService{log: hclog.NewNullLogger()}
+
+	// A flow run without a process on this worker is left to the other workers
+	found, err := ws.pauseFlowRun(uuid.New())
+	assert.False(t, found)
+	assert.NoError(t, err)
+
+	// A process without the flow library cannot pause
+	withoutLibrary := uuid.New()
+	ws.processes.add(withoutLibrary, &flowProcess{cmd: &exec.Cmd{}})
+	found, err = ws.pauseFlowRun(withoutLibrary)
+	assert.True(t, found)
+	assert.ErrorContains(t, err, "does not run the pinazu flow library")
+	assert.False(t, ws.processes.remove(withoutLibrary).paused)
+
+	flowRunID := uuid.New()
+	if runtime.GOOS == "windows" {
+		// The process cannot be signaled, its exit is not taken for a pause
+		ws.processes.add(flowRunID, &flowProcess{cmd: &exec.Cmd{}, reportsStatus: true})
+		found, err = ws.pauseFlowRun(flowRunID)
+		assert.True(t, found)
+		assert.ErrorContains(t, err, "not supported on Windows")
+		assert.False(t, ws.processes.remove(flowRunID).paused)
+		return
+	}
+
+	cmd := exec.Command("sleep", "30")
+	require.NoError(t, cmd.Start())
+	defer cmd.Process.Kill()
+	ws.processes.add(flowRunID, &flowProcess{cmd: cmd, reportsStatus: true})
+	found, err = ws.pauseFlowRun(flowRunID)
+	assert.True(t, found)
+	assert.NoError(t, err)
+	assert.True(t, ws.processes.remove(flowRunID).paused)
+	cmd.Wait()
+
+	// The process already exited, the signal fails and the pause is cleared
+	ws.processes.add(flowRunID, &flowProcess{cmd: cmd, reportsStatus: true})
+	found, err = ws.pauseFlowRun(flowRunID)
+	assert.True(t, found)
+	assert.Error(t, err)
+	assert.False(t, ws.processes.remove(flowRunID).paused)
+}
+
+func TestCancelFlowProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the flow process is started with sleep")
+	}
+	ws := &WorkerService{log: hclog.NewNullLogger()}
+
+	// A flow run without a process on this worker is left to the other workers
+	found, err := ws.cancelFlowProcess(uuid.New())
+	assert.False(t, found)
+	assert.NoError(t, err)
+
+	flowRunID := uuid.New()
+	cmd := exec.Command("sleep", "30")
+	setProcessGroup(cmd)
+	require.NoError(t, cmd.Start())
+	defer cmd.Process.Kill()
+	ws.processes.add(flowRunID, &flowProcess{cmd: cmd, reportsStatus: true})
+
+	found, err = ws.cancelFlowProcess(flowRunID)
+	assert.True(t, found)
+	assert.NoError(t, err)
+	assert.Error(t, cmd.Wait(), "the process is killed")
+	// The exit of the process is not reported as a failure
+	assert.True(t, ws.processes.remove(flowRunID).cancelled)
+}
+
+func TestFlowProcessesCancelAll(t *testing.T) {
+	var processes flowProcesses
+	first, second := uuid.New(), uuid.New()
+	processes.add(first, &flowProcess{cmd: &exec.Cmd{}})
+	processes.add(second, &flowProcess{cmd: &exec.Cmd{}, paused: true})
+	assert.Equal(t, 2, processes.count())
+
+	// The processes of a worker marked as failed are killed, their exit is not reported
+	cancelled := processes.cancelAll()
+	assert.Len(t, cancelled, 2)
+	for _, process := range cancelled {
+		assert.True(t, process.cancelled)
+	}
+	assert.NotNil(t, processes.remove(first))
+	assert.Equal(t, 1, processes.count())
+	assert.Nil(t, processes.remove(first))
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package worker
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, which has no process groups
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the flow process, the processes it spawned are left running on Windows
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
+
+// signalPause is not supported on Windows, which has no signal to send the flow process
+func signalPause(cmd *exec.Cmd) error {
+	return fmt.Errorf("pausing a flow process is not supported on Windows")
+}
@@ -0,0 +1,278 @@
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pinazu/internal/service"
+)
+
+const (
+	pythonManagerUv   = "uv"
+	pythonManagerVenv = "venv"
+
+	pythonEnvReadyFile    = ".pinazu-ready" // Written once an environment is built, its modification time is the last use
+	pythonBuildOutputTail = 2048            // Bytes of the build output kept in the error of a failed build
+)
+
+// pythonDependencyFiles are the dependency specifications of a flow, looked up in its code directory in this order
+var pythonDependencyFiles = []string{"requirements.txt", "pyproject.toml"}
+
+// pythonEnvironments serializes the builds of the same environment by the flow runs of the worker
+type pythonEnvironments struct {
+	mu     sync.Mutex
+	builds map[string]*sync.Mutex
+}
+
+// lock locks the build of an environment and returns its unlock function
+func (e *pythonEnvironments) lock(key string) func() {
+	e.mu.Lock()
+	if e.builds == nil {
+		e.builds = make(map[string]*sync.Mutex)
+	}
+	build, ok := e.builds[key]
+	if !ok {
+		build = &sync.Mutex{}
+		e.builds[key] = build
+	}
+	e.mu.Unlock()
+	build.Lock()
+	return build.Unlock
+}
+
+// preparePythonEnvironment returns the virtualenv of the dependencies of a flow, building it when it is not cached.
+// It returns an empty directory when the flow has no dependency specification or the environments are disabled.
+func (ws *WorkerService) preparePythonEnvironment(flowRunID uuid.UUID, workingDir string) (string, error) {
+	if ws.config == nil {
+		return "", nil
+	}
+	cfg := ws.config.GetWorkerPythonConfig()
+	if cfg.Disabled {
+		return "", nil
+	}
+	spec := ""
+	for _, name := range pythonDependencyFiles {
+		if info, err := os.Stat(filepath.Join(workingDir, name)); err == nil && !info.IsDir() {
+			spec = filepath.Join(workingDir, name)
+			break
+		}
+	}
+	if spec == "" {
+		return "", nil
+	}
+
+	manager, err := pythonManager(cfg)
+	if err != nil {
+		return "", err
+	}
+	key, err := pythonEnvKey(cfg, manager, spec)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cfg.CacheDir, key)
+
+	unlock := ws.pythonEnvs.lock(key)
+	defer unlock()
+
+	ready := filepath.Join(dir, pythonEnvReadyFile)
+	if _, err := os.Stat(ready); err == nil {
+		now := time.Now()
+		os.Chtimes(ready, now, now)
+		ws.log.Info("Using cached Python environment", "flow_run_id", flowRunID, "dependencies", filepath.Base(spec), "environment", dir)
+		return dir, nil
+	}
+
+	ws.log.Info("Building Python environment",
+		"flow_run_id", flowRunID,
+		"dependencies", filepath.Base(spec),
+		"manager", manager,
+		"python", cfg.Python,
+		"environment", dir,
+	)
+	startTime := time.Now()
+	// A directory without the ready file is left by a build that did not complete
+	if err := os.RemoveAll(dir); err != nil {
+		return "", fmt.Errorf("failed to remove incomplete Python environment: %w", err)
+	}
+	if err := os.MkdirAll(cfg.CacheDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create Python environments directory: %w", err)
+	}
+	if err := ws.buildPythonEnvironment(cfg, manager, dir, spec); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	if err := os.WriteFile(ready, []byte(filepath.Base(spec)+"\n"), 0o644); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to mark Python environment as built: %w", err)
+	}
+	ws.log.Info("Python environment built", "flow_run_id", flowRunID, "environment", dir, "duration_ms", time.Since(startTime).Milliseconds())
+
+	ws.removeUnusedPythonEnvironments(cfg, key)
+	return dir, nil
+}
+
+// pythonManager returns the builder of the environments, uv when it is found on the PATH unless configured
+func pythonManager(cfg *service.WorkerPythonConfig) (string, error) {
+	switch cfg.Manager {
+	case pythonManagerUv, pythonManagerVenv:
+		return cfg.Manager, nil
+	case "":
+		if _, err := exec.LookPath(cfg.UvBinary); err == nil {
+			return pythonManagerUv, nil
+		}
+		return pythonManagerVenv, nil
+	default:
+		return "", fmt.Errorf("invalid Python environment manager %q, valid options are: %s, %s", cfg.Manager, pythonManagerUv, pythonManagerVenv)
+	}
+}
+
+// pythonEnvKey hashes the dependencies of a flow with the settings changing the environment built from them
+func pythonEnvKey(cfg *service.WorkerPythonConfig, manager string, spec string) (string, error) {
+	content, err := os.ReadFile(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filepath.Base(spec), err)
+	}
+	h := sha256.New()
+	for _, part := range []string{manager, cfg.Python, strconv.FormatBool(cfg.Isolated), cfg.IndexURL, filepath.Base(spec)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))[:32], nil
+}
+
+// buildPythonEnvironment creates the virtualenv and installs the dependencies into it. A requirements.txt is installed
+// as is, a pyproject.toml installs its dependencies with uv and the project with its dependencies with pip.
+func (ws *WorkerService) buildPythonEnvironment(cfg *service.WorkerPythonConfig, manager string, dir string, spec string) error {
+	ctx, cancel := context.WithTimeout(ws.ctx, time.Duration(cfg.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	run := func(name string, args ...string) error {
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Dir = filepath.Dir(spec)
+		cmd.Env = append(os.Environ(), "VIRTUAL_ENV="+dir)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("timed out building the Python environment after %ds", cfg.TimeoutSeconds)
+			}
+			tail := strings.TrimSpace(string(output))
+			if len(tail) > pythonBuildOutputTail {
+				tail = "..." + tail[len(tail)-pythonBuildOutputTail:]
+			}
+			return fmt.Errorf("failed to build Python environment, %s %s: %w: %s", filepath.Base(name), args[0], err, tail)
+		}
+		return nil
+	}
+
+	python := pythonEnvExecutable(dir, "python")
+	isPyproject := filepath.Base(spec) == "pyproject.toml"
+	switch manager {
+	case pythonManagerUv:
+		args := []string{"venv", "--quiet", "--python", cfg.Python}
+		if !cfg.Isolated {
+			args = append(args, "--system-site-packages")
+		}
+		if err := run(cfg.UvBinary, append(args, dir)...); err != nil {
+			return err
+		}
+		args = []string{"pip", "install", "--quiet", "--python", python, "--requirement", spec}
+		if cfg.IndexURL != "" {
+			args = append(args, "--index-url", cfg.IndexURL)
+		}
+		return run(cfg.UvBinary, args...)
+
+	default:
+		args := []string{"-m", "venv"}
+		if !cfg.Isolated {
+			args = append(args, "--system-site-packages")
+		}
+		if err := run(cfg.Python, append(args, dir)...); err != nil {
+			return err
+		}
+		args = []string{"-m", "pip", "install", "--quiet", "--disable-pip-version-check"}
+		if cfg.IndexURL != "" {
+			args = append(args, "--index-url", cfg.IndexURL)
+		}
+		if isPyproject {
+			args = append(args, filepath.Dir(spec))
+		} else {
+			args = append(args, "--requirement", spec)
+		}
+		return run(python, args...)
+	}
+}
+
+// removeUnusedPythonEnvironments removes the environments unused for longer than the retention, removal failures
+// are only logged
+func (ws *WorkerService) removeUnusedPythonEnvironments(cfg *service.WorkerPythonConfig, current string) {
+	entries, err := os.ReadDir(cfg.CacheDir)
+	if err != nil {
+		ws.log.Warn("Failed to list Python environments", "dir", cfg.CacheDir, "error", err)
+		return
+	}
+	cutoff := time.Now().Add(-time.Duration(cfg.RetentionHours) * time.Hour)
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == current {
+			continue
+		}
+		dir := filepath.Join(cfg.CacheDir, entry.Name())
+		info, err := os.Stat(filepath.Join(dir, pythonEnvReadyFile))
+		if err != nil || info.ModTime().After(cutoff) {
+			// Environments being built have no ready file yet
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			ws.log.Warn("Failed to remove unused Python environment", "environment", dir, "error", err)
+			continue
+		}
+		ws.log.Info("Removed unused Python environment", "environment", dir, "last_used", info.ModTime())
+	}
+}
+
+// pythonEnvBinDir returns the directory of the executables of a virtualenv
+func pythonEnvBinDir(dir string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(dir, "Scripts")
+	}
+	return filepath.Join(dir, "bin")
+}
+
+// pythonEnvExecutable returns the path of an executable of a virtualenv
+func pythonEnvExecutable(dir string, name string) string {
+	if runtime.GOOS == "windows" && filepath.Ext(name) == "" {
+		name += ".exe"
+	}
+	return filepath.Join(pythonEnvBinDir(dir), name)
+}
+
+// pythonEnvCommand returns the entrypoint and the environment variables running a flow in a virtualenv. An entrypoint
+// installed in the environment, such as python, runs from it.
+func pythonEnvCommand(dir string, entrypoint string) (string, []string) {
+	if filepath.Base(entrypoint) == entrypoint {
+		if path := pythonEnvExecutable(dir, entrypoint); fileExists(path) {
+			entrypoint = path
+		}
+	}
+	return entrypoint, []string{
+		"VIRTUAL_ENV=" + dir,
+		"PATH=" + pythonEnvBinDir(dir) + string(os.PathListSeparator) + os.Getenv("PATH"),
+	}
+}
+
+// fileExists reports whether a path exists and is not a directory
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
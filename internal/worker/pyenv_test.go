@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pinazu/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPythonEnvKey(t *testing.T) {
+	dir := t.TempDir()
+	requirements := filepath.Join(dir, "requirements.txt")
+	require.NoError(t, os.WriteFile(requirements, []byte("requests==2.32.3\n"), 0o644))
+	cfg := &service.WorkerPythonConfig{Python: "python3"}
+
+	key, err := pythonEnvKey(cfg, pythonManagerUv, requirements)
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	// The same dependencies share their environment, whatever the directory of the flow
+	other := filepath.Join(t.TempDir(), "requirements.txt")
+	require.NoError(t, os.WriteFile(other, []byte("requests==2.32.3\n"), 0o644))
+	same, err := pythonEnvKey(cfg, pythonManagerUv, other)
+	require.NoError(t, err)
+	assert.Equal(t, key, same)
+
+	// The dependencies and the settings building the environment change it
+	keys := map[string]bool{key: true}
+	require.NoError(t, os.WriteFile(other, []byte("requests==2.32.4\n"), 0o644))
+	for _, changed := range []struct {
+		cfg     *service.WorkerPythonConfig
+		manager string
+		spec    string
+	}{
+		{cfg, pythonManagerUv, other},
+		{cfg, pythonManagerVenv, requirements},
+		{&service.WorkerPythonConfig{Python: "python3.12"}, pythonManagerUv, requirements},
+		{&service.WorkerPythonConfig{Python: "python3", Isolated: true}, pythonManagerUv, requirements},
+		{&service.WorkerPythonConfig{Python: "python3", IndexURL: "https://pypi.example.com/simple"}, pythonManagerUv, requirements},
+	} {
+		changedKey, err := pythonEnvKey(changed.cfg, changed.manager, changed.spec)
+		require.NoError(t, err)
+		assert.False(t, keys[changedKey], "environment key %s is reused", changedKey)
+		keys[changedKey] = true
+	}
+
+	_, err = pythonEnvKey(cfg, pythonManagerUv, filepath.Join(dir, "pyproject.toml"))
+	assert.Error(t, err)
+}
+
+func TestPythonEnvCommand(t *testing.T) {
+	dir := t.TempDir()
+	python := pythonEnvExecutable(dir, "python")
+	require.NoError(t, os.MkdirAll(filepath.Dir(python), 0o755))
+	require.NoError(t, os.WriteFile(python, nil, 0o755))
+
+	// An entrypoint installed in the environment runs from it, the others are left as is
+	entrypoint, env := pythonEnvCommand(dir, "python")
+	assert.Equal(t, python, entrypoint)
+	assert.Contains(t, env, "VIRTUAL_ENV="+dir)
+	entrypoint, _ = pythonEnvCommand(dir, "uvicorn")
+	assert.Equal(t, "uvicorn", entrypoint)
+	entrypoint, _ = pythonEnvCommand(dir, "/usr/bin/python3")
+	assert.Equal(t, "/usr/bin/python3", entrypoint)
+
+	_, err := pythonManager(&service.WorkerPythonConfig{Manager: "conda"})
+	assert.Error(t, err)
+	manager, err := pythonManager(&service.WorkerPythonConfig{Manager: pythonManagerVenv})
+	require.NoError(t, err)
+	assert.Equal(t, pythonManagerVenv, manager)
+}
@@ -6,18 +6,40 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
 	"github.com/nats-io/nats.go/jetstream"
+	"github.com/pinazu/internal/db"
+	"github.com/pinazu/internal/secrets"
 	"github.com/pinazu/internal/service"
 )
 
+const (
+	// workerFlowsStream holds the flow execution events until a worker executes them
+	workerFlowsStream = "WORKER_FLOWS"
+
+	// flowRunAckWait is the time a worker has to acknowledge a flow execution event, queued runs keep their event in progress
+	flowRunAckWait = 30 * time.Second
+)
+
 type WorkerService struct {
-	s      service.Service
-	js     *service.JetStreamService
-	config *service.ExternalDependenciesConfig
-	log    hclog.Logger
-	wg     *sync.WaitGroup
-	ctx    context.Context
+	s       service.Service
+	js      *service.JetStreamService
+	config  *service.ExternalDependenciesConfig
+	log     hclog.Logger
+	wg      *sync.WaitGroup
+	ctx     context.Context
+	secrets *secrets.Resolver // Resolves the API keys of the edge tools
+
+	workerID string   // Registration of the worker, a new one at each start
+	labels   []string // Labels advertised by the worker, sorted
+
+	processes   flowProcesses
+	pool        *flowRunPool // Bounds the flow runs executed at once
+	maxDeliver  int          // Deliveries of a flow execution event, the last one is queued even when the queue is full
+	pythonEnvs  pythonEnvironments
+	nodeModules pythonEnvironments // Serializes the installs of the node_modules of a code directory
+	metrics     *workerMetrics
 }
 
 // Create a new worker service instance
@@ -26,13 +48,24 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("externalDependenciesConfig is nil")
 	}
 
+	resolver, err := externalDependenciesConfig.Secrets.NewSecretsResolver(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secrets resolver: %w", err)
+	}
+
+	labels, err := externalDependenciesConfig.GetWorkerLabels()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a new service instance
 	config := &service.Config{
 		Name:                 "worker-service",
 		Version:              "0.0.1",
-		Description:          "Worker service for executing flow runs via local processes",
+		Description:          "Worker service for executing flow runs via local processes and calling edge tools",
 		ExternalDependencies: externalDependenciesConfig,
 		ErrorHandler:         nil,
+		Streams:              []string{workerFlowsStream},
 	}
 	s, err := service.NewService(ctx, config)
 	if err != nil {
@@ -45,18 +78,32 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 		return nil, fmt.Errorf("failed to create JetStream service: %w", err)
 	}
 
-	ws := &WorkerService{s: s, js: js, config: externalDependenciesConfig, log: log, wg: wg, ctx: ctx}
+	js.SetRedeliveryConfig(externalDependenciesConfig.Nats.GetRedeliveryConfig())
+
+	ws := &WorkerService{s: s, js: js, config: externalDependenciesConfig, log: log, wg: wg, ctx: ctx, secrets: resolver, workerID: uuid.NewString(), labels: labels}
+	ws.pool = newFlowRunPool(externalDependenciesConfig.GetWorkerConcurrencyConfig())
+	if ws.metrics, err = ws.newWorkerMetrics(); err != nil {
+		return nil, err
+	}
 
 	// Get JetStream configuration
 	jsConfig := externalDependenciesConfig.Nats.GetJetStreamConfig()
 
+	// Resolve the replication role of the region, standby streams mirror the primary region
+	replication := externalDependenciesConfig.GetReplicationConfig()
+	role, err := js.ReplicationRole(replication)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve replication role: %w", err)
+	}
+
 	// Create or update stream
 	streamConfig := service.CreateStreamConfigWithDefaults(
-		"WORKER_FLOWS",
-		[]string{string(service.FlowRunExecuteEventSubject)},
+		workerFlowsStream,
+		service.ManagedStreams[workerFlowsStream],
 		"Stream for worker flow execution events",
 		jsConfig,
 	)
+	streamConfig = service.ApplyReplication(streamConfig, replication, role)
 
 	stream, err := js.CreateOrUpdateStream(streamConfig)
 	if err != nil {
@@ -65,13 +112,14 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 
 	ws.log.Info("JetStream stream created/updated", "name", stream.CachedInfo().Config.Name)
 
-	// Create consumer configuration
+	// Create consumer configuration, the runs requiring labels are consumed by the workers advertising them
 	consumerConfig := service.ConsumerConfig{
 		Name:        "worker-flow-consumer",
-		StreamName:  "WORKER_FLOWS",
+		StreamName:  workerFlowsStream,
 		Subject:     string(service.FlowRunExecuteEventSubject),
 		Description: "Consumer for worker flow execution events",
-		AckWait:     30 * time.Second,
+		FilterBy:    string(service.FlowRunExecuteEventSubject),
+		AckWait:     flowRunAckWait,
 		MaxDeliver:  3,
 	}
 
@@ -81,29 +129,61 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 	}
 
 	ws.log.Info("JetStream consumer created/updated", "name", consumer.CachedInfo().Name)
+	ws.maxDeliver = consumer.CachedInfo().Config.MaxDeliver
 
-	// Start consuming messages
-	if err := js.ConsumeMessages("worker-flow-consumer", "WORKER_FLOWS", ws.handleFlowRunExecute); err != nil {
-		return nil, fmt.Errorf("failed to start consuming messages: %w", err)
+	if err := ws.createFlowLabelConsumers(jsConfig); err != nil {
+		return nil, err
 	}
 
-	ws.log.Info("Started consuming JetStream messages",
-		"subject", string(service.FlowRunExecuteEventSubject),
-		"stream", "WORKER_FLOWS",
-		"consumer", "worker-flow-consumer",
-	)
+	// Standalone tools marked as edge are called by the workers of their edge group
+	edgeTools := externalDependenciesConfig.GetWorkerEdgeToolsConfig()
+	if !edgeTools.Disabled {
+		if err := ws.createEdgeToolConsumers(edgeTools, jsConfig, replication, role); err != nil {
+			return nil, err
+		}
+	}
+
+	// Start consuming messages, a standby region waits until it is promoted so mirrored flow runs are not executed twice
+	if role == service.ReplicationRoleStandby {
+		ws.log.Warn("Standby region, flow execution starts after promotion", "region", replication.Region)
+		go func() {
+			if err := js.WaitForPromotion(replication); err != nil {
+				ws.log.Error("Failed to wait for region promotion", "error", err)
+				return
+			}
+			if err := ws.consume(edgeTools); err != nil {
+				ws.log.Error("Failed to start consuming messages after promotion", "error", err)
+			}
+		}()
+	} else if err := ws.consume(edgeTools); err != nil {
+		return nil, err
+	}
+
+	// Every worker receives the cancellations and pauses, the one running the flow process handles them
+	if _, err := s.GetNATS().Subscribe(service.FlowRunCancelEventSubject.String(), ws.handleFlowRunCancel); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to flow run cancellations: %w", err)
+	}
+	if _, err := s.GetNATS().Subscribe(service.FlowRunPauseEventSubject.String(), ws.handleFlowRunPause); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to flow run pauses: %w", err)
+	}
+
+	// Register the worker, the flows service reschedules the runs of the workers whose heartbeat stopped
+	heartbeat := externalDependenciesConfig.GetWorkerHeartbeatConfig()
+	ws.log.Info("Registering worker", "worker_id", ws.workerID, "name", heartbeat.Name, "labels", ws.labels)
+	go ws.runHeartbeat(heartbeat)
 
 	// Log cache configuration status
 	ws.logCacheConfiguration()
 
-	// Keep regular handlers for info and stats
-	s.RegisterHandler("v1.svc.worker._info", nil)
-	s.RegisterHandler("v1.svc.worker._stats", nil)
+	// Answer the info and stats requests addressed to the workers
+	s.RegisterHandler("v1.svc.worker._info", service.InfoHandler(s))
+	s.RegisterHandler("v1.svc.worker._stats", service.StatsHandler(s))
 
 	// Start a goroutine to wait for context cancellation and then shutdown
 	go func() {
 		<-ctx.Done()
 		ws.log.Warn("Worker service shutting down...")
+		ws.deregister()
 
 		if err := ws.s.Shutdown(); err != nil {
 			ws.log.Error("Error during worker service shutdown", "error", err)
@@ -114,6 +194,32 @@ func NewService(ctx context.Context, externalDependenciesConfig *service.Externa
 	return ws, nil
 }
 
+// consume starts consuming the flow execution events and the edge tool requests of the worker
+func (ws *WorkerService) consume(edgeTools *service.WorkerEdgeToolsConfig) error {
+	if err := ws.consumeFlowRunExecute(); err != nil {
+		return err
+	}
+	if edgeTools.Disabled {
+		return nil
+	}
+	return ws.consumeEdgeTools(edgeTools)
+}
+
+// consumeFlowRunExecute starts consuming flow execution events from the WORKER_FLOWS stream, the runs without required
+// labels and the runs requiring labels of the worker
+func (ws *WorkerService) consumeFlowRunExecute() error {
+	if err := ws.js.ConsumeMessages("worker-flow-consumer", workerFlowsStream, ws.handleFlowRunExecute); err != nil {
+		return fmt.Errorf("failed to start consuming messages: %w", err)
+	}
+
+	ws.log.Info("Started consuming JetStream messages",
+		"subject", string(service.FlowRunExecuteEventSubject),
+		"stream", workerFlowsStream,
+		"consumer", "worker-flow-consumer",
+	)
+	return ws.consumeFlowLabels()
+}
+
 // logCacheConfiguration logs the current cache configuration for flows
 func (ws *WorkerService) logCacheConfiguration() {
 	if ws.config == nil {
@@ -232,12 +338,44 @@ func (ws *WorkerService) handleFlowRunExecute(msg jetstream.Msg) error {
 		"code_location", req.Msg.CodeLocation,
 	)
 
+	// Skip flow runs that already succeeded, e.g. events replayed from a mirrored stream after a failover,
+	// the runs cancelled before a worker picked them up and the paused runs, executed again when resumed
+	if flowRun, err := db.New(ws.s.GetDB()).GetFlowRun(ws.ctx, req.Msg.FlowRunId); err == nil && (flowRun.Status == db.FlowStatusSuccess || flowRun.Status == db.FlowStatusCancelled || flowRun.Status == db.FlowStatusPaused) {
+		ws.log.Info("Flow run ended or paused, skipping execution", "flow_run_id", req.Msg.FlowRunId, "status", flowRun.Status)
+		if ackErr := msg.Ack(); ackErr != nil {
+			ws.log.Error("Failed to ACK message for ended flow run", "flow_run_id", req.Msg.FlowRunId, "error", ackErr)
+		}
+		return nil
+	}
+
+	// The runs beyond the slots of the worker wait in the queue, the ones received when the queue is full are redelivered
+	// later, possibly to another worker. The last delivery of an event is queued anyway, its run would be lost otherwise.
+	wait, ok := ws.pool.admit(ws.maxDeliver > 0 && deliveryCount >= uint64(ws.maxDeliver))
+	if !ok {
+		stats := ws.pool.stats()
+		delay := time.Duration(ws.config.GetWorkerConcurrencyConfig().RequeueDelaySeconds) * time.Second
+		ws.log.Warn("Flow run queue is full, requeuing flow run",
+			"flow_run_id", req.Msg.FlowRunId,
+			"running_flows", stats.Running,
+			"queued_flows", stats.Queued,
+			"requeued_flows", stats.Requeued,
+			"delay_seconds", delay.Seconds(),
+		)
+		if err := ws.js.Requeue(msg, delay); err != nil {
+			ws.log.Error("Failed to requeue flow run", "flow_run_id", req.Msg.FlowRunId, "error", err)
+		}
+		return nil
+	}
+
 	// Report PENDING status
 	ws.reportFlowRunStatus(req.Msg.FlowRunId, "PENDING")
 
 	// Start the flow process execution in a separate goroutine
 	// and handle the acknowledgment based on the result
 	go func() {
+		if wait != nil && !ws.waitForSlot(msg, wait, req.Msg.FlowRunId) {
+			return
+		}
 		processingStartTime := time.Now().UTC()
 
 		defer func() {
@@ -262,9 +400,44 @@ func (ws *WorkerService) handleFlowRunExecute(msg jetstream.Msg) error {
 			}
 		}()
 
-		// Execute the flow process
-		ws.executeFlowProcess(ws.ctx, req.Msg)
+		// Execute the flow process, the slot is released when the process exits
+		if !ws.executeFlowProcess(ws.ctx, req.Msg) {
+			ws.pool.release()
+		}
 	}()
 
 	return nil
 }
+
+// waitForSlot keeps the event of a queued flow run in progress until a slot is handed over to the run. It returns false
+// when the worker shuts down first, the event is left unacknowledged so another worker executes the run.
+func (ws *WorkerService) waitForSlot(msg jetstream.Msg, wait <-chan struct{}, flowRunID uuid.UUID) bool {
+	stats := ws.pool.stats()
+	ws.log.Info("Flow run queued, waiting for a free slot",
+		"flow_run_id", flowRunID,
+		"running_flows", stats.Running,
+		"queued_flows", stats.Queued,
+		"max_concurrent_flows", stats.MaxConcurrent,
+	)
+	queuedAt := time.Now()
+
+	ticker := time.NewTicker(flowRunAckWait / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-wait:
+			ws.log.Debug("Flow run left the queue", "flow_run_id", flowRunID, "queued_ms", time.Since(queuedAt).Milliseconds())
+			return true
+		case <-ticker.C:
+			if err := msg.InProgress(); err != nil {
+				ws.log.Warn("Failed to extend the ack deadline of a queued flow run", "flow_run_id", flowRunID, "error", err)
+			}
+		case <-ws.ctx.Done():
+			if !ws.pool.leave(wait) {
+				ws.pool.release()
+			}
+			ws.log.Info("Worker shutting down, queued flow run left for another worker", "flow_run_id", flowRunID)
+			return false
+		}
+	}
+}
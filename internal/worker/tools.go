@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/pinazu/internal/service"
+	"github.com/pinazu/internal/tools"
+)
+
+// edgeToolAckWait is the time a worker has to call an edge tool before the request is delivered to another worker,
+// the worker keeps the request in progress while the tool server answers
+const edgeToolAckWait = 30 * time.Second
+
+// createEdgeToolConsumers creates the stream of the edge tool requests and a consumer for each edge group of the worker
+func (ws *WorkerService) createEdgeToolConsumers(edgeTools *service.WorkerEdgeToolsConfig, jsConfig *service.JetStreamConfig, replication *service.ReplicationConfig, role service.ReplicationRole) error {
+	streamConfig := service.CreateStreamConfigWithDefaults(
+		service.WorkerToolsStream,
+		[]string{service.StandaloneToolRequestEventSubject.String() + ".>"},
+		"Stream for edge tool requests called by the workers",
+		jsConfig,
+	)
+	streamConfig = service.ApplyReplication(streamConfig, replication, role)
+
+	stream, err := ws.js.CreateOrUpdateStream(streamConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create edge tools stream: %w", err)
+	}
+	ws.log.Info("JetStream stream created/updated", "name", stream.CachedInfo().Config.Name)
+
+	for _, group := range edgeTools.Groups {
+		consumer, err := ws.js.CreateOrUpdateConsumer(service.ConsumerConfig{
+			Name:        service.EdgeToolConsumerName(group),
+			StreamName:  service.WorkerToolsStream,
+			Description: "Consumer for the edge tool requests of the " + group + " edge group",
+			FilterBy:    service.StandaloneToolEdgeSubject(group).String(),
+			AckWait:     edgeToolAckWait,
+			MaxDeliver:  3,
+		}, jsConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create consumer for edge group %s: %w", group, err)
+		}
+		ws.log.Info("JetStream consumer created/updated", "name", consumer.CachedInfo().Name)
+	}
+	return nil
+}
+
+// consumeEdgeTools starts consuming the edge tool requests of the edge groups of the worker
+func (ws *WorkerService) consumeEdgeTools(edgeTools *service.WorkerEdgeToolsConfig) error {
+	for _, group := range edgeTools.Groups {
+		if err := ws.js.ConsumeMessages(service.EdgeToolConsumerName(group), service.WorkerToolsStream, ws.handleEdgeToolRequest); err != nil {
+			return fmt.Errorf("failed to start consuming edge tool requests: %w", err)
+		}
+		ws.log.Info("Started consuming edge tool requests", "edge_group", group, "stream", service.WorkerToolsStream)
+	}
+	return nil
+}
+
+// handleEdgeToolRequest calls an edge tool from the network of the worker and publishes the result to the tool gather event
+func (ws *WorkerService) handleEdgeToolRequest(msg jetstream.Msg) error {
+	req, err := service.ParseEvent[*service.StandaloneToolRequestEventMessage](msg.Data())
+	if err != nil {
+		ws.log.Error("Failed to parse edge tool request", "error", err, "subject", msg.Subject())
+		// A malformed request fails the same way on every delivery
+		if termErr := msg.Term(); termErr != nil {
+			ws.log.Error("Failed to terminate edge tool request", "error", termErr)
+		}
+		return nil
+	}
+
+	ws.log.Info("Calling edge tool",
+		"tool_name", req.Msg.ToolName,
+		"tool_run_id", req.Msg.ToolRunId,
+		"edge_group", req.Msg.EdgeGroup,
+		"thread_id", req.H.ThreadID,
+	)
+	go func() {
+		// Acknowledged once the result is published, a request lost with its worker is called again by another worker
+		stop := ws.keepInProgress(msg)
+		content, isError := tools.RunStandaloneTool(ws.ctx, ws.secrets, ws.log, *req.Msg)
+		tools.PublishToolResult(ws.s.GetNATS(), ws.log, req.Msg.ToolRunId, content, isError, req.H, req.M)
+		stop()
+		if err := msg.Ack(); err != nil {
+			ws.log.Error("Failed to ACK edge tool request", "tool_run_id", req.Msg.ToolRunId, "error", err)
+		}
+	}()
+	return nil
+}
+
+// keepInProgress resets the ack wait of a message until the returned function is called, so a long tool call is not
+// delivered to another worker
+func (ws *WorkerService) keepInProgress(msg jetstream.Msg) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(edgeToolAckWait / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := msg.InProgress(); err != nil {
+					ws.log.Warn("Failed to extend edge tool request", "subject", msg.Subject(), "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -3,7 +3,7 @@
 import asyncio
 import time
 
-from pinazu import flow, task
+from pinazu import flow, task, publish_artifact
 
 
 @task
@@ -13,16 +13,18 @@ async def add_numbers(a: int, b: int) -> int:
     return a + b
 
 
-@task
+@task(depends_on=["add_numbers"], max_retries=2, retry_delay_seconds=1)
 async def multiply_numbers(a: int, b: int) -> int:
     """Multiply two numbers"""
     time.sleep(1)  # Simulate work
     return a * b
 
 
-@task
+@task(depends_on=["multiply_numbers"])
 async def format_result(result: int) -> str:
     """Format result as string"""
+    # Downloadable from the artifacts of the flow run
+    publish_artifact("result.json", {"result": result})
     return f"Final result: {result}"
 
 
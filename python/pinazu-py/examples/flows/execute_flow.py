@@ -50,8 +50,12 @@ try:
         name="basic_flow_example",
         engine="process",
         parameters_schema={
-            "x": {"type": "integer"},
-            "y": {"type": "integer"},
+            "type": "object",
+            "properties": {
+                "x": {"type": "integer"},
+                "y": {"type": "integer"},
+            },
+            "required": ["x", "y"],
         },
         code_location=flow_s3_location,
         entrypoint="python",
@@ -1,13 +1,16 @@
 """Flow and Task decorators library"""
 
-from .decorators import flow, task
-from .runtime import FlowRunner
+from .decorators import flow, task, publish_artifact, run_subflow
+from .runtime import FlowRunner, SubflowError
 from .api.base_client import Client, AsyncClient, PinazuAPIError
 
 __all__ = [
     "flow",
     "task",
+    "publish_artifact",
+    "run_subflow",
     "FlowRunner",
+    "SubflowError",
     "Client",
     "AsyncClient",
     "PinazuAPIError",
@@ -5,6 +5,7 @@ import json
 import codecs
 import asyncio
 import time
+from datetime import datetime
 
 from .models_generated import (
     Agent,
@@ -12,6 +13,7 @@ from .models_generated import (
     AgentList,
     AgentPermissionMapping,
     AgentPermissionMappingList,
+    BatchResult,
     AddPermissionToAgentRequest,
     UpdateAgentRequest,
     CreateFlowRequest,
@@ -20,12 +22,24 @@ from .models_generated import (
     UpdateFlowRequest,
     ExecuteFlowRequest,
     FlowRun,
+    FlowRunArtifactList,
+    FlowRunChildren,
+    FlowRunCost,
+    FlowRunList,
+    FlowRunTimeline,
+    CreateFlowScheduleBackfillRequest,
+    FlowScheduleBackfill,
+    FlowScheduleBackfillList,
     CreateTaskRequest,
     Task,
     TaskList,
     UpdateTaskRequest,
     ExecuteTaskRequest,
     TaskRun,
+    CreateTaskScheduleRequest,
+    TaskSchedule,
+    TaskScheduleList,
+    UpdateTaskScheduleRequest,
     CreateToolRequest,
     Tool,
     ToolList,
@@ -36,6 +50,7 @@ from .models_generated import (
     UpdateMessageRequest,
     CreateThreadRequest,
     Thread,
+    ThreadExport,
     ThreadList,
     UpdateThreadRequest,
     CreatePermissionRequest,
@@ -49,11 +64,54 @@ from .models_generated import (
     AddPermissionToRoleRequest,
     RolePermissionMapping,
     CreateUserRequest,
+    CreateServiceAccountRequest,
+    CreateApiKeyRequest,
+    CreatedApiKey,
+    ApiKey,
+    ApiKeyList,
+    Webhook,
+    WebhookList,
+    CreateWebhookRequest,
+    UpdateWebhookRequest,
+    CreatedWebhook,
+    WebhookDelivery,
+    WebhookDeliveryList,
+    File,
+    FileList,
+    CreateFileUploadRequest,
+    CreatedFile,
+    AttachmentList,
     User,
     UserList,
     UpdateUserRequest,
     AddRoleToUserRequest,
     UserRoleMapping,
+    CreateOrganizationRequest,
+    Organization,
+    OrganizationMember,
+    OrganizationMemberList,
+    SetOrganizationMemberRequest,
+    UserOrganizationList,
+    CreateWorkspaceRequest,
+    Workspace,
+    WorkspaceList,
+    WorkspaceMember,
+    WorkspaceMemberList,
+    SetWorkspaceMemberRequest,
+    ResourceGrant,
+    ResourceGrantList,
+    SetResourceGrantRequest,
+    UsageReport,
+    MessageSearchResults,
+    SemanticSearchResults,
+    EditMessageRequest,
+    RegenerateMessageRequest,
+    MessageRevision,
+    Feedback,
+    FeedbackList,
+    FeedbackRequest,
+    InvokeAgentRequest,
+    InvokeAgentResponse,
 )
 
 
@@ -97,6 +155,58 @@ def _handle_error_response(response: httpx.Response) -> None:
     )
 
 
+def _page_params(limit: int, cursor: Optional[str], **filters: Any) -> Dict[str, Any]:
+    """Query parameters of a page of a cursor paginated list, with its filters and sort.
+    The filters set to None are left out, the dates are sent in ISO 8601."""
+    params: Dict[str, Any] = {"limit": limit}
+    if cursor:
+        params["cursor"] = cursor
+    for name, value in filters.items():
+        if value is None:
+            continue
+        if isinstance(value, datetime):
+            value = value.isoformat()
+        elif isinstance(value, UUID):
+            value = str(value)
+        params[name] = value
+    return params
+
+
+def _idempotency_headers(idempotency_key: Optional[str]) -> Dict[str, str]:
+    """Headers of a request retried safely with an Idempotency-Key, the server replays
+    the response of the first request of the key to its retries."""
+    if not idempotency_key:
+        return {}
+    return {"Idempotency-Key": idempotency_key}
+
+
+def _if_match_headers(if_match: Optional[str]) -> Dict[str, str]:
+    """Headers of an update applied only to the version of the resource with the
+    ETag, the server rejects it with 412 when the resource changed since."""
+    if not if_match:
+        return {}
+    return {"If-Match": if_match}
+
+
+def _feedback_params(
+    rating: Optional[str],
+    thread_id: Optional[UUID],
+    user_id: Optional[UUID],
+    category: Optional[str],
+) -> Dict[str, Any]:
+    """Build the filters of the feedback list and export."""
+    params: Dict[str, Any] = {}
+    if rating:
+        params["rating"] = rating
+    if thread_id:
+        params["thread_id"] = str(thread_id)
+    if user_id:
+        params["user_id"] = str(user_id)
+    if category:
+        params["category"] = category
+    return params
+
+
 __all__ = [
     "Client",
     "AsyncClient",
@@ -110,6 +220,8 @@ class Client(httpx.Client):
         base_url: Optional[str] = "http://localhost:8080",
         headers: Optional[Dict[str, str]] = None,
         timeout: Optional[float] = None,
+        api_key: Optional[str] = None,
+        workspace_id: Optional[UUID] = None,
         max_connections: int = 20,
         max_keepalive_connections: int = 10,
         keepalive_expiry: float = 30.0,
@@ -138,6 +250,12 @@ class Client(httpx.Client):
             "Keep-Alive": "timeout=300, max=1000",
             "User-Agent": "pinazu-py/1.0",
         }
+        # API key of a service account, for the programmatic clients
+        if api_key:
+            default_headers["Authorization"] = f"Bearer {api_key}"
+        # Workspace the requests act in, the default workspace when not set
+        if workspace_id:
+            default_headers["X-Pinazu-Workspace"] = str(workspace_id)
         if headers:
             default_headers.update(headers)
 
@@ -182,8 +300,57 @@ class Client(httpx.Client):
         _handle_error_response(response)
         return Agent.model_validate(response.json())
 
-    def list_agents(self) -> AgentList:
-        response = self.get("/v1/agents")
+    def invoke_agent(
+        self, agent_id: UUID, messages: List[Dict[str, Any]]
+    ) -> InvokeAgentResponse:
+        """Invoke an agent once with messages and return its message, without a
+        thread or a task. The tools the agent asks for are not run."""
+        request = InvokeAgentRequest(messages=messages)
+        response = self.post(
+            url=f"/v1/agents/{agent_id}/invoke",
+            json=request.model_dump(mode="json"),
+            timeout=None,
+        )
+        _handle_error_response(response)
+        return InvokeAgentResponse.model_validate(response.json())
+
+    def stream_invoke_agent(
+        self, agent_id: UUID, messages: List[Dict[str, Any]]
+    ) -> Iterator[Dict[str, Any]]:
+        """Invoke an agent once with messages and stream the events of its model,
+        the last event of type agent_invoke_result holding its message."""
+        request = InvokeAgentRequest(messages=messages)
+        with self.stream(
+            method="POST",
+            url=f"/v1/agents/{agent_id}/invoke",
+            params={"stream": True},
+            json=request.model_dump(mode="json"),
+            headers={"Accept": "text/event-stream"},
+            timeout=None,
+        ) as response:
+            _handle_error_response(response)
+            buffer = ""
+            decoder = codecs.getincrementaldecoder("utf-8")(errors="ignore")
+
+            for chunk in response.iter_bytes(chunk_size=1024):
+                buffer += decoder.decode(chunk, False)
+                while "\n" in buffer:
+                    line, buffer = buffer.split("\n", 1)
+                    line = line.strip()
+                    if not line.startswith("data: "):
+                        continue
+                    try:
+                        data = json.loads(line[6:])
+                    except json.JSONDecodeError:
+                        # Skip malformed JSON lines
+                        continue
+                    yield data
+
+    def list_agents(
+        self, limit: int = 20, cursor: Optional[str] = None, deleted: bool = False
+    ) -> AgentList:
+        params = _page_params(limit, cursor, deleted=deleted or None)
+        response = self.get("/v1/agents", params=params)
         _handle_error_response(response)
         return AgentList.model_validate(response.json())
 
@@ -193,6 +360,7 @@ class Client(httpx.Client):
         name: Optional[str] = None,
         description: Optional[str] = None,
         specs: Optional[str] = None,
+        if_match: Optional[str] = None,
     ) -> Agent:
         request = UpdateAgentRequest(
             name=name,
@@ -202,6 +370,7 @@ class Client(httpx.Client):
         response = self.put(
             url=f"/v1/agents/{agent_id}",
             json=request.model_dump(mode="json"),
+            headers=_if_match_headers(if_match),
         )
         _handle_error_response(response)
         return Agent.model_validate(response.json())
@@ -210,6 +379,15 @@ class Client(httpx.Client):
         response = self.delete(f"/v1/agents/{agent_id}")
         _handle_error_response(response)
 
+    def restore_agent(self, agent_id: UUID) -> Agent:
+        response = self.post(f"/v1/agents/{agent_id}/restore")
+        _handle_error_response(response)
+        return Agent.model_validate(response.json())
+
+    def purge_agent(self, agent_id: UUID) -> None:
+        response = self.post(f"/v1/agents/{agent_id}/purge")
+        _handle_error_response(response)
+
     def add_permission_to_agent(
         self,
         agent_id: UUID,
@@ -256,6 +434,9 @@ class Client(httpx.Client):
         tags: Optional[str] = None,
         description: Optional[str] = None,
         additional_info: Optional[dict] = None,
+        required_labels: Optional[list[str]] = None,
+        max_concurrent_runs: Optional[int] = None,
+        concurrency_policy: Optional[str] = None,
     ) -> Flow:
         request = CreateFlowRequest(
             name=name,
@@ -266,6 +447,9 @@ class Client(httpx.Client):
             parameters_schema=parameters_schema,
             additional_info=additional_info,
             tags=tags,
+            required_labels=required_labels,
+            max_concurrent_runs=max_concurrent_runs,
+            concurrency_policy=concurrency_policy,
         )
         response = self.post(
             url="/v1/flows",
@@ -279,8 +463,12 @@ class Client(httpx.Client):
         _handle_error_response(response)
         return Flow.model_validate(response.json())
 
-    def list_flows(self, page: int = 1, per_page: int = 10) -> FlowList:
-        params = {"page": page, "per_page": per_page}
+    def list_flows(
+        self, page: int = 1, per_page: int = 10, deleted: bool = False
+    ) -> FlowList:
+        params: Dict[str, Any] = {"page": page, "per_page": per_page}
+        if deleted:
+            params["deleted"] = True
         response = self.get("/v1/flows", params=params)
         _handle_error_response(response)
         return FlowList.model_validate(response.json())
@@ -295,6 +483,9 @@ class Client(httpx.Client):
         entrypoint: Optional[str] = None,
         code_location: Optional[str] = None,
         additional_info: Optional[dict] = None,
+        required_labels: Optional[list[str]] = None,
+        max_concurrent_runs: Optional[int] = None,
+        concurrency_policy: Optional[str] = None,
     ) -> Flow:
         request = UpdateFlowRequest(
             name=name,
@@ -304,6 +495,9 @@ class Client(httpx.Client):
             code_location=code_location,
             additional_info=additional_info,
             tags=tags,
+            required_labels=required_labels,
+            max_concurrent_runs=max_concurrent_runs,
+            concurrency_policy=concurrency_policy,
         )
         response = self.put(
             url=f"/v1/flows/{flow_id}",
@@ -316,39 +510,154 @@ class Client(httpx.Client):
         response = self.delete(f"/v1/flows/{flow_id}")
         _handle_error_response(response)
 
+    def restore_flow(self, flow_id: UUID) -> Flow:
+        response = self.post(f"/v1/flows/{flow_id}/restore")
+        _handle_error_response(response)
+        return Flow.model_validate(response.json())
+
+    def purge_flow(self, flow_id: UUID) -> None:
+        response = self.post(f"/v1/flows/{flow_id}/purge")
+        _handle_error_response(response)
+
     def execute_flow(
         self,
         flow_id: UUID,
         parameters: dict,
+        idempotency_key: Optional[str] = None,
     ) -> FlowRun:
         request = ExecuteFlowRequest(parameters=parameters)
         response = self.post(
             url=f"/v1/flows/{flow_id}/execute",
             json=request.model_dump(mode="json"),
+            headers=_idempotency_headers(idempotency_key),
         )
         _handle_error_response(response)
         return FlowRun.model_validate(response.json())
 
+    def list_flow_runs(
+        self,
+        flow_id: UUID,
+        limit: int = 20,
+        cursor: Optional[str] = None,
+        **filters: Any,
+    ) -> FlowRunList:
+        response = self.get(
+            f"/v1/flows/{flow_id}/runs",
+            params=_page_params(limit, cursor, **filters),
+        )
+        _handle_error_response(response)
+        return FlowRunList.model_validate(response.json())
+
     def get_flow_run(self, flow_run_id: UUID) -> FlowRun:
         response = self.get(f"/v1/flows/{flow_run_id}/status")
         _handle_error_response(response)
         return FlowRun.model_validate(response.json())
 
+    def list_flow_run_artifacts(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunArtifactList:
+        response = self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/artifacts"
+        )
+        _handle_error_response(response)
+        return FlowRunArtifactList.model_validate(response.json())
+
+    def download_flow_run_artifact(
+        self, flow_id: UUID, flow_run_id: UUID, name: str
+    ) -> bytes:
+        response = self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/artifacts/{name}"
+        )
+        _handle_error_response(response)
+        return response.content
+
+    def list_child_flow_runs(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunChildren:
+        response = self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/children"
+        )
+        _handle_error_response(response)
+        return FlowRunChildren.model_validate(response.json())
+
+    def get_flow_run_timeline(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunTimeline:
+        response = self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/timeline"
+        )
+        _handle_error_response(response)
+        return FlowRunTimeline.model_validate(response.json())
+
+    def get_flow_run_cost(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunCost:
+        response = self.get(f"/v1/flows/{flow_id}/runs/{flow_run_id}/cost")
+        _handle_error_response(response)
+        return FlowRunCost.model_validate(response.json())
+
+    def retry_flow_run(self, flow_run_id: UUID) -> FlowRun:
+        response = self.post(f"/v1/flow-runs/{flow_run_id}/retry")
+        _handle_error_response(response)
+        return FlowRun.model_validate(response.json())
+
+    def create_flow_schedule_backfill(
+        self,
+        flow_id: UUID,
+        schedule_id: UUID,
+        start_time: datetime,
+        end_time: datetime,
+        max_concurrent_runs: Optional[int] = None,
+    ) -> FlowScheduleBackfill:
+        request = CreateFlowScheduleBackfillRequest(
+            start_time=start_time,
+            end_time=end_time,
+            max_concurrent_runs=max_concurrent_runs,
+        )
+        response = self.post(
+            url=f"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return FlowScheduleBackfill.model_validate(response.json())
+
+    def list_flow_schedule_backfills(
+        self, flow_id: UUID, schedule_id: UUID
+    ) -> FlowScheduleBackfillList:
+        response = self.get(
+            f"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills"
+        )
+        _handle_error_response(response)
+        return FlowScheduleBackfillList.model_validate(response.json())
+
+    def cancel_flow_schedule_backfill(
+        self, flow_id: UUID, schedule_id: UUID, backfill_id: UUID
+    ) -> FlowScheduleBackfill:
+        response = self.post(
+            f"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills/{backfill_id}/cancel"
+        )
+        _handle_error_response(response)
+        return FlowScheduleBackfill.model_validate(response.json())
+
     # Task methods
     def create_task(
         self,
         thread_id: UUID,
         additional_info: Optional[dict] = None,
         max_request_loop: Optional[str] = None,
+        flow_run_id: Optional[UUID] = None,
+        idempotency_key: Optional[str] = None,
     ) -> Task:
         request = CreateTaskRequest(
             thread_id=thread_id,
             additional_info=additional_info or {},
             max_request_loop=max_request_loop,
+            flow_run_id=flow_run_id,
         )
         response = self.post(
             url="/v1/tasks",
             json=request.model_dump(mode="json"),
+            headers=_idempotency_headers(idempotency_key),
         )
         _handle_error_response(response)
         task_data = response.json()
@@ -361,9 +670,12 @@ class Client(httpx.Client):
         _handle_error_response(response)
         return Task.model_validate(response.json())
 
-    def list_tasks(self, page: int = 1, per_page: int = 10) -> TaskList:
-        params = {"page": page, "per_page": per_page}
-        response = self.get("/v1/tasks", params=params)
+    def list_tasks(
+        self, limit: int = 20, cursor: Optional[str] = None, **filters: Any
+    ) -> TaskList:
+        response = self.get(
+            "/v1/tasks", params=_page_params(limit, cursor, **filters)
+        )
         _handle_error_response(response)
         return TaskList.model_validate(response.json())
 
@@ -394,13 +706,16 @@ class Client(httpx.Client):
         agent_id: UUID,
         current_loops: Optional[str] = None,
         stream: bool = False,
+        idempotency_key: Optional[str] = None,
+        queue: Optional[bool] = None,
     ) -> Union[TaskRun, Iterator[Dict[str, Any]]]:
         request = ExecuteTaskRequest(
             agent_id=agent_id,
             current_loops=current_loops,
+            queue=queue,
         )
 
-        headers = {}
+        headers = _idempotency_headers(idempotency_key)
         if stream:
             headers["Accept"] = "text/event-stream"
             return self._execute_task_stream(task_id, request, headers)
@@ -479,12 +794,90 @@ class Client(httpx.Client):
         _handle_error_response(response)
         return TaskRun.model_validate(response.json())
 
+    def cancel_task_run(self, task_id: UUID, run_id: UUID) -> TaskRun:
+        response = self.post(f"/v1/tasks/{task_id}/runs/{run_id}/cancel")
+        _handle_error_response(response)
+        return TaskRun.model_validate(response.json())
+
+    def list_task_schedules(self) -> TaskScheduleList:
+        response = self.get("/v1/tasks/schedules")
+        _handle_error_response(response)
+        return TaskScheduleList.model_validate(response.json())
+
+    def create_task_schedule(
+        self,
+        name: str,
+        agent_id: UUID,
+        prompt: str,
+        cron_expression: str,
+        thread_id: Optional[UUID] = None,
+        timezone: Optional[str] = None,
+        enabled: Optional[bool] = None,
+        overlap_policy: Optional[str] = None,
+    ) -> TaskSchedule:
+        """Invoke an agent with the prompt each time the cron expression fires, in a new thread when thread_id is not set."""
+        request = CreateTaskScheduleRequest(
+            name=name,
+            agent_id=agent_id,
+            prompt=prompt,
+            cron_expression=cron_expression,
+            thread_id=thread_id,
+            timezone=timezone,
+            enabled=enabled,
+            overlap_policy=overlap_policy,
+        )
+        response = self.post(
+            url="/v1/tasks/schedules",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return TaskSchedule.model_validate(response.json())
+
+    def get_task_schedule(self, schedule_id: UUID) -> TaskSchedule:
+        response = self.get(f"/v1/tasks/schedules/{schedule_id}")
+        _handle_error_response(response)
+        return TaskSchedule.model_validate(response.json())
+
+    def update_task_schedule(
+        self,
+        schedule_id: UUID,
+        name: Optional[str] = None,
+        agent_id: Optional[UUID] = None,
+        prompt: Optional[str] = None,
+        cron_expression: Optional[str] = None,
+        thread_id: Optional[UUID] = None,
+        timezone: Optional[str] = None,
+        enabled: Optional[bool] = None,
+        overlap_policy: Optional[str] = None,
+    ) -> TaskSchedule:
+        request = UpdateTaskScheduleRequest(
+            name=name,
+            agent_id=agent_id,
+            prompt=prompt,
+            cron_expression=cron_expression,
+            thread_id=thread_id,
+            timezone=timezone,
+            enabled=enabled,
+            overlap_policy=overlap_policy,
+        )
+        response = self.put(
+            url=f"/v1/tasks/schedules/{schedule_id}",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return TaskSchedule.model_validate(response.json())
+
+    def delete_task_schedule(self, schedule_id: UUID) -> None:
+        response = self.delete(f"/v1/tasks/schedules/{schedule_id}")
+        _handle_error_response(response)
+
     # Tool methods
     def create_tool(
         self,
         name: str,
         config: dict,
         description: Optional[str] = None,
+        idempotency_key: Optional[str] = None,
     ) -> Tool:
         request = CreateToolRequest(
             name=name,
@@ -494,17 +887,42 @@ class Client(httpx.Client):
         response = self.post(
             url="/v1/tools",
             json=request.model_dump(mode="json"),
+            headers=_idempotency_headers(idempotency_key),
         )
         _handle_error_response(response)
         return Tool.model_validate(response.json())
 
+    def batch_create_tools(self, tools: List[Dict[str, Any]]) -> BatchResult:
+        response = self.post(url="/v1/tools/batch-create", json={"tools": tools})
+        _handle_error_response(response)
+        return BatchResult.model_validate(response.json())
+
+    def batch_update_tools(self, updates: List[Dict[str, Any]]) -> BatchResult:
+        """Each update is a dict with the id of a tool and its changes"""
+        response = self.post(
+            url="/v1/tools/batch-update", json={"tools": updates}
+        )
+        _handle_error_response(response)
+        return BatchResult.model_validate(response.json())
+
+    def batch_delete_tools(self, tool_ids: List[UUID]) -> BatchResult:
+        response = self.post(
+            url="/v1/tools/batch-delete", json={"ids": [str(i) for i in tool_ids]}
+        )
+        _handle_error_response(response)
+        return BatchResult.model_validate(response.json())
+
     def get_tool(self, tool_id: UUID) -> Tool:
         response = self.get(f"/v1/tools/{tool_id}")
         _handle_error_response(response)
         return Tool.model_validate(response.json())
 
-    def list_tools(self) -> ToolList:
-        response = self.get("/v1/tools")
+    def list_tools(
+        self, limit: int = 20, cursor: Optional[str] = None, **filters: Any
+    ) -> ToolList:
+        response = self.get(
+            "/v1/tools", params=_page_params(limit, cursor, **filters)
+        )
         _handle_error_response(response)
         return ToolList.model_validate(response.json())
 
@@ -513,6 +931,7 @@ class Client(httpx.Client):
         tool_id: UUID,
         description: Optional[str] = None,
         config: Optional[dict] = None,
+        if_match: Optional[str] = None,
     ) -> Tool:
         request = UpdateToolRequest(
             description=description,
@@ -521,6 +940,7 @@ class Client(httpx.Client):
         response = self.put(
             url=f"/v1/tools/{tool_id}",
             json=request.model_dump(mode="json"),
+            headers=_if_match_headers(if_match),
         )
         _handle_error_response(response)
         return Tool.model_validate(response.json())
@@ -529,6 +949,15 @@ class Client(httpx.Client):
         response = self.delete(f"/v1/tools/{tool_id}")
         _handle_error_response(response)
 
+    def restore_tool(self, tool_id: UUID) -> Tool:
+        response = self.post(f"/v1/tools/{tool_id}/restore")
+        _handle_error_response(response)
+        return Tool.model_validate(response.json())
+
+    def purge_tool(self, tool_id: UUID) -> None:
+        response = self.post(f"/v1/tools/{tool_id}/purge")
+        _handle_error_response(response)
+
     # Message methods
     def create_message(
         self,
@@ -536,11 +965,14 @@ class Client(httpx.Client):
         message: dict,
         recipient_id: UUID,
         sender_id: UUID,
+        attachments: Optional[List[UUID]] = None,
     ) -> Message:
+        """Create a message, with the uploaded files attached to it."""
         request = CreateMessageRequest(
             message=message,
             recipient_id=recipient_id,
             sender_id=sender_id,
+            attachments=attachments,
         )
         response = self.post(
             url=f"/v1/threads/{thread_id}/messages",
@@ -549,13 +981,29 @@ class Client(httpx.Client):
         _handle_error_response(response)
         return Message.model_validate(response.json())
 
+    def import_messages(
+        self, thread_id: UUID, messages: List[Dict[str, Any]]
+    ) -> BatchResult:
+        response = self.post(
+            url=f"/v1/threads/{thread_id}/messages/batch",
+            json={"messages": messages},
+        )
+        _handle_error_response(response)
+        return BatchResult.model_validate(response.json())
+
     def get_message(self, thread_id: UUID, message_id: UUID) -> Message:
         response = self.get(f"/v1/threads/{thread_id}/messages/{message_id}")
         _handle_error_response(response)
         return Message.model_validate(response.json())
 
-    def list_messages(self, thread_id: UUID) -> MessageList:
-        response = self.get(f"/v1/threads/{thread_id}/messages")
+    def list_messages(
+        self, thread_id: UUID, include_history: bool = False
+    ) -> MessageList:
+        """List the live messages of a thread, with the superseded ones on request."""
+        params: Dict[str, Any] = {}
+        if include_history:
+            params["include_history"] = True
+        response = self.get(f"/v1/threads/{thread_id}/messages", params=params)
         _handle_error_response(response)
         return MessageList.model_validate(response.json())
 
@@ -573,11 +1021,77 @@ class Client(httpx.Client):
         _handle_error_response(response)
         return Message.model_validate(response.json())
 
-    def delete_message(self, thread_id: UUID, message_id: UUID) -> None:
+    def delete_message(
+        self, thread_id: UUID, message_id: UUID, keep_history: bool = False
+    ) -> None:
+        """Delete a message, or supersede it and the messages after it."""
+        params: Dict[str, Any] = {}
+        if keep_history:
+            params["keep_history"] = True
         response = self.delete(
             url=f"/v1/threads/{thread_id}/messages/{message_id}",
+            params=params,
+        )
+        _handle_error_response(response)
+
+    def edit_message(
+        self,
+        thread_id: UUID,
+        message_id: UUID,
+        message: dict,
+        agent_id: Optional[UUID] = None,
+    ) -> MessageRevision:
+        """Replace a user message, the agent answers the new version when given."""
+        request = EditMessageRequest(message=message, agent_id=agent_id)
+        response = self.post(
+            url=f"/v1/threads/{thread_id}/messages/{message_id}/edit",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return MessageRevision.model_validate(response.json())
+
+    def regenerate_message(
+        self, thread_id: UUID, message_id: UUID, agent_id: UUID
+    ) -> MessageRevision:
+        """Answer a message again, the previous answers are kept as history."""
+        request = RegenerateMessageRequest(agent_id=agent_id)
+        response = self.post(
+            url=f"/v1/threads/{thread_id}/messages/{message_id}/regenerate",
+            json=request.model_dump(mode="json"),
         )
         _handle_error_response(response)
+        return MessageRevision.model_validate(response.json())
+
+    def search_messages(
+        self,
+        query: str,
+        thread_id: Optional[UUID] = None,
+        page: int = 1,
+        per_page: int = 10,
+    ) -> MessageSearchResults:
+        """Search the messages of the threads visible to the user."""
+        params: Dict[str, Any] = {"q": query, "page": page, "per_page": per_page}
+        if thread_id:
+            params["thread_id"] = str(thread_id)
+        response = self.get("/v1/search/messages", params=params)
+        _handle_error_response(response)
+        return MessageSearchResults.model_validate(response.json())
+
+    def semantic_search_messages(
+        self,
+        query: str,
+        thread_id: Optional[UUID] = None,
+        limit: Optional[int] = None,
+    ) -> SemanticSearchResults:
+        """Search the messages of the threads visible to the user by meaning."""
+        params: Dict[str, Any] = {"q": query}
+        if thread_id:
+            params["thread_id"] = str(thread_id)
+        if limit is not None:
+            params["limit"] = limit
+        response = self.get("/v1/search/messages/semantic", params=params)
+        _handle_error_response(response)
+        return SemanticSearchResults.model_validate(response.json())
 
     # Thread methods
     def create_thread(
@@ -601,8 +1115,12 @@ class Client(httpx.Client):
         _handle_error_response(response)
         return Thread.model_validate(response.json())
 
-    def list_threads(self) -> ThreadList:
-        response = self.get("/v1/threads")
+    def list_threads(
+        self, limit: int = 20, cursor: Optional[str] = None, **filters: Any
+    ) -> ThreadList:
+        response = self.get(
+            "/v1/threads", params=_page_params(limit, cursor, **filters)
+        )
         _handle_error_response(response)
         return ThreadList.model_validate(response.json())
 
@@ -610,11 +1128,13 @@ class Client(httpx.Client):
         self,
         thread_id: UUID,
         title: str,
+        if_match: Optional[str] = None,
     ) -> Thread:
         request = UpdateThreadRequest(title=title)
         response = self.put(
             url=f"/v1/threads/{thread_id}",
             json=request.model_dump(mode="json"),
+            headers=_if_match_headers(if_match),
         )
         _handle_error_response(response)
         return Thread.model_validate(response.json())
@@ -623,6 +1143,91 @@ class Client(httpx.Client):
         response = self.delete(f"/v1/threads/{thread_id}")
         _handle_error_response(response)
 
+    def restore_thread(self, thread_id: UUID) -> Thread:
+        response = self.post(f"/v1/threads/{thread_id}/restore")
+        _handle_error_response(response)
+        return Thread.model_validate(response.json())
+
+    def purge_thread(self, thread_id: UUID) -> None:
+        response = self.post(f"/v1/threads/{thread_id}/purge")
+        _handle_error_response(response)
+
+    def fork_thread(
+        self,
+        thread_id: UUID,
+        from_message: Optional[UUID] = None,
+        title: Optional[str] = None,
+    ) -> Thread:
+        """Fork a thread with its live messages up to a message, the last by default."""
+        params: Dict[str, Any] = {}
+        if from_message:
+            params["from_message"] = str(from_message)
+        if title:
+            params["title"] = title
+        response = self.post(f"/v1/threads/{thread_id}/fork", params=params)
+        _handle_error_response(response)
+        return Thread.model_validate(response.json())
+
+    def export_thread(
+        self, thread_id: UUID, format: str = "json"
+    ) -> Union[ThreadExport, str]:
+        """Export a thread with its messages and tool runs. The json format is
+        imported with import_thread, the markdown format is a transcript."""
+        response = self.get(
+            f"/v1/threads/{thread_id}/export", params={"format": format}
+        )
+        _handle_error_response(response)
+        if format == "markdown":
+            return response.text
+        return ThreadExport.model_validate(response.json())
+
+    def import_thread(
+        self,
+        export: Union[ThreadExport, Dict[str, Any]],
+        title: Optional[str] = None,
+    ) -> Thread:
+        """Create a thread from an export, the tool runs are not imported."""
+        if isinstance(export, ThreadExport):
+            export = export.model_dump(mode="json", exclude_none=True)
+        params = {"title": title} if title else None
+        response = self.post("/v1/threads/import", json=export, params=params)
+        _handle_error_response(response)
+        return Thread.model_validate(response.json())
+
+    def stream_thread_events(
+        self, thread_id: UUID, events: Optional[str] = None
+    ) -> Iterator[Dict[str, Any]]:
+        """Follow the events of the tasks of a thread without starting a task.
+        Each event carries its header, naming its task. The stream is endless."""
+        params = {"events": events} if events else None
+        with self.stream(
+            method="GET",
+            url=f"/v1/threads/{thread_id}/events",
+            params=params,
+            headers={"Accept": "text/event-stream"},
+            timeout=60.0,  # Heartbeats are sent every 30 seconds
+        ) as response:
+            _handle_error_response(response)
+            buffer = ""
+            decoder = codecs.getincrementaldecoder("utf-8")(errors="ignore")
+
+            for chunk in response.iter_bytes(chunk_size=1024):
+                buffer += decoder.decode(chunk, False)
+                while "\n" in buffer:
+                    line, buffer = buffer.split("\n", 1)
+                    line = line.strip()
+                    if not line.startswith("data: "):
+                        continue
+                    try:
+                        data = json.loads(line[6:])
+                    except json.JSONDecodeError:
+                        # Skip malformed JSON lines
+                        continue
+                    if isinstance(data, dict) and data.get("type") == "heartbeat":
+                        self.record_heartbeat()
+                        continue
+                    yield data
+
     # Permission methods
     def create_permission(
         self,
@@ -857,105 +1462,604 @@ class Client(httpx.Client):
         response = self.delete(f"/v1/users/{user_id}/roles/{role_id}")
         _handle_error_response(response)
 
-    def record_heartbeat(self, connection_id: str = "default"):
-        """Record heartbeat for connection health monitoring."""
-        self._last_heartbeat[connection_id] = time.time()
-        self._connection_stats["heartbeats_received"] += 1
-
-
-class AsyncClient(httpx.AsyncClient):
-    def __init__(
-        self,
-        base_url: Optional[str] = "http://localhost:8080",
-        headers: Optional[Dict[str, str]] = None,
-        timeout: Optional[float] = None,
-        streaming_timeout: Optional[float] = None,
-        max_connections: int = 20,
-        max_keepalive_connections: int = 10,
-        keepalive_expiry: float = 30.0,
-        **kwargs: Any,
-    ):
-        # Configure connection limits for better streaming performance
-        limits = httpx.Limits(
-            max_connections=max_connections,
-            max_keepalive_connections=max_keepalive_connections,
-            keepalive_expiry=keepalive_expiry,
+    def create_service_account(
+        self, name: str, description: Optional[str] = None
+    ) -> User:
+        request = CreateServiceAccountRequest(name=name, description=description)
+        response = self.post(
+            url="/v1/service-accounts",
+            json=request.model_dump(mode="json"),
         )
+        _handle_error_response(response)
+        return User.model_validate(response.json())
 
-        # Configure transport with TCP keep-alive and retries
-        transport = httpx.AsyncHTTPTransport(
-            limits=limits,
-            retries=3,  # Automatic retries for failed connections
+    def create_api_key(
+        self,
+        user_id: UUID,
+        name: str,
+        scopes: List[str],
+        expires_at: Optional[datetime] = None,
+    ) -> CreatedApiKey:
+        """Create an API key for a user, the key is only returned here."""
+        request = CreateApiKeyRequest(name=name, scopes=scopes, expires_at=expires_at)
+        response = self.post(
+            url=f"/v1/users/{user_id}/api-keys",
+            json=request.model_dump(mode="json"),
         )
+        _handle_error_response(response)
+        return CreatedApiKey.model_validate(response.json())
 
-        # Set default timeout if not provided
-        if timeout is not None:
-            kwargs["timeout"] = timeout
-
-        # Store streaming timeout for SSE operations
-        self._streaming_timeout = streaming_timeout or 120.0
-
-        # Enhanced headers for better streaming
-        default_headers = {
-            "Connection": "keep-alive",
-            "Keep-Alive": "timeout=300, max=1000",
-            "User-Agent": "pinazu-py/1.0",
-        }
-        if headers:
-            default_headers.update(headers)
-
-        # Connection health monitoring
-        self._last_heartbeat = {}
-        self._connection_stats = {
-            "total_requests": 0,
-            "streaming_requests": 0,
-            "failed_connections": 0,
-            "heartbeats_received": 0,
-        }
+    def list_api_keys(self, user_id: UUID) -> ApiKeyList:
+        response = self.get(f"/v1/users/{user_id}/api-keys")
+        _handle_error_response(response)
+        return ApiKeyList.model_validate(response.json())
 
-        super().__init__(
-            base_url=base_url,
-            headers=default_headers,
-            transport=transport,
-            limits=limits,
-            **kwargs,
-        )
+    def revoke_api_key(self, user_id: UUID, key_id: UUID) -> ApiKey:
+        response = self.delete(f"/v1/users/{user_id}/api-keys/{key_id}")
+        _handle_error_response(response)
+        return ApiKey.model_validate(response.json())
 
-    # Agent methods
-    async def create_agent(
+    # Webhook methods
+    def create_webhook(
         self,
-        name: str,
+        url: str,
+        events: List[str],
         description: Optional[str] = None,
-        specs: Optional[str] = None,
-    ) -> Agent:
-        request = CreateAgentRequest(
-            name=name,
-            description=description,
-            specs=specs,
+        enabled: bool = True,
+    ) -> CreatedWebhook:
+        """Create a webhook of the current user, the secret is only returned here."""
+        request = CreateWebhookRequest(
+            url=url, events=events, description=description, enabled=enabled
         )
-        response = await self.post(
-            url="/v1/agents",
+        response = self.post(
+            url="/v1/webhooks",
             json=request.model_dump(mode="json"),
         )
         _handle_error_response(response)
-        return Agent.model_validate(response.json())
+        return CreatedWebhook.model_validate(response.json())
 
-    async def get_agent(self, agent_id: UUID) -> Agent:
-        response = await self.get(f"/v1/agents/{agent_id}")
+    def list_webhooks(self) -> WebhookList:
+        response = self.get("/v1/webhooks")
         _handle_error_response(response)
-        return Agent.model_validate(response.json())
+        return WebhookList.model_validate(response.json())
 
-    async def list_agents(self) -> AgentList:
-        response = await self.get("/v1/agents")
+    def get_webhook(self, webhook_id: UUID) -> Webhook:
+        response = self.get(f"/v1/webhooks/{webhook_id}")
         _handle_error_response(response)
-        return AgentList.model_validate(response.json())
+        return Webhook.model_validate(response.json())
 
-    async def update_agent(
+    def update_webhook(
         self,
-        agent_id: UUID,
-        name: Optional[str] = None,
+        webhook_id: UUID,
+        url: Optional[str] = None,
+        events: Optional[List[str]] = None,
         description: Optional[str] = None,
-        specs: Optional[str] = None,
+        enabled: Optional[bool] = None,
+    ) -> Webhook:
+        request = UpdateWebhookRequest(
+            url=url, events=events, description=description, enabled=enabled
+        )
+        response = self.put(
+            url=f"/v1/webhooks/{webhook_id}",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return Webhook.model_validate(response.json())
+
+    def delete_webhook(self, webhook_id: UUID) -> None:
+        response = self.delete(f"/v1/webhooks/{webhook_id}")
+        _handle_error_response(response)
+
+    def rotate_webhook_secret(self, webhook_id: UUID) -> CreatedWebhook:
+        """Replace the secret of a webhook, the new secret is only returned here."""
+        response = self.post(f"/v1/webhooks/{webhook_id}/rotate-secret")
+        _handle_error_response(response)
+        return CreatedWebhook.model_validate(response.json())
+
+    def list_webhook_deliveries(
+        self,
+        webhook_id: UUID,
+        status: Optional[str] = None,
+        limit: int = 20,
+        cursor: Optional[str] = None,
+    ) -> WebhookDeliveryList:
+        response = self.get(
+            f"/v1/webhooks/{webhook_id}/deliveries",
+            params=_page_params(limit, cursor, status=status),
+        )
+        _handle_error_response(response)
+        return WebhookDeliveryList.model_validate(response.json())
+
+    def redeliver_webhook_delivery(
+        self, webhook_id: UUID, delivery_id: UUID
+    ) -> WebhookDelivery:
+        response = self.post(
+            f"/v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver"
+        )
+        _handle_error_response(response)
+        return WebhookDelivery.model_validate(response.json())
+
+    def upload_file(
+        self,
+        content: bytes,
+        name: str,
+        content_type: Optional[str] = None,
+    ) -> File:
+        """Upload a file, its content type is detected when not given."""
+        file = (name, content, content_type) if content_type else (name, content)
+        response = self.post(url="/v1/files", files={"file": file})
+        _handle_error_response(response)
+        return File.model_validate(response.json()["file"])
+
+    def create_file_upload(
+        self, name: str, content_type: str, size_bytes: int
+    ) -> CreatedFile:
+        """Create a file uploaded to the returned presigned URL.
+
+        The content is uploaded with a PUT request carrying the same
+        Content-Type, then the upload is completed with complete_file_upload.
+        """
+        request = CreateFileUploadRequest(
+            name=name, content_type=content_type, size_bytes=size_bytes
+        )
+        response = self.post(
+            url="/v1/files", json=request.model_dump(mode="json")
+        )
+        _handle_error_response(response)
+        return CreatedFile.model_validate(response.json())
+
+    def complete_file_upload(self, file_id: UUID) -> File:
+        response = self.post(f"/v1/files/{file_id}/complete")
+        _handle_error_response(response)
+        return File.model_validate(response.json())
+
+    def list_files(
+        self, limit: int = 20, cursor: Optional[str] = None
+    ) -> FileList:
+        response = self.get("/v1/files", params=_page_params(limit, cursor))
+        _handle_error_response(response)
+        return FileList.model_validate(response.json())
+
+    def get_file(self, file_id: UUID) -> File:
+        response = self.get(f"/v1/files/{file_id}")
+        _handle_error_response(response)
+        return File.model_validate(response.json())
+
+    def download_file(self, file_id: UUID) -> bytes:
+        response = self.get(f"/v1/files/{file_id}/content")
+        _handle_error_response(response)
+        return response.content
+
+    def delete_file(self, file_id: UUID) -> None:
+        response = self.delete(f"/v1/files/{file_id}")
+        _handle_error_response(response)
+
+    def list_message_attachments(
+        self, thread_id: UUID, message_id: UUID
+    ) -> AttachmentList:
+        response = self.get(
+            f"/v1/threads/{thread_id}/messages/{message_id}/attachments"
+        )
+        _handle_error_response(response)
+        return AttachmentList.model_validate(response.json())
+
+    # Organization and workspace methods
+    def list_organizations(self) -> UserOrganizationList:
+        response = self.get("/v1/organizations")
+        _handle_error_response(response)
+        return UserOrganizationList.model_validate(response.json())
+
+    def create_organization(self, name: str) -> Organization:
+        """Create an organization owned by the current user."""
+        request = CreateOrganizationRequest(name=name)
+        response = self.post(
+            url="/v1/organizations",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return Organization.model_validate(response.json())
+
+    def list_organization_members(
+        self, organization_id: UUID
+    ) -> OrganizationMemberList:
+        response = self.get(f"/v1/organizations/{organization_id}/members")
+        _handle_error_response(response)
+        return OrganizationMemberList.model_validate(response.json())
+
+    def set_organization_member(
+        self, organization_id: UUID, user_id: UUID, role: str = "MEMBER"
+    ) -> OrganizationMember:
+        """Add a user to an organization or change the role of a member."""
+        request = SetOrganizationMemberRequest(role=role)
+        response = self.put(
+            url=f"/v1/organizations/{organization_id}/members/{user_id}",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return OrganizationMember.model_validate(response.json())
+
+    def remove_organization_member(
+        self, organization_id: UUID, user_id: UUID
+    ) -> None:
+        """Remove a user from an organization and from its workspaces."""
+        response = self.delete(
+            f"/v1/organizations/{organization_id}/members/{user_id}"
+        )
+        _handle_error_response(response)
+
+    def list_workspaces(self, organization_id: UUID) -> WorkspaceList:
+        response = self.get(
+            f"/v1/organizations/{organization_id}/workspaces"
+        )
+        _handle_error_response(response)
+        return WorkspaceList.model_validate(response.json())
+
+    def create_workspace(self, organization_id: UUID, name: str) -> Workspace:
+        """Create a workspace, the current user is its first admin."""
+        request = CreateWorkspaceRequest(name=name)
+        response = self.post(
+            url=f"/v1/organizations/{organization_id}/workspaces",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return Workspace.model_validate(response.json())
+
+    def list_workspace_members(self, workspace_id: UUID) -> WorkspaceMemberList:
+        response = self.get(f"/v1/workspaces/{workspace_id}/members")
+        _handle_error_response(response)
+        return WorkspaceMemberList.model_validate(response.json())
+
+    def set_workspace_member(
+        self, workspace_id: UUID, user_id: UUID, role: str = "MEMBER"
+    ) -> WorkspaceMember:
+        """Add a member of the organization to a workspace or change its role."""
+        request = SetWorkspaceMemberRequest(role=role)
+        response = self.put(
+            url=f"/v1/workspaces/{workspace_id}/members/{user_id}",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return WorkspaceMember.model_validate(response.json())
+
+    def remove_workspace_member(
+        self, workspace_id: UUID, user_id: UUID
+    ) -> None:
+        response = self.delete(
+            f"/v1/workspaces/{workspace_id}/members/{user_id}"
+        )
+        _handle_error_response(response)
+
+    # Resource grant methods
+    def list_agent_grants(self, agent_id: UUID) -> ResourceGrantList:
+        response = self.get(f"/v1/agents/{agent_id}/grants")
+        _handle_error_response(response)
+        return ResourceGrantList.model_validate(response.json())
+
+    def set_agent_grant(
+        self,
+        agent_id: UUID,
+        grantee_id: UUID,
+        access: str = "READ",
+        grantee_type: str = "USER",
+    ) -> ResourceGrant:
+        """Share an agent with a user or a role."""
+        request = SetResourceGrantRequest(
+            grantee_type=grantee_type, grantee_id=grantee_id, access=access
+        )
+        response = self.put(
+            url=f"/v1/agents/{agent_id}/grants",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return ResourceGrant.model_validate(response.json())
+
+    def remove_agent_grant(
+        self, agent_id: UUID, grantee_id: UUID, grantee_type: str = "USER"
+    ) -> None:
+        response = self.delete(
+            f"/v1/agents/{agent_id}/grants/{grantee_type}/{grantee_id}"
+        )
+        _handle_error_response(response)
+
+    def list_tool_grants(self, tool_id: UUID) -> ResourceGrantList:
+        response = self.get(f"/v1/tools/{tool_id}/grants")
+        _handle_error_response(response)
+        return ResourceGrantList.model_validate(response.json())
+
+    def set_tool_grant(
+        self,
+        tool_id: UUID,
+        grantee_id: UUID,
+        access: str = "READ",
+        grantee_type: str = "USER",
+    ) -> ResourceGrant:
+        """Share a tool with a user or a role."""
+        request = SetResourceGrantRequest(
+            grantee_type=grantee_type, grantee_id=grantee_id, access=access
+        )
+        response = self.put(
+            url=f"/v1/tools/{tool_id}/grants",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return ResourceGrant.model_validate(response.json())
+
+    def remove_tool_grant(
+        self, tool_id: UUID, grantee_id: UUID, grantee_type: str = "USER"
+    ) -> None:
+        response = self.delete(
+            f"/v1/tools/{tool_id}/grants/{grantee_type}/{grantee_id}"
+        )
+        _handle_error_response(response)
+
+    def list_thread_grants(self, thread_id: UUID) -> ResourceGrantList:
+        response = self.get(f"/v1/threads/{thread_id}/grants")
+        _handle_error_response(response)
+        return ResourceGrantList.model_validate(response.json())
+
+    def set_thread_grant(
+        self,
+        thread_id: UUID,
+        grantee_id: UUID,
+        access: str = "READ",
+        grantee_type: str = "USER",
+    ) -> ResourceGrant:
+        """Share a thread with a user or a role."""
+        request = SetResourceGrantRequest(
+            grantee_type=grantee_type, grantee_id=grantee_id, access=access
+        )
+        response = self.put(
+            url=f"/v1/threads/{thread_id}/grants",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return ResourceGrant.model_validate(response.json())
+
+    def remove_thread_grant(
+        self, thread_id: UUID, grantee_id: UUID, grantee_type: str = "USER"
+    ) -> None:
+        response = self.delete(
+            f"/v1/threads/{thread_id}/grants/{grantee_type}/{grantee_id}"
+        )
+        _handle_error_response(response)
+
+    # Usage methods
+    def get_usage(self) -> UsageReport:
+        """Get the usage of the quotas of the user and of its workspace."""
+        response = self.get("/v1/usage")
+        _handle_error_response(response)
+        return UsageReport.model_validate(response.json())
+
+    def set_message_feedback(
+        self,
+        thread_id: UUID,
+        message_id: UUID,
+        rating: str,
+        categories: Optional[List[str]] = None,
+        comment: Optional[str] = None,
+    ) -> Feedback:
+        """Rate a message of an agent, replacing the previous feedback of the user on it."""
+        request = FeedbackRequest(rating=rating, categories=categories, comment=comment)
+        response = self.put(
+            url=f"/v1/threads/{thread_id}/messages/{message_id}/feedback",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return Feedback.model_validate(response.json())
+
+    def delete_message_feedback(self, thread_id: UUID, message_id: UUID) -> None:
+        """Delete the feedback of the user on a message."""
+        response = self.delete(f"/v1/threads/{thread_id}/messages/{message_id}/feedback")
+        _handle_error_response(response)
+
+    def set_tool_run_feedback(
+        self,
+        thread_id: UUID,
+        tool_run_id: str,
+        rating: str,
+        categories: Optional[List[str]] = None,
+        comment: Optional[str] = None,
+    ) -> Feedback:
+        """Rate a tool run of a thread, replacing the previous feedback of the user on it."""
+        request = FeedbackRequest(rating=rating, categories=categories, comment=comment)
+        response = self.put(
+            url=f"/v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return Feedback.model_validate(response.json())
+
+    def delete_tool_run_feedback(self, thread_id: UUID, tool_run_id: str) -> None:
+        """Delete the feedback of the user on a tool run."""
+        response = self.delete(f"/v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback")
+        _handle_error_response(response)
+
+    def list_feedback(
+        self,
+        rating: Optional[str] = None,
+        thread_id: Optional[UUID] = None,
+        user_id: Optional[UUID] = None,
+        category: Optional[str] = None,
+        page: int = 1,
+        per_page: int = 10,
+    ) -> FeedbackList:
+        """List the feedback of the workspace, all of it for the workspace admins."""
+        params = _feedback_params(rating, thread_id, user_id, category)
+        params.update({"page": page, "per_page": per_page})
+        response = self.get("/v1/feedback", params=params)
+        _handle_error_response(response)
+        return FeedbackList.model_validate(response.json())
+
+    def export_feedback(
+        self,
+        rating: Optional[str] = None,
+        thread_id: Optional[UUID] = None,
+        user_id: Optional[UUID] = None,
+        category: Optional[str] = None,
+    ) -> List[Dict[str, Any]]:
+        """Export the feedback of the workspace as a dataset, one example per feedback."""
+        params = _feedback_params(rating, thread_id, user_id, category)
+        response = self.get("/v1/feedback/export", params=params)
+        _handle_error_response(response)
+        return [json.loads(line) for line in response.text.splitlines() if line]
+
+    def record_heartbeat(self, connection_id: str = "default"):
+        """Record heartbeat for connection health monitoring."""
+        self._last_heartbeat[connection_id] = time.time()
+        self._connection_stats["heartbeats_received"] += 1
+
+
+class AsyncClient(httpx.AsyncClient):
+    def __init__(
+        self,
+        base_url: Optional[str] = "http://localhost:8080",
+        headers: Optional[Dict[str, str]] = None,
+        timeout: Optional[float] = None,
+        api_key: Optional[str] = None,
+        workspace_id: Optional[UUID] = None,
+        streaming_timeout: Optional[float] = None,
+        max_connections: int = 20,
+        max_keepalive_connections: int = 10,
+        keepalive_expiry: float = 30.0,
+        **kwargs: Any,
+    ):
+        # Configure connection limits for better streaming performance
+        limits = httpx.Limits(
+            max_connections=max_connections,
+            max_keepalive_connections=max_keepalive_connections,
+            keepalive_expiry=keepalive_expiry,
+        )
+
+        # Configure transport with TCP keep-alive and retries
+        transport = httpx.AsyncHTTPTransport(
+            limits=limits,
+            retries=3,  # Automatic retries for failed connections
+        )
+
+        # Set default timeout if not provided
+        if timeout is not None:
+            kwargs["timeout"] = timeout
+
+        # Store streaming timeout for SSE operations
+        self._streaming_timeout = streaming_timeout or 120.0
+
+        # Enhanced headers for better streaming
+        default_headers = {
+            "Connection": "keep-alive",
+            "Keep-Alive": "timeout=300, max=1000",
+            "User-Agent": "pinazu-py/1.0",
+        }
+        # API key of a service account, for the programmatic clients
+        if api_key:
+            default_headers["Authorization"] = f"Bearer {api_key}"
+        # Workspace the requests act in, the default workspace when not set
+        if workspace_id:
+            default_headers["X-Pinazu-Workspace"] = str(workspace_id)
+        if headers:
+            default_headers.update(headers)
+
+        # Connection health monitoring
+        self._last_heartbeat = {}
+        self._connection_stats = {
+            "total_requests": 0,
+            "streaming_requests": 0,
+            "failed_connections": 0,
+            "heartbeats_received": 0,
+        }
+
+        super().__init__(
+            base_url=base_url,
+            headers=default_headers,
+            transport=transport,
+            limits=limits,
+            **kwargs,
+        )
+
+    # Agent methods
+    async def create_agent(
+        self,
+        name: str,
+        description: Optional[str] = None,
+        specs: Optional[str] = None,
+    ) -> Agent:
+        request = CreateAgentRequest(
+            name=name,
+            description=description,
+            specs=specs,
+        )
+        response = await self.post(
+            url="/v1/agents",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return Agent.model_validate(response.json())
+
+    async def get_agent(self, agent_id: UUID) -> Agent:
+        response = await self.get(f"/v1/agents/{agent_id}")
+        _handle_error_response(response)
+        return Agent.model_validate(response.json())
+
+    async def invoke_agent(
+        self, agent_id: UUID, messages: List[Dict[str, Any]]
+    ) -> InvokeAgentResponse:
+        """Invoke an agent once with messages and return its message, without a
+        thread or a task. The tools the agent asks for are not run."""
+        request = InvokeAgentRequest(messages=messages)
+        response = await self.post(
+            url=f"/v1/agents/{agent_id}/invoke",
+            json=request.model_dump(mode="json"),
+            timeout=None,
+        )
+        _handle_error_response(response)
+        return InvokeAgentResponse.model_validate(response.json())
+
+    async def stream_invoke_agent(
+        self, agent_id: UUID, messages: List[Dict[str, Any]]
+    ) -> AsyncIterator[Dict[str, Any]]:
+        """Invoke an agent once with messages and stream the events of its model,
+        the last event of type agent_invoke_result holding its message."""
+        request = InvokeAgentRequest(messages=messages)
+        async with self.stream(
+            method="POST",
+            url=f"/v1/agents/{agent_id}/invoke",
+            params={"stream": True},
+            json=request.model_dump(mode="json"),
+            headers={"Accept": "text/event-stream"},
+            timeout=None,
+        ) as response:
+            _handle_error_response(response)
+            buffer = ""
+            decoder = codecs.getincrementaldecoder("utf-8")(errors="ignore")
+
+            async for chunk in response.aiter_bytes(chunk_size=1024):
+                buffer += decoder.decode(chunk, False)
+                while "\n" in buffer:
+                    line, buffer = buffer.split("\n", 1)
+                    line = line.strip()
+                    if not line.startswith("data: "):
+                        continue
+                    try:
+                        data = json.loads(line[6:])
+                    except json.JSONDecodeError:
+                        # Skip malformed JSON lines
+                        continue
+                    yield data
+
+    async def list_agents(
+        self, limit: int = 20, cursor: Optional[str] = None, deleted: bool = False
+    ) -> AgentList:
+        params = _page_params(limit, cursor, deleted=deleted or None)
+        response = await self.get("/v1/agents", params=params)
+        _handle_error_response(response)
+        return AgentList.model_validate(response.json())
+
+    async def update_agent(
+        self,
+        agent_id: UUID,
+        name: Optional[str] = None,
+        description: Optional[str] = None,
+        specs: Optional[str] = None,
+        if_match: Optional[str] = None,
     ) -> Agent:
         request = UpdateAgentRequest(
             name=name,
@@ -965,6 +2069,7 @@ class AsyncClient(httpx.AsyncClient):
         response = await self.put(
             url=f"/v1/agents/{agent_id}",
             json=request.model_dump(mode="json"),
+            headers=_if_match_headers(if_match),
         )
         _handle_error_response(response)
         return Agent.model_validate(response.json())
@@ -973,6 +2078,15 @@ class AsyncClient(httpx.AsyncClient):
         response = await self.delete(f"/v1/agents/{agent_id}")
         _handle_error_response(response)
 
+    async def restore_agent(self, agent_id: UUID) -> Agent:
+        response = await self.post(f"/v1/agents/{agent_id}/restore")
+        _handle_error_response(response)
+        return Agent.model_validate(response.json())
+
+    async def purge_agent(self, agent_id: UUID) -> None:
+        response = await self.post(f"/v1/agents/{agent_id}/purge")
+        _handle_error_response(response)
+
     async def add_permission_to_agent(
         self,
         agent_id: UUID,
@@ -1019,6 +2133,9 @@ class AsyncClient(httpx.AsyncClient):
         description: Optional[str] = None,
         additional_info: Optional[dict] = None,
         tags: Optional[str] = None,
+        required_labels: Optional[list[str]] = None,
+        max_concurrent_runs: Optional[int] = None,
+        concurrency_policy: Optional[str] = None,
     ) -> Flow:
         request = CreateFlowRequest(
             name=name,
@@ -1029,6 +2146,9 @@ class AsyncClient(httpx.AsyncClient):
             parameters_schema=parameters_schema,
             additional_info=additional_info,
             tags=tags,
+            required_labels=required_labels,
+            max_concurrent_runs=max_concurrent_runs,
+            concurrency_policy=concurrency_policy,
         )
         response = await self.post(
             url="/v1/flows",
@@ -1042,8 +2162,12 @@ class AsyncClient(httpx.AsyncClient):
         _handle_error_response(response)
         return Flow.model_validate(response.json())
 
-    async def list_flows(self, page: int = 1, per_page: int = 10) -> FlowList:
-        params = {"page": page, "per_page": per_page}
+    async def list_flows(
+        self, page: int = 1, per_page: int = 10, deleted: bool = False
+    ) -> FlowList:
+        params: Dict[str, Any] = {"page": page, "per_page": per_page}
+        if deleted:
+            params["deleted"] = True
         response = await self.get("/v1/flows", params=params)
         _handle_error_response(response)
         return FlowList.model_validate(response.json())
@@ -1058,6 +2182,9 @@ class AsyncClient(httpx.AsyncClient):
         code_location: Optional[str] = None,
         additional_info: Optional[dict] = None,
         tags: Optional[str] = None,
+        required_labels: Optional[list[str]] = None,
+        max_concurrent_runs: Optional[int] = None,
+        concurrency_policy: Optional[str] = None,
     ) -> Flow:
         request = UpdateFlowRequest(
             name=name,
@@ -1067,6 +2194,9 @@ class AsyncClient(httpx.AsyncClient):
             code_location=code_location,
             additional_info=additional_info,
             tags=tags,
+            required_labels=required_labels,
+            max_concurrent_runs=max_concurrent_runs,
+            concurrency_policy=concurrency_policy,
         )
         response = await self.put(
             url=f"/v1/flows/{flow_id}",
@@ -1079,30 +2209,143 @@ class AsyncClient(httpx.AsyncClient):
         response = await self.delete(f"/v1/flows/{flow_id}")
         _handle_error_response(response)
 
+    async def restore_flow(self, flow_id: UUID) -> Flow:
+        response = await self.post(f"/v1/flows/{flow_id}/restore")
+        _handle_error_response(response)
+        return Flow.model_validate(response.json())
+
+    async def purge_flow(self, flow_id: UUID) -> None:
+        response = await self.post(f"/v1/flows/{flow_id}/purge")
+        _handle_error_response(response)
+
     async def execute_flow(
         self,
         flow_id: UUID,
         parameters: dict,
+        idempotency_key: Optional[str] = None,
     ) -> FlowRun:
         request = ExecuteFlowRequest(parameters=parameters)
         response = await self.post(
             url=f"/v1/flows/{flow_id}/execute",
             json=request.model_dump(mode="json"),
+            headers=_idempotency_headers(idempotency_key),
         )
         _handle_error_response(response)
         return FlowRun.model_validate(response.json())
 
+    async def list_flow_runs(
+        self,
+        flow_id: UUID,
+        limit: int = 20,
+        cursor: Optional[str] = None,
+        **filters: Any,
+    ) -> FlowRunList:
+        response = await self.get(
+            f"/v1/flows/{flow_id}/runs",
+            params=_page_params(limit, cursor, **filters),
+        )
+        _handle_error_response(response)
+        return FlowRunList.model_validate(response.json())
+
     async def get_flow_run(self, flow_run_id: UUID) -> FlowRun:
         response = await self.get(f"/v1/flows/{flow_run_id}/status")
         _handle_error_response(response)
         return FlowRun.model_validate(response.json())
 
+    async def list_flow_run_artifacts(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunArtifactList:
+        response = await self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/artifacts"
+        )
+        _handle_error_response(response)
+        return FlowRunArtifactList.model_validate(response.json())
+
+    async def download_flow_run_artifact(
+        self, flow_id: UUID, flow_run_id: UUID, name: str
+    ) -> bytes:
+        response = await self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/artifacts/{name}"
+        )
+        _handle_error_response(response)
+        return response.content
+
+    async def list_child_flow_runs(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunChildren:
+        response = await self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/children"
+        )
+        _handle_error_response(response)
+        return FlowRunChildren.model_validate(response.json())
+
+    async def get_flow_run_timeline(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunTimeline:
+        response = await self.get(
+            f"/v1/flows/{flow_id}/runs/{flow_run_id}/timeline"
+        )
+        _handle_error_response(response)
+        return FlowRunTimeline.model_validate(response.json())
+
+    async def get_flow_run_cost(
+        self, flow_id: UUID, flow_run_id: UUID
+    ) -> FlowRunCost:
+        response = await self.get(f"/v1/flows/{flow_id}/runs/{flow_run_id}/cost")
+        _handle_error_response(response)
+        return FlowRunCost.model_validate(response.json())
+
+    async def retry_flow_run(self, flow_run_id: UUID) -> FlowRun:
+        response = await self.post(f"/v1/flow-runs/{flow_run_id}/retry")
+        _handle_error_response(response)
+        return FlowRun.model_validate(response.json())
+
+    async def create_flow_schedule_backfill(
+        self,
+        flow_id: UUID,
+        schedule_id: UUID,
+        start_time: datetime,
+        end_time: datetime,
+        max_concurrent_runs: Optional[int] = None,
+    ) -> FlowScheduleBackfill:
+        request = CreateFlowScheduleBackfillRequest(
+            start_time=start_time,
+            end_time=end_time,
+            max_concurrent_runs=max_concurrent_runs,
+        )
+        response = await self.post(
+            url=f"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return FlowScheduleBackfill.model_validate(response.json())
+
+    async def list_flow_schedule_backfills(
+        self, flow_id: UUID, schedule_id: UUID
+    ) -> FlowScheduleBackfillList:
+        response = await self.get(
+            f"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills"
+        )
+        _handle_error_response(response)
+        return FlowScheduleBackfillList.model_validate(response.json())
+
+    async def cancel_flow_schedule_backfill(
+        self, flow_id: UUID, schedule_id: UUID, backfill_id: UUID
+    ) -> FlowScheduleBackfill:
+        response = await self.post(
+            f"/v1/flows/{flow_id}/schedules/{schedule_id}/backfills/{backfill_id}/cancel"
+        )
+        _handle_error_response(response)
+        return FlowScheduleBackfill.model_validate(response.json())
+
     # Task methods
     async def create_task(
         self,
         thread_id: UUID,
         additional_info: Optional[dict] = None,
         max_request_loop: Optional[int] = None,
+        flow_run_id: Optional[UUID] = None,
+        idempotency_key: Optional[str] = None,
     ) -> Task:
         request = CreateTaskRequest(
             thread_id=thread_id,
@@ -1110,10 +2353,12 @@ class AsyncClient(httpx.AsyncClient):
             max_request_loop=(
                 f"{max_request_loop}" if max_request_loop is not None else None
             ),
+            flow_run_id=flow_run_id,
         )
         response = await self.post(
             url="/v1/tasks",
             json=request.model_dump(mode="json"),
+            headers=_idempotency_headers(idempotency_key),
         )
         _handle_error_response(response)
         task_data = response.json()
@@ -1126,9 +2371,12 @@ class AsyncClient(httpx.AsyncClient):
         _handle_error_response(response)
         return Task.model_validate(response.json())
 
-    async def list_tasks(self, page: int = 1, per_page: int = 10) -> TaskList:
-        params = {"page": page, "per_page": per_page}
-        response = await self.get("/v1/tasks", params=params)
+    async def list_tasks(
+        self, limit: int = 20, cursor: Optional[str] = None, **filters: Any
+    ) -> TaskList:
+        response = await self.get(
+            "/v1/tasks", params=_page_params(limit, cursor, **filters)
+        )
         _handle_error_response(response)
         return TaskList.model_validate(response.json())
 
@@ -1160,13 +2408,16 @@ class AsyncClient(httpx.AsyncClient):
         current_loops: Optional[str] = None,
         stream: bool = False,
         streaming_timeout: Optional[float] = None,
+        idempotency_key: Optional[str] = None,
+        queue: Optional[bool] = None,
     ) -> Union[TaskRun, AsyncIterator[Dict[str, Any]]]:
         request = ExecuteTaskRequest(
             agent_id=agent_id,
             current_loops=current_loops,
+            queue=queue,
         )
 
-        headers = {}
+        headers = _idempotency_headers(idempotency_key)
         if stream:
             headers["Accept"] = "text/event-stream"
 
@@ -1251,6 +2502,83 @@ class AsyncClient(httpx.AsyncClient):
         _handle_error_response(response)
         return TaskRun.model_validate(response.json())
 
+    async def cancel_task_run(self, task_id: UUID, run_id: UUID) -> TaskRun:
+        response = await self.post(f"/v1/tasks/{task_id}/runs/{run_id}/cancel")
+        _handle_error_response(response)
+        return TaskRun.model_validate(response.json())
+
+    async def list_task_schedules(self) -> TaskScheduleList:
+        response = await self.get("/v1/tasks/schedules")
+        _handle_error_response(response)
+        return TaskScheduleList.model_validate(response.json())
+
+    async def create_task_schedule(
+        self,
+        name: str,
+        agent_id: UUID,
+        prompt: str,
+        cron_expression: str,
+        thread_id: Optional[UUID] = None,
+        timezone: Optional[str] = None,
+        enabled: Optional[bool] = None,
+        overlap_policy: Optional[str] = None,
+    ) -> TaskSchedule:
+        """Invoke an agent with the prompt each time the cron expression fires, in a new thread when thread_id is not set."""
+        request = CreateTaskScheduleRequest(
+            name=name,
+            agent_id=agent_id,
+            prompt=prompt,
+            cron_expression=cron_expression,
+            thread_id=thread_id,
+            timezone=timezone,
+            enabled=enabled,
+            overlap_policy=overlap_policy,
+        )
+        response = await self.post(
+            url="/v1/tasks/schedules",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return TaskSchedule.model_validate(response.json())
+
+    async def get_task_schedule(self, schedule_id: UUID) -> TaskSchedule:
+        response = await self.get(f"/v1/tasks/schedules/{schedule_id}")
+        _handle_error_response(response)
+        return TaskSchedule.model_validate(response.json())
+
+    async def update_task_schedule(
+        self,
+        schedule_id: UUID,
+        name: Optional[str] = None,
+        agent_id: Optional[UUID] = None,
+        prompt: Optional[str] = None,
+        cron_expression: Optional[str] = None,
+        thread_id: Optional[UUID] = None,
+        timezone: Optional[str] = None,
+        enabled: Optional[bool] = None,
+        overlap_policy: Optional[str] = None,
+    ) -> TaskSchedule:
+        request = UpdateTaskScheduleRequest(
+            name=name,
+            agent_id=agent_id,
+            prompt=prompt,
+            cron_expression=cron_expression,
+            thread_id=thread_id,
+            timezone=timezone,
+            enabled=enabled,
+            overlap_policy=overlap_policy,
+        )
+        response = await self.put(
+            url=f"/v1/tasks/schedules/{schedule_id}",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return TaskSchedule.model_validate(response.json())
+
+    async def delete_task_schedule(self, schedule_id: UUID) -> None:
+        response = await self.delete(f"/v1/tasks/schedules/{schedule_id}")
+        _handle_error_response(response)
+
     # Advanced streaming methods
     async def stream_with_retry(
         self,
@@ -1332,6 +2660,7 @@ class AsyncClient(httpx.AsyncClient):
         name: str,
         config: dict,
         description: Optional[str] = None,
+        idempotency_key: Optional[str] = None,
     ) -> Tool:
         request = CreateToolRequest(
             name=name,
@@ -1339,19 +2668,44 @@ class AsyncClient(httpx.AsyncClient):
             config=config,
         )
         response = await self.post(
-            url="/v1/tools",
-            json=request.model_dump(mode="json"),
+            url="/v1/tools",
+            json=request.model_dump(mode="json"),
+            headers=_idempotency_headers(idempotency_key),
+        )
+        _handle_error_response(response)
+        return Tool.model_validate(response.json())
+
+    async def batch_create_tools(self, tools: List[Dict[str, Any]]) -> BatchResult:
+        response = await self.post(url="/v1/tools/batch-create", json={"tools": tools})
+        _handle_error_response(response)
+        return BatchResult.model_validate(response.json())
+
+    async def batch_update_tools(self, updates: List[Dict[str, Any]]) -> BatchResult:
+        """Each update is a dict with the id of a tool and its changes"""
+        response = await self.post(
+            url="/v1/tools/batch-update", json={"tools": updates}
+        )
+        _handle_error_response(response)
+        return BatchResult.model_validate(response.json())
+
+    async def batch_delete_tools(self, tool_ids: List[UUID]) -> BatchResult:
+        response = await self.post(
+            url="/v1/tools/batch-delete", json={"ids": [str(i) for i in tool_ids]}
         )
         _handle_error_response(response)
-        return Tool.model_validate(response.json())
+        return BatchResult.model_validate(response.json())
 
     async def get_tool(self, tool_id: UUID) -> Tool:
         response = await self.get(f"/v1/tools/{tool_id}")
         _handle_error_response(response)
         return Tool.model_validate(response.json())
 
-    async def list_tools(self) -> ToolList:
-        response = await self.get("/v1/tools")
+    async def list_tools(
+        self, limit: int = 20, cursor: Optional[str] = None, **filters: Any
+    ) -> ToolList:
+        response = await self.get(
+            "/v1/tools", params=_page_params(limit, cursor, **filters)
+        )
         _handle_error_response(response)
         return ToolList.model_validate(response.json())
 
@@ -1360,6 +2714,7 @@ class AsyncClient(httpx.AsyncClient):
         tool_id: UUID,
         description: Optional[str] = None,
         config: Optional[dict] = None,
+        if_match: Optional[str] = None,
     ) -> Tool:
         request = UpdateToolRequest(
             description=description,
@@ -1368,6 +2723,7 @@ class AsyncClient(httpx.AsyncClient):
         response = await self.put(
             url=f"/v1/tools/{tool_id}",
             json=request.model_dump(mode="json"),
+            headers=_if_match_headers(if_match),
         )
         _handle_error_response(response)
         return Tool.model_validate(response.json())
@@ -1376,6 +2732,15 @@ class AsyncClient(httpx.AsyncClient):
         response = await self.delete(f"/v1/tools/{tool_id}")
         _handle_error_response(response)
 
+    async def restore_tool(self, tool_id: UUID) -> Tool:
+        response = await self.post(f"/v1/tools/{tool_id}/restore")
+        _handle_error_response(response)
+        return Tool.model_validate(response.json())
+
+    async def purge_tool(self, tool_id: UUID) -> None:
+        response = await self.post(f"/v1/tools/{tool_id}/purge")
+        _handle_error_response(response)
+
     # Message methods
     async def create_message(
         self,
@@ -1383,11 +2748,14 @@ class AsyncClient(httpx.AsyncClient):
         message: dict,
         recipient_id: UUID,
         sender_id: UUID,
+        attachments: Optional[List[UUID]] = None,
     ) -> Message:
+        """Create a message, with the uploaded files attached to it."""
         request = CreateMessageRequest(
             message=message,
             recipient_id=recipient_id,
             sender_id=sender_id,
+            attachments=attachments,
         )
         response = await self.post(
             url=f"/v1/threads/{thread_id}/messages",
@@ -1396,6 +2764,16 @@ class AsyncClient(httpx.AsyncClient):
         _handle_error_response(response)
         return Message.model_validate(response.json())
 
+    async def import_messages(
+        self, thread_id: UUID, messages: List[Dict[str, Any]]
+    ) -> BatchResult:
+        response = await self.post(
+            url=f"/v1/threads/{thread_id}/messages/batch",
+            json={"messages": messages},
+        )
+        _handle_error_response(response)
+        return BatchResult.model_validate(response.json())
+
     async def get_message(self, thread_id: UUID, message_id: UUID) -> Message:
         response = await self.get(
             url=f"/v1/threads/{thread_id}/messages/{message_id}",
@@ -1403,8 +2781,14 @@ class AsyncClient(httpx.AsyncClient):
         _handle_error_response(response)
         return Message.model_validate(response.json())
 
-    async def list_messages(self, thread_id: UUID) -> MessageList:
-        response = await self.get(f"/v1/threads/{thread_id}/messages")
+    async def list_messages(
+        self, thread_id: UUID, include_history: bool = False
+    ) -> MessageList:
+        """List the live messages of a thread, with the superseded ones on request."""
+        params: Dict[str, Any] = {}
+        if include_history:
+            params["include_history"] = True
+        response = await self.get(f"/v1/threads/{thread_id}/messages", params=params)
         _handle_error_response(response)
         return MessageList.model_validate(response.json())
 
@@ -1422,11 +2806,77 @@ class AsyncClient(httpx.AsyncClient):
         _handle_error_response(response)
         return Message.model_validate(response.json())
 
-    async def delete_message(self, thread_id: UUID, message_id: UUID) -> None:
+    async def delete_message(
+        self, thread_id: UUID, message_id: UUID, keep_history: bool = False
+    ) -> None:
+        """Delete a message, or supersede it and the messages after it."""
+        params: Dict[str, Any] = {}
+        if keep_history:
+            params["keep_history"] = True
         response = await self.delete(
             url=f"/v1/threads/{thread_id}/messages/{message_id}",
+            params=params,
+        )
+        _handle_error_response(response)
+
+    async def edit_message(
+        self,
+        thread_id: UUID,
+        message_id: UUID,
+        message: dict,
+        agent_id: Optional[UUID] = None,
+    ) -> MessageRevision:
+        """Replace a user message, the agent answers the new version when given."""
+        request = EditMessageRequest(message=message, agent_id=agent_id)
+        response = await self.post(
+            url=f"/v1/threads/{thread_id}/messages/{message_id}/edit",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return MessageRevision.model_validate(response.json())
+
+    async def regenerate_message(
+        self, thread_id: UUID, message_id: UUID, agent_id: UUID
+    ) -> MessageRevision:
+        """Answer a message again, the previous answers are kept as history."""
+        request = RegenerateMessageRequest(agent_id=agent_id)
+        response = await self.post(
+            url=f"/v1/threads/{thread_id}/messages/{message_id}/regenerate",
+            json=request.model_dump(mode="json"),
         )
         _handle_error_response(response)
+        return MessageRevision.model_validate(response.json())
+
+    async def search_messages(
+        self,
+        query: str,
+        thread_id: Optional[UUID] = None,
+        page: int = 1,
+        per_page: int = 10,
+    ) -> MessageSearchResults:
+        """Search the messages of the threads visible to the user."""
+        params: Dict[str, Any] = {"q": query, "page": page, "per_page": per_page}
+        if thread_id:
+            params["thread_id"] = str(thread_id)
+        response = await self.get("/v1/search/messages", params=params)
+        _handle_error_response(response)
+        return MessageSearchResults.model_validate(response.json())
+
+    async def semantic_search_messages(
+        self,
+        query: str,
+        thread_id: Optional[UUID] = None,
+        limit: Optional[int] = None,
+    ) -> SemanticSearchResults:
+        """Search the messages of the threads visible to the user by meaning."""
+        params: Dict[str, Any] = {"q": query}
+        if thread_id:
+            params["thread_id"] = str(thread_id)
+        if limit is not None:
+            params["limit"] = limit
+        response = await self.get("/v1/search/messages/semantic", params=params)
+        _handle_error_response(response)
+        return SemanticSearchResults.model_validate(response.json())
 
     # Thread methods
     async def create_thread(self, title: str, user_id: UUID) -> Thread:
@@ -1446,16 +2896,26 @@ class AsyncClient(httpx.AsyncClient):
         _handle_error_response(response)
         return Thread.model_validate(response.json())
 
-    async def list_threads(self) -> ThreadList:
-        response = await self.get("/v1/threads")
+    async def list_threads(
+        self, limit: int = 20, cursor: Optional[str] = None, **filters: Any
+    ) -> ThreadList:
+        response = await self.get(
+            "/v1/threads", params=_page_params(limit, cursor, **filters)
+        )
         _handle_error_response(response)
         return ThreadList.model_validate(response.json())
 
-    async def update_thread(self, thread_id: UUID, title: str) -> Thread:
+    async def update_thread(
+        self,
+        thread_id: UUID,
+        title: str,
+        if_match: Optional[str] = None,
+    ) -> Thread:
         request = UpdateThreadRequest(title=title)
         response = await self.put(
             url=f"/v1/threads/{thread_id}",
             json=request.model_dump(mode="json"),
+            headers=_if_match_headers(if_match),
         )
         _handle_error_response(response)
         return Thread.model_validate(response.json())
@@ -1464,6 +2924,93 @@ class AsyncClient(httpx.AsyncClient):
         response = await self.delete(f"/v1/threads/{thread_id}")
         _handle_error_response(response)
 
+    async def restore_thread(self, thread_id: UUID) -> Thread:
+        response = await self.post(f"/v1/threads/{thread_id}/restore")
+        _handle_error_response(response)
+        return Thread.model_validate(response.json())
+
+    async def purge_thread(self, thread_id: UUID) -> None:
+        response = await self.post(f"/v1/threads/{thread_id}/purge")
+        _handle_error_response(response)
+
+    async def fork_thread(
+        self,
+        thread_id: UUID,
+        from_message: Optional[UUID] = None,
+        title: Optional[str] = None,
+    ) -> Thread:
+        """Fork a thread with its live messages up to a message, the last by default."""
+        params: Dict[str, Any] = {}
+        if from_message:
+            params["from_message"] = str(from_message)
+        if title:
+            params["title"] = title
+        response = await self.post(f"/v1/threads/{thread_id}/fork", params=params)
+        _handle_error_response(response)
+        return Thread.model_validate(response.json())
+
+    async def export_thread(
+        self, thread_id: UUID, format: str = "json"
+    ) -> Union[ThreadExport, str]:
+        """Export a thread with its messages and tool runs. The json format is
+        imported with import_thread, the markdown format is a transcript."""
+        response = await self.get(
+            f"/v1/threads/{thread_id}/export", params={"format": format}
+        )
+        _handle_error_response(response)
+        if format == "markdown":
+            return response.text
+        return ThreadExport.model_validate(response.json())
+
+    async def import_thread(
+        self,
+        export: Union[ThreadExport, Dict[str, Any]],
+        title: Optional[str] = None,
+    ) -> Thread:
+        """Create a thread from an export, the tool runs are not imported."""
+        if isinstance(export, ThreadExport):
+            export = export.model_dump(mode="json", exclude_none=True)
+        params = {"title": title} if title else None
+        response = await self.post(
+            "/v1/threads/import", json=export, params=params
+        )
+        _handle_error_response(response)
+        return Thread.model_validate(response.json())
+
+    async def stream_thread_events(
+        self, thread_id: UUID, events: Optional[str] = None
+    ) -> AsyncIterator[Dict[str, Any]]:
+        """Follow the events of the tasks of a thread without starting a task.
+        Each event carries its header, naming its task. The stream is endless."""
+        params = {"events": events} if events else None
+        async with self.stream(
+            method="GET",
+            url=f"/v1/threads/{thread_id}/events",
+            params=params,
+            headers={"Accept": "text/event-stream"},
+            timeout=60.0,  # Heartbeats are sent every 30 seconds
+        ) as response:
+            _handle_error_response(response)
+            buffer = ""
+            decoder = codecs.getincrementaldecoder("utf-8")(errors="ignore")
+
+            async for chunk in response.aiter_bytes(chunk_size=1024):
+                buffer += decoder.decode(chunk, False)
+                while "\n" in buffer:
+                    line, buffer = buffer.split("\n", 1)
+                    line = line.strip()
+                    if not line.startswith("data: "):
+                        continue
+                    try:
+                        data = json.loads(line[6:])
+                    except json.JSONDecodeError:
+                        # Skip malformed JSON lines
+                        continue
+                    if isinstance(data, dict) and data.get("type") == "heartbeat":
+                        self.record_heartbeat()
+                        continue
+                    yield data
+
     # Permission methods
     async def create_permission(
         self,
@@ -1699,3 +3246,444 @@ class AsyncClient(httpx.AsyncClient):
     ) -> None:
         response = await self.delete(f"/v1/users/{user_id}/roles/{role_id}")
         _handle_error_response(response)
+
+    async def create_service_account(
+        self, name: str, description: Optional[str] = None
+    ) -> User:
+        request = CreateServiceAccountRequest(name=name, description=description)
+        response = await self.post(
+            url="/v1/service-accounts",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return User.model_validate(response.json())
+
+    async def create_api_key(
+        self,
+        user_id: UUID,
+        name: str,
+        scopes: List[str],
+        expires_at: Optional[datetime] = None,
+    ) -> CreatedApiKey:
+        """Create an API key for a user, the key is only returned here."""
+        request = CreateApiKeyRequest(name=name, scopes=scopes, expires_at=expires_at)
+        response = await self.post(
+            url=f"/v1/users/{user_id}/api-keys",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return CreatedApiKey.model_validate(response.json())
+
+    async def list_api_keys(self, user_id: UUID) -> ApiKeyList:
+        response = await self.get(f"/v1/users/{user_id}/api-keys")
+        _handle_error_response(response)
+        return ApiKeyList.model_validate(response.json())
+
+    async def revoke_api_key(self, user_id: UUID, key_id: UUID) -> ApiKey:
+        response = await self.delete(f"/v1/users/{user_id}/api-keys/{key_id}")
+        _handle_error_response(response)
+        return ApiKey.model_validate(response.json())
+
+    # Webhook methods
+    async def create_webhook(
+        self,
+        url: str,
+        events: List[str],
+        description: Optional[str] = None,
+        enabled: bool = True,
+    ) -> CreatedWebhook:
+        """Create a webhook of the current user, the secret is only returned here."""
+        request = CreateWebhookRequest(
+            url=url, events=events, description=description, enabled=enabled
+        )
+        response = await self.post(
+            url="/v1/webhooks",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return CreatedWebhook.model_validate(response.json())
+
+    async def list_webhooks(self) -> WebhookList:
+        response = await self.get("/v1/webhooks")
+        _handle_error_response(response)
+        return WebhookList.model_validate(response.json())
+
+    async def get_webhook(self, webhook_id: UUID) -> Webhook:
+        response = await self.get(f"/v1/webhooks/{webhook_id}")
+        _handle_error_response(response)
+        return Webhook.model_validate(response.json())
+
+    async def update_webhook(
+        self,
+        webhook_id: UUID,
+        url: Optional[str] = None,
+        events: Optional[List[str]] = None,
+        description: Optional[str] = None,
+        enabled: Optional[bool] = None,
+    ) -> Webhook:
+        request = UpdateWebhookRequest(
+            url=url, events=events, description=description, enabled=enabled
+        )
+        response = await self.put(
+            url=f"/v1/webhooks/{webhook_id}",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return Webhook.model_validate(response.json())
+
+    async def delete_webhook(self, webhook_id: UUID) -> None:
+        response = await self.delete(f"/v1/webhooks/{webhook_id}")
+        _handle_error_response(response)
+
+    async def rotate_webhook_secret(self, webhook_id: UUID) -> CreatedWebhook:
+        """Replace the secret of a webhook, the new secret is only returned here."""
+        response = await self.post(f"/v1/webhooks/{webhook_id}/rotate-secret")
+        _handle_error_response(response)
+        return CreatedWebhook.model_validate(response.json())
+
+    async def list_webhook_deliveries(
+        self,
+        webhook_id: UUID,
+        status: Optional[str] = None,
+        limit: int = 20,
+        cursor: Optional[str] = None,
+    ) -> WebhookDeliveryList:
+        response = await self.get(
+            f"/v1/webhooks/{webhook_id}/deliveries",
+            params=_page_params(limit, cursor, status=status),
+        )
+        _handle_error_response(response)
+        return WebhookDeliveryList.model_validate(response.json())
+
+    async def redeliver_webhook_delivery(
+        self, webhook_id: UUID, delivery_id: UUID
+    ) -> WebhookDelivery:
+        response = await self.post(
+            f"/v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver"
+        )
+        _handle_error_response(response)
+        return WebhookDelivery.model_validate(response.json())
+
+    async def upload_file(
+        self,
+        content: bytes,
+        name: str,
+        content_type: Optional[str] = None,
+    ) -> File:
+        """Upload a file, its content type is detected when not given."""
+        file = (name, content, content_type) if content_type else (name, content)
+        response = await self.post(url="/v1/files", files={"file": file})
+        _handle_error_response(response)
+        return File.model_validate(response.json()["file"])
+
+    async def create_file_upload(
+        self, name: str, content_type: str, size_bytes: int
+    ) -> CreatedFile:
+        """Create a file uploaded to the returned presigned URL.
+
+        The content is uploaded with a PUT request carrying the same
+        Content-Type, then the upload is completed with complete_file_upload.
+        """
+        request = CreateFileUploadRequest(
+            name=name, content_type=content_type, size_bytes=size_bytes
+        )
+        response = await self.post(
+            url="/v1/files", json=request.model_dump(mode="json")
+        )
+        _handle_error_response(response)
+        return CreatedFile.model_validate(response.json())
+
+    async def complete_file_upload(self, file_id: UUID) -> File:
+        response = await self.post(f"/v1/files/{file_id}/complete")
+        _handle_error_response(response)
+        return File.model_validate(response.json())
+
+    async def list_files(
+        self, limit: int = 20, cursor: Optional[str] = None
+    ) -> FileList:
+        response = await self.get("/v1/files", params=_page_params(limit, cursor))
+        _handle_error_response(response)
+        return FileList.model_validate(response.json())
+
+    async def get_file(self, file_id: UUID) -> File:
+        response = await self.get(f"/v1/files/{file_id}")
+        _handle_error_response(response)
+        return File.model_validate(response.json())
+
+    async def download_file(self, file_id: UUID) -> bytes:
+        response = await self.get(f"/v1/files/{file_id}/content")
+        _handle_error_response(response)
+        return response.content
+
+    async def delete_file(self, file_id: UUID) -> None:
+        response = await self.delete(f"/v1/files/{file_id}")
+        _handle_error_response(response)
+
+    async def list_message_attachments(
+        self, thread_id: UUID, message_id: UUID
+    ) -> AttachmentList:
+        response = await self.get(
+            f"/v1/threads/{thread_id}/messages/{message_id}/attachments"
+        )
+        _handle_error_response(response)
+        return AttachmentList.model_validate(response.json())
+
+    # Organization and workspace methods
+    async def list_organizations(self) -> UserOrganizationList:
+        response = await self.get("/v1/organizations")
+        _handle_error_response(response)
+        return UserOrganizationList.model_validate(response.json())
+
+    async def create_organization(self, name: str) -> Organization:
+        """Create an organization owned by the current user."""
+        request = CreateOrganizationRequest(name=name)
+        response = await self.post(
+            url="/v1/organizations",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return Organization.model_validate(response.json())
+
+    async def list_organization_members(
+        self, organization_id: UUID
+    ) -> OrganizationMemberList:
+        response = await self.get(f"/v1/organizations/{organization_id}/members")
+        _handle_error_response(response)
+        return OrganizationMemberList.model_validate(response.json())
+
+    async def set_organization_member(
+        self, organization_id: UUID, user_id: UUID, role: str = "MEMBER"
+    ) -> OrganizationMember:
+        """Add a user to an organization or change the role of a member."""
+        request = SetOrganizationMemberRequest(role=role)
+        response = await self.put(
+            url=f"/v1/organizations/{organization_id}/members/{user_id}",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return OrganizationMember.model_validate(response.json())
+
+    async def remove_organization_member(
+        self, organization_id: UUID, user_id: UUID
+    ) -> None:
+        """Remove a user from an organization and from its workspaces."""
+        response = await self.delete(
+            f"/v1/organizations/{organization_id}/members/{user_id}"
+        )
+        _handle_error_response(response)
+
+    async def list_workspaces(self, organization_id: UUID) -> WorkspaceList:
+        response = await self.get(
+            f"/v1/organizations/{organization_id}/workspaces"
+        )
+        _handle_error_response(response)
+        return WorkspaceList.model_validate(response.json())
+
+    async def create_workspace(self, organization_id: UUID, name: str) -> Workspace:
+        """Create a workspace, the current user is its first admin."""
+        request = CreateWorkspaceRequest(name=name)
+        response = await self.post(
+            url=f"/v1/organizations/{organization_id}/workspaces",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return Workspace.model_validate(response.json())
+
+    async def list_workspace_members(self, workspace_id: UUID) -> WorkspaceMemberList:
+        response = await self.get(f"/v1/workspaces/{workspace_id}/members")
+        _handle_error_response(response)
+        return WorkspaceMemberList.model_validate(response.json())
+
+    async def set_workspace_member(
+        self, workspace_id: UUID, user_id: UUID, role: str = "MEMBER"
+    ) -> WorkspaceMember:
+        """Add a member of the organization to a workspace or change its role."""
+        request = SetWorkspaceMemberRequest(role=role)
+        response = await self.put(
+            url=f"/v1/workspaces/{workspace_id}/members/{user_id}",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return WorkspaceMember.model_validate(response.json())
+
+    async def remove_workspace_member(
+        self, workspace_id: UUID, user_id: UUID
+    ) -> None:
+        response = await self.delete(
+            f"/v1/workspaces/{workspace_id}/members/{user_id}"
+        )
+        _handle_error_response(response)
+
+    # Resource grant methods
+    async def list_agent_grants(self, agent_id: UUID) -> ResourceGrantList:
+        response = await self.get(f"/v1/agents/{agent_id}/grants")
+        _handle_error_response(response)
+        return ResourceGrantList.model_validate(response.json())
+
+    async def set_agent_grant(
+        self,
+        agent_id: UUID,
+        grantee_id: UUID,
+        access: str = "READ",
+        grantee_type: str = "USER",
+    ) -> ResourceGrant:
+        """Share an agent with a user or a role."""
+        request = SetResourceGrantRequest(
+            grantee_type=grantee_type, grantee_id=grantee_id, access=access
+        )
+        response = await self.put(
+            url=f"/v1/agents/{agent_id}/grants",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return ResourceGrant.model_validate(response.json())
+
+    async def remove_agent_grant(
+        self, agent_id: UUID, grantee_id: UUID, grantee_type: str = "USER"
+    ) -> None:
+        response = await self.delete(
+            f"/v1/agents/{agent_id}/grants/{grantee_type}/{grantee_id}"
+        )
+        _handle_error_response(response)
+
+    async def list_tool_grants(self, tool_id: UUID) -> ResourceGrantList:
+        response = await self.get(f"/v1/tools/{tool_id}/grants")
+        _handle_error_response(response)
+        return ResourceGrantList.model_validate(response.json())
+
+    async def set_tool_grant(
+        self,
+        tool_id: UUID,
+        grantee_id: UUID,
+        access: str = "READ",
+        grantee_type: str = "USER",
+    ) -> ResourceGrant:
+        """Share a tool with a user or a role."""
+        request = SetResourceGrantRequest(
+            grantee_type=grantee_type, grantee_id=grantee_id, access=access
+        )
+        response = await self.put(
+            url=f"/v1/tools/{tool_id}/grants",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return ResourceGrant.model_validate(response.json())
+
+    async def remove_tool_grant(
+        self, tool_id: UUID, grantee_id: UUID, grantee_type: str = "USER"
+    ) -> None:
+        response = await self.delete(
+            f"/v1/tools/{tool_id}/grants/{grantee_type}/{grantee_id}"
+        )
+        _handle_error_response(response)
+
+    async def list_thread_grants(self, thread_id: UUID) -> ResourceGrantList:
+        response = await self.get(f"/v1/threads/{thread_id}/grants")
+        _handle_error_response(response)
+        return ResourceGrantList.model_validate(response.json())
+
+    async def set_thread_grant(
+        self,
+        thread_id: UUID,
+        grantee_id: UUID,
+        access: str = "READ",
+        grantee_type: str = "USER",
+    ) -> ResourceGrant:
+        """Share a thread with a user or a role."""
+        request = SetResourceGrantRequest(
+            grantee_type=grantee_type, grantee_id=grantee_id, access=access
+        )
+        response = await self.put(
+            url=f"/v1/threads/{thread_id}/grants",
+            json=request.model_dump(mode="json"),
+        )
+        _handle_error_response(response)
+        return ResourceGrant.model_validate(response.json())
+
+    async def remove_thread_grant(
+        self, thread_id: UUID, grantee_id: UUID, grantee_type: str = "USER"
+    ) -> None:
+        response = await self.delete(
+            f"/v1/threads/{thread_id}/grants/{grantee_type}/{grantee_id}"
+        )
+        _handle_error_response(response)
+
+    # Usage methods
+    async def get_usage(self) -> UsageReport:
+        """Get the usage of the quotas of the user and of its workspace."""
+        response = await self.get("/v1/usage")
+        _handle_error_response(response)
+        return UsageReport.model_validate(response.json())
+
+    async def set_message_feedback(
+        self,
+        thread_id: UUID,
+        message_id: UUID,
+        rating: str,
+        categories: Optional[List[str]] = None,
+        comment: Optional[str] = None,
+    ) -> Feedback:
+        """Rate a message of an agent, replacing the previous feedback of the user on it."""
+        request = FeedbackRequest(rating=rating, categories=categories, comment=comment)
+        response = await self.put(
+            url=f"/v1/threads/{thread_id}/messages/{message_id}/feedback",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return Feedback.model_validate(response.json())
+
+    async def delete_message_feedback(self, thread_id: UUID, message_id: UUID) -> None:
+        """Delete the feedback of the user on a message."""
+        response = await self.delete(f"/v1/threads/{thread_id}/messages/{message_id}/feedback")
+        _handle_error_response(response)
+
+    async def set_tool_run_feedback(
+        self,
+        thread_id: UUID,
+        tool_run_id: str,
+        rating: str,
+        categories: Optional[List[str]] = None,
+        comment: Optional[str] = None,
+    ) -> Feedback:
+        """Rate a tool run of a thread, replacing the previous feedback of the user on it."""
+        request = FeedbackRequest(rating=rating, categories=categories, comment=comment)
+        response = await self.put(
+            url=f"/v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback",
+            json=request.model_dump(mode="json", exclude_none=True),
+        )
+        _handle_error_response(response)
+        return Feedback.model_validate(response.json())
+
+    async def delete_tool_run_feedback(self, thread_id: UUID, tool_run_id: str) -> None:
+        """Delete the feedback of the user on a tool run."""
+        response = await self.delete(f"/v1/threads/{thread_id}/tool-runs/{tool_run_id}/feedback")
+        _handle_error_response(response)
+
+    async def list_feedback(
+        self,
+        rating: Optional[str] = None,
+        thread_id: Optional[UUID] = None,
+        user_id: Optional[UUID] = None,
+        category: Optional[str] = None,
+        page: int = 1,
+        per_page: int = 10,
+    ) -> FeedbackList:
+        """List the feedback of the workspace, all of it for the workspace admins."""
+        params = _feedback_params(rating, thread_id, user_id, category)
+        params.update({"page": page, "per_page": per_page})
+        response = await self.get("/v1/feedback", params=params)
+        _handle_error_response(response)
+        return FeedbackList.model_validate(response.json())
+
+    async def export_feedback(
+        self,
+        rating: Optional[str] = None,
+        thread_id: Optional[UUID] = None,
+        user_id: Optional[UUID] = None,
+        category: Optional[str] = None,
+    ) -> List[Dict[str, Any]]:
+        """Export the feedback of the workspace as a dataset, one example per feedback."""
+        params = _feedback_params(rating, thread_id, user_id, category)
+        response = await self.get("/v1/feedback/export", params=params)
+        _handle_error_response(response)
+        return [json.loads(line) for line in response.text.splitlines() if line]
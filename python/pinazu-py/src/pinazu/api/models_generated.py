@@ -1,7 +1,7 @@
 from pydantic import BaseModel
 from uuid import UUID
 from datetime import datetime
-from typing import Optional
+from typing import Any, Optional
 
 
 class AddPermissionToAgentRequest(BaseModel):
@@ -22,6 +22,7 @@ class AddRoleToUserRequest(BaseModel):
 class Agent(BaseModel):
     created_at: datetime
     created_by: UUID
+    deleted_at: Optional[datetime] = None
     description: Optional[str] = None
     id: UUID
     name: str
@@ -30,10 +31,10 @@ class Agent(BaseModel):
     
 
 class AgentList(BaseModel):
-    page: int
-    per_page: int
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
     total: int
-    total_pages: int
     agents: list[Agent]
 
 class AgentPermissionMapping(BaseModel):
@@ -51,33 +52,208 @@ class AgentPermissionMappingList(BaseModel):
     total_pages: int
     permissionMappings: list[AgentPermissionMapping]
 
+class AgentProbe(BaseModel):
+    agent_id: UUID
+    created_at: datetime
+    created_by: UUID
+    dry_run: bool
+    enabled: bool
+    expected_pattern: str
+    id: UUID
+    interval_seconds: int
+    last_run_at: Optional[datetime] = None
+    name: str
+    prompt: str
+    status: str
+    timeout_seconds: int
+    updated_at: datetime
+    
+
+class AgentProbeList(BaseModel):
+    probes: list[AgentProbe]
+    
+
+class AgentProbeRun(BaseModel):
+    created_at: datetime
+    error: Optional[str] = None
+    id: UUID
+    latency_ms: int
+    probe_id: UUID
+    response: Optional[str] = None
+    status: str
+    thread_id: Optional[UUID] = None
+    
+
+class AgentProbeRunList(BaseModel):
+    page: int
+    per_page: int
+    total: int
+    total_pages: int
+    runs: list[AgentProbeRun]
+
+class AgentPromptCacheStats(BaseModel):
+    agent_id: UUID
+    agent_name: str
+    cache_creation_input_tokens: int
+    cache_hit_count: int
+    cache_hit_rate: float
+    cache_read_input_tokens: int
+    input_tokens: int
+    last_warmup_at: Optional[datetime] = None
+    request_count: int
+    token_hit_rate: float
+    updated_at: datetime
+    warmup_count: int
+    warmup_input_tokens: int
+    
+
+class AgentThinkingBudgetStats(BaseModel):
+    agent_id: UUID
+    agent_name: str
+    average_thinking_tokens: float
+    budget_tokens: int
+    estimated_count: int
+    exhausted_count: int
+    exhausted_rate: float
+    max_thinking_tokens: int
+    request_count: int
+    thinking_tokens: int
+    updated_at: datetime
+    utilization: float
+    
+
+class ApiKey(BaseModel):
+    created_at: datetime
+    created_by: UUID
+    expires_at: Optional[datetime] = None
+    id: UUID
+    key_prefix: str
+    last_used_at: Optional[datetime] = None
+    name: str
+    revoked_at: Optional[datetime] = None
+    scopes: list
+    user_id: UUID
+    
+
+class ApiKeyList(BaseModel):
+    api_keys: list[ApiKey]
+    
+
+class AttachmentList(BaseModel):
+    attachments: list[File]
+    
+
 class BadRequest(BaseModel):
     message: str
     
 
+class BatchCreateToolsRequest(BaseModel):
+    tools: list[CreateToolRequest]
+    
+
+class BatchDeleteRequest(BaseModel):
+    ids: list
+    
+
+class BatchItemResult(BaseModel):
+    id: Optional[UUID] = None
+    index: int
+    message: Optional[str] = None
+    status: str
+    
+
+class BatchResult(BaseModel):
+    committed: bool
+    results: list[BatchItemResult]
+    
+
+class BatchToolUpdate(BaseModel):
+    changes: dict
+    id: UUID
+    
+
+class BatchUpdateToolsRequest(BaseModel):
+    tools: list[BatchToolUpdate]
+    
+
+class CancelFlowRunRequest(BaseModel):
+    reason: Optional[str] = None
+    
+
+class Conflict(BaseModel):
+    message: str
+    
+
+class CreateAgentProbeRequest(BaseModel):
+    dry_run: Optional[bool] = None
+    enabled: Optional[bool] = None
+    expected_pattern: str
+    interval_seconds: Optional[int] = None
+    name: str
+    prompt: str
+    timeout_seconds: Optional[int] = None
+    
+
 class CreateAgentRequest(BaseModel):
     description: Optional[str] = None
     name: str
     specs: Optional[str] = None
     
 
+class CreateApiKeyRequest(BaseModel):
+    expires_at: Optional[datetime] = None
+    name: str
+    scopes: list
+    
+
+class CreateFileUploadRequest(BaseModel):
+    content_type: str
+    name: str
+    size_bytes: int
+    
+
 class CreateFlowRequest(BaseModel):
     additional_info: Optional[dict] = None
     code_location: str
+    concurrency_policy: Optional[str] = None
     description: Optional[str] = None
     engine: str
     entrypoint: str
+    max_concurrent_runs: Optional[int] = None
     name: str
+    notifications: Optional[list[FlowNotification]] = None
     parameters_schema: dict
+    required_labels: Optional[list] = None
     tags: Optional[list] = None
     
 
+class CreateFlowScheduleBackfillRequest(BaseModel):
+    end_time: datetime
+    max_concurrent_runs: Optional[int] = None
+    start_time: datetime
+    
+
+class CreateFlowScheduleRequest(BaseModel):
+    catch_up_policy: Optional[str] = None
+    cron_expression: str
+    enabled: Optional[bool] = None
+    engine: Optional[str] = None
+    name: str
+    parameters: Optional[dict] = None
+    timezone: Optional[str] = None
+    
+
 class CreateMessageRequest(BaseModel):
+    attachments: Optional[list] = None
     message: dict
     recipient_id: UUID
     sender_id: UUID
     
 
+class CreateOrganizationRequest(BaseModel):
+    name: str
+    
+
 class CreatePermissionRequest(BaseModel):
     content: dict
     description: Optional[str] = None
@@ -90,21 +266,53 @@ class CreateRoleRequest(BaseModel):
     name: str
     
 
+class CreateServiceAccountRequest(BaseModel):
+    description: Optional[str] = None
+    name: str
+    
+
 class CreateTaskRequest(BaseModel):
     additional_info: Optional[dict] = None
+    flow_run_id: Optional[UUID] = None
     max_request_loop: Optional[int] = None
     thread_id: UUID
     
 
+class CreateTaskScheduleRequest(BaseModel):
+    agent_id: UUID
+    cron_expression: str
+    enabled: Optional[bool] = None
+    name: str
+    overlap_policy: Optional[str] = None
+    prompt: str
+    thread_id: Optional[UUID] = None
+    timezone: Optional[str] = None
+    
+
 class CreateThreadRequest(BaseModel):
     title: str
     user_id: UUID
     
 
+class CreateToolFromDefinitionRequest(BaseModel):
+    category: Optional[str] = None
+    config: dict
+    definition: dict
+    description: Optional[str] = None
+    format: Optional[str] = None
+    name: Optional[str] = None
+    tags: Optional[list] = None
+    
+
 class CreateToolRequest(BaseModel):
+    category: Optional[str] = None
     config: dict
     description: Optional[str] = None
+    documentation: Optional[str] = None
+    examples: Optional[list[ToolExample]] = None
+    icon: Optional[str] = None
     name: str
+    tags: Optional[list] = None
     
 
 class CreateUserRequest(BaseModel):
@@ -115,29 +323,144 @@ class CreateUserRequest(BaseModel):
     provider_name: Optional[str] = None
     
 
+class CreateWebhookRequest(BaseModel):
+    description: Optional[str] = None
+    enabled: Optional[bool] = None
+    events: list
+    url: str
+    
+
+class CreateWorkspaceRequest(BaseModel):
+    name: str
+    
+
+class CreatedApiKey(BaseModel):
+    api_key: dict
+    key: str
+    
+
+class CreatedFile(BaseModel):
+    file: dict
+    upload_expires_at: Optional[datetime] = None
+    upload_url: Optional[str] = None
+    
+
+class CreatedWebhook(BaseModel):
+    secret: str
+    webhook: dict
+    
+
+class CursorPaginationMeta(BaseModel):
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
+    total: int
+    
+
+class DeadLetterList(BaseModel):
+    page: int
+    per_page: int
+    total: int
+    total_pages: int
+    messages: list[ParkedMessage]
+
+class EditMessageRequest(BaseModel):
+    agent_id: Optional[UUID] = None
+    message: dict
+    
+
 class ExecuteFlowRequest(BaseModel):
+    max_retries: Optional[int] = None
     parameters: dict
+    parent_run_id: Optional[UUID] = None
     
 
 class ExecuteTaskRequest(BaseModel):
     agent_id: UUID
     current_loops: Optional[int] = None
+    dry_run: Optional[bool] = None
+    queue: Optional[bool] = None
     
 
+class Feedback(BaseModel):
+    categories: list
+    comment: Optional[str] = None
+    created_at: datetime
+    id: UUID
+    message_id: Optional[UUID] = None
+    rating: str
+    thread_id: UUID
+    tool_run_id: Optional[str] = None
+    updated_at: datetime
+    user_id: UUID
+    workspace_id: UUID
+    
+
+class FeedbackList(BaseModel):
+    page: int
+    per_page: int
+    total: int
+    total_pages: int
+    feedback: list[Feedback]
+
+class FeedbackRequest(BaseModel):
+    categories: Optional[list] = None
+    comment: Optional[str] = None
+    rating: str
+    
+
+class File(BaseModel):
+    content_type: str
+    created_at: datetime
+    id: UUID
+    name: str
+    size_bytes: int
+    status: str
+    uploaded_at: Optional[datetime] = None
+    user_id: UUID
+    
+
+class FileList(BaseModel):
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
+    total: int
+    files: list[File]
+
 class Flow(BaseModel):
     additional_info: Optional[dict] = None
     code_location: Optional[str] = None
+    concurrency_policy: Optional[str] = None
     created_at: datetime
+    deleted_at: Optional[datetime] = None
     description: Optional[str] = None
     engine: str
     entrypoint: Optional[str] = None
     id: UUID
+    max_concurrent_runs: Optional[int] = None
     name: str
+    notifications: Optional[list[FlowNotification]] = None
     parameters_schema: dict
+    required_labels: Optional[list] = None
     tags: list
     updated_at: datetime
     
 
+class FlowCostAnalytics(BaseModel):
+    flows: list[FlowCostStats]
+    
+
+class FlowCostStats(BaseModel):
+    cpu_seconds: float
+    flow_id: UUID
+    flow_name: str
+    input_tokens: int
+    llm_requests: int
+    output_tokens: int
+    run_count: int
+    wall_seconds: float
+    
+
 class FlowList(BaseModel):
     page: int
     per_page: int
@@ -145,28 +468,196 @@ class FlowList(BaseModel):
     total_pages: int
     flows: list[Flow]
 
+class FlowNotification(BaseModel):
+    events: Optional[list] = None
+    secret: Optional[str] = None
+    type: str
+    url: str
+    
+
 class FlowRun(BaseModel):
     created_at: datetime
     engine: str
     error_message: Optional[str] = None
+    failure_reason: Optional[str] = None
     finished_at: Optional[datetime] = None
     flow_id: UUID
     flow_run_id: UUID
     max_retries: Optional[int] = None
     parameters: dict
+    parent_run_id: Optional[UUID] = None
     retry_count: Optional[int] = None
     started_at: Optional[datetime] = None
     status: str
     success_task_results: dict
     task_statuses: dict
     updated_at: datetime
+    worker_id: Optional[str] = None
+    
+
+class FlowRunArtifact(BaseModel):
+    bucket: str
+    content_type: str
+    created_at: datetime
+    flow_run_id: UUID
+    id: UUID
+    name: str
+    object_key: str
+    size_bytes: int
+    task_name: Optional[str] = None
+    updated_at: datetime
+    
+
+class FlowRunArtifactList(BaseModel):
+    artifacts: list[FlowRunArtifact]
+    
+
+class FlowRunChildren(BaseModel):
+    children: list[FlowRun]
+    completed: bool
+    failed: int
+    flow_run_id: UUID
+    statuses: dict
+    total: int
+    
+
+class FlowRunCost(BaseModel):
+    cpu_seconds: float
+    finished_at: Optional[datetime] = None
+    flow_id: UUID
+    flow_run_id: UUID
+    input_tokens: int
+    llm_requests: int
+    output_tokens: int
+    started_at: Optional[datetime] = None
+    status: str
+    user_id: Optional[UUID] = None
+    wall_seconds: float
+    
+
+class FlowRunGraph(BaseModel):
+    edges: list
+    flow_run_id: UUID
+    nodes: list
+    status: str
+    
+
+class FlowRunList(BaseModel):
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
+    total: int
+    flow_runs: list[FlowRun]
+
+class FlowRunLog(BaseModel):
+    content: str
+    created_at: datetime
+    flow_run_id: UUID
+    id: int
+    stream: str
+    
+
+class FlowRunLogList(BaseModel):
+    logs: list[FlowRunLog]
+    next_cursor: int
+    
+
+class FlowRunTimeline(BaseModel):
+    duration_seconds: float
+    events: list
+    flow_run_id: UUID
+    status: str
+    
+
+class FlowSchedule(BaseModel):
+    catch_up_policy: str
+    created_at: datetime
+    created_by: UUID
+    cron_expression: str
+    enabled: bool
+    engine: Optional[str] = None
+    flow_id: UUID
+    id: UUID
+    last_flow_run_id: Optional[UUID] = None
+    last_run_at: Optional[datetime] = None
+    name: str
+    next_run_at: datetime
+    parameters: dict
+    timezone: str
+    updated_at: datetime
+    
+
+class FlowScheduleBackfill(BaseModel):
+    created_at: datetime
+    created_by: UUID
+    cron_expression: str
+    end_at: datetime
+    engine: Optional[str] = None
+    finished_at: Optional[datetime] = None
+    flow_id: UUID
+    id: UUID
+    max_concurrent_runs: int
+    parameters: dict
+    schedule_id: UUID
+    start_at: datetime
+    status: str
+    timezone: str
+    total_runs: int
+    triggered_runs: int
+    updated_at: datetime
+    
+
+class FlowScheduleBackfillList(BaseModel):
+    backfills: list[FlowScheduleBackfill]
+    
+
+class FlowScheduleList(BaseModel):
+    schedules: list[FlowSchedule]
+    
+
+class Forbidden(BaseModel):
+    message: str
+    
+
+class ImportMessage(BaseModel):
+    created_at: Optional[datetime] = None
+    message: dict
+    recipient_id: UUID
+    sender_id: UUID
+    sender_type: str
+    
+
+class ImportMessagesRequest(BaseModel):
+    messages: list[ImportMessage]
+    
+
+class InvalidFlowParameters(BaseModel):
+    message: str
+    violations: Optional[list[ToolInputViolation]] = None
+    
+
+class InvokeAgentRequest(BaseModel):
+    messages: list
+    
+
+class InvokeAgentResponse(BaseModel):
+    agent_id: UUID
+    message: dict
+    stop_reason: Optional[str] = None
     
 
 class MCPTool(BaseModel):
     api_key: Optional[str] = None
+    cache: Optional[dict] = None
     entrypoint: str
     env_vars: Optional[dict] = None
+    health_check: Optional[dict] = None
+    limits: Optional[dict] = None
+    mock: Optional[dict] = None
+    params: Optional[dict] = None
     protocol: str
+    tls: Optional[dict] = None
+    tool_name: Optional[str] = None
     type: str
     
 
@@ -180,8 +671,10 @@ class Message(BaseModel):
     sender_id: UUID
     sender_type: str
     stop_reason: Optional[str] = None
+    superseded_at: Optional[datetime] = None
     thread_id: UUID
     updated_at: datetime
+    version_of: Optional[UUID] = None
     
 
 class MessageList(BaseModel):
@@ -191,6 +684,32 @@ class MessageList(BaseModel):
     total_pages: int
     messages: list[Message]
 
+class MessageRevision(BaseModel):
+    message: Optional[dict] = None
+    stream_url: Optional[str] = None
+    superseded: int
+    task: Optional[dict] = None
+    task_run: Optional[dict] = None
+    
+
+class MessageSearchResult(BaseModel):
+    created_at: datetime
+    highlight: str
+    id: UUID
+    rank: float
+    sender_id: UUID
+    sender_type: str
+    thread_id: UUID
+    thread_title: str
+    
+
+class MessageSearchResults(BaseModel):
+    page: int
+    per_page: int
+    total: int
+    total_pages: int
+    results: list[MessageSearchResult]
+
 class MockToolRequest(BaseModel):
     input: str
     
@@ -215,6 +734,24 @@ class NotFound(BaseModel):
     resource: str
     
 
+class Organization(BaseModel):
+    created_at: datetime
+    id: UUID
+    name: str
+    updated_at: datetime
+    
+
+class OrganizationMember(BaseModel):
+    created_at: datetime
+    organization_id: UUID
+    role: str
+    user_id: UUID
+    
+
+class OrganizationMemberList(BaseModel):
+    members: list[OrganizationMember]
+    
+
 class PaginationMeta(BaseModel):
     page: int
     per_page: int
@@ -222,6 +759,19 @@ class PaginationMeta(BaseModel):
     total_pages: int
     
 
+class ParkedMessage(BaseModel):
+    consumer: str
+    consumers: dict
+    parked_at: datetime
+    payload: Any
+    reason: str
+    redeliveries: int
+    sequence: int
+    stream: str
+    stream_sequence: int
+    subject: str
+    
+
 class Permission(BaseModel):
     content: dict
     created_at: datetime
@@ -238,12 +788,95 @@ class PermissionList(BaseModel):
     total_pages: int
     permissions: list[Permission]
 
+class PreconditionFailed(BaseModel):
+    etag: Optional[str] = None
+    id: UUID
+    message: str
+    resource: str
+    
+
+class PromptCacheAnalytics(BaseModel):
+    agents: list[AgentPromptCacheStats]
+    
+
+class QuickstartRequest(BaseModel):
+    message: str
+    title: Optional[str] = None
+    
+
+class QuickstartResponse(BaseModel):
+    agent_id: UUID
+    message: dict
+    stream_url: str
+    task: dict
+    task_run: dict
+    thread: dict
+    
+
+class QuotaExceeded(BaseModel):
+    limit: Optional[int] = None
+    message: str
+    quota: Optional[str] = None
+    resets_at: Optional[datetime] = None
+    scope: Optional[str] = None
+    used: Optional[float] = None
+    
+
+class QuotaStatus(BaseModel):
+    limit: Optional[int] = None
+    quota: str
+    resets_at: Optional[datetime] = None
+    scope: str
+    used: float
+    
+
+class RegenerateMessageRequest(BaseModel):
+    agent_id: UUID
+    
+
 class ResourceAlreadyExists(BaseModel):
     id: UUID
     message: str
     resource: str
     
 
+class ResourceChange(BaseModel):
+    action: str
+    changed_by: UUID
+    changes: dict
+    created_at: datetime
+    id: UUID
+    resource_id: UUID
+    resource_type: str
+    snapshot: Optional[dict] = None
+    source_ip: Optional[str] = None
+    user_agent: Optional[str] = None
+    version: int
+    
+
+class ResourceGrant(BaseModel):
+    access: str
+    created_at: datetime
+    granted_by: UUID
+    grantee_id: UUID
+    grantee_type: str
+    id: UUID
+    resource_id: UUID
+    resource_type: str
+    updated_at: datetime
+    
+
+class ResourceGrantList(BaseModel):
+    grants: list[ResourceGrant]
+    
+
+class ResourceHistory(BaseModel):
+    page: int
+    per_page: int
+    total: int
+    total_pages: int
+    changes: list[ResourceChange]
+
 class Role(BaseModel):
     created_at: datetime
     description: Optional[str] = None
@@ -268,8 +901,79 @@ class RolePermissionMapping(BaseModel):
     role_id: UUID
     
 
+class SemanticSearchResult(BaseModel):
+    created_at: datetime
+    id: UUID
+    sender_id: UUID
+    sender_type: str
+    similarity: float
+    text: str
+    thread_id: UUID
+    thread_title: str
+    
+
+class SemanticSearchResults(BaseModel):
+    results: list[SemanticSearchResult]
+    
+
+class ServiceInstance(BaseModel):
+    description: str
+    id: str
+    name: str
+    num_errors: int
+    num_messages: int
+    started: Optional[datetime] = None
+    subscriptions: list[ServiceSubscription]
+    uptime_seconds: int
+    version: str
+    
+
+class ServiceOverview(BaseModel):
+    collected_at: datetime
+    instances: list[ServiceInstance]
+    services: list[ServiceSummary]
+    
+
+class ServiceSubscription(BaseModel):
+    last_error: Optional[str] = None
+    num_errors: int
+    num_messages: int
+    subject: str
+    
+
+class ServiceSummary(BaseModel):
+    instances: int
+    name: str
+    num_errors: int
+    num_messages: int
+    
+
+class SetOrganizationMemberRequest(BaseModel):
+    role: str
+    
+
+class SetResourceGrantRequest(BaseModel):
+    access: str
+    grantee_id: UUID
+    grantee_type: str
+    
+
+class SetUserDefaultAgentRequest(BaseModel):
+    agent_id: Optional[UUID] = None
+    
+
+class SetWorkspaceMemberRequest(BaseModel):
+    role: str
+    
+
 class StandaloneTool(BaseModel):
     api_key: Optional[str] = None
+    cache: Optional[dict] = None
+    edge: Optional[bool] = None
+    edge_group: Optional[str] = None
+    health_check: Optional[dict] = None
+    limits: Optional[dict] = None
+    mock: Optional[dict] = None
     params: dict
     type: str
     url: str
@@ -279,6 +983,7 @@ class Task(BaseModel):
     additional_info: dict
     created_at: datetime
     created_by: UUID
+    flow_run_id: Optional[UUID] = None
     id: str
     max_request_loop: int
     parent_task_id: Optional[str] = None
@@ -287,54 +992,298 @@ class Task(BaseModel):
     
 
 class TaskList(BaseModel):
-    page: int
-    per_page: int
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
     total: int
-    total_pages: int
     tasks: list[Task]
 
 class TaskRun(BaseModel):
+    agent_id: Optional[UUID] = None
     created_at: datetime
     current_loops: Optional[int] = None
+    deadline_at: Optional[datetime] = None
+    dry_run: Optional[bool] = None
     finished_at: Optional[datetime] = None
+    requested_by: Optional[UUID] = None
     started_at: Optional[datetime] = None
     status: str
     task_id: str
     task_run_id: UUID
     updated_at: datetime
+    workspace_id: Optional[UUID] = None
+    
+
+class TaskSchedule(BaseModel):
+    agent_id: UUID
+    created_at: datetime
+    created_by: UUID
+    cron_expression: str
+    enabled: bool
+    id: UUID
+    last_run_at: Optional[datetime] = None
+    last_thread_id: Optional[UUID] = None
+    name: str
+    next_run_at: datetime
+    overlap_policy: str
+    prompt: str
+    thread_id: Optional[UUID] = None
+    timezone: str
+    updated_at: datetime
+    workspace_id: UUID
+    
+
+class TaskScheduleList(BaseModel):
+    schedules: list[TaskSchedule]
+    
+
+class TestToolRequest(BaseModel):
+    input: dict
+    mock: Optional[dict] = None
+    revision: Optional[int] = None
+    
+
+class TestToolResponse(BaseModel):
+    is_error: bool
+    result: Optional[Any] = None
+    revision: int
+    tool_id: UUID
+    valid: bool
+    violations: list[ToolInputViolation]
+    
+
+class ThinkingBudgetAnalytics(BaseModel):
+    agents: list[AgentThinkingBudgetStats]
     
 
 class Thread(BaseModel):
     created_at: datetime
+    deleted_at: Optional[datetime] = None
+    forked_from: Optional[UUID] = None
+    forked_from_message: Optional[UUID] = None
     id: UUID
     title: str
     updated_at: datetime
     user_id: UUID
     
 
+class ThreadExport(BaseModel):
+    exported_at: Optional[datetime] = None
+    messages: list[ThreadExportMessage]
+    thread: dict
+    tool_runs: Optional[list[ThreadExportToolRun]] = None
+    version: int
+    
+
+class ThreadExportMessage(BaseModel):
+    citations: Optional[list] = None
+    created_at: Optional[datetime] = None
+    id: Optional[UUID] = None
+    message: dict
+    recipient_id: UUID
+    result_type: Optional[str] = None
+    sender_id: UUID
+    sender_type: str
+    stop_reason: Optional[str] = None
+    
+
+class ThreadExportToolRun(BaseModel):
+    agent_id: Optional[UUID] = None
+    created_at: Optional[datetime] = None
+    duration_seconds: Optional[float] = None
+    id: str
+    input: Optional[dict] = None
+    parent_run_id: Optional[str] = None
+    recipient_id: Optional[UUID] = None
+    result: Optional[Any] = None
+    status: str
+    tool_id: UUID
+    tool_name: Optional[str] = None
+    
+
 class ThreadList(BaseModel):
-    page: int
-    per_page: int
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
     total: int
-    total_pages: int
     threads: list[Thread]
 
 class Tool(BaseModel):
+    category: Optional[str] = None
     config: dict
     created_at: datetime
     created_by: UUID
+    deleted_at: Optional[datetime] = None
     description: Optional[str] = None
+    documentation: Optional[str] = None
+    examples: Optional[list[ToolExample]] = None
+    icon: Optional[str] = None
     id: UUID
     name: str
+    revision: int
+    status: Optional[str] = None
+    status_checked_at: Optional[datetime] = None
+    status_message: Optional[str] = None
+    tags: Optional[list] = None
     updated_at: datetime
     
 
+class ToolBundle(BaseModel):
+    exported_at: Optional[datetime] = None
+    tools: list[ToolBundleEntry]
+    version: int
+    
+
+class ToolBundleEntry(BaseModel):
+    category: Optional[str] = None
+    config: dict
+    description: Optional[str] = None
+    documentation: Optional[str] = None
+    examples: Optional[list[ToolExample]] = None
+    icon: Optional[str] = None
+    name: str
+    tags: Optional[list] = None
+    
+
+class ToolBundleImportResponse(BaseModel):
+    results: list[ToolBundleImportResult]
+    
+
+class ToolBundleImportResult(BaseModel):
+    action: str
+    message: Optional[str] = None
+    name: str
+    tool_id: Optional[UUID] = None
+    tool_name: Optional[str] = None
+    
+
+class ToolCache(BaseModel):
+    cacheable: bool
+    ttl_seconds: Optional[int] = None
+    
+
+class ToolCatalog(BaseModel):
+    categories: list[ToolCatalogCategory]
+    total: int
+    
+
+class ToolCatalogCategory(BaseModel):
+    count: int
+    name: str
+    tools: list[Tool]
+    
+
+class ToolExample(BaseModel):
+    description: Optional[str] = None
+    input: dict
+    title: str
+    
+
+class ToolHealthCheck(BaseModel):
+    command: Optional[list] = None
+    failure_threshold: Optional[int] = None
+    interval_seconds: Optional[int] = None
+    timeout_seconds: Optional[int] = None
+    url: Optional[str] = None
+    
+
+class ToolInputViolation(BaseModel):
+    message: str
+    path: str
+    
+
+class ToolLimits(BaseModel):
+    invocations_per_minute: Optional[int] = None
+    max_concurrent_runs: Optional[int] = None
+    max_wait_seconds: Optional[int] = None
+    
+
 class ToolList(BaseModel):
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
+    total: int
+    tools: list[Tool]
+
+class ToolMCPTLS(BaseModel):
+    ca_cert: Optional[str] = None
+    insecure_skip_verify: Optional[bool] = None
+    server_name: Optional[str] = None
+    
+
+class ToolMock(BaseModel):
+    is_error: Optional[bool] = None
+    response: Any
+    
+
+class ToolRevision(BaseModel):
+    config: dict
+    created_at: datetime
+    created_by: UUID
+    description: Optional[str] = None
+    revision: int
+    tool_id: UUID
+    
+
+class ToolRevisionList(BaseModel):
+    promoted_revision: int
+    revisions: list[ToolRevision]
+    
+
+class ToolRunAudit(BaseModel):
+    agent_id: UUID
+    completed_at: Optional[datetime] = None
+    connection_id: Optional[UUID] = None
+    created_at: datetime
+    duration: Optional[float] = None
+    expires_at: datetime
+    id: UUID
+    input: Optional[Any] = None
+    output: Optional[Any] = None
+    parent_run_id: Optional[str] = None
+    recipient_id: UUID
+    status: str
+    task_id: Optional[str] = None
+    thread_id: UUID
+    tool_id: UUID
+    tool_name: str
+    tool_revision: int
+    tool_run_id: str
+    user_id: UUID
+    
+
+class ToolRunAuditList(BaseModel):
     page: int
     per_page: int
     total: int
     total_pages: int
-    tools: list[Tool]
+    entries: list[ToolRunAudit]
+
+class ToolSchemaChange(BaseModel):
+    breaking: bool
+    kind: str
+    new_value: Optional[Any] = None
+    old_value: Optional[Any] = None
+    path: str
+    
+
+class ToolSchemaDiff(BaseModel):
+    breaking: bool
+    changes: list[ToolSchemaChange]
+    from_revision: int
+    to_revision: int
+    tool_id: UUID
+    
+
+class UpdateAgentProbeRequest(BaseModel):
+    dry_run: Optional[bool] = None
+    enabled: Optional[bool] = None
+    expected_pattern: Optional[str] = None
+    interval_seconds: Optional[int] = None
+    name: Optional[str] = None
+    prompt: Optional[str] = None
+    timeout_seconds: Optional[int] = None
+    
 
 class UpdateAgentRequest(BaseModel):
     description: Optional[str] = None
@@ -345,13 +1294,27 @@ class UpdateAgentRequest(BaseModel):
 class UpdateFlowRequest(BaseModel):
     additional_info: Optional[dict] = None
     code_location: Optional[str] = None
+    concurrency_policy: Optional[str] = None
     description: Optional[str] = None
     engine: Optional[str] = None
     entrypoint: Optional[str] = None
+    max_concurrent_runs: Optional[int] = None
     name: Optional[str] = None
+    notifications: Optional[list[FlowNotification]] = None
+    required_labels: Optional[list] = None
     tags: Optional[list] = None
     
 
+class UpdateFlowScheduleRequest(BaseModel):
+    catch_up_policy: Optional[str] = None
+    cron_expression: Optional[str] = None
+    enabled: Optional[bool] = None
+    engine: Optional[str] = None
+    name: Optional[str] = None
+    parameters: Optional[dict] = None
+    timezone: Optional[str] = None
+    
+
 class UpdateMessageRequest(BaseModel):
     message: dict
     
@@ -373,13 +1336,29 @@ class UpdateTaskRequest(BaseModel):
     max_request_loop: Optional[int] = None
     
 
+class UpdateTaskScheduleRequest(BaseModel):
+    agent_id: Optional[UUID] = None
+    cron_expression: Optional[str] = None
+    enabled: Optional[bool] = None
+    name: Optional[str] = None
+    overlap_policy: Optional[str] = None
+    prompt: Optional[str] = None
+    thread_id: Optional[UUID] = None
+    timezone: Optional[str] = None
+    
+
 class UpdateThreadRequest(BaseModel):
     title: str
     
 
 class UpdateToolRequest(BaseModel):
+    category: Optional[str] = None
     config: Optional[dict] = None
     description: Optional[str] = None
+    documentation: Optional[str] = None
+    examples: Optional[list[ToolExample]] = None
+    icon: Optional[str] = None
+    tags: Optional[list] = None
     
 
 class UpdateUserRequest(BaseModel):
@@ -389,6 +1368,24 @@ class UpdateUserRequest(BaseModel):
     username: Optional[str] = None
     
 
+class UpdateWebhookRequest(BaseModel):
+    description: Optional[str] = None
+    enabled: Optional[bool] = None
+    events: Optional[list] = None
+    url: Optional[str] = None
+    
+
+class UploadFileRequest(BaseModel):
+    file: str
+    name: Optional[str] = None
+    
+
+class UsageReport(BaseModel):
+    quotas: list[QuotaStatus]
+    user_id: UUID
+    workspace_id: UUID
+    
+
 class User(BaseModel):
     additional_info: Optional[dict] = None
     created_at: datetime
@@ -401,6 +1398,27 @@ class User(BaseModel):
     updated_at: datetime
     
 
+class UserDefaultAgent(BaseModel):
+    agent_id: Optional[UUID] = None
+    user_id: UUID
+    
+
+class UserFlowCostAnalytics(BaseModel):
+    users: list[UserFlowCostStats]
+    
+
+class UserFlowCostStats(BaseModel):
+    cpu_seconds: float
+    flow_count: int
+    input_tokens: int
+    llm_requests: int
+    output_tokens: int
+    run_count: int
+    user_id: Optional[UUID] = None
+    user_name: Optional[str] = None
+    wall_seconds: float
+    
+
 class UserList(BaseModel):
     page: int
     per_page: int
@@ -408,6 +1426,18 @@ class UserList(BaseModel):
     total_pages: int
     users: list[User]
 
+class UserOrganization(BaseModel):
+    created_at: datetime
+    id: UUID
+    name: str
+    role: str
+    updated_at: datetime
+    
+
+class UserOrganizationList(BaseModel):
+    organizations: list[UserOrganization]
+    
+
 class UserRoleMapping(BaseModel):
     assigned_at: datetime
     assigned_by: UUID
@@ -416,8 +1446,72 @@ class UserRoleMapping(BaseModel):
     user_id: UUID
     
 
+class Webhook(BaseModel):
+    created_at: datetime
+    description: Optional[str] = None
+    enabled: bool
+    events: list
+    id: UUID
+    updated_at: datetime
+    url: str
+    user_id: UUID
+    
+
+class WebhookDelivery(BaseModel):
+    attempts: int
+    created_at: datetime
+    delivered_at: Optional[datetime] = None
+    error_message: Optional[str] = None
+    event_id: UUID
+    event_type: str
+    id: UUID
+    next_attempt_at: Optional[datetime] = None
+    payload: dict
+    response_status: Optional[int] = None
+    status: str
+    webhook_id: UUID
+    
+
+class WebhookDeliveryList(BaseModel):
+    has_more: bool
+    limit: int
+    next_cursor: Optional[str] = None
+    total: int
+    deliveries: list[WebhookDelivery]
+
+class WebhookList(BaseModel):
+    webhooks: list[Webhook]
+    
+
 class WorkflowTool(BaseModel):
+    cache: Optional[dict] = None
+    health_check: Optional[dict] = None
+    limits: Optional[dict] = None
+    mock: Optional[dict] = None
     params: dict
     s3_url: str
     type: str
     
+
+class Workspace(BaseModel):
+    created_at: datetime
+    id: UUID
+    name: str
+    organization_id: UUID
+    updated_at: datetime
+    
+
+class WorkspaceList(BaseModel):
+    workspaces: list[Workspace]
+    
+
+class WorkspaceMember(BaseModel):
+    created_at: datetime
+    role: str
+    user_id: UUID
+    workspace_id: UUID
+    
+
+class WorkspaceMemberList(BaseModel):
+    members: list[WorkspaceMember]
+    
@@ -5,7 +5,9 @@ import inspect
 import uuid
 import os
 import threading
-from typing import Callable
+from typing import Any, Callable, Dict, List, Optional, Union
+from uuid import UUID
+from pinazu.models import FlowRunCompletedEvent, TaskDefinition
 from pinazu.runtime import FlowRunner, TaskRegistry
 import asyncio
 
@@ -90,11 +92,26 @@ def flow(func: Callable) -> Callable:
     return wrapper
 
 
-def task(func: Callable) -> Callable:
+def task(
+    func: Optional[Callable] = None,
+    *,
+    depends_on: Optional[List[str]] = None,
+    max_retries: int = 0,
+    retry_delay_seconds: int = 0,
+) -> Callable:
     """
     Decorator to mark a function as a task.
     Tasks can only be used inside flows.
+    Used as @task(...), it declares the tasks it depends on and its retries,
+    reported as the task DAG of the flow run.
     """
+    if func is None:
+        return functools.partial(
+            task,
+            depends_on=depends_on,
+            max_retries=max_retries,
+            retry_delay_seconds=retry_delay_seconds,
+        )
 
     @functools.wraps(func)
     def wrapper(*args, **kwargs):
@@ -155,6 +172,70 @@ def task(func: Callable) -> Callable:
     wrapper._task_name = func.__name__  # type: ignore
 
     # Register the task globally
-    TaskRegistry.register_task(func.__name__, func)
+    TaskRegistry.register_task(
+        func.__name__,
+        func,
+        TaskDefinition(
+            name=func.__name__,
+            depends_on=depends_on or [],
+            max_retries=max_retries,
+            retry_delay_seconds=retry_delay_seconds,
+        ),
+    )
 
     return wrapper
+
+
+def publish_artifact(
+    name: str,
+    data: Any = None,
+    *,
+    path: Optional[str] = None,
+    content_type: Optional[str] = None,
+) -> str:
+    """
+    Publish a named artifact of the running flow, from a file path, bytes,
+    text, or any other value stored as JSON.
+    The artifacts are listed and downloaded from the flow run API,
+    an artifact published again under the same name replaces the previous one.
+    """
+    with _flow_lock:
+        runner = list(_active_flows.values())[-1] if _active_flows else None
+    if runner is None:
+        raise FlowError("Artifacts can only be published within a @flow")
+    return runner.publish_artifact(
+        name, data=data, path=path, content_type=content_type
+    )
+
+
+async def run_subflow(
+    flow_id: Union[UUID, str],
+    parameters: Optional[Dict[str, Any]] = None,
+    *,
+    wait: bool = True,
+    engine: str = "process",
+    max_retries: Optional[int] = None,
+    timeout: Optional[float] = None,
+    raise_on_failure: bool = True,
+) -> Union[FlowRunCompletedEvent, UUID]:
+    """
+    Execute another flow as a child run of the running flow.
+    The child runs are listed from the flow run API with their statuses,
+    and are cancelled with the parent run.
+    With wait, the call blocks until the child run completes and returns
+    its completion, otherwise the ID of the child run is returned at once.
+    Several child runs are awaited together with asyncio.gather.
+    """
+    with _flow_lock:
+        runner = list(_active_flows.values())[-1] if _active_flows else None
+    if runner is None:
+        raise FlowError("Sub-flows can only be executed within a @flow")
+    return await runner.run_subflow(
+        flow_id,
+        parameters,
+        wait=wait,
+        engine=engine,
+        max_retries=max_retries,
+        timeout=timeout,
+        raise_on_failure=raise_on_failure,
+    )
@@ -11,6 +11,9 @@ class NatTopics(str, Enum):
 
     FLOW_STATUS = "v1.svc.worker.flow.status"
     TASK_STATUS = "v1.svc.worker.task.status"
+    FLOW_GRAPH = "v1.svc.worker.flow.graph"
+    FLOW_RUN_EXECUTE = "v1.svc.flowrun.execute"
+    FLOW_RUN_COMPLETED = "v1.svc.flowrun.completed"
 
 
 class FlowStatus(str, Enum):
@@ -19,8 +22,10 @@ class FlowStatus(str, Enum):
     SCHEDULED = "SCHEDULED"
     PENDING = "PENDING"
     RUNNING = "RUNNING"
+    PAUSED = "PAUSED"
     SUCCESS = "SUCCESS"
     FAILED = "FAILED"
+    CANCELLED = "CANCELLED"
 
 
 class EventHeaders(BaseModel):
@@ -66,7 +71,12 @@ class Event(BaseModel):
         )
     )  # Event headers with user, thread, and connection IDs
     # The message payload, can be any Pydantic model
-    message: FlowRunStatusEvent | TaskRunStatusEvent
+    message: (
+        FlowRunStatusEvent
+        | TaskRunStatusEvent
+        | FlowRunGraphEvent
+        | FlowRunExecuteRequest
+    )
     metadata: Optional[EventMetadata] = Field(
         default_factory=lambda: EventMetadata(
             timestamp=datetime.now(timezone.utc),
@@ -113,6 +123,78 @@ class TaskRunStatusEvent(BaseModel):
     event_timestamp: datetime
 
 
+class TaskDefinition(BaseModel):
+    """
+    Model to represent a task of the DAG of a flow - matches Go struct
+    FlowTaskDefinition struct {
+        Name              string   `json:"name"`
+        DependsOn         []string `json:"depends_on,omitempty"`
+        MaxRetries        int32    `json:"max_retries"`
+        RetryDelaySeconds int32    `json:"retry_delay_seconds"`
+    }
+    """
+
+    name: str
+    depends_on: list[str] = Field(default_factory=list)
+    max_retries: int = 0  # Retries of the task after a failure
+    retry_delay_seconds: int = 0  # Delay between two attempts
+
+
+class FlowRunGraphEvent(BaseModel):
+    """
+    Model to represent the task DAG of a flow run - matches Go struct
+    FlowRunGraphEventMessage struct {
+        FlowRunId      uuid.UUID               `json:"flow_run_id"`
+        Tasks          []db.FlowTaskDefinition `json:"tasks"`
+        EventTimestamp time.Time               `json:"event_timestamp"`
+    }
+    """
+
+    flow_run_id: UUID
+    tasks: list[TaskDefinition]
+    event_timestamp: datetime
+
+
+class FlowRunExecuteRequest(BaseModel):
+    """
+    Model to request the execution of a sub-flow run - matches Go struct
+    FlowRunExecuteRequestEventMessage struct {
+        FlowId      uuid.UUID              `json:"flow_id"`
+        FlowRunId   *uuid.UUID             `json:"flow_run_id,omitempty"`
+        Parameters  map[string]interface{} `json:"parameters"`
+        Engine      string                 `json:"engine"`
+        MaxRetries  *int32                 `json:"max_retries,omitempty"`
+        ParentRunId *uuid.UUID             `json:"parent_run_id,omitempty"`
+    }
+    """
+
+    flow_id: UUID
+    flow_run_id: Optional[UUID] = None
+    parameters: dict[str, Any] = Field(default_factory=dict)
+    engine: str = "process"
+    max_retries: Optional[int] = None  # Flows service default when not set
+    parent_run_id: Optional[UUID] = None  # Flow run triggering the sub-flow
+
+
+class FlowRunCompletedEvent(BaseModel):
+    """
+    Model to represent the completion of a flow run - matches Go struct
+    FlowRunCompletedEventMessage struct {
+        FlowRunId      uuid.UUID     `json:"flow_run_id"`
+        ParentRunId    *uuid.UUID    `json:"parent_run_id,omitempty"`
+        Status         db.FlowStatus `json:"status"`
+        ErrorMessage   string        `json:"error_message,omitempty"`
+        EventTimestamp time.Time     `json:"event_timestamp"`
+    }
+    """
+
+    flow_run_id: UUID
+    parent_run_id: Optional[UUID] = None
+    status: FlowStatus
+    error_message: Optional[str] = None  # Error message if the run failed
+    event_timestamp: datetime
+
+
 class CacheResult(BaseModel):
     """
     Model to represent a cached result
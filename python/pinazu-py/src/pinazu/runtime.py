@@ -2,16 +2,27 @@
 
 import os
 import nats
+import uuid
+import shutil
+import asyncio
+import signal
 import logging
 import hashlib
+import mimetypes
+import threading
+import contextvars
 import orjson as json
 from concurrent.futures import ThreadPoolExecutor
-from typing import Any, Callable, Dict, Optional, Union
+from typing import Any, Callable, Dict, List, Optional, Union
 from aiobotocore.session import get_session
 from botocore.exceptions import ClientError
 from pinazu.models import (
     FlowRunStatusEvent,
     TaskRunStatusEvent,
+    FlowRunGraphEvent,
+    FlowRunExecuteRequest,
+    FlowRunCompletedEvent,
+    TaskDefinition,
     FlowStatus,
     NatTopics,
     Event,
@@ -25,20 +36,61 @@ from uuid import UUID
 
 # Configure logging
 
+# Name of the task being run, recorded with the artifacts it publishes
+current_task_name: contextvars.ContextVar[Optional[str]] = (
+    contextvars.ContextVar("current_task_name", default=None)
+)
+
+
+class FlowPausedError(BaseException):
+    """Raised when a task is started after the flow was asked to pause.
+
+    Derives from BaseException so the `except Exception` of the tasks, the
+    task retries and the flow code do not catch the pause as a failure.
+    """
+
+    pass
+
+
+class SubflowError(Exception):
+    """Raised when a sub-flow run is rejected or does not succeed"""
+
+    def __init__(
+        self, message: str, completion: Optional[FlowRunCompletedEvent] = None
+    ):
+        super().__init__(message)
+        self.completion = completion
+
 
 class TaskRegistry:
     """Registry to store task functions"""
 
     _tasks: Dict[str, Callable] = {}
+    _definitions: Dict[str, TaskDefinition] = {}
 
     @classmethod
-    def register_task(cls, name: str, func: Callable):
+    def register_task(
+        cls,
+        name: str,
+        func: Callable,
+        definition: Optional[TaskDefinition] = None,
+    ):
         cls._tasks[name] = func
+        cls._definitions[name] = definition or TaskDefinition(name=name)
 
     @classmethod
     def get_task(cls, name: str) -> Optional[Callable]:
         return cls._tasks.get(name)
 
+    @classmethod
+    def get_definition(cls, name: str) -> Optional[TaskDefinition]:
+        return cls._definitions.get(name)
+
+    @classmethod
+    def definitions(cls) -> List[TaskDefinition]:
+        """Task definitions in their declaration order"""
+        return list(cls._definitions.values())
+
 
 class CacheManager:
     """Manages S3 caching for task results"""
@@ -219,6 +271,84 @@ class InMemoryCacheManager:
         self.logger.info("Memory cache cleared")
 
 
+class ArtifactManager:
+    """
+    Writes the artifacts published by the tasks to the artifacts directory
+    of the run, uploaded by the worker when the flow process exits.
+    Without worker, the artifacts stay in the local artifacts directory.
+    """
+
+    def __init__(self, flow_run_id: UUID, logger: Optional[Logger] = None):
+        self.dir = os.getenv(
+            "PINAZU_ARTIFACTS_DIR",
+            os.path.join("artifacts", str(flow_run_id)),
+        )
+        self.logger = logger or CustomLogger(
+            log_level=logging.DEBUG,
+            name="ArtifactManager",
+        )
+        self._lock = threading.Lock()
+
+    @staticmethod
+    def _validate_name(name: str):
+        """Same rules as the worker, the name is part of the artifact URL"""
+        if not name or len(name) > 255:
+            raise ValueError("Artifact name must have 1 to 255 characters")
+        if name in (".", "..") or "/" in name or "\\" in name:
+            raise ValueError(f"Invalid artifact name {name!r}")
+        if any(not ch.isprintable() for ch in name):
+            raise ValueError(f"Invalid artifact name {name!r}")
+
+    def publish(
+        self,
+        name: str,
+        data: Any = None,
+        path: Optional[str] = None,
+        content_type: Optional[str] = None,
+    ) -> str:
+        """
+        Publish an artifact from a file path, bytes, text,
+        or any other value stored as JSON
+        """
+        self._validate_name(name)
+        files_dir = os.path.join(self.dir, "files")
+        os.makedirs(files_dir, exist_ok=True)
+        file_name = uuid.uuid4().hex
+        file_path = os.path.join(files_dir, file_name)
+
+        if path is not None:
+            shutil.copyfile(path, file_path)
+            content_type = (
+                content_type
+                or mimetypes.guess_type(path)[0]
+                or "application/octet-stream"
+            )
+        elif isinstance(data, (bytes, bytearray)):
+            with open(file_path, "wb") as f:
+                f.write(data)
+            content_type = content_type or "application/octet-stream"
+        elif isinstance(data, str):
+            with open(file_path, "wb") as f:
+                f.write(data.encode("utf-8"))
+            content_type = content_type or "text/plain; charset=utf-8"
+        else:
+            with open(file_path, "wb") as f:
+                f.write(json.dumps(data, default=str))
+            content_type = content_type or "application/json"
+
+        entry = {
+            "name": name,
+            "file": file_name,
+            "content_type": content_type,
+            "task_name": current_task_name.get(),
+        }
+        with self._lock:
+            with open(os.path.join(self.dir, "manifest.jsonl"), "ab") as f:
+                f.write(json.dumps(entry) + b"\n")
+        self.logger.info(f"Published artifact {name} ({content_type})")
+        return name
+
+
 class LogManager:
     """Manages logging for both local and remote modes"""
 
@@ -271,6 +401,21 @@ class LogManager:
         else:
             self.logger.info(log_data.model_dump_json())
 
+    async def log_flow_graph(
+        self, flow_run_id: UUID, tasks: List[TaskDefinition]
+    ):
+        """Log the task DAG of the flow run"""
+        log_data = FlowRunGraphEvent(
+            flow_run_id=flow_run_id,
+            tasks=tasks,
+            event_timestamp=datetime.now(timezone.utc),
+        )
+
+        if self.is_remote:
+            await self._send_remote_log(log_data, NatTopics.FLOW_GRAPH)
+        else:
+            self.logger.info(log_data.model_dump_json())
+
     async def log_flow_status(self, flow_run_id: UUID, status: FlowStatus):
         """Log flow status"""
         log_data = FlowRunStatusEvent(
@@ -286,7 +431,9 @@ class LogManager:
 
     async def _send_remote_log(
         self,
-        log_data: Union[FlowRunStatusEvent, TaskRunStatusEvent],
+        log_data: Union[
+            FlowRunStatusEvent, TaskRunStatusEvent, FlowRunGraphEvent
+        ],
         log_type: NatTopics,
     ):
         """Send log to NATS URL"""
@@ -341,15 +488,36 @@ class FlowRunner:
             self.cache_manager = InMemoryCacheManager(logger=self.logger)
             self.cache_type = "memory"
         self.log_manager = LogManager(logger=self.logger)
+        self.artifact_manager = ArtifactManager(
+            flow_run_id=flow_run_id, logger=self.logger
+        )
         self.tasks_status: Dict[str, FlowStatus] = {}
+        self.child_run_ids: List[UUID] = []
         self.failed = False
+        self.paused = False
         self.executor = ThreadPoolExecutor(max_workers=num_threads)
 
+        # The worker sends SIGUSR1 to pause the flow before its next task
+        if (
+            hasattr(signal, "SIGUSR1")
+            and threading.current_thread() is threading.main_thread()
+        ):
+            signal.signal(signal.SIGUSR1, self._handle_pause_signal)
+
         # Start logging thread for local mode
 
+    def _handle_pause_signal(self, signum, frame):
+        """Stop starting tasks, the running ones complete and cache their result"""  # noqa: E501
+        self.logger.info(f"Flow {self.flow_name} pausing before its next task")
+        self.paused = True
+
     async def run_flow(self, flow_func: Callable, *args, **kwargs) -> Any:
         """Execute the flow function"""
         try:
+            # Report the task DAG so the run can be visualized
+            await self.log_manager.log_flow_graph(
+                self.flow_run_id, TaskRegistry.definitions()
+            )
             await self.log_manager.log_flow_status(
                 flow_run_id=self.flow_run_id,
                 status=FlowStatus.RUNNING,
@@ -364,6 +532,13 @@ class FlowRunner:
                 )
 
             return result
+        except FlowPausedError:
+            # Resumed later, the completed tasks are read from the cache
+            await self.log_manager.log_flow_status(
+                self.flow_run_id, FlowStatus.PAUSED
+            )
+            self.logger.info(f"Flow {self.flow_name} paused")
+            return None
         except Exception as e:
             self.failed = True
             await self.log_manager.log_flow_status(
@@ -380,14 +555,107 @@ class FlowRunner:
             ):
                 self.cache_manager.clear_cache()
 
+    def publish_artifact(
+        self,
+        name: str,
+        data: Any = None,
+        path: Optional[str] = None,
+        content_type: Optional[str] = None,
+    ) -> str:
+        """Publish a named artifact of the flow run"""
+        return self.artifact_manager.publish(
+            name, data=data, path=path, content_type=content_type
+        )
+
+    async def run_subflow(
+        self,
+        flow_id: Union[UUID, str],
+        parameters: Optional[Dict[str, Any]] = None,
+        *,
+        wait: bool = True,
+        engine: str = "process",
+        max_retries: Optional[int] = None,
+        timeout: Optional[float] = None,
+        raise_on_failure: bool = True,
+    ) -> Union[FlowRunCompletedEvent, UUID]:
+        """
+        Execute another flow as a child run of this flow run.
+        Without waiting, the ID of the child run is returned at once.
+        Otherwise the completion of the child run is returned once it reached
+        its final status, a child run which did not succeed raises a
+        SubflowError unless raise_on_failure is False.
+        """
+        child_run_id = uuid.uuid4()
+        nats_client = await self.log_manager.nats_client
+        completed_subject = (
+            f"{NatTopics.FLOW_RUN_COMPLETED.value}.{child_run_id}"
+        )
+        # Subscribed before the request, so the completion of a short run
+        # is not missed
+        subscription = (
+            await nats_client.subscribe(completed_subject, max_msgs=1)
+            if wait
+            else None
+        )
+        try:
+            request = Event(
+                message=FlowRunExecuteRequest(
+                    flow_id=UUID(str(flow_id)),
+                    flow_run_id=child_run_id,
+                    parameters=parameters or {},
+                    engine=engine,
+                    max_retries=max_retries,
+                    parent_run_id=self.flow_run_id,
+                )
+            )
+            response = await nats_client.request(
+                NatTopics.FLOW_RUN_EXECUTE.value,
+                request.model_dump_json().encode("utf-8"),
+                timeout=30,
+            )
+            error = json.loads(response.data).get("error")
+            if error:
+                raise SubflowError(
+                    f"Sub-flow {flow_id} rejected: {error.get('error')}"
+                )
+            self.child_run_ids.append(child_run_id)
+            self.logger.info(
+                f"Started sub-flow run {child_run_id} of flow {flow_id}"
+            )
+            if subscription is None:
+                return child_run_id
+
+            msg = await subscription.next_msg(timeout=timeout)
+            completion = FlowRunCompletedEvent.model_validate(
+                json.loads(msg.data)["message"]
+            )
+        finally:
+            if subscription is not None:
+                await subscription.unsubscribe()
+
+        self.logger.info(
+            f"Sub-flow run {child_run_id} completed with status"
+            f" {completion.status.value}"
+        )
+        if raise_on_failure and completion.status != FlowStatus.SUCCESS:
+            raise SubflowError(
+                f"Sub-flow run {child_run_id} ended with status"
+                f" {completion.status.value}: {completion.error_message}",
+                completion,
+            )
+        return completion
+
     async def run_task(
         self, task_name: str, task_func: Callable, *args, **kwargs
     ) -> Any:
         """Execute a task with caching"""
         if self.failed:
             raise RuntimeError("Flow is in failed state")
+        if self.paused:
+            raise FlowPausedError(f"Flow paused before task {task_name}")
 
         self.tasks_status[task_name] = FlowStatus.RUNNING
+        current_task_name.set(task_name)
         try:
             print(
                 f"FlowRunner: Flow Run Id {self.flow_run_id}"
@@ -409,8 +677,23 @@ class FlowRunner:
                 )
                 return cached_result.result
 
-            # Execute task
-            result = await task_func(*args, **kwargs)
+            # Execute task, retried after a failure as its definition allows
+            definition = TaskRegistry.get_definition(task_name)
+            max_retries = definition.max_retries if definition else 0
+            attempt = 0
+            while True:
+                try:
+                    result = await task_func(*args, **kwargs)
+                    break
+                except Exception as e:
+                    if attempt >= max_retries:
+                        raise
+                    attempt += 1
+                    self.logger.warning(
+                        f"Task {task_name} failed, retry {attempt}"
+                        f"/{max_retries}: {e}"
+                    )
+                    await asyncio.sleep(definition.retry_delay_seconds)
 
             # Store result in cache (always enabled - either S3 or in-memory)
             result_cache_key = await self.cache_manager.store_result(
@@ -4,7 +4,8 @@ Test suite for additional API client methods
 """
 
 from uuid import UUID
-from unittest.mock import patch
+from unittest.mock import MagicMock, patch
+from pinazu import Client
 from pinazu.api.models_generated import (
     User,
     UserList,
@@ -20,6 +21,17 @@ from pinazu.api.models_generated import (
     MessageList,
     UserRoleMapping,
     RolePermissionMapping,
+    BatchResult,
+    BatchItemResult,
+    Webhook,
+    CreatedWebhook,
+    WebhookDelivery,
+    WebhookDeliveryList,
+    File,
+    ThreadExport,
+    Workspace,
+    WorkspaceMember,
+    ResourceGrant,
 )
 
 
@@ -303,9 +315,8 @@ class TestToolsAPI:
                 )
             ],
             total=1,
-            page=1,
-            per_page=10,
-            total_pages=1,
+            limit=20,
+            has_more=False,
         )
 
         mock_response = mock_responses(tools_data.model_dump(mode="json"))
@@ -340,6 +351,33 @@ class TestToolsAPI:
             assert result.description == "Updated description"
             assert result.name == "Updated Tool"
 
+    def test_batch_delete_tools(self, client, sample_uuid, mock_responses):
+        """Test deleting tools in a batch, rolled back when one is missing."""
+        missing = UUID("12345678-1234-1234-1234-123456789012")
+        batch_result = BatchResult(
+            committed=False,
+            results=[
+                BatchItemResult(index=0, id=sample_uuid, status="rolled_back"),
+                BatchItemResult(
+                    index=1, status="failed", message=f"tool {missing} not found"
+                ),
+            ],
+        )
+
+        mock_response = mock_responses(batch_result.model_dump(mode="json"))
+
+        with patch.object(
+            client, "post", return_value=mock_response
+        ) as mock_post:  # noqa: E501
+            result = client.batch_delete_tools([sample_uuid, missing])
+
+            mock_post.assert_called_once_with(
+                url="/v1/tools/batch-delete",
+                json={"ids": [str(sample_uuid), str(missing)]},
+            )
+            assert not result.committed
+            assert result.results[1].status == "failed"
+
 
 class TestThreadsAndMessagesAPI:
     """Test class for Threads and Messages API methods."""
@@ -381,9 +419,8 @@ class TestThreadsAndMessagesAPI:
                 )
             ],
             total=1,
-            page=1,
-            per_page=10,
-            total_pages=1,
+            limit=20,
+            has_more=False,
         )
 
         mock_response = mock_responses(threads_data.model_dump(mode="json"))
@@ -394,6 +431,30 @@ class TestThreadsAndMessagesAPI:
             assert len(result.threads) == 1
             assert result.threads[0].title == "Thread 1"
 
+    def test_stream_thread_events(self, client, sample_uuid, mock_responses):
+        """Test following the events of a thread, without the heartbeats."""
+        response = mock_responses({})
+        response.iter_bytes = lambda chunk_size: iter(
+            [
+                b'data: {"type":"heartbeat"}\n\n',
+                b'data: {"header":{"task_id":"t1"},"message":{"type":"task_',
+                b'start"}}\n\n',
+            ]
+        )
+        stream = MagicMock()
+        stream.__enter__.return_value = response
+
+        with patch.object(client, "stream", return_value=stream) as mock_stream:
+            events = list(client.stream_thread_events(sample_uuid, events="lifecycle"))
+
+            call_args = mock_stream.call_args
+            assert call_args[1]["url"] == f"/v1/threads/{sample_uuid}/events"
+            assert call_args[1]["params"] == {"events": "lifecycle"}
+
+            assert events == [
+                {"header": {"task_id": "t1"}, "message": {"type": "task_start"}}
+            ]
+
     def test_create_message(self, client, sample_uuid, mock_responses):
         """Test creating a message in a thread."""
         expected_message = Message(
@@ -523,3 +584,542 @@ class TestUserRolesMappingAPI:
                 user_id=sample_uuid,
                 role_id=sample_uuid,
             )
+
+
+class TestWebhooksAPI:
+    """Test class for Webhooks API methods."""
+
+    def test_create_webhook(self, client, sample_uuid, mock_responses):
+        """Test creating a webhook returns its secret."""
+        webhook = Webhook(
+            id=UUID("12345678-1234-1234-1234-123456789012"),
+            user_id=sample_uuid,
+            url="https://hooks.example.com/pinazu",
+            events=["task.finished"],
+            enabled=True,
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(
+            {"webhook": webhook.model_dump(mode="json"), "secret": "whsec_test"},
+            201,
+        )
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.create_webhook(
+                url="https://hooks.example.com/pinazu",
+                events=["task.finished"],
+            )
+
+            call_args = mock_post.call_args
+            assert call_args[1]["url"] == "/v1/webhooks"
+            assert call_args[1]["json"]["events"] == ["task.finished"]
+            assert call_args[1]["json"]["enabled"] is True
+
+            assert isinstance(result, CreatedWebhook)
+            assert result.secret == "whsec_test"
+            assert result.webhook["url"] == "https://hooks.example.com/pinazu"
+
+    def test_list_webhook_deliveries(self, client, sample_uuid, mock_responses):
+        """Test listing the failed deliveries of a webhook."""
+        delivery = WebhookDelivery(
+            id=UUID("12345678-1234-1234-1234-123456789012"),
+            webhook_id=sample_uuid,
+            event_id=UUID("87654321-4321-4321-4321-210987654321"),
+            event_type="flow_run.failed",
+            payload={"type": "flow_run.failed"},
+            status="FAILED",
+            attempts=5,
+            response_status=500,
+            created_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(
+            WebhookDeliveryList(
+                deliveries=[delivery], total=1, limit=20, has_more=False
+            ).model_dump(mode="json"),
+            200,
+        )
+
+        with patch.object(client, "get", return_value=mock_response) as mock_get:
+            result = client.list_webhook_deliveries(sample_uuid, status="FAILED")
+
+            call_args = mock_get.call_args
+            assert call_args[0][0] == f"/v1/webhooks/{sample_uuid}/deliveries"
+            assert call_args[1]["params"] == {"limit": 20, "status": "FAILED"}
+
+            assert len(result.deliveries) == 1
+            assert result.deliveries[0].response_status == 500
+
+
+class TestFilesAPI:
+    """Test class for Files API methods."""
+
+    def test_upload_file(self, client, sample_uuid, mock_responses):
+        """Test uploading a file in a multipart request."""
+        file = File(
+            id=UUID("12345678-1234-1234-1234-123456789012"),
+            user_id=sample_uuid,
+            name="photo.png",
+            content_type="image/png",
+            size_bytes=4,
+            status="UPLOADED",
+            created_at="2025-01-01T00:00:00Z",
+            uploaded_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses({"file": file.model_dump(mode="json")}, 201)
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.upload_file(b"\x89PNG", "photo.png", "image/png")
+
+            call_args = mock_post.call_args
+            assert call_args[1]["url"] == "/v1/files"
+            assert call_args[1]["files"] == {
+                "file": ("photo.png", b"\x89PNG", "image/png")
+            }
+
+            assert isinstance(result, File)
+            assert result.status == "UPLOADED"
+
+    def test_create_message_with_attachments(
+        self, client, sample_uuid, mock_responses
+    ):
+        """Test attaching uploaded files to a new message."""
+        message = Message(
+            id=UUID("12345678-1234-1234-1234-123456789012"),
+            thread_id=sample_uuid,
+            message={"role": "user", "content": "Describe the photo"},
+            sender_type="user",
+            sender_id=sample_uuid,
+            recipient_id=sample_uuid,
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(message.model_dump(mode="json"), 201)
+        file_id = UUID("87654321-4321-4321-4321-210987654321")
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            client.create_message(
+                thread_id=sample_uuid,
+                message={"role": "user", "content": "Describe the photo"},
+                recipient_id=sample_uuid,
+                sender_id=sample_uuid,
+                attachments=[file_id],
+            )
+
+            call_args = mock_post.call_args
+            assert call_args[1]["json"]["attachments"] == [str(file_id)]
+
+
+class TestThreadExportAPI:
+    """Test class for thread export and import methods."""
+
+    def _export(self, sample_uuid):
+        return ThreadExport(
+            version=1,
+            exported_at="2025-01-01T00:00:00Z",
+            thread={"id": str(sample_uuid), "title": "Support case"},
+            messages=[
+                {
+                    "sender_type": "user",
+                    "sender_id": str(sample_uuid),
+                    "recipient_id": str(sample_uuid),
+                    "message": {"role": "user", "content": "Hello"},
+                    "created_at": "2025-01-01T00:00:00Z",
+                }
+            ],
+            tool_runs=[],
+        )
+
+    def test_export_thread(self, client, sample_uuid, mock_responses):
+        """Test exporting a thread as JSON and as a Markdown transcript."""
+        export = self._export(sample_uuid)
+        mock_response = mock_responses(export.model_dump(mode="json"), 200)
+
+        with patch.object(client, "get", return_value=mock_response) as mock_get:
+            result = client.export_thread(sample_uuid)
+
+            mock_get.assert_called_once_with(
+                f"/v1/threads/{sample_uuid}/export", params={"format": "json"}
+            )
+            assert isinstance(result, ThreadExport)
+            assert result.messages[0].sender_type == "user"
+
+        mock_response = mock_responses("# Support case\n", 200)
+        with patch.object(client, "get", return_value=mock_response):
+            result = client.export_thread(sample_uuid, format="markdown")
+
+            assert result == "# Support case\n"
+
+    def test_import_thread(self, client, sample_uuid, mock_responses):
+        """Test importing an export as a new thread with its own title."""
+        thread = Thread(
+            id=UUID("12345678-1234-1234-1234-123456789012"),
+            title="Copy",
+            user_id=sample_uuid,
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(thread.model_dump(mode="json"), 201)
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.import_thread(self._export(sample_uuid), title="Copy")
+
+            call_args = mock_post.call_args
+            assert call_args[0][0] == "/v1/threads/import"
+            assert call_args[1]["params"] == {"title": "Copy"}
+            assert call_args[1]["json"]["thread"]["title"] == "Support case"
+            assert result.title == "Copy"
+
+    def test_fork_thread(self, client, sample_uuid, mock_responses):
+        """Test forking a thread from one of its messages."""
+        message_id = "87654321-4321-4321-4321-210987654321"
+        mock_response = mock_responses(
+            {
+                "id": "12345678-1234-1234-1234-123456789012",
+                "title": "Support case",
+                "user_id": str(sample_uuid),
+                "created_at": "2025-01-01T00:00:00Z",
+                "updated_at": "2025-01-01T00:00:00Z",
+                "forked_from": str(sample_uuid),
+                "forked_from_message": message_id,
+            },
+            201,
+        )
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.fork_thread(sample_uuid, from_message=UUID(message_id))
+
+            mock_post.assert_called_once_with(
+                f"/v1/threads/{sample_uuid}/fork",
+                params={"from_message": message_id},
+            )
+            assert result.forked_from == sample_uuid
+
+
+class TestWorkspacesAPI:
+    """Test class for Organizations and Workspaces API methods."""
+
+    def test_client_workspace_header(self, sample_uuid):
+        """Test the client sends the workspace it acts in with every request."""
+        with Client(workspace_id=sample_uuid) as workspace_client:
+            assert workspace_client.headers["X-Pinazu-Workspace"] == str(sample_uuid)
+        with Client() as default_client:
+            assert "X-Pinazu-Workspace" not in default_client.headers
+
+    def test_create_workspace(self, client, sample_uuid, mock_responses):
+        """Test creating a workspace in an organization."""
+        workspace = Workspace(
+            id=UUID("12345678-1234-1234-1234-123456789012"),
+            organization_id=sample_uuid,
+            name="Research",
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(workspace.model_dump(mode="json"), 201)
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.create_workspace(sample_uuid, "Research")
+
+            call_args = mock_post.call_args
+            assert call_args[1]["url"] == f"/v1/organizations/{sample_uuid}/workspaces"
+            assert call_args[1]["json"] == {"name": "Research"}
+            assert result.name == "Research"
+
+    def test_set_workspace_member(self, client, sample_uuid, mock_responses):
+        """Test adding a member to a workspace."""
+        user_id = UUID("12345678-1234-1234-1234-123456789012")
+        member = WorkspaceMember(
+            workspace_id=sample_uuid,
+            user_id=user_id,
+            role="ADMIN",
+            created_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(member.model_dump(mode="json"), 200)
+
+        with patch.object(client, "put", return_value=mock_response) as mock_put:
+            result = client.set_workspace_member(sample_uuid, user_id, role="ADMIN")
+
+            call_args = mock_put.call_args
+            assert call_args[1]["url"] == (
+                f"/v1/workspaces/{sample_uuid}/members/{user_id}"
+            )
+            assert call_args[1]["json"] == {"role": "ADMIN"}
+            assert result.role == "ADMIN"
+
+
+class TestResourceGrantsAPI:
+    """Test class for Resource Grants API methods."""
+
+    def test_set_agent_grant(self, client, sample_uuid, mock_responses):
+        """Test sharing an agent with a role."""
+        role_id = UUID("12345678-1234-1234-1234-123456789012")
+        grant = ResourceGrant(
+            id=UUID("87654321-4321-4321-4321-210987654321"),
+            resource_type="agent",
+            resource_id=sample_uuid,
+            grantee_type="ROLE",
+            grantee_id=role_id,
+            access="INVOKE",
+            granted_by=UUID("550e8400-e29b-41d4-a716-446655440000"),
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(grant.model_dump(mode="json"), 200)
+
+        with patch.object(client, "put", return_value=mock_response) as mock_put:
+            result = client.set_agent_grant(
+                sample_uuid, role_id, access="INVOKE", grantee_type="ROLE"
+            )
+
+            call_args = mock_put.call_args
+            assert call_args[1]["url"] == f"/v1/agents/{sample_uuid}/grants"
+            assert call_args[1]["json"] == {
+                "access": "INVOKE",
+                "grantee_id": str(role_id),
+                "grantee_type": "ROLE",
+            }
+            assert result.access == "INVOKE"
+
+    def test_remove_thread_grant(self, client, sample_uuid, mock_responses):
+        """Test revoking the grant of a user on a thread."""
+        user_id = UUID("12345678-1234-1234-1234-123456789012")
+        mock_response = mock_responses(None, 204)
+
+        with patch.object(client, "delete", return_value=mock_response) as mock_delete:
+            client.remove_thread_grant(sample_uuid, user_id)
+
+            mock_delete.assert_called_once_with(
+                f"/v1/threads/{sample_uuid}/grants/USER/{user_id}"
+            )
+
+
+class TestUsageAPI:
+    """Test class for Usage API methods."""
+
+    def test_get_usage(self, client, sample_uuid, mock_responses):
+        """Test getting the usage of the quotas."""
+        mock_response = mock_responses(
+            {
+                "user_id": str(sample_uuid),
+                "workspace_id": "00000000-0000-0000-0000-000000000000",
+                "quotas": [
+                    {
+                        "quota": "tokens_per_day",
+                        "scope": "USER",
+                        "used": 1200.0,
+                        "limit": 1000,
+                        "resets_at": "2025-01-02T00:00:00Z",
+                    },
+                    {"quota": "concurrent_tasks", "scope": "WORKSPACE", "used": 2.0},
+                ],
+            },
+            200,
+        )
+
+        with patch.object(client, "get", return_value=mock_response) as mock_get:
+            result = client.get_usage()
+
+            mock_get.assert_called_once_with("/v1/usage")
+            assert result.quotas[0].limit == 1000
+            assert result.quotas[1].resets_at is None
+
+
+class TestMessageSearchAPI:
+    """Test class for Message Search API methods."""
+
+    def test_search_messages(self, client, sample_uuid, mock_responses):
+        """Test searching the messages of a thread."""
+        mock_response = mock_responses(
+            {
+                "results": [
+                    {
+                        "id": "87654321-4321-4321-4321-210987654321",
+                        "thread_id": str(sample_uuid),
+                        "thread_title": "Quarterly report",
+                        "sender_type": "assistant",
+                        "sender_id": "550e8400-e29b-41d4-a716-446655440000",
+                        "created_at": "2025-01-01T00:00:00Z",
+                        "highlight": "the <mark>revenue</mark> grew",
+                        "rank": 0.6,
+                    }
+                ],
+                "page": 1,
+                "per_page": 10,
+                "total": 1,
+                "total_pages": 1,
+            },
+            200,
+        )
+
+        with patch.object(client, "get", return_value=mock_response) as mock_get:
+            result = client.search_messages("revenue", thread_id=sample_uuid)
+
+            mock_get.assert_called_once_with(
+                "/v1/search/messages",
+                params={
+                    "q": "revenue",
+                    "page": 1,
+                    "per_page": 10,
+                    "thread_id": str(sample_uuid),
+                },
+            )
+            assert result.results[0].highlight == "the <mark>revenue</mark> grew"
+
+    def test_semantic_search_messages(self, client, sample_uuid, mock_responses):
+        """Test searching the messages by meaning."""
+        mock_response = mock_responses(
+            {
+                "results": [
+                    {
+                        "id": "87654321-4321-4321-4321-210987654321",
+                        "thread_id": str(sample_uuid),
+                        "thread_title": "Quarterly report",
+                        "sender_type": "assistant",
+                        "sender_id": "550e8400-e29b-41d4-a716-446655440000",
+                        "created_at": "2025-01-01T00:00:00Z",
+                        "text": "The revenue grew by 12% over the quarter.",
+                        "similarity": 0.82,
+                    }
+                ]
+            },
+            200,
+        )
+
+        with patch.object(client, "get", return_value=mock_response) as mock_get:
+            result = client.semantic_search_messages("how did sales go", limit=5)
+
+            mock_get.assert_called_once_with(
+                "/v1/search/messages/semantic",
+                params={"q": "how did sales go", "limit": 5},
+            )
+            assert result.results[0].similarity == 0.82
+
+
+class TestMessageVersionAPI:
+    """Test class for the message edit and regenerate API methods."""
+
+    def test_edit_message(self, client, sample_uuid, mock_responses):
+        """Test editing a user message and answering the new version."""
+        message_id = "87654321-4321-4321-4321-210987654321"
+        mock_response = mock_responses(
+            {
+                "message": {
+                    "id": "11111111-1111-1111-1111-111111111111",
+                    "thread_id": str(sample_uuid),
+                    "message": {"role": "user", "content": "Hello again"},
+                    "sender_type": "user",
+                    "created_at": "2025-01-01T00:00:00Z",
+                    "updated_at": "2025-01-01T00:00:00Z",
+                    "sender_id": "550e8400-e29b-41d4-a716-446655440000",
+                    "recipient_id": str(sample_uuid),
+                    "version_of": message_id,
+                },
+                "superseded": 3,
+            },
+            200,
+        )
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.edit_message(
+                sample_uuid, message_id, {"role": "user", "content": "Hello again"}
+            )
+
+            mock_post.assert_called_once_with(
+                url=f"/v1/threads/{sample_uuid}/messages/{message_id}/edit",
+                json={"message": {"role": "user", "content": "Hello again"}},
+            )
+            assert result.superseded == 3
+            assert str(result.message.version_of) == message_id
+
+    def test_delete_message_keep_history(self, client, sample_uuid, mock_responses):
+        """Test superseding a message instead of deleting it."""
+        message_id = "87654321-4321-4321-4321-210987654321"
+        mock_response = mock_responses(None, 204)
+
+        with patch.object(client, "delete", return_value=mock_response) as mock_del:
+            client.delete_message(sample_uuid, message_id, keep_history=True)
+
+            mock_del.assert_called_once_with(
+                url=f"/v1/threads/{sample_uuid}/messages/{message_id}",
+                params={"keep_history": True},
+            )
+
+
+class TestFeedbackAPI:
+    """Test class for Feedback API methods."""
+
+    def test_set_message_feedback(self, client, sample_uuid, mock_responses):
+        """Test rating a message of an agent."""
+        message_id = "87654321-4321-4321-4321-210987654321"
+        mock_response = mock_responses(
+            {
+                "id": "11111111-1111-1111-1111-111111111111",
+                "workspace_id": "550e8400-c95b-4444-7777-000000000002",
+                "thread_id": str(sample_uuid),
+                "message_id": message_id,
+                "user_id": "550e8400-e29b-41d4-a716-446655440000",
+                "rating": "DOWN",
+                "categories": ["incorrect"],
+                "comment": "The total is wrong",
+                "created_at": "2025-01-01T00:00:00Z",
+                "updated_at": "2025-01-01T00:00:00Z",
+            },
+            200,
+        )
+
+        with patch.object(client, "put", return_value=mock_response) as mock_put:
+            result = client.set_message_feedback(
+                sample_uuid,
+                message_id,
+                "DOWN",
+                categories=["incorrect"],
+                comment="The total is wrong",
+            )
+
+            mock_put.assert_called_once_with(
+                url=f"/v1/threads/{sample_uuid}/messages/{message_id}/feedback",
+                json={
+                    "rating": "DOWN",
+                    "categories": ["incorrect"],
+                    "comment": "The total is wrong",
+                },
+            )
+            assert result.rating == "DOWN"
+            assert result.tool_run_id is None
+
+    def test_list_feedback(self, client, sample_uuid, mock_responses):
+        """Test listing the feedback of a thread."""
+        mock_response = mock_responses(
+            {"feedback": [], "page": 1, "per_page": 10, "total": 0, "total_pages": 0}
+        )
+
+        with patch.object(client, "get", return_value=mock_response) as mock_get:
+            result = client.list_feedback(rating="UP", thread_id=sample_uuid)
+
+            mock_get.assert_called_once_with(
+                "/v1/feedback",
+                params={
+                    "rating": "UP",
+                    "thread_id": str(sample_uuid),
+                    "page": 1,
+                    "per_page": 10,
+                },
+            )
+            assert result.feedback == []
+
+    def test_export_feedback(self, client, mock_responses):
+        """Test exporting the feedback as newline-delimited JSON."""
+        mock_response = mock_responses(None)
+        mock_response.text = (
+            '{"feedback": {"rating": "UP"}, "response": {"role": "assistant"}}\n'
+            '{"feedback": {"rating": "DOWN"}, "tool_run": {"status": "FAILED"}}\n'
+        )
+
+        with patch.object(client, "get", return_value=mock_response) as mock_get:
+            examples = client.export_feedback(category="incorrect")
+
+            mock_get.assert_called_once_with(
+                "/v1/feedback/export", params={"category": "incorrect"}
+            )
+            assert len(examples) == 2
+            assert examples[1]["tool_run"]["status"] == "FAILED"
@@ -90,9 +90,8 @@ class TestAgentsAPI:
                 ),
             ],
             total=2,
-            page=1,
-            per_page=10,
-            total_pages=1
+            limit=20,
+            has_more=False
         )
 
         mock_response = mock_responses(agents_data.model_dump(mode="json"))
@@ -102,7 +101,7 @@ class TestAgentsAPI:
         ) as mock_get:  # noqa: E501
             result = client.list_agents()
 
-            mock_get.assert_called_once_with("/v1/agents")
+            mock_get.assert_called_once_with("/v1/agents", params={"limit": 20})
             assert len(result.agents) == 2
             assert result.agents[0].name == "Agent 1"
             assert result.agents[1].name == "Agent 2"
@@ -137,6 +136,30 @@ class TestAgentsAPI:
             assert result.name == "Updated Agent"
             assert result.description == "Updated Description"
 
+    def test_update_agent_if_match(self, client, sample_uuid, mock_responses):
+        """Test updating an agent only while it is at the version of an ETag."""
+        updated_agent = Agent(
+            id=sample_uuid,
+            name="Updated Agent",
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T01:00:00Z",
+            created_by=sample_uuid,
+        )
+
+        mock_response = mock_responses(updated_agent.model_dump(mode="json"))
+
+        with patch.object(
+            client, "put", return_value=mock_response
+        ) as mock_put:  # noqa: E501
+            client.update_agent(
+                agent_id=sample_uuid,
+                name="Updated Agent",
+                if_match='"5f3a0c1e2b400"',
+            )
+
+            call_args = mock_put.call_args
+            assert call_args[1]["headers"] == {"If-Match": '"5f3a0c1e2b400"'}
+
     def test_delete_agent(self, client, sample_uuid, mock_responses):
         """Test deleting an agent."""
         mock_response = mock_responses(None, 204)
@@ -149,6 +172,48 @@ class TestAgentsAPI:
 
             mock_delete.assert_called_once_with(f"/v1/agents/{sample_uuid}")
 
+    def test_restore_agent(self, client, sample_uuid, mock_responses):
+        """Test restoring an agent from the trash."""
+        restored_agent = Agent(
+            id=sample_uuid,
+            name="Restored Agent",
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+            created_by=sample_uuid,
+        )
+        mock_response = mock_responses(restored_agent.model_dump(mode="json"))
+
+        with patch.object(
+            client, "post", return_value=mock_response
+        ) as mock_post:  # noqa: E501
+            result = client.restore_agent(sample_uuid)
+
+            mock_post.assert_called_once_with(f"/v1/agents/{sample_uuid}/restore")
+            assert result.name == "Restored Agent"
+            assert result.deleted_at is None
+
+    def test_invoke_agent(self, client, sample_uuid, mock_responses):
+        """Test invoking an agent without a thread or a task."""
+        messages = [{"role": "user", "content": "Hello"}]
+        mock_response = mock_responses(
+            {
+                "agent_id": str(sample_uuid),
+                "message": {"role": "assistant", "content": "Hi!"},
+                "stop_reason": "end_turn",
+            }
+        )
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.invoke_agent(sample_uuid, messages)
+
+            mock_post.assert_called_once_with(
+                url=f"/v1/agents/{sample_uuid}/invoke",
+                json={"messages": messages},
+                timeout=None,
+            )
+            assert result.message["content"] == "Hi!"
+            assert result.stop_reason == "end_turn"
+
     def test_get_nonexistent_agent(self, client, sample_uuid, mock_responses):
         """Test getting a non-existent agent returns 404."""
         error_data = {
@@ -237,9 +302,8 @@ class TestAgentsAPIAsync:
                 )
             ],
             total=1,
-            page=1,
-            per_page=10,
-            total_pages=1
+            limit=20,
+            has_more=False
         )
 
         mock_response = mock_responses(agents_data.model_dump(mode="json"))
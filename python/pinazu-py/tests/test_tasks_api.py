@@ -4,7 +4,7 @@ import pytest
 from uuid import UUID
 from unittest.mock import patch
 from pinazu import PinazuAPIError
-from pinazu.api.models_generated import Task, TaskList, TaskRun
+from pinazu.api.models_generated import Task, TaskList, TaskRun, TaskSchedule
 
 
 class TestTasksAPI:
@@ -46,6 +46,29 @@ class TestTasksAPI:
             assert result.thread_id == sample_uuid
             assert result.additional_info == {"test": "data"}
             assert result.max_request_loop == 5
+            assert call_args[1]["headers"] == {}
+
+    def test_create_task_idempotency_key(self, client, sample_uuid, mock_responses):
+        """Test creating a task with an idempotency key to retry it safely."""
+        expected_task = Task(
+            id=UUID("12345678-1234-1234-1234-123456789012"),
+            thread_id=sample_uuid,
+            created_by=sample_uuid,
+            additional_info={},
+            max_request_loop=5,
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+        )
+        mock_response = mock_responses(expected_task.model_dump(mode="json"), 201)
+
+        with patch.object(client, "post", return_value=mock_response) as mock_post:
+            result = client.create_task(
+                thread_id=sample_uuid, idempotency_key="create-task-1"
+            )
+
+            call_args = mock_post.call_args
+            assert call_args[1]["headers"] == {"Idempotency-Key": "create-task-1"}
+            assert result.id == expected_task.id
 
     def test_get_task(self, client, sample_uuid, mock_responses):
         """Test getting a task by ID."""
@@ -94,9 +117,8 @@ class TestTasksAPI:
                 ),
             ],
             total=2,
-            page=1,
-            per_page=10,
-            total_pages=1,
+            limit=20,
+            has_more=False,
         )
 
         mock_response = mock_responses(tasks_data.model_dump(mode="json"))
@@ -104,10 +126,13 @@ class TestTasksAPI:
         with patch.object(
             client, "get", return_value=mock_response
         ) as mock_get:  # noqa: E501
-            result = client.list_tasks(page=1, per_page=10)
+            result = client.list_tasks(
+                limit=10, cursor="next", status="RUNNING", sort=None
+            )
 
             mock_get.assert_called_once_with(
-                "/v1/tasks", params={"page": 1, "per_page": 10}
+                "/v1/tasks",
+                params={"limit": 10, "cursor": "next", "status": "RUNNING"},
             )
             assert len(result.tasks) == 2
 
@@ -188,6 +213,38 @@ class TestTasksAPI:
             assert result.current_loops == 1
             assert result.status == "pending"
 
+    def test_execute_task_queued(self, client, sample_uuid, mock_responses):
+        """Test queueing a run behind the started run of a task."""
+        agent_id = UUID("12345678-1234-1234-1234-123456789020")
+        expected_task_run = TaskRun(
+            task_run_id=UUID("12345678-1234-1234-1234-123456789016"),
+            task_id=sample_uuid,
+            status="SCHEDULED",
+            current_loops=0,
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+            agent_id=agent_id,
+        )
+
+        mock_response = mock_responses(
+            expected_task_run.model_dump(mode="json"),
+            202,
+        )
+
+        with patch.object(
+            client, "post", return_value=mock_response
+        ) as mock_post:  # noqa: E501
+            result = client.execute_task(
+                task_id=sample_uuid,
+                agent_id=agent_id,
+                queue=True,
+            )
+
+            call_args = mock_post.call_args
+            assert call_args[1]["json"]["queue"] is True
+            assert result.status == "SCHEDULED"
+            assert result.agent_id == agent_id
+
     def test_execute_task_streaming(self, client, sample_uuid):
         """Test executing a task with streaming."""
         agent_id = UUID("12345678-1234-1234-1234-123456789020")
@@ -278,6 +335,79 @@ class TestTasksAPI:
             assert result.status == "completed"
             assert result.current_loops == 3
 
+    def test_cancel_task_run(self, client, sample_uuid, mock_responses):
+        """Test cancelling a task run."""
+        run_id = UUID("12345678-1234-1234-1234-123456789019")
+        task_run = TaskRun(
+            task_run_id=run_id,
+            task_id=sample_uuid,
+            status="CANCELLED",
+            current_loops=2,
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:02:00Z",
+        )
+
+        mock_response = mock_responses(task_run.model_dump(mode="json"))
+
+        with patch.object(
+            client, "post", return_value=mock_response
+        ) as mock_post:  # noqa: E501
+            result = client.cancel_task_run(sample_uuid, run_id)
+
+            mock_post.assert_called_once_with(
+                f"/v1/tasks/{sample_uuid}/runs/{run_id}/cancel"
+            )
+            assert result.task_run_id == run_id
+            assert result.status == "CANCELLED"
+
+    def test_create_task_schedule(self, client, sample_uuid, mock_responses):
+        """Test creating a task schedule running in a new thread each time."""
+        schedule_id = UUID("12345678-1234-1234-1234-123456789020")
+        schedule = TaskSchedule(
+            id=schedule_id,
+            workspace_id=sample_uuid,
+            agent_id=sample_uuid,
+            name="daily-report",
+            prompt="Summarize the sales of {{ .Date }}",
+            cron_expression="0 8 * * *",
+            timezone="Europe/Paris",
+            enabled=True,
+            overlap_policy="allow",
+            next_run_at="2025-01-02T07:00:00Z",
+            created_by=sample_uuid,
+            created_at="2025-01-01T00:00:00Z",
+            updated_at="2025-01-01T00:00:00Z",
+        )
+
+        mock_response = mock_responses(schedule.model_dump(mode="json"), 201)
+
+        with patch.object(
+            client, "post", return_value=mock_response
+        ) as mock_post:  # noqa: E501
+            result = client.create_task_schedule(
+                name="daily-report",
+                agent_id=sample_uuid,
+                prompt="Summarize the sales of {{ .Date }}",
+                cron_expression="0 8 * * *",
+                timezone="Europe/Paris",
+                overlap_policy="allow",
+            )
+
+            mock_post.assert_called_once_with(
+                url="/v1/tasks/schedules",
+                json={
+                    "name": "daily-report",
+                    "agent_id": str(sample_uuid),
+                    "prompt": "Summarize the sales of {{ .Date }}",
+                    "cron_expression": "0 8 * * *",
+                    "timezone": "Europe/Paris",
+                    "overlap_policy": "allow",
+                },
+            )
+            assert result.id == schedule_id
+            assert result.thread_id is None
+            assert result.overlap_policy == "allow"
+
     def test_get_nonexistent_task(self, client, sample_uuid, mock_responses):
         """Test getting a non-existent task returns 404."""
         error_data = {
@@ -239,12 +239,18 @@ func generatePythonModels(spec *openapi3.T) error {
 			if prop.Value.Type.Includes(openapi3.TypeBoolean) {
 				return "bool"
 			}
+			if prop.Value.Type.Includes(openapi3.TypeNumber) {
+				return "float"
+			}
 			return "str"
 		},
 		"toPythonTypeWithOptional": func(prop *openapi3.SchemaRef, fieldName string, required []string, nullable bool) string {
 			// Get base type
 			baseType := "str"
-			if prop.Value.Type.Includes(openapi3.TypeString) {
+			if len(prop.Value.Type.Slice()) == 0 && prop.Value.AllOf == nil && prop.Value.OneOf == nil && prop.Value.AnyOf == nil {
+				// Untyped schemas accept any JSON value
+				baseType = "Any"
+			} else if prop.Value.Type.Includes(openapi3.TypeString) {
 				if prop.Value.Format == "uuid" {
 					baseType = "UUID"
 				} else if prop.Value.Format == "date-time" {
@@ -257,8 +263,8 @@ func generatePythonModels(spec *openapi3.T) error {
 			} else if prop.Value.Type.Includes(openapi3.TypeArray) {
 				// Handle array types
 				if prop.Value.Items != nil {
-					if prop.Value.Items.Ref != "" {
-						// Extract class name from reference
+					if prop.Value.Items.Ref != "" && prop.Value.Items.Value.Type.Includes(openapi3.TypeObject) {
+						// Extract class name from reference, the referenced enums are plain values
 						parts := strings.Split(prop.Value.Items.Ref, "/")
 						if len(parts) > 0 {
 							className := parts[len(parts)-1]
@@ -274,6 +280,8 @@ func generatePythonModels(spec *openapi3.T) error {
 				}
 			} else if prop.Value.Type.Includes(openapi3.TypeInteger) {
 				baseType = "int"
+			} else if prop.Value.Type.Includes(openapi3.TypeNumber) {
+				baseType = "float"
 			}
 
 			// Check if field is required
@@ -0,0 +1,303 @@
+//go:build ignore
+
+// generate-schema-contract derives the schema contract used by `pinazu db verify`
+// from the sqlc generated models and the SQL migrations.
+//
+// Run it from the repository root after `sqlc generate`:
+//
+//	go run scripts/generate-schema-contract.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modelsFile   = "internal/db/models.go"
+	typesFile    = "internal/db/types.go"
+	migrationDir = "sql/migrations"
+	outputFile   = "internal/db/schema_contract.go"
+)
+
+var createTableRegexp = regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+
+// goTypeUdtNames maps the Go types used in models.go to the Postgres types they can scan.
+var goTypeUdtNames = map[string][]string{
+	"uuid.UUID":          {"uuid"},
+	"pgtype.UUID":        {"uuid"},
+	"string":             {"text", "varchar", "bpchar"},
+	"pgtype.Text":        {"text", "varchar", "bpchar"},
+	"pgtype.Timestamptz": {"timestamptz"},
+	"pgtype.Timestamp":   {"timestamp"},
+	"pgtype.Date":        {"date"},
+	"pgtype.Int4":        {"int4"},
+	"int32":              {"int4"},
+	"int64":              {"int8"},
+	"float64":            {"float8"},
+	"pgtype.Float8":      {"float8"},
+	"bool":               {"bool"},
+	"pgtype.Bool":        {"bool"},
+	"JsonRaw":            {"jsonb", "json"},
+	"ToolConfig":         {"jsonb", "json"},
+	"[]JsonRaw":          {"_jsonb", "_json"},
+	"[]string":           {"_text", "_varchar"},
+	"[]byte":             {"bytea"},
+	"Vector":             {"vector"},
+}
+
+// notNullGoTypes lists the Go types that fail to scan a NULL value.
+var notNullGoTypes = map[string]bool{
+	"uuid.UUID": true,
+	"string":    true,
+	"int32":     true,
+	"int64":     true,
+	"float64":   true,
+	"bool":      true,
+}
+
+type column struct {
+	Name     string
+	Field    string
+	GoType   string
+	UdtNames []string
+	NotNull  bool
+	Enum     string
+}
+
+type table struct {
+	Name    string
+	Model   string
+	Columns []column
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	fset := token.NewFileSet()
+	models, err := parser.ParseFile(fset, modelsFile, nil, 0)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", modelsFile, err)
+	}
+	types, err := parser.ParseFile(fset, typesFile, nil, 0)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", typesFile, err)
+	}
+
+	tableNames, err := loadTableNames()
+	if err != nil {
+		return err
+	}
+	enums := loadEnums(types)
+
+	var tables []table
+	for _, decl := range models.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			name, ok := tableNames[ts.Name.Name]
+			if !ok {
+				return fmt.Errorf("error: no migration creates a table for model %s", ts.Name.Name)
+			}
+			t := table{Name: name, Model: ts.Name.Name}
+			for _, field := range st.Fields.List {
+				c, err := newColumn(field, enums)
+				if err != nil {
+					return fmt.Errorf("error in %s: %w", ts.Name.Name, err)
+				}
+				t.Columns = append(t.Columns, c)
+			}
+			tables = append(tables, t)
+		}
+	}
+
+	src, err := render(tables, enums)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputFile, src, 0644)
+}
+
+// loadTableNames maps sqlc model names to the tables created by the migrations.
+func loadTableNames() (map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(migrationDir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", file, err)
+		}
+		for _, m := range createTableRegexp.FindAllStringSubmatch(string(data), -1) {
+			names[modelName(m[1])] = m[1]
+		}
+	}
+	return names, nil
+}
+
+// modelName mirrors the sqlc struct naming: the last word is singularized and the name camel cased.
+func modelName(tableName string) string {
+	words := strings.Split(tableName, "_")
+	last := words[len(words)-1]
+	if strings.HasSuffix(last, "ies") {
+		words[len(words)-1] = strings.TrimSuffix(last, "ies") + "y"
+	} else if strings.HasSuffix(last, "s") && !strings.HasSuffix(last, "ss") {
+		words[len(words)-1] = strings.TrimSuffix(last, "s")
+	}
+	var b strings.Builder
+	for _, w := range words {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// loadEnums collects the string constants declared for each named string type in types.go.
+func loadEnums(f *ast.File) map[string][]string {
+	enums := make(map[string][]string)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			ident, ok := vs.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			for _, v := range vs.Values {
+				lit, ok := v.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil || value == "" {
+					continue
+				}
+				enums[ident.Name] = append(enums[ident.Name], value)
+			}
+		}
+	}
+	return enums
+}
+
+func newColumn(field *ast.Field, enums map[string][]string) (column, error) {
+	if len(field.Names) != 1 || field.Tag == nil {
+		return column{}, fmt.Errorf("unexpected field layout")
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return column{}, err
+	}
+	c := column{
+		Name:   reflect.StructTag(tag).Get("db"),
+		Field:  field.Names[0].Name,
+		GoType: exprString(field.Type),
+	}
+
+	baseType := strings.TrimPrefix(c.GoType, "*")
+	if _, ok := enums[baseType]; ok {
+		c.Enum = baseType
+		c.UdtNames = goTypeUdtNames["string"]
+		c.NotNull = !strings.HasPrefix(c.GoType, "*")
+		return c, nil
+	}
+	udtNames, ok := goTypeUdtNames[c.GoType]
+	if !ok {
+		return column{}, fmt.Errorf("unsupported Go type %s for field %s", c.GoType, c.Field)
+	}
+	c.UdtNames = udtNames
+	c.NotNull = notNullGoTypes[c.GoType]
+	return c, nil
+}
+
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(e.Elt)
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
+
+func render(tables []table, enums map[string][]string) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by scripts/generate-schema-contract.go. DO NOT EDIT.\n\n")
+	b.WriteString("package db\n\n")
+	b.WriteString("// schemaContract lists the tables and columns the generated queries scan into the models.\n")
+	b.WriteString("var schemaContract = []contractTable{\n")
+	for _, t := range tables {
+		fmt.Fprintf(&b, "{\nName: %q,\nModel: %q,\nColumns: []contractColumn{\n", t.Name, t.Model)
+		for _, c := range t.Columns {
+			fmt.Fprintf(&b, "{Name: %q, Field: %q, GoType: %q, UdtNames: %#v", c.Name, c.Field, c.GoType, c.UdtNames)
+			if c.NotNull {
+				b.WriteString(", NotNull: true")
+			}
+			if c.Enum != "" {
+				fmt.Fprintf(&b, ", Enum: %q", c.Enum)
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n},\n")
+	}
+	b.WriteString("}\n\n")
+
+	// Only the enums referenced by a column are part of the contract
+	used := make(map[string]bool)
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Enum != "" {
+				used[c.Enum] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b.WriteString("// schemaContractEnums lists the values defined by each enum type used in the models.\n")
+	b.WriteString("var schemaContractEnums = map[string][]string{\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "%q: %s,\n", name, strings.TrimPrefix(fmt.Sprintf("%#v", enums[name]), "[]string"))
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+	return src, nil
+}
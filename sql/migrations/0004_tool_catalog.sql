@@ -0,0 +1,27 @@
+-- +goose Up
+-- =============================================
+-- TOOL CATALOG METADATA
+-- =============================================
+
+-- Catalog metadata used by frontends to render a browsable tool marketplace
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS category VARCHAR(100);
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS icon VARCHAR(255); -- Icon name or URL
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS tags TEXT[];
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS examples JSONB; -- Array of example invocations: [{"title": "...", "description": "...", "input": {...}}]
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS documentation TEXT; -- Markdown documentation
+
+CREATE INDEX IF NOT EXISTS idx_tools_category ON tools (category);
+CREATE INDEX IF NOT EXISTS idx_tools_tags ON tools USING GIN (tags);
+
+-- Group the seeded internal tools under the system category
+UPDATE tools SET category = 'system' WHERE config->>'type' = 'internal' AND category IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tools_tags;
+DROP INDEX IF EXISTS idx_tools_category;
+
+ALTER TABLE tools DROP COLUMN IF EXISTS documentation;
+ALTER TABLE tools DROP COLUMN IF EXISTS examples;
+ALTER TABLE tools DROP COLUMN IF EXISTS tags;
+ALTER TABLE tools DROP COLUMN IF EXISTS icon;
+ALTER TABLE tools DROP COLUMN IF EXISTS category;
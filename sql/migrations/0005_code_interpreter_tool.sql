@@ -0,0 +1,21 @@
+-- +goose Up
+-- =============================================
+-- BUILT-IN CODE INTERPRETER TOOL
+-- =============================================
+
+INSERT INTO tools (id, name, description, config, created_by, category, icon, tags, documentation)
+VALUES (
+    '550e8400-c00b-8888-4444-446655447896',
+    'code_interpreter',
+    'Execute a Python or JavaScript snippet in a sandboxed process and return its output, generated files and images',
+    '{"type": "internal", "params": {"type": "object", "properties": {"language": {"type": "string", "enum": ["python", "javascript"], "description": "Programming language of the snippet"}, "code": {"type": "string", "description": "Source code to execute. Files written to the current working directory are returned as generated files"}}, "required": ["language", "code"]}}',
+    '550e8400-c95b-4444-6666-000000000000',
+    'system',
+    'terminal',
+    ARRAY['code', 'python', 'javascript'],
+    E'# Code interpreter\n\nRuns the snippet in a fresh working directory with CPU, memory and wall-clock limits.\n\n- `stdout` and `stderr` are returned as text (truncated when too large).\n- Files written to the working directory are uploaded to S3 when a bucket is configured.\n- PNG, JPEG, GIF and WebP files are also returned as image blocks.'
+)
+ON CONFLICT (name) DO NOTHING;
+
+-- +goose Down
+DELETE FROM tools WHERE name = 'code_interpreter';
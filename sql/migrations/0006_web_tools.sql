@@ -0,0 +1,31 @@
+-- +goose Up
+-- =============================================
+-- BUILT-IN WEB SEARCH AND URL FETCH TOOLS
+-- =============================================
+
+INSERT INTO tools (id, name, description, config, created_by, category, icon, tags, documentation)
+VALUES (
+    '550e8400-c00b-8888-4444-446655447897',
+    'web_search',
+    'Search the web and return the most relevant results with their titles, URLs and snippets',
+    '{"type": "internal", "params": {"type": "object", "properties": {"query": {"type": "string", "description": "Search query"}, "max_results": {"type": "integer", "minimum": 1, "maximum": 20, "description": "Maximum number of results to return"}}, "required": ["query"]}}',
+    '550e8400-c95b-4444-6666-000000000000',
+    'system',
+    'search',
+    ARRAY['web', 'search'],
+    E'# Web search\n\nQueries the search provider configured under `tools.web_search` (Tavily, Brave or SerpAPI).\n\nResults are returned as a numbered markdown list of links with a short snippet. Use `fetch_url` to read a result in full.'
+), (
+    '550e8400-c00b-8888-4444-446655447898',
+    'fetch_url',
+    'Fetch a web page and return its main content converted to markdown',
+    '{"type": "internal", "params": {"type": "object", "properties": {"url": {"type": "string", "description": "Absolute http or https URL to fetch"}, "max_chars": {"type": "integer", "minimum": 1, "description": "Maximum number of characters of content to return"}}, "required": ["url"]}}',
+    '550e8400-c95b-4444-6666-000000000000',
+    'system',
+    'globe',
+    ARRAY['web', 'fetch'],
+    E'# Fetch URL\n\nDownloads the page and converts its main content (`<main>`, `<article>` or `<body>`) to markdown. Plain text and JSON responses are returned as is.\n\n- Scripts, styles, navigation and forms are removed.\n- Content is truncated at the configured `max_chars`.\n- Private network addresses are refused unless `allow_private_networks` is enabled.'
+)
+ON CONFLICT (name) DO NOTHING;
+
+-- +goose Down
+DELETE FROM tools WHERE name IN ('web_search', 'fetch_url');
@@ -0,0 +1,21 @@
+-- +goose Up
+-- =============================================
+-- AGENT PROMPT CACHE USAGE
+-- =============================================
+
+-- Prompt cache counters per agent, used to verify that interactive requests hit the provider cache
+CREATE TABLE IF NOT EXISTS agent_prompt_cache_usage (
+    agent_id UUID PRIMARY KEY REFERENCES agents(id) ON DELETE CASCADE,
+    request_count BIGINT NOT NULL DEFAULT 0, -- Interactive requests, warmups excluded
+    cache_hit_count BIGINT NOT NULL DEFAULT 0, -- Interactive requests that read from the cache
+    input_tokens BIGINT NOT NULL DEFAULT 0, -- Uncached input tokens of interactive requests
+    cache_read_input_tokens BIGINT NOT NULL DEFAULT 0,
+    cache_creation_input_tokens BIGINT NOT NULL DEFAULT 0,
+    warmup_count BIGINT NOT NULL DEFAULT 0,
+    warmup_input_tokens BIGINT NOT NULL DEFAULT 0, -- Tokens written to the cache by warmups
+    last_warmup_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS agent_prompt_cache_usage;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- =============================================
+-- RESOURCE CHANGE HISTORY
+-- =============================================
+
+-- Versioned snapshots of agents, tools and flows with the actor and origin of each change
+CREATE TABLE IF NOT EXISTS resource_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    resource_type VARCHAR(50) NOT NULL CHECK (resource_type IN ('agent', 'tool', 'flow')),
+    resource_id UUID NOT NULL, -- No foreign key, the history outlives deleted resources
+    version INT NOT NULL,
+    action VARCHAR(50) NOT NULL CHECK (action IN ('CREATE', 'UPDATE', 'DELETE')),
+    snapshot JSONB, -- State of the resource after the change, NULL once deleted
+    changes JSONB NOT NULL DEFAULT '{}', -- Changed fields as {"field": {"from": ..., "to": ...}}
+    changed_by UUID NOT NULL,
+    source_ip VARCHAR(255),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (resource_type, resource_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_changes_resource ON resource_changes(resource_type, resource_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS resource_changes;
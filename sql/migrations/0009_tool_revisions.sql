@@ -0,0 +1,44 @@
+-- +goose Up
+-- =============================================
+-- TOOL REVISIONS
+-- =============================================
+
+-- Revision promoted on the tool, served to the agents that do not pin a revision
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS revision INT NOT NULL DEFAULT 1;
+
+-- Immutable snapshots of the description and configuration of the tools
+CREATE TABLE IF NOT EXISTS tool_revisions (
+    tool_id UUID NOT NULL REFERENCES tools(id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    description TEXT,
+    config JSONB,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tool_id, revision)
+);
+
+-- Revisions are never modified once written
+-- +goose statementbegin
+CREATE OR REPLACE FUNCTION trigger_prevent_tool_revision_update () RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'tool revisions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose statementend
+
+DROP TRIGGER IF EXISTS prevent_tool_revision_update ON tool_revisions;
+CREATE TRIGGER prevent_tool_revision_update BEFORE
+UPDATE ON tool_revisions FOR EACH ROW
+EXECUTE FUNCTION trigger_prevent_tool_revision_update ();
+
+-- The current state of the existing tools becomes their first revision
+INSERT INTO tool_revisions (tool_id, revision, description, config, created_by, created_at)
+SELECT id, 1, description, config, created_by, COALESCE(updated_at, NOW())
+FROM tools
+ON CONFLICT (tool_id, revision) DO NOTHING;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS prevent_tool_revision_update ON tool_revisions;
+DROP FUNCTION IF EXISTS trigger_prevent_tool_revision_update ();
+DROP TABLE IF EXISTS tool_revisions;
+ALTER TABLE tools DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- =============================================
+-- TOOL HEALTH
+-- =============================================
+
+-- Status of the last health check of the tools, tools without a health check stay unknown
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS status VARCHAR(50) NOT NULL DEFAULT 'unknown' CHECK (status IN ('unknown', 'healthy', 'degraded', 'unreachable'));
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS status_message TEXT; -- Error of the last failed health check
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS status_checked_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose Down
+ALTER TABLE tools DROP COLUMN IF EXISTS status_checked_at;
+ALTER TABLE tools DROP COLUMN IF EXISTS status_message;
+ALTER TABLE tools DROP COLUMN IF EXISTS status;
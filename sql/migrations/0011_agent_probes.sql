@@ -0,0 +1,41 @@
+-- +goose Up
+-- =============================================
+-- AGENT PROBES
+-- =============================================
+
+-- Synthetic conversations run on a schedule against an agent to catch broken agents before the users do
+CREATE TABLE IF NOT EXISTS agent_probes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    agent_id UUID NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    prompt TEXT NOT NULL, -- User message sent to the agent
+    expected_pattern TEXT NOT NULL, -- Regular expression the final response of the agent must match
+    interval_seconds INT NOT NULL DEFAULT 300,
+    timeout_seconds INT NOT NULL DEFAULT 120,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE, -- Tools return their mock responses instead of being called
+    status VARCHAR(50) NOT NULL DEFAULT 'unknown' CHECK (status IN ('unknown', 'passing', 'failing')),
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (agent_id, name)
+);
+
+-- Latency and correctness of every probe run
+CREATE TABLE IF NOT EXISTS agent_probe_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    probe_id UUID NOT NULL REFERENCES agent_probes(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL CHECK (status IN ('passed', 'failed', 'error', 'timeout')),
+    latency_ms INT NOT NULL, -- Time from the task execution to the final response
+    response TEXT, -- Text of the final response, truncated
+    error TEXT,
+    thread_id UUID, -- Thread of the probe conversation, no foreign key so the runs outlive deleted threads
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_probe_runs_probe ON agent_probe_runs(probe_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS agent_probe_runs;
+DROP TABLE IF EXISTS agent_probes;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- =============================================
+-- AGENT THINKING USAGE
+-- =============================================
+
+-- Extended thinking counters per agent, used to right-size the thinking budgets of the agent specs
+CREATE TABLE IF NOT EXISTS agent_thinking_usage (
+    agent_id UUID PRIMARY KEY REFERENCES agents(id) ON DELETE CASCADE,
+    request_count BIGINT NOT NULL DEFAULT 0, -- Requests with thinking enabled
+    budget_tokens BIGINT NOT NULL DEFAULT 0, -- Thinking budget of the last request
+    total_budget_tokens BIGINT NOT NULL DEFAULT 0, -- Sum of the thinking budgets of the requests
+    thinking_tokens BIGINT NOT NULL DEFAULT 0, -- Thinking tokens consumed by the requests
+    max_thinking_tokens BIGINT NOT NULL DEFAULT 0, -- Largest thinking token count of a single request
+    exhausted_count BIGINT NOT NULL DEFAULT 0, -- Requests that consumed their whole thinking budget
+    estimated_count BIGINT NOT NULL DEFAULT 0, -- Requests whose thinking tokens were estimated from the thinking content
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS agent_thinking_usage;
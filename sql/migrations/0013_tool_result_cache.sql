@@ -0,0 +1,19 @@
+-- +goose Up
+-- =============================================
+-- TOOL RESULT CACHE
+-- =============================================
+
+-- Hash of the input of the cacheable tool runs, identical calls within the cache TTL of the tool reuse their result
+ALTER TABLE tool_runs ADD COLUMN IF NOT EXISTS input_hash TEXT;
+ALTER TABLE tool_runs ADD COLUMN IF NOT EXISTS cached_from_run_id TEXT; -- Run whose result was reused, set on cache hits
+ALTER TABLE tool_runs ADD COLUMN IF NOT EXISTS cache_hits INTEGER NOT NULL DEFAULT 0; -- Later runs that reused the result of this run
+
+CREATE INDEX IF NOT EXISTS idx_tool_runs_cache ON tool_runs (tool_id, input_hash, updated_at DESC)
+    WHERE input_hash IS NOT NULL AND cached_from_run_id IS NULL AND status = 'SUCCESS';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tool_runs_cache;
+
+ALTER TABLE tool_runs DROP COLUMN IF EXISTS cache_hits;
+ALTER TABLE tool_runs DROP COLUMN IF EXISTS cached_from_run_id;
+ALTER TABLE tool_runs DROP COLUMN IF EXISTS input_hash;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- =============================================
+-- USER DEFAULT AGENT
+-- =============================================
+
+-- Agent used by the quickstart endpoint for the user, the workspace default agent of the configuration applies when unset
+ALTER TABLE users ADD COLUMN IF NOT EXISTS default_agent_id UUID REFERENCES agents(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS default_agent_id;
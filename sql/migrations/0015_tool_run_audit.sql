@@ -0,0 +1,38 @@
+-- +goose Up
+-- =============================================
+-- TOOL RUN AUDIT
+-- =============================================
+
+-- Who triggered each tool run with its redacted input and output, kept for compliance review until it expires.
+-- The entries do not reference the tool runs, tools or agents so that they outlive their deletion.
+CREATE TABLE IF NOT EXISTS tool_run_audit (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tool_run_id TEXT NOT NULL UNIQUE,
+    parent_run_id TEXT, -- Batch run the tool was called from
+    tool_id UUID NOT NULL,
+    tool_name VARCHAR(255) NOT NULL,
+    tool_revision INT NOT NULL,
+    user_id UUID NOT NULL, -- User the task or connection runs for
+    agent_id UUID NOT NULL, -- Agent that called the tool
+    recipient_id UUID NOT NULL,
+    thread_id UUID NOT NULL,
+    task_id TEXT,
+    connection_id UUID,
+    input JSONB,
+    output JSONB,
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'RUNNING', 'SUCCESS', 'FAILED')),
+    duration FLOAT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tool_run_audit_created_at ON tool_run_audit (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tool_run_audit_tool_id ON tool_run_audit (tool_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tool_run_audit_agent_id ON tool_run_audit (agent_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tool_run_audit_user_id ON tool_run_audit (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tool_run_audit_task_id ON tool_run_audit (task_id);
+CREATE INDEX IF NOT EXISTS idx_tool_run_audit_expires_at ON tool_run_audit (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS tool_run_audit;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- =============================================
+-- SUB-AGENT RUNS
+-- =============================================
+
+-- Runs of the sub-agents invoked with invoke_agent, the calling agent gets a timeout error result once past it
+ALTER TABLE tasks_runs ADD COLUMN IF NOT EXISTS deadline_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_runs_deadline_at ON tasks_runs (deadline_at)
+    WHERE deadline_at IS NOT NULL AND status IN ('SCHEDULED', 'PENDING', 'RUNNING');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_runs_deadline_at;
+
+ALTER TABLE tasks_runs DROP COLUMN IF EXISTS deadline_at;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- =============================================
+-- FLOW SCHEDULES
+-- =============================================
+
+-- Flow runs triggered on a cron expression by the flows service
+CREATE TABLE IF NOT EXISTS flow_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    flow_id UUID NOT NULL REFERENCES flows(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    cron_expression VARCHAR(255) NOT NULL, -- Standard 5 fields cron expression or a macro such as @daily
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA time zone the cron expression is evaluated in
+    parameters JSONB NOT NULL DEFAULT '{}', -- Parameters of the triggered flow runs
+    engine VARCHAR(50), -- Engine of the triggered flow runs, the engine of the flow when NULL
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    catch_up_policy VARCHAR(20) NOT NULL DEFAULT 'skip' CHECK (catch_up_policy IN ('skip', 'once', 'all')), -- Runs missed while the scheduler was down
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_flow_run_id UUID, -- No foreign key so the schedule outlives the deleted flow runs
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (flow_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_flow_schedules_next_run_at ON flow_schedules (next_run_at) WHERE enabled;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flow_schedules_next_run_at;
+
+DROP TABLE IF EXISTS flow_schedules;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN CANCELLATION
+-- =============================================
+
+-- Flow runs cancelled through the API, the worker running them stops their process and their
+-- pending or running task runs are cancelled with them
+ALTER TABLE flow_runs DROP CONSTRAINT IF EXISTS flow_runs_status_check;
+ALTER TABLE flow_runs ADD CONSTRAINT flow_runs_status_check
+    CHECK (status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'SUCCESS', 'FAILED', 'CANCELLED'));
+
+ALTER TABLE flow_task_runs DROP CONSTRAINT IF EXISTS flow_task_runs_status_check;
+ALTER TABLE flow_task_runs ADD CONSTRAINT flow_task_runs_status_check
+    CHECK (status IN ('PENDING', 'RUNNING', 'SUCCESS', 'FAILED', 'CANCELLED'));
+
+-- +goose Down
+UPDATE flow_task_runs SET status = 'FAILED' WHERE status = 'CANCELLED';
+ALTER TABLE flow_task_runs DROP CONSTRAINT IF EXISTS flow_task_runs_status_check;
+ALTER TABLE flow_task_runs ADD CONSTRAINT flow_task_runs_status_check
+    CHECK (status IN ('PENDING', 'RUNNING', 'SUCCESS', 'FAILED'));
+
+UPDATE flow_runs SET status = 'FAILED' WHERE status = 'CANCELLED';
+ALTER TABLE flow_runs DROP CONSTRAINT IF EXISTS flow_runs_status_check;
+ALTER TABLE flow_runs ADD CONSTRAINT flow_runs_status_check
+    CHECK (status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'SUCCESS', 'FAILED'));
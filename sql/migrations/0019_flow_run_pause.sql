@@ -0,0 +1,16 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN PAUSE
+-- =============================================
+
+-- Flow runs paused through the API stop before their next task, they are resumed later
+-- from the cached results of the tasks they completed
+ALTER TABLE flow_runs DROP CONSTRAINT IF EXISTS flow_runs_status_check;
+ALTER TABLE flow_runs ADD CONSTRAINT flow_runs_status_check
+    CHECK (status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'PAUSED', 'SUCCESS', 'FAILED', 'CANCELLED'));
+
+-- +goose Down
+UPDATE flow_runs SET status = 'CANCELLED', finished_at = NOW() WHERE status = 'PAUSED';
+ALTER TABLE flow_runs DROP CONSTRAINT IF EXISTS flow_runs_status_check;
+ALTER TABLE flow_runs ADD CONSTRAINT flow_runs_status_check
+    CHECK (status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'SUCCESS', 'FAILED', 'CANCELLED'));
@@ -0,0 +1,21 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN TASK GRAPH
+-- =============================================
+
+-- The task DAG of a flow run as reported by the flow library when the run starts,
+-- the live status of each task is kept in flow_task_runs
+CREATE TABLE IF NOT EXISTS flow_run_graph_tasks (
+    flow_run_id UUID NOT NULL REFERENCES flow_runs(flow_run_id) ON DELETE CASCADE,
+    task_name VARCHAR(255) NOT NULL,
+    depends_on TEXT[] NOT NULL DEFAULT '{}', -- Names of the tasks this task waits for
+    max_retries INTEGER NOT NULL DEFAULT 0,
+    retry_delay_seconds INTEGER NOT NULL DEFAULT 0,
+    position INTEGER NOT NULL DEFAULT 0, -- Declaration order of the task in the flow
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (flow_run_id, task_name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS flow_run_graph_tasks;
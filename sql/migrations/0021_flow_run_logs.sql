@@ -0,0 +1,19 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN LOGS
+-- =============================================
+
+-- The output of the flow processes, captured by the workers in chunks of lines.
+-- The id orders the chunks of a run and is the cursor of the log tailing.
+CREATE TABLE IF NOT EXISTS flow_run_logs (
+    id BIGSERIAL PRIMARY KEY,
+    flow_run_id UUID NOT NULL REFERENCES flow_runs(flow_run_id) ON DELETE CASCADE,
+    stream VARCHAR(10) NOT NULL CHECK (stream IN ('stdout', 'stderr')),
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_flow_run_logs_flow_run_id ON flow_run_logs(flow_run_id, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS flow_run_logs;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN ARTIFACTS
+-- =============================================
+
+-- The named outputs published by the tasks of a flow run, uploaded to object storage by the worker.
+-- An artifact published again under the same name replaces the previous one.
+CREATE TABLE IF NOT EXISTS flow_run_artifacts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    flow_run_id UUID NOT NULL REFERENCES flow_runs(flow_run_id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    task_name VARCHAR(255), -- Task that published the artifact, NULL when published by the flow itself
+    content_type VARCHAR(255) NOT NULL DEFAULT 'application/octet-stream',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    bucket VARCHAR(255) NOT NULL,
+    object_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (flow_run_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS flow_run_artifacts;
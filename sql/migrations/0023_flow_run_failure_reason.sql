@@ -0,0 +1,11 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN FAILURE REASON
+-- =============================================
+
+-- Classifies why a flow run failed, e.g. RESOURCE_EXCEEDED when the worker killed its process for going
+-- over the resource limits. NULL for the failures of the flow itself.
+ALTER TABLE flow_runs ADD COLUMN IF NOT EXISTS failure_reason VARCHAR(50);
+
+-- +goose Down
+ALTER TABLE flow_runs DROP COLUMN IF EXISTS failure_reason;
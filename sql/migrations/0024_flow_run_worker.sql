@@ -0,0 +1,14 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN WORKER
+-- =============================================
+
+-- The worker running the process of a flow run, registered in worker_heartbeats. The active runs of a worker
+-- whose heartbeat stopped are rescheduled on another worker.
+ALTER TABLE flow_runs ADD COLUMN IF NOT EXISTS worker_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_flow_runs_worker_id ON flow_runs(worker_id) WHERE worker_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flow_runs_worker_id;
+ALTER TABLE flow_runs DROP COLUMN IF EXISTS worker_id;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN PARENT
+-- =============================================
+
+-- The flow run that triggered a sub-flow run. The parent records its child runs and can wait for them to complete,
+-- as the parent tool runs do.
+ALTER TABLE flow_runs ADD COLUMN IF NOT EXISTS parent_run_id UUID REFERENCES flow_runs(flow_run_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_flow_runs_parent_run_id ON flow_runs(parent_run_id) WHERE parent_run_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flow_runs_parent_run_id;
+ALTER TABLE flow_runs DROP COLUMN IF EXISTS parent_run_id;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- =============================================
+-- FLOW NOTIFICATIONS
+-- =============================================
+
+-- Webhooks and Slack channels notified by the flows service when a run of the flow succeeds or fails
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS notifications JSONB DEFAULT '[]'::jsonb;
+
+-- +goose Down
+ALTER TABLE flows DROP COLUMN IF EXISTS notifications;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- =============================================
+-- FLOW REQUIRED LABELS
+-- =============================================
+
+-- Labels a worker must advertise to execute the runs of the flow, e.g. gpu or python3.12
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS required_labels TEXT[];
+
+-- +goose Down
+ALTER TABLE flows DROP COLUMN IF EXISTS required_labels;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- =============================================
+-- FLOW SCHEDULE BACKFILLS
+-- =============================================
+
+-- Runs of a schedule triggered over a past time range, e.g. while the schedule was disabled or the scheduler down.
+-- The cron expression, time zone, parameters and engine of the schedule are copied so the runs do not change with it.
+CREATE TABLE IF NOT EXISTS flow_schedule_backfills (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    schedule_id UUID NOT NULL REFERENCES flow_schedules(id) ON DELETE CASCADE,
+    flow_id UUID NOT NULL REFERENCES flows(id) ON DELETE CASCADE,
+    cron_expression VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    parameters JSONB NOT NULL DEFAULT '{}', -- Parameters of the runs, the scheduled_time parameter is set for each run
+    engine VARCHAR(50),
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL, -- First time of the range, inclusive
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Last time of the range, inclusive
+    max_concurrent_runs INTEGER NOT NULL, -- Runs of the backfill scheduled or running at once
+    status VARCHAR(20) NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED', 'CANCELLED')),
+    total_runs INTEGER NOT NULL,
+    triggered_runs INTEGER NOT NULL DEFAULT 0, -- Runs triggered so far, in the order of their scheduled time
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_flow_schedule_backfills_schedule_id ON flow_schedule_backfills (schedule_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_flow_schedule_backfills_running ON flow_schedule_backfills (created_at) WHERE status = 'RUNNING';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flow_schedule_backfills_running;
+DROP INDEX IF EXISTS idx_flow_schedule_backfills_schedule_id;
+
+DROP TABLE IF EXISTS flow_schedule_backfills;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- =============================================
+-- FLOW CONCURRENCY LIMITS
+-- =============================================
+
+-- Runs of the flow scheduled or running at once, unlimited when NULL. The runs beyond it are queued or rejected.
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS max_concurrent_runs INTEGER CHECK (max_concurrent_runs > 0);
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS concurrency_policy VARCHAR(16) NOT NULL DEFAULT 'QUEUE' CHECK (concurrency_policy IN ('QUEUE', 'REJECT'));
+
+-- Runs waiting for a run of their flow to end, they stay SCHEDULED and are dispatched to the workers in order
+CREATE TABLE IF NOT EXISTS flow_run_queue (
+    flow_run_id UUID PRIMARY KEY REFERENCES flow_runs(flow_run_id) ON DELETE CASCADE,
+    flow_id UUID NOT NULL REFERENCES flows(id) ON DELETE CASCADE,
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_flow_run_queue_flow ON flow_run_queue (flow_id, queued_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flow_run_queue_flow;
+DROP TABLE IF EXISTS flow_run_queue;
+ALTER TABLE flows DROP COLUMN IF EXISTS concurrency_policy;
+ALTER TABLE flows DROP COLUMN IF EXISTS max_concurrent_runs;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- =============================================
+-- FLOW RUN COST ATTRIBUTION
+-- =============================================
+
+-- Flow run a task was created by, the LLM tokens of the task and of its sub-agent tasks are attributed to the run
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS flow_run_id UUID REFERENCES flow_runs(flow_run_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_flow_run_id ON tasks (flow_run_id) WHERE flow_run_id IS NOT NULL;
+
+-- Resources consumed by a flow run over all its attempts, rolled up per flow and per user for chargeback.
+-- The wall time is read from the started_at and finished_at of the run.
+CREATE TABLE IF NOT EXISTS flow_run_costs (
+    flow_run_id UUID PRIMARY KEY REFERENCES flow_runs(flow_run_id) ON DELETE CASCADE,
+    flow_id UUID NOT NULL REFERENCES flows(id) ON DELETE CASCADE,
+    user_id UUID, -- User who requested the run, NULL when unknown
+    cpu_seconds DOUBLE PRECISION NOT NULL DEFAULT 0, -- User and system CPU time of the flow processes on the workers
+    llm_requests BIGINT NOT NULL DEFAULT 0, -- Model requests of the agents invoked by the tasks of the run
+    input_tokens BIGINT NOT NULL DEFAULT 0,
+    output_tokens BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_flow_run_costs_flow ON flow_run_costs (flow_id);
+CREATE INDEX IF NOT EXISTS idx_flow_run_costs_user ON flow_run_costs (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flow_run_costs_user;
+DROP INDEX IF EXISTS idx_flow_run_costs_flow;
+DROP TABLE IF EXISTS flow_run_costs;
+DROP INDEX IF EXISTS idx_tasks_flow_run_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS flow_run_id;
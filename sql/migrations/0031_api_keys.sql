@@ -0,0 +1,33 @@
+-- +goose Up
+-- =============================================
+-- API KEYS AND SERVICE ACCOUNTS
+-- =============================================
+
+-- Service accounts are users of the service_account provider, authenticated by their API keys only
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_provider_name_check;
+ALTER TABLE users ADD CONSTRAINT users_provider_name_check CHECK (provider_name IN ('local', 'google', 'azure', 'github', 'service_account'));
+
+-- API keys of the programmatic clients, sent as Authorization: Bearer pk_... Only the SHA-256 hash of a key is stored,
+-- the key is shown once when it is created.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL, -- Start of the key, to recognize it in the list of keys
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL, -- read, write or admin
+    expires_at TIMESTAMPTZ, -- The key never expires when NULL
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON api_keys (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_api_keys_user;
+DROP TABLE IF EXISTS api_keys;
+DELETE FROM users WHERE provider_name = 'service_account';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_provider_name_check;
+ALTER TABLE users ADD CONSTRAINT users_provider_name_check CHECK (provider_name IN ('local', 'google', 'azure', 'github'));
@@ -0,0 +1,25 @@
+-- +goose Up
+-- =============================================
+-- USER SESSIONS
+-- =============================================
+
+-- Sessions of the users logged in with the OIDC provider, sent as the pinazu_session cookie. Only the SHA-256 hash
+-- of a session token is stored.
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    user_agent TEXT,
+    ip_address VARCHAR(64),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user ON user_sessions (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_expires_at ON user_sessions (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_sessions_expires_at;
+DROP INDEX IF EXISTS idx_user_sessions_user;
+DROP TABLE IF EXISTS user_sessions;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- =============================================
+-- LIST PAGINATION
+-- =============================================
+
+-- The lists are paged with a cursor on their sort key, the id breaking the ties between equal keys
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_threads_user_updated_at_id ON threads (user_id, updated_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_flow_runs_flow_created_at_id ON flow_runs (flow_id, created_at DESC, flow_run_id DESC);
+CREATE INDEX IF NOT EXISTS idx_tools_created_at_id ON tools (created_at DESC, id DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tools_created_at_id;
+DROP INDEX IF EXISTS idx_flow_runs_flow_created_at_id;
+DROP INDEX IF EXISTS idx_threads_user_updated_at_id;
+DROP INDEX IF EXISTS idx_tasks_created_at_id;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- =============================================
+-- LIST FILTERS AND SORTS
+-- =============================================
+
+-- Sort keys of the lists besides the defaults of 0033, the id breaking the ties between equal keys
+CREATE INDEX IF NOT EXISTS idx_tasks_updated_at_id ON tasks (updated_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_threads_user_created_at_id ON threads (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_threads_user_title_id ON threads (user_id, title, id);
+CREATE INDEX IF NOT EXISTS idx_flow_runs_flow_updated_at_id ON flow_runs (flow_id, updated_at DESC, flow_run_id DESC);
+
+-- Filters of the lists
+CREATE INDEX IF NOT EXISTS idx_tasks_created_by_created_at ON tasks (created_by, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tasks_runs_task_created_at ON tasks_runs (task_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_flow_runs_flow_status_created_at ON flow_runs (flow_id, status, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flow_runs_flow_status_created_at;
+DROP INDEX IF EXISTS idx_tasks_runs_task_created_at;
+DROP INDEX IF EXISTS idx_tasks_created_by_created_at;
+DROP INDEX IF EXISTS idx_flow_runs_flow_updated_at_id;
+DROP INDEX IF EXISTS idx_threads_user_title_id;
+DROP INDEX IF EXISTS idx_threads_user_created_at_id;
+DROP INDEX IF EXISTS idx_tasks_updated_at_id;
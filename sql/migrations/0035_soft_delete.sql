@@ -0,0 +1,54 @@
+-- +goose Up
+-- =============================================
+-- SOFT DELETE
+-- =============================================
+
+-- A deleted resource stays in the trash until it is restored or purged
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE threads ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- The names of the agents and the tools in the trash can be taken again
+ALTER TABLE agents DROP CONSTRAINT IF EXISTS agents_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agents_name_live ON agents (name) WHERE deleted_at IS NULL;
+ALTER TABLE tools DROP CONSTRAINT IF EXISTS tools_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tools_name_live ON tools (name) WHERE deleted_at IS NULL;
+
+-- Lists of the trash
+CREATE INDEX IF NOT EXISTS idx_agents_deleted_at ON agents (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tools_deleted_at ON tools (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_threads_user_deleted_at ON threads (user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_flows_deleted_at ON flows (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Restores are recorded in the history of the resources
+ALTER TABLE resource_changes DROP CONSTRAINT IF EXISTS resource_changes_action_check;
+ALTER TABLE resource_changes ADD CONSTRAINT resource_changes_action_check
+    CHECK (action IN ('CREATE', 'UPDATE', 'DELETE', 'RESTORE'));
+
+-- +goose Down
+ALTER TABLE resource_changes DROP CONSTRAINT IF EXISTS resource_changes_action_check;
+DELETE FROM resource_changes WHERE action = 'RESTORE';
+ALTER TABLE resource_changes ADD CONSTRAINT resource_changes_action_check
+    CHECK (action IN ('CREATE', 'UPDATE', 'DELETE'));
+
+DROP INDEX IF EXISTS idx_flows_deleted_at;
+DROP INDEX IF EXISTS idx_threads_user_deleted_at;
+DROP INDEX IF EXISTS idx_tools_deleted_at;
+DROP INDEX IF EXISTS idx_agents_deleted_at;
+
+-- The resources in the trash are purged, their names could conflict with the live ones
+DELETE FROM flows WHERE deleted_at IS NOT NULL;
+DELETE FROM threads WHERE deleted_at IS NOT NULL;
+DELETE FROM tools WHERE deleted_at IS NOT NULL;
+DELETE FROM agents WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_tools_name_live;
+ALTER TABLE tools ADD CONSTRAINT tools_name_key UNIQUE (name);
+DROP INDEX IF EXISTS idx_agents_name_live;
+ALTER TABLE agents ADD CONSTRAINT agents_name_key UNIQUE (name);
+
+ALTER TABLE flows DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE threads DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE tools DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE agents DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- =============================================
+-- FILES
+-- =============================================
+
+-- Files uploaded by the users to the S3 storage, either through the API or to a presigned URL. A file uploaded to a
+-- presigned URL stays PENDING until its upload is completed.
+CREATE TABLE IF NOT EXISTS files (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL DEFAULT 'application/octet-stream',
+    size_bytes BIGINT NOT NULL DEFAULT 0, -- Declared size of a pending upload, size of the stored object once uploaded
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'UPLOADED')),
+    bucket VARCHAR(255) NOT NULL,
+    object_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    uploaded_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_files_user ON files (user_id, created_at DESC, id DESC);
+
+-- Files attached to the messages of the threads, given to the agents as image or document content blocks when the
+-- thread is executed. A file cannot be deleted while it is attached to a message.
+CREATE TABLE IF NOT EXISTS message_attachments (
+    message_id UUID NOT NULL REFERENCES thread_messages(id) ON DELETE CASCADE,
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE RESTRICT,
+    position INTEGER NOT NULL, -- Order of the attachment in the message
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, file_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_attachments_file ON message_attachments (file_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_message_attachments_file;
+DROP TABLE IF EXISTS message_attachments;
+DROP INDEX IF EXISTS idx_files_user;
+DROP TABLE IF EXISTS files;
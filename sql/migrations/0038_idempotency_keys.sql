@@ -0,0 +1,26 @@
+-- +goose Up
+-- =============================================
+-- IDEMPOTENCY KEYS
+-- =============================================
+
+-- Idempotency-Key headers of the POST requests creating tasks and tools or executing tasks and flows. The response of
+-- the first request of a key is replayed to its retries, a key reused for another request is rejected. The response
+-- is NULL while the first request is in progress, and its body is NULL when it is too large to be replayed.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    request_hash TEXT NOT NULL, -- SHA-256 of the method, URI and body of the request
+    status_code INTEGER,
+    content_type TEXT NOT NULL DEFAULT '',
+    response_body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW() + INTERVAL '24 hours',
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,104 @@
+-- +goose Up
+-- =============================================
+-- ORGANIZATIONS AND WORKSPACES
+-- =============================================
+
+-- An organization groups the workspaces of a team, its owners and admins manage its members and workspaces
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'MEMBER' CHECK (role IN ('OWNER', 'ADMIN', 'MEMBER')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user ON organization_members (user_id);
+
+-- A workspace isolates the agents, tools, threads and flows of its members. The requests and the NATS events
+-- carry the workspace they act in, the resources of the other workspaces are not found.
+CREATE TABLE IF NOT EXISTS workspaces (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (organization_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'MEMBER' CHECK (role IN ('ADMIN', 'MEMBER')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user ON workspace_members (user_id);
+
+-- The default workspace holds the resources created before the workspaces, and the resources of the requests
+-- without a workspace. Every user can act in it.
+INSERT INTO organizations (id, name)
+VALUES ('550e8400-c95b-4444-7777-000000000001', 'Default')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO workspaces (id, organization_id, name)
+VALUES ('550e8400-c95b-4444-7777-000000000002', '550e8400-c95b-4444-7777-000000000001', 'Default')
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS workspace_id UUID NOT NULL
+    DEFAULT '550e8400-c95b-4444-7777-000000000002' REFERENCES workspaces(id);
+ALTER TABLE threads ADD COLUMN IF NOT EXISTS workspace_id UUID NOT NULL
+    DEFAULT '550e8400-c95b-4444-7777-000000000002' REFERENCES workspaces(id);
+ALTER TABLE flows ADD COLUMN IF NOT EXISTS workspace_id UUID NOT NULL
+    DEFAULT '550e8400-c95b-4444-7777-000000000002' REFERENCES workspaces(id);
+
+-- The built-in and internal tools have no workspace, they are shared by every workspace and cannot be changed from one
+ALTER TABLE tools ADD COLUMN IF NOT EXISTS workspace_id UUID REFERENCES workspaces(id);
+UPDATE tools SET workspace_id = '550e8400-c95b-4444-7777-000000000002'
+WHERE NOT (created_by = '550e8400-c95b-4444-6666-000000000000' AND config->>'type' = 'internal');
+
+-- The names of the agents and the tools are unique in their workspace
+DROP INDEX IF EXISTS idx_agents_name_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agents_workspace_name_live ON agents (workspace_id, name) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_tools_name_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tools_workspace_name_live ON tools (workspace_id, name)
+    WHERE deleted_at IS NULL AND workspace_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tools_shared_name_live ON tools (name) WHERE deleted_at IS NULL AND workspace_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_threads_workspace_user ON threads (workspace_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_flows_workspace ON flows (workspace_id, name);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_flows_workspace;
+DROP INDEX IF EXISTS idx_threads_workspace_user;
+
+-- The resources of the other workspaces are deleted, their names could conflict with the ones of the default workspace
+DELETE FROM flows WHERE workspace_id <> '550e8400-c95b-4444-7777-000000000002';
+DELETE FROM threads WHERE workspace_id <> '550e8400-c95b-4444-7777-000000000002';
+DELETE FROM tools WHERE workspace_id <> '550e8400-c95b-4444-7777-000000000002';
+DELETE FROM agents WHERE workspace_id <> '550e8400-c95b-4444-7777-000000000002';
+
+DROP INDEX IF EXISTS idx_tools_shared_name_live;
+DROP INDEX IF EXISTS idx_tools_workspace_name_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tools_name_live ON tools (name) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_agents_workspace_name_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agents_name_live ON agents (name) WHERE deleted_at IS NULL;
+
+ALTER TABLE tools DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE flows DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE threads DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE agents DROP COLUMN IF EXISTS workspace_id;
+
+DROP INDEX IF EXISTS idx_workspace_members_user;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
+DROP INDEX IF EXISTS idx_organization_members_user;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
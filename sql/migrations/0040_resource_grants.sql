@@ -0,0 +1,27 @@
+-- +goose Up
+-- =============================================
+-- RESOURCE GRANTS
+-- =============================================
+
+-- A grant shares an agent, a tool or a thread with a user, or with the users of a role. READ shows the resource,
+-- INVOKE also runs the agent or the tool or posts to the thread, MANAGE also changes the resource and its grants.
+-- The agents and the tools without grants stay open to their workspace, the first grant restricts them to their
+-- creator and the grantees. The threads are always restricted to their owner and the grantees.
+CREATE TABLE IF NOT EXISTS resource_grants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    resource_type VARCHAR(50) NOT NULL CHECK (resource_type IN ('agent', 'tool', 'thread')),
+    resource_id UUID NOT NULL, -- No foreign key, the grants of a purged resource are deleted with it
+    grantee_type VARCHAR(50) NOT NULL CHECK (grantee_type IN ('USER', 'ROLE')),
+    grantee_id UUID NOT NULL,
+    access VARCHAR(50) NOT NULL CHECK (access IN ('READ', 'INVOKE', 'MANAGE')),
+    granted_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (resource_type, resource_id, grantee_type, grantee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_grants_grantee ON resource_grants (grantee_type, grantee_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_resource_grants_grantee;
+DROP TABLE IF EXISTS resource_grants;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- =============================================
+-- USAGE QUOTAS
+-- =============================================
+
+-- Daily usage of each user in each workspace, checked against the quotas of the users and of the workspaces. The
+-- days are UTC days, the quotas reset at midnight UTC. The flow minutes are read from the flow runs and the
+-- concurrent tasks from the task runs. No foreign keys, the usage outlives the users and the workspaces it counts.
+CREATE TABLE IF NOT EXISTS daily_usage (
+    workspace_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    day DATE NOT NULL,
+    input_tokens BIGINT NOT NULL DEFAULT 0, -- Tokens of the model requests of the agents
+    output_tokens BIGINT NOT NULL DEFAULT 0,
+    tool_runs BIGINT NOT NULL DEFAULT 0, -- Tool runs dispatched, the calls within a batch counted one by one
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, user_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_usage_user ON daily_usage (user_id, day);
+
+-- The runs overlapping the current day are looked up by their end, the active task runs by their status
+CREATE INDEX IF NOT EXISTS idx_flow_runs_finished_at ON flow_runs (finished_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_runs_active ON tasks_runs (task_id) WHERE status IN ('SCHEDULED', 'PENDING', 'RUNNING');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_runs_active;
+DROP INDEX IF EXISTS idx_flow_runs_finished_at;
+DROP INDEX IF EXISTS idx_daily_usage_user;
+DROP TABLE IF EXISTS daily_usage;
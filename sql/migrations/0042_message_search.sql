@@ -0,0 +1,27 @@
+-- +goose Up
+-- =============================================
+-- MESSAGE SEARCH
+-- =============================================
+
+-- Text of a message searched by the full-text search, its string content or the text of its text blocks. The
+-- thinking, tool use and tool result blocks are not searched. Immutable to be indexed.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION message_search_text (message JSONB) RETURNS TEXT AS $$
+    SELECT CASE jsonb_typeof(message->'content')
+        WHEN 'string' THEN message->>'content'
+        WHEN 'array' THEN COALESCE((
+            SELECT string_agg(block->>'text', E'\n')
+            FROM jsonb_array_elements(message->'content') AS block
+            WHERE block->>'type' = 'text'
+        ), '')
+        ELSE ''
+    END;
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+-- +goose StatementEnd
+
+-- The searches match the english stems of the words, the queries must use the same expression to hit the index
+CREATE INDEX IF NOT EXISTS idx_thread_messages_search ON thread_messages USING GIN (to_tsvector('english', message_search_text(message)));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_thread_messages_search;
+DROP FUNCTION IF EXISTS message_search_text (JSONB);
@@ -0,0 +1,26 @@
+-- +goose Up
+-- =============================================
+-- MESSAGE EMBEDDINGS
+-- =============================================
+
+-- The embeddings are stored in pgvector columns, the database must have the pgvector extension available
+CREATE EXTENSION IF NOT EXISTS vector;
+
+-- Embedding of the text of a message, written by the embeddings service after the message is created or changed. A
+-- message is embedded again when it is updated after its embedding or when the embedding model changes.
+CREATE TABLE IF NOT EXISTS message_embeddings (
+    message_id UUID PRIMARY KEY REFERENCES thread_messages(id) ON DELETE CASCADE,
+    thread_id UUID NOT NULL REFERENCES threads(id) ON DELETE CASCADE,
+    model VARCHAR(255) NOT NULL,
+    embedding vector(1024) NOT NULL,
+    embedded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_embeddings_thread_id ON message_embeddings (thread_id);
+-- The semantic searches order the messages by cosine distance, the queries must use the <=> operator to hit the index
+CREATE INDEX IF NOT EXISTS idx_message_embeddings_embedding ON message_embeddings USING hnsw (embedding vector_cosine_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_message_embeddings_embedding;
+DROP INDEX IF EXISTS idx_message_embeddings_thread_id;
+DROP TABLE IF EXISTS message_embeddings;
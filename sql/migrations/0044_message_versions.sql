@@ -0,0 +1,17 @@
+-- +goose Up
+-- =============================================
+-- MESSAGE VERSIONS
+-- =============================================
+
+-- Editing, deleting or regenerating a message of a thread supersedes the message and the messages after it. The
+-- superseded messages are kept as the history of the thread but are no longer sent to the agents. An edited message
+-- is a new message pointing to the message it replaces.
+ALTER TABLE thread_messages ADD COLUMN IF NOT EXISTS superseded_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE thread_messages ADD COLUMN IF NOT EXISTS version_of UUID REFERENCES thread_messages(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_thread_messages_live ON thread_messages (thread_id, created_at) WHERE superseded_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_thread_messages_live;
+ALTER TABLE thread_messages DROP COLUMN IF EXISTS version_of;
+ALTER TABLE thread_messages DROP COLUMN IF EXISTS superseded_at;
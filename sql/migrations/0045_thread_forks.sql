@@ -0,0 +1,16 @@
+-- +goose Up
+-- =============================================
+-- THREAD FORKS
+-- =============================================
+
+-- A fork is a new thread starting with a copy of the live messages of another thread up to a message. The original
+-- thread is left untouched, the fork keeps a reference to it and to the message it was forked from.
+ALTER TABLE threads ADD COLUMN IF NOT EXISTS forked_from UUID REFERENCES threads(id) ON DELETE SET NULL;
+ALTER TABLE threads ADD COLUMN IF NOT EXISTS forked_from_message UUID REFERENCES thread_messages(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_threads_forked_from ON threads (forked_from);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_threads_forked_from;
+ALTER TABLE threads DROP COLUMN IF EXISTS forked_from_message;
+ALTER TABLE threads DROP COLUMN IF EXISTS forked_from;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- =============================================
+-- FEEDBACK
+-- =============================================
+
+-- Rating of an agent message or of a tool run by a user, with categories and a comment. A user rates a message or a
+-- tool run once, rating it again replaces the feedback. The feedback is exported as a dataset for the evaluation and
+-- the fine-tuning of the agents.
+CREATE TABLE IF NOT EXISTS feedback (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    thread_id UUID NOT NULL REFERENCES threads(id) ON DELETE CASCADE,
+    message_id UUID REFERENCES thread_messages(id) ON DELETE CASCADE,
+    tool_run_id TEXT REFERENCES tool_runs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating VARCHAR(10) NOT NULL CHECK (rating IN ('UP', 'DOWN')),
+    categories TEXT[] NOT NULL DEFAULT '{}', -- Free labels of the feedback, e.g. incorrect or too_long
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK ((message_id IS NULL) <> (tool_run_id IS NULL)) -- Either a message or a tool run is rated
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feedback_user_message ON feedback (user_id, message_id) WHERE message_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feedback_user_tool_run ON feedback (user_id, tool_run_id) WHERE tool_run_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_feedback_workspace_created_at ON feedback (workspace_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_feedback_workspace_created_at;
+DROP INDEX IF EXISTS idx_feedback_user_tool_run;
+DROP INDEX IF EXISTS idx_feedback_user_message;
+DROP TABLE IF EXISTS feedback;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- =============================================
+-- TASK RUN CANCELLATION
+-- =============================================
+
+-- Task runs cancelled through the API, the agent loop stops and the tool runs still in flight
+-- are cancelled with them
+ALTER TABLE tasks_runs DROP CONSTRAINT IF EXISTS tasks_runs_status_check;
+ALTER TABLE tasks_runs ADD CONSTRAINT tasks_runs_status_check
+    CHECK (status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'FINISHED', 'FAILED', 'CANCELLED'));
+
+ALTER TABLE tool_runs DROP CONSTRAINT IF EXISTS tool_runs_status_check;
+ALTER TABLE tool_runs ADD CONSTRAINT tool_runs_status_check
+    CHECK (status IN ('PENDING', 'RUNNING', 'SUCCESS', 'FAILED', 'CANCELLED'));
+
+-- +goose Down
+UPDATE tool_runs SET status = 'FAILED' WHERE status = 'CANCELLED';
+ALTER TABLE tool_runs DROP CONSTRAINT IF EXISTS tool_runs_status_check;
+ALTER TABLE tool_runs ADD CONSTRAINT tool_runs_status_check
+    CHECK (status IN ('PENDING', 'RUNNING', 'SUCCESS', 'FAILED'));
+
+UPDATE tasks_runs SET status = 'FAILED' WHERE status = 'CANCELLED';
+ALTER TABLE tasks_runs DROP CONSTRAINT IF EXISTS tasks_runs_status_check;
+ALTER TABLE tasks_runs ADD CONSTRAINT tasks_runs_status_check
+    CHECK (status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'FINISHED', 'FAILED'));
@@ -0,0 +1,32 @@
+-- +goose Up
+-- =============================================
+-- TASK SCHEDULES
+-- =============================================
+
+-- Agents invoked on a cron expression by the task service, in a thread or in a new thread for each run
+CREATE TABLE IF NOT EXISTS task_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    agent_id UUID NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+    thread_id UUID REFERENCES threads(id) ON DELETE CASCADE, -- Thread the agent is invoked in, a new thread for each run when NULL
+    name VARCHAR(255) NOT NULL,
+    prompt TEXT NOT NULL, -- Go template of the user message sent to the agent
+    cron_expression VARCHAR(255) NOT NULL, -- Standard 5 fields cron expression or a macro such as @daily
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA time zone the cron expression is evaluated in
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    overlap_policy VARCHAR(20) NOT NULL DEFAULT 'skip' CHECK (overlap_policy IN ('skip', 'queue', 'allow')), -- Runs due while the previous run is still running
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_thread_id UUID, -- No foreign key so the schedule outlives the deleted threads of its runs
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (workspace_id, created_by, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_schedules_next_run_at ON task_schedules (next_run_at) WHERE enabled;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_schedules_next_run_at;
+
+DROP TABLE IF EXISTS task_schedules;
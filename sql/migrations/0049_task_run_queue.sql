@@ -0,0 +1,36 @@
+-- +goose Up
+-- =============================================
+-- TASK RUN QUEUE
+-- =============================================
+
+-- A task keeps the history of its runs. A run is SCHEDULED while queued behind the started run of its task,
+-- RUNNING or PENDING once started and FINISHED, FAILED or CANCELLED once ended. A queued run keeps the agent,
+-- the user and the workspace it is started with.
+ALTER TABLE tasks_runs ADD COLUMN IF NOT EXISTS agent_id UUID;
+ALTER TABLE tasks_runs ADD COLUMN IF NOT EXISTS requested_by UUID;
+ALTER TABLE tasks_runs ADD COLUMN IF NOT EXISTS workspace_id UUID;
+ALTER TABLE tasks_runs ADD COLUMN IF NOT EXISTS dry_run BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The runs created before stayed SCHEDULED while they were executing
+UPDATE tasks_runs SET status = 'RUNNING', started_at = COALESCE(started_at, created_at)
+WHERE status = 'SCHEDULED';
+
+-- A task has a single started run, the older ones are failed
+UPDATE tasks_runs r SET status = 'FAILED', finished_at = NOW()
+WHERE r.status IN ('PENDING', 'RUNNING') AND EXISTS (
+    SELECT 1 FROM tasks_runs n
+    WHERE n.task_id = r.task_id AND n.status IN ('PENDING', 'RUNNING')
+      AND (n.created_at, n.task_run_id) > (r.created_at, r.task_run_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_runs_started ON tasks_runs (task_id) WHERE status IN ('PENDING', 'RUNNING');
+CREATE INDEX IF NOT EXISTS idx_tasks_runs_queued ON tasks_runs (task_id, created_at) WHERE status = 'SCHEDULED';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_runs_queued;
+DROP INDEX IF EXISTS idx_tasks_runs_started;
+
+ALTER TABLE tasks_runs DROP COLUMN IF EXISTS dry_run;
+ALTER TABLE tasks_runs DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE tasks_runs DROP COLUMN IF EXISTS requested_by;
+ALTER TABLE tasks_runs DROP COLUMN IF EXISTS agent_id;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- =============================================
+-- TOOL RUN AUDIT CANCELLATION
+-- =============================================
+
+-- The audit entries of the tool runs cancelled with their task run are completed as cancelled
+ALTER TABLE tool_run_audit DROP CONSTRAINT IF EXISTS tool_run_audit_status_check;
+ALTER TABLE tool_run_audit ADD CONSTRAINT tool_run_audit_status_check
+    CHECK (status IN ('PENDING', 'RUNNING', 'SUCCESS', 'FAILED', 'CANCELLED'));
+
+-- +goose Down
+UPDATE tool_run_audit SET status = 'FAILED' WHERE status = 'CANCELLED';
+ALTER TABLE tool_run_audit DROP CONSTRAINT IF EXISTS tool_run_audit_status_check;
+ALTER TABLE tool_run_audit ADD CONSTRAINT tool_run_audit_status_check
+    CHECK (status IN ('PENDING', 'RUNNING', 'SUCCESS', 'FAILED'));
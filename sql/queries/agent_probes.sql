@@ -0,0 +1,84 @@
+-- ==============================================
+-- AGENT PROBE QUERIES FOR SQLC
+-- ==============================================
+
+-- name: ListAgentProbes :many
+SELECT * FROM agent_probes
+WHERE agent_id = $1
+ORDER BY name;
+
+-- name: GetAgentProbe :one
+SELECT * FROM agent_probes
+WHERE id = $1 AND agent_id = $2;
+
+-- name: CreateAgentProbe :one
+INSERT INTO agent_probes (
+    agent_id,
+    name,
+    prompt,
+    expected_pattern,
+    interval_seconds,
+    timeout_seconds,
+    enabled,
+    dry_run,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: UpdateAgentProbe :one
+UPDATE agent_probes SET
+    name = $3,
+    prompt = $4,
+    expected_pattern = $5,
+    interval_seconds = $6,
+    timeout_seconds = $7,
+    enabled = $8,
+    dry_run = $9,
+    updated_at = NOW()
+WHERE id = $1 AND agent_id = $2
+RETURNING *;
+
+-- name: DeleteAgentProbe :exec
+DELETE FROM agent_probes WHERE id = $1 AND agent_id = $2;
+
+-- name: ClaimDueAgentProbes :many
+-- Marks the enabled probes due for a run as started, SKIP LOCKED lets several task services share the probes
+UPDATE agent_probes SET
+    last_run_at = NOW()
+WHERE id IN (
+    SELECT p.id FROM agent_probes p
+    WHERE p.enabled
+      AND (p.last_run_at IS NULL OR p.last_run_at + make_interval(secs => p.interval_seconds) <= NOW())
+    ORDER BY p.last_run_at NULLS FIRST
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateAgentProbeStatus :exec
+UPDATE agent_probes SET
+    status = $2
+WHERE id = $1;
+
+-- name: CreateAgentProbeRun :one
+INSERT INTO agent_probe_runs (
+    probe_id,
+    status,
+    latency_ms,
+    response,
+    error,
+    thread_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListAgentProbeRuns :many
+SELECT * FROM agent_probe_runs
+WHERE probe_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountAgentProbeRuns :one
+SELECT COUNT(*) FROM agent_probe_runs
+WHERE probe_id = $1;
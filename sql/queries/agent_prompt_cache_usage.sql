@@ -0,0 +1,48 @@
+-- name: RecordAgentPromptCacheUsage :exec
+INSERT INTO agent_prompt_cache_usage (
+    agent_id,
+    request_count,
+    cache_hit_count,
+    input_tokens,
+    cache_read_input_tokens,
+    cache_creation_input_tokens
+) VALUES (
+    @agent_id, 1, CASE WHEN @cache_read_input_tokens::BIGINT > 0 THEN 1 ELSE 0 END, @input_tokens, @cache_read_input_tokens, @cache_creation_input_tokens
+) ON CONFLICT (agent_id) DO UPDATE SET
+    request_count = agent_prompt_cache_usage.request_count + 1,
+    cache_hit_count = agent_prompt_cache_usage.cache_hit_count + EXCLUDED.cache_hit_count,
+    input_tokens = agent_prompt_cache_usage.input_tokens + EXCLUDED.input_tokens,
+    cache_read_input_tokens = agent_prompt_cache_usage.cache_read_input_tokens + EXCLUDED.cache_read_input_tokens,
+    cache_creation_input_tokens = agent_prompt_cache_usage.cache_creation_input_tokens + EXCLUDED.cache_creation_input_tokens,
+    updated_at = NOW();
+
+-- name: RecordAgentPromptCacheWarmup :exec
+INSERT INTO agent_prompt_cache_usage (
+    agent_id,
+    warmup_count,
+    warmup_input_tokens,
+    last_warmup_at
+) VALUES (
+    $1, 1, $2, NOW()
+) ON CONFLICT (agent_id) DO UPDATE SET
+    warmup_count = agent_prompt_cache_usage.warmup_count + 1,
+    warmup_input_tokens = agent_prompt_cache_usage.warmup_input_tokens + EXCLUDED.warmup_input_tokens,
+    last_warmup_at = NOW(),
+    updated_at = NOW();
+
+-- name: ListAgentPromptCacheUsage :many
+SELECT
+    u.agent_id,
+    a.name AS agent_name,
+    u.request_count,
+    u.cache_hit_count,
+    u.input_tokens,
+    u.cache_read_input_tokens,
+    u.cache_creation_input_tokens,
+    u.warmup_count,
+    u.warmup_input_tokens,
+    u.last_warmup_at,
+    u.updated_at
+FROM agent_prompt_cache_usage u
+JOIN agents a ON a.id = u.agent_id
+ORDER BY u.request_count DESC, a.name;
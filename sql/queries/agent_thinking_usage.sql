@@ -0,0 +1,39 @@
+-- name: RecordAgentThinkingUsage :exec
+INSERT INTO agent_thinking_usage (
+    agent_id,
+    request_count,
+    budget_tokens,
+    total_budget_tokens,
+    thinking_tokens,
+    max_thinking_tokens,
+    exhausted_count,
+    estimated_count
+) VALUES (
+    @agent_id, 1, @budget_tokens, @budget_tokens, @thinking_tokens, @thinking_tokens,
+    CASE WHEN @thinking_tokens::BIGINT >= @budget_tokens::BIGINT THEN 1 ELSE 0 END,
+    CASE WHEN @estimated::BOOLEAN THEN 1 ELSE 0 END
+) ON CONFLICT (agent_id) DO UPDATE SET
+    request_count = agent_thinking_usage.request_count + 1,
+    budget_tokens = EXCLUDED.budget_tokens,
+    total_budget_tokens = agent_thinking_usage.total_budget_tokens + EXCLUDED.total_budget_tokens,
+    thinking_tokens = agent_thinking_usage.thinking_tokens + EXCLUDED.thinking_tokens,
+    max_thinking_tokens = GREATEST(agent_thinking_usage.max_thinking_tokens, EXCLUDED.max_thinking_tokens),
+    exhausted_count = agent_thinking_usage.exhausted_count + EXCLUDED.exhausted_count,
+    estimated_count = agent_thinking_usage.estimated_count + EXCLUDED.estimated_count,
+    updated_at = NOW();
+
+-- name: ListAgentThinkingUsage :many
+SELECT
+    u.agent_id,
+    a.name AS agent_name,
+    u.request_count,
+    u.budget_tokens,
+    u.total_budget_tokens,
+    u.thinking_tokens,
+    u.max_thinking_tokens,
+    u.exhausted_count,
+    u.estimated_count,
+    u.updated_at
+FROM agent_thinking_usage u
+JOIN agents a ON a.id = u.agent_id
+ORDER BY u.request_count DESC, a.name;
@@ -1,19 +1,54 @@
 -- name: GetAgents :many
-SELECT * FROM agents ORDER BY name;
+SELECT * FROM agents WHERE deleted_at IS NULL ORDER BY name;
+-- name: ListAgents :many
+-- Lists the agents or the agents in the trash of a workspace visible to a user by name, after the agent of the cursor
+-- when set. The agents with grants are only visible to their creator and their grantees.
+SELECT * FROM agents
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND (deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean
+  AND (created_by = sqlc.arg(user_id)
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'agent' AND g.resource_id = agents.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'agent' AND g.resource_id = agents.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = sqlc.arg(user_id)))))
+  AND (sqlc.narg(cursor_name)::text IS NULL
+       OR (name, id) > (sqlc.narg(cursor_name)::text, sqlc.arg(cursor_id)::uuid))
+ORDER BY name, id
+LIMIT sqlc.arg(row_limit);
+-- name: CountAgents :one
+-- Counts the agents of ListAgents
+SELECT COUNT(*) FROM agents
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND (deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean
+  AND (created_by = sqlc.arg(user_id)
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'agent' AND g.resource_id = agents.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'agent' AND g.resource_id = agents.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = sqlc.arg(user_id)))));
 -- name: GetAgentByID :one
-SELECT * FROM agents WHERE id = $1 LIMIT 1;
+SELECT * FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL LIMIT 1;
 -- name: GetAgentSpecsByID :one
-SELECT specs FROM agents WHERE id = $1 LIMIT 1;
+SELECT specs FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL LIMIT 1;
+-- name: GetAgentOwner :one
+-- Returns the creator of an agent of a workspace, live or in the trash
+SELECT created_by FROM agents WHERE workspace_id = $1 AND id = $2;
+-- name: GetAgentWorkspaceID :one
+-- Returns the workspace of an agent, for the jobs acting for the agent outside of a request
+SELECT workspace_id FROM agents WHERE id = $1;
 -- name: CheckAgentIDValid :one
-SELECT EXISTS(SELECT 1 FROM agents WHERE id = $1) AS is_valid;
+SELECT EXISTS(SELECT 1 FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL) AS is_valid;
 -- name: CreateAgent :one
-INSERT INTO agents (name, description, specs, created_by, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO agents (name, description, specs, created_by, created_at, updated_at, workspace_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 -- name: UpdateAgent :one
+-- Updates an agent of a workspace, only while its update time is if_updated_at when set
 UPDATE agents
 SET name = $1, description = $2, specs = $3
-WHERE id = $4
+WHERE id = $4 AND workspace_id = $5 AND deleted_at IS NULL
+  AND (sqlc.narg(if_updated_at)::timestamptz IS NULL OR updated_at = sqlc.narg(if_updated_at)::timestamptz)
 RETURNING *;
 -- name: ListPermissionsForAgent :many
 SELECT * FROM agent_permission_mapping WHERE agent_id = $1 ORDER BY assigned_at DESC;
@@ -25,3 +60,17 @@ RETURNING *;
 DELETE FROM agent_permission_mapping WHERE agent_id = $1 AND permission_id = $2;
 -- name: DeleteAgent :exec
 DELETE FROM agents WHERE id = $1;
+-- name: SoftDeleteAgent :execrows
+-- Moves an agent of a workspace to the trash
+UPDATE agents SET deleted_at = NOW() WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL;
+-- name: RestoreAgent :one
+-- Restores an agent of a workspace from the trash
+UPDATE agents SET deleted_at = NULL WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL
+RETURNING *;
+-- name: PurgeAgent :execrows
+-- Deletes an agent of the trash of a workspace for good
+DELETE FROM agents WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL;
+-- name: UpdateAgentSpecs :execrows
+UPDATE agents
+SET specs = sqlc.arg(specs)
+WHERE id = sqlc.arg(id) AND specs = sqlc.arg(previous_specs) AND deleted_at IS NULL;
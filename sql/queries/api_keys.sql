@@ -0,0 +1,33 @@
+-- ==============================================
+-- API KEY QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (user_id, name, key_prefix, key_hash, scopes, expires_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetAPIKey :one
+SELECT * FROM api_keys WHERE id = $1 AND user_id = $2;
+
+-- name: GetActiveAPIKeyByHash :one
+-- Returns the API key of a hash unless it was revoked or expired
+SELECT * FROM api_keys
+WHERE key_hash = $1
+AND revoked_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: ListAPIKeysByUser :many
+SELECT * FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC, id;
+
+-- name: RevokeAPIKey :one
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: TouchAPIKey :exec
+-- Records the use of an API key, at most once a minute
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
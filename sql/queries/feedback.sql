@@ -0,0 +1,49 @@
+-- ==============================================
+-- FEEDBACK QUERIES FOR SQLC
+-- ==============================================
+
+-- name: UpsertMessageFeedback :one
+-- Records the feedback of a user on a message, replacing the previous feedback of the user on it
+INSERT INTO feedback (workspace_id, thread_id, message_id, user_id, rating, categories, comment)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, message_id) WHERE message_id IS NOT NULL
+DO UPDATE SET rating = EXCLUDED.rating, categories = EXCLUDED.categories, comment = EXCLUDED.comment, updated_at = NOW()
+RETURNING *;
+
+-- name: UpsertToolRunFeedback :one
+-- Records the feedback of a user on a tool run, replacing the previous feedback of the user on it
+INSERT INTO feedback (workspace_id, thread_id, tool_run_id, user_id, rating, categories, comment)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, tool_run_id) WHERE tool_run_id IS NOT NULL
+DO UPDATE SET rating = EXCLUDED.rating, categories = EXCLUDED.categories, comment = EXCLUDED.comment, updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteMessageFeedback :execrows
+DELETE FROM feedback WHERE user_id = $1 AND message_id = $2;
+
+-- name: DeleteToolRunFeedback :execrows
+DELETE FROM feedback WHERE user_id = $1 AND tool_run_id = $2;
+
+-- name: ListFeedback :many
+-- Lists the feedback of a workspace matching the filters, the most recent first
+SELECT * FROM feedback f
+WHERE f.workspace_id = sqlc.arg(workspace_id)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR f.user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(thread_id)::uuid IS NULL OR f.thread_id = sqlc.narg(thread_id)::uuid)
+  AND (sqlc.narg(rating)::text IS NULL OR f.rating = sqlc.narg(rating)::text)
+  AND (sqlc.narg(category)::text IS NULL OR sqlc.narg(category)::text = ANY(f.categories))
+  AND (sqlc.narg(since)::timestamptz IS NULL OR f.created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR f.created_at < sqlc.narg(until)::timestamptz)
+ORDER BY f.created_at DESC, f.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountFeedback :one
+-- Counts the feedback of a workspace matching the filters of ListFeedback
+SELECT COUNT(*) FROM feedback f
+WHERE f.workspace_id = sqlc.arg(workspace_id)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR f.user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(thread_id)::uuid IS NULL OR f.thread_id = sqlc.narg(thread_id)::uuid)
+  AND (sqlc.narg(rating)::text IS NULL OR f.rating = sqlc.narg(rating)::text)
+  AND (sqlc.narg(category)::text IS NULL OR sqlc.narg(category)::text = ANY(f.categories))
+  AND (sqlc.narg(since)::timestamptz IS NULL OR f.created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR f.created_at < sqlc.narg(until)::timestamptz);
@@ -0,0 +1,50 @@
+-- ==============================================
+-- FILE QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateFile :one
+INSERT INTO files (id, user_id, name, content_type, size_bytes, status, bucket, object_key, uploaded_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetFile :one
+SELECT * FROM files WHERE id = $1 AND user_id = $2;
+
+-- name: ListFiles :many
+-- Lists the files of a user from the most recent, after the file of the cursor when set
+SELECT * FROM files
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(cursor_time)::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountFiles :one
+SELECT COUNT(*) FROM files WHERE user_id = $1;
+
+-- name: CompleteFileUpload :one
+-- Marks the presigned upload of a file done, with the size and the content type of the stored object
+UPDATE files SET
+    status = 'UPLOADED',
+    size_bytes = $3,
+    content_type = $4,
+    uploaded_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND status = 'PENDING'
+RETURNING *;
+
+-- name: DeleteFile :one
+DELETE FROM files WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: AttachFileToMessage :exec
+INSERT INTO message_attachments (message_id, file_id, position)
+VALUES ($1, $2, $3);
+
+-- name: ListMessageAttachments :many
+-- Lists the files attached to messages, in the order of their attachment to each message
+SELECT a.message_id, f.id, f.user_id, f.name, f.content_type, f.size_bytes, f.status, f.bucket, f.object_key, f.created_at, f.updated_at, f.uploaded_at
+FROM message_attachments a
+JOIN files f ON f.id = a.file_id
+WHERE a.message_id = ANY(sqlc.arg(message_ids)::uuid[])
+ORDER BY a.message_id, a.position;
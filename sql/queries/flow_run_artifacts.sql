@@ -0,0 +1,34 @@
+-- ==============================================
+-- FLOW RUN ARTIFACTS QUERIES FOR SQLC
+-- ==============================================
+
+-- name: UpsertFlowRunArtifact :one
+-- Records an artifact uploaded for a flow run, replacing the artifact of the same name
+INSERT INTO flow_run_artifacts (
+    flow_run_id,
+    name,
+    task_name,
+    content_type,
+    size_bytes,
+    bucket,
+    object_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (flow_run_id, name) DO UPDATE SET
+    task_name = EXCLUDED.task_name,
+    content_type = EXCLUDED.content_type,
+    size_bytes = EXCLUDED.size_bytes,
+    bucket = EXCLUDED.bucket,
+    object_key = EXCLUDED.object_key,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: ListFlowRunArtifacts :many
+SELECT * FROM flow_run_artifacts
+WHERE flow_run_id = $1
+ORDER BY name;
+
+-- name: GetFlowRunArtifact :one
+SELECT * FROM flow_run_artifacts
+WHERE flow_run_id = $1 AND name = $2;
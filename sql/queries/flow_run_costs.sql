@@ -0,0 +1,99 @@
+-- ==============================================
+-- FLOW RUN COST QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateFlowRunCost :exec
+-- Starts the cost of a flow run with the user who requested it
+INSERT INTO flow_run_costs (flow_run_id, flow_id, user_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (flow_run_id) DO NOTHING;
+
+-- name: AddFlowRunCPUSeconds :exec
+-- Adds the CPU time of a flow process to the cost of its run
+INSERT INTO flow_run_costs (flow_run_id, flow_id, cpu_seconds)
+SELECT fr.flow_run_id, fr.flow_id, @cpu_seconds::DOUBLE PRECISION
+FROM flow_runs fr
+WHERE fr.flow_run_id = @flow_run_id
+ON CONFLICT (flow_run_id) DO UPDATE SET
+    cpu_seconds = flow_run_costs.cpu_seconds + EXCLUDED.cpu_seconds,
+    updated_at = NOW();
+
+-- name: RecordTaskTokenUsage :execrows
+-- Adds the tokens of a model request to the cost of the flow run that created the task, or the task it was handed over from
+WITH RECURSIVE task_chain AS (
+    SELECT t.id, t.parent_task_id, t.flow_run_id, 1 AS depth
+    FROM tasks t
+    WHERE t.id = @task_id
+    UNION ALL
+    SELECT t.id, t.parent_task_id, t.flow_run_id, c.depth + 1
+    FROM tasks t
+    JOIN task_chain c ON t.id = c.parent_task_id
+    WHERE c.flow_run_id IS NULL AND c.depth < 32
+)
+INSERT INTO flow_run_costs (flow_run_id, flow_id, llm_requests, input_tokens, output_tokens)
+SELECT fr.flow_run_id, fr.flow_id, 1, @input_tokens::BIGINT, @output_tokens::BIGINT
+FROM task_chain c
+JOIN flow_runs fr ON fr.flow_run_id = c.flow_run_id
+LIMIT 1
+ON CONFLICT (flow_run_id) DO UPDATE SET
+    llm_requests = flow_run_costs.llm_requests + 1,
+    input_tokens = flow_run_costs.input_tokens + EXCLUDED.input_tokens,
+    output_tokens = flow_run_costs.output_tokens + EXCLUDED.output_tokens,
+    updated_at = NOW();
+
+-- name: GetFlowRunCost :one
+-- Returns the cost of a flow run, its wall time runs up to now while the run is active
+SELECT
+    fr.flow_run_id,
+    fr.flow_id,
+    c.user_id,
+    fr.status,
+    fr.started_at,
+    fr.finished_at,
+    (CASE WHEN fr.started_at IS NULL THEN 0 ELSE EXTRACT(EPOCH FROM COALESCE(fr.finished_at, NOW()) - fr.started_at) END)::DOUBLE PRECISION AS wall_seconds,
+    COALESCE(c.cpu_seconds, 0)::DOUBLE PRECISION AS cpu_seconds,
+    COALESCE(c.llm_requests, 0)::BIGINT AS llm_requests,
+    COALESCE(c.input_tokens, 0)::BIGINT AS input_tokens,
+    COALESCE(c.output_tokens, 0)::BIGINT AS output_tokens
+FROM flow_runs fr
+LEFT JOIN flow_run_costs c ON c.flow_run_id = fr.flow_run_id
+WHERE fr.flow_run_id = $1;
+
+-- name: ListFlowCosts :many
+-- Rolls up the costs of the flow runs created in a time range per flow
+SELECT
+    f.id AS flow_id,
+    f.name AS flow_name,
+    COUNT(fr.flow_run_id) AS run_count,
+    COALESCE(SUM(CASE WHEN fr.started_at IS NULL THEN 0 ELSE EXTRACT(EPOCH FROM COALESCE(fr.finished_at, NOW()) - fr.started_at) END), 0)::DOUBLE PRECISION AS wall_seconds,
+    COALESCE(SUM(c.cpu_seconds), 0)::DOUBLE PRECISION AS cpu_seconds,
+    COALESCE(SUM(c.llm_requests), 0)::BIGINT AS llm_requests,
+    COALESCE(SUM(c.input_tokens), 0)::BIGINT AS input_tokens,
+    COALESCE(SUM(c.output_tokens), 0)::BIGINT AS output_tokens
+FROM flow_runs fr
+JOIN flows f ON f.id = fr.flow_id
+LEFT JOIN flow_run_costs c ON c.flow_run_id = fr.flow_run_id
+WHERE (sqlc.narg(since)::timestamptz IS NULL OR fr.created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR fr.created_at < sqlc.narg(until)::timestamptz)
+GROUP BY f.id, f.name
+ORDER BY f.name, f.id;
+
+-- name: ListUserFlowCosts :many
+-- Rolls up the costs of the flow runs created in a time range per user who requested them, the runs of an unknown user are grouped together
+SELECT
+    c.user_id,
+    u.name AS user_name,
+    COUNT(fr.flow_run_id) AS run_count,
+    COUNT(DISTINCT fr.flow_id) AS flow_count,
+    COALESCE(SUM(CASE WHEN fr.started_at IS NULL THEN 0 ELSE EXTRACT(EPOCH FROM COALESCE(fr.finished_at, NOW()) - fr.started_at) END), 0)::DOUBLE PRECISION AS wall_seconds,
+    COALESCE(SUM(c.cpu_seconds), 0)::DOUBLE PRECISION AS cpu_seconds,
+    COALESCE(SUM(c.llm_requests), 0)::BIGINT AS llm_requests,
+    COALESCE(SUM(c.input_tokens), 0)::BIGINT AS input_tokens,
+    COALESCE(SUM(c.output_tokens), 0)::BIGINT AS output_tokens
+FROM flow_runs fr
+LEFT JOIN flow_run_costs c ON c.flow_run_id = fr.flow_run_id
+LEFT JOIN users u ON u.id = c.user_id
+WHERE (sqlc.narg(since)::timestamptz IS NULL OR fr.created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR fr.created_at < sqlc.narg(until)::timestamptz)
+GROUP BY c.user_id, u.name
+ORDER BY u.name NULLS LAST, c.user_id;
@@ -17,7 +17,7 @@ SELECT * FROM flow_run_events WHERE event_id = $1;
 -- name: GetFlowRunEventsByFlowRun :many
 SELECT * FROM flow_run_events 
 WHERE flow_run_id = $1 
-ORDER BY event_timestamp ASC;
+ORDER BY event_timestamp ASC, event_id ASC;
 
 -- name: GetFlowRunEventsByType :many
 SELECT * FROM flow_run_events 
@@ -0,0 +1,26 @@
+-- ==============================================
+-- FLOW RUN GRAPH QUERIES FOR SQLC
+-- ==============================================
+
+-- name: UpsertFlowRunGraphTask :exec
+-- Records a task of the DAG of a flow run, a resumed run reports its graph again
+INSERT INTO flow_run_graph_tasks (
+    flow_run_id,
+    task_name,
+    depends_on,
+    max_retries,
+    retry_delay_seconds,
+    position
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (flow_run_id, task_name) DO UPDATE SET
+    depends_on = EXCLUDED.depends_on,
+    max_retries = EXCLUDED.max_retries,
+    retry_delay_seconds = EXCLUDED.retry_delay_seconds,
+    position = EXCLUDED.position;
+
+-- name: ListFlowRunGraphTasks :many
+SELECT * FROM flow_run_graph_tasks
+WHERE flow_run_id = $1
+ORDER BY position, task_name;
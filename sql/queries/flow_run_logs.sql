@@ -0,0 +1,20 @@
+-- ==============================================
+-- FLOW RUN LOGS QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateFlowRunLog :exec
+-- Stores a chunk of the output of a flow process
+INSERT INTO flow_run_logs (
+    flow_run_id,
+    stream,
+    content
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: ListFlowRunLogs :many
+-- Lists the log chunks of a flow run written after the cursor, in the order they were written
+SELECT * FROM flow_run_logs
+WHERE flow_run_id = sqlc.arg(flow_run_id) AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(max_chunks);
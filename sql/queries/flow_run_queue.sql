@@ -0,0 +1,41 @@
+-- ==============================================
+-- FLOW RUN QUEUE QUERIES FOR SQLC
+-- ==============================================
+
+-- name: LockFlowConcurrency :one
+-- Serializes the admission of the runs of a flow until the end of the transaction
+SELECT id FROM flows WHERE id = $1 FOR UPDATE;
+
+-- name: CountDispatchedFlowRuns :one
+-- Counts the runs of a flow holding a concurrency slot, the active runs not waiting in the queue
+SELECT COUNT(*) FROM flow_runs fr
+WHERE fr.flow_id = $1
+AND fr.status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+AND NOT EXISTS (SELECT 1 FROM flow_run_queue q WHERE q.flow_run_id = fr.flow_run_id);
+
+-- name: EnqueueFlowRun :exec
+INSERT INTO flow_run_queue (flow_run_id, flow_id) VALUES ($1, $2);
+
+-- name: DequeueFlowRuns :many
+-- Removes the oldest queued runs of a flow still scheduled from the queue, to dispatch them
+DELETE FROM flow_run_queue
+WHERE flow_run_id IN (
+    SELECT q.flow_run_id FROM flow_run_queue q
+    JOIN flow_runs fr ON fr.flow_run_id = q.flow_run_id
+    WHERE q.flow_id = $1 AND fr.status = 'SCHEDULED'
+    ORDER BY q.queued_at, q.flow_run_id
+    LIMIT $2
+)
+RETURNING flow_run_id;
+
+-- name: DeleteEndedQueuedFlowRuns :execrows
+-- Removes the queued runs cancelled before their dispatch
+DELETE FROM flow_run_queue q
+USING flow_runs fr
+WHERE q.flow_run_id = fr.flow_run_id AND fr.status <> 'SCHEDULED';
+
+-- name: ListQueuedFlows :many
+SELECT DISTINCT flow_id FROM flow_run_queue;
+
+-- name: CountQueuedFlowRuns :one
+SELECT COUNT(*) FROM flow_run_queue WHERE flow_id = $1;
@@ -7,19 +7,66 @@ INSERT INTO flow_runs (
     engine,
     task_statuses,
     success_task_results,
-    max_retries
+    max_retries,
+    parent_run_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetFlowRun :one
 SELECT * FROM flow_runs WHERE flow_run_id = $1;
 
+-- name: GetWorkspaceFlowRun :one
+-- Gets a run of a flow of a workspace, the runs of the flows in the trash included
+SELECT r.* FROM flow_runs r
+JOIN flows f ON f.id = r.flow_id
+WHERE f.workspace_id = $1 AND r.flow_run_id = $2
+LIMIT 1;
+
 -- name: GetFlowRunsByFlowID :many
 SELECT * FROM flow_runs 
 WHERE flow_id = $1 
 ORDER BY created_at DESC;
 
+-- name: ListFlowRunsByFlowID :many
+-- Lists the runs of a flow matching the filters in the order of the sort, after the run of the cursor when set
+SELECT * FROM flow_runs r
+WHERE r.flow_id = sqlc.arg(flow_id)
+  AND (sqlc.narg(status)::text IS NULL OR r.status::text = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR r.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR r.created_at < sqlc.narg(created_before)::timestamptz)
+  AND (sqlc.narg(cursor_time)::timestamptz IS NULL OR CASE
+    WHEN sqlc.arg(sort)::text = 'updated_at' AND NOT sqlc.arg(descending)::boolean
+      THEN (r.updated_at, r.flow_run_id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'updated_at'
+      THEN (r.updated_at, r.flow_run_id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid)
+    WHEN NOT sqlc.arg(descending)::boolean
+      THEN (r.created_at, r.flow_run_id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid)
+    ELSE (r.created_at, r.flow_run_id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid)
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'updated_at' AND NOT sqlc.arg(descending)::boolean THEN r.updated_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'updated_at' AND sqlc.arg(descending)::boolean THEN r.updated_at END DESC,
+  CASE WHEN sqlc.arg(sort)::text <> 'updated_at' AND NOT sqlc.arg(descending)::boolean THEN r.created_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text <> 'updated_at' AND sqlc.arg(descending)::boolean THEN r.created_at END DESC,
+  CASE WHEN NOT sqlc.arg(descending)::boolean THEN r.flow_run_id END ASC,
+  CASE WHEN sqlc.arg(descending)::boolean THEN r.flow_run_id END DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountFlowRunsByFlowID :one
+-- Counts the runs of a flow matching the filters of ListFlowRunsByFlowID
+SELECT COUNT(*) FROM flow_runs r
+WHERE r.flow_id = sqlc.arg(flow_id)
+  AND (sqlc.narg(status)::text IS NULL OR r.status::text = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR r.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR r.created_at < sqlc.narg(created_before)::timestamptz);
+
+-- name: GetChildFlowRunsByParentID :many
+-- Lists the sub-flow runs triggered by a flow run, oldest first
+SELECT * FROM flow_runs
+WHERE parent_run_id = $1
+ORDER BY created_at ASC;
+
 -- name: GetFlowRunsByStatus :many
 SELECT * FROM flow_runs 
 WHERE status = $1 
@@ -48,7 +95,7 @@ SET status = sqlc.arg(status)::text,
     updated_at = NOW(),
     started_at = CASE WHEN sqlc.arg(status)::text = 'RUNNING' AND started_at IS NULL THEN NOW() ELSE started_at END,
     finished_at = CASE WHEN sqlc.arg(status)::text IN ('SUCCESS', 'FAILED') AND finished_at IS NULL THEN NOW() ELSE finished_at END
-WHERE flow_run_id = sqlc.arg(flow_run_id);
+WHERE flow_run_id = sqlc.arg(flow_run_id) AND status <> 'CANCELLED';
 
 -- name: UpdateFlowRunStartedAt :exec
 UPDATE flow_runs 
@@ -68,13 +115,54 @@ SET success_task_results = $2,
     updated_at = NOW()
 WHERE flow_run_id = $1;
 
+-- name: SetFlowRunSuccessTaskResult :exec
+-- Records the result cache key of a task that succeeded, a resumed run skips the task
+UPDATE flow_runs
+SET success_task_results = COALESCE(success_task_results, '{}'::jsonb) || jsonb_build_object(sqlc.arg(task_name)::text, sqlc.arg(result_cache_key)::text),
+    updated_at = NOW()
+WHERE flow_run_id = sqlc.arg(flow_run_id);
+
 -- name: UpdateFlowRunError :exec
 UPDATE flow_runs 
 SET error_message = $2, 
+    failure_reason = $3,
     status = 'FAILED',
     finished_at = NOW(),
     updated_at = NOW()
-WHERE flow_run_id = $1;
+WHERE flow_run_id = $1 AND status <> 'CANCELLED';
+
+-- name: CancelFlowRun :one
+-- Ends an active flow run as cancelled, no row is returned when the run already ended
+UPDATE flow_runs
+SET status = 'CANCELLED',
+    error_message = $2,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status IN ('SCHEDULED', 'PENDING', 'RUNNING', 'PAUSED')
+RETURNING *;
+
+-- name: ResumeFlowRun :one
+-- Schedules a paused flow run again, no row is returned when the run is not paused
+UPDATE flow_runs
+SET status = 'SCHEDULED',
+    worker_id = NULL,
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status = 'PAUSED'
+RETURNING *;
+
+-- name: RetryFlowRun :one
+-- Schedules a failed flow run for its next retry, no row is returned when the run has no retry left or was already retried
+UPDATE flow_runs
+SET retry_count = COALESCE(retry_count, 0) + 1,
+    status = 'SCHEDULED',
+    started_at = NULL,
+    finished_at = NULL,
+    error_message = NULL,
+    failure_reason = NULL,
+    worker_id = NULL,
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status = 'FAILED' AND COALESCE(retry_count, 0) < COALESCE(max_retries, 0)
+RETURNING *;
 
 -- name: IncrementFlowRunRetryCount :exec
 UPDATE flow_runs 
@@ -98,4 +186,28 @@ SELECT fr.*, f.name as flow_name, f.description as flow_description
 FROM flow_runs fr
 JOIN flows f ON fr.flow_id = f.id
 ORDER BY fr.created_at DESC
-LIMIT $1 OFFSET $2;
\ No newline at end of file
+LIMIT $1 OFFSET $2;
+
+-- name: AssignFlowRunWorker :exec
+-- Records the worker running the process of a flow run
+UPDATE flow_runs
+SET worker_id = sqlc.arg(worker_id),
+    updated_at = NOW()
+WHERE flow_run_id = sqlc.arg(flow_run_id);
+
+-- name: RescheduleOrphanedFlowRuns :many
+-- Schedules again the active flow runs of the workers that stopped, each run is only returned to a single caller
+UPDATE flow_runs
+SET status = 'SCHEDULED',
+    worker_id = NULL,
+    started_at = NULL,
+    updated_at = NOW()
+WHERE status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+AND worker_id IN (SELECT worker_id FROM worker_heartbeats WHERE status IN ('INACTIVE', 'FAILED'))
+RETURNING *;
+
+-- name: CountActiveFlowRuns :one
+-- Counts the flow runs among the IDs that are scheduled, pending or running
+SELECT COUNT(*) FROM flow_runs
+WHERE flow_run_id = ANY(sqlc.arg(flow_run_ids)::uuid[])
+AND status IN ('SCHEDULED', 'PENDING', 'RUNNING');
@@ -0,0 +1,54 @@
+-- ==============================================
+-- FLOW SCHEDULE BACKFILL QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateFlowScheduleBackfill :one
+INSERT INTO flow_schedule_backfills (
+    schedule_id,
+    flow_id,
+    cron_expression,
+    timezone,
+    parameters,
+    engine,
+    start_at,
+    end_at,
+    max_concurrent_runs,
+    total_runs,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: ListFlowScheduleBackfills :many
+SELECT * FROM flow_schedule_backfills
+WHERE schedule_id = $1 AND flow_id = $2
+ORDER BY created_at DESC;
+
+-- name: GetFlowScheduleBackfill :one
+SELECT * FROM flow_schedule_backfills
+WHERE id = $1 AND schedule_id = $2 AND flow_id = $3;
+
+-- name: ListRunningFlowScheduleBackfills :many
+SELECT * FROM flow_schedule_backfills
+WHERE status = 'RUNNING'
+ORDER BY created_at
+LIMIT $1;
+
+-- name: ClaimFlowScheduleBackfillRuns :execrows
+-- Moves a running backfill past the runs about to be triggered, completed once every run is triggered. No row is
+-- updated when another flows service already claimed the runs.
+UPDATE flow_schedule_backfills SET
+    triggered_runs = sqlc.arg(triggered_runs),
+    status = CASE WHEN sqlc.arg(triggered_runs) >= total_runs THEN 'COMPLETED' ELSE status END,
+    finished_at = CASE WHEN sqlc.arg(triggered_runs) >= total_runs THEN NOW() ELSE finished_at END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'RUNNING' AND triggered_runs = sqlc.arg(previous_triggered_runs);
+
+-- name: CancelFlowScheduleBackfill :one
+-- Stops a running backfill, the runs already triggered are left running
+UPDATE flow_schedule_backfills SET
+    status = 'CANCELLED',
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND schedule_id = $2 AND flow_id = $3 AND status = 'RUNNING'
+RETURNING *;
@@ -0,0 +1,67 @@
+-- ==============================================
+-- FLOW SCHEDULE QUERIES FOR SQLC
+-- ==============================================
+
+-- name: ListFlowSchedules :many
+SELECT * FROM flow_schedules
+WHERE flow_id = $1
+ORDER BY name;
+
+-- name: GetFlowSchedule :one
+SELECT * FROM flow_schedules
+WHERE id = $1 AND flow_id = $2;
+
+-- name: CreateFlowSchedule :one
+INSERT INTO flow_schedules (
+    flow_id,
+    name,
+    cron_expression,
+    timezone,
+    parameters,
+    engine,
+    enabled,
+    catch_up_policy,
+    next_run_at,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: UpdateFlowSchedule :one
+UPDATE flow_schedules SET
+    name = $3,
+    cron_expression = $4,
+    timezone = $5,
+    parameters = $6,
+    engine = $7,
+    enabled = $8,
+    catch_up_policy = $9,
+    next_run_at = $10,
+    updated_at = NOW()
+WHERE id = $1 AND flow_id = $2
+RETURNING *;
+
+-- name: DeleteFlowSchedule :exec
+DELETE FROM flow_schedules WHERE id = $1 AND flow_id = $2;
+
+-- name: ListDueFlowSchedules :many
+SELECT * FROM flow_schedules
+WHERE enabled AND next_run_at <= NOW()
+  AND flow_id IN (SELECT id FROM flows WHERE deleted_at IS NULL)
+ORDER BY next_run_at
+LIMIT $1;
+
+-- name: ClaimFlowScheduleRun :execrows
+-- Moves a due schedule to its next run, no row is updated when another flows service already claimed the run
+UPDATE flow_schedules SET
+    next_run_at = sqlc.arg(next_run_at),
+    last_run_at = sqlc.arg(last_run_at),
+    last_flow_run_id = sqlc.arg(last_flow_run_id)
+WHERE id = sqlc.arg(id) AND enabled AND next_run_at = sqlc.arg(due_at);
+
+-- name: DisableFlowSchedule :execrows
+-- Disables a due schedule that cannot fire again, so it is not listed on every poll
+UPDATE flow_schedules SET
+    enabled = FALSE,
+    updated_at = NOW()
+WHERE id = $1 AND enabled AND next_run_at = $2;
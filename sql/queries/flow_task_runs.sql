@@ -9,6 +9,19 @@ INSERT INTO flow_task_runs (
     $1, $2, $3, $4, $5
 ) RETURNING *;
 
+-- name: EnsureFlowTaskRun :exec
+-- Creates the pending task run of a task of the flow run graph, an existing task run keeps its status
+INSERT INTO flow_task_runs (
+    flow_run_id,
+    task_name,
+    status,
+    max_retries
+) VALUES (
+    $1, $2, 'PENDING', $3
+)
+ON CONFLICT (flow_run_id, task_name) DO UPDATE SET
+    max_retries = EXCLUDED.max_retries;
+
 -- name: GetFlowTaskRun :one
 SELECT * FROM flow_task_runs WHERE flow_run_id = $1 AND task_name = $2;
 
@@ -41,7 +54,7 @@ ORDER BY created_at ASC;
 UPDATE flow_task_runs 
 SET status = sqlc.arg(status), 
     updated_at = NOW()
-WHERE flow_run_id = sqlc.arg(flow_run_id) AND task_name = sqlc.arg(task_name);
+WHERE flow_run_id = sqlc.arg(flow_run_id) AND task_name = sqlc.arg(task_name) AND status <> 'CANCELLED';
 
 -- name: UpdateFlowTaskRunStatusWithTimestamps :exec
 UPDATE flow_task_runs 
@@ -70,6 +83,15 @@ SET error_message = $3,
     updated_at = NOW()
 WHERE flow_run_id = $1 AND task_name = $2;
 
+-- name: CancelFlowTaskRuns :execrows
+-- Finalizes the pending and running task runs of a cancelled flow run
+UPDATE flow_task_runs
+SET status = 'CANCELLED',
+    finished_at = NOW(),
+    duration_seconds = EXTRACT(EPOCH FROM (NOW() - started_at)),
+    updated_at = NOW()
+WHERE flow_run_id = $1 AND status IN ('PENDING', 'RUNNING');
+
 -- name: IncrementFlowTaskRunRetryCount :exec
 UPDATE flow_task_runs 
 SET retry_count = retry_count + 1,
@@ -1,15 +1,35 @@
 -- name: GetFlows :many
-SELECT * FROM flows ORDER BY name LIMIT $1 OFFSET $2;
+-- Lists the flows or the flows in the trash of a workspace by name
+SELECT * FROM flows
+WHERE workspace_id = sqlc.arg(workspace_id) AND (deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean
+ORDER BY name
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+-- name: CountFlows :one
+-- Counts the flows or the flows in the trash of a workspace
+SELECT COUNT(*) FROM flows WHERE workspace_id = sqlc.arg(workspace_id) AND (deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean;
 -- name: GetFlowById :one
+SELECT * FROM flows WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL LIMIT 1;
+-- name: GetFlowByIdWithDeleted :one
+-- Gets a flow of any workspace even from the trash, the runs started before the flow was deleted still complete
 SELECT * FROM flows WHERE id = $1 LIMIT 1;
 -- name: CreateFlow :one
-INSERT INTO flows (id, name, description, parameters_schema, engine, additional_info, tags, code_location, entrypoint)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO flows (id, name, description, parameters_schema, engine, additional_info, tags, code_location, entrypoint, notifications, required_labels, max_concurrent_runs, concurrency_policy, workspace_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 RETURNING *;
 -- name: UpdateFlow :one
 UPDATE flows
-SET name = $1, description = $2, parameters_schema = $3, engine = $4, additional_info = $5, tags = $6, code_location = $7, entrypoint = $8, updated_at = CURRENT_TIMESTAMP
-WHERE id = $9
+SET name = $1, description = $2, parameters_schema = $3, engine = $4, additional_info = $5, tags = $6, code_location = $7, entrypoint = $8, notifications = $9, required_labels = $10, max_concurrent_runs = $11, concurrency_policy = $12, updated_at = CURRENT_TIMESTAMP
+WHERE id = $13 AND workspace_id = $14 AND deleted_at IS NULL
 RETURNING *;
 -- name: DeleteFlow :exec
-DELETE FROM flows WHERE id = $1;
\ No newline at end of file
+DELETE FROM flows WHERE id = $1;
+-- name: SoftDeleteFlow :execrows
+-- Moves a flow of a workspace to the trash, its schedules stop triggering runs until it is restored
+UPDATE flows SET deleted_at = NOW() WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NULL;
+-- name: RestoreFlow :one
+-- Restores a flow of a workspace from the trash
+UPDATE flows SET deleted_at = NULL WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL
+RETURNING *;
+-- name: PurgeFlow :execrows
+-- Deletes a flow of the trash of a workspace for good
+DELETE FROM flows WHERE workspace_id = $1 AND id = $2 AND deleted_at IS NOT NULL;
\ No newline at end of file
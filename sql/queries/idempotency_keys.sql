@@ -0,0 +1,40 @@
+-- ==============================================
+-- IDEMPOTENCY KEY QUERIES FOR SQLC
+-- ==============================================
+
+-- name: ClaimIdempotencyKey :one
+-- Claims a key for a request, or claims again an expired key. No row is returned when the key is held by a request.
+INSERT INTO idempotency_keys (user_id, key, request_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    content_type = '',
+    response_body = NULL,
+    created_at = NOW(),
+    completed_at = NULL,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at < NOW()
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE user_id = $1 AND key = $2;
+
+-- name: CompleteIdempotencyKey :exec
+-- Records the response of the request of a key, replayed to its retries
+UPDATE idempotency_keys
+SET status_code = $3,
+    content_type = $4,
+    response_body = $5,
+    completed_at = NOW()
+WHERE user_id = $1 AND key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+-- Releases the key of a request that failed, its retries run the request again
+DELETE FROM idempotency_keys
+WHERE user_id = $1 AND key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at < NOW();
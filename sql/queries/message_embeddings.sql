@@ -0,0 +1,51 @@
+-- ==============================================
+-- MESSAGE EMBEDDING QUERIES FOR SQLC
+-- ==============================================
+
+-- name: ListMessagesToEmbed :many
+-- Lists the messages of the live threads with a text whose embedding is missing, made by another model or older than
+-- the last change of the message, from the most recently changed
+SELECT m.id, m.thread_id, message_search_text(m.message)::TEXT AS text
+FROM thread_messages m
+JOIN threads t ON t.id = m.thread_id
+LEFT JOIN message_embeddings e ON e.message_id = m.id
+WHERE t.deleted_at IS NULL AND m.superseded_at IS NULL AND message_search_text(m.message) <> ''
+  AND (e.message_id IS NULL OR e.model <> sqlc.arg(model) OR e.embedded_at < COALESCE(m.updated_at, m.created_at))
+ORDER BY COALESCE(m.updated_at, m.created_at) DESC, m.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpsertMessageEmbedding :exec
+-- Saves the embedding of a message, replacing its previous embedding
+INSERT INTO message_embeddings (message_id, thread_id, model, embedding)
+VALUES (sqlc.arg(message_id), sqlc.arg(thread_id), sqlc.arg(model), sqlc.arg(embedding))
+ON CONFLICT (message_id) DO UPDATE SET
+    model = EXCLUDED.model,
+    embedding = EXCLUDED.embedding,
+    embedded_at = NOW();
+
+-- name: SemanticSearchMessages :many
+-- Lists the messages of the live threads of a workspace visible to a user embedded by a model, from the nearest to an
+-- embedding by cosine distance
+SELECT
+    m.id,
+    m.thread_id,
+    t.title AS thread_title,
+    m.sender_type,
+    m.sender_id,
+    m.created_at,
+    message_search_text(m.message)::TEXT AS text,
+    (1 - (e.embedding <=> sqlc.arg(embedding)::vector))::REAL AS similarity
+FROM message_embeddings e
+JOIN thread_messages m ON m.id = e.message_id
+JOIN threads t ON t.id = e.thread_id
+WHERE e.model = sqlc.arg(model)
+  AND m.superseded_at IS NULL
+  AND t.workspace_id = sqlc.arg(workspace_id) AND t.deleted_at IS NULL
+  AND (t.user_id = sqlc.arg(user_id) OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT r.role_id FROM user_role_mapping r WHERE r.user_id = sqlc.arg(user_id)))))
+  AND (sqlc.narg(thread_id)::uuid IS NULL OR e.thread_id = sqlc.narg(thread_id)::uuid)
+  AND (sqlc.narg(exclude_thread_id)::uuid IS NULL OR e.thread_id <> sqlc.narg(exclude_thread_id)::uuid)
+ORDER BY e.embedding <=> sqlc.arg(embedding)::vector
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,43 @@
+-- ==============================================
+-- MESSAGE SEARCH QUERIES FOR SQLC
+-- ==============================================
+
+-- name: SearchMessages :many
+-- Lists the messages of the live threads of a workspace visible to a user matching a web search query, from the most
+-- relevant, with the matching fragments of their text highlighted
+SELECT
+    m.id,
+    m.thread_id,
+    t.title AS thread_title,
+    m.sender_type,
+    m.sender_id,
+    m.created_at,
+    ts_headline('english', message_search_text(m.message), q.query,
+        'StartSel=<mark>, StopSel=</mark>, MaxFragments=3, MaxWords=24, MinWords=8, FragmentDelimiter=" ... "')::TEXT AS highlight,
+    ts_rank(to_tsvector('english', message_search_text(m.message)), q.query)::REAL AS rank
+FROM thread_messages m
+JOIN threads t ON t.id = m.thread_id
+CROSS JOIN websearch_to_tsquery('english', sqlc.arg(query)) AS q (query)
+WHERE to_tsvector('english', message_search_text(m.message)) @@ q.query
+  AND m.superseded_at IS NULL
+  AND t.workspace_id = sqlc.arg(workspace_id) AND t.deleted_at IS NULL
+  AND (t.user_id = sqlc.arg(user_id) OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT r.role_id FROM user_role_mapping r WHERE r.user_id = sqlc.arg(user_id)))))
+  AND (sqlc.narg(thread_id)::uuid IS NULL OR m.thread_id = sqlc.narg(thread_id)::uuid)
+ORDER BY rank DESC, m.created_at DESC, m.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountMessageMatches :one
+-- Counts the messages matching a web search query of SearchMessages
+SELECT COUNT(*) FROM thread_messages m
+JOIN threads t ON t.id = m.thread_id
+WHERE to_tsvector('english', message_search_text(m.message)) @@ websearch_to_tsquery('english', sqlc.arg(query))
+  AND m.superseded_at IS NULL
+  AND t.workspace_id = sqlc.arg(workspace_id) AND t.deleted_at IS NULL
+  AND (t.user_id = sqlc.arg(user_id) OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT r.role_id FROM user_role_mapping r WHERE r.user_id = sqlc.arg(user_id)))))
+  AND (sqlc.narg(thread_id)::uuid IS NULL OR m.thread_id = sqlc.narg(thread_id)::uuid);
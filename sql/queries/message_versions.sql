@@ -0,0 +1,35 @@
+-- ==============================================
+-- MESSAGE VERSION QUERIES FOR SQLC
+-- ==============================================
+
+-- name: GetMessageHistory :many
+-- Lists all the messages of a thread with the superseded ones, in the order they were created
+SELECT * FROM thread_messages WHERE thread_id = $1 ORDER BY created_at ASC, id;
+
+-- name: SupersedeMessages :execrows
+-- Supersedes the live messages of a thread created after a message, and the message itself unless it is kept
+UPDATE thread_messages
+SET superseded_at = NOW()
+WHERE thread_id = sqlc.arg(thread_id) AND superseded_at IS NULL
+  AND (created_at > sqlc.arg(created_at) OR (id = sqlc.arg(message_id) AND NOT sqlc.arg(keep_message)::BOOLEAN));
+
+-- name: CreateMessageVersion :one
+-- Creates a new version of a message with another content, sent by the same sender to the same recipient
+INSERT INTO thread_messages (thread_id, message, sender_type, sender_id, recipient_id, version_of)
+SELECT m.thread_id, sqlc.arg(message)::jsonb, m.sender_type, m.sender_id, m.recipient_id, m.id
+FROM thread_messages m
+WHERE m.id = sqlc.arg(version_of)
+RETURNING *;
+
+-- name: CopyMessageAttachments :exec
+-- Attaches the files of a message to another message in the same order
+INSERT INTO message_attachments (message_id, file_id, position)
+SELECT sqlc.arg(message_id)::uuid, a.file_id, a.position
+FROM message_attachments a
+WHERE a.message_id = sqlc.arg(source_message_id);
+
+-- name: CountActiveThreadTaskRuns :one
+-- Counts the task runs of a thread that are scheduled, pending or running
+SELECT COUNT(*) FROM tasks_runs r
+JOIN tasks t ON t.id = r.task_id
+WHERE t.thread_id = $1 AND r.status IN ('SCHEDULED', 'PENDING', 'RUNNING');
@@ -1,9 +1,9 @@
 -- name: GetMessages :many
-SELECT * FROM thread_messages WHERE thread_id = $1 ORDER BY created_at ASC;
+SELECT * FROM thread_messages WHERE thread_id = $1 AND superseded_at IS NULL ORDER BY created_at ASC;
 -- name: GetMessageContents :many
-SELECT message FROM thread_messages WHERE thread_id = $1 ORDER BY created_at ASC;
+SELECT id, message FROM thread_messages WHERE thread_id = $1 AND superseded_at IS NULL ORDER BY created_at ASC;
 -- name: GetSenderRecipientMessages :many
-SELECT message FROM thread_messages WHERE thread_id = $1 AND ((sender_id = $2 AND recipient_id = $3) OR (sender_id = $3 AND recipient_id = $2)) ORDER BY created_at ASC;
+SELECT id, message FROM thread_messages WHERE thread_id = $1 AND superseded_at IS NULL AND ((sender_id = $2 AND recipient_id = $3) OR (sender_id = $3 AND recipient_id = $2)) ORDER BY created_at ASC;
 -- name: GetMessageByID :one
 SELECT * FROM thread_messages WHERE id = $1 LIMIT 1;
 -- name: CreateCustomMessage :one
@@ -30,3 +30,21 @@ RETURNING *;
 -- name: DeleteMessage :exec
 DELETE FROM thread_messages WHERE id = $1;
 
+-- name: ImportMessage :one
+-- Inserts a message of an imported conversation. A message without a date is dated with the clock rather than the start
+-- of the transaction, the messages of a batch keep their order.
+INSERT INTO thread_messages (thread_id, message, sender_type, sender_id, recipient_id, created_at)
+VALUES (
+    sqlc.arg(thread_id), sqlc.arg(message), sqlc.arg(sender_type), sqlc.arg(sender_id), sqlc.arg(recipient_id),
+    COALESCE(sqlc.narg(created_at)::timestamptz, clock_timestamp())
+)
+RETURNING *;
+-- name: ImportThreadMessage :one
+-- Inserts a message of an imported thread with its result type, stop reason and citations, dated like ImportMessage.
+INSERT INTO thread_messages (thread_id, message, sender_type, result_type, stop_reason, sender_id, citations, recipient_id, created_at)
+VALUES (
+    sqlc.arg(thread_id), sqlc.arg(message), sqlc.arg(sender_type), sqlc.narg(result_type), sqlc.narg(stop_reason),
+    sqlc.arg(sender_id), sqlc.arg(citations), sqlc.arg(recipient_id),
+    COALESCE(sqlc.narg(created_at)::timestamptz, clock_timestamp())
+)
+RETURNING *;
@@ -0,0 +1,32 @@
+-- name: CreateResourceChange :one
+INSERT INTO resource_changes (
+    resource_type,
+    resource_id,
+    version,
+    action,
+    snapshot,
+    changes,
+    changed_by,
+    source_ip,
+    user_agent
+) VALUES (
+    @resource_type,
+    @resource_id,
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM resource_changes WHERE resource_type = @resource_type AND resource_id = @resource_id),
+    @action,
+    @snapshot,
+    @changes,
+    @changed_by,
+    @source_ip,
+    @user_agent
+) RETURNING *;
+
+-- name: ListResourceChanges :many
+SELECT * FROM resource_changes
+WHERE resource_type = $1 AND resource_id = $2
+ORDER BY version DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountResourceChanges :one
+SELECT COUNT(*) FROM resource_changes
+WHERE resource_type = $1 AND resource_id = $2;
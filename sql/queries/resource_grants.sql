@@ -0,0 +1,32 @@
+-- ==============================================
+-- RESOURCE GRANT QUERIES FOR SQLC
+-- ==============================================
+
+-- name: ListResourceGrants :many
+SELECT * FROM resource_grants WHERE resource_type = $1 AND resource_id = $2 ORDER BY created_at, id;
+
+-- name: UpsertResourceGrant :one
+-- Grants an access on a resource to a user or a role, or changes the access of a grantee
+INSERT INTO resource_grants (resource_type, resource_id, grantee_type, grantee_id, access, granted_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (resource_type, resource_id, grantee_type, grantee_id)
+DO UPDATE SET access = EXCLUDED.access, granted_by = EXCLUDED.granted_by, updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteResourceGrant :execrows
+DELETE FROM resource_grants WHERE resource_type = $1 AND resource_id = $2 AND grantee_type = $3 AND grantee_id = $4;
+
+-- name: DeleteResourceGrants :exec
+-- Deletes the grants of a purged resource
+DELETE FROM resource_grants WHERE resource_type = $1 AND resource_id = $2;
+
+-- name: HasResourceGrants :one
+-- Checks whether a resource is restricted to its owner and its grantees
+SELECT EXISTS (SELECT 1 FROM resource_grants WHERE resource_type = $1 AND resource_id = $2) AS restricted;
+
+-- name: ListUserResourceAccess :many
+-- Lists the accesses granted on a resource to a user, directly or through the roles of the user
+SELECT g.access FROM resource_grants g
+WHERE g.resource_type = sqlc.arg(resource_type) AND g.resource_id = sqlc.arg(resource_id)
+  AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+       OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = sqlc.arg(user_id)));
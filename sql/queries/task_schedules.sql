@@ -0,0 +1,67 @@
+-- ==============================================
+-- TASK SCHEDULE QUERIES FOR SQLC
+-- ==============================================
+
+-- name: ListTaskSchedules :many
+SELECT * FROM task_schedules
+WHERE workspace_id = $1 AND created_by = $2
+ORDER BY name;
+
+-- name: GetTaskSchedule :one
+SELECT * FROM task_schedules
+WHERE id = $1 AND workspace_id = $2 AND created_by = $3;
+
+-- name: CreateTaskSchedule :one
+INSERT INTO task_schedules (
+    workspace_id,
+    agent_id,
+    thread_id,
+    name,
+    prompt,
+    cron_expression,
+    timezone,
+    enabled,
+    overlap_policy,
+    next_run_at,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: UpdateTaskSchedule :one
+UPDATE task_schedules SET
+    agent_id = $2,
+    thread_id = $3,
+    name = $4,
+    prompt = $5,
+    cron_expression = $6,
+    timezone = $7,
+    enabled = $8,
+    overlap_policy = $9,
+    next_run_at = $10,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteTaskSchedule :exec
+DELETE FROM task_schedules WHERE id = $1;
+
+-- name: ListDueTaskSchedules :many
+SELECT * FROM task_schedules
+WHERE enabled AND next_run_at <= NOW()
+  AND agent_id IN (SELECT id FROM agents WHERE deleted_at IS NULL)
+ORDER BY next_run_at
+LIMIT $1;
+
+-- name: ClaimTaskScheduleRun :execrows
+-- Moves a due schedule to its next run, no row is updated when another task service already claimed the run
+UPDATE task_schedules SET
+    next_run_at = sqlc.arg(next_run_at),
+    last_run_at = sqlc.arg(last_run_at)
+WHERE id = sqlc.arg(id) AND enabled AND next_run_at = sqlc.arg(due_at);
+
+-- name: UpdateTaskScheduleLastThread :exec
+-- Records the new thread of the last run of a schedule without thread
+UPDATE task_schedules SET
+    last_thread_id = $2
+WHERE id = $1;
@@ -1,12 +1,64 @@
 -- name: GetTasks :many
-SELECT * FROM tasks ORDER BY created_at DESC LIMIT $1 OFFSET $2;
+-- Lists the tasks matching the filters in the order of the sort, after the task of the cursor when set. The status of a
+-- task is the status of its latest run. Only the tasks of the live threads of the workspace visible to the user are listed.
+SELECT t.* FROM tasks t
+JOIN threads th ON th.id = t.thread_id
+WHERE (sqlc.narg(status)::text IS NULL
+       OR (SELECT r.status::text FROM tasks_runs r WHERE r.task_id = t.id ORDER BY r.created_at DESC LIMIT 1) = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_by)::uuid IS NULL OR t.created_by = sqlc.narg(created_by)::uuid)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_before)::timestamptz)
+  AND (sqlc.narg(cursor_time)::timestamptz IS NULL OR CASE
+    WHEN sqlc.arg(sort)::text = 'updated_at' AND NOT sqlc.arg(descending)::boolean
+      THEN (t.updated_at, t.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::text)
+    WHEN sqlc.arg(sort)::text = 'updated_at'
+      THEN (t.updated_at, t.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::text)
+    WHEN NOT sqlc.arg(descending)::boolean
+      THEN (t.created_at, t.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::text)
+    ELSE (t.created_at, t.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::text)
+  END)
+  AND th.workspace_id = sqlc.arg(workspace_id) AND th.deleted_at IS NULL
+  AND (th.user_id = sqlc.arg(user_id) OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = th.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT r.role_id FROM user_role_mapping r WHERE r.user_id = sqlc.arg(user_id)))))
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'updated_at' AND NOT sqlc.arg(descending)::boolean THEN t.updated_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'updated_at' AND sqlc.arg(descending)::boolean THEN t.updated_at END DESC,
+  CASE WHEN sqlc.arg(sort)::text <> 'updated_at' AND NOT sqlc.arg(descending)::boolean THEN t.created_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text <> 'updated_at' AND sqlc.arg(descending)::boolean THEN t.created_at END DESC,
+  CASE WHEN NOT sqlc.arg(descending)::boolean THEN t.id END ASC,
+  CASE WHEN sqlc.arg(descending)::boolean THEN t.id END DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountTasks :one
+-- Counts the tasks matching the filters of GetTasks
+SELECT COUNT(*) FROM tasks t
+JOIN threads th ON th.id = t.thread_id
+WHERE (sqlc.narg(status)::text IS NULL
+       OR (SELECT r.status::text FROM tasks_runs r WHERE r.task_id = t.id ORDER BY r.created_at DESC LIMIT 1) = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_by)::uuid IS NULL OR t.created_by = sqlc.narg(created_by)::uuid)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_before)::timestamptz)
+  AND th.workspace_id = sqlc.arg(workspace_id) AND th.deleted_at IS NULL
+  AND (th.user_id = sqlc.arg(user_id) OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = th.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT r.role_id FROM user_role_mapping r WHERE r.user_id = sqlc.arg(user_id)))));
 
 -- name: GetTaskById :one
 SELECT * FROM tasks WHERE id = $1 LIMIT 1;
 
+-- name: GetWorkspaceTask :one
+-- Gets a task of a thread of a workspace
+SELECT t.* FROM tasks t
+JOIN threads th ON th.id = t.thread_id
+WHERE th.workspace_id = $1 AND t.id = $2
+LIMIT 1;
+
 -- name: CreateTask :one
-INSERT INTO tasks (thread_id, max_request_loop, additional_info, created_by)
-VALUES ($1, $2, $3, $4)
+INSERT INTO tasks (thread_id, max_request_loop, additional_info, created_by, flow_run_id)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: CreateTaskWithID :one
@@ -15,13 +67,15 @@ VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: UpdateTask :one
+-- Updates a task of a thread of a workspace
 UPDATE tasks
 SET max_request_loop = $1, additional_info = $2
-WHERE id = $3
+WHERE id = $3 AND thread_id IN (SELECT th.id FROM threads th WHERE th.workspace_id = $4)
 RETURNING *;
 
 -- name: DeleteTask :exec
-DELETE FROM tasks WHERE id = $1;
+-- Deletes a task of a thread of a workspace
+DELETE FROM tasks WHERE id = $1 AND thread_id IN (SELECT th.id FROM threads th WHERE th.workspace_id = $2);
 
 -- name: GetTasksByThreadId :many
 SELECT * FROM tasks WHERE thread_id = $1 ORDER BY created_at DESC;
\ No newline at end of file
@@ -1,5 +1,29 @@
 -- name: CreateTasksRun :one
-INSERT INTO tasks_runs (task_id) VALUES ($1) RETURNING *;
+-- Creates a started run of a task, fails with a unique violation when the task already has a started run
+INSERT INTO tasks_runs (task_id, status, started_at) VALUES ($1, 'RUNNING', NOW()) RETURNING *;
+
+-- name: CreateQueuedTasksRun :one
+-- Creates a run of a task queued behind its started run, with the agent it invokes once started
+INSERT INTO tasks_runs (
+    task_id,
+    current_loops,
+    agent_id,
+    requested_by,
+    workspace_id,
+    dry_run
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: CreateSubTasksRun :one
+-- Run of a sub-agent invoked with invoke_agent, failed with a timeout result past its deadline when the timeout is set
+INSERT INTO tasks_runs (task_id, status, started_at, deadline_at)
+VALUES (
+    @task_id,
+    'RUNNING',
+    NOW(),
+    CASE WHEN @timeout_seconds::INTEGER > 0 THEN NOW() + make_interval(secs => @timeout_seconds::INTEGER) END
+) RETURNING *;
 
 -- name: GetTasksRun :one
 SELECT * FROM tasks_runs WHERE task_run_id = $1;
@@ -10,8 +34,41 @@ WHERE task_id = $1
 ORDER BY created_at DESC;
 
 -- name: GetCurrentTaskRunByTaskID :one
+-- Gets the started run of a task, the queued runs are not started yet
 SELECT * FROM tasks_runs
-WHERE task_id = $1 AND status IN ('PAUSE', 'SCHEDULED', 'RUNNING');
+WHERE task_id = $1 AND status IN ('PENDING', 'RUNNING');
+
+-- name: GetLastStartedTaskRunByTaskID :one
+-- Gets the run of a task started last, running or ended
+SELECT * FROM tasks_runs
+WHERE task_id = $1 AND started_at IS NOT NULL
+ORDER BY started_at DESC
+LIMIT 1;
+
+-- name: CountQueuedTaskRuns :one
+SELECT COUNT(*) FROM tasks_runs
+WHERE task_id = $1 AND status = 'SCHEDULED';
+
+-- name: ListStartableQueuedTaskIDs :many
+-- Lists the tasks with a queued run and without started run
+SELECT DISTINCT q.task_id FROM tasks_runs q
+WHERE q.status = 'SCHEDULED' AND q.agent_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM tasks_runs s WHERE s.task_id = q.task_id AND s.status IN ('PENDING', 'RUNNING'))
+LIMIT $1;
+
+-- name: StartQueuedTaskRun :one
+-- Starts the oldest queued run of a task, no row is returned when the task has a started run or nothing queued
+UPDATE tasks_runs
+SET status = 'RUNNING', started_at = NOW(), updated_at = NOW()
+WHERE task_run_id = (
+    SELECT q.task_run_id FROM tasks_runs q
+    WHERE q.task_id = $1 AND q.status = 'SCHEDULED' AND q.agent_id IS NOT NULL
+      AND NOT EXISTS (SELECT 1 FROM tasks_runs s WHERE s.task_id = q.task_id AND s.status IN ('PENDING', 'RUNNING'))
+    ORDER BY q.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+) AND status = 'SCHEDULED'
+RETURNING *;
 
 -- name: GetTaskRunByStatus :many
 SELECT * FROM tasks_runs 
@@ -29,22 +86,54 @@ WHERE status = 'RUNNING'
 ORDER BY created_at ASC;
 
 -- name: UpdateTaskRunStatus :exec
+-- Moves a run to a status, an ended run keeps the status it ended with
 UPDATE tasks_runs
 SET status = sqlc.arg(status),
-    updated_at = NOW()
-WHERE task_run_id = sqlc.arg(task_run_id);
+    updated_at = NOW(),
+    finished_at = CASE WHEN sqlc.arg(status) IN ('FINISHED', 'FAILED', 'CANCELLED') THEN NOW() ELSE finished_at END
+WHERE task_run_id = sqlc.arg(task_run_id) AND status NOT IN ('FINISHED', 'FAILED', 'CANCELLED');
 
 -- name: UpdateTaskRunStatusByTaskID :exec
+-- Moves the started run of a task between RUNNING and PENDING, the queued runs are left as they are
 UPDATE tasks_runs
 SET status = sqlc.arg(status), updated_at = NOW()
-WHERE task_id = sqlc.arg(task_id) AND status IN ('SCHEDULED', 'RUNNING', 'PENDING');
+WHERE task_id = sqlc.arg(task_id) AND status IN ('RUNNING', 'PENDING');
+
+-- name: CompleteTaskRunByTaskID :execrows
+-- Ends the started run of a task, no row is updated when the run already ended. The queued runs are left as they are.
+UPDATE tasks_runs
+SET status = sqlc.arg(status), updated_at = NOW(), finished_at = NOW()
+WHERE task_id = sqlc.arg(task_id) AND status IN ('RUNNING', 'PENDING');
+
+-- name: CancelTaskRun :one
+-- Cancels a queued or started run of a task, no row is returned when the run already ended
+UPDATE tasks_runs
+SET status = 'CANCELLED', updated_at = NOW(), finished_at = NOW()
+WHERE task_run_id = $1 AND status IN ('SCHEDULED', 'RUNNING', 'PENDING')
+RETURNING *;
+
+-- name: ListExpiredTaskRuns :many
+SELECT * FROM tasks_runs
+WHERE deadline_at < NOW() AND status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+ORDER BY deadline_at ASC;
+
+-- name: ListActiveSubTaskIDs :many
+SELECT t.id FROM tasks t
+JOIN tasks_runs tr ON tr.task_id = t.id
+WHERE t.parent_task_id = $1 AND tr.status IN ('SCHEDULED', 'PENDING', 'RUNNING');
+
+-- name: CountRunningThreadTaskRuns :one
+-- Counts the runs of the top level tasks of a thread still in flight, the runs paused for the user are not counted
+SELECT COUNT(*) FROM tasks_runs tr
+JOIN tasks t ON t.id = tr.task_id
+WHERE t.thread_id = $1 AND t.parent_task_id IS NULL AND tr.status IN ('SCHEDULED', 'RUNNING');
 
 -- name: UpdateTaskRunStatusWithTimestamps :exec
 UPDATE tasks_runs 
 SET status = sqlc.arg(status)::text, 
     updated_at = NOW(),
     started_at = CASE WHEN sqlc.arg(status)::text = 'RUNNING' AND started_at IS NULL THEN NOW() ELSE started_at END,
-    finished_at = CASE WHEN sqlc.arg(status)::text IN ('FINISHED', 'FAILED') AND finished_at IS NULL THEN NOW() ELSE finished_at END
+    finished_at = CASE WHEN sqlc.arg(status)::text IN ('FINISHED', 'FAILED', 'CANCELLED') AND finished_at IS NULL THEN NOW() ELSE finished_at END
 WHERE task_run_id = sqlc.arg(task_run_id);
 
 -- name: UpdateTaskRunStartedAt :exec
@@ -71,7 +160,7 @@ DELETE FROM tasks_runs WHERE task_run_id = $1;
 -- name: DeleteOldTaskRun :exec
 DELETE FROM tasks_runs 
 WHERE created_at < $1 
-AND status IN ('FINISHED', 'FAILED');
+AND status IN ('FINISHED', 'FAILED', 'CANCELLED');
 
 -- name: ListTaskRun :many
 SELECT tr.*, t.thread_id, t.max_request_loop
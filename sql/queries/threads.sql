@@ -1,14 +1,87 @@
 -- name: GetThreads :many
-SELECT * FROM threads WHERE user_id = $1 ORDER BY updated_at DESC;
+-- Lists the threads or the threads in the trash of a user in a workspace matching the filters in the order of the
+-- sort, after the thread of the cursor when set. The live threads shared with the user are listed with its threads.
+SELECT * FROM threads t
+WHERE t.workspace_id = sqlc.arg(workspace_id)
+  AND (t.user_id = sqlc.arg(user_id)
+       OR (t.deleted_at IS NULL AND EXISTS (SELECT 1 FROM resource_grants g
+           WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+             AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+                  OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = sqlc.arg(user_id))))))
+  AND (sqlc.narg(title)::text IS NULL OR t.title ILIKE '%' || sqlc.narg(title)::text || '%')
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_before)::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean
+  AND (sqlc.narg(cursor_id)::uuid IS NULL OR CASE
+    WHEN sqlc.arg(sort)::text = 'title' AND NOT sqlc.arg(descending)::boolean
+      THEN (t.title, t.id) > (sqlc.narg(cursor_text)::text, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'title'
+      THEN (t.title, t.id) < (sqlc.narg(cursor_text)::text, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'created_at' AND NOT sqlc.arg(descending)::boolean
+      THEN (t.created_at, t.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'created_at'
+      THEN (t.created_at, t.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+    WHEN NOT sqlc.arg(descending)::boolean
+      THEN (t.updated_at, t.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+    ELSE (t.updated_at, t.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'title' AND NOT sqlc.arg(descending)::boolean THEN t.title END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'title' AND sqlc.arg(descending)::boolean THEN t.title END DESC,
+  CASE WHEN sqlc.arg(sort)::text = 'created_at' AND NOT sqlc.arg(descending)::boolean THEN t.created_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'created_at' AND sqlc.arg(descending)::boolean THEN t.created_at END DESC,
+  CASE WHEN sqlc.arg(sort)::text NOT IN ('title', 'created_at') AND NOT sqlc.arg(descending)::boolean THEN t.updated_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text NOT IN ('title', 'created_at') AND sqlc.arg(descending)::boolean THEN t.updated_at END DESC,
+  CASE WHEN NOT sqlc.arg(descending)::boolean THEN t.id END ASC,
+  CASE WHEN sqlc.arg(descending)::boolean THEN t.id END DESC
+LIMIT sqlc.arg(row_limit);
+-- name: CountThreads :one
+-- Counts the threads of a user in a workspace matching the filters of GetThreads
+SELECT COUNT(*) FROM threads t
+WHERE t.workspace_id = sqlc.arg(workspace_id)
+  AND (t.user_id = sqlc.arg(user_id)
+       OR (t.deleted_at IS NULL AND EXISTS (SELECT 1 FROM resource_grants g
+           WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+             AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)
+                  OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = sqlc.arg(user_id))))))
+  AND (sqlc.narg(title)::text IS NULL OR t.title ILIKE '%' || sqlc.narg(title)::text || '%')
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_before)::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean;
 -- name: GetThreadByID :one
-SELECT * FROM threads WHERE user_id = $1 AND id = $2 LIMIT 1;
+-- Gets a live thread of a workspace owned by the user or shared with the user
+SELECT * FROM threads t
+WHERE t.workspace_id = $1
+  AND (t.user_id = $2 OR EXISTS (SELECT 1 FROM resource_grants g
+       WHERE g.resource_type = 'thread' AND g.resource_id = t.id
+         AND (g.grantee_type = 'USER' AND g.grantee_id = $2
+              OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = $2))))
+  AND t.id = $3 AND t.deleted_at IS NULL
+LIMIT 1;
 -- name: CreateThread :one
-INSERT INTO threads (title, created_at, updated_at, user_id) VALUES ($1, $2, $3, $4) RETURNING *;
+INSERT INTO threads (title, created_at, updated_at, user_id, workspace_id) VALUES ($1, $2, $3, $4, $5) RETURNING *;
+-- name: CreateThreadFork :one
+-- Creates a thread of a user in a workspace forked from a message of another thread
+INSERT INTO threads (title, user_id, workspace_id, forked_from, forked_from_message)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
 -- name: UpdateThread :one
+-- Updates a thread of a workspace, only while its update time is if_updated_at when set
 UPDATE threads
 SET title = $1
-WHERE id = $2
+WHERE id = $2 AND workspace_id = $3 AND deleted_at IS NULL
+  AND (sqlc.narg(if_updated_at)::timestamptz IS NULL OR updated_at = sqlc.narg(if_updated_at)::timestamptz)
 RETURNING *;
 -- name: DeleteThread :exec
 DELETE FROM threads WHERE id = $1;
+-- name: SoftDeleteThread :execrows
+-- Moves a thread of a user in a workspace to the trash
+UPDATE threads SET deleted_at = NOW() WHERE workspace_id = $1 AND user_id = $2 AND id = $3 AND deleted_at IS NULL;
+-- name: RestoreThread :one
+-- Restores a thread of a user in a workspace from the trash
+UPDATE threads SET deleted_at = NULL WHERE workspace_id = $1 AND user_id = $2 AND id = $3 AND deleted_at IS NOT NULL
+RETURNING *;
+-- name: PurgeThread :execrows
+-- Deletes a thread of the trash of a user in a workspace for good
+DELETE FROM threads WHERE workspace_id = $1 AND user_id = $2 AND id = $3 AND deleted_at IS NOT NULL;
 
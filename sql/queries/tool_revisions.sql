@@ -0,0 +1,36 @@
+-- ==============================================
+-- TOOL REVISION QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateToolRevision :one
+-- Snapshots the current description and configuration of the tool as its next revision and promotes it
+WITH new_revision AS (
+    INSERT INTO tool_revisions (tool_id, revision, description, config, created_by)
+    SELECT t.id, (SELECT COALESCE(MAX(r.revision), 0) + 1 FROM tool_revisions r WHERE r.tool_id = t.id), t.description, t.config, $2
+    FROM tools t
+    WHERE t.id = $1
+    RETURNING tool_id, revision
+)
+UPDATE tools t SET
+    revision = new_revision.revision
+FROM new_revision
+WHERE t.id = new_revision.tool_id
+RETURNING t.*;
+
+-- name: PromoteToolRevision :one
+UPDATE tools t SET
+    description = r.description,
+    config = r.config,
+    revision = r.revision
+FROM tool_revisions r
+WHERE t.id = r.tool_id AND r.tool_id = $1 AND r.revision = $2
+RETURNING t.*;
+
+-- name: GetToolRevision :one
+SELECT * FROM tool_revisions
+WHERE tool_id = $1 AND revision = $2;
+
+-- name: ListToolRevisions :many
+SELECT * FROM tool_revisions
+WHERE tool_id = $1
+ORDER BY revision DESC;
@@ -0,0 +1,69 @@
+-- name: CreateToolRunAudit :exec
+INSERT INTO tool_run_audit (
+    tool_run_id,
+    parent_run_id,
+    tool_id,
+    tool_name,
+    tool_revision,
+    user_id,
+    agent_id,
+    recipient_id,
+    thread_id,
+    task_id,
+    connection_id,
+    input,
+    expires_at
+) VALUES (
+    @tool_run_id,
+    @parent_run_id,
+    @tool_id,
+    @tool_name,
+    @tool_revision,
+    @user_id,
+    @agent_id,
+    @recipient_id,
+    @thread_id,
+    @task_id,
+    @connection_id,
+    @input,
+    NOW() + make_interval(days => sqlc.arg(retention_days)::INTEGER)
+) ON CONFLICT (tool_run_id) DO NOTHING;
+
+-- name: CompleteToolRunAudit :exec
+UPDATE tool_run_audit
+SET output = @output, status = @status, duration = @duration, completed_at = NOW()
+WHERE tool_run_id = @tool_run_id;
+
+-- name: CancelToolRunAudit :exec
+-- Completes the audit entries of the tool runs cancelled with their task run
+UPDATE tool_run_audit
+SET status = 'CANCELLED', duration = EXTRACT(EPOCH FROM NOW() - created_at), completed_at = NOW()
+WHERE tool_run_id = ANY(@tool_run_ids::text[]) AND status IN ('PENDING', 'RUNNING');
+
+-- name: GetToolRunAudit :one
+SELECT * FROM tool_run_audit WHERE tool_run_id = $1;
+
+-- name: ListToolRunAudit :many
+SELECT * FROM tool_run_audit a
+WHERE (sqlc.narg(tool_id)::uuid IS NULL OR a.tool_id = sqlc.narg(tool_id)::uuid)
+  AND (sqlc.narg(agent_id)::uuid IS NULL OR a.agent_id = sqlc.narg(agent_id)::uuid)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR a.user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(task_id)::text IS NULL OR a.task_id = sqlc.narg(task_id)::text)
+  AND (sqlc.narg(status)::text IS NULL OR a.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR a.created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR a.created_at < sqlc.narg(until)::timestamptz)
+ORDER BY a.created_at DESC, a.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountToolRunAudit :one
+SELECT COUNT(*) FROM tool_run_audit a
+WHERE (sqlc.narg(tool_id)::uuid IS NULL OR a.tool_id = sqlc.narg(tool_id)::uuid)
+  AND (sqlc.narg(agent_id)::uuid IS NULL OR a.agent_id = sqlc.narg(agent_id)::uuid)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR a.user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(task_id)::text IS NULL OR a.task_id = sqlc.narg(task_id)::text)
+  AND (sqlc.narg(status)::text IS NULL OR a.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR a.created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR a.created_at < sqlc.narg(until)::timestamptz);
+
+-- name: DeleteExpiredToolRunAudit :execrows
+DELETE FROM tool_run_audit WHERE expires_at < NOW();
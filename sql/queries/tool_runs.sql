@@ -7,12 +7,24 @@ SELECT * FROM tool_runs WHERE id = $1 AND parent_run_id IS NOT NULL LIMIT 1;
 -- name: GetToolRunStatusByID :one
 SELECT * FROM tool_runs WHERE id = $1 LIMIT 1;
 -- name: CreateToolRunStatus :one
+-- The tool is looked up by name in the workspace of the run, then in the shared tools
 INSERT INTO tool_runs (connection_id, thread_id, agent_id, recipient_id, id, tool_id, input)
-VALUES ($1, $2, $3, $4, $5, (SELECT id FROM tools WHERE name = $6), $7)
+VALUES ($1, $2, $3, $4, $5, (
+    SELECT id FROM tools
+    WHERE name = $6 AND (workspace_id = $8 OR workspace_id IS NULL) AND deleted_at IS NULL
+    ORDER BY workspace_id NULLS LAST
+    LIMIT 1
+), $7)
 RETURNING *;
 -- name: CreateChildToolRunStatus :one
+-- The tool is looked up by name in the workspace of the run, then in the shared tools
 INSERT INTO tool_runs (connection_id, thread_id, agent_id, recipient_id, id, tool_id, input, parent_run_id)
-VALUES ($1, $2, $3, $4, $5, (SELECT id FROM tools WHERE name = $6), $7, $8)
+VALUES ($1, $2, $3, $4, $5, (
+    SELECT id FROM tools
+    WHERE name = $6 AND (workspace_id = $9 OR workspace_id IS NULL) AND deleted_at IS NULL
+    ORDER BY workspace_id NULLS LAST
+    LIMIT 1
+), $7, $8)
 RETURNING *;
 -- name: UpdateToolRunStatusByID :one
 UPDATE tool_runs
@@ -39,7 +51,7 @@ SELECT NOT EXISTS (
   SELECT 1
   FROM tool_runs
   WHERE parent_run_id = $1
-    AND status NOT IN ('SUCCESS', 'FAILED')
+    AND status NOT IN ('SUCCESS', 'FAILED', 'CANCELLED')
 ) AS all_completed;
 -- name: DeleteToolRunStatusByID :exec
 DELETE FROM tool_runs WHERE id = $1;
@@ -58,4 +70,36 @@ SELECT EXISTS (
     JOIN tools t ON tr.tool_id = t.id
     WHERE tr.id = $1
     AND t.name = 'temp_parallel_tool_management'
-) AS is_temp_parallel_tool;
\ No newline at end of file
+) AS is_temp_parallel_tool;
+-- name: GetCachedToolRun :one
+-- Latest successful run of the tool with the same input hash within the cache TTL, cache hits excluded. The hash covers
+-- the revision of the tool, the workspace and the user with the input, so the key and its index scope the results
+SELECT id, result FROM tool_runs
+WHERE tool_id = @tool_id
+  AND input_hash = @input_hash
+  AND status = 'SUCCESS'
+  AND cached_from_run_id IS NULL
+  AND updated_at > NOW() - make_interval(secs => @ttl_seconds::INTEGER)
+ORDER BY updated_at DESC
+LIMIT 1;
+-- name: SetToolRunInputHash :exec
+UPDATE tool_runs SET input_hash = $1 WHERE id = $2;
+-- name: RecordToolRunCacheHit :exec
+WITH cached AS (
+    UPDATE tool_runs SET cache_hits = cache_hits + 1 WHERE tool_runs.id = @cached_from_run_id
+)
+UPDATE tool_runs SET input_hash = @input_hash, cached_from_run_id = @cached_from_run_id WHERE tool_runs.id = @id;
+-- name: ListThreadToolRuns :many
+-- Tool runs of a thread in the order they were requested, with the name of their tool
+SELECT tr.id, tr.tool_id, t.name AS tool_name, tr.agent_id, tr.recipient_id, tr.parent_run_id, tr.status, tr.input, tr.result,
+       tr.duration, tr.created_at
+FROM tool_runs tr
+LEFT JOIN tools t ON t.id = tr.tool_id
+WHERE tr.thread_id = $1
+ORDER BY tr.created_at, tr.id;
+-- name: CancelThreadToolRuns :many
+-- Cancels the tool runs of a thread still in flight that started since a time, their late results are dropped
+UPDATE tool_runs
+SET status = 'CANCELLED', result = @result, updated_at = NOW()
+WHERE thread_id = @thread_id AND status IN ('PENDING', 'RUNNING') AND created_at >= @since
+RETURNING id;
@@ -3,39 +3,206 @@
 -- ==============================================
 
 -- name: ListTools :many
+-- Lists the tools of a workspace and the shared tools
 SELECT *
 FROM tools t
+WHERE (t.workspace_id = sqlc.arg(workspace_id)::uuid OR t.workspace_id IS NULL) AND t.deleted_at IS NULL
 ORDER BY t.created_at DESC;
 
+-- name: SearchTools :many
+-- Lists the tools or the tools in the trash matching the filters in the order of the sort, after the tool of the cursor
+-- when set. The id breaks the ties between equal sort keys, the tools without a category come last in both directions.
+-- The shared tools are listed with the tools of the workspace, the tools with grants are only listed to their creator
+-- and their grantees.
+SELECT *
+FROM tools t
+WHERE (t.workspace_id = sqlc.arg(workspace_id)::uuid OR t.workspace_id IS NULL)
+  AND (sqlc.narg(category)::text IS NULL OR t.category = sqlc.narg(category)::text)
+  AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(t.tags))
+  AND (sqlc.narg(search)::text IS NULL
+       OR strpos(lower(t.name), lower(sqlc.narg(search)::text)) > 0
+       OR strpos(lower(t.description), lower(sqlc.narg(search)::text)) > 0)
+  AND (sqlc.narg(type)::text IS NULL OR t.config->>'type' = sqlc.narg(type)::text)
+  AND (sqlc.narg(status)::text IS NULL OR t.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_by)::uuid IS NULL OR t.created_by = sqlc.narg(created_by)::uuid)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_before)::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean
+  AND (t.created_by = sqlc.arg(user_id)::uuid
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'tool' AND g.resource_id = t.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'tool' AND g.resource_id = t.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)::uuid
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = sqlc.arg(user_id)::uuid))))
+  AND (sqlc.narg(cursor_id)::uuid IS NULL OR CASE
+    WHEN sqlc.arg(sort)::text = 'name' AND NOT sqlc.arg(descending)::boolean
+      THEN (t.name, t.id) > (sqlc.narg(cursor_text)::text, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'name'
+      THEN (t.name, t.id) < (sqlc.narg(cursor_text)::text, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'category' AND sqlc.narg(cursor_text)::text IS NULL
+      THEN t.category IS NULL AND CASE WHEN sqlc.arg(descending)::boolean THEN t.id < sqlc.narg(cursor_id)::uuid ELSE t.id > sqlc.narg(cursor_id)::uuid END
+    WHEN sqlc.arg(sort)::text = 'category' AND NOT sqlc.arg(descending)::boolean
+      THEN t.category IS NULL OR (t.category, t.id) > (sqlc.narg(cursor_text)::text, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'category'
+      THEN t.category IS NULL OR (t.category, t.id) < (sqlc.narg(cursor_text)::text, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'updated_at' AND NOT sqlc.arg(descending)::boolean
+      THEN (t.updated_at, t.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+    WHEN sqlc.arg(sort)::text = 'updated_at'
+      THEN (t.updated_at, t.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+    WHEN NOT sqlc.arg(descending)::boolean
+      THEN (t.created_at, t.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+    ELSE (t.created_at, t.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid)
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'name' AND NOT sqlc.arg(descending)::boolean THEN t.name END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'name' AND sqlc.arg(descending)::boolean THEN t.name END DESC,
+  CASE WHEN sqlc.arg(sort)::text = 'category' AND NOT sqlc.arg(descending)::boolean THEN t.category END ASC NULLS LAST,
+  CASE WHEN sqlc.arg(sort)::text = 'category' AND sqlc.arg(descending)::boolean THEN t.category END DESC NULLS LAST,
+  CASE WHEN sqlc.arg(sort)::text = 'updated_at' AND NOT sqlc.arg(descending)::boolean THEN t.updated_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'updated_at' AND sqlc.arg(descending)::boolean THEN t.updated_at END DESC,
+  CASE WHEN sqlc.arg(sort)::text NOT IN ('name', 'category', 'updated_at') AND NOT sqlc.arg(descending)::boolean THEN t.created_at END ASC,
+  CASE WHEN sqlc.arg(sort)::text NOT IN ('name', 'category', 'updated_at') AND sqlc.arg(descending)::boolean THEN t.created_at END DESC,
+  CASE WHEN NOT sqlc.arg(descending)::boolean THEN t.id END ASC,
+  CASE WHEN sqlc.arg(descending)::boolean THEN t.id END DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountSearchTools :one
+-- Counts the tools matching the filters of SearchTools
+SELECT COUNT(*)
+FROM tools t
+WHERE (t.workspace_id = sqlc.arg(workspace_id)::uuid OR t.workspace_id IS NULL)
+  AND (sqlc.narg(category)::text IS NULL OR t.category = sqlc.narg(category)::text)
+  AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(t.tags))
+  AND (sqlc.narg(search)::text IS NULL
+       OR strpos(lower(t.name), lower(sqlc.narg(search)::text)) > 0
+       OR strpos(lower(t.description), lower(sqlc.narg(search)::text)) > 0)
+  AND (sqlc.narg(type)::text IS NULL OR t.config->>'type' = sqlc.narg(type)::text)
+  AND (sqlc.narg(status)::text IS NULL OR t.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_by)::uuid IS NULL OR t.created_by = sqlc.narg(created_by)::uuid)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_before)::timestamptz)
+  AND (t.deleted_at IS NOT NULL) = sqlc.arg(deleted)::boolean
+  AND (t.created_by = sqlc.arg(user_id)::uuid
+       OR NOT EXISTS (SELECT 1 FROM resource_grants g WHERE g.resource_type = 'tool' AND g.resource_id = t.id)
+       OR EXISTS (SELECT 1 FROM resource_grants g
+                  WHERE g.resource_type = 'tool' AND g.resource_id = t.id
+                    AND (g.grantee_type = 'USER' AND g.grantee_id = sqlc.arg(user_id)::uuid
+                         OR g.grantee_type = 'ROLE' AND g.grantee_id IN (SELECT m.role_id FROM user_role_mapping m WHERE m.user_id = sqlc.arg(user_id)::uuid))));
+
 -- name: GetToolById :one
+-- Gets a tool of a workspace or a shared tool
 SELECT *
 FROM tools
-WHERE id = $1;
+WHERE id = sqlc.arg(id)
+  AND (workspace_id = sqlc.arg(workspace_id)::uuid OR workspace_id IS NULL)
+  AND deleted_at IS NULL;
 
 -- name: CreateTool :one
 INSERT INTO tools (
     name,
     description, 
     config,
-    created_by
+    created_by,
+    category,
+    icon,
+    tags,
+    examples,
+    documentation,
+    workspace_id
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: UpdateTool :one
+-- Updates a tool of a workspace, or a shared tool when the workspace is null, only while its update time is
+-- if_updated_at when set. A tool whose health check is removed is no longer probed, its status is reset.
 UPDATE tools SET
-    description = COALESCE($2, description),
-    config = COALESCE($3, config)
-WHERE id = $1
+    description = COALESCE(sqlc.narg(description), description),
+    config = COALESCE(sqlc.narg(config), config),
+    category = COALESCE(sqlc.narg(category), category),
+    icon = COALESCE(sqlc.narg(icon), icon),
+    tags = COALESCE(sqlc.narg(tags), tags),
+    examples = COALESCE(sqlc.narg(examples), examples),
+    documentation = COALESCE(sqlc.narg(documentation), documentation),
+    status = CASE WHEN COALESCE(sqlc.narg(config), config) ? 'health_check' THEN status ELSE 'unknown' END,
+    status_message = CASE WHEN COALESCE(sqlc.narg(config), config) ? 'health_check' THEN status_message ELSE NULL END,
+    status_checked_at = CASE WHEN COALESCE(sqlc.narg(config), config) ? 'health_check' THEN status_checked_at ELSE NULL END
+WHERE id = sqlc.arg(id)
+  AND workspace_id IS NOT DISTINCT FROM sqlc.narg(workspace_id)::uuid
+  AND deleted_at IS NULL
+  AND (sqlc.narg(if_updated_at)::timestamptz IS NULL OR updated_at = sqlc.narg(if_updated_at)::timestamptz)
 RETURNING *;
 
 -- name: DeleteTool :exec
 DELETE FROM tools WHERE id = $1;
 
+-- name: SoftDeleteTool :execrows
+-- Moves a tool of a workspace to the trash, the shared tools cannot be deleted
+UPDATE tools SET deleted_at = NOW()
+WHERE workspace_id = sqlc.arg(workspace_id)::uuid AND id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: RestoreTool :one
+-- Restores a tool of a workspace from the trash
+UPDATE tools SET deleted_at = NULL
+WHERE workspace_id = sqlc.arg(workspace_id)::uuid AND id = sqlc.arg(id) AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeTool :execrows
+-- Deletes a tool of the trash of a workspace for good
+DELETE FROM tools
+WHERE workspace_id = sqlc.arg(workspace_id)::uuid AND id = sqlc.arg(id) AND deleted_at IS NOT NULL;
+
+-- name: GetToolOwner :one
+-- Returns the creator of a tool of a workspace, live or in the trash
+SELECT created_by FROM tools WHERE workspace_id = sqlc.arg(workspace_id)::uuid AND id = sqlc.arg(id);
+
 -- name: GetToolInfoByName :one
-SELECT * FROM tools WHERE name = $1;
+-- Gets a tool of a workspace by name, or the shared tool of the name. A null workspace only gets the shared tools.
+SELECT * FROM tools
+WHERE name = $1 AND (workspace_id = $2 OR workspace_id IS NULL) AND deleted_at IS NULL
+ORDER BY workspace_id NULLS LAST
+LIMIT 1;
 
 -- name: GetToolsByIDs :many
-SELECT * FROM tools 
-WHERE id = ANY($1::uuid[])
+SELECT * FROM tools
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+  AND (workspace_id = sqlc.arg(workspace_id)::uuid OR workspace_id IS NULL)
+  AND deleted_at IS NULL
+ORDER BY name;
+
+-- name: ListToolsByTags :many
+SELECT * FROM tools
+WHERE tags && sqlc.arg(tags)::text[]
+  AND (workspace_id = sqlc.arg(workspace_id)::uuid OR workspace_id IS NULL)
+  AND deleted_at IS NULL
+ORDER BY name;
+
+-- name: ListToolCatalog :many
+-- Lists the tools of the catalog of a workspace by category, the tools with a null or empty category sorted under
+-- uncategorized where they are grouped. The search matches its text literally, % and _ are not wildcards.
+SELECT *
+FROM tools t
+WHERE (t.workspace_id = sqlc.arg(workspace_id)::uuid OR t.workspace_id IS NULL)
+  AND t.deleted_at IS NULL
+  AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(t.tags))
+  AND (sqlc.narg(search)::text IS NULL
+       OR strpos(lower(t.name), lower(sqlc.narg(search)::text)) > 0
+       OR strpos(lower(t.description), lower(sqlc.narg(search)::text)) > 0)
+ORDER BY COALESCE(NULLIF(t.category, ''), 'uncategorized'), t.name;
+
+-- name: ListAllTools :many
+-- Lists the tools of every workspace and the shared tools, for the jobs of the tools service
+SELECT * FROM tools WHERE deleted_at IS NULL ORDER BY name;
+
+-- name: ListToolsWithHealthCheck :many
+SELECT *
+FROM tools
+WHERE config ? 'health_check' AND deleted_at IS NULL
 ORDER BY name;
+
+-- name: UpdateToolStatus :exec
+UPDATE tools SET
+    status = $2,
+    status_message = $3,
+    status_checked_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,62 @@
+-- ==============================================
+-- USAGE QUOTA QUERIES FOR SQLC
+-- ==============================================
+
+-- name: AddDailyUsage :exec
+-- Adds tokens and tool runs to the usage of the current UTC day of a user in a workspace
+INSERT INTO daily_usage (workspace_id, user_id, day, input_tokens, output_tokens, tool_runs)
+VALUES (@workspace_id, @user_id, (NOW() AT TIME ZONE 'UTC')::DATE, @input_tokens::BIGINT, @output_tokens::BIGINT, @tool_runs::BIGINT)
+ON CONFLICT (workspace_id, user_id, day) DO UPDATE SET
+    input_tokens = daily_usage.input_tokens + EXCLUDED.input_tokens,
+    output_tokens = daily_usage.output_tokens + EXCLUDED.output_tokens,
+    tool_runs = daily_usage.tool_runs + EXCLUDED.tool_runs,
+    updated_at = NOW();
+
+-- name: GetQuotaUsage :one
+-- Returns the usage of the current UTC day of a user over all the workspaces and of a workspace over all its users,
+-- with their task runs scheduled or running. The flow minutes count the wall time of the runs spent in the day.
+WITH day_start AS (
+    SELECT date_trunc('day', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS at
+),
+usage AS (
+    SELECT
+        COALESCE(SUM(u.input_tokens + u.output_tokens) FILTER (WHERE u.user_id = @user_id), 0)::BIGINT AS user_tokens,
+        COALESCE(SUM(u.tool_runs) FILTER (WHERE u.user_id = @user_id), 0)::BIGINT AS user_tool_runs,
+        COALESCE(SUM(u.input_tokens + u.output_tokens) FILTER (WHERE u.workspace_id = @workspace_id), 0)::BIGINT AS workspace_tokens,
+        COALESCE(SUM(u.tool_runs) FILTER (WHERE u.workspace_id = @workspace_id), 0)::BIGINT AS workspace_tool_runs
+    FROM daily_usage u
+    WHERE u.day = (NOW() AT TIME ZONE 'UTC')::DATE
+      AND (u.user_id = @user_id OR u.workspace_id = @workspace_id)
+),
+flow_usage AS (
+    SELECT
+        COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(fr.finished_at, NOW()) - GREATEST(fr.started_at, d.at))) FILTER (WHERE c.user_id = @user_id), 0)::DOUBLE PRECISION AS user_flow_seconds,
+        COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(fr.finished_at, NOW()) - GREATEST(fr.started_at, d.at))) FILTER (WHERE f.workspace_id = @workspace_id), 0)::DOUBLE PRECISION AS workspace_flow_seconds
+    FROM flow_runs fr
+    CROSS JOIN day_start d
+    JOIN flows f ON f.id = fr.flow_id
+    LEFT JOIN flow_run_costs c ON c.flow_run_id = fr.flow_run_id
+    WHERE fr.started_at IS NOT NULL
+      AND (fr.finished_at IS NULL OR fr.finished_at >= d.at)
+      AND (c.user_id = @user_id OR f.workspace_id = @workspace_id)
+),
+task_usage AS (
+    SELECT
+        COUNT(*) FILTER (WHERE t.created_by = @user_id) AS user_active_tasks,
+        COUNT(*) FILTER (WHERE th.workspace_id = @workspace_id) AS workspace_active_tasks
+    FROM tasks_runs r
+    JOIN tasks t ON t.id = r.task_id
+    JOIN threads th ON th.id = t.thread_id
+    WHERE r.status IN ('SCHEDULED', 'PENDING', 'RUNNING')
+      AND (t.created_by = @user_id OR th.workspace_id = @workspace_id)
+)
+SELECT
+    usage.user_tokens,
+    usage.user_tool_runs,
+    flow_usage.user_flow_seconds,
+    task_usage.user_active_tasks,
+    usage.workspace_tokens,
+    usage.workspace_tool_runs,
+    flow_usage.workspace_flow_seconds,
+    task_usage.workspace_active_tasks
+FROM usage, flow_usage, task_usage;
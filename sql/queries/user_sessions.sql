@@ -0,0 +1,25 @@
+-- ==============================================
+-- USER SESSION QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateUserSession :one
+INSERT INTO user_sessions (user_id, token_hash, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetActiveUserSessionByHash :one
+-- Returns the session of a token hash unless it was revoked or expired
+SELECT * FROM user_sessions
+WHERE token_hash = $1
+AND revoked_at IS NULL
+AND expires_at > NOW();
+
+-- name: RevokeUserSession :exec
+UPDATE user_sessions
+SET revoked_at = NOW()
+WHERE token_hash = $1 AND revoked_at IS NULL;
+
+-- name: DeleteExpiredUserSessions :execrows
+-- Removes the sessions expired or revoked before a time
+DELETE FROM user_sessions
+WHERE expires_at < $1 OR revoked_at < $1;
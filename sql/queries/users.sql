@@ -10,6 +10,12 @@ VALUES ($1, $2, $3, $4, $5)
 RETURNING id, name, email, additional_info, provider_name, is_online, created_at, updated_at;
 -- name: ListRolesForUser :many
 SELECT * FROM user_role_mapping WHERE user_id = $1 ORDER BY assigned_at DESC;
+-- name: IsUserAdmin :one
+-- Reports whether the user has the admin system role, managing the users, their API keys, roles and permissions
+SELECT EXISTS (
+    SELECT 1 FROM user_role_mapping m JOIN roles r ON r.id = m.role_id
+    WHERE m.user_id = $1 AND r.name = 'admin' AND r.is_system
+);
 -- name: AddRoleToUser :one
 INSERT INTO user_role_mapping (user_id, role_id, assigned_by)
 VALUES ($1, $2, $3)
@@ -28,4 +34,14 @@ SET name = $1, email = $2, additional_info = $3, provider_name = $4
 WHERE id = $5
 RETURNING id, name, email, additional_info, provider_name, is_online, created_at, updated_at;
 -- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1;
\ No newline at end of file
+DELETE FROM users WHERE id = $1;
+-- name: GetUserDefaultAgent :one
+SELECT default_agent_id FROM users WHERE id = $1 LIMIT 1;
+-- name: SetUserDefaultAgent :exec
+UPDATE users
+SET default_agent_id = $1
+WHERE id = $2;
+-- name: UpdateUserLastLogin :exec
+UPDATE users
+SET last_login = NOW()
+WHERE id = $1;
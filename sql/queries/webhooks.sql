@@ -0,0 +1,113 @@
+-- ==============================================
+-- WEBHOOK QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateWebhook :one
+INSERT INTO webhooks (user_id, url, events, secret, description, enabled)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks WHERE id = $1 AND user_id = $2;
+
+-- name: ListWebhooksByUser :many
+SELECT * FROM webhooks WHERE user_id = $1 ORDER BY created_at DESC, id;
+
+-- name: UpdateWebhook :one
+UPDATE webhooks SET
+    url = $3,
+    events = $4,
+    description = $5,
+    enabled = $6,
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: RotateWebhookSecret :one
+UPDATE webhooks SET
+    secret = $3,
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = $1 AND user_id = $2;
+
+-- name: EnqueueWebhookDeliveries :execrows
+-- Records a delivery of an event to the enabled webhooks subscribed to its type, of a user or of every user when the
+-- user is null, restricted to the users acting in the workspace of the event when set, as in CanAccessWorkspace. An
+-- event already recorded for a webhook is skipped, the services receiving it record it once.
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+SELECT w.id, sqlc.arg(event_id)::uuid, sqlc.arg(event_type)::text, sqlc.arg(payload)::jsonb
+FROM webhooks w
+WHERE w.enabled
+  AND sqlc.arg(event_type)::text = ANY(w.events)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR w.user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(workspace_id)::uuid IS NULL OR EXISTS (
+       SELECT 1 FROM workspace_members wm
+       WHERE wm.workspace_id = sqlc.narg(workspace_id)::uuid AND wm.user_id = w.user_id
+       UNION ALL
+       SELECT 1 FROM workspaces ws
+       JOIN organization_members om ON om.organization_id = ws.organization_id
+       WHERE ws.id = sqlc.narg(workspace_id)::uuid AND om.user_id = w.user_id AND om.role IN ('OWNER', 'ADMIN')))
+ON CONFLICT (webhook_id, event_id) DO NOTHING;
+
+-- name: ListDueWebhookDeliveries :many
+-- Lists the pending deliveries of the enabled webhooks due for an attempt, with the URL and the secret of their webhook
+SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, d.next_attempt_at, w.url, w.secret
+FROM webhook_deliveries d
+JOIN webhooks w ON w.id = d.webhook_id
+WHERE d.status = 'PENDING' AND d.next_attempt_at <= NOW() AND w.enabled
+ORDER BY d.next_attempt_at
+LIMIT $1;
+
+-- name: ClaimWebhookDelivery :execrows
+-- Counts an attempt of a due delivery and postpones it until the attempt is recorded, no row is updated when another
+-- webhooks service already claimed the attempt
+UPDATE webhook_deliveries SET
+    attempts = attempts + 1,
+    next_attempt_at = sqlc.arg(lease_until),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'PENDING' AND next_attempt_at = sqlc.arg(due_at);
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries SET
+    status = sqlc.arg(status),
+    response_status = sqlc.narg(response_status),
+    error_message = sqlc.narg(error_message),
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    delivered_at = CASE WHEN sqlc.arg(status) = 'SUCCEEDED' THEN NOW() ELSE delivered_at END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2;
+
+-- name: ListWebhookDeliveries :many
+-- Lists the deliveries of a webhook from the most recent, after the delivery of the cursor when set
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(cursor_time)::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.arg(cursor_id)::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text);
+
+-- name: RedeliverWebhookDelivery :one
+-- Schedules an ended delivery for a new round of attempts
+UPDATE webhook_deliveries SET
+    status = 'PENDING',
+    attempts = 0,
+    next_attempt_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND webhook_id = $2 AND status <> 'PENDING'
+RETURNING *;
+
+-- name: DeleteEndedWebhookDeliveries :execrows
+-- Removes the deliveries which ended before a date
+DELETE FROM webhook_deliveries WHERE status <> 'PENDING' AND updated_at < $1;
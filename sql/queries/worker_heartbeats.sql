@@ -41,12 +41,22 @@ SET status = 'INACTIVE',
 WHERE last_heartbeat < $1 
 AND status = 'ACTIVE';
 
+-- name: MarkStaleWorkersFailed :many
+-- Marks the active workers without heartbeat since the given time as failed and returns them
+UPDATE worker_heartbeats
+SET status = 'FAILED',
+    updated_at = NOW()
+WHERE last_heartbeat < sqlc.arg(last_heartbeat)
+AND status = 'ACTIVE'
+RETURNING *;
+
 -- name: DeleteWorkerHeartbeat :exec
 DELETE FROM worker_heartbeats WHERE worker_id = $1;
 
 -- name: DeleteInactiveWorkers :exec
+-- Removes the workers stopped or failed for longer than the retention
 DELETE FROM worker_heartbeats 
-WHERE status = 'INACTIVE' 
+WHERE status IN ('INACTIVE', 'FAILED') 
 AND updated_at < $1;
 
 -- name: GetWorkerStats :one
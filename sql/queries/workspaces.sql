@@ -0,0 +1,77 @@
+-- ==============================================
+-- ORGANIZATION AND WORKSPACE QUERIES FOR SQLC
+-- ==============================================
+
+-- name: CreateOrganization :one
+INSERT INTO organizations (name) VALUES ($1)
+RETURNING *;
+
+-- name: ListUserOrganizations :many
+-- Lists the organizations the user is a member of, with the role of the user
+SELECT o.id, o.name, o.created_at, o.updated_at, m.role
+FROM organizations o
+JOIN organization_members m ON m.organization_id = o.id
+WHERE m.user_id = $1
+ORDER BY o.name, o.id;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT * FROM organization_members WHERE organization_id = $1 ORDER BY created_at, user_id;
+
+-- name: UpsertOrganizationMember :one
+-- Adds a user to an organization, or changes the role of a member
+INSERT INTO organization_members (organization_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: DeleteOrganizationMember :execrows
+DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2;
+
+-- name: CountOrganizationOwners :one
+SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = 'OWNER';
+
+-- name: CreateWorkspace :one
+INSERT INTO workspaces (organization_id, name) VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetWorkspace :one
+SELECT * FROM workspaces WHERE id = $1;
+
+-- name: ListOrganizationWorkspaces :many
+SELECT * FROM workspaces WHERE organization_id = $1 ORDER BY name, id;
+
+-- name: GetWorkspaceMember :one
+SELECT * FROM workspace_members WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: ListWorkspaceMembers :many
+SELECT * FROM workspace_members WHERE workspace_id = $1 ORDER BY created_at, user_id;
+
+-- name: UpsertWorkspaceMember :one
+-- Adds a user to a workspace, or changes the role of a member
+INSERT INTO workspace_members (workspace_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: DeleteWorkspaceMember :execrows
+DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: DeleteOrganizationWorkspaceMembers :exec
+-- Removes a user from the workspaces of an organization
+DELETE FROM workspace_members wm
+USING workspaces w
+WHERE wm.workspace_id = w.id AND w.organization_id = $1 AND wm.user_id = $2;
+
+-- name: CanAccessWorkspace :one
+-- Checks the user can act in a workspace, as a member of the workspace or an owner or admin of its organization
+SELECT EXISTS (
+    SELECT 1 FROM workspace_members wm
+    WHERE wm.workspace_id = $1 AND wm.user_id = $2
+    UNION ALL
+    SELECT 1 FROM workspaces w
+    JOIN organization_members om ON om.organization_id = w.organization_id
+    WHERE w.id = $1 AND om.user_id = $2 AND om.role IN ('OWNER', 'ADMIN')
+) AS can_access;
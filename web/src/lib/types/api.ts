@@ -147,9 +147,16 @@ export interface PaginationMeta {
 	total_pages: number;
 }
 
+export interface CursorPaginationMeta {
+	total: number;
+	limit: number;
+	has_more: boolean;
+	next_cursor?: string;
+}
+
 export interface AgentList {
 	agents: Agent[];
-	meta?: PaginationMeta;
+	meta?: CursorPaginationMeta;
 }
 
 export interface UserList {
@@ -159,7 +166,7 @@ export interface UserList {
 
 export interface ThreadList {
 	threads: Thread[];
-	meta?: PaginationMeta;
+	meta?: CursorPaginationMeta;
 }
 
 export interface MessageList {